@@ -23,6 +23,7 @@ const { MAX_COMMENT_LENGTH, MAX_MENTIONS, MAX_LINKS, enforceCommentLimits } = re
 const { createDiscussionComment, resolveTopLevelDiscussionCommentId } = require("./github_api_helpers.cjs");
 const { logStagedPreviewInfo } = require("./staged_preview.cjs");
 const { ERR_NOT_FOUND } = require("./error_codes.cjs");
+const { withRetry, RATE_LIMIT_RETRY_CONFIG } = require("./error_recovery.cjs");
 const { isPayloadUserBot } = require("./resolve_mentions.cjs");
 const { buildWorkflowRunUrl } = require("./workflow_metadata_helpers.cjs");
 const { generateHistoryUrl } = require("./generate_history_link.cjs");
@@ -262,6 +263,58 @@ async function findDiscussionCommentsWithTrackerId(github, owner, repo, discussi
   return comments;
 }
 
+/**
+ * Generates the hidden anchor marker used by update-if-exists: anchor.
+ * @param {string} anchorKey - Workflow (or caller workflow) ID that owns the comment
+ * @returns {string} HTML comment marker
+ */
+function generateCommentAnchorMarker(anchorKey) {
+  return `<!-- gh-aw-comment-anchor: ${anchorKey} -->`;
+}
+
+/**
+ * Find the most recent bot comment on an issue/PR that carries the anchor marker.
+ * Only bot-authored comments are considered so a user cannot paste the marker into
+ * their own comment and have the workflow overwrite it.
+ * @param {any} github - GitHub REST API instance
+ * @param {string} owner - Repository owner
+ * @param {string} repo - Repository name
+ * @param {number} issueNumber - Issue/PR number
+ * @param {string} anchorKey - Anchor key to search for
+ * @returns {Promise<number | null>} Comment ID, or null when no anchored comment exists
+ */
+async function findAnchoredComment(github, owner, repo, issueNumber, anchorKey) {
+  const marker = generateCommentAnchorMarker(anchorKey);
+  let page = 1;
+  const perPage = 100;
+  /** @type {number | null} */
+  let latestId = null;
+
+  while (true) {
+    const { data } = await github.rest.issues.listComments({
+      owner,
+      repo,
+      issue_number: issueNumber,
+      per_page: perPage,
+      page,
+    });
+
+    for (const comment of data) {
+      if (comment.user?.type === "Bot" && typeof comment.body === "string" && comment.body.includes(marker)) {
+        latestId = comment.id;
+      }
+    }
+
+    if (data.length < perPage) {
+      break;
+    }
+
+    page++;
+  }
+
+  return latestId;
+}
+
 /**
  * Hide all previous comments from the same workflow
  * @param {any} github - GitHub API instance
@@ -345,6 +398,22 @@ function isDiscussionIntegrationAccessError(error) {
   return messages.some(message => message.toLowerCase().includes(fragment));
 }
 
+/**
+ * Run a comment API call with rate-limit retries. Errors are rethrown unwrapped so
+ * the caller can still inspect the original status (404 fallback, locked items).
+ * @template T
+ * @param {() => Promise<T>} operation - Comment API call
+ * @param {string} operationName - Name used in retry logs
+ * @returns {Promise<T>}
+ */
+async function withCommentRetry(operation, operationName) {
+  try {
+    return await withRetry(operation, RATE_LIMIT_RETRY_CONFIG, operationName);
+  } catch (error) {
+    throw /** @type {any} */ (error)?.originalError ?? error;
+  }
+}
+
 /**
  * Comment on a GitHub Discussion using GraphQL
  * @param {any} github - GitHub REST API instance
@@ -402,6 +471,7 @@ async function main(config = {}) {
       ? normalizeWorkflowIdList(config.hide_older_comments_match)
       : [];
   const commentTarget = config.target || "triggering";
+  const updateIfExistsAnchor = config.update_if_exists === "anchor";
   const maxCount = config.max || 20;
   const { defaultTargetRepo, allowedRepos } = resolveTargetRepoConfig(config);
   const includeFooter = parseBoolTemplatable(config.footer, true);
@@ -440,6 +510,9 @@ async function main(config = {}) {
   if (appendOnlyComments) {
     core.info("Append-only-comments is enabled - will not hide older comments");
   }
+  if (updateIfExistsAnchor) {
+    core.info("Update-if-exists is enabled - will edit the previous anchored comment instead of posting a new one");
+  }
 
   // Track state
   let processedCount = 0;
@@ -449,6 +522,9 @@ async function main(config = {}) {
   // Get workflow ID for hiding older comments
   const workflowId = process.env.GH_AW_WORKFLOW_ID || "";
   const callerWorkflowId = process.env.GH_AW_CALLER_WORKFLOW_ID || "";
+  // Anchor key for update-if-exists: prefer the caller so reusable workflows shared
+  // by several callers keep one comment per caller.
+  const anchorKey = callerWorkflowId || workflowId;
 
   /**
    * Message handler function
@@ -724,6 +800,9 @@ async function main(config = {}) {
     if (callerWorkflowId) {
       processedBody += "\n" + generateWorkflowCallIdMarker(callerWorkflowId);
     }
+    if (updateIfExistsAnchor && anchorKey) {
+      processedBody += "\n" + generateCommentAnchorMarker(anchorKey);
+    }
 
     // Enforce max limits again after adding footer and metadata
     // This ensures the final body (including generated content) doesn't exceed limits
@@ -798,6 +877,24 @@ async function main(config = {}) {
     }
 
     try {
+      // update-if-exists: anchor edits the previous anchored comment in place. Explicit
+      // comment_id/target=status reuse takes precedence, and discussions and inline
+      // review replies always post a new comment.
+      const isReviewCommentReply = effectiveContext.eventName === "pull_request_review_comment" && itemTargetResult.number === null;
+      if (updateIfExistsAnchor && commentIdToReuse === null && !isDiscussion && !isReviewCommentReply) {
+        if (!anchorKey) {
+          core.warning("update-if-exists is enabled but GH_AW_WORKFLOW_ID is not set; creating a new comment");
+        } else {
+          const anchoredCommentId = await findAnchoredComment(githubClient, repoParts.owner, repoParts.repo, itemNumber, anchorKey);
+          if (anchoredCommentId !== null) {
+            core.info(`Found anchored comment ${anchoredCommentId} for ${anchorKey}; updating it in place`);
+            commentIdToReuse = anchoredCommentId;
+          } else {
+            core.info(`No anchored comment found for ${anchorKey}; creating a new comment`);
+          }
+        }
+      }
+
       // Hide older comments if enabled AND append-only-comments is not enabled
       // When append-only-comments is true, we want to keep all comments visible
       if (hideOlderCommentsEnabled) {
@@ -847,34 +944,46 @@ async function main(config = {}) {
 
         if (shouldReplyToTriggeringPRReviewComment && Number.isInteger(triggeringReviewCommentId) && triggeringReviewCommentId > 0) {
           core.info(`Replying inline to triggering PR review comment ID: ${triggeringReviewCommentId}`);
-          const { data } = await githubClient.rest.pulls.createReplyForReviewComment({
-            owner: repoParts.owner,
-            repo: repoParts.repo,
-            pull_number: itemNumber,
-            comment_id: triggeringReviewCommentId,
-            body: processedBody,
-          });
+          const { data } = await withCommentRetry(
+            () =>
+              githubClient.rest.pulls.createReplyForReviewComment({
+                owner: repoParts.owner,
+                repo: repoParts.repo,
+                pull_number: itemNumber,
+                comment_id: triggeringReviewCommentId,
+                body: processedBody,
+              }),
+            `add_comment reply in ${repoParts.owner}/${repoParts.repo}#${itemNumber}`
+          );
           comment = data;
         } else if (commentIdToReuse !== null) {
           core.info(`Updating existing comment ID: ${commentIdToReuse}`);
-          const { data } = await githubClient.rest.issues.updateComment({
-            owner: repoParts.owner,
-            repo: repoParts.repo,
-            comment_id: commentIdToReuse,
-            body: processedBody,
-          });
+          const { data } = await withCommentRetry(
+            () =>
+              githubClient.rest.issues.updateComment({
+                owner: repoParts.owner,
+                repo: repoParts.repo,
+                comment_id: commentIdToReuse,
+                body: processedBody,
+              }),
+            `add_comment update in ${repoParts.owner}/${repoParts.repo}`
+          );
           comment = data;
         } else {
           if (shouldReplyToTriggeringPRReviewComment) {
             core.warning("Triggering PR review comment ID is missing or invalid; falling back to top-level PR comment");
           }
           // Use REST API for issues/PRs
-          const { data } = await githubClient.rest.issues.createComment({
-            owner: repoParts.owner,
-            repo: repoParts.repo,
-            issue_number: itemNumber,
-            body: processedBody,
-          });
+          const { data } = await withCommentRetry(
+            () =>
+              githubClient.rest.issues.createComment({
+                owner: repoParts.owner,
+                repo: repoParts.repo,
+                issue_number: itemNumber,
+                body: processedBody,
+              }),
+            `add_comment in ${repoParts.owner}/${repoParts.repo}#${itemNumber}`
+          );
           comment = data;
         }
       }
@@ -989,4 +1098,6 @@ module.exports = {
   MAX_LINKS,
   enforceCommentLimits,
   isDiscussionIntegrationAccessError,
+  generateCommentAnchorMarker,
+  findAnchoredComment,
 };
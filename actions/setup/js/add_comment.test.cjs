@@ -3456,6 +3456,96 @@ describe("add_comment", () => {
     });
   });
 
+  describe("update-if-exists anchor", () => {
+    let originalWorkflowId;
+
+    beforeEach(() => {
+      originalWorkflowId = process.env.GH_AW_WORKFLOW_ID;
+      process.env.GH_AW_WORKFLOW_ID = "status-report";
+    });
+
+    afterEach(() => {
+      if (originalWorkflowId === undefined) {
+        delete process.env.GH_AW_WORKFLOW_ID;
+      } else {
+        process.env.GH_AW_WORKFLOW_ID = originalWorkflowId;
+      }
+    });
+
+    it("should edit the most recent anchored bot comment instead of creating a new one", async () => {
+      const addCommentScript = fs.readFileSync(path.join(__dirname, "add_comment.cjs"), "utf8");
+      const anchor = "<!-- gh-aw-comment-anchor: status-report -->";
+      mockGithub.rest.issues.listComments = async () => ({
+        data: [
+          { id: 101, user: { type: "Bot" }, body: `Old status\n${anchor}` },
+          { id: 102, user: { type: "User" }, body: `Copied marker\n${anchor}` },
+          { id: 103, user: { type: "Bot" }, body: `Newer status\n${anchor}` },
+          { id: 104, user: { type: "Bot" }, body: "Unrelated bot comment" },
+        ],
+      });
+
+      /** @type {any} */
+      let capturedUpdateParams = null;
+      let createCommentCalled = false;
+      mockGithub.rest.issues.updateComment = async params => {
+        capturedUpdateParams = params;
+        return { data: { id: params.comment_id, html_url: `https://github.com/owner/repo/issues/8535#issuecomment-${params.comment_id}` } };
+      };
+      mockGithub.rest.issues.createComment = async () => {
+        createCommentCalled = true;
+        return { data: { id: 1, html_url: "https://github.com/owner/repo/issues/8535#issuecomment-1" } };
+      };
+
+      const handler = await eval(`(async () => { ${addCommentScript}; return await main({ update_if_exists: "anchor" }); })()`);
+      const result = await handler({ type: "add_comment", body: "Current status" }, {});
+
+      expect(result.success).toBe(true);
+      expect(createCommentCalled).toBe(false);
+      expect(capturedUpdateParams.comment_id).toBe(103);
+      expect(capturedUpdateParams.body).toContain(anchor);
+    });
+
+    it("should create an anchored comment when none exists", async () => {
+      const addCommentScript = fs.readFileSync(path.join(__dirname, "add_comment.cjs"), "utf8");
+      mockGithub.rest.issues.listComments = async () => ({ data: [] });
+
+      /** @type {any} */
+      let capturedCreateParams = null;
+      mockGithub.rest.issues.createComment = async params => {
+        capturedCreateParams = params;
+        return { data: { id: 1, html_url: "https://github.com/owner/repo/issues/8535#issuecomment-1" } };
+      };
+
+      const handler = await eval(`(async () => { ${addCommentScript}; return await main({ update_if_exists: "anchor" }); })()`);
+      const result = await handler({ type: "add_comment", body: "First status" }, {});
+
+      expect(result.success).toBe(true);
+      expect(capturedCreateParams.body).toContain("<!-- gh-aw-comment-anchor: status-report -->");
+    });
+
+    it("should not add an anchor or look up comments when disabled", async () => {
+      const addCommentScript = fs.readFileSync(path.join(__dirname, "add_comment.cjs"), "utf8");
+      let listCommentsCalled = false;
+      mockGithub.rest.issues.listComments = async () => {
+        listCommentsCalled = true;
+        return { data: [] };
+      };
+
+      /** @type {any} */
+      let capturedCreateParams = null;
+      mockGithub.rest.issues.createComment = async params => {
+        capturedCreateParams = params;
+        return { data: { id: 1, html_url: "https://github.com/owner/repo/issues/8535#issuecomment-1" } };
+      };
+
+      const handler = await eval(`(async () => { ${addCommentScript}; return await main({}); })()`);
+      await handler({ type: "add_comment", body: "Plain comment" }, {});
+
+      expect(listCommentsCalled).toBe(false);
+      expect(capturedCreateParams.body).not.toContain("gh-aw-comment-anchor");
+    });
+  });
+
   describe("hide-older-comments behavior", () => {
     it("should normalize workflow ID match lists", async () => {
       const addCommentScript = fs.readFileSync(path.join(__dirname, "add_comment.cjs"), "utf8");
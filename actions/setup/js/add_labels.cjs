@@ -13,6 +13,7 @@
  *   issue_number?: number|string,
  *   pr_number?: number|string,
  *   pull_number?: number|string,
+ *   item_numbers?: Array<number|string>,
  *   labels?: Array<string|{name: string, rationale?: string, confidence?: "LOW"|"MEDIUM"|"HIGH", suggest?: boolean}>,
  *   repo?: string
  * }} AddLabelsMessage
@@ -31,6 +32,7 @@ const { resolveSafeOutputIssueTarget } = require("./temporary_id.cjs");
 const { attachExecutionState, fetchIssueState, normalizeLabelNames } = require("./safe_output_execution_metadata.cjs");
 const { MAX_LABELS } = require("./constants.cjs");
 const { createCountGatedHandler } = require("./handler_scaffold.cjs");
+const { processBatchMessage } = require("./batch_target_helpers.cjs");
 const { withRetry, RATE_LIMIT_RETRY_CONFIG } = require("./error_recovery.cjs");
 const { resolveInvocationContext } = require("./invocation_context_helpers.cjs");
 const { normalizeIssueIntentLabelInputs } = require("./issue_intents.cjs");
@@ -49,9 +51,11 @@ const main = createCountGatedHandler({
     const requiredLabels = Array.isArray(config.required_labels) ? config.required_labels : [];
     const requiredTitlePrefix = config.required_title_prefix || "";
     const { defaultTargetRepo, allowedRepos } = resolveTargetRepoConfig(config);
+    const batchEnabled = config.batch === true;
     const githubClient = await createAuthenticatedGitHubClient(config);
 
     core.info(`Add labels configuration: max=${maxCount}`);
+    if (batchEnabled) core.info("Batch mode enabled: item_numbers targets are accepted");
     if (allowedLabels.length > 0) core.info(`Allowed labels: ${allowedLabels.join(", ")}`);
     if (blockedPatterns.length > 0) core.info(`Blocked patterns: ${blockedPatterns.join(", ")}`);
     if (requiredLabels.length > 0) core.info(`Required labels (all): ${requiredLabels.join(", ")}`);
@@ -60,12 +64,12 @@ const main = createCountGatedHandler({
     if (allowedRepos.size > 0) core.info(`Allowed repos: ${[...allowedRepos].join(", ")}`);
 
     /**
-     * Message handler function that processes a single add_labels message
+     * Processes a single add_labels message targeting one issue/PR
      * @param {AddLabelsMessage} message - The add_labels message to process
      * @param {ResolvedTemporaryIds} resolvedTemporaryIds - Map of temporary IDs to {repo, number}
      * @returns {Promise<HandlerResult>} Result with success/error status
      */
-    return async function handleAddLabels(message, resolvedTemporaryIds) {
+    async function handleAddLabelsItem(message, resolvedTemporaryIds) {
       // Resolve and validate target repository
       const repoResult = resolveAndValidateRepo(message, defaultTargetRepo, allowedRepos, "label");
       if (!repoResult.success) {
@@ -272,6 +276,29 @@ const main = createCountGatedHandler({
         core.error(`Failed to add labels: ${errorMessage}`);
         return { success: false, error: errorMessage };
       }
+    }
+
+    /**
+     * Entry point: dispatches batch messages (item_numbers) to the single-item handler
+     * @param {AddLabelsMessage} message - The add_labels message to process
+     * @param {ResolvedTemporaryIds} resolvedTemporaryIds - Map of temporary IDs to {repo, number}
+     * @returns {Promise<HandlerResult>} Result with success/error status
+     */
+    return async function handleAddLabels(message, resolvedTemporaryIds) {
+      if (message?.item_numbers !== undefined) {
+        if (!batchEnabled) {
+          const error = "item_numbers is only supported when batch: true is set in the add-labels configuration";
+          core.warning(`Skipping add_labels: ${error}`);
+          return { success: false, error };
+        }
+        return processBatchMessage({
+          handlerType: HANDLER_TYPE,
+          message,
+          githubClient,
+          handleItem: itemMessage => handleAddLabelsItem(itemMessage, resolvedTemporaryIds),
+        });
+      }
+      return handleAddLabelsItem(message, resolvedTemporaryIds);
     };
   },
 });
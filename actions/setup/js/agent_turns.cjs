@@ -0,0 +1,200 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+/**
+ * Structured agent turn log (agent_turns.jsonl)
+ *
+ * Engine CLIs print their activity in engine-specific formats. The log parser step
+ * normalizes the engine output after the run; this module then writes the parsed
+ * entries as engine-neutral JSONL with explicit turn and tool-call markers, one
+ * record per line:
+ *
+ *   {"type":"header","format":"gh-aw.agent-turns","version":1,"engine":"Claude"}
+ *   {"type":"turn","turn":1,"input_tokens":120,"output_tokens":40,...}
+ *   {"type":"tool_call","turn":1,"id":"toolu_1","name":"Bash","input_size":24}
+ *   {"type":"tool_result","turn":1,"id":"toolu_1","name":"Bash","is_error":false,"output_size":512}
+ *   {"type":"result","turns":3,"input_tokens":900,"output_tokens":300,...,"cost_usd":0.12}
+ *
+ * The records are derived from the parser output, not written by the engine step
+ * itself. `gh aw logs` and `gh aw audit` read this file before re-parsing the raw
+ * engine output, so their metrics come from the same parser that ran with the workflow
+ * instead of the parsers in the locally installed gh-aw version.
+ */
+
+const fs = require("fs");
+const path = require("path");
+const { convertCopilotEventsToLegacyLogEntries } = require("./log_parser_shared.cjs");
+const { getErrorMessage } = require("./error_helpers.cjs");
+
+/** Format identifier written in the header record. */
+const AGENT_TURNS_FORMAT = "gh-aw.agent-turns";
+
+/** Format version written in the header record. */
+const AGENT_TURNS_VERSION = 1;
+
+/** Default location of the structured turn log; uploaded with the agent artifact. */
+const AGENT_TURNS_PATH = "/tmp/gh-aw/agent_turns.jsonl";
+
+/**
+ * Return a non-negative finite number, or 0.
+ * @param {any} value
+ * @returns {number}
+ */
+function toCount(value) {
+  return typeof value === "number" && Number.isFinite(value) && value >= 0 ? value : 0;
+}
+
+/**
+ * Extract token counts from an Anthropic-style usage object.
+ * @param {any} usage
+ * @returns {{input_tokens: number, output_tokens: number, cache_read_tokens: number, cache_write_tokens: number}}
+ */
+function readUsage(usage) {
+  const u = usage && typeof usage === "object" ? usage : {};
+  return {
+    input_tokens: toCount(u.input_tokens),
+    output_tokens: toCount(u.output_tokens),
+    cache_read_tokens: toCount(u.cache_read_input_tokens),
+    cache_write_tokens: toCount(u.cache_creation_input_tokens),
+  };
+}
+
+/**
+ * Measure the size of a tool input or output in characters.
+ * @param {any} value
+ * @returns {number}
+ */
+function measureSize(value) {
+  if (value === undefined || value === null) return 0;
+  if (typeof value === "string") return value.length;
+  if (Array.isArray(value)) {
+    return value.reduce((total, part) => total + (part && typeof part.text === "string" ? part.text.length : measureSize(part)), 0);
+  }
+  try {
+    return JSON.stringify(value).length;
+  } catch {
+    return 0;
+  }
+}
+
+/**
+ * Build the structured turn records from parsed log entries.
+ *
+ * A turn is one model response. Consecutive assistant entries with the same message
+ * id (Claude streams one entry per content block) belong to the same turn.
+ *
+ * @param {Array<any>} logEntries - Entries returned by an engine log parser
+ * @param {{engine?: string}} [options]
+ * @returns {Array<Object>} Records in log order, starting with the header
+ */
+function buildAgentTurnRecords(logEntries, options = {}) {
+  const entries = convertCopilotEventsToLegacyLogEntries(logEntries);
+  /** @type {Array<any>} */
+  const records = [{ type: "header", format: AGENT_TURNS_FORMAT, version: AGENT_TURNS_VERSION, engine: options.engine || "unknown" }];
+  /** @type {Map<string, string>} */
+  const toolNames = new Map();
+  /** @type {any} */
+  let currentTurn = null;
+  let currentMessageId = "";
+  let previousType = "";
+  let turnCount = 0;
+  /** @type {any} */
+  let result = null;
+
+  for (const entry of entries) {
+    if (!entry || typeof entry !== "object") continue;
+
+    if (entry.type === "assistant" && entry.message && Array.isArray(entry.message.content)) {
+      const messageId = typeof entry.message.id === "string" ? entry.message.id : "";
+      const sameMessage = messageId ? messageId === currentMessageId : previousType === "assistant";
+      if (!currentTurn || !sameMessage) {
+        turnCount++;
+        currentTurn = { type: "turn", turn: turnCount, input_tokens: 0, output_tokens: 0, cache_read_tokens: 0, cache_write_tokens: 0 };
+        records.push(currentTurn);
+        currentMessageId = messageId;
+      }
+      // Streamed content blocks repeat the message usage, so keep the largest value.
+      const usage = readUsage(entry.message.usage);
+      for (const key of /** @type {Array<keyof typeof usage>} */ (Object.keys(usage))) {
+        currentTurn[key] = Math.max(currentTurn[key], usage[key]);
+      }
+      for (const content of entry.message.content) {
+        if (!content || content.type !== "tool_use") continue;
+        const id = typeof content.id === "string" ? content.id : "";
+        const name = typeof content.name === "string" && content.name ? content.name : "unknown";
+        if (id) toolNames.set(id, name);
+        records.push({ type: "tool_call", turn: turnCount, id, name, input_size: measureSize(content.input) });
+      }
+    } else if (entry.type === "user" && entry.message && Array.isArray(entry.message.content)) {
+      for (const content of entry.message.content) {
+        if (!content || content.type !== "tool_result") continue;
+        const id = typeof content.tool_use_id === "string" ? content.tool_use_id : "";
+        records.push({
+          type: "tool_result",
+          turn: turnCount,
+          id,
+          name: toolNames.get(id) || "unknown",
+          is_error: content.is_error === true,
+          output_size: measureSize(content.content),
+        });
+      }
+    } else if (entry.type === "result") {
+      result = entry;
+    }
+    previousType = entry.type;
+  }
+
+  const resultTurns = result ? toCount(result.num_turns) : 0;
+  /** @type {any} */
+  const summary = { type: "result", turns: resultTurns > 0 ? resultTurns : turnCount };
+  if (result && result.usage) {
+    Object.assign(summary, readUsage(result.usage));
+  } else {
+    Object.assign(summary, { input_tokens: 0, output_tokens: 0, cache_read_tokens: 0, cache_write_tokens: 0 });
+    for (const record of records) {
+      if (record.type !== "turn") continue;
+      summary.input_tokens += record.input_tokens;
+      summary.output_tokens += record.output_tokens;
+      summary.cache_read_tokens += record.cache_read_tokens;
+      summary.cache_write_tokens += record.cache_write_tokens;
+    }
+  }
+  if (result && toCount(result.total_cost_usd) > 0) {
+    summary.cost_usd = result.total_cost_usd;
+  }
+  records.push(summary);
+  return records;
+}
+
+/**
+ * Write the structured turn log for parsed log entries. Errors are reported as
+ * warnings because the turn log is diagnostic data and must never fail a workflow.
+ * @param {Array<any>} logEntries - Entries returned by an engine log parser
+ * @param {{engine?: string, filePath?: string}} [options]
+ * @returns {boolean} True when the file was written
+ */
+function writeAgentTurns(logEntries, options = {}) {
+  if (!Array.isArray(logEntries) || logEntries.length === 0) {
+    return false;
+  }
+  const filePath = options.filePath || AGENT_TURNS_PATH;
+  try {
+    const records = buildAgentTurnRecords(logEntries, options);
+    fs.mkdirSync(path.dirname(filePath), { recursive: true });
+    fs.writeFileSync(filePath, records.map(record => JSON.stringify(record)).join("\n") + "\n", "utf8");
+    const summary = records[records.length - 1];
+    core.info(`[log-parser] Wrote structured turn log to ${filePath}: turns=${summary.turns}, records=${records.length}`);
+    return true;
+  } catch (error) {
+    core.warning(`[log-parser] Failed to write structured turn log: ${getErrorMessage(error)}`);
+    return false;
+  }
+}
+
+module.exports = {
+  AGENT_TURNS_FORMAT,
+  AGENT_TURNS_VERSION,
+  AGENT_TURNS_PATH,
+  buildAgentTurnRecords,
+  writeAgentTurns,
+};
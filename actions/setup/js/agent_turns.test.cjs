@@ -0,0 +1,108 @@
+import { describe, it, expect, beforeEach, afterEach, vi } from "vitest";
+import fs from "fs";
+import os from "os";
+import path from "path";
+
+const { buildAgentTurnRecords, writeAgentTurns, AGENT_TURNS_FORMAT, AGENT_TURNS_VERSION } = require("./agent_turns.cjs");
+
+const claudeEntries = [
+  { type: "system", subtype: "init", model: "claude-sonnet-4" },
+  {
+    type: "assistant",
+    message: { id: "msg_1", content: [{ type: "text", text: "Looking" }], usage: { input_tokens: 100, output_tokens: 10, cache_read_input_tokens: 50 } },
+  },
+  {
+    type: "assistant",
+    message: { id: "msg_1", content: [{ type: "tool_use", id: "toolu_1", name: "Bash", input: { command: "ls" } }], usage: { input_tokens: 100, output_tokens: 20, cache_read_input_tokens: 50 } },
+  },
+  { type: "user", message: { content: [{ type: "tool_result", tool_use_id: "toolu_1", content: "a.txt\nb.txt", is_error: false }] } },
+  {
+    type: "assistant",
+    message: { id: "msg_2", content: [{ type: "tool_use", id: "toolu_2", name: "mcp__github__get_issue", input: { issue_number: 1 } }], usage: { input_tokens: 200, output_tokens: 30 } },
+  },
+  { type: "user", message: { content: [{ type: "tool_result", tool_use_id: "toolu_2", content: [{ type: "text", text: "not found" }], is_error: true }] } },
+  { type: "result", num_turns: 2, total_cost_usd: 0.05, usage: { input_tokens: 300, output_tokens: 50, cache_read_input_tokens: 50, cache_creation_input_tokens: 5 } },
+];
+
+describe("agent_turns", () => {
+  it("should write a header, turn, tool and result records", () => {
+    const records = buildAgentTurnRecords(claudeEntries, { engine: "Claude" });
+
+    expect(records[0]).toEqual({ type: "header", format: AGENT_TURNS_FORMAT, version: AGENT_TURNS_VERSION, engine: "Claude" });
+    expect(records.filter(r => r.type === "turn")).toEqual([
+      { type: "turn", turn: 1, input_tokens: 100, output_tokens: 20, cache_read_tokens: 50, cache_write_tokens: 0 },
+      { type: "turn", turn: 2, input_tokens: 200, output_tokens: 30, cache_read_tokens: 0, cache_write_tokens: 0 },
+    ]);
+    expect(records.filter(r => r.type === "tool_call")).toEqual([
+      { type: "tool_call", turn: 1, id: "toolu_1", name: "Bash", input_size: 16 },
+      { type: "tool_call", turn: 2, id: "toolu_2", name: "mcp__github__get_issue", input_size: 18 },
+    ]);
+    expect(records.filter(r => r.type === "tool_result")).toEqual([
+      { type: "tool_result", turn: 1, id: "toolu_1", name: "Bash", is_error: false, output_size: 11 },
+      { type: "tool_result", turn: 2, id: "toolu_2", name: "mcp__github__get_issue", is_error: true, output_size: 9 },
+    ]);
+    expect(records[records.length - 1]).toEqual({ type: "result", turns: 2, input_tokens: 300, output_tokens: 50, cache_read_tokens: 50, cache_write_tokens: 5, cost_usd: 0.05 });
+  });
+
+  it("should sum turn usage when there is no result entry", () => {
+    const records = buildAgentTurnRecords(claudeEntries.filter(e => e.type !== "result"));
+
+    expect(records[0].engine).toBe("unknown");
+    expect(records[records.length - 1]).toEqual({ type: "result", turns: 2, input_tokens: 300, output_tokens: 50, cache_read_tokens: 50, cache_write_tokens: 0 });
+  });
+
+  it("should start a new turn for assistant entries without message ids after a tool result", () => {
+    const entries = [
+      { type: "assistant", message: { content: [{ type: "tool_use", id: "t1", name: "Read", input: {} }] } },
+      { type: "assistant", message: { content: [{ type: "text", text: "still turn one" }] } },
+      { type: "user", message: { content: [{ type: "tool_result", tool_use_id: "t1", content: "ok" }] } },
+      { type: "assistant", message: { content: [{ type: "text", text: "done" }] } },
+    ];
+
+    const records = buildAgentTurnRecords(entries);
+
+    expect(records.filter(r => r.type === "turn").map(r => r.turn)).toEqual([1, 2]);
+    expect(records[records.length - 1].turns).toBe(2);
+  });
+
+  describe("writeAgentTurns", () => {
+    let tmpDir;
+
+    beforeEach(() => {
+      tmpDir = fs.mkdtempSync(path.join(os.tmpdir(), "agent-turns-"));
+      global.core = { info: vi.fn(), warning: vi.fn() };
+    });
+
+    afterEach(() => {
+      fs.rmSync(tmpDir, { recursive: true, force: true });
+      delete global.core;
+    });
+
+    it("should write one JSON record per line", () => {
+      const filePath = path.join(tmpDir, "nested", "agent_turns.jsonl");
+
+      expect(writeAgentTurns(claudeEntries, { engine: "Claude", filePath })).toBe(true);
+
+      const lines = fs.readFileSync(filePath, "utf8").trim().split("\n");
+      expect(JSON.parse(lines[0]).type).toBe("header");
+      expect(JSON.parse(lines[lines.length - 1]).type).toBe("result");
+      expect(global.core.info).toHaveBeenCalledWith(expect.stringContaining("turns=2"));
+    });
+
+    it("should skip empty log entries", () => {
+      const filePath = path.join(tmpDir, "agent_turns.jsonl");
+
+      expect(writeAgentTurns([], { filePath })).toBe(false);
+      expect(fs.existsSync(filePath)).toBe(false);
+    });
+
+    it("should warn instead of throwing when the file cannot be written", () => {
+      vi.spyOn(fs, "writeFileSync").mockImplementationOnce(() => {
+        throw new Error("Permission denied");
+      });
+
+      expect(writeAgentTurns(claudeEntries, { filePath: path.join(tmpDir, "agent_turns.jsonl") })).toBe(false);
+      expect(global.core.warning).toHaveBeenCalledWith("[log-parser] Failed to write structured turn log: Permission denied");
+    });
+  });
+});
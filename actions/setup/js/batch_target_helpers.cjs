@@ -0,0 +1,117 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+/**
+ * @typedef {import('./types/handler-factory').HandlerResult} HandlerResult
+ */
+
+const { checkRateLimit } = require("./rate_limit_helpers.cjs");
+const { MAX_BATCH_ITEMS, BATCH_PAGE_SIZE } = require("./constants.cjs");
+
+/**
+ * Resolve the list of target item numbers from a batch message.
+ *
+ * Batch messages carry an `item_numbers` array instead of a single `item_number`.
+ * Duplicates are removed while preserving order. Temporary IDs (e.g. "aw_abc123")
+ * are passed through unchanged so the per-item handler can resolve them.
+ *
+ * @param {any} message - Safe output message
+ * @returns {{success: true, items: Array<number|string>} | {success: false, error: string}}
+ */
+function resolveBatchItemNumbers(message) {
+  const raw = message?.item_numbers;
+  if (!Array.isArray(raw) || raw.length === 0) {
+    return { success: false, error: "item_numbers must be a non-empty array of issue/PR numbers" };
+  }
+
+  /** @type {Array<number|string>} */
+  const items = [];
+  const seen = new Set();
+  for (const value of raw) {
+    const key = String(value).trim();
+    if (key === "" || (typeof value !== "number" && typeof value !== "string")) {
+      return { success: false, error: `item_numbers contains an invalid entry: ${JSON.stringify(value)}` };
+    }
+    if (seen.has(key)) {
+      continue;
+    }
+    seen.add(key);
+    items.push(value);
+  }
+
+  if (items.length > MAX_BATCH_ITEMS) {
+    return { success: false, error: `item_numbers contains ${items.length} entries; the maximum per batch is ${MAX_BATCH_ITEMS}` };
+  }
+  return { success: true, items };
+}
+
+/**
+ * Apply a single-item handler to every target of a batch message.
+ *
+ * Targets are processed in pages of {@link BATCH_PAGE_SIZE}. Before each page after
+ * the first, the remaining rate-limit quota is checked; when it falls below the
+ * reserved minimum the remaining targets are skipped rather than exhausting the
+ * token's budget. Each item reuses the single-item handler, so per-item filters,
+ * validation and retries behave exactly as for non-batch messages.
+ *
+ * @param {Object} options
+ * @param {string} options.handlerType - Handler type used in log messages
+ * @param {any} options.message - The batch message
+ * @param {any} options.githubClient - Authenticated GitHub client used for rate-limit checks
+ * @param {(itemMessage: any) => Promise<any>} options.handleItem - Single-item handler
+ * @returns {Promise<HandlerResult>} Aggregated result
+ */
+async function processBatchMessage({ handlerType, message, githubClient, handleItem }) {
+  const resolved = resolveBatchItemNumbers(message);
+  if (!resolved.success) {
+    core.warning(`Skipping ${handlerType}: ${resolved.error}`);
+    return { success: false, error: resolved.error };
+  }
+
+  const { item_numbers: _itemNumbers, ...baseMessage } = message;
+  const { items } = resolved;
+  core.info(`Processing ${handlerType} batch for ${items.length} item(s)`);
+
+  /** @type {Array<{item: number|string, result: any}>} */
+  const results = [];
+  /** @type {Array<number|string>} */
+  const skipped = [];
+
+  for (let start = 0; start < items.length; start += BATCH_PAGE_SIZE) {
+    if (start > 0) {
+      const { ok, remaining } = await checkRateLimit(githubClient, `${handlerType}_batch`);
+      if (!ok) {
+        skipped.push(...items.slice(start));
+        core.warning(`Stopping ${handlerType} batch: only ${remaining} API requests remaining; skipped ${items.length - start} item(s)`);
+        break;
+      }
+    }
+    for (const item of items.slice(start, start + BATCH_PAGE_SIZE)) {
+      const result = await handleItem({ ...baseMessage, item_number: item });
+      results.push({ item, result });
+    }
+  }
+
+  const failed = results.filter(r => !r.result.success && !r.result.skipped);
+  const succeeded = results.filter(r => r.result.success);
+  core.info(`${handlerType} batch complete: ${succeeded.length} succeeded, ${failed.length} failed, ${skipped.length} skipped (rate limit)`);
+
+  const itemResults = results.map(({ item, result }) => ({ item_number: item, ...result }));
+  if (failed.length === 0 && skipped.length === 0) {
+    return { success: true, batch: true, results: itemResults };
+  }
+
+  const details = failed.map(({ item, result }) => `#${item}: ${result.error ?? "unknown error"}`);
+  if (skipped.length > 0) {
+    details.push(`skipped due to rate limit: ${skipped.map(n => `#${n}`).join(", ")}`);
+  }
+  return {
+    success: false,
+    batch: true,
+    results: itemResults,
+    rateLimitSkipped: skipped,
+    error: `${handlerType} batch incomplete: ${details.join("; ")}`,
+  };
+}
+
+module.exports = { resolveBatchItemNumbers, processBatchMessage };
@@ -0,0 +1,132 @@
+// @ts-check
+import { describe, it, expect, beforeEach, vi } from "vitest";
+
+const mockCore = {
+  info: vi.fn(),
+  warning: vi.fn(),
+  error: vi.fn(),
+};
+
+global.core = mockCore;
+
+describe("batch_target_helpers", () => {
+  let mockGithub;
+
+  beforeEach(() => {
+    vi.clearAllMocks();
+    mockGithub = {
+      rest: {
+        rateLimit: {
+          get: vi.fn().mockResolvedValue({
+            data: {
+              rate: { remaining: 5000, limit: 5000, used: 0 },
+              resources: {},
+            },
+          }),
+        },
+      },
+    };
+  });
+
+  describe("resolveBatchItemNumbers", () => {
+    it("should deduplicate item numbers preserving order", async () => {
+      const { resolveBatchItemNumbers } = await import("./batch_target_helpers.cjs");
+      const result = resolveBatchItemNumbers({ item_numbers: [3, 1, "3", 2, 1] });
+      expect(result).toEqual({ success: true, items: [3, 1, 2] });
+    });
+
+    it("should pass temporary IDs through", async () => {
+      const { resolveBatchItemNumbers } = await import("./batch_target_helpers.cjs");
+      const result = resolveBatchItemNumbers({ item_numbers: ["aw_abc123", 7] });
+      expect(result).toEqual({ success: true, items: ["aw_abc123", 7] });
+    });
+
+    it("should reject an empty array", async () => {
+      const { resolveBatchItemNumbers } = await import("./batch_target_helpers.cjs");
+      const result = resolveBatchItemNumbers({ item_numbers: [] });
+      expect(result.success).toBe(false);
+    });
+
+    it("should reject invalid entries", async () => {
+      const { resolveBatchItemNumbers } = await import("./batch_target_helpers.cjs");
+      const result = resolveBatchItemNumbers({ item_numbers: [1, { number: 2 }] });
+      expect(result.success).toBe(false);
+      expect(result.error).toContain("invalid entry");
+    });
+
+    it("should reject batches over the maximum size", async () => {
+      const { resolveBatchItemNumbers } = await import("./batch_target_helpers.cjs");
+      const result = resolveBatchItemNumbers({ item_numbers: Array.from({ length: 101 }, (_, i) => i + 1) });
+      expect(result.success).toBe(false);
+      expect(result.error).toContain("maximum per batch is 100");
+    });
+  });
+
+  describe("processBatchMessage", () => {
+    it("should call the item handler once per target with item_number set", async () => {
+      const { processBatchMessage } = await import("./batch_target_helpers.cjs");
+      const handleItem = vi.fn().mockResolvedValue({ success: true });
+      const result = await processBatchMessage({
+        handlerType: "add_labels",
+        message: { type: "add_labels", labels: ["stale"], item_numbers: [1, 2] },
+        githubClient: mockGithub,
+        handleItem,
+      });
+
+      expect(handleItem).toHaveBeenCalledTimes(2);
+      expect(handleItem).toHaveBeenNthCalledWith(1, { type: "add_labels", labels: ["stale"], item_number: 1 });
+      expect(handleItem).toHaveBeenNthCalledWith(2, { type: "add_labels", labels: ["stale"], item_number: 2 });
+      expect(result.success).toBe(true);
+      expect(result.batch).toBe(true);
+      expect(result.results).toHaveLength(2);
+    });
+
+    it("should not treat filter skips as failures", async () => {
+      const { processBatchMessage } = await import("./batch_target_helpers.cjs");
+      const handleItem = vi.fn().mockResolvedValueOnce({ success: true }).mockResolvedValueOnce({ success: false, skipped: true, error: "filtered" });
+      const result = await processBatchMessage({
+        handlerType: "remove_labels",
+        message: { labels: ["stale"], item_numbers: [1, 2] },
+        githubClient: mockGithub,
+        handleItem,
+      });
+      expect(result.success).toBe(true);
+    });
+
+    it("should report failed items", async () => {
+      const { processBatchMessage } = await import("./batch_target_helpers.cjs");
+      const handleItem = vi.fn().mockResolvedValueOnce({ success: true }).mockResolvedValueOnce({ success: false, error: "Not Found" });
+      const result = await processBatchMessage({
+        handlerType: "add_labels",
+        message: { labels: ["stale"], item_numbers: [1, 2] },
+        githubClient: mockGithub,
+        handleItem,
+      });
+      expect(result.success).toBe(false);
+      expect(result.error).toContain("#2: Not Found");
+    });
+
+    it("should check the rate limit between pages and stop when it is low", async () => {
+      const { processBatchMessage } = await import("./batch_target_helpers.cjs");
+      mockGithub.rest.rateLimit.get.mockResolvedValue({
+        data: {
+          rate: { remaining: 10, limit: 5000, used: 4990 },
+          resources: {},
+        },
+      });
+      const handleItem = vi.fn().mockResolvedValue({ success: true });
+      const itemNumbers = Array.from({ length: 25 }, (_, i) => i + 1);
+      const result = await processBatchMessage({
+        handlerType: "add_labels",
+        message: { labels: ["stale"], item_numbers: itemNumbers },
+        githubClient: mockGithub,
+        handleItem,
+      });
+
+      // First page of 20 is processed without a check; the second page is skipped.
+      expect(handleItem).toHaveBeenCalledTimes(20);
+      expect(result.success).toBe(false);
+      expect(result.rateLimitSkipped).toEqual([21, 22, 23, 24, 25]);
+    });
+  });
+});
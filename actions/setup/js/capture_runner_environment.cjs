@@ -0,0 +1,146 @@
+#!/usr/bin/env node
+// @ts-check
+
+// Captures runner metadata in the agent job and merges it into aw_info.json as
+// `runner_environment`. aw_info.json is created by the activation job, which may run on a
+// different runner, so this step records what the agent actually executed on:
+//   os / arch / environment  - RUNNER_OS, RUNNER_ARCH, RUNNER_ENVIRONMENT
+//   image_os / image_version - runner image identifiers (GitHub-hosted runners only)
+//   tool_cache               - versions installed under RUNNER_TOOL_CACHE, keyed by tool
+//   engine_cli_version       - first line of `<engine cli> --version`
+// `gh aw audit <base> <compare>` diffs these fields to surface runner image updates.
+
+const fs = require("fs");
+const path = require("path");
+const { execFileSync } = require("child_process");
+
+require("./shim.cjs");
+
+const { TMP_GH_AW_PATH } = require("./constants.cjs");
+const { getErrorMessage } = require("./error_helpers.cjs");
+
+/** Upper bound for the engine CLI version probe so a hung binary cannot stall the job. */
+const ENGINE_VERSION_TIMEOUT_MS = 10000;
+
+/** Engine CLI commands are plain executable names or paths; anything else is not probed. */
+const ENGINE_COMMAND_PATTERN = /^[A-Za-z0-9._/-]+$/;
+
+/**
+ * List tool versions installed in the hosted tool cache.
+ * The cache layout is <RUNNER_TOOL_CACHE>/<tool>/<version>/<arch>, with
+ * <version>/<arch>.complete marker files alongside each install.
+ * @param {string} toolCacheDir
+ * @returns {Record<string, string[]>}
+ */
+function listToolCacheVersions(toolCacheDir) {
+  /** @type {Record<string, string[]>} */
+  const tools = {};
+  if (!toolCacheDir || !fs.existsSync(toolCacheDir)) {
+    return tools;
+  }
+  for (const tool of fs.readdirSync(toolCacheDir, { withFileTypes: true })) {
+    if (!tool.isDirectory()) {
+      continue;
+    }
+    const versions = fs
+      .readdirSync(path.join(toolCacheDir, tool.name), { withFileTypes: true })
+      .filter(entry => entry.isDirectory())
+      .map(entry => entry.name)
+      .sort();
+    if (versions.length > 0) {
+      tools[tool.name] = versions;
+    }
+  }
+  return tools;
+}
+
+/**
+ * Run `<command> --version` and return the first non-empty output line.
+ * @param {string} command
+ * @returns {string}
+ */
+function probeEngineCLIVersion(command) {
+  if (!command || !ENGINE_COMMAND_PATTERN.test(command)) {
+    return "";
+  }
+  try {
+    const output = execFileSync(command, ["--version"], {
+      encoding: "utf8",
+      timeout: ENGINE_VERSION_TIMEOUT_MS,
+      stdio: ["ignore", "pipe", "pipe"],
+    });
+    const firstLine = output.split("\n").find(line => line.trim() !== "") || "";
+    return firstLine.trim().slice(0, 200);
+  } catch (err) {
+    core.warning(`Failed to read ${command} version: ${getErrorMessage(err)}`);
+    return "";
+  }
+}
+
+/**
+ * Collect runner environment metadata from the current process environment.
+ * Empty values are omitted so self-hosted runners produce a compact record.
+ * @param {NodeJS.ProcessEnv} env
+ * @returns {Record<string, unknown>}
+ */
+function collectRunnerEnvironment(env) {
+  /** @type {Record<string, unknown>} */
+  const runnerEnvironment = {};
+  const fields = {
+    os: env.RUNNER_OS,
+    arch: env.RUNNER_ARCH,
+    environment: env.RUNNER_ENVIRONMENT,
+    image_os: env.ImageOS,
+    image_version: env.ImageVersion,
+    engine_cli_version: probeEngineCLIVersion(env.GH_AW_ENGINE_CLI_COMMAND || ""),
+  };
+  for (const [key, value] of Object.entries(fields)) {
+    if (value) {
+      runnerEnvironment[key] = value;
+    }
+  }
+
+  try {
+    const toolCache = listToolCacheVersions(env.RUNNER_TOOL_CACHE || "");
+    if (Object.keys(toolCache).length > 0) {
+      runnerEnvironment.tool_cache = toolCache;
+    }
+  } catch (err) {
+    core.warning(`Failed to list tool cache versions: ${getErrorMessage(err)}`);
+  }
+
+  return runnerEnvironment;
+}
+
+/**
+ * Merge the runner environment into aw_info.json.
+ * @param {string} [awInfoPath]
+ */
+function main(awInfoPath = path.join(TMP_GH_AW_PATH, "aw_info.json")) {
+  if (!fs.existsSync(awInfoPath)) {
+    core.warning(`${awInfoPath} not found; skipping runner environment capture`);
+    return;
+  }
+
+  let awInfo;
+  try {
+    awInfo = JSON.parse(fs.readFileSync(awInfoPath, "utf8"));
+  } catch (err) {
+    core.warning(`Failed to parse ${awInfoPath}: ${getErrorMessage(err)}`);
+    return;
+  }
+
+  awInfo.runner_environment = collectRunnerEnvironment(process.env);
+  try {
+    fs.writeFileSync(awInfoPath, JSON.stringify(awInfo, null, 2));
+  } catch (err) {
+    throw new Error(`Failed to write file ${awInfoPath}: ${String(err)}`, { cause: err });
+  }
+  core.info(`Runner environment: ${JSON.stringify(awInfo.runner_environment)}`);
+}
+
+if (require.main === module) {
+  main();
+}
+
+module.exports = { main, collectRunnerEnvironment, listToolCacheVersions, probeEngineCLIVersion };
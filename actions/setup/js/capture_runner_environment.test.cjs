@@ -0,0 +1,87 @@
+import { afterEach, beforeEach, describe, expect, it } from "vitest";
+import fs from "fs";
+import os from "os";
+import path from "path";
+import { createRequire } from "module";
+
+const req = createRequire(import.meta.url);
+const { main, collectRunnerEnvironment, listToolCacheVersions, probeEngineCLIVersion } = req("./capture_runner_environment.cjs");
+
+describe("capture_runner_environment.cjs", () => {
+  let workDir;
+
+  beforeEach(() => {
+    workDir = fs.mkdtempSync(path.join(os.tmpdir(), "runner-env-test-"));
+  });
+
+  afterEach(() => {
+    fs.rmSync(workDir, { recursive: true, force: true });
+  });
+
+  describe("listToolCacheVersions", () => {
+    it("lists version directories per tool and ignores .complete markers", () => {
+      const toolCache = path.join(workDir, "toolcache");
+      fs.mkdirSync(path.join(toolCache, "node", "22.3.0", "x64"), { recursive: true });
+      fs.mkdirSync(path.join(toolCache, "node", "20.1.0", "x64"), { recursive: true });
+      fs.writeFileSync(path.join(toolCache, "node", "20.1.0", "x64.complete"), "");
+      fs.mkdirSync(path.join(toolCache, "Python", "3.12.1", "x64"), { recursive: true });
+      fs.mkdirSync(path.join(toolCache, "empty"), { recursive: true });
+
+      expect(listToolCacheVersions(toolCache)).toEqual({
+        node: ["20.1.0", "22.3.0"],
+        Python: ["3.12.1"],
+      });
+    });
+
+    it("returns an empty map when the tool cache is missing", () => {
+      expect(listToolCacheVersions(path.join(workDir, "missing"))).toEqual({});
+      expect(listToolCacheVersions("")).toEqual({});
+    });
+  });
+
+  describe("probeEngineCLIVersion", () => {
+    it("returns the first line of --version output", () => {
+      expect(probeEngineCLIVersion(process.execPath)).toBe(process.version);
+    });
+
+    it("does not run commands with shell metacharacters", () => {
+      expect(probeEngineCLIVersion("copilot; rm -rf /")).toBe("");
+      expect(probeEngineCLIVersion("")).toBe("");
+    });
+  });
+
+  describe("collectRunnerEnvironment", () => {
+    it("omits values that are not set", () => {
+      const env = collectRunnerEnvironment({ RUNNER_OS: "Linux", RUNNER_ARCH: "X64", ImageOS: "ubuntu24", ImageVersion: "20261012.1" });
+      expect(env).toEqual({ os: "Linux", arch: "X64", image_os: "ubuntu24", image_version: "20261012.1" });
+    });
+  });
+
+  describe("main", () => {
+    it("merges runner_environment into aw_info.json", () => {
+      const awInfoPath = path.join(workDir, "aw_info.json");
+      fs.writeFileSync(awInfoPath, JSON.stringify({ engine_id: "copilot" }));
+      const saved = { ...process.env };
+      process.env.ImageOS = "ubuntu24";
+      process.env.ImageVersion = "20261012.1";
+      process.env.GH_AW_ENGINE_CLI_COMMAND = process.execPath;
+      try {
+        main(awInfoPath);
+      } finally {
+        process.env = saved;
+      }
+
+      const awInfo = JSON.parse(fs.readFileSync(awInfoPath, "utf8"));
+      expect(awInfo.engine_id).toBe("copilot");
+      expect(awInfo.runner_environment.image_os).toBe("ubuntu24");
+      expect(awInfo.runner_environment.image_version).toBe("20261012.1");
+      expect(awInfo.runner_environment.engine_cli_version).toBe(process.version);
+    });
+
+    it("leaves a missing aw_info.json alone", () => {
+      const awInfoPath = path.join(workDir, "aw_info.json");
+      main(awInfoPath);
+      expect(fs.existsSync(awInfoPath)).toBe(false);
+    });
+  });
+});
@@ -0,0 +1,305 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+const { ERR_CONFIG } = require("./error_codes.cjs");
+const { getErrorMessage } = require("./error_helpers.cjs");
+const { writeDenialSummary } = require("./pre_activation_summary.cjs");
+const { buildAwContext } = require("./aw_context.cjs");
+
+const WEEKDAYS = ["sun", "mon", "tue", "wed", "thu", "fri", "sat"];
+const WEEKDAY_LABELS = ["Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"];
+
+// Events whose triggers can be queued. The queue lives in the notice comment, and only
+// issue and pull request comments can be listed repository-wide when the window opens.
+const QUEUE_EVENTS = ["issue_comment", "issues", "pull_request", "pull_request_review", "pull_request_review_comment"];
+const QUEUE_MARKER_PATTERN = /<!-- gh-aw-active-hours-queued:([\w.-]+):([A-Za-z0-9+/=]+) -->/;
+// Queued notices older than a week have already been drained by an earlier window.
+const QUEUE_LOOKBACK_MS = 8 * 24 * 60 * 60 * 1000;
+
+/**
+ * Parses an "HH:MM" string into minutes since midnight.
+ * @param {string | undefined} value
+ * @returns {number} Minutes since midnight, or NaN when invalid
+ */
+function parseClock(value) {
+  const match = /^(\d{2}):(\d{2})$/.exec(value || "");
+  if (!match) {
+    return NaN;
+  }
+  return Number(match[1]) * 60 + Number(match[2]);
+}
+
+/**
+ * Returns the weekday index (0 = Sunday) and minutes since midnight of `date` in `timeZone`.
+ * Throws a RangeError when the time zone is unknown.
+ * @param {Date} date
+ * @param {string} timeZone
+ * @returns {{ weekday: number, minutes: number }}
+ */
+function getZonedTime(date, timeZone) {
+  const parts = new Intl.DateTimeFormat("en-US", { timeZone, weekday: "short", hour: "2-digit", minute: "2-digit", hourCycle: "h23" }).formatToParts(date);
+  /** @param {string} type */
+  const part = type => parts.find(p => p.type === type)?.value || "";
+  return {
+    weekday: WEEKDAYS.indexOf(part("weekday").toLowerCase()),
+    minutes: (Number(part("hour")) % 24) * 60 + Number(part("minute")),
+  };
+}
+
+/**
+ * Reports whether the zoned time falls inside the window. Windows whose end is
+ * earlier than their start span midnight and belong to the day they start on.
+ * @param {{ weekday: number, minutes: number }} now
+ * @param {string[]} days - Three-letter lowercase day names
+ * @param {number} start - Window start in minutes since midnight
+ * @param {number} end - Window end in minutes since midnight
+ * @returns {boolean}
+ */
+function isWithinActiveHours(now, days, start, end) {
+  const today = days.includes(WEEKDAYS[now.weekday]);
+  if (start < end) {
+    return today && now.minutes >= start && now.minutes < end;
+  }
+  const yesterday = days.includes(WEEKDAYS[(now.weekday + 6) % 7]);
+  return (today && now.minutes >= start) || (yesterday && now.minutes < end);
+}
+
+/**
+ * Describes when the next window opens relative to the zoned time, e.g. "tomorrow at 09:00".
+ * @param {{ weekday: number, minutes: number }} now
+ * @param {string[]} days
+ * @param {string} startClock - Window start as "HH:MM"
+ * @returns {string}
+ */
+function describeNextWindow(now, days, startClock) {
+  const start = parseClock(startClock);
+  for (let offset = 0; offset <= 7; offset++) {
+    const weekday = (now.weekday + offset) % 7;
+    if (!days.includes(WEEKDAYS[weekday]) || (offset === 0 && now.minutes >= start)) {
+      continue;
+    }
+    if (offset === 0) {
+      return `today at ${startClock}`;
+    }
+    if (offset === 1) {
+      return `tomorrow at ${startClock}`;
+    }
+    return `${WEEKDAY_LABELS[weekday]} at ${startClock}`;
+  }
+  return `at ${startClock}`;
+}
+
+/**
+ * Returns the file name of the running workflow, e.g. "triage.lock.yml".
+ * @returns {string}
+ */
+function currentWorkflowFile() {
+  const workflowPath = (process.env.GITHUB_WORKFLOW_REF || "").split("@")[0];
+  return workflowPath.split("/").pop() || "";
+}
+
+/**
+ * Builds the hidden marker that records a queued trigger in the notice comment.
+ * @param {string} workflowFile
+ * @param {Record<string, unknown>} awContext
+ * @returns {string}
+ */
+function buildQueueMarker(workflowFile, awContext) {
+  return `<!-- gh-aw-active-hours-queued:${workflowFile}:${Buffer.from(JSON.stringify(awContext)).toString("base64")} -->`;
+}
+
+/**
+ * Extracts a queued trigger from a notice comment body.
+ * @param {string} body
+ * @returns {{ marker: string, workflowFile: string, awContext: Record<string, unknown> } | null}
+ */
+function parseQueueMarker(body) {
+  const match = QUEUE_MARKER_PATTERN.exec(body || "");
+  if (!match) {
+    return null;
+  }
+  try {
+    const awContext = JSON.parse(Buffer.from(match[2], "base64").toString("utf8"));
+    if (typeof awContext !== "object" || awContext === null || Array.isArray(awContext)) {
+      return null;
+    }
+    return { marker: match[0], workflowFile: match[1], awContext };
+  } catch {
+    return null;
+  }
+}
+
+/**
+ * Re-dispatches the triggers this workflow queued outside active hours. Only notices
+ * posted by github-actions[bot] on the item they record are trusted; each one is
+ * dispatched with its recorded aw_context and then edited so it is not dispatched again.
+ * @param {string} workflowFile
+ * @returns {Promise<number>} Number of dispatched triggers
+ */
+async function drainQueue(workflowFile) {
+  const { owner, repo } = context.repo;
+  const since = new Date(Date.now() - QUEUE_LOOKBACK_MS).toISOString();
+  const comments = await github.paginate(github.rest.issues.listCommentsForRepo, { owner, repo, since, per_page: 100 });
+
+  let dispatched = 0;
+  for (const comment of comments) {
+    if (comment.user?.login !== "github-actions[bot]") {
+      continue;
+    }
+    const queued = parseQueueMarker(comment.body || "");
+    if (!queued || queued.workflowFile !== workflowFile) {
+      continue;
+    }
+    // The recorded trigger must belong to the issue or pull request the notice is on.
+    const issueNumber = (comment.issue_url || "").split("/").pop();
+    if (!issueNumber || String(queued.awContext.item_number) !== issueNumber) {
+      core.warning(`Ignoring queued trigger in comment ${comment.id}: it does not match the item it was posted on`);
+      continue;
+    }
+    try {
+      await github.rest.actions.createWorkflowDispatch({
+        owner,
+        repo,
+        workflow_id: workflowFile,
+        ref: context.ref,
+        inputs: { aw_context: JSON.stringify(queued.awContext) },
+      });
+      await github.rest.issues.updateComment({
+        owner,
+        repo,
+        comment_id: comment.id,
+        body: `${comment.body.replace(queued.marker, "").trimEnd()}\n\n▶️ Active hours have started; this request is now being processed.`,
+      });
+      dispatched++;
+      core.info(`Re-dispatched queued trigger from comment ${comment.html_url || comment.id}`);
+    } catch (error) {
+      core.warning(`Could not re-dispatch queued trigger from comment ${comment.id}: ${getErrorMessage(error)}`);
+    }
+  }
+  return dispatched;
+}
+
+/**
+ * Posts the out-of-hours notice on the triggering issue, pull request, or discussion.
+ * @param {string} body
+ */
+async function postNotice(body) {
+  const payload = context.payload || {};
+  const number = payload.issue?.number ?? payload.pull_request?.number;
+  if (number) {
+    await github.rest.issues.createComment({ owner: context.repo.owner, repo: context.repo.repo, issue_number: number, body });
+    core.info(`Posted active-hours notice on #${number}`);
+    return;
+  }
+  const discussionId = payload.discussion?.node_id;
+  if (discussionId) {
+    await github.graphql(
+      `mutation($dId: ID!, $body: String!) {
+        addDiscussionComment(input: { discussionId: $dId, body: $body }) {
+          comment { id }
+        }
+      }`,
+      { dId: discussionId, body }
+    );
+    core.info(`Posted active-hours notice on discussion #${payload.discussion.number}`);
+    return;
+  }
+  core.info("No issue, pull request, or discussion to notify");
+}
+
+async function main() {
+  const days = (process.env.GH_AW_ACTIVE_HOURS_DAYS || "").split(",").filter(Boolean);
+  const startClock = process.env.GH_AW_ACTIVE_HOURS_START || "";
+  const endClock = process.env.GH_AW_ACTIVE_HOURS_END || "";
+  const timeZone = process.env.GH_AW_ACTIVE_HOURS_TIMEZONE || "UTC";
+  const events = (process.env.GH_AW_ACTIVE_HOURS_EVENTS || "").split(",").filter(Boolean);
+  const workflowName = process.env.GH_AW_WORKFLOW_NAME || "workflow";
+
+  const start = parseClock(startClock);
+  const end = parseClock(endClock);
+  if (days.length === 0 || Number.isNaN(start) || Number.isNaN(end)) {
+    core.setFailed(`${ERR_CONFIG}: Configuration error: active-hours requires GH_AW_ACTIVE_HOURS_DAYS, GH_AW_ACTIVE_HOURS_START, and GH_AW_ACTIVE_HOURS_END.`);
+    return;
+  }
+
+  // A run started by one of the queue crons only drains the queue; it never runs the agent.
+  const queueCrons = (process.env.GH_AW_ACTIVE_HOURS_QUEUE_CRONS || "").split(";").filter(Boolean);
+  if (context.eventName === "schedule" && queueCrons.includes(context.payload?.schedule)) {
+    core.setOutput("active_hours_ok", "false");
+    let now;
+    try {
+      now = getZonedTime(new Date(), timeZone);
+    } catch (error) {
+      core.setFailed(`${ERR_CONFIG}: Configuration error: invalid active-hours timezone '${timeZone}': ${getErrorMessage(error)}`);
+      return;
+    }
+    // Zones with daylight saving time get one cron per UTC offset; only the one that
+    // fires inside the window drains the queue.
+    if (!isWithinActiveHours(now, days, start, end)) {
+      core.info("Queue schedule fired outside active hours; nothing to drain");
+      return;
+    }
+    const dispatched = await drainQueue(currentWorkflowFile());
+    core.info(`Re-dispatched ${dispatched} queued trigger(s)`);
+    return;
+  }
+
+  if (events.length > 0 && !events.includes(context.eventName)) {
+    core.info(`Event '${context.eventName}' is not subject to active hours`);
+    core.setOutput("active_hours_ok", "true");
+    return;
+  }
+
+  let now;
+  try {
+    now = getZonedTime(new Date(), timeZone);
+  } catch (error) {
+    core.setFailed(`${ERR_CONFIG}: Configuration error: invalid active-hours timezone '${timeZone}': ${getErrorMessage(error)}`);
+    return;
+  }
+
+  const window = `${days.map(d => d.charAt(0).toUpperCase() + d.slice(1)).join(", ")} ${startClock}-${endClock} (${timeZone})`;
+  core.info(`Active hours: ${window}`);
+
+  if (isWithinActiveHours(now, days, start, end)) {
+    core.setOutput("active_hours_ok", "true");
+    return;
+  }
+
+  const next = describeNextWindow(now, days, startClock);
+  core.warning(`⏰ Outside active hours (${window}). The next window opens ${next}.`);
+  core.setOutput("active_hours_ok", "false");
+
+  // Only notify when every other pre-activation gate passed, so comments that
+  // were never going to run the workflow (non-members, missing command) stay quiet.
+  const payload = context.payload || {};
+  const notify = process.env.GH_AW_ACTIVE_HOURS_COMMENT === "true" && process.env.GH_AW_ACTIVE_HOURS_NOTIFY === "true";
+  const workflowFile = currentWorkflowFile();
+  const queue = notify && queueCrons.length > 0 && QUEUE_EVENTS.includes(context.eventName) && Boolean(payload.issue?.number ?? payload.pull_request?.number) && workflowFile !== "";
+  let queued = false;
+  if (notify) {
+    const defaultTemplate = queue
+      ? "⏰ **{workflow_name}** only runs during active hours ({window}). This request is queued and will be processed {next}."
+      : "⏰ **{workflow_name}** only runs during active hours ({window}), so this request was not processed. Please trigger it again {next}.";
+    const template = process.env.GH_AW_ACTIVE_HOURS_MESSAGE || defaultTemplate;
+    let body = template.replaceAll("{workflow_name}", workflowName).replaceAll("{window}", window).replaceAll("{next}", next).replaceAll("{timezone}", timeZone);
+    if (queue) {
+      const awContext = { ...buildAwContext(), command_name: process.env.GH_AW_ACTIVE_HOURS_COMMAND || "", active_hours_queued: true };
+      body = `${body}\n\n${buildQueueMarker(workflowFile, awContext)}`;
+    }
+    try {
+      await postNotice(body);
+      queued = queue;
+    } catch (error) {
+      core.warning(`Could not post active-hours notice: ${getErrorMessage(error)}`);
+    }
+  }
+
+  if (queued) {
+    await writeDenialSummary(`Workflow '${workflowName}' was triggered outside its active hours (${window}).`, `The request is queued and will be re-dispatched ${next}.`);
+    return;
+  }
+  await writeDenialSummary(`Workflow '${workflowName}' was triggered outside its active hours (${window}).`, `Trigger it again ${next}, or update \`active-hours:\` in the workflow frontmatter.`);
+}
+
+module.exports = { main, parseClock, getZonedTime, isWithinActiveHours, describeNextWindow, buildQueueMarker, parseQueueMarker };
@@ -0,0 +1,259 @@
+import { describe, it, expect, beforeEach, afterEach, vi } from "vitest";
+
+const mockCore = {
+  debug: vi.fn(),
+  info: vi.fn(),
+  warning: vi.fn(),
+  error: vi.fn(),
+  setFailed: vi.fn(),
+  setOutput: vi.fn(),
+  summary: {
+    addRaw: vi.fn().mockReturnThis(),
+    write: vi.fn().mockResolvedValue(),
+  },
+};
+
+const mockGithub = {
+  rest: {
+    issues: {
+      createComment: vi.fn(),
+      listCommentsForRepo: vi.fn(),
+      updateComment: vi.fn(),
+    },
+    actions: {
+      createWorkflowDispatch: vi.fn(),
+    },
+  },
+  graphql: vi.fn(),
+  paginate: vi.fn(),
+};
+
+const mockContext = {
+  repo: { owner: "test-owner", repo: "test-repo" },
+  eventName: "issue_comment",
+  payload: { issue: { number: 42 } },
+};
+
+global.core = mockCore;
+global.github = mockGithub;
+global.context = mockContext;
+
+const ENV_KEYS = [
+  "GH_AW_ACTIVE_HOURS_DAYS",
+  "GH_AW_ACTIVE_HOURS_START",
+  "GH_AW_ACTIVE_HOURS_END",
+  "GH_AW_ACTIVE_HOURS_TIMEZONE",
+  "GH_AW_ACTIVE_HOURS_EVENTS",
+  "GH_AW_ACTIVE_HOURS_COMMENT",
+  "GH_AW_ACTIVE_HOURS_NOTIFY",
+  "GH_AW_ACTIVE_HOURS_MESSAGE",
+  "GH_AW_ACTIVE_HOURS_QUEUE_CRONS",
+  "GH_AW_ACTIVE_HOURS_COMMAND",
+  "GH_AW_WORKFLOW_NAME",
+  "GITHUB_WORKFLOW_REF",
+];
+
+describe("check_active_hours.cjs", () => {
+  beforeEach(() => {
+    vi.clearAllMocks();
+    vi.useFakeTimers();
+    mockContext.eventName = "issue_comment";
+    mockContext.payload = { issue: { number: 42 } };
+    process.env.GH_AW_ACTIVE_HOURS_DAYS = "mon,tue,wed,thu,fri";
+    process.env.GH_AW_ACTIVE_HOURS_START = "09:00";
+    process.env.GH_AW_ACTIVE_HOURS_END = "17:00";
+    process.env.GH_AW_ACTIVE_HOURS_TIMEZONE = "UTC";
+    process.env.GH_AW_ACTIVE_HOURS_EVENTS = "issue_comment,issues";
+    process.env.GH_AW_ACTIVE_HOURS_COMMENT = "true";
+    process.env.GH_AW_ACTIVE_HOURS_NOTIFY = "true";
+    process.env.GH_AW_WORKFLOW_NAME = "Triage";
+  });
+
+  afterEach(() => {
+    vi.useRealTimers();
+    for (const key of ENV_KEYS) {
+      delete process.env[key];
+    }
+  });
+
+  describe("isWithinActiveHours", () => {
+    const { isWithinActiveHours } = require("./check_active_hours.cjs");
+    const weekdays = ["mon", "tue", "wed", "thu", "fri"];
+
+    it("matches daytime windows on configured days", () => {
+      expect(isWithinActiveHours({ weekday: 1, minutes: 9 * 60 }, weekdays, 540, 1020)).toBe(true);
+      expect(isWithinActiveHours({ weekday: 1, minutes: 17 * 60 }, weekdays, 540, 1020)).toBe(false);
+      expect(isWithinActiveHours({ weekday: 0, minutes: 12 * 60 }, weekdays, 540, 1020)).toBe(false);
+    });
+
+    it("assigns overnight windows to the day they start on", () => {
+      // 22:00-06:00 on Friday runs into Saturday morning but not into Monday morning.
+      expect(isWithinActiveHours({ weekday: 6, minutes: 2 * 60 }, ["fri"], 1320, 360)).toBe(true);
+      expect(isWithinActiveHours({ weekday: 5, minutes: 2 * 60 }, ["fri"], 1320, 360)).toBe(false);
+      expect(isWithinActiveHours({ weekday: 5, minutes: 23 * 60 }, ["fri"], 1320, 360)).toBe(true);
+    });
+  });
+
+  describe("describeNextWindow", () => {
+    const { describeNextWindow } = require("./check_active_hours.cjs");
+    const weekdays = ["mon", "tue", "wed", "thu", "fri"];
+
+    it("describes later today, tomorrow, and later weekdays", () => {
+      expect(describeNextWindow({ weekday: 2, minutes: 7 * 60 }, weekdays, "09:00")).toBe("today at 09:00");
+      expect(describeNextWindow({ weekday: 2, minutes: 18 * 60 }, weekdays, "09:00")).toBe("tomorrow at 09:00");
+      expect(describeNextWindow({ weekday: 5, minutes: 18 * 60 }, weekdays, "09:00")).toBe("Monday at 09:00");
+    });
+  });
+
+  it("passes inside the window", async () => {
+    vi.setSystemTime(new Date("2026-10-14T10:00:00Z")); // Wednesday
+    const { main } = require("./check_active_hours.cjs");
+    await main();
+
+    expect(mockCore.setOutput).toHaveBeenCalledWith("active_hours_ok", "true");
+    expect(mockGithub.rest.issues.createComment).not.toHaveBeenCalled();
+  });
+
+  it("stops the run and comments outside the window", async () => {
+    vi.setSystemTime(new Date("2026-10-16T18:30:00Z")); // Friday evening
+    const { main } = require("./check_active_hours.cjs");
+    await main();
+
+    expect(mockCore.setOutput).toHaveBeenCalledWith("active_hours_ok", "false");
+    expect(mockGithub.rest.issues.createComment).toHaveBeenCalledWith(expect.objectContaining({ issue_number: 42 }));
+    const body = mockGithub.rest.issues.createComment.mock.calls[0][0].body;
+    expect(body).toContain("Triage");
+    expect(body).toContain("Monday at 09:00");
+    expect(mockCore.summary.write).toHaveBeenCalled();
+  });
+
+  it("stays quiet when other pre-activation gates failed", async () => {
+    vi.setSystemTime(new Date("2026-10-17T12:00:00Z")); // Saturday
+    process.env.GH_AW_ACTIVE_HOURS_NOTIFY = "false";
+    const { main } = require("./check_active_hours.cjs");
+    await main();
+
+    expect(mockCore.setOutput).toHaveBeenCalledWith("active_hours_ok", "false");
+    expect(mockGithub.rest.issues.createComment).not.toHaveBeenCalled();
+  });
+
+  it("uses the custom message", async () => {
+    vi.setSystemTime(new Date("2026-10-17T12:00:00Z")); // Saturday
+    process.env.GH_AW_ACTIVE_HOURS_MESSAGE = "Back {next}";
+    const { main } = require("./check_active_hours.cjs");
+    await main();
+
+    expect(mockGithub.rest.issues.createComment).toHaveBeenCalledWith(expect.objectContaining({ body: "Back Monday at 09:00" }));
+  });
+
+  it("ignores events outside the configured list", async () => {
+    vi.setSystemTime(new Date("2026-10-17T12:00:00Z")); // Saturday
+    mockContext.eventName = "workflow_dispatch";
+    const { main } = require("./check_active_hours.cjs");
+    await main();
+
+    expect(mockCore.setOutput).toHaveBeenCalledWith("active_hours_ok", "true");
+  });
+
+  it("evaluates the window in the configured timezone", async () => {
+    vi.setSystemTime(new Date("2026-10-14T07:30:00Z")); // 09:30 in Berlin (CEST)
+    process.env.GH_AW_ACTIVE_HOURS_TIMEZONE = "Europe/Berlin";
+    const { main } = require("./check_active_hours.cjs");
+    await main();
+
+    expect(mockCore.setOutput).toHaveBeenCalledWith("active_hours_ok", "true");
+  });
+
+  describe("queue", () => {
+    const { buildQueueMarker, parseQueueMarker } = require("./check_active_hours.cjs");
+
+    beforeEach(() => {
+      process.env.GH_AW_ACTIVE_HOURS_QUEUE_CRONS = "0 9 * * 1,2,3,4,5";
+      process.env.GITHUB_WORKFLOW_REF = "test-owner/test-repo/.github/workflows/triage.lock.yml@refs/heads/main";
+      mockContext.ref = "refs/heads/main";
+    });
+
+    it("round-trips the queue marker", () => {
+      const marker = buildQueueMarker("triage.lock.yml", { item_number: "42", actor: "octocat" });
+      expect(parseQueueMarker(`Queued.\n\n${marker}`)).toEqual({ marker, workflowFile: "triage.lock.yml", awContext: { item_number: "42", actor: "octocat" } });
+      expect(parseQueueMarker("no marker here")).toBeNull();
+    });
+
+    it("records the trigger in the notice", async () => {
+      vi.setSystemTime(new Date("2026-10-16T18:30:00Z")); // Friday evening
+      mockContext.actor = "octocat";
+      process.env.GH_AW_ACTIVE_HOURS_COMMAND = "triage";
+      const { main } = require("./check_active_hours.cjs");
+      await main();
+
+      expect(mockCore.setOutput).toHaveBeenCalledWith("active_hours_ok", "false");
+      const body = mockGithub.rest.issues.createComment.mock.calls[0][0].body;
+      expect(body).toContain("queued and will be processed Monday at 09:00");
+      const queued = parseQueueMarker(body);
+      expect(queued?.workflowFile).toBe("triage.lock.yml");
+      expect(queued?.awContext).toMatchObject({ item_number: "42", command_name: "triage", active_hours_queued: true });
+    });
+
+    it("does not queue discussion triggers", async () => {
+      vi.setSystemTime(new Date("2026-10-16T18:30:00Z")); // Friday evening
+      mockContext.eventName = "discussion_comment";
+      mockContext.payload = { discussion: { number: 7, node_id: "D_1" } };
+      process.env.GH_AW_ACTIVE_HOURS_EVENTS = "discussion_comment";
+      const { main } = require("./check_active_hours.cjs");
+      await main();
+
+      const body = mockGithub.graphql.mock.calls[0][1].body;
+      expect(body).toContain("Please trigger it again Monday at 09:00");
+      expect(parseQueueMarker(body)).toBeNull();
+    });
+
+    it("re-dispatches queued triggers when the window opens", async () => {
+      vi.setSystemTime(new Date("2026-10-19T09:02:00Z")); // Monday morning
+      mockContext.eventName = "schedule";
+      mockContext.payload = { schedule: "0 9 * * 1,2,3,4,5" };
+      const awContext = { item_type: "issue", item_number: "42", actor: "octocat", active_hours_queued: true };
+      const marker = buildQueueMarker("triage.lock.yml", awContext);
+      mockGithub.paginate.mockResolvedValue([
+        { id: 1, issue_url: "https://api.github.com/repos/test-owner/test-repo/issues/42", user: { login: "github-actions[bot]" }, body: `Queued.\n\n${marker}` },
+        { id: 2, issue_url: "https://api.github.com/repos/test-owner/test-repo/issues/42", user: { login: "mallory" }, body: `Forged.\n\n${marker}` },
+        { id: 3, issue_url: "https://api.github.com/repos/test-owner/test-repo/issues/43", user: { login: "github-actions[bot]" }, body: `Moved.\n\n${marker}` },
+        { id: 4, issue_url: "https://api.github.com/repos/test-owner/test-repo/issues/42", user: { login: "github-actions[bot]" }, body: buildQueueMarker("other.lock.yml", awContext) },
+      ]);
+      const { main } = require("./check_active_hours.cjs");
+      await main();
+
+      expect(mockCore.setOutput).toHaveBeenCalledWith("active_hours_ok", "false");
+      expect(mockGithub.rest.actions.createWorkflowDispatch).toHaveBeenCalledTimes(1);
+      expect(mockGithub.rest.actions.createWorkflowDispatch).toHaveBeenCalledWith({
+        owner: "test-owner",
+        repo: "test-repo",
+        workflow_id: "triage.lock.yml",
+        ref: "refs/heads/main",
+        inputs: { aw_context: JSON.stringify(awContext) },
+      });
+      expect(mockGithub.rest.issues.updateComment).toHaveBeenCalledTimes(1);
+      const updated = mockGithub.rest.issues.updateComment.mock.calls[0][0];
+      expect(updated.comment_id).toBe(1);
+      expect(parseQueueMarker(updated.body)).toBeNull();
+    });
+
+    it("leaves the queue alone when the schedule fires outside the window", async () => {
+      vi.setSystemTime(new Date("2026-10-19T08:00:00Z")); // Monday before the window
+      mockContext.eventName = "schedule";
+      mockContext.payload = { schedule: "0 9 * * 1,2,3,4,5" };
+      const { main } = require("./check_active_hours.cjs");
+      await main();
+
+      expect(mockCore.setOutput).toHaveBeenCalledWith("active_hours_ok", "false");
+      expect(mockGithub.paginate).not.toHaveBeenCalled();
+    });
+  });
+
+  it("fails on an unknown timezone", async () => {
+    process.env.GH_AW_ACTIVE_HOURS_TIMEZONE = "Mars/Olympus";
+    const { main } = require("./check_active_hours.cjs");
+    await main();
+
+    expect(mockCore.setFailed).toHaveBeenCalledWith(expect.stringContaining("invalid active-hours timezone"));
+  });
+});
@@ -0,0 +1,140 @@
+// @ts-check
+
+/**
+ * Decide whether the next engine in an engine fallback chain should run.
+ *
+ * Workflows can declare an ordered engine list (engine: [copilot, claude]).
+ * When an engine step fails, this script inspects the part of the agent stdio
+ * log written since the previous check and classifies the failure:
+ *
+ *   - rate_limit: The provider rejected requests because of rate limiting or
+ *     quota exhaustion (e.g. HTTP 429, "Too Many Requests", CAPI quota errors).
+ *   - auth: The engine could not authenticate (e.g. HTTP 401, invalid API key,
+ *     "Access denied by policy settings").
+ *   - unavailable: The provider is overloaded or temporarily unavailable
+ *     (e.g. "overloaded_error", HTTP 503).
+ *
+ * Exhaustion of the per-run LLM invocation cap is not a fallback reason: the
+ * pooled budget is shared, so the next engine could not make progress either.
+ * Any other failure (test failures, timeouts, tool errors) is left to fail the
+ * job as before.
+ *
+ * Outputs (written to $GITHUB_OUTPUT):
+ *   - fallback: "true" when the next engine should run
+ *   - reason: the failure class, or "none"
+ *
+ * Environment variables:
+ *   - GH_AW_FALLBACK_ENGINE: ID of the engine that would run next (for logging)
+ *
+ * Exit codes:
+ *   0 — Always succeeds; the decision is reported through step outputs
+ */
+
+"use strict";
+
+const fs = require("fs");
+const { CAPI_QUOTA_EXCEEDED_PATTERN, INFERENCE_ACCESS_ERROR_PATTERN, isInvocationCapExceededError } = require("./detect_agent_errors.cjs");
+
+const LOG_FILE = "/tmp/gh-aw/agent-stdio.log";
+
+// Byte offset into LOG_FILE already examined by a previous check. The log is
+// appended to by every engine in the chain, so each check only looks at the
+// output of the engine that just failed.
+const OFFSET_FILE = "/tmp/gh-aw/engine_fallback_offset.txt";
+
+// Pattern: provider rate limiting or quota exhaustion.
+const RATE_LIMIT_PATTERN = /(?:\b429\b|Too Many Requests|rate[ _-]?limit(?:ed|_error)?|quota exceeded|insufficient_quota)/i;
+
+// Pattern: authentication or authorization failures at the provider.
+const AUTH_ERROR_PATTERN = /(?:\b401\b|Unauthorized|authentication_error|invalid[ _-]api[ _-]key|Invalid API key|Incorrect API key|permission_error)/i;
+
+// Pattern: provider overloaded or temporarily unavailable.
+const PROVIDER_UNAVAILABLE_PATTERN = /(?:overloaded_error|\bOverloaded\b|\b503\b|Service Unavailable)/i;
+
+/**
+ * Classify an engine failure from its log output.
+ * @param {string} logContent - Agent stdio output written by the failed engine
+ * @returns {"rate_limit" | "auth" | "unavailable" | "none"}
+ */
+function classifyFailure(logContent) {
+  if (!logContent || isInvocationCapExceededError(logContent)) {
+    return "none";
+  }
+  if (CAPI_QUOTA_EXCEEDED_PATTERN.test(logContent) || RATE_LIMIT_PATTERN.test(logContent)) {
+    return "rate_limit";
+  }
+  if (INFERENCE_ACCESS_ERROR_PATTERN.test(logContent) || AUTH_ERROR_PATTERN.test(logContent)) {
+    return "auth";
+  }
+  if (PROVIDER_UNAVAILABLE_PATTERN.test(logContent)) {
+    return "unavailable";
+  }
+  return "none";
+}
+
+/**
+ * Read the log content appended since the last check and advance the offset.
+ * @param {string} logFile
+ * @param {string} offsetFile
+ * @returns {string}
+ */
+function readNewLogContent(logFile, offsetFile) {
+  if (!fs.existsSync(logFile)) {
+    process.stderr.write(`[check-engine-fallback] Log file not found: ${logFile}\n`);
+    return "";
+  }
+
+  let offset = 0;
+  if (fs.existsSync(offsetFile)) {
+    const parsed = parseInt(fs.readFileSync(offsetFile, "utf8").trim(), 10);
+    if (Number.isFinite(parsed) && parsed > 0) {
+      offset = parsed;
+    }
+  }
+
+  const content = fs.readFileSync(logFile);
+  fs.writeFileSync(offsetFile, String(content.length));
+  return content.subarray(Math.min(offset, content.length)).toString("utf8");
+}
+
+/**
+ * Write GitHub Actions outputs to $GITHUB_OUTPUT.
+ * @param {string} reason
+ */
+function writeOutputs(reason) {
+  const outputFile = process.env.GITHUB_OUTPUT;
+  if (!outputFile) {
+    process.stderr.write("[check-engine-fallback] GITHUB_OUTPUT not set — skipping output\n");
+    return;
+  }
+  try {
+    fs.appendFileSync(outputFile, `fallback=${reason !== "none"}\nreason=${reason}\n`);
+  } catch (err) {
+    process.stderr.write(`[check-engine-fallback] Failed to write to GITHUB_OUTPUT: ${String(err)}\n`);
+  }
+}
+
+function main() {
+  const nextEngine = process.env.GH_AW_FALLBACK_ENGINE || "next engine";
+  const reason = classifyFailure(readNewLogContent(LOG_FILE, OFFSET_FILE));
+
+  if (reason === "none") {
+    process.stderr.write(`[check-engine-fallback] Engine failure is not a rate-limit, authentication, or availability error; not falling back to ${nextEngine}\n`);
+  } else {
+    process.stderr.write(`[check-engine-fallback] Detected ${reason} failure; falling back to ${nextEngine}\n`);
+  }
+
+  writeOutputs(reason);
+}
+
+if (require.main === module) {
+  main();
+}
+
+module.exports = {
+  classifyFailure,
+  readNewLogContent,
+  RATE_LIMIT_PATTERN,
+  AUTH_ERROR_PATTERN,
+  PROVIDER_UNAVAILABLE_PATTERN,
+};
@@ -0,0 +1,64 @@
+import { describe, it, expect, beforeEach, afterEach } from "vitest";
+import fs from "fs";
+import os from "os";
+import path from "path";
+
+const { classifyFailure, readNewLogContent } = require("./check_engine_fallback.cjs");
+
+describe("check_engine_fallback.cjs", () => {
+  describe("classifyFailure", () => {
+    it("classifies rate limit errors", () => {
+      expect(classifyFailure("Error: 429 Too Many Requests")).toBe("rate_limit");
+      expect(classifyFailure("CAPIError: 429 429 quota exceeded")).toBe("rate_limit");
+      expect(classifyFailure('{"type":"error","error":{"type":"rate_limit_error"}}')).toBe("rate_limit");
+    });
+
+    it("classifies authentication errors", () => {
+      expect(classifyFailure("API Error: 401 Unauthorized")).toBe("auth");
+      expect(classifyFailure('{"error":{"type":"authentication_error","message":"invalid x-api-key"}}')).toBe("auth");
+      expect(classifyFailure("Error: Access denied by policy settings")).toBe("auth");
+    });
+
+    it("classifies provider availability errors", () => {
+      expect(classifyFailure('{"type":"overloaded_error","message":"Overloaded"}')).toBe("unavailable");
+      expect(classifyFailure("503 Service Unavailable")).toBe("unavailable");
+    });
+
+    it("does not fall back when the invocation cap is exhausted", () => {
+      expect(classifyFailure("CAPIError: 429 Maximum LLM invocations exceeded (50/50)")).toBe("none");
+    });
+
+    it("does not fall back for unrelated failures", () => {
+      expect(classifyFailure("")).toBe("none");
+      expect(classifyFailure("Error: tests failed with exit code 1")).toBe("none");
+    });
+  });
+
+  describe("readNewLogContent", () => {
+    let tmpDir;
+    let logFile;
+    let offsetFile;
+
+    beforeEach(() => {
+      tmpDir = fs.mkdtempSync(path.join(os.tmpdir(), "engine-fallback-"));
+      logFile = path.join(tmpDir, "agent-stdio.log");
+      offsetFile = path.join(tmpDir, "offset.txt");
+    });
+
+    afterEach(() => {
+      fs.rmSync(tmpDir, { recursive: true, force: true });
+    });
+
+    it("returns only content appended since the previous check", () => {
+      fs.writeFileSync(logFile, "primary: 429 Too Many Requests\n");
+      expect(readNewLogContent(logFile, offsetFile)).toBe("primary: 429 Too Many Requests\n");
+
+      fs.appendFileSync(logFile, "fallback: tests failed\n");
+      expect(readNewLogContent(logFile, offsetFile)).toBe("fallback: tests failed\n");
+    });
+
+    it("returns an empty string when the log file is missing", () => {
+      expect(readNewLogContent(logFile, offsetFile)).toBe("");
+    });
+  });
+});
@@ -82,8 +82,10 @@ async function main() {
     const commandName = typeof awContext?.command_name === "string" ? awContext.command_name.trim() : "";
     const triggerLabel = typeof awContext?.trigger_label === "string" ? awContext.trigger_label.trim() : "";
     const propagatedActor = typeof awContext?.actor === "string" ? awContext.actor.trim() : "";
+    // Triggers queued outside active hours are replayed by github-actions[bot] on behalf of the original actor.
+    const activeHoursQueued = awContext?.active_hours_queued === true;
 
-    if ((commandName || triggerLabel) && actor === "github-actions[bot]") {
+    if ((commandName || triggerLabel || activeHoursQueued) && actor === "github-actions[bot]") {
       if (!propagatedActor) {
         const errorMessage = "Access denied: workflow_dispatch aw_context.actor is required for centralized command dispatches.";
         core.warning(errorMessage);
@@ -1,7 +1,11 @@
 /**
- * @fileoverview Shared helpers for commit SHA normalization.
+ * @fileoverview Shared helpers for commit SHA normalization and resolution.
  */
 
+const { getErrorMessage } = require("./error_helpers.cjs");
+const { resolveTarget } = require("./safe_output_helpers.cjs");
+const { withRetry, RATE_LIMIT_RETRY_CONFIG } = require("./error_recovery.cjs");
+
 const GIT_COMMIT_SHA_PATTERN = /^[0-9a-fA-F]{7,40}$/;
 
 /**
@@ -15,6 +19,86 @@ function normalizeCommitSHA(value) {
   return GIT_COMMIT_SHA_PATTERN.test(normalized) ? normalized : "";
 }
 
+/**
+ * Resolve the commit SHA that a check run or commit status is attached to.
+ *
+ * Without a target, the SHA comes from the triggering event. With a target, the pull
+ * request is resolved using the standard PR target rules and its current head SHA is
+ * fetched from the API. In staged mode the API call is skipped and headSha is empty.
+ *
+ * @param {Object} params
+ * @param {any} params.githubClient - Authenticated GitHub client
+ * @param {string|null} params.targetConfig - Configured target ("triggering", "*", or PR number), or null
+ * @param {any} params.message - Safe output message (carries pull_request_number for target "*")
+ * @param {string} params.handlerType - Safe output type used in log and error messages
+ * @param {boolean} params.isStaged - Whether the handler runs in staged mode
+ * @returns {Promise<{success: true, headSha: string, prNumber: number|null} | {success: false, error: string, skipped?: boolean}>}
+ */
+async function resolveTargetHeadSHA({ githubClient, targetConfig, message, handlerType, isStaged }) {
+  if (!targetConfig) {
+    // For pull_request events, GITHUB_SHA is the ephemeral merge commit SHA which is
+    // not visible in the PR checks UI or the GitHub mobile app. Use the actual PR head
+    // SHA from the event payload instead so the result appears on the PR.
+    const prHeadSha = context.payload?.pull_request?.head?.sha;
+    if (prHeadSha) {
+      core.info(`Using PR head SHA ${prHeadSha} (pull_request event)`);
+    }
+    return { success: true, headSha: prHeadSha || process.env.GITHUB_SHA || context.sha, prNumber: null };
+  }
+
+  const targetResult = resolveTarget({
+    targetConfig,
+    item: message,
+    context,
+    itemType: handlerType,
+    supportsPR: false,
+    supportsIssue: false,
+  });
+  if (!targetResult.success) {
+    if (targetResult.shouldFail) {
+      core.error(targetResult.error);
+    } else {
+      core.info(targetResult.error);
+    }
+    return { success: false, error: targetResult.error, skipped: !targetResult.shouldFail };
+  }
+
+  const prNumber = targetResult.number;
+  if (isStaged) {
+    // Nothing is attached in staged mode, so the PR head is not fetched
+    return { success: true, headSha: "", prNumber };
+  }
+
+  // Fetch the current PR head SHA via the API. We intentionally go through the API
+  // even when the context payload already carries a SHA (e.g. target: "triggering" on
+  // a pull_request event) so that we always use the most recent head in case the PR
+  // was force-pushed between the triggering event and when the handler runs.
+  try {
+    const { data: pullRequest } = await withRetry(
+      () =>
+        githubClient.rest.pulls.get({
+          owner: context.repo.owner,
+          repo: context.repo.repo,
+          pull_number: prNumber,
+        }),
+      RATE_LIMIT_RETRY_CONFIG
+    );
+    const headSha = pullRequest?.head?.sha || "";
+    if (!headSha) {
+      const msg = `${handlerType}: pull request #${prNumber} has no head SHA`;
+      core.error(msg);
+      return { success: false, error: msg };
+    }
+    core.info(`Using PR #${prNumber} head SHA ${headSha} (target=${targetConfig})`);
+    return { success: true, headSha, prNumber };
+  } catch (error) {
+    const msg = `Failed to resolve pull request for ${handlerType}: ${getErrorMessage(error)}`;
+    core.error(msg);
+    return { success: false, error: msg };
+  }
+}
+
 module.exports = {
   normalizeCommitSHA,
+  resolveTargetHeadSHA,
 };
@@ -0,0 +1,202 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+/**
+ * Compare the outputs of parallel agent jobs in compare-engines: mode
+ *
+ * Each engine's agent artifact has been downloaded into its own directory. This script
+ * reads the job result and usage outputs from the needs context, loads each engine's
+ * agent_output.json, and writes a side-by-side comparison to the step summary. When
+ * comments are enabled and the run was triggered by an issue or pull request, the same
+ * comparison is posted as a comment once every engine's threat detection job has passed.
+ * Safe outputs are never executed.
+ *
+ * Environment Variables:
+ * - GH_AW_COMPARE_ENGINES: JSON array of {id, name, job, dir, model?, detection?}
+ * - GH_AW_COMPARE_NEEDS: JSON of the needs context (job results and outputs)
+ * - GH_AW_COMPARE_COMMENT: "true" to post the comparison as a comment
+ * - GH_AW_WORKFLOW_NAME: Workflow name used in the heading
+ */
+
+const fs = require("fs");
+const path = require("path");
+const { sanitizeContent } = require("./sanitize_content.cjs");
+const { getErrorMessage } = require("./error_helpers.cjs");
+const { ERR_CONFIG, ERR_PARSE } = require("./error_codes.cjs");
+
+/** Maximum characters shown for each output item in the comparison. */
+const MAX_ITEM_LENGTH = 2000;
+
+/**
+ * @typedef {Object} CompareEngine
+ * @property {string} id - Engine ID
+ * @property {string} name - Engine display name
+ * @property {string} job - Agent job that ran the engine
+ * @property {string} dir - Directory holding the engine's downloaded agent artifact
+ * @property {string} [model] - Model label, used instead of the job's model output
+ * @property {string} [detection] - Threat detection job that scanned the engine's output
+ */
+
+/**
+ * Lists the engines whose output did not pass threat detection. An engine without a
+ * detection job, or whose detection job did not report success, is not cleared.
+ * @param {CompareEngine[]} engines
+ * @param {Record<string, any>} needs - The needs context
+ * @returns {string[]} IDs of the engines that were not cleared
+ */
+function findUnclearedEngines(engines, needs) {
+  return engines.filter(engine => !engine.detection || needs?.[engine.detection]?.outputs?.detection_success !== "true").map(engine => engine.id);
+}
+
+/**
+ * Load the safe output items recorded by one engine.
+ * @param {string} dir - Directory holding the engine's agent artifact
+ * @returns {{items: any[], error?: string}}
+ */
+function loadEngineOutput(dir) {
+  const file = path.join(dir, "agent_output.json");
+  if (!fs.existsSync(file)) {
+    return { items: [], error: "no agent output" };
+  }
+  try {
+    const parsed = JSON.parse(fs.readFileSync(file, "utf8"));
+    return { items: Array.isArray(parsed.items) ? parsed.items : [] };
+  } catch (error) {
+    return { items: [], error: `invalid agent output: ${getErrorMessage(error)}` };
+  }
+}
+
+/**
+ * Count items by type, e.g. "create_issue ×2, noop ×1".
+ * @param {any[]} items
+ * @returns {string}
+ */
+function summarizeTypes(items) {
+  /** @type {Map<string, number>} */
+  const counts = new Map();
+  for (const item of items) {
+    const type = typeof item?.type === "string" ? item.type : "unknown";
+    counts.set(type, (counts.get(type) || 0) + 1);
+  }
+  if (counts.size === 0) {
+    return "—";
+  }
+  return [...counts.entries()].map(([type, count]) => `${type} ×${count}`).join(", ");
+}
+
+/**
+ * Render one output item as markdown. Item text comes from the agent and is sanitized.
+ * @param {any} item
+ * @returns {string}
+ */
+function renderItem(item) {
+  const type = typeof item?.type === "string" ? item.type : "unknown";
+  const title = typeof item?.title === "string" ? item.title : "";
+  const text = [item?.body, item?.message, item?.reason].find(value => typeof value === "string") || "";
+  let line = `- **${sanitizeContent(type, 100)}**`;
+  if (title) {
+    line += `: ${sanitizeContent(title, 200)}`;
+  }
+  if (text) {
+    const quoted = sanitizeContent(text, MAX_ITEM_LENGTH)
+      .split("\n")
+      .map(l => `  > ${l}`)
+      .join("\n");
+    line += `\n${quoted}`;
+  }
+  return line;
+}
+
+/**
+ * Build the comparison markdown.
+ * @param {string} workflowName
+ * @param {CompareEngine[]} engines
+ * @param {Record<string, any>} needs - The needs context
+ * @returns {string}
+ */
+function buildComparison(workflowName, engines, needs) {
+  const rows = [];
+  const sections = [];
+  for (const engine of engines) {
+    const job = needs[engine.job] || {};
+    const outputs = job.outputs || {};
+    const { items, error } = loadEngineOutput(engine.dir);
+    const result = job.result || "unknown";
+    const model = engine.model || outputs.model || "—";
+    const tokens = outputs.effective_tokens || "—";
+    const summary = error ? `_${error}_` : summarizeTypes(items);
+    rows.push(`| ${engine.name} | ${result} | ${model} | ${tokens} | ${summary} |`);
+
+    const body = items.length > 0 ? items.map(renderItem).join("\n") : "_No outputs._";
+    sections.push(`<details>\n<summary>${engine.name} (${items.length} output${items.length === 1 ? "" : "s"})</summary>\n\n${body}\n\n</details>`);
+  }
+
+  return [
+    `## Engine comparison: ${workflowName}`,
+    "",
+    "Safe outputs were recorded but not executed.",
+    "",
+    "| Engine | Result | Model | Effective tokens | Outputs |",
+    "| --- | --- | --- | --- | --- |",
+    ...rows,
+    "",
+    ...sections,
+  ].join("\n");
+}
+
+async function main() {
+  /** @type {CompareEngine[]} */
+  let engines;
+  /** @type {Record<string, any>} */
+  let needs;
+  try {
+    engines = JSON.parse(process.env.GH_AW_COMPARE_ENGINES || "[]");
+    needs = JSON.parse(process.env.GH_AW_COMPARE_NEEDS || "{}");
+  } catch (error) {
+    core.setFailed(`${ERR_PARSE}: Failed to parse compare-engines configuration: ${getErrorMessage(error)}`);
+    return;
+  }
+  if (!Array.isArray(engines) || engines.length === 0) {
+    core.setFailed(`${ERR_CONFIG}: GH_AW_COMPARE_ENGINES must be a non-empty JSON array`);
+    return;
+  }
+
+  const workflowName = process.env.GH_AW_WORKFLOW_NAME || "Workflow";
+  const comparison = buildComparison(workflowName, engines, needs);
+  await core.summary.addRaw(comparison).write();
+  core.info(`Compared ${engines.length} engine(s): ${engines.map(e => e.id).join(", ")}`);
+
+  if (process.env.GH_AW_COMPARE_COMMENT !== "true") {
+    return;
+  }
+  const issueNumber = context.issue.number;
+  if (!issueNumber) {
+    core.info("No triggering issue or pull request; comparison written to the step summary only");
+    return;
+  }
+  const uncleared = findUnclearedEngines(engines, needs);
+  if (uncleared.length > 0) {
+    core.warning(`Threat detection did not pass for ${uncleared.join(", ")}; comparison written to the step summary only`);
+    return;
+  }
+  const runUrl = `${context.serverUrl}/${context.repo.owner}/${context.repo.repo}/actions/runs/${context.runId}`;
+  try {
+    await github.rest.issues.createComment({
+      owner: context.repo.owner,
+      repo: context.repo.repo,
+      issue_number: issueNumber,
+      body: `${comparison}\n\n> Generated by [${workflowName}](${runUrl})`,
+    });
+    core.info(`Posted engine comparison to #${issueNumber}`);
+  } catch (error) {
+    core.warning(`Failed to post engine comparison comment: ${getErrorMessage(error)}`);
+  }
+}
+
+module.exports = {
+  buildComparison,
+  findUnclearedEngines,
+  loadEngineOutput,
+  main,
+  summarizeTypes,
+};
@@ -0,0 +1,146 @@
+import { describe, it, expect, beforeEach, afterEach, vi } from "vitest";
+import fs from "fs";
+import os from "os";
+import path from "path";
+
+const mockCore = {
+  debug: vi.fn(),
+  info: vi.fn(),
+  warning: vi.fn(),
+  setFailed: vi.fn(),
+  summary: {
+    addRaw: vi.fn().mockReturnThis(),
+    write: vi.fn().mockResolvedValue(undefined),
+  },
+};
+global.core = mockCore;
+
+const { buildComparison, findUnclearedEngines, loadEngineOutput, main, summarizeTypes } = require("./compare_engines.cjs");
+
+describe("compare_engines", () => {
+  let tmpDir;
+
+  beforeEach(() => {
+    vi.clearAllMocks();
+    tmpDir = fs.mkdtempSync(path.join(os.tmpdir(), "compare-engines-"));
+    fs.mkdirSync(path.join(tmpDir, "copilot"));
+    fs.writeFileSync(
+      path.join(tmpDir, "copilot", "agent_output.json"),
+      JSON.stringify({
+        items: [
+          { type: "add_comment", body: "Looks like a duplicate" },
+          { type: "add_labels", labels: ["bug"] },
+        ],
+      })
+    );
+    delete process.env.GH_AW_COMPARE_COMMENT;
+    global.context = {
+      issue: { number: 7 },
+      repo: { owner: "octo", repo: "repo" },
+      serverUrl: "https://github.com",
+      runId: 42,
+    };
+    global.github = { rest: { issues: { createComment: vi.fn().mockResolvedValue({}) } } };
+  });
+
+  afterEach(() => {
+    fs.rmSync(tmpDir, { recursive: true, force: true });
+  });
+
+  const engines = () => [
+    { id: "copilot", name: "GitHub Copilot CLI", job: "agent", dir: path.join(tmpDir, "copilot") },
+    { id: "claude", name: "Claude Code", job: "agent_claude", dir: path.join(tmpDir, "claude"), model: "engine default" },
+  ];
+
+  describe("loadEngineOutput", () => {
+    it("should load items and report missing output", () => {
+      expect(loadEngineOutput(path.join(tmpDir, "copilot")).items).toHaveLength(2);
+      expect(loadEngineOutput(path.join(tmpDir, "claude"))).toEqual({ items: [], error: "no agent output" });
+    });
+  });
+
+  describe("summarizeTypes", () => {
+    it("should count items by type", () => {
+      expect(summarizeTypes([{ type: "noop" }, { type: "noop" }, { type: "add_comment" }])).toBe("noop ×2, add_comment ×1");
+      expect(summarizeTypes([])).toBe("—");
+    });
+  });
+
+  describe("buildComparison", () => {
+    it("should render a row and section per engine", () => {
+      const needs = {
+        agent: { result: "success", outputs: { model: "gpt-5", effective_tokens: "1200" } },
+        agent_claude: { result: "failure", outputs: { model: "gpt-5" } },
+      };
+      const markdown = buildComparison("Triage", engines(), needs);
+
+      expect(markdown).toContain("## Engine comparison: Triage");
+      expect(markdown).toContain("| GitHub Copilot CLI | success | gpt-5 | 1200 | add_comment ×1, add_labels ×1 |");
+      expect(markdown).toContain("| Claude Code | failure | engine default | — | _no agent output_ |");
+      expect(markdown).toContain("Looks like a duplicate");
+    });
+  });
+
+  describe("findUnclearedEngines", () => {
+    it("should clear only engines whose detection job reported success", () => {
+      const list = [
+        { id: "copilot", name: "", job: "agent", dir: "", detection: "detection" },
+        { id: "claude", name: "", job: "agent_claude", dir: "", detection: "detection_claude" },
+        { id: "codex", name: "", job: "agent_codex", dir: "" },
+      ];
+      const needs = { detection: { outputs: { detection_success: "true" } }, detection_claude: { result: "skipped", outputs: {} } };
+      expect(findUnclearedEngines(list, needs)).toEqual(["claude", "codex"]);
+    });
+  });
+
+  describe("main", () => {
+    it("should write the summary without commenting by default", async () => {
+      process.env.GH_AW_COMPARE_ENGINES = JSON.stringify(engines());
+      process.env.GH_AW_COMPARE_NEEDS = "{}";
+
+      await main();
+
+      expect(mockCore.summary.addRaw).toHaveBeenCalled();
+      expect(global.github.rest.issues.createComment).not.toHaveBeenCalled();
+      expect(mockCore.setFailed).not.toHaveBeenCalled();
+    });
+
+    const detectedEngines = () => engines().map(engine => ({ ...engine, detection: engine.job.replace("agent", "detection") }));
+
+    it("should post a comment when enabled and every engine passed detection", async () => {
+      process.env.GH_AW_COMPARE_ENGINES = JSON.stringify(detectedEngines());
+      process.env.GH_AW_COMPARE_NEEDS = JSON.stringify({
+        detection: { result: "success", outputs: { detection_success: "true" } },
+        detection_claude: { result: "success", outputs: { detection_success: "true" } },
+      });
+      process.env.GH_AW_COMPARE_COMMENT = "true";
+
+      await main();
+
+      expect(global.github.rest.issues.createComment).toHaveBeenCalledWith(expect.objectContaining({ owner: "octo", repo: "repo", issue_number: 7 }));
+    });
+
+    it("should not comment when an engine failed detection", async () => {
+      process.env.GH_AW_COMPARE_ENGINES = JSON.stringify(detectedEngines());
+      process.env.GH_AW_COMPARE_NEEDS = JSON.stringify({
+        detection: { result: "success", outputs: { detection_success: "true" } },
+        detection_claude: { result: "success", outputs: { detection_success: "false" } },
+      });
+      process.env.GH_AW_COMPARE_COMMENT = "true";
+
+      await main();
+
+      expect(mockCore.summary.addRaw).toHaveBeenCalled();
+      expect(global.github.rest.issues.createComment).not.toHaveBeenCalled();
+      expect(mockCore.warning).toHaveBeenCalledWith(expect.stringContaining("Threat detection did not pass for claude"));
+    });
+
+    it("should fail on invalid configuration", async () => {
+      process.env.GH_AW_COMPARE_ENGINES = "not json";
+
+      await main();
+
+      expect(mockCore.setFailed).toHaveBeenCalledWith(expect.stringContaining("Failed to parse compare-engines configuration"));
+    });
+  });
+});
@@ -67,6 +67,18 @@ const MAX_LABELS = 10;
  */
 const MAX_ASSIGNEES = 5;
 
+/**
+ * Maximum number of target issues/PRs in a single batch safe output message
+ * @type {number}
+ */
+const MAX_BATCH_ITEMS = 100;
+
+/**
+ * Number of batch targets processed between rate-limit checks
+ * @type {number}
+ */
+const BATCH_PAGE_SIZE = 20;
+
 // ---------------------------------------------------------------------------
 // File paths
 // ---------------------------------------------------------------------------
@@ -138,6 +150,8 @@ module.exports = {
   FAQ_CREATE_PR_PERMISSIONS_URL,
   MAX_LABELS,
   MAX_ASSIGNEES,
+  MAX_BATCH_ITEMS,
+  BATCH_PAGE_SIZE,
   GATEWAY_JSONL_PATH,
   RPC_MESSAGES_PATH,
   MANIFEST_FILE_PATH,
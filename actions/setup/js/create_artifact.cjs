@@ -0,0 +1,163 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+const { isStagedMode } = require("./safe_output_helpers.cjs");
+
+/**
+ * create_artifact handler
+ *
+ * Publishes encrypted agent results emitted via the create_artifact safe output tool.
+ *
+ * The safe-outputs MCP server writes each result to the create-artifact staging directory in the
+ * agent job. Before that directory leaves the agent runner, it is encrypted file-by-file with the
+ * workflow's GPG public key (encrypt_create_artifacts.sh) and the plaintext is deleted. The safe_outputs
+ * job downloads the encrypted staging directory and this handler uploads each <name>.gpg file as its
+ * own run-scoped artifact named <name>.
+ *
+ * Configuration keys (passed via config parameter from handler manager):
+ *   max            - Max number of create_artifact calls allowed (default: 1)
+ *   retention-days - Fixed retention period in days (default: 30); agent cannot override
+ *   max-size-bytes - Maximum encrypted file size in bytes (default: 10 MB plus encryption overhead)
+ *   staged         - true for staged/dry-run mode (skips actual upload)
+ */
+
+const fs = require("fs");
+const path = require("path");
+const { DefaultArtifactClient } = require("./artifact_client.cjs");
+const { getErrorMessage } = require("./error_helpers.cjs");
+const { ERR_VALIDATION } = require("./error_codes.cjs");
+const { lstatGuard } = require("./symlink_guard.cjs");
+
+/**
+ * Staging directory holding the encrypted results downloaded from the agent job.
+ * Note: Computed once at module load time. RUNNER_TEMP must be set before
+ * this module is required/evaluated.
+ */
+const STAGING_DIR = path.join(process.env.RUNNER_TEMP || "/tmp", "gh-aw", "safeoutputs", "create-artifacts");
+
+/** Extra bytes allowed on top of max-size-bytes for the GPG envelope. */
+const ENCRYPTION_OVERHEAD_BYTES = 65536;
+
+/** Artifact names accepted from the model; matches the MCP tool's sanitised names. */
+const ARTIFACT_NAME_PATTERN = /^[a-zA-Z0-9._-]{1,128}$/;
+
+/**
+ * Create or return the internal DefaultArtifactClient.
+ * global.__createArtifactClient can be set in tests to inject a mock client factory.
+ * @returns {Promise<{ uploadArtifact: (name: string, files: string[], rootDir: string, opts: object) => Promise<{id?: number, size?: number}> }>}
+ */
+async function getArtifactClient() {
+  if (typeof global.__createArtifactClient === "function") {
+    return global.__createArtifactClient();
+  }
+  return new DefaultArtifactClient();
+}
+
+/**
+ * Returns a per-message handler function that processes a single create_artifact request.
+ *
+ * @param {Object} config - Handler configuration from the safe outputs config
+ * @returns {Promise<Function>} Per-message handler function
+ */
+async function main(config = {}) {
+  const maxCount = typeof config.max === "number" ? config.max : 1;
+  const retentionDays = typeof config["retention-days"] === "number" ? config["retention-days"] : 30;
+  const maxSizeBytes = typeof config["max-size-bytes"] === "number" ? config["max-size-bytes"] : 10485760;
+  const isStaged = isStagedMode(config);
+
+  core.info(`create_artifact handler: max=${maxCount}, retention_days=${retentionDays}, max_size_bytes=${maxSizeBytes}`);
+
+  /** @type {string[]} */
+  const createdNames = [];
+
+  /**
+   * Per-message handler: uploads one encrypted create_artifact result.
+   *
+   * @param {Object} message - The create_artifact message from the model
+   * @returns {Promise<{success: boolean, error?: string, artifactName?: string, artifactId?: number, artifactUrl?: string}>}
+   */
+  return async function handleCreateArtifact(message) {
+    if (createdNames.length >= maxCount) {
+      return {
+        success: false,
+        error: `${ERR_VALIDATION}: create_artifact: exceeded max policy (${maxCount}). Reduce the number of create_artifact calls or raise max in workflow configuration.`,
+      };
+    }
+
+    const artifactName = typeof message?.name === "string" ? message.name : "";
+    if (!ARTIFACT_NAME_PATTERN.test(artifactName) || artifactName === "." || artifactName === "..") {
+      return { success: false, error: `${ERR_VALIDATION}: create_artifact: invalid artifact name "${artifactName}"` };
+    }
+    if (createdNames.includes(artifactName)) {
+      return { success: false, error: `${ERR_VALIDATION}: create_artifact: duplicate artifact name "${artifactName}"` };
+    }
+
+    const encryptedFile = path.join(STAGING_DIR, `${artifactName}.gpg`);
+    if (!fs.existsSync(encryptedFile)) {
+      return {
+        success: false,
+        error: `${ERR_VALIDATION}: create_artifact: encrypted file ${artifactName}.gpg not found. Check the "Encrypt create-artifact files" step in the agent job.`,
+      };
+    }
+    const stat = lstatGuard(encryptedFile);
+    if (stat === null || !stat.isFile()) {
+      return { success: false, error: `${ERR_VALIDATION}: create_artifact: ${artifactName}.gpg is not a regular file` };
+    }
+    if (stat.size > maxSizeBytes + ENCRYPTION_OVERHEAD_BYTES) {
+      return {
+        success: false,
+        error: `${ERR_VALIDATION}: create_artifact: encrypted file size ${stat.size} bytes exceeds max-size-bytes limit of ${maxSizeBytes} bytes.`,
+      };
+    }
+
+    /** @type {number|undefined} */
+    let artifactId;
+    let artifactUrl = "";
+
+    if (!isStaged) {
+      const client = await getArtifactClient();
+      try {
+        const uploadResult = await client.uploadArtifact(artifactName, [encryptedFile], STAGING_DIR, { retentionDays });
+        artifactId = uploadResult.id;
+        core.info(`Uploaded encrypted artifact "${artifactName}" (id=${artifactId ?? "n/a"}, size=${uploadResult.size ?? stat.size}B)`);
+
+        if (artifactId) {
+          const serverUrl = process.env.GITHUB_SERVER_URL || "https://github.com";
+          const repository = process.env.GITHUB_REPOSITORY || "";
+          const runId = process.env.GITHUB_RUN_ID || "";
+          if (repository && runId) {
+            artifactUrl = new URL(`/${repository}/actions/runs/${runId}/artifacts/${artifactId}`, serverUrl).toString();
+          }
+        }
+      } catch (err) {
+        return {
+          success: false,
+          error: `${ERR_VALIDATION}: create_artifact: failed to upload artifact "${artifactName}": ${getErrorMessage(err)}`,
+        };
+      }
+    } else {
+      let summaryContent = "## 🎭 Staged Mode: Create Artifact Preview\n\n";
+      summaryContent += "The following encrypted artifact would be uploaded if staged mode was disabled:\n\n";
+      summaryContent += `**Artifact Name:** ${artifactName}\n\n`;
+      summaryContent += `**Encrypted Size:** ${stat.size} bytes\n\n`;
+      summaryContent += `**Retention:** ${retentionDays} days\n\n`;
+      summaryContent += `> ℹ️ Upload skipped (staged mode active)\n\n`;
+
+      await core.summary.addRaw(summaryContent).write();
+      core.info("📝 Create artifact preview written to step summary");
+    }
+
+    createdNames.push(artifactName);
+    core.setOutput("create_artifact_count", String(createdNames.length));
+    core.setOutput("create_artifact_names", createdNames.join(","));
+
+    return {
+      success: true,
+      artifactName,
+      artifactId,
+      artifactUrl,
+    };
+  };
+}
+
+module.exports = { main };
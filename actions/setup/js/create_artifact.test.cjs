@@ -0,0 +1,119 @@
+// @ts-check
+import { describe, it, expect, beforeEach, afterEach, vi } from "vitest";
+import fs from "fs";
+import path from "path";
+import { fileURLToPath } from "url";
+
+const __filename = fileURLToPath(import.meta.url);
+const __dirname = path.dirname(__filename);
+
+// Matches what create_artifact.cjs computes when RUNNER_TEMP is unset.
+const STAGING_DIR = "/tmp/gh-aw/safeoutputs/create-artifacts";
+
+describe("create_artifact.cjs", () => {
+  let mockCore;
+  let mockArtifactClient;
+  let originalEnv;
+
+  /**
+   * @param {object} config
+   * @param {object[]} messages
+   * @returns {Promise<object[]>}
+   */
+  async function runHandler(config, messages) {
+    const scriptText = fs.readFileSync(path.join(__dirname, "create_artifact.cjs"), "utf8");
+    global.core = mockCore;
+    global.__createArtifactClient = () => mockArtifactClient;
+    let handlerFn;
+    await eval(`(async () => { ${scriptText}; handlerFn = await main(config); })()`);
+    const results = [];
+    for (const msg of messages) {
+      results.push(await handlerFn(msg, {}, new Map()));
+    }
+    return results;
+  }
+
+  beforeEach(() => {
+    vi.clearAllMocks();
+
+    mockCore = {
+      info: vi.fn(),
+      warning: vi.fn(),
+      setOutput: vi.fn(),
+      summary: {
+        addRaw: vi.fn().mockReturnThis(),
+        write: vi.fn().mockResolvedValue(undefined),
+      },
+    };
+    mockArtifactClient = {
+      uploadArtifact: vi.fn().mockResolvedValue({ id: 7, size: 64 }),
+    };
+
+    originalEnv = { ...process.env };
+    delete process.env.GH_AW_SAFE_OUTPUTS_STAGED;
+    delete process.env.RUNNER_TEMP;
+    process.env.GITHUB_REPOSITORY = "octo/repo";
+    process.env.GITHUB_RUN_ID = "123";
+
+    fs.rmSync(STAGING_DIR, { recursive: true, force: true });
+    fs.mkdirSync(STAGING_DIR, { recursive: true });
+  });
+
+  afterEach(() => {
+    process.env = originalEnv;
+    delete global.__createArtifactClient;
+    fs.rmSync(STAGING_DIR, { recursive: true, force: true });
+  });
+
+  it("uploads the encrypted file using the configured retention", async () => {
+    fs.writeFileSync(path.join(STAGING_DIR, "report.json.gpg"), "encrypted");
+
+    const results = await runHandler({ "retention-days": 7 }, [{ type: "create_artifact", name: "report.json" }]);
+
+    expect(results[0].success).toBe(true);
+    expect(results[0].artifactUrl).toBe("https://github.com/octo/repo/actions/runs/123/artifacts/7");
+    const [name, files, rootDir, opts] = mockArtifactClient.uploadArtifact.mock.calls[0];
+    expect(name).toBe("report.json");
+    expect(files).toEqual([path.join(STAGING_DIR, "report.json.gpg")]);
+    expect(rootDir).toBe(STAGING_DIR);
+    expect(opts.retentionDays).toBe(7);
+    expect(mockCore.setOutput).toHaveBeenCalledWith("create_artifact_count", "1");
+    expect(mockCore.setOutput).toHaveBeenCalledWith("create_artifact_names", "report.json");
+  });
+
+  it("never uploads plaintext when the encrypted file is missing", async () => {
+    fs.writeFileSync(path.join(STAGING_DIR, "report.json"), "plaintext");
+
+    const results = await runHandler({}, [{ type: "create_artifact", name: "report.json" }]);
+
+    expect(results[0].success).toBe(false);
+    expect(results[0].error).toContain("encrypted file report.json.gpg not found");
+    expect(mockArtifactClient.uploadArtifact).not.toHaveBeenCalled();
+  });
+
+  it("rejects invalid names and calls over the max", async () => {
+    fs.writeFileSync(path.join(STAGING_DIR, "a.gpg"), "encrypted");
+    fs.writeFileSync(path.join(STAGING_DIR, "b.gpg"), "encrypted");
+
+    const results = await runHandler({ max: 1 }, [
+      { type: "create_artifact", name: "../a" },
+      { type: "create_artifact", name: "a" },
+      { type: "create_artifact", name: "b" },
+    ]);
+
+    expect(results[0].error).toContain("invalid artifact name");
+    expect(results[1].success).toBe(true);
+    expect(results[2].error).toContain("exceeded max policy (1)");
+    expect(mockArtifactClient.uploadArtifact).toHaveBeenCalledOnce();
+  });
+
+  it("writes a preview instead of uploading in staged mode", async () => {
+    fs.writeFileSync(path.join(STAGING_DIR, "report.json.gpg"), "encrypted");
+
+    const results = await runHandler({ staged: true }, [{ type: "create_artifact", name: "report.json" }]);
+
+    expect(results[0].success).toBe(true);
+    expect(mockArtifactClient.uploadArtifact).not.toHaveBeenCalled();
+    expect(mockCore.summary.addRaw).toHaveBeenCalledWith(expect.stringContaining("Staged Mode: Create Artifact Preview"));
+  });
+});
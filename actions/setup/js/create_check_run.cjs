@@ -7,10 +7,11 @@
 
 const { getErrorMessage } = require("./error_helpers.cjs");
 const { logStagedPreviewInfo } = require("./staged_preview.cjs");
-const { isStagedMode, resolveTarget } = require("./safe_output_helpers.cjs");
+const { isStagedMode } = require("./safe_output_helpers.cjs");
 const { createAuthenticatedGitHubClient } = require("./handler_auth.cjs");
 const { withRetry, RATE_LIMIT_RETRY_CONFIG } = require("./error_recovery.cjs");
 const { sanitizeContent } = require("./sanitize_content.cjs");
+const { resolveTargetHeadSHA } = require("./commit_sha_helpers.cjs");
 
 /** @type {string} Safe output type handled by this module */
 const HANDLER_TYPE = "create_check_run";
@@ -24,6 +25,79 @@ const MAX_CONTENT_LENGTH = 65535;
 /** @type {number} Maximum length for the title field */
 const MAX_TITLE_LENGTH = 256;
 
+/** @type {Set<string>} Valid annotation levels for GitHub Check Run annotations */
+const VALID_ANNOTATION_LEVELS = new Set(["notice", "warning", "failure"]);
+
+/** @type {number} Maximum annotations accepted by a single Checks API request */
+const ANNOTATIONS_PER_REQUEST = 50;
+
+/** @type {number} Maximum annotations accepted per check run (bounds the number of API requests) */
+const MAX_ANNOTATIONS = 500;
+
+/** @type {number} Maximum length for an annotation title */
+const MAX_ANNOTATION_TITLE_LENGTH = 255;
+
+/** @type {number} Maximum length for an annotation message */
+const MAX_ANNOTATION_MESSAGE_LENGTH = 64 * 1024;
+
+/**
+ * Validates and normalizes agent-supplied check run annotations into the Checks API shape.
+ * Invalid annotations are dropped with a warning rather than failing the whole check run.
+ * @param {any} rawAnnotations - The annotations field from the agent message
+ * @returns {{annotations: Array<Object>, warnings: string[]}}
+ */
+function normalizeAnnotations(rawAnnotations) {
+  /** @type {Array<Object>} */
+  const annotations = [];
+  /** @type {string[]} */
+  const warnings = [];
+  if (rawAnnotations === undefined || rawAnnotations === null) {
+    return { annotations, warnings };
+  }
+  if (!Array.isArray(rawAnnotations)) {
+    warnings.push("annotations must be an array; ignoring");
+    return { annotations, warnings };
+  }
+
+  rawAnnotations.forEach((raw, index) => {
+    const path = typeof raw?.path === "string" ? raw.path.trim().replace(/^\.\//, "") : "";
+    const startLine = Number(raw?.start_line);
+    const endLine = raw?.end_line != null ? Number(raw.end_line) : startLine;
+    const message = typeof raw?.message === "string" ? sanitizeContent(raw.message.trim(), MAX_ANNOTATION_MESSAGE_LENGTH) : "";
+    const level = raw?.annotation_level || "warning";
+
+    if (!path || path.startsWith("/") || path.split("/").includes("..")) {
+      warnings.push(`annotation ${index}: 'path' must be a repository-relative file path; skipping`);
+      return;
+    }
+    if (!Number.isInteger(startLine) || startLine < 1 || !Number.isInteger(endLine) || endLine < startLine) {
+      warnings.push(`annotation ${index}: 'start_line' must be a positive integer and 'end_line' must not precede it; skipping`);
+      return;
+    }
+    if (!message) {
+      warnings.push(`annotation ${index}: 'message' is required; skipping`);
+      return;
+    }
+    if (!VALID_ANNOTATION_LEVELS.has(level)) {
+      warnings.push(`annotation ${index}: invalid annotation_level '${level}'. Must be one of: ${[...VALID_ANNOTATION_LEVELS].join(", ")}; skipping`);
+      return;
+    }
+
+    /** @type {Record<string, any>} */
+    const annotation = { path, start_line: startLine, end_line: endLine, annotation_level: level, message };
+    if (typeof raw.title === "string" && raw.title.trim()) {
+      annotation.title = sanitizeContent(raw.title.trim(), MAX_ANNOTATION_TITLE_LENGTH);
+    }
+    annotations.push(annotation);
+  });
+
+  if (annotations.length > MAX_ANNOTATIONS) {
+    warnings.push(`${annotations.length} annotations exceed the limit of ${MAX_ANNOTATIONS}; keeping the first ${MAX_ANNOTATIONS}`);
+    annotations.length = MAX_ANNOTATIONS;
+  }
+  return { annotations, warnings };
+}
+
 /**
  * Main handler factory for create_check_run
  * Returns a message handler function that processes individual create_check_run messages
@@ -112,73 +186,22 @@ async function main(config = {}) {
 
     const owner = context.repo.owner;
     const repo = context.repo.repo;
-    let headSha = "";
-    /** @type {any} */
-    let resolvedPrNumber = null;
-
-    if (checkRunTarget) {
-      const targetResult = resolveTarget({
-        targetConfig: checkRunTarget,
-        item: message,
-        context,
-        itemType: HANDLER_TYPE,
-        supportsPR: false,
-        supportsIssue: false,
-      });
-      if (!targetResult.success) {
-        if (targetResult.shouldFail) {
-          core.error(targetResult.error);
-        } else {
-          core.info(targetResult.error);
-        }
-        return {
-          success: false,
-          error: targetResult.error,
-          skipped: !targetResult.shouldFail,
-        };
-      }
+    const shaResult = await resolveTargetHeadSHA({
+      githubClient,
+      targetConfig: checkRunTarget,
+      message,
+      handlerType: HANDLER_TYPE,
+      isStaged,
+    });
+    if (!shaResult.success) {
+      return { success: false, error: shaResult.error, ...(shaResult.skipped ? { skipped: true } : {}) };
+    }
+    const headSha = shaResult.headSha;
+    const resolvedPrNumber = shaResult.prNumber;
 
-      resolvedPrNumber = targetResult.number;
-
-      // Fetch the current PR head SHA via the API. We intentionally go through the API
-      // even when the context payload already carries a SHA (e.g. target: "triggering" on
-      // a pull_request event) so that we always use the most recent head in case the PR
-      // was force-pushed between the triggering event and when this handler runs.
-      // Skipped in staged mode — there is nothing to attach a real check run to.
-      if (!isStaged) {
-        try {
-          const { data: pullRequest } = await withRetry(
-            () =>
-              githubClient.rest.pulls.get({
-                owner,
-                repo,
-                pull_number: resolvedPrNumber,
-              }),
-            RATE_LIMIT_RETRY_CONFIG
-          );
-          headSha = pullRequest?.head?.sha || "";
-          if (!headSha) {
-            const msg = `create_check_run: pull request #${resolvedPrNumber} has no head SHA`;
-            core.error(msg);
-            return { success: false, error: msg };
-          }
-          core.info(`Using PR #${resolvedPrNumber} head SHA ${headSha} (target=${checkRunTarget})`);
-        } catch (error) {
-          const errorMessage = getErrorMessage(error);
-          const msg = `Failed to resolve pull request for create_check_run: ${errorMessage}`;
-          core.error(msg);
-          return { success: false, error: msg };
-        }
-      }
-    } else {
-      // For pull_request events, GITHUB_SHA is the ephemeral merge commit SHA which is
-      // not visible in the PR checks UI or the GitHub mobile app. Use the actual PR head
-      // SHA from the event payload instead so the check run appears on the PR.
-      const prHeadSha = context.payload?.pull_request?.head?.sha;
-      headSha = prHeadSha || process.env.GITHUB_SHA || context.sha;
-      if (prHeadSha) {
-        core.info(`Using PR head SHA ${prHeadSha} (pull_request event)`);
-      }
+    const { annotations, warnings: annotationWarnings } = normalizeAnnotations(message.annotations);
+    for (const warning of annotationWarnings) {
+      core.warning(`create_check_run: ${warning}`);
     }
 
     // In staged mode, preview without making live API calls to create the actual check run.
@@ -214,6 +237,10 @@ async function main(config = {}) {
         summary: resolvedSummary,
         ...(resolvedText ? { text: resolvedText } : {}),
       };
+      const annotationBatches = [];
+      for (let i = 0; i < annotations.length; i += ANNOTATIONS_PER_REQUEST) {
+        annotationBatches.push(annotations.slice(i, i + ANNOTATIONS_PER_REQUEST));
+      }
 
       const response = await withRetry(
         () =>
@@ -225,7 +252,7 @@ async function main(config = {}) {
             status: "completed",
             conclusion,
             completed_at: new Date().toISOString(),
-            output,
+            output: annotationBatches.length > 0 ? { ...output, annotations: annotationBatches[0] } : output,
           }),
         RATE_LIMIT_RETRY_CONFIG
       );
@@ -233,6 +260,24 @@ async function main(config = {}) {
       const checkRunId = response.data.id;
       const checkRunUrl = response.data.html_url;
 
+      // The Checks API accepts at most 50 annotations per request; remaining batches
+      // are appended to the same check run with update calls.
+      for (const batch of annotationBatches.slice(1)) {
+        await withRetry(
+          () =>
+            githubClient.rest.checks.update({
+              owner,
+              repo,
+              check_run_id: checkRunId,
+              output: { title: resolvedTitle, summary: resolvedSummary, annotations: batch },
+            }),
+          RATE_LIMIT_RETRY_CONFIG
+        );
+      }
+      if (annotations.length > 0) {
+        core.info(`Attached ${annotations.length} annotation(s) to check run #${checkRunId}`);
+      }
+
       core.info(`✓ Created check run "${checkRunName}" #${checkRunId}: ${checkRunUrl}`);
       processedCount++;
 
@@ -240,6 +285,7 @@ async function main(config = {}) {
         success: true,
         check_run_id: checkRunId,
         check_run_url: checkRunUrl,
+        annotations: annotations.length,
         conclusion,
         name: checkRunName,
       };
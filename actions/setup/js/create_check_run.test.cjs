@@ -659,4 +659,84 @@ describe("create_check_run", () => {
       expect(result.error).toContain("title");
     });
   });
+
+  describe("annotations", () => {
+    beforeEach(() => {
+      process.env.GITHUB_SHA = "sha-abc123";
+    });
+
+    it("attaches valid annotations and drops invalid ones with a warning", async () => {
+      let capturedParams;
+      mockGithub.rest.checks.create = makeChecksCreate(p => {
+        capturedParams = p;
+      });
+      const warnings = [];
+      mockCore.warning = msg => warnings.push(msg);
+
+      const { main } = require("./create_check_run.cjs");
+      const handler = await main({ max: 10 });
+      const result = await handler(
+        {
+          type: "create_check_run",
+          conclusion: "failure",
+          title: "2 issues found",
+          summary: "See annotations.",
+          annotations: [
+            { path: "./src/index.js", start_line: 10, message: "Unused variable", title: "Lint" },
+            { path: "src/app.js", start_line: 3, end_line: 5, annotation_level: "failure", message: "Null dereference" },
+            { path: "../etc/passwd", start_line: 1, message: "Outside the repository" },
+            { path: "src/app.js", start_line: 0, message: "Invalid line" },
+          ],
+        },
+        {}
+      );
+
+      expect(result.success).toBe(true);
+      expect(result.annotations).toBe(2);
+      expect(capturedParams.output.annotations).toEqual([
+        { path: "src/index.js", start_line: 10, end_line: 10, annotation_level: "warning", message: "Unused variable", title: "Lint" },
+        { path: "src/app.js", start_line: 3, end_line: 5, annotation_level: "failure", message: "Null dereference" },
+      ]);
+      expect(warnings.filter(w => w.includes("skipping"))).toHaveLength(2);
+    });
+
+    it("sends annotations beyond the first 50 with checks.update", async () => {
+      let capturedParams;
+      mockGithub.rest.checks.create = makeChecksCreate(p => {
+        capturedParams = p;
+      });
+      const updateCalls = [];
+      mockGithub.rest.checks.update = async params => {
+        updateCalls.push(params);
+        return { data: {} };
+      };
+
+      const annotations = Array.from({ length: 120 }, (_, i) => ({ path: "src/a.js", start_line: i + 1, message: `Finding ${i + 1}` }));
+
+      const { main } = require("./create_check_run.cjs");
+      const handler = await main({ max: 10 });
+      const result = await handler({ type: "create_check_run", conclusion: "neutral", title: "Findings", summary: "Many findings.", annotations }, {});
+
+      expect(result.success).toBe(true);
+      expect(capturedParams.output.annotations).toHaveLength(50);
+      expect(updateCalls).toHaveLength(2);
+      expect(updateCalls[0].check_run_id).toBe(77313480284);
+      expect(updateCalls[0].output.annotations).toHaveLength(50);
+      expect(updateCalls[1].output.annotations).toHaveLength(20);
+      expect(updateCalls[1].output.title).toBe("Findings");
+    });
+
+    it("omits annotations from the request when none are provided", async () => {
+      let capturedParams;
+      mockGithub.rest.checks.create = makeChecksCreate(p => {
+        capturedParams = p;
+      });
+
+      const { main } = require("./create_check_run.cjs");
+      const handler = await main({ max: 10 });
+      await handler({ type: "create_check_run", conclusion: "success", title: "OK", summary: "Nothing found." }, {});
+
+      expect(capturedParams.output.annotations).toBeUndefined();
+    });
+  });
 });
@@ -0,0 +1,167 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+/**
+ * @typedef {import('./types/handler-factory').HandlerFactoryFunction} HandlerFactoryFunction
+ */
+
+const { getErrorMessage } = require("./error_helpers.cjs");
+const { logStagedPreviewInfo } = require("./staged_preview.cjs");
+const { isStagedMode } = require("./safe_output_helpers.cjs");
+const { createAuthenticatedGitHubClient } = require("./handler_auth.cjs");
+const { withRetry, RATE_LIMIT_RETRY_CONFIG } = require("./error_recovery.cjs");
+const { sanitizeContent } = require("./sanitize_content.cjs");
+const { resolveTargetHeadSHA } = require("./commit_sha_helpers.cjs");
+
+/** @type {string} Safe output type handled by this module */
+const HANDLER_TYPE = "create_commit_status";
+
+/** @type {Set<string>} Valid commit status states */
+const VALID_STATES = new Set(["success", "failure", "error", "pending"]);
+
+/** @type {number} Maximum length for the status description (GitHub API limit) */
+const MAX_DESCRIPTION_LENGTH = 140;
+
+/**
+ * Main handler factory for create_commit_status
+ * Returns a message handler function that processes individual create_commit_status messages
+ * @type {HandlerFactoryFunction}
+ */
+async function main(config = {}) {
+  // Extract configuration
+  const maxCount = config.max != null ? Number(config.max) : 1;
+  const statusTarget = typeof config.target === "string" && config.target.trim() ? config.target.trim() : null;
+  const githubClient = await createAuthenticatedGitHubClient(config);
+  const isStaged = isStagedMode(config);
+
+  // Resolve the status context: config > workflow name env var > fallback.
+  // The context identifies the status on the commit; a later status with the same
+  // context replaces the earlier one.
+  const statusContext = config.context || process.env.GITHUB_WORKFLOW || "agent";
+
+  // The status links back to the workflow run so reviewers can inspect the agent's reasoning.
+  const serverUrl = process.env.GITHUB_SERVER_URL || "https://github.com";
+  const runUrl = context.runId ? `${serverUrl}/${context.repo.owner}/${context.repo.repo}/actions/runs/${context.runId}` : "";
+
+  core.info(`Create commit status configuration: context="${statusContext}", max=${maxCount}${statusTarget ? `, target=${statusTarget}` : ""}`);
+
+  // Track how many statuses we've created for max limit enforcement
+  let processedCount = 0;
+
+  /**
+   * Message handler function that processes a single create_commit_status message
+   * @param {Object} message - The create_commit_status message to process
+   * @param {Object} _resolvedTemporaryIds - Map of temporary IDs (unused for commit statuses)
+   * @returns {Promise<Object>} Result with success/error status
+   */
+  return async function handleCreateCommitStatus(message, _resolvedTemporaryIds) {
+    // Check if we've hit the max limit
+    if (processedCount >= maxCount) {
+      core.warning(`Skipping create_commit_status: max count of ${maxCount} reached`);
+      return {
+        success: false,
+        error: `Max count of ${maxCount} reached`,
+      };
+    }
+
+    // Validate required fields
+    const state = message.state;
+    if (!state) {
+      const msg = "create_commit_status requires a 'state' field";
+      core.error(msg);
+      return { success: false, error: msg };
+    }
+    if (!VALID_STATES.has(state)) {
+      const msg = `create_commit_status: invalid state '${state}'. Must be one of: ${[...VALID_STATES].join(", ")}`;
+      core.error(msg);
+      return { success: false, error: msg };
+    }
+
+    // sanitizeContent appends a truncation marker, so shorten the sanitized text directly
+    // to stay within the API limit.
+    const rawDescription = (message.description || "").trim();
+    let description = rawDescription ? sanitizeContent(rawDescription).replace(/\s+/g, " ").trim() : "";
+    if (description.length > MAX_DESCRIPTION_LENGTH) {
+      description = `${description.slice(0, MAX_DESCRIPTION_LENGTH - 1).trimEnd()}…`;
+    }
+    if (!description) {
+      const msg = "create_commit_status requires a non-empty 'description' field";
+      core.error(msg);
+      return { success: false, error: msg };
+    }
+
+    const owner = context.repo.owner;
+    const repo = context.repo.repo;
+    const shaResult = await resolveTargetHeadSHA({
+      githubClient,
+      targetConfig: statusTarget,
+      message,
+      handlerType: HANDLER_TYPE,
+      isStaged,
+    });
+    if (!shaResult.success) {
+      return { success: false, error: shaResult.error, ...(shaResult.skipped ? { skipped: true } : {}) };
+    }
+    const headSha = shaResult.headSha;
+
+    // In staged mode, preview without making live API calls
+    if (isStaged) {
+      const prSuffix = shaResult.prNumber != null ? ` targeting PR #${shaResult.prNumber}` : "";
+      logStagedPreviewInfo(`Would set commit status "${statusContext}"${prSuffix} to state=${state}, description="${description}"`);
+      processedCount++;
+      return {
+        success: true,
+        staged: true,
+        previewInfo: {
+          context: statusContext,
+          state,
+          description,
+        },
+      };
+    }
+
+    if (!headSha) {
+      const msg = "create_commit_status: cannot determine commit SHA for commit status";
+      core.error(msg);
+      return { success: false, error: msg };
+    }
+
+    core.info(`Setting commit status "${statusContext}" on ${owner}/${repo}@${headSha} to state=${state}`);
+
+    try {
+      const response = await withRetry(
+        () =>
+          githubClient.rest.repos.createCommitStatus({
+            owner,
+            repo,
+            sha: headSha,
+            state,
+            context: statusContext,
+            description,
+            ...(runUrl ? { target_url: runUrl } : {}),
+          }),
+        RATE_LIMIT_RETRY_CONFIG
+      );
+
+      core.info(`✓ Set commit status "${statusContext}" to ${state} on ${headSha}`);
+      processedCount++;
+
+      return {
+        success: true,
+        status_id: response.data.id,
+        sha: headSha,
+        state,
+        context: statusContext,
+      };
+    } catch (error) {
+      const errorMessage = getErrorMessage(error);
+      core.error(`Failed to set commit status "${statusContext}": ${errorMessage}`);
+      return {
+        success: false,
+        error: errorMessage,
+      };
+    }
+  };
+}
+
+module.exports = { main };
@@ -0,0 +1,187 @@
+// @ts-check
+import { describe, it, expect, beforeEach, afterEach } from "vitest";
+
+describe("create_commit_status", () => {
+  let mockCore;
+  let mockGithub;
+  let mockContext;
+  let originalGlobals;
+  let originalEnv;
+  let statusCalls;
+
+  beforeEach(() => {
+    originalGlobals = {
+      core: global.core,
+      github: global.github,
+      context: global.context,
+      getOctokit: global.getOctokit,
+    };
+    originalEnv = { ...process.env };
+    statusCalls = [];
+
+    mockCore = {
+      debug: () => {},
+      info: () => {},
+      warning: () => {},
+      error: () => {},
+      setOutput: () => {},
+      setFailed: () => {},
+    };
+
+    mockGithub = {
+      rest: {
+        repos: {
+          createCommitStatus: async params => {
+            statusCalls.push(params);
+            return { data: { id: 4242 } };
+          },
+        },
+        pulls: {
+          get: async ({ pull_number }) => ({
+            data: { number: pull_number, head: { sha: `pr-head-sha-${pull_number}` } },
+          }),
+        },
+      },
+    };
+
+    mockContext = {
+      eventName: "push",
+      runId: 12345,
+      repo: {
+        owner: "test-owner",
+        repo: "test-repo",
+      },
+      sha: "abc123def456",
+      payload: {},
+    };
+
+    global.core = mockCore;
+    global.github = mockGithub;
+    global.context = mockContext;
+    global.getOctokit = () => mockGithub;
+
+    process.env.GITHUB_SHA = "push-sha-abc123";
+    delete process.env.GITHUB_WORKFLOW;
+    delete process.env.GITHUB_SERVER_URL;
+    delete process.env.GH_AW_SAFE_OUTPUTS_STAGED;
+  });
+
+  afterEach(() => {
+    global.core = originalGlobals.core;
+    global.github = originalGlobals.github;
+    global.context = originalGlobals.context;
+    global.getOctokit = originalGlobals.getOctokit;
+    Object.keys(process.env).forEach(k => {
+      if (!(k in originalEnv)) delete process.env[k];
+    });
+    Object.assign(process.env, originalEnv);
+  });
+
+  it("sets a status on the triggering commit linked to the workflow run", async () => {
+    const { main } = require("./create_commit_status.cjs");
+    const handler = await main({ context: "ai-review" });
+    const result = await handler({ type: "create_commit_status", state: "failure", description: "2 blocking issues found" }, {});
+
+    expect(result.success).toBe(true);
+    expect(result.status_id).toBe(4242);
+    expect(statusCalls).toHaveLength(1);
+    expect(statusCalls[0]).toEqual({
+      owner: "test-owner",
+      repo: "test-repo",
+      sha: "push-sha-abc123",
+      state: "failure",
+      context: "ai-review",
+      description: "2 blocking issues found",
+      target_url: "https://github.com/test-owner/test-repo/actions/runs/12345",
+    });
+  });
+
+  it("uses the PR head SHA on pull_request events", async () => {
+    mockContext.eventName = "pull_request";
+    mockContext.payload = { pull_request: { head: { sha: "pr-head-sha" } } };
+
+    const { main } = require("./create_commit_status.cjs");
+    const handler = await main({});
+    await handler({ type: "create_commit_status", state: "success", description: "Looks good" }, {});
+
+    expect(statusCalls[0].sha).toBe("pr-head-sha");
+  });
+
+  it("defaults the context to the workflow name", async () => {
+    process.env.GITHUB_WORKFLOW = "AI Review";
+
+    const { main } = require("./create_commit_status.cjs");
+    const handler = await main({});
+    await handler({ type: "create_commit_status", state: "success", description: "Looks good" }, {});
+
+    expect(statusCalls[0].context).toBe("AI Review");
+  });
+
+  it("resolves the pull request head when target is '*'", async () => {
+    const { main } = require("./create_commit_status.cjs");
+    const handler = await main({ target: "*" });
+    const result = await handler({ type: "create_commit_status", pull_request_number: 42, state: "success", description: "Looks good" }, {});
+
+    expect(result.success).toBe(true);
+    expect(statusCalls[0].sha).toBe("pr-head-sha-42");
+  });
+
+  it("returns an error when target is '*' and pull_request_number is missing", async () => {
+    const { main } = require("./create_commit_status.cjs");
+    const handler = await main({ target: "*" });
+    const result = await handler({ type: "create_commit_status", state: "success", description: "Looks good" }, {});
+
+    expect(result.success).toBe(false);
+    expect(result.error).toContain('Target is "*"');
+    expect(statusCalls).toHaveLength(0);
+  });
+
+  it("rejects an invalid state", async () => {
+    const { main } = require("./create_commit_status.cjs");
+    const handler = await main({});
+    const result = await handler({ type: "create_commit_status", state: "passed", description: "Looks good" }, {});
+
+    expect(result.success).toBe(false);
+    expect(result.error).toContain("invalid state");
+  });
+
+  it("requires a description", async () => {
+    const { main } = require("./create_commit_status.cjs");
+    const handler = await main({});
+    const result = await handler({ type: "create_commit_status", state: "success", description: "   " }, {});
+
+    expect(result.success).toBe(false);
+    expect(result.error).toContain("description");
+  });
+
+  it("truncates the description to the 140 character API limit", async () => {
+    const { main } = require("./create_commit_status.cjs");
+    const handler = await main({});
+    await handler({ type: "create_commit_status", state: "failure", description: "x".repeat(300) }, {});
+
+    expect(statusCalls[0].description).toHaveLength(140);
+    expect(statusCalls[0].description.endsWith("…")).toBe(true);
+  });
+
+  it("enforces the max count", async () => {
+    const { main } = require("./create_commit_status.cjs");
+    const handler = await main({ max: 1 });
+    await handler({ type: "create_commit_status", state: "pending", description: "Reviewing" }, {});
+    const result = await handler({ type: "create_commit_status", state: "success", description: "Done" }, {});
+
+    expect(result.success).toBe(false);
+    expect(result.error).toContain("Max count of 1 reached");
+    expect(statusCalls).toHaveLength(1);
+  });
+
+  it("returns a staged preview without calling the API", async () => {
+    const { main } = require("./create_commit_status.cjs");
+    const handler = await main({ context: "ai-review", staged: true });
+    const result = await handler({ type: "create_commit_status", state: "failure", description: "Issues found" }, {});
+
+    expect(result.success).toBe(true);
+    expect(result.staged).toBe(true);
+    expect(result.previewInfo).toEqual({ context: "ai-review", state: "failure", description: "Issues found" });
+    expect(statusCalls).toHaveLength(0);
+  });
+});
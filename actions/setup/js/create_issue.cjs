@@ -29,7 +29,10 @@ const { buildWorkflowRunUrl } = require("./workflow_metadata_helpers.cjs");
 const { MAX_LABELS, MAX_ASSIGNEES } = require("./constants.cjs");
 const { findAgent, getIssueDetails, assignAgentToIssue } = require("./assign_agent_helpers.cjs");
 const { parseDeduplicateByTitle, normalizeTitleForDedup, findDuplicateByTitle } = require("./issue_title_dedup.cjs");
+const { parseIssueDedupeConfig, generateDedupeFingerprintMarker, describeIssueDedupe, matchesIssueDedupe, findIssueDedupeMatch } = require("./issue_dedupe.cjs");
+const { parseIssueFormConfig, validateIssueFormValues, renderIssueFormBody } = require("./issue_form.cjs");
 const { resolveAllowedMentionsFromPayload } = require("./resolve_mentions_from_payload.cjs");
+const { createIssueConventions, NEXT_OPEN_MILESTONE } = require("./issue_conventions.cjs");
 const MS_PER_DAY = 24 * 60 * 60 * 1000;
 const ISSUE_FIELD_DATE_PATTERN = /^\d{4}-\d{2}-\d{2}$/;
 const RECENTLY_CLOSED_DEDUP_DAYS = 30;
@@ -518,10 +521,18 @@ async function shouldSkipRepoTitleDedupSearch(githubClient, owner, repo) {
  */
 async function main(config = {}) {
   // Extract configuration
-  const envLabels = config.labels ? (Array.isArray(config.labels) ? config.labels : config.labels.split(",")).map(label => String(label).trim()).filter(Boolean) : [];
+  let issueForm;
+  try {
+    issueForm = parseIssueFormConfig(config.form);
+  } catch (error) {
+    throw new Error(`${ERR_VALIDATION}: ${getErrorMessage(error)}`, { cause: error });
+  }
+  const configLabels = config.labels ? (Array.isArray(config.labels) ? config.labels : config.labels.split(",")).map(label => String(label).trim()).filter(Boolean) : [];
+  // Issue form labels are applied like GitHub applies them to form submissions
+  const envLabels = [...configLabels, ...(issueForm?.labels ?? [])].filter((label, index, arr) => arr.indexOf(label) === index);
   const allowedIssueFields = parseAllowedIssueFields(config.allowed_fields);
   const envAssignees = config.assignees ? (Array.isArray(config.assignees) ? config.assignees : config.assignees.split(",")).map(assignee => String(assignee).trim()).filter(Boolean) : [];
-  const titlePrefix = config.title_prefix ?? "";
+  const titlePrefix = config.title_prefix ?? issueForm?.title ?? "";
   const expiresHours = config.expires ? parseInt(String(config.expires), 10) : 0;
   const maxCount = config.max ?? 10;
   const { defaultTargetRepo, allowedRepos } = resolveTargetRepoConfig(config);
@@ -534,6 +545,12 @@ async function main(config = {}) {
   } catch (error) {
     throw new Error(`${ERR_VALIDATION}: ${getErrorMessage(error)}`, { cause: error });
   }
+  let dedupe;
+  try {
+    dedupe = parseIssueDedupeConfig(config.dedupe);
+  } catch (error) {
+    throw new Error(`${ERR_VALIDATION}: ${getErrorMessage(error)}`, { cause: error });
+  }
   const rawCloseOlderKey = config.close_older_key ? String(config.close_older_key) : "";
   const closeOlderKey = rawCloseOlderKey ? normalizeCloseOlderKey(rawCloseOlderKey) : "";
   if (rawCloseOlderKey && !closeOlderKey) {
@@ -551,6 +568,15 @@ async function main(config = {}) {
     allowedMentionAliases = await resolveAllowedMentionsFromPayload(context, githubClient, core, config.mentions);
   }
 
+  // Milestone and project conventions are applied to every issue regardless of agent output.
+  // Projects v2 are not accessible with GITHUB_TOKEN, so the project token is preferred.
+  const conventions = createIssueConventions({
+    milestone: config.milestone ? String(config.milestone).trim() : "",
+    project: config.project ? String(config.project).trim() : "",
+    githubClient,
+    projectClient: config.project && process.env.GH_AW_PROJECT_GITHUB_TOKEN ? global.getOctokit(process.env.GH_AW_PROJECT_GITHUB_TOKEN) : githubClient,
+  });
+
   // Check if copilot assignment is enabled
   const assignCopilot = process.env.GH_AW_ASSIGN_COPILOT === "true";
 
@@ -573,6 +599,12 @@ async function main(config = {}) {
   if (envAssignees.length > 0) {
     core.info(`Default assignees: ${envAssignees.join(", ")}`);
   }
+  if (conventions.milestone) {
+    core.info(`Milestone: ${conventions.milestone === NEXT_OPEN_MILESTONE ? "next open milestone" : conventions.milestone}`);
+  }
+  if (conventions.project) {
+    core.info(`Project: ${conventions.project}`);
+  }
   if (allowedIssueFields.length > 0 && !allowedIssueFields.includes("*")) {
     core.info(`Allowed issue fields: ${allowedIssueFields.join(", ")}`);
   }
@@ -602,6 +634,12 @@ async function main(config = {}) {
     const mode = deduplicateByTitle.maxDistance === 0 ? "exact title match" : `Levenshtein distance <= ${deduplicateByTitle.maxDistance}`;
     core.info(`Title deduplication enabled (${mode})`);
   }
+  if (issueForm) {
+    core.info(`Issue form: ${issueForm.file} (${issueForm.fields.length} field(s))`);
+  }
+  if (dedupe.enabled) {
+    core.info(`Cross-run deduplication enabled: matching open issues where ${describeIssueDedupe(dedupe)} receive a comment instead of a new issue`);
+  }
 
   // Track how many items we've processed for max limit
   let processedCount = 0;
@@ -616,6 +654,11 @@ async function main(config = {}) {
   const repoTitleDedupCandidatesCache = new Map();
   let skipRepoLevelSearch = false;
 
+  // Issues created in this run, checked before searching so dedupe also applies
+  // within a run (the search index can lag behind freshly created issues).
+  /** @type {Map<string, Array<{number: number, html_url: string, title: string, body: string, labels: string[]}>>} */
+  const dedupeCreatedIssuesByRepo = new Map();
+
   /**
    * @param {string} repo
    * @param {string} seenTitle
@@ -744,6 +787,16 @@ async function main(config = {}) {
       return { success: false, error: getErrorMessage(error) };
     }
 
+    let issueFormValues;
+    if (issueForm) {
+      try {
+        issueFormValues = validateIssueFormValues(issueForm, message.form);
+      } catch (error) {
+        core.warning(`Issue form validation failed: ${getErrorMessage(error)}`);
+        return { success: false, error: getErrorMessage(error) };
+      }
+    }
+
     // Check if copilot is in the assignees list
     const hasCopilot = assignees.includes("copilot");
 
@@ -772,6 +825,11 @@ async function main(config = {}) {
     // Remove duplicate title from description if it starts with a header matching the title
     processedBody = removeDuplicateTitleFromDescription(title, processedBody);
 
+    // Render the issue form sections so form automation can parse the issue
+    if (issueForm && issueFormValues) {
+      processedBody = replaceTemporaryIdReferences(renderIssueFormBody(issueForm, issueFormValues, processedBody), temporaryIdMap, qualifiedItemRepo);
+    }
+
     // Sanitize body content to neutralize @mentions, URLs, and other security risks
     processedBody = sanitizeContent(processedBody, { allowedAliases: allowedMentionAliases });
 
@@ -941,6 +999,10 @@ async function main(config = {}) {
     if (closeOlderKey) {
       bodyLines.push(generateCloseKeyMarker(closeOlderKey));
     }
+    // Add dedupe fingerprint marker so later runs can find this issue
+    if (dedupe.fingerprint) {
+      bodyLines.push(generateDedupeFingerprintMarker(dedupe.fingerprint));
+    }
 
     bodyLines.push("");
     const body = bodyLines.join("\n").trim();
@@ -957,6 +1019,42 @@ async function main(config = {}) {
     }
     processedCount++;
 
+    // Dedupe check: if enabled, look for an open issue matching the configured title
+    // pattern, labels and/or fingerprint. When found, post the new content as a comment
+    // on it instead of creating a duplicate, and release the reserved max-count slot.
+    if (dedupe.enabled) {
+      try {
+        const withinRunMatch = (dedupeCreatedIssuesByRepo.get(qualifiedItemRepo) || []).find(issue => matchesIssueDedupe(issue, dedupe));
+        const existingIssue = withinRunMatch || (await findIssueDedupeMatch(githubClient, repoParts.owner, repoParts.repo, dedupe));
+        if (existingIssue) {
+          processedCount--;
+          if (isStaged) {
+            logStagedPreviewInfo(`Would add comment to existing issue ${qualifiedItemRepo}#${existingIssue.number} instead of creating: ${title}`);
+            return {
+              success: true,
+              staged: true,
+              deduplicated: true,
+              existingIssueNumber: existingIssue.number,
+              existingIssueUrl: existingIssue.html_url,
+            };
+          }
+          core.info(`Dedupe: found open issue #${existingIssue.number} matching ${describeIssueDedupe(dedupe)} — posting new content as a comment`);
+          const comment = await addIssueComment(githubClient, repoParts.owner, repoParts.repo, existingIssue.number, body);
+          core.info(`Posted content as comment ${comment.html_url} on issue #${existingIssue.number}`);
+          return {
+            success: true,
+            deduplicated: true,
+            existingIssueNumber: existingIssue.number,
+            existingIssueUrl: existingIssue.html_url,
+            commentUrl: comment.html_url,
+          };
+        }
+      } catch (error) {
+        // Log but do not abort — fall through to normal creation
+        core.warning(`Dedupe pre-check failed: ${getErrorMessage(error)} — proceeding with issue creation`);
+      }
+    }
+
     // Group-by-day check: if enabled, search for an existing open issue created today.
     // When found, post the new content as a comment on the existing issue instead of
     // creating a duplicate. This groups multiple same-day runs into a single issue.
@@ -1024,12 +1122,19 @@ async function main(config = {}) {
           labels,
           assignees,
           fields: issueFields,
+          ...(conventions.milestone ? { milestone: conventions.milestone } : {}),
+          ...(conventions.project ? { project: conventions.project } : {}),
           bodyLength: body.length,
           temporaryId,
         },
       };
     }
 
+    const milestone = await conventions.resolveMilestone(repoParts.owner, repoParts.repo);
+    if (milestone) {
+      core.info(`Milestone: ${milestone.title} (#${milestone.number})`);
+    }
+
     try {
       const { data: issue } = await withRetry(
         () =>
@@ -1040,6 +1145,7 @@ async function main(config = {}) {
             body,
             labels,
             assignees,
+            ...(milestone ? { milestone: milestone.number } : {}),
           }),
         RATE_LIMIT_RETRY_CONFIG,
         `create_issue in ${qualifiedItemRepo}`
@@ -1050,6 +1156,11 @@ async function main(config = {}) {
       if (deduplicateByTitle.enabled) {
         recordSeenTitle(qualifiedItemRepo, title, normalizedTitle);
       }
+      if (dedupe.enabled) {
+        const createdForRepo = dedupeCreatedIssuesByRepo.get(qualifiedItemRepo) || [];
+        createdForRepo.push({ number: issue.number, html_url: issue.html_url, title, body, labels });
+        dedupeCreatedIssuesByRepo.set(qualifiedItemRepo, createdForRepo);
+      }
 
       if (issueFields.length > 0) {
         try {
@@ -1071,6 +1182,16 @@ async function main(config = {}) {
         }
       }
 
+      if (conventions.project) {
+        try {
+          const project = await conventions.addToProject(repoParts.owner, issue);
+          core.info(`Added ${qualifiedItemRepo}#${issue.number} to project ${project?.title} (#${project?.number})`);
+        } catch (error) {
+          // The issue exists; a project the token cannot reach should not fail the run
+          core.warning(`Failed to add ${qualifiedItemRepo}#${issue.number} to project "${conventions.project}": ${getErrorMessage(error)}`);
+        }
+      }
+
       // Store the mapping of temporary_id -> {repo, number}
       // temporaryId is guaranteed to be non-null because we checked tempIdResult.error above
       const normalizedTempId = normalizeTemporaryId(String(temporaryId));
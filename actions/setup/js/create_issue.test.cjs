@@ -956,6 +956,61 @@ describe("create_issue", () => {
     });
   });
 
+  describe("milestone and project conventions", () => {
+    it("should apply configured labels and the next open milestone regardless of agent output", async () => {
+      mockGithub.rest.issues.listMilestones = vi.fn();
+      mockGithub.paginate = vi.fn().mockResolvedValue([
+        { number: 4, title: "Backlog", state: "open", due_on: null },
+        { number: 7, title: "v2.1", state: "open", due_on: "2026-12-01T00:00:00Z" },
+        { number: 6, title: "v2.0", state: "open", due_on: "2026-11-01T00:00:00Z" },
+      ]);
+
+      const handler = await main({ labels: ["triage"], milestone: "next-open" });
+      const result = await handler({ title: "Test Issue", body: "Test body content", labels: ["bug"] });
+
+      expect(result.success).toBe(true);
+      expect(mockGithub.paginate).toHaveBeenCalledWith(mockGithub.rest.issues.listMilestones, expect.objectContaining({ owner: "test-owner", repo: "test-repo", state: "open" }));
+      expect(mockGithub.rest.issues.create).toHaveBeenCalledWith(expect.objectContaining({ labels: ["triage", "bug"], milestone: 6 }));
+    });
+
+    it("should create the issue without a milestone when it is not found", async () => {
+      mockGithub.rest.issues.listMilestones = vi.fn();
+      mockGithub.paginate = vi.fn().mockResolvedValue([]);
+
+      const handler = await main({ milestone: "v9" });
+      const result = await handler({ title: "Test Issue", body: "Test body content" });
+
+      expect(result.success).toBe(true);
+      expect(mockGithub.rest.issues.create.mock.calls[0][0]).not.toHaveProperty("milestone");
+      expect(mockCore.warning).toHaveBeenCalledWith(expect.stringContaining('Milestone "v9" not found'));
+    });
+
+    it("should add the created issue to the configured project", async () => {
+      mockGithub.rest.issues.create.mockResolvedValue({ data: { number: 123, node_id: "I_123", html_url: "https://github.com/test-owner/test-repo/issues/123" } });
+      mockGithub.graphql
+        .mockResolvedValueOnce({ repositoryOwner: { projectsV2: { nodes: [{ id: "PVT_1", number: 5, title: "Roadmap", closed: false }] } } })
+        .mockResolvedValueOnce({ addProjectV2ItemById: { item: { id: "PVTI_1" } } });
+
+      const handler = await main({ project: "Roadmap" });
+      const result = await handler({ title: "Test Issue", body: "Test body content" });
+
+      expect(result.success).toBe(true);
+      expect(mockGithub.graphql).toHaveBeenNthCalledWith(1, expect.stringContaining("projectsV2"), expect.objectContaining({ login: "test-owner", byNumber: false, query: "Roadmap" }));
+      expect(mockGithub.graphql).toHaveBeenNthCalledWith(2, expect.stringContaining("addProjectV2ItemById"), { projectId: "PVT_1", contentId: "I_123" });
+    });
+
+    it("should keep the created issue when adding it to the project fails", async () => {
+      mockGithub.rest.issues.create.mockResolvedValue({ data: { number: 123, node_id: "I_123", html_url: "https://github.com/test-owner/test-repo/issues/123" } });
+      mockGithub.graphql.mockRejectedValue(new Error("Resource not accessible by integration"));
+
+      const handler = await main({ project: "Roadmap" });
+      const result = await handler({ title: "Test Issue", body: "Test body content" });
+
+      expect(result.success).toBe(true);
+      expect(mockCore.warning).toHaveBeenCalledWith(expect.stringContaining('to project "Roadmap"'));
+    });
+  });
+
   describe("group-by-day mode", () => {
     it("should post new content as a comment if an open issue was already created today", async () => {
       const today = new Date().toISOString().split("T")[0];
@@ -12,6 +12,7 @@ const { createAuthenticatedGitHubClient } = require("./handler_auth.cjs");
 const { buildWorkflowRunUrl } = require("./workflow_metadata_helpers.cjs");
 const { isTemplatableTrue, isStagedMode, logStagedPreviewInfo, checkRequiredFilter } = require("./safe_output_helpers.cjs");
 const { resolveAllowedMentionsFromPayload } = require("./resolve_mentions_from_payload.cjs");
+const { buildSuggestionBlock, containsSuggestionBlock, createDiffLinesCache, validateSuggestionTarget } = require("./pr_review_suggestions.cjs");
 
 /** @type {string} Safe output type handled by this module */
 const HANDLER_TYPE = "create_pull_request_review_comment";
@@ -104,6 +105,9 @@ async function main(config = {}) {
     registry.setDefaultFooterContext(footerCtx);
   }
 
+  // Diff lines are fetched at most once per PR to validate suggestion targets
+  const getDiffLines = createDiffLinesCache(githubClient);
+
   // Track how many items we've processed for max limit
   let processedCount = 0;
 
@@ -304,6 +308,34 @@ async function main(config = {}) {
       };
     }
 
+    if (commentItem.suggestion !== undefined && typeof commentItem.suggestion !== "string") {
+      core.warning('Invalid field "suggestion" in review comment item (must be a string)');
+      return {
+        success: false,
+        error: 'Invalid field "suggestion" (must be a string)',
+      };
+    }
+
+    // Suggestions are validated against the PR diff so "Apply suggestion" works on the target lines
+    let body = commentItem.body.trim();
+    const hasSuggestion = typeof commentItem.suggestion === "string" || containsSuggestionBlock(body);
+    if (hasSuggestion) {
+      const diffFiles = await getDiffLines(itemRepo, repoParts, pullRequestNumber);
+      if (diffFiles) {
+        const suggestionError = validateSuggestionTarget(diffFiles, { path: commentItem.path, line, startLine, side });
+        if (suggestionError) {
+          core.warning(`Skipping review comment with suggestion: ${suggestionError}`);
+          return {
+            success: false,
+            error: suggestionError,
+          };
+        }
+      }
+      if (typeof commentItem.suggestion === "string") {
+        body = `${body}\n\n${buildSuggestionBlock(commentItem.suggestion)}`;
+      }
+    }
+
     // Obtain the buffer for this PR.
     // In registry mode: get or create a per-PR buffer (no cross-PR check needed).
     // In legacy mode: use the single shared buffer with cross-PR rejection.
@@ -350,7 +382,7 @@ async function main(config = {}) {
     const bufferedComment = {
       path: commentItem.path,
       line: line,
-      body: sanitizeContent(commentItem.body.trim(), { allowedAliases: allowedMentionAliases }),
+      body: sanitizeContent(body, { allowedAliases: allowedMentionAliases }),
       side: side,
     };
 
@@ -360,7 +392,7 @@ async function main(config = {}) {
 
     buffer.addComment(bufferedComment);
 
-    core.info(`Buffered review comment on PR #${pullRequestNumber} in ${itemRepo} at ${commentItem.path}:${line}${startLine ? ` (lines ${startLine}-${line})` : ""} [${side}]`);
+    core.info(`Buffered review comment on PR #${pullRequestNumber} in ${itemRepo} at ${commentItem.path}:${line}${startLine ? ` (lines ${startLine}-${line})` : ""} [${side}]${hasSuggestion ? " with suggestion" : ""}`);
 
     return {
       success: true,
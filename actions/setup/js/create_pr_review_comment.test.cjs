@@ -38,6 +38,7 @@ const mockGithub = {
     pulls: {
       createReviewComment: vi.fn(),
       get: vi.fn(),
+      listFiles: vi.fn(),
     },
   },
 };
@@ -305,6 +306,44 @@ describe("create_pr_review_comment.cjs", () => {
     expect(buffer.getBufferedCount()).toBe(0);
   });
 
+  it("should append a suggestion block when the target lines are in the diff", async () => {
+    mockGithub.rest.pulls.listFiles.mockResolvedValue({
+      data: [{ filename: "src/main.js", patch: "@@ -9,2 +9,2 @@\n context\n-let x = 1;\n+let x = 2;" }],
+    });
+    const addCommentSpy = vi.spyOn(buffer, "addComment");
+    const handler = await createHandler();
+    const message = {
+      type: "create_pull_request_review_comment",
+      path: "src/main.js",
+      line: 10,
+      body: "Use const here.",
+      suggestion: "const x = 2;",
+    };
+    const result = await handler(message, {});
+
+    expect(result.success).toBe(true);
+    expect(addCommentSpy.mock.calls[0][0].body).toBe("Use const here.\n\n```suggestion\nconst x = 2;\n```");
+  });
+
+  it("should reject a suggestion on lines outside the diff", async () => {
+    mockGithub.rest.pulls.listFiles.mockResolvedValue({
+      data: [{ filename: "src/main.js", patch: "@@ -9,2 +9,2 @@\n context\n-let x = 1;\n+let x = 2;" }],
+    });
+    const handler = await createHandler();
+    const message = {
+      type: "create_pull_request_review_comment",
+      path: "src/main.js",
+      line: 40,
+      body: "Use const here.",
+      suggestion: "const y = 2;",
+    };
+    const result = await handler(message, {});
+
+    expect(result.success).toBe(false);
+    expect(result.error).toContain("src/main.js:40");
+    expect(buffer.getBufferedCount()).toBe(0);
+  });
+
   it("should buffer comment body without footer (footer is added at review level)", async () => {
     process.env.GH_AW_WORKFLOW_NAME = "Test Workflow";
     const handler = await createHandler();
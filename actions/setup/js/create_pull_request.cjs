@@ -37,7 +37,7 @@ const { normalizeCommitSHA } = require("./commit_sha_helpers.cjs");
 const { withRetry, RATE_LIMIT_RETRY_CONFIG } = require("./error_recovery.cjs");
 const { findAgent, getIssueDetails, assignAgentToIssue } = require("./assign_agent_helpers.cjs");
 const { ensureFullHistoryForBundle, extractBundlePrerequisiteCommits, isShallowOrSparseCheckout, linearizeRangeAsCommit } = require("./git_helpers.cjs");
-const { parseDiffGitHeader: parseDiffGitHeaderPaths, extractDiffGitHeaderEntries } = require("./patch_path_helpers.cjs");
+const { parseDiffGitHeader: parseDiffGitHeaderPaths, countUniquePatchFiles } = require("./patch_path_helpers.cjs");
 const { resolveTransportPaths } = require("./resolve_transport_paths.cjs");
 const { resolveAllowedMentionsFromPayload } = require("./resolve_mentions_from_payload.cjs");
 const {
@@ -471,40 +471,6 @@ function parseDiffGitHeader(headerLine) {
   return parsed.newPath || parsed.oldPath || null;
 }
 
-/**
- * Counts the number of unique file paths touched by a git patch.
- *
- * `git format-patch` emits one `diff --git` header per (commit, file), so the
- * same file modified across multiple commits will appear multiple times. The
- * file-count safety limit counts unique files (i.e. how many distinct files
- * this push touches), not raw header occurrences.
- *
- * Headers whose paths cannot be parsed contribute one *synthetic* entry each
- * to the unique-file set, so a malformed or quoted-with-escapes header line
- * can never silently bypass the limit (we conservatively over-count rather
- * than under-count when in doubt).
- *
- * @param {string} patchContent - Patch content to inspect (may be empty)
- * @returns {number} Number of unique file paths referenced in the patch
- */
-function countUniquePatchFiles(patchContent) {
-  if (!patchContent || !patchContent.trim()) {
-    return 0;
-  }
-  const files = new Set();
-  const entries = extractDiffGitHeaderEntries(patchContent);
-  let unparseableIdx = 0;
-  for (const entry of entries) {
-    const path = entry.newPath || entry.oldPath;
-    if (path) {
-      files.add(path);
-    } else {
-      files.add(`__unparseable_header_${entry.headerIndex}_${unparseableIdx++}`);
-    }
-  }
-  return files.size;
-}
-
 /**
  * Enforces maximum limits on pull request parameters to prevent resource exhaustion attacks.
  * Per Safe Outputs specification requirement SEC-003, limits must be enforced before API calls.
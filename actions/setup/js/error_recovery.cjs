@@ -53,6 +53,52 @@ const RATE_LIMIT_RETRY_CONFIG = {
   shouldRetry: isTransientError,
 };
 
+/**
+ * Retry policy configured in the workflow frontmatter (safe-outputs `retry:`),
+ * applied on top of the caller's retry configuration while a safe-output
+ * handler runs. Set and restored by the handler manager.
+ * @type {{max_attempts?: number, backoff?: number}|null}
+ */
+let retryPolicyOverride = null;
+
+/**
+ * Set the retry policy applied to every withRetry call until it is cleared.
+ * @param {{max_attempts?: number, backoff?: number}|null} policy - Policy from the handler config, or null to clear
+ * @returns {{max_attempts?: number, backoff?: number}|null} The previously active policy
+ */
+function setRetryPolicyOverride(policy) {
+  const previous = retryPolicyOverride;
+  retryPolicyOverride = policy && typeof policy === "object" ? policy : null;
+  return previous;
+}
+
+/**
+ * Apply the active retry policy to a resolved retry configuration.
+ * `max_attempts` counts the first attempt; `backoff` is the delay in seconds
+ * before the first retry, doubling on each following retry.
+ * @param {RetryConfig} config - Resolved retry configuration
+ * @returns {RetryConfig} Configuration with the policy applied
+ */
+function applyRetryPolicyOverride(config) {
+  if (!retryPolicyOverride) {
+    return config;
+  }
+  const result = { ...config };
+  const maxAttempts = Number(retryPolicyOverride.max_attempts);
+  if (Number.isInteger(maxAttempts) && maxAttempts >= 1) {
+    result.maxRetries = maxAttempts - 1;
+  }
+  const backoffSeconds = Number(retryPolicyOverride.backoff);
+  if (Number.isFinite(backoffSeconds) && backoffSeconds > 0) {
+    const backoffMs = backoffSeconds * 1000;
+    // withRetry multiplies the delay before sleeping, so the first retry waits initialDelayMs * multiplier.
+    result.backoffMultiplier = 2;
+    result.initialDelayMs = backoffMs / result.backoffMultiplier;
+    result.maxDelayMs = Math.max(result.maxDelayMs, backoffMs);
+  }
+  return result;
+}
+
 /**
  * Message indicators used to detect GitHub API rate-limit errors.
  * Matched case-insensitively against lower-cased error messages.
@@ -205,7 +251,7 @@ function isRateLimitError(error) {
  * @throws {Error} If all retry attempts fail
  */
 async function withRetry(operation, config = {}, operationName = "operation") {
-  const fullConfig = { ...DEFAULT_RETRY_CONFIG, ...config };
+  const fullConfig = applyRetryPolicyOverride({ ...DEFAULT_RETRY_CONFIG, ...config });
   let lastError;
   let delay = fullConfig.initialDelayMs;
 
@@ -409,6 +455,7 @@ module.exports = {
   isTransientError,
   getRetryAfterMs,
   enhanceError,
+  setRetryPolicyOverride,
   createValidationError,
   createOperationError,
   DEFAULT_RETRY_CONFIG,
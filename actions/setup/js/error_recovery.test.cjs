@@ -11,7 +11,7 @@ global.core = {
   debug: vi.fn(),
 };
 
-import { withRetry, isTransientError, getRetryAfterMs, enhanceError, createValidationError, createOperationError, DEFAULT_RETRY_CONFIG, RATE_LIMIT_RETRY_CONFIG } from "./error_recovery.cjs";
+import { withRetry, isTransientError, getRetryAfterMs, enhanceError, setRetryPolicyOverride, createValidationError, createOperationError, DEFAULT_RETRY_CONFIG, RATE_LIMIT_RETRY_CONFIG } from "./error_recovery.cjs";
 
 describe("error_recovery", () => {
   beforeEach(() => {
@@ -540,4 +540,50 @@ describe("error_recovery", () => {
       expect(entry.remaining).toBe(0);
     });
   });
+
+  describe("withRetry with retry policy override", () => {
+    beforeEach(() => {
+      vi.useFakeTimers();
+    });
+
+    afterEach(() => {
+      setRetryPolicyOverride(null);
+      vi.useRealTimers();
+    });
+
+    it("should use max_attempts and backoff from the active policy", async () => {
+      setRetryPolicyOverride({ max_attempts: 3, backoff: 2 });
+      const operation = vi.fn().mockRejectedValue(new Error("503 Service Unavailable"));
+
+      const promise = withRetry(operation, { maxRetries: 5, initialDelayMs: 10, jitterMs: 0 }, "test-operation");
+      const assertion = expect(promise).rejects.toThrow("All retry attempts exhausted");
+      await vi.runAllTimersAsync();
+      await assertion;
+
+      expect(operation).toHaveBeenCalledTimes(3);
+      expect(core.info).toHaveBeenCalledWith(expect.stringContaining("Retry attempt 1/2 for test-operation after 2000ms delay"));
+      expect(core.info).toHaveBeenCalledWith(expect.stringContaining("Retry attempt 2/2 for test-operation after 4000ms delay"));
+    });
+
+    it("should disable retries when max_attempts is 1", async () => {
+      setRetryPolicyOverride({ max_attempts: 1 });
+      const operation = vi.fn().mockRejectedValue(new Error("secondary rate limit"));
+
+      await expect(withRetry(operation, { maxRetries: 3, initialDelayMs: 10 }, "test-operation")).rejects.toThrow("test-operation failed");
+
+      expect(operation).toHaveBeenCalledTimes(1);
+    });
+
+    it("should return the previous policy and restore caller config when cleared", async () => {
+      expect(setRetryPolicyOverride({ max_attempts: 1 })).toBeNull();
+      expect(setRetryPolicyOverride(null)).toEqual({ max_attempts: 1 });
+
+      const operation = vi.fn().mockRejectedValueOnce(new Error("Network timeout")).mockResolvedValue("success");
+      const promise = withRetry(operation, { maxRetries: 2, initialDelayMs: 10, jitterMs: 0 }, "test-operation");
+      await vi.runAllTimersAsync();
+
+      expect(await promise).toBe("success");
+      expect(operation).toHaveBeenCalledTimes(2);
+    });
+  });
 });
@@ -0,0 +1,235 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+/**
+ * Fetch issue and discussion imports into the prompt
+ *
+ * Workflows can import GitHub issues and discussions as prompt context
+ * (imports: [issue:123, discussion:88, https://github.com/owner/repo/issues/5]).
+ * The compiler writes a {{#github-context KEY}} marker line into the prompt for
+ * each one; this script fetches the title, body, and comments, sanitizes them,
+ * and replaces the marker with the result.
+ *
+ * It runs after placeholder validation, so fetched text is never processed as
+ * template syntax or expression placeholders.
+ *
+ * Environment Variables:
+ * - GH_AW_PROMPT: Path to the prompt file
+ * - GH_AW_GITHUB_CONTEXT_IMPORTS: JSON array of {kind, repo, number, max_size, comments}
+ */
+
+const fs = require("fs");
+
+const { sanitizeIncomingText } = require("./sanitize_incoming_text.cjs");
+const { getErrorMessage } = require("./error_helpers.cjs");
+const { ERR_API, ERR_CONFIG, ERR_PARSE } = require("./error_codes.cjs");
+
+/** Default maximum characters of fetched content per import. */
+const DEFAULT_MAX_SIZE = 20000;
+
+/** Maximum number of comments fetched per import. */
+const MAX_COMMENTS = 100;
+
+/**
+ * @typedef {Object} GitHubContextImport
+ * @property {string} kind - "issue" or "discussion"
+ * @property {string} [repo] - "owner/repo"; the current repository when empty
+ * @property {number} number - Issue or discussion number
+ * @property {number} [max_size] - Maximum characters of fetched content
+ * @property {boolean} comments - Whether comments are included
+ */
+
+/**
+ * @typedef {Object} FetchedContext
+ * @property {string} title
+ * @property {string} url
+ * @property {string} author
+ * @property {string} createdAt
+ * @property {string} body
+ * @property {{author: string, createdAt: string, body: string}[]} comments
+ */
+
+/**
+ * Return the key used in the prompt marker, matching the compiler's GitHubContextImport.Key.
+ * @param {GitHubContextImport} ref
+ * @returns {string} e.g. "issue:123" or "discussion:octo/repo#88"
+ */
+function contextImportKey(ref) {
+  return ref.repo ? `${ref.kind}:${ref.repo}#${ref.number}` : `${ref.kind}:${ref.number}`;
+}
+
+/**
+ * Return the prompt marker line the compiler emits for an import.
+ * @param {GitHubContextImport} ref
+ * @returns {string}
+ */
+function contextImportMarker(ref) {
+  return `{{#github-context ${contextImportKey(ref)}}}`;
+}
+
+/**
+ * Split "owner/repo" into its parts, defaulting to the current repository.
+ * @param {GitHubContextImport} ref
+ * @returns {{owner: string, repo: string}}
+ */
+function resolveRepo(ref) {
+  if (!ref.repo) {
+    return context.repo;
+  }
+  const [owner, repo] = ref.repo.split("/");
+  return { owner, repo };
+}
+
+/**
+ * Fetch an issue (or pull request) with its comments through the REST API.
+ * @param {string} owner
+ * @param {string} repo
+ * @param {number} number
+ * @param {boolean} includeComments
+ * @returns {Promise<FetchedContext>}
+ */
+async function fetchIssue(owner, repo, number, includeComments) {
+  const { data: issue } = await github.rest.issues.get({ owner, repo, issue_number: number });
+  let comments = [];
+  if (includeComments && issue.comments > 0) {
+    const { data } = await github.rest.issues.listComments({ owner, repo, issue_number: number, per_page: MAX_COMMENTS });
+    comments = data.map(comment => ({
+      author: comment.user?.login || "ghost",
+      createdAt: comment.created_at,
+      body: comment.body || "",
+    }));
+  }
+  return {
+    title: issue.title,
+    url: issue.html_url,
+    author: issue.user?.login || "ghost",
+    createdAt: issue.created_at,
+    body: issue.body || "",
+    comments,
+  };
+}
+
+/**
+ * Fetch a discussion with its comments through the GraphQL API.
+ * @param {string} owner
+ * @param {string} repo
+ * @param {number} number
+ * @param {boolean} includeComments
+ * @returns {Promise<FetchedContext>}
+ */
+async function fetchDiscussion(owner, repo, number, includeComments) {
+  const query = `
+    query($owner: String!, $repo: String!, $number: Int!, $comments: Int!) {
+      repository(owner: $owner, name: $repo) {
+        discussion(number: $number) {
+          title
+          url
+          body
+          createdAt
+          author { login }
+          comments(first: $comments) {
+            nodes {
+              body
+              createdAt
+              author { login }
+            }
+          }
+        }
+      }
+    }`;
+  const result = await github.graphql(query, { owner, repo, number, comments: includeComments ? MAX_COMMENTS : 0 });
+  const discussion = result.repository?.discussion;
+  if (!discussion) {
+    throw new Error(`Discussion #${number} not found in ${owner}/${repo}`);
+  }
+  return {
+    title: discussion.title,
+    url: discussion.url,
+    author: discussion.author?.login || "ghost",
+    createdAt: discussion.createdAt,
+    body: discussion.body || "",
+    comments: (discussion.comments?.nodes || []).map((/** @type {any} */ comment) => ({
+      author: comment.author?.login || "ghost",
+      createdAt: comment.createdAt,
+      body: comment.body || "",
+    })),
+  };
+}
+
+/**
+ * Format fetched content for the prompt. The title, body, and comments are
+ * sanitized and truncated to maxSize characters; the wrapper is added afterwards
+ * so it cannot be altered by the fetched text.
+ * @param {GitHubContextImport} ref
+ * @param {FetchedContext} fetched
+ * @param {number} maxSize
+ * @returns {string}
+ */
+function formatContext(ref, fetched, maxSize) {
+  const kindLabel = ref.kind === "discussion" ? "Discussion" : "Issue";
+  const lines = [`# ${kindLabel} #${ref.number}: ${fetched.title}`, "", `Opened by @${fetched.author} on ${fetched.createdAt}`, "", fetched.body.trim()];
+  if (fetched.comments.length > 0) {
+    lines.push("", "## Comments");
+    for (const comment of fetched.comments) {
+      lines.push("", `### @${comment.author} on ${comment.createdAt}`, "", comment.body.trim());
+    }
+  }
+  const sanitized = sanitizeIncomingText(lines.join("\n"), maxSize);
+  return `<github-context source="${contextImportKey(ref)}" url="${fetched.url}">\n${sanitized}\n</github-context>`;
+}
+
+/**
+ * Fetch every import and replace its marker in the prompt content.
+ * @param {string} content - Prompt content
+ * @param {GitHubContextImport[]} imports
+ * @returns {Promise<string>}
+ */
+async function resolveContextImports(content, imports) {
+  for (const ref of imports) {
+    const key = contextImportKey(ref);
+    const { owner, repo } = resolveRepo(ref);
+    core.info(`Fetching ${key} from ${owner}/${repo}`);
+    const fetched = ref.kind === "discussion" ? await fetchDiscussion(owner, repo, ref.number, ref.comments) : await fetchIssue(owner, repo, ref.number, ref.comments);
+    const formatted = formatContext(ref, fetched, ref.max_size || DEFAULT_MAX_SIZE);
+    core.info(`Fetched ${key}: ${fetched.comments.length} comment(s), ${formatted.length} characters`);
+    content = content.split(contextImportMarker(ref)).join(formatted);
+  }
+  return content;
+}
+
+async function main() {
+  const promptPath = process.env.GH_AW_PROMPT;
+  if (!promptPath) {
+    core.setFailed(`${ERR_CONFIG}: GH_AW_PROMPT environment variable is not set`);
+    return;
+  }
+
+  /** @type {GitHubContextImport[]} */
+  let imports;
+  try {
+    imports = JSON.parse(process.env.GH_AW_GITHUB_CONTEXT_IMPORTS || "[]");
+  } catch (error) {
+    core.setFailed(`${ERR_PARSE}: Failed to parse GH_AW_GITHUB_CONTEXT_IMPORTS: ${getErrorMessage(error)}`);
+    return;
+  }
+  if (imports.length === 0) {
+    core.info("No issue or discussion imports to fetch");
+    return;
+  }
+
+  try {
+    const content = fs.readFileSync(promptPath, "utf8");
+    fs.writeFileSync(promptPath, await resolveContextImports(content, imports), "utf8");
+    core.info(`Added ${imports.length} issue/discussion import(s) to the prompt`);
+  } catch (error) {
+    core.setFailed(`${ERR_API}: Failed to fetch issue or discussion import: ${getErrorMessage(error)}`);
+  }
+}
+
+module.exports = {
+  contextImportKey,
+  contextImportMarker,
+  formatContext,
+  main,
+  resolveContextImports,
+};
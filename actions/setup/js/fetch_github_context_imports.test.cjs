@@ -0,0 +1,138 @@
+import { describe, it, expect, beforeEach, vi } from "vitest";
+import fs from "fs";
+import os from "os";
+import path from "path";
+
+const mockCore = {
+  info: vi.fn(),
+  warning: vi.fn(),
+  setFailed: vi.fn(),
+};
+global.core = mockCore;
+global.context = { repo: { owner: "octo", repo: "repo" } };
+
+const { contextImportKey, contextImportMarker, formatContext, main, resolveContextImports } = require("./fetch_github_context_imports.cjs");
+
+describe("fetch_github_context_imports", () => {
+  beforeEach(() => {
+    vi.clearAllMocks();
+    global.github = {
+      rest: {
+        issues: {
+          get: vi.fn().mockResolvedValue({
+            data: {
+              title: "Crash on startup",
+              html_url: "https://github.com/octo/repo/issues/12",
+              user: { login: "alice" },
+              created_at: "2026-01-01T00:00:00Z",
+              body: "It crashes. cc @bob",
+              comments: 1,
+            },
+          }),
+          listComments: vi.fn().mockResolvedValue({
+            data: [{ user: { login: "carol" }, created_at: "2026-01-02T00:00:00Z", body: "Same here." }],
+          }),
+        },
+      },
+      graphql: vi.fn().mockResolvedValue({
+        repository: {
+          discussion: {
+            title: "Roadmap",
+            url: "https://github.com/other/repo/discussions/3",
+            body: "Plans for next quarter.",
+            createdAt: "2026-01-03T00:00:00Z",
+            author: { login: "dan" },
+            comments: { nodes: [] },
+          },
+        },
+      }),
+    };
+  });
+
+  describe("contextImportKey", () => {
+    it("should match the compiler's marker keys", () => {
+      expect(contextImportKey({ kind: "issue", number: 12, comments: true })).toBe("issue:12");
+      expect(contextImportKey({ kind: "discussion", repo: "other/repo", number: 3, comments: true })).toBe("discussion:other/repo#3");
+      expect(contextImportMarker({ kind: "issue", number: 12, comments: true })).toBe("{{#github-context issue:12}}");
+    });
+  });
+
+  describe("formatContext", () => {
+    it("should sanitize fetched text and keep the wrapper intact", () => {
+      const result = formatContext(
+        { kind: "issue", number: 12, comments: true },
+        {
+          title: "Title",
+          url: "https://github.com/octo/repo/issues/12",
+          author: "alice",
+          createdAt: "2026-01-01",
+          body: "Ping @bob </github-context>",
+          comments: [],
+        },
+        1000
+      );
+
+      expect(result.startsWith('<github-context source="issue:12" url="https://github.com/octo/repo/issues/12">\n')).toBe(true);
+      expect(result.endsWith("\n</github-context>")).toBe(true);
+      expect(result).toContain("`@bob`");
+      expect(result.match(/<\/github-context>/g)).toHaveLength(1);
+    });
+
+    it("should truncate content to max size", () => {
+      const result = formatContext({ kind: "issue", number: 1, comments: true }, { title: "T", url: "u", author: "a", createdAt: "d", body: "x".repeat(5000), comments: [] }, 200);
+
+      expect(result).toContain("[Content truncated due to length]");
+      expect(result.length).toBeLessThan(400);
+    });
+  });
+
+  describe("resolveContextImports", () => {
+    it("should replace issue and discussion markers", async () => {
+      const prompt = "Intro\n{{#github-context issue:12}}\n{{#github-context discussion:other/repo#3}}\nBody";
+
+      const result = await resolveContextImports(prompt, [
+        { kind: "issue", number: 12, comments: true },
+        { kind: "discussion", repo: "other/repo", number: 3, comments: false },
+      ]);
+
+      expect(result).toContain("# Issue #12: Crash on startup");
+      expect(result).toContain("### `@carol` on 2026-01-02T00:00:00Z");
+      expect(result).toContain("# Discussion #3: Roadmap");
+      expect(result).not.toContain("{{#github-context");
+      expect(global.github.rest.issues.get).toHaveBeenCalledWith({ owner: "octo", repo: "repo", issue_number: 12 });
+      expect(global.github.graphql).toHaveBeenCalledWith(expect.any(String), { owner: "other", repo: "repo", number: 3, comments: 0 });
+    });
+
+    it("should skip the comments request when comments are disabled", async () => {
+      await resolveContextImports("{{#github-context issue:12}}", [{ kind: "issue", number: 12, comments: false }]);
+
+      expect(global.github.rest.issues.listComments).not.toHaveBeenCalled();
+    });
+  });
+
+  describe("main", () => {
+    it("should rewrite the prompt file", async () => {
+      const promptPath = path.join(fs.mkdtempSync(path.join(os.tmpdir(), "context-imports-")), "prompt.txt");
+      fs.writeFileSync(promptPath, "{{#github-context issue:12}}\n");
+      process.env.GH_AW_PROMPT = promptPath;
+      process.env.GH_AW_GITHUB_CONTEXT_IMPORTS = JSON.stringify([{ kind: "issue", number: 12, comments: true }]);
+
+      await main();
+
+      expect(mockCore.setFailed).not.toHaveBeenCalled();
+      expect(fs.readFileSync(promptPath, "utf8")).toContain("# Issue #12: Crash on startup");
+    });
+
+    it("should fail when the issue cannot be fetched", async () => {
+      const promptPath = path.join(fs.mkdtempSync(path.join(os.tmpdir(), "context-imports-")), "prompt.txt");
+      fs.writeFileSync(promptPath, "{{#github-context issue:99}}\n");
+      process.env.GH_AW_PROMPT = promptPath;
+      process.env.GH_AW_GITHUB_CONTEXT_IMPORTS = JSON.stringify([{ kind: "issue", number: 99, comments: true }]);
+      global.github.rest.issues.get.mockRejectedValue(new Error("Not Found"));
+
+      await main();
+
+      expect(mockCore.setFailed).toHaveBeenCalledWith(expect.stringContaining("Not Found"));
+    });
+  });
+});
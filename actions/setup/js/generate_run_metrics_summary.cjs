@@ -0,0 +1,261 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+/**
+ * Run metrics job summary
+ *
+ * Writes a compact "Run metrics" section (turns, tokens, cost, tool calls, safe outputs,
+ * firewall stats) to $GITHUB_STEP_SUMMARY at the end of the agent job.
+ *
+ * The markdown layout is shared with `gh aw audit --format markdown`
+ * (pkg/cli/run_metrics_summary.go). Both renderers are tested against the fixtures in
+ * pkg/cli/testdata/run_metrics_summary, so any change here must be mirrored in Go.
+ */
+
+const fs = require("fs");
+const path = require("path");
+const { getErrorMessage } = require("./error_helpers.cjs");
+const { AGENT_TURNS_PATH } = require("./agent_turns.cjs");
+const { analyzeFirewallLogLines } = require("./parse_firewall_logs.cjs");
+
+const AW_INFO_PATH = "/tmp/gh-aw/aw_info.json";
+const AGENT_OUTPUT_PATH = "/tmp/gh-aw/agent_output.json";
+const FIREWALL_LOGS_DIR = "/tmp/gh-aw/sandbox/firewall/logs";
+
+/**
+ * @typedef {Object} RunMetricsSummary
+ * @property {string} [engine]
+ * @property {number} turns
+ * @property {number} tokens
+ * @property {number} [cost_usd]
+ * @property {Array<{name: string, calls: number}>} [tool_calls]
+ * @property {Array<{type: string, count: number}>} [safe_outputs]
+ * @property {{allowed: number, blocked: number, blocked_domains?: string[]}} [firewall]
+ */
+
+/**
+ * @param {string} filePath
+ * @returns {any}
+ */
+function readJSONIfExists(filePath) {
+  if (!fs.existsSync(filePath)) {
+    return null;
+  }
+  try {
+    return JSON.parse(fs.readFileSync(filePath, "utf8"));
+  } catch {
+    return null;
+  }
+}
+
+/**
+ * Normalize a tool name the same way as workflow.PrettifyToolName in Go, so the tool table
+ * matches `gh aw audit`.
+ * @param {string} name
+ * @returns {string}
+ */
+function prettifyToolName(name) {
+  if (name.startsWith("mcp__")) {
+    const parts = name.split("__");
+    if (parts.length >= 3) {
+      return `${parts[1]}_${parts.slice(2).join("_")}`;
+    }
+    return name.slice("mcp__".length);
+  }
+  if (name.toLowerCase() === "bash") {
+    return "bash";
+  }
+  return name;
+}
+
+/**
+ * Read turns, tokens, cost, and tool calls from the structured turn log.
+ * @param {string} filePath
+ * @returns {{turns: number, tokens: number, cost_usd: number, tool_calls: Array<{name: string, calls: number}>}}
+ */
+function readAgentTurns(filePath) {
+  const metrics = { turns: 0, tokens: 0, cost_usd: 0, tool_calls: /** @type {Array<{name: string, calls: number}>} */ [] };
+  if (!fs.existsSync(filePath)) {
+    return metrics;
+  }
+
+  /** @type {Map<string, number>} */
+  const calls = new Map();
+  for (const rawLine of fs.readFileSync(filePath, "utf8").split("\n")) {
+    const line = rawLine.trim();
+    if (!line) continue;
+    let record;
+    try {
+      record = JSON.parse(line);
+    } catch {
+      continue;
+    }
+    if (!record || typeof record !== "object") continue;
+    if (record.type === "tool_call" && typeof record.name === "string") {
+      const name = prettifyToolName(record.name);
+      calls.set(name, (calls.get(name) || 0) + 1);
+    } else if (record.type === "result") {
+      metrics.turns = Number(record.turns) || 0;
+      metrics.tokens = (Number(record.input_tokens) || 0) + (Number(record.output_tokens) || 0) + (Number(record.cache_read_tokens) || 0) + (Number(record.cache_write_tokens) || 0);
+      metrics.cost_usd = Number(record.cost_usd) || 0;
+    }
+  }
+  metrics.tool_calls = [...calls].map(([name, count]) => ({ name, calls: count }));
+  return metrics;
+}
+
+/**
+ * Count requested safe output items by type.
+ * @param {any} agentOutput
+ * @returns {Array<{type: string, count: number}>}
+ */
+function countSafeOutputs(agentOutput) {
+  const items = agentOutput && Array.isArray(agentOutput.items) ? agentOutput.items : [];
+  /** @type {Map<string, number>} */
+  const counts = new Map();
+  for (const item of items) {
+    if (item && typeof item.type === "string" && item.type.trim() !== "") {
+      counts.set(item.type, (counts.get(item.type) || 0) + 1);
+    }
+  }
+  return [...counts].map(([type, count]) => ({ type, count }));
+}
+
+/**
+ * Aggregate firewall request counts from the squid access logs.
+ * @param {string} logsDir
+ * @returns {{allowed: number, blocked: number, blocked_domains: string[]} | undefined}
+ */
+function readFirewallStats(logsDir) {
+  if (!fs.existsSync(logsDir)) {
+    return undefined;
+  }
+  const files = fs.readdirSync(logsDir).filter(file => file.endsWith(".log"));
+  if (files.length === 0) {
+    return undefined;
+  }
+  const stats = { allowed: 0, blocked: 0, blocked_domains: /** @type {string[]} */ [] };
+  const blockedDomains = new Set();
+  for (const file of files) {
+    const lines = fs
+      .readFileSync(path.join(logsDir, file), "utf8")
+      .split("\n")
+      .filter(line => line.trim());
+    const result = analyzeFirewallLogLines(lines);
+    stats.allowed += result.allowedRequests;
+    stats.blocked += result.blockedRequests;
+    for (const domain of result.blockedDomains) {
+      blockedDomains.add(domain);
+    }
+  }
+  stats.blocked_domains = [...blockedDomains];
+  return stats;
+}
+
+/**
+ * Collect the run metrics summary from the files written during the agent job.
+ * @returns {RunMetricsSummary}
+ */
+function collectRunMetricsSummary() {
+  const awInfo = readJSONIfExists(AW_INFO_PATH) || {};
+  const turns = readAgentTurns(AGENT_TURNS_PATH);
+  /** @type {RunMetricsSummary} */
+  const summary = {
+    engine: typeof awInfo.engine_id === "string" ? awInfo.engine_id : "",
+    turns: turns.turns,
+    tokens: turns.tokens,
+    cost_usd: turns.cost_usd,
+    tool_calls: turns.tool_calls,
+    safe_outputs: countSafeOutputs(readJSONIfExists(AGENT_OUTPUT_PATH)),
+  };
+  const firewall = readFirewallStats(FIREWALL_LOGS_DIR);
+  if (firewall) {
+    summary.firewall = firewall;
+  }
+  return summary;
+}
+
+/**
+ * Escape characters that would break a markdown table row.
+ * @param {string} value
+ * @returns {string}
+ */
+function escapeTableCell(value) {
+  return value.replace(/\|/g, "\\|").replace(/\n/g, " ");
+}
+
+/**
+ * Byte-wise string comparison, matching strings.Compare in Go.
+ * @param {string} a
+ * @param {string} b
+ * @returns {number}
+ */
+function compareStrings(a, b) {
+  return a < b ? -1 : a > b ? 1 : 0;
+}
+
+/**
+ * Render the run metrics summary as a collapsible markdown section.
+ * @param {RunMetricsSummary} summary
+ * @returns {string}
+ */
+function renderRunMetricsSummary(summary) {
+  const lines = ["<details>", "<summary>Run metrics</summary>", "", "| Metric | Value |", "| --- | --- |"];
+  if (summary.engine) {
+    lines.push(`| Engine | ${escapeTableCell(summary.engine)} |`);
+  }
+  lines.push(`| Turns | ${summary.turns || 0} |`);
+  lines.push(`| Tokens | ${summary.tokens || 0} |`);
+  if (summary.cost_usd && summary.cost_usd > 0) {
+    lines.push(`| Estimated cost | $${summary.cost_usd.toFixed(2)} |`);
+  }
+  if (summary.firewall) {
+    lines.push(`| Firewall requests | ${summary.firewall.allowed} allowed, ${summary.firewall.blocked} blocked |`);
+  }
+
+  const toolCalls = [...(summary.tool_calls || [])].sort((a, b) => b.calls - a.calls || compareStrings(a.name, b.name));
+  if (toolCalls.length > 0) {
+    lines.push("", "#### Tool calls", "", "| Tool | Calls |", "| --- | --- |");
+    for (const tool of toolCalls) {
+      lines.push(`| ${escapeTableCell(tool.name)} | ${tool.calls} |`);
+    }
+  }
+
+  const safeOutputs = [...(summary.safe_outputs || [])].sort((a, b) => compareStrings(a.type, b.type));
+  if (safeOutputs.length > 0) {
+    lines.push("", "#### Safe outputs", "", "| Type | Count |", "| --- | --- |");
+    for (const output of safeOutputs) {
+      lines.push(`| ${escapeTableCell(output.type)} | ${output.count} |`);
+    }
+  }
+
+  const blockedDomains = [...((summary.firewall && summary.firewall.blocked_domains) || [])].sort(compareStrings);
+  if (blockedDomains.length > 0) {
+    lines.push("", "#### Blocked domains", "");
+    for (const domain of blockedDomains) {
+      lines.push(`- \`${domain}\``);
+    }
+  }
+
+  lines.push("", "</details>");
+  return lines.join("\n") + "\n";
+}
+
+async function main() {
+  try {
+    const summary = collectRunMetricsSummary();
+    await core.summary.addRaw(renderRunMetricsSummary(summary)).write();
+    core.info(`Generated run metrics summary: turns=${summary.turns}, tokens=${summary.tokens}`);
+  } catch (error) {
+    core.warning(`Failed to generate run metrics summary: ${getErrorMessage(error)}`);
+  }
+}
+
+module.exports = {
+  collectRunMetricsSummary,
+  countSafeOutputs,
+  prettifyToolName,
+  readAgentTurns,
+  renderRunMetricsSummary,
+  main,
+};
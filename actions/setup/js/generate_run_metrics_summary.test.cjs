@@ -0,0 +1,99 @@
+import { afterEach, beforeEach, describe, expect, it, vi } from "vitest";
+import fs from "fs";
+import os from "os";
+import path from "path";
+
+const mockCore = {
+  info: vi.fn(),
+  warning: vi.fn(),
+  summary: {
+    addRaw: vi.fn().mockReturnThis(),
+    write: vi.fn().mockResolvedValue(),
+  },
+};
+
+global.core = mockCore;
+
+// Fixtures shared with pkg/cli/run_metrics_summary_test.go so the job summary and
+// `gh aw audit --format markdown` render identically.
+const FIXTURES_DIR = path.join(__dirname, "../../../pkg/cli/testdata/run_metrics_summary");
+
+describe("generate_run_metrics_summary.cjs", () => {
+  let module;
+  let tmpDir;
+
+  beforeEach(async () => {
+    vi.clearAllMocks();
+    tmpDir = fs.mkdtempSync(path.join(os.tmpdir(), "run-metrics-"));
+    module = await import("./generate_run_metrics_summary.cjs");
+  });
+
+  afterEach(() => {
+    fs.rmSync(tmpDir, { recursive: true, force: true });
+  });
+
+  describe("renderRunMetricsSummary", () => {
+    const fixtures = fs
+      .readdirSync(FIXTURES_DIR)
+      .filter(file => file.endsWith(".json"))
+      .map(file => file.replace(/\.json$/, ""));
+
+    it.each(fixtures)("matches the shared %s fixture", name => {
+      const summary = JSON.parse(fs.readFileSync(path.join(FIXTURES_DIR, `${name}.json`), "utf8"));
+      const expected = fs.readFileSync(path.join(FIXTURES_DIR, `${name}.md`), "utf8");
+      expect(module.renderRunMetricsSummary(summary)).toBe(expected);
+    });
+  });
+
+  describe("readAgentTurns", () => {
+    it("reads totals from the result record and counts tool calls", () => {
+      const turnsPath = path.join(tmpDir, "agent_turns.jsonl");
+      fs.writeFileSync(
+        turnsPath,
+        [
+          '{"type":"header","format":"gh-aw.agent-turns","version":1,"engine":"Claude"}',
+          '{"type":"turn","turn":1,"input_tokens":100,"output_tokens":20}',
+          '{"type":"tool_call","turn":1,"id":"t1","name":"mcp__github__issue_read"}',
+          '{"type":"tool_call","turn":1,"id":"t2","name":"Bash"}',
+          '{"type":"tool_call","turn":2,"id":"t3","name":"Bash"}',
+          '{"type":"result","turns":2,"input_tokens":300,"output_tokens":40,"cache_read_tokens":10,"cache_write_tokens":0,"cost_usd":0.25}',
+          "",
+        ].join("\n")
+      );
+
+      const metrics = module.readAgentTurns(turnsPath);
+
+      expect(metrics.turns).toBe(2);
+      expect(metrics.tokens).toBe(350);
+      expect(metrics.cost_usd).toBe(0.25);
+      expect(metrics.tool_calls).toEqual([
+        { name: "github_issue_read", calls: 1 },
+        { name: "bash", calls: 2 },
+      ]);
+    });
+
+    it("returns empty metrics when the file is missing", () => {
+      expect(module.readAgentTurns(path.join(tmpDir, "missing.jsonl"))).toEqual({ turns: 0, tokens: 0, cost_usd: 0, tool_calls: [] });
+    });
+  });
+
+  describe("countSafeOutputs", () => {
+    it("groups items by type", () => {
+      const counts = module.countSafeOutputs({ items: [{ type: "add_comment" }, { type: "add_comment" }, { type: "create_issue" }, { body: "no type" }] });
+      expect(counts).toEqual([
+        { type: "add_comment", count: 2 },
+        { type: "create_issue", count: 1 },
+      ]);
+    });
+  });
+
+  describe("main", () => {
+    it("writes the summary to the step summary", async () => {
+      await module.main();
+
+      expect(mockCore.summary.addRaw).toHaveBeenCalledWith(expect.stringContaining("<summary>Run metrics</summary>"));
+      expect(mockCore.summary.write).toHaveBeenCalled();
+      expect(mockCore.warning).not.toHaveBeenCalled();
+    });
+  });
+});
@@ -16,6 +16,8 @@ const { writeInlineSkills } = require("./extract_inline_skills.cjs");
 const { getErrorMessage } = require("./error_helpers.cjs");
 const { ERR_API, ERR_CONFIG, ERR_VALIDATION } = require("./error_codes.cjs");
 const { renderMarkdownTemplate } = require("./render_template.cjs");
+const { enforcePromptBudget } = require("./prompt_budget.cjs");
+const { parsePromptRoutes, collectTriggerLabels, selectRoutes, appendRoutedImports, removeUnselectedSections, describeRoute } = require("./prompt_routing.cjs");
 
 /**
  * @typedef {Object} ImportTreeNode
@@ -101,6 +103,24 @@ async function main() {
     core.info(`[main] Writing raw template to: ${templatePath}`);
     fs.writeFileSync(templatePath, content, "utf8");
 
+    // Step 0.5: Select route: targets from the labels of the triggering event
+    // Routed files are appended as runtime imports so that Step 1 resolves them.
+    const routing = parsePromptRoutes(process.env.GH_AW_PROMPT_ROUTES);
+    /** @type {import("./prompt_routing.cjs").RouteSelection | null} */
+    let routeSelection = null;
+    if (routing) {
+      core.info("\n========================================");
+      core.info("[main] STEP 0.5: Label Routing");
+      core.info("========================================");
+      const labels = collectTriggerLabels(context.payload);
+      core.info(`Trigger labels: ${labels.length > 0 ? labels.join(", ") : "(none)"}`);
+      routeSelection = selectRoutes(routing, labels);
+      for (const route of routeSelection.selected) {
+        core.info(`  Selected route: ${describeRoute(route)}`);
+      }
+      content = appendRoutedImports(content, routeSelection);
+    }
+
     // Step 1: Process runtime imports (files and URLs)
     core.info("\n========================================");
     core.info("[main] STEP 1: Runtime Imports");
@@ -115,6 +135,10 @@ async function main() {
       children: /** @type {ImportTreeNode[]} */ [],
     };
 
+    // Resolved import content keyed by import path, used to enforce prompt.max-tokens.
+    /** @type {Map<string, string>} */
+    const importCache = new Map();
+
     if (hasRuntimeImports) {
       const importMatches = content.match(/{{#runtime-import\??[ \t]+[^\}]+}}/g) || [];
       core.info(`Processing ${importMatches.length} runtime import macro(s) (files and URLs)`);
@@ -123,7 +147,7 @@ async function main() {
       });
 
       const beforeImports = content.length;
-      content = await processRuntimeImports(content, workspaceDir, new Set(), new Map(), [], importTree.children);
+      content = await processRuntimeImports(content, workspaceDir, new Set(), importCache, [], importTree.children);
       const afterImports = content.length;
 
       core.info(`Runtime imports processed successfully`);
@@ -138,6 +162,25 @@ async function main() {
     core.info(`[main] Writing import tree to: ${importTreePath}`);
     fs.writeFileSync(importTreePath, JSON.stringify(importTree, null, 2), "utf8");
 
+    // Step 1.3: Remove routed sections whose labels did not match
+    // This runs after runtime imports because the main workflow body is itself imported.
+    if (routeSelection) {
+      const { content: routed, removed } = removeUnselectedSections(content, routeSelection);
+      content = routed;
+      core.info(removed.length > 0 ? `Removed unrouted section(s): ${removed.join(", ")}` : "No routed sections removed");
+    }
+
+    // Step 1.4: Enforce the prompt size budget (prompt.max-tokens)
+    // This runs right after runtime imports so that imported content still appears
+    // verbatim in the prompt and can be truncated before the agent starts.
+    const maxTokens = parseInt(process.env.GH_AW_PROMPT_MAX_TOKENS || "", 10);
+    if (maxTokens > 0) {
+      core.info("\n========================================");
+      core.info("[main] STEP 1.4: Prompt Size Budget");
+      core.info("========================================");
+      content = enforcePromptBudget(content, importTree.children, importCache, maxTokens, process.env.GH_AW_PROMPT_MAIN_FILE || "");
+    }
+
     // Step 1.5: Extract and write inline sub-agents
     // ## agent: name / ## end: name blocks are written to .github/agents/<name>.md.
     // This happens after runtime imports so that any {{#runtime-import}} macros
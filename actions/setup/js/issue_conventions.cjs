@@ -0,0 +1,206 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+const { parseProjectUrl } = require("./update_project.cjs");
+const { getErrorMessage } = require("./error_helpers.cjs");
+const { ERR_NOT_FOUND } = require("./error_codes.cjs");
+
+/** Milestone value that selects the open milestone due soonest */
+const NEXT_OPEN_MILESTONE = "next-open";
+
+/**
+ * @typedef {Object} MilestoneCandidate
+ * @property {number} number
+ * @property {string} title
+ * @property {string} [state]
+ * @property {string|null} [due_on]
+ */
+
+/**
+ * @typedef {Object} ProjectTarget
+ * @property {string} ownerLogin - Login of the user or organization that owns the project
+ * @property {string} [scope] - "orgs" or "users" when known from a project URL
+ * @property {number} [number] - Project number
+ * @property {string} [title] - Project title, when the project is selected by name
+ */
+
+/**
+ * Select the open milestone with the earliest due date. Milestones without a due
+ * date come after dated ones, lowest number first.
+ *
+ * @param {MilestoneCandidate[]} milestones
+ * @returns {MilestoneCandidate|null}
+ */
+function selectNextOpenMilestone(milestones) {
+  const open = milestones.filter(m => m && m.state !== "closed");
+  if (open.length === 0) {
+    return null;
+  }
+  const dueTime = (/** @type {MilestoneCandidate} */ m) => (m.due_on ? Date.parse(m.due_on) : Number.POSITIVE_INFINITY);
+  return open.reduce((best, m) => {
+    const diff = dueTime(m) - dueTime(best);
+    return diff < 0 || (diff === 0 && m.number < best.number) ? m : best;
+  });
+}
+
+/**
+ * Parse the create-issue `project` handler config into a project lookup target.
+ * A project URL names its owner; a number or title refers to a project owned by
+ * the owner of the repository the issue is created in.
+ *
+ * @param {string} project - Project URL, number, or title
+ * @param {string} repoOwner - Owner of the repository the issue is created in
+ * @returns {ProjectTarget}
+ */
+function parseProjectTarget(project, repoOwner) {
+  if (/^https:\/\//.test(project)) {
+    const info = parseProjectUrl(project);
+    return { ownerLogin: info.ownerLogin, scope: info.scope, number: parseInt(info.projectNumber, 10) };
+  }
+  if (/^\d+$/.test(project)) {
+    return { ownerLogin: repoOwner, number: parseInt(project, 10) };
+  }
+  return { ownerLogin: repoOwner, title: project };
+}
+
+/**
+ * Find a Project (v2) by number or title. Closed projects are never matched by title.
+ *
+ * @param {Object} githubClient - GitHub client with Projects access
+ * @param {ProjectTarget} target
+ * @returns {Promise<{ id: string, number: number, title: string }>}
+ */
+async function findProjectV2(githubClient, target) {
+  const result = await githubClient.graphql(
+    `query($login: String!, $number: Int!, $byNumber: Boolean!, $query: String!) {
+      repositoryOwner(login: $login) {
+        ... on ProjectV2Owner {
+          projectV2(number: $number) @include(if: $byNumber) {
+            id
+            number
+            title
+          }
+          projectsV2(first: 100, query: $query) @skip(if: $byNumber) {
+            nodes {
+              id
+              number
+              title
+              closed
+            }
+          }
+        }
+      }
+    }`,
+    { login: target.ownerLogin, number: target.number ?? 0, byNumber: target.number !== undefined, query: target.title ?? "" }
+  );
+  const owner = result?.repositoryOwner;
+  const project = target.number !== undefined ? owner?.projectV2 : (owner?.projectsV2?.nodes ?? []).find(p => p && !p.closed && p.title === target.title);
+  if (!project) {
+    const name = target.number !== undefined ? `#${target.number}` : `"${target.title}"`;
+    throw new Error(`${ERR_NOT_FOUND}: Project ${name} not found or not accessible for ${target.ownerLogin}`);
+  }
+  return project;
+}
+
+/**
+ * Create the resolver that applies the create-issue milestone and project conventions.
+ * Lookups are cached per repository (milestones) and per owner (projects) so a run that
+ * creates several issues queries each only once.
+ *
+ * @param {{ milestone?: string, project?: string, githubClient: Object, projectClient: Object }} options
+ */
+function createIssueConventions({ milestone = "", project = "", githubClient, projectClient }) {
+  /** @type {Map<string, Promise<MilestoneCandidate|null>>} */
+  const milestoneCache = new Map();
+  /** @type {Map<string, Promise<{ id: string, number: number, title: string }>>} */
+  const projectCache = new Map();
+
+  /**
+   * @param {string} owner
+   * @param {string} repo
+   * @returns {Promise<MilestoneCandidate|null>}
+   */
+  async function lookupMilestone(owner, repo) {
+    if (/^\d+$/.test(milestone)) {
+      return { number: parseInt(milestone, 10), title: milestone };
+    }
+    const state = milestone === NEXT_OPEN_MILESTONE ? "open" : "all";
+    /** @type {MilestoneCandidate[]} */
+    const milestones = await githubClient.paginate(githubClient.rest.issues.listMilestones, { owner, repo, state, per_page: 100 });
+    if (milestone === NEXT_OPEN_MILESTONE) {
+      return selectNextOpenMilestone(milestones);
+    }
+    return milestones.find(m => m.title === milestone && m.state !== "closed") || milestones.find(m => m.title === milestone) || null;
+  }
+
+  return {
+    milestone,
+    project,
+
+    /**
+     * Resolve the configured milestone for a repository. Returns null, after logging a
+     * warning, when no milestone is configured or it cannot be found.
+     *
+     * @param {string} owner
+     * @param {string} repo
+     * @returns {Promise<MilestoneCandidate|null>}
+     */
+    async resolveMilestone(owner, repo) {
+      if (!milestone) {
+        return null;
+      }
+      const key = `${owner}/${repo}`;
+      if (!milestoneCache.has(key)) {
+        milestoneCache.set(key, lookupMilestone(owner, repo));
+      }
+      try {
+        const found = await milestoneCache.get(key);
+        if (!found) {
+          core.warning(`Milestone "${milestone}" not found in ${key}; creating the issue without a milestone`);
+        }
+        return found ?? null;
+      } catch (error) {
+        core.warning(`Failed to look up milestone "${milestone}" in ${key}: ${getErrorMessage(error)}`);
+        return null;
+      }
+    },
+
+    /**
+     * Add a created issue to the configured project.
+     *
+     * @param {string} repoOwner - Owner of the repository the issue was created in
+     * @param {{ node_id: string }} issue - Created issue
+     * @returns {Promise<{ id: string, number: number, title: string }|null>} The project, or null when none is configured
+     */
+    async addToProject(repoOwner, issue) {
+      if (!project) {
+        return null;
+      }
+      const target = parseProjectTarget(project, repoOwner);
+      const key = `${target.ownerLogin}::${project}`;
+      if (!projectCache.has(key)) {
+        projectCache.set(key, findProjectV2(projectClient, target));
+      }
+      const found = await /** @type {Promise<{ id: string, number: number, title: string }>} */ (projectCache.get(key));
+      await projectClient.graphql(
+        `mutation($projectId: ID!, $contentId: ID!) {
+          addProjectV2ItemById(input: { projectId: $projectId, contentId: $contentId }) {
+            item {
+              id
+            }
+          }
+        }`,
+        { projectId: found.id, contentId: issue.node_id }
+      );
+      return found;
+    },
+  };
+}
+
+module.exports = {
+  NEXT_OPEN_MILESTONE,
+  selectNextOpenMilestone,
+  parseProjectTarget,
+  findProjectV2,
+  createIssueConventions,
+};
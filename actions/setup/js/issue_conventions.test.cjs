@@ -0,0 +1,81 @@
+// @ts-check
+
+import { describe, it, expect, vi } from "vitest";
+import { selectNextOpenMilestone, parseProjectTarget, createIssueConventions } from "./issue_conventions.cjs";
+
+// Mock globals
+global.core = {
+  info: vi.fn(),
+  warning: vi.fn(),
+  error: vi.fn(),
+};
+
+describe("issue_conventions", () => {
+  describe("selectNextOpenMilestone", () => {
+    it("selects the open milestone due soonest", () => {
+      const milestones = [
+        { number: 1, title: "v1", state: "closed", due_on: "2026-01-01T00:00:00Z" },
+        { number: 3, title: "v3", state: "open", due_on: "2026-12-01T00:00:00Z" },
+        { number: 2, title: "v2", state: "open", due_on: "2026-11-01T00:00:00Z" },
+      ];
+      expect(selectNextOpenMilestone(milestones)?.title).toBe("v2");
+    });
+
+    it("falls back to the lowest-numbered milestone without a due date", () => {
+      const milestones = [
+        { number: 9, title: "Later", state: "open", due_on: null },
+        { number: 5, title: "Backlog", state: "open", due_on: null },
+      ];
+      expect(selectNextOpenMilestone(milestones)?.title).toBe("Backlog");
+    });
+
+    it("returns null when no milestone is open", () => {
+      expect(selectNextOpenMilestone([])).toBeNull();
+      expect(selectNextOpenMilestone([{ number: 1, title: "v1", state: "closed" }])).toBeNull();
+    });
+  });
+
+  describe("parseProjectTarget", () => {
+    it("parses project URLs, numbers, and titles", () => {
+      expect(parseProjectTarget("https://github.com/orgs/acme/projects/42", "octo")).toEqual({ ownerLogin: "acme", scope: "orgs", number: 42 });
+      expect(parseProjectTarget("7", "octo")).toEqual({ ownerLogin: "octo", number: 7 });
+      expect(parseProjectTarget("Roadmap", "octo")).toEqual({ ownerLogin: "octo", title: "Roadmap" });
+    });
+
+    it("rejects URLs that are not project URLs", () => {
+      expect(() => parseProjectTarget("https://github.com/acme/repo", "octo")).toThrow(/Invalid project URL/);
+    });
+  });
+
+  describe("createIssueConventions", () => {
+    it("looks up a milestone title once per repository", async () => {
+      const githubClient = {
+        rest: { issues: { listMilestones: vi.fn() } },
+        paginate: vi.fn().mockResolvedValue([
+          { number: 1, title: "v2.0", state: "closed" },
+          { number: 4, title: "v2.0", state: "open" },
+        ]),
+      };
+      const conventions = createIssueConventions({ milestone: "v2.0", githubClient, projectClient: githubClient });
+
+      expect((await conventions.resolveMilestone("o", "r"))?.number).toBe(4);
+      expect((await conventions.resolveMilestone("o", "r"))?.number).toBe(4);
+      expect(githubClient.paginate).toHaveBeenCalledTimes(1);
+    });
+
+    it("uses milestone numbers without a lookup", async () => {
+      const githubClient = { paginate: vi.fn() };
+      const conventions = createIssueConventions({ milestone: "12", githubClient, projectClient: githubClient });
+
+      expect((await conventions.resolveMilestone("o", "r"))?.number).toBe(12);
+      expect(githubClient.paginate).not.toHaveBeenCalled();
+    });
+
+    it("fails to add an issue to a project that is not found", async () => {
+      const projectClient = { graphql: vi.fn().mockResolvedValue({ repositoryOwner: { projectsV2: { nodes: [{ id: "P", number: 1, title: "Roadmap", closed: true }] } } }) };
+      const conventions = createIssueConventions({ project: "Roadmap", githubClient: projectClient, projectClient });
+
+      await expect(conventions.addToProject("o", { node_id: "I_1" })).rejects.toThrow(/Project "Roadmap" not found/);
+    });
+  });
+});
@@ -0,0 +1,188 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+const { normalizeCloseOlderKey } = require("./generate_footer.cjs");
+
+/** Number of open issues requested per dedupe search page */
+const DEDUPE_SEARCH_PER_PAGE = 100;
+
+/** Maximum number of search pages inspected; the search API returns at most 1000 results */
+const DEDUPE_SEARCH_MAX_PAGES = 10;
+
+/**
+ * @typedef {Object} IssueDedupeConfig
+ * @property {boolean} enabled - Whether cross-run deduplication is active
+ * @property {RegExp|null} titlePattern - Pattern an existing issue title must match
+ * @property {string[]} labels - Labels an existing issue must carry (all of them)
+ * @property {string} fingerprint - Normalized fingerprint embedded as a body marker
+ */
+
+/**
+ * @typedef {Object} DedupeCandidate
+ * @property {number} number
+ * @property {string} html_url
+ * @property {string} title
+ * @property {string} [body]
+ * @property {Array<string|{name?: string}>} [labels]
+ */
+
+/**
+ * Parse the create-issue `dedupe` handler config.
+ *
+ * @param {unknown} value - Raw `dedupe` object from the handler config
+ * @returns {IssueDedupeConfig}
+ */
+function parseIssueDedupeConfig(value) {
+  /** @type {IssueDedupeConfig} */
+  const disabled = { enabled: false, titlePattern: null, labels: [], fingerprint: "" };
+  if (!value || typeof value !== "object" || Array.isArray(value)) {
+    return disabled;
+  }
+  const raw = /** @type {Record<string, unknown>} */ value;
+
+  let titlePattern = null;
+  if (typeof raw.title === "string" && raw.title.trim() !== "") {
+    try {
+      titlePattern = new RegExp(raw.title);
+    } catch (error) {
+      throw new Error(`dedupe.title is not a valid regular expression: ${raw.title}`, { cause: error });
+    }
+  }
+
+  const labels = (Array.isArray(raw.labels) ? raw.labels : [])
+    .map(label => String(label).trim())
+    .filter(Boolean)
+    .filter((label, index, arr) => arr.indexOf(label) === index);
+
+  const rawFingerprint = typeof raw.fingerprint === "string" ? raw.fingerprint : "";
+  const fingerprint = rawFingerprint ? normalizeCloseOlderKey(rawFingerprint) : "";
+  if (rawFingerprint && !fingerprint) {
+    throw new Error(`dedupe.fingerprint "${rawFingerprint}" is invalid: it must contain at least one alphanumeric character after normalization`);
+  }
+
+  if (!titlePattern && labels.length === 0 && !fingerprint) {
+    return disabled;
+  }
+  return { enabled: true, titlePattern, labels, fingerprint };
+}
+
+/**
+ * Generates the XML comment marker that identifies issues by dedupe fingerprint.
+ *
+ * @param {string} fingerprint - Normalized fingerprint
+ * @returns {string}
+ */
+function generateDedupeFingerprintMarker(fingerprint) {
+  return `<!-- gh-aw-dedupe: ${fingerprint} -->`;
+}
+
+/**
+ * Describe the configured dedupe criteria for logging.
+ *
+ * @param {IssueDedupeConfig} dedupe
+ * @returns {string}
+ */
+function describeIssueDedupe(dedupe) {
+  const parts = [];
+  if (dedupe.titlePattern) {
+    parts.push(`title matches /${dedupe.titlePattern.source}/`);
+  }
+  if (dedupe.labels.length > 0) {
+    parts.push(`labels include ${dedupe.labels.join(", ")}`);
+  }
+  if (dedupe.fingerprint) {
+    parts.push(`fingerprint "${dedupe.fingerprint}"`);
+  }
+  return parts.join(" and ");
+}
+
+/**
+ * Check whether an existing issue satisfies every configured dedupe criterion.
+ *
+ * @param {DedupeCandidate} issue
+ * @param {IssueDedupeConfig} dedupe
+ * @returns {boolean}
+ */
+function matchesIssueDedupe(issue, dedupe) {
+  if (!dedupe.enabled) {
+    return false;
+  }
+  if (dedupe.titlePattern && !dedupe.titlePattern.test(String(issue.title ?? ""))) {
+    return false;
+  }
+  if (dedupe.labels.length > 0) {
+    const issueLabels = new Set((issue.labels || []).map(label => (typeof label === "string" ? label : String(label?.name ?? ""))).map(label => label.toLowerCase()));
+    if (!dedupe.labels.every(label => issueLabels.has(label.toLowerCase()))) {
+      return false;
+    }
+  }
+  if (dedupe.fingerprint && !String(issue.body ?? "").includes(generateDedupeFingerprintMarker(dedupe.fingerprint))) {
+    return false;
+  }
+  return true;
+}
+
+/**
+ * Build the search query used to find open issues matching the dedupe criteria.
+ * Title patterns cannot be expressed in search syntax, so they are applied locally.
+ *
+ * @param {string} owner
+ * @param {string} repo
+ * @param {IssueDedupeConfig} dedupe
+ * @returns {string}
+ */
+function buildIssueDedupeSearchQuery(owner, repo, dedupe) {
+  let query = `repo:${owner}/${repo} is:issue is:open`;
+  for (const label of dedupe.labels) {
+    query += ` label:"${label.replace(/"/g, "")}"`;
+  }
+  if (dedupe.fingerprint) {
+    query += ` "gh-aw-dedupe: ${dedupe.fingerprint}" in:body`;
+  }
+  return query;
+}
+
+/**
+ * Search for an open issue in the repository that matches the dedupe criteria.
+ * Labels and fingerprints narrow the search server-side; title patterns are applied
+ * locally, so results are paged (most recently updated first) until a match is found
+ * or DEDUPE_SEARCH_MAX_PAGES pages have been inspected.
+ *
+ * @param {any} githubClient - Authenticated GitHub client
+ * @param {string} owner
+ * @param {string} repo
+ * @param {IssueDedupeConfig} dedupe
+ * @returns {Promise<DedupeCandidate|null>}
+ */
+async function findIssueDedupeMatch(githubClient, owner, repo, dedupe) {
+  const query = buildIssueDedupeSearchQuery(owner, repo, dedupe);
+  core.info(`Searching for duplicate issues with query: ${query}`);
+  for (let page = 1; page <= DEDUPE_SEARCH_MAX_PAGES; page++) {
+    const response = await githubClient.rest.search.issuesAndPullRequests({
+      q: query,
+      per_page: DEDUPE_SEARCH_PER_PAGE,
+      page,
+      sort: "updated",
+      order: "desc",
+    });
+    const items = Array.isArray(response?.data?.items) ? response.data.items : [];
+    const match = items.find(item => !item.pull_request && item.state !== "closed" && matchesIssueDedupe(item, dedupe));
+    if (match) {
+      return match;
+    }
+    if (items.length < DEDUPE_SEARCH_PER_PAGE) {
+      return null;
+    }
+  }
+  core.warning(`No duplicate found in the ${DEDUPE_SEARCH_PER_PAGE * DEDUPE_SEARCH_MAX_PAGES} most recently updated open issues; older issues are not checked`);
+  return null;
+}
+
+module.exports = {
+  parseIssueDedupeConfig,
+  generateDedupeFingerprintMarker,
+  describeIssueDedupe,
+  matchesIssueDedupe,
+  buildIssueDedupeSearchQuery,
+  findIssueDedupeMatch,
+};
@@ -0,0 +1,126 @@
+// @ts-check
+
+import { describe, it, expect, beforeEach, vi } from "vitest";
+import { parseIssueDedupeConfig, generateDedupeFingerprintMarker, describeIssueDedupe, matchesIssueDedupe, buildIssueDedupeSearchQuery, findIssueDedupeMatch } from "./issue_dedupe.cjs";
+
+// Mock globals
+global.core = {
+  info: vi.fn(),
+  warning: vi.fn(),
+  error: vi.fn(),
+};
+
+describe("issue_dedupe", () => {
+  describe("parseIssueDedupeConfig", () => {
+    it("is disabled when not configured", () => {
+      expect(parseIssueDedupeConfig(undefined).enabled).toBe(false);
+      expect(parseIssueDedupeConfig({}).enabled).toBe(false);
+      expect(parseIssueDedupeConfig([]).enabled).toBe(false);
+    });
+
+    it("parses all criteria", () => {
+      const dedupe = parseIssueDedupeConfig({ title: "^\\[scan\\]", labels: ["security", " security ", "automated"], fingerprint: "Nightly Scan" });
+      expect(dedupe.enabled).toBe(true);
+      expect(dedupe.titlePattern?.test("[scan] finding")).toBe(true);
+      expect(dedupe.labels).toEqual(["security", "automated"]);
+      expect(dedupe.fingerprint).toBe("nightly-scan");
+    });
+
+    it("rejects invalid title patterns", () => {
+      expect(() => parseIssueDedupeConfig({ title: "[scan" })).toThrow(/not a valid regular expression/);
+    });
+
+    it("rejects fingerprints without alphanumeric characters", () => {
+      expect(() => parseIssueDedupeConfig({ fingerprint: "---" })).toThrow(/fingerprint/);
+    });
+  });
+
+  describe("matchesIssueDedupe", () => {
+    const dedupe = parseIssueDedupeConfig({ title: "^\\[scan\\]", labels: ["Security"], fingerprint: "nightly" });
+    const body = `Findings\n\n${generateDedupeFingerprintMarker("nightly")}`;
+
+    it("matches when every criterion is satisfied", () => {
+      expect(matchesIssueDedupe({ number: 1, html_url: "", title: "[scan] 3 findings", body, labels: [{ name: "security" }] }, dedupe)).toBe(true);
+    });
+
+    it("accepts string labels", () => {
+      expect(matchesIssueDedupe({ number: 1, html_url: "", title: "[scan] 3 findings", body, labels: ["security"] }, dedupe)).toBe(true);
+    });
+
+    it("rejects issues missing a criterion", () => {
+      expect(matchesIssueDedupe({ number: 1, html_url: "", title: "Other", body, labels: ["security"] }, dedupe)).toBe(false);
+      expect(matchesIssueDedupe({ number: 1, html_url: "", title: "[scan] x", body, labels: [] }, dedupe)).toBe(false);
+      expect(matchesIssueDedupe({ number: 1, html_url: "", title: "[scan] x", body: "no marker", labels: ["security"] }, dedupe)).toBe(false);
+    });
+  });
+
+  describe("buildIssueDedupeSearchQuery", () => {
+    it("includes labels and fingerprint marker", () => {
+      const dedupe = parseIssueDedupeConfig({ labels: ["security", "needs triage"], fingerprint: "nightly" });
+      expect(buildIssueDedupeSearchQuery("owner", "repo", dedupe)).toBe('repo:owner/repo is:issue is:open label:"security" label:"needs triage" "gh-aw-dedupe: nightly" in:body');
+    });
+  });
+
+  describe("describeIssueDedupe", () => {
+    it("lists the configured criteria", () => {
+      const dedupe = parseIssueDedupeConfig({ title: "^scan", labels: ["security"] });
+      expect(describeIssueDedupe(dedupe)).toBe("title matches /^scan/ and labels include security");
+    });
+  });
+
+  describe("findIssueDedupeMatch", () => {
+    let mockGithub;
+
+    beforeEach(() => {
+      vi.clearAllMocks();
+      mockGithub = {
+        rest: {
+          search: {
+            issuesAndPullRequests: vi.fn(),
+          },
+        },
+      };
+    });
+
+    it("returns the first open issue matching locally", async () => {
+      mockGithub.rest.search.issuesAndPullRequests.mockResolvedValue({
+        data: {
+          items: [
+            { number: 10, title: "[scan] pr", html_url: "u10", pull_request: {}, labels: [] },
+            { number: 11, title: "Unrelated", html_url: "u11", labels: [] },
+            { number: 12, title: "[scan] findings", html_url: "u12", labels: [] },
+          ],
+        },
+      });
+      const dedupe = parseIssueDedupeConfig({ title: "^\\[scan\\]" });
+      const match = await findIssueDedupeMatch(mockGithub, "owner", "repo", dedupe);
+      expect(match?.number).toBe(12);
+      expect(mockGithub.rest.search.issuesAndPullRequests).toHaveBeenCalledWith(expect.objectContaining({ q: "repo:owner/repo is:issue is:open" }));
+    });
+
+    it("returns null when nothing matches", async () => {
+      mockGithub.rest.search.issuesAndPullRequests.mockResolvedValue({ data: { items: [] } });
+      const dedupe = parseIssueDedupeConfig({ labels: ["security"] });
+      expect(await findIssueDedupeMatch(mockGithub, "owner", "repo", dedupe)).toBeNull();
+    });
+
+    it("pages past full result pages until a title match is found", async () => {
+      const unrelated = Array.from({ length: 100 }, (_, i) => ({ number: 100 + i, title: `Unrelated ${i}`, html_url: `u${i}`, labels: [] }));
+      mockGithub.rest.search.issuesAndPullRequests.mockResolvedValueOnce({ data: { items: unrelated } }).mockResolvedValueOnce({ data: { items: [{ number: 7, title: "[scan] old findings", html_url: "u7", labels: [] }] } });
+      const dedupe = parseIssueDedupeConfig({ title: "^\\[scan\\]" });
+      const match = await findIssueDedupeMatch(mockGithub, "owner", "repo", dedupe);
+      expect(match?.number).toBe(7);
+      expect(mockGithub.rest.search.issuesAndPullRequests).toHaveBeenCalledTimes(2);
+      expect(mockGithub.rest.search.issuesAndPullRequests).toHaveBeenLastCalledWith(expect.objectContaining({ page: 2, per_page: 100 }));
+    });
+
+    it("stops after the page limit and warns", async () => {
+      const unrelated = Array.from({ length: 100 }, (_, i) => ({ number: 100 + i, title: `Unrelated ${i}`, html_url: `u${i}`, labels: [] }));
+      mockGithub.rest.search.issuesAndPullRequests.mockResolvedValue({ data: { items: unrelated } });
+      const dedupe = parseIssueDedupeConfig({ title: "^\\[scan\\]" });
+      expect(await findIssueDedupeMatch(mockGithub, "owner", "repo", dedupe)).toBeNull();
+      expect(mockGithub.rest.search.issuesAndPullRequests).toHaveBeenCalledTimes(10);
+      expect(global.core.warning).toHaveBeenCalledWith(expect.stringContaining("1000 most recently updated open issues"));
+    });
+  });
+});
@@ -0,0 +1,195 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+/** Placeholder GitHub renders for form fields left empty */
+const NO_RESPONSE = "_No response_";
+
+/**
+ * @typedef {Object} IssueFormField
+ * @property {string} id - Key the agent uses in the create_issue form object
+ * @property {string} type - input, textarea, dropdown or checkboxes
+ * @property {string} label - Heading rendered above the value
+ * @property {boolean} required - Whether a value must be provided
+ * @property {string[]} options - Dropdown or checkbox options
+ * @property {boolean} multiple - Dropdown allows several options
+ * @property {string} render - Code block language for textarea values
+ * @property {string[]} requiredOptions - Checkbox options that must be checked
+ */
+
+/**
+ * @typedef {Object} IssueForm
+ * @property {string} file - Issue form file name in .github/ISSUE_TEMPLATE
+ * @property {string} title - Default issue title from the form
+ * @property {string[]} labels - Labels the form applies
+ * @property {IssueFormField[]} fields - Input fields in form order
+ */
+
+/**
+ * @param {unknown} value
+ * @returns {string[]}
+ */
+function toStringList(value) {
+  return (Array.isArray(value) ? value : [])
+    .map(item => String(item).trim())
+    .filter(Boolean)
+    .filter((item, index, arr) => arr.indexOf(item) === index);
+}
+
+/**
+ * Parse the create-issue `form` handler config compiled from the issue form YAML.
+ *
+ * @param {unknown} value - Raw `form` object from the handler config
+ * @returns {IssueForm|null} The form, or null when create-issue.form is not configured
+ */
+function parseIssueFormConfig(value) {
+  if (!value || typeof value !== "object" || Array.isArray(value)) {
+    return null;
+  }
+  const raw = /** @type {Record<string, unknown>} */ value;
+  const file = typeof raw.file === "string" ? raw.file : "";
+
+  /** @type {IssueFormField[]} */
+  const fields = [];
+  for (const entry of Array.isArray(raw.fields) ? raw.fields : []) {
+    if (!entry || typeof entry !== "object") {
+      continue;
+    }
+    const field = /** @type {Record<string, unknown>} */ entry;
+    if (typeof field.id !== "string" || !field.id || typeof field.label !== "string") {
+      throw new Error(`form "${file}" has a field without an id or label`);
+    }
+    fields.push({
+      id: field.id,
+      type: typeof field.type === "string" ? field.type : "input",
+      label: field.label,
+      required: field.required === true,
+      options: toStringList(field.options),
+      multiple: field.multiple === true,
+      render: typeof field.render === "string" ? field.render : "",
+      requiredOptions: toStringList(field.required_options),
+    });
+  }
+  if (fields.length === 0) {
+    throw new Error(`form "${file}" has no fields`);
+  }
+
+  return {
+    file,
+    title: typeof raw.title === "string" ? raw.title : "",
+    labels: toStringList(raw.labels),
+    fields,
+  };
+}
+
+/**
+ * Normalize the agent-provided value of one field.
+ *
+ * @param {IssueFormField} field
+ * @param {unknown} value
+ * @returns {string|string[]} A string for input, textarea and single dropdowns; a list otherwise
+ */
+function normalizeFieldValue(field, value) {
+  const isList = field.type === "checkboxes" || (field.type === "dropdown" && field.multiple);
+  if (isList) {
+    if (value === undefined || value === null) {
+      return [];
+    }
+    const items = Array.isArray(value) ? value : [value];
+    if (items.some(item => typeof item !== "string")) {
+      throw new Error(`form field "${field.id}" must be a list of strings`);
+    }
+    return toStringList(items);
+  }
+  if (value === undefined || value === null) {
+    return "";
+  }
+  if (typeof value !== "string" && typeof value !== "number" && typeof value !== "boolean") {
+    throw new Error(`form field "${field.id}" must be a string`);
+  }
+  return String(value).trim();
+}
+
+/**
+ * Validate the agent-provided form values and normalize them by field id.
+ * Required fields, dropdown options and required checkboxes are enforced the same way
+ * GitHub enforces them when a person submits the form.
+ *
+ * @param {IssueForm} form
+ * @param {unknown} values - The `form` object from the create_issue message
+ * @returns {Map<string, string|string[]>}
+ */
+function validateIssueFormValues(form, values) {
+  if (values !== undefined && values !== null && (typeof values !== "object" || Array.isArray(values))) {
+    throw new Error(`create_issue 'form' must be an object keyed by the field ids of "${form.file}"`);
+  }
+  const raw = /** @type {Record<string, unknown>} */ values ?? {};
+
+  const knownIds = new Set(form.fields.map(field => field.id));
+  const unknown = Object.keys(raw).filter(id => !knownIds.has(id));
+  if (unknown.length > 0) {
+    throw new Error(`unknown form field(s) ${unknown.map(id => `"${id}"`).join(", ")} for "${form.file}". Available fields: ${[...knownIds].join(", ")}`);
+  }
+
+  /** @type {Map<string, string|string[]>} */
+  const normalized = new Map();
+  for (const field of form.fields) {
+    const value = normalizeFieldValue(field, raw[field.id]);
+    const isEmpty = Array.isArray(value) ? value.length === 0 : value === "";
+    if (field.required && isEmpty) {
+      throw new Error(`form field "${field.id}" (${field.label}) is required by "${form.file}"`);
+    }
+    if (!isEmpty && field.options.length > 0 && (field.type === "dropdown" || field.type === "checkboxes")) {
+      const invalid = (Array.isArray(value) ? value : [value]).filter(option => !field.options.includes(option));
+      if (invalid.length > 0) {
+        throw new Error(`invalid option(s) ${invalid.map(option => `"${option}"`).join(", ")} for form field "${field.id}". Available options: ${field.options.join(", ")}`);
+      }
+    }
+    const missing = field.requiredOptions.filter(option => !(Array.isArray(value) && value.includes(option)));
+    if (missing.length > 0) {
+      throw new Error(`form field "${field.id}" must check ${missing.map(option => `"${option}"`).join(", ")}`);
+    }
+    normalized.set(field.id, value);
+  }
+  return normalized;
+}
+
+/**
+ * Render the issue body in the format GitHub produces for issue form submissions,
+ * so automation that parses form responses works on agent-created issues.
+ * The free-form body, when present, is placed above the form sections.
+ *
+ * @param {IssueForm} form
+ * @param {Map<string, string|string[]>} values - Values returned by validateIssueFormValues
+ * @param {string} body - Free-form issue body from the agent
+ * @returns {string}
+ */
+function renderIssueFormBody(form, values, body) {
+  const sections = [];
+  if (body.trim()) {
+    sections.push(body.trim());
+  }
+  for (const field of form.fields) {
+    const value = values.get(field.id) ?? "";
+    let rendered;
+    if (field.type === "checkboxes") {
+      const checked = Array.isArray(value) ? value : [];
+      rendered = field.options.map(option => `- [${checked.includes(option) ? "X" : " "}] ${option}`).join("\n");
+    } else if (Array.isArray(value)) {
+      rendered = value.length > 0 ? value.join(", ") : NO_RESPONSE;
+    } else if (!value) {
+      rendered = NO_RESPONSE;
+    } else if (field.render) {
+      rendered = "```" + field.render + "\n" + value + "\n```";
+    } else {
+      rendered = value;
+    }
+    sections.push(`### ${field.label}\n\n${rendered}`);
+  }
+  return sections.join("\n\n");
+}
+
+module.exports = {
+  parseIssueFormConfig,
+  validateIssueFormValues,
+  renderIssueFormBody,
+};
@@ -0,0 +1,111 @@
+// @ts-check
+
+import { describe, it, expect } from "vitest";
+import { parseIssueFormConfig, validateIssueFormValues, renderIssueFormBody } from "./issue_form.cjs";
+
+const formConfig = {
+  file: "bug_report.yml",
+  title: "[Bug]: ",
+  labels: ["bug", "triage"],
+  fields: [
+    { id: "what-happened", type: "textarea", label: "What happened?", required: true },
+    { id: "version", type: "dropdown", label: "Version", required: true, options: ["1.0.0", "2.0.0"] },
+    { id: "browsers", type: "dropdown", label: "Browsers", multiple: true, options: ["Firefox", "Chrome", "Safari"] },
+    { id: "logs", type: "textarea", label: "Relevant log output", render: "shell" },
+    { id: "terms", type: "checkboxes", label: "Code of Conduct", required: true, options: ["I agree to follow the Code of Conduct", "I searched existing issues"], required_options: ["I agree to follow the Code of Conduct"] },
+  ],
+};
+
+describe("issue_form", () => {
+  describe("parseIssueFormConfig", () => {
+    it("returns null when not configured", () => {
+      expect(parseIssueFormConfig(undefined)).toBeNull();
+      expect(parseIssueFormConfig([])).toBeNull();
+    });
+
+    it("parses the compiled form", () => {
+      const form = parseIssueFormConfig(formConfig);
+      expect(form?.file).toBe("bug_report.yml");
+      expect(form?.title).toBe("[Bug]: ");
+      expect(form?.labels).toEqual(["bug", "triage"]);
+      expect(form?.fields.map(field => field.id)).toEqual(["what-happened", "version", "browsers", "logs", "terms"]);
+      expect(form?.fields[4].requiredOptions).toEqual(["I agree to follow the Code of Conduct"]);
+    });
+
+    it("rejects forms without fields", () => {
+      expect(() => parseIssueFormConfig({ file: "empty.yml", fields: [] })).toThrow(/has no fields/);
+    });
+  });
+
+  describe("validateIssueFormValues", () => {
+    const form = /** @type {NonNullable<ReturnType<typeof parseIssueFormConfig>>} */ (parseIssueFormConfig(formConfig));
+    const valid = {
+      "what-happened": "The app crashes on start.",
+      version: "2.0.0",
+      terms: ["I agree to follow the Code of Conduct"],
+    };
+
+    it("accepts valid values", () => {
+      const values = validateIssueFormValues(form, { ...valid, browsers: ["Chrome"] });
+      expect(values.get("version")).toBe("2.0.0");
+      expect(values.get("browsers")).toEqual(["Chrome"]);
+      expect(values.get("logs")).toBe("");
+    });
+
+    it("requires required fields", () => {
+      expect(() => validateIssueFormValues(form, { ...valid, "what-happened": "  " })).toThrow(/"what-happened" \(What happened\?\) is required/);
+      expect(() => validateIssueFormValues(form, undefined)).toThrow(/is required/);
+    });
+
+    it("rejects options the form does not offer", () => {
+      expect(() => validateIssueFormValues(form, { ...valid, version: "3.0.0" })).toThrow(/invalid option\(s\) "3.0.0" for form field "version"/);
+      expect(() => validateIssueFormValues(form, { ...valid, browsers: ["Edge"] })).toThrow(/Available options: Firefox, Chrome, Safari/);
+    });
+
+    it("requires required checkboxes", () => {
+      expect(() => validateIssueFormValues(form, { ...valid, terms: ["I searched existing issues"] })).toThrow(/must check "I agree to follow the Code of Conduct"/);
+    });
+
+    it("rejects unknown fields and non-object values", () => {
+      expect(() => validateIssueFormValues(form, { ...valid, severity: "high" })).toThrow(/unknown form field\(s\) "severity"/);
+      expect(() => validateIssueFormValues(form, "text")).toThrow(/must be an object/);
+    });
+  });
+
+  describe("renderIssueFormBody", () => {
+    const form = /** @type {NonNullable<ReturnType<typeof parseIssueFormConfig>>} */ (parseIssueFormConfig(formConfig));
+
+    it("renders sections in the issue form format", () => {
+      const values = validateIssueFormValues(form, {
+        "what-happened": "The app crashes on start.",
+        version: "2.0.0",
+        browsers: ["Firefox", "Chrome"],
+        logs: "panic: boom",
+        terms: ["I agree to follow the Code of Conduct"],
+      });
+      const body = renderIssueFormBody(form, values, "Found by the nightly run.\n");
+      expect(body).toBe(
+        [
+          "Found by the nightly run.",
+          "### What happened?\n\nThe app crashes on start.",
+          "### Version\n\n2.0.0",
+          "### Browsers\n\nFirefox, Chrome",
+          "### Relevant log output\n\n```shell\npanic: boom\n```",
+          "### Code of Conduct\n\n- [X] I agree to follow the Code of Conduct\n- [ ] I searched existing issues",
+        ].join("\n\n")
+      );
+    });
+
+    it("marks empty optional fields as no response", () => {
+      const values = validateIssueFormValues(form, {
+        "what-happened": "Crash",
+        version: "1.0.0",
+        terms: ["I agree to follow the Code of Conduct"],
+      });
+      const body = renderIssueFormBody(form, values, "");
+      expect(body.startsWith("### What happened?")).toBe(true);
+      expect(body).toContain("### Browsers\n\n_No response_");
+      expect(body).toContain("### Relevant log output\n\n_No response_");
+    });
+  });
+});
@@ -0,0 +1,143 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+/**
+ * @typedef {import('./types/handler-factory').HandlerFactoryFunction} HandlerFactoryFunction
+ */
+
+const { getErrorMessage } = require("./error_helpers.cjs");
+const { resolveTarget, checkRequiredFilter } = require("./safe_output_helpers.cjs");
+const { resolveTargetRepoConfig, resolveAndValidateRepo } = require("./repo_helpers.cjs");
+const { logStagedPreviewInfo } = require("./staged_preview.cjs");
+const { createAuthenticatedGitHubClient } = require("./handler_auth.cjs");
+const { createCountGatedHandler } = require("./handler_scaffold.cjs");
+
+/** Lock reasons accepted by the GitHub REST API. */
+const LOCK_REASONS = ["off-topic", "too heated", "resolved", "spam"];
+
+/**
+ * Normalize an agent-supplied lock reason to the REST API form
+ * ("too_heated" and "Too Heated" become "too heated").
+ * @param {unknown} reason
+ * @returns {string}
+ */
+function normalizeLockReason(reason) {
+  if (typeof reason !== "string") return "";
+  const normalized = reason.trim().toLowerCase().replace(/_/g, " ");
+  return normalized === "off topic" ? "off-topic" : normalized;
+}
+
+/**
+ * @typedef {Object} LockConversationHandlerOptions
+ * @property {string} handlerType - Safe output type (lock_issue or lock_pull_request)
+ * @property {"issue" | "pull request"} entity - Kind of conversation the handler locks
+ */
+
+/**
+ * Create the handler factory shared by lock_issue and lock_pull_request.
+ * Both lock the conversation through the issues API; the entity selects which
+ * kind of item the handler accepts and how the target is resolved.
+ * @param {LockConversationHandlerOptions} options
+ * @returns {HandlerFactoryFunction}
+ */
+function createLockConversationHandler({ handlerType, entity }) {
+  const isIssue = entity === "issue";
+  return createCountGatedHandler({
+    handlerType,
+    setup: async (config, maxCount, isStaged) => {
+      const targetConfig = config.target || "triggering";
+      const allowedReasons = (Array.isArray(config.allowed_reasons) ? config.allowed_reasons : []).map(normalizeLockReason);
+      const { defaultTargetRepo, allowedRepos } = resolveTargetRepoConfig(config);
+      const githubClient = await createAuthenticatedGitHubClient(config);
+      const requiredLabels = Array.isArray(config.required_labels) ? config.required_labels : [];
+      const requiredTitlePrefix = config.required_title_prefix || "";
+
+      core.info(`Lock ${entity} configuration: max=${maxCount}, target=${targetConfig}`);
+      if (allowedReasons.length > 0) core.info(`Allowed lock reasons: ${allowedReasons.join(", ")}`);
+      if (requiredLabels.length > 0) core.info(`Required labels (all): ${requiredLabels.join(", ")}`);
+      if (requiredTitlePrefix) core.info(`Required title prefix: ${requiredTitlePrefix}`);
+      core.info(`Default target repository: ${defaultTargetRepo}`);
+
+      /**
+       * Message handler function that locks a single conversation
+       * @param {Object} message - The lock message to process
+       * @returns {Promise<Object>} Result with success/error status
+       */
+      return async function handleLockConversation(message) {
+        const item = /** @type {any} */ message;
+
+        const reason = normalizeLockReason(item.reason);
+        if (reason && !LOCK_REASONS.includes(reason)) {
+          return { success: false, error: `Invalid lock reason "${item.reason}". Valid reasons: ${LOCK_REASONS.join(", ")}` };
+        }
+        if (allowedReasons.length > 0 && !allowedReasons.includes(reason)) {
+          return { success: false, error: `Lock reason "${reason || "(none)"}" is not allowed. Allowed reasons: ${allowedReasons.join(", ")}` };
+        }
+
+        const targetResult = resolveTarget({
+          targetConfig,
+          item,
+          context,
+          itemType: handlerType,
+          // In resolveTarget conventions, supportsPR=false without supportsIssue means PR-only.
+          supportsPR: false,
+          supportsIssue: isIssue,
+        });
+        if (!targetResult.success) {
+          core.warning(`Skipping ${handlerType}: ${targetResult.error}`);
+          return { success: false, error: targetResult.error, skipped: !targetResult.shouldFail };
+        }
+        const number = targetResult.number;
+
+        const repoResult = resolveAndValidateRepo(item, defaultTargetRepo, allowedRepos, entity);
+        if (!repoResult.success) {
+          core.warning(`Repository validation failed: ${repoResult.error}`);
+          return { success: false, error: repoResult.error };
+        }
+        const repoParts = repoResult.repoParts;
+        const targetRepo = repoResult.repo;
+
+        const filterResult = await checkRequiredFilter(githubClient, repoParts, number, requiredLabels, requiredTitlePrefix, handlerType);
+        if (filterResult) return filterResult;
+
+        if (isStaged) {
+          logStagedPreviewInfo(`Would lock ${entity} #${number} in ${targetRepo}${reason ? ` as ${reason}` : ""}`);
+          return { success: true, staged: true, previewInfo: { number, repo: targetRepo, reason } };
+        }
+
+        try {
+          const { data: conversation } = await githubClient.rest.issues.get({
+            owner: repoParts.owner,
+            repo: repoParts.repo,
+            issue_number: number,
+          });
+          const isPullRequest = Boolean(conversation.pull_request);
+          if (isIssue && isPullRequest) {
+            return { success: false, error: `#${number} in ${targetRepo} is a pull request; use lock_pull_request to lock it` };
+          }
+          if (!isIssue && !isPullRequest) {
+            return { success: false, error: `#${number} in ${targetRepo} is an issue; use lock_issue to lock it` };
+          }
+          if (conversation.locked) {
+            core.info(`${entity} #${number} in ${targetRepo} is already locked`);
+            return { success: true, number, repo: targetRepo, alreadyLocked: true };
+          }
+
+          /** @type {any} */
+          const params = { owner: repoParts.owner, repo: repoParts.repo, issue_number: number };
+          if (reason) params.lock_reason = reason;
+          await githubClient.rest.issues.lock(params);
+
+          core.info(`Locked ${entity} #${number} in ${targetRepo}${reason ? ` as ${reason}` : ""}`);
+          return { success: true, number, repo: targetRepo, reason, url: conversation.html_url };
+        } catch (error) {
+          const errorMessage = getErrorMessage(error);
+          core.error(`Failed to lock ${entity} #${number}: ${errorMessage}`);
+          return { success: false, error: errorMessage };
+        }
+      };
+    },
+  });
+}
+
+module.exports = { createLockConversationHandler, normalizeLockReason, LOCK_REASONS };
@@ -0,0 +1,136 @@
+import { describe, it, expect, beforeEach, vi } from "vitest";
+
+const mockCore = {
+  debug: vi.fn(),
+  info: vi.fn(),
+  warning: vi.fn(),
+  error: vi.fn(),
+  setFailed: vi.fn(),
+  setOutput: vi.fn(),
+  summary: {
+    addRaw: vi.fn().mockReturnThis(),
+    write: vi.fn().mockResolvedValue(),
+  },
+};
+
+const mockContext = {
+  repo: {
+    owner: "test-owner",
+    repo: "test-repo",
+  },
+  eventName: "issues",
+  payload: {
+    issue: {
+      number: 123,
+    },
+  },
+};
+
+const mockGithub = {
+  rest: {
+    issues: {
+      get: vi.fn(),
+      lock: vi.fn(),
+    },
+  },
+};
+
+global.core = mockCore;
+global.context = mockContext;
+global.github = mockGithub;
+
+describe("lock_conversation_helpers", () => {
+  beforeEach(() => {
+    vi.clearAllMocks();
+    delete process.env.GITHUB_REPOSITORY;
+    delete process.env.GH_AW_TARGET_REPO_SLUG;
+    mockContext.eventName = "issues";
+    mockContext.payload = { issue: { number: 123 } };
+  });
+
+  it("normalizes lock reasons to the REST API form", () => {
+    const { normalizeLockReason } = require("./lock_conversation_helpers.cjs");
+    expect(normalizeLockReason("Too_Heated")).toBe("too heated");
+    expect(normalizeLockReason("off_topic")).toBe("off-topic");
+    expect(normalizeLockReason(undefined)).toBe("");
+  });
+
+  it("locks the triggering issue with a reason", async () => {
+    mockGithub.rest.issues.get.mockResolvedValue({ data: { locked: false, html_url: "https://github.com/test-owner/test-repo/issues/123" } });
+    mockGithub.rest.issues.lock.mockResolvedValue({});
+
+    const { main } = require("./lock_issue.cjs");
+    const handler = await main({});
+    const result = await handler({ type: "lock_issue", reason: "too heated" }, {});
+
+    expect(result.success).toBe(true);
+    expect(result.number).toBe(123);
+    expect(mockGithub.rest.issues.lock).toHaveBeenCalledWith({
+      owner: "test-owner",
+      repo: "test-repo",
+      issue_number: 123,
+      lock_reason: "too heated",
+    });
+  });
+
+  it("rejects reasons outside allowed-reasons", async () => {
+    const { main } = require("./lock_issue.cjs");
+    const handler = await main({ allowed_reasons: ["spam"] });
+    const result = await handler({ type: "lock_issue", reason: "resolved" }, {});
+
+    expect(result.success).toBe(false);
+    expect(result.error).toContain("is not allowed");
+    expect(mockGithub.rest.issues.lock).not.toHaveBeenCalled();
+  });
+
+  it("does not lock a pull request through lock_issue", async () => {
+    mockGithub.rest.issues.get.mockResolvedValue({ data: { locked: false, pull_request: {} } });
+
+    const { main } = require("./lock_issue.cjs");
+    const handler = await main({});
+    const result = await handler({ type: "lock_issue" }, {});
+
+    expect(result.success).toBe(false);
+    expect(result.error).toContain("use lock_pull_request");
+    expect(mockGithub.rest.issues.lock).not.toHaveBeenCalled();
+  });
+
+  it("skips conversations that are already locked", async () => {
+    mockGithub.rest.issues.get.mockResolvedValue({ data: { locked: true } });
+
+    const { main } = require("./lock_issue.cjs");
+    const handler = await main({});
+    const result = await handler({ type: "lock_issue" }, {});
+
+    expect(result.success).toBe(true);
+    expect(result.alreadyLocked).toBe(true);
+    expect(mockGithub.rest.issues.lock).not.toHaveBeenCalled();
+  });
+
+  it("locks the pull request given with target '*'", async () => {
+    mockGithub.rest.issues.get.mockResolvedValue({ data: { locked: false, pull_request: {} } });
+    mockGithub.rest.issues.lock.mockResolvedValue({});
+
+    const { main } = require("./lock_pull_request.cjs");
+    const handler = await main({ target: "*" });
+    const result = await handler({ type: "lock_pull_request", pull_request_number: 45 }, {});
+
+    expect(result.success).toBe(true);
+    expect(mockGithub.rest.issues.lock).toHaveBeenCalledWith({
+      owner: "test-owner",
+      repo: "test-repo",
+      issue_number: 45,
+    });
+  });
+
+  it("previews the lock in staged mode", async () => {
+    const { main } = require("./lock_pull_request.cjs");
+    const handler = await main({ target: "*", staged: true });
+    const result = await handler({ type: "lock_pull_request", pull_request_number: 45, reason: "spam" }, {});
+
+    expect(result.success).toBe(true);
+    expect(result.staged).toBe(true);
+    expect(mockGithub.rest.issues.get).not.toHaveBeenCalled();
+    expect(mockGithub.rest.issues.lock).not.toHaveBeenCalled();
+  });
+});
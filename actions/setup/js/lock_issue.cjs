@@ -0,0 +1,17 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+/**
+ * @typedef {import('./types/handler-factory').HandlerFactoryFunction} HandlerFactoryFunction
+ */
+
+const { createLockConversationHandler } = require("./lock_conversation_helpers.cjs");
+
+/**
+ * Main handler factory for lock_issue
+ * Locks issue conversations with an optional reason.
+ * @type {HandlerFactoryFunction}
+ */
+const main = createLockConversationHandler({ handlerType: "lock_issue", entity: "issue" });
+
+module.exports = { main };
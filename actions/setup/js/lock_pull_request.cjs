@@ -0,0 +1,17 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+/**
+ * @typedef {import('./types/handler-factory').HandlerFactoryFunction} HandlerFactoryFunction
+ */
+
+const { createLockConversationHandler } = require("./lock_conversation_helpers.cjs");
+
+/**
+ * Main handler factory for lock_pull_request
+ * Locks pull request conversations with an optional reason.
+ * @type {HandlerFactoryFunction}
+ */
+const main = createLockConversationHandler({ handlerType: "lock_pull_request", entity: "pull request" });
+
+module.exports = { main };
@@ -3,6 +3,7 @@
 
 const { generatePlainTextSummary, generateCopilotCliStyleSummary, wrapAgentLogInSection, formatSafeOutputsPreview } = require("./log_parser_shared.cjs");
 const { getErrorMessage } = require("./error_helpers.cjs");
+const { writeAgentTurns } = require("./agent_turns.cjs");
 const { ERR_API, ERR_CONFIG, ERR_VALIDATION } = require("./error_codes.cjs");
 const INFERENCE_ACCESS_ERROR_PATTERN = /Access denied by policy settings|invalid access to inference/i;
 const CLAUDE_RATE_LIMIT_PATTERN = /rate_limit_error|429 Too Many Requests|"api_error_status"\s*:\s*429|request rejected \(429\)|rate limit/i;
@@ -325,6 +326,12 @@ async function runLogParser(options) {
       }
     }
 
+    // Write the parsed entries as a structured turn log (agent_turns.jsonl) so gh aw logs and
+    // gh aw audit can reuse this run's parse instead of re-parsing the raw engine output.
+    if (logEntries && Array.isArray(logEntries)) {
+      writeAgentTurns(logEntries, { engine: parserName });
+    }
+
     // Read safe outputs file if available
     let safeOutputsContent = "";
     let safeOutputEntriesCount = 0;
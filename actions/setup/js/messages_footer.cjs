@@ -735,4 +735,5 @@ module.exports = {
   getFooterAgentFailureCommentMessage,
   generateFooterWithMessages,
   generateXMLMarker,
+  getAICFromEnv,
 };
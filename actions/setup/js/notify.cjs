@@ -0,0 +1,213 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+/**
+ * @typedef {import('./types/handler-factory').HandlerFactoryFunction} HandlerFactoryFunction
+ */
+
+const { getErrorMessage } = require("./error_helpers.cjs");
+const { logStagedPreviewInfo } = require("./staged_preview.cjs");
+const { isStagedMode } = require("./safe_output_helpers.cjs");
+const { withRetry } = require("./error_recovery.cjs");
+const { sanitizeContent } = require("./sanitize_content.cjs");
+const { renderTemplate } = require("./messages_core.cjs");
+
+/** @type {string} Safe output type handled by this module */
+const HANDLER_TYPE = "notify";
+
+/** @type {Set<string>} Supported webhook payload formats */
+const VALID_FORMATS = new Set(["slack", "teams", "json"]);
+
+/** @type {string} Template used when the workflow does not configure one */
+const DEFAULT_TEMPLATE = "{title}\n{message}\n\n{workflow_name}: {run_url}";
+
+/** @type {number} Timeout for a single webhook request */
+const WEBHOOK_TIMEOUT_MS = 10000;
+
+/**
+ * Returns the environment variable holding the webhook URL for a channel.
+ * Must stay in sync with notifyWebhookEnvVarName in pkg/workflow/notify.go.
+ * @param {string} channel - Channel name from the workflow configuration
+ * @returns {string}
+ */
+function getWebhookEnvVarName(channel) {
+  return `GH_AW_NOTIFY_WEBHOOK_${channel.toUpperCase().replace(/[^A-Z0-9]/g, "_")}`;
+}
+
+/**
+ * Builds the webhook request body for the configured payload format.
+ * @param {string} format - One of VALID_FORMATS
+ * @param {string} text - Rendered notification text
+ * @param {Record<string, string>} templateContext - Values available to the template
+ * @returns {Object}
+ */
+function buildPayload(format, text, templateContext) {
+  switch (format) {
+    case "teams":
+      // Teams Workflows webhooks expect an Adaptive Card wrapped in a message.
+      return {
+        type: "message",
+        attachments: [
+          {
+            contentType: "application/vnd.microsoft.card.adaptive",
+            content: {
+              $schema: "http://adaptivecards.io/schemas/adaptive-card.json",
+              type: "AdaptiveCard",
+              version: "1.4",
+              body: [{ type: "TextBlock", text, wrap: true }],
+            },
+          },
+        ],
+      };
+    case "json":
+      return { text, ...templateContext };
+    default:
+      return { text };
+  }
+}
+
+/**
+ * Main handler factory for notify
+ * Returns a message handler function that posts notify messages to configured webhooks
+ * @type {HandlerFactoryFunction}
+ */
+async function main(config = {}) {
+  // Extract configuration
+  const maxCount = config.max != null ? Number(config.max) : 1;
+  const isStaged = isStagedMode(config);
+  const template = typeof config.template === "string" && config.template.trim() ? config.template : DEFAULT_TEMPLATE;
+
+  /** @type {Record<string, {format?: string}>} */
+  const channels = config.channels && typeof config.channels === "object" ? config.channels : {};
+  const channelNames = Object.keys(channels);
+
+  const serverUrl = process.env.GITHUB_SERVER_URL || "https://github.com";
+  const repository = `${context.repo.owner}/${context.repo.repo}`;
+  const runUrl = context.runId ? `${serverUrl}/${repository}/actions/runs/${context.runId}` : "";
+  const workflowName = process.env.GH_AW_WORKFLOW_NAME ?? "Workflow";
+
+  core.info(`Notify configuration: channels=${channelNames.join(", ") || "(none)"}, max=${maxCount}`);
+
+  // Track how many notifications we've sent for max limit enforcement
+  let processedCount = 0;
+
+  /**
+   * Message handler function that processes a single notify message
+   * @param {Object} message - The notify message to process
+   * @param {Object} _resolvedTemporaryIds - Map of temporary IDs (unused for notifications)
+   * @returns {Promise<Object>} Result with success/error status
+   */
+  return async function handleNotify(message, _resolvedTemporaryIds) {
+    // Check if we've hit the max limit
+    if (processedCount >= maxCount) {
+      core.warning(`Skipping notify: max count of ${maxCount} reached`);
+      return {
+        success: false,
+        error: `Max count of ${maxCount} reached`,
+      };
+    }
+
+    // The channel is optional when exactly one channel is configured.
+    const requestedChannel = typeof message.channel === "string" ? message.channel.trim() : "";
+    const channel = requestedChannel || (channelNames.length === 1 ? channelNames[0] : "");
+    if (!channel) {
+      const msg = `notify requires a 'channel' field. Allowed channels: ${channelNames.join(", ")}`;
+      core.error(msg);
+      return { success: false, error: msg };
+    }
+    if (!Object.hasOwn(channels, channel)) {
+      const msg = `notify: channel '${channel}' is not allowed. Allowed channels: ${channelNames.join(", ") || "(none)"}`;
+      core.error(msg);
+      return { success: false, error: msg };
+    }
+
+    const rawMessage = typeof message.message === "string" ? message.message.trim() : "";
+    if (!rawMessage) {
+      const msg = "notify requires a non-empty 'message' field";
+      core.error(msg);
+      return { success: false, error: msg };
+    }
+
+    const format = channels[channel]?.format || "slack";
+    if (!VALID_FORMATS.has(format)) {
+      const msg = `notify: channel '${channel}' has unsupported format '${format}'. Must be one of: ${[...VALID_FORMATS].join(", ")}`;
+      core.error(msg);
+      return { success: false, error: msg };
+    }
+
+    /** @type {Record<string, string>} */
+    const templateContext = {
+      channel,
+      title: typeof message.title === "string" ? sanitizeContent(message.title.trim()) : "",
+      message: sanitizeContent(rawMessage),
+      workflow_name: workflowName,
+      repository,
+      run_url: runUrl,
+    };
+    // Trimming drops the blank line an omitted title leaves in the default template.
+    const text = renderTemplate(template, templateContext).trim();
+    const payload = buildPayload(format, text, templateContext);
+
+    // In staged mode, preview without posting to the webhook
+    if (isStaged) {
+      logStagedPreviewInfo(`Would post a ${format} notification to channel '${channel}':\n\n${text}`);
+      processedCount++;
+      return {
+        success: true,
+        staged: true,
+        previewInfo: {
+          channel,
+          format,
+          text,
+        },
+      };
+    }
+
+    const envVarName = getWebhookEnvVarName(channel);
+    const webhookUrl = process.env[envVarName];
+    if (!webhookUrl) {
+      const msg = `notify: webhook for channel '${channel}' is not set (${envVarName} is empty). Check that the secret referenced by safe-outputs.notify.channels.${channel}.webhook exists`;
+      core.error(msg);
+      return { success: false, error: msg };
+    }
+
+    core.info(`Posting ${format} notification to channel '${channel}' (${text.length} characters)`);
+
+    try {
+      await withRetry(
+        async () => {
+          const response = await fetch(webhookUrl, {
+            method: "POST",
+            headers: { "Content-Type": "application/json" },
+            body: JSON.stringify(payload),
+            signal: AbortSignal.timeout(WEBHOOK_TIMEOUT_MS),
+          });
+          if (!response.ok) {
+            // The webhook URL is a secret, so only the status is reported.
+            throw new Error(`Webhook request failed: HTTP ${response.status} ${response.statusText}`);
+          }
+        },
+        {},
+        `${HANDLER_TYPE} to channel '${channel}'`
+      );
+
+      core.info(`✓ Posted notification to channel '${channel}'`);
+      processedCount++;
+
+      return {
+        success: true,
+        channel,
+        format,
+      };
+    } catch (error) {
+      const errorMessage = getErrorMessage(error);
+      core.error(`Failed to post notification to channel '${channel}': ${errorMessage}`);
+      return {
+        success: false,
+        error: errorMessage,
+      };
+    }
+  };
+}
+
+module.exports = { main, getWebhookEnvVarName, buildPayload };
@@ -0,0 +1,155 @@
+// @ts-check
+import { describe, it, expect, beforeEach, afterEach } from "vitest";
+
+describe("notify", () => {
+  let originalGlobals;
+  let originalEnv;
+  let fetchCalls;
+  let fetchResponse;
+
+  beforeEach(() => {
+    originalGlobals = {
+      core: global.core,
+      context: global.context,
+      fetch: global.fetch,
+    };
+    originalEnv = { ...process.env };
+    fetchCalls = [];
+    fetchResponse = { ok: true, status: 200, statusText: "OK" };
+
+    global.core = {
+      debug: () => {},
+      info: () => {},
+      warning: () => {},
+      error: () => {},
+      setOutput: () => {},
+      setFailed: () => {},
+    };
+    global.context = {
+      runId: 12345,
+      repo: {
+        owner: "test-owner",
+        repo: "test-repo",
+      },
+      payload: {},
+    };
+    global.fetch = async (url, init) => {
+      fetchCalls.push({ url, init, body: JSON.parse(init.body) });
+      return fetchResponse;
+    };
+
+    process.env.GH_AW_WORKFLOW_NAME = "Nightly Build";
+    process.env.GH_AW_NOTIFY_WEBHOOK_ALERTS = "https://hooks.example.com/alerts";
+    process.env.GH_AW_NOTIFY_WEBHOOK_ENG_TEAMS = "https://hooks.example.com/teams";
+    delete process.env.GITHUB_SERVER_URL;
+    delete process.env.GH_AW_SAFE_OUTPUTS_STAGED;
+  });
+
+  afterEach(() => {
+    global.core = originalGlobals.core;
+    global.context = originalGlobals.context;
+    global.fetch = originalGlobals.fetch;
+    Object.keys(process.env).forEach(k => {
+      if (!(k in originalEnv)) delete process.env[k];
+    });
+    Object.assign(process.env, originalEnv);
+  });
+
+  it("posts a slack message rendered with the default template", async () => {
+    const { main } = require("./notify.cjs");
+    const handler = await main({ channels: { alerts: {} } });
+    const result = await handler({ type: "notify", title: "Build failed", message: "3 tests are failing on main" }, {});
+
+    expect(result.success).toBe(true);
+    expect(result.channel).toBe("alerts");
+    expect(fetchCalls).toHaveLength(1);
+    expect(fetchCalls[0].url).toBe("https://hooks.example.com/alerts");
+    expect(fetchCalls[0].body).toEqual({
+      text: "Build failed\n3 tests are failing on main\n\nNightly Build: https://github.com/test-owner/test-repo/actions/runs/12345",
+    });
+  });
+
+  it("renders a custom template and teams payload for the requested channel", async () => {
+    const { main } = require("./notify.cjs");
+    const handler = await main({
+      channels: { alerts: {}, "eng-teams": { format: "teams" } },
+      template: "[{repository}] {message}",
+    });
+    const result = await handler({ type: "notify", channel: "eng-teams", message: "Release is ready" }, {});
+
+    expect(result.success).toBe(true);
+    expect(fetchCalls[0].url).toBe("https://hooks.example.com/teams");
+    expect(fetchCalls[0].body.type).toBe("message");
+    expect(fetchCalls[0].body.attachments[0].content.body[0].text).toBe("[test-owner/test-repo] Release is ready");
+  });
+
+  it("rejects channels that are not configured", async () => {
+    const { main } = require("./notify.cjs");
+    const handler = await main({ channels: { alerts: {} } });
+    const result = await handler({ type: "notify", channel: "random", message: "hello" }, {});
+
+    expect(result.success).toBe(false);
+    expect(result.error).toContain("channel 'random' is not allowed");
+    expect(fetchCalls).toHaveLength(0);
+  });
+
+  it("requires a channel when several are configured", async () => {
+    const { main } = require("./notify.cjs");
+    const handler = await main({ channels: { alerts: {}, "eng-teams": { format: "teams" } } });
+    const result = await handler({ type: "notify", message: "hello" }, {});
+
+    expect(result.success).toBe(false);
+    expect(result.error).toContain("requires a 'channel' field");
+  });
+
+  it("enforces the max count", async () => {
+    const { main } = require("./notify.cjs");
+    const handler = await main({ channels: { alerts: {} }, max: 1 });
+    await handler({ type: "notify", message: "first" }, {});
+    const result = await handler({ type: "notify", message: "second" }, {});
+
+    expect(result.success).toBe(false);
+    expect(result.error).toContain("Max count of 1 reached");
+    expect(fetchCalls).toHaveLength(1);
+  });
+
+  it("reports a missing webhook secret without posting", async () => {
+    delete process.env.GH_AW_NOTIFY_WEBHOOK_ALERTS;
+
+    const { main } = require("./notify.cjs");
+    const handler = await main({ channels: { alerts: {} } });
+    const result = await handler({ type: "notify", message: "hello" }, {});
+
+    expect(result.success).toBe(false);
+    expect(result.error).toContain("GH_AW_NOTIFY_WEBHOOK_ALERTS is empty");
+    expect(fetchCalls).toHaveLength(0);
+  });
+
+  it("reports webhook failures without leaking the URL", async () => {
+    fetchResponse = { ok: false, status: 404, statusText: "Not Found" };
+
+    const { main } = require("./notify.cjs");
+    const handler = await main({ channels: { alerts: {} } });
+    const result = await handler({ type: "notify", message: "hello" }, {});
+
+    expect(result.success).toBe(false);
+    expect(result.error).toContain("HTTP 404");
+    expect(result.error).not.toContain("hooks.example.com");
+  });
+
+  it("returns a staged preview without posting", async () => {
+    const { main } = require("./notify.cjs");
+    const handler = await main({ channels: { alerts: {} }, staged: true });
+    const result = await handler({ type: "notify", message: "hello" }, {});
+
+    expect(result.success).toBe(true);
+    expect(result.staged).toBe(true);
+    expect(result.previewInfo.channel).toBe("alerts");
+    expect(fetchCalls).toHaveLength(0);
+  });
+
+  it("maps channel names to webhook env vars", () => {
+    const { getWebhookEnvVarName } = require("./notify.cjs");
+    expect(getWebhookEnvVarName("eng-teams")).toBe("GH_AW_NOTIFY_WEBHOOK_ENG_TEAMS");
+  });
+});
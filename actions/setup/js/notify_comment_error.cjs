@@ -7,12 +7,14 @@
 
 const { loadAgentOutput } = require("./load_agent_output.cjs");
 const { getRunSuccessMessage, getRunFailureMessage, getDetectionFailureMessage, getDetectionWarningMessage } = require("./messages_run_status.cjs");
-const { getMessages } = require("./messages_core.cjs");
+const { getMessages, renderTemplate } = require("./messages_core.cjs");
+const { getAICFromEnv } = require("./messages_footer.cjs");
 const { getErrorMessage, isLockedError } = require("./error_helpers.cjs");
 const { sanitizeContent } = require("./sanitize_content.cjs");
 const { ERR_VALIDATION } = require("./error_codes.cjs");
 const { parseBoolTemplatable } = require("./templatable.cjs");
 const { resolveTopLevelDiscussionCommentId } = require("./github_api_helpers.cjs");
+const { formatDroppedOutputsSection } = require("./safe_output_max_total.cjs");
 
 /**
  * Collect generated asset URLs from safe output jobs
@@ -107,6 +109,57 @@ function getRunFailureStatusText(agentConclusion, assignToAgentErrorCount, safeO
   }
 }
 
+/**
+ * Format noop messages as a single paragraph or a numbered list.
+ * @param {string[]} noopMessages
+ * @returns {string}
+ */
+function formatNoopMessages(noopMessages) {
+  if (noopMessages.length === 1) {
+    return noopMessages[0];
+  }
+  return noopMessages.map((msg, idx) => `${idx + 1}. ${msg}`).join("\n");
+}
+
+/**
+ * @typedef {Object} ConclusionTemplateContext
+ * @property {string} workflowName - Name of the workflow
+ * @property {string} runUrl - URL of the workflow run
+ * @property {string} conclusion - Raw agent job conclusion (success, failure, cancelled, ...)
+ * @property {string} status - Human-readable outcome (e.g. "completed successfully", "timed out")
+ * @property {string} statusMessage - The default success/failure line, honoring messages overrides
+ * @property {string[]} outputs - URLs of items created by safe output jobs
+ * @property {string[]} noopMessages - Messages reported through noop
+ * @property {string[]} errors - Markdown sections describing failures and warnings
+ */
+
+/**
+ * Render the conclusion.template frontmatter field. Placeholders are validated at compile
+ * time against the same list (conclusionTemplateVariables in conclusion_template.go).
+ * Empty placeholders leave blank lines behind, so runs of blank lines are collapsed.
+ * @param {string} template
+ * @param {ConclusionTemplateContext} ctx
+ * @returns {string}
+ */
+function renderConclusionTemplate(template, ctx) {
+  const { aiCredits, aiCreditsFormatted } = getAICFromEnv();
+  const rendered = renderTemplate(template, {
+    workflow_name: ctx.workflowName,
+    run_url: ctx.runUrl,
+    conclusion: ctx.conclusion,
+    status: ctx.status,
+    status_message: ctx.statusMessage,
+    ai_credits: aiCredits ?? "",
+    ai_credits_formatted: aiCreditsFormatted ?? "",
+    effective_tokens: process.env.GH_AW_EFFECTIVE_TOKENS || "",
+    outputs: ctx.outputs.map(url => `- ${url}`).join("\n"),
+    output_count: ctx.outputs.length,
+    noop_messages: ctx.noopMessages.length > 0 ? formatNoopMessages(ctx.noopMessages) : "",
+    errors: ctx.errors.join("\n\n"),
+  });
+  return rendered.replace(/\n{3,}/g, "\n\n").trim();
+}
+
 async function main() {
   const commentId = process.env.GH_AW_COMMENT_ID;
   const commentRepo = process.env.GH_AW_COMMENT_REPO;
@@ -160,12 +213,7 @@ async function main() {
 
     let summaryContent = "## No-Op Messages\n\n";
     summaryContent += "The following messages were logged for transparency:\n\n";
-
-    if (noopMessages.length === 1) {
-      summaryContent += noopMessages[0];
-    } else {
-      summaryContent += noopMessages.map((msg, idx) => `${idx + 1}. ${msg}`).join("\n");
-    }
+    summaryContent += formatNoopMessages(noopMessages);
 
     await core.summary.addRaw(summaryContent).write();
     core.info(`Successfully wrote ${noopMessages.length} noop message(s) to step summary`);
@@ -192,6 +240,8 @@ async function main() {
   // Determine the message based on agent conclusion using custom messages if configured
   let message;
   let detectionWarningMessage = "";
+  const succeeded = agentConclusion === "success" && assignToAgentErrorCount === 0 && safeOutputsResult !== "failure";
+  const status = succeeded ? "completed successfully" : getRunFailureStatusText(agentConclusion, assignToAgentErrorCount, safeOutputsResult);
 
   // Check if detection job failed (if detection job exists)
   if (detectionConclusion && detectionConclusion === "failure") {
@@ -203,7 +253,7 @@ async function main() {
   } else if (detectionConclusion && detectionConclusion === "warning") {
     // Detection job produced a warning (continue-on-error mode)
     // Show success message but append caution section with progressive disclosure
-    if (agentConclusion === "success" && assignToAgentErrorCount === 0 && safeOutputsResult !== "failure") {
+    if (succeeded) {
       message = getRunSuccessMessage({
         workflowName,
         runUrl,
@@ -212,7 +262,7 @@ async function main() {
       message = getRunFailureMessage({
         workflowName,
         runUrl,
-        status: getRunFailureStatusText(agentConclusion, assignToAgentErrorCount, safeOutputsResult),
+        status,
       });
     }
     // Build the caution section for detection warning
@@ -221,7 +271,7 @@ async function main() {
       runUrl,
       reason: detectionReason,
     });
-  } else if (agentConclusion === "success" && assignToAgentErrorCount === 0 && safeOutputsResult !== "failure") {
+  } else if (succeeded) {
     message = getRunSuccessMessage({
       workflowName,
       runUrl,
@@ -230,9 +280,10 @@ async function main() {
     message = getRunFailureMessage({
       workflowName,
       runUrl,
-      status: getRunFailureStatusText(agentConclusion, assignToAgentErrorCount, safeOutputsResult),
+      status,
     });
   }
+  const statusMessage = message;
 
   // Append detection warning caution section if present
   if (detectionWarningMessage) {
@@ -241,12 +292,13 @@ async function main() {
 
   // Add noop messages to the comment if any
   if (noopMessages.length > 0) {
-    message += "\n\n";
-    if (noopMessages.length === 1) {
-      message += noopMessages[0];
-    } else {
-      message += noopMessages.map((msg, idx) => `${idx + 1}. ${msg}`).join("\n");
-    }
+    message += "\n\n" + formatNoopMessages(noopMessages);
+  }
+
+  // List outputs dropped by safe-outputs.max-total
+  const droppedOutputsSection = formatDroppedOutputsSection(process.env.GH_AW_DROPPED_OUTPUTS || "", parseInt(process.env.GH_AW_DROPPED_OUTPUT_COUNT || "0", 10));
+  if (droppedOutputsSection) {
+    message += "\n\n" + droppedOutputsSection;
   }
 
   // Collect generated asset URLs from safe output jobs
@@ -258,6 +310,28 @@ async function main() {
     });
   }
 
+  // A conclusion.template from the frontmatter replaces the assembled report
+  const conclusionTemplate = process.env.GH_AW_CONCLUSION_TEMPLATE;
+  if (conclusionTemplate) {
+    const errors = [];
+    if (detectionConclusion === "failure") {
+      errors.push(getDetectionFailureMessage({ workflowName, runUrl }));
+    } else if (!succeeded) {
+      errors.push(statusMessage);
+    }
+    errors.push(...[detectionWarningMessage, droppedOutputsSection].filter(Boolean));
+    message = renderConclusionTemplate(conclusionTemplate, {
+      workflowName,
+      runUrl,
+      conclusion: agentConclusion,
+      status: detectionConclusion === "failure" ? "failed security scanning" : status,
+      statusMessage,
+      outputs: generatedAssets,
+      noopMessages,
+      errors,
+    });
+  }
+
   // Add "needs-review" label when detection produced a warning
   if (detectionConclusion === "warning") {
     await tryAddNeedsReviewLabel(commentRepo);
@@ -415,4 +489,4 @@ async function main() {
   }
 }
 
-module.exports = { main };
+module.exports = { main, renderConclusionTemplate };
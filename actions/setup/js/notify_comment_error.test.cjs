@@ -59,6 +59,8 @@ const mockCore = {
           GH_AW_OUTPUT_CREATE_ISSUE_ISSUE_URL: process.env.GH_AW_OUTPUT_CREATE_ISSUE_ISSUE_URL,
           GH_AW_OUTPUT_ADD_COMMENT_COMMENT_URL: process.env.GH_AW_OUTPUT_ADD_COMMENT_COMMENT_URL,
           GH_AW_OUTPUT_CREATE_PULL_REQUEST_PULL_REQUEST_URL: process.env.GH_AW_OUTPUT_CREATE_PULL_REQUEST_PULL_REQUEST_URL,
+          GH_AW_CONCLUSION_TEMPLATE: process.env.GH_AW_CONCLUSION_TEMPLATE,
+          GH_AW_AIC: process.env.GH_AW_AIC,
         }));
       const scriptPath = path.join(process.cwd(), "notify_comment_error.cjs");
       notifyCommentScript = fs.readFileSync(scriptPath, "utf8");
@@ -373,5 +375,30 @@ const mockCore = {
               await eval(`(async () => { ${notifyCommentScript}; await main(); })()`),
               expect(mockGithub.request).toHaveBeenCalledWith("PATCH /repos/{owner}/{repo}/issues/comments/{comment_id}", expect.objectContaining({ body: expect.stringContaining("completed successfully!") })));
           }));
+      }),
+      describe("conclusion template", () => {
+        (it("should render the template with outputs and metrics", async () => {
+          ((process.env.GH_AW_COMMENT_ID = "123456"),
+            (process.env.GH_AW_RUN_URL = "https://github.com/owner/repo/actions/runs/123"),
+            (process.env.GH_AW_WORKFLOW_NAME = "test-workflow"),
+            (process.env.GH_AW_AGENT_CONCLUSION = "success"),
+            (process.env.GH_AW_AIC = "12.5"),
+            (process.env.GH_AW_SAFE_OUTPUT_JOBS = JSON.stringify({ create_issue: "issue_url" })),
+            (process.env.GH_AW_OUTPUT_CREATE_ISSUE_ISSUE_URL = "https://github.com/owner/repo/issues/42"),
+            (process.env.GH_AW_CONCLUSION_TEMPLATE = "### {workflow_name}: {status}\n\n{outputs}\n\n{errors}\n\n{output_count} output(s) · {ai_credits_formatted} AIC"),
+            await eval(`(async () => { ${notifyCommentScript}; await main(); })()`));
+          const callArgs = mockGithub.request.mock.calls[0][1];
+          expect(callArgs.body).toBe("### test-workflow: completed successfully\n\n- https://github.com/owner/repo/issues/42\n\n1 output(s) · 12.5 AIC");
+        }),
+          it("should list failures under {errors}", async () => {
+            ((process.env.GH_AW_COMMENT_ID = "123456"),
+              (process.env.GH_AW_RUN_URL = "https://github.com/owner/repo/actions/runs/123"),
+              (process.env.GH_AW_WORKFLOW_NAME = "test-workflow"),
+              (process.env.GH_AW_AGENT_CONCLUSION = "timed_out"),
+              (process.env.GH_AW_CONCLUSION_TEMPLATE = "{workflow_name} ({conclusion}): {status}\n\n{errors}"),
+              await eval(`(async () => { ${notifyCommentScript}; await main(); })()`));
+            const callArgs = mockGithub.request.mock.calls[0][1];
+            (expect(callArgs.body).toContain("test-workflow (timed_out): timed out"), expect(callArgs.body).toContain("Please review the logs"));
+          }));
       }));
   }));
@@ -130,4 +130,38 @@ function extractDiffGitHeaderEntries(patchContent) {
   return entries;
 }
 
-module.exports = { parseDiffGitHeader, extractDiffGitHeaderEntries };
+/**
+ * Counts the number of unique file paths touched by a git patch.
+ *
+ * `git format-patch` emits one `diff --git` header per (commit, file), so the
+ * same file modified across multiple commits will appear multiple times. The
+ * file-count safety limit counts unique files (i.e. how many distinct files
+ * this push touches), not raw header occurrences.
+ *
+ * Headers whose paths cannot be parsed contribute one *synthetic* entry each
+ * to the unique-file set, so a malformed or quoted-with-escapes header line
+ * can never silently bypass the limit (we conservatively over-count rather
+ * than under-count when in doubt).
+ *
+ * @param {string} patchContent - Patch content to inspect (may be empty)
+ * @returns {number} Number of unique file paths referenced in the patch
+ */
+function countUniquePatchFiles(patchContent) {
+  if (!patchContent || !patchContent.trim()) {
+    return 0;
+  }
+  const files = new Set();
+  const entries = extractDiffGitHeaderEntries(patchContent);
+  let unparseableIdx = 0;
+  for (const entry of entries) {
+    const path = entry.newPath || entry.oldPath;
+    if (path) {
+      files.add(path);
+    } else {
+      files.add(`__unparseable_header_${entry.headerIndex}_${unparseableIdx++}`);
+    }
+  }
+  return files.size;
+}
+
+module.exports = { parseDiffGitHeader, extractDiffGitHeaderEntries, countUniquePatchFiles };
@@ -0,0 +1,184 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+/**
+ * PR Review Suggestion Helpers
+ *
+ * Builds GitHub suggestion blocks (```suggestion fences) for inline review comments and
+ * validates that the lines a suggestion replaces are part of the pull request diff.
+ * GitHub only renders the "Apply suggestion" button when the commented lines are on the
+ * RIGHT side of a single diff hunk, so suggestions outside the diff are rejected up front
+ * instead of failing when the review is submitted.
+ */
+
+const { getErrorMessage } = require("./error_helpers.cjs");
+
+// Same cap as the changed-path validation in pr_review_buffer.cjs (1,000 files).
+const MAX_LIST_FILES_PAGES = 10;
+
+const SUGGESTION_FENCE_PATTERN = /^\s*(`{3,}|~{3,})\s*suggestion\s*$/m;
+
+/**
+ * @typedef {Object} DiffLines
+ * @property {Map<number, number>} right - RIGHT-side line number → hunk index (context and added lines)
+ * @property {Map<number, number>} left - LEFT-side line number → hunk index (context and removed lines)
+ */
+
+/**
+ * Parse a unified diff patch (as returned by pulls.listFiles) into the line numbers it covers.
+ * @param {string} patch - Unified diff patch for a single file
+ * @returns {DiffLines}
+ */
+function parsePatchLines(patch) {
+  /** @type {DiffLines} */
+  const lines = { right: new Map(), left: new Map() };
+  let hunk = -1;
+  let leftLine = 0;
+  let rightLine = 0;
+  for (const line of patch.split("\n")) {
+    const header = line.match(/^@@ -(\d+)(?:,\d+)? \+(\d+)(?:,\d+)? @@/);
+    if (header) {
+      hunk++;
+      leftLine = parseInt(header[1], 10);
+      rightLine = parseInt(header[2], 10);
+      continue;
+    }
+    if (hunk < 0 || line.startsWith("\\")) {
+      // Skip anything before the first hunk and "\ No newline at end of file" markers
+      continue;
+    }
+    if (line.startsWith("+")) {
+      lines.right.set(rightLine++, hunk);
+    } else if (line.startsWith("-")) {
+      lines.left.set(leftLine++, hunk);
+    } else {
+      lines.right.set(rightLine++, hunk);
+      lines.left.set(leftLine++, hunk);
+    }
+  }
+  return lines;
+}
+
+/**
+ * Fetch the diff lines of every file changed in a pull request.
+ * Files without a textual patch (binary or too large) map to null.
+ * Returns null when the file list is incomplete (more than 1,000 files).
+ * @param {any} githubClient - Authenticated GitHub client
+ * @param {{owner: string, repo: string}} repoParts
+ * @param {number} pullRequestNumber
+ * @returns {Promise<Map<string, DiffLines | null> | null>}
+ */
+async function fetchPullRequestDiffLines(githubClient, repoParts, pullRequestNumber) {
+  /** @type {Map<string, DiffLines | null>} */
+  const files = new Map();
+  for (let page = 1; page <= MAX_LIST_FILES_PAGES; page++) {
+    const { data } = await githubClient.rest.pulls.listFiles({
+      owner: repoParts.owner,
+      repo: repoParts.repo,
+      pull_number: pullRequestNumber,
+      per_page: 100,
+      page,
+    });
+    if (!Array.isArray(data) || data.length === 0) {
+      return files;
+    }
+    for (const file of data) {
+      files.set(file.filename, typeof file.patch === "string" ? parsePatchLines(file.patch) : null);
+    }
+    if (data.length < 100) {
+      return files;
+    }
+  }
+  return null;
+}
+
+/**
+ * Check whether a comment body already contains a suggestion fence.
+ * @param {string} body
+ * @returns {boolean}
+ */
+function containsSuggestionBlock(body) {
+  return SUGGESTION_FENCE_PATTERN.test(body);
+}
+
+/**
+ * Wrap replacement code in a suggestion fence. The fence is made longer than any backtick
+ * run in the suggestion so code containing ``` cannot terminate the block early.
+ * @param {string} suggestion - Replacement text for the commented lines
+ * @returns {string}
+ */
+function buildSuggestionBlock(suggestion) {
+  const runs = suggestion.match(/`{3,}/g) || [];
+  const longestRun = runs.reduce((max, run) => Math.max(max, run.length), 0);
+  const fence = "`".repeat(Math.max(3, longestRun + 1));
+  // An empty suggestion deletes the commented lines
+  const content = suggestion === "" || suggestion.endsWith("\n") ? suggestion : `${suggestion}\n`;
+  return `${fence}suggestion\n${content}${fence}`;
+}
+
+/**
+ * Validate that a suggestion targets lines GitHub can apply it to.
+ * @param {Map<string, DiffLines | null>} diffFiles - Result of fetchPullRequestDiffLines
+ * @param {{path: string, line: number, startLine?: number, side: string}} target
+ * @returns {string | null} Error message, or null when the suggestion is valid
+ */
+function validateSuggestionTarget(diffFiles, target) {
+  const { path, line, side } = target;
+  const startLine = target.startLine || line;
+  if (side !== "RIGHT") {
+    return `Suggestions can only be made on the RIGHT side of the diff (got side ${side})`;
+  }
+  if (!diffFiles.has(path)) {
+    return `Cannot suggest changes to ${path}: file is not part of the pull request diff`;
+  }
+  const diffLines = diffFiles.get(path);
+  if (!diffLines) {
+    return `Cannot suggest changes to ${path}: no textual diff is available for this file`;
+  }
+  const hunk = diffLines.right.get(startLine);
+  for (let current = startLine; current <= line; current++) {
+    if (!diffLines.right.has(current)) {
+      return `Cannot suggest changes to ${path}:${current}: line is not part of the pull request diff`;
+    }
+    if (diffLines.right.get(current) !== hunk) {
+      return `Cannot suggest changes to ${path}:${startLine}-${line}: lines span more than one diff hunk`;
+    }
+  }
+  return null;
+}
+
+/**
+ * Create a per-handler cache of pull request diff lines, keyed by repo and PR number.
+ * Fetch failures are logged and cached as null so validation fails open once per PR.
+ * @param {any} githubClient - Authenticated GitHub client
+ * @returns {(repo: string, repoParts: {owner: string, repo: string}, pullRequestNumber: number) => Promise<Map<string, DiffLines | null> | null>}
+ */
+function createDiffLinesCache(githubClient) {
+  /** @type {Map<string, Map<string, DiffLines | null> | null>} */
+  const cache = new Map();
+  return async function getDiffLines(repo, repoParts, pullRequestNumber) {
+    const key = `${repo}#${pullRequestNumber}`;
+    if (!cache.has(key)) {
+      try {
+        const files = await fetchPullRequestDiffLines(githubClient, repoParts, pullRequestNumber);
+        if (!files) {
+          core.warning(`PR ${key} has more than 1,000 changed files; suggestion lines will not be validated against the diff`);
+        }
+        cache.set(key, files);
+      } catch (error) {
+        core.warning(`Failed to fetch diff for PR ${key}: ${getErrorMessage(error)}. Suggestion lines will not be validated against the diff.`);
+        cache.set(key, null);
+      }
+    }
+    return cache.get(key) ?? null;
+  };
+}
+
+module.exports = {
+  buildSuggestionBlock,
+  containsSuggestionBlock,
+  createDiffLinesCache,
+  fetchPullRequestDiffLines,
+  parsePatchLines,
+  validateSuggestionTarget,
+};
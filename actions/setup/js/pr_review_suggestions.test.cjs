@@ -0,0 +1,103 @@
+import { describe, it, expect, beforeEach, vi } from "vitest";
+
+const mockCore = {
+  info: vi.fn(),
+  warning: vi.fn(),
+};
+
+global.core = mockCore;
+
+const { buildSuggestionBlock, containsSuggestionBlock, createDiffLinesCache, parsePatchLines, validateSuggestionTarget } = require("./pr_review_suggestions.cjs");
+
+const PATCH = ["@@ -1,3 +1,4 @@", " const a = 1;", "-let b = 2;", "+const b = 2;", "+const c = 3;", " export { a };", "@@ -20,2 +21,2 @@", " function f() {", "-  return 1;", "+  return 2;", "\\ No newline at end of file"].join("\n");
+
+describe("pr_review_suggestions.cjs", () => {
+  beforeEach(() => {
+    vi.clearAllMocks();
+  });
+
+  describe("parsePatchLines", () => {
+    it("maps RIGHT and LEFT line numbers to hunk indexes", () => {
+      const lines = parsePatchLines(PATCH);
+
+      expect([...lines.right.keys()]).toEqual([1, 2, 3, 4, 21, 22]);
+      expect([...lines.left.keys()]).toEqual([1, 2, 3, 20, 21]);
+      expect(lines.right.get(4)).toBe(0);
+      expect(lines.right.get(21)).toBe(1);
+    });
+  });
+
+  describe("buildSuggestionBlock", () => {
+    it("wraps the suggestion in a suggestion fence", () => {
+      expect(buildSuggestionBlock("const b = 2;")).toBe("```suggestion\nconst b = 2;\n```");
+    });
+
+    it("uses an empty block to suggest deleting lines", () => {
+      expect(buildSuggestionBlock("")).toBe("```suggestion\n```");
+    });
+
+    it("uses a longer fence when the suggestion contains backtick fences", () => {
+      expect(buildSuggestionBlock("```js\nx\n```")).toBe("````suggestion\n```js\nx\n```\n````");
+    });
+  });
+
+  describe("containsSuggestionBlock", () => {
+    it("detects suggestion fences in the body", () => {
+      expect(containsSuggestionBlock("Try this:\n```suggestion\nx\n```")).toBe(true);
+      expect(containsSuggestionBlock("Try this:\n```js\nx\n```")).toBe(false);
+    });
+  });
+
+  describe("validateSuggestionTarget", () => {
+    const diffFiles = new Map([
+      ["src/a.js", parsePatchLines(PATCH)],
+      ["assets/logo.png", null],
+    ]);
+
+    it("accepts lines within a single hunk", () => {
+      expect(validateSuggestionTarget(diffFiles, { path: "src/a.js", line: 3, startLine: 2, side: "RIGHT" })).toBeNull();
+    });
+
+    it("rejects the LEFT side", () => {
+      expect(validateSuggestionTarget(diffFiles, { path: "src/a.js", line: 2, side: "LEFT" })).toContain("RIGHT side");
+    });
+
+    it("rejects files outside the diff", () => {
+      expect(validateSuggestionTarget(diffFiles, { path: "src/other.js", line: 2, side: "RIGHT" })).toContain("not part of the pull request diff");
+    });
+
+    it("rejects files without a textual diff", () => {
+      expect(validateSuggestionTarget(diffFiles, { path: "assets/logo.png", line: 1, side: "RIGHT" })).toContain("no textual diff");
+    });
+
+    it("rejects lines outside the diff", () => {
+      expect(validateSuggestionTarget(diffFiles, { path: "src/a.js", line: 10, side: "RIGHT" })).toContain("src/a.js:10");
+    });
+
+    it("rejects ranges spanning hunks", () => {
+      expect(validateSuggestionTarget(diffFiles, { path: "src/a.js", line: 21, startLine: 4, side: "RIGHT" })).toContain("src/a.js:5");
+    });
+  });
+
+  describe("createDiffLinesCache", () => {
+    it("fetches the PR files once per PR", async () => {
+      const listFiles = vi.fn().mockResolvedValue({ data: [{ filename: "src/a.js", patch: PATCH }] });
+      const getDiffLines = createDiffLinesCache({ rest: { pulls: { listFiles } } });
+
+      const first = await getDiffLines("o/r", { owner: "o", repo: "r" }, 1);
+      const second = await getDiffLines("o/r", { owner: "o", repo: "r" }, 1);
+
+      expect(listFiles).toHaveBeenCalledTimes(1);
+      expect(first).toBe(second);
+      expect(first.has("src/a.js")).toBe(true);
+    });
+
+    it("returns null and warns when the diff cannot be fetched", async () => {
+      const listFiles = vi.fn().mockRejectedValue(new Error("boom"));
+      const getDiffLines = createDiffLinesCache({ rest: { pulls: { listFiles } } });
+
+      expect(await getDiffLines("o/r", { owner: "o", repo: "r" }, 1)).toBeNull();
+      expect(mockCore.warning).toHaveBeenCalledWith(expect.stringContaining("boom"));
+    });
+  });
+});
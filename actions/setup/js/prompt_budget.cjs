@@ -0,0 +1,167 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+/**
+ * Prompt size budget (prompt.max-tokens)
+ *
+ * When the prompt exceeds its token budget after runtime imports are resolved,
+ * imported context is truncated, largest import first, until the prompt fits.
+ * The main workflow body is never trimmed. Each truncated import ends with a
+ * marker so the agent knows content is missing.
+ */
+
+const { estimateTokens } = require("./estimate_tokens.cjs");
+
+/** Characters per token, matching estimateTokens. */
+const CHARS_PER_TOKEN = 4;
+
+/** Room reserved for the truncation marker appended to a trimmed import. */
+const MARKER_ALLOWANCE = 200;
+
+/**
+ * @typedef {Object} BudgetImportNode
+ * @property {string} macro - The {{#runtime-import ...}} macro text
+ * @property {boolean} [cached] - True when the import repeats an earlier one
+ * @property {BudgetImportNode[]} children - Nested import nodes
+ */
+
+/**
+ * Return the import cache key (path with optional line range) of a runtime-import macro.
+ * @param {string} macro - e.g. "{{#runtime-import? docs/context.md:1-20}}"
+ * @returns {string} e.g. "docs/context.md:1-20"
+ */
+function importKeyFromMacro(macro) {
+  return macro
+    .replace(/^\{\{#runtime-import\??[ \t]+/, "")
+    .replace(/\}\}$/, "")
+    .trim();
+}
+
+/**
+ * Collect the imports that may be trimmed. The main workflow file is skipped,
+ * but the files it imports are included. Repeated (cached) imports are skipped
+ * because trimming the first occurrence trims them all.
+ * @param {BudgetImportNode[]} nodes - Top-level import tree nodes
+ * @param {string} mainFile - Workspace-relative path of the main workflow file, if any
+ * @returns {BudgetImportNode[]}
+ */
+function collectTrimmableImports(nodes, mainFile) {
+  /** @type {BudgetImportNode[]} */
+  const result = [];
+  for (const node of nodes) {
+    if (node.cached) {
+      continue;
+    }
+    if (mainFile && importKeyFromMacro(node.macro) === mainFile) {
+      result.push(...collectTrimmableImports(node.children, ""));
+      continue;
+    }
+    result.push(node);
+  }
+  return result;
+}
+
+/**
+ * Format the marker that replaces truncated content.
+ * @param {string} src - Import source
+ * @param {number} removedChars - Number of characters removed
+ * @returns {string}
+ */
+function formatTruncationMarker(src, removedChars) {
+  return `[... ${removedChars} characters of imported context from ${src} were truncated to fit prompt.max-tokens ...]`;
+}
+
+/**
+ * Truncate text to about keepChars characters, preferring a line boundary, and append a marker.
+ * @param {string} text - Imported content
+ * @param {number} keepChars - Number of characters to keep
+ * @param {string} src - Import source, for the marker
+ * @returns {{text: string, removedChars: number}}
+ */
+function truncateImport(text, keepChars, src) {
+  let cut = Math.max(0, keepChars);
+  const lastNewline = text.lastIndexOf("\n", cut);
+  if (lastNewline > cut / 2) {
+    cut = lastNewline;
+  }
+  const removedChars = text.length - cut;
+  const kept = text.slice(0, cut).trimEnd();
+  return {
+    text: `${kept}${kept ? "\n\n" : ""}${formatTruncationMarker(src, removedChars)}\n`,
+    removedChars,
+  };
+}
+
+/**
+ * Trim imported context until content fits maxTokens.
+ * @param {string} content - Prompt with runtime imports resolved
+ * @param {{src: string, text: string}[]} imports - Trimmable imports and their resolved text
+ * @param {number} maxTokens - Token budget
+ * @returns {{content: string, trimmed: {src: string, removedChars: number}[]}}
+ */
+function trimImportedContext(content, imports, maxTokens) {
+  const maxChars = maxTokens * CHARS_PER_TOKEN;
+  /** @type {{src: string, removedChars: number}[]} */
+  const trimmed = [];
+  const candidates = imports.filter(({ text }) => text).sort((a, b) => b.text.length - a.text.length);
+
+  for (const { src, text } of candidates) {
+    const excess = content.length - maxChars;
+    if (excess <= 0) {
+      break;
+    }
+    const occurrences = content.split(text).length - 1;
+    if (occurrences === 0) {
+      // Already removed as part of a larger import that was trimmed.
+      continue;
+    }
+    const keepChars = text.length - Math.ceil(excess / occurrences) - MARKER_ALLOWANCE;
+    const truncated = truncateImport(text, keepChars, src);
+    content = content.split(text).join(truncated.text);
+    trimmed.push({ src, removedChars: truncated.removedChars });
+  }
+
+  return { content, trimmed };
+}
+
+/**
+ * Enforce prompt.max-tokens on a prompt whose runtime imports have been resolved.
+ * @param {string} content - Prompt with runtime imports resolved
+ * @param {BudgetImportNode[]} importTree - Top-level import tree nodes
+ * @param {Map<string, string>} importCache - Resolved import content keyed by import key
+ * @param {number} maxTokens - Token budget
+ * @param {string} mainFile - Workspace-relative path of the main workflow file, if any
+ * @returns {string} The prompt, trimmed when it exceeded the budget
+ */
+function enforcePromptBudget(content, importTree, importCache, maxTokens, mainFile) {
+  const estimated = estimateTokens(content);
+  core.info(`Prompt size: ~${estimated} tokens (budget ${maxTokens})`);
+  if (estimated <= maxTokens) {
+    return content;
+  }
+
+  const imports = collectTrimmableImports(importTree, mainFile).map(node => {
+    const key = importKeyFromMacro(node.macro);
+    return { src: key, text: importCache.get(key) || "" };
+  });
+  const result = trimImportedContext(content, imports, maxTokens);
+  for (const { src, removedChars } of result.trimmed) {
+    core.warning(`Truncated ${removedChars} characters of imported context from ${src} to fit prompt.max-tokens (${maxTokens})`);
+  }
+
+  const remaining = estimateTokens(result.content);
+  if (remaining > maxTokens) {
+    core.warning(`Prompt is still ~${remaining} tokens after trimming imported context, over prompt.max-tokens (${maxTokens}). Shorten the workflow body or raise the budget.`);
+  } else {
+    core.info(`Prompt trimmed to ~${remaining} tokens`);
+  }
+  return result.content;
+}
+
+module.exports = {
+  collectTrimmableImports,
+  enforcePromptBudget,
+  formatTruncationMarker,
+  importKeyFromMacro,
+  trimImportedContext,
+};
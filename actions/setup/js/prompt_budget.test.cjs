@@ -0,0 +1,118 @@
+import { describe, it, expect, beforeEach, vi } from "vitest";
+
+const mockCore = {
+  info: vi.fn(),
+  warning: vi.fn(),
+};
+global.core = mockCore;
+
+const { collectTrimmableImports, enforcePromptBudget, formatTruncationMarker, importKeyFromMacro, trimImportedContext } = require("./prompt_budget.cjs");
+
+describe("prompt_budget", () => {
+  beforeEach(() => {
+    vi.clearAllMocks();
+  });
+
+  describe("importKeyFromMacro", () => {
+    it("should strip the macro syntax", () => {
+      expect(importKeyFromMacro("{{#runtime-import docs/context.md}}")).toBe("docs/context.md");
+      expect(importKeyFromMacro("{{#runtime-import? docs/context.md:1-20}}")).toBe("docs/context.md:1-20");
+    });
+  });
+
+  describe("formatTruncationMarker", () => {
+    it("should name the source and removed size", () => {
+      expect(formatTruncationMarker("shared/context.md", 1200)).toBe("[... 1200 characters of imported context from shared/context.md were truncated to fit prompt.max-tokens ...]");
+    });
+  });
+
+  describe("collectTrimmableImports", () => {
+    it("should skip the main file but include its children", () => {
+      const child = { macro: "{{#runtime-import shared/context.md}}", children: [] };
+      const tree = [
+        { macro: "{{#runtime-import shared/tools.md}}", children: [] },
+        { macro: "{{#runtime-import .github/workflows/main.md}}", children: [child] },
+      ];
+
+      const result = collectTrimmableImports(tree, ".github/workflows/main.md");
+
+      expect(result.map(node => node.macro)).toEqual(["{{#runtime-import shared/tools.md}}", "{{#runtime-import shared/context.md}}"]);
+    });
+
+    it("should skip cached imports", () => {
+      const tree = [
+        { macro: "{{#runtime-import shared/context.md}}", children: [] },
+        { macro: "{{#runtime-import shared/context.md}}", cached: true, children: [] },
+      ];
+
+      expect(collectTrimmableImports(tree, "")).toHaveLength(1);
+    });
+  });
+
+  describe("trimImportedContext", () => {
+    it("should leave content within budget unchanged", () => {
+      const content = "short prompt";
+      const result = trimImportedContext(content, [{ src: "a.md", text: "short" }], 100);
+
+      expect(result.content).toBe(content);
+      expect(result.trimmed).toEqual([]);
+    });
+
+    it("should trim the largest import first and add a marker", () => {
+      const small = "small context\n".repeat(5);
+      const large = "large context line\n".repeat(200);
+      const body = "# Task\n\nDo the work.\n";
+      const content = `${small}${large}${body}`;
+
+      const result = trimImportedContext(
+        content,
+        [
+          { src: "small.md", text: small },
+          { src: "large.md", text: large },
+        ],
+        300
+      );
+
+      expect(result.content.length).toBeLessThanOrEqual(300 * 4);
+      expect(result.content).toContain(small);
+      expect(result.content).toContain(body);
+      expect(result.content).toContain("imported context from large.md were truncated to fit prompt.max-tokens");
+      expect(result.trimmed).toHaveLength(1);
+      expect(result.trimmed[0].src).toBe("large.md");
+    });
+  });
+
+  describe("enforcePromptBudget", () => {
+    it("should return content unchanged when within budget", () => {
+      const content = "Do the work.";
+
+      expect(enforcePromptBudget(content, [], new Map(), 100, "")).toBe(content);
+      expect(mockCore.warning).not.toHaveBeenCalled();
+    });
+
+    it("should trim imported context but never the main workflow body", () => {
+      const shared = "shared context line\n".repeat(100);
+      const body = "Main body line\n".repeat(20);
+      const content = `${shared}${body}`;
+      const tree = [{ macro: "{{#runtime-import .github/workflows/main.md}}", children: [{ macro: "{{#runtime-import shared/context.md}}", children: [] }] }];
+      const cache = new Map([
+        [".github/workflows/main.md", content],
+        ["shared/context.md", shared],
+      ]);
+
+      const result = enforcePromptBudget(content, tree, cache, 200, ".github/workflows/main.md");
+
+      expect(result).toContain(body);
+      expect(result).toContain("imported context from shared/context.md were truncated");
+      expect(mockCore.warning).toHaveBeenCalledWith(expect.stringContaining("from shared/context.md to fit prompt.max-tokens (200)"));
+    });
+
+    it("should warn when the prompt is still over budget after trimming", () => {
+      const body = "Main body line\n".repeat(100);
+
+      enforcePromptBudget(body, [], new Map(), 10, "");
+
+      expect(mockCore.warning).toHaveBeenCalledWith(expect.stringContaining("still ~"));
+    });
+  });
+});
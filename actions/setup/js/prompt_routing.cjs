@@ -0,0 +1,219 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+/**
+ * Label-based prompt routing (route:)
+ *
+ * The compiler passes the route: frontmatter section as JSON in GH_AW_PROMPT_ROUTES.
+ * Each route maps label patterns to either a section of the workflow markdown
+ * ("#Heading") or a markdown file that is runtime-imported. Routes are selected
+ * from the labels of the triggering issue, pull request, or discussion. The
+ * default route is used only when no other route matches.
+ *
+ * Import routes are appended as {{#runtime-import}} macros before runtime imports
+ * are processed. Section routes are applied after imports: routed sections whose
+ * route did not match are removed, and all other content is kept.
+ */
+
+/**
+ * @typedef {Object} PromptRoute
+ * @property {string[]} [labels] - Label glob patterns
+ * @property {string} [section] - Heading text of a routed section
+ * @property {string} [import] - Markdown file to runtime-import
+ */
+
+/**
+ * @typedef {Object} PromptRouting
+ * @property {PromptRoute[]} routes - Label routes
+ * @property {PromptRoute} [default] - Route used when no label matches
+ */
+
+/**
+ * @typedef {Object} RouteSelection
+ * @property {PromptRoute[]} selected - Routes whose content is kept (matched routes, or the default)
+ * @property {PromptRoute[]} unselected - Routes whose content is dropped
+ */
+
+/**
+ * Parse the GH_AW_PROMPT_ROUTES value.
+ * @param {string | undefined} json - JSON-encoded routing table
+ * @returns {PromptRouting | null}
+ */
+function parsePromptRoutes(json) {
+  if (!json) {
+    return null;
+  }
+  const parsed = JSON.parse(json);
+  return {
+    routes: Array.isArray(parsed.routes) ? parsed.routes : [],
+    default: parsed.default || undefined,
+  };
+}
+
+/**
+ * Collect the label names of the triggering event.
+ * @param {any} payload - The event payload (context.payload)
+ * @returns {string[]}
+ */
+function collectTriggerLabels(payload) {
+  /** @type {Set<string>} */
+  const labels = new Set();
+  if (!payload) {
+    return [];
+  }
+  for (const subject of [payload.issue, payload.pull_request, payload.discussion]) {
+    for (const label of (subject && subject.labels) || []) {
+      const name = typeof label === "string" ? label : label && label.name;
+      if (name) {
+        labels.add(name);
+      }
+    }
+  }
+  // A labeled event carries the label that was just added.
+  if (payload.label && payload.label.name) {
+    labels.add(payload.label.name);
+  }
+  return [...labels];
+}
+
+/**
+ * Check whether a label matches a glob pattern. "*" matches any run of characters
+ * and "?" matches one character. Matching is case-insensitive.
+ * @param {string} pattern - Label pattern, e.g. "bug" or "area/*"
+ * @param {string} label - Label name
+ * @returns {boolean}
+ */
+function matchesLabelPattern(pattern, label) {
+  const source = pattern
+    .split("")
+    .map(ch => {
+      if (ch === "*") return ".*";
+      if (ch === "?") return ".";
+      return ch.replace(/[.+^${}()|[\]\\]/g, "\\$&");
+    })
+    .join("");
+  return new RegExp(`^${source}$`, "i").test(label);
+}
+
+/**
+ * Select the routes that apply to a set of labels.
+ * @param {PromptRouting} routing - Routing table
+ * @param {string[]} labels - Labels of the triggering event
+ * @returns {RouteSelection}
+ */
+function selectRoutes(routing, labels) {
+  /** @type {PromptRoute[]} */
+  const selected = [];
+  /** @type {PromptRoute[]} */
+  const unselected = [];
+  for (const route of routing.routes) {
+    const matched = (route.labels || []).some(pattern => labels.some(label => matchesLabelPattern(pattern, label)));
+    (matched ? selected : unselected).push(route);
+  }
+  if (routing.default) {
+    (selected.length === 0 ? selected : unselected).push(routing.default);
+  }
+  return { selected, unselected };
+}
+
+/**
+ * Append runtime-import macros for the selected import routes.
+ * @param {string} content - Prompt content before runtime imports are processed
+ * @param {RouteSelection} selection - Selected routes
+ * @returns {string}
+ */
+function appendRoutedImports(content, selection) {
+  const imports = [...new Set(selection.selected.filter(route => route.import).map(route => route.import))];
+  if (imports.length === 0) {
+    return content;
+  }
+  const macros = imports.map(file => `{{#runtime-import ${file}}}`).join("\n");
+  return `${content.replace(/\n*$/, "")}\n\n${macros}\n`;
+}
+
+/**
+ * Normalise heading text for comparison with a route section.
+ * @param {string} text - Heading text
+ * @returns {string}
+ */
+function normalizeHeading(text) {
+  return text
+    .replace(/[ \t]+#*[ \t]*$/, "")
+    .trim()
+    .toLowerCase();
+}
+
+/**
+ * Remove the sections of unselected section routes. A section runs from its heading
+ * to the next heading of the same or a higher level. Headings inside fenced code
+ * blocks are ignored. Sections also targeted by a selected route are kept.
+ * @param {string} content - Prompt content after runtime imports are processed
+ * @param {RouteSelection} selection - Selected routes
+ * @returns {{content: string, removed: string[]}}
+ */
+function removeUnselectedSections(content, selection) {
+  const keep = new Set(selection.selected.filter(route => route.section).map(route => normalizeHeading(route.section || "")));
+  const drop = new Set(
+    selection.unselected
+      .filter(route => route.section)
+      .map(route => normalizeHeading(route.section || ""))
+      .filter(section => !keep.has(section))
+  );
+  if (drop.size === 0) {
+    return { content, removed: [] };
+  }
+
+  const lines = content.split("\n");
+  /** @type {string[]} */
+  const output = [];
+  /** @type {string[]} */
+  const removed = [];
+  let fence = "";
+  let droppingLevel = 0;
+  for (const line of lines) {
+    const fenceMatch = line.match(/^[ \t]*(```+|~~~+)/);
+    if (fenceMatch) {
+      if (!fence) {
+        fence = fenceMatch[1];
+      } else if (fenceMatch[1].startsWith(fence)) {
+        fence = "";
+      }
+    } else if (!fence) {
+      const heading = line.match(/^(#{1,6})[ \t]+(.+)$/);
+      if (heading) {
+        const level = heading[1].length;
+        if (droppingLevel && level <= droppingLevel) {
+          droppingLevel = 0;
+        }
+        if (!droppingLevel && drop.has(normalizeHeading(heading[2]))) {
+          droppingLevel = level;
+          removed.push(heading[2].trim());
+        }
+      }
+    }
+    if (!droppingLevel) {
+      output.push(line);
+    }
+  }
+  return { content: output.join("\n"), removed };
+}
+
+/**
+ * Describe a route for log output.
+ * @param {PromptRoute} route
+ * @returns {string}
+ */
+function describeRoute(route) {
+  const target = route.section ? `#${route.section}` : route.import || "";
+  return route.labels && route.labels.length > 0 ? `${route.labels.join(", ")} -> ${target}` : `default -> ${target}`;
+}
+
+module.exports = {
+  parsePromptRoutes,
+  collectTriggerLabels,
+  matchesLabelPattern,
+  selectRoutes,
+  appendRoutedImports,
+  removeUnselectedSections,
+  describeRoute,
+};
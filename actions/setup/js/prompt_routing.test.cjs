@@ -0,0 +1,69 @@
+import { describe, it, expect } from "vitest";
+
+const { parsePromptRoutes, collectTriggerLabels, matchesLabelPattern, selectRoutes, appendRoutedImports, removeUnselectedSections } = require("./prompt_routing.cjs");
+
+const routing = parsePromptRoutes(
+  JSON.stringify({
+    routes: [
+      { labels: ["bug"], section: "Bug Triage" },
+      { labels: ["enhancement", "feature*"], section: "Feature Intake" },
+      { labels: ["docs"], import: "prompts/docs.md" },
+    ],
+    default: { section: "General" },
+  })
+);
+
+const markdown = ["# Triage", "Read the issue.", "## Bug Triage", "Reproduce the bug.", "### Logs", "Ask for logs.", "## Feature Intake", "Summarize the request.", "```md", "## Bug Triage", "```", "## General", "Label the issue.", "## Output", "Comment once."].join("\n");
+
+describe("prompt_routing", () => {
+  it("should collect labels from the triggering event", () => {
+    const labels = collectTriggerLabels({
+      issue: { labels: [{ name: "bug" }, { name: "p1" }] },
+      label: { name: "needs-triage" },
+    });
+    expect(labels).toEqual(["bug", "p1", "needs-triage"]);
+    expect(collectTriggerLabels({ pull_request: { labels: [{ name: "docs" }] } })).toEqual(["docs"]);
+    expect(collectTriggerLabels(undefined)).toEqual([]);
+  });
+
+  it("should match label globs case-insensitively", () => {
+    expect(matchesLabelPattern("bug", "Bug")).toBe(true);
+    expect(matchesLabelPattern("feature*", "feature-request")).toBe(true);
+    expect(matchesLabelPattern("area/?", "area/x")).toBe(true);
+    expect(matchesLabelPattern("bug", "bugfix")).toBe(false);
+    expect(matchesLabelPattern("a.b", "axb")).toBe(false);
+  });
+
+  it("should keep the matched section and drop other routed sections", () => {
+    const selection = selectRoutes(routing, ["bug"]);
+    const { content, removed } = removeUnselectedSections(markdown, selection);
+
+    expect(removed).toEqual(["Feature Intake", "General"]);
+    expect(content).toContain("## Bug Triage\nReproduce the bug.\n### Logs\nAsk for logs.");
+    expect(content).toContain("# Triage\nRead the issue.");
+    expect(content).toContain("## Output\nComment once.");
+    expect(content).not.toContain("Summarize the request.");
+    expect(content).not.toContain("Label the issue.");
+  });
+
+  it("should fall back to the default route when no label matches", () => {
+    const selection = selectRoutes(routing, ["question"]);
+    const { content } = removeUnselectedSections(markdown, selection);
+
+    expect(selection.selected).toEqual([{ section: "General" }]);
+    expect(content).toContain("Label the issue.");
+    expect(content).not.toContain("Reproduce the bug.");
+  });
+
+  it("should append runtime imports for matched import routes", () => {
+    const selection = selectRoutes(routing, ["docs"]);
+
+    expect(appendRoutedImports("Body\n", selection)).toBe("Body\n\n{{#runtime-import prompts/docs.md}}\n");
+    expect(appendRoutedImports("Body\n", selectRoutes(routing, ["bug"]))).toBe("Body\n");
+  });
+
+  it("should return null without routes", () => {
+    expect(parsePromptRoutes(undefined)).toBeNull();
+    expect(parsePromptRoutes("")).toBeNull();
+  });
+});
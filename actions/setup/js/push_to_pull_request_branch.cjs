@@ -24,6 +24,7 @@ const { findRepoCheckout } = require("./find_repo_checkout.cjs");
 const { getThreatDetectedMarker } = require("./threat_detection_warning.cjs");
 const { attachExecutionState } = require("./safe_output_execution_metadata.cjs");
 const { resolveTransportPaths } = require("./resolve_transport_paths.cjs");
+const { countUniquePatchFiles } = require("./patch_path_helpers.cjs");
 
 /**
  * @typedef {import('./types/handler-factory').HandlerFactoryFunction} HandlerFactoryFunction
@@ -318,6 +319,7 @@ async function main(config = {}) {
   const signedCommits = config.signed_commits !== false;
   const commitTitleSuffix = config.commit_title_suffix || "";
   const maxSizeKb = parsePositiveInteger(config.max_patch_size) ?? 4096;
+  const maxFiles = parsePositiveInteger(config.max_patch_files) ?? 0; // 0 means no limit
   const maxCount = config.max || 0; // 0 means no limit
   const allowWorkflows = config.allow_workflows === true;
 
@@ -370,6 +372,7 @@ async function main(config = {}) {
     core.info(`Commit title suffix: ${commitTitleSuffix}`);
   }
   core.info(`Max patch size: ${maxSizeKb} KB`);
+  core.info(`Max patch files: ${maxFiles || "unlimited"}`);
   core.info(`Max count: ${maxCount || "unlimited"}`);
   core.info(`Default target repo: ${defaultTargetRepo}`);
   if (configuredHeadRepo) {
@@ -520,6 +523,21 @@ async function main(config = {}) {
       }
 
       core.info("Patch size validation passed");
+
+      // Validate the number of unique files touched by the patch when `max_patch_files` is set.
+      if (maxFiles) {
+        const fileCount = countUniquePatchFiles(patchContent);
+        core.info(`Patch files: ${fileCount} (maximum allowed: ${maxFiles})`);
+        if (fileCount > maxFiles) {
+          return {
+            success: false,
+            error:
+              `E003: Cannot push to pull request branch with more than ${maxFiles} files (received ${fileCount}). ` +
+              `To increase the limit, set \`max-patch-files: ${fileCount}\` (or higher) under ` +
+              `\`safe-outputs.push-to-pull-request-branch\` in your workflow frontmatter.`,
+          };
+        }
+      }
     }
 
     // Check file protection: allowlist (strict) or protected-files policy.
@@ -2126,6 +2126,53 @@ index 0000000..abc1234
     });
   });
 
+  // ──────────────────────────────────────────────────────
+  // Patch File Count Validation
+  // ──────────────────────────────────────────────────────
+
+  describe("patch file count validation", () => {
+    const twoFilePatch = ["diff --git a/one.txt b/one.txt", "+one", "diff --git a/two.txt b/two.txt", "+two", ""].join("\n");
+
+    it("should reject patches touching more files than max_patch_files", async () => {
+      createPatchFile("should-reject-patches-exceeding-max-files", twoFilePatch);
+
+      const module = await loadModule();
+      const handler = await module.main({ max_patch_files: 1 });
+      const result = await handler({ branch: "should-reject-patches-exceeding-max-files" }, {});
+
+      expect(result.success).toBe(false);
+      expect(result.error).toContain("more than 1 files (received 2)");
+      expect(result.error).toContain("max-patch-files: 2");
+    });
+
+    it("should accept patches within max_patch_files", async () => {
+      createPatchFile("should-accept-patches-within-max-files", twoFilePatch);
+
+      mockExec.getExecOutput.mockResolvedValue({ exitCode: 0, stdout: "abc123\n", stderr: "" });
+
+      const module = await loadModule();
+      const handler = await module.main({ max_patch_files: 2 });
+      const result = await handler({ branch: "should-accept-patches-within-max-files" }, {});
+
+      expect(result.success).toBe(true);
+      expect(mockCore.info).toHaveBeenCalledWith("Patch files: 2 (maximum allowed: 2)");
+    });
+
+    it("should not limit the file count when max_patch_files is unset", async () => {
+      const manyFilePatch = Array.from({ length: 150 }, (_, i) => [`diff --git a/f${i}.txt b/f${i}.txt`, `+${i}`].join("\n")).join("\n") + "\n";
+      createPatchFile("should-not-limit-files-when-unset", manyFilePatch);
+
+      mockExec.getExecOutput.mockResolvedValue({ exitCode: 0, stdout: "abc123\n", stderr: "" });
+
+      const module = await loadModule();
+      const handler = await module.main({});
+      const result = await handler({ branch: "should-not-limit-files-when-unset" }, {});
+
+      expect(result.success).toBe(true);
+      expect(mockCore.info).toHaveBeenCalledWith("Max patch files: unlimited");
+    });
+  });
+
   // ──────────────────────────────────────────────────────
   // Bundle Transport Application
   // ──────────────────────────────────────────────────────
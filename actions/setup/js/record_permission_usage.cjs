@@ -0,0 +1,129 @@
+// @ts-check
+
+/**
+ * Record which GitHub MCP tools the agent called, alongside the permissions the
+ * agent job requested, so `gh aw audit` can compare requested and used scopes.
+ *
+ * Enabled with `features: permission-telemetry: true`. The script reads the MCP
+ * gateway's JSON-RPC trace (rpc-messages.jsonl) and counts tools/call requests
+ * sent to the GitHub MCP server. Each GitHub MCP tool maps to a toolset whose
+ * permission scopes are known to the compiler, so the audit command can flag
+ * scopes that were requested but never needed.
+ *
+ * Output: /tmp/gh-aw/permission_usage.json
+ *   {
+ *     "requested_permissions": { "contents": "read", "issues": "read" },
+ *     "github_tool_calls": { "issue_read": 3, "list_issues": 1 },
+ *     "source": "rpc-messages.jsonl"
+ *   }
+ *
+ * Environment variables:
+ *   - GH_AW_REQUESTED_PERMISSIONS: JSON object of the agent job's compiled permissions
+ *
+ * Exit codes:
+ *   0 — Always succeeds; telemetry is best-effort
+ */
+
+"use strict";
+
+const fs = require("fs");
+const path = require("path");
+
+const MCP_LOGS_DIR = "/tmp/gh-aw/mcp-logs";
+const OUTPUT_FILE = "/tmp/gh-aw/permission_usage.json";
+const GITHUB_SERVER_ID = "github";
+
+/**
+ * Count tools/call requests sent to the GitHub MCP server.
+ * @param {string} content - Contents of rpc-messages.jsonl
+ * @returns {Record<string, number>}
+ */
+function countGitHubToolCalls(content) {
+  /** @type {Record<string, number>} */
+  const counts = {};
+  for (const line of content.split("\n")) {
+    const trimmed = line.trim();
+    if (!trimmed) {
+      continue;
+    }
+    let entry;
+    try {
+      entry = JSON.parse(trimmed);
+    } catch {
+      continue;
+    }
+    if (entry?.direction !== "OUT" || entry?.type !== "REQUEST" || entry?.server_id !== GITHUB_SERVER_ID) {
+      continue;
+    }
+    const payload = entry.payload;
+    if (payload?.method !== "tools/call" || typeof payload?.params?.name !== "string") {
+      continue;
+    }
+    const name = payload.params.name;
+    counts[name] = (counts[name] || 0) + 1;
+  }
+  return counts;
+}
+
+/**
+ * Parse the requested permissions passed by the compiler.
+ * @param {string | undefined} raw
+ * @returns {Record<string, string>}
+ */
+function parseRequestedPermissions(raw) {
+  if (!raw) {
+    return {};
+  }
+  try {
+    const parsed = JSON.parse(raw);
+    return parsed && typeof parsed === "object" && !Array.isArray(parsed) ? parsed : {};
+  } catch {
+    process.stderr.write("[record-permission-usage] Failed to parse GH_AW_REQUESTED_PERMISSIONS\n");
+    return {};
+  }
+}
+
+/**
+ * Build the permission usage record.
+ * @param {string} mcpLogsDir
+ * @param {string | undefined} requestedPermissionsJSON
+ * @returns {{requested_permissions: Record<string, string>, github_tool_calls: Record<string, number>, source: string}}
+ */
+function buildPermissionUsage(mcpLogsDir, requestedPermissionsJSON) {
+  const rpcPath = path.join(mcpLogsDir, "rpc-messages.jsonl");
+  let toolCalls = {};
+  let source = "";
+  if (fs.existsSync(rpcPath)) {
+    toolCalls = countGitHubToolCalls(fs.readFileSync(rpcPath, "utf8"));
+    source = "rpc-messages.jsonl";
+  } else {
+    process.stderr.write(`[record-permission-usage] MCP trace not found: ${rpcPath}\n`);
+  }
+  return {
+    requested_permissions: parseRequestedPermissions(requestedPermissionsJSON),
+    github_tool_calls: toolCalls,
+    source,
+  };
+}
+
+function main() {
+  const usage = buildPermissionUsage(MCP_LOGS_DIR, process.env.GH_AW_REQUESTED_PERMISSIONS);
+  const total = Object.values(usage.github_tool_calls).reduce((sum, n) => sum + n, 0);
+  try {
+    fs.mkdirSync(path.dirname(OUTPUT_FILE), { recursive: true });
+    fs.writeFileSync(OUTPUT_FILE, JSON.stringify(usage, null, 2));
+    process.stderr.write(`[record-permission-usage] Recorded ${total} GitHub MCP tool call(s) across ${Object.keys(usage.github_tool_calls).length} tool(s)\n`);
+  } catch (err) {
+    process.stderr.write(`[record-permission-usage] Failed to write ${OUTPUT_FILE}: ${String(err)}\n`);
+  }
+}
+
+if (require.main === module) {
+  main();
+}
+
+module.exports = {
+  countGitHubToolCalls,
+  parseRequestedPermissions,
+  buildPermissionUsage,
+};
@@ -0,0 +1,70 @@
+import { describe, it, expect, beforeEach, afterEach } from "vitest";
+import fs from "fs";
+import os from "os";
+import path from "path";
+
+const { countGitHubToolCalls, parseRequestedPermissions, buildPermissionUsage } = require("./record_permission_usage.cjs");
+
+function rpcLine(direction, type, serverID, payload) {
+  return JSON.stringify({ timestamp: "2026-01-01T00:00:00Z", direction, type, server_id: serverID, payload });
+}
+
+describe("record_permission_usage.cjs", () => {
+  describe("countGitHubToolCalls", () => {
+    it("counts tools/call requests sent to the GitHub MCP server", () => {
+      const content = [
+        rpcLine("OUT", "REQUEST", "github", { jsonrpc: "2.0", id: 1, method: "tools/call", params: { name: "issue_read" } }),
+        rpcLine("IN", "RESPONSE", "github", { jsonrpc: "2.0", id: 1, result: {} }),
+        rpcLine("OUT", "REQUEST", "github", { jsonrpc: "2.0", id: 2, method: "tools/call", params: { name: "issue_read" } }),
+        rpcLine("OUT", "REQUEST", "github", { jsonrpc: "2.0", id: 3, method: "tools/call", params: { name: "list_pull_requests" } }),
+        rpcLine("OUT", "REQUEST", "github", { jsonrpc: "2.0", id: 4, method: "tools/list" }),
+        rpcLine("OUT", "REQUEST", "safeoutputs", { jsonrpc: "2.0", id: 5, method: "tools/call", params: { name: "create_issue" } }),
+        "not json",
+      ].join("\n");
+
+      expect(countGitHubToolCalls(content)).toEqual({ issue_read: 2, list_pull_requests: 1 });
+    });
+  });
+
+  describe("parseRequestedPermissions", () => {
+    it("parses a permissions object", () => {
+      expect(parseRequestedPermissions('{"contents":"read","issues":"read"}')).toEqual({ contents: "read", issues: "read" });
+    });
+
+    it("returns an empty object for missing or invalid input", () => {
+      expect(parseRequestedPermissions(undefined)).toEqual({});
+      expect(parseRequestedPermissions("not json")).toEqual({});
+      expect(parseRequestedPermissions('["contents"]')).toEqual({});
+    });
+  });
+
+  describe("buildPermissionUsage", () => {
+    let tmpDir;
+
+    beforeEach(() => {
+      tmpDir = fs.mkdtempSync(path.join(os.tmpdir(), "permission-usage-"));
+    });
+
+    afterEach(() => {
+      fs.rmSync(tmpDir, { recursive: true, force: true });
+    });
+
+    it("combines requested permissions with GitHub tool calls", () => {
+      fs.writeFileSync(path.join(tmpDir, "rpc-messages.jsonl"), rpcLine("OUT", "REQUEST", "github", { id: 1, method: "tools/call", params: { name: "get_file_contents" } }) + "\n");
+
+      expect(buildPermissionUsage(tmpDir, '{"contents":"read"}')).toEqual({
+        requested_permissions: { contents: "read" },
+        github_tool_calls: { get_file_contents: 1 },
+        source: "rpc-messages.jsonl",
+      });
+    });
+
+    it("records requested permissions when the MCP trace is missing", () => {
+      expect(buildPermissionUsage(tmpDir, '{"issues":"read"}')).toEqual({
+        requested_permissions: { issues: "read" },
+        github_tool_calls: {},
+        source: "",
+      });
+    });
+  });
+});
@@ -5,6 +5,8 @@
  * Redacts secrets from files in /tmp/gh-aw and ${RUNNER_TEMP}/gh-aw directories before uploading artifacts
  * This script processes all .txt, .json, .log, .md, .mdx, .yml, .jsonl files under /tmp/gh-aw and ${RUNNER_TEMP}/gh-aw
  * and redacts any strings matching the actual secret values provided via environment variables.
+ * Custom regular expressions from secret-masking.patterns (GH_AW_SECRET_PATTERNS) are also applied:
+ * each match is registered with core.setSecret (::add-mask::) and redacted from the files.
  */
 const fs = require("fs");
 const path = require("path");
@@ -152,6 +154,72 @@ function redactBuiltInPatterns(content) {
   return { content: redacted, redactionCount, detectedPatterns };
 }
 
+/**
+ * Parses custom secret-masking patterns from a JSON array of regular expression sources.
+ * Invalid entries are skipped with a warning so a single bad pattern does not disable redaction.
+ * @param {string|undefined} patternsJSON - JSON array of pattern strings (GH_AW_SECRET_PATTERNS)
+ * @returns {{name: string, pattern: RegExp}[]} Compiled global patterns
+ */
+function parseCustomPatterns(patternsJSON) {
+  if (!patternsJSON || patternsJSON.trim() === "") {
+    return [];
+  }
+  let sources;
+  try {
+    sources = JSON.parse(patternsJSON);
+  } catch (error) {
+    core.warning(`Failed to parse secret-masking patterns: ${getErrorMessage(error)}`);
+    return [];
+  }
+  if (!Array.isArray(sources)) {
+    core.warning("Secret-masking patterns must be a JSON array of strings");
+    return [];
+  }
+  const patterns = [];
+  for (const [index, source] of sources.entries()) {
+    if (typeof source !== "string" || source === "") {
+      continue;
+    }
+    try {
+      patterns.push({ name: `custom pattern #${index + 1}`, pattern: new RegExp(source, "g") });
+    } catch (error) {
+      core.warning(`Skipping invalid secret-masking pattern #${index + 1}: ${getErrorMessage(error)}`);
+    }
+  }
+  return patterns;
+}
+
+/**
+ * Redacts matches of custom secret-masking patterns and masks each matched value
+ * in the job log via core.setSecret (::add-mask::)
+ * @param {string} content - File content to process
+ * @param {{name: string, pattern: RegExp}[]} customPatterns - Compiled custom patterns
+ * @param {Set<string>} maskedValues - Values already registered with core.setSecret
+ * @returns {{content: string, redactionCount: number}} Redacted content and count of redactions
+ */
+function redactCustomPatterns(content, customPatterns, maskedValues) {
+  let redactionCount = 0;
+  let redacted = content;
+  for (const { name, pattern } of customPatterns) {
+    const matches = (redacted.match(pattern) || []).filter(match => match.length > 0);
+    if (matches.length === 0) {
+      continue;
+    }
+    // Replace longest matches first so a shorter match cannot split a longer one
+    const uniqueMatches = [...new Set(matches)].sort((a, b) => b.length - a.length);
+    for (const match of uniqueMatches) {
+      if (!maskedValues.has(match)) {
+        maskedValues.add(match);
+        core.setSecret(match);
+      }
+      redacted = redacted.split(match).join("***REDACTED***");
+    }
+    redactionCount += matches.length;
+    core.info(`Redacted ${matches.length} occurrence(s) of ${name}`);
+  }
+  return { content: redacted, redactionCount };
+}
+
 /**
  * Redacts secrets from file content using exact string matching
  * @param {string} content - File content to process
@@ -188,9 +256,11 @@ function redactSecrets(content, secretValues) {
  * Process a single file for secret redaction
  * @param {string} filePath - Path to the file
  * @param {string[]} secretValues - Array of secret values to redact
+ * @param {{name: string, pattern: RegExp}[]} [customPatterns] - Custom secret-masking patterns
+ * @param {Set<string>} [maskedValues] - Values already registered with core.setSecret
  * @returns {number} Number of redactions made
  */
-function processFile(filePath, secretValues) {
+function processFile(filePath, secretValues, customPatterns = [], maskedValues = new Set()) {
   try {
     const content = fs.readFileSync(filePath, "utf8");
 
@@ -204,6 +274,13 @@ function processFile(filePath, secretValues) {
     redacted = customResult.content;
     totalRedactions += customResult.redactionCount;
 
+    // Finally, redact matches of custom secret-masking patterns
+    if (customPatterns.length > 0) {
+      const patternResult = redactCustomPatterns(redacted, customPatterns, maskedValues);
+      redacted = patternResult.content;
+      totalRedactions += patternResult.redactionCount;
+    }
+
     if (totalRedactions > 0) {
       fs.writeFileSync(filePath, redacted, "utf8");
       core.info(`Processed ${filePath}: ${totalRedactions} redaction(s)`);
@@ -253,6 +330,13 @@ async function main() {
       secretValues.push(...gatewayTokens);
     }
 
+    const customPatterns = parseCustomPatterns(process.env.GH_AW_SECRET_PATTERNS);
+    if (customPatterns.length > 0) {
+      core.info(`Found ${customPatterns.length} custom secret-masking pattern(s)`);
+    }
+    /** @type {Set<string>} */
+    const maskedValues = new Set();
+
     // Always scan for built-in patterns, even if there are no custom secrets
     core.info("Scanning for built-in credential patterns and custom secrets");
 
@@ -266,7 +350,7 @@ async function main() {
     let filesWithRedactions = 0;
     // Process each file
     for (const file of files) {
-      const redactionCount = processFile(file, secretValues);
+      const redactionCount = processFile(file, secretValues, customPatterns, maskedValues);
       if (redactionCount > 0) {
         filesWithRedactions++;
         totalRedactions += redactionCount;
@@ -282,4 +366,4 @@ async function main() {
   }
 }
 
-module.exports = { main, redactSecrets, redactBuiltInPatterns, extractMCPGatewayTokens, BUILT_IN_PATTERNS, MCP_GATEWAY_CONFIG_PATHS };
+module.exports = { main, redactSecrets, redactBuiltInPatterns, parseCustomPatterns, redactCustomPatterns, extractMCPGatewayTokens, BUILT_IN_PATTERNS, MCP_GATEWAY_CONFIG_PATHS };
@@ -37,7 +37,8 @@ describe("redact_secrets.cjs", () => {
       }),
       mockCore.summary.addRaw && mockCore.summary.addRaw.mockClear(),
       mockCore.summary.write && mockCore.summary.write.mockClear(),
-      delete process.env.GH_AW_SECRET_NAMES);
+      delete process.env.GH_AW_SECRET_NAMES,
+      delete process.env.GH_AW_SECRET_PATTERNS);
   }),
     afterEach(() => {
       tempDir && fs.existsSync(tempDir) && fs.rmSync(tempDir, { recursive: !0, force: !0 });
@@ -431,6 +432,41 @@ describe("redact_secrets.cjs", () => {
         });
       });
 
+      describe("custom secret-masking patterns", () => {
+        it("should redact and mask matches of custom patterns", async () => {
+          const testFile = path.join(tempDir, "agent-stdio.log");
+          fs.writeFileSync(testFile, "token acme_0123456789abcdef0123456789abcdef used twice: acme_0123456789abcdef0123456789abcdef");
+          process.env.GH_AW_SECRET_PATTERNS = JSON.stringify(["acme_[0-9a-f]{32}"]);
+          const modifiedScript = redactScript.replace('findFiles("/tmp/gh-aw", targetExtensions)', `findFiles("${tempDir.replace(/\\/g, "\\\\")}", targetExtensions)`);
+          await eval(`(async () => { ${modifiedScript}; await main(); })()`);
+          expect(fs.readFileSync(testFile, "utf8")).toBe("token ***REDACTED*** used twice: ***REDACTED***");
+          expect(mockCore.setSecret).toHaveBeenCalledTimes(1);
+          expect(mockCore.setSecret).toHaveBeenCalledWith("acme_0123456789abcdef0123456789abcdef");
+          expect(mockCore.info).toHaveBeenCalledWith(expect.stringContaining("Found 1 custom secret-masking pattern(s)"));
+        });
+
+        it("should skip invalid patterns with a warning", async () => {
+          const testFile = path.join(tempDir, "test.txt");
+          fs.writeFileSync(testFile, "internal-key-42");
+          process.env.GH_AW_SECRET_PATTERNS = JSON.stringify(["(unclosed", "internal-key-\\d+"]);
+          const modifiedScript = redactScript.replace('findFiles("/tmp/gh-aw", targetExtensions)', `findFiles("${tempDir.replace(/\\/g, "\\\\")}", targetExtensions)`);
+          await eval(`(async () => { ${modifiedScript}; await main(); })()`);
+          expect(fs.readFileSync(testFile, "utf8")).toBe("***REDACTED***");
+          expect(mockCore.warning).toHaveBeenCalledWith(expect.stringContaining("Skipping invalid secret-masking pattern #1"));
+        });
+
+        it("should ignore malformed pattern lists", async () => {
+          const testFile = path.join(tempDir, "test.txt");
+          fs.writeFileSync(testFile, "nothing to see");
+          process.env.GH_AW_SECRET_PATTERNS = "not-json";
+          const modifiedScript = redactScript.replace('findFiles("/tmp/gh-aw", targetExtensions)', `findFiles("${tempDir.replace(/\\/g, "\\\\")}", targetExtensions)`);
+          await eval(`(async () => { ${modifiedScript}; await main(); })()`);
+          expect(fs.readFileSync(testFile, "utf8")).toBe("nothing to see");
+          expect(mockCore.warning).toHaveBeenCalledWith(expect.stringContaining("Failed to parse secret-masking patterns"));
+          expect(mockCore.setFailed).not.toHaveBeenCalled();
+        });
+      });
+
       describe("edge cases", () => {
         it("should handle files with no secrets", async () => {
           const testFile = path.join(tempDir, "test.txt");
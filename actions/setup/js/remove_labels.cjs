@@ -3,6 +3,8 @@
 
 /**
  * @typedef {import('./types/handler-factory').HandlerFactoryFunction} HandlerFactoryFunction
+ * @typedef {import('./types/handler-factory').ResolvedTemporaryIds} ResolvedTemporaryIds
+ * @typedef {import('./types/handler-factory').HandlerResult} HandlerResult
  */
 
 /** @type {string} Safe output type handled by this module */
@@ -15,6 +17,7 @@ const { logStagedPreviewInfo } = require("./staged_preview.cjs");
 const { createAuthenticatedGitHubClient } = require("./handler_auth.cjs");
 const { resolveSafeOutputIssueTarget } = require("./temporary_id.cjs");
 const { createCountGatedHandler } = require("./handler_scaffold.cjs");
+const { processBatchMessage } = require("./batch_target_helpers.cjs");
 const { resolveInvocationContext } = require("./invocation_context_helpers.cjs");
 const { normalizeIssueIntentLabelNames } = require("./issue_intents.cjs");
 
@@ -32,9 +35,13 @@ const main = createCountGatedHandler({
     const requiredLabels = Array.isArray(config.required_labels) ? config.required_labels : [];
     const requiredTitlePrefix = config.required_title_prefix || "";
     const { defaultTargetRepo, allowedRepos } = resolveTargetRepoConfig(config);
+    const batchEnabled = config.batch === true;
     const githubClient = await createAuthenticatedGitHubClient(config);
 
     core.info(`Remove labels configuration: max=${maxCount}`);
+    if (batchEnabled) {
+      core.info("Batch mode enabled: item_numbers targets are accepted");
+    }
     if (allowedLabels.length > 0) {
       core.info(`Allowed labels to remove: ${allowedLabels.join(", ")}`);
     }
@@ -49,12 +56,12 @@ const main = createCountGatedHandler({
     }
 
     /**
-     * Message handler function that processes a single remove_labels message
+     * Processes a single remove_labels message targeting one issue/PR
      * @param {Object} message - The remove_labels message to process
      * @param {Object} resolvedTemporaryIds - Map of temporary IDs to {repo, number}
      * @returns {Promise<Object>} Result with success/error status
      */
-    return async function handleRemoveLabels(message, resolvedTemporaryIds) {
+    async function handleRemoveLabelsItem(message, resolvedTemporaryIds) {
       // Resolve and validate target repository
       const repoResult = resolveAndValidateRepo(message, defaultTargetRepo, allowedRepos, "label");
       if (!repoResult.success) {
@@ -215,6 +222,29 @@ const main = createCountGatedHandler({
         failedLabels: failedLabels.length > 0 ? failedLabels : undefined,
         contextType,
       };
+    }
+
+    /**
+     * Entry point: dispatches batch messages (item_numbers) to the single-item handler
+     * @param {any} message - The remove_labels message to process
+     * @param {ResolvedTemporaryIds} resolvedTemporaryIds - Map of temporary IDs to {repo, number}
+     * @returns {Promise<HandlerResult>} Result with success/error status
+     */
+    return async function handleRemoveLabels(message, resolvedTemporaryIds) {
+      if (message?.item_numbers !== undefined) {
+        if (!batchEnabled) {
+          const error = "item_numbers is only supported when batch: true is set in the remove-labels configuration";
+          core.warning(`Skipping remove_labels: ${error}`);
+          return { success: false, error };
+        }
+        return processBatchMessage({
+          handlerType: HANDLER_TYPE,
+          message,
+          githubClient,
+          handleItem: itemMessage => handleRemoveLabelsItem(itemMessage, resolvedTemporaryIds),
+        });
+      }
+      return handleRemoveLabelsItem(message, resolvedTemporaryIds);
     };
   },
 });
@@ -100,9 +100,11 @@ function parseAllowedRepos(allowedReposValue) {
  * @returns {string} Repository slug in "owner/repo" format
  */
 function getDefaultTargetRepo(config) {
-  // First check if there's a target-repo in config
-  if (config && config["target-repo"]) {
-    return config["target-repo"];
+  // First check if there's a target-repo in config. Expressions such as
+  // ${{ vars.TARGET_REPO }} are expanded by Actions; an empty result falls through.
+  const configuredTargetRepo = config && config["target-repo"] ? String(config["target-repo"]).trim() : "";
+  if (configuredTargetRepo) {
+    return configuredTargetRepo;
   }
   // Fall back to env var for backward compatibility
   const targetRepoSlug = process.env.GH_AW_TARGET_REPO_SLUG;
@@ -148,6 +150,16 @@ function isRepoAllowed(qualifiedRepo, allowedRepos) {
   return false;
 }
 
+/**
+ * Check if a target-repo value is a repository pattern such as "org/*".
+ * The bare wildcard "*" is handled separately and is not considered a pattern.
+ * @param {string} repoSlug - Configured target repository
+ * @returns {boolean}
+ */
+function isRepoPattern(repoSlug) {
+  return typeof repoSlug === "string" && repoSlug !== "*" && repoSlug.includes("*");
+}
+
 /**
  * Validate that a repo is allowed for operations
  * If repo is a bare name (no slash), it is automatically qualified with the
@@ -164,7 +176,7 @@ function validateRepo(repo, defaultRepo, allowedRepos) {
   let qualifiedRepo = repo;
   if (!repo.includes("/")) {
     const defaultRepoParts = parseRepoSlug(defaultRepo);
-    if (defaultRepoParts) {
+    if (defaultRepoParts && !defaultRepoParts.owner.includes("*")) {
       qualifiedRepo = `${defaultRepoParts.owner}/${repo}`;
     }
   }
@@ -184,6 +196,21 @@ function validateRepo(repo, defaultRepo, allowedRepos) {
     return { valid: true, error: null, qualifiedRepo };
   }
 
+  // Pattern default repo (e.g. "org/*") allows any matching repo or an allowed-repos entry
+  if (isRepoPattern(defaultRepo)) {
+    const parsed = parseRepoSlug(qualifiedRepo);
+    if (!parsed || qualifiedRepo.includes("*")) {
+      return {
+        valid: false,
+        error: `Repository '${repo}' is not a valid 'owner/repo' slug.`,
+        qualifiedRepo,
+      };
+    }
+    if (isRepoAllowed(qualifiedRepo, new Set([defaultRepo, ...allowedRepos]))) {
+      return { valid: true, error: null, qualifiedRepo };
+    }
+  }
+
   // Default repo is always allowed
   if (qualifiedRepo === defaultRepo) {
     return { valid: true, error: null, qualifiedRepo };
@@ -252,6 +279,22 @@ function resolveAndValidateRepo(item, defaultTargetRepo, allowedRepos, operation
     };
   }
 
+  // An expression that was not expanded by Actions cannot be used as a repository
+  if (itemRepo.includes("${{")) {
+    return {
+      success: false,
+      error: `Target repository '${itemRepo}' for ${operationType} contains an unresolved expression. Ensure the referenced variable or input is defined.`,
+    };
+  }
+
+  // A pattern target-repo has no single default; each item must name its repository
+  if (!rawItemRepo && isRepoPattern(trimmedDefaultTargetRepo)) {
+    return {
+      success: false,
+      error: `target-repo '${trimmedDefaultTargetRepo}' is a pattern, so the ${operationType} must specify a 'repo' that matches it or the allowed-repos list.`,
+    };
+  }
+
   // Validate the repository is allowed
   const repoValidation = validateRepo(itemRepo, trimmedDefaultTargetRepo, allowedRepos);
   if (!repoValidation.valid) {
@@ -328,6 +371,7 @@ function resolveExecutionOwnerRepo() {
 module.exports = {
   parseAllowedRepos,
   getDefaultTargetRepo,
+  isRepoPattern,
   isRepoAllowed,
   validateRepo,
   validateTargetRepo,
@@ -336,6 +336,74 @@ describe("repo_helpers", () => {
     });
   });
 
+  describe("target-repo patterns and expressions", () => {
+    it("should detect repository patterns", async () => {
+      const { isRepoPattern } = await import("./repo_helpers.cjs");
+      expect(isRepoPattern("org/*")).toBe(true);
+      expect(isRepoPattern("*/gh-aw")).toBe(true);
+      expect(isRepoPattern("*")).toBe(false);
+      expect(isRepoPattern("org/repo")).toBe(false);
+    });
+
+    it("should trim an expanded target-repo expression", async () => {
+      const { getDefaultTargetRepo } = await import("./repo_helpers.cjs");
+      expect(getDefaultTargetRepo({ "target-repo": "  org/from-var  " })).toBe("org/from-var");
+    });
+
+    it("should fall back to the context repo when the expanded target-repo is empty", async () => {
+      const { getDefaultTargetRepo } = await import("./repo_helpers.cjs");
+      expect(getDefaultTargetRepo({ "target-repo": "  " })).toBe("test-owner/test-repo");
+    });
+
+    it("should allow repos matching a pattern target-repo", async () => {
+      const { resolveAndValidateRepo } = await import("./repo_helpers.cjs");
+      const result = resolveAndValidateRepo({ repo: "org/service-a" }, "org/*", new Set(), "issue");
+      expect(result.success).toBe(true);
+      expect(result.repo).toBe("org/service-a");
+    });
+
+    it("should qualify bare repo names with the pattern owner", async () => {
+      const { resolveAndValidateRepo } = await import("./repo_helpers.cjs");
+      const result = resolveAndValidateRepo({ repo: "service-b" }, "org/*", new Set(), "issue");
+      expect(result.success).toBe(true);
+      expect(result.repo).toBe("org/service-b");
+    });
+
+    it("should allow allowed-repos entries outside the pattern", async () => {
+      const { resolveAndValidateRepo } = await import("./repo_helpers.cjs");
+      const result = resolveAndValidateRepo({ repo: "partner/shared" }, "org/*", new Set(["partner/shared"]), "issue");
+      expect(result.success).toBe(true);
+    });
+
+    it("should reject repos outside the pattern and allowed-repos", async () => {
+      const { resolveAndValidateRepo } = await import("./repo_helpers.cjs");
+      const result = resolveAndValidateRepo({ repo: "other/repo" }, "org/*", new Set(), "issue");
+      expect(result.success).toBe(false);
+      expect(result.error).toContain("not in the allowed-repos list");
+    });
+
+    it("should require an explicit repo when target-repo is a pattern", async () => {
+      const { resolveAndValidateRepo } = await import("./repo_helpers.cjs");
+      const result = resolveAndValidateRepo({}, "org/*", new Set(), "issue");
+      expect(result.success).toBe(false);
+      expect(result.error).toContain("is a pattern");
+    });
+
+    it("should reject wildcard repos even when they match the pattern", async () => {
+      const { resolveAndValidateRepo } = await import("./repo_helpers.cjs");
+      const result = resolveAndValidateRepo({ repo: "org/*" }, "org/*", new Set(), "issue");
+      expect(result.success).toBe(false);
+      expect(result.error).toContain("not a valid 'owner/repo' slug");
+    });
+
+    it("should reject unresolved expressions", async () => {
+      const { resolveAndValidateRepo } = await import("./repo_helpers.cjs");
+      const result = resolveAndValidateRepo({}, "${{ vars.TARGET_REPO }}", new Set(), "issue");
+      expect(result.success).toBe(false);
+      expect(result.error).toContain("unresolved expression");
+    });
+  });
+
   describe("resolveAndValidateRepo", () => {
     it("should successfully resolve and validate default repo", async () => {
       const { resolveAndValidateRepo } = await import("./repo_helpers.cjs");
@@ -0,0 +1,289 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+/**
+ * @typedef {import('./types/handler-factory').HandlerFactoryFunction} HandlerFactoryFunction
+ * @typedef {import('./types/handler-factory').HandlerConfig} HandlerConfig
+ * @typedef {import('./types/handler-factory').ResolvedTemporaryIds} ResolvedTemporaryIds
+ * @typedef {import('./types/handler-factory').HandlerResult} HandlerResult
+ */
+
+/**
+ * @typedef {{ teams?: Array<string|null|undefined|false>, pull_request_number?: number|string, repo?: string }} RequestTeamReviewMessage
+ */
+
+/** @type {string} Safe output type handled by this module */
+const HANDLER_TYPE = "request_team_review";
+
+const { processItems } = require("./safe_output_processor.cjs");
+const { getErrorMessage } = require("./error_helpers.cjs");
+const { getPullRequestNumber } = require("./pr_helpers.cjs");
+const { logStagedPreviewInfo } = require("./staged_preview.cjs");
+const { isStagedMode, checkRequiredFilter } = require("./safe_output_helpers.cjs");
+const { createAuthenticatedGitHubClient } = require("./handler_auth.cjs");
+const { attachExecutionState, extractReviewStateFromData, fetchPullRequestReviewState } = require("./safe_output_execution_metadata.cjs");
+const { resolveTargetRepoConfig, resolveAndValidateRepo } = require("./repo_helpers.cjs");
+
+/**
+ * Strips a leading "@" and an optional "org/" prefix from a team reference.
+ * @param {string} reference - Team reference such as "@my-org/Platform Team", "my-org/platform", or "platform"
+ * @param {string} org - Organization that owns the pull request repository
+ * @returns {{ name: string, error?: string }} Bare team name or slug, or an error for a different org
+ */
+function normalizeTeamReference(reference, org) {
+  let name = String(reference).trim().replace(/^@/, "");
+  const slash = name.indexOf("/");
+  if (slash !== -1) {
+    const teamOrg = name.slice(0, slash);
+    if (teamOrg.toLowerCase() !== org.toLowerCase()) {
+      return { name, error: `Team ${reference} does not belong to organization ${org}` };
+    }
+    name = name.slice(slash + 1);
+  }
+  return { name: name.trim() };
+}
+
+/**
+ * Converts a team name to the slug GitHub derives from it (lowercase, spaces and
+ * punctuation collapsed to hyphens).
+ * @param {string} name - Team name or slug
+ * @returns {string} Team slug
+ */
+function toTeamSlug(name) {
+  return name
+    .toLowerCase()
+    .replace(/[^a-z0-9_]+/g, "-")
+    .replace(/^-+|-+$/g, "");
+}
+
+/**
+ * Main handler factory for request_team_review
+ * Returns a message handler function that requests reviews from organization teams
+ * @type {HandlerFactoryFunction}
+ */
+async function main(config = {}) {
+  const allowedTeams = (config.allowed ?? []).map(team => String(team).replace(/^@/, "").toLowerCase());
+  const maxCount = config.max ?? 3;
+  const { defaultTargetRepo, allowedRepos } = resolveTargetRepoConfig(config);
+  const githubClient = await createAuthenticatedGitHubClient(config);
+  const isStaged = isStagedMode(config);
+
+  const requiredLabels = Array.isArray(config.required_labels) ? config.required_labels : [];
+  const requiredTitlePrefix = config.required_title_prefix || "";
+  if (requiredLabels.length > 0) core.info(`Required labels (all): ${requiredLabels.join(", ")}`);
+  if (requiredTitlePrefix) core.info(`Required title prefix: ${requiredTitlePrefix}`);
+
+  core.info(`Request team review configuration: max=${maxCount}`);
+  core.info(`Default target repo: ${defaultTargetRepo}`);
+  if (allowedRepos.size > 0) {
+    core.info(`Allowed repos: ${Array.from(allowedRepos).join(", ")}`);
+  }
+  if (allowedTeams.length > 0) {
+    core.info(`Allowed teams: ${allowedTeams.join(", ")}`);
+  }
+
+  /** @type {Map<string, Array<{ slug: string, name: string }>>} Organization teams, listed once per org */
+  const orgTeamsCache = new Map();
+
+  /**
+   * Lists all teams in an organization. Used when a team is referenced by display name.
+   * @param {string} org - Organization login
+   * @returns {Promise<Array<{ slug: string, name: string }>>}
+   */
+  async function listOrgTeams(org) {
+    const cached = orgTeamsCache.get(org);
+    if (cached) {
+      return cached;
+    }
+    const teams = await githubClient.paginate(githubClient.rest.teams.list, { org, per_page: 100 });
+    const result = teams.map(/** @param {any} team */ team => ({ slug: team.slug, name: team.name }));
+    orgTeamsCache.set(org, result);
+    return result;
+  }
+
+  /**
+   * Resolves a team reference to its slug and display name via the org teams API.
+   * When the token cannot read org teams, the derived slug is used as-is and the
+   * review request itself validates it.
+   * @param {string} org - Organization login
+   * @param {string} name - Team name or slug
+   * @returns {Promise<{ slug: string, name: string } | null>} Resolved team, or null when the team does not exist
+   */
+  async function resolveTeam(org, name) {
+    const slug = toTeamSlug(name);
+    try {
+      const { data } = await githubClient.rest.teams.getByName({ org, team_slug: slug });
+      return { slug: data.slug, name: data.name };
+    } catch (error) {
+      const status = /** @type {any} */ error?.status;
+      if (status !== 404) {
+        core.warning(`Could not look up team ${org}/${slug} (${getErrorMessage(error)}); using the slug as given`);
+        return { slug, name };
+      }
+    }
+    const teams = await listOrgTeams(org);
+    const match = teams.find(team => team.name.toLowerCase() === name.toLowerCase() || team.slug === slug);
+    return match ?? null;
+  }
+
+  let processedCount = 0;
+
+  /**
+   * @param {RequestTeamReviewMessage} message - The request_team_review message to process
+   * @param {ResolvedTemporaryIds} resolvedTemporaryIds - Map of temporary IDs to {repo, number}
+   * @returns {Promise<HandlerResult>} Result with success/error status
+   */
+  return async function handleRequestTeamReview(message, resolvedTemporaryIds) {
+    if (processedCount >= maxCount) {
+      core.warning(`Skipping request_team_review: max count of ${maxCount} reached`);
+      return {
+        success: false,
+        error: `Max count of ${maxCount} reached`,
+      };
+    }
+
+    processedCount++;
+
+    const { prNumber, error } = getPullRequestNumber(message, context);
+    if (error) {
+      core.warning(error);
+      return {
+        success: false,
+        error,
+      };
+    }
+    if (prNumber === null) {
+      return {
+        success: false,
+        error: "Pull request number is required",
+      };
+    }
+
+    const repoResult = resolveAndValidateRepo(message, defaultTargetRepo, allowedRepos, "pull request team reviewer");
+    if (!repoResult.success) {
+      core.warning(`Skipping request_team_review: ${repoResult.error}`);
+      return {
+        success: false,
+        error: repoResult.error,
+      };
+    }
+    const { repo: itemRepo, repoParts } = repoResult;
+    core.info(`Target repository: ${itemRepo}`);
+
+    const filterResult = await checkRequiredFilter(githubClient, repoParts, prNumber, requiredLabels, requiredTitlePrefix, HANDLER_TYPE);
+    if (filterResult) return filterResult;
+
+    const requestedTeams = (message.teams ?? []).filter(team => typeof team === "string" && team.trim() !== "");
+    core.info(`Requested teams: ${JSON.stringify(requestedTeams)}`);
+
+    /** @type {string[]} */
+    const resolvedSlugs = [];
+    /** @type {string[]} */
+    const rejected = [];
+    try {
+      for (const reference of /** @type {string[]} */ requestedTeams) {
+        const normalized = normalizeTeamReference(reference, repoParts.owner);
+        if (normalized.error) {
+          core.warning(normalized.error);
+          rejected.push(reference);
+          continue;
+        }
+        const team = await resolveTeam(repoParts.owner, normalized.name);
+        if (!team) {
+          core.warning(`Team ${normalized.name} was not found in organization ${repoParts.owner}`);
+          rejected.push(reference);
+          continue;
+        }
+        if (allowedTeams.length > 0 && !allowedTeams.includes(team.slug) && !allowedTeams.includes(team.name.toLowerCase())) {
+          core.warning(`Team ${team.slug} is not in the allowed list`);
+          rejected.push(reference);
+          continue;
+        }
+        resolvedSlugs.push(team.slug);
+      }
+    } catch (error) {
+      const errorMessage = getErrorMessage(error);
+      core.error(`Failed to resolve teams: ${errorMessage}`);
+      return {
+        success: false,
+        error: errorMessage,
+      };
+    }
+
+    // Dedupe and limit using the shared helper; the allowlist was applied after resolution
+    const uniqueTeams = processItems(resolvedSlugs, [], maxCount);
+
+    if (uniqueTeams.length === 0) {
+      core.info("No teams to request");
+      return {
+        success: true,
+        skipped: true,
+        prNumber,
+        teamReviewersAdded: [],
+        teamsRejected: rejected,
+        message: "No valid teams found",
+      };
+    }
+
+    core.info(`Requesting review from teams on PR #${prNumber}: ${JSON.stringify(uniqueTeams)}`);
+
+    // If in staged mode, preview without executing
+    if (isStaged) {
+      logStagedPreviewInfo(`Would request review from teams on PR #${prNumber}`);
+      return {
+        success: true,
+        staged: true,
+        previewInfo: {
+          number: prNumber,
+          team_reviewers: uniqueTeams,
+        },
+      };
+    }
+
+    try {
+      const beforeState = await fetchPullRequestReviewState(githubClient, repoParts, prNumber);
+      const response = await githubClient.rest.pulls.requestReviewers({
+        owner: repoParts.owner,
+        repo: repoParts.repo,
+        pull_number: prNumber,
+        reviewers: [],
+        team_reviewers: uniqueTeams,
+      });
+      core.info(`Successfully requested review from teams on PR #${prNumber}: ${JSON.stringify(uniqueTeams)}`);
+
+      const afterState = response?.data
+        ? {
+            ...extractReviewStateFromData(response.data, []),
+            reviews: beforeState.reviews,
+          }
+        : await fetchPullRequestReviewState(githubClient, repoParts, prNumber);
+
+      return attachExecutionState(
+        {
+          success: true,
+          prNumber,
+          number: prNumber,
+          repo: itemRepo,
+          pull_request_number: prNumber,
+          pull_request_url: `https://github.com/${repoParts.owner}/${repoParts.repo}/pull/${prNumber}`,
+          teamReviewersAdded: uniqueTeams,
+          teamsRejected: rejected,
+          metadata: {
+            requested_team_reviewers: uniqueTeams,
+          },
+        },
+        beforeState,
+        afterState
+      );
+    } catch (error) {
+      const errorMessage = getErrorMessage(error);
+      core.error(`Failed to request team review: ${errorMessage}`);
+      return {
+        success: false,
+        error: errorMessage,
+      };
+    }
+  };
+}
+
+module.exports = { main, normalizeTeamReference, toTeamSlug };
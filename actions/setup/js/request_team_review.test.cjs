@@ -0,0 +1,168 @@
+import { describe, it, expect, beforeEach, vi } from "vitest";
+
+// Mock the global objects that GitHub Actions provides
+const mockCore = {
+  debug: vi.fn(),
+  info: vi.fn(),
+  notice: vi.fn(),
+  warning: vi.fn(),
+  error: vi.fn(),
+  setFailed: vi.fn(),
+  setOutput: vi.fn(),
+  summary: {
+    addRaw: vi.fn().mockReturnThis(),
+    write: vi.fn().mockResolvedValue(),
+  },
+};
+
+const orgTeams = [
+  { slug: "platform-reviewers", name: "Platform Reviewers" },
+  { slug: "security", name: "Security" },
+  { slug: "docs", name: "Docs" },
+];
+
+const mockGithub = {
+  paginate: vi.fn(),
+  rest: {
+    pulls: {
+      get: vi.fn(),
+      listReviews: vi.fn(),
+      requestReviewers: vi.fn(),
+    },
+    teams: {
+      getByName: vi.fn(),
+      list: vi.fn(),
+    },
+  },
+};
+
+const mockContext = {
+  eventName: "pull_request",
+  repo: {
+    owner: "testorg",
+    repo: "testrepo",
+  },
+  payload: {
+    pull_request: {
+      number: 123,
+    },
+  },
+};
+
+// Set up global mocks before importing the module
+global.core = mockCore;
+global.github = mockGithub;
+global.context = mockContext;
+
+describe("request_team_review", () => {
+  beforeEach(() => {
+    vi.clearAllMocks();
+    global.context = mockContext;
+    mockGithub.rest.pulls.get.mockResolvedValue({ data: { requested_reviewers: [], requested_teams: [] } });
+    mockGithub.rest.pulls.listReviews.mockResolvedValue({ data: [] });
+    mockGithub.rest.pulls.requestReviewers.mockResolvedValue({});
+    mockGithub.rest.teams.getByName.mockImplementation(async ({ team_slug }) => {
+      const team = orgTeams.find(t => t.slug === team_slug);
+      if (!team) {
+        throw Object.assign(new Error("Not Found"), { status: 404 });
+      }
+      return { data: team };
+    });
+    mockGithub.paginate.mockResolvedValue(orgTeams);
+  });
+
+  it("should request review from resolved team slugs", async () => {
+    const { main } = require("./request_team_review.cjs");
+    const handler = await main({ max: 3 });
+
+    const result = await handler({ type: "request_team_review", teams: ["security", "@testorg/docs"] }, {});
+
+    expect(result.success).toBe(true);
+    expect(result.teamReviewersAdded).toEqual(["security", "docs"]);
+    expect(mockGithub.rest.pulls.requestReviewers).toHaveBeenCalledWith({
+      owner: "testorg",
+      repo: "testrepo",
+      pull_number: 123,
+      reviewers: [],
+      team_reviewers: ["security", "docs"],
+    });
+  });
+
+  it("should resolve a team display name through the org teams list", async () => {
+    mockGithub.rest.teams.getByName.mockRejectedValueOnce(Object.assign(new Error("Not Found"), { status: 404 }));
+    const { main } = require("./request_team_review.cjs");
+    const handler = await main({});
+
+    const result = await handler({ type: "request_team_review", teams: ["Platform Reviewers"] }, {});
+
+    expect(result.success).toBe(true);
+    expect(result.teamReviewersAdded).toEqual(["platform-reviewers"]);
+  });
+
+  it("should reject teams outside the allowlist", async () => {
+    const { main } = require("./request_team_review.cjs");
+    const handler = await main({ allowed: ["security"] });
+
+    const result = await handler({ type: "request_team_review", teams: ["security", "docs"] }, {});
+
+    expect(result.success).toBe(true);
+    expect(result.teamReviewersAdded).toEqual(["security"]);
+    expect(result.teamsRejected).toEqual(["docs"]);
+    expect(mockCore.warning).toHaveBeenCalledWith("Team docs is not in the allowed list");
+  });
+
+  it("should skip teams from another organization and unknown teams", async () => {
+    const { main } = require("./request_team_review.cjs");
+    const handler = await main({});
+
+    const result = await handler({ type: "request_team_review", teams: ["otherorg/security", "missing-team"] }, {});
+
+    expect(result.success).toBe(true);
+    expect(result.skipped).toBe(true);
+    expect(result.teamsRejected).toEqual(["otherorg/security", "missing-team"]);
+    expect(mockGithub.rest.pulls.requestReviewers).not.toHaveBeenCalled();
+  });
+
+  it("should fall back to the slug when the token cannot read org teams", async () => {
+    mockGithub.rest.teams.getByName.mockRejectedValue(Object.assign(new Error("Forbidden"), { status: 403 }));
+    const { main } = require("./request_team_review.cjs");
+    const handler = await main({});
+
+    const result = await handler({ type: "request_team_review", teams: ["Release Managers"] }, {});
+
+    expect(result.success).toBe(true);
+    expect(result.teamReviewersAdded).toEqual(["release-managers"]);
+    expect(mockGithub.paginate).not.toHaveBeenCalled();
+  });
+
+  it("should preview without requesting reviews in staged mode", async () => {
+    const { main } = require("./request_team_review.cjs");
+    const handler = await main({ staged: true });
+
+    const result = await handler({ type: "request_team_review", teams: ["security"] }, {});
+
+    expect(result.staged).toBe(true);
+    expect(result.previewInfo.team_reviewers).toEqual(["security"]);
+    expect(mockGithub.rest.pulls.requestReviewers).not.toHaveBeenCalled();
+  });
+
+  it("should respect max count", async () => {
+    const { main } = require("./request_team_review.cjs");
+    const handler = await main({ max: 1 });
+
+    await handler({ type: "request_team_review", teams: ["security"] }, {});
+    const result = await handler({ type: "request_team_review", teams: ["docs"] }, {});
+
+    expect(result.success).toBe(false);
+    expect(result.error).toContain("Max count of 1 reached");
+  });
+});
+
+describe("normalizeTeamReference", () => {
+  it("should strip @ and org prefixes", () => {
+    const { normalizeTeamReference, toTeamSlug } = require("./request_team_review.cjs");
+    expect(normalizeTeamReference("@TestOrg/Platform Reviewers", "testorg")).toEqual({ name: "Platform Reviewers" });
+    expect(normalizeTeamReference("other/team", "testorg").error).toContain("does not belong to organization testorg");
+    expect(toTeamSlug("Platform Reviewers!")).toBe("platform-reviewers");
+  });
+});
@@ -11,8 +11,9 @@
 
 const { loadAgentOutput } = require("./load_agent_output.cjs");
 const { getErrorMessage } = require("./error_helpers.cjs");
+const { setRetryPolicyOverride } = require("./error_recovery.cjs");
 const { ERR_CONFIG, ERR_PARSE, ERR_VALIDATION } = require("./error_codes.cjs");
-const { hasUnresolvedTemporaryIds, replaceTemporaryIdReferences, replaceArtifactUrlReferences, normalizeTemporaryId } = require("./temporary_id.cjs");
+const { hasUnresolvedTemporaryIds, replaceTemporaryIdReferences, replaceArtifactUrlReferences, normalizeTemporaryId, getCreatedTemporaryId, resolveTargetTemporaryIds } = require("./temporary_id.cjs");
 const { generateMissingInfoSections } = require("./missing_info_formatter.cjs");
 const { setCollectedMissings } = require("./missing_messages_helper.cjs");
 const { writeSafeOutputSummaries } = require("./safe_output_summary.cjs");
@@ -27,6 +28,7 @@ const { emitSafeOutputActionOutputs } = require("./safe_outputs_action_outputs.c
 const { listCommentMemoryFiles, COMMENT_MEMORY_DIR } = require("./comment_memory_helpers.cjs");
 const { checkRateLimitHeadroom } = require("./rate_limit_helpers.cjs");
 const { redactSensitiveConfig } = require("./safe_outputs_config_redact.cjs");
+const { applyMaxTotal, describeDroppedOutput } = require("./safe_output_max_total.cjs");
 const nodePath = require("path");
 const fs = require("fs");
 const GITHUB_TOKEN_CONFIG_KEY = "github-token";
@@ -63,14 +65,19 @@ const HANDLER_MAP = {
   set_issue_type: "./set_issue_type.cjs",
   set_issue_field: "./set_issue_field.cjs",
   add_reviewer: "./add_reviewer.cjs",
+  request_team_review: "./request_team_review.cjs",
   assign_milestone: "./assign_milestone.cjs",
   assign_to_user: "./assign_to_user.cjs",
   unassign_from_user: "./unassign_from_user.cjs",
+  lock_issue: "./lock_issue.cjs",
+  lock_pull_request: "./lock_pull_request.cjs",
   assign_to_agent: "./assign_to_agent.cjs",
   create_agent_session: "./create_agent_session.cjs",
   create_code_scanning_alert: "./create_code_scanning_alert.cjs",
   autofix_code_scanning_alert: "./autofix_code_scanning_alert.cjs",
   create_check_run: "./create_check_run.cjs",
+  create_commit_status: "./create_commit_status.cjs",
+  notify: "./notify.cjs",
   dispatch_workflow: "./dispatch_workflow.cjs",
   dispatch_repository: "./dispatch_repository.cjs",
   call_workflow: "./call_workflow.cjs",
@@ -84,7 +91,9 @@ const HANDLER_MAP = {
   create_project: "./create_project.cjs",
   create_project_status_update: "./create_project_status_update.cjs",
   update_project: "./update_project.cjs",
+  update_project_item: "./update_project_item.cjs",
   upload_artifact: "./upload_artifact.cjs",
+  create_artifact: "./create_artifact.cjs",
 };
 
 /**
@@ -103,7 +112,7 @@ const STANDALONE_STEP_TYPES = new Set(["upload_asset", "noop"]);
 const CODE_PUSH_TYPES = new Set(["push_to_pull_request_branch", "create_pull_request"]);
 
 /** @type {Set<string>} Project-safe-output handlers that should default to GH_AW_PROJECT_GITHUB_TOKEN when no per-handler github-token is configured. */
-const PROJECT_HANDLER_TYPES = new Set(["create_project", "create_project_status_update", "update_project"]);
+const PROJECT_HANDLER_TYPES = new Set(["create_project", "create_project_status_update", "update_project", "update_project_item"]);
 
 // Threat-detection warn-mode requirement IDs from safe-outputs specification:
 // - WTD2: Convertible outputs must be mapped to a reviewable type.
@@ -137,6 +146,7 @@ const THREAT_WARNING_REVIEWABLE_TYPES = new Set([
   "update_release",
   "create_code_scanning_alert",
   "create_check_run",
+  "create_commit_status",
   "create_missing_tool_issue",
   "missing_tool",
   "create_missing_data_issue",
@@ -173,17 +183,23 @@ const THREAT_WARNING_ABORT_TYPES = new Set([
   "add_labels",
   "remove_labels",
   "add_reviewer",
+  "request_team_review",
   "assign_milestone",
   "assign_to_agent",
   "assign_to_user",
   "unassign_from_user",
+  "lock_issue",
+  "lock_pull_request",
   "hide_comment",
   "set_issue_type",
   "set_issue_field",
   "create_project",
   "update_project",
+  "update_project_item",
   "upload_asset",
   "upload_artifact",
+  "create_artifact",
+  "notify",
   "dispatch_workflow",
   "dispatch_repository",
   "call_workflow",
@@ -318,6 +334,30 @@ function wrapWithClientRebinding(type, messageHandler, handlerGithubClient) {
   };
 }
 
+/**
+ * Wrap a handler so GitHub API calls it retries through withRetry follow the
+ * configured safe-outputs retry policy (per-output `retry` or the global one).
+ *
+ * @param {string} type - Safe-output handler type
+ * @param {Function} messageHandler - Loaded handler function
+ * @param {Object|null|undefined} retryPolicy - Retry policy ({max_attempts, backoff}) or null
+ * @returns {Function} Wrapped handler function
+ */
+function wrapWithRetryPolicy(type, messageHandler, retryPolicy) {
+  if (!retryPolicy || typeof retryPolicy !== "object") {
+    return messageHandler;
+  }
+  core.info(`Retry policy for ${type}: max_attempts=${retryPolicy.max_attempts ?? "default"}, backoff=${retryPolicy.backoff ?? "default"}s`);
+  return async (...args) => {
+    const previousPolicy = setRetryPolicyOverride(retryPolicy);
+    try {
+      return await messageHandler(...args);
+    } finally {
+      setRetryPolicyOverride(previousPolicy);
+    }
+  };
+}
+
 /**
  * Load and initialize handlers for enabled safe output types
  * Calls each handler's factory function (main) to get message processors
@@ -376,7 +416,8 @@ async function loadHandlers(config, prReviewBufferRegistry, resolvedAllowedMenti
             throw error;
           }
 
-          messageHandlers.set(type, wrapWithClientRebinding(type, messageHandler, handlerGithubClient));
+          const retryPolicy = handlerConfig.retry ?? config.retry;
+          messageHandlers.set(type, wrapWithRetryPolicy(type, wrapWithClientRebinding(type, messageHandler, handlerGithubClient), retryPolicy));
           core.info(`✓ Loaded and initialized handler for: ${type}`);
         } else {
           core.warning(`Handler module ${type} does not export a main function`);
@@ -735,6 +776,24 @@ async function processMessages(messageHandlers, messages, onItemCreated = null)
   /** @type {Array<{type: string, message: any, messageIndex: number, handler: Function}>} */
   const deferredMessages = [];
 
+  // Index of the message that creates each temporary ID. A message whose target number
+  // field references a temporary ID created by a later message is deferred until the
+  // retry pass; one whose creating message already failed fails as well.
+  /** @type {Map<string, number>} */
+  const temporaryIdCreators = new Map();
+  messages.forEach((message, index) => {
+    const createdId = getCreatedTemporaryId(message);
+    if (createdId !== null && !temporaryIdCreators.has(createdId)) {
+      temporaryIdCreators.set(createdId, index);
+    }
+  });
+  const currentRepo = typeof context !== "undefined" && context.repo?.owner && context.repo?.repo ? `${context.repo.owner}/${context.repo.repo}` : "";
+  /**
+   * @param {number|undefined} creatorIndex - Index of the message that creates a temporary ID
+   * @returns {boolean} True when that message has been processed and did not succeed
+   */
+  const isFailedCreator = creatorIndex => creatorIndex !== undefined && results.some(r => r.messageIndex === creatorIndex && r.success === false && r.deferred !== true);
+
   // Track code-push failures for fail-fast behaviour.
   // If a code-push type (push_to_pull_request_branch / create_pull_request) fails,
   // all subsequent non-code-push messages are cancelled with a clear reason.
@@ -903,8 +962,45 @@ async function processMessages(messageHandlers, messages, onItemCreated = null)
         }
       }
 
+      // Resolve temporary IDs in target number fields so any handler can act on an item
+      // created earlier in this run (e.g. add_reviewer on the pull request of a
+      // create_pull_request). A target created later in the run defers the message.
+      const targetResolution = resolveTargetTemporaryIds(effectiveMessage, temporaryIdMap, currentRepo);
+      const failedTargets = targetResolution.unresolved.filter(id => isFailedCreator(temporaryIdCreators.get(id)));
+      if (failedTargets.length > 0) {
+        const errorMsg = `Target ${failedTargets.join(", ")} was not created: the safe output that creates it did not succeed`;
+        core.error(`✗ Message ${i + 1} (${messageType}) failed: ${errorMsg}`);
+        results.push({
+          type: messageType,
+          messageIndex: i,
+          success: false,
+          error: errorMsg,
+        });
+        continue;
+      }
+      const pendingTargets = targetResolution.unresolved.filter(id => {
+        const creatorIndex = temporaryIdCreators.get(id);
+        return creatorIndex !== undefined && (creatorIndex > i || results.some(r => r.messageIndex === creatorIndex && r.deferred === true));
+      });
+      if (pendingTargets.length > 0) {
+        core.info(`⏸ Message ${i + 1} (${messageType}) deferred - waiting for ${pendingTargets.join(", ")} to be created`);
+        deferredMessages.push({
+          type: messageType,
+          message: effectiveMessage,
+          messageIndex: i,
+          handler: messageHandler,
+        });
+        results.push({
+          type: messageType,
+          messageIndex: i,
+          success: false,
+          deferred: true,
+        });
+        continue;
+      }
+
       // Call the message handler with the individual message and resolved temp IDs
-      const result = await messageHandler(effectiveMessage, resolvedTemporaryIds, temporaryIdMap);
+      const result = await messageHandler(targetResolution.message, resolvedTemporaryIds, temporaryIdMap);
 
       // Check if the handler explicitly returned a skipped result (e.g. if_no_changes: warn/ignore).
       // Skipped results should NOT trigger fail-fast cancellation of subsequent messages.
@@ -1088,8 +1184,23 @@ async function processMessages(messageHandlers, messages, onItemCreated = null)
         // Record the temp ID map size before processing to detect new IDs
         const tempIdMapSizeBefore = temporaryIdMap.size;
 
+        // A target that is still unresolved was never created: the message that
+        // creates it failed or was skipped
+        const targetResolution = resolveTargetTemporaryIds(deferred.message, temporaryIdMap, currentRepo);
+        const missingTargets = targetResolution.unresolved.filter(id => isFailedCreator(temporaryIdCreators.get(id)) || results.some(r => r.messageIndex === temporaryIdCreators.get(id) && r.deferred === true));
+        if (missingTargets.length > 0) {
+          const errorMsg = `Target ${missingTargets.join(", ")} was not created: the safe output that creates it did not succeed`;
+          core.error(`✗ Retry of message ${deferred.messageIndex + 1} (${deferred.type}) failed: ${errorMsg}`);
+          const resultIndex = results.findIndex(r => r.messageIndex === deferred.messageIndex);
+          if (resultIndex >= 0) {
+            results[resultIndex].deferred = false;
+            results[resultIndex].error = errorMsg;
+          }
+          continue;
+        }
+
         // Call the handler again with updated temp ID map
-        const result = await deferred.handler(deferred.message, resolvedTemporaryIds, temporaryIdMap);
+        const result = await deferred.handler(targetResolution.message, resolvedTemporaryIds, temporaryIdMap);
 
         // Check if the handler explicitly returned a failure
         if (result && result.success === false && !result.deferred) {
@@ -1477,8 +1588,8 @@ async function main() {
     }
 
     const fileBackedCommentMemoryMessages = buildCommentMemoryMessagesFromFiles(agentOutputItems, config);
-    const allMessages = [...agentOutputItems, ...fileBackedCommentMemoryMessages];
-    if (allMessages.length === 0) {
+    const collectedMessages = [...agentOutputItems, ...fileBackedCommentMemoryMessages];
+    if (collectedMessages.length === 0) {
       core.info("No safe-output messages available - nothing to process");
       if (!isStaged) ensureManifestExists();
       core.setOutput("temporary_id_map", "{}");
@@ -1486,6 +1597,18 @@ async function main() {
       return;
     }
 
+    // Enforce safe-outputs.max-total across all types. Outputs handled by standalone
+    // steps or custom jobs are processed elsewhere, so they are not counted or dropped here.
+    const { kept: allMessages, dropped: droppedMessages } = applyMaxTotal(collectedMessages, config.max_total, config.priority, new Set([...STANDALONE_STEP_TYPES, ...loadCustomSafeOutputJobTypes()]));
+    if (typeof config.max_total === "number") {
+      const droppedOutputs = droppedMessages.map(describeDroppedOutput);
+      if (droppedOutputs.length > 0) {
+        core.warning(`Dropped ${droppedOutputs.length} safe output(s) exceeding max-total (${config.max_total}):\n${droppedOutputs.map(line => `  - ${line}`).join("\n")}`);
+      }
+      core.setOutput("dropped_outputs", droppedOutputs.join("\n"));
+      core.setOutput("dropped_output_count", String(droppedOutputs.length));
+    }
+
     // Create the PR review buffer registry (one per-PR buffer created on demand)
     const prReviewBufferRegistry = createPrReviewBufferRegistry();
 
@@ -1770,6 +1893,7 @@ module.exports = {
   main,
   loadConfig,
   loadHandlers,
+  wrapWithRetryPolicy,
   processMessages,
   buildCommentMemoryMessagesFromFiles,
   rollbackReviewResults,
@@ -6,6 +6,7 @@ import { createRequire } from "module";
 import {
   loadConfig,
   loadHandlers,
+  wrapWithRetryPolicy,
   processMessages,
   buildCommentMemoryMessagesFromFiles,
   rollbackReviewResults,
@@ -414,6 +415,32 @@ describe("Safe Output Handler Manager", () => {
     });
   });
 
+  describe("wrapWithRetryPolicy", () => {
+    it("should return the handler unchanged when no retry policy is configured", () => {
+      const handler = vi.fn();
+      expect(wrapWithRetryPolicy("create_issue", handler, undefined)).toBe(handler);
+    });
+
+    it("should apply the retry policy to withRetry calls made by the handler", async () => {
+      const { withRetry } = require("./error_recovery.cjs");
+      const operation = vi.fn().mockRejectedValue(new Error("502 Bad Gateway"));
+      const handler = async () => withRetry(operation, { maxRetries: 3, initialDelayMs: 1 }, "create_issue");
+
+      const wrapped = wrapWithRetryPolicy("create_issue", handler, { max_attempts: 1 });
+      await expect(wrapped({ type: "create_issue" })).rejects.toThrow("create_issue failed");
+
+      expect(operation).toHaveBeenCalledTimes(1);
+    });
+
+    it("should restore the previous policy after the handler finishes", async () => {
+      const { setRetryPolicyOverride } = require("./error_recovery.cjs");
+      const wrapped = wrapWithRetryPolicy("add_comment", async () => "done", { max_attempts: 2, backoff: 5 });
+
+      expect(await wrapped({ type: "add_comment" })).toBe("done");
+      expect(setRetryPolicyOverride(null)).toBeNull();
+    });
+  });
+
   describe("loadHandlers - path traversal sanitization", () => {
     // These tests exercise the path traversal sanitization added to protect against
     // malicious scriptFilename values in GH_AW_SAFE_OUTPUT_SCRIPTS.
@@ -1176,6 +1203,51 @@ describe("Safe Output Handler Manager", () => {
       expect(linkResult.deferred).toBe(false);
     });
 
+    it("should resolve target temporary IDs for handlers and defer targets created later", async () => {
+      const messages = [
+        { type: "add_reviewer", pull_request_number: "#aw_pr1", reviewers: ["octocat"] },
+        { type: "create_pull_request", temporary_id: "aw_pr1", title: "Fix", body: "Body" },
+      ];
+
+      const mockReviewerHandler = vi.fn().mockResolvedValue({ success: true, prNumber: 7 });
+      const mockCreatePRHandler = vi.fn().mockResolvedValue({ success: true, repo: "owner/repo", number: 7, temporaryId: "aw_pr1" });
+
+      const handlers = new Map([
+        ["add_reviewer", mockReviewerHandler],
+        ["create_pull_request", mockCreatePRHandler],
+      ]);
+
+      const result = await processMessages(handlers, messages);
+
+      expect(mockReviewerHandler).toHaveBeenCalledTimes(1);
+      expect(mockReviewerHandler.mock.calls[0][0]).toEqual({ type: "add_reviewer", pull_request_number: 7, reviewers: ["octocat"] });
+      const reviewerResult = result.results.find(r => r.type === "add_reviewer");
+      expect(reviewerResult.success).toBe(true);
+      expect(reviewerResult.deferred).toBe(false);
+    });
+
+    it("should fail outputs whose target temporary ID was not created", async () => {
+      const messages = [
+        { type: "create_issue", temporary_id: "aw_issue1", title: "Issue", body: "Body" },
+        { type: "assign_to_user", issue_number: "aw_issue1", assignees: ["octocat"] },
+      ];
+
+      const mockCreateIssueHandler = vi.fn().mockResolvedValue({ success: false, error: "API error" });
+      const mockAssignHandler = vi.fn();
+
+      const handlers = new Map([
+        ["create_issue", mockCreateIssueHandler],
+        ["assign_to_user", mockAssignHandler],
+      ]);
+
+      const result = await processMessages(handlers, messages);
+
+      expect(mockAssignHandler).not.toHaveBeenCalled();
+      const assignResult = result.results.find(r => r.type === "assign_to_user");
+      expect(assignResult.success).toBe(false);
+      expect(assignResult.error).toContain("aw_issue1 was not created");
+    });
+
     it("should track outputs created during deferred retry with unresolved temp IDs", async () => {
       const messages = [
         {
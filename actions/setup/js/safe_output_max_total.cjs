@@ -0,0 +1,98 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+/**
+ * Cross-type cap for safe outputs
+ *
+ * Enforces safe-outputs.max-total: when the agent emits more outputs than the cap
+ * allows, the highest-priority outputs are kept and the rest are dropped. Kept
+ * outputs are returned in their original order so temporary ID references between
+ * them still resolve.
+ */
+
+/**
+ * Diagnostic message types that never count toward max-total. They only report on
+ * the run and are needed for the conclusion comment and failure issues.
+ */
+const MAX_TOTAL_EXEMPT_TYPES = new Set(["noop", "missing_tool", "missing_data", "report_incomplete"]);
+
+/** Maximum length of the summary shown for a dropped output. */
+const DROPPED_SUMMARY_MAX_LENGTH = 80;
+
+/**
+ * Rank messages by priority and drop those beyond the cap.
+ *
+ * Types listed in priority rank in list order; unlisted types rank after them.
+ * Messages of the same rank keep the order the agent emitted them, so the result
+ * is deterministic for a given agent output.
+ *
+ * @param {any[]} messages - Agent output messages in order of appearance
+ * @param {number|undefined} maxTotal - Maximum number of counted messages to keep
+ * @param {string[]|undefined} priority - Normalized safe output types, highest priority first
+ * @param {Set<string>} [exemptTypes] - Additional types processed outside the handler manager
+ * @returns {{kept: any[], dropped: any[]}}
+ */
+function applyMaxTotal(messages, maxTotal, priority, exemptTypes = new Set()) {
+  if (typeof maxTotal !== "number" || maxTotal <= 0) {
+    return { kept: messages, dropped: [] };
+  }
+
+  const isExempt = (/** @type {any} */ message) => MAX_TOTAL_EXEMPT_TYPES.has(message?.type) || exemptTypes.has(message?.type);
+  const ranks = new Map((priority || []).map((type, index) => [type, index]));
+  const unlistedRank = ranks.size;
+
+  const counted = messages.map((message, index) => ({ message, index })).filter(({ message }) => !isExempt(message));
+  if (counted.length <= maxTotal) {
+    return { kept: messages, dropped: [] };
+  }
+
+  const ranked = [...counted].sort((a, b) => (ranks.get(a.message.type) ?? unlistedRank) - (ranks.get(b.message.type) ?? unlistedRank) || a.index - b.index);
+  const droppedIndexes = new Set(ranked.slice(maxTotal).map(({ index }) => index));
+
+  return {
+    kept: messages.filter((_, index) => !droppedIndexes.has(index)),
+    dropped: messages.filter((_, index) => droppedIndexes.has(index)),
+  };
+}
+
+/**
+ * Describe a dropped output in one line for the conclusion comment.
+ * @param {any} message - Dropped agent output message
+ * @returns {string} e.g. "create-issue: Flaky test in CI"
+ */
+function describeDroppedOutput(message) {
+  const type = String(message?.type || "unknown").replace(/_/g, "-");
+  const text = [message?.title, message?.body, message?.message].find(value => typeof value === "string" && value.trim() !== "");
+  if (!text) {
+    return type;
+  }
+  const firstLine = text.trim().split("\n")[0].trim();
+  const summary = firstLine.length > DROPPED_SUMMARY_MAX_LENGTH ? `${firstLine.slice(0, DROPPED_SUMMARY_MAX_LENGTH - 1)}…` : firstLine;
+  return `${type}: ${summary}`;
+}
+
+/**
+ * Render the dropped outputs section of the conclusion comment.
+ * @param {string} droppedOutputs - Newline-separated descriptions from describeDroppedOutput
+ * @param {number} droppedCount - Number of dropped outputs
+ * @returns {string} Markdown section, or an empty string when nothing was dropped
+ */
+function formatDroppedOutputsSection(droppedOutputs, droppedCount) {
+  const lines = droppedOutputs
+    .split("\n")
+    .map(line => line.trim())
+    .filter(Boolean);
+  if (droppedCount <= 0 || lines.length === 0) {
+    return "";
+  }
+  let section = `> [!NOTE]\n> ${droppedCount} safe output(s) were dropped because the run exceeded \`safe-outputs.max-total\`:\n>\n`;
+  section += lines.map(line => `> - ${line}`).join("\n");
+  return section;
+}
+
+module.exports = {
+  MAX_TOTAL_EXEMPT_TYPES,
+  applyMaxTotal,
+  describeDroppedOutput,
+  formatDroppedOutputsSection,
+};
@@ -0,0 +1,77 @@
+// @ts-check
+import { describe, it, expect } from "vitest";
+import { applyMaxTotal, describeDroppedOutput, formatDroppedOutputsSection } from "./safe_output_max_total.cjs";
+
+const messages = [
+  { type: "add_comment", body: "First comment" },
+  { type: "create_issue", title: "Bug A", temporary_id: "aw_a" },
+  { type: "noop", message: "Nothing else to do" },
+  { type: "add_comment", body: "Second comment" },
+  { type: "create_pull_request", title: "Fix bug A" },
+  { type: "create_issue", title: "Bug B" },
+];
+
+describe("applyMaxTotal", () => {
+  it("keeps all messages when max-total is not configured", () => {
+    const { kept, dropped } = applyMaxTotal(messages, undefined, undefined);
+    expect(kept).toBe(messages);
+    expect(dropped).toEqual([]);
+  });
+
+  it("keeps all messages when the cap is not exceeded", () => {
+    const { kept, dropped } = applyMaxTotal(messages, 5, undefined);
+    expect(kept).toBe(messages);
+    expect(dropped).toEqual([]);
+  });
+
+  it("keeps the earliest messages when no priority is configured", () => {
+    const { kept, dropped } = applyMaxTotal(messages, 3, undefined);
+    expect(kept.map(m => m.body || m.title || m.message)).toEqual(["First comment", "Bug A", "Nothing else to do", "Second comment"]);
+    expect(dropped.map(m => m.title)).toEqual(["Fix bug A", "Bug B"]);
+  });
+
+  it("keeps the highest-priority messages in their original order", () => {
+    const { kept, dropped } = applyMaxTotal(messages, 3, ["create_pull_request", "create_issue"]);
+    expect(kept.map(m => m.type)).toEqual(["create_issue", "noop", "create_pull_request", "create_issue"]);
+    expect(dropped.map(m => m.body)).toEqual(["First comment", "Second comment"]);
+  });
+
+  it("ranks unlisted types after listed types", () => {
+    const { dropped } = applyMaxTotal(messages, 4, ["add_comment"]);
+    expect(dropped.map(m => m.title)).toEqual(["Bug B"]);
+  });
+
+  it("does not count or drop exempt types", () => {
+    const withAssets = [{ type: "upload_asset", path: "a.png" }, ...messages];
+    const { kept, dropped } = applyMaxTotal(withAssets, 1, ["create_issue"], new Set(["upload_asset"]));
+    expect(kept.map(m => m.type)).toEqual(["upload_asset", "create_issue", "noop"]);
+    expect(dropped).toHaveLength(4);
+  });
+});
+
+describe("describeDroppedOutput", () => {
+  it("uses the title when present", () => {
+    expect(describeDroppedOutput({ type: "create_issue", title: "Bug A", body: "Details" })).toBe("create-issue: Bug A");
+  });
+
+  it("uses the first line of the body and truncates long text", () => {
+    const description = describeDroppedOutput({ type: "add_comment", body: `${"x".repeat(100)}\nsecond line` });
+    expect(description).toBe(`add-comment: ${"x".repeat(79)}…`);
+  });
+
+  it("falls back to the type", () => {
+    expect(describeDroppedOutput({ type: "add_labels", labels: ["bug"] })).toBe("add-labels");
+  });
+});
+
+describe("formatDroppedOutputsSection", () => {
+  it("lists dropped outputs", () => {
+    const section = formatDroppedOutputsSection("add-comment: First comment\ncreate-issue: Bug B\n", 2);
+    expect(section).toContain("2 safe output(s) were dropped because the run exceeded `safe-outputs.max-total`");
+    expect(section).toContain("> - add-comment: First comment\n> - create-issue: Bug B");
+  });
+
+  it("returns an empty string when nothing was dropped", () => {
+    expect(formatDroppedOutputsSection("", 0)).toBe("");
+  });
+});
@@ -2153,6 +2153,91 @@ function createHandlers(server, appendSafeOutput, config = {}) {
     };
   };
 
+  /**
+   * Handler for create_artifact tool.
+   * Spec cross-reference: not part of the numbered outcome types in Safe Output Outcome Evaluation v1.0.0.
+   *
+   * Writes the result content to the create-artifact staging directory
+   * ($RUNNER_TEMP/gh-aw/safeoutputs/create-artifacts/). After the agent finishes, the agent
+   * job encrypts every staged file with the workflow's public key and deletes the plaintext,
+   * so only the encrypted files reach the safe_outputs job.
+   *
+   * The recorded safe output entry carries only the artifact name and size; the content
+   * itself never appears in the safe outputs log.
+   */
+  const createArtifactHandler = args => {
+    const { name, content } = args || {};
+    if (typeof content !== "string" || content.length === 0) {
+      throw {
+        code: -32602,
+        message: `${ERR_VALIDATION}: create_artifact: content must be a non-empty string`,
+      };
+    }
+    const artifactName = typeof name === "string" ? name.trim().replace(/[^a-zA-Z0-9._\-]/g, "-") : "";
+    if (!artifactName || artifactName === "." || artifactName === ".." || artifactName.length > 128) {
+      throw {
+        code: -32602,
+        message: `${ERR_VALIDATION}: create_artifact: name must be 1-128 characters of letters, digits, '.', '_' or '-'`,
+      };
+    }
+    // The encrypted form of each result is published as <name>.gpg, so a name that already
+    // ends in .gpg would be indistinguishable from ciphertext.
+    if (artifactName.toLowerCase().endsWith(".gpg")) {
+      throw {
+        code: -32602,
+        message: `${ERR_VALIDATION}: create_artifact: name must not end in .gpg; results are encrypted to <name>.gpg automatically`,
+      };
+    }
+
+    const sizeBytes = Buffer.byteLength(content, "utf8");
+    const createArtifactConfig = config.create_artifact || {};
+    const maxSizeBytes = typeof createArtifactConfig["max-size-bytes"] === "number" ? createArtifactConfig["max-size-bytes"] : 10485760;
+    if (sizeBytes > maxSizeBytes) {
+      throw {
+        code: -32602,
+        message: `${ERR_VALIDATION}: create_artifact: content size ${sizeBytes} bytes exceeds max-size-bytes limit of ${maxSizeBytes} bytes`,
+      };
+    }
+
+    // Check the max budget before staging so a rejected call leaves no file behind.
+    enforcePerTypeMax("create_artifact");
+
+    const stagingDir = path.join(process.env.RUNNER_TEMP || "/tmp", "gh-aw", "safeoutputs", "create-artifacts");
+    try {
+      fs.mkdirSync(stagingDir, { recursive: true });
+    } catch (err) {
+      throw new Error(`Failed to create directory ${stagingDir}: ${String(err)}`, { cause: err });
+    }
+
+    const destPath = path.join(stagingDir, artifactName);
+    if (fs.existsSync(destPath)) {
+      throw {
+        code: -32602,
+        message: `${ERR_VALIDATION}: create_artifact: an artifact named "${artifactName}" was already created in this run`,
+      };
+    }
+    try {
+      fs.writeFileSync(destPath, content, { encoding: "utf8", mode: 0o600 });
+    } catch (err) {
+      throw new Error(`Failed to write ${destPath}: ${getErrorMessage(err)}`, { cause: err });
+    }
+    server.debug(`create_artifact: staged ${sizeBytes} bytes as ${artifactName}`);
+
+    appendSafeOutputCounted({ type: "create_artifact", name: artifactName, size_bytes: sizeBytes });
+
+    return {
+      content: [
+        {
+          type: "text",
+          text: JSON.stringify({
+            result: "success",
+            name: artifactName,
+          }),
+        },
+      ],
+    };
+  };
+
   /**
    * Handler for update_issue tool
    * Spec cross-reference: Safe Output Outcome Evaluation §update_issue.
@@ -2255,6 +2340,7 @@ function createHandlers(server, appendSafeOutput, config = {}) {
     defaultHandler,
     uploadAssetHandler,
     uploadArtifactHandler,
+    createArtifactHandler,
     createPullRequestHandler,
     pushToPullRequestBranchHandler,
     pushRepoMemoryHandler,
@@ -422,6 +422,49 @@ describe("safe_outputs_handlers", () => {
     });
   });
 
+  describe("createArtifactHandler", () => {
+    let testStagingDir;
+
+    beforeEach(() => {
+      const testId = Math.random().toString(36).substring(7);
+      testStagingDir = `/tmp/test-staging-${testId}`;
+      process.env.RUNNER_TEMP = testStagingDir;
+    });
+
+    afterEach(() => {
+      delete process.env.RUNNER_TEMP;
+      fs.rmSync(testStagingDir, { recursive: true, force: true });
+    });
+
+    it("should stage content and record only metadata", () => {
+      const result = handlers.createArtifactHandler({ name: "secret scan.json", content: "token=abc" });
+
+      const stagedPath = path.join(testStagingDir, "gh-aw", "safeoutputs", "create-artifacts", "secret-scan.json");
+      expect(fs.readFileSync(stagedPath, "utf8")).toBe("token=abc");
+      expect(mockAppendSafeOutput).toHaveBeenCalledWith({ type: "create_artifact", name: "secret-scan.json", size_bytes: 9 });
+      expect(JSON.parse(result.content[0].text)).toEqual({ result: "success", name: "secret-scan.json" });
+    });
+
+    it("should reject empty content, oversized content, and duplicate names", () => {
+      const sizeLimited = createHandlers(mockServer, mockAppendSafeOutput, { create_artifact: { "max-size-bytes": 4 } });
+
+      expect(() => handlers.createArtifactHandler({ name: "report", content: "" })).toThrow(expect.objectContaining({ message: expect.stringContaining("non-empty string") }));
+      expect(() => sizeLimited.createArtifactHandler({ name: "report", content: "12345" })).toThrow(expect.objectContaining({ message: expect.stringContaining("exceeds max-size-bytes") }));
+
+      handlers.createArtifactHandler({ name: "report", content: "one" });
+      expect(() => handlers.createArtifactHandler({ name: "report", content: "two" })).toThrow(expect.objectContaining({ message: expect.stringContaining("already created") }));
+      expect(mockAppendSafeOutput).toHaveBeenCalledOnce();
+    });
+
+    it("should reject names ending in .gpg so plaintext cannot pose as an encrypted result", () => {
+      expect(() => handlers.createArtifactHandler({ name: "x.gpg", content: "token=abc" })).toThrow(expect.objectContaining({ message: expect.stringContaining("must not end in .gpg") }));
+      expect(() => handlers.createArtifactHandler({ name: "x.GPG", content: "token=abc" })).toThrow(expect.objectContaining({ message: expect.stringContaining("must not end in .gpg") }));
+
+      expect(fs.existsSync(path.join(testStagingDir, "gh-aw", "safeoutputs", "create-artifacts", "x.gpg"))).toBe(false);
+      expect(mockAppendSafeOutput).not.toHaveBeenCalled();
+    });
+  });
+
   describe("uploadArtifactHandler", () => {
     let testStagingDir;
 
@@ -135,6 +135,7 @@ function attachHandlers(tools, handlers, logger) {
     push_repo_memory: handlers.pushRepoMemoryHandler,
     upload_asset: handlers.uploadAssetHandler,
     upload_artifact: handlers.uploadArtifactHandler,
+    create_artifact: handlers.createArtifactHandler,
     create_project: handlers.createProjectHandler,
     add_comment: handlers.addCommentHandler,
     create_pull_request_review_comment: handlers.createPullRequestReviewCommentHandler,
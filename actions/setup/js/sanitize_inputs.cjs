@@ -0,0 +1,117 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+/**
+ * Sanitize event payload fields declared in sanitized-inputs: frontmatter
+ *
+ * Each configured field is read from the event payload, optionally stripped of
+ * HTML, sanitized (mentions, template syntax, untrusted URLs), truncated, and
+ * checked against an optional allowlist pattern. The result is exposed as a step
+ * output so the prompt can reference ${{ steps.sanitized_inputs.outputs.<name> }}
+ * instead of the raw, attacker-controlled field.
+ *
+ * Environment Variables:
+ * - GH_AW_SANITIZED_INPUTS: JSON array of {name, path, max_length, strip_html, allow}
+ * - GH_AW_ALLOWED_DOMAINS: Domains whose URLs are kept (read by the sanitizer)
+ */
+
+const { sanitizeIncomingText, writeRedactedDomainsLog } = require("./sanitize_incoming_text.cjs");
+const { getErrorMessage } = require("./error_helpers.cjs");
+const { ERR_CONFIG, ERR_PARSE } = require("./error_codes.cjs");
+
+/**
+ * @typedef {Object} SanitizedInput
+ * @property {string} name - Step output name
+ * @property {string} path - Event payload path, e.g. "github.event.issue.body"
+ * @property {number} max_length - Maximum characters kept after sanitization
+ * @property {boolean} [strip_html] - Remove HTML tags and comments first
+ * @property {string} [allow] - Pattern the sanitized value must match
+ */
+
+/**
+ * Read a github.event.* path from the event payload.
+ * @param {any} payload - Event payload (context.payload)
+ * @param {string} path - Event payload path
+ * @returns {string} The field as text; "" when missing, JSON for objects
+ */
+function readEventField(payload, path) {
+  /** @type {any} */
+  let value = payload;
+  for (const key of path.split(".").slice(2)) {
+    if (value === null || typeof value !== "object" || !Object.prototype.hasOwnProperty.call(value, key)) {
+      return "";
+    }
+    value = value[key];
+  }
+  if (value === null || value === undefined) {
+    return "";
+  }
+  return typeof value === "object" ? JSON.stringify(value) : String(value);
+}
+
+/**
+ * Remove HTML comments and tags, keeping the text between tags.
+ * @param {string} text
+ * @returns {string}
+ */
+function stripHtml(text) {
+  return text.replace(/<!--[\s\S]*?(?:-->|$)/g, "").replace(/<\/?[a-zA-Z][^>]*>/g, "");
+}
+
+/**
+ * Sanitize one configured field.
+ * @param {any} payload - Event payload
+ * @param {SanitizedInput} input
+ * @returns {string}
+ */
+function sanitizeInput(payload, input) {
+  let value = readEventField(payload, input.path);
+  if (input.strip_html) {
+    value = stripHtml(value);
+  }
+  value = sanitizeIncomingText(value, input.max_length);
+  if (input.allow && value !== "" && !new RegExp(input.allow).test(value)) {
+    core.warning(`${input.name}: value of ${input.path} does not match the allow pattern and was dropped`);
+    return "";
+  }
+  return value;
+}
+
+async function main() {
+  /** @type {SanitizedInput[]} */
+  let inputs;
+  try {
+    inputs = JSON.parse(process.env.GH_AW_SANITIZED_INPUTS || "[]");
+  } catch (error) {
+    core.setFailed(`${ERR_PARSE}: Failed to parse GH_AW_SANITIZED_INPUTS: ${getErrorMessage(error)}`);
+    return;
+  }
+  if (!Array.isArray(inputs)) {
+    core.setFailed(`${ERR_CONFIG}: GH_AW_SANITIZED_INPUTS must be a JSON array`);
+    return;
+  }
+
+  for (const input of inputs) {
+    let value;
+    try {
+      value = sanitizeInput(context.payload, input);
+    } catch (error) {
+      core.setFailed(`${ERR_CONFIG}: Failed to sanitize ${input.name}: ${getErrorMessage(error)}`);
+      return;
+    }
+    core.info(`${input.name}: ${value.length} character(s) from ${input.path}`);
+    core.setOutput(input.name, value);
+  }
+
+  const logPath = writeRedactedDomainsLog();
+  if (logPath) {
+    core.info(`Redacted URL domains written to: ${logPath}`);
+  }
+}
+
+module.exports = {
+  main,
+  readEventField,
+  sanitizeInput,
+  stripHtml,
+};
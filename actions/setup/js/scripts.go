@@ -0,0 +1,12 @@
+// Package setupjs embeds the JavaScript handler scripts that the setup action copies
+// to the runner, so the compiler can write or inline them into compiled workflows.
+package setupjs
+
+import "embed"
+
+// Scripts contains the handler scripts (*.cjs), excluding *.test.cjs files.
+//
+// The patterns match every .cjs file whose name does not end in "test.cjs".
+//
+//go:embed *[^t].cjs *[^s]t.cjs *[^t]est.cjs
+var Scripts embed.FS
@@ -0,0 +1,35 @@
+//go:build !integration
+
+package setupjs
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScriptsEmbedsAllHandlerScripts(t *testing.T) {
+	onDisk, err := filepath.Glob("*.cjs")
+	require.NoError(t, err, "list scripts on disk")
+	var want []string
+	for _, name := range onDisk {
+		if !strings.HasSuffix(name, ".test.cjs") {
+			want = append(want, name)
+		}
+	}
+
+	embedded, err := fs.Glob(Scripts, "*")
+	require.NoError(t, err, "list embedded scripts")
+	assert.ElementsMatch(t, want, embedded, "every non-test .cjs script should be embedded")
+
+	content, err := Scripts.ReadFile("create_issue.cjs")
+	require.NoError(t, err, "read embedded script")
+	onDiskContent, err := os.ReadFile("create_issue.cjs")
+	require.NoError(t, err, "read script on disk")
+	assert.Equal(t, string(onDiskContent), string(content), "embedded content should match the file on disk")
+}
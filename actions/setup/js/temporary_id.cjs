@@ -724,6 +724,55 @@ function getCreatedTemporaryId(message) {
   return null;
 }
 
+/**
+ * Message fields that name the issue or pull request a safe output acts on.
+ */
+const TARGET_NUMBER_FIELDS = ["item_number", "issue_number", "pull_request_number"];
+
+/**
+ * Replace temporary IDs in the target number fields of a message with the numbers of the
+ * items they resolved to, so a handler can act on an item created earlier in the same run
+ * (e.g. add_reviewer on the pull request of a create_pull_request) without resolving
+ * temporary IDs itself.
+ *
+ * When the item was created in another repository and the message names no repository,
+ * the item's repository is set on the message. References that cannot be resolved, or
+ * whose item is in a different repository than the one the message names, are left
+ * unchanged and reported in `unresolved`.
+ *
+ * @param {any} message - The safe output message
+ * @param {Map<string, RepoIssuePair>} tempIdMap - Map of temporary ID to {repo, number}
+ * @param {string} currentRepo - Repository of the running workflow ("owner/repo")
+ * @returns {{message: any, unresolved: string[]}} The message with resolved targets, and the normalized IDs left unresolved
+ */
+function resolveTargetTemporaryIds(message, tempIdMap, currentRepo) {
+  /** @type {string[]} */
+  const unresolved = [];
+  if (!message || typeof message !== "object") {
+    return { message, unresolved };
+  }
+
+  let resolvedMessage = message;
+  for (const field of TARGET_NUMBER_FIELDS) {
+    const value = message[field];
+    if (value === undefined || value === null || !isTemporaryId(String(value).trim())) {
+      continue;
+    }
+    const tempId = normalizeTemporaryId(String(value).trim());
+    const target = tempIdMap.get(tempId);
+    const messageRepo = typeof resolvedMessage.repo === "string" ? resolvedMessage.repo.trim() : "";
+    if (!target || !target.number || (messageRepo && target.repo && messageRepo.toLowerCase() !== target.repo.toLowerCase())) {
+      unresolved.push(tempId);
+      continue;
+    }
+    resolvedMessage = { ...resolvedMessage, [field]: Number(target.number) };
+    if (!messageRepo && target.repo && currentRepo && target.repo.toLowerCase() !== currentRepo.toLowerCase()) {
+      resolvedMessage.repo = target.repo;
+    }
+  }
+  return { message: resolvedMessage, unresolved };
+}
+
 /**
  * Resolve a number value that may be a temporary ID using a plain resolved-IDs object.
  * This is a low-level helper for safe output handlers that receive resolvedTemporaryIds
@@ -799,4 +848,6 @@ module.exports = {
   replaceTemporaryProjectReferences,
   extractTemporaryIdReferences,
   getCreatedTemporaryId,
+  TARGET_NUMBER_FIELDS,
+  resolveTargetTemporaryIds,
 };
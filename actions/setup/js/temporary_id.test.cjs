@@ -1285,4 +1285,45 @@ describe("temporary_id.cjs", () => {
       expect(result.number).toBe(33);
     });
   });
+
+  describe("resolveTargetTemporaryIds", () => {
+    const tempIdMap = new Map([
+      ["aw_pr1", { repo: "testowner/testrepo", number: 12 }],
+      ["aw_other1", { repo: "otherowner/otherrepo", number: 34 }],
+    ]);
+
+    it("should replace resolved temporary IDs in target number fields", async () => {
+      const { resolveTargetTemporaryIds } = await import("./temporary_id.cjs");
+      const message = { type: "add_reviewer", pull_request_number: "#aw_pr1", reviewers: ["octocat"] };
+      const result = resolveTargetTemporaryIds(message, tempIdMap, "testowner/testrepo");
+      expect(result.unresolved).toEqual([]);
+      expect(result.message).toEqual({ type: "add_reviewer", pull_request_number: 12, reviewers: ["octocat"] });
+      expect(message.pull_request_number).toBe("#aw_pr1");
+    });
+
+    it("should set the repository of items created in another repository", async () => {
+      const { resolveTargetTemporaryIds } = await import("./temporary_id.cjs");
+      const result = resolveTargetTemporaryIds({ type: "assign_to_user", issue_number: "aw_other1" }, tempIdMap, "testowner/testrepo");
+      expect(result.message).toEqual({ type: "assign_to_user", issue_number: 34, repo: "otherowner/otherrepo" });
+    });
+
+    it("should report unresolved and mismatched references without changing them", async () => {
+      const { resolveTargetTemporaryIds } = await import("./temporary_id.cjs");
+      const pending = resolveTargetTemporaryIds({ type: "add_comment", item_number: "aw_later1" }, tempIdMap, "testowner/testrepo");
+      expect(pending.unresolved).toEqual(["aw_later1"]);
+      expect(pending.message.item_number).toBe("aw_later1");
+
+      const mismatched = resolveTargetTemporaryIds({ type: "close_issue", issue_number: "aw_other1", repo: "testowner/testrepo" }, tempIdMap, "testowner/testrepo");
+      expect(mismatched.unresolved).toEqual(["aw_other1"]);
+      expect(mismatched.message.issue_number).toBe("aw_other1");
+    });
+
+    it("should leave numeric targets unchanged", async () => {
+      const { resolveTargetTemporaryIds } = await import("./temporary_id.cjs");
+      const message = { type: "add_comment", item_number: 5 };
+      const result = resolveTargetTemporaryIds(message, tempIdMap, "testowner/testrepo");
+      expect(result.message).toBe(message);
+      expect(result.unresolved).toEqual([]);
+    });
+  });
 });
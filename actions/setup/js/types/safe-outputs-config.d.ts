@@ -177,6 +177,14 @@ interface AddReviewerConfig extends SafeOutputConfig {
   target?: string;
 }
 
+/**
+ * Configuration for requesting reviews from organization teams
+ */
+interface RequestTeamReviewConfig extends SafeOutputConfig {
+  allowed?: string[];
+  target?: string;
+}
+
 /**
  * Configuration for updating issues
  */
@@ -362,6 +370,7 @@ type SpecificSafeOutputConfig =
   | AutofixCodeScanningAlertConfig
   | AddLabelsConfig
   | AddReviewerConfig
+  | RequestTeamReviewConfig
   | UpdateIssueConfig
   | UpdatePullRequestConfig
   | MergePullRequestConfig
@@ -399,6 +408,7 @@ export {
   AutofixCodeScanningAlertConfig,
   AddLabelsConfig,
   AddReviewerConfig,
+  RequestTeamReviewConfig,
   UpdateIssueConfig,
   UpdatePullRequestConfig,
   MergePullRequestConfig,
@@ -257,6 +257,17 @@ interface AddReviewerItem extends BaseSafeOutputItem {
   pull_request_number?: number | string;
 }
 
+/**
+ * JSONL item for requesting a review from organization teams
+ */
+interface RequestTeamReviewItem extends BaseSafeOutputItem {
+  type: "request_team_review";
+  /** Team slugs or names to request a review from (optionally prefixed with "org/" or "@") */
+  teams: string[];
+  /** Pull request number (optional - uses triggering PR if not provided) */
+  pull_request_number?: number | string;
+}
+
 /**
  * JSONL item for updating an issue
  */
@@ -504,6 +515,7 @@ type SafeOutputItem =
   | AddLabelsItem
   | RemoveLabelsItem
   | AddReviewerItem
+  | RequestTeamReviewItem
   | UpdateIssueItem
   | UpdatePullRequestItem
   | PushToPrBranchItem
@@ -549,6 +561,7 @@ export {
   AddLabelsItem,
   RemoveLabelsItem,
   AddReviewerItem,
+  RequestTeamReviewItem,
   UpdateIssueItem,
   UpdatePullRequestItem,
   PushToPrBranchItem,
@@ -1516,4 +1516,16 @@ async function main(config = {}, githubClient = null) {
   };
 }
 
-module.exports = { updateProject, parseProjectInput, main, normalizeUpdateProjectOutput, summarizeProjectsV2, summarizeEmptyProjectsV2List, inferFieldDataType };
+module.exports = {
+  updateProject,
+  parseProjectInput,
+  parseProjectUrl,
+  resolveProjectV2,
+  fetchAllProjectFields,
+  findExistingItemByContentId,
+  main,
+  normalizeUpdateProjectOutput,
+  summarizeProjectsV2,
+  summarizeEmptyProjectsV2List,
+  inferFieldDataType,
+};
@@ -0,0 +1,391 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+const { getErrorMessage } = require("./error_helpers.cjs");
+const { sanitizeContent } = require("./sanitize_content.cjs");
+const { logStagedPreviewInfo } = require("./staged_preview.cjs");
+const { isStagedMode } = require("./safe_output_helpers.cjs");
+const { resolveIssueNumber } = require("./temporary_id.cjs");
+const { ERR_CONFIG, ERR_NOT_FOUND, ERR_PARSE, ERR_VALIDATION } = require("./error_codes.cjs");
+const { parseRepoSlug, getDefaultTargetRepo } = require("./repo_helpers.cjs");
+const { logGraphQLError } = require("./github_api_helpers.cjs");
+const { parseProjectUrl, resolveProjectV2, fetchAllProjectFields, findExistingItemByContentId } = require("./update_project.cjs");
+
+/**
+ * @typedef {import('./types/handler-factory').HandlerFactoryFunction} HandlerFactoryFunction
+ */
+
+/** @type {string} Safe output type handled by this module */
+const HANDLER_TYPE = "update_project_item";
+
+/** @type {import('./github_api_helpers.cjs').GraphQLErrorHints} */
+const PROJECT_GRAPHQL_HINTS = {
+  insufficientScopesHint:
+    "This looks like a token permission problem for Projects v2. The GraphQL fields used by update-project-item require a token with Projects access (classic PAT: scope 'project'; fine-grained PAT: Organization permission 'Projects' and access to the org). Fix: set safe-outputs.update-project-item.github-token to a secret PAT that can access the target org project.",
+  notFoundHint:
+    "GitHub returned NOT_FOUND for ProjectV2. This can mean either: (1) the project number is wrong for Projects v2, (2) the project is a classic Projects board (not Projects v2), or (3) the token does not have access to that org/user project.",
+  notFoundPredicate: msg => /projectV2\b/.test(msg),
+};
+
+/** Field data types whose values can be set through updateProjectV2ItemFieldValue */
+const UPDATABLE_FIELD_TYPES = new Set(["TEXT", "NUMBER", "DATE", "SINGLE_SELECT", "ITERATION"]);
+
+const DATE_PATTERN = /^\d{4}-\d{2}-\d{2}$/;
+
+/**
+ * Find the iteration matching an iteration reference.
+ * Accepts an iteration title (case-insensitive) or the relative references
+ * "@current" (the iteration containing today) and "@next" (the first iteration starting after today).
+ * @param {Array<{id: string, title: string, startDate: string, duration: number}>} iterations - Configured iterations
+ * @param {string} value - Iteration title or relative reference
+ * @param {Date} [now] - Reference date (defaults to the current date)
+ * @returns {{id: string, title: string} | undefined} Matching iteration
+ */
+function findIteration(iterations, value, now = new Date()) {
+  const reference = value.trim().toLowerCase();
+  const today = now.toISOString().split("T")[0];
+  const dayMs = 24 * 60 * 60 * 1000;
+  const sorted = [...iterations].sort((a, b) => a.startDate.localeCompare(b.startDate));
+
+  if (reference === "@current") {
+    return sorted.find(iter => {
+      const end = new Date(Date.parse(iter.startDate) + iter.duration * dayMs).toISOString().split("T")[0];
+      return iter.startDate <= today && today < end;
+    });
+  }
+  if (reference === "@next") {
+    return sorted.find(iter => iter.startDate > today);
+  }
+  return iterations.find(iter => iter.title.toLowerCase() === reference);
+}
+
+/**
+ * Validate requested field values against the project's field definitions.
+ * All fields are validated before any mutation so that a single invalid value
+ * leaves the item untouched.
+ * @param {Record<string, unknown>} fields - Requested field name/value pairs
+ * @param {Array<any>} projectFields - Field definitions returned by fetchAllProjectFields
+ * @param {string[]} allowedFields - Field names the workflow permits (empty means all)
+ * @param {Object} [sanitizeOptions] - Options passed to sanitizeContent for text values
+ * @returns {{ updates: Array<{fieldName: string, fieldId: string, value: Object | null}>, errors: string[] }}
+ */
+function resolveFieldUpdates(fields, projectFields, allowedFields, sanitizeOptions = {}) {
+  const updates = [];
+  const errors = [];
+  const allowed = new Set(allowedFields.map(name => name.toLowerCase()));
+
+  for (const [fieldName, fieldValue] of Object.entries(fields)) {
+    if (allowed.size > 0 && !allowed.has(fieldName.toLowerCase())) {
+      errors.push(`Field "${fieldName}" is not in the allowed-fields list. Allowed fields: ${allowedFields.join(", ")}`);
+      continue;
+    }
+
+    const field = projectFields.find(f => f && typeof f.name === "string" && f.name.toLowerCase() === fieldName.toLowerCase());
+    if (!field) {
+      const available = projectFields
+        .filter(f => f && UPDATABLE_FIELD_TYPES.has(f.dataType))
+        .map(f => f.name)
+        .join(", ");
+      errors.push(`Field "${fieldName}" does not exist on the project. Available fields: ${available || "(none)"}`);
+      continue;
+    }
+
+    if (!UPDATABLE_FIELD_TYPES.has(field.dataType)) {
+      errors.push(`Field "${field.name}" has type ${field.dataType}, which cannot be updated through update_project_item.`);
+      continue;
+    }
+
+    if (fieldValue === null) {
+      updates.push({ fieldName: field.name, fieldId: field.id, value: null });
+      continue;
+    }
+
+    switch (field.dataType) {
+      case "SINGLE_SELECT": {
+        const options = Array.isArray(field.options) ? field.options : [];
+        const option = options.find(o => o.name.toLowerCase() === String(fieldValue).trim().toLowerCase());
+        if (!option) {
+          errors.push(`Option "${fieldValue}" is not valid for field "${field.name}". Available options: ${options.map(o => o.name).join(", ") || "(none)"}`);
+          continue;
+        }
+        updates.push({ fieldName: field.name, fieldId: field.id, value: { singleSelectOptionId: option.id } });
+        break;
+      }
+      case "ITERATION": {
+        const iterations = Array.isArray(field.configuration?.iterations) ? field.configuration.iterations : [];
+        const iteration = findIteration(iterations, String(fieldValue));
+        if (!iteration) {
+          errors.push(`Iteration "${fieldValue}" is not valid for field "${field.name}". Available iterations: ${iterations.map(i => i.title).join(", ") || "(none)"}, @current, @next`);
+          continue;
+        }
+        updates.push({ fieldName: field.name, fieldId: field.id, value: { iterationId: iteration.id } });
+        break;
+      }
+      case "NUMBER": {
+        const numValue = typeof fieldValue === "number" ? fieldValue : typeof fieldValue === "string" && fieldValue.trim() !== "" ? Number(fieldValue) : NaN;
+        if (!Number.isFinite(numValue)) {
+          errors.push(`Value "${fieldValue}" is not a valid number for field "${field.name}".`);
+          continue;
+        }
+        updates.push({ fieldName: field.name, fieldId: field.id, value: { number: numValue } });
+        break;
+      }
+      case "DATE": {
+        const dateValue = String(fieldValue).trim();
+        if (!DATE_PATTERN.test(dateValue) || Number.isNaN(Date.parse(dateValue))) {
+          errors.push(`Value "${fieldValue}" is not a valid date for field "${field.name}". Use YYYY-MM-DD format.`);
+          continue;
+        }
+        updates.push({ fieldName: field.name, fieldId: field.id, value: { date: dateValue } });
+        break;
+      }
+      default: {
+        if (typeof fieldValue !== "string" && typeof fieldValue !== "number") {
+          errors.push(`Value for text field "${field.name}" must be a string.`);
+          continue;
+        }
+        updates.push({ fieldName: field.name, fieldId: field.id, value: { text: sanitizeContent(String(fieldValue), sanitizeOptions) } });
+      }
+    }
+  }
+
+  return { updates, errors };
+}
+
+/**
+ * Resolve the node ID of the issue or pull request referenced by the message.
+ * @param {Object} github - GitHub client (Octokit instance)
+ * @param {"issue" | "pull_request"} contentType - Content type
+ * @param {string} owner - Repository owner
+ * @param {string} repo - Repository name
+ * @param {number} number - Issue or pull request number
+ * @returns {Promise<string | null>} Content node ID, or null when not found
+ */
+async function resolveContentId(github, contentType, owner, repo, number) {
+  const query =
+    contentType === "issue"
+      ? `query($owner: String!, $repo: String!, $number: Int!) {
+          repository(owner: $owner, name: $repo) {
+            issue(number: $number) {
+              id
+            }
+          }
+        }`
+      : `query($owner: String!, $repo: String!, $number: Int!) {
+          repository(owner: $owner, name: $repo) {
+            pullRequest(number: $number) {
+              id
+            }
+          }
+        }`;
+  const result = await github.graphql(query, { owner, repo, number });
+  const content = contentType === "issue" ? result?.repository?.issue : result?.repository?.pullRequest;
+  return content?.id || null;
+}
+
+/**
+ * Main handler factory for update_project_item
+ * Returns a message handler function that processes individual update_project_item messages
+ * @type {HandlerFactoryFunction}
+ */
+async function main(config = {}, githubClient = null) {
+  const maxCount = config.max || 10;
+  const allowedFields = Array.isArray(config.allowed_fields) ? config.allowed_fields.map(String) : [];
+  const defaultProject = typeof config.project === "string" ? config.project.trim() : "";
+  const contentRepoSlug = getDefaultTargetRepo(config);
+
+  // Check if we're in staged mode
+  const isStaged = isStagedMode(config);
+
+  // Use the provided github client, or fall back to the global github object
+  // @ts-ignore - global.github is set by setupGlobals() from github-script context
+  const github = githubClient || global.github;
+
+  if (!github) {
+    throw new Error(`${ERR_CONFIG}: GitHub client is required but not provided. Either pass a github client to main() or ensure global.github is set by github-script action.`);
+  }
+
+  core.info(`Max count: ${maxCount}`);
+  if (allowedFields.length > 0) {
+    core.info(`Allowed fields: ${allowedFields.join(", ")}`);
+  }
+
+  // Track how many items we've processed for max limit
+  let processedCount = 0;
+
+  /**
+   * Message handler function that processes a single update_project_item message
+   * @param {Object} message - The update_project_item message to process
+   * @param {Object} resolvedTemporaryIds - Plain object version of temporaryIdMap for backward compatibility
+   * @param {Map<string, {repo?: string, number?: number, projectUrl?: string}>|null} temporaryIdMap - Unified map of temporary IDs
+   * @returns {Promise<Object>} Result with success/error status and updated field names
+   */
+  return async function handleUpdateProjectItem(message, resolvedTemporaryIds = {}, temporaryIdMap = null) {
+    // Check if we've hit the max limit
+    if (processedCount >= maxCount) {
+      core.warning(`Skipping update-project-item: max count of ${maxCount} reached`);
+      return {
+        success: false,
+        error: `Max count of ${maxCount} reached`,
+      };
+    }
+
+    processedCount++;
+
+    const projectUrl = typeof message.project === "string" && message.project.trim() ? message.project.trim() : defaultProject;
+    if (!projectUrl) {
+      core.error('Missing required "project" field. The agent must include the project URL in the output message: {"type": "update_project_item", "project": "https://github.com/orgs/myorg/projects/42", ...}');
+      return {
+        success: false,
+        error: "Missing required field: project",
+      };
+    }
+
+    const contentType = message.content_type;
+    if (contentType !== "issue" && contentType !== "pull_request") {
+      return {
+        success: false,
+        error: `${ERR_VALIDATION}: Invalid content_type "${contentType}". Must be "issue" or "pull_request".`,
+      };
+    }
+
+    const fields = message.fields;
+    if (!fields || typeof fields !== "object" || Array.isArray(fields) || Object.keys(fields).length === 0) {
+      return {
+        success: false,
+        error: `${ERR_VALIDATION}: Missing required field: fields (object of field name/value pairs)`,
+      };
+    }
+
+    const tempIdMap = temporaryIdMap instanceof Map ? temporaryIdMap : new Map(Object.entries(resolvedTemporaryIds || {}));
+    const resolvedNumber = resolveIssueNumber(message.content_number, tempIdMap);
+    if (resolvedNumber.errorMessage || !resolvedNumber.resolved) {
+      return {
+        success: false,
+        error: `${ERR_VALIDATION}: Invalid content_number: ${resolvedNumber.errorMessage || "Unknown error"}`,
+      };
+    }
+
+    // Temporary IDs resolve to the repository that created the issue; otherwise use target-repo
+    const repoSlug = resolvedNumber.wasTemporaryId && resolvedNumber.resolved.repo ? resolvedNumber.resolved.repo : contentRepoSlug;
+    const repoParts = parseRepoSlug(repoSlug);
+    if (!repoParts) {
+      return {
+        success: false,
+        error: `${ERR_CONFIG}: Invalid repository "${repoSlug}" for content resolution. Use "owner/repo" format.`,
+      };
+    }
+    const contentNumber = resolvedNumber.resolved.number;
+
+    try {
+      const projectInfo = parseProjectUrl(projectUrl);
+      const projectNumberInt = parseInt(projectInfo.projectNumber, 10);
+      if (!Number.isFinite(projectNumberInt)) {
+        throw new Error(`${ERR_PARSE}: Invalid project number parsed from URL: ${projectInfo.projectNumber}`);
+      }
+
+      const project = await resolveProjectV2(projectInfo, projectNumberInt, github);
+      const projectId = project.id;
+      core.info(`✓ Resolved project #${project.number} (${projectInfo.ownerLogin}) (ID: ${projectId})`);
+
+      const contentLabel = `${contentType === "issue" ? "Issue" : "Pull request"} ${repoSlug}#${contentNumber}`;
+      const contentId = await resolveContentId(github, contentType, repoParts.owner, repoParts.repo, contentNumber);
+      if (!contentId) {
+        throw new Error(`${ERR_NOT_FOUND}: ${contentLabel} not found.`);
+      }
+
+      const item = await findExistingItemByContentId(github, projectId, contentId);
+      if (!item) {
+        throw new Error(`${ERR_NOT_FOUND}: ${contentLabel} is not an item on project ${projectUrl}. Use update_project to add it first.`);
+      }
+
+      // Validate every field before making any change
+      const projectFields = await fetchAllProjectFields(github, projectId);
+      const { updates, errors } = resolveFieldUpdates(fields, projectFields, allowedFields);
+      if (errors.length > 0) {
+        for (const error of errors) {
+          core.error(error);
+        }
+        return {
+          success: false,
+          error: `${ERR_VALIDATION}: ${errors.join(" ")}`,
+        };
+      }
+
+      const fieldNames = updates.map(u => u.fieldName);
+
+      // If in staged mode, preview without executing
+      if (isStaged) {
+        logStagedPreviewInfo(`Would update ${fieldNames.join(", ")} on ${contentLabel} in project ${projectUrl}`);
+        return {
+          success: true,
+          staged: true,
+          previewInfo: {
+            projectUrl,
+            itemId: item.id,
+            fields: fieldNames,
+          },
+        };
+      }
+
+      for (const update of updates) {
+        if (update.value === null) {
+          await github.graphql(
+            `mutation($projectId: ID!, $itemId: ID!, $fieldId: ID!) {
+              clearProjectV2ItemFieldValue(input: {
+                projectId: $projectId,
+                itemId: $itemId,
+                fieldId: $fieldId
+              }) {
+                projectV2Item {
+                  id
+                }
+              }
+            }`,
+            { projectId, itemId: item.id, fieldId: update.fieldId }
+          );
+          core.info(`✓ Cleared field "${update.fieldName}"`);
+          continue;
+        }
+
+        await github.graphql(
+          `mutation($projectId: ID!, $itemId: ID!, $fieldId: ID!, $value: ProjectV2FieldValue!) {
+            updateProjectV2ItemFieldValue(input: {
+              projectId: $projectId,
+              itemId: $itemId,
+              fieldId: $fieldId,
+              value: $value
+            }) {
+              projectV2Item {
+                id
+              }
+            }
+          }`,
+          { projectId, itemId: item.id, fieldId: update.fieldId, value: update.value }
+        );
+        core.info(`✓ Updated field "${update.fieldName}"`);
+      }
+
+      core.setOutput("item-id", item.id);
+
+      return {
+        success: true,
+        item_id: item.id,
+        project_id: projectId,
+        fields: fieldNames,
+      };
+    } catch (err) {
+      // prettier-ignore
+      const error = /** @type {Error & { errors?: Array<{ type?: string, message: string, path?: unknown, locations?: unknown }>, request?: unknown, data?: unknown }} */ (err);
+      core.error(`Failed to update project item: ${getErrorMessage(error)}`);
+      logGraphQLError(error, "Updating project item", PROJECT_GRAPHQL_HINTS);
+
+      return {
+        success: false,
+        error: getErrorMessage(error),
+      };
+    }
+  };
+}
+
+module.exports = { main, HANDLER_TYPE, findIteration, resolveFieldUpdates };
@@ -0,0 +1,196 @@
+// @ts-check
+import { describe, it, expect, beforeAll, beforeEach, vi } from "vitest";
+
+let main;
+let findIteration;
+
+const mockCore = {
+  info: vi.fn(),
+  warning: vi.fn(),
+  error: vi.fn(),
+  setOutput: vi.fn(),
+};
+
+const mockGithub = {
+  graphql: vi.fn(),
+};
+
+const mockContext = {
+  repo: {
+    owner: "test-owner",
+    repo: "test-repo",
+  },
+};
+
+global.core = mockCore;
+global.github = mockGithub;
+global.context = mockContext;
+
+const projectUrl = "https://github.com/orgs/test-org/projects/42";
+
+const projectFields = [
+  { id: "F_status", name: "Status", dataType: "SINGLE_SELECT", options: [{ id: "O_todo", name: "Todo" }, { id: "O_done", name: "Done" }] },
+  { id: "F_estimate", name: "Estimate", dataType: "NUMBER" },
+  { id: "F_due", name: "Due Date", dataType: "DATE" },
+  { id: "F_notes", name: "Notes", dataType: "TEXT" },
+  { id: "F_repo", name: "Repository", dataType: "REPOSITORY" },
+  {
+    id: "F_sprint",
+    name: "Sprint",
+    dataType: "ITERATION",
+    configuration: {
+      iterations: [
+        { id: "I_2", title: "Sprint 2", startDate: "2025-01-15", duration: 14 },
+        { id: "I_1", title: "Sprint 1", startDate: "2025-01-01", duration: 14 },
+      ],
+    },
+  },
+];
+
+/**
+ * Queue the GraphQL responses for project, content, item and field lookups.
+ * @param {{ item?: any }} [options]
+ */
+function mockLookups(options = {}) {
+  const item = "item" in options ? options.item : { id: "PVTI_item1", content: { id: "I_content1" } };
+  mockGithub.graphql
+    .mockResolvedValueOnce({ organization: { projectV2: { id: "PVT_test123", number: 42, title: "Test Project", url: projectUrl } } })
+    .mockResolvedValueOnce({ repository: { issue: { id: "I_content1" } } })
+    .mockResolvedValueOnce({ node: { items: { nodes: item ? [item] : [], pageInfo: { hasNextPage: false, endCursor: null } } } })
+    .mockResolvedValueOnce({ node: { fields: { nodes: projectFields, pageInfo: { hasNextPage: false, endCursor: null } } } });
+}
+
+beforeAll(async () => {
+  const mod = await import("./update_project_item.cjs");
+  main = mod.main;
+  findIteration = mod.findIteration;
+});
+
+describe("update_project_item", () => {
+  beforeEach(() => {
+    vi.clearAllMocks();
+    mockGithub.graphql.mockReset();
+  });
+
+  it("should update validated field values on an existing item", async () => {
+    mockLookups();
+    mockGithub.graphql.mockResolvedValue({ updateProjectV2ItemFieldValue: { projectV2Item: { id: "PVTI_item1" } } });
+
+    const handler = await main({ max: 10 });
+    const result = await handler({
+      project: projectUrl,
+      content_type: "issue",
+      content_number: 7,
+      fields: { status: "done", Estimate: "3", "Due Date": "2025-02-01", Notes: "Blocked on review", Sprint: "Sprint 1" },
+    });
+
+    expect(result.success).toBe(true);
+    expect(result.fields).toEqual(["Status", "Estimate", "Due Date", "Notes", "Sprint"]);
+
+    const mutationValues = mockGithub.graphql.mock.calls.slice(4).map(call => call[1].value);
+    expect(mutationValues).toEqual([{ singleSelectOptionId: "O_done" }, { number: 3 }, { date: "2025-02-01" }, { text: "Blocked on review" }, { iterationId: "I_1" }]);
+    expect(mockCore.setOutput).toHaveBeenCalledWith("item-id", "PVTI_item1");
+  });
+
+  it("should clear a field when the value is null", async () => {
+    mockLookups();
+    mockGithub.graphql.mockResolvedValueOnce({ clearProjectV2ItemFieldValue: { projectV2Item: { id: "PVTI_item1" } } });
+
+    const handler = await main({});
+    const result = await handler({ project: projectUrl, content_type: "issue", content_number: 7, fields: { Estimate: null } });
+
+    expect(result.success).toBe(true);
+    expect(mockGithub.graphql.mock.calls[4][0]).toContain("clearProjectV2ItemFieldValue");
+  });
+
+  it("should reject unknown options without mutating the item", async () => {
+    mockLookups();
+
+    const handler = await main({});
+    const result = await handler({ project: projectUrl, content_type: "issue", content_number: 7, fields: { Status: "Done", Estimate: "lots" } });
+
+    expect(result.success).toBe(false);
+    expect(result.error).toContain("not a valid number");
+    expect(mockGithub.graphql).toHaveBeenCalledTimes(4);
+  });
+
+  it("should list available options for invalid single select values", async () => {
+    mockLookups();
+
+    const handler = await main({});
+    const result = await handler({ project: projectUrl, content_type: "issue", content_number: 7, fields: { Status: "Blocked" } });
+
+    expect(result.success).toBe(false);
+    expect(result.error).toContain("Available options: Todo, Done");
+  });
+
+  it("should reject fields that do not exist or cannot be updated", async () => {
+    mockLookups();
+
+    const handler = await main({});
+    const result = await handler({ project: projectUrl, content_type: "issue", content_number: 7, fields: { Priority: "High", Repository: "x" } });
+
+    expect(result.success).toBe(false);
+    expect(result.error).toContain('Field "Priority" does not exist');
+    expect(result.error).toContain("cannot be updated");
+  });
+
+  it("should enforce allowed fields", async () => {
+    mockLookups();
+
+    const handler = await main({ allowed_fields: ["Status"] });
+    const result = await handler({ project: projectUrl, content_type: "issue", content_number: 7, fields: { Notes: "hi" } });
+
+    expect(result.success).toBe(false);
+    expect(result.error).toContain("not in the allowed-fields list");
+  });
+
+  it("should fail when the item is not on the project", async () => {
+    mockLookups({ item: null });
+
+    const handler = await main({});
+    const result = await handler({ project: projectUrl, content_type: "issue", content_number: 7, fields: { Status: "Done" } });
+
+    expect(result.success).toBe(false);
+    expect(result.error).toContain("is not an item on project");
+  });
+
+  it("should preview without mutating in staged mode", async () => {
+    mockLookups();
+
+    const handler = await main({ staged: true });
+    const result = await handler({ project: projectUrl, content_type: "issue", content_number: 7, fields: { Status: "Done" } });
+
+    expect(result.success).toBe(true);
+    expect(result.staged).toBe(true);
+    expect(mockGithub.graphql).toHaveBeenCalledTimes(4);
+  });
+
+  it("should require a project and a valid content type", async () => {
+    const handler = await main({});
+
+    expect((await handler({ content_type: "issue", content_number: 7, fields: { Status: "Done" } })).error).toContain("project");
+    expect((await handler({ project: projectUrl, content_type: "draft_issue", content_number: 7, fields: { Status: "Done" } })).error).toContain("content_type");
+    expect(mockGithub.graphql).not.toHaveBeenCalled();
+  });
+
+  it("should respect max count", async () => {
+    const handler = await main({ max: 1 });
+
+    await handler({ content_type: "issue", content_number: 7, fields: { Status: "Done" } });
+    const result = await handler({ content_type: "issue", content_number: 7, fields: { Status: "Done" } });
+
+    expect(result.success).toBe(false);
+    expect(result.error).toContain("Max count of 1 reached");
+  });
+
+  it("should resolve relative iteration references", () => {
+    const iterations = projectFields[5].configuration.iterations;
+    const now = new Date("2025-01-10T12:00:00Z");
+
+    expect(findIteration(iterations, "@current", now)?.id).toBe("I_1");
+    expect(findIteration(iterations, "@next", now)?.id).toBe("I_2");
+    expect(findIteration(iterations, "sprint 2", now)?.id).toBe("I_2");
+    expect(findIteration(iterations, "@current", new Date("2025-03-01T00:00:00Z"))).toBeUndefined();
+  });
+});
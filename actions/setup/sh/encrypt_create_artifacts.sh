@@ -0,0 +1,52 @@
+#!/bin/bash
+set +o histexpand
+
+# Encrypt create-artifact results with the configured GPG public key.
+# Every file in the staging directory is encrypted to <file>.gpg in a separate
+# output directory, which is the only directory uploaded from the agent job.
+# The plaintext staging directory is always emptied, and on any failure the
+# output directory is emptied too, so plaintext is never uploaded.
+
+set -euo pipefail
+
+STAGING_DIR="${GH_AW_CREATE_ARTIFACTS_DIR:-${RUNNER_TEMP}/gh-aw/safeoutputs/create-artifacts}"
+OUTPUT_DIR="${GH_AW_CREATE_ARTIFACTS_ENCRYPTED_DIR:-${RUNNER_TEMP}/gh-aw/safeoutputs/create-artifacts-encrypted}"
+
+if [ ! -d "$STAGING_DIR" ] || [ -z "$(find "$STAGING_DIR" -type f -print -quit)" ]; then
+  echo "No create-artifact files to encrypt"
+  exit 0
+fi
+
+GNUPGHOME="$(mktemp -d)"
+export GNUPGHOME
+chmod 700 "$GNUPGHOME"
+
+cleanup() {
+  local status=$?
+  rm -rf "$GNUPGHOME"
+  find "$STAGING_DIR" -mindepth 1 -delete || true
+  if [ "$status" -ne 0 ]; then
+    echo "::error title=create-artifact::Encryption failed; removing staged create-artifact files"
+    find "$OUTPUT_DIR" -mindepth 1 -delete 2>/dev/null || true
+  fi
+  exit "$status"
+}
+trap cleanup EXIT
+
+if [ -z "${GH_AW_CREATE_ARTIFACT_PUBLIC_KEY:-}" ]; then
+  echo "::error title=create-artifact::safe-outputs.create-artifact.public-key resolved to an empty value"
+  exit 1
+fi
+
+KEY_FILE="$GNUPGHOME/recipient.asc"
+printf '%s\n' "$GH_AW_CREATE_ARTIFACT_PUBLIC_KEY" >"$KEY_FILE"
+
+mkdir -p "$OUTPUT_DIR"
+
+count=0
+while IFS= read -r -d '' file; do
+  gpg --batch --yes --quiet --trust-model always --recipient-file "$KEY_FILE" --encrypt --output "$OUTPUT_DIR/$(basename "$file").gpg" "$file"
+  count=$((count + 1))
+done < <(find "$STAGING_DIR" -type f -print0)
+
+echo "Encrypted $count create-artifact file(s)"
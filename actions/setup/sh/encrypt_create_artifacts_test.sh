@@ -0,0 +1,115 @@
+#!/usr/bin/env bash
+set +o histexpand
+
+# Test script for encrypt_create_artifacts.sh
+# Run: bash encrypt_create_artifacts_test.sh
+
+set -euo pipefail
+
+SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
+SCRIPT="${SCRIPT_DIR}/encrypt_create_artifacts.sh"
+
+TESTS_PASSED=0
+TESTS_FAILED=0
+
+WORK_DIR="$(mktemp -d)"
+cleanup() {
+  rm -rf "${WORK_DIR}"
+}
+trap cleanup EXIT
+
+assert() {
+  local name="$1"
+  local condition="$2"
+  if eval "${condition}" 2>/dev/null; then
+    echo "  ✓ ${name}"
+    TESTS_PASSED=$((TESTS_PASSED + 1))
+  else
+    echo "  ✗ ${name}"
+    TESTS_FAILED=$((TESTS_FAILED + 1))
+  fi
+}
+
+# Generate a throwaway recipient key pair in an isolated keyring.
+KEYRING="${WORK_DIR}/keyring"
+mkdir -m 700 "${KEYRING}"
+GNUPGHOME="${KEYRING}" gpg --batch --quiet --passphrase '' --quick-generate-key "gh-aw test <test@example.com>" default default never 2>/dev/null
+PUBLIC_KEY="$(GNUPGHOME="${KEYRING}" gpg --batch --armor --export test@example.com)"
+STAGING="${WORK_DIR}/create-artifacts"
+ENCRYPTED="${WORK_DIR}/create-artifacts-encrypted"
+export GH_AW_CREATE_ARTIFACTS_ENCRYPTED_DIR="${ENCRYPTED}"
+
+echo "Testing encrypt_create_artifacts.sh"
+echo ""
+
+# ── Test 1: Script syntax is valid ──────────────────────────────────────────
+echo "Test 1: Script syntax is valid"
+assert "script passes bash -n" "bash -n '${SCRIPT}'"
+echo ""
+
+# ── Test 2: No-op when there is nothing to encrypt ──────────────────────────
+echo "Test 2: No-op when the staging directory is missing"
+set +e
+OUTPUT="$(GH_AW_CREATE_ARTIFACTS_DIR="${STAGING}" bash "${SCRIPT}" 2>&1)"
+EXIT_CODE=$?
+set -e
+assert "exits 0 without staged files" "[ '${EXIT_CODE}' -eq 0 ]"
+assert "reports nothing to encrypt" "printf '%s' \"${OUTPUT}\" | grep -q 'No create-artifact files'"
+echo ""
+
+# ── Test 3: Encrypts files and removes plaintext ────────────────────────────
+echo "Test 3: Encrypts staged files and removes plaintext"
+mkdir -p "${STAGING}"
+printf 'secret finding\n' >"${STAGING}/report.json"
+GH_AW_CREATE_ARTIFACTS_DIR="${STAGING}" GH_AW_CREATE_ARTIFACT_PUBLIC_KEY="${PUBLIC_KEY}" bash "${SCRIPT}" >/dev/null 2>&1
+assert "removes plaintext file" "[ ! -e '${STAGING}/report.json' ]"
+assert "creates encrypted file in the output directory" "[ -s '${ENCRYPTED}/report.json.gpg' ]"
+assert "leaves nothing in the staging directory" "[ -z \"\$(ls -A '${STAGING}')\" ]"
+assert "encrypted file does not contain plaintext" "! grep -q 'secret finding' '${ENCRYPTED}/report.json.gpg'"
+assert "encrypted file decrypts with the private key" "[ \"\$(GNUPGHOME='${KEYRING}' gpg --batch --quiet --decrypt '${ENCRYPTED}/report.json.gpg')\" = 'secret finding' ]"
+echo ""
+
+# ── Test 4: Encrypts staged files that already end in .gpg ─────────────────
+echo "Test 4: Encrypts a staged x.gpg instead of passing it through"
+rm -rf "${STAGING}" "${ENCRYPTED}"
+mkdir -p "${STAGING}"
+printf 'secret finding\n' >"${STAGING}/x.gpg"
+GH_AW_CREATE_ARTIFACTS_DIR="${STAGING}" GH_AW_CREATE_ARTIFACT_PUBLIC_KEY="${PUBLIC_KEY}" bash "${SCRIPT}" >/dev/null 2>&1
+assert "removes plaintext x.gpg from staging" "[ ! -e '${STAGING}/x.gpg' ]"
+assert "does not copy plaintext x.gpg to the output directory" "[ ! -e '${ENCRYPTED}/x.gpg' ]"
+assert "encrypts x.gpg to x.gpg.gpg" "[ \"\$(GNUPGHOME='${KEYRING}' gpg --batch --quiet --decrypt '${ENCRYPTED}/x.gpg.gpg')\" = 'secret finding' ]"
+echo ""
+
+# ── Test 5: Removes staged files when encryption fails ──────────────────────
+echo "Test 5: Removes staged files when the public key is invalid"
+rm -rf "${STAGING}"
+mkdir -p "${STAGING}"
+printf 'secret finding\n' >"${STAGING}/report.json"
+set +e
+GH_AW_CREATE_ARTIFACTS_DIR="${STAGING}" GH_AW_CREATE_ARTIFACT_PUBLIC_KEY="not a key" bash "${SCRIPT}" >/dev/null 2>&1
+EXIT_CODE=$?
+set -e
+assert "exits non-zero" "[ '${EXIT_CODE}' -ne 0 ]"
+assert "removes plaintext file" "[ ! -e '${STAGING}/report.json' ]"
+assert "leaves no output to upload" "[ -z \"\$(ls -A '${ENCRYPTED}' 2>/dev/null)\" ]"
+echo ""
+
+# ── Test 6: Fails when the public key is empty ──────────────────────────────
+echo "Test 6: Fails when the public key is empty"
+printf 'secret finding\n' >"${STAGING}/report.json"
+set +e
+GH_AW_CREATE_ARTIFACTS_DIR="${STAGING}" GH_AW_CREATE_ARTIFACT_PUBLIC_KEY="" bash "${SCRIPT}" >/dev/null 2>&1
+EXIT_CODE=$?
+set -e
+assert "exits non-zero" "[ '${EXIT_CODE}' -ne 0 ]"
+assert "removes plaintext file" "[ ! -e '${STAGING}/report.json' ]"
+echo ""
+
+echo "Tests passed: ${TESTS_PASSED}"
+echo "Tests failed: ${TESTS_FAILED}"
+
+if [ "${TESTS_FAILED}" -gt 0 ]; then
+  exit 1
+fi
+
+echo "✓ All tests passed!"
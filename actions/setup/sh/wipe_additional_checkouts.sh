@@ -0,0 +1,60 @@
+#!/usr/bin/env bash
+set +o histexpand
+
+#
+# wipe_additional_checkouts.sh - Remove token-authenticated additional checkouts
+#                                after the agent has finished
+#
+# Additional checkouts (checkout: entries with a path and their own github-token or
+# GitHub App) clone repositories that are unrelated to the safe-output jobs. Once the
+# agent artifact has been uploaded these working trees are no longer needed, so this
+# script deletes them together with any credential files actions/checkout left in
+# RUNNER_TEMP. This keeps the cloned content and its credentials from outliving the
+# agent step on runners that are reused by subsequent jobs.
+#
+# Environment variables:
+#   GH_AW_ADDITIONAL_CHECKOUT_PATHS - newline-separated checkout paths, relative to
+#                                     GITHUB_WORKSPACE
+#
+# Paths that resolve to the workspace root or outside of it are skipped.
+#
+# Exit codes:
+#   0 - Success (checkouts removed or nothing to remove)
+
+set -euo pipefail
+
+WORKSPACE="$(realpath "${GITHUB_WORKSPACE:-$(pwd)}")"
+REMOVED=0
+
+while IFS= read -r checkout_path; do
+  checkout_path="${checkout_path#./}"
+  if [[ -z "${checkout_path}" ]]; then
+    continue
+  fi
+  target="$(realpath -m "${WORKSPACE}/${checkout_path}")"
+  if [[ "${target}" == "${WORKSPACE}" || "${target}" != "${WORKSPACE}/"* ]]; then
+    echo "Skipping checkout path outside the workspace: ${checkout_path@Q}"
+    continue
+  fi
+  if [[ -e "${target}" ]]; then
+    rm -rf "${target}"
+    echo "Removed additional checkout: ${checkout_path@Q}"
+    REMOVED=$((REMOVED + 1))
+  fi
+done <<< "${GH_AW_ADDITIONAL_CHECKOUT_PATHS:-}"
+
+# actions/checkout stores persisted credentials in RUNNER_TEMP/git-credentials-*.config
+# and references them from the repository config via includeIf.
+if [[ -n "${RUNNER_TEMP:-}" ]]; then
+  while IFS= read -r cred_file; do
+    rm -f "${cred_file}"
+    echo "Removed checkout credential file: ${cred_file@Q}"
+    REMOVED=$((REMOVED + 1))
+  done < <(find "${RUNNER_TEMP}" -maxdepth 1 -type f -name "git-credentials-*.config" 2>/dev/null) || true
+fi
+
+if [[ "${REMOVED}" -eq 0 ]]; then
+  echo "No additional checkouts found to remove"
+fi
+
+exit 0
@@ -25,6 +25,7 @@ func TestCommandGroupAssignments(t *testing.T) {
 		{name: "update command in setup group", commandName: "update", expectedGroup: "setup", shouldHaveGroup: true},
 		{name: "deploy command in setup group", commandName: "deploy", expectedGroup: "setup", shouldHaveGroup: true},
 		{name: "upgrade command in setup group", commandName: "upgrade", expectedGroup: "setup", shouldHaveGroup: true},
+		{name: "migrate command in setup group", commandName: "migrate", expectedGroup: "setup", shouldHaveGroup: true},
 		{name: "secrets command in setup group", commandName: "secrets", expectedGroup: "setup", shouldHaveGroup: true},
 		{name: "doctor command in setup group", commandName: "doctor", expectedGroup: "setup", shouldHaveGroup: true},
 
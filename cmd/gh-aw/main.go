@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"runtime"
 	"sort"
 	"strings"
 	"syscall"
@@ -13,6 +14,7 @@ import (
 	"github.com/github/gh-aw/pkg/cli"
 	"github.com/github/gh-aw/pkg/console"
 	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/gitutil"
 	"github.com/github/gh-aw/pkg/parser"
 	"github.com/github/gh-aw/pkg/workflow"
 	"github.com/spf13/cobra"
@@ -173,11 +175,19 @@ The workflow-id is the basename of the Markdown file without the .md extension.
 You can provide a substring to match multiple workflows, or a specific workflow-id.
 
 By default, this command also removes orphaned include files that are no longer referenced
-by any workflow. Use --no-remove-orphans to skip this cleanup.`,
+by any workflow. Use --no-remove-orphans to skip this cleanup.
+
+Entries in .github/aw/actions-lock.json that were only used by the removed workflows are
+pruned. The command warns about remaining workflows that import the removed files.
+
+Use --disable to disable the workflows on GitHub before removing them, so scheduled runs
+stop before the removal is pushed. Use --delete-caches to delete their GitHub Actions
+caches (cache-memory and daily usage caches).`,
 	Example: `  ` + string(constants.CLIExtensionPrefix) + ` remove my-workflow                    # Remove specific workflow
   ` + string(constants.CLIExtensionPrefix) + ` remove test-                          # Remove all workflows containing 'test-' in name
   ` + string(constants.CLIExtensionPrefix) + ` remove old- --no-remove-orphans       # Remove workflows but keep orphaned includes
-  ` + string(constants.CLIExtensionPrefix) + ` remove my-workflow --dir .github/workflows/shared  # Remove from custom directory`,
+  ` + string(constants.CLIExtensionPrefix) + ` remove my-workflow --dir .github/workflows/shared  # Remove from custom directory
+  ` + string(constants.CLIExtensionPrefix) + ` remove my-workflow --disable --delete-caches  # Also disable on GitHub and delete its caches`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		var pattern string
 		if len(args) > 0 {
@@ -187,7 +197,15 @@ by any workflow. Use --no-remove-orphans to skip this cleanup.`,
 		noRemoveOrphans, _ := cmd.Flags().GetBool("no-remove-orphans")
 		keepOrphans = keepOrphans || noRemoveOrphans
 		workflowDir, _ := cmd.Flags().GetString("dir")
-		return cli.RemoveWorkflows(pattern, keepOrphans, workflowDir)
+		disable, _ := cmd.Flags().GetBool("disable")
+		deleteCaches, _ := cmd.Flags().GetBool("delete-caches")
+		return cli.RemoveWorkflows(cmd.Context(), cli.RemoveOptions{
+			Pattern:      pattern,
+			KeepOrphans:  keepOrphans,
+			WorkflowDir:  workflowDir,
+			Disable:      disable,
+			DeleteCaches: deleteCaches,
+		})
 	},
 }
 
@@ -250,6 +268,10 @@ Three flags govern this. --gh-aw-ref is mutually exclusive with the other two;
 --action-tag and --action-mode may be combined (e.g. --action-mode action --action-tag v1.2.3):
 
 Unlike ` + "`gh aw upgrade`" + `, ` + "`gh aw compile`" + ` only applies codemods when you opt in with ` + "`--fix`" + `.
+Use ` + "`--migrate`" + ` to rewrite only renamed and removed frontmatter fields (e.g. timeout_minutes,
+engine.steps) to their replacements before compiling.
+Use ` + "`--check`" + ` in CI to fail when a lock file is missing or differs from what the
+current compiler would generate. No files are written in check mode.
 
   --action-mode <mode>
     Explicit mode selection. Values:
@@ -281,6 +303,11 @@ Unlike ` + "`gh aw upgrade`" + `, ` + "`gh aw compile`" + ` only applies codemod
   ` + string(constants.CLIExtensionPrefix) + ` compile --dir custom/workflows  # Compile from custom directory
   ` + string(constants.CLIExtensionPrefix) + ` compile ci-doctor --watch     # Watch and auto-compile
   ` + string(constants.CLIExtensionPrefix) + ` compile --trial --logical-repo owner/repo  # Compile for trial mode
+  ` + string(constants.CLIExtensionPrefix) + ` compile --migrate           # Rewrite renamed and removed fields, then compile
+  ` + string(constants.CLIExtensionPrefix) + ` compile --check             # Fail if any lock file is stale (for CI)
+  ` + string(constants.CLIExtensionPrefix) + ` compile --offline           # Compile from actions-lock.json and cached imports only
+  ` + string(constants.CLIExtensionPrefix) + ` compile --merge-duplicate-keys  # Merge repeated tools:/imports: frontmatter keys
+  ` + string(constants.CLIExtensionPrefix) + ` compile --shared-actions    # Reference handler steps from .github/actions/gh-aw
   ` + string(constants.CLIExtensionPrefix) + ` compile --dependabot        # Generate Dependabot manifests
   ` + string(constants.CLIExtensionPrefix) + ` compile --dependabot --force  # Force overwrite existing dependabot.yml
   ` + string(constants.CLIExtensionPrefix) + ` compile --gh-aw-ref main       # Pin workflows to the SHA of github/gh-aw main at compile time
@@ -290,7 +317,14 @@ Unlike ` + "`gh aw upgrade`" + `, ` + "`gh aw compile`" + ` only applies codemod
 		actionMode, _ := cmd.Flags().GetString("action-mode")
 		actionTag, _ := cmd.Flags().GetString("action-tag")
 		actionsRepo, _ := cmd.Flags().GetString("actions-repo")
+		handlerScripts, _ := cmd.Flags().GetString("handler-scripts")
+		sharedActions, _ := cmd.Flags().GetBool("shared-actions")
 		ghAwRef, _ := cmd.Flags().GetString("gh-aw-ref")
+		offline, _ := cmd.Flags().GetBool("offline")
+		mergeDuplicateKeys, _ := cmd.Flags().GetBool("merge-duplicate-keys")
+		if offline && ghAwRef != "" && !gitutil.IsValidFullSHA(ghAwRef) {
+			return fmt.Errorf("--gh-aw-ref %q must be a full commit SHA with --offline (branch and tag names need the GitHub API to resolve)", ghAwRef)
+		}
 		if ghAwRef != "" {
 			// --gh-aw-ref is a convenience alias: emit refs like
 			// `github/gh-aw/actions/setup@<sha>` so external e2e harnesses can
@@ -309,8 +343,10 @@ Unlike ` + "`gh aw upgrade`" + `, ` + "`gh aw compile`" + ` only applies codemod
 		dir, _ := cmd.Flags().GetString("dir")
 		workflowsDir, _ := cmd.Flags().GetString("workflows-dir")
 		noEmit, _ := cmd.Flags().GetBool("no-emit")
+		check, _ := cmd.Flags().GetBool("check")
 		purge, _ := cmd.Flags().GetBool("purge")
 		strict, _ := cmd.Flags().GetBool("strict")
+		strictExplicit, _ := cmd.Flags().GetBool("strict-explicit")
 		trial, _ := cmd.Flags().GetBool("trial")
 		logicalRepo, _ := cmd.Flags().GetString("logical-repo")
 		dependabot, _ := cmd.Flags().GetBool("dependabot")
@@ -327,10 +363,17 @@ Unlike ` + "`gh aw upgrade`" + `, ` + "`gh aw compile`" + ` only applies codemod
 		grant, _ := cmd.Flags().GetBool("grant")
 		yamllint, _ := cmd.Flags().GetBool("yamllint")
 		jsonOutput, _ := cmd.Flags().GetBool("json")
+		porcelain, _ := cmd.Flags().GetBool("porcelain")
 		showAllErrors, _ := cmd.Flags().GetBool("show-all")
 		fix, _ := cmd.Flags().GetBool("fix")
+		migrate, _ := cmd.Flags().GetBool("migrate")
 		stats, _ := cmd.Flags().GetBool("stats")
+		explain, _ := cmd.Flags().GetBool("explain")
 		failFast, _ := cmd.Flags().GetBool("fail-fast")
+		jobs, _ := cmd.Flags().GetInt("jobs")
+		if jobs == 0 {
+			jobs = runtime.NumCPU()
+		}
 		noCheckUpdate, _ := cmd.Flags().GetBool("no-check-update")
 		scheduleSeed, _ := cmd.Flags().GetString("schedule-seed")
 		staged, _ := cmd.Flags().GetBool("staged")
@@ -345,7 +388,7 @@ Unlike ` + "`gh aw upgrade`" + `, ` + "`gh aw compile`" + ` only applies codemod
 			return err
 		}
 
-		finishCompileUpdateCheck := cli.StartCompileUpdateCheck(cmd.Context(), noCheckUpdate, verbose)
+		finishCompileUpdateCheck := cli.StartCompileUpdateCheck(cmd.Context(), noCheckUpdate || offline, verbose)
 		defer finishCompileUpdateCheck()
 
 		// If --fix is specified, run fix --write first
@@ -361,6 +404,14 @@ Unlike ` + "`gh aw upgrade`" + `, ` + "`gh aw compile`" + ` only applies codemod
 			}
 		}
 
+		// If --migrate is specified, rewrite renamed and removed fields first.
+		// --fix already runs every migration codemod.
+		if migrate && !fix {
+			if err := cli.RunMigrate(args, dir, verbose); err != nil {
+				return err
+			}
+		}
+
 		// Handle --workflows-dir deprecation (mutual exclusion is enforced by Cobra)
 		workflowDir := dir
 		if workflowsDir != "" {
@@ -373,15 +424,19 @@ Unlike ` + "`gh aw upgrade`" + `, ` + "`gh aw compile`" + ` only applies codemod
 			ActionMode:             actionMode,
 			ActionTag:              actionTag,
 			ActionsRepo:            actionsRepo,
+			HandlerScripts:         handlerScripts,
+			SharedActions:          sharedActions,
 			Validate:               validate,
 			Watch:                  watch,
 			WorkflowDir:            workflowDir,
 			SkipInstructions:       false, // Deprecated field, kept for backward compatibility
 			NoEmit:                 noEmit,
+			Check:                  check,
 			Purge:                  purge,
 			TrialMode:              trial,
 			TrialLogicalRepoSlug:   logicalRepo,
 			Strict:                 strict,
+			StrictExplicit:         strictExplicit,
 			Dependabot:             dependabot,
 			ForceOverwrite:         forceOverwrite,
 			RefreshStopTime:        refreshStopTime,
@@ -395,10 +450,13 @@ Unlike ` + "`gh aw upgrade`" + `, ` + "`gh aw compile`" + ` only applies codemod
 			Grype:                  grype,
 			Grant:                  grant,
 			Yamllint:               yamllint,
-			JSONOutput:             jsonOutput,
+			JSONOutput:             jsonOutput || porcelain,
+			Porcelain:              porcelain,
 			ShowAllErrors:          showAllErrors,
 			Stats:                  stats,
+			Explain:                explain,
 			FailFast:               failFast,
+			Jobs:                   jobs,
 			ScheduleSeed:           scheduleSeed,
 			Staged:                 staged,
 			Approve:                approve,
@@ -407,6 +465,8 @@ Unlike ` + "`gh aw upgrade`" + `, ` + "`gh aw compile`" + ` only applies codemod
 			PriorManifestFile:      priorManifestFile,
 			GHESCompat:             ghes,
 			UseSamples:             useSamples,
+			Offline:                offline,
+			MergeDuplicateKeys:     mergeDuplicateKeys,
 		}
 		if _, err := cli.CompileWorkflows(cmd.Context(), config); err != nil {
 			// Return error as-is without additional formatting
@@ -731,15 +791,19 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	compileCmd.Flags().String("action-mode", "", "How gh-aw action scripts are referenced in compiled workflows: 'dev' uses local paths (for developing gh-aw itself), 'release' emits SHA-pinned remote refs from github/gh-aw, 'action' uses the github/gh-aw-actions repository. Auto-detected from the binary build type if not specified")
 	compileCmd.Flags().String("action-tag", "", "Pin compiled workflows to a specific version of gh-aw actions. Accepts a full commit SHA or a version tag (e.g. v1, v1.2.3). Sets --action-mode to 'release' unless --action-mode action is also specified. Cannot be combined with --gh-aw-ref; use --gh-aw-ref when you want to resolve a branch or tag name to its current SHA")
 	compileCmd.Flags().String("actions-repo", "", "Override the external actions repository used in action mode (default: github/gh-aw-actions)")
+	compileCmd.Flags().String("handler-scripts", "", "How compiled steps load JavaScript handler scripts: 'action' loads them from the setup action (default), 'files' writes the scripts each workflow uses to .github/aw/scripts and installs them from the repository at run time, 'inline' embeds minified entry scripts in the compiled steps")
+	compileCmd.Flags().Bool("shared-actions", false, "Move actions/github-script steps into shared composite actions under .github/actions/gh-aw that every lock file references, shrinking the compiled workflows")
 	compileCmd.Flags().String("gh-aw-ref", "", "Pin compiled workflows to a specific branch, tag, or commit SHA of github/gh-aw (e.g. main, my-feature, abc123). Branch and tag names are resolved to their full commit SHA at compile time so the baked-in ref is immutable. Equivalent to --action-mode release --action-tag <resolved-sha>. Cannot be combined with --action-tag or --action-mode. Use this to E2E-test workflows against a specific gh-aw revision")
 	compileCmd.Flags().Bool("validate", false, "Enable GitHub Actions workflow schema validation, container image validation, and action SHA validation")
-	compileCmd.Flags().BoolP("watch", "w", false, "Watch for changes to workflow files and recompile automatically")
+	compileCmd.Flags().BoolP("watch", "w", false, "Watch workflow files and their imports, recompile on change, and show lock file diffs")
 	compileCmd.Flags().StringP("dir", "d", "", "Workflow directory (default: $GH_AW_WORKFLOWS_DIR or .github/workflows)")
 	compileCmd.Flags().String("workflows-dir", "", "Deprecated: use --dir instead")
 	_ = compileCmd.Flags().MarkDeprecated("workflows-dir", "use --dir instead")
 	compileCmd.Flags().Bool("no-emit", false, "Validate workflow without generating lock files")
+	compileCmd.Flags().Bool("check", false, "Exit with an error if any lock file is missing or out of date, without writing lock files (for CI)")
 	compileCmd.Flags().Bool("purge", false, "Delete .lock.yml files that were not regenerated during compilation (only when no specific files are provided)")
 	compileCmd.Flags().Bool("strict", false, "Override frontmatter to enforce strict mode validation for all workflows (enforces action pinning, network config, safe-outputs, disallows write permissions and deprecated fields). Note: Workflows default to strict mode unless frontmatter sets strict: false")
+	compileCmd.Flags().Bool("strict-explicit", false, "Refuse implicit defaults for security-relevant settings: web-fetch/web-search without network config, destructive safe outputs without max, third-party actions not pinned to a commit SHA, and engines without engine.version")
 	compileCmd.Flags().Bool("trial", false, "Enable trial mode compilation (modifies workflows for trial execution)")
 	compileCmd.Flags().StringP("logical-repo", "l", "", "Repository to simulate workflow execution against (for trial mode)")
 	compileCmd.Flags().Bool("use-samples", false, "Hidden: replace the agentic 'Execute coding agent' step with a deterministic driver that replays the workflow's safe-outputs `samples` frontmatter entries through the safe-outputs MCP server. Used to make end-to-end tests deterministic.")
@@ -758,15 +822,21 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	compileCmd.Flags().Bool("grant", false, "Run grant license scanner on container images referenced in compiled .lock.yml files (uses Docker image "+cli.GrantImage+")")
 	compileCmd.Flags().Bool("yamllint", false, "Run yamllint YAML linter on generated .lock.yml files (uses Docker image "+cli.YamllintImage+")")
 	compileCmd.Flags().Bool("fix", false, "Apply automatic codemod fixes to workflows before compiling")
+	compileCmd.Flags().Bool("migrate", false, "Rewrite renamed and removed frontmatter fields to their replacements before compiling")
 	compileCmd.Flags().BoolP("json", "j", false, "Output results in JSON format")
+	compileCmd.Flags().Bool("porcelain", false, "Output stable tab-separated records for scripts (see exit codes in the CLI reference)")
 	compileCmd.Flags().Bool("show-all", false, "Display all compilation errors instead of only the highest-priority subset (default: top 5)")
 	compileCmd.Flags().Bool("stats", false, "Display statistics table sorted by workflow file size (shows jobs, steps, scripts, and shells)")
+	compileCmd.Flags().Bool("explain", false, "Print each workflow's permissions, network buckets, engine/model, safe-output limits, and estimated per-run cost range")
 	compileCmd.Flags().Bool("fail-fast", false, "Stop at the first validation error instead of collecting all errors")
+	compileCmd.Flags().Int("jobs", 1, "Number of workflows to compile in parallel (0 uses one worker per CPU)")
 	compileCmd.Flags().Bool("no-check-update", false, "Skip checking for gh-aw updates")
 	compileCmd.Flags().String("schedule-seed", "", "Override the repository slug (owner/repo) used as seed for fuzzy schedule scattering (e.g., \"github/gh-aw\"). Bypasses git remote detection entirely. Use this when your git remote is not named \"origin\" and you have multiple remotes configured")
 	compileCmd.Flags().Bool("staged", false, "Force all safe-outputs into staged mode")
 	compileCmd.Flags().Bool("approve", false, "Approve all safe update changes. When strict mode is active (the default), the compiler emits warnings for new restricted secrets or unapproved action additions/removals not present in the existing gh-aw-manifest. Use this flag to approve and skip safe update enforcement")
 	compileCmd.Flags().Bool("validate-images", false, "Require Docker to be available for container image validation. Without this flag, container image validation is silently skipped when Docker is not installed or the daemon is not running")
+	compileCmd.Flags().Bool("offline", false, "Compile without network access: resolve action pins only from .github/aw/actions-lock.json and embedded pins, and remote imports only from .github/aw/imports. Fails with a clear error on anything that would need the network")
+	compileCmd.Flags().Bool("merge-duplicate-keys", false, "Merge the list and mapping values of top-level frontmatter keys defined more than once (such as tools or imports) instead of failing, with a warning for each merged key")
 	compileCmd.Flags().Bool("no-models-dev-lookup", false, "Disable compile-time models.dev pricing lookup for models missing from the embedded catalog")
 	compileCmd.Flags().String("prior-manifest-file", "", "Path to a JSON file containing pre-cached gh-aw-manifests (map[lockFile]*GHAWManifest); used by the MCP server to supply a tamper-proof manifest baseline captured at startup")
 	compileCmd.Flags().Bool("ghes", false, "Enable GitHub Enterprise Server (GHES) compatibility mode. Artifact actions continue using latest non-v3 pins (v3 is deprecated). Overrides the aw.json ghes field")
@@ -779,6 +849,10 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	// combining it with either of those flags leads to one silently overwriting the other.
 	compileCmd.MarkFlagsMutuallyExclusive("gh-aw-ref", "action-tag")
 	compileCmd.MarkFlagsMutuallyExclusive("gh-aw-ref", "action-mode")
+	// --check must not modify the tree, so it cannot rewrite workflow sources first.
+	compileCmd.MarkFlagsMutuallyExclusive("check", "fix")
+	compileCmd.MarkFlagsMutuallyExclusive("check", "migrate")
+	compileCmd.MarkFlagsMutuallyExclusive("json", "porcelain")
 
 	// Register completions for compile command
 	compileCmd.ValidArgsFunction = cli.CompleteWorkflowNames
@@ -792,6 +866,8 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	removeCmd.Flags().Bool("keep-orphans", false, "Skip removal of orphaned include files that are no longer referenced by any workflow")
 	_ = removeCmd.Flags().MarkDeprecated("keep-orphans", "use --no-remove-orphans instead")
 	removeCmd.Flags().StringP("dir", "d", "", "Workflow directory (default: $GH_AW_WORKFLOWS_DIR or .github/workflows)")
+	removeCmd.Flags().Bool("disable", false, "Disable the workflows on GitHub before removing their files")
+	removeCmd.Flags().Bool("delete-caches", false, "Delete the GitHub Actions caches created by the removed workflows")
 	// Register completions for remove command
 	removeCmd.ValidArgsFunction = cli.CompleteWorkflowNames
 	cli.RegisterDirFlagCompletion(removeCmd, "dir")
@@ -838,14 +914,20 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	secretsCmd := cli.NewSecretsCommand()
 	fixCmd := cli.NewFixCommand()
 	upgradeCmd := cli.NewUpgradeCommand(validateEngine)
+	migrateCmd := cli.NewMigrateCommand()
 	completionCmd := cli.NewCompletionCommand()
 	hashCmd := cli.NewHashCommand()
 	projectCmd := cli.NewProjectCommand()
 	doctorCmd := cli.NewDoctorCommand()
 	checksCmd := cli.NewChecksCommand()
+	prStatusCmd := cli.NewPRStatusCommand()
 	validateCmd := cli.NewValidateCommand(validateEngine)
 	lintCmd := cli.NewLintCommand()
+	verifyCmd := cli.NewVerifyCommand()
+	validateOutputCmd := cli.NewValidateOutputCommand()
 	domainsCmd := cli.NewDomainsCommand()
+	docsCmd := cli.NewDocsCommand()
+	schemaCmd := cli.NewSchemaCommand()
 	experimentsCmd := cli.NewExperimentsCommand()
 	forecastCmd := cli.NewForecastCommand()
 	envCmd := cli.NewEnvCommand()
@@ -860,6 +942,7 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	updateCmd.GroupID = "setup"
 	deployCmd.GroupID = "setup"
 	upgradeCmd.GroupID = "setup"
+	migrateCmd.GroupID = "setup"
 	secretsCmd.GroupID = "setup"
 	envCmd.GroupID = "setup"
 	doctorCmd.GroupID = "setup"
@@ -868,9 +951,13 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	compileCmd.GroupID = "development"
 	validateCmd.GroupID = "development"
 	lintCmd.GroupID = "development"
+	verifyCmd.GroupID = "development"
+	validateOutputCmd.GroupID = "development"
 	mcpCmd.GroupID = "development"
 	fixCmd.GroupID = "development"
 	domainsCmd.GroupID = "development"
+	docsCmd.GroupID = "development"
+	schemaCmd.GroupID = "development"
 
 	// Execution Commands
 	runCmd.GroupID = "execution"
@@ -885,6 +972,7 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	healthCmd.GroupID = "analysis"
 	outcomesCmd.GroupID = "analysis"
 	checksCmd.GroupID = "analysis"
+	prStatusCmd.GroupID = "analysis"
 	statusCmd.GroupID = "analysis"
 	listCmd.GroupID = "analysis"
 	experimentsCmd.GroupID = "analysis"
@@ -905,6 +993,7 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	rootCmd.AddCommand(updateCmd)
 	rootCmd.AddCommand(deployCmd)
 	rootCmd.AddCommand(upgradeCmd)
+	rootCmd.AddCommand(migrateCmd)
 	rootCmd.AddCommand(trialCmd)
 	rootCmd.AddCommand(newCmd)
 	rootCmd.AddCommand(initCmd)
@@ -921,6 +1010,7 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	rootCmd.AddCommand(healthCmd)
 	rootCmd.AddCommand(outcomesCmd)
 	rootCmd.AddCommand(checksCmd)
+	rootCmd.AddCommand(prStatusCmd)
 	rootCmd.AddCommand(mcpCmd)
 	rootCmd.AddCommand(mcpServerCmd)
 	rootCmd.AddCommand(prCmd)
@@ -929,11 +1019,15 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	rootCmd.AddCommand(fixCmd)
 	rootCmd.AddCommand(validateCmd)
 	rootCmd.AddCommand(lintCmd)
+	rootCmd.AddCommand(verifyCmd)
+	rootCmd.AddCommand(validateOutputCmd)
 	rootCmd.AddCommand(completionCmd)
 	rootCmd.AddCommand(hashCmd)
 	rootCmd.AddCommand(projectCmd)
 	rootCmd.AddCommand(doctorCmd)
 	rootCmd.AddCommand(domainsCmd)
+	rootCmd.AddCommand(docsCmd)
+	rootCmd.AddCommand(schemaCmd)
 	rootCmd.AddCommand(experimentsCmd)
 	rootCmd.AddCommand(forecastCmd)
 	rootCmd.AddCommand(envCmd)
@@ -1001,6 +1095,6 @@ func main() {
 		} else {
 			fmt.Fprintln(os.Stderr, console.FormatErrorChain(err))
 		}
-		os.Exit(1)
+		os.Exit(cli.ExitCodeForError(err))
 	}
 }
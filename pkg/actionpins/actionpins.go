@@ -9,6 +9,7 @@ import (
 	"context"
 	_ "embed"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"maps"
 	"os"
@@ -18,6 +19,7 @@ import (
 	"sync"
 
 	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/errorutil"
 	"github.com/github/gh-aw/pkg/gitutil"
 	"github.com/github/gh-aw/pkg/logger"
 	"github.com/github/gh-aw/pkg/semverutil"
@@ -361,8 +363,9 @@ func ResolveActionPin(actionRepo, version string, ctx *PinContext) (string, erro
 	actionRepo, version = applyActionPinMapping(actionRepo, version, ctx)
 
 	isAlreadySHA := gitutil.IsValidFullSHA(version)
-	if pinnedRef, ok := resolveActionPinDynamically(actionRepo, version, isAlreadySHA, ctx); ok {
-		return pinnedRef, nil
+	dynamicRef, ok, dynamicErr := resolveActionPinDynamically(actionRepo, version, isAlreadySHA, ctx)
+	if ok {
+		return dynamicRef, nil
 	}
 
 	if pinnedRef, ok := resolveActionPinFromHardcodedPins(actionRepo, version, isAlreadySHA, ctx); ok {
@@ -381,6 +384,9 @@ func ResolveActionPin(actionRepo, version string, ctx *PinContext) (string, erro
 		errorType = ResolutionErrorTypeDynamicResolutionFailed
 	}
 	recordPinResolutionFailure(ctx, actionRepo, version, errorType)
+	if errors.Is(dynamicErr, errorutil.ErrOffline) {
+		return "", fmt.Errorf("unable to pin action %s@%s: %w", actionRepo, version, dynamicErr)
+	}
 	if ctx.EnforcePinned && !ctx.AllowActionRefs {
 		if ctx.Resolver != nil {
 			return "", fmt.Errorf("unable to pin action %s@%s: resolution failed", actionRepo, version)
@@ -399,10 +405,10 @@ func ResolveActionPin(actionRepo, version string, ctx *PinContext) (string, erro
 	return "", nil
 }
 
-func resolveActionPinDynamically(actionRepo, version string, isAlreadySHA bool, ctx *PinContext) (string, bool) {
+func resolveActionPinDynamically(actionRepo, version string, isAlreadySHA bool, ctx *PinContext) (string, bool, error) {
 	if ctx.Resolver == nil || isAlreadySHA {
 		logDynamicResolutionSkipped(ctx.Resolver != nil, isAlreadySHA)
-		return "", false
+		return "", false, nil
 	}
 
 	actionPinsLog.Printf("Attempting dynamic resolution for %s@%s", actionRepo, version)
@@ -412,11 +418,11 @@ func resolveActionPinDynamically(actionRepo, version string, isAlreadySHA bool,
 		resolvedVersion := findVersionBySHA(actionRepo, sha)
 		result := formatPinnedActionWithResolution(actionRepo, sha, version, resolvedVersion)
 		actionPinsLog.Printf("Returning pinned reference: %s", result)
-		return result, true
+		return result, true, nil
 	}
 
 	actionPinsLog.Printf("Dynamic resolution failed for %s@%s: %v", actionRepo, version, err)
-	return "", false
+	return "", false, err
 }
 
 func logDynamicResolutionSkipped(hasResolver, isAlreadySHA bool) {
@@ -428,13 +428,14 @@ func TestResolveActionPinDynamically_SkipsForSHAInput(t *testing.T) {
 	resolver := &countingResolver{}
 	ctx := &PinContext{Resolver: resolver}
 
-	result, ok := resolveActionPinDynamically(
+	result, ok, err := resolveActionPinDynamically(
 		"actions/checkout",
 		"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
 		true,
 		ctx,
 	)
 
+	require.NoError(t, err, "Expected no error when dynamic resolution is skipped")
 	assert.False(t, ok, "Expected no dynamic resolution for SHA input")
 	assert.Empty(t, result, "Expected empty result when dynamic resolution is skipped")
 	assert.Zero(t, resolver.called, "Expected resolver not to be called for SHA input")
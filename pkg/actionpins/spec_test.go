@@ -6,6 +6,7 @@ package actionpins_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 	"sync"
 	"testing"
@@ -15,6 +16,7 @@ import (
 
 	"github.com/github/gh-aw/pkg/actionpins"
 	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/errorutil"
 )
 
 type testContextKey string
@@ -322,6 +324,15 @@ func TestSpec_PublicAPI_ResolveActionPin_EnforcePinned(t *testing.T) {
 			wantFailureType:  actionpins.ResolutionErrorTypeDynamicResolutionFailed,
 			wantFailureCount: 1,
 		},
+		{
+			name:             "offline resolver miss stays an error even with AllowActionRefs",
+			resolver:         &testSHAResolver{err: fmt.Errorf("%w: not in actions-lock.json", errorutil.ErrOffline)},
+			allowActionRefs:  true,
+			wantErr:          true,
+			wantErrContains:  "network access is disabled",
+			wantFailureType:  actionpins.ResolutionErrorTypeDynamicResolutionFailed,
+			wantFailureCount: 1,
+		},
 		{
 			name:          "resolver succeeds with EnforcePinned=true returns pinned reference",
 			resolver:      &testSHAResolver{sha: testResolvedSHA},
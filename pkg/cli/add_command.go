@@ -37,6 +37,10 @@ Workflow specifications:
   - Local file: "./path/to/workflow.md" (adds a workflow from local filesystem)
   - Local wildcard: "./*.md" or "./dir/*.md" (adds all .md files matching pattern)
   - Version can be tag, branch, or SHA (for remote workflows)
+  - Registry name: "issue-triage" (looked up in the curated workflow registry)
+
+Use --search to find workflows in the curated registry (githubnext/agentics by default).
+Results show each workflow's description, required secrets, and tools; add one by name.
 
 The -n flag allows you to specify a custom name for the workflow file (not allowed when adding multiple workflows at once).
 The --dir flag allows you to specify the workflow directory (default: .github/workflows).
@@ -60,6 +64,8 @@ Note: For guided interactive setup, use the 'add-wizard' command instead.`
   ` + string(constants.CLIExtensionPrefix) + ` add ./my-workflow.md                             # Add local workflow
   ` + string(constants.CLIExtensionPrefix) + ` add ./*.md                                       # Add all local workflows
   ` + string(constants.CLIExtensionPrefix) + ` add githubnext/agentics/ci-doctor --dir .github/workflows/shared   # Add to .github/workflows/shared/
+  ` + string(constants.CLIExtensionPrefix) + ` add --search triage                              # Search the workflow registry
+  ` + string(constants.CLIExtensionPrefix) + ` add issue-triage                                 # Add a workflow by registry name
 `
 )
 
@@ -102,7 +108,7 @@ func NewAddCommand(validateEngine func(string) error) *cobra.Command {
 		Long:    addCommandLong,
 		Example: addCommandExample,
 		Args: func(cmd *cobra.Command, args []string) error {
-			if len(args) < 1 {
+			if len(args) < 1 && !cmd.Flags().Changed("search") {
 				return fmt.Errorf("missing workflow specification\n\nUsage:\n  %s <workflow>...\n\nExamples:\n  %[1]s githubnext/agentics/daily-repo-status      Add from repository\n  %[1]s ./my-workflow.md                           Add local workflow\n\nRun '%[1]s --help' for more information", cmd.CommandPath())
 			}
 			return nil
@@ -131,6 +137,15 @@ func runAddCommand(cmd *cobra.Command, args []string, validateEngine func(string
 	disableSecurityScanner, _ := cmd.Flags().GetBool("no-security-scanner")
 	disableSecurityScannerLegacy, _ := cmd.Flags().GetBool("disable-security-scanner")
 	disableSecurityScanner = disableSecurityScanner || disableSecurityScannerLegacy
+	searchQuery, _ := cmd.Flags().GetString("search")
+	registry, _ := cmd.Flags().GetString("registry")
+
+	if cmd.Flags().Changed("search") {
+		if len(args) > 0 {
+			return errors.New("--search cannot be combined with workflow arguments")
+		}
+		return runAddRegistrySearch(cmd.Context(), registry, searchQuery)
+	}
 
 	if nameFlag != "" && len(args) > 1 {
 		return errors.New("--name flag cannot be used when adding multiple workflows at once")
@@ -152,6 +167,10 @@ func runAddCommand(cmd *cobra.Command, args []string, validateEngine func(string
 		StopAfter:              stopAfter,
 		DisableSecurityScanner: disableSecurityScanner,
 	}
+	args, err := resolveRegistryWorkflowNames(cmd.Context(), args, registry)
+	if err != nil {
+		return err
+	}
 	resolved, err := ResolveWorkflows(cmd.Context(), args, verbose)
 	if err != nil {
 		return err
@@ -223,6 +242,10 @@ func registerAddCommandFlags(cmd *cobra.Command) {
 	cmd.Flags().Bool("disable-security-scanner", false, "Skip security scanning of workflow markdown content")
 	_ = cmd.Flags().MarkDeprecated("disable-security-scanner", "use --no-security-scanner instead")
 
+	// Add registry search flags to add command
+	cmd.Flags().String("search", "", "Search the curated workflow registry instead of adding workflows")
+	cmd.Flags().String("registry", "", "Workflow registry repository for --search and registry names (owner/repo[@ref], default: "+defaultWorkflowRegistry+")")
+
 	// Register completions for add command
 	RegisterEngineFlagCompletion(cmd)
 	RegisterDirFlagCompletion(cmd, "dir")
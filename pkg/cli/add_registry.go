@@ -0,0 +1,224 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+	"github.com/github/gh-aw/pkg/stringutil"
+)
+
+var addRegistryLog = logger.New("cli:add_registry")
+
+const (
+	// defaultWorkflowRegistry is the repository holding the curated workflow registry index
+	defaultWorkflowRegistry = "githubnext/agentics"
+	// workflowRegistryIndexPath is the path of the registry index within the registry repository
+	workflowRegistryIndexPath = "registry.json"
+	// workflowRegistryDefaultRef is the ref the registry index is read from when none is given
+	workflowRegistryDefaultRef = "main"
+)
+
+// workflowRegistryIndex is the JSON manifest listing installable workflows
+type workflowRegistryIndex struct {
+	Workflows []workflowRegistryEntry `json:"workflows"`
+}
+
+// workflowRegistryEntry describes one installable workflow in the registry index
+type workflowRegistryEntry struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Source      string   `json:"source"`            // Workflow spec passed to 'gh aw add' (e.g. "githubnext/agentics/issue-triage")
+	Secrets     []string `json:"secrets,omitempty"` // Secrets the workflow needs (e.g. "COPILOT_GITHUB_TOKEN")
+	Tools       []string `json:"tools,omitempty"`   // Tools the workflow uses (e.g. "github", "playwright")
+	Tags        []string `json:"tags,omitempty"`
+}
+
+// fetchWorkflowRegistry downloads and parses the registry index from an "owner/repo[@ref]" registry spec
+func fetchWorkflowRegistry(ctx context.Context, registry string) (*workflowRegistryIndex, error) {
+	if registry == "" {
+		registry = defaultWorkflowRegistry
+	}
+	repoSlug, ref, _ := strings.Cut(registry, "@")
+	if ref == "" {
+		ref = workflowRegistryDefaultRef
+	}
+	owner, repo, ok := strings.Cut(repoSlug, "/")
+	if !ok || !parser.IsValidGitHubIdentifier(owner) || !parser.IsValidGitHubRepositoryName(repo) {
+		return nil, fmt.Errorf("invalid registry %q: expected owner/repo[@ref]", registry)
+	}
+
+	addRegistryLog.Printf("Fetching workflow registry: repo=%s, ref=%s", repoSlug, ref)
+	content, err := parser.DownloadFileFromGitHubForHost(ctx, owner, repo, workflowRegistryIndexPath, ref, explicitHostForRepo(repoSlug))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch workflow registry %s/%s@%s: %w", repoSlug, workflowRegistryIndexPath, ref, err)
+	}
+	return parseWorkflowRegistry(content)
+}
+
+// parseWorkflowRegistry parses a registry index and drops entries without a name or source
+func parseWorkflowRegistry(content []byte) (*workflowRegistryIndex, error) {
+	var index workflowRegistryIndex
+	if err := json.Unmarshal(content, &index); err != nil {
+		return nil, fmt.Errorf("invalid workflow registry index: %w", err)
+	}
+	index.Workflows = slices.DeleteFunc(index.Workflows, func(entry workflowRegistryEntry) bool {
+		return entry.Name == "" || entry.Source == ""
+	})
+	addRegistryLog.Printf("Parsed workflow registry with %d entries", len(index.Workflows))
+	return &index, nil
+}
+
+// searchWorkflowRegistry returns the entries matching every word of the query in their name,
+// description, tags, or tools. Name matches sort first; an empty query returns all entries.
+func searchWorkflowRegistry(index *workflowRegistryIndex, query string) []workflowRegistryEntry {
+	terms := strings.Fields(strings.ToLower(query))
+	var matches []workflowRegistryEntry
+	for _, entry := range index.Workflows {
+		fields := append([]string{entry.Name, entry.Description}, entry.Tags...)
+		haystack := strings.ToLower(strings.Join(append(fields, entry.Tools...), " "))
+		if !containsAllTerms(haystack, terms) {
+			continue
+		}
+		matches = append(matches, entry)
+	}
+
+	nameMatches := func(entry workflowRegistryEntry) bool {
+		return containsAllTerms(strings.ToLower(entry.Name), terms)
+	}
+	slices.SortStableFunc(matches, func(a, b workflowRegistryEntry) int {
+		if aName, bName := nameMatches(a), nameMatches(b); aName != bName {
+			if aName {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return matches
+}
+
+func containsAllTerms(s string, terms []string) bool {
+	for _, term := range terms {
+		if !strings.Contains(s, term) {
+			return false
+		}
+	}
+	return true
+}
+
+// findWorkflowRegistryEntry returns the entry whose name matches case-insensitively
+func findWorkflowRegistryEntry(index *workflowRegistryIndex, name string) (workflowRegistryEntry, bool) {
+	for _, entry := range index.Workflows {
+		if strings.EqualFold(entry.Name, name) {
+			return entry, true
+		}
+	}
+	return workflowRegistryEntry{}, false
+}
+
+// isRegistryWorkflowName reports whether an add argument is a bare registry name rather than
+// a workflow spec, URL, or local path (e.g. "issue-triage" but not "owner/repo" or "./x.md").
+func isRegistryWorkflowName(arg string) bool {
+	if arg == "" || strings.ContainsAny(arg, `/\:*`) || strings.HasSuffix(arg, ".md") || isLocalWorkflowPath(arg) {
+		return false
+	}
+	if _, err := os.Stat(arg); err == nil {
+		return false
+	}
+	return true
+}
+
+// resolveRegistryWorkflowNames replaces bare registry names in args with their workflow specs.
+// The registry is only fetched when at least one argument is a bare name.
+func resolveRegistryWorkflowNames(ctx context.Context, args []string, registry string) ([]string, error) {
+	if !slices.ContainsFunc(args, isRegistryWorkflowName) {
+		return args, nil
+	}
+	index, err := fetchWorkflowRegistry(ctx, registry)
+	if err != nil {
+		return nil, err
+	}
+	return resolveRegistryWorkflowNamesFromIndex(args, index)
+}
+
+func resolveRegistryWorkflowNamesFromIndex(args []string, index *workflowRegistryIndex) ([]string, error) {
+	resolved := make([]string, 0, len(args))
+	for _, arg := range args {
+		if !isRegistryWorkflowName(arg) {
+			resolved = append(resolved, arg)
+			continue
+		}
+		entry, ok := findWorkflowRegistryEntry(index, arg)
+		if !ok {
+			names := make([]string, 0, len(index.Workflows))
+			for _, e := range index.Workflows {
+				names = append(names, e.Name)
+			}
+			msg := fmt.Sprintf("workflow %q not found in the registry", arg)
+			if suggestions := stringutil.FindClosestMatches(arg, names, 3); len(suggestions) > 0 {
+				msg += fmt.Sprintf(". Did you mean: %s?", strings.Join(suggestions, ", "))
+			}
+			return nil, fmt.Errorf("%s\n\nRun '%s add --search %s' to search the registry", msg, string(constants.CLIExtensionPrefix), arg)
+		}
+		addRegistryLog.Printf("Resolved registry workflow %s to %s", arg, entry.Source)
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Resolved %s to %s from the workflow registry", arg, entry.Source)))
+		resolved = append(resolved, entry.Source)
+	}
+	return resolved, nil
+}
+
+// runAddRegistrySearch prints the registry workflows matching query
+func runAddRegistrySearch(ctx context.Context, registry, query string) error {
+	index, err := fetchWorkflowRegistry(ctx, registry)
+	if err != nil {
+		return err
+	}
+
+	matches := searchWorkflowRegistry(index, query)
+	if len(matches) == 0 {
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("No workflows in the registry match %q", query)))
+		return nil
+	}
+
+	rows := make([][]string, 0, len(matches))
+	for _, entry := range matches {
+		rows = append(rows, []string{
+			entry.Name,
+			orDash(stringutil.Truncate(entry.Description, 60)),
+			orDash(strings.Join(entry.Secrets, ", ")),
+			orDash(strings.Join(entry.Tools, ", ")),
+		})
+	}
+
+	fmt.Fprint(os.Stderr, console.RenderTable(console.TableConfig{
+		Title:     "Workflow registry: " + registryDisplayName(registry),
+		Headers:   []string{"Name", "Description", "Secrets", "Tools"},
+		Rows:      rows,
+		ShowTotal: true,
+		TotalRow:  []string{fmt.Sprintf("Total: %d workflows", len(matches)), "", "", ""},
+	}))
+	fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Usage: %s add <name>", string(constants.CLIExtensionPrefix))))
+	return nil
+}
+
+func registryDisplayName(registry string) string {
+	if registry == "" {
+		return defaultWorkflowRegistry
+	}
+	return registry
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,93 @@
+//go:build !integration
+
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testWorkflowRegistryJSON = `{
+  "workflows": [
+    {"name": "issue-triage", "description": "Triage new issues", "source": "githubnext/agentics/issue-triage", "secrets": ["COPILOT_GITHUB_TOKEN"], "tools": ["github"], "tags": ["issues"]},
+    {"name": "ci-doctor", "description": "Investigate failing CI runs and triage the cause", "source": "githubnext/agentics/ci-doctor", "tools": ["github", "bash"]},
+    {"name": "daily-plan", "description": "Plan the day", "source": "githubnext/agentics/daily-plan", "tools": ["playwright"]},
+    {"name": "", "source": "githubnext/agentics/unnamed"},
+    {"name": "no-source"}
+  ]
+}`
+
+func TestParseWorkflowRegistry(t *testing.T) {
+	index, err := parseWorkflowRegistry([]byte(testWorkflowRegistryJSON))
+	require.NoError(t, err, "registry index should parse")
+	require.Len(t, index.Workflows, 3, "entries without a name or source should be dropped")
+	assert.Equal(t, []string{"COPILOT_GITHUB_TOKEN"}, index.Workflows[0].Secrets)
+
+	_, err = parseWorkflowRegistry([]byte("not json"))
+	require.Error(t, err, "invalid JSON should be rejected")
+	assert.Contains(t, err.Error(), "invalid workflow registry index")
+}
+
+func TestSearchWorkflowRegistry(t *testing.T) {
+	index, err := parseWorkflowRegistry([]byte(testWorkflowRegistryJSON))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		query    string
+		expected []string
+	}{
+		{name: "empty query returns all sorted by name", query: "", expected: []string{"ci-doctor", "daily-plan", "issue-triage"}},
+		{name: "name matches sort before description matches", query: "triage", expected: []string{"issue-triage", "ci-doctor"}},
+		{name: "matches tools", query: "playwright", expected: []string{"daily-plan"}},
+		{name: "all terms must match", query: "CI github", expected: []string{"ci-doctor"}},
+		{name: "no matches", query: "deploy", expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var names []string
+			for _, entry := range searchWorkflowRegistry(index, tt.query) {
+				names = append(names, entry.Name)
+			}
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+}
+
+func TestIsRegistryWorkflowName(t *testing.T) {
+	tests := []struct {
+		arg      string
+		expected bool
+	}{
+		{arg: "issue-triage", expected: true},
+		{arg: "githubnext/agentics/ci-doctor", expected: false},
+		{arg: "./my-workflow.md", expected: false},
+		{arg: "my-workflow.md", expected: false},
+		{arg: "https://example.com/workflow.md", expected: false},
+		{arg: "*.md", expected: false},
+		{arg: "", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.arg, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isRegistryWorkflowName(tt.arg))
+		})
+	}
+}
+
+func TestResolveRegistryWorkflowNamesFromIndex(t *testing.T) {
+	index, err := parseWorkflowRegistry([]byte(testWorkflowRegistryJSON))
+	require.NoError(t, err)
+
+	resolved, err := resolveRegistryWorkflowNamesFromIndex([]string{"Issue-Triage", "owner/repo/other", "./local.md"}, index)
+	require.NoError(t, err, "known registry names should resolve")
+	assert.Equal(t, []string{"githubnext/agentics/issue-triage", "owner/repo/other", "./local.md"}, resolved)
+
+	_, err = resolveRegistryWorkflowNamesFromIndex([]string{"ci-docter"}, index)
+	require.Error(t, err, "unknown registry names should fail")
+	assert.Contains(t, err.Error(), "not found in the registry")
+	assert.Contains(t, err.Error(), "ci-doctor", "error should suggest the closest name")
+}
@@ -2,10 +2,13 @@ package cli
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -31,12 +34,22 @@ type AuditOptions struct {
 	Parse            bool
 	JSONOutput       bool
 	JobID            int64
+	JobName          string // job name or ID from --job, resolved against the run's jobs
 	StepNumber       int
+	StepName         string // step name from --step
+	FailingStep      bool   // --failing: select the first failing job and step
+	PrintStep        bool   // print the selected step's output to stdout
 	Format           string
 	ArtifactSets     []string
 	ExperimentFilter string
 	VariantFilter    string
 	EvalsOnly        bool
+	MCPOnly          bool
+	OTLPEndpoint     string
+	Porcelain        bool
+	Redact           bool // mask repository names, usernames, secrets, and internal hostnames in the rendered report
+	Annotate         bool // emit ::error/::warning workflow commands for errors and MCP failures when running in GitHub Actions
+	Timeline         bool // render a waterfall of job and step durations and write timeline.html
 }
 
 var auditCommandLong = `Audit one or more workflow runs by downloading artifacts and logs, detecting errors,
@@ -57,21 +70,53 @@ Each argument accepts:
 When a job URL is provided (single-run mode only):
 - If a step number is included (#step:7:1), extracts that specific step's output
 - If no step number, finds and extracts the first failing step's output
-- Saves job logs to the output directory`
+- Saves job logs to the output directory
+
+Use --job <name> and --step <name> to print a single step's output with error lines
+highlighted, without downloading the run's artifacts. Job and step names match the
+names shown in the Actions UI (case-insensitive); numbers are accepted too. Use
+--failing instead of --step to select the first failing step, and without --job
+to select the first failed job as well. --json prints the step as JSON.
+
+Use --mcp to render only the MCP diagnostics section: per-server startup time,
+registered tools, per-tool call latency distribution, and raw JSON-RPC error payloads.
+
+Use --otlp <endpoint> to export the run's metrics (duration, tokens, cost, turns, tool
+calls, errors, per-job durations, firewall requests) and spans to an OTLP/HTTP endpoint
+such as an OpenTelemetry collector or Prometheus' OTLP receiver. Headers are read from
+OTEL_EXPORTER_OTLP_HEADERS and the service name from OTEL_SERVICE_NAME.
+
+When GitHub denies access, the audit explains which token, scope, or permission is
+missing and the command or setting that fixes it. Use --fix-auth to run the fix
+(e.g. gh auth refresh -s repo) interactively and retry.`
 
 var auditCommandExample = `  ` + string(constants.CLIExtensionPrefix) + ` audit 1234567890 --repo owner/repo  # Audit with bare run ID (--repo required)
   ` + string(constants.CLIExtensionPrefix) + ` audit https://github.com/owner/repo/actions/runs/1234567890  # Audit from run URL
   ` + string(constants.CLIExtensionPrefix) + ` audit https://github.com/owner/repo/actions/runs/1234567890/job/9876543210  # Audit job and extract first failing step
   ` + string(constants.CLIExtensionPrefix) + ` audit https://github.com/owner/repo/actions/runs/1234567890/job/9876543210#step:7:1  # Extract step 7 output
+  ` + string(constants.CLIExtensionPrefix) + ` audit 1234567890 --job agent --step "Execute agent"  # Print one step's output
+  ` + string(constants.CLIExtensionPrefix) + ` audit 1234567890 --failing          # Print the first failing step of the first failed job
   ` + string(constants.CLIExtensionPrefix) + ` audit https://github.com/owner/repo/runs/1234567890  # Audit from workflow run URL
   ` + string(constants.CLIExtensionPrefix) + ` audit https://github.example.com/owner/repo/actions/runs/1234567890  # Audit from GitHub Enterprise
   ` + string(constants.CLIExtensionPrefix) + ` audit 1234567890 -o ./audit-reports # Custom output directory
   ` + string(constants.CLIExtensionPrefix) + ` audit 1234567890 -v                 # Verbose output
   ` + string(constants.CLIExtensionPrefix) + ` audit 1234567890 --parse            # Parse agent logs and firewall logs, generating log.md and firewall.md
+  ` + string(constants.CLIExtensionPrefix) + ` audit 1234567890 --mcp              # Show only MCP server diagnostics
   ` + string(constants.CLIExtensionPrefix) + ` audit 1234567890 --repo owner/repo  # Audit run from a specific repository
+  ` + string(constants.CLIExtensionPrefix) + ` audit 1234567890 --otlp http://localhost:4318  # Export run metrics and spans over OTLP
+  ` + string(constants.CLIExtensionPrefix) + ` audit 1234567890 --fix-auth         # Fix missing GitHub access interactively and retry
+  ` + string(constants.CLIExtensionPrefix) + ` audit 1234567890 --porcelain        # Print tab-separated records for scripts
+  ` + string(constants.CLIExtensionPrefix) + ` audit 1234567890 --redact           # Mask repositories, users, secrets, and internal hosts for sharing
+  ` + string(constants.CLIExtensionPrefix) + ` audit 1234567890 --annotate         # In GitHub Actions, annotate the job with the run's errors
+  ` + string(constants.CLIExtensionPrefix) + ` audit 1234567890 --timeline         # Waterfall of job and step durations with the critical path
+  ` + string(constants.CLIExtensionPrefix) + ` audit 1234567890 --format markdown  # Print the run metrics summary shown in the job summary
   ` + string(constants.CLIExtensionPrefix) + ` audit 1234567890 1234567891         # Diff two runs (base vs comparison)
   ` + string(constants.CLIExtensionPrefix) + ` audit 1234567890 1234567891 1234567892  # Diff base against multiple runs
-  ` + string(constants.CLIExtensionPrefix) + ` audit 1234567890 1234567891 --format markdown  # Markdown diff output for PR comments`
+  ` + string(constants.CLIExtensionPrefix) + ` audit 1234567890 1234567891 --format markdown  # Markdown diff output for PR comments
+  ` + string(constants.CLIExtensionPrefix) + ` audit flaky daily-plan         # Cluster failures across cached runs of a workflow
+  ` + string(constants.CLIExtensionPrefix) + ` audit missing-tools --workflow daily-plan  # Rank tools agents report missing and suggest tools: config
+  ` + string(constants.CLIExtensionPrefix) + ` audit query 1234567890 --tool github_issue_read --min-duration 2s  # Filter tool calls of a downloaded run
+  ` + string(constants.CLIExtensionPrefix) + ` audit show 1234567890 --file aw.patch  # Render an artifact file of a downloaded run`
 
 type auditCommandOptions struct {
 	outputDir        string
@@ -85,6 +130,16 @@ type auditCommandOptions struct {
 	experimentFilter string
 	variantFilter    string
 	evalsOnly        bool
+	mcpOnly          bool
+	otlpEndpoint     string
+	fixAuth          bool
+	porcelain        bool
+	jobFlag          string
+	stepFlag         string
+	failing          bool
+	redact           bool
+	annotate         bool
+	timeline         bool
 }
 
 // NewAuditCommand creates the audit command
@@ -99,20 +154,36 @@ func NewAuditCommand() *cobra.Command {
 	}
 	registerAuditCommandFlags(cmd)
 	cmd.AddCommand(NewAuditDiffSubcommand())
+	cmd.AddCommand(NewAuditFlakySubcommand())
+	cmd.AddCommand(NewAuditMissingToolsSubcommand())
+	cmd.AddCommand(NewAuditQuerySubcommand())
+	cmd.AddCommand(NewAuditShowSubcommand())
 	return cmd
 }
 
 func registerAuditCommandFlags(cmd *cobra.Command) {
 	addOutputFlag(cmd, defaultLogsOutputDir)
 	addJSONFlag(cmd)
+	addPorcelainFlag(cmd)
+	cmd.MarkFlagsMutuallyExclusive("json", "porcelain")
 	addRepoFlag(cmd)
 	cmd.Flags().Bool("parse", false, "Run JavaScript parsers on agent logs and firewall logs, writing Markdown to log.md and firewall.md")
-	cmd.Flags().String("format", "pretty", "Diff output format for multi-run mode: pretty, markdown")
+	cmd.Flags().String("format", "pretty", "Output format: pretty, markdown (single run: the run metrics summary written to the job summary; multi-run: the diff report)")
 	cmd.Flags().StringSlice("artifacts", nil, "Artifact sets to download (default: all — comprehensive artifacts required for analysis). Valid sets: "+strings.Join(ValidArtifactSetNames(), ", "))
 	cmd.Flags().Bool("stdin", false, "Read workflow run IDs or URLs from stdin (one per line) instead of positional arguments")
 	cmd.Flags().String("experiment", "", "Filter to runs that include this experiment name")
 	cmd.Flags().String("variant", "", "Filter to runs with a specific variant value (requires --experiment)")
 	cmd.Flags().Bool("evals", false, "Filter to runs containing evals results (evals.jsonl); automatically downloads the usage artifact (which includes evals) when --artifacts is narrowed")
+	cmd.Flags().Bool("mcp", false, "Render only the MCP diagnostics section (server startup, registered tools, tool latency, JSON-RPC errors)")
+	cmd.Flags().String("otlp", "", "Export run metrics and spans to this OTLP/HTTP endpoint (e.g. http://localhost:4318)")
+	cmd.Flags().Bool("fix-auth", false, "When GitHub denies access, run the gh auth command that grants the missing access (after confirmation) and retry")
+	cmd.Flags().String("job", "", "Audit only this job of the run (job name or ID)")
+	cmd.Flags().String("step", "", "Print only this step's output (step name or number; requires --job or a job URL)")
+	cmd.Flags().Bool("failing", false, "Print only the first failing step's output (of the first failed job unless --job is set)")
+	cmd.Flags().Bool("redact", false, "Mask repository names, usernames, token remnants, and internal hostnames in the report so it can be shared externally")
+	cmd.Flags().Bool("annotate", false, "When running in GitHub Actions, emit an error or warning annotation for each error, warning, and MCP server failure found in the run")
+	cmd.Flags().Bool("timeline", false, "Render a waterfall of job and step durations (queued vs running) highlighting the critical path, and write it as HTML to timeline.html")
+	cmd.MarkFlagsMutuallyExclusive("step", "failing")
 	RegisterDirFlagCompletion(cmd, "output")
 }
 
@@ -134,7 +205,51 @@ func runAuditCommand(cmd *cobra.Command, args []string) error {
 			[]string{"Provide a single run ID with --evals to filter by evals results"},
 		))
 	}
-	return runAuditMulti(cmd.Context(), args, opts.repoFlag, opts.outputDir, opts.verbose, opts.jsonOutput, opts.format, opts.artifacts)
+	if opts.mcpOnly {
+		return errors.New(console.FormatErrorWithSuggestions(
+			"--mcp is not supported in multi-run diff mode",
+			[]string{"Provide a single run ID with --mcp to inspect its MCP diagnostics"},
+		))
+	}
+	if opts.porcelain {
+		return errors.New(console.FormatErrorWithSuggestions(
+			"--porcelain is not supported in multi-run diff mode",
+			[]string{"Use --json for machine-readable diff output"},
+		))
+	}
+	if opts.otlpEndpoint != "" {
+		return errors.New(console.FormatErrorWithSuggestions(
+			"--otlp is not supported in multi-run diff mode",
+			[]string{"Export each run separately by auditing one run ID at a time with --otlp"},
+		))
+	}
+	if opts.jobFlag != "" || opts.stepFlag != "" || opts.failing {
+		return errors.New(console.FormatErrorWithSuggestions(
+			"--job, --step, and --failing are not supported in multi-run diff mode",
+			[]string{"Provide a single run ID to extract a job's step output"},
+		))
+	}
+	if opts.redact {
+		return errors.New(console.FormatErrorWithSuggestions(
+			"--redact is not supported in multi-run diff mode",
+			[]string{"Audit one run ID at a time with --redact to produce a shareable report"},
+		))
+	}
+	if opts.annotate {
+		return errors.New(console.FormatErrorWithSuggestions(
+			"--annotate is not supported in multi-run diff mode",
+			[]string{"Audit one run ID at a time with --annotate to annotate each run's errors"},
+		))
+	}
+	if opts.timeline {
+		return errors.New(console.FormatErrorWithSuggestions(
+			"--timeline is not supported in multi-run diff mode",
+			[]string{"Audit one run ID at a time with --timeline to see each run's job timeline"},
+		))
+	}
+	return withAuthRemediation(resolveAuditHostname(""), opts.fixAuth, func() error {
+		return runAuditMulti(cmd.Context(), args, opts.repoFlag, opts.outputDir, opts.verbose, opts.jsonOutput, opts.format, opts.artifacts)
+	})
 }
 
 func getAuditCommandOptions(cmd *cobra.Command) (auditCommandOptions, error) {
@@ -150,6 +265,46 @@ func getAuditCommandOptions(cmd *cobra.Command) (auditCommandOptions, error) {
 	opts.experimentFilter, _ = cmd.Flags().GetString("experiment")
 	opts.variantFilter, _ = cmd.Flags().GetString("variant")
 	opts.evalsOnly, _ = cmd.Flags().GetBool("evals")
+	opts.mcpOnly, _ = cmd.Flags().GetBool("mcp")
+	opts.otlpEndpoint, _ = cmd.Flags().GetString("otlp")
+	opts.fixAuth, _ = cmd.Flags().GetBool("fix-auth")
+	opts.porcelain, _ = cmd.Flags().GetBool("porcelain")
+	opts.jobFlag, _ = cmd.Flags().GetString("job")
+	opts.stepFlag, _ = cmd.Flags().GetString("step")
+	opts.failing, _ = cmd.Flags().GetBool("failing")
+	opts.redact, _ = cmd.Flags().GetBool("redact")
+	opts.annotate, _ = cmd.Flags().GetBool("annotate")
+	opts.timeline, _ = cmd.Flags().GetBool("timeline")
+	if opts.otlpEndpoint != "" {
+		// Validate the endpoint before downloading artifacts.
+		if _, err := NewAuditOTLPExporter(opts.otlpEndpoint, ""); err != nil {
+			return auditCommandOptions{}, err
+		}
+	}
+	if opts.porcelain && opts.mcpOnly {
+		return auditCommandOptions{}, errors.New(console.FormatErrorWithSuggestions(
+			"--porcelain cannot be combined with --mcp",
+			[]string{"Use --mcp --json for machine-readable MCP diagnostics"},
+		))
+	}
+	if opts.redact && (opts.jobFlag != "" || opts.stepFlag != "" || opts.failing) {
+		return auditCommandOptions{}, errors.New(console.FormatErrorWithSuggestions(
+			"--redact cannot be combined with --job, --step, or --failing",
+			[]string{"Raw step output cannot be redacted reliably; audit the whole run with --redact instead"},
+		))
+	}
+	if opts.annotate && (opts.jobFlag != "" || opts.stepFlag != "" || opts.failing) {
+		return auditCommandOptions{}, errors.New(console.FormatErrorWithSuggestions(
+			"--annotate cannot be combined with --job, --step, or --failing",
+			[]string{"Audit the whole run with --annotate to annotate its errors"},
+		))
+	}
+	if opts.timeline && (opts.jobFlag != "" || opts.stepFlag != "" || opts.failing || opts.mcpOnly) {
+		return auditCommandOptions{}, errors.New(console.FormatErrorWithSuggestions(
+			"--timeline cannot be combined with --job, --step, --failing, or --mcp",
+			[]string{"Audit the whole run with --timeline to see all of its jobs"},
+		))
+	}
 	if opts.variantFilter != "" && opts.experimentFilter == "" {
 		return auditCommandOptions{}, errors.New(console.FormatErrorWithSuggestions(
 			"--variant requires --experiment to be specified",
@@ -205,23 +360,71 @@ func runAuditSingle(ctx context.Context, runIDOrURL string, opts auditCommandOpt
 	if err := applyAuditRepoFlag(opts.repoFlag, components); err != nil {
 		return err
 	}
-	return AuditWorkflowRun(ctx, components.Number, AuditOptions{
+	auditOpts := AuditOptions{
 		Owner:            components.Owner,
 		Repo:             components.Repo,
 		Hostname:         components.Host,
 		OutputDir:        opts.outputDir,
 		Verbose:          opts.verbose,
 		Parse:            opts.parse,
-		JSONOutput:       opts.jsonOutput,
+		JSONOutput:       opts.jsonOutput || opts.porcelain,
+		Porcelain:        opts.porcelain,
 		JobID:            components.JobID,
 		StepNumber:       components.StepNumber,
 		ArtifactSets:     opts.artifacts,
 		ExperimentFilter: opts.experimentFilter,
 		VariantFilter:    opts.variantFilter,
 		EvalsOnly:        opts.evalsOnly,
+		MCPOnly:          opts.mcpOnly,
+		OTLPEndpoint:     opts.otlpEndpoint,
+		Format:           opts.format,
+		Redact:           opts.redact,
+		Annotate:         opts.annotate,
+		Timeline:         opts.timeline,
+	}
+	if err := applyAuditStepFlags(&auditOpts, opts); err != nil {
+		return err
+	}
+	return withAuthRemediation(resolveAuditHostname(components.Host), opts.fixAuth, func() error {
+		return AuditWorkflowRun(ctx, components.Number, auditOpts)
 	})
 }
 
+// applyAuditStepFlags applies --job, --step, and --failing to the audit options. The
+// flags take precedence over the job and step encoded in a job URL.
+func applyAuditStepFlags(auditOpts *AuditOptions, opts auditCommandOptions) error {
+	if opts.jobFlag == "" && opts.stepFlag == "" && !opts.failing {
+		return nil
+	}
+	// --job alone behaves like a job URL; --step and --failing print the selected step.
+	auditOpts.PrintStep = opts.stepFlag != "" || opts.failing
+	auditOpts.FailingStep = opts.failing
+	if opts.jobFlag != "" {
+		auditOpts.JobID = 0
+		auditOpts.JobName = opts.jobFlag
+	}
+	if opts.stepFlag == "" {
+		if opts.failing {
+			// The URL's step number would otherwise win over the first failing step.
+			auditOpts.StepNumber = 0
+		}
+		return nil
+	}
+	if auditOpts.JobID == 0 && auditOpts.JobName == "" {
+		return errors.New(console.FormatErrorWithSuggestions(
+			"--step requires --job or a job URL",
+			[]string{"Add --job <name> to select the job that contains the step", "Use --failing to select the first failing step of the run"},
+		))
+	}
+	if stepNumber, err := strconv.Atoi(opts.stepFlag); err == nil && stepNumber > 0 {
+		auditOpts.StepNumber = stepNumber
+		return nil
+	}
+	auditOpts.StepNumber = 0
+	auditOpts.StepName = opts.stepFlag
+	return nil
+}
+
 func applyAuditRepoFlag(repoFlag string, components *parser.GitHubURLComponents) error {
 	if repoFlag == "" || components.Owner != "" {
 		return nil
@@ -316,11 +519,21 @@ type auditRunConfig struct {
 	parse            bool
 	jsonOutput       bool
 	jobID            int64
+	jobName          string
 	stepNumber       int
+	stepName         string
+	failingStep      bool
+	printStep        bool
 	artifactFilter   []string
 	experimentFilter string
 	variantFilter    string
 	evalsOnly        bool
+	mcpOnly          bool
+	otlpEndpoint     string
+	porcelain        bool
+	redact           bool
+	annotate         bool
+	timeline         bool
 	// evalsArtifactRequested is true when evals were requested via --evals or
 	// explicit --artifacts evals, and is used to trigger legacy dedicated-evals
 	// fallback behavior for older runs.
@@ -368,7 +581,7 @@ func AuditWorkflowRun(ctx context.Context, runID int64, opts AuditOptions) error
 		return err
 	}
 	announceAuditRun(cfg)
-	if cfg.jobID > 0 {
+	if cfg.jobID > 0 || cfg.jobName != "" || cfg.failingStep {
 		return auditJobRun(cfg.jobOptions())
 	}
 	if done, err := renderCachedAuditIfAvailable(ctx, cfg); done {
@@ -408,11 +621,21 @@ func newAuditRunConfig(runID int64, opts AuditOptions) (auditRunConfig, error) {
 		parse:                  opts.Parse,
 		jsonOutput:             opts.JSONOutput,
 		jobID:                  opts.JobID,
+		jobName:                opts.JobName,
 		stepNumber:             opts.StepNumber,
+		stepName:               opts.StepName,
+		failingStep:            opts.FailingStep,
+		printStep:              opts.PrintStep,
 		artifactFilter:         ResolveArtifactFilter(opts.ArtifactSets),
 		experimentFilter:       opts.ExperimentFilter,
 		variantFilter:          opts.VariantFilter,
 		evalsOnly:              opts.EvalsOnly,
+		mcpOnly:                opts.MCPOnly,
+		otlpEndpoint:           opts.OTLPEndpoint,
+		porcelain:              opts.Porcelain,
+		redact:                 opts.Redact,
+		annotate:               opts.Annotate,
+		timeline:               opts.Timeline,
 		evalsArtifactRequested: isEvalsArtifactRequested(opts.EvalsOnly, opts.ArtifactSets),
 	}, nil
 }
@@ -458,6 +681,10 @@ func announceAuditRun(cfg auditRunConfig) {
 	if !cfg.verbose {
 		return
 	}
+	if cfg.jobName != "" {
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Auditing workflow run %d, job %q...", cfg.runID, cfg.jobName)))
+		return
+	}
 	if cfg.jobID > 0 && cfg.stepNumber > 0 {
 		fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Auditing workflow run %d, job %d, step %d...", cfg.runID, cfg.jobID, cfg.stepNumber)))
 		return
@@ -473,7 +700,11 @@ func (cfg auditRunConfig) jobOptions() auditJobRunOptions {
 	return auditJobRunOptions{
 		runID:      cfg.runID,
 		jobID:      cfg.jobID,
+		jobName:    cfg.jobName,
 		stepNumber: cfg.stepNumber,
+		stepName:   cfg.stepName,
+		failing:    cfg.failingStep,
+		printStep:  cfg.printStep,
 		owner:      cfg.owner,
 		repo:       cfg.repo,
 		hostname:   cfg.hostname,
@@ -485,14 +716,20 @@ func (cfg auditRunConfig) jobOptions() auditJobRunOptions {
 
 func (cfg auditRunConfig) auditOptions() AuditOptions {
 	return AuditOptions{
-		Owner:      cfg.owner,
-		Repo:       cfg.repo,
-		Hostname:   cfg.hostname,
-		OutputDir:  cfg.outputDir,
-		Verbose:    cfg.verbose,
-		Parse:      cfg.parse,
-		JSONOutput: cfg.jsonOutput,
-		EvalsOnly:  cfg.evalsOnly,
+		Owner:        cfg.owner,
+		Repo:         cfg.repo,
+		Hostname:     cfg.hostname,
+		OutputDir:    cfg.outputDir,
+		Verbose:      cfg.verbose,
+		Parse:        cfg.parse,
+		JSONOutput:   cfg.jsonOutput,
+		EvalsOnly:    cfg.evalsOnly,
+		MCPOnly:      cfg.mcpOnly,
+		OTLPEndpoint: cfg.otlpEndpoint,
+		Porcelain:    cfg.porcelain,
+		Redact:       cfg.redact,
+		Annotate:     cfg.annotate,
+		Timeline:     cfg.timeline,
 	}
 }
 
@@ -925,6 +1162,7 @@ func buildAuditRunSummary(run WorkflowRun, processedRun ProcessedRun, results au
 		MCPToolUsage:            results.mcpToolUsage,
 		TokenUsage:              results.tokenUsageSummary,
 		GitHubRateLimitUsage:    results.rateLimitUsage,
+		RunnerEnvironment:       loadRunnerEnvironment(run.LogsPath),
 		ArtifactsList:           results.artifacts,
 		JobDetails:              results.jobDetails,
 	}
@@ -937,14 +1175,67 @@ func renderAuditReport(ctx context.Context, processedRun ProcessedRun, metrics L
 	runID := processedRun.Run.DatabaseID
 	runOutputDir := opts.OutputDir
 	processedRun.Run.SafeItemsCount = len(extractCreatedItemsFromManifest(runOutputDir))
+	var redactor *auditRedactor
+	if opts.Redact {
+		redactor = newAuditRedactor(opts.Owner, opts.Repo, opts.Hostname)
+	}
+	if opts.MCPOnly {
+		if err := renderAuditMCPDiagnostics(processedRun, runOutputDir, opts.JSONOutput, redactor); err != nil {
+			return err
+		}
+		if opts.Annotate {
+			failures, err := redactAuditValue(redactor, processedRun.MCPFailures)
+			if err != nil {
+				return err
+			}
+			emitAuditAnnotations(buildAuditAnnotations(AuditData{Overview: OverviewData{RunID: runID, WorkflowName: processedRun.Run.WorkflowName}, MCPFailures: failures}))
+		}
+		return exportAuditOTLPIfRequested(ctx, processedRun, metrics, opts)
+	}
 	auditData := buildRenderedAuditData(ctx, processedRun, metrics, mcpToolUsage, runOutputDir, opts)
-	if err := renderAuditOutput(auditData, runOutputDir, opts.JSONOutput, opts.Verbose); err != nil {
+	auditData, err := redactAuditValue(redactor, auditData)
+	if err != nil {
+		return err
+	}
+	if opts.Annotate {
+		emitAuditAnnotations(buildAuditAnnotations(auditData))
+	}
+	if opts.Porcelain {
+		writeAuditPorcelain(os.Stdout, auditData, runOutputDir)
+	} else if opts.Format == "markdown" && !opts.JSONOutput {
+		fmt.Fprint(os.Stdout, renderRunMetricsSummaryMarkdown(buildRunMetricsSummary(auditData, metrics)))
+	} else if err := renderAuditOutput(auditData, runOutputDir, opts.JSONOutput, opts.Verbose); err != nil {
 		return err
 	}
-	renderAuditGatewayMetrics(runOutputDir, opts.Verbose)
-	renderAuditUnifiedTimeline(runOutputDir, opts.Verbose)
+	renderAuditGatewayMetrics(runOutputDir, opts.Verbose, redactor)
+	renderAuditUnifiedTimeline(runOutputDir, opts.Verbose, redactor)
+	if opts.Timeline {
+		if err := renderAuditJobTimeline(processedRun, runOutputDir, redactor); err != nil {
+			return err
+		}
+	}
 	parseAuditLogsIfRequested(runID, runOutputDir, opts)
 	renderAuditCompletion(runOutputDir, opts.JSONOutput)
+	return exportAuditOTLPIfRequested(ctx, processedRun, metrics, opts)
+}
+
+// exportAuditOTLPIfRequested sends the run's metrics and spans to opts.OTLPEndpoint (audit --otlp).
+func exportAuditOTLPIfRequested(ctx context.Context, processedRun ProcessedRun, metrics LogMetrics, opts AuditOptions) error {
+	if opts.OTLPEndpoint == "" {
+		return nil
+	}
+	repository := ""
+	if opts.Owner != "" && opts.Repo != "" {
+		repository = opts.Owner + "/" + opts.Repo
+	}
+	exporter, err := NewAuditOTLPExporter(opts.OTLPEndpoint, repository)
+	if err != nil {
+		return err
+	}
+	if err := exporter.Export(ctx, processedRun, metrics); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, console.FormatSuccessMessage("Exported run metrics to "+exporter.Endpoint))
 	return nil
 }
 
@@ -971,27 +1262,71 @@ func renderAuditOutput(auditData AuditData, runOutputDir string, jsonOutput, ver
 	return nil
 }
 
-func renderAuditGatewayMetrics(runOutputDir string, verbose bool) {
+// writeAuditPorcelain writes the audit result as porcelain records:
+//
+//	run     <run-id> <workflow> <status> <conclusion> <errors> <warnings> <logs-dir>
+//	error   <file> <line> <message>
+//	warning <file> <line> <message>
+func writeAuditPorcelain(w io.Writer, auditData AuditData, runOutputDir string) {
+	absOutputDir, _ := filepath.Abs(runOutputDir)
+	o := auditData.Overview
+	writePorcelainRecord(w, "run", porcelainInt(o.RunID), o.WorkflowName, o.Status, o.Conclusion,
+		strconv.Itoa(auditData.Metrics.ErrorCount), strconv.Itoa(auditData.Metrics.WarningCount), absOutputDir)
+	for _, e := range auditData.Errors {
+		writePorcelainRecord(w, "error", e.File, porcelainInt(e.Line), e.Message)
+	}
+	for _, warning := range auditData.Warnings {
+		writePorcelainRecord(w, "warning", warning.File, porcelainInt(warning.Line), warning.Message)
+	}
+}
+
+// renderAuditMCPDiagnostics renders only the MCP diagnostics section (audit --mcp).
+// The baseline comparison and other report sections are skipped.
+func renderAuditMCPDiagnostics(processedRun ProcessedRun, runOutputDir string, jsonOutput bool, redactor *auditRedactor) error {
+	logsPath := processedRun.Run.LogsPath
+	if logsPath == "" {
+		logsPath = runOutputDir
+	}
+	diagnostics, err := redactAuditValue(redactor, buildMCPDiagnostics(logsPath, processedRun.MCPFailures))
+	if err != nil {
+		return err
+	}
+	if jsonOutput {
+		if diagnostics == nil {
+			diagnostics = &MCPDiagnostics{}
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(diagnostics); err != nil {
+			return fmt.Errorf("failed to render JSON output: %w", err)
+		}
+		return nil
+	}
+	renderMCPDiagnosticsReport(diagnostics)
+	return nil
+}
+
+func renderAuditGatewayMetrics(runOutputDir string, verbose bool, redactor *auditRedactor) {
 	gatewayMetrics, err := parseGatewayLogs(runOutputDir, verbose)
 	if err != nil {
 		return
 	}
 	if metricsOutput := renderGatewayMetricsTable(gatewayMetrics, verbose); metricsOutput != "" {
-		fmt.Fprint(os.Stderr, metricsOutput)
+		fmt.Fprint(os.Stderr, redactor.String(metricsOutput))
 	}
 }
 
 // renderAuditUnifiedTimeline builds the unified event timeline from the run output
 // directory (combining MCP Gateway, AWF firewall, and agent events) and writes the
 // rendered table to stderr.  It is a no-op when no events can be collected.
-func renderAuditUnifiedTimeline(runOutputDir string, verbose bool) {
+func renderAuditUnifiedTimeline(runOutputDir string, verbose bool, redactor *auditRedactor) {
 	events, err := BuildUnifiedTimeline(runOutputDir, verbose)
 	if err != nil {
 		auditLog.Printf("BuildUnifiedTimeline error for %s: %v", runOutputDir, err)
 		return
 	}
 	if output := renderUnifiedTimeline(events); output != "" {
-		fmt.Fprint(os.Stderr, output)
+		fmt.Fprint(os.Stderr, redactor.String(output))
 	}
 }
 
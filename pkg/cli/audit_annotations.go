@@ -0,0 +1,137 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/fileutil"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var auditAnnotationsLog = logger.New("cli:audit_annotations")
+
+// workflowCommandDataEscaper and workflowCommandPropertyEscaper escape the message and the
+// properties of GitHub Actions workflow commands, matching @actions/core.
+var (
+	workflowCommandDataEscaper     = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+	workflowCommandPropertyEscaper = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C")
+)
+
+// auditAnnotation is one ::error or ::warning workflow command emitted by audit --annotate.
+type auditAnnotation struct {
+	level   string // "error" or "warning"
+	file    string // repository-relative path, only set when the file exists in the workspace
+	line    int
+	title   string
+	message string
+}
+
+// String renders the annotation as a workflow command line.
+func (a auditAnnotation) String() string {
+	var props []string
+	if a.file != "" {
+		props = append(props, "file="+workflowCommandPropertyEscaper.Replace(a.file))
+		if a.line > 0 {
+			props = append(props, "line="+strconv.Itoa(a.line))
+		}
+	}
+	if a.title != "" {
+		props = append(props, "title="+workflowCommandPropertyEscaper.Replace(a.title))
+	}
+	command := "::" + a.level
+	if len(props) > 0 {
+		command += " " + strings.Join(props, ",")
+	}
+	return command + "::" + workflowCommandDataEscaper.Replace(a.message)
+}
+
+// buildAuditAnnotations returns an annotation for each error, warning, and MCP server
+// failure in the audit report. Errors found in run logs point at log files that are not
+// part of the repository, so their location goes into the title instead of file/line.
+func buildAuditAnnotations(data AuditData) []auditAnnotation {
+	prefix := auditAnnotationTitlePrefix(data.Overview)
+	var annotations []auditAnnotation
+	for _, group := range []struct {
+		level string
+		items []ErrorInfo
+	}{{"error", data.Errors}, {"warning", data.Warnings}} {
+		for _, item := range group.items {
+			annotations = append(annotations, newErrorInfoAnnotation(group.level, prefix, item))
+		}
+	}
+	for _, failure := range data.MCPFailures {
+		annotations = append(annotations, newMCPFailureAnnotation(prefix, failure))
+	}
+	return annotations
+}
+
+func newErrorInfoAnnotation(level, prefix string, item ErrorInfo) auditAnnotation {
+	annotation := auditAnnotation{level: level, message: item.Message}
+	title := prefix
+	if item.Type != "" {
+		title += ": " + item.Type
+	}
+	if item.File != "" {
+		if filepath.IsLocal(item.File) && fileutil.FileExists(item.File) {
+			annotation.file = filepath.ToSlash(item.File)
+			annotation.line = item.Line
+		} else if item.Line > 0 {
+			title += fmt.Sprintf(" (%s:%d)", item.File, item.Line)
+		} else {
+			title += fmt.Sprintf(" (%s)", item.File)
+		}
+	}
+	annotation.title = title
+	return annotation
+}
+
+func newMCPFailureAnnotation(prefix string, failure MCPFailureReport) auditAnnotation {
+	message := fmt.Sprintf("MCP server '%s' failed", failure.ServerName)
+	if failure.Status != "" {
+		message += ": " + failure.Status
+	}
+	return auditAnnotation{
+		level:   "error",
+		title:   prefix + ": mcp_failure",
+		message: message,
+	}
+}
+
+// auditAnnotationTitlePrefix names the audited run so annotations from several audits in
+// one job can be told apart.
+func auditAnnotationTitlePrefix(overview OverviewData) string {
+	name := overview.WorkflowName
+	if name == "" {
+		name = "Workflow"
+	}
+	if overview.RunID > 0 {
+		return fmt.Sprintf("%s run %d", name, overview.RunID)
+	}
+	return name
+}
+
+// writeAuditAnnotations writes one workflow command per annotation and returns how many
+// were written.
+func writeAuditAnnotations(w io.Writer, annotations []auditAnnotation) int {
+	for _, annotation := range annotations {
+		fmt.Fprintln(w, annotation.String())
+	}
+	return len(annotations)
+}
+
+// emitAuditAnnotations implements audit --annotate. The runner parses workflow commands
+// from stderr as well as stdout, so annotations go to stderr and never mix with --json,
+// --porcelain, or markdown output. Outside GitHub Actions nothing is emitted.
+func emitAuditAnnotations(annotations []auditAnnotation) {
+	if os.Getenv("GITHUB_ACTIONS") != "true" { //nolint:osgetenvlibrary
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage("--annotate only emits annotations when running in GitHub Actions"))
+		return
+	}
+	count := writeAuditAnnotations(os.Stderr, annotations)
+	auditAnnotationsLog.Printf("Emitted %d workflow annotation(s)", count)
+}
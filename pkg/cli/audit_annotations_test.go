@@ -0,0 +1,73 @@
+//go:build !integration
+
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditAnnotationString(t *testing.T) {
+	tests := []struct {
+		name       string
+		annotation auditAnnotation
+		expected   string
+	}{
+		{
+			name:       "message only",
+			annotation: auditAnnotation{level: "warning", message: "slow tool call"},
+			expected:   "::warning::slow tool call",
+		},
+		{
+			name:       "file, line and title",
+			annotation: auditAnnotation{level: "error", file: "src/app.go", line: 12, title: "Triage run 1: agent_failure", message: "boom"},
+			expected:   "::error file=src/app.go,line=12,title=Triage run 1%3A agent_failure::boom",
+		},
+		{
+			name:       "multi-line message and special characters",
+			annotation: auditAnnotation{level: "error", title: "a,b", message: "100% failed\r\nsecond line"},
+			expected:   "::error title=a%2Cb::100%25 failed%0D%0Asecond line",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.annotation.String(), "workflow command should be escaped")
+		})
+	}
+}
+
+func TestBuildAuditAnnotations(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "src"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "src", "main.go"), []byte("package main\n"), 0600))
+
+	data := AuditData{
+		Overview: OverviewData{RunID: 42, WorkflowName: "Issue triage"},
+		Errors: []ErrorInfo{
+			{File: "agent-stdio.log", Line: 10, Type: "error", Message: "token expired"},
+			{File: "agent/3_Run agent", Type: "step_failure", Message: "##[error]Process completed with exit code 1"},
+			{File: "src/main.go", Line: 3, Type: "error", Message: "compile error"},
+		},
+		Warnings:    []ErrorInfo{{Message: "rate limited"}},
+		MCPFailures: []MCPFailureReport{{ServerName: "github", Status: "failed to start"}},
+	}
+
+	annotations := buildAuditAnnotations(data)
+	require.Len(t, annotations, 5, "each error, warning, and MCP failure should be annotated")
+
+	var out bytes.Buffer
+	assert.Equal(t, 5, writeAuditAnnotations(&out, annotations), "all annotations should be written")
+	assert.Equal(t,
+		"::error title=Issue triage run 42%3A error (agent-stdio.log%3A10)::token expired\n"+
+			"::error title=Issue triage run 42%3A step_failure (agent/3_Run agent)::##[error]Process completed with exit code 1\n"+
+			"::error file=src/main.go,line=3,title=Issue triage run 42%3A error::compile error\n"+
+			"::warning title=Issue triage run 42::rate limited\n"+
+			"::error title=Issue triage run 42%3A mcp_failure::MCP server 'github' failed: failed to start\n",
+		out.String(), "annotations should locate repository files and title log locations")
+}
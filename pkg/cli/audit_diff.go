@@ -367,6 +367,8 @@ type AuditDiff struct {
 	FirewallDiff   *FirewallDiff   `json:"firewall_diff,omitempty"`
 	MCPToolsDiff   *MCPToolsDiff   `json:"mcp_tools_diff,omitempty"`
 	RunMetricsDiff *RunMetricsDiff `json:"run_metrics_diff,omitempty"`
+	// RunnerEnvironmentDiff lists runner image, tool cache and engine CLI changes
+	RunnerEnvironmentDiff *RunnerEnvironmentDiff `json:"runner_environment_diff,omitempty"`
 }
 
 // computeAuditDiff produces a full AuditDiff combining firewall, MCP tool, and run metrics diffs.
@@ -402,6 +404,15 @@ func computeAuditDiff(run1ID, run2ID int64, summary1, summary2 *RunSummary) *Aud
 		diff.RunMetricsDiff = metricsDiff
 	}
 
+	var env1, env2 *RunnerEnvironment
+	if summary1 != nil {
+		env1 = summary1.RunnerEnvironment
+	}
+	if summary2 != nil {
+		env2 = summary2.RunnerEnvironment
+	}
+	diff.RunnerEnvironmentDiff = computeRunnerEnvironmentDiff(env1, env2)
+
 	return diff
 }
 
@@ -940,6 +951,10 @@ func loadRunSummaryForDiff(ctx context.Context, runID int64, outputDir string, o
 	// Try cached summary first (full data including MCP tool usage, token usage, etc.)
 	if summary, ok := loadRunSummary(runOutputDir, verbose); ok {
 		auditDiffLog.Printf("Using cached run summary for run %d", runID)
+		// Summaries cached before runner environment capture still have aw_info.json on disk.
+		if summary.RunnerEnvironment == nil {
+			summary.RunnerEnvironment = loadRunnerEnvironment(runOutputDir)
+		}
 		return summary, nil
 	}
 
@@ -975,5 +990,6 @@ func loadRunSummaryForDiff(ctx context.Context, runID int64, outputDir string, o
 		RunID:                runID,
 		FirewallAnalysis:     analysis,
 		GitHubRateLimitUsage: rateLimitUsage,
+		RunnerEnvironment:    loadRunnerEnvironment(runOutputDir),
 	}, nil
 }
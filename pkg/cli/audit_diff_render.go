@@ -68,6 +68,7 @@ func renderSingleAuditDiffMarkdown(diff *AuditDiff) {
 
 	renderFirewallDiffMarkdownSection(diff.FirewallDiff)
 	renderMCPToolsDiffMarkdownSection(diff.MCPToolsDiff)
+	renderRunnerEnvironmentDiffMarkdownSection(diff.Run1ID, diff.Run2ID, diff.RunnerEnvironmentDiff)
 	renderRunMetricsDiffMarkdownSection(diff.Run1ID, diff.Run2ID, diff.RunMetricsDiff)
 }
 
@@ -123,6 +124,10 @@ func renderSingleAuditDiffPretty(diff *AuditDiff) {
 		anomalyCount += diff.MCPToolsDiff.Summary.AnomalyCount
 	}
 
+	if diff.RunnerEnvironmentDiff != nil {
+		summaryParts = append(summaryParts, fmt.Sprintf("Runner environment: %d changes", len(diff.RunnerEnvironmentDiff.Changes)))
+	}
+
 	if len(summaryParts) > 0 {
 		fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Changes: "+strings.Join(summaryParts, " | ")))
 	}
@@ -133,6 +138,7 @@ func renderSingleAuditDiffPretty(diff *AuditDiff) {
 
 	renderFirewallDiffPrettySection(diff.FirewallDiff)
 	renderMCPToolsDiffPrettySection(diff.MCPToolsDiff)
+	renderRunnerEnvironmentDiffPrettySection(diff.Run1ID, diff.Run2ID, diff.RunnerEnvironmentDiff)
 	renderRunMetricsDiffPrettySection(diff.Run1ID, diff.Run2ID, diff.RunMetricsDiff)
 }
 
@@ -230,6 +236,22 @@ func renderMCPToolsDiffMarkdownSection(diff *MCPToolsDiff) {
 	}
 }
 
+// renderRunnerEnvironmentDiffMarkdownSection renders runner environment changes as markdown
+func renderRunnerEnvironmentDiffMarkdownSection(run1ID, run2ID int64, diff *RunnerEnvironmentDiff) {
+	if diff == nil {
+		return
+	}
+
+	fmt.Fprintln(os.Stdout, "#### Runner Environment Changes")
+	fmt.Fprintln(os.Stdout)
+	fmt.Fprintf(os.Stdout, "| Field | Run #%d | Run #%d |\n", run1ID, run2ID)
+	fmt.Fprintln(os.Stdout, "|-------|---------|---------|")
+	for _, change := range diff.Changes {
+		fmt.Fprintf(os.Stdout, "| `%s` | %s | %s |\n", change.Field, formatRunnerEnvironmentValue(change.Run1Value), formatRunnerEnvironmentValue(change.Run2Value))
+	}
+	fmt.Fprintln(os.Stdout)
+}
+
 // renderRunMetricsDiffMarkdownSection renders the run metrics diff sub-section as markdown
 func renderRunMetricsDiffMarkdownSection(run1ID, run2ID int64, diff *RunMetricsDiff) {
 	if diff == nil {
@@ -447,6 +469,29 @@ func renderMCPToolsDiffPrettySection(diff *MCPToolsDiff) {
 	}
 }
 
+// renderRunnerEnvironmentDiffPrettySection renders runner environment changes as a pretty console sub-section
+func renderRunnerEnvironmentDiffPrettySection(run1ID, run2ID int64, diff *RunnerEnvironmentDiff) {
+	if diff == nil {
+		return
+	}
+
+	fmt.Fprintln(os.Stderr, console.FormatSectionHeader("Runner Environment Changes"))
+	fmt.Fprintln(os.Stderr)
+
+	config := console.TableConfig{
+		Headers: []string{"Field", fmt.Sprintf("Run #%d", run1ID), fmt.Sprintf("Run #%d", run2ID)},
+		Rows:    make([][]string, 0, len(diff.Changes)),
+	}
+	for _, change := range diff.Changes {
+		config.Rows = append(config.Rows, []string{
+			change.Field,
+			formatRunnerEnvironmentValue(change.Run1Value),
+			formatRunnerEnvironmentValue(change.Run2Value),
+		})
+	}
+	fmt.Fprint(os.Stderr, console.RenderTable(config))
+}
+
 // renderRunMetricsDiffPrettySection renders the run metrics diff as a pretty console sub-section
 func renderRunMetricsDiffPrettySection(run1ID, run2ID int64, diff *RunMetricsDiff) {
 	if diff == nil {
@@ -828,10 +873,19 @@ func isEmptyMCPToolsDiff(diff *MCPToolsDiff) bool {
 		len(diff.ChangedTools) == 0
 }
 
+// formatRunnerEnvironmentValue renders an unset runner environment value as "(none)"
+func formatRunnerEnvironmentValue(value string) string {
+	if value == "" {
+		return "(none)"
+	}
+	return value
+}
+
 // isEmptyAuditDiff returns true if the audit diff contains no changes across all sections
 func isEmptyAuditDiff(diff *AuditDiff) bool {
 	fwEmpty := diff.FirewallDiff == nil || isEmptyFirewallDiff(diff.FirewallDiff)
 	mcpEmpty := diff.MCPToolsDiff == nil || isEmptyMCPToolsDiff(diff.MCPToolsDiff)
 	metricsEmpty := diff.RunMetricsDiff == nil
-	return fwEmpty && mcpEmpty && metricsEmpty
+	envEmpty := diff.RunnerEnvironmentDiff == nil
+	return fwEmpty && mcpEmpty && metricsEmpty && envEmpty
 }
@@ -256,38 +256,45 @@ func extractPromptAnalysis(logsPath string) *PromptAnalysis {
 		return nil
 	}
 
-	// Try multiple possible locations for prompt.txt.
-	// The activation artifact may or may not have been flattened to the root.
+	promptPath := findPromptPath(logsPath)
+	if promptPath != "" {
+		data, err := os.ReadFile(promptPath)
+		if err == nil {
+			// Store a stable relative path instead of machine-specific absolute path
+			relPromptPath, relErr := filepath.Rel(logsPath, promptPath)
+			if relErr != nil {
+				relPromptPath = filepath.Base(promptPath)
+			}
+
+			analysis := &PromptAnalysis{
+				PromptSize: len(data),
+				PromptFile: relPromptPath,
+			}
+
+			auditExpandedLog.Printf("Extracted prompt analysis: size=%d chars from %s", analysis.PromptSize, relPromptPath)
+			return analysis
+		}
+	}
+
+	auditExpandedLog.Printf("No prompt.txt found in %s", logsPath)
+	return nil
+}
+
+// findPromptPath returns the first prompt.txt found in logsPath.
+// The activation artifact may or may not have been flattened to the root.
+func findPromptPath(logsPath string) string {
 	promptPaths := []string{
 		filepath.Join(logsPath, "prompt.txt"),
 		filepath.Join(logsPath, "aw-prompts", "prompt.txt"),
 		filepath.Join(logsPath, "activation", "aw-prompts", "prompt.txt"),
 		filepath.Join(logsPath, "agent", "aw-prompts", "prompt.txt"),
 	}
-
 	for _, promptPath := range promptPaths {
-		data, err := os.ReadFile(promptPath)
-		if err != nil {
-			continue
-		}
-
-		// Store a stable relative path instead of machine-specific absolute path
-		relPromptPath, relErr := filepath.Rel(logsPath, promptPath)
-		if relErr != nil {
-			relPromptPath = filepath.Base(promptPath)
-		}
-
-		analysis := &PromptAnalysis{
-			PromptSize: len(data),
-			PromptFile: relPromptPath,
+		if fileutil.FileExists(promptPath) {
+			return promptPath
 		}
-
-		auditExpandedLog.Printf("Extracted prompt analysis: size=%d chars from %s", analysis.PromptSize, relPromptPath)
-		return analysis
 	}
-
-	auditExpandedLog.Printf("No prompt.txt found in %s", logsPath)
-	return nil
+	return ""
 }
 
 // buildSessionAnalysis creates session performance metrics from available data
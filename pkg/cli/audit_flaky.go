@@ -0,0 +1,395 @@
+package cli
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/spf13/cobra"
+)
+
+var auditFlakyLog = logger.New("cli:audit_flaky")
+
+const (
+	// defaultFlakyRunLimit is the number of most recent cached runs analyzed by default
+	defaultFlakyRunLimit = 20
+	// maxFailureFingerprintLen bounds the normalized error message used for clustering
+	maxFailureFingerprintLen = 120
+	// noFailureMessage is the fingerprint used when a failed run has no extractable error
+	noFailureMessage = "(no error message)"
+	// unknownFailureStep is the step label used when a failed run has no failing step details
+	unknownFailureStep = "(unknown step)"
+)
+
+// FlakyRunReport summarizes the outcome history of one workflow across cached runs.
+type FlakyRunReport struct {
+	Workflow       string           `json:"workflow"`
+	RunsAnalyzed   int              `json:"runs_analyzed"`
+	Failures       int              `json:"failures"`
+	Successes      int              `json:"successes"`
+	FailureRate    float64          `json:"failure_rate"`
+	Transitions    int              `json:"transitions"` // Success/failure flips between consecutive runs
+	Flaky          bool             `json:"flaky"`
+	Headline       string           `json:"headline,omitempty"`
+	Clusters       []FailureCluster `json:"clusters,omitempty"`
+	FirstRunAt     time.Time        `json:"first_run_at,omitzero"`
+	LastRunAt      time.Time        `json:"last_run_at,omitzero"`
+	AnalyzedRunIDs []int64          `json:"analyzed_run_ids"`
+}
+
+// FailureCluster groups failed runs that share an error signature.
+type FailureCluster struct {
+	Step        string    `json:"step"`        // First failing step ("job › step")
+	Fingerprint string    `json:"fingerprint"` // Normalized error message
+	Count       int       `json:"count"`
+	Rate        float64   `json:"rate"` // Share of all analyzed runs
+	RunIDs      []int64   `json:"run_ids"`
+	FirstSeen   time.Time `json:"first_seen,omitzero"`
+	LastSeen    time.Time `json:"last_seen,omitzero"`
+	Example     string    `json:"example,omitempty"` // Original (truncated) message from the most recent run
+}
+
+// flakyRunInput is the per-run data the flaky analysis needs.
+type flakyRunInput struct {
+	Summary *RunSummary
+	Failed  bool
+	Step    string
+	Message string
+}
+
+// NewAuditFlakySubcommand creates the audit flaky subcommand.
+func NewAuditFlakySubcommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "flaky <workflow>",
+		Short: "Detect flaky runs and cluster failures across a workflow's cached run history",
+		Long: `Analyze the most recent cached runs of a workflow, cluster failures by error signature
+(first failing step and a normalized error message), and report how often each cluster occurs.
+
+The analysis reads run summaries already downloaded to the logs cache; it does not call the
+GitHub API. Populate the cache first with '` + string(constants.CLIExtensionPrefix) + ` logs <workflow>'.
+
+Cancelled and skipped runs are ignored. A workflow is reported as flaky when its recent runs
+flip between success and failure more than once, rather than failing consistently.`,
+		Example: `  ` + string(constants.CLIExtensionPrefix) + ` audit flaky daily-plan               # Analyze the last 20 cached runs
+  ` + string(constants.CLIExtensionPrefix) + ` audit flaky daily-plan --last 50     # Analyze the last 50 cached runs
+  ` + string(constants.CLIExtensionPrefix) + ` audit flaky daily-plan --json        # JSON output for CI integration`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputDir, _ := cmd.Flags().GetString("output")
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			last, _ := cmd.Flags().GetInt("last")
+			if last < 1 {
+				return fmt.Errorf("--last must be at least 1, got %d", last)
+			}
+			return RunAuditFlaky(args[0], outputDir, last, jsonOutput)
+		},
+	}
+
+	addOutputFlag(cmd, defaultLogsOutputDir)
+	addJSONFlag(cmd)
+	cmd.Flags().Int("last", defaultFlakyRunLimit, "Number of most recent cached runs to analyze")
+
+	return cmd
+}
+
+// RunAuditFlaky builds and renders the flaky-run report for a workflow from the local run cache.
+func RunAuditFlaky(workflowName, outputDir string, last int, jsonOutput bool) error {
+	auditFlakyLog.Printf("Starting flaky analysis: workflow=%s, dir=%s, last=%d", workflowName, outputDir, last)
+
+	inputs, err := loadFlakyRunInputs(outputDir, workflowName, last)
+	if err != nil {
+		return err
+	}
+	if len(inputs) == 0 {
+		return fmt.Errorf("no completed runs of workflow %q found in %s\n\nRun '%s logs %s' to download recent runs first", workflowName, outputDir, string(constants.CLIExtensionPrefix), workflowName)
+	}
+
+	report := buildFlakyRunReport(workflowName, inputs)
+	if jsonOutput {
+		return renderFlakyRunReportJSON(report)
+	}
+	renderFlakyRunReportPretty(report)
+	return nil
+}
+
+// loadFlakyRunInputs reads cached run summaries for the workflow, keeps completed non-cancelled
+// runs, and returns the most recent `last` of them in chronological order.
+func loadFlakyRunInputs(outputDir, workflowName string, last int) ([]flakyRunInput, error) {
+	summaries, err := readCachedRunSummaries(outputDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var inputs []flakyRunInput
+	for _, summary := range summaries {
+		if !matchesFlakyWorkflow(summary.Run, workflowName) || !isFlakyAnalyzableConclusion(summary.Run.Conclusion) {
+			continue
+		}
+		inputs = append(inputs, newFlakyRunInput(summary))
+	}
+
+	if len(inputs) > last {
+		inputs = inputs[len(inputs)-last:]
+	}
+	auditFlakyLog.Printf("Loaded %d cached runs for workflow %s", len(inputs), workflowName)
+	return inputs, nil
+}
+
+// readCachedRunSummaries reads the run summaries of every run-<id> directory in the logs
+// cache, in chronological order. Unreadable summaries are skipped.
+func readCachedRunSummaries(outputDir string) ([]*RunSummary, error) {
+	entries, err := os.ReadDir(outputDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read logs cache directory: %w", err)
+	}
+
+	var summaries []*RunSummary
+	for _, entry := range entries {
+		if !entry.IsDir() || !strings.HasPrefix(entry.Name(), "run-") {
+			continue
+		}
+		if _, parseErr := strconv.ParseInt(strings.TrimPrefix(entry.Name(), "run-"), 10, 64); parseErr != nil {
+			continue
+		}
+		runDir := filepath.Join(outputDir, entry.Name())
+		summary, err := readCachedRunSummary(runDir)
+		if err != nil {
+			auditFlakyLog.Printf("Skipping %s: %v", entry.Name(), err)
+			continue
+		}
+		summary.Run.LogsPath = runDir
+		summaries = append(summaries, summary)
+	}
+
+	slices.SortFunc(summaries, func(a, b *RunSummary) int {
+		if c := a.Run.CreatedAt.Compare(b.Run.CreatedAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.RunID, b.RunID)
+	})
+	return summaries, nil
+}
+
+// readCachedRunSummary reads run_summary.json without the CLI version check applied by
+// loadRunSummary: outcome history is still valid when the summary was written by an older CLI.
+func readCachedRunSummary(runDir string) (*RunSummary, error) {
+	data, err := os.ReadFile(filepath.Join(runDir, runSummaryFileName))
+	if err != nil {
+		return nil, err
+	}
+	var summary RunSummary
+	if err := json.Unmarshal(data, &summary); err != nil {
+		return nil, fmt.Errorf("invalid run summary: %w", err)
+	}
+	if summary.RunID == 0 {
+		summary.RunID = summary.Run.DatabaseID
+	}
+	if summary.RunID == 0 {
+		return nil, errors.New("run summary has no run ID")
+	}
+	return &summary, nil
+}
+
+// matchesFlakyWorkflow reports whether a run belongs to the named workflow. The name may be the
+// display name, the workflow ID (markdown file name without extension), or the lock file name.
+func matchesFlakyWorkflow(run WorkflowRun, workflowName string) bool {
+	name := strings.TrimSuffix(strings.TrimSuffix(filepath.Base(workflowName), ".md"), ".lock.yml")
+	if strings.EqualFold(run.WorkflowName, workflowName) || strings.EqualFold(run.WorkflowName, name) {
+		return true
+	}
+	if run.WorkflowPath == "" {
+		return false
+	}
+	pathID := strings.TrimSuffix(strings.TrimSuffix(filepath.Base(run.WorkflowPath), ".yml"), ".lock")
+	return strings.EqualFold(pathID, name)
+}
+
+// isFlakyAnalyzableConclusion reports whether a run conclusion counts towards the outcome history.
+// Cancelled runs usually come from concurrency groups rather than the workflow itself.
+func isFlakyAnalyzableConclusion(conclusion string) bool {
+	switch conclusion {
+	case "success", "failure", "timed_out":
+		return true
+	default:
+		return false
+	}
+}
+
+func newFlakyRunInput(summary *RunSummary) flakyRunInput {
+	input := flakyRunInput{Summary: summary}
+	if summary.Run.Conclusion == "success" {
+		return input
+	}
+
+	input.Failed = true
+	input.Step = firstFailingStep(summary.JobDetails)
+	if errs := extractPreAgentStepErrors(summary.Run.LogsPath); len(errs) > 0 {
+		input.Message = errs[0].Message
+	} else if len(summary.MCPFailures) > 0 {
+		failure := summary.MCPFailures[0]
+		input.Message = fmt.Sprintf("MCP server %s %s", failure.ServerName, failure.Status)
+		if input.Step == unknownFailureStep {
+			input.Step = "MCP server startup"
+		}
+	}
+	return input
+}
+
+// firstFailingStep returns "job › step" for the earliest failed step, falling back to the
+// first failed job when step details are unavailable.
+func firstFailingStep(jobs []JobInfoWithDuration) string {
+	failedJobs := make([]JobInfoWithDuration, 0, len(jobs))
+	for _, job := range jobs {
+		if isFailureConclusion(job.Conclusion) && job.Conclusion != "cancelled" {
+			failedJobs = append(failedJobs, job)
+		}
+	}
+	if len(failedJobs) == 0 {
+		return unknownFailureStep
+	}
+	slices.SortStableFunc(failedJobs, func(a, b JobInfoWithDuration) int {
+		return a.StartedAt.Compare(b.StartedAt)
+	})
+
+	job := failedJobs[0]
+	for _, step := range job.Steps {
+		if isFailureConclusion(step.Conclusion) && step.Conclusion != "cancelled" {
+			return job.Name + " › " + step.Name
+		}
+	}
+	return job.Name
+}
+
+var (
+	fingerprintTimestampPattern = regexp.MustCompile(`\d{4}-\d{2}-\d{2}[t ]\d{2}:\d{2}:\d{2}(\.\d+)?z?`)
+	fingerprintUUIDPattern      = regexp.MustCompile(`[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}`)
+	fingerprintHexPattern       = regexp.MustCompile(`\b[0-9a-f]{7,}\b`)
+	fingerprintPathPattern      = regexp.MustCompile(`(/[\w.@-]+){2,}`)
+	fingerprintNumberPattern    = regexp.MustCompile(`\d+`)
+	fingerprintSpacePattern     = regexp.MustCompile(`\s+`)
+)
+
+// fingerprintFailureMessage normalizes an error message so that runs failing the same way
+// cluster together: only the first line is kept, and timestamps, IDs, hashes, paths, and
+// numbers are replaced with placeholders.
+func fingerprintFailureMessage(message string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(message), "\n")
+	line = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "##[error]"))
+	if line == "" {
+		return noFailureMessage
+	}
+
+	line = strings.ToLower(line)
+	line = fingerprintTimestampPattern.ReplaceAllString(line, "<time>")
+	line = fingerprintUUIDPattern.ReplaceAllString(line, "<id>")
+	line = fingerprintHexPattern.ReplaceAllString(line, "<hex>")
+	line = fingerprintPathPattern.ReplaceAllString(line, "<path>")
+	line = fingerprintNumberPattern.ReplaceAllString(line, "<n>")
+	line = fingerprintSpacePattern.ReplaceAllString(line, " ")
+	return stringutil.Truncate(strings.TrimSpace(line), maxFailureFingerprintLen)
+}
+
+// buildFlakyRunReport clusters failures and computes failure rate and flakiness. Inputs must be
+// in chronological order.
+func buildFlakyRunReport(workflowName string, inputs []flakyRunInput) *FlakyRunReport {
+	report := &FlakyRunReport{
+		Workflow:       workflowName,
+		RunsAnalyzed:   len(inputs),
+		AnalyzedRunIDs: make([]int64, 0, len(inputs)),
+	}
+	if len(inputs) == 0 {
+		return report
+	}
+	report.FirstRunAt = inputs[0].Summary.Run.CreatedAt
+	report.LastRunAt = inputs[len(inputs)-1].Summary.Run.CreatedAt
+
+	clusters := make(map[string]*FailureCluster)
+	for i, input := range inputs {
+		run := input.Summary.Run
+		report.AnalyzedRunIDs = append(report.AnalyzedRunIDs, input.Summary.RunID)
+		if i > 0 && inputs[i-1].Failed != input.Failed {
+			report.Transitions++
+		}
+		if !input.Failed {
+			report.Successes++
+			continue
+		}
+		report.Failures++
+
+		fingerprint := fingerprintFailureMessage(input.Message)
+		key := input.Step + "\x00" + fingerprint
+		cluster, ok := clusters[key]
+		if !ok {
+			cluster = &FailureCluster{Step: input.Step, Fingerprint: fingerprint, FirstSeen: run.CreatedAt}
+			clusters[key] = cluster
+		}
+		cluster.Count++
+		cluster.RunIDs = append(cluster.RunIDs, input.Summary.RunID)
+		cluster.LastSeen = run.CreatedAt
+		if input.Message != "" {
+			cluster.Example = stringutil.Truncate(strings.TrimSpace(input.Message), 300)
+		}
+	}
+
+	report.FailureRate = float64(report.Failures) / float64(report.RunsAnalyzed)
+	// A single flip is a regression or a fix; repeated flips mean the outcome is not deterministic.
+	report.Flaky = report.Failures > 0 && report.Successes > 0 && report.Transitions >= 2
+
+	for _, cluster := range clusters {
+		cluster.Rate = float64(cluster.Count) / float64(report.RunsAnalyzed)
+		report.Clusters = append(report.Clusters, *cluster)
+	}
+	slices.SortFunc(report.Clusters, func(a, b FailureCluster) int {
+		if a.Count != b.Count {
+			return b.Count - a.Count
+		}
+		return b.LastSeen.Compare(a.LastSeen)
+	})
+
+	report.Headline = buildFlakyHeadline(report)
+	auditFlakyLog.Printf("Flaky report: runs=%d, failures=%d, clusters=%d, flaky=%t", report.RunsAnalyzed, report.Failures, len(report.Clusters), report.Flaky)
+	return report
+}
+
+// buildFlakyHeadline summarizes the report in one sentence, naming the step where the workflow
+// fails most often (e.g. "fails 40% of the time at the agent › Start MCP gateway step").
+func buildFlakyHeadline(report *FlakyRunReport) string {
+	if report.Failures == 0 {
+		return fmt.Sprintf("%s succeeded in all %d analyzed runs", report.Workflow, report.RunsAnalyzed)
+	}
+
+	stepCounts := make(map[string]int)
+	for _, cluster := range report.Clusters {
+		stepCounts[cluster.Step] += cluster.Count
+	}
+	topStep, topCount := "", 0
+	for step, count := range stepCounts {
+		if count > topCount || (count == topCount && step < topStep) {
+			topStep, topCount = step, count
+		}
+	}
+
+	rate := fmt.Sprintf("%s fails %.0f%% of the time", report.Workflow, report.FailureRate*100)
+	switch {
+	case topStep == unknownFailureStep:
+		return fmt.Sprintf("%s (%d of %d runs)", rate, report.Failures, report.RunsAnalyzed)
+	case topCount == report.Failures:
+		return fmt.Sprintf("%s at the %s step (%d of %d runs)", rate, topStep, report.Failures, report.RunsAnalyzed)
+	default:
+		return fmt.Sprintf("%s (%d of %d runs), most often at the %s step (%d failures)", rate, report.Failures, report.RunsAnalyzed, topStep, topCount)
+	}
+}
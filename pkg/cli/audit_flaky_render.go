@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/stringutil"
+)
+
+var auditFlakyRenderLog = logger.New("cli:audit_flaky_render")
+
+// renderFlakyRunReportJSON outputs the flaky-run report as JSON to stdout.
+func renderFlakyRunReportJSON(report *FlakyRunReport) error {
+	auditFlakyRenderLog.Printf("Rendering flaky report as JSON: runs=%d, clusters=%d", report.RunsAnalyzed, len(report.Clusters))
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
+
+// renderFlakyRunReportPretty outputs the flaky-run report to stderr.
+func renderFlakyRunReportPretty(report *FlakyRunReport) {
+	auditFlakyRenderLog.Printf("Rendering flaky report as pretty output: runs=%d, clusters=%d", report.RunsAnalyzed, len(report.Clusters))
+	fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Audit Report — Failure History: "+report.Workflow))
+	fmt.Fprintln(os.Stderr)
+
+	fmt.Fprintf(os.Stderr, "  Runs analyzed:  %d (%s → %s)\n", report.RunsAnalyzed,
+		report.FirstRunAt.Format("2006-01-02"), report.LastRunAt.Format("2006-01-02"))
+	fmt.Fprintf(os.Stderr, "  Failures:       %d (%.0f%%)\n", report.Failures, report.FailureRate*100)
+	fmt.Fprintf(os.Stderr, "  Outcome flips:  %d\n", report.Transitions)
+	fmt.Fprintln(os.Stderr)
+
+	if len(report.Clusters) > 0 {
+		rows := make([][]string, 0, len(report.Clusters))
+		for _, cluster := range report.Clusters {
+			rows = append(rows, []string{
+				fmt.Sprintf("%d (%.0f%%)", cluster.Count, cluster.Rate*100),
+				cluster.Step,
+				stringutil.Truncate(cluster.Fingerprint, 70),
+				formatFlakyClusterRunIDs(cluster.RunIDs),
+			})
+		}
+		fmt.Fprint(os.Stderr, console.RenderTable(console.TableConfig{
+			Title:   "Failure Clusters",
+			Headers: []string{"Runs", "First Failing Step", "Error Signature", "Run IDs"},
+			Rows:    rows,
+		}))
+		fmt.Fprintln(os.Stderr)
+	}
+
+	switch {
+	case report.Failures == 0:
+		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(report.Headline))
+	case report.Flaky:
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(report.Headline+" — outcome is flaky"))
+	default:
+		fmt.Fprintln(os.Stderr, console.FormatErrorMessage(report.Headline))
+	}
+}
+
+// formatFlakyClusterRunIDs lists the most recent run IDs of a cluster, eliding older ones.
+func formatFlakyClusterRunIDs(ids []int64) string {
+	const maxIDs = 3
+	if len(ids) <= maxIDs {
+		return formatRunIDs(ids)
+	}
+	recent := formatRunIDs(ids[len(ids)-maxIDs:])
+	return strings.Join([]string{recent, fmt.Sprintf("+%d more", len(ids)-maxIDs)}, ", ")
+}
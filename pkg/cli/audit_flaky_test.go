@@ -0,0 +1,129 @@
+//go:build !integration
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFlakyTestRun(t *testing.T, dir string, runID int64, workflowName, workflowID, conclusion string, createdAt time.Time, jobs []JobInfoWithDuration) {
+	t.Helper()
+	writeCachedRunSummary(t, dir, RunSummary{
+		CLIVersion: "old-version",
+		RunID:      runID,
+		Run: WorkflowRun{
+			DatabaseID:   runID,
+			WorkflowName: workflowName,
+			WorkflowPath: ".github/workflows/" + workflowID + ".lock.yml",
+			Conclusion:   conclusion,
+			CreatedAt:    createdAt,
+		},
+		JobDetails: jobs,
+	})
+}
+
+func failedAgentJob(stepName string) []JobInfoWithDuration {
+	return []JobInfoWithDuration{
+		{JobInfo: JobInfo{Name: "activation", Conclusion: "success"}},
+		{JobInfo: JobInfo{Name: "agent", Conclusion: "failure", Steps: []JobStep{
+			{Name: "Checkout", Conclusion: "success"},
+			{Name: stepName, Conclusion: "failure"},
+			{Name: "Upload logs", Conclusion: "failure"},
+		}}},
+	}
+}
+
+func TestLoadFlakyRunInputs(t *testing.T) {
+	dir := t.TempDir()
+	base := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	writeFlakyTestRun(t, dir, 101, "Daily Plan", "daily-plan", "success", base, nil)
+	writeFlakyTestRun(t, dir, 102, "Daily Plan", "daily-plan", "failure", base.Add(time.Hour), failedAgentJob("Start MCP gateway"))
+	writeFlakyTestRun(t, dir, 103, "Daily Plan", "daily-plan", "cancelled", base.Add(2*time.Hour), nil)
+	writeFlakyTestRun(t, dir, 104, "Other", "other", "failure", base.Add(3*time.Hour), nil)
+	writeFlakyTestRun(t, dir, 105, "Daily Plan", "daily-plan", "success", base.Add(4*time.Hour), nil)
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "run-backup"), 0755))
+
+	inputs, err := loadFlakyRunInputs(dir, "daily-plan", 10)
+	require.NoError(t, err, "cache should load")
+	require.Len(t, inputs, 3, "cancelled runs and other workflows should be skipped")
+	assert.Equal(t, int64(101), inputs[0].Summary.RunID, "runs should be in chronological order")
+	assert.Equal(t, "agent › Start MCP gateway", inputs[1].Step, "first failing step should be recorded")
+
+	inputs, err = loadFlakyRunInputs(dir, "Daily Plan", 2)
+	require.NoError(t, err)
+	require.Len(t, inputs, 2, "only the most recent runs should be kept")
+	assert.Equal(t, int64(102), inputs[0].Summary.RunID)
+
+	inputs, err = loadFlakyRunInputs(filepath.Join(dir, "missing"), "daily-plan", 10)
+	require.NoError(t, err, "a missing cache directory is not an error")
+	assert.Empty(t, inputs)
+}
+
+func TestFingerprintFailureMessage(t *testing.T) {
+	a := fingerprintFailureMessage("##[error]MCP server github failed to start after 30s at 2026-10-01T10:00:00Z (run 12345)\nstack trace")
+	b := fingerprintFailureMessage("##[error]MCP server github failed to start after 45s at 2026-10-02T11:30:00Z (run 67890)")
+	assert.Equal(t, a, b, "messages differing only in numbers and timestamps should share a fingerprint")
+	assert.Equal(t, "mcp server github failed to start after <n>s at <time> (run <n>)", a)
+
+	assert.Equal(t,
+		fingerprintFailureMessage("open /tmp/gh-aw/run-1/out.json: no such file, sha deadbeef1234"),
+		fingerprintFailureMessage("open /tmp/gh-aw/run-2/out.json: no such file, sha cafebabe5678"),
+		"paths and hashes should be normalized")
+	assert.Equal(t, noFailureMessage, fingerprintFailureMessage("  "))
+}
+
+func TestBuildFlakyRunReport(t *testing.T) {
+	base := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	mkInput := func(id int64, failed bool, step, message string) flakyRunInput {
+		return flakyRunInput{
+			Summary: &RunSummary{RunID: id, Run: WorkflowRun{CreatedAt: base.Add(time.Duration(id) * time.Hour)}},
+			Failed:  failed,
+			Step:    step,
+			Message: message,
+		}
+	}
+
+	inputs := []flakyRunInput{
+		mkInput(1, false, "", ""),
+		mkInput(2, true, "agent › Start MCP gateway", "MCP server github timed out after 30s"),
+		mkInput(3, false, "", ""),
+		mkInput(4, true, "agent › Start MCP gateway", "MCP server github timed out after 60s"),
+		mkInput(5, true, "agent › Execute agent", "rate limit exceeded"),
+	}
+
+	report := buildFlakyRunReport("daily-plan", inputs)
+	assert.Equal(t, 5, report.RunsAnalyzed)
+	assert.Equal(t, 3, report.Failures)
+	assert.Equal(t, 2, report.Successes)
+	assert.InDelta(t, 0.6, report.FailureRate, 0.001)
+	assert.Equal(t, 3, report.Transitions)
+	assert.True(t, report.Flaky, "alternating outcomes should be reported as flaky")
+
+	require.Len(t, report.Clusters, 2, "failures should cluster by step and fingerprint")
+	assert.Equal(t, "agent › Start MCP gateway", report.Clusters[0].Step, "largest cluster should sort first")
+	assert.Equal(t, []int64{2, 4}, report.Clusters[0].RunIDs)
+	assert.InDelta(t, 0.4, report.Clusters[0].Rate, 0.001)
+	assert.Equal(t, "daily-plan fails 60% of the time (3 of 5 runs), most often at the agent › Start MCP gateway step (2 failures)", report.Headline)
+}
+
+func TestBuildFlakyRunReport_ConsistentFailure(t *testing.T) {
+	base := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	var inputs []flakyRunInput
+	for i := range 4 {
+		inputs = append(inputs, flakyRunInput{
+			Summary: &RunSummary{RunID: int64(i + 1), Run: WorkflowRun{CreatedAt: base.Add(time.Duration(i) * time.Hour)}},
+			Failed:  i >= 2,
+			Step:    "agent › Start MCP gateway",
+		})
+	}
+
+	report := buildFlakyRunReport("daily-plan", inputs)
+	assert.False(t, report.Flaky, "a single success-to-failure flip is a regression, not flakiness")
+	assert.Equal(t, "daily-plan fails 50% of the time at the agent › Start MCP gateway step (2 of 4 runs)", report.Headline)
+}
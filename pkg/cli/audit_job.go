@@ -10,6 +10,7 @@ import (
 	"github.com/github/gh-aw/pkg/console"
 	"github.com/github/gh-aw/pkg/constants"
 	"github.com/github/gh-aw/pkg/fileutil"
+	"github.com/github/gh-aw/pkg/stringutil"
 	"github.com/github/gh-aw/pkg/workflow"
 )
 
@@ -17,7 +18,11 @@ import (
 type auditJobRunOptions struct {
 	runID      int64
 	jobID      int64
+	jobName    string // --job: job name or ID, resolved to jobID when jobID is 0
 	stepNumber int
+	stepName   string // --step: step name as shown in the job log
+	failing    bool   // --failing: select the first failed job (without --job) and its first failing step
+	printStep  bool   // print the selected step to stdout (set when the step was chosen with flags)
 	owner      string
 	repo       string
 	hostname   string
@@ -27,10 +32,17 @@ type auditJobRunOptions struct {
 }
 
 // auditJobRun performs a targeted audit of a specific job within a workflow run
-// If stepNumber > 0, focuses on extracting output for that specific step
+// If stepNumber > 0 or stepName is set, focuses on extracting output for that specific step
 func auditJobRun(opts auditJobRunOptions) error {
 	opts.hostname = resolveAuditHostname(opts.hostname)
-	auditLog.Printf("Starting job-specific audit: runID=%d, jobID=%d, stepNumber=%d, hostname=%s", opts.runID, opts.jobID, opts.stepNumber, opts.hostname)
+	if opts.jobID == 0 {
+		jobID, err := resolveAuditJobID(opts)
+		if err != nil {
+			return err
+		}
+		opts.jobID = jobID
+	}
+	auditLog.Printf("Starting job-specific audit: runID=%d, jobID=%d, stepNumber=%d, stepName=%q, hostname=%s", opts.runID, opts.jobID, opts.stepNumber, opts.stepName, opts.hostname)
 	if err := os.MkdirAll(opts.outputDir, constants.DirPermSensitive); err != nil {
 		return fmt.Errorf("failed to create output directory: %w", err)
 	}
@@ -72,15 +84,43 @@ func fetchAuditJobLog(opts auditJobRunOptions) (string, string, error) {
 }
 
 func extractAuditJobDetails(opts auditJobRunOptions, jobLogContent string) error {
+	if opts.stepName != "" {
+		return extractNamedStep(opts, jobLogContent)
+	}
 	if opts.stepNumber > 0 {
 		return extractRequestedStepOutput(opts, jobLogContent)
 	}
 	return extractFirstFailingStepOutput(opts, jobLogContent)
 }
 
+// extractNamedStep extracts the step selected with --step <name>. Unlike URL step
+// numbers, a named step that does not exist is an error.
+func extractNamedStep(opts auditJobRunOptions, jobLogContent string) error {
+	stepOutput, err := extractNamedStepOutput(jobLogContent, opts.stepName)
+	if err != nil {
+		return err
+	}
+	stepLogPath := filepath.Join(opts.outputDir, fmt.Sprintf("job-%d-step-%s.log", opts.jobID, namedStepFileSuffix(opts.stepName)))
+	if err := os.WriteFile(stepLogPath, []byte(stepOutput), constants.FilePermSensitive); err != nil {
+		return fmt.Errorf("failed to write step log: %w", err)
+	}
+	if opts.verbose {
+		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("Step %q output saved to %s", opts.stepName, stepLogPath)))
+	}
+	return printAuditStepOutput(opts, opts.stepName, stepOutput)
+}
+
+// namedStepFileSuffix turns a step name into a file name component.
+func namedStepFileSuffix(stepName string) string {
+	return stringutil.SanitizeName(stepName, &stringutil.SanitizeOptions{TrimHyphens: true, DefaultValue: "step"})
+}
+
 func extractRequestedStepOutput(opts auditJobRunOptions, jobLogContent string) error {
 	stepOutput, err := extractStepOutput(jobLogContent, opts.stepNumber)
 	if err != nil {
+		if opts.printStep {
+			return err
+		}
 		if opts.verbose {
 			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Could not extract step %d output: %v", opts.stepNumber, err)))
 		}
@@ -93,12 +133,18 @@ func extractRequestedStepOutput(opts auditJobRunOptions, jobLogContent string) e
 	if opts.verbose {
 		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("Step %d output saved to %s", opts.stepNumber, stepLogPath)))
 	}
+	if opts.printStep {
+		return printAuditStepOutput(opts, strconv.Itoa(opts.stepNumber), stepOutput)
+	}
 	return nil
 }
 
 func extractFirstFailingStepOutput(opts auditJobRunOptions, jobLogContent string) error {
 	failingStepNum, failingStepOutput := findFirstFailingStep(jobLogContent)
 	if failingStepNum == 0 {
+		if opts.printStep {
+			return fmt.Errorf("no failing step found in job %d", opts.jobID)
+		}
 		if opts.verbose {
 			fmt.Fprintln(os.Stderr, console.FormatInfoMessage("No failing steps found in job"))
 		}
@@ -111,6 +157,9 @@ func extractFirstFailingStepOutput(opts auditJobRunOptions, jobLogContent string
 	if opts.verbose {
 		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("First failing step %d output saved to %s", failingStepNum, stepLogPath)))
 	}
+	if opts.printStep {
+		return printAuditStepOutput(opts, strconv.Itoa(failingStepNum), failingStepOutput)
+	}
 	return nil
 }
 
@@ -122,6 +171,10 @@ func renderAuditJobSummary(opts auditJobRunOptions, jobLogPath string) {
 	fmt.Fprintln(os.Stderr, console.FormatSuccessMessage("Job audit complete. Logs saved to "+absOutputDir))
 	fmt.Fprintln(os.Stderr, console.FormatInfoMessage("\nDownloaded files:"))
 	fmt.Fprintf(os.Stderr, "  - %s (full job log)\n", jobLogPath)
+	if opts.stepName != "" {
+		fmt.Fprintf(os.Stderr, "  - %s (step %q output)\n", filepath.Join(opts.outputDir, fmt.Sprintf("job-%d-step-%s.log", opts.jobID, namedStepFileSuffix(opts.stepName))), opts.stepName)
+		return
+	}
 	if opts.stepNumber > 0 {
 		renderRequestedStepSummary(opts)
 		return
@@ -204,10 +257,7 @@ func findFirstFailingStep(jobLog string) (int, string) {
 			stepOutput = append(stepOutput, line)
 
 			// Detect failure indicators
-			if strings.Contains(line, "##[error]") ||
-				strings.Contains(line, "Error:") ||
-				strings.Contains(line, "FAILED") ||
-				strings.Contains(line, "exit code") && !strings.Contains(line, "exit code 0") {
+			if isStepFailureLine(line) {
 				foundFailure = true
 			}
 		}
@@ -0,0 +1,173 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/styles"
+	"github.com/github/gh-aw/pkg/tty"
+	"github.com/github/gh-aw/pkg/workflow"
+)
+
+var auditJobSelectLog = logger.New("cli:audit_job_select")
+
+// auditJobRef identifies a job of a workflow run.
+type auditJobRef struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	Conclusion string `json:"conclusion"`
+}
+
+// auditStepResult is the --json output of a targeted step extraction.
+type auditStepResult struct {
+	RunID      int64    `json:"run_id"`
+	JobID      int64    `json:"job_id"`
+	Step       string   `json:"step"`
+	Output     string   `json:"output"`
+	ErrorLines []string `json:"error_lines,omitempty"`
+}
+
+// resolveAuditJobID resolves --job (a job name or numeric ID) or --failing to a job ID.
+func resolveAuditJobID(opts auditJobRunOptions) (int64, error) {
+	if id, err := strconv.ParseInt(opts.jobName, 10, 64); err == nil && id > 0 {
+		return id, nil
+	}
+	jobs, err := fetchAuditRunJobs(opts)
+	if err != nil {
+		return 0, err
+	}
+	job, err := selectAuditJob(jobs, opts.jobName, opts.failing)
+	if err != nil {
+		return 0, err
+	}
+	auditJobSelectLog.Printf("Selected job %q (id=%d, conclusion=%s)", job.Name, job.ID, job.Conclusion)
+	return job.ID, nil
+}
+
+// fetchAuditRunJobs lists the jobs of a workflow run.
+func fetchAuditRunJobs(opts auditJobRunOptions) ([]auditJobRef, error) {
+	repoPath := "{owner}/{repo}"
+	if opts.owner != "" && opts.repo != "" {
+		repoPath = opts.owner + "/" + opts.repo
+	}
+	cmd := workflow.ExecGH("api", "--paginate",
+		fmt.Sprintf("repos/%s/actions/runs/%d/jobs", repoPath, opts.runID),
+		"--jq", `.jobs[] | {id: .id, name: .name, conclusion: (.conclusion // "")}`)
+	workflow.SetGHHostEnv(cmd, opts.hostname)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs of run %d: %w\nOutput: %s", opts.runID, err, string(output))
+	}
+	var jobs []auditJobRef
+	for line := range strings.SplitSeq(strings.TrimSpace(string(output)), "\n") {
+		var job auditJobRef
+		if err := json.Unmarshal([]byte(line), &job); err != nil {
+			auditJobSelectLog.Printf("Skipping unparseable job entry: %q", line)
+			continue
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+// selectAuditJob picks the job named name (case-insensitive), or the first failed job
+// when name is empty and failing is set.
+func selectAuditJob(jobs []auditJobRef, name string, failing bool) (auditJobRef, error) {
+	names := make([]string, 0, len(jobs))
+	for _, job := range jobs {
+		names = append(names, job.Name)
+		if name != "" && strings.EqualFold(job.Name, name) {
+			return job, nil
+		}
+		if name == "" && failing && isFailureConclusion(job.Conclusion) {
+			return job, nil
+		}
+	}
+	if name == "" {
+		return auditJobRef{}, errors.New(console.FormatErrorWithSuggestions(
+			"no failed job found in this run",
+			[]string{"Select a job explicitly with --job <name>; jobs in this run: " + strings.Join(names, ", ")},
+		))
+	}
+	return auditJobRef{}, errors.New(console.FormatErrorWithSuggestions(
+		fmt.Sprintf("job %q not found in this run", name),
+		[]string{"Jobs in this run: " + strings.Join(names, ", ")},
+	))
+}
+
+// extractNamedStepOutput extracts the output of the step named stepName from a job log
+// downloaded with `gh run view --log`, whose lines are "<job>\t<step>\t<output>".
+func extractNamedStepOutput(jobLog, stepName string) (string, error) {
+	var stepOutput []string
+	var stepNames []string
+	seen := make(map[string]struct{})
+	for line := range strings.SplitSeq(jobLog, "\n") {
+		parts := strings.SplitN(line, "\t", 3)
+		if len(parts) < 3 {
+			continue
+		}
+		name := strings.TrimSpace(parts[1])
+		if _, ok := seen[name]; !ok {
+			seen[name] = struct{}{}
+			stepNames = append(stepNames, name)
+		}
+		if strings.EqualFold(name, stepName) {
+			stepOutput = append(stepOutput, parts[2])
+		}
+	}
+	if len(stepOutput) == 0 {
+		return "", fmt.Errorf("step %q not found in job logs; steps in this job: %s", stepName, strings.Join(stepNames, ", "))
+	}
+	auditJobSelectLog.Printf("Extracted %d lines for step %q", len(stepOutput), stepName)
+	return strings.Join(stepOutput, "\n"), nil
+}
+
+// isStepFailureLine reports whether a job log line carries an error marker.
+func isStepFailureLine(line string) bool {
+	return strings.Contains(line, "##[error]") ||
+		strings.Contains(line, "Error:") ||
+		strings.Contains(line, "FAILED") ||
+		strings.Contains(line, "exit code") && !strings.Contains(line, "exit code 0")
+}
+
+// highlightStepErrors renders lines with error markers in the error style.
+func highlightStepErrors(output string, colorize bool) string {
+	if !colorize {
+		return output
+	}
+	lines := strings.Split(output, "\n")
+	for i, line := range lines {
+		if isStepFailureLine(line) {
+			lines[i] = styles.Error.Render(line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// printAuditStepOutput prints an extracted step to stdout: as JSON with --json, otherwise
+// as text with error markers highlighted on a terminal.
+func printAuditStepOutput(opts auditJobRunOptions, step, output string) error {
+	if opts.jsonOutput {
+		result := auditStepResult{RunID: opts.runID, JobID: opts.jobID, Step: step, Output: output}
+		for line := range strings.SplitSeq(output, "\n") {
+			if isStepFailureLine(line) {
+				result.ErrorLines = append(result.ErrorLines, line)
+			}
+		}
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal step output: %w", err)
+		}
+		fmt.Fprintln(os.Stdout, string(data))
+		return nil
+	}
+	fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Job %d, step %s:", opts.jobID, step)))
+	fmt.Fprintln(os.Stdout, highlightStepErrors(output, tty.IsStdoutTerminal()))
+	return nil
+}
@@ -0,0 +1,116 @@
+//go:build !integration
+
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectAuditJob(t *testing.T) {
+	jobs := []auditJobRef{
+		{ID: 1, Name: "activation", Conclusion: "success"},
+		{ID: 2, Name: "agent", Conclusion: "failure"},
+		{ID: 3, Name: "safe_outputs", Conclusion: "skipped"},
+	}
+
+	job, err := selectAuditJob(jobs, "Agent", false)
+	require.NoError(t, err, "job name should match case-insensitively")
+	assert.Equal(t, int64(2), job.ID, "should select the agent job")
+
+	job, err = selectAuditJob(jobs, "", true)
+	require.NoError(t, err, "first failed job should be selected")
+	assert.Equal(t, int64(2), job.ID, "should select the first failed job")
+
+	_, err = selectAuditJob(jobs, "detection", false)
+	require.Error(t, err, "unknown job should be rejected")
+	assert.Contains(t, err.Error(), "activation, agent, safe_outputs", "error should list the run's jobs")
+
+	_, err = selectAuditJob(jobs[:1], "", true)
+	require.Error(t, err, "run without failed jobs should be rejected")
+	assert.Contains(t, err.Error(), "no failed job found", "error should explain that no job failed")
+}
+
+func TestExtractNamedStepOutput(t *testing.T) {
+	jobLog := "agent\tSet up job\t2026-01-01T00:00:00Z Current runner version: '2.330.0'\n" +
+		"agent\tExecute agent\t2026-01-01T00:00:01Z Starting agent\n" +
+		"agent\tExecute agent\t2026-01-01T00:00:02Z ##[error]Process completed with exit code 1.\n" +
+		"agent\tPost checkout\t2026-01-01T00:00:03Z Cleaning up\n"
+
+	output, err := extractNamedStepOutput(jobLog, "execute agent")
+	require.NoError(t, err, "step name should match case-insensitively")
+	assert.Equal(t, "2026-01-01T00:00:01Z Starting agent\n2026-01-01T00:00:02Z ##[error]Process completed with exit code 1.", output, "should extract only the step's lines")
+
+	_, err = extractNamedStepOutput(jobLog, "Run tests")
+	require.Error(t, err, "unknown step should be rejected")
+	assert.Contains(t, err.Error(), "Set up job, Execute agent, Post checkout", "error should list the job's steps")
+}
+
+func TestHighlightStepErrors(t *testing.T) {
+	output := "Starting agent\n##[error]Process completed with exit code 1."
+	assert.Equal(t, output, highlightStepErrors(output, false), "output should be unchanged without color")
+	highlighted := highlightStepErrors(output, true)
+	assert.Contains(t, highlighted, "Starting agent\n", "lines without errors should be unchanged")
+	assert.Contains(t, highlighted, "Process completed with exit code 1.", "error lines should be kept")
+}
+
+func TestApplyAuditStepFlags(t *testing.T) {
+	tests := []struct {
+		name        string
+		urlJobID    int64
+		urlStep     int
+		opts        auditCommandOptions
+		expected    AuditOptions
+		errContains string
+	}{
+		{
+			name:     "no flags keeps URL components",
+			urlJobID: 9, urlStep: 7,
+			expected: AuditOptions{JobID: 9, StepNumber: 7},
+		},
+		{
+			name:     "job and step names",
+			opts:     auditCommandOptions{jobFlag: "agent", stepFlag: "Execute agent"},
+			expected: AuditOptions{JobName: "agent", StepName: "Execute agent", PrintStep: true},
+		},
+		{
+			name:     "numeric step overrides URL step",
+			urlJobID: 9, urlStep: 7,
+			opts:     auditCommandOptions{stepFlag: "3"},
+			expected: AuditOptions{JobID: 9, StepNumber: 3, PrintStep: true},
+		},
+		{
+			name:     "job flag alone saves logs without printing",
+			urlJobID: 9,
+			opts:     auditCommandOptions{jobFlag: "agent"},
+			expected: AuditOptions{JobName: "agent"},
+		},
+		{
+			name:     "failing ignores URL step",
+			urlJobID: 9, urlStep: 7,
+			opts:     auditCommandOptions{failing: true},
+			expected: AuditOptions{JobID: 9, FailingStep: true, PrintStep: true},
+		},
+		{
+			name:        "step without job",
+			opts:        auditCommandOptions{stepFlag: "Execute agent"},
+			errContains: "--step requires --job or a job URL",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auditOpts := AuditOptions{JobID: tt.urlJobID, StepNumber: tt.urlStep}
+			err := applyAuditStepFlags(&auditOpts, tt.opts)
+			if tt.errContains != "" {
+				require.Error(t, err, "flags should be rejected")
+				assert.Contains(t, err.Error(), tt.errContains, "error should explain the rejection")
+				return
+			}
+			require.NoError(t, err, "flags should be accepted")
+			assert.Equal(t, tt.expected, auditOpts, "audit options should reflect the flags")
+		})
+	}
+}
@@ -0,0 +1,514 @@
+// This file builds the MCP diagnostics section of the audit report.
+// It combines MCP server failures from the agent log with protocol traces from
+// rpc-messages.jsonl (or gateway.jsonl) to report per-server startup time, the tools
+// each server registered, per-tool call latency distributions, and raw JSON-RPC errors.
+
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/timeutil"
+	"github.com/github/gh-aw/pkg/tty"
+)
+
+var auditMCPDiagnosticsLog = logger.New("cli:audit_mcp_diagnostics")
+
+const (
+	// maxMCPProtocolErrors caps the number of raw JSON-RPC errors kept in the report.
+	maxMCPProtocolErrors = 50
+	// maxMCPErrorPayloadLength caps the length of each raw JSON-RPC error payload.
+	maxMCPErrorPayloadLength = 1000
+)
+
+// MCPDiagnostics is the MCP deep-dive section of the audit report
+type MCPDiagnostics struct {
+	Servers       []MCPServerDiagnostics `json:"servers,omitempty"`
+	ToolLatencies []MCPToolLatency       `json:"tool_latencies,omitempty"`
+	Errors        []MCPProtocolError     `json:"errors,omitempty"`
+	Source        string                 `json:"source,omitempty"` // Trace file the diagnostics were read from
+}
+
+// MCPServerDiagnostics describes the startup and tool registration of a single MCP server
+type MCPServerDiagnostics struct {
+	ServerName      string   `json:"server_name" console:"header:Server"`
+	Status          string   `json:"status" console:"header:Status"`
+	StartupTime     string   `json:"startup_time,omitempty" console:"header:Startup,omitempty"`
+	StartupMs       float64  `json:"startup_ms,omitempty" console:"-"`
+	ToolCount       int      `json:"tool_count" console:"header:Tools"`
+	RegisteredTools []string `json:"registered_tools,omitempty" console:"-"`
+}
+
+// MCPToolLatency is the call latency distribution of a single MCP tool
+type MCPToolLatency struct {
+	ServerName string  `json:"server_name" console:"header:Server"`
+	ToolName   string  `json:"tool_name" console:"header:Tool"`
+	Calls      int     `json:"calls" console:"header:Calls"`
+	Errors     int     `json:"errors,omitempty" console:"header:Errors,omitempty"`
+	MinMs      float64 `json:"min_ms"`
+	P50Ms      float64 `json:"p50_ms"`
+	P90Ms      float64 `json:"p90_ms"`
+	P99Ms      float64 `json:"p99_ms"`
+	MaxMs      float64 `json:"max_ms"`
+}
+
+// MCPProtocolError is a JSON-RPC error returned by an MCP server, with its raw payload
+type MCPProtocolError struct {
+	Timestamp  string `json:"timestamp,omitempty"`
+	ServerName string `json:"server_name,omitempty"`
+	Method     string `json:"method,omitempty"`
+	ToolName   string `json:"tool_name,omitempty"`
+	Code       int    `json:"code,omitempty"`
+	Message    string `json:"message,omitempty"`
+	Payload    string `json:"payload"`
+	Source     string `json:"source"` // "rpc-messages", "gateway", or "agent-log"
+}
+
+// mcpDiagnosticsBuilder accumulates diagnostics while trace files are scanned
+type mcpDiagnosticsBuilder struct {
+	servers   map[string]*MCPServerDiagnostics
+	latencies map[string][]float64 // "<server>/<tool>" -> call durations in ms
+	toolErrs  map[string]int       // "<server>/<tool>" -> error count
+	errors    []MCPProtocolError
+}
+
+// mcpTracePending tracks an in-flight JSON-RPC request in rpc-messages.jsonl
+type mcpTracePending struct {
+	method    string
+	toolName  string
+	timestamp time.Time
+}
+
+// mcpTraceResponse is the subset of a JSON-RPC response needed for diagnostics
+type mcpTraceResponse struct {
+	ID     any             `json:"id"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  json.RawMessage `json:"error,omitempty"`
+}
+
+// buildMCPDiagnostics builds the MCP diagnostics section from the run's logs directory.
+// Returns nil when the run has no MCP traces and no MCP failures.
+func buildMCPDiagnostics(logsPath string, mcpFailures []MCPFailureReport) *MCPDiagnostics {
+	b := &mcpDiagnosticsBuilder{
+		servers:   make(map[string]*MCPServerDiagnostics),
+		latencies: make(map[string][]float64),
+		toolErrs:  make(map[string]int),
+	}
+
+	var source string
+	if logsPath != "" {
+		if rpcPath := findRPCMessagesPath(logsPath); rpcPath != "" {
+			source = rpcPath
+			if err := b.scanRPCMessages(rpcPath); err != nil {
+				auditMCPDiagnosticsLog.Printf("Failed to scan %s: %v", rpcPath, err)
+			}
+		} else if gatewayPath := findGatewayJSONLPath(logsPath); gatewayPath != "" {
+			source = gatewayPath
+			if err := b.scanGatewayLog(gatewayPath); err != nil {
+				auditMCPDiagnosticsLog.Printf("Failed to scan %s: %v", gatewayPath, err)
+			}
+		}
+		if agentLogPath := findAgentStdioLogPath(logsPath); agentLogPath != "" {
+			b.scanAgentLog(agentLogPath)
+		}
+	}
+
+	for _, failure := range mcpFailures {
+		b.server(failure.ServerName).Status = failure.Status
+	}
+
+	if len(b.servers) == 0 && len(b.latencies) == 0 && len(b.errors) == 0 {
+		return nil
+	}
+
+	diagnostics := &MCPDiagnostics{
+		Servers:       b.serverList(),
+		ToolLatencies: b.latencyList(),
+		Errors:        b.errors,
+		Source:        source,
+	}
+	auditMCPDiagnosticsLog.Printf("Built MCP diagnostics: servers=%d, tools=%d, errors=%d",
+		len(diagnostics.Servers), len(diagnostics.ToolLatencies), len(diagnostics.Errors))
+	return diagnostics
+}
+
+func (b *mcpDiagnosticsBuilder) server(name string) *MCPServerDiagnostics {
+	server, ok := b.servers[name]
+	if !ok {
+		server = &MCPServerDiagnostics{ServerName: name, Status: "ok"}
+		b.servers[name] = server
+	}
+	return server
+}
+
+func (b *mcpDiagnosticsBuilder) addError(protocolErr MCPProtocolError) {
+	if len(b.errors) >= maxMCPProtocolErrors {
+		return
+	}
+	if len(protocolErr.Payload) > maxMCPErrorPayloadLength {
+		protocolErr.Payload = protocolErr.Payload[:maxMCPErrorPayloadLength] + "…"
+	}
+	b.errors = append(b.errors, protocolErr)
+}
+
+// scanRPCMessages pairs requests and responses in rpc-messages.jsonl to measure
+// initialize round trips (startup), collect tools/list registrations, time tool
+// calls, and keep raw JSON-RPC error payloads.
+func (b *mcpDiagnosticsBuilder) scanRPCMessages(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open rpc-messages.jsonl: %w", err)
+	}
+	defer file.Close()
+
+	pending := make(map[string]*mcpTracePending)
+	scanner := bufio.NewScanner(file)
+	buf := make([]byte, maxScannerBufferSize)
+	scanner.Buffer(buf, maxScannerBufferSize)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var entry RPCMessageEntry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil || entry.ServerID == "" {
+			continue
+		}
+		timestamp, _ := time.Parse(time.RFC3339Nano, entry.Timestamp)
+
+		switch {
+		case entry.Direction == "OUT" && entry.Type == "REQUEST":
+			var req rpcRequestPayload
+			if err := json.Unmarshal(entry.Payload, &req); err != nil || req.ID == nil {
+				continue
+			}
+			b.server(entry.ServerID)
+			p := &mcpTracePending{method: req.Method, timestamp: timestamp}
+			if req.Method == "tools/call" {
+				var params rpcToolCallParams
+				if err := json.Unmarshal(req.Params, &params); err == nil {
+					p.toolName = params.Name
+				}
+			}
+			pending[fmt.Sprintf("%s/%v", entry.ServerID, req.ID)] = p
+
+		case entry.Direction == "IN" && entry.Type == "RESPONSE":
+			var resp mcpTraceResponse
+			if err := json.Unmarshal(entry.Payload, &resp); err != nil || resp.ID == nil {
+				continue
+			}
+			key := fmt.Sprintf("%s/%v", entry.ServerID, resp.ID)
+			req, ok := pending[key]
+			if !ok {
+				continue
+			}
+			delete(pending, key)
+			b.recordRPCResponse(entry, req, resp, timestamp)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("error reading rpc-messages.jsonl: %w", err)
+	}
+	return nil
+}
+
+func (b *mcpDiagnosticsBuilder) recordRPCResponse(entry RPCMessageEntry, req *mcpTracePending, resp mcpTraceResponse, timestamp time.Time) {
+	server := b.server(entry.ServerID)
+	durationMs := -1.0
+	if !req.timestamp.IsZero() && !timestamp.IsZero() {
+		durationMs = float64(timestamp.Sub(req.timestamp).Milliseconds())
+	}
+
+	if len(resp.Error) > 0 && string(resp.Error) != "null" {
+		var rpcErr rpcError
+		_ = json.Unmarshal(resp.Error, &rpcErr)
+		b.addError(MCPProtocolError{
+			Timestamp:  entry.Timestamp,
+			ServerName: entry.ServerID,
+			Method:     req.method,
+			ToolName:   req.toolName,
+			Code:       rpcErr.Code,
+			Message:    rpcErr.Message,
+			Payload:    string(resp.Error),
+			Source:     "rpc-messages",
+		})
+		if req.method == "initialize" {
+			server.Status = "error"
+		}
+	}
+
+	switch req.method {
+	case "initialize":
+		if durationMs >= 0 {
+			server.StartupMs = durationMs
+			server.StartupTime = formatMCPLatency(durationMs)
+		}
+	case "tools/list":
+		var result struct {
+			Tools []struct {
+				Name string `json:"name"`
+			} `json:"tools"`
+		}
+		if err := json.Unmarshal(resp.Result, &result); err == nil {
+			for _, tool := range result.Tools {
+				if tool.Name != "" && !slices.Contains(server.RegisteredTools, tool.Name) {
+					server.RegisteredTools = append(server.RegisteredTools, tool.Name)
+				}
+			}
+			server.ToolCount = len(server.RegisteredTools)
+		}
+	case "tools/call":
+		if req.toolName == "" {
+			return
+		}
+		key := entry.ServerID + "/" + req.toolName
+		if durationMs >= 0 {
+			b.latencies[key] = append(b.latencies[key], durationMs)
+		}
+		if len(resp.Error) > 0 && string(resp.Error) != "null" {
+			b.toolErrs[key]++
+		}
+	}
+}
+
+// scanGatewayLog reads gateway.jsonl, which records durations and error strings but
+// not tools/list results, so registered tools are unavailable from this source.
+func (b *mcpDiagnosticsBuilder) scanGatewayLog(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open gateway.jsonl: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	buf := make([]byte, maxScannerBufferSize)
+	scanner.Buffer(buf, maxScannerBufferSize)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var entry GatewayLogEntry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil || entry.ServerName == "" {
+			continue
+		}
+		server := b.server(entry.ServerName)
+		failed := entry.Status == "error" || entry.Error != "" || entry.Level == "error"
+
+		if entry.Method == "initialize" && entry.Duration > 0 {
+			server.StartupMs = entry.Duration
+			server.StartupTime = formatMCPLatency(entry.Duration)
+		}
+		if entry.ToolName != "" && (entry.Event == "request" || entry.Event == "tool_call" || entry.Event == "rpc_call") {
+			key := entry.ServerName + "/" + entry.ToolName
+			if entry.Duration > 0 {
+				b.latencies[key] = append(b.latencies[key], entry.Duration)
+			}
+			if failed {
+				b.toolErrs[key]++
+			}
+		}
+		if failed {
+			b.addError(MCPProtocolError{
+				Timestamp:  entry.Timestamp,
+				ServerName: entry.ServerName,
+				Method:     entry.Method,
+				ToolName:   entry.ToolName,
+				Message:    entry.Error,
+				Payload:    line,
+				Source:     "gateway",
+			})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("error reading gateway.jsonl: %w", err)
+	}
+	return nil
+}
+
+// scanAgentLog extracts JSON-RPC error objects printed by the engine or MCP servers
+// in agent-stdio.log, for example when a server fails before the gateway traces it.
+func (b *mcpDiagnosticsBuilder) scanAgentLog(path string) {
+	file, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	buf := make([]byte, maxScannerBufferSize)
+	scanner.Buffer(buf, maxScannerBufferSize)
+	for scanner.Scan() {
+		line := scanner.Text()
+		start := strings.Index(line, `{"jsonrpc"`)
+		if start == -1 || !strings.Contains(line[start:], `"error"`) {
+			continue
+		}
+		var resp mcpTraceResponse
+		if err := json.NewDecoder(strings.NewReader(line[start:])).Decode(&resp); err != nil {
+			continue
+		}
+		if len(resp.Error) == 0 || string(resp.Error) == "null" {
+			continue
+		}
+		var rpcErr rpcError
+		_ = json.Unmarshal(resp.Error, &rpcErr)
+		b.addError(MCPProtocolError{
+			Code:    rpcErr.Code,
+			Message: rpcErr.Message,
+			Payload: string(resp.Error),
+			Source:  "agent-log",
+		})
+	}
+}
+
+func (b *mcpDiagnosticsBuilder) serverList() []MCPServerDiagnostics {
+	servers := make([]MCPServerDiagnostics, 0, len(b.servers))
+	for _, server := range b.servers {
+		sort.Strings(server.RegisteredTools)
+		servers = append(servers, *server)
+	}
+	sort.Slice(servers, func(i, j int) bool { return servers[i].ServerName < servers[j].ServerName })
+	return servers
+}
+
+func (b *mcpDiagnosticsBuilder) latencyList() []MCPToolLatency {
+	keys := make([]string, 0, len(b.latencies))
+	for key := range b.latencies {
+		keys = append(keys, key)
+	}
+	for key := range b.toolErrs {
+		if _, ok := b.latencies[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	latencies := make([]MCPToolLatency, 0, len(keys))
+	for _, key := range keys {
+		serverName, toolName, _ := strings.Cut(key, "/")
+		durations := slices.Clone(b.latencies[key])
+		sort.Float64s(durations)
+		latency := MCPToolLatency{
+			ServerName: serverName,
+			ToolName:   toolName,
+			Calls:      max(len(durations), b.toolErrs[key]),
+			Errors:     b.toolErrs[key],
+		}
+		if len(durations) > 0 {
+			latency.MinMs = durations[0]
+			latency.P50Ms = latencyPercentile(durations, 50)
+			latency.P90Ms = latencyPercentile(durations, 90)
+			latency.P99Ms = latencyPercentile(durations, 99)
+			latency.MaxMs = durations[len(durations)-1]
+		}
+		latencies = append(latencies, latency)
+	}
+	return latencies
+}
+
+// latencyPercentile returns the nearest-rank percentile of sorted durations
+func latencyPercentile(sorted []float64, percentile float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(percentile / 100 * float64(len(sorted))))
+	return sorted[min(max(rank, 1), len(sorted))-1]
+}
+
+func formatMCPLatency(ms float64) string {
+	return timeutil.FormatDuration(time.Duration(ms * float64(time.Millisecond)))
+}
+
+// renderConsoleMCPDiagnostics renders a one-line summary of the MCP diagnostics in the compact report
+func renderConsoleMCPDiagnostics(diagnostics *MCPDiagnostics) {
+	if diagnostics == nil {
+		return
+	}
+	registered := 0
+	for _, server := range diagnostics.Servers {
+		registered += server.ToolCount
+	}
+	fmt.Fprintf(os.Stderr, "  mcp_diagnostics: servers=%d registered_tools=%d rpc_errors=%d (use --mcp for details)\n",
+		len(diagnostics.Servers), registered, len(diagnostics.Errors))
+}
+
+// renderMCPDiagnosticsReport renders the full MCP diagnostics section, used by `audit --mcp`
+func renderMCPDiagnosticsReport(diagnostics *MCPDiagnostics) {
+	if diagnostics == nil {
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage("No MCP traces or MCP server failures found for this run"))
+		return
+	}
+
+	if len(diagnostics.Servers) > 0 {
+		rows := make([][]string, 0, len(diagnostics.Servers))
+		for _, server := range diagnostics.Servers {
+			rows = append(rows, []string{server.ServerName, server.Status, stringOrDash(server.StartupTime), fmt.Sprintf("%d", server.ToolCount)})
+		}
+		fmt.Fprint(os.Stderr, console.RenderTable(console.TableConfig{
+			Title:   "MCP Servers",
+			Headers: []string{"Server", "Status", "Startup", "Tools"},
+			Rows:    rows,
+			TTYFunc: tty.IsStderrTerminal,
+		}))
+		for _, server := range diagnostics.Servers {
+			if len(server.RegisteredTools) > 0 {
+				fmt.Fprintf(os.Stderr, "  %s tools: %s\n", server.ServerName, strings.Join(server.RegisteredTools, ", "))
+			}
+		}
+	}
+
+	if len(diagnostics.ToolLatencies) > 0 {
+		rows := make([][]string, 0, len(diagnostics.ToolLatencies))
+		for _, latency := range diagnostics.ToolLatencies {
+			rows = append(rows, []string{
+				latency.ServerName,
+				latency.ToolName,
+				fmt.Sprintf("%d", latency.Calls),
+				fmt.Sprintf("%d", latency.Errors),
+				formatMCPLatency(latency.MinMs),
+				formatMCPLatency(latency.P50Ms),
+				formatMCPLatency(latency.P90Ms),
+				formatMCPLatency(latency.P99Ms),
+				formatMCPLatency(latency.MaxMs),
+			})
+		}
+		fmt.Fprint(os.Stderr, console.RenderTable(console.TableConfig{
+			Title:   "MCP Tool Latency",
+			Headers: []string{"Server", "Tool", "Calls", "Errors", "Min", "P50", "P90", "P99", "Max"},
+			Rows:    rows,
+			TTYFunc: tty.IsStderrTerminal,
+		}))
+	}
+
+	if len(diagnostics.Errors) > 0 {
+		fmt.Fprintln(os.Stderr, "MCP JSON-RPC Errors")
+		for _, protocolErr := range diagnostics.Errors {
+			target := protocolErr.ServerName
+			if protocolErr.ToolName != "" {
+				target += "/" + protocolErr.ToolName
+			} else if protocolErr.Method != "" {
+				target += " " + protocolErr.Method
+			}
+			fmt.Fprintf(os.Stderr, "  [%s] %s %s\n", protocolErr.Source, strings.TrimSpace(target), protocolErr.Timestamp)
+			fmt.Fprintf(os.Stderr, "    %s\n", protocolErr.Payload)
+		}
+	}
+}
+
+func stringOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
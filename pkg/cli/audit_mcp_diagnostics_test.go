@@ -0,0 +1,101 @@
+//go:build !integration
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildMCPDiagnosticsFromRPCMessages(t *testing.T) {
+	logsDir := t.TempDir()
+	mcpLogsDir := filepath.Join(logsDir, "mcp-logs")
+	require.NoError(t, os.MkdirAll(mcpLogsDir, 0755), "failed to create mcp-logs dir")
+
+	rpcMessages := strings.Join([]string{
+		`{"timestamp":"2026-01-01T00:00:00.000Z","direction":"OUT","type":"REQUEST","server_id":"github","payload":{"jsonrpc":"2.0","id":1,"method":"initialize"}}`,
+		`{"timestamp":"2026-01-01T00:00:01.250Z","direction":"IN","type":"RESPONSE","server_id":"github","payload":{"jsonrpc":"2.0","id":1,"result":{}}}`,
+		`{"timestamp":"2026-01-01T00:00:02.000Z","direction":"OUT","type":"REQUEST","server_id":"github","payload":{"jsonrpc":"2.0","id":2,"method":"tools/list"}}`,
+		`{"timestamp":"2026-01-01T00:00:02.100Z","direction":"IN","type":"RESPONSE","server_id":"github","payload":{"jsonrpc":"2.0","id":2,"result":{"tools":[{"name":"list_issues"},{"name":"get_issue"}]}}}`,
+		`{"timestamp":"2026-01-01T00:00:03.000Z","direction":"OUT","type":"REQUEST","server_id":"github","payload":{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"get_issue"}}}`,
+		`{"timestamp":"2026-01-01T00:00:03.100Z","direction":"IN","type":"RESPONSE","server_id":"github","payload":{"jsonrpc":"2.0","id":3,"result":{}}}`,
+		`{"timestamp":"2026-01-01T00:00:04.000Z","direction":"OUT","type":"REQUEST","server_id":"github","payload":{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"get_issue"}}}`,
+		`{"timestamp":"2026-01-01T00:00:04.300Z","direction":"IN","type":"RESPONSE","server_id":"github","payload":{"jsonrpc":"2.0","id":4,"error":{"code":-32603,"message":"upstream timeout"}}}`,
+	}, "\n")
+	require.NoError(t, os.WriteFile(filepath.Join(mcpLogsDir, "rpc-messages.jsonl"), []byte(rpcMessages), 0644), "failed to write rpc-messages.jsonl")
+
+	diagnostics := buildMCPDiagnostics(logsDir, []MCPFailureReport{{ServerName: "playwright", Status: "failed"}})
+	require.NotNil(t, diagnostics, "diagnostics should be built from rpc messages")
+
+	require.Len(t, diagnostics.Servers, 2, "should include traced and failed servers")
+	github := diagnostics.Servers[0]
+	assert.Equal(t, "github", github.ServerName, "servers should be sorted by name")
+	assert.Equal(t, "ok", github.Status, "traced server should be ok")
+	assert.InDelta(t, 1250, github.StartupMs, 0.001, "startup should be the initialize round trip")
+	assert.Equal(t, []string{"get_issue", "list_issues"}, github.RegisteredTools, "registered tools should come from tools/list")
+	assert.Equal(t, 2, github.ToolCount, "tool count should match registrations")
+	assert.Equal(t, "failed", diagnostics.Servers[1].Status, "failed server should keep its failure status")
+
+	require.Len(t, diagnostics.ToolLatencies, 1, "should have one tool latency entry")
+	latency := diagnostics.ToolLatencies[0]
+	assert.Equal(t, "get_issue", latency.ToolName, "latency should be keyed by tool")
+	assert.Equal(t, 2, latency.Calls, "both calls should be counted")
+	assert.Equal(t, 1, latency.Errors, "failed call should be counted")
+	assert.InDelta(t, 100, latency.MinMs, 0.001, "min latency")
+	assert.InDelta(t, 300, latency.MaxMs, 0.001, "max latency")
+	assert.InDelta(t, 100, latency.P50Ms, 0.001, "p50 latency")
+
+	require.Len(t, diagnostics.Errors, 1, "should capture the JSON-RPC error")
+	protocolErr := diagnostics.Errors[0]
+	assert.Equal(t, "rpc-messages", protocolErr.Source, "error source")
+	assert.Equal(t, "get_issue", protocolErr.ToolName, "error should name the tool")
+	assert.Equal(t, -32603, protocolErr.Code, "error code")
+	assert.JSONEq(t, `{"code":-32603,"message":"upstream timeout"}`, protocolErr.Payload, "raw payload should be preserved")
+}
+
+func TestBuildMCPDiagnosticsFromGatewayAndAgentLog(t *testing.T) {
+	logsDir := t.TempDir()
+	gatewayLog := strings.Join([]string{
+		`{"timestamp":"2026-01-01T00:00:00Z","event":"rpc_call","server_name":"safeoutputs","method":"initialize","duration":420}`,
+		`{"timestamp":"2026-01-01T00:00:01Z","event":"tool_call","server_name":"safeoutputs","tool_name":"create_issue","duration":80}`,
+		`{"timestamp":"2026-01-01T00:00:02Z","event":"tool_call","server_name":"safeoutputs","tool_name":"create_issue","duration":120,"status":"error","error":"rate limited"}`,
+	}, "\n")
+	require.NoError(t, os.WriteFile(filepath.Join(logsDir, "gateway.jsonl"), []byte(gatewayLog), 0644), "failed to write gateway.jsonl")
+	agentLog := "starting\n[mcp] response {\"jsonrpc\":\"2.0\",\"id\":7,\"error\":{\"code\":-32000,\"message\":\"Connection closed\"}} trailing\n"
+	require.NoError(t, os.WriteFile(filepath.Join(logsDir, "agent-stdio.log"), []byte(agentLog), 0644), "failed to write agent-stdio.log")
+
+	diagnostics := buildMCPDiagnostics(logsDir, nil)
+	require.NotNil(t, diagnostics, "diagnostics should be built from gateway logs")
+
+	require.Len(t, diagnostics.Servers, 1, "should have one server")
+	assert.InDelta(t, 420, diagnostics.Servers[0].StartupMs, 0.001, "startup should come from the initialize duration")
+	require.Len(t, diagnostics.ToolLatencies, 1, "should have one tool")
+	assert.Equal(t, 2, diagnostics.ToolLatencies[0].Calls, "both calls should be counted")
+	assert.Equal(t, 1, diagnostics.ToolLatencies[0].Errors, "error call should be counted")
+
+	require.Len(t, diagnostics.Errors, 2, "should capture gateway and agent log errors")
+	assert.Equal(t, "gateway", diagnostics.Errors[0].Source, "first error from gateway")
+	assert.Contains(t, diagnostics.Errors[0].Payload, "rate limited", "gateway payload should be the raw entry")
+	assert.Equal(t, "agent-log", diagnostics.Errors[1].Source, "second error from agent log")
+	assert.Equal(t, -32000, diagnostics.Errors[1].Code, "agent log error code")
+	assert.JSONEq(t, `{"code":-32000,"message":"Connection closed"}`, diagnostics.Errors[1].Payload, "agent log payload should be the raw error object")
+}
+
+func TestBuildMCPDiagnosticsEmpty(t *testing.T) {
+	assert.Nil(t, buildMCPDiagnostics(t.TempDir(), nil), "no traces and no failures should produce no section")
+	assert.Nil(t, buildMCPDiagnostics("", nil), "missing logs path should produce no section")
+}
+
+func TestLatencyPercentile(t *testing.T) {
+	sorted := []float64{10, 20, 30, 40, 50, 60, 70, 80, 90, 100}
+	assert.InDelta(t, 50, latencyPercentile(sorted, 50), 0.001, "p50")
+	assert.InDelta(t, 90, latencyPercentile(sorted, 90), 0.001, "p90")
+	assert.InDelta(t, 100, latencyPercentile(sorted, 99), 0.001, "p99")
+	assert.InDelta(t, 7, latencyPercentile([]float64{7}, 50), 0.001, "single value")
+	assert.Zero(t, latencyPercentile(nil, 50), "empty input")
+}
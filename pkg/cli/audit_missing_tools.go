@@ -0,0 +1,319 @@
+package cli
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/sliceutil"
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/spf13/cobra"
+)
+
+var auditMissingToolsLog = logger.New("cli:audit_missing_tools")
+
+// defaultMissingToolsRunLimit is the number of most recent cached runs analyzed by default
+const defaultMissingToolsRunLimit = 50
+
+// Suggestion kinds, one per frontmatter location a missing tool can be enabled in.
+const (
+	missingToolKindGitHubToolset = "github-toolset" // tools.github.toolsets
+	missingToolKindBuiltin       = "builtin"        // a built-in tools: entry such as web-fetch
+	missingToolKindBash          = "bash"           // tools.bash allowlist
+	missingToolKindMCPServer     = "mcp-server"     // mcp-servers.<server>.allowed
+)
+
+// MissingToolsReport ranks the tools agents reported missing across cached runs.
+type MissingToolsReport struct {
+	Workflow        string               `json:"workflow,omitempty"` // Empty when all workflows were analyzed
+	RunsAnalyzed    int                  `json:"runs_analyzed"`
+	RunsWithReports int                  `json:"runs_with_reports"`
+	Tools           []MissingToolRanking `json:"tools"`
+	Snippet         string               `json:"snippet,omitempty"` // Combined frontmatter for every suggestion
+	AnalyzedRunIDs  []int64              `json:"analyzed_run_ids"`
+}
+
+// MissingToolRanking aggregates the reports of one missing tool.
+type MissingToolRanking struct {
+	Tool         string                 `json:"tool"`
+	Runs         int                    `json:"runs"` // Distinct runs that reported the tool
+	Reports      int                    `json:"reports"`
+	RunRate      float64                `json:"run_rate"` // Share of all analyzed runs
+	Workflows    []string               `json:"workflows"`
+	Reason       string                 `json:"reason,omitempty"` // Reason from the most recent report
+	Alternatives string                 `json:"alternatives,omitempty"`
+	RunIDs       []int64                `json:"run_ids"`
+	LastSeen     time.Time              `json:"last_seen,omitzero"`
+	Suggestion   *MissingToolSuggestion `json:"suggestion,omitempty"`
+}
+
+// MissingToolSuggestion is the frontmatter change that makes a missing tool available.
+type MissingToolSuggestion struct {
+	Kind    string `json:"kind"`           // One of the missingToolKind* values
+	Name    string `json:"name"`           // Toolset, built-in tool, command, or MCP server name
+	Tool    string `json:"tool,omitempty"` // MCP tool to allow (mcp-server suggestions only)
+	Snippet string `json:"snippet"`
+}
+
+// NewAuditMissingToolsSubcommand creates the audit missing-tools subcommand.
+func NewAuditMissingToolsSubcommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "missing-tools",
+		Short: "Rank the tools agents report missing across cached runs and suggest tools: config",
+		Long: `Aggregate the missing-tool reports of the most recent cached runs, rank tools by how many
+runs reported them, and print a ready-to-paste frontmatter snippet that enables them.
+
+Suggestions are derived from the tool name: GitHub MCP tools map to the toolset that provides
+them, known names map to built-in tools (web-fetch, web-search, playwright, edit), shell
+commands map to the bash allowlist, and tools of other MCP servers map to mcp-servers allowed
+lists. Tools with no known configuration are listed without a suggestion.
+
+The analysis reads run summaries already downloaded to the logs cache; it does not call the
+GitHub API. Populate the cache first with '` + string(constants.CLIExtensionPrefix) + ` logs'.`,
+		Example: `  ` + string(constants.CLIExtensionPrefix) + ` audit missing-tools                          # All workflows, last 50 cached runs
+  ` + string(constants.CLIExtensionPrefix) + ` audit missing-tools --workflow daily-plan  # A single workflow
+  ` + string(constants.CLIExtensionPrefix) + ` audit missing-tools --last 200 --json      # JSON output for CI integration`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputDir, _ := cmd.Flags().GetString("output")
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			workflowName, _ := cmd.Flags().GetString("workflow")
+			last, _ := cmd.Flags().GetInt("last")
+			if last < 1 {
+				return fmt.Errorf("--last must be at least 1, got %d", last)
+			}
+			return RunAuditMissingTools(workflowName, outputDir, last, jsonOutput)
+		},
+	}
+
+	addOutputFlag(cmd, defaultLogsOutputDir)
+	addJSONFlag(cmd)
+	cmd.Flags().StringP("workflow", "w", "", "Only analyze runs of this workflow (ID, name, or lock file)")
+	cmd.Flags().Int("last", defaultMissingToolsRunLimit, "Number of most recent cached runs to analyze")
+
+	return cmd
+}
+
+// RunAuditMissingTools builds and renders the missing-tools report from the local run cache.
+func RunAuditMissingTools(workflowName, outputDir string, last int, jsonOutput bool) error {
+	auditMissingToolsLog.Printf("Starting missing tools analysis: workflow=%s, dir=%s, last=%d", workflowName, outputDir, last)
+
+	summaries, err := readCachedRunSummaries(outputDir)
+	if err != nil {
+		return err
+	}
+	if workflowName != "" {
+		summaries = sliceutil.Filter(summaries, func(summary *RunSummary) bool {
+			return matchesFlakyWorkflow(summary.Run, workflowName)
+		})
+	}
+	if len(summaries) > last {
+		summaries = summaries[len(summaries)-last:]
+	}
+	if len(summaries) == 0 {
+		if workflowName == "" {
+			return fmt.Errorf("no cached runs found in %s\n\nRun '%s logs' to download recent runs first", outputDir, string(constants.CLIExtensionPrefix))
+		}
+		return fmt.Errorf("no cached runs of workflow %q found in %s\n\nRun '%s logs %s' to download recent runs first", workflowName, outputDir, string(constants.CLIExtensionPrefix), workflowName)
+	}
+
+	report := buildMissingToolsReport(workflowName, summaries)
+	if jsonOutput {
+		return renderMissingToolsReportJSON(report)
+	}
+	renderMissingToolsReportPretty(report)
+	return nil
+}
+
+// buildMissingToolsReport ranks missing tools by the number of runs that reported them.
+// Summaries must be in chronological order.
+func buildMissingToolsReport(workflowName string, summaries []*RunSummary) *MissingToolsReport {
+	report := &MissingToolsReport{
+		Workflow:       workflowName,
+		RunsAnalyzed:   len(summaries),
+		AnalyzedRunIDs: make([]int64, 0, len(summaries)),
+	}
+
+	rankings := make(map[string]*MissingToolRanking)
+	for _, summary := range summaries {
+		report.AnalyzedRunIDs = append(report.AnalyzedRunIDs, summary.RunID)
+		if len(summary.MissingTools) > 0 {
+			report.RunsWithReports++
+		}
+		for _, missing := range summary.MissingTools {
+			name := strings.TrimSpace(missing.Tool)
+			if name == "" {
+				continue
+			}
+			key := strings.ToLower(name)
+			ranking, ok := rankings[key]
+			if !ok {
+				ranking = &MissingToolRanking{Tool: name}
+				rankings[key] = ranking
+			}
+			ranking.Reports++
+			if !slices.Contains(ranking.RunIDs, summary.RunID) {
+				ranking.Runs++
+				ranking.RunIDs = append(ranking.RunIDs, summary.RunID)
+			}
+			ranking.Workflows = sliceutil.MergeUnique(ranking.Workflows, cmp.Or(summary.Run.WorkflowName, missing.WorkflowName))
+			ranking.LastSeen = summary.Run.CreatedAt
+			ranking.Reason = cmp.Or(strings.TrimSpace(missing.Reason), ranking.Reason)
+			ranking.Alternatives = cmp.Or(strings.TrimSpace(missing.Alternatives), ranking.Alternatives)
+		}
+	}
+
+	var suggestions []MissingToolSuggestion
+	for _, ranking := range rankings {
+		ranking.RunRate = float64(ranking.Runs) / float64(report.RunsAnalyzed)
+		ranking.Suggestion = suggestMissingToolConfig(ranking.Tool, ranking.Reason+" "+ranking.Alternatives)
+		report.Tools = append(report.Tools, *ranking)
+	}
+	slices.SortFunc(report.Tools, func(a, b MissingToolRanking) int {
+		return cmp.Or(
+			cmp.Compare(b.Runs, a.Runs),
+			cmp.Compare(b.Reports, a.Reports),
+			b.LastSeen.Compare(a.LastSeen),
+			strings.Compare(a.Tool, b.Tool),
+		)
+	})
+	for _, ranking := range report.Tools {
+		if ranking.Suggestion != nil {
+			suggestions = append(suggestions, *ranking.Suggestion)
+		}
+	}
+	report.Snippet = renderMissingToolsSnippet(suggestions)
+
+	auditMissingToolsLog.Printf("Missing tools report: runs=%d, runs_with_reports=%d, tools=%d, suggestions=%d", report.RunsAnalyzed, report.RunsWithReports, len(report.Tools), len(suggestions))
+	return report
+}
+
+// missingToolBuiltinAliases maps names agents use for built-in capabilities to the tools:
+// key that enables them.
+var missingToolBuiltinAliases = map[string]string{
+	"web-fetch":         "web-fetch",
+	"web_fetch":         "web-fetch",
+	"webfetch":          "web-fetch",
+	"fetch":             "web-fetch",
+	"web-search":        "web-search",
+	"web_search":        "web-search",
+	"websearch":         "web-search",
+	"playwright":        "playwright",
+	"browser":           "playwright",
+	"edit":              "edit",
+	"write":             "edit",
+	"write_file":        "edit",
+	"str_replace":       "edit",
+	"agentic-workflows": "agentic-workflows",
+	"cache-memory":      "cache-memory",
+	"repo-memory":       "repo-memory",
+}
+
+var (
+	// missingToolMCPNamePattern matches fully qualified MCP tool names (mcp__server__tool).
+	missingToolMCPNamePattern = regexp.MustCompile(`^mcp__([\w-]+?)__([\w.-]+)$`)
+	// missingToolBashNamePattern matches explicit shell tool names such as bash:jq or shell(jq).
+	missingToolBashNamePattern = regexp.MustCompile(`^(?:bash|shell)[:(]\s*([^)\s]+)\)?$`)
+	// missingToolCommandPattern matches names that can be executables.
+	missingToolCommandPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9.+-]*$`)
+	// missingToolCommandHint matches reasons that describe a shell command rather than a tool.
+	missingToolCommandHint = regexp.MustCompile(`(?i)\b(command|cli|shell|bash|executable|binary)\b`)
+)
+
+// suggestMissingToolConfig derives the frontmatter that enables a reported tool, or nil when
+// the name does not match any known configuration. context is the report's reason and
+// alternatives, used to recognize shell commands.
+func suggestMissingToolConfig(tool, context string) *MissingToolSuggestion {
+	name := strings.TrimSpace(tool)
+	lower := strings.ToLower(name)
+
+	if builtin, ok := missingToolBuiltinAliases[lower]; ok {
+		return newMissingToolSuggestion(missingToolKindBuiltin, builtin, "")
+	}
+	if m := missingToolBashNamePattern.FindStringSubmatch(name); m != nil {
+		return newMissingToolSuggestion(missingToolKindBash, m[1], "")
+	}
+
+	server, mcpTool := "", name
+	if m := missingToolMCPNamePattern.FindStringSubmatch(name); m != nil {
+		server, mcpTool = m[1], m[2]
+	} else if rest, ok := strings.CutPrefix(lower, "github_"); ok {
+		server, mcpTool = "github", rest
+	} else if rest, ok := strings.CutPrefix(lower, "github-"); ok {
+		server, mcpTool = "github", rest
+	}
+
+	if server == "" || strings.EqualFold(server, "github") {
+		if toolset, ok := workflow.GitHubToolToolset(strings.ToLower(mcpTool)); ok {
+			return newMissingToolSuggestion(missingToolKindGitHubToolset, toolset, "")
+		}
+	}
+	if server != "" {
+		if strings.EqualFold(server, "github") {
+			return nil
+		}
+		return newMissingToolSuggestion(missingToolKindMCPServer, server, mcpTool)
+	}
+	if missingToolCommandPattern.MatchString(name) && missingToolCommandHint.MatchString(context) {
+		return newMissingToolSuggestion(missingToolKindBash, name, "")
+	}
+	return nil
+}
+
+func newMissingToolSuggestion(kind, name, tool string) *MissingToolSuggestion {
+	suggestion := MissingToolSuggestion{Kind: kind, Name: name, Tool: tool}
+	suggestion.Snippet = renderMissingToolsSnippet([]MissingToolSuggestion{suggestion})
+	return &suggestion
+}
+
+// renderMissingToolsSnippet merges suggestions into a single frontmatter fragment. GitHub
+// toolsets keep the default toolsets enabled, since listing toolsets replaces the defaults.
+func renderMissingToolsSnippet(suggestions []MissingToolSuggestion) string {
+	var toolsets, builtins, commands []string
+	mcpServers := make(map[string][]string)
+	for _, suggestion := range suggestions {
+		switch suggestion.Kind {
+		case missingToolKindGitHubToolset:
+			toolsets = sliceutil.MergeUnique(toolsets, suggestion.Name)
+		case missingToolKindBuiltin:
+			builtins = sliceutil.MergeUnique(builtins, suggestion.Name)
+		case missingToolKindBash:
+			commands = sliceutil.MergeUnique(commands, suggestion.Name)
+		case missingToolKindMCPServer:
+			mcpServers[suggestion.Name] = sliceutil.MergeUnique(mcpServers[suggestion.Name], suggestion.Tool)
+		}
+	}
+
+	var b strings.Builder
+	if len(toolsets) > 0 || len(builtins) > 0 || len(commands) > 0 {
+		b.WriteString("tools:\n")
+	}
+	if len(toolsets) > 0 {
+		toolsets = slices.DeleteFunc(toolsets, func(toolset string) bool {
+			return slices.Contains(workflow.DefaultGitHubToolsets, toolset)
+		})
+		fmt.Fprintf(&b, "  github:\n    toolsets: [%s]\n", strings.Join(append([]string{"default"}, toolsets...), ", "))
+	}
+	for _, builtin := range builtins {
+		fmt.Fprintf(&b, "  %s:\n", builtin)
+	}
+	if len(commands) > 0 {
+		quoted := make([]string, 0, len(commands))
+		for _, command := range commands {
+			quoted = append(quoted, fmt.Sprintf("%q", command))
+		}
+		fmt.Fprintf(&b, "  bash: [%s]\n", strings.Join(quoted, ", "))
+	}
+	if len(mcpServers) > 0 {
+		b.WriteString("mcp-servers:\n")
+		for _, server := range sliceutil.SortedKeys(mcpServers) {
+			fmt.Fprintf(&b, "  %s:\n    allowed: [%s]\n", server, strings.Join(mcpServers[server], ", "))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/stringutil"
+)
+
+var auditMissingToolsRenderLog = logger.New("cli:audit_missing_tools_render")
+
+// renderMissingToolsReportJSON outputs the missing-tools report as JSON to stdout.
+func renderMissingToolsReportJSON(report *MissingToolsReport) error {
+	auditMissingToolsRenderLog.Printf("Rendering missing tools report as JSON: runs=%d, tools=%d", report.RunsAnalyzed, len(report.Tools))
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
+
+// renderMissingToolsReportPretty outputs the missing-tools report to stderr.
+func renderMissingToolsReportPretty(report *MissingToolsReport) {
+	auditMissingToolsRenderLog.Printf("Rendering missing tools report as pretty output: runs=%d, tools=%d", report.RunsAnalyzed, len(report.Tools))
+	title := "Audit Report — Missing Tools"
+	if report.Workflow != "" {
+		title += ": " + report.Workflow
+	}
+	fmt.Fprintln(os.Stderr, console.FormatInfoMessage(title))
+	fmt.Fprintln(os.Stderr)
+
+	fmt.Fprintf(os.Stderr, "  Runs analyzed:       %d\n", report.RunsAnalyzed)
+	fmt.Fprintf(os.Stderr, "  Runs with reports:   %d\n", report.RunsWithReports)
+	fmt.Fprintln(os.Stderr)
+
+	if len(report.Tools) == 0 {
+		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage("No missing tools were reported"))
+		return
+	}
+
+	rows := make([][]string, 0, len(report.Tools))
+	for _, tool := range report.Tools {
+		rows = append(rows, []string{
+			fmt.Sprintf("%d (%.0f%%)", tool.Runs, tool.RunRate*100),
+			tool.Tool,
+			formatMissingToolSuggestion(tool.Suggestion),
+			stringutil.Truncate(strings.Join(tool.Workflows, ", "), 40),
+			stringutil.Truncate(tool.Reason, 60),
+		})
+	}
+	fmt.Fprint(os.Stderr, console.RenderTable(console.TableConfig{
+		Title:   "Missing Tools",
+		Headers: []string{"Runs", "Tool", "Suggested Config", "Workflows", "Latest Reason"},
+		Rows:    rows,
+	}))
+	fmt.Fprintln(os.Stderr)
+
+	if report.Snippet == "" {
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage("No known tools: configuration provides these tools; add an MCP server under mcp-servers: that does"))
+		return
+	}
+	fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Add to the workflow frontmatter to enable the suggested tools:"))
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprint(os.Stderr, report.Snippet)
+}
+
+// formatMissingToolSuggestion describes a suggestion in one table cell.
+func formatMissingToolSuggestion(suggestion *MissingToolSuggestion) string {
+	if suggestion == nil {
+		return "-"
+	}
+	switch suggestion.Kind {
+	case missingToolKindGitHubToolset:
+		return "github toolset " + suggestion.Name
+	case missingToolKindBash:
+		return "bash " + suggestion.Name
+	case missingToolKindMCPServer:
+		return "mcp-servers." + suggestion.Name + " allowed"
+	default:
+		return suggestion.Name
+	}
+}
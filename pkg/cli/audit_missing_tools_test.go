@@ -0,0 +1,122 @@
+//go:build !integration
+
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func missingToolsTestSummary(runID int64, workflowName string, createdAt time.Time, tools ...MissingToolReport) *RunSummary {
+	return &RunSummary{
+		RunID: runID,
+		Run: WorkflowRun{
+			DatabaseID:   runID,
+			WorkflowName: workflowName,
+			WorkflowPath: ".github/workflows/" + workflowName + ".lock.yml",
+			Conclusion:   "success",
+			CreatedAt:    createdAt,
+		},
+		MissingTools: tools,
+	}
+}
+
+func TestSuggestMissingToolConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		tool    string
+		context string
+		want    *MissingToolSuggestion
+	}{
+		{name: "github tool", tool: "actions_list", want: &MissingToolSuggestion{Kind: missingToolKindGitHubToolset, Name: "actions"}},
+		{name: "prefixed github tool", tool: "github-actions_list", want: &MissingToolSuggestion{Kind: missingToolKindGitHubToolset, Name: "actions"}},
+		{name: "qualified github tool", tool: "mcp__github__actions_list", want: &MissingToolSuggestion{Kind: missingToolKindGitHubToolset, Name: "actions"}},
+		{name: "builtin alias", tool: "WebFetch", want: &MissingToolSuggestion{Kind: missingToolKindBuiltin, Name: "web-fetch"}},
+		{name: "explicit bash", tool: "bash:jq", want: &MissingToolSuggestion{Kind: missingToolKindBash, Name: "jq"}},
+		{name: "command from reason", tool: "jq", context: "jq command is not allowed", want: &MissingToolSuggestion{Kind: missingToolKindBash, Name: "jq"}},
+		{name: "other mcp server", tool: "mcp__jira__create_ticket", want: &MissingToolSuggestion{Kind: missingToolKindMCPServer, Name: "jira", Tool: "create_ticket"}},
+		{name: "unknown github tool", tool: "mcp__github__does_not_exist"},
+		{name: "unknown capability", tool: "slack", context: "need to notify the team"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := suggestMissingToolConfig(tt.tool, tt.context)
+			if tt.want == nil {
+				assert.Nil(t, got, "no suggestion expected for %s", tt.tool)
+				return
+			}
+			require.NotNil(t, got, "suggestion expected for %s", tt.tool)
+			assert.Equal(t, tt.want.Kind, got.Kind, "suggestion kind")
+			assert.Equal(t, tt.want.Name, got.Name, "suggestion name")
+			assert.Equal(t, tt.want.Tool, got.Tool, "suggestion tool")
+			assert.NotEmpty(t, got.Snippet, "suggestion should carry a snippet")
+		})
+	}
+}
+
+func TestBuildMissingToolsReport(t *testing.T) {
+	base := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	summaries := []*RunSummary{
+		missingToolsTestSummary(1, "triage", base,
+			MissingToolReport{Tool: "actions_list", Reason: "cannot inspect workflow runs"},
+			MissingToolReport{Tool: "actions_list", Reason: "still needed"}),
+		missingToolsTestSummary(2, "triage", base.Add(time.Hour)),
+		missingToolsTestSummary(3, "docs", base.Add(2*time.Hour),
+			MissingToolReport{Tool: "jq", Reason: "jq command not available"},
+			MissingToolReport{Tool: "actions_list", Reason: "latest reason"}),
+		missingToolsTestSummary(4, "docs", base.Add(3*time.Hour),
+			MissingToolReport{Tool: "slack"}),
+	}
+
+	report := buildMissingToolsReport("", summaries)
+
+	assert.Equal(t, 4, report.RunsAnalyzed, "all runs should be analyzed")
+	assert.Equal(t, 3, report.RunsWithReports, "runs without reports should not count")
+	require.Len(t, report.Tools, 3, "tools should be aggregated by name")
+
+	top := report.Tools[0]
+	assert.Equal(t, "actions_list", top.Tool, "the tool reported in the most runs should rank first")
+	assert.Equal(t, 2, top.Runs, "repeated reports in one run count once")
+	assert.Equal(t, 3, top.Reports, "every report should be counted")
+	assert.InDelta(t, 0.5, top.RunRate, 0.001, "run rate should use all analyzed runs")
+	assert.Equal(t, []string{"triage", "docs"}, top.Workflows, "workflows should be listed")
+	assert.Equal(t, "latest reason", top.Reason, "the most recent reason should be kept")
+	assert.Equal(t, []int64{1, 3}, top.RunIDs, "run IDs should be listed once")
+
+	assert.Equal(t, "slack", report.Tools[1].Tool, "ties should prefer the most recently seen tool")
+	assert.Nil(t, report.Tools[1].Suggestion, "unknown tools should have no suggestion")
+	assert.Equal(t, "jq", report.Tools[2].Tool, "the least recently seen tool should rank last")
+	assert.Equal(t, "tools:\n  github:\n    toolsets: [default, actions]\n  bash: [\"jq\"]\n", report.Snippet, "snippet should merge all suggestions")
+}
+
+func TestRenderMissingToolsSnippet(t *testing.T) {
+	snippet := renderMissingToolsSnippet([]MissingToolSuggestion{
+		{Kind: missingToolKindGitHubToolset, Name: "repos"},
+		{Kind: missingToolKindBuiltin, Name: "web-fetch"},
+		{Kind: missingToolKindMCPServer, Name: "jira", Tool: "create_ticket"},
+		{Kind: missingToolKindMCPServer, Name: "jira", Tool: "search"},
+	})
+	assert.Equal(t, "tools:\n  github:\n    toolsets: [default]\n  web-fetch:\nmcp-servers:\n  jira:\n    allowed: [create_ticket, search]\n", snippet,
+		"default toolsets should not be repeated and MCP tools should be grouped by server")
+	assert.Empty(t, renderMissingToolsSnippet(nil), "no suggestions should render nothing")
+}
+
+func TestRunAuditMissingTools(t *testing.T) {
+	dir := t.TempDir()
+	base := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	for _, summary := range []*RunSummary{
+		missingToolsTestSummary(201, "triage", base, MissingToolReport{Tool: "actions_list"}),
+		missingToolsTestSummary(202, "docs", base.Add(time.Hour), MissingToolReport{Tool: "web_search"}),
+	} {
+		writeCachedRunSummary(t, dir, *summary)
+	}
+
+	require.NoError(t, RunAuditMissingTools("triage", dir, 10, true), "cached runs of the workflow should be analyzed")
+
+	err := RunAuditMissingTools("unknown", dir, 10, true)
+	require.Error(t, err, "a workflow without cached runs should fail")
+	assert.Contains(t, err.Error(), "logs unknown", "error should explain how to populate the cache")
+}
@@ -0,0 +1,340 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var auditOTLPLog = logger.New("cli:audit_otlp")
+
+// auditOTLPTimeout bounds each OTLP export request.
+const auditOTLPTimeout = 30 * time.Second
+
+// AuditOTLPExporter converts audited run data into OTLP/HTTP JSON metrics and spans
+// and posts them to an OpenTelemetry collector or any backend that accepts OTLP
+// (for example Prometheus' /api/v1/otlp receiver).
+type AuditOTLPExporter struct {
+	// Endpoint is the OTLP/HTTP base URL; /v1/metrics and /v1/traces are appended.
+	Endpoint string
+	// Headers are sent with every request. NewAuditOTLPExporter populates them from
+	// OTEL_EXPORTER_OTLP_HEADERS.
+	Headers map[string]string
+	// ServiceName is the service.name resource attribute (OTEL_SERVICE_NAME or "gh-aw").
+	ServiceName string
+	// Repository is the owner/repo slug recorded as a resource attribute when known.
+	Repository string
+	// Client is the HTTP client used for export; nil uses http.DefaultClient.
+	Client *http.Client
+}
+
+// NewAuditOTLPExporter creates an exporter for the given endpoint, reading headers
+// and the service name from the standard OpenTelemetry environment variables.
+func NewAuditOTLPExporter(endpoint, repository string) (*AuditOTLPExporter, error) {
+	parsed, err := url.Parse(endpoint)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid OTLP endpoint %q: expected an http(s) URL such as http://localhost:4318", endpoint)
+	}
+	serviceName := os.Getenv("OTEL_SERVICE_NAME")
+	if serviceName == "" {
+		serviceName = "gh-aw"
+	}
+	return &AuditOTLPExporter{
+		Endpoint:    strings.TrimSuffix(endpoint, "/"),
+		Headers:     parseOTLPHeaders(os.Getenv("OTEL_EXPORTER_OTLP_HEADERS")),
+		ServiceName: serviceName,
+		Repository:  repository,
+	}, nil
+}
+
+// parseOTLPHeaders parses an OTEL_EXPORTER_OTLP_HEADERS value (key=value[,key=value...],
+// optionally percent-encoded). Malformed pairs are skipped.
+func parseOTLPHeaders(raw string) map[string]string {
+	headers := make(map[string]string)
+	for pair := range strings.SplitSeq(raw, ",") {
+		key, value, found := strings.Cut(pair, "=")
+		if !found || key == "" {
+			continue
+		}
+		if decoded, err := url.PathUnescape(key); err == nil {
+			key = decoded
+		}
+		if decoded, err := url.PathUnescape(value); err == nil {
+			value = decoded
+		}
+		if key = strings.TrimSpace(key); key != "" {
+			headers[key] = strings.TrimSpace(value)
+		}
+	}
+	return headers
+}
+
+// Export sends the run's metrics and spans. Metrics are required; a trace endpoint
+// that does not exist (HTTP 404, e.g. a metrics-only Prometheus receiver) is reported
+// as a warning rather than an error.
+func (e *AuditOTLPExporter) Export(ctx context.Context, processedRun ProcessedRun, metrics LogMetrics) error {
+	auditOTLPLog.Printf("Exporting run %d to %s", processedRun.Run.DatabaseID, e.Endpoint)
+	if err := e.post(ctx, "/v1/metrics", e.BuildMetricsPayload(processedRun, metrics)); err != nil {
+		return fmt.Errorf("failed to export metrics: %w", err)
+	}
+	err := e.post(ctx, "/v1/traces", e.BuildTracesPayload(processedRun, metrics))
+	var statusErr *otlpStatusError
+	if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound {
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage("OTLP endpoint does not accept traces (HTTP 404); exported metrics only"))
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to export traces: %w", err)
+	}
+	return nil
+}
+
+// otlpStatusError is returned when the OTLP endpoint responds with a non-2xx status.
+type otlpStatusError struct {
+	URL        string
+	StatusCode int
+	Body       string
+}
+
+func (e *otlpStatusError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("%s returned HTTP %d", e.URL, e.StatusCode)
+	}
+	return fmt.Sprintf("%s returned HTTP %d: %s", e.URL, e.StatusCode, e.Body)
+}
+
+func (e *AuditOTLPExporter) post(ctx context.Context, path string, payload map[string]any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode OTLP payload: %w", err)
+	}
+	reqCtx, cancel := context.WithTimeout(ctx, auditOTLPTimeout)
+	defer cancel()
+	target := e.Endpoint + path
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range e.Headers {
+		req.Header.Set(key, value)
+	}
+	client := e.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return &otlpStatusError{URL: target, StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(snippet))}
+	}
+	auditOTLPLog.Printf("Exported %d bytes to %s", len(body), target)
+	return nil
+}
+
+// resourceAttributes returns the resource attributes shared by metrics and spans.
+func (e *AuditOTLPExporter) resourceAttributes(run WorkflowRun) []map[string]any {
+	attrs := []map[string]any{
+		otlpStringAttr("service.name", e.ServiceName),
+		otlpStringAttr("gh-aw.workflow.name", run.WorkflowName),
+		otlpStringAttr("gh-aw.run.id", strconv.FormatInt(run.DatabaseID, 10)),
+	}
+	if e.Repository != "" {
+		attrs = append(attrs, otlpStringAttr("gh-aw.repository", e.Repository))
+	}
+	if run.Conclusion != "" {
+		attrs = append(attrs, otlpStringAttr("gh-aw.run.conclusion", run.Conclusion))
+	}
+	if run.Event != "" {
+		attrs = append(attrs, otlpStringAttr("gh-aw.trigger.event", run.Event))
+	}
+	return attrs
+}
+
+// BuildMetricsPayload converts the run's LogMetrics, durations, and firewall stats into
+// an OTLP ExportMetricsServiceRequest. Every metric is a gauge sampled at the run's end.
+func (e *AuditOTLPExporter) BuildMetricsPayload(processedRun ProcessedRun, metrics LogMetrics) map[string]any {
+	run := processedRun.Run
+	timestamp := otlpTimestamp(auditRunEndTime(run))
+
+	gauge := func(name, unit string, value float64, attrs ...map[string]any) map[string]any {
+		point := map[string]any{"timeUnixNano": timestamp, "asDouble": value}
+		if len(attrs) > 0 {
+			point["attributes"] = attrs
+		}
+		return map[string]any{"name": name, "unit": unit, "gauge": map[string]any{"dataPoints": []any{point}}}
+	}
+
+	toolCalls := 0
+	for _, tool := range metrics.ToolCalls {
+		toolCalls += tool.CallCount
+	}
+
+	list := []any{
+		gauge("gh-aw.run.duration", "s", run.Duration.Seconds()),
+		gauge("gh-aw.run.tokens", "{token}", float64(metrics.TokenUsage)),
+		gauge("gh-aw.run.effective_tokens", "{token}", float64(run.EffectiveTokens)),
+		gauge("gh-aw.run.cost", "USD", metrics.EstimatedCost),
+		gauge("gh-aw.run.turns", "{turn}", float64(metrics.Turns)),
+		gauge("gh-aw.run.tool_calls", "{call}", float64(toolCalls)),
+		gauge("gh-aw.run.errors", "{error}", float64(run.ErrorCount)),
+		gauge("gh-aw.run.warnings", "{warning}", float64(run.WarningCount)),
+		gauge("gh-aw.run.missing_tools", "{tool}", float64(len(processedRun.MissingTools))),
+		gauge("gh-aw.run.mcp_failures", "{failure}", float64(len(processedRun.MCPFailures))),
+		gauge("gh-aw.run.safe_items", "{item}", float64(run.SafeItemsCount)),
+	}
+	if metrics.AvgTimeBetweenTurns > 0 {
+		list = append(list, gauge("gh-aw.run.time_between_turns", "s", metrics.AvgTimeBetweenTurns.Seconds()))
+	}
+	if processedRun.TokenUsage != nil && processedRun.TokenUsage.TotalAIC > 0 {
+		list = append(list, gauge("gh-aw.run.aic", "{credit}", processedRun.TokenUsage.TotalAIC))
+	}
+	for _, job := range processedRun.JobDetails {
+		if job.Duration <= 0 {
+			continue
+		}
+		list = append(list, gauge("gh-aw.job.duration", "s", job.Duration.Seconds(),
+			otlpStringAttr("gh-aw.job.name", job.Name),
+			otlpStringAttr("gh-aw.job.conclusion", job.Conclusion)))
+	}
+	if fw := processedRun.FirewallAnalysis; fw != nil {
+		list = append(list,
+			gauge("gh-aw.firewall.requests", "{request}", float64(fw.AllowedRequests), otlpStringAttr("gh-aw.firewall.decision", "allowed")),
+			gauge("gh-aw.firewall.requests", "{request}", float64(fw.BlockedRequests), otlpStringAttr("gh-aw.firewall.decision", "blocked")),
+			gauge("gh-aw.firewall.blocked_domains", "{domain}", float64(len(fw.BlockedDomains))),
+		)
+	}
+
+	return map[string]any{
+		"resourceMetrics": []any{map[string]any{
+			"resource": map[string]any{"attributes": e.resourceAttributes(run)},
+			"scopeMetrics": []any{map[string]any{
+				"scope":   map[string]any{"name": "gh-aw.audit", "version": GetVersion()},
+				"metrics": list,
+			}},
+		}},
+	}
+}
+
+// BuildTracesPayload converts the run into an OTLP ExportTraceServiceRequest with one
+// root span for the run and one child span per job. Trace and span IDs are derived from
+// the run and job identity so re-exporting the same run yields the same IDs.
+func (e *AuditOTLPExporter) BuildTracesPayload(processedRun ProcessedRun, metrics LogMetrics) map[string]any {
+	run := processedRun.Run
+	traceID := otlpID(16, e.Repository, strconv.FormatInt(run.DatabaseID, 10))
+	rootSpanID := otlpID(8, traceID, "run")
+	start := run.StartedAt
+	if start.IsZero() {
+		start = auditRunEndTime(run).Add(-run.Duration)
+	}
+
+	rootAttrs := []map[string]any{
+		otlpIntAttr("gh-aw.tokens", int64(metrics.TokenUsage)),
+		otlpIntAttr("gh-aw.turns", int64(metrics.Turns)),
+		otlpIntAttr("gh-aw.errors", int64(run.ErrorCount)),
+	}
+	if run.URL != "" {
+		rootAttrs = append(rootAttrs, otlpStringAttr("gh-aw.run.url", run.URL))
+	}
+	if processedRun.FirewallAnalysis != nil {
+		rootAttrs = append(rootAttrs, otlpIntAttr("gh-aw.firewall.blocked_requests", int64(processedRun.FirewallAnalysis.BlockedRequests)))
+	}
+	spans := []any{map[string]any{
+		"traceId":           traceID,
+		"spanId":            rootSpanID,
+		"name":              "gh-aw.run",
+		"kind":              1, // SPAN_KIND_INTERNAL
+		"startTimeUnixNano": otlpTimestamp(start),
+		"endTimeUnixNano":   otlpTimestamp(auditRunEndTime(run)),
+		"attributes":        rootAttrs,
+		"status":            otlpSpanStatus(run.Conclusion),
+	}}
+
+	for _, job := range processedRun.JobDetails {
+		if job.StartedAt.IsZero() || job.CompletedAt.IsZero() {
+			continue
+		}
+		spans = append(spans, map[string]any{
+			"traceId":           traceID,
+			"spanId":            otlpID(8, traceID, "job", job.Name),
+			"parentSpanId":      rootSpanID,
+			"name":              "gh-aw.job",
+			"kind":              1,
+			"startTimeUnixNano": otlpTimestamp(job.StartedAt),
+			"endTimeUnixNano":   otlpTimestamp(job.CompletedAt),
+			"attributes":        []map[string]any{otlpStringAttr("gh-aw.job.name", job.Name)},
+			"status":            otlpSpanStatus(job.Conclusion),
+		})
+	}
+
+	return map[string]any{
+		"resourceSpans": []any{map[string]any{
+			"resource": map[string]any{"attributes": e.resourceAttributes(run)},
+			"scopeSpans": []any{map[string]any{
+				"scope": map[string]any{"name": "gh-aw.audit", "version": GetVersion()},
+				"spans": spans,
+			}},
+		}},
+	}
+}
+
+// auditRunEndTime returns when the run finished, falling back to now for runs
+// without timing metadata (e.g. audits of locally cached artifacts).
+func auditRunEndTime(run WorkflowRun) time.Time {
+	switch {
+	case !run.UpdatedAt.IsZero():
+		return run.UpdatedAt
+	case !run.StartedAt.IsZero():
+		return run.StartedAt.Add(run.Duration)
+	default:
+		return time.Now()
+	}
+}
+
+func otlpSpanStatus(conclusion string) map[string]any {
+	switch conclusion {
+	case "success":
+		return map[string]any{"code": 1} // STATUS_CODE_OK
+	case "failure", "timed_out", "startup_failure":
+		return map[string]any{"code": 2, "message": conclusion} // STATUS_CODE_ERROR
+	default:
+		return map[string]any{"code": 0} // STATUS_CODE_UNSET
+	}
+}
+
+// otlpID derives a deterministic hex-encoded ID of size bytes from the given parts.
+func otlpID(size int, parts ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:size])
+}
+
+func otlpTimestamp(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
+
+func otlpStringAttr(key, value string) map[string]any {
+	return map[string]any{"key": key, "value": map[string]any{"stringValue": value}}
+}
+
+func otlpIntAttr(key string, value int64) map[string]any {
+	// OTLP/JSON encodes 64-bit integers as strings.
+	return map[string]any{"key": key, "value": map[string]any{"intValue": strconv.FormatInt(value, 10)}}
+}
@@ -0,0 +1,161 @@
+//go:build !integration
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testOTLPProcessedRun() (ProcessedRun, LogMetrics) {
+	started := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	run := ProcessedRun{
+		Run: WorkflowRun{
+			DatabaseID:   1234,
+			WorkflowName: "Daily Plan",
+			Conclusion:   "failure",
+			StartedAt:    started,
+			UpdatedAt:    started.Add(5 * time.Minute),
+			Duration:     5 * time.Minute,
+			ErrorCount:   2,
+		},
+		FirewallAnalysis: &FirewallAnalysis{AnalysisBase: AnalysisBase{
+			DomainBuckets:   DomainBuckets{BlockedDomains: []string{"evil.example.com"}},
+			TotalRequests:   10,
+			AllowedRequests: 7,
+			BlockedRequests: 3,
+		}},
+		TokenUsage: &TokenUsageSummary{TotalAIC: 4.5},
+		JobDetails: []JobInfoWithDuration{{
+			JobInfo:  JobInfo{Name: "agent", Conclusion: "failure", StartedAt: started, CompletedAt: started.Add(4 * time.Minute)},
+			Duration: 4 * time.Minute,
+		}},
+	}
+	metrics := LogMetrics{TokenUsage: 1500, Turns: 6, ToolCalls: []ToolCallInfo{{Name: "bash", CallCount: 3}, {Name: "github::get_issue", CallCount: 2}}}
+	return run, metrics
+}
+
+// otlpGaugeValues flattens a metrics payload into name(+decision) → value.
+func otlpGaugeValues(t *testing.T, payload map[string]any) map[string]float64 {
+	t.Helper()
+	raw, err := json.Marshal(payload)
+	require.NoError(t, err)
+	var decoded struct {
+		ResourceMetrics []struct {
+			ScopeMetrics []struct {
+				Metrics []struct {
+					Name  string `json:"name"`
+					Gauge struct {
+						DataPoints []struct {
+							AsDouble   float64 `json:"asDouble"`
+							Attributes []struct {
+								Key   string `json:"key"`
+								Value struct {
+									StringValue string `json:"stringValue"`
+								} `json:"value"`
+							} `json:"attributes"`
+						} `json:"dataPoints"`
+					} `json:"gauge"`
+				} `json:"metrics"`
+			} `json:"scopeMetrics"`
+		} `json:"resourceMetrics"`
+	}
+	require.NoError(t, json.Unmarshal(raw, &decoded))
+	values := make(map[string]float64)
+	for _, metric := range decoded.ResourceMetrics[0].ScopeMetrics[0].Metrics {
+		for _, point := range metric.Gauge.DataPoints {
+			key := metric.Name
+			for _, attr := range point.Attributes {
+				key += ":" + attr.Value.StringValue
+			}
+			values[key] = point.AsDouble
+		}
+	}
+	return values
+}
+
+func TestAuditOTLPBuildMetricsPayload(t *testing.T) {
+	exporter := &AuditOTLPExporter{ServiceName: "gh-aw", Repository: "owner/repo"}
+	run, metrics := testOTLPProcessedRun()
+
+	values := otlpGaugeValues(t, exporter.BuildMetricsPayload(run, metrics))
+	assert.InDelta(t, 300.0, values["gh-aw.run.duration"], 0.001)
+	assert.InDelta(t, 1500.0, values["gh-aw.run.tokens"], 0.001)
+	assert.InDelta(t, 5.0, values["gh-aw.run.tool_calls"], 0.001, "tool calls should be summed across tools")
+	assert.InDelta(t, 4.5, values["gh-aw.run.aic"], 0.001)
+	assert.InDelta(t, 240.0, values["gh-aw.job.duration:agent:failure"], 0.001)
+	assert.InDelta(t, 7.0, values["gh-aw.firewall.requests:allowed"], 0.001)
+	assert.InDelta(t, 3.0, values["gh-aw.firewall.requests:blocked"], 0.001)
+	assert.InDelta(t, 1.0, values["gh-aw.firewall.blocked_domains"], 0.001)
+}
+
+func TestAuditOTLPBuildTracesPayload(t *testing.T) {
+	exporter := &AuditOTLPExporter{ServiceName: "gh-aw", Repository: "owner/repo"}
+	run, metrics := testOTLPProcessedRun()
+
+	first, err := json.Marshal(exporter.BuildTracesPayload(run, metrics))
+	require.NoError(t, err)
+	second, err := json.Marshal(exporter.BuildTracesPayload(run, metrics))
+	require.NoError(t, err)
+	assert.JSONEq(t, string(first), string(second), "re-exporting a run should produce identical IDs")
+
+	spans := exporter.BuildTracesPayload(run, metrics)["resourceSpans"].([]any)[0].(map[string]any)["scopeSpans"].([]any)[0].(map[string]any)["spans"].([]any)
+	require.Len(t, spans, 2, "expected a run span and one job span")
+	root := spans[0].(map[string]any)
+	job := spans[1].(map[string]any)
+	assert.Len(t, root["traceId"], 32)
+	assert.Len(t, root["spanId"], 16)
+	assert.Equal(t, root["spanId"], job["parentSpanId"])
+	assert.Equal(t, map[string]any{"code": 2, "message": "failure"}, root["status"])
+}
+
+func TestAuditOTLPExport(t *testing.T) {
+	var mu sync.Mutex
+	received := make(map[string]string)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		received[r.URL.Path] = r.Header.Get("Authorization")
+		_, _ = io.Copy(io.Discard, r.Body)
+		if r.URL.Path == "/api/v1/otlp/v1/traces" {
+			http.NotFound(w, r)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	t.Setenv("OTEL_EXPORTER_OTLP_HEADERS", "Authorization=Bearer%20tok")
+	t.Setenv("OTEL_SERVICE_NAME", "")
+	exporter, err := NewAuditOTLPExporter(server.URL+"/api/v1/otlp/", "owner/repo")
+	require.NoError(t, err)
+	assert.Equal(t, "gh-aw", exporter.ServiceName)
+
+	run, metrics := testOTLPProcessedRun()
+	require.NoError(t, exporter.Export(context.Background(), run, metrics), "a 404 from the traces endpoint should not fail the export")
+	assert.Equal(t, "Bearer tok", received["/api/v1/otlp/v1/metrics"], "headers should come from OTEL_EXPORTER_OTLP_HEADERS")
+	assert.Contains(t, received, "/api/v1/otlp/v1/traces")
+
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad payload", http.StatusBadRequest)
+	})
+	err = exporter.Export(context.Background(), run, metrics)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "HTTP 400")
+}
+
+func TestNewAuditOTLPExporterRejectsInvalidEndpoint(t *testing.T) {
+	for _, endpoint := range []string{"localhost:4318", "ftp://collector", "http://"} {
+		_, err := NewAuditOTLPExporter(endpoint, "")
+		assert.Error(t, err, "endpoint %q should be rejected", endpoint)
+	}
+}
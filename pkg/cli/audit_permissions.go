@@ -0,0 +1,219 @@
+// This file builds the permissions section of the audit report.
+// It compares the agent job's compiled permissions with the permission scopes needed
+// by the GitHub MCP tools the agent actually called, as recorded by the
+// permission-telemetry feature in permission_usage.json, and highlights
+// over-provisioned scopes that can be tightened.
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/fileutil"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/tty"
+	"github.com/github/gh-aw/pkg/workflow"
+)
+
+var auditPermissionsLog = logger.New("cli:audit_permissions")
+
+// Permission scope usage statuses
+const (
+	permissionStatusUsed           = "used"
+	permissionStatusUnused         = "unused"
+	permissionStatusWriteUnused    = "write-unused"
+	permissionStatusNotRequested   = "not-requested"
+	permissionStatusInfrastructure = "infrastructure"
+)
+
+// permissionInfrastructureScopes are scopes the compiled workflow uses outside the GitHub
+// MCP server (repository checkout, OIDC, Copilot inference). They are not reported as
+// unused when granted at read level.
+var permissionInfrastructureScopes = []string{"contents", "copilot-requests", "id-token", "metadata"}
+
+// PermissionAudit compares requested agent job permissions with the scopes used by
+// GitHub MCP tool calls during the run
+type PermissionAudit struct {
+	Scopes          []PermissionScopeUsage `json:"scopes"`
+	Recommendations []string               `json:"recommendations,omitempty"`
+	UnmappedTools   []string               `json:"unmapped_tools,omitempty"` // GitHub MCP tools with no known toolset
+	Source          string                 `json:"source,omitempty"`
+}
+
+// PermissionScopeUsage is the requested and used level of a single permission scope
+type PermissionScopeUsage struct {
+	Scope     string   `json:"scope" console:"header:Scope"`
+	Requested string   `json:"requested,omitempty" console:"header:Requested,omitempty"`
+	Used      string   `json:"used,omitempty" console:"header:Used,omitempty"`
+	Calls     int      `json:"calls" console:"header:Calls"`
+	Status    string   `json:"status" console:"header:Status"`
+	Tools     []string `json:"tools,omitempty" console:"-"`
+}
+
+// permissionUsageRecord is the permission_usage.json file written by record_permission_usage.cjs
+type permissionUsageRecord struct {
+	RequestedPermissions map[string]string `json:"requested_permissions"`
+	GitHubToolCalls      map[string]int    `json:"github_tool_calls"`
+	Source               string            `json:"source"`
+}
+
+// findPermissionUsagePath returns the permission_usage.json path from the agent artifact,
+// which may or may not have been flattened to the run root.
+func findPermissionUsagePath(logsPath string) string {
+	candidates := []string{
+		filepath.Join(logsPath, constants.PermissionUsageFilename),
+		filepath.Join(logsPath, constants.AgentArtifactName, constants.PermissionUsageFilename),
+	}
+	for _, p := range candidates {
+		if fileutil.FileExists(p) {
+			return p
+		}
+	}
+	return ""
+}
+
+// buildPermissionAudit builds the permissions section from permission_usage.json.
+// Returns nil when the run did not record permission telemetry.
+func buildPermissionAudit(logsPath string) *PermissionAudit {
+	if logsPath == "" {
+		return nil
+	}
+	path := findPermissionUsagePath(logsPath)
+	if path == "" {
+		return nil
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		auditPermissionsLog.Printf("Failed to read %s: %v", path, err)
+		return nil
+	}
+	var record permissionUsageRecord
+	if err := json.Unmarshal(content, &record); err != nil {
+		auditPermissionsLog.Printf("Failed to parse %s: %v", path, err)
+		return nil
+	}
+	audit := analyzePermissionUsage(record)
+	auditPermissionsLog.Printf("Built permission audit: scopes=%d, recommendations=%d", len(audit.Scopes), len(audit.Recommendations))
+	return audit
+}
+
+// analyzePermissionUsage maps each GitHub MCP tool call to the permission scopes its
+// toolset needs and compares them with the requested permissions.
+func analyzePermissionUsage(record permissionUsageRecord) *PermissionAudit {
+	audit := &PermissionAudit{Source: record.Source}
+
+	usage := make(map[string]*PermissionScopeUsage)
+	scopeUsage := func(scope string) *PermissionScopeUsage {
+		if u, ok := usage[scope]; ok {
+			return u
+		}
+		u := &PermissionScopeUsage{Scope: scope, Requested: record.RequestedPermissions[scope]}
+		usage[scope] = u
+		return u
+	}
+
+	for scope := range record.RequestedPermissions {
+		scopeUsage(scope)
+	}
+	for tool, calls := range record.GitHubToolCalls {
+		scopes, ok := workflow.GitHubToolReadPermissions(tool)
+		if !ok {
+			audit.UnmappedTools = append(audit.UnmappedTools, tool)
+			continue
+		}
+		for _, scope := range scopes {
+			u := scopeUsage(string(scope))
+			u.Used = string(workflow.PermissionRead)
+			u.Calls += calls
+			u.Tools = append(u.Tools, tool)
+		}
+	}
+
+	for _, u := range usage {
+		slices.Sort(u.Tools)
+		u.Status = permissionScopeStatus(u)
+		switch u.Status {
+		case permissionStatusUnused:
+			audit.Recommendations = append(audit.Recommendations,
+				fmt.Sprintf("Remove '%s: %s' — no GitHub MCP tool call needed it", u.Scope, u.Requested))
+		case permissionStatusWriteUnused:
+			audit.Recommendations = append(audit.Recommendations,
+				fmt.Sprintf("Downgrade '%s: write' — the GitHub MCP server only reads this scope", u.Scope))
+		case permissionStatusNotRequested:
+			audit.Recommendations = append(audit.Recommendations,
+				fmt.Sprintf("Add '%s: read' — GitHub MCP tools (%s) needed it", u.Scope, strings.Join(u.Tools, ", ")))
+		}
+		audit.Scopes = append(audit.Scopes, *u)
+	}
+
+	slices.SortFunc(audit.Scopes, func(a, b PermissionScopeUsage) int { return strings.Compare(a.Scope, b.Scope) })
+	slices.Sort(audit.Recommendations)
+	slices.Sort(audit.UnmappedTools)
+	return audit
+}
+
+// permissionScopeStatus classifies a scope by comparing its requested and used levels.
+func permissionScopeStatus(u *PermissionScopeUsage) string {
+	switch {
+	case u.Requested == "":
+		return permissionStatusNotRequested
+	case u.Requested == string(workflow.PermissionWrite):
+		// The GitHub MCP server is read-only, so a write grant is never needed by its tools.
+		return permissionStatusWriteUnused
+	case u.Used != "":
+		return permissionStatusUsed
+	case slices.Contains(permissionInfrastructureScopes, u.Scope):
+		return permissionStatusInfrastructure
+	default:
+		return permissionStatusUnused
+	}
+}
+
+// overProvisionedScopeCount returns the number of scopes that can be removed or downgraded.
+func (a *PermissionAudit) overProvisionedScopeCount() int {
+	count := 0
+	for _, scope := range a.Scopes {
+		if scope.Status == permissionStatusUnused || scope.Status == permissionStatusWriteUnused {
+			count++
+		}
+	}
+	return count
+}
+
+// renderConsolePermissionAudit renders the permissions section of the audit report
+func renderConsolePermissionAudit(audit *PermissionAudit) {
+	if audit == nil || len(audit.Scopes) == 0 {
+		return
+	}
+
+	rows := make([][]string, 0, len(audit.Scopes))
+	for _, scope := range audit.Scopes {
+		rows = append(rows, []string{
+			scope.Scope,
+			stringOrDash(scope.Requested),
+			stringOrDash(scope.Used),
+			strconv.Itoa(scope.Calls),
+			scope.Status,
+		})
+	}
+	fmt.Fprint(os.Stderr, console.RenderTable(console.TableConfig{
+		Title:   fmt.Sprintf("Permissions (requested vs used, %d over-provisioned)", audit.overProvisionedScopeCount()),
+		Headers: []string{"Scope", "Requested", "Used", "Calls", "Status"},
+		Rows:    rows,
+		TTYFunc: tty.IsStderrTerminal,
+	}))
+	for _, recommendation := range audit.Recommendations {
+		fmt.Fprintf(os.Stderr, "  • %s\n", recommendation)
+	}
+	if len(audit.UnmappedTools) > 0 {
+		fmt.Fprintf(os.Stderr, "  GitHub MCP tools with unknown permission scopes: %s\n", strings.Join(audit.UnmappedTools, ", "))
+	}
+}
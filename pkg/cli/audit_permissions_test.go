@@ -0,0 +1,78 @@
+//go:build !integration
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyzePermissionUsage(t *testing.T) {
+	audit := analyzePermissionUsage(permissionUsageRecord{
+		RequestedPermissions: map[string]string{
+			"contents":      "read",
+			"issues":        "read",
+			"pull-requests": "read",
+			"discussions":   "write",
+		},
+		GitHubToolCalls: map[string]int{
+			"issue_read":  3,
+			"list_issues": 1,
+			"custom_tool": 2,
+		},
+		Source: "rpc-messages.jsonl",
+	})
+
+	byScope := make(map[string]PermissionScopeUsage)
+	for _, scope := range audit.Scopes {
+		byScope[scope.Scope] = scope
+	}
+
+	require.Len(t, audit.Scopes, 4, "every requested scope should be reported")
+	assert.Equal(t, permissionStatusUsed, byScope["issues"].Status, "issues should be used")
+	assert.Equal(t, 4, byScope["issues"].Calls, "issues calls should be aggregated across tools")
+	assert.Equal(t, []string{"issue_read", "list_issues"}, byScope["issues"].Tools, "tools should be sorted")
+	assert.Equal(t, permissionStatusUnused, byScope["pull-requests"].Status, "pull-requests was never needed")
+	assert.Equal(t, permissionStatusWriteUnused, byScope["discussions"].Status, "write grants are never needed by the read-only MCP server")
+	assert.Equal(t, permissionStatusInfrastructure, byScope["contents"].Status, "contents: read is needed for checkout")
+
+	assert.Equal(t, []string{
+		"Downgrade 'discussions: write' — the GitHub MCP server only reads this scope",
+		"Remove 'pull-requests: read' — no GitHub MCP tool call needed it",
+	}, audit.Recommendations, "over-provisioned scopes should produce recommendations")
+	assert.Equal(t, []string{"custom_tool"}, audit.UnmappedTools, "unknown tools should be listed")
+	assert.Equal(t, 2, audit.overProvisionedScopeCount(), "two scopes should be over-provisioned")
+}
+
+func TestAnalyzePermissionUsage_NotRequested(t *testing.T) {
+	audit := analyzePermissionUsage(permissionUsageRecord{
+		RequestedPermissions: map[string]string{"contents": "read"},
+		GitHubToolCalls:      map[string]int{"list_pull_requests": 1},
+	})
+
+	require.Len(t, audit.Scopes, 2, "used scope should be reported even when not requested")
+	assert.Equal(t, "pull-requests", audit.Scopes[1].Scope, "scopes should be sorted")
+	assert.Equal(t, permissionStatusNotRequested, audit.Scopes[1].Status, "pull-requests was used but not requested")
+	assert.Equal(t, []string{"Add 'pull-requests: read' — GitHub MCP tools (list_pull_requests) needed it"}, audit.Recommendations,
+		"missing scope should be recommended")
+}
+
+func TestBuildPermissionAudit(t *testing.T) {
+	logsPath := t.TempDir()
+	assert.Nil(t, buildPermissionAudit(logsPath), "runs without permission telemetry should have no section")
+
+	agentDir := filepath.Join(logsPath, "agent")
+	require.NoError(t, os.MkdirAll(agentDir, 0755), "Failed to create agent dir")
+	content := `{"requested_permissions":{"issues":"read"},"github_tool_calls":{"issue_read":2},"source":"rpc-messages.jsonl"}`
+	require.NoError(t, os.WriteFile(filepath.Join(agentDir, "permission_usage.json"), []byte(content), 0644), "Failed to write telemetry")
+
+	audit := buildPermissionAudit(logsPath)
+	require.NotNil(t, audit, "telemetry in the agent artifact should be found")
+	require.Len(t, audit.Scopes, 1, "one scope should be reported")
+	assert.Equal(t, permissionStatusUsed, audit.Scopes[0].Status, "issues should be used")
+	assert.Equal(t, "rpc-messages.jsonl", audit.Source, "source should be kept")
+}
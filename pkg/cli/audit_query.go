@@ -0,0 +1,270 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/fileutil"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+	"github.com/spf13/cobra"
+)
+
+var auditQueryLog = logger.New("cli:audit_query")
+
+// defaultQueryLogLineLimit bounds the number of matching log lines returned by default
+const defaultQueryLogLineLimit = 200
+
+// AuditQueryFilters holds the filters applied by audit query.
+type AuditQueryFilters struct {
+	Tool        string        `json:"tool,omitempty"`        // Tool name or glob pattern (case-insensitive)
+	MinDuration time.Duration `json:"-"`                     // Minimum tool call duration
+	Grep        string        `json:"grep,omitempty"`        // Regular expression (case-insensitive)
+	ErrorsOnly  bool          `json:"errors_only,omitempty"` // Only tool calls that failed
+}
+
+// AuditQueryLogMatch is a single log line matched by audit query.
+type AuditQueryLogMatch struct {
+	File string `json:"file"`
+	Line int    `json:"line"`
+	Text string `json:"text"`
+}
+
+// AuditQueryResult is the output of audit query for one cached run.
+type AuditQueryResult struct {
+	RunID            int64                `json:"run_id"`
+	Filters          AuditQueryFilters    `json:"filters"`
+	MinDuration      string               `json:"min_duration,omitempty"`
+	ToolCallsScanned int                  `json:"tool_calls_scanned"`
+	ToolCalls        []MCPToolCall        `json:"tool_calls"`
+	LogFile          string               `json:"log_file,omitempty"`
+	LogLinesScanned  int                  `json:"log_lines_scanned,omitempty"`
+	LogMatches       []AuditQueryLogMatch `json:"log_matches,omitempty"`
+	LogMatchCount    int                  `json:"log_match_count,omitempty"` // Total matches, including lines beyond --limit
+}
+
+// NewAuditQuerySubcommand creates the audit query subcommand.
+func NewAuditQuerySubcommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "query <run-id-or-url>",
+		Short: "Filter tool calls and log lines of a downloaded workflow run",
+		Long: `Query the structured logs of a workflow run that has already been downloaded to the logs
+cache, instead of grepping the raw agent logs by hand.
+
+Tool calls are read from the MCP gateway logs (gateway.jsonl or rpc-messages.jsonl) and
+filtered by --tool, --min-duration, --errors and --grep. When --grep is set, agent-stdio.log
+is also streamed line by line and matching lines are reported with their line numbers;
+--tool additionally restricts those lines to ones mentioning the tool.
+
+The query reads local files only; it does not call the GitHub API. Download the run first
+with '` + string(constants.CLIExtensionPrefix) + ` audit <run-id>' or '` + string(constants.CLIExtensionPrefix) + ` logs'.`,
+		Example: `  ` + string(constants.CLIExtensionPrefix) + ` audit query 1234567890 --tool github_issue_read        # Calls to one tool
+  ` + string(constants.CLIExtensionPrefix) + ` audit query 1234567890 --tool 'github_*' --min-duration 2s  # Slow GitHub tool calls
+  ` + string(constants.CLIExtensionPrefix) + ` audit query 1234567890 --grep "rate limit"              # Tool errors and log lines mentioning rate limits
+  ` + string(constants.CLIExtensionPrefix) + ` audit query 1234567890 --errors --json                  # Failed tool calls as JSON`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			components, err := parser.ParseRunURLExtended(args[0])
+			if err != nil {
+				return err
+			}
+			outputDir, _ := cmd.Flags().GetString("output")
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			tool, _ := cmd.Flags().GetString("tool")
+			minDuration, _ := cmd.Flags().GetDuration("min-duration")
+			grep, _ := cmd.Flags().GetString("grep")
+			errorsOnly, _ := cmd.Flags().GetBool("errors")
+			limit, _ := cmd.Flags().GetInt("limit")
+			if minDuration < 0 {
+				return fmt.Errorf("--min-duration must not be negative, got %s", minDuration)
+			}
+			if limit < 1 {
+				return fmt.Errorf("--limit must be at least 1, got %d", limit)
+			}
+			filters := AuditQueryFilters{
+				Tool:        tool,
+				MinDuration: minDuration,
+				Grep:        grep,
+				ErrorsOnly:  errorsOnly,
+			}
+			return RunAuditQuery(components.Number, outputDir, filters, limit, jsonOutput)
+		},
+	}
+
+	addOutputFlag(cmd, defaultLogsOutputDir)
+	addJSONFlag(cmd)
+	cmd.Flags().String("tool", "", "Only include calls to this tool (case-insensitive, supports glob patterns such as 'github_*')")
+	cmd.Flags().Duration("min-duration", 0, "Only include tool calls that took at least this long (e.g. 500ms, 2s)")
+	cmd.Flags().String("grep", "", "Case-insensitive regular expression matched against tool calls and agent log lines")
+	cmd.Flags().Bool("errors", false, "Only include tool calls that returned an error")
+	cmd.Flags().Int("limit", defaultQueryLogLineLimit, "Maximum number of matching log lines to print")
+	RegisterDirFlagCompletion(cmd, "output")
+
+	return cmd
+}
+
+// RunAuditQuery filters the tool calls and log lines of a cached run and renders the result.
+func RunAuditQuery(runID int64, outputDir string, filters AuditQueryFilters, limit int, jsonOutput bool) error {
+	auditQueryLog.Printf("Starting audit query: run=%d, dir=%s, filters=%+v, limit=%d", runID, outputDir, filters, limit)
+
+	runDir := filepath.Join(outputDir, fmt.Sprintf("run-%d", runID))
+	if !fileutil.DirExists(runDir) {
+		return fmt.Errorf("run %d has not been downloaded to %s\n\nRun '%s audit %d' to download it first", runID, outputDir, string(constants.CLIExtensionPrefix), runID)
+	}
+
+	result, err := queryAuditRun(runDir, runID, filters, limit)
+	if err != nil {
+		return err
+	}
+	if jsonOutput {
+		return renderAuditQueryJSON(result)
+	}
+	renderAuditQueryPretty(result)
+	return nil
+}
+
+// queryAuditRun applies the filters to the run's tool calls and, when a grep pattern is set,
+// to the lines of agent-stdio.log.
+func queryAuditRun(runDir string, runID int64, filters AuditQueryFilters, limit int) (*AuditQueryResult, error) {
+	var grepPattern *regexp.Regexp
+	if filters.Grep != "" {
+		compiled, err := regexp.Compile("(?i)" + filters.Grep)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --grep pattern %q: %w", filters.Grep, err)
+		}
+		grepPattern = compiled
+	}
+	if filters.Tool != "" {
+		if _, err := path.Match(strings.ToLower(filters.Tool), ""); err != nil {
+			return nil, fmt.Errorf("invalid --tool pattern %q: %w", filters.Tool, err)
+		}
+	}
+
+	result := &AuditQueryResult{
+		RunID:     runID,
+		Filters:   filters,
+		ToolCalls: []MCPToolCall{},
+	}
+	if filters.MinDuration > 0 {
+		result.MinDuration = filters.MinDuration.String()
+	}
+
+	mcpData, err := extractMCPToolUsageData(runDir, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tool calls: %w", err)
+	}
+	if mcpData != nil {
+		result.ToolCallsScanned = len(mcpData.ToolCalls)
+		for _, call := range mcpData.ToolCalls {
+			if matchesAuditQueryToolCall(call, filters, grepPattern) {
+				result.ToolCalls = append(result.ToolCalls, call)
+			}
+		}
+	}
+	auditQueryLog.Printf("Matched %d of %d tool calls", len(result.ToolCalls), result.ToolCallsScanned)
+
+	if grepPattern == nil {
+		return result, nil
+	}
+
+	logPath := findAgentStdioLogPath(runDir)
+	if logPath == "" {
+		auditQueryLog.Printf("No agent-stdio.log found in %s", runDir)
+		return result, nil
+	}
+	if rel, relErr := filepath.Rel(runDir, logPath); relErr == nil {
+		result.LogFile = rel
+	} else {
+		result.LogFile = logPath
+	}
+	if err := grepAuditLogFile(logPath, result, grepPattern, filters.Tool, limit); err != nil {
+		return nil, err
+	}
+	auditQueryLog.Printf("Matched %d of %d log lines", result.LogMatchCount, result.LogLinesScanned)
+	return result, nil
+}
+
+// matchesAuditQueryToolCall reports whether a tool call satisfies every filter.
+func matchesAuditQueryToolCall(call MCPToolCall, filters AuditQueryFilters, grepPattern *regexp.Regexp) bool {
+	if filters.Tool != "" && !matchesAuditQueryTool(call.ToolName, filters.Tool) {
+		return false
+	}
+	if filters.ErrorsOnly && call.Status != "error" && call.Error == "" {
+		return false
+	}
+	if filters.MinDuration > 0 {
+		// Durations are stored in their display form ("1.5s", "250ms"), which time.ParseDuration accepts.
+		duration, err := time.ParseDuration(call.Duration)
+		if err != nil || duration < filters.MinDuration {
+			return false
+		}
+	}
+	if grepPattern != nil {
+		text := strings.Join([]string{call.ServerName, call.ToolName, call.Method, call.Status, call.Error}, " ")
+		if !grepPattern.MatchString(text) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesAuditQueryTool matches a tool name against a case-insensitive name or glob pattern.
+func matchesAuditQueryTool(toolName, pattern string) bool {
+	name := strings.ToLower(toolName)
+	pattern = strings.ToLower(pattern)
+	if name == pattern {
+		return true
+	}
+	matched, err := path.Match(pattern, name)
+	return err == nil && matched
+}
+
+// grepAuditLogFile streams a log file and records the lines matching the pattern. Only the
+// first `limit` matches are kept, but all lines are scanned so the total match count is exact.
+func grepAuditLogFile(logPath string, result *AuditQueryResult, grepPattern *regexp.Regexp, tool string, limit int) error {
+	file, err := os.Open(logPath)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", logPath, err)
+	}
+	defer file.Close()
+
+	// Log lines are free text, so a glob --tool is reduced to its literal prefix.
+	toolLower := strings.ToLower(tool)
+	if i := strings.IndexAny(toolLower, "*?["); i >= 0 {
+		toolLower = toolLower[:i]
+	}
+	scanner := bufio.NewScanner(file)
+	buf := make([]byte, maxScannerBufferSize)
+	scanner.Buffer(buf, maxScannerBufferSize)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := scanner.Text()
+		if !grepPattern.MatchString(line) {
+			continue
+		}
+		if toolLower != "" && !strings.Contains(strings.ToLower(line), toolLower) {
+			continue
+		}
+		result.LogMatchCount++
+		if len(result.LogMatches) < limit {
+			result.LogMatches = append(result.LogMatches, AuditQueryLogMatch{
+				File: result.LogFile,
+				Line: lineNumber,
+				Text: line,
+			})
+		}
+	}
+	result.LogLinesScanned = lineNumber
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("error reading %s: %w", logPath, err)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/stringutil"
+)
+
+var auditQueryRenderLog = logger.New("cli:audit_query_render")
+
+// renderAuditQueryJSON outputs the audit query result as JSON to stdout.
+func renderAuditQueryJSON(result *AuditQueryResult) error {
+	auditQueryRenderLog.Printf("Rendering audit query as JSON: tool_calls=%d, log_matches=%d", len(result.ToolCalls), result.LogMatchCount)
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
+
+// renderAuditQueryPretty outputs the audit query result to stderr, with matching log lines on stdout
+// so they can be piped to other tools.
+func renderAuditQueryPretty(result *AuditQueryResult) {
+	auditQueryRenderLog.Printf("Rendering audit query as pretty output: tool_calls=%d, log_matches=%d", len(result.ToolCalls), result.LogMatchCount)
+	fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Audit Query — Run %d", result.RunID)))
+	fmt.Fprintln(os.Stderr)
+
+	if len(result.ToolCalls) > 0 {
+		rows := make([][]string, 0, len(result.ToolCalls))
+		for _, call := range result.ToolCalls {
+			rows = append(rows, []string{
+				call.Timestamp,
+				call.ServerName,
+				call.ToolName,
+				call.Duration,
+				call.Status,
+				stringutil.Truncate(call.Error, 60),
+			})
+		}
+		fmt.Fprint(os.Stderr, console.RenderTable(console.TableConfig{
+			Title:   fmt.Sprintf("Tool Calls (%d of %d)", len(result.ToolCalls), result.ToolCallsScanned),
+			Headers: []string{"Time", "Server", "Tool", "Duration", "Status", "Error"},
+			Rows:    rows,
+		}))
+		fmt.Fprintln(os.Stderr)
+	} else {
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("No tool calls matched (%d scanned)", result.ToolCallsScanned)))
+	}
+
+	if result.Filters.Grep == "" {
+		return
+	}
+	if result.LogFile == "" {
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage("No agent-stdio.log found for this run"))
+		return
+	}
+
+	fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("%s: %d matching line(s) of %d", result.LogFile, result.LogMatchCount, result.LogLinesScanned)))
+	for _, match := range result.LogMatches {
+		fmt.Fprintln(os.Stdout, match.File+":"+strconv.Itoa(match.Line)+": "+match.Text)
+	}
+	if omitted := result.LogMatchCount - len(result.LogMatches); omitted > 0 {
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("%d more matching line(s) omitted; raise --limit to see them", omitted)))
+	}
+}
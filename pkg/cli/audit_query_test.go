@@ -0,0 +1,145 @@
+//go:build !integration
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeAuditQueryTestRun(t *testing.T) string {
+	t.Helper()
+	runDir := filepath.Join(t.TempDir(), "run-4242")
+	require.NoError(t, os.MkdirAll(runDir, 0755))
+
+	gatewayLog := strings.Join([]string{
+		`{"timestamp":"2026-10-01T10:00:00Z","level":"info","event":"tool_call","server_name":"github","tool_name":"github_issue_read","duration":350,"status":"success"}`,
+		`{"timestamp":"2026-10-01T10:00:05Z","level":"info","event":"tool_call","server_name":"github","tool_name":"github_issue_read","duration":2500,"status":"error","error":"API rate limit exceeded"}`,
+		`{"timestamp":"2026-10-01T10:00:09Z","level":"info","event":"tool_call","server_name":"github","tool_name":"search_code","duration":4100,"status":"success"}`,
+		`{"timestamp":"2026-10-01T10:00:12Z","level":"info","event":"tool_call","server_name":"safeoutputs","tool_name":"add_comment","duration":20,"status":"success"}`,
+	}, "\n")
+	require.NoError(t, os.WriteFile(filepath.Join(runDir, "gateway.jsonl"), []byte(gatewayLog), 0644))
+
+	agentLog := strings.Join([]string{
+		"Starting agent",
+		"calling github_issue_read for #12",
+		"warning: rate limit approaching (github_issue_read)",
+		"Rate limit reset in 30s",
+		"done",
+	}, "\n")
+	require.NoError(t, os.WriteFile(filepath.Join(runDir, "agent-stdio.log"), []byte(agentLog), 0644))
+	return runDir
+}
+
+func auditQueryToolNames(calls []MCPToolCall) []string {
+	names := make([]string, 0, len(calls))
+	for _, call := range calls {
+		names = append(names, call.ToolName+"@"+call.Duration)
+	}
+	return names
+}
+
+func TestQueryAuditRun(t *testing.T) {
+	runDir := writeAuditQueryTestRun(t)
+
+	tests := []struct {
+		name           string
+		filters        AuditQueryFilters
+		limit          int
+		wantTools      []string
+		wantLogLines   []int
+		wantMatchCount int
+	}{
+		{
+			name:      "no filters returns all tool calls",
+			wantTools: []string{"github_issue_read@350ms", "github_issue_read@2.5s", "search_code@4.1s", "add_comment@20ms"},
+		},
+		{
+			name:      "tool name is case-insensitive",
+			filters:   AuditQueryFilters{Tool: "GitHub_Issue_Read"},
+			wantTools: []string{"github_issue_read@350ms", "github_issue_read@2.5s"},
+		},
+		{
+			name:      "tool glob with min duration",
+			filters:   AuditQueryFilters{Tool: "*_*", MinDuration: 2 * time.Second},
+			wantTools: []string{"github_issue_read@2.5s", "search_code@4.1s"},
+		},
+		{
+			name:      "errors only",
+			filters:   AuditQueryFilters{ErrorsOnly: true},
+			wantTools: []string{"github_issue_read@2.5s"},
+		},
+		{
+			name:           "grep matches tool errors and log lines",
+			filters:        AuditQueryFilters{Grep: "rate limit"},
+			wantTools:      []string{"github_issue_read@2.5s"},
+			wantLogLines:   []int{3, 4},
+			wantMatchCount: 2,
+		},
+		{
+			name:           "tool narrows grep log lines",
+			filters:        AuditQueryFilters{Tool: "github_issue_read", Grep: "rate limit"},
+			wantTools:      []string{"github_issue_read@2.5s"},
+			wantLogLines:   []int{3},
+			wantMatchCount: 1,
+		},
+		{
+			name:           "limit keeps the total match count",
+			filters:        AuditQueryFilters{Grep: "github|rate"},
+			limit:          1,
+			wantTools:      []string{"github_issue_read@350ms", "github_issue_read@2.5s", "search_code@4.1s"},
+			wantLogLines:   []int{2},
+			wantMatchCount: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limit := tt.limit
+			if limit == 0 {
+				limit = defaultQueryLogLineLimit
+			}
+			result, err := queryAuditRun(runDir, 4242, tt.filters, limit)
+			require.NoError(t, err, "query should succeed")
+			assert.Equal(t, 4, result.ToolCallsScanned, "all tool calls should be scanned")
+			assert.Equal(t, tt.wantTools, auditQueryToolNames(result.ToolCalls), "matched tool calls")
+
+			lines := make([]int, 0, len(result.LogMatches))
+			for _, match := range result.LogMatches {
+				assert.Equal(t, "agent-stdio.log", match.File, "log file should be relative to the run directory")
+				lines = append(lines, match.Line)
+			}
+			if tt.filters.Grep == "" {
+				assert.Empty(t, result.LogFile, "log lines should only be searched with --grep")
+			} else {
+				assert.Equal(t, tt.wantLogLines, lines, "matched log line numbers")
+				assert.Equal(t, 5, result.LogLinesScanned, "all log lines should be scanned")
+			}
+			assert.Equal(t, tt.wantMatchCount, result.LogMatchCount, "total log match count")
+		})
+	}
+}
+
+func TestQueryAuditRunInvalidPatterns(t *testing.T) {
+	runDir := writeAuditQueryTestRun(t)
+
+	_, err := queryAuditRun(runDir, 4242, AuditQueryFilters{Grep: "("}, defaultQueryLogLineLimit)
+	require.Error(t, err, "invalid regular expression should be rejected")
+	assert.Contains(t, err.Error(), "--grep", "error should name the flag")
+
+	_, err = queryAuditRun(runDir, 4242, AuditQueryFilters{Tool: "["}, defaultQueryLogLineLimit)
+	require.Error(t, err, "invalid glob should be rejected")
+	assert.Contains(t, err.Error(), "--tool", "error should name the flag")
+}
+
+func TestRunAuditQueryRequiresDownloadedRun(t *testing.T) {
+	err := RunAuditQuery(99, t.TempDir(), AuditQueryFilters{}, defaultQueryLogLineLimit, true)
+	require.Error(t, err, "missing run should be reported")
+	assert.Contains(t, err.Error(), "has not been downloaded", "error should explain how to download the run")
+}
@@ -0,0 +1,329 @@
+// This file implements audit --redact, which masks internal details in rendered audit
+// reports so they can be shared outside the organization (with vendors, in public issues).
+//
+// Redaction is applied to the assembled report data rather than to each renderer: the
+// report is round-tripped through JSON, every string value and map key is rewritten, and
+// the result is decoded back into the report type. Pretty, markdown, porcelain, and JSON
+// output therefore all render the same redacted data.
+//
+// The following are masked:
+//   - Repository names (owner/repo) of the audited run and of repositories found in
+//     repository fields and GitHub URLs → redacted/repo-N
+//   - GitHub usernames from actor/author fields and @mentions → user-N
+//   - Token and secret remnants (GitHub tokens, API keys, bearer tokens, JWTs,
+//     key=value secrets) → [REDACTED]
+//   - Internal hostnames (the GitHub Enterprise host, *.internal, *.corp, *.local, ...,
+//     and private IPv4 addresses) → internal-host-N
+//
+// Placeholders are numbered per report, so the same repository, user, or host always maps
+// to the same placeholder and relationships in the report stay readable.
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var auditRedactLog = logger.New("cli:audit_redact")
+
+const redactedSecretPlaceholder = "[REDACTED]"
+
+var (
+	// redactSecretPatterns match token and secret remnants that survived log masking.
+	redactSecretPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{16,}|github_pat_[A-Za-z0-9_]{16,})`),
+		regexp.MustCompile(`\bsk-(?:ant-|proj-)?[A-Za-z0-9_-]{16,}`),
+		regexp.MustCompile(`\bAKIA[0-9A-Z]{16}\b`),
+		regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{8,}\.[A-Za-z0-9_-]{8,}\.[A-Za-z0-9_-]{8,}`),
+		regexp.MustCompile(`(?i)\bBearer\s+[A-Za-z0-9._~+/=-]{8,}`),
+	}
+	// redactSecretAssignmentPattern matches key=value and "key": "value" secrets; the key is kept.
+	redactSecretAssignmentPattern = regexp.MustCompile(`(?i)\b([\w-]*(?:token|secret|password|passwd|api[_-]?key)["']?\s*[:=]\s*["']?)([^\s"',;]{6,})`)
+	// redactGitHubURLPattern matches the owner/repo part of GitHub web and API URLs.
+	redactGitHubURLPattern = regexp.MustCompile(`(https?://)([A-Za-z0-9.-]+)((?:/api/v3)?/repos)?/([A-Za-z0-9-]+)/([A-Za-z0-9._-]+)`)
+	// redactMentionPattern matches @username mentions (but not email addresses or npm scopes).
+	redactMentionPattern = regexp.MustCompile(`(^|[^\w@./-])@([A-Za-z0-9](?:[A-Za-z0-9-]{0,38}))([^\w/-]|$)`)
+	// redactInternalHostPattern matches hostnames under internal-only suffixes and private IPv4 addresses.
+	redactInternalHostPattern = regexp.MustCompile(`(?i)\b(?:[a-z0-9-]+\.)+(?:internal|intranet|corp|local|localdomain|lan|home\.arpa)\b|\b(?:10\.\d{1,3}|192\.168|172\.(?:1[6-9]|2\d|3[01]))\.\d{1,3}\.\d{1,3}\b`)
+	// redactRepoPrefixPattern matches an owner/repo prefix, e.g. of a workflow ref.
+	redactRepoPrefixPattern = regexp.MustCompile(`^([A-Za-z0-9-]+/[A-Za-z0-9._-]+)`)
+)
+
+// redactUserKeys are JSON keys whose string values are GitHub usernames.
+var redactUserKeys = map[string]bool{
+	"actor":            true,
+	"triggering_actor": true,
+	"author":           true,
+	"login":            true,
+	"user":             true,
+	"username":         true,
+	"assignee":         true,
+}
+
+// redactRepoKeys are JSON keys whose string values start with an owner/repo name.
+var redactRepoKeys = map[string]bool{
+	"repo":        true,
+	"repository":  true,
+	"target_repo": true,
+	"workflow_id": true,
+}
+
+// githubPathPrefixes are first URL path segments on GitHub hosts that are not owners.
+var githubPathPrefixes = map[string]bool{
+	"orgs":          true,
+	"users":         true,
+	"settings":      true,
+	"marketplace":   true,
+	"features":      true,
+	"apps":          true,
+	"login":         true,
+	"notifications": true,
+	"search":        true,
+}
+
+// auditRedactor masks repository names, usernames, secrets, and internal hostnames.
+// A nil *auditRedactor leaves values unchanged.
+type auditRedactor struct {
+	githubHost string
+	repos      map[string]string // lowercased owner/repo → placeholder
+	users      map[string]string // lowercased login → placeholder
+	hosts      map[string]string // lowercased hostname → placeholder
+	knownUsers map[string]bool   // logins from user fields, also masked in free text
+
+	// Literal patterns are rebuilt lazily when a new repository, user, or host is added
+	repoPattern *regexp.Regexp
+	userPattern *regexp.Regexp
+	hostPattern *regexp.Regexp
+}
+
+// newAuditRedactor creates a redactor that treats owner/repo as a known repository and
+// hostname (when it is a GitHub Enterprise host) as an internal host.
+func newAuditRedactor(owner, repo, hostname string) *auditRedactor {
+	r := &auditRedactor{
+		githubHost: "github.com",
+		repos:      make(map[string]string),
+		users:      make(map[string]string),
+		hosts:      make(map[string]string),
+		knownUsers: make(map[string]bool),
+	}
+	if hostname != "" && hostname != "github.com" {
+		r.githubHost = strings.ToLower(hostname)
+		r.hostAlias(r.githubHost)
+	}
+	if owner != "" && repo != "" {
+		r.repoAlias(owner + "/" + repo)
+	}
+	return r
+}
+
+func (r *auditRedactor) repoAlias(fullName string) string {
+	key := strings.ToLower(fullName)
+	if alias, ok := r.repos[key]; ok {
+		return alias
+	}
+	alias := fmt.Sprintf("redacted/repo-%d", len(r.repos)+1)
+	r.repos[key] = alias
+	r.repoPattern = nil
+	return alias
+}
+
+func (r *auditRedactor) userAlias(login string) string {
+	// Bot accounts are public apps, not people
+	if strings.HasSuffix(login, "[bot]") {
+		return login
+	}
+	key := strings.ToLower(login)
+	if alias, ok := r.users[key]; ok {
+		return alias
+	}
+	alias := fmt.Sprintf("user-%d", len(r.users)+1)
+	r.users[key] = alias
+	return alias
+}
+
+func (r *auditRedactor) hostAlias(host string) string {
+	key := strings.ToLower(host)
+	if alias, ok := r.hosts[key]; ok {
+		return alias
+	}
+	alias := fmt.Sprintf("internal-host-%d", len(r.hosts)+1)
+	r.hosts[key] = alias
+	r.hostPattern = nil
+	return alias
+}
+
+// String returns s with secrets, repository names, usernames, and internal hostnames masked.
+func (r *auditRedactor) String(s string) string {
+	if r == nil || s == "" {
+		return s
+	}
+	for _, pattern := range redactSecretPatterns {
+		s = pattern.ReplaceAllString(s, redactedSecretPlaceholder)
+	}
+	s = redactSecretAssignmentPattern.ReplaceAllString(s, "${1}"+redactedSecretPlaceholder)
+	s = r.redactGitHubURLs(s)
+	s = r.redactKnownRepos(s)
+	s = redactMentionPattern.ReplaceAllStringFunc(s, func(match string) string {
+		parts := redactMentionPattern.FindStringSubmatch(match)
+		return parts[1] + "@" + r.userAlias(parts[2]) + parts[3]
+	})
+	s = r.redactKnownUsers(s)
+	s = r.redactInternalHosts(s)
+	return s
+}
+
+func (r *auditRedactor) redactGitHubURLs(s string) string {
+	return redactGitHubURLPattern.ReplaceAllStringFunc(s, func(match string) string {
+		parts := redactGitHubURLPattern.FindStringSubmatch(match)
+		scheme, host, reposPrefix, owner, repo := parts[1], strings.ToLower(parts[2]), parts[3], parts[4], parts[5]
+		if host != "github.com" && host != "api.github.com" && host != r.githubHost {
+			return match
+		}
+		// Only /repos/ paths name a repository on the API host
+		if reposPrefix == "" && (host == "api.github.com" || githubPathPrefixes[owner]) {
+			return match
+		}
+		repo = strings.TrimSuffix(repo, ".git")
+		return scheme + parts[2] + reposPrefix + "/" + r.repoAlias(owner+"/"+repo)
+	})
+}
+
+func (r *auditRedactor) redactKnownRepos(s string) string {
+	if len(r.repos) == 0 {
+		return s
+	}
+	if r.repoPattern == nil {
+		r.repoPattern = literalAlternation(`(?i)(^|[^\w./-])(`, r.repos, `)([^\w-]|$)`)
+	}
+	return r.repoPattern.ReplaceAllStringFunc(s, func(match string) string {
+		parts := r.repoPattern.FindStringSubmatch(match)
+		return parts[1] + r.repos[strings.ToLower(parts[2])] + parts[3]
+	})
+}
+
+func (r *auditRedactor) redactKnownUsers(s string) string {
+	if len(r.knownUsers) == 0 {
+		return s
+	}
+	if r.userPattern == nil {
+		r.userPattern = literalAlternation(`(?i)\b(`, r.knownUsers, `)\b`)
+	}
+	return r.userPattern.ReplaceAllStringFunc(s, func(match string) string {
+		return r.users[strings.ToLower(match)]
+	})
+}
+
+func (r *auditRedactor) redactInternalHosts(s string) string {
+	if len(r.hosts) > 0 {
+		if r.hostPattern == nil {
+			r.hostPattern = literalAlternation(`(?i)(`, r.hosts, `)`)
+		}
+		s = r.hostPattern.ReplaceAllStringFunc(s, func(match string) string {
+			return r.hosts[strings.ToLower(match)]
+		})
+	}
+	return redactInternalHostPattern.ReplaceAllStringFunc(s, r.hostAlias)
+}
+
+// literalAlternation compiles prefix + (k1|k2|...) + suffix for the keys of m, longest
+// first so that a name is never partially replaced by a shorter name it starts with.
+func literalAlternation[V any](prefix string, m map[string]V, suffix string) *regexp.Regexp {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	slices.SortFunc(names, func(a, b string) int {
+		if len(a) != len(b) {
+			return len(b) - len(a)
+		}
+		return strings.Compare(a, b)
+	})
+	for i, name := range names {
+		names[i] = regexp.QuoteMeta(name)
+	}
+	return regexp.MustCompile(prefix + strings.Join(names, "|") + suffix)
+}
+
+// collect registers the usernames and repositories found in user and repository fields
+// so that they are also masked where they appear in free text.
+func (r *auditRedactor) collect(value any, key string) {
+	switch v := value.(type) {
+	case map[string]any:
+		for k, child := range v {
+			r.collect(child, k)
+		}
+	case []any:
+		for _, child := range v {
+			r.collect(child, key)
+		}
+	case string:
+		switch {
+		case redactUserKeys[key] && v != "" && !strings.HasSuffix(v, "[bot]"):
+			r.userAlias(v)
+			r.knownUsers[strings.ToLower(v)] = true
+			r.userPattern = nil
+		case redactRepoKeys[key]:
+			if match := redactRepoPrefixPattern.FindString(v); match != "" {
+				r.repoAlias(match)
+			}
+		}
+	}
+}
+
+// value returns a copy of a decoded JSON value with every string and map key redacted.
+func (r *auditRedactor) value(value any, key string) any {
+	switch v := value.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, child := range v {
+			out[r.String(k)] = r.value(child, k)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, child := range v {
+			out[i] = r.value(child, key)
+		}
+		return out
+	case string:
+		if redactUserKeys[key] && v != "" {
+			return r.userAlias(v)
+		}
+		return r.String(v)
+	default:
+		return v
+	}
+}
+
+// redactAuditValue returns a redacted copy of v. The value is round-tripped through JSON,
+// so only fields that are part of its JSON representation are kept.
+func redactAuditValue[T any](r *auditRedactor, v T) (T, error) {
+	if r == nil {
+		return v, nil
+	}
+	var redacted T
+	data, err := json.Marshal(v)
+	if err != nil {
+		return redacted, fmt.Errorf("failed to encode audit data for redaction: %w", err)
+	}
+	var decoded any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return redacted, fmt.Errorf("failed to decode audit data for redaction: %w", err)
+	}
+	r.collect(decoded, "")
+	data, err = json.Marshal(r.value(decoded, ""))
+	if err != nil {
+		return redacted, fmt.Errorf("failed to encode redacted audit data: %w", err)
+	}
+	if err := json.Unmarshal(data, &redacted); err != nil {
+		return redacted, fmt.Errorf("failed to decode redacted audit data: %w", err)
+	}
+	auditRedactLog.Printf("Redacted audit data: repos=%d, users=%d, hosts=%d", len(r.repos), len(r.users), len(r.hosts))
+	return redacted, nil
+}
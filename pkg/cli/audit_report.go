@@ -39,6 +39,10 @@ type AuditData struct {
 	SessionAnalysis         *SessionAnalysis         `json:"session_analysis,omitempty"`
 	SafeOutputSummary       *SafeOutputSummary       `json:"safe_output_summary,omitempty"`
 	MCPServerHealth         *MCPServerHealth         `json:"mcp_server_health,omitempty"`
+	MCPDiagnostics          *MCPDiagnostics          `json:"mcp_diagnostics,omitempty"`
+	PermissionAudit         *PermissionAudit         `json:"permission_audit,omitempty"`
+	SecuritySignals         *SecuritySignals         `json:"security_signals,omitempty"`
+	TokenBreakdown          *TokenBreakdown          `json:"token_breakdown,omitempty"`
 	Jobs                    []JobData                `json:"jobs,omitempty"`
 	DownloadedFiles         []FileInfo               `json:"downloaded_files"`
 	MissingTools            []MissingToolReport      `json:"missing_tools,omitempty"`
@@ -390,7 +394,7 @@ func buildAuditJobs(jobDetails []JobInfoWithDuration) []JobData {
 			Status:     jobDetail.Status,
 			Conclusion: jobDetail.Conclusion,
 			Steps: sliceutil.Map(jobDetail.Steps, func(step JobStep) JobStepData {
-				return JobStepData(step)
+				return JobStepData{Name: step.Name, Status: step.Status, Conclusion: step.Conclusion}
 			}),
 		}
 		if jobDetail.Duration > 0 {
@@ -470,6 +474,9 @@ func assembleAuditData(inputs auditDataInputs) AuditData {
 	sessionAnalysis := buildSessionAnalysis(inputs.processedRun, inputs.metrics)
 	safeOutputSummary := buildSafeOutputSummary(inputs.createdItems, chainMetrics)
 	mcpServerHealth := buildMCPServerHealth(inputs.mcpToolUsage, inputs.processedRun.MCPFailures)
+	mcpDiagnostics := buildMCPDiagnostics(run.LogsPath, inputs.processedRun.MCPFailures)
+	permissionAudit := buildPermissionAudit(run.LogsPath)
+	securitySignals := buildSecuritySignals(run.LogsPath)
 
 	if auditReportLog.Enabled() {
 		auditReportLog.Printf("Built audit data: %d jobs, %d errors, %d tool types, %d findings, %d recommendations",
@@ -491,6 +498,10 @@ func assembleAuditData(inputs auditDataInputs) AuditData {
 		SessionAnalysis:         sessionAnalysis,
 		SafeOutputSummary:       safeOutputSummary,
 		MCPServerHealth:         mcpServerHealth,
+		MCPDiagnostics:          mcpDiagnostics,
+		PermissionAudit:         permissionAudit,
+		SecuritySignals:         securitySignals,
+		TokenBreakdown:          buildTokenBreakdown(inputs.metrics.TurnTokens),
 		Jobs:                    inputs.jobs,
 		DownloadedFiles:         inputs.downloadedFiles,
 		MissingTools:            inputs.processedRun.MissingTools,
@@ -29,6 +29,7 @@ func renderConsole(data AuditData, logsPath string) {
 	renderConsoleMetrics(data.Metrics)
 	renderConsoleSession(data.SessionAnalysis)
 	renderConsoleTokenUsage(data.FirewallTokenUsage)
+	renderConsoleTokenBreakdown(data.TokenBreakdown)
 	renderConsoleGitHubAPIUsage(data.GitHubRateLimitUsage)
 	renderConsoleJobs(data.Jobs)
 	renderConsolePrompt(data.PromptAnalysis)
@@ -304,6 +305,9 @@ func renderConsoleOperationalSections(data AuditData) {
 	renderConsoleMissingTools(data.MissingTools)
 	renderConsoleMCPFailures(data.MCPFailures)
 	renderCompactMCPHealth(data.MCPServerHealth)
+	renderConsoleMCPDiagnostics(data.MCPDiagnostics)
+	renderConsolePermissionAudit(data.PermissionAudit)
+	renderConsoleSecuritySignals(data.SecuritySignals)
 	renderConsoleSafeOutputs(data.SafeOutputSummary)
 	renderConsoleCreatedItems(data.CreatedItems)
 	renderConsoleToolUsage(data.ToolUsage)
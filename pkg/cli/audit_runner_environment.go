@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var auditRunnerEnvironmentLog = logger.New("cli:audit_runner_environment")
+
+// RunnerEnvironment is the runner metadata captured by the agent job into aw_info.json
+// (see actions/setup/js/capture_runner_environment.cjs).
+type RunnerEnvironment struct {
+	OS               string              `json:"os,omitempty"`
+	Arch             string              `json:"arch,omitempty"`
+	Environment      string              `json:"environment,omitempty"`   // github-hosted or self-hosted
+	ImageOS          string              `json:"image_os,omitempty"`      // e.g. ubuntu24
+	ImageVersion     string              `json:"image_version,omitempty"` // e.g. 20261012.1
+	EngineCLIVersion string              `json:"engine_cli_version,omitempty"`
+	ToolCache        map[string][]string `json:"tool_cache,omitempty"` // tool name -> installed versions
+}
+
+// RunnerEnvironmentChange is a single runner environment value that differs between two runs.
+type RunnerEnvironmentChange struct {
+	Field     string `json:"field"` // e.g. "image_version" or "tool_cache.node"
+	Run1Value string `json:"run1_value,omitempty"`
+	Run2Value string `json:"run2_value,omitempty"`
+}
+
+// RunnerEnvironmentDiff lists runner environment changes between two runs.
+// Image and engine CLI changes are listed before tool cache changes.
+type RunnerEnvironmentDiff struct {
+	Changes []RunnerEnvironmentChange `json:"changes,omitempty"`
+}
+
+// loadRunnerEnvironment reads the runner environment from aw_info.json in a run directory.
+// Returns nil when the run predates runner environment capture.
+func loadRunnerEnvironment(runDir string) *RunnerEnvironment {
+	if runDir == "" {
+		return nil
+	}
+	awInfo, err := parseAwInfo(filepath.Join(runDir, "aw_info.json"), false)
+	if err != nil || awInfo == nil {
+		return nil
+	}
+	return awInfo.RunnerEnvironment
+}
+
+// computeRunnerEnvironmentDiff compares the runner environments of two runs.
+// Returns nil when either run has no captured environment or nothing changed.
+func computeRunnerEnvironmentDiff(env1, env2 *RunnerEnvironment) *RunnerEnvironmentDiff {
+	if env1 == nil || env2 == nil {
+		auditRunnerEnvironmentLog.Printf("Skipping runner environment diff: run1_captured=%t, run2_captured=%t", env1 != nil, env2 != nil)
+		return nil
+	}
+
+	var changes []RunnerEnvironmentChange
+	addChange := func(field, v1, v2 string) {
+		if v1 != v2 {
+			changes = append(changes, RunnerEnvironmentChange{Field: field, Run1Value: v1, Run2Value: v2})
+		}
+	}
+	addChange("os", env1.OS, env2.OS)
+	addChange("arch", env1.Arch, env2.Arch)
+	addChange("environment", env1.Environment, env2.Environment)
+	addChange("image_os", env1.ImageOS, env2.ImageOS)
+	addChange("image_version", env1.ImageVersion, env2.ImageVersion)
+	addChange("engine_cli_version", env1.EngineCLIVersion, env2.EngineCLIVersion)
+
+	tools := make(map[string]struct{}, len(env1.ToolCache)+len(env2.ToolCache))
+	for tool := range env1.ToolCache {
+		tools[tool] = struct{}{}
+	}
+	for tool := range env2.ToolCache {
+		tools[tool] = struct{}{}
+	}
+	toolNames := make([]string, 0, len(tools))
+	for tool := range tools {
+		toolNames = append(toolNames, tool)
+	}
+	sort.Strings(toolNames)
+	for _, tool := range toolNames {
+		v1, v2 := env1.ToolCache[tool], env2.ToolCache[tool]
+		if !slices.Equal(v1, v2) {
+			changes = append(changes, RunnerEnvironmentChange{
+				Field:     "tool_cache." + tool,
+				Run1Value: strings.Join(v1, ", "),
+				Run2Value: strings.Join(v2, ", "),
+			})
+		}
+	}
+
+	auditRunnerEnvironmentLog.Printf("Runner environment diff: %d changes", len(changes))
+	if len(changes) == 0 {
+		return nil
+	}
+	return &RunnerEnvironmentDiff{Changes: changes}
+}
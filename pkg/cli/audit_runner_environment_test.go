@@ -0,0 +1,87 @@
+//go:build !integration
+
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeRunnerEnvironmentDiff(t *testing.T) {
+	env1 := &RunnerEnvironment{
+		OS:               "Linux",
+		ImageOS:          "ubuntu24",
+		ImageVersion:     "20261005.1",
+		EngineCLIVersion: "1.0.72",
+		ToolCache: map[string][]string{
+			"node":   {"20.19.4", "22.19.0"},
+			"Python": {"3.12.11"},
+			"go":     {"1.24.7"},
+		},
+	}
+	env2 := &RunnerEnvironment{
+		OS:               "Linux",
+		ImageOS:          "ubuntu24",
+		ImageVersion:     "20261012.1",
+		EngineCLIVersion: "1.0.73",
+		ToolCache: map[string][]string{
+			"node":   {"20.19.5", "22.20.0"},
+			"Python": {"3.12.11"},
+			"Ruby":   {"3.3.9"},
+		},
+	}
+
+	diff := computeRunnerEnvironmentDiff(env1, env2)
+	require.NotNil(t, diff, "diff should be produced when the environment changed")
+	assert.Equal(t, []RunnerEnvironmentChange{
+		{Field: "image_version", Run1Value: "20261005.1", Run2Value: "20261012.1"},
+		{Field: "engine_cli_version", Run1Value: "1.0.72", Run2Value: "1.0.73"},
+		{Field: "tool_cache.Ruby", Run2Value: "3.3.9"},
+		{Field: "tool_cache.go", Run1Value: "1.24.7"},
+		{Field: "tool_cache.node", Run1Value: "20.19.4, 22.19.0", Run2Value: "20.19.5, 22.20.0"},
+	}, diff.Changes, "changes should list scalar fields first, then tools sorted by name")
+}
+
+func TestComputeRunnerEnvironmentDiff_NoDiff(t *testing.T) {
+	env := &RunnerEnvironment{ImageVersion: "20261012.1", ToolCache: map[string][]string{"node": {"22.20.0"}}}
+
+	assert.Nil(t, computeRunnerEnvironmentDiff(env, env), "identical environments should produce no diff")
+	assert.Nil(t, computeRunnerEnvironmentDiff(nil, env), "missing base environment should produce no diff")
+	assert.Nil(t, computeRunnerEnvironmentDiff(env, nil), "missing comparison environment should produce no diff")
+}
+
+func TestLoadRunnerEnvironment(t *testing.T) {
+	dir := t.TempDir()
+	assert.Nil(t, loadRunnerEnvironment(dir), "missing aw_info.json should yield nil")
+
+	awInfo := map[string]any{
+		"engine_id": "copilot",
+		"runner_environment": map[string]any{
+			"image_os":      "ubuntu24",
+			"image_version": "20261012.1",
+			"tool_cache":    map[string]any{"node": []string{"22.20.0"}},
+		},
+	}
+	data, err := json.Marshal(awInfo)
+	require.NoError(t, err, "marshal aw_info")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "aw_info.json"), data, 0o644), "write aw_info")
+
+	env := loadRunnerEnvironment(dir)
+	require.NotNil(t, env, "runner environment should be parsed")
+	assert.Equal(t, "20261012.1", env.ImageVersion, "image version")
+	assert.Equal(t, []string{"22.20.0"}, env.ToolCache["node"], "tool cache versions")
+}
+
+func TestComputeAuditDiff_RunnerEnvironment(t *testing.T) {
+	summary1 := &RunSummary{RunnerEnvironment: &RunnerEnvironment{ImageVersion: "20261005.1"}}
+	summary2 := &RunSummary{RunnerEnvironment: &RunnerEnvironment{ImageVersion: "20261012.1"}}
+
+	diff := computeAuditDiff(1, 2, summary1, summary2)
+	require.NotNil(t, diff.RunnerEnvironmentDiff, "runner environment diff should be included")
+	assert.False(t, isEmptyAuditDiff(diff), "runner environment changes should make the diff non-empty")
+}
@@ -0,0 +1,269 @@
+// This file builds the security signals section of the audit report.
+// It scans the downloaded prompt and agent log for prompt-injection phrasing and
+// attempts to read credential paths, and walks the unified timeline for domains
+// first contacted right after the agent fetched web content. Signals are heuristics
+// meant to point a reviewer at the parts of a run worth reading, not verdicts.
+
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/tty"
+)
+
+var auditSecuritySignalsLog = logger.New("cli:audit_security_signals")
+
+// Security signal categories
+const (
+	securitySignalPromptInjection = "prompt-injection"
+	securitySignalSecretAccess    = "secret-access"
+	securitySignalPostFetchDomain = "post-fetch-domain"
+)
+
+const (
+	// maxSecuritySignals caps the number of distinct signals kept in the report.
+	maxSecuritySignals = 50
+	// maxSecuritySignalIndicatorLen caps the matched text shown for a signal.
+	maxSecuritySignalIndicatorLen = 120
+	// postFetchDomainWindow is how long after a web fetch completes a newly contacted
+	// domain is attributed to the fetched content.
+	postFetchDomainWindow = 30 * time.Second
+)
+
+// promptInjectionPatterns match phrasing typical of instructions embedded in untrusted
+// content (issue bodies, comments, fetched pages) that try to override the agent's task.
+var promptInjectionPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)\bignore\s+(?:all\s+)?(?:the\s+|any\s+)?(?:previous|prior|above|earlier|preceding)\s+(?:instructions|prompts|directions|rules)`),
+	regexp.MustCompile(`(?i)\bdisregard\s+(?:all\s+)?(?:the\s+|your\s+)?(?:previous|prior|above|earlier|system)\s+(?:instructions|prompts|rules|guidelines)`),
+	regexp.MustCompile(`(?i)\bforget\s+(?:all\s+)?(?:your|the|previous|prior)\s+(?:previous\s+|prior\s+)?instructions`),
+	regexp.MustCompile(`(?i)\byou\s+are\s+now\s+(?:a|an|in)\s+\w+`),
+	regexp.MustCompile(`(?i)\bnew\s+(?:system\s+)?instructions\s*:`),
+	regexp.MustCompile(`(?i)\b(?:reveal|print|output|leak|send)\s+(?:your|the)\s+(?:system\s+prompt|instructions|secrets?|tokens?|credentials)`),
+	regexp.MustCompile(`(?i)\bdo\s+not\s+(?:tell|inform|alert)\s+the\s+(?:user|maintainers?|reviewers?)`),
+	regexp.MustCompile(`<\|im_start\|>|\[INST\]|</?system>`),
+}
+
+// secretAccessPatterns match reads of credential files and environment dumps.
+var secretAccessPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`/proc/(?:self|\d+)/environ`),
+	regexp.MustCompile(`\.ssh/(?:id_[a-z0-9]+|authorized_keys|known_hosts)`),
+	regexp.MustCompile(`\.aws/credentials`),
+	regexp.MustCompile(`\.git-credentials`),
+	regexp.MustCompile(`(?:~|\$HOME|/home/\w+|/root)/\.netrc`),
+	regexp.MustCompile(`\.docker/config\.json`),
+	regexp.MustCompile(`(?:/etc/shadow|/var/run/secrets)\b`),
+	regexp.MustCompile(`\bgh\s+auth\s+token\b`),
+	regexp.MustCompile(`\bprintenv\b`),
+}
+
+// SecuritySignals lists indicators of prompt injection or credential access found in a run
+type SecuritySignals struct {
+	Signals []SecuritySignal `json:"signals"`
+}
+
+// SecuritySignal is a single suspicious pattern, deduplicated by category and indicator
+type SecuritySignal struct {
+	Category    string `json:"category" console:"header:Category"`
+	Severity    string `json:"severity" console:"header:Severity"`
+	Indicator   string `json:"indicator" console:"header:Indicator"`
+	Source      string `json:"source" console:"header:Source"` // file:line of the first occurrence, or "timeline"
+	Occurrences int    `json:"occurrences" console:"header:Count"`
+	Description string `json:"description" console:"-"`
+}
+
+// securitySignalCollector deduplicates signals while preserving first-seen order.
+// Indicators are compared case-insensitively so the same phrase echoed from the prompt
+// into the agent log is counted once.
+type securitySignalCollector struct {
+	signals []SecuritySignal
+	index   map[string]int
+}
+
+func (c *securitySignalCollector) add(signal SecuritySignal) {
+	key := signal.Category + "\x00" + strings.ToLower(signal.Indicator)
+	if i, ok := c.index[key]; ok {
+		c.signals[i].Occurrences++
+		return
+	}
+	if len(c.signals) >= maxSecuritySignals {
+		return
+	}
+	if c.index == nil {
+		c.index = make(map[string]int)
+	}
+	signal.Occurrences = 1
+	c.index[key] = len(c.signals)
+	c.signals = append(c.signals, signal)
+}
+
+// buildSecuritySignals scans the run's prompt, agent log, and unified timeline.
+// Returns nil when nothing suspicious was found.
+func buildSecuritySignals(logsPath string) *SecuritySignals {
+	if logsPath == "" {
+		return nil
+	}
+
+	var collector securitySignalCollector
+	if promptPath := findPromptPath(logsPath); promptPath != "" {
+		scanFileForSecuritySignals(&collector, logsPath, promptPath, false)
+	}
+	if agentLogPath := findAgentStdioLogPath(logsPath); agentLogPath != "" {
+		scanFileForSecuritySignals(&collector, logsPath, agentLogPath, true)
+	}
+	events, err := BuildUnifiedTimeline(logsPath, false)
+	if err != nil {
+		auditSecuritySignalsLog.Printf("Failed to build unified timeline: %v", err)
+	}
+	detectPostFetchDomains(&collector, events)
+
+	if len(collector.signals) == 0 {
+		return nil
+	}
+	auditSecuritySignalsLog.Printf("Built security signals: %d distinct signals", len(collector.signals))
+	return &SecuritySignals{Signals: collector.signals}
+}
+
+// scanFileForSecuritySignals matches each line against the prompt-injection patterns
+// and, for agent logs, the secret-access patterns. The prompt is not checked for
+// secret paths because the workflow's own instructions may legitimately name them.
+func scanFileForSecuritySignals(collector *securitySignalCollector, logsPath, path string, agentLog bool) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		auditSecuritySignalsLog.Printf("Failed to open %s: %v", path, err)
+		return
+	}
+	defer f.Close()
+
+	relPath, err := filepath.Rel(logsPath, path)
+	if err != nil {
+		relPath = filepath.Base(path)
+	}
+	injectionDescription := "Instruction-override phrasing in the prompt, likely from interpolated issue, PR, or comment content"
+	if agentLog {
+		injectionDescription = "Instruction-override phrasing in content the agent read or produced"
+	}
+
+	scanner := bufio.NewScanner(f)
+	buf := make([]byte, maxScannerBufferSize)
+	scanner.Buffer(buf, maxScannerBufferSize)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+		source := relPath + ":" + strconv.Itoa(lineNum)
+		for _, pattern := range promptInjectionPatterns {
+			if match := pattern.FindString(line); match != "" {
+				collector.add(SecuritySignal{
+					Category:    securitySignalPromptInjection,
+					Severity:    "medium",
+					Indicator:   stringutil.Truncate(match, maxSecuritySignalIndicatorLen),
+					Source:      source,
+					Description: injectionDescription,
+				})
+			}
+		}
+		if !agentLog {
+			continue
+		}
+		for _, pattern := range secretAccessPatterns {
+			if match := pattern.FindString(line); match != "" {
+				collector.add(SecuritySignal{
+					Category:    securitySignalSecretAccess,
+					Severity:    "high",
+					Indicator:   match,
+					Source:      source,
+					Description: "Agent referenced a credential file or environment dump",
+				})
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		auditSecuritySignalsLog.Printf("Failed to scan %s: %v", path, err)
+	}
+}
+
+// isWebFetchTool reports whether an agent tool fetches web content (web_fetch, WebFetch, fetch, ...).
+func isWebFetchTool(name string) bool {
+	return strings.Contains(strings.ToLower(name), "fetch")
+}
+
+// detectPostFetchDomains flags domains contacted for the first time within
+// postFetchDomainWindow after a web fetch completed. Fetched pages can carry
+// instructions to send data elsewhere; a new destination right after a fetch is the
+// network trace such an instruction leaves. The fetched domain itself is contacted
+// while the fetch is running and so is not flagged.
+func detectPostFetchDomains(collector *securitySignalCollector, events []UnifiedTimelineEvent) {
+	seen := make(map[string]struct{})
+	var lastFetchDone time.Time
+	var lastFetchTool string
+	for _, evt := range events {
+		switch evt.Kind {
+		case TimelineKindAgentToolDone:
+			if isWebFetchTool(evt.ToolName) {
+				lastFetchDone = evt.Time
+				lastFetchTool = evt.ToolName
+			}
+		case TimelineKindNetworkAllowed, TimelineKindNetworkBlocked:
+			if evt.Host == "" {
+				continue
+			}
+			if _, ok := seen[evt.Host]; ok {
+				continue
+			}
+			seen[evt.Host] = struct{}{}
+			if lastFetchDone.IsZero() || evt.Time.Before(lastFetchDone) || evt.Time.Sub(lastFetchDone) > postFetchDomainWindow {
+				continue
+			}
+			severity := "medium"
+			outcome := "allowed"
+			if evt.Kind == TimelineKindNetworkBlocked {
+				severity = "high"
+				outcome = "blocked"
+			}
+			collector.add(SecuritySignal{
+				Category:  securitySignalPostFetchDomain,
+				Severity:  severity,
+				Indicator: evt.Host,
+				Source:    "timeline",
+				Description: fmt.Sprintf("First request to %s (%s) %s after %s returned",
+					evt.Host, outcome, evt.Time.Sub(lastFetchDone).Round(time.Millisecond), lastFetchTool),
+			})
+		}
+	}
+}
+
+// renderConsoleSecuritySignals renders the security signals section of the audit report
+func renderConsoleSecuritySignals(signals *SecuritySignals) {
+	if signals == nil || len(signals.Signals) == 0 {
+		return
+	}
+
+	rows := make([][]string, 0, len(signals.Signals))
+	for _, signal := range signals.Signals {
+		rows = append(rows, []string{
+			signal.Category,
+			signal.Severity,
+			signal.Indicator,
+			signal.Source,
+			strconv.Itoa(signal.Occurrences),
+		})
+	}
+	fmt.Fprint(os.Stderr, console.RenderTable(console.TableConfig{
+		Title:   fmt.Sprintf("Security signals (%d)", len(signals.Signals)),
+		Headers: []string{"Category", "Severity", "Indicator", "Source", "Count"},
+		Rows:    rows,
+		TTYFunc: tty.IsStderrTerminal,
+	}))
+	fmt.Fprintln(os.Stderr, "  Signals are heuristics; review the referenced log lines before acting on them.")
+}
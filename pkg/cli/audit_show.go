@@ -0,0 +1,403 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/fileutil"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+	"github.com/spf13/cobra"
+)
+
+var auditShowLog = logger.New("cli:audit_show")
+
+const (
+	// auditShowPatchFile is the legacy patch artifact name; branch-named patches use aw-<branch>.patch.
+	auditShowPatchFile = "aw.patch"
+	// auditShowSafeOutputFile is the raw safe output stream written by the agent.
+	auditShowSafeOutputFile = "safe_output.jsonl"
+)
+
+// Safe output entry verdicts reported by audit show.
+const (
+	SafeOutputVerdictAccepted = "accepted" // Entry passed validation in the agent job
+	SafeOutputVerdictRejected = "rejected" // Entry was dropped by validation in the agent job
+	SafeOutputVerdictUnknown  = "unknown"  // agent_output.json was not downloaded, so the verdict cannot be derived
+)
+
+// validationErrorLinePattern matches the "Line N: message" errors written by collect_ndjson_output.cjs.
+var validationErrorLinePattern = regexp.MustCompile(`^Line (\d+): (.*)$`)
+
+// AuditShowPatchFile summarizes the changes to a single file in a patch.
+type AuditShowPatchFile struct {
+	Path      string `json:"path"`
+	Additions int    `json:"additions"`
+	Deletions int    `json:"deletions"`
+}
+
+// AuditShowPatch is the parsed content of a patch artifact.
+type AuditShowPatch struct {
+	Files     []AuditShowPatchFile `json:"files"`
+	Additions int                  `json:"additions"`
+	Deletions int                  `json:"deletions"`
+	Content   string               `json:"content"`
+}
+
+// AuditShowSafeOutputEntry is a single line of safe_output.jsonl with its validation verdict.
+type AuditShowSafeOutputEntry struct {
+	Line    int             `json:"line"`
+	Type    string          `json:"type,omitempty"`
+	Verdict string          `json:"verdict"`
+	Errors  []string        `json:"errors,omitempty"`
+	Entry   json.RawMessage `json:"entry,omitempty"`
+	Raw     string          `json:"raw,omitempty"` // Original line when it is not valid JSON
+}
+
+// AuditShowResult is the output of audit show for one artifact file.
+type AuditShowResult struct {
+	RunID            int64                      `json:"run_id"`
+	File             string                     `json:"file"`
+	Patch            *AuditShowPatch            `json:"patch,omitempty"`
+	SafeOutputs      []AuditShowSafeOutputEntry `json:"safe_outputs,omitempty"`
+	ValidationFile   string                     `json:"validation_file,omitempty"`
+	ValidationErrors []string                   `json:"validation_errors,omitempty"` // Errors not tied to a line
+}
+
+// NewAuditShowSubcommand creates the audit show subcommand.
+func NewAuditShowSubcommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show <run-id-or-url>",
+		Short: "Render an artifact file of a downloaded workflow run",
+		Long: `Render an artifact file of a workflow run that has already been downloaded to the logs
+cache, instead of opening the raw file by hand.
+
+Supported files:
+  aw.patch            Stat summary followed by the colorized patch. Branch-named patches
+                      (aw-<branch>.patch) are used when the legacy aw.patch is absent, or
+                      can be selected by name.
+  safe_output.jsonl   Each safe output entry pretty-printed with its validation verdict.
+                      Verdicts are derived from the validation errors recorded in
+                      agent_output.json; they are reported as "unknown" when that file
+                      was not downloaded.
+
+The command reads local files only; it does not call the GitHub API. Download the run first
+with '` + string(constants.CLIExtensionPrefix) + ` audit <run-id>' or '` + string(constants.CLIExtensionPrefix) + ` logs'.`,
+		Example: `  ` + string(constants.CLIExtensionPrefix) + ` audit show 1234567890 --file aw.patch                # Patch with stat summary
+  ` + string(constants.CLIExtensionPrefix) + ` audit show 1234567890 --file aw-fix-typo.patch       # A branch-named patch
+  ` + string(constants.CLIExtensionPrefix) + ` audit show 1234567890 --file safe_output.jsonl       # Safe outputs with verdicts
+  ` + string(constants.CLIExtensionPrefix) + ` audit show 1234567890 --file safe_output.jsonl --json  # Safe outputs as JSON`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			components, err := parser.ParseRunURLExtended(args[0])
+			if err != nil {
+				return err
+			}
+			outputDir, _ := cmd.Flags().GetString("output")
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			file, _ := cmd.Flags().GetString("file")
+			return RunAuditShow(components.Number, outputDir, file, jsonOutput)
+		},
+	}
+
+	addOutputFlag(cmd, defaultLogsOutputDir)
+	addJSONFlag(cmd)
+	cmd.Flags().String("file", "", "Artifact file to render: aw.patch, aw-<branch>.patch or safe_output.jsonl")
+	_ = cmd.MarkFlagRequired("file")
+	RegisterDirFlagCompletion(cmd, "output")
+	_ = cmd.RegisterFlagCompletionFunc("file", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{auditShowPatchFile, auditShowSafeOutputFile}, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	return cmd
+}
+
+// RunAuditShow renders an artifact file of a cached run.
+func RunAuditShow(runID int64, outputDir, file string, jsonOutput bool) error {
+	auditShowLog.Printf("Starting audit show: run=%d, dir=%s, file=%s", runID, outputDir, file)
+
+	if !isAuditShowPatchName(file) && file != auditShowSafeOutputFile {
+		return fmt.Errorf("unsupported --file %q: must be %s, aw-<branch>.patch or %s", file, auditShowPatchFile, auditShowSafeOutputFile)
+	}
+
+	runDir := filepath.Join(outputDir, fmt.Sprintf("run-%d", runID))
+	if !fileutil.DirExists(runDir) {
+		return fmt.Errorf("run %d has not been downloaded to %s\n\nRun '%s audit %d' to download it first", runID, outputDir, string(constants.CLIExtensionPrefix), runID)
+	}
+
+	result, err := showAuditRunFile(runDir, runID, file)
+	if err != nil {
+		return err
+	}
+	if jsonOutput {
+		return renderAuditShowJSON(result)
+	}
+	renderAuditShowPretty(result)
+	return nil
+}
+
+// isAuditShowPatchName reports whether name refers to a patch artifact.
+func isAuditShowPatchName(name string) bool {
+	if name == auditShowPatchFile {
+		return true
+	}
+	matched, _ := filepath.Match("aw-*.patch", name)
+	return matched
+}
+
+// showAuditRunFile locates and parses the requested artifact file within a run directory.
+func showAuditRunFile(runDir string, runID int64, file string) (*AuditShowResult, error) {
+	path := findAuditArtifactFile(runDir, file)
+	if path == "" && file == auditShowPatchFile {
+		path = findAuditBranchPatchFile(runDir)
+	}
+	if path == "" {
+		return nil, fmt.Errorf("%s was not found in the artifacts of run %d; the run may not have produced it", file, runID)
+	}
+
+	result := &AuditShowResult{RunID: runID, File: relativeToRunDir(runDir, path)}
+	if isAuditShowPatchName(file) {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		result.Patch = parseAuditPatch(string(content))
+		auditShowLog.Printf("Parsed patch %s: files=%d, +%d -%d", result.File, len(result.Patch.Files), result.Patch.Additions, result.Patch.Deletions)
+		return result, nil
+	}
+
+	lineErrors := map[int][]string{}
+	validationAvailable := false
+	if outputPath := findAuditArtifactFile(runDir, constants.AgentOutputFilename); outputPath != "" {
+		errs, err := readAgentOutputValidationErrors(outputPath)
+		if err != nil {
+			auditShowLog.Printf("Ignoring unreadable %s: %v", outputPath, err)
+		} else {
+			validationAvailable = true
+			result.ValidationFile = relativeToRunDir(runDir, outputPath)
+			lineErrors, result.ValidationErrors = groupValidationErrorsByLine(errs)
+		}
+	}
+
+	entries, err := readAuditSafeOutputEntries(path, lineErrors, validationAvailable)
+	if err != nil {
+		return nil, err
+	}
+	result.SafeOutputs = entries
+	auditShowLog.Printf("Parsed %d safe output entries from %s (validation available: %v)", len(entries), result.File, validationAvailable)
+	return result, nil
+}
+
+// findAuditArtifactFile searches the run directory tree for a file with the given name.
+// Artifacts are stored either at the run root or inside their artifact directory.
+func findAuditArtifactFile(runDir, name string) string {
+	direct := filepath.Join(runDir, name)
+	if fileutil.FileExists(direct) {
+		return direct
+	}
+	var foundPath string
+	if walkErr := filepath.Walk(runDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info == nil {
+			return nil
+		}
+		if !info.IsDir() && strings.EqualFold(info.Name(), name) {
+			foundPath = path
+			return errWalkStop
+		}
+		return nil
+	}); walkErr != nil && !errors.Is(walkErr, errWalkStop) {
+		auditShowLog.Printf("walk error in %s: %v", runDir, walkErr)
+	}
+	return foundPath
+}
+
+// findAuditBranchPatchFile returns the first branch-named patch (aw-*.patch) in the run directory tree.
+func findAuditBranchPatchFile(runDir string) string {
+	var matches []string
+	_ = filepath.Walk(runDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info == nil || info.IsDir() {
+			return nil
+		}
+		if matched, _ := filepath.Match("aw-*.patch", info.Name()); matched {
+			matches = append(matches, path)
+		}
+		return nil
+	})
+	if len(matches) == 0 {
+		return ""
+	}
+	sort.Strings(matches)
+	if len(matches) > 1 {
+		auditShowLog.Printf("Found %d branch patches, showing %s", len(matches), matches[0])
+	}
+	return matches[0]
+}
+
+// relativeToRunDir returns path relative to the run directory, or path itself when that fails.
+func relativeToRunDir(runDir, path string) string {
+	if rel, err := filepath.Rel(runDir, path); err == nil {
+		return rel
+	}
+	return path
+}
+
+// parseAuditPatch computes per-file addition and deletion counts for a git patch. Hunk headers
+// are used to bound each hunk so format-patch commit messages and signatures are not counted.
+func parseAuditPatch(content string) *AuditShowPatch {
+	patch := &AuditShowPatch{Files: []AuditShowPatchFile{}, Content: content}
+	var current *AuditShowPatchFile
+	oldRemaining, newRemaining := 0, 0
+
+	for line := range strings.SplitSeq(content, "\n") {
+		if oldRemaining > 0 || newRemaining > 0 {
+			switch {
+			case strings.HasPrefix(line, "+"):
+				current.Additions++
+				newRemaining--
+			case strings.HasPrefix(line, "-"):
+				current.Deletions++
+				oldRemaining--
+			case strings.HasPrefix(line, `\`):
+				// "\ No newline at end of file" does not consume a line
+			default:
+				oldRemaining--
+				newRemaining--
+			}
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			patch.Files = append(patch.Files, AuditShowPatchFile{Path: patchFilePath(line)})
+			current = &patch.Files[len(patch.Files)-1]
+		case strings.HasPrefix(line, "+++ b/") && current != nil:
+			current.Path = strings.TrimPrefix(line, "+++ b/")
+		case strings.HasPrefix(line, "@@") && current != nil:
+			oldRemaining, newRemaining = parseHunkHeaderCounts(line)
+		}
+	}
+
+	for _, f := range patch.Files {
+		patch.Additions += f.Additions
+		patch.Deletions += f.Deletions
+	}
+	return patch
+}
+
+// patchFilePath extracts the destination path from a "diff --git a/<path> b/<path>" line.
+func patchFilePath(line string) string {
+	rest := strings.TrimPrefix(line, "diff --git ")
+	if i := strings.LastIndex(rest, " b/"); i >= 0 {
+		return rest[i+3:]
+	}
+	return rest
+}
+
+// hunkHeaderPattern matches "@@ -start[,count] +start[,count] @@".
+var hunkHeaderPattern = regexp.MustCompile(`^@@ -\d+(?:,(\d+))? \+\d+(?:,(\d+))? @@`)
+
+// parseHunkHeaderCounts returns the old and new line counts of a hunk header. An omitted
+// count means one line.
+func parseHunkHeaderCounts(line string) (int, int) {
+	m := hunkHeaderPattern.FindStringSubmatch(line)
+	if m == nil {
+		return 0, 0
+	}
+	count := func(s string) int {
+		if s == "" {
+			return 1
+		}
+		n, _ := strconv.Atoi(s)
+		return n
+	}
+	return count(m[1]), count(m[2])
+}
+
+// readAgentOutputValidationErrors reads the errors array of agent_output.json.
+func readAgentOutputValidationErrors(path string) ([]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var output struct {
+		Errors []string `json:"errors"`
+	}
+	if err := json.Unmarshal(content, &output); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return output.Errors, nil
+}
+
+// groupValidationErrorsByLine splits validation errors into per-line messages and errors that
+// are not tied to a line of safe_output.jsonl.
+func groupValidationErrorsByLine(errs []string) (map[int][]string, []string) {
+	byLine := map[int][]string{}
+	var general []string
+	for _, e := range errs {
+		if m := validationErrorLinePattern.FindStringSubmatch(e); m != nil {
+			if line, err := strconv.Atoi(m[1]); err == nil {
+				byLine[line] = append(byLine[line], m[2])
+				continue
+			}
+		}
+		general = append(general, e)
+	}
+	return byLine, general
+}
+
+// readAuditSafeOutputEntries parses safe_output.jsonl and attaches a verdict to each entry.
+// Line numbers match the 1-based numbering used by the validation errors; blank lines are skipped.
+func readAuditSafeOutputEntries(path string, lineErrors map[int][]string, validationAvailable bool) ([]AuditShowSafeOutputEntry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	entries := []AuditShowSafeOutputEntry{}
+	scanner := bufio.NewScanner(file)
+	buf := make([]byte, maxScannerBufferSize)
+	scanner.Buffer(buf, maxScannerBufferSize)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		entry := AuditShowSafeOutputEntry{Line: lineNumber, Errors: lineErrors[lineNumber]}
+		var fields map[string]any
+		if err := json.Unmarshal([]byte(line), &fields); err != nil {
+			entry.Raw = line
+		} else {
+			entry.Entry = json.RawMessage(line)
+			if t, ok := fields["type"].(string); ok {
+				entry.Type = t
+			}
+		}
+
+		switch {
+		case len(entry.Errors) > 0:
+			entry.Verdict = SafeOutputVerdictRejected
+		case validationAvailable:
+			entry.Verdict = SafeOutputVerdictAccepted
+		default:
+			entry.Verdict = SafeOutputVerdictUnknown
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", path, err)
+	}
+	return entries, nil
+}
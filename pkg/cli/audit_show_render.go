@@ -0,0 +1,139 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/styles"
+	"github.com/github/gh-aw/pkg/tty"
+)
+
+var auditShowRenderLog = logger.New("cli:audit_show_render")
+
+// renderAuditShowJSON outputs the audit show result as JSON to stdout.
+func renderAuditShowJSON(result *AuditShowResult) error {
+	auditShowRenderLog.Printf("Rendering audit show as JSON: file=%s", result.File)
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
+
+// renderAuditShowPretty outputs the summary to stderr and the file content to stdout, so the
+// content can be piped to a pager or other tools. Colors are only applied on a terminal.
+func renderAuditShowPretty(result *AuditShowResult) {
+	auditShowRenderLog.Printf("Rendering audit show as pretty output: file=%s", result.File)
+	fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Audit Show — Run %d: %s", result.RunID, result.File)))
+	fmt.Fprintln(os.Stderr)
+
+	colorize := tty.IsStdoutTerminal()
+	if result.Patch != nil {
+		renderAuditShowPatchStat(result.Patch)
+		fmt.Fprint(os.Stdout, renderColorizedPatch(result.Patch.Content, colorize))
+		return
+	}
+	renderAuditShowSafeOutputs(result, colorize)
+}
+
+// renderAuditShowPatchStat prints a git-style stat summary of the patch to stderr.
+func renderAuditShowPatchStat(patch *AuditShowPatch) {
+	if len(patch.Files) == 0 {
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage("Patch does not contain any file changes"))
+		return
+	}
+	rows := make([][]string, 0, len(patch.Files))
+	for _, f := range patch.Files {
+		rows = append(rows, []string{f.Path, fmt.Sprintf("+%d", f.Additions), fmt.Sprintf("-%d", f.Deletions)})
+	}
+	fmt.Fprint(os.Stderr, console.RenderTable(console.TableConfig{
+		Title:     fmt.Sprintf("%d file(s) changed", len(patch.Files)),
+		Headers:   []string{"File", "Additions", "Deletions"},
+		Rows:      rows,
+		ShowTotal: true,
+		TotalRow:  []string{"Total", fmt.Sprintf("+%d", patch.Additions), fmt.Sprintf("-%d", patch.Deletions)},
+		TTYFunc:   tty.IsStderrTerminal,
+	}))
+	fmt.Fprintln(os.Stderr)
+}
+
+// renderColorizedPatch colors diff headers, hunk headers, additions and deletions.
+func renderColorizedPatch(content string, colorize bool) string {
+	if !colorize {
+		return content
+	}
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "diff --git "), strings.HasPrefix(line, "+++ "), strings.HasPrefix(line, "--- "):
+			lines[i] = styles.FilePath.Render(line)
+		case strings.HasPrefix(line, "@@"):
+			lines[i] = styles.Info.Render(line)
+		case strings.HasPrefix(line, "+"):
+			lines[i] = styles.Success.Render(line)
+		case strings.HasPrefix(line, "-"):
+			lines[i] = styles.Error.Render(line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderAuditShowSafeOutputs prints each safe output entry with its verdict to stdout and a
+// verdict summary to stderr.
+func renderAuditShowSafeOutputs(result *AuditShowResult, colorize bool) {
+	counts := map[string]int{}
+	for _, entry := range result.SafeOutputs {
+		counts[entry.Verdict]++
+	}
+	if len(result.SafeOutputs) == 0 {
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage("No safe output entries were written"))
+	} else {
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("%d entries: %d accepted, %d rejected, %d unknown",
+			len(result.SafeOutputs), counts[SafeOutputVerdictAccepted], counts[SafeOutputVerdictRejected], counts[SafeOutputVerdictUnknown])))
+	}
+	if result.ValidationFile == "" {
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage("agent_output.json was not found; verdicts cannot be derived for this run"))
+	}
+	for _, e := range result.ValidationErrors {
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(e))
+	}
+	fmt.Fprintln(os.Stderr)
+
+	for _, entry := range result.SafeOutputs {
+		fmt.Fprintln(os.Stdout, formatAuditShowEntryHeader(entry, colorize))
+		for _, e := range entry.Errors {
+			fmt.Fprintln(os.Stdout, "  ✗ "+e)
+		}
+		body := entry.Raw
+		var indented bytes.Buffer
+		if entry.Entry != nil && json.Indent(&indented, entry.Entry, "  ", "  ") == nil {
+			body = indented.String()
+		}
+		fmt.Fprintln(os.Stdout, "  "+body)
+		fmt.Fprintln(os.Stdout)
+	}
+}
+
+// formatAuditShowEntryHeader formats the "Line N · type · verdict" header of an entry.
+func formatAuditShowEntryHeader(entry AuditShowSafeOutputEntry, colorize bool) string {
+	entryType := entry.Type
+	if entryType == "" {
+		entryType = "(no type)"
+	}
+	verdict := entry.Verdict
+	if colorize {
+		switch verdict {
+		case SafeOutputVerdictAccepted:
+			verdict = styles.Success.Render(verdict)
+		case SafeOutputVerdictRejected:
+			verdict = styles.Error.Render(verdict)
+		default:
+			verdict = styles.Warning.Render(verdict)
+		}
+		entryType = styles.Command.Render(entryType)
+	}
+	return fmt.Sprintf("Line %d · %s · %s", entry.Line, entryType, verdict)
+}
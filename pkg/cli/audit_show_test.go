@@ -0,0 +1,126 @@
+//go:build !integration
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const auditShowTestPatch = `From 1234567 Mon Sep 17 00:00:00 2001
+From: agent <agent@example.com>
+Subject: [PATCH] Fix typo
+
+---
+ README.md | 3 ++-
+ main.go   | 1 +
+ 2 files changed, 3 insertions(+), 1 deletion(-)
+
+diff --git a/README.md b/README.md
+index 1111111..2222222 100644
+--- a/README.md
++++ b/README.md
+@@ -1,3 +1,4 @@
+ # Title
+-Teh project
++The project
++
+ More text
+diff --git a/main.go b/main.go
+index 3333333..4444444 100644
+--- a/main.go
++++ b/main.go
+@@ -10 +10,2 @@ func main() {
+ 	run()
++	cleanup()
+--
+2.45.0
+`
+
+func TestParseAuditPatch(t *testing.T) {
+	patch := parseAuditPatch(auditShowTestPatch)
+
+	assert.Equal(t, []AuditShowPatchFile{
+		{Path: "README.md", Additions: 2, Deletions: 1},
+		{Path: "main.go", Additions: 1, Deletions: 0},
+	}, patch.Files, "per-file stats should ignore the commit message and signature")
+	assert.Equal(t, 3, patch.Additions, "total additions")
+	assert.Equal(t, 1, patch.Deletions, "total deletions")
+}
+
+func TestShowAuditRunFilePatchFallsBackToBranchPatch(t *testing.T) {
+	runDir := filepath.Join(t.TempDir(), "run-7")
+	require.NoError(t, os.MkdirAll(runDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(runDir, "aw-fix-typo.patch"), []byte(auditShowTestPatch), 0644))
+
+	result, err := showAuditRunFile(runDir, 7, "aw.patch")
+	require.NoError(t, err, "branch patch should be used when aw.patch is absent")
+	assert.Equal(t, "aw-fix-typo.patch", result.File, "result should name the file that was shown")
+	require.NotNil(t, result.Patch, "patch should be parsed")
+	assert.Len(t, result.Patch.Files, 2, "patch files should be listed")
+}
+
+func TestShowAuditRunFileSafeOutputVerdicts(t *testing.T) {
+	runDir := filepath.Join(t.TempDir(), "run-8")
+	require.NoError(t, os.MkdirAll(filepath.Join(runDir, "agent"), 0755))
+
+	safeOutputs := strings.Join([]string{
+		`{"type":"add_comment","body":"Looks good"}`,
+		``,
+		`{"type":"create_issue","title":""}`,
+		`not json`,
+	}, "\n")
+	require.NoError(t, os.WriteFile(filepath.Join(runDir, "safe_output.jsonl"), []byte(safeOutputs), 0644))
+
+	t.Run("verdicts are unknown without agent_output.json", func(t *testing.T) {
+		result, err := showAuditRunFile(runDir, 8, "safe_output.jsonl")
+		require.NoError(t, err, "safe outputs should be read")
+		require.Len(t, result.SafeOutputs, 3, "blank lines should be skipped")
+		for _, entry := range result.SafeOutputs {
+			assert.Equal(t, SafeOutputVerdictUnknown, entry.Verdict, "verdict should be unknown for line %d", entry.Line)
+		}
+	})
+
+	agentOutput := `{"items":[{"type":"add_comment","body":"Looks good"}],"errors":["Line 3: title is required","Line 4: Invalid JSON - JSON parsing failed","Too many errors"]}`
+	require.NoError(t, os.WriteFile(filepath.Join(runDir, "agent", "agent_output.json"), []byte(agentOutput), 0644))
+
+	t.Run("verdicts are derived from validation errors", func(t *testing.T) {
+		result, err := showAuditRunFile(runDir, 8, "safe_output.jsonl")
+		require.NoError(t, err, "safe outputs should be read")
+		assert.Equal(t, filepath.Join("agent", "agent_output.json"), result.ValidationFile, "nested agent_output.json should be found")
+		assert.Equal(t, []string{"Too many errors"}, result.ValidationErrors, "errors without a line should be reported separately")
+		require.Len(t, result.SafeOutputs, 3, "blank lines should be skipped")
+
+		assert.Equal(t, 1, result.SafeOutputs[0].Line)
+		assert.Equal(t, "add_comment", result.SafeOutputs[0].Type)
+		assert.Equal(t, SafeOutputVerdictAccepted, result.SafeOutputs[0].Verdict)
+
+		assert.Equal(t, 3, result.SafeOutputs[1].Line, "line numbers should match the file")
+		assert.Equal(t, SafeOutputVerdictRejected, result.SafeOutputs[1].Verdict)
+		assert.Equal(t, []string{"title is required"}, result.SafeOutputs[1].Errors)
+
+		assert.Equal(t, SafeOutputVerdictRejected, result.SafeOutputs[2].Verdict)
+		assert.Equal(t, "not json", result.SafeOutputs[2].Raw, "invalid lines should be kept verbatim")
+	})
+}
+
+func TestRunAuditShowErrors(t *testing.T) {
+	err := RunAuditShow(99, t.TempDir(), "notes.txt", true)
+	require.Error(t, err, "unsupported file should be rejected")
+	assert.Contains(t, err.Error(), "unsupported --file", "error should name the flag")
+
+	err = RunAuditShow(99, t.TempDir(), "aw.patch", true)
+	require.Error(t, err, "missing run should be reported")
+	assert.Contains(t, err.Error(), "has not been downloaded", "error should explain how to download the run")
+
+	outputDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(outputDir, "run-99"), 0755))
+	err = RunAuditShow(99, outputDir, "aw.patch", true)
+	require.Error(t, err, "missing artifact should be reported")
+	assert.Contains(t, err.Error(), "was not found", "error should say the file is missing")
+}
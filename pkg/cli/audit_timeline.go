@@ -0,0 +1,364 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/timeutil"
+)
+
+var auditTimelineLog = logger.New("cli:audit_timeline")
+
+// auditTimelineWidth is the number of columns used for the bars of the ASCII waterfall.
+const auditTimelineWidth = 60
+
+// auditTimelineMaxLabel is the longest job or step label printed before a bar.
+const auditTimelineMaxLabel = 40
+
+// auditTimelineFile is the HTML waterfall written to the run output directory.
+const auditTimelineFile = "timeline.html"
+
+// auditTimeline is the job and step waterfall rendered by audit --timeline.
+type auditTimeline struct {
+	start time.Time // when the first job was queued
+	end   time.Time // when the last job completed
+	jobs  []auditTimelineJob
+}
+
+// auditTimelineJob is one job of the waterfall. The job is queued from queuedAt until
+// startedAt and running from startedAt until completedAt.
+type auditTimelineJob struct {
+	name        string
+	conclusion  string
+	queuedAt    time.Time
+	startedAt   time.Time
+	completedAt time.Time
+	critical    bool // the job is on the run's critical path
+	steps       []auditTimelineStep
+}
+
+// auditTimelineStep is one step of a job. Steps run back to back inside their job,
+// so they have no queued time.
+type auditTimelineStep struct {
+	name        string
+	conclusion  string
+	startedAt   time.Time
+	completedAt time.Time
+}
+
+func (j auditTimelineJob) queued() time.Duration  { return j.startedAt.Sub(j.queuedAt) }
+func (j auditTimelineJob) running() time.Duration { return j.completedAt.Sub(j.startedAt) }
+
+func (s auditTimelineStep) running() time.Duration { return s.completedAt.Sub(s.startedAt) }
+
+// buildAuditTimeline builds the waterfall from the run's jobs. Jobs that were skipped
+// or have not completed are left out, as are steps without timestamps (for example
+// when the job details come from a run summary cached by an older version).
+func buildAuditTimeline(jobDetails []JobInfoWithDuration) auditTimeline {
+	var timeline auditTimeline
+	for _, detail := range jobDetails {
+		if detail.Conclusion == "skipped" || detail.StartedAt.IsZero() || detail.CompletedAt.Before(detail.StartedAt) {
+			continue
+		}
+		job := auditTimelineJob{
+			name:        detail.Name,
+			conclusion:  detail.Conclusion,
+			queuedAt:    detail.CreatedAt,
+			startedAt:   detail.StartedAt,
+			completedAt: detail.CompletedAt,
+		}
+		if job.queuedAt.IsZero() || job.queuedAt.After(job.startedAt) {
+			job.queuedAt = job.startedAt
+		}
+		for _, step := range detail.Steps {
+			if step.Conclusion == "skipped" || step.StartedAt.IsZero() || step.CompletedAt.Before(step.StartedAt) {
+				continue
+			}
+			job.steps = append(job.steps, auditTimelineStep{
+				name:        step.Name,
+				conclusion:  step.Conclusion,
+				startedAt:   step.StartedAt,
+				completedAt: step.CompletedAt,
+			})
+		}
+		timeline.jobs = append(timeline.jobs, job)
+	}
+	if len(timeline.jobs) == 0 {
+		return timeline
+	}
+
+	slices.SortStableFunc(timeline.jobs, func(a, b auditTimelineJob) int {
+		if c := a.queuedAt.Compare(b.queuedAt); c != 0 {
+			return c
+		}
+		return a.startedAt.Compare(b.startedAt)
+	})
+	timeline.start = timeline.jobs[0].queuedAt
+	for _, job := range timeline.jobs {
+		if job.completedAt.After(timeline.end) {
+			timeline.end = job.completedAt
+		}
+	}
+	markAuditTimelineCriticalPath(timeline.jobs)
+	auditTimelineLog.Printf("Built timeline: jobs=%d, duration=%s", len(timeline.jobs), timeline.end.Sub(timeline.start))
+	return timeline
+}
+
+// markAuditTimelineCriticalPath marks the chain of jobs that determined the run's
+// duration. The jobs API does not expose needs:, so the chain is reconstructed from
+// timestamps: it ends with the job that completed last, and each job's predecessor is
+// the job that completed last before it started.
+func markAuditTimelineCriticalPath(jobs []auditTimelineJob) {
+	current := -1
+	for i := range jobs {
+		if current < 0 || jobs[i].completedAt.After(jobs[current].completedAt) {
+			current = i
+		}
+	}
+	for current >= 0 {
+		jobs[current].critical = true
+		next := -1
+		for i := range jobs {
+			if jobs[i].critical || jobs[i].completedAt.After(jobs[current].startedAt) {
+				continue
+			}
+			if next < 0 || jobs[i].completedAt.After(jobs[next].completedAt) {
+				next = i
+			}
+		}
+		current = next
+	}
+}
+
+// criticalPath returns the names of the critical path jobs in the order they ran.
+func (t auditTimeline) criticalPath() []string {
+	var names []string
+	for _, job := range t.jobs {
+		if job.critical {
+			names = append(names, job.name)
+		}
+	}
+	return names
+}
+
+// auditTimelineBar draws the queued (░) and running (█) segments of one row, scaled so
+// that the full width spans the run. Running segments are at least one column wide so
+// that short jobs and steps stay visible.
+func auditTimelineBar(timeline auditTimeline, queuedAt, startedAt, completedAt time.Time) string {
+	total := timeline.end.Sub(timeline.start)
+	column := func(t time.Time) int {
+		if total <= 0 {
+			return 0
+		}
+		c := int(math.Round(float64(t.Sub(timeline.start)) / float64(total) * auditTimelineWidth))
+		return min(max(c, 0), auditTimelineWidth)
+	}
+	queued, started, completed := column(queuedAt), column(startedAt), column(completedAt)
+	if completed == started {
+		if completed < auditTimelineWidth {
+			completed++
+		} else {
+			started--
+			queued = min(queued, started)
+		}
+	}
+	return strings.Repeat(" ", queued) +
+		strings.Repeat("░", started-queued) +
+		strings.Repeat("█", completed-started) +
+		strings.Repeat(" ", auditTimelineWidth-completed)
+}
+
+// renderAuditTimeline renders the ASCII waterfall. Steps are listed under the critical
+// path jobs only, and steps that took less than a second are left out, so the output
+// points at the setup time worth optimizing. It returns "" when no job has timestamps.
+func renderAuditTimeline(timeline auditTimeline) string {
+	if len(timeline.jobs) == 0 {
+		return ""
+	}
+
+	labelWidth := 0
+	for _, job := range timeline.jobs {
+		labelWidth = max(labelWidth, len(job.name)+2)
+		if job.critical {
+			for _, step := range job.steps {
+				labelWidth = max(labelWidth, len(step.name)+4)
+			}
+		}
+	}
+	labelWidth = min(labelWidth, auditTimelineMaxLabel)
+	writeRow := func(sb *strings.Builder, label, bar, detail string) {
+		fmt.Fprintf(sb, "%-*s %s %s\n", labelWidth, stringutil.Truncate(label, labelWidth), bar, detail)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("\n")
+	sb.WriteString(console.FormatInfoMessage("Job Timeline"))
+	sb.WriteString("\n\n")
+	fmt.Fprintf(&sb, "Duration      : %s\n", timeutil.FormatDuration(timeline.end.Sub(timeline.start)))
+	fmt.Fprintf(&sb, "Critical path : %s\n", strings.Join(timeline.criticalPath(), " → "))
+	sb.WriteString("Legend        : ░ queued  █ running  * critical path\n\n")
+
+	for _, job := range timeline.jobs {
+		marker := "  "
+		if job.critical {
+			marker = "* "
+		}
+		detail := timeutil.FormatDuration(job.running())
+		if queued := job.queued(); queued >= time.Second {
+			detail += " (queued " + timeutil.FormatDuration(queued) + ")"
+		}
+		if job.conclusion != "" && job.conclusion != "success" {
+			detail += " " + job.conclusion
+		}
+		writeRow(&sb, marker+job.name, auditTimelineBar(timeline, job.queuedAt, job.startedAt, job.completedAt), detail)
+		if !job.critical {
+			continue
+		}
+		for _, step := range job.steps {
+			if step.running() < time.Second {
+				continue
+			}
+			detail := timeutil.FormatDuration(step.running())
+			if step.conclusion != "" && step.conclusion != "success" {
+				detail += " " + step.conclusion
+			}
+			writeRow(&sb, "    "+step.name, auditTimelineBar(timeline, step.startedAt, step.startedAt, step.completedAt), detail)
+		}
+	}
+	return sb.String()
+}
+
+// auditTimelineHTMLRow is one bar of the HTML waterfall. Offsets and widths are
+// percentages of the run duration.
+type auditTimelineHTMLRow struct {
+	Label        string
+	Step         bool
+	Critical     bool
+	QueuedLeft   float64
+	QueuedWidth  float64
+	RunningLeft  float64
+	RunningWidth float64
+	Detail       string
+}
+
+var auditTimelineHTMLTemplate = template.Must(template.New("timeline").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", sans-serif; margin: 2em; color: #1f2328; }
+table { border-collapse: collapse; width: 100%; }
+td { padding: 2px 8px; font-size: 13px; white-space: nowrap; }
+td.label { width: 1%; }
+tr.step td.label { padding-left: 2em; color: #59636e; }
+tr.critical td.label { font-weight: 600; }
+td.bar { position: relative; width: 100%; }
+td.bar span { position: absolute; top: 4px; bottom: 4px; min-width: 2px; }
+span.queued { background: #d1d9e0; }
+span.running { background: #0969da; }
+tr.critical span.running { background: #cf222e; }
+tr.step span.running { opacity: 0.6; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p>Duration: {{.Duration}}. Critical path (red): {{.CriticalPath}}. Grey is time queued, color is time running.</p>
+<table>
+{{- range .Rows}}
+<tr class="{{if .Step}}step{{else}}job{{end}}{{if .Critical}} critical{{end}}">
+<td class="label">{{.Label}}</td>
+<td class="bar">{{if gt .QueuedWidth 0.0}}<span class="queued" style="left: {{printf "%.3f" .QueuedLeft}}%; width: {{printf "%.3f" .QueuedWidth}}%"></span>{{end}}<span class="running" style="left: {{printf "%.3f" .RunningLeft}}%; width: {{printf "%.3f" .RunningWidth}}%"></span></td>
+<td>{{.Detail}}</td>
+</tr>
+{{- end}}
+</table>
+</body>
+</html>
+`))
+
+// renderAuditTimelineHTML renders the HTML waterfall, which lists every step of every job.
+func renderAuditTimelineHTML(timeline auditTimeline, title string) (string, error) {
+	total := timeline.end.Sub(timeline.start)
+	percent := func(d time.Duration) float64 {
+		if total <= 0 {
+			return 0
+		}
+		return float64(d) / float64(total) * 100
+	}
+	var rows []auditTimelineHTMLRow
+	for _, job := range timeline.jobs {
+		detail := timeutil.FormatDuration(job.running())
+		if queued := job.queued(); queued > 0 {
+			detail += " (queued " + timeutil.FormatDuration(queued) + ")"
+		}
+		rows = append(rows, auditTimelineHTMLRow{
+			Label:        job.name,
+			Critical:     job.critical,
+			QueuedLeft:   percent(job.queuedAt.Sub(timeline.start)),
+			QueuedWidth:  percent(job.queued()),
+			RunningLeft:  percent(job.startedAt.Sub(timeline.start)),
+			RunningWidth: percent(job.running()),
+			Detail:       detail,
+		})
+		for _, step := range job.steps {
+			rows = append(rows, auditTimelineHTMLRow{
+				Label:        step.name,
+				Step:         true,
+				Critical:     job.critical,
+				RunningLeft:  percent(step.startedAt.Sub(timeline.start)),
+				RunningWidth: percent(step.running()),
+				Detail:       timeutil.FormatDuration(step.running()),
+			})
+		}
+	}
+
+	var buf bytes.Buffer
+	err := auditTimelineHTMLTemplate.Execute(&buf, map[string]any{
+		"Title":        title,
+		"Duration":     timeutil.FormatDuration(total),
+		"CriticalPath": strings.Join(timeline.criticalPath(), " → "),
+		"Rows":         rows,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to render timeline: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// renderAuditJobTimeline writes the ASCII waterfall of the run's jobs to stderr and the
+// HTML waterfall to timeline.html in the run output directory (audit --timeline).
+func renderAuditJobTimeline(processedRun ProcessedRun, runOutputDir string, redactor *auditRedactor) error {
+	timeline := buildAuditTimeline(processedRun.JobDetails)
+	if len(timeline.jobs) == 0 {
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage("No job timestamps available for this run; skipping the job timeline"))
+		return nil
+	}
+	fmt.Fprint(os.Stderr, redactor.String(renderAuditTimeline(timeline)))
+
+	title := fmt.Sprintf("Job timeline for run %d", processedRun.Run.DatabaseID)
+	if processedRun.Run.WorkflowName != "" {
+		title = fmt.Sprintf("Job timeline for %s run %d", processedRun.Run.WorkflowName, processedRun.Run.DatabaseID)
+	}
+	html, err := renderAuditTimelineHTML(timeline, title)
+	if err != nil {
+		return err
+	}
+	htmlPath := filepath.Join(runOutputDir, auditTimelineFile)
+	if err := os.WriteFile(htmlPath, []byte(redactor.String(html)), constants.FilePermPublic); err != nil {
+		return fmt.Errorf("failed to write %s: %w", htmlPath, err)
+	}
+	fmt.Fprintln(os.Stderr, console.FormatSuccessMessage("Wrote job timeline to "+htmlPath))
+	return nil
+}
@@ -0,0 +1,111 @@
+//go:build !integration
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// auditTimelineTestJobs returns a run where activation feeds agent and detection, and
+// conclusion waits for both: activation → agent → conclusion is the critical path.
+func auditTimelineTestJobs() []JobInfoWithDuration {
+	base := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	at := func(seconds int) time.Time { return base.Add(time.Duration(seconds) * time.Second) }
+	job := func(name string, created, started, completed int, steps ...JobStep) JobInfoWithDuration {
+		return JobInfoWithDuration{JobInfo: JobInfo{
+			Name:        name,
+			Conclusion:  "success",
+			CreatedAt:   at(created),
+			StartedAt:   at(started),
+			CompletedAt: at(completed),
+			Steps:       steps,
+		}}
+	}
+	step := func(name string, started, completed int) JobStep {
+		return JobStep{Name: name, Conclusion: "success", StartedAt: at(started), CompletedAt: at(completed)}
+	}
+	return []JobInfoWithDuration{
+		job("conclusion", 0, 330, 360),
+		job("agent", 0, 70, 300,
+			step("Set up job", 70, 71),
+			step("Install Copilot CLI", 71, 130),
+			step("Run agent", 130, 298),
+			step("Upload logs", 298, 298)),
+		job("activation", 0, 10, 60),
+		job("detection", 0, 75, 120),
+		{JobInfo: JobInfo{Name: "pre_activation", Conclusion: "skipped"}},
+	}
+}
+
+func TestBuildAuditTimeline(t *testing.T) {
+	timeline := buildAuditTimeline(auditTimelineTestJobs())
+
+	require.Len(t, timeline.jobs, 4, "skipped jobs should be left out")
+	var names []string
+	for _, job := range timeline.jobs {
+		names = append(names, job.name)
+	}
+	assert.Equal(t, []string{"activation", "agent", "detection", "conclusion"}, names, "jobs should be ordered by start time")
+	assert.Equal(t, 6*time.Minute, timeline.end.Sub(timeline.start), "timeline should span the run")
+	assert.Equal(t, []string{"activation", "agent", "conclusion"}, timeline.criticalPath(), "critical path should follow the last finishing predecessors")
+
+	agent := timeline.jobs[1]
+	assert.Equal(t, 70*time.Second, agent.queued(), "queued time should run from creation to start")
+	assert.Equal(t, 230*time.Second, agent.running(), "running time should run from start to completion")
+	assert.Len(t, agent.steps, 4, "steps with timestamps should be kept")
+}
+
+func TestBuildAuditTimelineWithoutTimestamps(t *testing.T) {
+	timeline := buildAuditTimeline([]JobInfoWithDuration{
+		{JobInfo: JobInfo{Name: "agent", Conclusion: "success", StartedAt: time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC), CompletedAt: time.Date(2026, 10, 1, 12, 5, 0, 0, time.UTC), Steps: []JobStep{{Name: "Run agent"}}}},
+		{JobInfo: JobInfo{Name: "queued", Status: "queued"}},
+	})
+	require.Len(t, timeline.jobs, 1, "jobs that have not run should be left out")
+	assert.Zero(t, timeline.jobs[0].queued(), "jobs without a creation time should have no queued time")
+	assert.Empty(t, timeline.jobs[0].steps, "steps without timestamps should be left out")
+	assert.Empty(t, renderAuditTimeline(buildAuditTimeline(nil)), "an empty timeline should render nothing")
+}
+
+func TestAuditTimelineBar(t *testing.T) {
+	start := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	timeline := auditTimeline{start: start, end: start.Add(60 * time.Second)}
+	at := func(seconds int) time.Time { return start.Add(time.Duration(seconds) * time.Second) }
+
+	bar := auditTimelineBar(timeline, at(0), at(15), at(30))
+	assert.Equal(t, strings.Repeat("░", 15)+strings.Repeat("█", 15)+strings.Repeat(" ", 30), bar, "bar should be scaled to the run")
+
+	bar = auditTimelineBar(timeline, at(60), at(60), at(60))
+	assert.Equal(t, strings.Repeat(" ", 59)+"█", bar, "instant rows at the end should stay visible")
+}
+
+func TestRenderAuditTimeline(t *testing.T) {
+	output := renderAuditTimeline(buildAuditTimeline(auditTimelineTestJobs()))
+
+	assert.Contains(t, output, "Critical path : activation → agent → conclusion", "critical path should be listed")
+	assert.Contains(t, output, "* agent", "critical jobs should be marked")
+	assert.Contains(t, output, "  detection", "other jobs should be listed")
+	assert.Contains(t, output, "(queued 1.2m)", "queued time should be shown")
+	assert.Contains(t, output, "    Install Copilot CLI", "steps of critical jobs should be listed")
+	assert.NotContains(t, output, "Upload logs", "steps under a second should be left out")
+}
+
+func TestRenderAuditJobTimeline(t *testing.T) {
+	dir := testutil.TempDir(t, "audit-timeline-*")
+	processedRun := ProcessedRun{Run: WorkflowRun{DatabaseID: 42, WorkflowName: "Triage <bot>"}, JobDetails: auditTimelineTestJobs()}
+
+	require.NoError(t, renderAuditJobTimeline(processedRun, dir, nil), "timeline should render")
+
+	html, err := os.ReadFile(filepath.Join(dir, auditTimelineFile))
+	require.NoError(t, err, "timeline.html should be written")
+	assert.Contains(t, string(html), "Job timeline for Triage &lt;bot&gt; run 42", "title should be escaped")
+	assert.Contains(t, string(html), `<tr class="job critical">`, "critical jobs should be highlighted")
+	assert.Contains(t, string(html), "Upload logs", "the HTML waterfall should list every step")
+}
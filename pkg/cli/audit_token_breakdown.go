@@ -0,0 +1,138 @@
+// This file builds the token breakdown section of the audit report.
+// Engine logs that report usage for each LLM response (Claude stream-json, Copilot debug
+// logs) are split into per-turn token counts, and the tokens of each turn are attributed
+// to the tools called in it to estimate which tools drive the run's token spend.
+
+package cli
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/workflow"
+)
+
+var auditTokenBreakdownLog = logger.New("cli:audit_token_breakdown")
+
+// maxConsoleTokenBreakdownRows limits the turns and tools listed in the console report
+const maxConsoleTokenBreakdownRows = 5
+
+// TokenBreakdown is the run's token usage split per turn and attributed per tool
+type TokenBreakdown struct {
+	TotalTokens int                  `json:"total_tokens"`
+	Turns       []TurnTokenBreakdown `json:"turns"`
+	Tools       []ToolTokenBreakdown `json:"tools,omitempty"`
+}
+
+// TurnTokenBreakdown is the token usage of a single LLM response
+type TurnTokenBreakdown struct {
+	Turn             int      `json:"turn"`
+	InputTokens      int      `json:"input_tokens"`
+	OutputTokens     int      `json:"output_tokens"`
+	CacheReadTokens  int      `json:"cache_read_tokens,omitempty"`
+	CacheWriteTokens int      `json:"cache_write_tokens,omitempty"`
+	TotalTokens      int      `json:"total_tokens"`
+	ToolCalls        []string `json:"tool_calls,omitempty"`
+}
+
+// ToolTokenBreakdown is the estimated number of tokens attributable to a tool.
+// A tool call costs the output tokens spent writing its arguments plus the prompt
+// growth caused by its result on the next turn, both split evenly among the calls
+// made in the same turn.
+type ToolTokenBreakdown struct {
+	Tool            string  `json:"tool"`
+	Calls           int     `json:"calls"`
+	EstimatedTokens int     `json:"estimated_tokens"`
+	Share           float64 `json:"share"` // Fraction of the run's total tokens (0-1)
+}
+
+// buildTokenBreakdown builds the token breakdown from per-turn usage parsed from the
+// engine logs. Returns nil when the engine did not report per-turn usage.
+func buildTokenBreakdown(turns []workflow.TurnTokenUsage) *TokenBreakdown {
+	if len(turns) == 0 {
+		return nil
+	}
+
+	breakdown := &TokenBreakdown{Turns: make([]TurnTokenBreakdown, 0, len(turns))}
+	toolStats := make(map[string]*ToolTokenBreakdown)
+
+	for i, turn := range turns {
+		breakdown.TotalTokens += turn.TotalTokens()
+		breakdown.Turns = append(breakdown.Turns, TurnTokenBreakdown{
+			Turn:             turn.Turn,
+			InputTokens:      turn.InputTokens,
+			OutputTokens:     turn.OutputTokens,
+			CacheReadTokens:  turn.CacheReadTokens,
+			CacheWriteTokens: turn.CacheWriteTokens,
+			TotalTokens:      turn.TotalTokens(),
+			ToolCalls:        turn.ToolCalls,
+		})
+
+		if len(turn.ToolCalls) == 0 {
+			continue
+		}
+		attributed := turn.OutputTokens
+		if i+1 < len(turns) {
+			// Tool results become part of the next prompt, after this turn's output
+			attributed += max(0, turns[i+1].PromptTokens()-turn.PromptTokens()-turn.OutputTokens)
+		}
+		perCall := attributed / len(turn.ToolCalls)
+		for _, tool := range turn.ToolCalls {
+			stats, ok := toolStats[tool]
+			if !ok {
+				stats = &ToolTokenBreakdown{Tool: tool}
+				toolStats[tool] = stats
+			}
+			stats.Calls++
+			stats.EstimatedTokens += perCall
+		}
+	}
+
+	for _, stats := range toolStats {
+		if breakdown.TotalTokens > 0 {
+			stats.Share = float64(stats.EstimatedTokens) / float64(breakdown.TotalTokens)
+		}
+		breakdown.Tools = append(breakdown.Tools, *stats)
+	}
+	slices.SortFunc(breakdown.Tools, func(a, b ToolTokenBreakdown) int {
+		if c := cmp.Compare(b.EstimatedTokens, a.EstimatedTokens); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Tool, b.Tool)
+	})
+
+	auditTokenBreakdownLog.Printf("Built token breakdown: turns=%d, tools=%d, total=%d", len(breakdown.Turns), len(breakdown.Tools), breakdown.TotalTokens)
+	return breakdown
+}
+
+// renderConsoleTokenBreakdown renders the most expensive turns and tools
+func renderConsoleTokenBreakdown(breakdown *TokenBreakdown) {
+	if breakdown == nil || len(breakdown.Turns) == 0 {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "  token_breakdown: turns=%d total=%s\n", len(breakdown.Turns), console.FormatNumber(breakdown.TotalTokens))
+
+	turns := slices.Clone(breakdown.Turns)
+	slices.SortStableFunc(turns, func(a, b TurnTokenBreakdown) int { return cmp.Compare(b.TotalTokens, a.TotalTokens) })
+	for _, turn := range turns[:min(len(turns), maxConsoleTokenBreakdownRows)] {
+		line := fmt.Sprintf("    turn %d: in=%s out=%s cache_read=%s",
+			turn.Turn,
+			console.FormatNumber(turn.InputTokens),
+			console.FormatNumber(turn.OutputTokens),
+			console.FormatNumber(turn.CacheReadTokens),
+		)
+		if len(turn.ToolCalls) > 0 {
+			line += " tools=" + strings.Join(turn.ToolCalls, ",")
+		}
+		fmt.Fprintln(os.Stderr, line)
+	}
+
+	for _, tool := range breakdown.Tools[:min(len(breakdown.Tools), maxConsoleTokenBreakdownRows)] {
+		fmt.Fprintf(os.Stderr, "    %s ×%d ~%s (%.0f%%)\n", tool.Tool, tool.Calls, console.FormatNumber(tool.EstimatedTokens), tool.Share*100)
+	}
+}
@@ -0,0 +1,52 @@
+//go:build !integration
+
+package cli
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildTokenBreakdown(t *testing.T) {
+	turns := []workflow.TurnTokenUsage{
+		{Turn: 1, InputTokens: 1000, OutputTokens: 100, ToolCalls: []string{"Read", "Grep"}},
+		// Prompt grew by 1100 (previous prompt + output) + 900 from tool results
+		{Turn: 2, InputTokens: 100, CacheReadTokens: 1900, OutputTokens: 50, ToolCalls: []string{"Read"}},
+		// Prompt grew by 2050 + 3000 from the second Read result
+		{Turn: 3, InputTokens: 50, CacheReadTokens: 5000, OutputTokens: 20},
+	}
+
+	breakdown := buildTokenBreakdown(turns)
+
+	require.NotNil(t, breakdown, "breakdown should be built from per-turn usage")
+	require.Len(t, breakdown.Turns, 3, "every turn should be reported")
+	assert.Equal(t, 1100, breakdown.Turns[0].TotalTokens, "turn total should sum all token types")
+	assert.Equal(t, 1100+2050+5070, breakdown.TotalTokens, "run total should sum all turns")
+
+	require.Len(t, breakdown.Tools, 2, "each distinct tool should be reported")
+	read := breakdown.Tools[0]
+	assert.Equal(t, "Read", read.Tool, "tools should be sorted by estimated tokens")
+	assert.Equal(t, 2, read.Calls, "Read was called twice")
+	assert.Equal(t, (100+900)/2+50+3000, read.EstimatedTokens, "Read should get its share of each turn")
+	assert.InDelta(t, float64(read.EstimatedTokens)/float64(breakdown.TotalTokens), read.Share, 0.0001, "share should be relative to the run total")
+	assert.Equal(t, ToolTokenBreakdown{Tool: "Grep", Calls: 1, EstimatedTokens: 500, Share: 500.0 / 8220}, breakdown.Tools[1], "Grep should get half of turn 1")
+}
+
+func TestBuildTokenBreakdown_NoTurns(t *testing.T) {
+	assert.Nil(t, buildTokenBreakdown(nil), "no per-turn usage should produce no breakdown")
+}
+
+func TestTokenBreakdownJSON(t *testing.T) {
+	data := AuditData{TokenBreakdown: buildTokenBreakdown([]workflow.TurnTokenUsage{
+		{Turn: 1, InputTokens: 10, OutputTokens: 5, ToolCalls: []string{"bash"}},
+	})}
+
+	encoded, err := json.Marshal(data)
+	require.NoError(t, err, "audit data should marshal")
+	assert.Contains(t, string(encoded), `"token_breakdown":{"total_tokens":15,"turns":[{"turn":1,"input_tokens":10,"output_tokens":5,"total_tokens":15,"tool_calls":["bash"]}]`,
+		"token breakdown should be included in JSON output")
+}
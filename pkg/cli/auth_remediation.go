@@ -0,0 +1,199 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var authRemediationLog = logger.New("cli:auth_remediation")
+
+// authRemediation describes how to fix a GitHub permission or authentication error.
+type authRemediation struct {
+	Problem string   // What is wrong with the credentials, in one sentence
+	Scopes  []string // OAuth scopes the token is missing, if the error names them
+	Command []string // gh command that fixes the problem (e.g. ["gh", "auth", "refresh", "-s", "repo"]), if any
+	Steps   []string // Manual steps, such as secrets or workflow permissions to configure
+}
+
+// Patterns that extract missing OAuth scopes from GitHub API and gh CLI error messages.
+var missingScopePatterns = []*regexp.Regexp{
+	// gh CLI: "your authentication token is missing required scopes [read:org repo]"
+	regexp.MustCompile(`missing required scopes? \[([^\]]+)\]`),
+	// GitHub API: "This API operation needs the \"repo\" scope"
+	regexp.MustCompile(`needs the "([a-z_:]+)" scope`),
+	// gh CLI hint: "To request it, run:  gh auth refresh -h github.com -s repo"
+	regexp.MustCompile(`gh auth refresh(?: (?:-h|--hostname) \S+)? (?:-s|--scopes) ([a-z_:,]+)`),
+}
+
+// diagnoseAuthError determines why a GitHub request failed with a permission error
+// and how to fix it. The error message (including the API response body that gh
+// prints) is matched from the most to the least specific cause.
+func diagnoseAuthError(errMsg, hostname string) authRemediation {
+	hostArgs := []string{}
+	if hostname != "" && hostname != "github.com" {
+		hostArgs = []string{"-h", hostname}
+	}
+
+	if scopes := extractMissingScopes(errMsg); len(scopes) > 0 {
+		authRemediationLog.Printf("Missing scopes detected: %v", scopes)
+		return authRemediation{
+			Problem: fmt.Sprintf("Your GitHub CLI token is missing the %s scope(s).", strings.Join(scopes, ", ")),
+			Scopes:  scopes,
+			Command: append(append([]string{"gh", "auth", "refresh"}, hostArgs...), "-s", strings.Join(scopes, ",")),
+		}
+	}
+
+	switch {
+	case strings.Contains(errMsg, "To use GitHub CLI in a GitHub Actions workflow"), os.Getenv("GITHUB_ACTIONS") == "true" && strings.Contains(errMsg, "GH_TOKEN"):
+		return authRemediation{
+			Problem: "GitHub CLI has no token in this GitHub Actions job.",
+			Steps: []string{
+				"Set GH_TOKEN on the step: `env: GH_TOKEN: ${{ github.token }}`",
+				"Grant the job read access to workflow runs: `permissions: actions: read`",
+			},
+		}
+	case strings.Contains(errMsg, "not logged into any GitHub hosts"), strings.Contains(errMsg, "authentication required"),
+		strings.Contains(errMsg, "exit status 4"), strings.Contains(errMsg, "gh auth login"):
+		return authRemediation{
+			Problem: "GitHub CLI is not logged in.",
+			Command: append([]string{"gh", "auth", "login"}, hostArgs...),
+			Steps:   []string{"Or set GH_TOKEN to a token with read access to the repository's Actions"},
+		}
+	case strings.Contains(errMsg, "Resource not accessible by integration"):
+		return authRemediation{
+			Problem: "The GitHub Actions token does not have permission to read workflow runs.",
+			Steps: []string{
+				"Grant the job read access to workflow runs: `permissions: actions: read`",
+				"For runs in another repository, set GH_TOKEN to a token with access to that repository",
+			},
+		}
+	case strings.Contains(errMsg, "Resource not accessible by personal access token"):
+		return authRemediation{
+			Problem: "Your fine-grained personal access token cannot read this repository's workflow runs.",
+			Steps: []string{
+				"Give the token read access to \"Actions\" and \"Contents\" for this repository",
+				"Then log in with it: `gh auth login --with-token < token.txt`",
+			},
+		}
+	case strings.Contains(errMsg, "HTTP 404") || strings.Contains(errMsg, "Not Found"):
+		return authRemediation{
+			Problem: "The run was not found. Private repositories return 404 when the token cannot read them.",
+			Scopes:  []string{"repo"},
+			Command: append(append([]string{"gh", "auth", "refresh"}, hostArgs...), "-s", "repo"),
+		}
+	default:
+		return authRemediation{
+			Problem: "GitHub denied access to the workflow run.",
+			Command: append(append([]string{"gh", "auth", "refresh"}, hostArgs...), "-s", "repo"),
+			Steps:   []string{"Check the active account with `gh auth status`"},
+		}
+	}
+}
+
+// extractMissingScopes returns the OAuth scopes named as missing in an error message.
+func extractMissingScopes(errMsg string) []string {
+	var scopes []string
+	for _, pattern := range missingScopePatterns {
+		for _, match := range pattern.FindAllStringSubmatch(errMsg, -1) {
+			for scope := range strings.FieldsFuncSeq(match[1], func(r rune) bool { return r == ',' || r == ' ' }) {
+				if !slices.Contains(scopes, scope) {
+					scopes = append(scopes, scope)
+				}
+			}
+		}
+	}
+	return scopes
+}
+
+// formatAuthRemediation renders a remediation as lines for stderr.
+func formatAuthRemediation(r authRemediation) string {
+	var b strings.Builder
+	b.WriteString(console.FormatWarningMessage(r.Problem))
+	b.WriteString("\n")
+	if len(r.Command) > 0 {
+		b.WriteString("\nTo fix it, run:\n\n")
+		b.WriteString(console.FormatCommandMessageStderr(strings.Join(r.Command, " ")))
+		b.WriteString("\n")
+	}
+	if len(r.Steps) > 0 {
+		b.WriteString("\n")
+		for _, step := range r.Steps {
+			b.WriteString(console.FormatListItemStderr(step))
+			b.WriteString("\n")
+		}
+	}
+	if len(r.Command) > 0 {
+		b.WriteString("\nOr re-run with --fix-auth to do this interactively.\n")
+	}
+	return b.String()
+}
+
+// Function variables for testability.
+var (
+	authConfirmFn  = console.ConfirmAction
+	runAuthCommand = func(args []string) error {
+		// #nosec G204 -- args is built by diagnoseAuthError from a fixed gh auth subcommand,
+		// a hostname, and scope names matched by [a-z_:]+; no shell is involved.
+		cmd := exec.Command(args[0], args[1:]...)
+		cmd.Stdin = os.Stdin
+		cmd.Stdout = os.Stderr
+		cmd.Stderr = os.Stderr
+		return cmd.Run()
+	}
+)
+
+// fixAuthInteractively runs the gh command that fixes the permission error after
+// the user confirms it. It returns true when the command ran successfully and the
+// failed operation should be retried.
+func fixAuthInteractively(r authRemediation) (bool, error) {
+	fmt.Fprint(os.Stderr, formatAuthRemediation(authRemediation{Problem: r.Problem, Steps: r.Steps}))
+	if len(r.Command) == 0 {
+		return false, errors.New("this permission error cannot be fixed from the command line; follow the steps above")
+	}
+	command := strings.Join(r.Command, " ")
+	confirmed, err := authConfirmFn("Run `"+command+"` now?", "Yes, fix authentication", "No")
+	if err != nil {
+		return false, fmt.Errorf("failed to confirm authentication fix: %w", err)
+	}
+	if !confirmed {
+		authRemediationLog.Print("User declined the authentication fix")
+		return false, nil
+	}
+	fmt.Fprintln(os.Stderr, console.FormatCommandMessageStderr(command))
+	if err := runAuthCommand(r.Command); err != nil {
+		return false, fmt.Errorf("%s failed: %w", command, err)
+	}
+	fmt.Fprintln(os.Stderr, console.FormatSuccessMessage("Authentication updated. Retrying..."))
+	return true, nil
+}
+
+// withAuthRemediation runs op and, if it fails with a permission error, prints how
+// to fix it. When fixAuth is set, the fix is applied interactively and op is retried once.
+func withAuthRemediation(hostname string, fixAuth bool, op func() error) error {
+	err := op()
+	if !isPermissionError(err) {
+		return err
+	}
+	remediation := diagnoseAuthError(err.Error(), hostname)
+	authRemediationLog.Printf("Permission error: problem=%q, command=%v", remediation.Problem, remediation.Command)
+	if !fixAuth {
+		fmt.Fprint(os.Stderr, "\n"+formatAuthRemediation(remediation))
+		return err
+	}
+	retry, fixErr := fixAuthInteractively(remediation)
+	if fixErr != nil {
+		return errors.Join(err, fixErr)
+	}
+	if !retry {
+		return err
+	}
+	return op()
+}
@@ -0,0 +1,132 @@
+//go:build !integration
+
+package cli
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiagnoseAuthError(t *testing.T) {
+	t.Setenv("GITHUB_ACTIONS", "")
+
+	tests := []struct {
+		name        string
+		errMsg      string
+		hostname    string
+		wantScopes  []string
+		wantCommand []string
+		wantProblem string
+	}{
+		{
+			name:        "gh missing scopes",
+			errMsg:      "error: your authentication token is missing required scopes [read:org repo]\nTo request it, run:  gh auth refresh -s read:org,repo",
+			wantScopes:  []string{"read:org", "repo"},
+			wantCommand: []string{"gh", "auth", "refresh", "-s", "read:org,repo"},
+			wantProblem: "missing the read:org, repo scope(s)",
+		},
+		{
+			name:        "API scope message on GHES",
+			errMsg:      `gh: This API operation needs the "workflow" scope. (HTTP 403)`,
+			hostname:    "github.example.com",
+			wantScopes:  []string{"workflow"},
+			wantCommand: []string{"gh", "auth", "refresh", "-h", "github.example.com", "-s", "workflow"},
+		},
+		{
+			name:        "not logged in",
+			errMsg:      "You are not logged into any GitHub hosts. To log in, run: gh auth login",
+			wantCommand: []string{"gh", "auth", "login"},
+			wantProblem: "not logged in",
+		},
+		{
+			name:        "Actions without GH_TOKEN",
+			errMsg:      "gh: To use GitHub CLI in a GitHub Actions workflow, set the GH_TOKEN environment variable.",
+			wantProblem: "no token in this GitHub Actions job",
+		},
+		{
+			name:        "integration token",
+			errMsg:      "HTTP 403: Resource not accessible by integration (https://api.github.com/repos/o/r/actions/runs/1)",
+			wantProblem: "does not have permission to read workflow runs",
+		},
+		{
+			name:        "private repository not found",
+			errMsg:      "failed to fetch run: permission check failed: HTTP 404: Not Found",
+			wantScopes:  []string{"repo"},
+			wantCommand: []string{"gh", "auth", "refresh", "-s", "repo"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := diagnoseAuthError(tt.errMsg, tt.hostname)
+			assert.Equal(t, tt.wantScopes, r.Scopes, "missing scopes")
+			assert.Equal(t, tt.wantCommand, r.Command, "fix command")
+			assert.Contains(t, r.Problem, tt.wantProblem, "problem description")
+		})
+	}
+}
+
+func TestDiagnoseAuthErrorActionsSteps(t *testing.T) {
+	r := diagnoseAuthError("gh: To use GitHub CLI in a GitHub Actions workflow, set the GH_TOKEN environment variable.", "")
+	assert.Empty(t, r.Command, "there is no command to run inside Actions")
+	assert.Contains(t, r.Steps, "Set GH_TOKEN on the step: `env: GH_TOKEN: ${{ github.token }}`", "GH_TOKEN should be explained")
+
+	text := formatAuthRemediation(r)
+	assert.Contains(t, text, "permissions: actions: read", "workflow permission should be shown")
+	assert.NotContains(t, text, "--fix-auth", "--fix-auth is only suggested when there is a command to run")
+}
+
+func TestWithAuthRemediation(t *testing.T) {
+	t.Setenv("GITHUB_ACTIONS", "")
+	permissionErr := errors.New("failed to fetch run: permission check failed: gh: This API operation needs the \"repo\" scope. (HTTP 403)")
+
+	origConfirm, origRun := authConfirmFn, runAuthCommand
+	t.Cleanup(func() { authConfirmFn, runAuthCommand = origConfirm, origRun })
+
+	t.Run("non-permission errors pass through", func(t *testing.T) {
+		otherErr := errors.New("invalid run ID")
+		assert.Equal(t, otherErr, withAuthRemediation("", true, func() error { return otherErr }), "error should be returned unchanged")
+	})
+
+	t.Run("hints only without --fix-auth", func(t *testing.T) {
+		authConfirmFn = func(string, string, string) (bool, error) {
+			t.Fatal("should not prompt without --fix-auth")
+			return false, nil
+		}
+		calls := 0
+		err := withAuthRemediation("", false, func() error { calls++; return permissionErr })
+		assert.Equal(t, permissionErr, err, "original error should be returned")
+		assert.Equal(t, 1, calls, "operation should not be retried")
+	})
+
+	t.Run("fix and retry", func(t *testing.T) {
+		authConfirmFn = func(string, string, string) (bool, error) { return true, nil }
+		var ran []string
+		runAuthCommand = func(args []string) error { ran = args; return nil }
+		calls := 0
+		err := withAuthRemediation("", true, func() error {
+			calls++
+			if calls == 1 {
+				return permissionErr
+			}
+			return nil
+		})
+		require.NoError(t, err, "retry should succeed")
+		assert.Equal(t, []string{"gh", "auth", "refresh", "-s", "repo"}, ran, "missing scope should be requested")
+		assert.Equal(t, 2, calls, "operation should be retried once")
+	})
+
+	t.Run("declined fix", func(t *testing.T) {
+		authConfirmFn = func(string, string, string) (bool, error) { return false, nil }
+		runAuthCommand = func([]string) error {
+			t.Fatal("should not run gh when declined")
+			return nil
+		}
+		calls := 0
+		err := withAuthRemediation("", true, func() error { calls++; return permissionErr })
+		assert.Equal(t, permissionErr, err, "original error should be returned")
+		assert.Equal(t, 1, calls, "operation should not be retried")
+	})
+}
@@ -599,7 +599,7 @@ func TestCompileModifiedFilesWithDependencies_FormatsWatchMessage(t *testing.T)
 	os.Stderr = w
 	t.Cleanup(func() { os.Stderr = oldStderr })
 
-	compileModifiedFilesWithDependencies(context.Background(), compiler, depGraph, []string{filePath}, false)
+	compileModifiedFilesWithDependencies(context.Background(), compiler, depGraph, []string{filePath}, false, compileSingleFile)
 
 	w.Close()
 
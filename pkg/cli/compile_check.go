@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/workflow"
+)
+
+var compileCheckLog = logger.New("cli:compile_check")
+
+// reportStaleLockFiles backs the compile --check flag. It lists the lock files that the
+// compiler found missing or out of date, plus orphaned lock files whose source was
+// deleted, and returns an error when there are any, so CI can fail when someone edits
+// a workflow without recompiling it.
+func reportStaleLockFiles(compiler *workflow.Compiler, config CompileConfig, orphanedLockFiles []string) error {
+	if !config.Check {
+		return nil
+	}
+
+	stale := compiler.GetStaleLockFiles()
+	compileCheckLog.Printf("Check mode found %d stale and %d orphaned lock files", len(stale), len(orphanedLockFiles))
+	if len(stale) == 0 && len(orphanedLockFiles) == 0 {
+		if !config.JSONOutput {
+			fmt.Fprintln(os.Stderr, console.FormatSuccessMessage("All lock files are up to date"))
+		}
+		return nil
+	}
+
+	if config.Porcelain {
+		for _, lockFile := range stale {
+			writePorcelainRecord(os.Stdout, "stale", console.ToRelativePath(lockFile))
+		}
+		for _, lockFile := range orphanedLockFiles {
+			writePorcelainRecord(os.Stdout, "orphaned", console.ToRelativePath(lockFile))
+		}
+	}
+
+	if len(stale) > 0 {
+		fmt.Fprintln(os.Stderr, console.FormatErrorMessage(fmt.Sprintf("%d lock file(s) are missing or out of date:", len(stale))))
+		for _, lockFile := range stale {
+			fmt.Fprintln(os.Stderr, console.FormatListItemStderr(console.ToRelativePath(lockFile)))
+		}
+	}
+	if len(orphanedLockFiles) > 0 {
+		fmt.Fprintln(os.Stderr, console.FormatErrorMessage(fmt.Sprintf("%d lock file(s) have no workflow source (remove them with --purge):", len(orphanedLockFiles))))
+		for _, lockFile := range orphanedLockFiles {
+			fmt.Fprintln(os.Stderr, console.FormatListItemStderr(console.ToRelativePath(lockFile)))
+		}
+	}
+	return fmt.Errorf("lock files are out of date; run '%s compile' and commit the result", constants.CLIExtensionPrefix)
+}
@@ -0,0 +1,104 @@
+//go:build !integration
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCompileConfig_Check(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   CompileConfig
+		errorMsg string
+	}{
+		{name: "check alone", config: CompileConfig{Check: true}},
+		{name: "check with specific files", config: CompileConfig{Check: true, MarkdownFiles: []string{"test.md"}}},
+		{name: "check with watch", config: CompileConfig{Check: true, Watch: true}, errorMsg: "--check cannot be used with --watch"},
+		{name: "check with purge", config: CompileConfig{Check: true, Purge: true}, errorMsg: "--check cannot be used with --purge"},
+		{name: "check with dependabot", config: CompileConfig{Check: true, Dependabot: true}, errorMsg: "--check cannot be used with --dependabot"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCompileConfig(tt.config)
+			if tt.errorMsg == "" {
+				assert.NoError(t, err, "config should be valid")
+			} else {
+				assert.EqualError(t, err, tt.errorMsg, "config should be rejected")
+			}
+		})
+	}
+}
+
+func TestValidateCompileConfig_Offline(t *testing.T) {
+	assert.NoError(t, validateCompileConfig(CompileConfig{Offline: true}), "offline alone should be valid")
+	assert.EqualError(t, validateCompileConfig(CompileConfig{Offline: true, ForceRefreshActionPins: true}),
+		"--offline cannot be used with --force-refresh-action-pins", "refreshing pins needs the network")
+	assert.EqualError(t, validateCompileConfig(CompileConfig{Offline: true, Dependabot: true}),
+		"--offline cannot be used with --dependabot", "dependabot lock files need the network")
+}
+
+func TestReportStaleLockFiles(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "compile-check")
+	workflowPath := filepath.Join(tmpDir, "check.md")
+	require.NoError(t, os.WriteFile(workflowPath, []byte("---\non: push\nengine: copilot\n---\n\n# Check\n"), 0644), "failed to write workflow")
+
+	newCheckCompiler := func() *workflow.Compiler {
+		compiler := workflow.NewCompiler(workflow.WithVersion("1.0.0"))
+		compiler.SetQuiet(true)
+		compiler.SetNoEmit(true)
+		compiler.SetCheckLockFiles(true)
+		return compiler
+	}
+
+	// Missing lock file is stale
+	compiler := newCheckCompiler()
+	require.NoError(t, compiler.CompileWorkflow(workflowPath), "check compilation should succeed")
+	err := reportStaleLockFiles(compiler, CompileConfig{Check: true}, nil)
+	require.Error(t, err, "missing lock file should fail the check")
+	assert.Contains(t, err.Error(), "lock files are out of date", "error should explain how to fix stale lock files")
+	assert.NoError(t, reportStaleLockFiles(compiler, CompileConfig{}, nil), "stale lock files should only fail in check mode")
+
+	// Freshly compiled lock file passes
+	writer := workflow.NewCompiler(workflow.WithVersion("1.0.0"))
+	writer.SetQuiet(true)
+	require.NoError(t, writer.CompileWorkflow(workflowPath), "compilation should succeed")
+	compiler = newCheckCompiler()
+	require.NoError(t, compiler.CompileWorkflow(workflowPath), "check compilation should succeed")
+	assert.NoError(t, reportStaleLockFiles(compiler, CompileConfig{Check: true}, nil), "up-to-date lock file should pass the check")
+
+	// Editing the workflow makes the lock file stale again
+	require.NoError(t, os.WriteFile(workflowPath, []byte("---\non: push\nengine: copilot\n---\n\n# Check\n\nNew instructions.\n"), 0644), "failed to update workflow")
+	compiler = newCheckCompiler()
+	require.NoError(t, compiler.CompileWorkflow(workflowPath), "check compilation should succeed")
+	assert.Equal(t, []string{stringutil.MarkdownToLockFile(workflowPath)}, compiler.GetStaleLockFiles(), "edited workflow should have a stale lock file")
+	assert.Error(t, reportStaleLockFiles(compiler, CompileConfig{Check: true}, nil), "edited workflow should fail the check")
+}
+
+func TestReportStaleLockFiles_Orphaned(t *testing.T) {
+	compiler := workflow.NewCompiler(workflow.WithVersion("1.0.0"))
+	orphaned := filterOrphanedLockFiles(
+		[]string{"/wf/a.lock.yml", "/wf/deleted.lock.yml", "/wf/x.campaign.lock.yml"},
+		[]string{"/wf/a.lock.yml"},
+	)
+
+	assert.Equal(t, []string{"/wf/deleted.lock.yml"}, orphaned, "only lock files without a source should be orphaned")
+	assert.Error(t, reportStaleLockFiles(compiler, CompileConfig{Check: true}, orphaned), "orphaned lock files should fail the check")
+}
+
+func TestValidateCompileConfig_HandlerScripts(t *testing.T) {
+	for _, mode := range []string{"", "action", "files", "inline"} {
+		assert.NoError(t, validateCompileConfig(CompileConfig{HandlerScripts: mode}), "handler scripts mode %q should be valid", mode)
+	}
+	assert.EqualError(t, validateCompileConfig(CompileConfig{HandlerScripts: "bundle"}),
+		"invalid --handler-scripts value 'bundle'. Must be 'action', 'files', or 'inline'", "unknown mode should be rejected")
+}
@@ -16,6 +16,7 @@
 //
 // Compiler Creation:
 //   - createAndConfigureCompiler() - Creates compiler with full configuration
+//   - newConfiguredCompiler() - Creates a configured compiler without one-time setup (used for worker compilers)
 //
 // Configuration:
 //   - configureCompilerFlags() - Sets validation, strict mode, trial mode flags
@@ -34,9 +35,11 @@ import (
 	"path/filepath"
 	"strings"
 
+	setupjs "github.com/github/gh-aw/actions/setup/js"
 	"github.com/github/gh-aw/pkg/console"
 	"github.com/github/gh-aw/pkg/constants"
 	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
 	"github.com/github/gh-aw/pkg/workflow"
 )
 
@@ -92,6 +95,12 @@ func createAndConfigureCompiler(config CompileConfig) *workflow.Compiler {
 		}
 	}
 
+	return newConfiguredCompiler(config)
+}
+
+// newConfiguredCompiler creates a compiler configured from config without the one-time
+// setup done by createAndConfigureCompiler, so parallel compilation can create one per worker.
+func newConfiguredCompiler(config CompileConfig) *workflow.Compiler {
 	// Create compiler with auto-detected version and action mode
 	// Git root is now auto-detected in NewCompiler() for all compiler instances
 	compiler := workflow.NewCompiler(
@@ -113,11 +122,31 @@ func createAndConfigureCompiler(config CompileConfig) *workflow.Compiler {
 		compileCompilerSetupLog.Printf("Actions repository overridden: %s (default: %s)", config.ActionsRepo, workflow.GitHubActionsOrgRepo)
 	}
 
+	// Set up handler script mode if specified
+	if mode := workflow.HandlerScriptMode(config.HandlerScripts); mode != "" && mode != workflow.HandlerScriptModeAction {
+		compiler.SetHandlerScripts(mode, setupjs.Scripts)
+		compileCompilerSetupLog.Printf("Handler scripts mode: %s", mode)
+	}
+	if config.SharedActions {
+		compiler.SetSharedActions(true)
+		compileCompilerSetupLog.Print("Shared actions enabled")
+	}
+
 	// Set up repository context
 	setupRepositoryContext(compiler, config)
 
+	// Offline mode: action pins come from actions-lock.json and embedded pins, remote
+	// imports from the import cache, and anything else that needs the network fails.
+	parser.SetOfflineMode(config.Offline)
+	compiler.SetOffline(config.Offline)
+
+	// Duplicate top-level frontmatter keys are rejected unless merging was requested.
+	parser.SetMergeDuplicateKeys(config.MergeDuplicateKeys)
+
 	if config.DisableModelsDevLookup {
 		compileCompilerSetupLog.Print("models.dev pricing lookup disabled via --no-models-dev-lookup")
+	} else if config.Offline {
+		compileCompilerSetupLog.Print("models.dev pricing lookup disabled in offline mode")
 	} else {
 		// Register the models.dev pricing resolver so the compiler can inject pricing for
 		// models absent from the embedded catalog into GH_AW_INFO_MODEL_COSTS in the lock.yml.
@@ -140,9 +169,11 @@ func configureCompilerFlags(compiler *workflow.Compiler, config CompileConfig) {
 	if config.NoEmit {
 		compileCompilerSetupLog.Print("No-emit mode enabled: validating without generating lock files")
 	}
+	compiler.SetCheckLockFiles(config.Check)
 
 	// Set strict mode if specified
 	compiler.SetStrictMode(config.Strict)
+	compiler.SetStrictExplicit(config.StrictExplicit)
 	compiler.SetAllowActionRefs(config.AllowActionRefs)
 	compiler.SetForceStaged(config.Staged)
 
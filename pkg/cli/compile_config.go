@@ -10,11 +10,13 @@ type CompileConfig struct {
 	WorkflowDir            string   // Custom workflow directory
 	SkipInstructions       bool     // Deprecated: Instructions are no longer written during compilation
 	NoEmit                 bool     // Validate without generating lock files
+	Check                  bool     // Fail if any lock file is missing or out of date, without writing lock files
 	Purge                  bool     // Remove orphaned lock files
 	TrialMode              bool     // Enable trial mode (suppress safe outputs)
 	TrialLogicalRepoSlug   string   // Target repository for trial mode
 	UseSamples             bool     // Hidden: replace agentic step with a deterministic samples replay driver
 	Strict                 bool     // Enable strict mode validation
+	StrictExplicit         bool     // Refuse implicit defaults for security-relevant settings
 	Dependabot             bool     // Generate Dependabot manifests for npm dependencies
 	ForceOverwrite         bool     // Force overwrite of existing files (dependabot.yml)
 	RefreshStopTime        bool     // Force regeneration of stop-after times instead of preserving existing ones
@@ -30,18 +32,25 @@ type CompileConfig struct {
 	Grant                  bool     // Run grant license scanner on container images referenced in compiled .lock.yml files
 	Yamllint               bool     // Run yamllint YAML linter on generated .lock.yml files
 	JSONOutput             bool     // Output validation results as JSON
+	Porcelain              bool     // Output validation results as porcelain records (implies JSONOutput's quiet stderr)
 	ShowAllErrors          bool     // Display all prioritized errors instead of the default top five
 	ActionMode             string   // How action scripts are referenced: dev, release, or action. Auto-detected if empty.
 	ActionTag              string   // Pin action refs to this SHA or version tag (e.g. v1, <full-sha>). Sets release mode unless ActionMode is already "action". Mutually exclusive with GHAwRef at the CLI layer.
 	ActionsRepo            string   // Override the external actions repository (default: github/gh-aw-actions)
+	HandlerScripts         string   // How compiled steps load JavaScript handler scripts: action (default), files, or inline
+	SharedActions          bool     // Move github-script steps into shared composite actions under .github/actions/gh-aw
 	Stats                  bool     // Display statistics table sorted by file size
+	Explain                bool     // Display per-workflow permissions, network, engine, safe-output, and cost footprint
 	FailFast               bool     // Stop at first error instead of collecting all errors
+	Jobs                   int      // Number of workflows compiled in parallel; values <= 1 compile sequentially
 	ScheduleSeed           string   // Override repository slug used for fuzzy schedule scattering (e.g. owner/repo)
 	Approve                bool     // Approve all safe update changes, skipping safe update enforcement regardless of strict mode setting.
 	ValidateImages         bool     // Require Docker to be available for container image validation (fail instead of skipping when Docker is unavailable)
 	PriorManifestFile      string   // Path to a JSON file containing pre-cached manifests (map[lockFile]*GHAWManifest) collected at MCP server startup; takes precedence over git HEAD / filesystem reads for safe update enforcement
 	GHESCompat             bool     // Enable GHES compatibility mode (overrides aw.json ghes field); artifact actions still use latest non-v3 pins
 	DisableModelsDevLookup bool     // Disable compile-time models.dev pricing lookup for models missing from the embedded catalog
+	Offline                bool     // Never use the network: resolve action pins from actions-lock.json and remote imports from .github/aw/imports only
+	MergeDuplicateKeys     bool     // Merge list and mapping values of top-level frontmatter keys defined more than once instead of rejecting them
 }
 
 // CompileValidationError represents a single validation error or warning
@@ -58,5 +67,6 @@ type ValidationResult struct {
 	Errors       []CompileValidationError `json:"errors"`
 	Warnings     []CompileValidationError `json:"warnings"`
 	CompiledFile string                   `json:"compiled_file,omitempty"`
-	Labels       []string                 `json:"labels,omitempty"` // Labels referenced in safe-outputs configurations
+	Labels       []string                 `json:"labels,omitempty"`  // Labels referenced in safe-outputs configurations
+	Explain      *WorkflowExplanation     `json:"explain,omitempty"` // Permissions and cost footprint (populated with --explain)
 }
@@ -0,0 +1,229 @@
+package cli
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/workflow"
+)
+
+var compileExplainLog = logger.New("cli:compile_explain")
+
+// WorkflowExplanation summarizes the permissions, network, engine, safe-output, and cost
+// footprint of a compiled workflow so reviewers can assess the risk of a workflow change.
+type WorkflowExplanation struct {
+	Permissions           map[string]string    `json:"permissions"`                       // Agent job permissions (scope → level)
+	SafeOutputPermissions map[string]string    `json:"safe_output_permissions,omitempty"` // Write permissions granted to safe-output jobs
+	NetworkBuckets        []string             `json:"network_buckets"`                   // Ecosystem identifiers allowed for egress (e.g. defaults, python)
+	NetworkDomains        []string             `json:"network_domains,omitempty"`         // Individual domains allowed for egress
+	NetworkBlocked        []string             `json:"network_blocked,omitempty"`         // Domains explicitly blocked
+	Engine                string               `json:"engine"`
+	Model                 string               `json:"model,omitempty"` // Empty when the engine default model is used
+	SafeOutputs           map[string]string    `json:"safe_outputs,omitempty"`
+	Cost                  WorkflowCostEstimate `json:"cost"`
+}
+
+// WorkflowCostEstimate is the estimated per-run cost range of a workflow in AI Credits (AIC).
+// When cached runs are available the range comes from their observed usage; otherwise it
+// spans zero to the per-run max-ai-credits budget.
+type WorkflowCostEstimate struct {
+	MinAIC     float64 `json:"min_aic"`
+	AvgAIC     float64 `json:"avg_aic,omitempty"`
+	MaxAIC     float64 `json:"max_aic"`
+	BudgetAIC  int64   `json:"budget_aic"`
+	SampleRuns int     `json:"sample_runs"`
+	Source     string  `json:"source"` // "history" or "budget"
+}
+
+// buildWorkflowExplanation computes the explanation for a compiled workflow. Historical
+// usage is read from the local run cache in logsDir; no network requests are made.
+func buildWorkflowExplanation(data *workflow.WorkflowData, logsDir string) *WorkflowExplanation {
+	compileExplainLog.Printf("Building explanation for workflow: %s", data.WorkflowID)
+
+	explanation := &WorkflowExplanation{
+		Permissions:           permissionLevels(workflow.NewPermissionsParser(data.Permissions).ToPermissions()),
+		SafeOutputPermissions: permissionLevels(workflow.ComputePermissionsForSafeOutputs(data.SafeOutputs)),
+		SafeOutputs:           formatSafeOutputMaxCounts(workflow.SafeOutputMaxCounts(data.SafeOutputs)),
+		Engine:                data.AI,
+		Model:                 data.Model,
+	}
+	if data.EngineConfig != nil && data.EngineConfig.ID != "" {
+		explanation.Engine = data.EngineConfig.ID
+	}
+
+	if data.NetworkPermissions == nil {
+		// No network configuration means the default ecosystem allowlist.
+		explanation.NetworkBuckets = []string{"defaults"}
+	} else {
+		for _, entry := range data.NetworkPermissions.Allowed {
+			if strings.Contains(entry, ".") {
+				explanation.NetworkDomains = append(explanation.NetworkDomains, entry)
+			} else {
+				explanation.NetworkBuckets = append(explanation.NetworkBuckets, entry)
+			}
+		}
+		explanation.NetworkBlocked = data.NetworkPermissions.Blocked
+	}
+	if explanation.NetworkBuckets == nil {
+		explanation.NetworkBuckets = []string{}
+	}
+
+	explanation.Cost = estimateWorkflowCost(data, logsDir)
+	return explanation
+}
+
+// permissionLevels flattens permissions into a scope → level map, omitting scopes set to none.
+func permissionLevels(permissions *workflow.Permissions) map[string]string {
+	levels := make(map[string]string)
+	if permissions == nil {
+		return levels
+	}
+	for _, scope := range workflow.GetAllPermissionScopes() {
+		level, ok := permissions.Get(scope)
+		if !ok || level == workflow.PermissionNone {
+			continue
+		}
+		levels[string(scope)] = string(level)
+	}
+	return levels
+}
+
+// formatSafeOutputMaxCounts replaces unset and unlimited max values with readable labels.
+func formatSafeOutputMaxCounts(counts map[string]string) map[string]string {
+	for key, value := range counts {
+		switch value {
+		case "":
+			counts[key] = "default"
+		case "-1":
+			counts[key] = "unlimited"
+		}
+	}
+	return counts
+}
+
+// estimateWorkflowCost derives the per-run cost range from cached run summaries of the
+// workflow, falling back to the max-ai-credits budget when no usage has been recorded.
+func estimateWorkflowCost(data *workflow.WorkflowData, logsDir string) WorkflowCostEstimate {
+	estimate := WorkflowCostEstimate{
+		BudgetAIC: data.EngineConfig.GetMaxAICredits(),
+		Source:    "budget",
+	}
+
+	observations := loadCachedWorkflowAIC(logsDir, data.WorkflowID)
+	if len(observations) == 0 {
+		estimate.MaxAIC = float64(estimate.BudgetAIC)
+		return estimate
+	}
+
+	total := 0.0
+	for _, aic := range observations {
+		total += aic
+	}
+	estimate.MinAIC = slices.Min(observations)
+	estimate.MaxAIC = slices.Max(observations)
+	estimate.AvgAIC = total / float64(len(observations))
+	estimate.SampleRuns = len(observations)
+	estimate.Source = "history"
+	return estimate
+}
+
+// loadCachedWorkflowAIC returns the AI Credits recorded by cached runs of the workflow.
+func loadCachedWorkflowAIC(logsDir, workflowID string) []float64 {
+	entries, err := os.ReadDir(logsDir)
+	if err != nil {
+		compileExplainLog.Printf("No run cache available in %s: %v", logsDir, err)
+		return nil
+	}
+
+	var observations []float64
+	for _, entry := range entries {
+		if !entry.IsDir() || !strings.HasPrefix(entry.Name(), "run-") {
+			continue
+		}
+		if _, parseErr := strconv.ParseInt(strings.TrimPrefix(entry.Name(), "run-"), 10, 64); parseErr != nil {
+			continue
+		}
+		summary, err := readCachedRunSummary(filepath.Join(logsDir, entry.Name()))
+		if err != nil || !matchesFlakyWorkflow(summary.Run, workflowID) {
+			continue
+		}
+		// Skipped runs and runs without a usage artifact record no AIC.
+		if summary.TokenUsage == nil || summary.TokenUsage.TotalAIC <= 0 {
+			continue
+		}
+		observations = append(observations, summary.TokenUsage.TotalAIC)
+	}
+	compileExplainLog.Printf("Loaded %d cached AIC observations for workflow %s", len(observations), workflowID)
+	return observations
+}
+
+// displayWorkflowExplanations prints the explanation of each successfully compiled workflow to stderr.
+func displayWorkflowExplanations(results []ValidationResult) {
+	for _, result := range results {
+		if result.Explain == nil {
+			continue
+		}
+		explanation := result.Explain
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Explain: "+result.Workflow))
+		fmt.Fprintf(os.Stderr, "  Engine:         %s\n", formatExplainEngine(explanation))
+		fmt.Fprintf(os.Stderr, "  Permissions:    %s\n", formatExplainLevels(explanation.Permissions))
+		if len(explanation.SafeOutputPermissions) > 0 {
+			fmt.Fprintf(os.Stderr, "  Output perms:   %s\n", formatExplainLevels(explanation.SafeOutputPermissions))
+		}
+		fmt.Fprintf(os.Stderr, "  Network:        %s\n", formatExplainNetwork(explanation))
+		if len(explanation.NetworkBlocked) > 0 {
+			fmt.Fprintf(os.Stderr, "  Blocked:        %s\n", strings.Join(explanation.NetworkBlocked, ", "))
+		}
+		fmt.Fprintf(os.Stderr, "  Safe outputs:   %s\n", formatExplainLevels(explanation.SafeOutputs))
+		fmt.Fprintf(os.Stderr, "  Cost per run:   %s\n", formatExplainCost(explanation.Cost, result.Workflow))
+		fmt.Fprintln(os.Stderr)
+	}
+}
+
+func formatExplainEngine(explanation *WorkflowExplanation) string {
+	model := explanation.Model
+	if model == "" {
+		model = "engine default model"
+	}
+	return fmt.Sprintf("%s (%s)", explanation.Engine, model)
+}
+
+// formatExplainLevels renders a key → value map as a sorted, comma-separated list.
+func formatExplainLevels(values map[string]string) string {
+	if len(values) == 0 {
+		return "none"
+	}
+	keys := slices.Sorted(maps.Keys(values))
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, key+": "+values[key])
+	}
+	return strings.Join(parts, ", ")
+}
+
+func formatExplainNetwork(explanation *WorkflowExplanation) string {
+	entries := append(slices.Clone(explanation.NetworkBuckets), explanation.NetworkDomains...)
+	if len(entries) == 0 {
+		return "no egress"
+	}
+	return strings.Join(entries, ", ")
+}
+
+func formatExplainCost(cost WorkflowCostEstimate, workflowFile string) string {
+	if cost.Source != "history" {
+		workflowID := strings.TrimSuffix(filepath.Base(workflowFile), ".md")
+		return fmt.Sprintf("up to %d AIC (max-ai-credits budget; run '%s logs %s' to estimate from history)",
+			cost.BudgetAIC, string(constants.CLIExtensionPrefix), workflowID)
+	}
+	return fmt.Sprintf("%s–%s AIC, avg %s (%d cached runs; budget %d AIC)",
+		formatForecastAIC(cost.MinAIC), formatForecastAIC(cost.MaxAIC), formatForecastAIC(cost.AvgAIC),
+		cost.SampleRuns, cost.BudgetAIC)
+}
@@ -0,0 +1,90 @@
+//go:build !integration
+
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/github/gh-aw/pkg/workflow"
+)
+
+func writeExplainTestRun(t *testing.T, dir string, runID int64, workflowID string, aic float64) {
+	t.Helper()
+	writeCachedRunSummary(t, dir, RunSummary{
+		RunID: runID,
+		Run: WorkflowRun{
+			DatabaseID:   runID,
+			WorkflowName: workflowID,
+			WorkflowPath: ".github/workflows/" + workflowID + ".lock.yml",
+			Status:       "completed",
+			Conclusion:   "success",
+		},
+		TokenUsage: &TokenUsageSummary{TotalAIC: aic},
+	})
+}
+
+func explainTestWorkflowData() *workflow.WorkflowData {
+	commentMax := "3"
+	unlimited := "-1"
+	return &workflow.WorkflowData{
+		WorkflowID:   "triage",
+		AI:           "copilot",
+		Model:        "gpt-5",
+		Permissions:  "permissions:\n  contents: read\n  issues: read",
+		EngineConfig: &workflow.EngineConfig{ID: "copilot", MaxAICredits: 250},
+		NetworkPermissions: &workflow.NetworkPermissions{
+			Allowed: []string{"defaults", "python", "api.example.com"},
+			Blocked: []string{"tracker.example.com"},
+		},
+		SafeOutputs: &workflow.SafeOutputsConfig{
+			AddComments: &workflow.AddCommentsConfig{BaseSafeOutputConfig: workflow.BaseSafeOutputConfig{Max: &commentMax}},
+			AddLabels:   &workflow.AddLabelsConfig{BaseSafeOutputConfig: workflow.BaseSafeOutputConfig{Max: &unlimited}},
+			NoOp:        &workflow.NoOpConfig{},
+		},
+	}
+}
+
+func TestBuildWorkflowExplanation(t *testing.T) {
+	explanation := buildWorkflowExplanation(explainTestWorkflowData(), t.TempDir())
+
+	assert.Equal(t, map[string]string{"contents": "read", "issues": "read"}, explanation.Permissions)
+	assert.Equal(t, "write", explanation.SafeOutputPermissions["issues"], "add-comment should require issues: write")
+	assert.Equal(t, []string{"defaults", "python"}, explanation.NetworkBuckets)
+	assert.Equal(t, []string{"api.example.com"}, explanation.NetworkDomains)
+	assert.Equal(t, []string{"tracker.example.com"}, explanation.NetworkBlocked)
+	assert.Equal(t, "copilot", explanation.Engine)
+	assert.Equal(t, "gpt-5", explanation.Model)
+	assert.Equal(t, map[string]string{"add-comment": "3", "add-labels": "unlimited", "noop": "default"}, explanation.SafeOutputs)
+
+	assert.Equal(t, "budget", explanation.Cost.Source, "no cached runs should fall back to the budget")
+	assert.Equal(t, WorkflowCostEstimate{MaxAIC: 250, BudgetAIC: 250, Source: "budget"}, explanation.Cost)
+}
+
+func TestBuildWorkflowExplanationDefaultNetwork(t *testing.T) {
+	data := explainTestWorkflowData()
+	data.NetworkPermissions = nil
+	data.EngineConfig = nil
+
+	explanation := buildWorkflowExplanation(data, t.TempDir())
+	assert.Equal(t, []string{"defaults"}, explanation.NetworkBuckets, "missing network config should use the defaults bucket")
+	assert.Equal(t, "copilot", explanation.Engine, "engine should fall back to the AI field")
+	assert.EqualValues(t, 1000, explanation.Cost.BudgetAIC, "budget should fall back to the default max-ai-credits")
+}
+
+func TestEstimateWorkflowCostFromHistory(t *testing.T) {
+	dir := t.TempDir()
+	writeExplainTestRun(t, dir, 1, "triage", 12)
+	writeExplainTestRun(t, dir, 2, "triage", 30)
+	writeExplainTestRun(t, dir, 3, "triage", 0)
+	writeExplainTestRun(t, dir, 4, "other", 500)
+
+	cost := estimateWorkflowCost(explainTestWorkflowData(), dir)
+	assert.Equal(t, "history", cost.Source)
+	assert.Equal(t, 2, cost.SampleRuns, "runs without usage and other workflows should be ignored")
+	assert.InDelta(t, 12.0, cost.MinAIC, 0.001)
+	assert.InDelta(t, 30.0, cost.MaxAIC, 0.001)
+	assert.InDelta(t, 21.0, cost.AvgAIC, 0.001)
+	assert.Contains(t, formatExplainCost(cost, "triage.md"), "12–30 AIC, avg 21 (2 cached runs; budget 250 AIC)")
+}
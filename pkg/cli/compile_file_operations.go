@@ -18,6 +18,7 @@
 //
 // Single File Compilation:
 //   - compileSingleFile() - Compile a single markdown workflow with stats tracking
+//   - compileSingleFileWithError() - Same, also returning the compilation error (watch mode)
 //
 // Batch Compilation:
 //   - compileBatchWorkflows() - Compile multiple workflows in parallel
@@ -50,15 +51,26 @@ import (
 
 var compileHelpersLog = logger.New("cli:compile_file_operations")
 
+// compileFileFunc compiles one workflow file and updates compilation statistics.
+// compileSingleFile is the default; watch mode wraps it to diff lock files and track errors.
+type compileFileFunc func(ctx context.Context, compiler *workflow.Compiler, file string, stats *CompilationStats, verbose bool, checkExists bool) bool
+
 // compileSingleFile compiles a single markdown workflow file and updates compilation statistics
 // If checkExists is true, the function will check if the file exists before compiling
 // Returns true if compilation was attempted (file exists or checkExists is false), false otherwise
 func compileSingleFile(ctx context.Context, compiler *workflow.Compiler, file string, stats *CompilationStats, verbose bool, checkExists bool) bool {
+	attempted, _ := compileSingleFileWithError(ctx, compiler, file, stats, verbose, checkExists)
+	return attempted
+}
+
+// compileSingleFileWithError is compileSingleFile that also returns the compilation error,
+// which has already been printed and counted in stats.
+func compileSingleFileWithError(ctx context.Context, compiler *workflow.Compiler, file string, stats *CompilationStats, verbose bool, checkExists bool) (bool, error) {
 	// Check if file exists if requested (for watch mode)
 	if checkExists {
 		if _, err := os.Stat(file); os.IsNotExist(err) {
 			compileHelpersLog.Printf("File %s was deleted, skipping compilation", file)
-			return false
+			return false, nil
 		}
 	}
 
@@ -70,7 +82,8 @@ func compileSingleFile(ctx context.Context, compiler *workflow.Compiler, file st
 		fmt.Fprintln(os.Stderr, console.FormatProgressMessage("Compiling: "+file))
 	}
 
-	if err := CompileWorkflowWithValidation(ctx, compiler, file, CompileValidationOptions{Verbose: verbose}); err != nil {
+	err := CompileWorkflowWithValidation(ctx, compiler, file, CompileValidationOptions{Verbose: verbose})
+	if err != nil {
 		// Always show compilation errors on a new line using standard CLI error styling.
 		fmt.Fprintln(os.Stderr, console.FormatErrorMessage(err.Error()))
 		stats.Errors++
@@ -79,11 +92,16 @@ func compileSingleFile(ctx context.Context, compiler *workflow.Compiler, file st
 		compileHelpersLog.Printf("Successfully compiled: %s", file)
 	}
 
-	return true
+	return true, err
 }
 
 // compileAllWorkflowFiles compiles all markdown files in the workflows directory
 func compileAllWorkflowFiles(ctx context.Context, compiler *workflow.Compiler, workflowsDir string, verbose bool) (*CompilationStats, error) {
+	return compileAllWorkflowFilesWith(ctx, compiler, workflowsDir, verbose, compileSingleFile)
+}
+
+// compileAllWorkflowFilesWith compiles all markdown files in the workflows directory using compileFile
+func compileAllWorkflowFilesWith(ctx context.Context, compiler *workflow.Compiler, workflowsDir string, verbose bool, compileFile compileFileFunc) (*CompilationStats, error) {
 	compileHelpersLog.Printf("Compiling all workflow files in directory: %s", workflowsDir)
 	// Reset warning count before compilation
 	compiler.ResetWarningCount()
@@ -123,7 +141,7 @@ func compileAllWorkflowFiles(ctx context.Context, compiler *workflow.Compiler, w
 		} else {
 			file = absFile
 		}
-		compileSingleFile(ctx, compiler, file, stats, verbose, false)
+		compileFile(ctx, compiler, file, stats, verbose, false)
 	}
 
 	// Get warning count from compiler
@@ -139,7 +157,7 @@ func compileAllWorkflowFiles(ctx context.Context, compiler *workflow.Compiler, w
 }
 
 // compileModifiedFilesWithDependencies compiles modified files and their dependencies using the dependency graph
-func compileModifiedFilesWithDependencies(ctx context.Context, compiler *workflow.Compiler, depGraph *DependencyGraph, files []string, verbose bool) {
+func compileModifiedFilesWithDependencies(ctx context.Context, compiler *workflow.Compiler, depGraph *DependencyGraph, files []string, verbose bool, compileFile compileFileFunc) {
 	if len(files) == 0 {
 		return
 	}
@@ -186,7 +204,7 @@ func compileModifiedFilesWithDependencies(ctx context.Context, compiler *workflo
 	stats := &CompilationStats{}
 
 	for _, file := range workflowsToCompile {
-		compileSingleFile(ctx, compiler, file, stats, verbose, true)
+		compileFile(ctx, compiler, file, stats, verbose, true)
 	}
 
 	// Get warning count from compiler
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+)
+
+var compileMigrateLog = logger.New("cli:compile_migrate")
+
+// GetMigrationCodemods returns the codemods linked from the parser's registry of renamed
+// and removed frontmatter fields, in the order they appear in GetAllCodemods.
+func GetMigrationCodemods() []Codemod {
+	ids := make(map[string]struct{})
+	for _, migration := range parser.GetFieldMigrations() {
+		if migration.Codemod != "" {
+			ids[migration.Codemod] = struct{}{}
+		}
+	}
+
+	var codemods []Codemod
+	for _, codemod := range GetAllCodemods() {
+		if _, ok := ids[codemod.ID]; ok {
+			codemods = append(codemods, codemod)
+		}
+	}
+	return codemods
+}
+
+// RunMigrate rewrites renamed and removed frontmatter fields in the given workflows
+// (or all workflows in workflowDir) using the field migration codemods. It backs the
+// compile --migrate flag and, unlike fix --write, only touches workflow files.
+func RunMigrate(workflowIDs []string, workflowDir string, verbose bool) error {
+	compileMigrateLog.Printf("Migrating deprecated fields: workflowIDs=%v, workflowDir=%s", workflowIDs, workflowDir)
+
+	files, err := resolveFixWorkflowFiles(workflowIDs, verbose, workflowDir)
+	if err != nil {
+		return err
+	}
+
+	codemods := GetMigrationCodemods()
+	compileMigrateLog.Printf("Loaded %d migration codemods for %d files", len(codemods), len(files))
+
+	var migrated int
+	var errs []error
+	for _, file := range files {
+		fixed, _, err := processWorkflowFileWithInfo(file, codemods, true, verbose)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to migrate %s: %w", filepath.Base(file), err))
+			continue
+		}
+		if fixed {
+			migrated++
+		}
+	}
+
+	if migrated > 0 {
+		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("Migrated deprecated fields in %d of %d workflow files", migrated, len(files))))
+	} else if verbose {
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage("No deprecated fields to migrate"))
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,53 @@
+//go:build !integration
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetMigrationCodemods_CoversFieldMigrations(t *testing.T) {
+	codemods := GetMigrationCodemods()
+	ids := make(map[string]bool, len(codemods))
+	for _, codemod := range codemods {
+		ids[codemod.ID] = true
+	}
+
+	for _, migration := range parser.GetFieldMigrations() {
+		assert.True(t, ids[migration.Codemod], "field %s should link to an existing codemod, got %q", migration.OldField(), migration.Codemod)
+	}
+	assert.Less(t, len(codemods), len(GetAllCodemods()), "migration codemods should be a subset of all codemods")
+}
+
+func TestRunMigrate_RewritesRenamedFields(t *testing.T) {
+	tmpDir := t.TempDir()
+	workflowFile := filepath.Join(tmpDir, "migrate.md")
+	content := `---
+on: workflow_dispatch
+timeout_minutes: 15
+engine:
+  id: copilot
+  max-runs: 5
+permissions: read
+---
+
+# Migrate
+`
+	require.NoError(t, os.WriteFile(workflowFile, []byte(content), 0644), "Failed to write workflow")
+
+	require.NoError(t, RunMigrate(nil, tmpDir, false), "migration should succeed")
+
+	updated, err := os.ReadFile(workflowFile)
+	require.NoError(t, err, "Failed to read migrated workflow")
+	result := string(updated)
+	assert.Contains(t, result, "timeout-minutes: 15", "timeout_minutes should be renamed")
+	assert.Contains(t, result, "max-turns: 5", "engine.max-runs should move to max-turns")
+	assert.NotContains(t, result, "max-runs", "engine.max-runs should be removed")
+	assert.Contains(t, result, "permissions: read", "fields outside the migration registry should be left alone")
+}
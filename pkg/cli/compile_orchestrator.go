@@ -41,6 +41,16 @@ func CompileWorkflows(ctx context.Context, config CompileConfig) ([]*workflow.Wo
 		return nil, err
 	}
 
+	if err := loadProjectSuggestionConfig(); err != nil {
+		return nil, err
+	}
+
+	// Check mode compiles in memory and compares against the existing lock files
+	if config.Check {
+		compileOrchestratorLog.Print("Check mode enabled: comparing compiled output against existing lock files")
+		config.NoEmit = true
+	}
+
 	// Validate action mode if specified
 	if err := validateActionModeConfig(config.ActionMode); err != nil {
 		return nil, err
@@ -15,12 +15,14 @@
 //
 // Summary Output:
 //   - formatValidationOutput() - Format validation results as JSON
+//   - writeCompilePorcelain() - Write validation results as porcelain records
 
 package cli
 
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 
 	"github.com/github/gh-aw/pkg/logger"
 )
@@ -42,3 +44,27 @@ func formatValidationOutput(results []ValidationResult) (string, error) {
 
 	return string(jsonBytes), nil
 }
+
+// writeCompilePorcelain writes validation results as porcelain records:
+//
+//	workflow <valid|invalid> <workflow> <compiled-file>
+//	error    <workflow> <line> <type> <message>
+//	warning  <workflow> <line> <type> <message>
+//
+// Each workflow record is followed by its error and warning records.
+func writeCompilePorcelain(w io.Writer, results []ValidationResult) {
+	compileOutputFormatterLog.Printf("Writing porcelain output for %d workflow(s)", len(results))
+	for _, result := range sanitizeValidationResults(results) {
+		state := "valid"
+		if !result.Valid {
+			state = "invalid"
+		}
+		writePorcelainRecord(w, "workflow", state, result.Workflow, result.CompiledFile)
+		for _, e := range result.Errors {
+			writePorcelainRecord(w, "error", result.Workflow, porcelainInt(e.Line), e.Type, e.Message)
+		}
+		for _, warning := range result.Warnings {
+			writePorcelainRecord(w, "warning", result.Workflow, porcelainInt(warning.Line), warning.Type, warning.Message)
+		}
+	}
+}
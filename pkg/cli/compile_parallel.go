@@ -0,0 +1,136 @@
+// This file provides the parallel compile path used by `gh aw compile --jobs N`.
+//
+// workflow.Compiler is not goroutine-safe, so each worker compiles with its own
+// compiler instance. Once every worker has finished, their warnings, check-mode
+// results and action cache changes are merged back into the primary compiler so
+// the rest of the pipeline (summary, post-processing, cache save) is unchanged.
+//
+// # Key Functions
+//
+//   - resolveCompileJobs() - Resolve the --jobs value into a worker count
+//   - compileWorkflowFilesInParallel() - Compile files on a worker pool with progress reporting
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/workflow"
+)
+
+var compileParallelLog = logger.New("cli:compile_parallel")
+
+// resolveCompileJobs returns the number of compile workers for CompileConfig.Jobs.
+// The result never exceeds the number of files; 1 means compile sequentially.
+func resolveCompileJobs(jobs int, fileCount int) int {
+	return max(min(jobs, fileCount), 1)
+}
+
+// compileProgress reports per-workflow progress while the worker pool runs.
+type compileProgress struct {
+	mu      sync.Mutex
+	spinner *console.SpinnerWrapper
+	total   int
+	done    int
+	failed  int
+}
+
+// newCompileProgress starts a progress spinner unless output is JSON. The spinner
+// disables itself when stderr is not a terminal.
+func newCompileProgress(total int, jobs int, jsonOutput bool) *compileProgress {
+	progress := &compileProgress{total: total}
+	if !jsonOutput {
+		progress.spinner = console.NewSpinner(fmt.Sprintf("Compiling %d workflow(s) with %d jobs...", total, jobs))
+		progress.spinner.Start()
+	}
+	return progress
+}
+
+// record marks one workflow as finished and updates the spinner message.
+func (p *compileProgress) record(file string, success bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.done++
+	if !success {
+		p.failed++
+	}
+	if p.spinner == nil {
+		return
+	}
+	message := fmt.Sprintf("Compiled %d/%d workflow(s): %s", p.done, p.total, filepath.Base(file))
+	if p.failed > 0 {
+		message += fmt.Sprintf(" (%d failed)", p.failed)
+	}
+	p.spinner.UpdateMessage(message)
+}
+
+// stop clears the spinner.
+func (p *compileProgress) stop() {
+	if p.spinner != nil {
+		p.spinner.Stop()
+	}
+}
+
+// compileWorkflowFilesInParallel compiles files on a pool of jobs workers and returns the
+// results keyed by file path. Each worker owns a compiler created from config; their state
+// is merged into compiler before returning. When ctx is cancelled, workers stop picking up
+// new files and the files that were not compiled are missing from the result.
+func compileWorkflowFilesInParallel(
+	ctx context.Context,
+	compiler *workflow.Compiler,
+	config CompileConfig,
+	files []string,
+	jobs int,
+	opts compileWorkflowFileOptions,
+) map[string]compileWorkflowFileResult {
+	compileParallelLog.Printf("Compiling %d workflow files with %d workers", len(files), jobs)
+
+	// Load every action cache before any worker can save it (--validate does), so all
+	// workers start from the baseline the merge compares against.
+	compiler.GetSharedActionCache()
+	workers := make([]*workflow.Compiler, jobs)
+	for i := range workers {
+		workers[i] = newConfiguredCompiler(config)
+		workers[i].SetContext(ctx)
+		workers[i].GetSharedActionCache()
+	}
+
+	progress := newCompileProgress(len(files), jobs, config.JSONOutput)
+
+	fileCh := make(chan string)
+	var mu sync.Mutex
+	results := make(map[string]compileWorkflowFileResult, len(files))
+	var wg sync.WaitGroup
+	for _, worker := range workers {
+		wg.Go(func() {
+			for file := range fileCh {
+				result := compileWorkflowFile(ctx, worker, file, opts)
+				progress.record(file, result.success)
+				mu.Lock()
+				results[file] = result
+				mu.Unlock()
+			}
+		})
+	}
+
+dispatch:
+	for _, file := range files {
+		select {
+		case <-ctx.Done():
+			break dispatch
+		case fileCh <- file:
+		}
+	}
+	close(fileCh)
+	wg.Wait()
+	progress.stop()
+
+	compiler.MergeWorkerCompilers(workers)
+	compileParallelLog.Printf("Parallel compilation finished: compiled=%d, failed=%d", progress.done, progress.failed)
+	return results
+}
@@ -0,0 +1,89 @@
+//go:build !integration
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveCompileJobs(t *testing.T) {
+	tests := []struct {
+		name      string
+		jobs      int
+		fileCount int
+		expected  int
+	}{
+		{name: "unset compiles sequentially", jobs: 0, fileCount: 10, expected: 1},
+		{name: "one job", jobs: 1, fileCount: 10, expected: 1},
+		{name: "capped by file count", jobs: 8, fileCount: 3, expected: 3},
+		{name: "fewer jobs than files", jobs: 4, fileCount: 100, expected: 4},
+		{name: "no files", jobs: 4, fileCount: 0, expected: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, resolveCompileJobs(tt.jobs, tt.fileCount), "worker count")
+		})
+	}
+}
+
+func TestCompileWorkflowsWithJobs(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "test-*")
+
+	var files []string
+	for i := range 6 {
+		content := "---\non: workflow_dispatch\npermissions:\n  contents: read\nengine: copilot\n---\n\n# Workflow\n"
+		if i%2 == 1 {
+			content = "---\non: workflow_dispatch\nengine: copilot\nbogus_field: 1\n---\n\n# Broken\n"
+		}
+		file := filepath.Join(tmpDir, fmt.Sprintf("workflow-%d.md", i))
+		require.NoError(t, os.WriteFile(file, []byte(content), 0644), "write workflow")
+		files = append(files, file)
+	}
+
+	config := CompileConfig{MarkdownFiles: files, NoEmit: true, Jobs: 4}
+	compiler := createAndConfigureCompiler(config)
+	opts := compileWorkflowFileOptions{noEmit: true}
+
+	results := compileWorkflowFilesInParallel(context.Background(), compiler, config, files, 4, opts)
+	require.Len(t, results, len(files), "every file should be compiled")
+	for i, file := range files {
+		assert.Equal(t, i%2 == 0, results[file].success, "success for %s", filepath.Base(file))
+		assert.Equal(t, filepath.Base(file), results[file].validationResult.Workflow, "result should belong to its file")
+	}
+
+	t.Run("cancelled context compiles nothing", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		results := compileWorkflowFilesInParallel(ctx, createAndConfigureCompiler(config), config, files, 4, opts)
+		assert.Less(t, len(results), len(files), "cancelled pool should stop dispatching files")
+	})
+}
+
+func TestGroupSharedCompilationErrors(t *testing.T) {
+	failures := []WorkflowFailure{
+		{Path: "/repo/.github/workflows/a.md", ErrorMessages: []string{
+			".github/workflows/a.md:4:1: error: Unknown property: bogus\n4 | bogus: 1",
+			"a.md: engine is required",
+		}},
+		{Path: "/repo/.github/workflows/b.md", ErrorMessages: []string{
+			".github/workflows/b.md:7:3: error: Unknown property: bogus\n7 | bogus: 2",
+		}},
+		{Path: "/repo/.github/workflows/c.md", ErrorMessages: []string{
+			"timeout must be positive",
+		}},
+	}
+
+	shared := groupSharedCompilationErrors(failures)
+	require.Len(t, shared, 1, "only errors reported by more than one workflow should be grouped")
+	assert.Equal(t, []string{"a.md", "b.md"}, shared[0].Workflows, "workflows should be listed in failure order")
+	assert.Contains(t, shared[0].Message, "a.md:4:1", "first occurrence should be kept with its context")
+	assert.Equal(t, "Unknown property: bogus", compilationErrorKey(shared[0].Message), "key should ignore location and context")
+}
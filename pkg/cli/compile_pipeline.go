@@ -17,6 +17,9 @@
 //   - compileSpecificFiles() - Compile a list of specific workflow files
 //   - compileAllFilesInDirectory() - Compile all workflows in a directory
 //
+// Both functions compile on a worker pool (see compile_parallel.go) when --jobs is
+// greater than one, then process the results sequentially in input order.
+//
 // These functions handle the complete compilation pipeline for their respective scenarios.
 
 package cli
@@ -70,6 +73,31 @@ func compileSpecificFiles(
 	var strictGrantErr error
 	var lockFilesForYamllint []string // lock files for yamllint YAML linter
 
+	// Per-file security tools are disabled; zizmor, poutine and actionlint run in batch instead
+	fileOpts := compileWorkflowFileOptions{
+		verbose:    config.Verbose,
+		jsonOutput: config.JSONOutput,
+		noEmit:     config.NoEmit,
+		strict:     config.Strict,
+		validate:   shouldValidate,
+	}
+
+	// With --jobs, compile on a worker pool first; the loop below still consumes the
+	// results in argument order so the summary and JSON output stay deterministic.
+	var precompiled map[string]compileWorkflowFileResult
+	if jobs := resolveCompileJobs(config.Jobs, len(config.MarkdownFiles)); jobs > 1 {
+		var resolvedFiles []string
+		seen := make(map[string]bool)
+		for _, markdownFile := range config.MarkdownFiles {
+			// Resolution errors are reported by the loop below
+			if resolvedFile, err := resolveWorkflowFile(markdownFile, false); err == nil && !seen[resolvedFile] {
+				seen[resolvedFile] = true
+				resolvedFiles = append(resolvedFiles, resolvedFile)
+			}
+		}
+		precompiled = compileWorkflowFilesInParallel(ctx, compiler, config, resolvedFiles, jobs, fileOpts)
+	}
+
 	// Compile each specified file
 	for _, markdownFile := range config.MarkdownFiles {
 		// Respect context cancellation between files (e.g. Ctrl+C)
@@ -112,17 +140,11 @@ func compileSpecificFiles(
 		// Update result with resolved file name
 		result.Workflow = filepath.Base(resolvedFile)
 
-		// Compile regular workflow file (disable per-file security tools)
-		fileResult := compileWorkflowFile(
-			ctx, compiler, resolvedFile, compileWorkflowFileOptions{
-				verbose:    config.Verbose,
-				jsonOutput: config.JSONOutput,
-				noEmit:     config.NoEmit,
-				strict:     config.Strict,
-				validate:   shouldValidate,
-				// zizmor, poutine, actionlint disabled per-file (batched instead)
-			},
-		)
+		// Compile regular workflow file unless the worker pool already did
+		fileResult, ok := precompiled[resolvedFile]
+		if !ok {
+			fileResult = compileWorkflowFile(ctx, compiler, resolvedFile, fileOpts)
+		}
 
 		if !fileResult.success {
 			// Collect error messages from validation result for display in summary
@@ -140,6 +162,9 @@ func compileSpecificFiles(
 			compiledCount++
 			if fileResult.workflowData != nil {
 				workflowDataList = append(workflowDataList, fileResult.workflowData)
+				if config.Explain {
+					fileResult.validationResult.Explain = buildWorkflowExplanation(fileResult.workflowData, defaultLogsOutputDir)
+				}
 			}
 
 			// Collect lock files for batch security tools
@@ -313,6 +338,11 @@ func compileSpecificFiles(
 		return workflowDataList, errors.New("compilation failed")
 	}
 
+	// Fail when check mode found lock files that need recompiling
+	if err := reportStaleLockFiles(compiler, config, nil); err != nil {
+		return workflowDataList, err
+	}
+
 	return workflowDataList, nil
 }
 
@@ -362,9 +392,10 @@ func compileAllFilesInDirectory(
 		fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Found %d markdown files to compile", len(mdFiles))))
 	}
 
-	// Handle purge logic: collect existing files before compilation
+	// Handle purge logic: collect existing files before compilation.
+	// Check mode uses the same data to report orphaned lock files.
 	var purgeData *purgeTrackingData
-	if config.Purge {
+	if config.Purge || config.Check {
 		purgeData = collectPurgeData(workflowsDir, mdFiles, config.Verbose)
 	}
 
@@ -388,6 +419,22 @@ func compileAllFilesInDirectory(
 	var strictGrantErr error
 	var lockFilesForYamllint []string // lock files for yamllint YAML linter
 
+	// Per-file security tools are disabled; zizmor, poutine and actionlint run in batch instead
+	fileOpts := compileWorkflowFileOptions{
+		verbose:    config.Verbose,
+		jsonOutput: config.JSONOutput,
+		noEmit:     config.NoEmit,
+		strict:     config.Strict,
+		validate:   shouldValidate,
+	}
+
+	// With --jobs, compile on a worker pool first; the loop below still consumes the
+	// results in file order so the summary and JSON output stay deterministic.
+	var precompiled map[string]compileWorkflowFileResult
+	if jobs := resolveCompileJobs(config.Jobs, len(mdFiles)); jobs > 1 {
+		precompiled = compileWorkflowFilesInParallel(ctx, compiler, config, mdFiles, jobs, fileOpts)
+	}
+
 	for _, file := range mdFiles {
 		// Respect context cancellation between files (e.g. Ctrl+C)
 		select {
@@ -399,17 +446,11 @@ func compileAllFilesInDirectory(
 
 		stats.Total++
 
-		// Compile regular workflow file (disable per-file security tools)
-		fileResult := compileWorkflowFile(
-			ctx, compiler, file, compileWorkflowFileOptions{
-				verbose:    config.Verbose,
-				jsonOutput: config.JSONOutput,
-				noEmit:     config.NoEmit,
-				strict:     config.Strict,
-				validate:   shouldValidate,
-				// zizmor, poutine, actionlint disabled per-file (batched instead)
-			},
-		)
+		// Compile regular workflow file unless the worker pool already did
+		fileResult, ok := precompiled[file]
+		if !ok {
+			fileResult = compileWorkflowFile(ctx, compiler, file, fileOpts)
+		}
 
 		if !fileResult.success {
 			// Collect error messages from validation result
@@ -427,6 +468,9 @@ func compileAllFilesInDirectory(
 			successCount++
 			if fileResult.workflowData != nil {
 				workflowDataList = append(workflowDataList, fileResult.workflowData)
+				if config.Explain {
+					fileResult.validationResult.Explain = buildWorkflowExplanation(fileResult.workflowData, defaultLogsOutputDir)
+				}
 			}
 
 			// Collect lock files for batch security tools
@@ -611,6 +655,15 @@ func compileAllFilesInDirectory(
 		return workflowDataList, errors.New("compilation failed")
 	}
 
+	// Fail when check mode found lock files that need recompiling or deleting
+	var orphanedLockFiles []string
+	if config.Check && purgeData != nil {
+		orphanedLockFiles = filterOrphanedLockFiles(purgeData.existingLockFiles, purgeData.expectedLockFiles)
+	}
+	if err := reportStaleLockFiles(compiler, config, orphanedLockFiles); err != nil {
+		return workflowDataList, err
+	}
+
 	return workflowDataList, nil
 }
 
@@ -652,6 +705,7 @@ func runPurgeOperations(workflowsDir string, data *purgeTrackingData, verbose bo
 	// Errors from purge operations are logged but don't stop compilation
 	_ = purgeOrphanedLockFiles(workflowsDir, data.expectedLockFiles, verbose)
 	_ = purgeInvalidFiles(workflowsDir, verbose)
+	_ = purgeUnusedSharedActions(workflowsDir, verbose)
 }
 
 // runPostProcessing runs post-processing for specific files compilation
@@ -785,8 +839,15 @@ func outputResults(
 		displayScheduleCalendar(statsList)
 	}
 
-	// Output JSON if requested
-	if config.JSONOutput {
+	// Display per-workflow explanations if requested (JSON output embeds them in each result)
+	if config.Explain && !config.JSONOutput {
+		displayWorkflowExplanations(*validationResults)
+	}
+
+	// Output porcelain records or JSON if requested
+	if config.Porcelain {
+		writeCompilePorcelain(os.Stdout, *validationResults)
+	} else if config.JSONOutput {
 		jsonStr, err := formatValidationOutput(*validationResults)
 		if err != nil {
 			return err
@@ -23,6 +23,7 @@
 // Cleanup:
 //   - purgeOrphanedLockFiles() - Remove orphaned .lock.yml files
 //   - purgeInvalidFiles() - Remove .invalid.yml files
+//   - purgeUnusedSharedActions() - Remove shared actions no lock file references
 //
 // Warnings and Cache:
 //   - displayScheduleWarnings() - Display schedule warnings from the compiler
@@ -39,9 +40,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 
 	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/gitutil"
 	"github.com/github/gh-aw/pkg/logger"
 	"github.com/github/gh-aw/pkg/setutil"
 	"github.com/github/gh-aw/pkg/workflow"
@@ -100,6 +103,7 @@ func generateMaintenanceWorkflowWrapper(
 		ActionTag:        compiler.GetActionTag(),
 		RepoConfig:       repoConfig,
 		RepoSlug:         compiler.GetRepositorySlug(),
+		Offline:          compiler.IsOffline(),
 	}); err != nil {
 		if strict {
 			return fmt.Errorf("failed to generate maintenance workflow: %w", err)
@@ -162,25 +166,8 @@ func purgeOrphanedLockFiles(workflowsDir string, expectedLockFiles []string, ver
 		fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Found %d existing .lock.yml files", len(existingLockFiles))))
 	}
 
-	// Build a set of expected lock files
-	expectedLockFileSet := make(map[string]struct {
-	})
-	for _, expected := range expectedLockFiles {
-		expectedLockFileSet[expected] = struct {
-		}{}
-	}
-
 	// Find lock files that should be deleted (exist but aren't expected)
-	var orphanedFiles []string
-	for _, existing := range existingLockFiles {
-		// Skip .campaign.lock.yml files - they're handled by purgeOrphanedCampaignOrchestratorLockFiles
-		if strings.HasSuffix(existing, ".campaign.lock.yml") {
-			continue
-		}
-		if !setutil.Contains(expectedLockFileSet, existing) {
-			orphanedFiles = append(orphanedFiles, existing)
-		}
-	}
+	orphanedFiles := filterOrphanedLockFiles(existingLockFiles, expectedLockFiles)
 
 	// Delete orphaned lock files
 	if len(orphanedFiles) > 0 {
@@ -200,6 +187,29 @@ func purgeOrphanedLockFiles(workflowsDir string, expectedLockFiles []string, ver
 	return nil
 }
 
+// filterOrphanedLockFiles returns the existing lock files that are not expected,
+// i.e. lock files whose .md source no longer exists
+func filterOrphanedLockFiles(existingLockFiles []string, expectedLockFiles []string) []string {
+	expectedLockFileSet := make(map[string]struct {
+	})
+	for _, expected := range expectedLockFiles {
+		expectedLockFileSet[expected] = struct {
+		}{}
+	}
+
+	var orphanedFiles []string
+	for _, existing := range existingLockFiles {
+		// Skip .campaign.lock.yml files - they're handled by purgeOrphanedCampaignOrchestratorLockFiles
+		if strings.HasSuffix(existing, ".campaign.lock.yml") {
+			continue
+		}
+		if !setutil.Contains(expectedLockFileSet, existing) {
+			orphanedFiles = append(orphanedFiles, existing)
+		}
+	}
+	return orphanedFiles
+}
+
 // purgeInvalidFiles removes all .invalid.yml files
 // These are temporary debugging artifacts that should not persist
 func purgeInvalidFiles(workflowsDir string, verbose bool) error {
@@ -237,6 +247,66 @@ func purgeInvalidFiles(workflowsDir string, verbose bool) error {
 	return nil
 }
 
+// sharedActionReferencePattern matches a step reference to a shared action
+var sharedActionReferencePattern = regexp.MustCompile(`uses: \./` + regexp.QuoteMeta(workflow.SharedActionsDir) + `/([A-Za-z0-9_.-]+)`)
+
+// purgeUnusedSharedActions removes the generated shared actions that no lock file in
+// workflowsDir references. Each compile writes the actions its workflow uses but never
+// deletes any, since other lock files may still use them.
+func purgeUnusedSharedActions(workflowsDir string, verbose bool) error {
+	repoRoot, err := gitutil.FindGitRootFrom(workflowsDir)
+	if err != nil {
+		repoRoot = filepath.Join(workflowsDir, "..", "..")
+	}
+	actionsDir := filepath.Join(repoRoot, workflow.SharedActionsDir)
+	entries, err := os.ReadDir(actionsDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read %s: %w", workflow.SharedActionsDir, err)
+	}
+
+	lockFiles, err := filepath.Glob(filepath.Join(workflowsDir, "*.lock.yml"))
+	if err != nil {
+		return fmt.Errorf("failed to find existing lock files: %w", err)
+	}
+	referenced := make(map[string]bool)
+	for _, lockFile := range lockFiles {
+		content, err := os.ReadFile(lockFile)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", filepath.Base(lockFile), err)
+		}
+		for _, match := range sharedActionReferencePattern.FindAllStringSubmatch(string(content), -1) {
+			referenced[match[1]] = true
+		}
+	}
+
+	removed := 0
+	for _, entry := range entries {
+		if !entry.IsDir() || referenced[entry.Name()] {
+			continue
+		}
+		dir := filepath.Join(actionsDir, entry.Name())
+		content, err := os.ReadFile(filepath.Join(dir, "action.yml"))
+		if err != nil || !strings.HasPrefix(string(content), workflow.SharedActionHeader) {
+			// Not generated by the compiler
+			continue
+		}
+		if err := os.RemoveAll(dir); err != nil {
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Failed to remove unused shared action %s: %v", entry.Name(), err)))
+			continue
+		}
+		removed++
+		if verbose {
+			fmt.Fprintln(os.Stderr, console.FormatSuccessMessage("Removed unused shared action: "+entry.Name()))
+		}
+	}
+
+	compilePostProcessingLog.Printf("Purged %d unused shared actions", removed)
+	return nil
+}
+
 // displayScheduleWarnings displays any schedule warnings from the compiler
 func displayScheduleWarnings(compiler *workflow.Compiler, jsonOutput bool) {
 	scheduleWarnings := compiler.GetScheduleWarnings()
@@ -0,0 +1,36 @@
+//go:build !integration
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPurgeUnusedSharedActions(t *testing.T) {
+	repoRoot := testutil.TempDir(t, "shared-actions-*")
+	workflowsDir := filepath.Join(repoRoot, ".github", "workflows")
+	require.NoError(t, os.MkdirAll(workflowsDir, 0o755), "create workflows dir")
+	require.NoError(t, os.WriteFile(filepath.Join(workflowsDir, "triage.lock.yml"), []byte("jobs:\n  conclusion:\n    steps:\n      - name: Process no-op messages\n        uses: ./.github/actions/gh-aw/noop\n"), 0o644), "write lock file")
+
+	actionsDir := filepath.Join(repoRoot, workflow.SharedActionsDir)
+	writeAction := func(name, content string) {
+		require.NoError(t, os.MkdirAll(filepath.Join(actionsDir, name), 0o755), "create action dir")
+		require.NoError(t, os.WriteFile(filepath.Join(actionsDir, name, "action.yml"), []byte(content), 0o644), "write action")
+	}
+	writeAction("noop", workflow.SharedActionHeader+"\nname: gh-aw noop\n")
+	writeAction("missing-tool", workflow.SharedActionHeader+"\nname: gh-aw missing-tool\n")
+	writeAction("custom", "name: Hand-written action\n")
+
+	require.NoError(t, purgeUnusedSharedActions(workflowsDir, false), "purge should succeed")
+
+	assert.DirExists(t, filepath.Join(actionsDir, "noop"), "referenced actions should be kept")
+	assert.NoDirExists(t, filepath.Join(actionsDir, "missing-tool"), "unreferenced generated actions should be removed")
+	assert.DirExists(t, filepath.Join(actionsDir, "custom"), "actions not generated by the compiler should be kept")
+}
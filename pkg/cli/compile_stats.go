@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/github/gh-aw/pkg/console"
 	"github.com/github/gh-aw/pkg/logger"
@@ -134,6 +136,84 @@ func trackWorkflowFailure(stats *CompilationStats, workflowPath string, errorCou
 	})
 }
 
+// sharedCompilationError is an error reported identically by more than one failed workflow.
+type sharedCompilationError struct {
+	Message   string   // First occurrence of the error, including its source context
+	Workflows []string // Base names of the workflows that reported it, in failure order
+}
+
+// compilationErrorLocationPattern matches the "path:line:col: error: " prefix of formatted errors.
+var compilationErrorLocationPattern = regexp.MustCompile(`^\S+:\d+(?::\d+)?:\s*(?:error:\s*)?`)
+
+// compilationErrorKey identifies an error independently of the file it was reported in:
+// the location prefix and the source context lines that follow the first line are ignored.
+func compilationErrorKey(message string) string {
+	firstLine, _, _ := strings.Cut(strings.TrimSpace(message), "\n")
+	return strings.TrimSpace(compilationErrorLocationPattern.ReplaceAllString(firstLine, ""))
+}
+
+// groupSharedCompilationErrors returns the errors reported by two or more failed workflows,
+// most widespread first, so a schema mistake repeated across many files is shown once.
+func groupSharedCompilationErrors(failures []WorkflowFailure) []sharedCompilationError {
+	var groups []*sharedCompilationError
+	byKey := make(map[string]*sharedCompilationError)
+	for _, failure := range failures {
+		name := filepath.Base(failure.Path)
+		seen := make(map[string]bool)
+		for _, message := range failure.ErrorMessages {
+			key := compilationErrorKey(message)
+			if key == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			group, ok := byKey[key]
+			if !ok {
+				group = &sharedCompilationError{Message: message}
+				byKey[key] = group
+				groups = append(groups, group)
+			}
+			group.Workflows = append(group.Workflows, name)
+		}
+	}
+
+	var shared []sharedCompilationError
+	for _, group := range groups {
+		if len(group.Workflows) > 1 {
+			shared = append(shared, *group)
+		}
+	}
+	slices.SortStableFunc(shared, func(a, b sharedCompilationError) int {
+		return len(b.Workflows) - len(a.Workflows)
+	})
+	return shared
+}
+
+// printSharedCompilationErrors prints each shared error once with the workflows that reported it
+// and returns the keys that were printed so per-workflow output can skip them.
+func printSharedCompilationErrors(failures []WorkflowFailure) map[string]bool {
+	shared := groupSharedCompilationErrors(failures)
+	if len(shared) == 0 {
+		return nil
+	}
+	compileStatsLog.Printf("Grouped %d error(s) shared across failed workflows", len(shared))
+
+	printed := make(map[string]bool, len(shared))
+	for _, group := range shared {
+		printed[compilationErrorKey(group.Message)] = true
+		header := fmt.Sprintf("Shared by %d workflow(s): %s", len(group.Workflows), strings.Join(group.Workflows, ", "))
+		fmt.Fprintln(os.Stderr, console.FormatErrorMessage(header))
+		report := workflow.BuildPrioritizedErrorReportFromMessages([]string{group.Message}, true)
+		for _, prioritized := range report.DisplayedErrors {
+			fmt.Fprintln(os.Stderr, console.FormatListItem(fmt.Sprintf("%s %s", prioritized.Severity.Icon(), prioritized.Message)))
+			if prioritized.Suggestion != "" {
+				fmt.Fprintln(os.Stderr, console.FormatInfoMessage("→ "+prioritized.Suggestion))
+			}
+		}
+	}
+	fmt.Fprintln(os.Stderr)
+	return printed
+}
+
 // printCompilationSummary prints a summary of the compilation results
 func printCompilationSummary(stats *CompilationStats, showAllErrors bool) {
 	if stats.Total == 0 {
@@ -164,9 +244,18 @@ func printCompilationSummary(stats *CompilationStats, showAllErrors bool) {
 			}
 			fmt.Fprintln(os.Stderr)
 
+			// Errors repeated across workflows are printed once instead of per workflow
+			sharedKeys := printSharedCompilationErrors(stats.FailureDetails)
+
 			// Display the actual error messages for each failed workflow
 			for _, failure := range stats.FailureDetails {
-				report := workflow.BuildPrioritizedErrorReportFromMessages(failure.ErrorMessages, showAllErrors)
+				messages := failure.ErrorMessages
+				if len(sharedKeys) > 0 {
+					messages = slices.DeleteFunc(slices.Clone(messages), func(message string) bool {
+						return sharedKeys[compilationErrorKey(message)]
+					})
+				}
+				report := workflow.BuildPrioritizedErrorReportFromMessages(messages, showAllErrors)
 				if report.TotalCount == 0 {
 					continue
 				}
@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/github/gh-aw/pkg/gitutil"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+)
+
+var compileSuggestionConfigLog = logger.New("cli:compile_suggestion_config")
+
+// suggestionConfigPath returns the location of the project's suggestion settings.
+func suggestionConfigPath(gitRoot string) string {
+	return filepath.Join(gitRoot, ".github", "aw", "suggestions.json")
+}
+
+// loadProjectSuggestionConfig applies .github/aw/suggestions.json from the repository
+// root to the "Did you mean" suggestions in compiler errors. Defaults are restored when
+// the file does not exist or the command runs outside a git repository.
+func loadProjectSuggestionConfig() error {
+	gitRoot, err := gitutil.FindGitRoot()
+	if err != nil {
+		compileSuggestionConfigLog.Printf("Not in a git repository, using default suggestions: %v", err)
+		parser.SetSuggestionConfig(parser.SuggestionConfig{})
+		return nil
+	}
+
+	path := suggestionConfigPath(gitRoot)
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		parser.SetSuggestionConfig(parser.SuggestionConfig{})
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	config, err := parser.ParseSuggestionConfig(content)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	compileSuggestionConfigLog.Printf("Loaded suggestion config from %s: maxDistance=%d, synonyms=%d", path, config.MaxDistance, len(config.Synonyms))
+	parser.SetSuggestionConfig(config)
+	return nil
+}
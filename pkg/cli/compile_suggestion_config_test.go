@@ -0,0 +1,34 @@
+//go:build !integration
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadProjectSuggestionConfig(t *testing.T) {
+	gitRoot := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(gitRoot, ".git"), 0755), "Failed to create .git")
+	require.NoError(t, os.MkdirAll(filepath.Join(gitRoot, ".github", "aw"), 0755), "Failed to create .github/aw")
+	t.Chdir(gitRoot)
+	t.Cleanup(func() { parser.SetSuggestionConfig(parser.SuggestionConfig{}) })
+
+	candidates := []string{"pull_request", "issues"}
+	require.NoError(t, loadProjectSuggestionConfig(), "missing config should use defaults")
+	assert.Empty(t, parser.FindClosestMatches("pr", candidates, 3), "defaults should not know project synonyms")
+
+	require.NoError(t, os.WriteFile(suggestionConfigPath(gitRoot), []byte(`{"synonyms": {"pr": "pull_request"}}`), 0644), "Failed to write config")
+	require.NoError(t, loadProjectSuggestionConfig(), "valid config should load")
+	assert.Equal(t, []string{"pull_request"}, parser.FindClosestMatches("pr", candidates, 3), "project synonym should be applied")
+
+	require.NoError(t, os.WriteFile(suggestionConfigPath(gitRoot), []byte(`{"max-distance": 50}`), 0644), "Failed to write config")
+	err := loadProjectSuggestionConfig()
+	require.Error(t, err, "invalid config should be rejected")
+	assert.Contains(t, err.Error(), "suggestions.json", "error should name the file")
+}
@@ -210,6 +210,33 @@ func validateCompileConfig(config CompileConfig) error {
 		return errors.New("--purge flag can only be used when compiling all markdown files (no specific files specified)")
 	}
 
+	// Validate check flag usage: check mode never writes, so it cannot watch, purge, or generate files
+	if config.Check {
+		if config.Watch {
+			return errors.New("--check cannot be used with --watch")
+		}
+		if config.Purge {
+			return errors.New("--check cannot be used with --purge")
+		}
+		if config.Dependabot {
+			return errors.New("--check cannot be used with --dependabot")
+		}
+	}
+
+	if err := validateOfflineCompileConfig(config); err != nil {
+		return err
+	}
+
+	if config.HandlerScripts != "" && !workflow.HandlerScriptMode(config.HandlerScripts).IsValid() {
+		compileValidationLog.Printf("Config validation failed: invalid handler scripts mode: %s", config.HandlerScripts)
+		return fmt.Errorf("invalid --handler-scripts value '%s'. Must be 'action', 'files', or 'inline'", config.HandlerScripts)
+	}
+
+	if config.Jobs < 0 {
+		compileValidationLog.Printf("Config validation failed: negative jobs: %d", config.Jobs)
+		return fmt.Errorf("--jobs must not be negative, got: %d", config.Jobs)
+	}
+
 	// Validate workflow directory path
 	if config.WorkflowDir != "" && filepath.IsAbs(config.WorkflowDir) {
 		compileValidationLog.Printf("Config validation failed: absolute path in workflowDir: %s", config.WorkflowDir)
@@ -220,6 +247,21 @@ func validateCompileConfig(config CompileConfig) error {
 	return nil
 }
 
+// validateOfflineCompileConfig rejects flags that need the network when --offline is set:
+// refreshing pins queries the GitHub API and Dependabot lock files are generated with npm.
+func validateOfflineCompileConfig(config CompileConfig) error {
+	if !config.Offline {
+		return nil
+	}
+	if config.ForceRefreshActionPins {
+		return errors.New("--offline cannot be used with --force-refresh-action-pins")
+	}
+	if config.Dependabot {
+		return errors.New("--offline cannot be used with --dependabot")
+	}
+	return nil
+}
+
 // validateActionModeConfig validates the action mode configuration
 func validateActionModeConfig(actionMode string) error {
 	if actionMode == "" {
@@ -263,6 +305,7 @@ func sanitizeValidationResults(results []ValidationResult) []ValidationResult {
 			Errors:       sliceutil.Map(result.Errors, sanitizeError),
 			Warnings:     sliceutil.Map(result.Warnings, sanitizeError),
 			Labels:       result.Labels,
+			Explain:      result.Explain,
 		}
 	})
 }
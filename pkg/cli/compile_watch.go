@@ -7,8 +7,9 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
+	"sort"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/fsnotify/fsnotify"
@@ -73,10 +74,31 @@ func watchAndCompileWorkflows(ctx context.Context, markdownFile string, compiler
 		return watcher.Add(path)
 	}
 
+	// Watch every directory in the import graph: the workflows directory, its
+	// subdirectories (shared fragments), and directories of imports outside it.
+	watchedDirs := make(map[string]struct{})
+	watchDir := func(dir string) {
+		if _, watched := watchedDirs[dir]; watched {
+			return
+		}
+		if err := addWatchPath(dir); err != nil {
+			compileWatchLog.Printf("Failed to watch directory %s: %v", dir, err)
+			return
+		}
+		watchedDirs[dir] = struct{}{}
+		compileWatchLog.Printf("Watching directory: %s", dir)
+	}
+	watchImportGraph := func() {
+		for _, dir := range depGraph.WatchDirs() {
+			watchDir(dir)
+		}
+	}
+
 	// Add the workflows directory to the watcher
 	if err := addWatchPath(workflowsDir); err != nil {
 		return fmt.Errorf("failed to watch directory %s: %w", workflowsDir, err)
 	}
+	watchedDirs[workflowsDir] = struct{}{}
 
 	// Also watch subdirectories for include files (recursive watching)
 	err = filepath.Walk(workflowsDir, func(path string, info os.FileInfo, err error) error {
@@ -84,18 +106,14 @@ func watchAndCompileWorkflows(ctx context.Context, markdownFile string, compiler
 			return nil // Skip errors but continue walking
 		}
 		if info.IsDir() && path != workflowsDir {
-			// Add subdirectories to the watcher
-			if err := addWatchPath(path); err != nil {
-				compileWatchLog.Printf("Failed to watch subdirectory %s: %v", path, err)
-			} else {
-				compileWatchLog.Printf("Watching subdirectory: %s", path)
-			}
+			watchDir(path)
 		}
 		return nil
 	})
 	if err != nil {
 		compileWatchLog.Printf("Failed to walk subdirectories: %v", err)
 	}
+	watchImportGraph()
 
 	// Always emit the begin pattern for task integration
 	if markdownFile != "" {
@@ -108,19 +126,34 @@ func watchAndCompileWorkflows(ctx context.Context, markdownFile string, compiler
 		fmt.Fprintln(os.Stderr, "Press Ctrl+C to stop watching.")
 	}
 
-	// Debouncing setup
+	// Debouncing setup: changes are collected until no event arrives for debounceDelay,
+	// then compiled together on the watch loop goroutine.
 	const debounceDelay = 300 * time.Millisecond
-	var debounceTimer *time.Timer
-	var debounceMu sync.Mutex
+	debounceTimer := time.NewTimer(debounceDelay)
+	debounceTimer.Stop()
+	defer debounceTimer.Stop()
 	modifiedFiles := make(map[string]struct{})
 
+	// The session keeps errors on screen until fixed and diffs lock files after each compile.
+	session := newWatchSession()
+	compileFile := compileFileFunc(session.compileFile)
+	if markdownFile != "" {
+		// Only the watched workflow is recompiled, even when a shared import changes.
+		compileFile = func(ctx context.Context, compiler *workflow.Compiler, file string, stats *CompilationStats, verbose bool, checkExists bool) bool {
+			if file != markdownFile {
+				return false
+			}
+			return session.compileFile(ctx, compiler, file, stats, verbose, checkExists)
+		}
+	}
+
 	// Compile initially if no specific file provided
 	if markdownFile == "" {
 		fmt.Fprintln(os.Stderr, "Watching for file changes")
 		if verbose {
 			fmt.Fprintln(os.Stderr, "🔨 Initial compilation of all workflow files...")
 		}
-		stats, err := compileAllWorkflowFiles(ctx, compiler, workflowsDir, verbose)
+		stats, err := compileAllWorkflowFilesWith(ctx, compiler, workflowsDir, verbose, compileFile)
 		if err != nil {
 			// Always show initial compilation errors, not just in verbose mode
 			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Initial compilation failed: %v", err)))
@@ -139,8 +172,7 @@ func watchAndCompileWorkflows(ctx context.Context, markdownFile string, compiler
 			fmt.Fprintln(os.Stderr, console.FormatProgressMessage(fmt.Sprintf("Initial compilation of %s...", markdownFile)))
 		}
 
-		// Use compileSingleFile to handle both regular workflows and campaign files
-		compileSingleFile(ctx, compiler, markdownFile, stats, verbose, false)
+		compileFile(ctx, compiler, markdownFile, stats, verbose, false)
 
 		// Get warning count from compiler
 		stats.Warnings = compiler.GetWarningCount()
@@ -149,6 +181,17 @@ func watchAndCompileWorkflows(ctx context.Context, markdownFile string, compiler
 		printCompilationSummary(stats, false)
 	}
 
+	// isWatchedChange reports whether a changed file belongs to the watched import graph.
+	isWatchedChange := func(path string) bool {
+		if strings.HasSuffix(path, ".lock.yml") {
+			return false
+		}
+		if markdownFile != "" {
+			return path == markdownFile || slices.Contains(depGraph.ImportClosure(markdownFile), path)
+		}
+		return strings.HasSuffix(path, ".md") || depGraph.IsTracked(path)
+	}
+
 	// Main watch loop
 	for {
 		select {
@@ -162,13 +205,8 @@ func watchAndCompileWorkflows(ctx context.Context, markdownFile string, compiler
 				continue
 			}
 
-			// Only process markdown files and ignore lock files
-			if !strings.HasSuffix(event.Name, ".md") {
-				continue
-			}
-
-			// If watching a specific file, only process that file
-			if markdownFile != "" && event.Name != markdownFile {
+			// Only process workflows and files they import; ignore lock files
+			if !isWatchedChange(event.Name) {
 				continue
 			}
 
@@ -182,37 +220,29 @@ func watchAndCompileWorkflows(ctx context.Context, markdownFile string, compiler
 			case event.Has(fsnotify.Remove):
 				// Handle file deletion
 				handleFileDeleted(event.Name, verbose)
+				session.removeWorkflow(event.Name)
 				// Remove from dependency graph
 				depGraph.RemoveWorkflow(event.Name)
 			case event.Has(fsnotify.Write) || event.Has(fsnotify.Create):
 				// Handle file modification or creation - add to debounced compilation
-				func() {
-					debounceMu.Lock()
-					defer debounceMu.Unlock()
-					modifiedFiles[event.Name] = struct{}{}
-
-					// Reset debounce timer
-					if debounceTimer != nil {
-						debounceTimer.Stop()
-					}
-					debounceTimer = time.AfterFunc(debounceDelay, func() {
-						filesToCompile := func() []string {
-							debounceMu.Lock()
-							defer debounceMu.Unlock()
-							files := make([]string, 0, len(modifiedFiles))
-							for file := range modifiedFiles {
-								files = append(files, file)
-							}
-							// Clear the modifiedFiles map
-							modifiedFiles = make(map[string]struct{})
-							return files
-						}()
-
-						// Compile the modified files using dependency graph
-						compileModifiedFilesWithDependencies(ctx, compiler, depGraph, filesToCompile, verbose)
-					})
-				}()
+				modifiedFiles[event.Name] = struct{}{}
+				debounceTimer.Reset(debounceDelay)
+			}
+
+		case <-debounceTimer.C:
+			filesToCompile := make([]string, 0, len(modifiedFiles))
+			for file := range modifiedFiles {
+				filesToCompile = append(filesToCompile, file)
 			}
+			sort.Strings(filesToCompile)
+			modifiedFiles = make(map[string]struct{})
+
+			// Compile the modified files using dependency graph, then keep unresolved
+			// errors on screen and pick up directories of newly added imports.
+			session.beginPass()
+			compileModifiedFilesWithDependencies(ctx, compiler, depGraph, filesToCompile, verbose, compileFile)
+			session.endPass()
+			watchImportGraph()
 
 		case err, ok := <-watcher.Errors:
 			if !ok {
@@ -227,9 +257,6 @@ func watchAndCompileWorkflows(ctx context.Context, markdownFile string, compiler
 			if verbose {
 				fmt.Fprintln(os.Stderr, "\n🛑 Stopping watch mode...")
 			}
-			if debounceTimer != nil {
-				debounceTimer.Stop()
-			}
 			return nil
 		}
 	}
@@ -0,0 +1,151 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/aymanbagabas/go-udiff"
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/tty"
+	"github.com/github/gh-aw/pkg/workflow"
+)
+
+var compileWatchSessionLog = logger.New("cli:compile_watch_session")
+
+// maxWatchLockDiffLines caps the lock file diff printed after each recompilation so a
+// large change (for example a compiler upgrade) does not flood the terminal.
+const maxWatchLockDiffLines = 200
+
+// terminalBell is written to stderr when a change introduces a new compilation error.
+const terminalBell = "\a"
+
+// watchSession carries state across recompilations in watch mode: the last error of each
+// failing workflow, so errors stay visible until fixed even when other files change and the
+// screen is cleared.
+type watchSession struct {
+	failures map[string]string   // workflow path -> error from its latest compilation
+	compiled map[string]struct{} // workflows compiled in the current pass
+	previous map[string]struct{} // workflows failing before the current pass
+	showDiff bool                // lock diffs are skipped for the initial compilation
+	colorize bool
+	out      io.Writer
+}
+
+// newWatchSession creates a watch session that writes to stderr.
+func newWatchSession() *watchSession {
+	return &watchSession{
+		failures: make(map[string]string),
+		compiled: make(map[string]struct{}),
+		previous: make(map[string]struct{}),
+		colorize: tty.IsStderrTerminal(),
+		out:      os.Stderr,
+	}
+}
+
+// beginPass starts a recompilation pass.
+func (s *watchSession) beginPass() {
+	s.showDiff = true
+	s.compiled = make(map[string]struct{})
+	s.previous = make(map[string]struct{}, len(s.failures))
+	for file := range s.failures {
+		s.previous[file] = struct{}{}
+	}
+}
+
+// compileFile compiles one workflow, records or clears its error, and prints a colored diff
+// of the lock file changes. It has the compileFileFunc signature.
+func (s *watchSession) compileFile(ctx context.Context, compiler *workflow.Compiler, file string, stats *CompilationStats, verbose bool, checkExists bool) bool {
+	lockFile := stringutil.MarkdownToLockFile(file)
+	before, _ := os.ReadFile(lockFile)
+
+	attempted, err := compileSingleFileWithError(ctx, compiler, file, stats, verbose, checkExists)
+	if !attempted {
+		// Deleted workflows cannot stay in the overlay.
+		delete(s.failures, file)
+		return false
+	}
+	s.compiled[file] = struct{}{}
+	if err != nil {
+		s.failures[file] = err.Error()
+		return true
+	}
+	delete(s.failures, file)
+
+	after, readErr := os.ReadFile(lockFile)
+	if readErr != nil {
+		compileWatchSessionLog.Printf("Skipping lock file diff for %s: %v", lockFile, readErr)
+		return true
+	}
+	s.printLockDiff(lockFile, string(before), string(after))
+	return true
+}
+
+// removeWorkflow drops a deleted workflow from the error overlay.
+func (s *watchSession) removeWorkflow(file string) {
+	delete(s.failures, file)
+}
+
+// printLockDiff prints a colored unified diff between two lock file versions.
+func (s *watchSession) printLockDiff(lockFile, before, after string) {
+	if !s.showDiff || before == after {
+		return
+	}
+	name := console.ToRelativePath(lockFile)
+	if before == "" {
+		fmt.Fprintln(s.out, console.FormatInfoMessage("Created "+name))
+		return
+	}
+
+	diff := strings.TrimSuffix(udiff.Unified(name, name, before, after), "\n")
+	lines := strings.Split(diff, "\n")
+	hidden := 0
+	if len(lines) > maxWatchLockDiffLines {
+		hidden = len(lines) - maxWatchLockDiffLines
+		lines = lines[:maxWatchLockDiffLines]
+	}
+	compileWatchSessionLog.Printf("Lock file diff for %s: %d lines (%d hidden)", name, len(lines), hidden)
+	fmt.Fprintln(s.out, renderColorizedPatch(strings.Join(lines, "\n"), s.colorize))
+	if hidden > 0 {
+		fmt.Fprintln(s.out, console.FormatInfoMessage(fmt.Sprintf("%d more diff line(s) not shown; run 'git diff %s' for the full change", hidden, name)))
+	}
+}
+
+// endPass prints the persistent error overlay: unresolved errors of workflows that were not
+// recompiled in this pass (errors from this pass were already printed). It rings the
+// terminal bell when the pass introduced a new failure and confirms when the last error is fixed.
+func (s *watchSession) endPass() {
+	var earlier []string
+	newFailure := false
+	for file := range s.failures {
+		if _, compiled := s.compiled[file]; !compiled {
+			earlier = append(earlier, file)
+		}
+		if _, failing := s.previous[file]; !failing {
+			newFailure = true
+		}
+	}
+	sort.Strings(earlier)
+
+	if len(earlier) > 0 {
+		fmt.Fprintln(s.out)
+		fmt.Fprintln(s.out, console.FormatErrorMessage(fmt.Sprintf("%d workflow(s) still failing from earlier changes:", len(earlier))))
+		for _, file := range earlier {
+			fmt.Fprintln(s.out, console.FormatErrorMessage(filepath.Base(file)))
+			fmt.Fprintln(s.out, s.failures[file])
+		}
+	}
+
+	switch {
+	case newFailure && s.colorize:
+		fmt.Fprint(s.out, terminalBell)
+	case len(s.failures) == 0 && len(s.previous) > 0:
+		fmt.Fprintln(s.out, console.FormatSuccessMessage("All compilation errors are fixed"))
+	}
+}
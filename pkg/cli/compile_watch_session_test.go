@@ -0,0 +1,73 @@
+//go:build !integration
+
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestWatchSession(out *bytes.Buffer) *watchSession {
+	session := newWatchSession()
+	session.out = out
+	session.colorize = false
+	return session
+}
+
+func TestWatchSessionPrintLockDiff(t *testing.T) {
+	var out bytes.Buffer
+	session := newTestWatchSession(&out)
+
+	session.printLockDiff("/repo/.github/workflows/a.lock.yml", "name: a\non: push\n", "name: a\non: pull_request\n")
+	assert.Empty(t, out.String(), "initial compilation should not print diffs")
+
+	session.beginPass()
+	session.printLockDiff("/repo/.github/workflows/a.lock.yml", "name: a\non: push\n", "name: a\non: pull_request\n")
+	assert.Contains(t, out.String(), "-on: push", "removed line should be shown")
+	assert.Contains(t, out.String(), "+on: pull_request", "added line should be shown")
+
+	out.Reset()
+	session.printLockDiff("/repo/.github/workflows/a.lock.yml", "same\n", "same\n")
+	assert.Empty(t, out.String(), "unchanged lock file should print nothing")
+
+	out.Reset()
+	before := strings.Repeat("old\n", maxWatchLockDiffLines)
+	after := strings.Repeat("new\n", maxWatchLockDiffLines)
+	session.printLockDiff("/repo/.github/workflows/a.lock.yml", before, after)
+	assert.Contains(t, out.String(), "more diff line(s) not shown", "long diffs should be truncated")
+}
+
+func TestWatchSessionErrorOverlay(t *testing.T) {
+	var out bytes.Buffer
+	session := newTestWatchSession(&out)
+
+	// a.md fails in one pass; b.md is then edited while a.md is still broken.
+	session.beginPass()
+	session.compiled["/wf/a.md"] = struct{}{}
+	session.failures["/wf/a.md"] = "a.md:3:1: error: unknown property"
+	session.endPass()
+	assert.NotContains(t, out.String(), "still failing", "errors from this pass are already printed")
+
+	out.Reset()
+	session.beginPass()
+	session.compiled["/wf/b.md"] = struct{}{}
+	session.endPass()
+	assert.Contains(t, out.String(), "1 workflow(s) still failing from earlier changes", "earlier errors should stay visible")
+	assert.Contains(t, out.String(), "a.md:3:1: error: unknown property", "earlier error message should be repeated")
+
+	out.Reset()
+	session.beginPass()
+	session.compiled["/wf/a.md"] = struct{}{}
+	delete(session.failures, "/wf/a.md")
+	session.endPass()
+	assert.Contains(t, out.String(), "All compilation errors are fixed", "fixing the last error should be confirmed")
+
+	out.Reset()
+	session.removeWorkflow("/wf/a.md")
+	session.beginPass()
+	session.endPass()
+	assert.Empty(t, out.String(), "nothing should be printed without errors")
+}
@@ -299,6 +299,36 @@ func TestExtractLogMetrics_EventsJSONLPriority(t *testing.T) {
 		assert.Equal(t, 120, metrics.TokenUsage, "token usage should come from modelMetrics in events.jsonl")
 	})
 
+	t.Run("prefers agent_turns.jsonl over events.jsonl", func(t *testing.T) {
+		dir := t.TempDir()
+
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "aw_info.json"),
+			[]byte(`{"engine_id":"copilot"}`), 0644))
+
+		sessionDir := filepath.Join(dir, "sandbox", "agent", "logs",
+			"copilot-session-state", "session-uuid-123")
+		require.NoError(t, os.MkdirAll(sessionDir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(sessionDir, "events.jsonl"),
+			[]byte(realFormatEventsLine("user.message", `{"content":"Do something"}`)+"\n"), 0644))
+
+		// The structured turn log is uploaded from /tmp/gh-aw/, next to agent-stdio.log
+		turnsContent := `{"type":"header","format":"gh-aw.agent-turns","version":1,"engine":"Copilot"}
+{"type":"turn","turn":1,"input_tokens":300,"output_tokens":40}
+{"type":"tool_call","turn":1,"id":"tc1","name":"bash","input_size":12}
+{"type":"turn","turn":2,"input_tokens":400,"output_tokens":60}
+{"type":"result","turns":2,"input_tokens":700,"output_tokens":100}
+`
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "agent_turns.jsonl"), []byte(turnsContent), 0644))
+
+		metrics, err := extractLogMetrics(dir, false)
+		require.NoError(t, err, "extractLogMetrics should not error")
+
+		assert.Equal(t, 2, metrics.Turns, "turns should come from agent_turns.jsonl")
+		assert.Equal(t, 800, metrics.TokenUsage, "token usage should come from agent_turns.jsonl")
+		require.Len(t, metrics.ToolCalls, 1, "tool calls should come from agent_turns.jsonl")
+		assert.Equal(t, "bash", metrics.ToolCalls[0].Name, "tool name")
+	})
+
 	t.Run("falls back to log file walk when events.jsonl absent", func(t *testing.T) {
 		dir := t.TempDir()
 
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/github/gh-aw/pkg/logger"
@@ -128,6 +129,23 @@ func (g *DependencyGraph) addWorkflow(workflowPath string, compiler *workflow.Co
 		depGraphLog.Printf("Tracking reverse import: %s <- %s", importPath, workflowPath)
 	}
 
+	// Follow imports that live outside the workflows directory so their own imports
+	// are tracked too; files inside the directory are added by BuildGraph's walk.
+	for _, importPath := range imports {
+		if _, exists := g.nodes[importPath]; exists || !strings.HasSuffix(importPath, ".md") {
+			continue
+		}
+		if rel, err := filepath.Rel(g.workflowsDir, importPath); err == nil && !strings.HasPrefix(rel, "..") {
+			continue
+		}
+		if _, err := os.Stat(importPath); err != nil {
+			continue
+		}
+		if err := g.addWorkflow(importPath, compiler); err != nil {
+			depGraphLog.Printf("Failed to add external import %s to graph: %v", importPath, err)
+		}
+	}
+
 	depGraphLog.Printf("Added workflow to graph: %s (top-level: %v, imports: %d)", workflowPath, node.IsTopLevel, len(imports))
 	return nil
 }
@@ -255,6 +273,12 @@ func (g *DependencyGraph) GetAffectedWorkflows(modifiedPath string) []string {
 	depGraphLog.Printf("Finding affected workflows for modified file: %s", modifiedPath)
 
 	node, exists := g.nodes[modifiedPath]
+	if !exists && len(g.reverseImports[modifiedPath]) > 0 {
+		// Imported file that is not itself a workflow (e.g. a non-markdown fragment)
+		affected := g.findAffectedTopLevelWorkflows(modifiedPath)
+		depGraphLog.Printf("Found %d affected top-level workflows for imported file %s", len(affected), modifiedPath)
+		return affected
+	}
 	if !exists {
 		// File not in graph - it might be a new file
 		// If it's a top-level workflow, just compile it
@@ -332,6 +356,56 @@ func (g *DependencyGraph) getAllTopLevelWorkflows() []string {
 	return topLevel
 }
 
+// ImportClosure returns every file the workflow imports, directly or through other imports.
+func (g *DependencyGraph) ImportClosure(workflowPath string) []string {
+	visited := map[string]struct{}{workflowPath: {}}
+	var closure []string
+	queue := []string{workflowPath}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		node := g.nodes[current]
+		if node == nil {
+			continue
+		}
+		for _, importPath := range node.Imports {
+			if setutil.Contains(visited, importPath) {
+				continue
+			}
+			visited[importPath] = struct{}{}
+			closure = append(closure, importPath)
+			queue = append(queue, importPath)
+		}
+	}
+	return closure
+}
+
+// IsTracked reports whether the file is a workflow in the graph or imported by one.
+func (g *DependencyGraph) IsTracked(path string) bool {
+	if _, exists := g.nodes[path]; exists {
+		return true
+	}
+	return len(g.reverseImports[path]) > 0
+}
+
+// WatchDirs returns the sorted directories containing workflows or imported files,
+// including imports that live outside the workflows directory.
+func (g *DependencyGraph) WatchDirs() []string {
+	dirs := make(map[string]struct{})
+	for path := range g.nodes {
+		dirs[filepath.Dir(path)] = struct{}{}
+	}
+	for path := range g.reverseImports {
+		dirs[filepath.Dir(path)] = struct{}{}
+	}
+	result := make([]string, 0, len(dirs))
+	for dir := range dirs {
+		result = append(result, dir)
+	}
+	sort.Strings(result)
+	return result
+}
+
 // UpdateWorkflow updates a workflow in the graph (e.g., after it's been modified)
 func (g *DependencyGraph) UpdateWorkflow(workflowPath string, compiler *workflow.Compiler) error {
 	depGraphLog.Printf("Updating workflow in graph: %s", workflowPath)
@@ -946,3 +946,61 @@ tools:
 		})
 	}
 }
+
+func TestDependencyGraph_ImportsOutsideWorkflowsDir(t *testing.T) {
+	tmpDir := t.TempDir()
+	workflowsDir := filepath.Join(tmpDir, ".github", "workflows")
+	fragmentsDir := filepath.Join(tmpDir, ".github", "fragments")
+	for _, dir := range []string{workflowsDir, fragmentsDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	leaf := filepath.Join(fragmentsDir, "leaf.md")
+	if err := os.WriteFile(leaf, []byte("---\ndescription: Leaf\n---\n# Leaf"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	fragment := filepath.Join(fragmentsDir, "fragment.md")
+	if err := os.WriteFile(fragment, []byte("---\ndescription: Fragment\nimports:\n  - leaf.md\n---\n# Fragment"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	mainWorkflow := filepath.Join(workflowsDir, "main.md")
+	if err := os.WriteFile(mainWorkflow, []byte("---\non: push\nimports:\n  - ../fragments/fragment.md\n---\n# Main"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	otherWorkflow := filepath.Join(workflowsDir, "other.md")
+	if err := os.WriteFile(otherWorkflow, []byte("---\non: push\n---\n# Other"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	graph := NewDependencyGraph(workflowsDir)
+	if err := graph.BuildGraph(workflow.NewCompiler()); err != nil {
+		t.Fatalf("BuildGraph() error = %v", err)
+	}
+
+	t.Run("nested import outside the workflows directory affects only its importer", func(t *testing.T) {
+		affected := graph.GetAffectedWorkflows(leaf)
+		if len(affected) != 1 || affected[0] != mainWorkflow {
+			t.Errorf("GetAffectedWorkflows() = %v, want [%s]", affected, mainWorkflow)
+		}
+	})
+
+	t.Run("import closure includes transitive imports", func(t *testing.T) {
+		closure := graph.ImportClosure(mainWorkflow)
+		if len(closure) != 2 || closure[0] != fragment || closure[1] != leaf {
+			t.Errorf("ImportClosure() = %v, want [%s %s]", closure, fragment, leaf)
+		}
+		if !graph.IsTracked(leaf) {
+			t.Errorf("IsTracked(%s) = false, want true", leaf)
+		}
+	})
+
+	t.Run("watch dirs include directories of external imports", func(t *testing.T) {
+		dirs := graph.WatchDirs()
+		want := []string{fragmentsDir, workflowsDir}
+		if fmt.Sprint(dirs) != fmt.Sprint(want) {
+			t.Errorf("WatchDirs() = %v, want %v", dirs, want)
+		}
+	})
+}
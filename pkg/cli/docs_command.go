@@ -0,0 +1,363 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+	"github.com/github/gh-aw/pkg/sliceutil"
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/spf13/cobra"
+)
+
+var docsCommandLog = logger.New("cli:docs_command")
+
+// WorkflowInventoryEntry describes a single agentic workflow in the generated inventory
+type WorkflowInventoryEntry struct {
+	Workflow       string   `json:"workflow"`
+	Path           string   `json:"path"`
+	Title          string   `json:"title,omitempty"`
+	Description    string   `json:"description,omitempty"`
+	Triggers       []string `json:"triggers"`
+	Engine         string   `json:"engine"`
+	Tools          []string `json:"tools"`
+	SafeOutputs    []string `json:"safe_outputs"`
+	Network        []string `json:"network"`
+	BlockedDomains []string `json:"blocked_domains,omitempty"`
+	Permissions    []string `json:"permissions"`
+}
+
+// triggerModifierKeys lists keys under `on:` that configure trigger behavior
+// rather than naming a GitHub event. Hyphenated keys are always modifiers.
+var triggerModifierKeys = map[string]bool{
+	"bots":        true,
+	"labels":      true,
+	"needs":       true,
+	"permissions": true,
+	"public":      true,
+	"reaction":    true,
+	"roles":       true,
+	"steps":       true,
+}
+
+// NewDocsCommand creates the docs command
+func NewDocsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "docs",
+		Short: "Generate an inventory of all agentic workflows",
+		Long: `Generate an inventory of all agentic workflows in the repository.
+
+Walks the workflows directory, parses the frontmatter of each workflow and
+produces a summary of its triggers, engine, tools, safe outputs, network
+policy, and permissions.
+
+By default the inventory is rendered as a Markdown document suitable for a
+README. Use --json for machine-readable output. Use --output to write the
+inventory to a file instead of stdout; links to workflow sources are then
+made relative to the output file.`,
+		Example: `  ` + string(constants.CLIExtensionPrefix) + ` docs                                   # Print Markdown inventory
+  ` + string(constants.CLIExtensionPrefix) + ` docs --json                            # Print JSON inventory
+  ` + string(constants.CLIExtensionPrefix) + ` docs -o .github/workflows/README.md    # Write Markdown inventory to a file`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonFlag, _ := cmd.Flags().GetBool("json")
+			outputPath, _ := cmd.Flags().GetString("output")
+			return RunDocs(outputPath, jsonFlag)
+		},
+	}
+
+	addJSONFlag(cmd)
+	cmd.Flags().StringP("output", "o", "", "Write the inventory to a file instead of stdout")
+
+	return cmd
+}
+
+// RunDocs builds the workflow inventory and writes it as Markdown or JSON
+func RunDocs(outputPath string, jsonOutput bool) error {
+	docsCommandLog.Printf("Generating workflow inventory: output=%q, jsonOutput=%v", outputPath, jsonOutput)
+
+	workflowsDir := getWorkflowsDir()
+	mdFiles, err := getMarkdownWorkflowFiles(workflowsDir)
+	if err != nil {
+		return err
+	}
+
+	entries := make([]WorkflowInventoryEntry, 0, len(mdFiles))
+	for _, file := range mdFiles {
+		entry, err := buildWorkflowInventoryEntry(file)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Skipping %s: %v", file, err)))
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	var rendered string
+	if jsonOutput {
+		jsonBytes, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		rendered = string(jsonBytes) + "\n"
+	} else {
+		linkBase := "."
+		if outputPath != "" {
+			linkBase = filepath.Dir(outputPath)
+		}
+		rendered = renderWorkflowInventoryMarkdown(entries, linkBase)
+	}
+
+	if outputPath == "" {
+		fmt.Fprint(os.Stdout, rendered)
+		return nil
+	}
+
+	if err := os.WriteFile(outputPath, []byte(rendered), constants.FilePermPublic); err != nil {
+		return fmt.Errorf("failed to write inventory to %s: %w", outputPath, err)
+	}
+	fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("Wrote inventory of %d workflow(s) to %s", len(entries), outputPath)))
+	return nil
+}
+
+// buildWorkflowInventoryEntry parses a workflow file's frontmatter into an inventory entry
+func buildWorkflowInventoryEntry(filePath string) (WorkflowInventoryEntry, error) {
+	entry := WorkflowInventoryEntry{
+		Workflow: extractWorkflowNameFromPath(filePath),
+		Path:     filePath,
+	}
+
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return entry, fmt.Errorf("failed to read workflow file: %w", err)
+	}
+
+	result, err := parser.ExtractFrontmatterFromContent(string(content))
+	if err != nil {
+		return entry, fmt.Errorf("failed to parse frontmatter: %w", err)
+	}
+	frontmatter := result.Frontmatter
+	if frontmatter == nil {
+		frontmatter = map[string]any{}
+	}
+
+	if title, err := extractWorkflowNameFromFile(filePath); err == nil {
+		entry.Title = title
+	}
+	if description, ok := frontmatter["description"].(string); ok {
+		entry.Description = strings.TrimSpace(description)
+	}
+
+	entry.Triggers = extractInventoryTriggers(frontmatter["on"])
+	entry.Engine = extractEngineIDFromFrontmatter(frontmatter)
+	entry.Tools = extractInventoryTools(frontmatter)
+	entry.SafeOutputs = extractInventorySafeOutputs(frontmatter["safe-outputs"])
+	entry.Network, entry.BlockedDomains = extractInventoryNetwork(frontmatter["network"])
+	entry.Permissions = extractInventoryPermissions(frontmatter["permissions"])
+
+	return entry, nil
+}
+
+// extractInventoryTriggers returns the event names configured under `on:`,
+// ignoring trigger modifiers such as stop-after or reaction.
+func extractInventoryTriggers(onValue any) []string {
+	triggers := []string{}
+	switch on := onValue.(type) {
+	case string:
+		if trimmed := strings.TrimSpace(on); trimmed != "" {
+			triggers = append(triggers, trimmed)
+		}
+	case []any:
+		for _, item := range on {
+			if name, ok := item.(string); ok && name != "" {
+				triggers = append(triggers, name)
+			}
+		}
+		sort.Strings(triggers)
+	case map[string]any:
+		for _, name := range sliceutil.SortedKeys(on) {
+			if strings.Contains(name, "-") || triggerModifierKeys[name] {
+				continue
+			}
+			triggers = append(triggers, name)
+		}
+	}
+	return triggers
+}
+
+// extractInventoryTools returns the sorted names of configured tools and MCP servers
+func extractInventoryTools(frontmatter map[string]any) []string {
+	tools := []string{}
+	for _, key := range []string{"tools", "mcp-servers"} {
+		if section, ok := frontmatter[key].(map[string]any); ok {
+			for name := range section {
+				if !slices.Contains(tools, name) {
+					tools = append(tools, name)
+				}
+			}
+		}
+	}
+	sort.Strings(tools)
+	return tools
+}
+
+// extractInventorySafeOutputs returns the sorted user-facing safe output types,
+// including custom safe output jobs. Meta-configuration keys such as staged or
+// github-token and internal outputs such as noop are excluded.
+func extractInventorySafeOutputs(safeOutputsValue any) []string {
+	outputs := []string{}
+	section, ok := safeOutputsValue.(map[string]any)
+	if !ok {
+		return outputs
+	}
+
+	known := make(map[string]bool)
+	for _, option := range workflow.GetSafeOutputToolOptions() {
+		known[option.Key] = true
+	}
+
+	for name := range section {
+		if known[name] {
+			outputs = append(outputs, name)
+		}
+	}
+	if jobs, ok := section["jobs"].(map[string]any); ok {
+		for name := range jobs {
+			outputs = append(outputs, name)
+		}
+	}
+	sort.Strings(outputs)
+	return outputs
+}
+
+// extractInventoryNetwork returns the configured allowed network entries and
+// blocked domains. Workflows without a network section use the defaults.
+func extractInventoryNetwork(networkValue any) (allowed []string, blocked []string) {
+	switch network := networkValue.(type) {
+	case nil:
+		return []string{"defaults"}, nil
+	case string:
+		return []string{network}, nil
+	case map[string]any:
+		allowed = inventoryStringList(network["allowed"])
+		blocked = inventoryStringList(network["blocked"])
+		if allowed == nil {
+			allowed = []string{}
+		}
+		return allowed, blocked
+	}
+	return []string{}, nil
+}
+
+// extractInventoryPermissions returns permissions as "scope: level" entries,
+// or the shorthand value (e.g. read-all) when permissions is a string.
+func extractInventoryPermissions(permissionsValue any) []string {
+	permissions := []string{}
+	switch perms := permissionsValue.(type) {
+	case string:
+		if perms != "" {
+			permissions = append(permissions, perms)
+		}
+	case map[string]any:
+		for _, scope := range sliceutil.SortedKeys(perms) {
+			permissions = append(permissions, fmt.Sprintf("%s: %v", scope, perms[scope]))
+		}
+	}
+	return permissions
+}
+
+// inventoryStringList converts a YAML list value into a string slice
+func inventoryStringList(value any) []string {
+	items, ok := value.([]any)
+	if !ok {
+		return nil
+	}
+	var result []string
+	for _, item := range items {
+		if s, ok := item.(string); ok && s != "" {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// renderWorkflowInventoryMarkdown renders the inventory as a Markdown document.
+// Workflow links are made relative to linkBase.
+func renderWorkflowInventoryMarkdown(entries []WorkflowInventoryEntry, linkBase string) string {
+	var sb strings.Builder
+	sb.WriteString("# Agentic Workflows\n\n")
+	fmt.Fprintf(&sb, "This repository contains %d agentic workflow(s). Generated by `%s docs`.\n\n", len(entries), constants.CLIExtensionPrefix)
+
+	if len(entries) == 0 {
+		return sb.String()
+	}
+
+	sb.WriteString("| Workflow | Triggers | Engine | Tools | Safe Outputs | Network | Permissions |\n")
+	sb.WriteString("| --- | --- | --- | --- | --- | --- | --- |\n")
+	for _, entry := range entries {
+		link := filepath.ToSlash(entry.Path)
+		if rel, err := filepath.Rel(linkBase, entry.Path); err == nil {
+			link = filepath.ToSlash(rel)
+		}
+		title := entry.Title
+		if title == "" {
+			title = entry.Workflow
+		}
+
+		network := inventoryMarkdownList(entry.Network)
+		if len(entry.BlockedDomains) > 0 {
+			network += "<br>blocked: " + inventoryMarkdownList(entry.BlockedDomains)
+		}
+
+		fmt.Fprintf(&sb, "| [%s](%s) | %s | %s | %s | %s | %s | %s |\n",
+			escapeMarkdownTableCell(title),
+			link,
+			inventoryMarkdownList(entry.Triggers),
+			escapeMarkdownTableCell(entry.Engine),
+			inventoryMarkdownList(entry.Tools),
+			inventoryMarkdownList(entry.SafeOutputs),
+			network,
+			inventoryMarkdownList(entry.Permissions),
+		)
+	}
+
+	var described []WorkflowInventoryEntry
+	for _, entry := range entries {
+		if entry.Description != "" {
+			described = append(described, entry)
+		}
+	}
+	if len(described) > 0 {
+		sb.WriteString("\n## Descriptions\n\n")
+		for _, entry := range described {
+			fmt.Fprintf(&sb, "- **%s**: %s\n", entry.Workflow, strings.Join(strings.Fields(entry.Description), " "))
+		}
+	}
+
+	return sb.String()
+}
+
+// inventoryMarkdownList formats a list of values as inline code for a table cell
+func inventoryMarkdownList(values []string) string {
+	if len(values) == 0 {
+		return "-"
+	}
+	formatted := make([]string, 0, len(values))
+	for _, value := range values {
+		formatted = append(formatted, "`"+escapeMarkdownTableCell(value)+"`")
+	}
+	return strings.Join(formatted, ", ")
+}
+
+// escapeMarkdownTableCell escapes characters that would break a Markdown table row
+func escapeMarkdownTableCell(value string) string {
+	value = strings.ReplaceAll(value, "|", "\\|")
+	return strings.ReplaceAll(value, "\n", " ")
+}
@@ -0,0 +1,143 @@
+//go:build !integration
+
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractInventoryTriggers(t *testing.T) {
+	tests := []struct {
+		name     string
+		on       any
+		expected []string
+	}{
+		{name: "nil", on: nil, expected: []string{}},
+		{name: "string shorthand", on: "daily", expected: []string{"daily"}},
+		{name: "list", on: []any{"push", "issues"}, expected: []string{"issues", "push"}},
+		{
+			name: "map skips modifiers",
+			on: map[string]any{
+				"workflow_dispatch": nil,
+				"schedule":          "daily",
+				"stop-after":        "+48h",
+				"reaction":          "eyes",
+				"roles":             []any{"admin"},
+			},
+			expected: []string{"schedule", "workflow_dispatch"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, extractInventoryTriggers(tt.on), "triggers should match")
+		})
+	}
+}
+
+func TestExtractInventorySafeOutputs(t *testing.T) {
+	safeOutputs := map[string]any{
+		"create-issue": map[string]any{"max": 1},
+		"add-comment":  nil,
+		"noop":         nil,
+		"staged":       true,
+		"github-token": "${{ secrets.TOKEN }}",
+		"jobs": map[string]any{
+			"notify-slack": map[string]any{},
+		},
+	}
+
+	assert.Equal(t, []string{"add-comment", "create-issue", "notify-slack"}, extractInventorySafeOutputs(safeOutputs),
+		"should list safe output types and custom jobs, excluding meta and internal keys")
+	assert.Empty(t, extractInventorySafeOutputs(nil), "missing safe-outputs should produce no entries")
+}
+
+func TestExtractInventoryNetworkAndPermissions(t *testing.T) {
+	allowed, blocked := extractInventoryNetwork(nil)
+	assert.Equal(t, []string{"defaults"}, allowed, "missing network should use defaults")
+	assert.Nil(t, blocked, "missing network should have no blocked domains")
+
+	allowed, blocked = extractInventoryNetwork(map[string]any{
+		"allowed": []any{"defaults", "python"},
+		"blocked": []any{"tracker.example.com"},
+	})
+	assert.Equal(t, []string{"defaults", "python"}, allowed, "allowed entries should be preserved")
+	assert.Equal(t, []string{"tracker.example.com"}, blocked, "blocked entries should be preserved")
+
+	assert.Equal(t, []string{"read-all"}, extractInventoryPermissions("read-all"), "string permissions should be kept as-is")
+	assert.Equal(t, []string{"contents: read", "issues: write"},
+		extractInventoryPermissions(map[string]any{"issues": "write", "contents": "read"}),
+		"map permissions should be sorted scope: level entries")
+}
+
+func TestRunDocs(t *testing.T) {
+	tmpDir := t.TempDir()
+	originalDir, err := os.Getwd()
+	require.NoError(t, err, "Failed to get current directory")
+	err = os.Chdir(tmpDir)
+	require.NoError(t, err, "Failed to change to temp directory")
+	defer os.Chdir(originalDir) //nolint:errcheck
+
+	err = os.MkdirAll(".github/workflows", 0755)
+	require.NoError(t, err, "Failed to create workflows directory")
+
+	workflowContent := `---
+description: Triage new issues | label them
+on:
+  issues:
+    types: [opened]
+  reaction: eyes
+engine: claude
+permissions:
+  contents: read
+tools:
+  github:
+    toolsets: [issues]
+safe-outputs:
+  add-labels:
+network:
+  allowed:
+    - defaults
+    - python
+---
+# Issue Triage
+Triage the issue.
+`
+	err = os.WriteFile(filepath.Join(".github", "workflows", "issue-triage.md"), []byte(workflowContent), 0600)
+	require.NoError(t, err, "Failed to write workflow file")
+
+	t.Run("markdown output file", func(t *testing.T) {
+		outputPath := filepath.Join(".github", "workflows", "README.md")
+		require.NoError(t, RunDocs(outputPath, false), "RunDocs should not error")
+
+		content, err := os.ReadFile(outputPath)
+		require.NoError(t, err, "Inventory file should be written")
+		text := string(content)
+
+		assert.Contains(t, text, "| Workflow | Triggers | Engine | Tools | Safe Outputs | Network | Permissions |", "should render the table header")
+		assert.Contains(t, text, "| [Issue Triage](issue-triage.md) | `issues` | claude | `github` | `add-labels` | `defaults`, `python` | `contents: read` |",
+			"should render a row with links relative to the output file")
+		assert.Contains(t, text, "- **issue-triage**: Triage new issues | label them", "should list workflow descriptions")
+	})
+
+	t.Run("json output file", func(t *testing.T) {
+		outputPath := filepath.Join(tmpDir, "inventory.json")
+		require.NoError(t, RunDocs(outputPath, true), "RunDocs JSON should not error")
+
+		content, err := os.ReadFile(outputPath)
+		require.NoError(t, err, "Inventory file should be written")
+
+		var entries []WorkflowInventoryEntry
+		require.NoError(t, json.Unmarshal(content, &entries), "JSON inventory should be valid")
+		require.Len(t, entries, 1, "should include one workflow")
+		assert.Equal(t, "issue-triage", entries[0].Workflow, "workflow ID should match")
+		assert.Equal(t, "claude", entries[0].Engine, "engine should match")
+		assert.Equal(t, []string{"issues"}, entries[0].Triggers, "triggers should exclude modifiers")
+	})
+}
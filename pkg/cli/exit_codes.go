@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/gitutil"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var exitCodesLog = logger.New("cli:exit_codes")
+
+// Process exit codes. They are part of the CLI contract for --porcelain output
+// and are documented in the CLI reference; do not renumber them.
+const (
+	ExitCodeOK         = 0 // Command succeeded
+	ExitCodeValidation = 1 // Validation errors, or any failure not covered below
+	ExitCodeAuth       = 2 // GitHub authentication or permission failure
+	ExitCodeNetwork    = 3 // Network failure, timeout, or GitHub API rate limit
+)
+
+// authErrorMarkers are substrings of gh CLI and GitHub API errors that mean the
+// credentials are missing or insufficient. They are narrower than
+// isPermissionErrorStr so that compile errors about the permissions: frontmatter
+// field are not reported as auth failures.
+var authErrorMarkers = []string{
+	"authentication required",
+	"not logged into any github hosts",
+	"gh auth login",
+	"to use github cli in a github actions workflow",
+	"bad credentials",
+	"http 401",
+	"resource not accessible by",
+	"missing required scope",
+	"api operation needs the",
+	"saml enforcement",
+}
+
+// networkErrorMarkers are substrings of errors raised when GitHub could not be reached.
+var networkErrorMarkers = []string{
+	"dial tcp",
+	"no such host",
+	"could not resolve host",
+	"connection refused",
+	"connection reset",
+	"network is unreachable",
+	"i/o timeout",
+	"tls handshake timeout",
+	"error connecting to",
+}
+
+// ExitCodeForError maps a command error to the documented process exit code.
+func ExitCodeForError(err error) int {
+	if err == nil {
+		return ExitCodeOK
+	}
+	var exitCodeErr *ExitCodeError
+	if errors.As(err, &exitCodeErr) {
+		return exitCodeErr.Code
+	}
+	code := ExitCodeValidation
+	if isNetworkError(err) {
+		code = ExitCodeNetwork
+	} else if isAuthError(err) {
+		code = ExitCodeAuth
+	}
+	exitCodesLog.Printf("Exit code %d for error: %v", code, err)
+	return code
+}
+
+// isAuthError reports whether err is a GitHub authentication or permission failure.
+func isAuthError(err error) bool {
+	msg := strings.ToLower(err.Error())
+	for _, marker := range authErrorMarkers {
+		if strings.Contains(msg, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// isNetworkError reports whether err means GitHub could not be reached or
+// refused the request because of rate limiting.
+func isNetworkError(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	if gitutil.IsRateLimitError(msg) {
+		return true
+	}
+	for _, marker := range networkErrorMarkers {
+		if strings.Contains(msg, marker) {
+			return true
+		}
+	}
+	return false
+}
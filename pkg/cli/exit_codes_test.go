@@ -0,0 +1,38 @@
+//go:build !integration
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExitCodeForError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "nil", err: nil, want: ExitCodeOK},
+		{name: "explicit exit code", err: fmt.Errorf("relaunch: %w", &ExitCodeError{Code: 7}), want: 7},
+		{name: "validation error", err: errors.New("workflow.md:3:1: error: unknown property 'permisions'"), want: ExitCodeValidation},
+		{name: "permissions frontmatter error", err: errors.New("invalid permissions: contents must be read or write"), want: ExitCodeValidation},
+		{name: "not logged in", err: errors.New("You are not logged into any GitHub hosts. To log in, run: gh auth login"), want: ExitCodeAuth},
+		{name: "bad credentials", err: errors.New("gh: Bad credentials (HTTP 401)"), want: ExitCodeAuth},
+		{name: "missing scope", err: errors.New(`gh: This API operation needs the "repo" scope. (HTTP 403)`), want: ExitCodeAuth},
+		{name: "dns failure", err: errors.New("error connecting to api.github.com: dial tcp: lookup api.github.com: no such host"), want: ExitCodeNetwork},
+		{name: "net error", err: fmt.Errorf("fetch failed: %w", &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("refused")}), want: ExitCodeNetwork},
+		{name: "deadline", err: fmt.Errorf("request: %w", context.DeadlineExceeded), want: ExitCodeNetwork},
+		{name: "rate limit", err: errors.New("HTTP 403: API rate limit exceeded for user ID 1"), want: ExitCodeNetwork},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ExitCodeForError(tt.err), "exit code")
+		})
+	}
+}
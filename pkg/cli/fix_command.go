@@ -118,34 +118,9 @@ func listAvailableCodemods() error {
 func runFixCommand(workflowIDs []string, write bool, verbose bool, workflowDir string, disabledCodemodIDs []string) error {
 	fixLog.Printf("Running fix command: workflowIDs=%v, write=%v, verbose=%v, workflowDir=%s, disabledCodemodIDs=%v", workflowIDs, write, verbose, workflowDir, disabledCodemodIDs)
 
-	// Set up workflow directory (using default if not specified)
-	if workflowDir == "" {
-		workflowDir = constants.GetWorkflowDir()
-		fixLog.Printf("Using default workflow directory: %s", workflowDir)
-	} else {
-		workflowDir = filepath.Clean(workflowDir)
-		fixLog.Printf("Using custom workflow directory: %s", workflowDir)
-	}
-
-	// Get workflow files to process
-	var files []string
-	var err error
-
-	if len(workflowIDs) > 0 {
-		// Process specific workflows
-		for _, workflowID := range workflowIDs {
-			file, err := resolveWorkflowFileInDir(workflowID, verbose, workflowDir)
-			if err != nil {
-				return err
-			}
-			files = append(files, file)
-		}
-	} else {
-		// Process all workflows in the workflow directory
-		files, err = getMarkdownWorkflowFiles(workflowDir)
-		if err != nil {
-			return err
-		}
+	files, err := resolveFixWorkflowFiles(workflowIDs, verbose, workflowDir)
+	if err != nil {
+		return err
 	}
 
 	if len(files) == 0 {
@@ -290,6 +265,35 @@ func runFixCommand(workflowIDs []string, write bool, verbose bool, workflowDir s
 	return nil
 }
 
+// resolveFixWorkflowFiles returns the workflow files to process: the given workflow IDs
+// resolved in workflowDir, or every Markdown workflow in workflowDir when none are given.
+func resolveFixWorkflowFiles(workflowIDs []string, verbose bool, workflowDir string) ([]string, error) {
+	// Set up workflow directory (using default if not specified)
+	if workflowDir == "" {
+		workflowDir = constants.GetWorkflowDir()
+		fixLog.Printf("Using default workflow directory: %s", workflowDir)
+	} else {
+		workflowDir = filepath.Clean(workflowDir)
+		fixLog.Printf("Using custom workflow directory: %s", workflowDir)
+	}
+
+	if len(workflowIDs) == 0 {
+		// Process all workflows in the workflow directory
+		return getMarkdownWorkflowFiles(workflowDir)
+	}
+
+	// Process specific workflows
+	files := make([]string, 0, len(workflowIDs))
+	for _, workflowID := range workflowIDs {
+		file, err := resolveWorkflowFileInDir(workflowID, verbose, workflowDir)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
 // workflowFixInfo tracks workflow files that need fixes
 type workflowFixInfo struct {
 	File  string
@@ -38,3 +38,9 @@ func addOutputFlag(cmd *cobra.Command, defaultValue string) {
 func addJSONFlag(cmd *cobra.Command) {
 	cmd.Flags().BoolP("json", "j", false, "Output results in JSON format")
 }
+
+// addPorcelainFlag adds the --porcelain flag to a command.
+// This flag enables stable, tab-separated output for scripts.
+func addPorcelainFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool("porcelain", false, "Output stable tab-separated records for scripts (see exit codes in the CLI reference)")
+}
@@ -64,6 +64,11 @@ func processImportsWithWorkflowSpec(content string, workflow *WorkflowSpec, comm
 	processImportPaths := func(imports []string) []string {
 		processed := make([]string, 0, len(imports))
 		for _, importPath := range imports {
+			if parser.IsGitHubContextImport(importPath) {
+				importsLog.Printf("Import names an issue or discussion, leaving unchanged: %s", importPath)
+				processed = append(processed, importPath)
+				continue
+			}
 			if isWorkflowSpecFormat(importPath) {
 				importsLog.Printf("Import already in workflowspec format: %s", importPath)
 				processed = append(processed, importPath)
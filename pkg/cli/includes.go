@@ -251,7 +251,8 @@ func fetchFrontmatterImportsRecursive(ctx context.Context, content, currentBaseD
 
 	for _, importPath := range importPaths {
 		// Skip workflowspec-format imports (already pinned to a remote ref)
-		if isWorkflowSpecFormat(importPath) {
+		// and issue/discussion imports, which are fetched at runtime
+		if isWorkflowSpecFormat(importPath) || parser.IsGitHubContextImport(importPath) {
 			continue
 		}
 
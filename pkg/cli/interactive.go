@@ -51,6 +51,18 @@ type InteractiveWorkflowBuilder struct {
 	Intent        string
 	NetworkAccess string
 	CustomDomains []string
+
+	// Trigger details collected by the trigger sub-wizard
+	EventTypes    []string // Activity types for issues/pull_request triggers
+	Branches      []string // Branch filters for push triggers
+	CommandName   string   // Command name (without leading slash) for command triggers
+	ScheduleCron  string   // Cron expression for custom schedule triggers
+	branchesInput string
+	cronInput     cronBuilderInput
+
+	// Frontmatter holds the frontmatter YAML (without delimiters) approved in the review
+	// step. When empty, the frontmatter is generated from the selections above.
+	Frontmatter string
 }
 
 func (b *InteractiveWorkflowBuilder) ensureNonTTYScanner(r io.Reader) *bufio.Scanner {
@@ -93,6 +105,11 @@ func CreateWorkflowInteractively(ctx context.Context, workflowName string, verbo
 		return fmt.Errorf("failed to get workflow configuration: %w", err)
 	}
 
+	// Preview the generated markdown and let the user adjust the frontmatter
+	if err := builder.reviewWorkflow(); err != nil {
+		return fmt.Errorf("failed to review workflow: %w", err)
+	}
+
 	// Generate the workflow
 	if err := builder.generateWorkflow(force); err != nil {
 		return fmt.Errorf("failed to generate workflow: %w", err)
@@ -153,15 +170,9 @@ func (b *InteractiveWorkflowBuilder) promptForConfiguration() error {
 	}
 
 	// Prepare trigger options
-	triggerOptions := []huh.Option[string]{
-		huh.NewOption("Manual trigger (workflow_dispatch)", "workflow_dispatch"),
-		huh.NewOption("Issue opened or reopened", "issues"),
-		huh.NewOption("Pull request opened or synchronized", "pull_request"),
-		huh.NewOption("Push to main branch", "push"),
-		huh.NewOption("Issue comment created", "issue_comment"),
-		huh.NewOption("Schedule (daily, scattered execution time)", "schedule_daily"),
-		huh.NewOption("Schedule (weekly on Monday, scattered execution time)", "schedule_weekly"),
-		huh.NewOption("Command trigger (/bot-name)", "command"),
+	triggerHuhOptions := make([]huh.Option[string], len(triggerOptions))
+	for i, opt := range triggerOptions {
+		triggerHuhOptions[i] = huh.NewOption(opt.label, opt.value)
 	}
 
 	// Prepare engine options
@@ -211,14 +222,14 @@ func (b *InteractiveWorkflowBuilder) promptForConfiguration() error {
 	var selectedOutputs []string
 
 	// Create form with organized groups
-	form := console.NewForm(
+	groups := []*huh.Group{
 		// Group 1: Basic Configuration
 		huh.NewGroup(
 			huh.NewSelect[string]().
 				Title("When should this workflow run?").
 				Description("Choose the GitHub event that triggers this workflow").
-				Options(triggerOptions...).
-				Height(8).
+				Options(triggerHuhOptions...).
+				Height(9).
 				Value(&b.Trigger),
 			huh.NewSelect[string]().
 				Title("Which AI engine should process this workflow?").
@@ -228,6 +239,12 @@ func (b *InteractiveWorkflowBuilder) promptForConfiguration() error {
 		).
 			Title("Basic Configuration").
 			Description("Let's start with the fundamentals of your workflow"),
+	}
+
+	// Trigger sub-wizard groups are only shown for the trigger they refine
+	groups = append(groups, b.triggerDetailGroups()...)
+
+	groups = append(groups,
 
 		// Group 2: Capabilities
 		huh.NewGroup(
@@ -270,10 +287,15 @@ func (b *InteractiveWorkflowBuilder) promptForConfiguration() error {
 			Description("Describe what you want this workflow to accomplish"),
 	)
 
+	form := console.NewForm(groups...)
 	if err := form.RunWithContext(b.ctx); err != nil {
 		return err
 	}
 
+	if err := b.finalizeTriggerDetails(); err != nil {
+		return fmt.Errorf("invalid trigger configuration: %w", err)
+	}
+
 	// Store the multi-select results
 	b.Tools = selectedTools
 	b.SafeOutputs = selectedOutputs
@@ -292,22 +314,21 @@ func (b *InteractiveWorkflowBuilder) promptForConfigurationFrom(r io.Reader) err
 	scanner := b.ensureNonTTYScanner(r)
 
 	// --- Trigger (single-select) ---
-	triggerOptions := []struct{ label, value string }{
-		{"Manual trigger (workflow_dispatch)", "workflow_dispatch"},
-		{"Issue opened or reopened", "issues"},
-		{"Pull request opened or synchronized", "pull_request"},
-		{"Push to main branch", "push"},
-		{"Issue comment created", "issue_comment"},
-		{"Schedule (daily, scattered execution time)", "schedule_daily"},
-		{"Schedule (weekly on Monday, scattered execution time)", "schedule_weekly"},
-		{"Command trigger (/bot-name)", "command"},
-	}
-	trigger, err := promptNonInteractiveSelect(scanner, "When should this workflow run?", triggerOptions)
+	triggerItems := make([]struct{ label, value string }, len(triggerOptions))
+	for i, opt := range triggerOptions {
+		triggerItems[i] = struct{ label, value string }{opt.label, opt.value}
+	}
+	trigger, err := promptNonInteractiveSelect(scanner, "When should this workflow run?", triggerItems)
 	if err != nil {
 		return fmt.Errorf("failed to select trigger: %w", err)
 	}
 	b.Trigger = trigger
 
+	// --- Trigger details (depends on the selected trigger) ---
+	if err := b.promptForTriggerDetailsFrom(scanner); err != nil {
+		return fmt.Errorf("failed to configure trigger: %w", err)
+	}
+
 	// --- Engine (single-select) ---
 	engineOptions := []struct{ label, value string }{
 		{"copilot - GitHub Copilot CLI", "copilot"},
@@ -529,13 +550,25 @@ func (b *InteractiveWorkflowBuilder) generateWorkflow(force bool) error {
 	return nil
 }
 
-// generateWorkflowContent creates the workflow markdown content
+// generateWorkflowContent creates the workflow markdown content, using the reviewed
+// frontmatter when the user edited it in the preview step
 func (b *InteractiveWorkflowBuilder) generateWorkflowContent() string {
 	interactiveLog.Printf("Generating workflow content: trigger=%s, engine=%s, tools=%v, safe_outputs=%v", b.Trigger, b.Engine, b.Tools, b.SafeOutputs)
-	var content strings.Builder
+	frontmatter := b.Frontmatter
+	if frontmatter == "" {
+		frontmatter = b.generateFrontmatter()
+	}
+	return assembleWorkflowContent(frontmatter, b.generateWorkflowBody())
+}
+
+// assembleWorkflowContent joins frontmatter YAML and the markdown body into a workflow file
+func assembleWorkflowContent(frontmatter, body string) string {
+	return "---\n" + strings.TrimRight(frontmatter, "\n") + "\n---\n\n" + body
+}
 
-	// Write frontmatter
-	content.WriteString("---\n")
+// generateFrontmatter creates the frontmatter YAML (without delimiters) from the user selections
+func (b *InteractiveWorkflowBuilder) generateFrontmatter() string {
+	var content strings.Builder
 
 	// Add trigger configuration
 	content.WriteString(b.generateTriggerConfig())
@@ -559,7 +592,12 @@ func (b *InteractiveWorkflowBuilder) generateWorkflowContent() string {
 		content.WriteString(b.generateSafeOutputsConfig())
 	}
 
-	content.WriteString("---\n\n")
+	return content.String()
+}
+
+// generateWorkflowBody creates the markdown body that follows the frontmatter
+func (b *InteractiveWorkflowBuilder) generateWorkflowBody() string {
+	var content strings.Builder
 
 	// Add workflow title and content
 	fmt.Fprintf(&content, "# %s\n\n", b.WorkflowName)
@@ -610,19 +648,27 @@ func (b *InteractiveWorkflowBuilder) generateTriggerConfig() string {
 	case "workflow_dispatch":
 		return "on:\n  workflow_dispatch:\n"
 	case "issues":
-		return "on:\n  issues:\n    types: [opened, reopened]\n"
+		return "on:\n  issues:\n    types: " + formatYAMLFlowList(b.eventTypesOrDefault(defaultIssueTypes)) + "\n"
 	case "pull_request":
-		return "on:\n  pull_request:\n    types: [opened, synchronize]\n"
+		return "on:\n  pull_request:\n    types: " + formatYAMLFlowList(b.eventTypesOrDefault(defaultPullRequestTypes)) + "\n"
 	case "push":
-		return "on:\n  push:\n    branches: [main]\n"
+		return "on:\n  push:\n    branches: " + formatYAMLFlowList(b.branchesOrDefault()) + "\n"
 	case "issue_comment":
 		return "on:\n  issue_comment:\n    types: [created]\n"
 	case "schedule_daily":
 		return "on:\n  schedule: daily\n"
 	case "schedule_weekly":
 		return "on:\n  schedule: weekly on monday\n"
+	case "schedule_custom":
+		if b.ScheduleCron == "" {
+			return "on:\n  schedule: daily\n"
+		}
+		return fmt.Sprintf("on:\n  schedule:\n    - cron: %q  # %s\n", b.ScheduleCron, describeCronExpression(b.ScheduleCron))
 	case "command":
-		return "on:\n  command:\n    name: bot-name  # TODO: Replace with your bot name\n"
+		if b.CommandName == "" && b.WorkflowName == "" {
+			return "on:\n  command:\n    name: bot-name  # TODO: Replace with your bot name\n"
+		}
+		return "on:\n  command:\n    name: " + b.resolvedCommandName() + "\n"
 	default:
 		return "on:\n  workflow_dispatch:\n"
 	}
@@ -723,10 +769,19 @@ func (b *InteractiveWorkflowBuilder) describeTrigger() string {
 	case "workflow_dispatch":
 		return "Manual trigger"
 	case "issues":
+		if len(b.EventTypes) > 0 {
+			return "Issue events: " + strings.Join(b.EventTypes, ", ")
+		}
 		return "Issue opened or reopened"
 	case "pull_request":
+		if len(b.EventTypes) > 0 {
+			return "Pull request events: " + strings.Join(b.EventTypes, ", ")
+		}
 		return "Pull request opened or synchronized"
 	case "push":
+		if len(b.Branches) > 0 {
+			return "Push to branches: " + strings.Join(b.Branches, ", ")
+		}
 		return "Push to main branch"
 	case "issue_comment":
 		return "Issue comment created"
@@ -734,8 +789,13 @@ func (b *InteractiveWorkflowBuilder) describeTrigger() string {
 		return "Daily schedule (fuzzy, scattered time)"
 	case "schedule_weekly":
 		return "Weekly schedule (Monday, fuzzy scattered time)"
+	case "schedule_custom":
+		if b.ScheduleCron == "" {
+			return "Daily schedule (fuzzy, scattered time)"
+		}
+		return describeCronExpression(b.ScheduleCron)
 	case "command":
-		return "Command trigger (/bot-name)"
+		return "Command trigger (/" + b.resolvedCommandName() + ")"
 	case "custom":
 		return "Custom trigger (TODO: configure)"
 	default:
@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"charm.land/huh/v2"
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+	"github.com/github/gh-aw/pkg/tty"
+)
+
+var interactivePreviewLog = logger.New("cli:interactive_preview")
+
+// reviewWorkflow shows the generated workflow markdown and lets the user edit the
+// frontmatter before the file is written. The approved frontmatter is stored in
+// b.Frontmatter so generateWorkflow writes exactly what was previewed.
+func (b *InteractiveWorkflowBuilder) reviewWorkflow() error {
+	if !tty.IsStderrTerminal() {
+		return b.reviewWorkflowFrom(os.Stdin)
+	}
+
+	frontmatter := b.generateFrontmatter()
+	body := b.generateWorkflowBody()
+	confirmed := true
+	destFile := filepath.Join(constants.GetWorkflowDir(), b.WorkflowName+".md")
+
+	form := console.NewForm(
+		huh.NewGroup(
+			huh.NewText().
+				Title("Frontmatter").
+				Description("Edit the generated configuration before the workflow is written (ctrl+e opens $EDITOR)").
+				Lines(12).
+				ShowLineNumbers(true).
+				EditorExtension("yml").
+				Value(&frontmatter).
+				Validate(validateWorkflowFrontmatter),
+			huh.NewNote().
+				Title("Preview").
+				DescriptionFunc(func() string {
+					return escapeNoteMarkup(assembleWorkflowContent(frontmatter, body))
+				}, &frontmatter),
+			huh.NewConfirm().
+				Title(fmt.Sprintf("Write %s and compile it?", destFile)).
+				Affirmative("Write and compile").
+				Negative("Cancel").
+				Value(&confirmed),
+		).
+			Title("Review").
+			Description("Scroll to review the generated workflow"),
+	)
+	if err := form.RunWithContext(b.ctx); err != nil {
+		return err
+	}
+	if !confirmed {
+		return errors.New("workflow creation cancelled")
+	}
+
+	b.Frontmatter = frontmatter
+	interactivePreviewLog.Printf("Workflow frontmatter approved: edited=%v", frontmatter != b.generateFrontmatter())
+	return nil
+}
+
+// reviewWorkflowFrom is the non-TTY fallback for reviewWorkflow. It prints the generated
+// markdown and asks for confirmation; the frontmatter cannot be edited inline, and a
+// missing answer (EOF) accepts the workflow so existing scripted input keeps working.
+// Separated from reviewWorkflow so it can be exercised in unit tests without a real TTY.
+func (b *InteractiveWorkflowBuilder) reviewWorkflowFrom(r io.Reader) error {
+	interactivePreviewLog.Print("Non-TTY detected, printing workflow preview")
+	fmt.Fprintf(os.Stderr, "\n%s\n", b.generateWorkflowContent())
+	fmt.Fprintf(os.Stderr, "Write this workflow and compile it? [Y/n]: ")
+
+	scanner := b.ensureNonTTYScanner(r)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return fmt.Errorf("failed to read confirmation: %w", err)
+		}
+		return nil
+	}
+	switch strings.ToLower(strings.TrimSpace(scanner.Text())) {
+	case "", "y", "yes":
+		return nil
+	case "n", "no":
+		return errors.New("workflow creation cancelled")
+	default:
+		return fmt.Errorf("invalid answer %q (expected y or n)", scanner.Text())
+	}
+}
+
+// validateWorkflowFrontmatter checks that edited frontmatter is valid YAML and
+// satisfies the main workflow schema, so the review step catches typos before compiling.
+func validateWorkflowFrontmatter(frontmatter string) error {
+	if strings.TrimSpace(frontmatter) == "" {
+		return errors.New("frontmatter cannot be empty")
+	}
+	result, err := parser.ExtractFrontmatterFromContent(assembleWorkflowContent(frontmatter, ""))
+	if err != nil {
+		return err
+	}
+	if len(result.Frontmatter) == 0 {
+		return errors.New("frontmatter must be a YAML mapping")
+	}
+	return parser.ValidateMainWorkflowFrontmatterWithSchemaAndLocation(result.Frontmatter, "")
+}
+
+// escapeNoteMarkup escapes the characters huh notes interpret as markup so the
+// preview shows the markdown source verbatim.
+func escapeNoteMarkup(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "_", `\_`, "*", `\*`, "`", "\\`").Replace(s)
+}
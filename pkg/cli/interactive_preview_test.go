@@ -0,0 +1,84 @@
+//go:build !integration
+
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPreviewTestBuilder() *InteractiveWorkflowBuilder {
+	return &InteractiveWorkflowBuilder{
+		WorkflowName:  "preview-workflow",
+		Trigger:       "workflow_dispatch",
+		Engine:        "copilot",
+		Tools:         []string{"github"},
+		Intent:        "Summarize the open issues in this repository",
+		NetworkAccess: "defaults",
+	}
+}
+
+func TestGenerateWorkflowContentUsesReviewedFrontmatter(t *testing.T) {
+	b := newPreviewTestBuilder()
+	generated := b.generateWorkflowContent()
+	assert.Equal(t, assembleWorkflowContent(b.generateFrontmatter(), b.generateWorkflowBody()), generated, "content should be frontmatter plus body")
+
+	b.Frontmatter = "on:\n  workflow_dispatch:\nengine: claude\n"
+	content := b.generateWorkflowContent()
+	assert.True(t, strings.HasPrefix(content, "---\non:\n  workflow_dispatch:\nengine: claude\n---\n\n# preview-workflow\n"), "reviewed frontmatter should replace the generated one, got:\n%s", content)
+	assert.NotContains(t, content, "engine: copilot", "generated frontmatter should not be written")
+}
+
+func TestValidateWorkflowFrontmatter(t *testing.T) {
+	b := newPreviewTestBuilder()
+	require.NoError(t, validateWorkflowFrontmatter(b.generateFrontmatter()), "generated frontmatter should be valid")
+
+	tests := []struct {
+		name        string
+		frontmatter string
+	}{
+		{name: "empty", frontmatter: "  \n"},
+		{name: "invalid yaml", frontmatter: "on: [workflow_dispatch\n"},
+		{name: "not a mapping", frontmatter: "- workflow_dispatch\n"},
+		{name: "missing trigger", frontmatter: "engine: copilot\n"},
+		{name: "unknown field", frontmatter: "on: workflow_dispatch\nengnie: copilot\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Error(t, validateWorkflowFrontmatter(tt.frontmatter), "frontmatter should be rejected")
+		})
+	}
+}
+
+func TestReviewWorkflowFrom(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{name: "blank accepts", input: "\n"},
+		{name: "yes accepts", input: "Y\n"},
+		{name: "EOF accepts", input: ""},
+		{name: "no cancels", input: "n\n", wantErr: "cancelled"},
+		{name: "unknown answer", input: "maybe\n", wantErr: "invalid answer"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newPreviewTestBuilder()
+			err := b.reviewWorkflowFrom(strings.NewReader(tt.input))
+			if tt.wantErr == "" {
+				require.NoError(t, err, "review should be accepted")
+				return
+			}
+			require.Error(t, err, "review should fail")
+			assert.Contains(t, err.Error(), tt.wantErr, "error should explain the outcome")
+		})
+	}
+}
+
+func TestEscapeNoteMarkup(t *testing.T) {
+	assert.Equal(t, "Run \\`gh aw compile\\` for \\*my\\_workflow\\* in C:\\\\repo", escapeNoteMarkup("Run `gh aw compile` for *my_workflow* in C:\\repo"), "markup characters should be escaped")
+}
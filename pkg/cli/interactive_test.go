@@ -940,8 +940,8 @@ func TestPromptForConfigurationFrom_Basic(t *testing.T) {
 }
 
 func TestPromptForConfigurationFrom_ByValue(t *testing.T) {
-	// Use values instead of numbers for engine and tools
-	input := "issues\nclaude\ngithub,bash\ncreate-issue\ndefaults\nThis is a test workflow that does something useful and important\n"
+	// Use values instead of numbers for engine and tools; blank issue types keep the defaults
+	input := "issues\n\nclaude\ngithub,bash\ncreate-issue\ndefaults\nThis is a test workflow that does something useful and important\n"
 	b := &InteractiveWorkflowBuilder{}
 	err := b.promptForConfigurationFrom(strings.NewReader(input))
 	if err != nil {
@@ -0,0 +1,597 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"charm.land/huh/v2"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+)
+
+var interactiveTriggerLog = logger.New("cli:interactive_trigger")
+
+// Default trigger details used when the user leaves a sub-wizard field blank.
+var (
+	defaultIssueTypes       = []string{"opened", "reopened"}
+	defaultPullRequestTypes = []string{"opened", "synchronize"}
+	defaultPushBranches     = []string{"main"}
+)
+
+const (
+	defaultCronTimeOfDay = "09:00"
+	defaultCommandName   = "bot-name"
+)
+
+// triggerOption is a label/value pair shared by the TTY and non-TTY trigger prompts.
+type triggerOption struct {
+	label string
+	value string
+}
+
+// triggerOptions lists the top-level trigger choices offered by the wizard.
+var triggerOptions = []triggerOption{
+	{"Manual trigger (workflow_dispatch)", "workflow_dispatch"},
+	{"Issue events (choose event types)", "issues"},
+	{"Pull request events (choose event types)", "pull_request"},
+	{"Push to branches (choose branch filters)", "push"},
+	{"Issue comment created", "issue_comment"},
+	{"Schedule (daily, scattered execution time)", "schedule_daily"},
+	{"Schedule (weekly on Monday, scattered execution time)", "schedule_weekly"},
+	{"Schedule (custom time, cron builder)", "schedule_custom"},
+	{"Command trigger (/command-name)", "command"},
+}
+
+// issueTypeOptions lists the issue activity types offered by the sub-wizard.
+var issueTypeOptions = []triggerOption{
+	{"opened", "opened"},
+	{"reopened", "reopened"},
+	{"edited", "edited"},
+	{"closed", "closed"},
+	{"labeled", "labeled"},
+	{"assigned", "assigned"},
+}
+
+// pullRequestTypeOptions lists the pull request activity types offered by the sub-wizard.
+var pullRequestTypeOptions = []triggerOption{
+	{"opened", "opened"},
+	{"synchronize - new commits pushed", "synchronize"},
+	{"reopened", "reopened"},
+	{"ready_for_review", "ready_for_review"},
+	{"labeled", "labeled"},
+	{"closed", "closed"},
+}
+
+// cronFrequencyOptions lists the frequencies supported by the cron builder.
+var cronFrequencyOptions = []triggerOption{
+	{"Hourly", "hourly"},
+	{"Daily", "daily"},
+	{"Weekdays (Monday to Friday)", "weekdays"},
+	{"Weekly", "weekly"},
+	{"Monthly", "monthly"},
+	{"Custom cron expression", "cron"},
+}
+
+// cronWeekdayOptions lists the days of the week using cron day-of-week numbers.
+var cronWeekdayOptions = []triggerOption{
+	{"Sunday", "0"},
+	{"Monday", "1"},
+	{"Tuesday", "2"},
+	{"Wednesday", "3"},
+	{"Thursday", "4"},
+	{"Friday", "5"},
+	{"Saturday", "6"},
+}
+
+// cronBuilderInput holds the raw answers collected by the cron builder.
+type cronBuilderInput struct {
+	Frequency  string // One of the cronFrequencyOptions values
+	TimeOfDay  string // HH:MM in UTC (daily, weekdays, weekly, monthly)
+	Minute     string // Minute past the hour (hourly)
+	Weekday    string // Cron day-of-week number (weekly)
+	DayOfMonth string // Day of the month, 1-28 (monthly)
+	Expression string // Raw cron expression (cron)
+}
+
+// build converts the builder answers into a five-field cron expression.
+func (c cronBuilderInput) build() (string, error) {
+	switch c.Frequency {
+	case "hourly":
+		minute, err := parseCronMinute(c.Minute)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%d * * * *", minute), nil
+	case "daily", "weekdays", "weekly", "monthly":
+		hour, minute, err := parseTimeOfDay(c.TimeOfDay)
+		if err != nil {
+			return "", err
+		}
+		switch c.Frequency {
+		case "daily":
+			return fmt.Sprintf("%d %d * * *", minute, hour), nil
+		case "weekdays":
+			return fmt.Sprintf("%d %d * * 1-5", minute, hour), nil
+		case "weekly":
+			weekday := c.Weekday
+			if weekday == "" {
+				weekday = "1"
+			}
+			if _, ok := cronWeekdayName(weekday); !ok {
+				return "", fmt.Errorf("invalid day of week %q", weekday)
+			}
+			return fmt.Sprintf("%d %d * * %s", minute, hour, weekday), nil
+		default:
+			day, err := parseDayOfMonth(c.DayOfMonth)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("%d %d %d * *", minute, hour, day), nil
+		}
+	case "cron":
+		expr := strings.Join(strings.Fields(c.Expression), " ")
+		if !parser.IsCronExpression(expr) {
+			return "", fmt.Errorf("invalid cron expression %q (expected 5 fields: minute hour day-of-month month day-of-week)", c.Expression)
+		}
+		return expr, nil
+	default:
+		return "", fmt.Errorf("unknown schedule frequency %q", c.Frequency)
+	}
+}
+
+// preview returns a human-readable description of the schedule being built,
+// or the validation error when the current answers are incomplete.
+func (c cronBuilderInput) preview() string {
+	cron, err := c.build()
+	if err != nil {
+		return "⚠ " + err.Error()
+	}
+	return fmt.Sprintf("%s  (cron: %s)", describeCronExpression(cron), cron)
+}
+
+// parseTimeOfDay parses an HH:MM time in 24-hour format. An empty value
+// resolves to defaultCronTimeOfDay.
+func parseTimeOfDay(value string) (int, int, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		value = defaultCronTimeOfDay
+	}
+	hourStr, minuteStr, ok := strings.Cut(value, ":")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid time %q (expected HH:MM in 24-hour UTC)", value)
+	}
+	hour, err := strconv.Atoi(hourStr)
+	if err != nil || hour < 0 || hour > 23 {
+		return 0, 0, fmt.Errorf("invalid hour in %q (must be 0-23)", value)
+	}
+	minute, err := strconv.Atoi(minuteStr)
+	if err != nil || minute < 0 || minute > 59 {
+		return 0, 0, fmt.Errorf("invalid minute in %q (must be 0-59)", value)
+	}
+	return hour, minute, nil
+}
+
+// parseCronMinute parses a minute past the hour. An empty value resolves to 0.
+func parseCronMinute(value string) (int, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+	minute, err := strconv.Atoi(value)
+	if err != nil || minute < 0 || minute > 59 {
+		return 0, fmt.Errorf("invalid minute %q (must be 0-59)", value)
+	}
+	return minute, nil
+}
+
+// parseDayOfMonth parses a day of the month. Days are limited to 1-28 so the
+// schedule fires every month. An empty value resolves to 1.
+func parseDayOfMonth(value string) (int, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 1, nil
+	}
+	day, err := strconv.Atoi(value)
+	if err != nil || day < 1 || day > 28 {
+		return 0, fmt.Errorf("invalid day of month %q (must be 1-28)", value)
+	}
+	return day, nil
+}
+
+// cronWeekdayName maps a cron day-of-week number to its English name.
+func cronWeekdayName(value string) (string, bool) {
+	for _, opt := range cronWeekdayOptions {
+		if opt.value == value {
+			return opt.label, true
+		}
+	}
+	return "", false
+}
+
+// describeCronExpression renders the cron shapes produced by the cron builder
+// as English. Other expressions are described generically.
+func describeCronExpression(cron string) string {
+	fields := strings.Fields(cron)
+	if len(fields) != 5 {
+		return "Custom schedule: " + cron
+	}
+	minute, hour, dom, month, dow := fields[0], fields[1], fields[2], fields[3], fields[4]
+	if month != "*" || !isNumericCronField(minute) {
+		return fmt.Sprintf("Custom schedule: %s (UTC)", cron)
+	}
+	m, _ := strconv.Atoi(minute)
+	if hour == "*" && dom == "*" && dow == "*" {
+		return fmt.Sprintf("Every hour at %d minutes past the hour", m)
+	}
+	if !isNumericCronField(hour) {
+		return fmt.Sprintf("Custom schedule: %s (UTC)", cron)
+	}
+	h, _ := strconv.Atoi(hour)
+	at := fmt.Sprintf("at %02d:%02d UTC", h, m)
+	switch {
+	case dom == "*" && dow == "*":
+		return "Every day " + at
+	case dom == "*" && dow == "1-5":
+		return "Every weekday (Monday to Friday) " + at
+	case dom == "*":
+		if name, ok := cronWeekdayName(dow); ok {
+			return fmt.Sprintf("Every %s %s", name, at)
+		}
+	case dow == "*" && isNumericCronField(dom):
+		return fmt.Sprintf("On day %s of every month %s", dom, at)
+	}
+	return fmt.Sprintf("Custom schedule: %s (UTC)", cron)
+}
+
+// isNumericCronField reports whether a cron field is a plain non-negative integer.
+func isNumericCronField(field string) bool {
+	_, err := strconv.Atoi(field)
+	return err == nil && !strings.HasPrefix(field, "-") && !strings.HasPrefix(field, "+")
+}
+
+// normalizeCommandName strips a leading slash and validates the command name.
+func normalizeCommandName(value string) (string, error) {
+	name := strings.TrimPrefix(strings.TrimSpace(value), "/")
+	if name == "" {
+		return "", nil
+	}
+	if strings.ContainsAny(name, "/* \t") {
+		return "", fmt.Errorf("invalid command name %q (must not contain spaces, '/' or '*')", value)
+	}
+	return name, nil
+}
+
+// parseBranchList splits a comma-separated list of branch names or patterns.
+func parseBranchList(value string) []string {
+	var branches []string
+	for b := range strings.SplitSeq(value, ",") {
+		if b = strings.TrimSpace(b); b != "" {
+			branches = append(branches, b)
+		}
+	}
+	return branches
+}
+
+// triggerDetailGroups returns the second-level form groups that refine the
+// selected trigger. Each group is hidden unless its trigger is selected, so
+// the groups can be appended to the main configuration form.
+func (b *InteractiveWorkflowBuilder) triggerDetailGroups() []*huh.Group {
+	toHuhOptions := func(opts []triggerOption) []huh.Option[string] {
+		out := make([]huh.Option[string], len(opts))
+		for i, o := range opts {
+			out[i] = huh.NewOption(o.label, o.value)
+		}
+		return out
+	}
+
+	b.cronInput = cronBuilderInput{Frequency: "daily", TimeOfDay: defaultCronTimeOfDay, Weekday: "1", DayOfMonth: "1"}
+	b.EventTypes = nil
+
+	return []*huh.Group{
+		huh.NewGroup(
+			huh.NewMultiSelect[string]().
+				Title("Which issue events should trigger the workflow?").
+				Description("Leave empty for: " + strings.Join(defaultIssueTypes, ", ")).
+				Options(toHuhOptions(issueTypeOptions)...).
+				Value(&b.EventTypes),
+		).
+			Title("Issue Trigger").
+			WithHideFunc(func() bool { return b.Trigger != "issues" }),
+
+		huh.NewGroup(
+			huh.NewMultiSelect[string]().
+				Title("Which pull request events should trigger the workflow?").
+				Description("Leave empty for: " + strings.Join(defaultPullRequestTypes, ", ")).
+				Options(toHuhOptions(pullRequestTypeOptions)...).
+				Value(&b.EventTypes),
+		).
+			Title("Pull Request Trigger").
+			WithHideFunc(func() bool { return b.Trigger != "pull_request" }),
+
+		huh.NewGroup(
+			huh.NewInput().
+				Title("Which branches should trigger the workflow?").
+				Description("Comma-separated branch names or glob patterns (e.g. main, release/*)").
+				Placeholder(strings.Join(defaultPushBranches, ", ")).
+				Value(&b.branchesInput),
+		).
+			Title("Push Trigger").
+			WithHideFunc(func() bool { return b.Trigger != "push" }),
+
+		huh.NewGroup(
+			huh.NewInput().
+				Title("What command should trigger the workflow?").
+				Description("Users trigger the workflow by commenting /<name> on issues and pull requests").
+				Placeholder(b.defaultCommandName()).
+				Value(&b.CommandName).
+				Validate(func(s string) error {
+					_, err := normalizeCommandName(s)
+					return err
+				}),
+		).
+			Title("Command Trigger").
+			WithHideFunc(func() bool { return b.Trigger != "command" }),
+
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title("How often should the workflow run?").
+				Options(toHuhOptions(cronFrequencyOptions)...).
+				Value(&b.cronInput.Frequency),
+		).
+			Title("Schedule").
+			Description("Build a cron schedule (all times are UTC)").
+			WithHideFunc(func() bool { return b.Trigger != "schedule_custom" }),
+
+		huh.NewGroup(
+			huh.NewInput().
+				Title("Minute past the hour (0-59)").
+				Value(&b.cronInput.Minute).
+				Validate(func(s string) error { _, err := parseCronMinute(s); return err }),
+			huh.NewNote().
+				Title("Preview").
+				DescriptionFunc(func() string { return b.cronInput.preview() }, &b.cronInput),
+		).
+			Title("Schedule").
+			WithHideFunc(func() bool { return b.Trigger != "schedule_custom" || b.cronInput.Frequency != "hourly" }),
+
+		huh.NewGroup(
+			huh.NewInput().
+				Title("Time of day (HH:MM, 24-hour UTC)").
+				Value(&b.cronInput.TimeOfDay).
+				Validate(func(s string) error { _, _, err := parseTimeOfDay(s); return err }),
+			huh.NewSelect[string]().
+				Title("Day of the week").
+				Options(toHuhOptions(cronWeekdayOptions)...).
+				Value(&b.cronInput.Weekday),
+			huh.NewNote().
+				Title("Preview").
+				DescriptionFunc(func() string { return b.cronInput.preview() }, &b.cronInput),
+		).
+			Title("Schedule").
+			WithHideFunc(func() bool { return b.Trigger != "schedule_custom" || b.cronInput.Frequency != "weekly" }),
+
+		huh.NewGroup(
+			huh.NewInput().
+				Title("Time of day (HH:MM, 24-hour UTC)").
+				Value(&b.cronInput.TimeOfDay).
+				Validate(func(s string) error { _, _, err := parseTimeOfDay(s); return err }),
+			huh.NewInput().
+				Title("Day of the month (1-28)").
+				Value(&b.cronInput.DayOfMonth).
+				Validate(func(s string) error { _, err := parseDayOfMonth(s); return err }),
+			huh.NewNote().
+				Title("Preview").
+				DescriptionFunc(func() string { return b.cronInput.preview() }, &b.cronInput),
+		).
+			Title("Schedule").
+			WithHideFunc(func() bool { return b.Trigger != "schedule_custom" || b.cronInput.Frequency != "monthly" }),
+
+		huh.NewGroup(
+			huh.NewInput().
+				Title("Time of day (HH:MM, 24-hour UTC)").
+				Value(&b.cronInput.TimeOfDay).
+				Validate(func(s string) error { _, _, err := parseTimeOfDay(s); return err }),
+			huh.NewNote().
+				Title("Preview").
+				DescriptionFunc(func() string { return b.cronInput.preview() }, &b.cronInput),
+		).
+			Title("Schedule").
+			WithHideFunc(func() bool {
+				return b.Trigger != "schedule_custom" || (b.cronInput.Frequency != "daily" && b.cronInput.Frequency != "weekdays")
+			}),
+
+		huh.NewGroup(
+			huh.NewInput().
+				Title("Cron expression (minute hour day-of-month month day-of-week)").
+				Placeholder("0 9 * * 1-5").
+				Value(&b.cronInput.Expression).
+				Validate(func(s string) error {
+					_, err := cronBuilderInput{Frequency: "cron", Expression: s}.build()
+					return err
+				}),
+			huh.NewNote().
+				Title("Preview").
+				DescriptionFunc(func() string { return b.cronInput.preview() }, &b.cronInput),
+		).
+			Title("Schedule").
+			WithHideFunc(func() bool { return b.Trigger != "schedule_custom" || b.cronInput.Frequency != "cron" }),
+	}
+}
+
+// finalizeTriggerDetails converts the raw sub-wizard answers into the builder's
+// trigger fields once the form has been submitted.
+func (b *InteractiveWorkflowBuilder) finalizeTriggerDetails() error {
+	switch b.Trigger {
+	case "push":
+		b.Branches = parseBranchList(b.branchesInput)
+	case "command":
+		name, err := normalizeCommandName(b.CommandName)
+		if err != nil {
+			return err
+		}
+		b.CommandName = name
+	case "schedule_custom":
+		cron, err := b.cronInput.build()
+		if err != nil {
+			return err
+		}
+		b.ScheduleCron = cron
+	}
+	if b.Trigger != "issues" && b.Trigger != "pull_request" {
+		b.EventTypes = nil
+	}
+	interactiveTriggerLog.Printf("Trigger details: trigger=%s, types=%v, branches=%v, command=%s, cron=%s", b.Trigger, b.EventTypes, b.Branches, b.CommandName, b.ScheduleCron)
+	return nil
+}
+
+// promptForTriggerDetailsFrom is the non-TTY fallback for the trigger sub-wizard.
+// Every prompt accepts a blank answer, which keeps the trigger's default.
+func (b *InteractiveWorkflowBuilder) promptForTriggerDetailsFrom(scanner *bufio.Scanner) error {
+	toItems := func(opts []triggerOption) []struct{ label, value string } {
+		out := make([]struct{ label, value string }, len(opts))
+		for i, o := range opts {
+			out[i] = struct{ label, value string }{o.label, o.value}
+		}
+		return out
+	}
+
+	switch b.Trigger {
+	case "issues":
+		types, err := promptNonInteractiveMultiSelect(scanner, "Which issue events should trigger the workflow? (leave blank for "+strings.Join(defaultIssueTypes, ", ")+")", toItems(issueTypeOptions))
+		if err != nil {
+			return fmt.Errorf("failed to select issue events: %w", err)
+		}
+		b.EventTypes = types
+	case "pull_request":
+		types, err := promptNonInteractiveMultiSelect(scanner, "Which pull request events should trigger the workflow? (leave blank for "+strings.Join(defaultPullRequestTypes, ", ")+")", toItems(pullRequestTypeOptions))
+		if err != nil {
+			return fmt.Errorf("failed to select pull request events: %w", err)
+		}
+		b.EventTypes = types
+	case "push":
+		answer, err := promptNonInteractiveLine(scanner, "Which branches should trigger the workflow? (comma-separated, leave blank for "+strings.Join(defaultPushBranches, ", ")+")")
+		if err != nil {
+			return err
+		}
+		b.Branches = parseBranchList(answer)
+	case "command":
+		answer, err := promptNonInteractiveLine(scanner, "What command should trigger the workflow? (leave blank for /"+b.defaultCommandName()+")")
+		if err != nil {
+			return err
+		}
+		name, err := normalizeCommandName(answer)
+		if err != nil {
+			return err
+		}
+		b.CommandName = name
+	case "schedule_custom":
+		return b.promptForCronFrom(scanner, toItems)
+	}
+	return nil
+}
+
+// promptForCronFrom runs the non-TTY cron builder and prints a human-readable preview.
+func (b *InteractiveWorkflowBuilder) promptForCronFrom(scanner *bufio.Scanner, toItems func([]triggerOption) []struct{ label, value string }) error {
+	frequency, err := promptNonInteractiveSelect(scanner, "How often should the workflow run? (all times are UTC)", toItems(cronFrequencyOptions))
+	if err != nil {
+		return fmt.Errorf("failed to select schedule frequency: %w", err)
+	}
+	input := cronBuilderInput{Frequency: frequency}
+
+	switch frequency {
+	case "hourly":
+		if input.Minute, err = promptNonInteractiveLine(scanner, "Minute past the hour (0-59, leave blank for 0)"); err != nil {
+			return err
+		}
+	case "cron":
+		if input.Expression, err = promptNonInteractiveLine(scanner, "Cron expression (minute hour day-of-month month day-of-week)"); err != nil {
+			return err
+		}
+	default:
+		if input.TimeOfDay, err = promptNonInteractiveLine(scanner, "Time of day (HH:MM, 24-hour UTC, leave blank for "+defaultCronTimeOfDay+")"); err != nil {
+			return err
+		}
+	}
+	switch frequency {
+	case "weekly":
+		if input.Weekday, err = promptNonInteractiveSelect(scanner, "Day of the week", toItems(cronWeekdayOptions)); err != nil {
+			return fmt.Errorf("failed to select day of week: %w", err)
+		}
+	case "monthly":
+		if input.DayOfMonth, err = promptNonInteractiveLine(scanner, "Day of the month (1-28, leave blank for 1)"); err != nil {
+			return err
+		}
+	}
+
+	cron, err := input.build()
+	if err != nil {
+		return fmt.Errorf("invalid schedule: %w", err)
+	}
+	b.cronInput = input
+	b.ScheduleCron = cron
+	fmt.Fprintf(os.Stderr, "Schedule: %s\n", input.preview())
+	return nil
+}
+
+// promptNonInteractiveLine prints a prompt and reads a single trimmed line.
+// EOF is treated as a blank answer.
+func promptNonInteractiveLine(scanner *bufio.Scanner, title string) (string, error) {
+	fmt.Fprintf(os.Stderr, "\n%s\n> ", title)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return "", fmt.Errorf("failed to read input: %w", err)
+		}
+		return "", nil
+	}
+	return strings.TrimSpace(scanner.Text()), nil
+}
+
+// defaultCommandName returns the command name used when none is entered:
+// the workflow name when known, otherwise a placeholder.
+func (b *InteractiveWorkflowBuilder) defaultCommandName() string {
+	if b.WorkflowName != "" {
+		return b.WorkflowName
+	}
+	return defaultCommandName
+}
+
+// resolvedCommandName returns the configured command name or its default.
+func (b *InteractiveWorkflowBuilder) resolvedCommandName() string {
+	if b.CommandName != "" {
+		return b.CommandName
+	}
+	return b.defaultCommandName()
+}
+
+// eventTypesOrDefault returns the selected event types, or the defaults when none were chosen.
+func (b *InteractiveWorkflowBuilder) eventTypesOrDefault(defaults []string) []string {
+	if len(b.EventTypes) > 0 {
+		return b.EventTypes
+	}
+	return defaults
+}
+
+// branchesOrDefault returns the selected push branches, or the defaults when none were entered.
+func (b *InteractiveWorkflowBuilder) branchesOrDefault() []string {
+	if len(b.Branches) > 0 {
+		return b.Branches
+	}
+	return defaultPushBranches
+}
+
+// formatYAMLFlowList renders values as a YAML flow sequence, quoting entries
+// that YAML would otherwise misinterpret (such as glob patterns).
+func formatYAMLFlowList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		if strings.ContainsAny(v, "*!&?{}[],:#'\"") {
+			quoted[i] = strconv.Quote(v)
+		} else {
+			quoted[i] = v
+		}
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
@@ -0,0 +1,257 @@
+//go:build !integration
+
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCronBuilderInput_Build(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    cronBuilderInput
+		expected string
+		wantErr  string
+	}{
+		{name: "hourly default minute", input: cronBuilderInput{Frequency: "hourly"}, expected: "0 * * * *"},
+		{name: "hourly at minute", input: cronBuilderInput{Frequency: "hourly", Minute: "15"}, expected: "15 * * * *"},
+		{name: "daily default time", input: cronBuilderInput{Frequency: "daily"}, expected: "0 9 * * *"},
+		{name: "daily", input: cronBuilderInput{Frequency: "daily", TimeOfDay: "14:30"}, expected: "30 14 * * *"},
+		{name: "weekdays", input: cronBuilderInput{Frequency: "weekdays", TimeOfDay: "08:05"}, expected: "5 8 * * 1-5"},
+		{name: "weekly", input: cronBuilderInput{Frequency: "weekly", TimeOfDay: "10:00", Weekday: "5"}, expected: "0 10 * * 5"},
+		{name: "monthly", input: cronBuilderInput{Frequency: "monthly", TimeOfDay: "00:00", DayOfMonth: "15"}, expected: "0 0 15 * *"},
+		{name: "raw cron normalizes spacing", input: cronBuilderInput{Frequency: "cron", Expression: " 0  6 * * 1 "}, expected: "0 6 * * 1"},
+		{name: "invalid time", input: cronBuilderInput{Frequency: "daily", TimeOfDay: "25:00"}, wantErr: "invalid hour"},
+		{name: "invalid minute", input: cronBuilderInput{Frequency: "hourly", Minute: "60"}, wantErr: "invalid minute"},
+		{name: "invalid day of month", input: cronBuilderInput{Frequency: "monthly", DayOfMonth: "31"}, wantErr: "must be 1-28"},
+		{name: "invalid weekday", input: cronBuilderInput{Frequency: "weekly", Weekday: "7"}, wantErr: "invalid day of week"},
+		{name: "invalid cron", input: cronBuilderInput{Frequency: "cron", Expression: "every day"}, wantErr: "invalid cron expression"},
+		{name: "unknown frequency", input: cronBuilderInput{Frequency: "yearly"}, wantErr: "unknown schedule frequency"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, err := tt.input.build()
+			if tt.wantErr != "" {
+				require.Error(t, err, "build should fail")
+				assert.Contains(t, err.Error(), tt.wantErr, "error message should explain the problem")
+				return
+			}
+			require.NoError(t, err, "build should succeed")
+			assert.Equal(t, tt.expected, cron, "cron expression should match")
+		})
+	}
+}
+
+func TestDescribeCronExpression(t *testing.T) {
+	tests := []struct {
+		cron     string
+		expected string
+	}{
+		{"15 * * * *", "Every hour at 15 minutes past the hour"},
+		{"30 14 * * *", "Every day at 14:30 UTC"},
+		{"5 8 * * 1-5", "Every weekday (Monday to Friday) at 08:05 UTC"},
+		{"0 10 * * 5", "Every Friday at 10:00 UTC"},
+		{"0 0 15 * *", "On day 15 of every month at 00:00 UTC"},
+		{"*/5 * * * *", "Custom schedule: */5 * * * * (UTC)"},
+		{"0 9 * 1 *", "Custom schedule: 0 9 * 1 * (UTC)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cron, func(t *testing.T) {
+			assert.Equal(t, tt.expected, describeCronExpression(tt.cron), "description should match")
+		})
+	}
+}
+
+func TestNormalizeCommandName(t *testing.T) {
+	name, err := normalizeCommandName("/triage")
+	require.NoError(t, err, "leading slash should be accepted")
+	assert.Equal(t, "triage", name, "leading slash should be stripped")
+
+	name, err = normalizeCommandName("  ")
+	require.NoError(t, err, "blank name should be accepted")
+	assert.Empty(t, name, "blank name should resolve to empty")
+
+	_, err = normalizeCommandName("two words")
+	require.Error(t, err, "names with spaces should be rejected")
+}
+
+func TestParseBranchList(t *testing.T) {
+	assert.Equal(t, []string{"main", "release/*"}, parseBranchList(" main, release/* ,,"), "branches should be trimmed and blanks dropped")
+	assert.Nil(t, parseBranchList(""), "empty input should yield no branches")
+}
+
+func TestGenerateTriggerConfig_WithDetails(t *testing.T) {
+	tests := []struct {
+		name     string
+		builder  InteractiveWorkflowBuilder
+		expected string
+	}{
+		{
+			name:     "issue types",
+			builder:  InteractiveWorkflowBuilder{Trigger: "issues", EventTypes: []string{"opened", "labeled"}},
+			expected: "on:\n  issues:\n    types: [opened, labeled]\n",
+		},
+		{
+			name:     "pull request types",
+			builder:  InteractiveWorkflowBuilder{Trigger: "pull_request", EventTypes: []string{"ready_for_review"}},
+			expected: "on:\n  pull_request:\n    types: [ready_for_review]\n",
+		},
+		{
+			name:     "push default branch",
+			builder:  InteractiveWorkflowBuilder{Trigger: "push"},
+			expected: "on:\n  push:\n    branches: [main]\n",
+		},
+		{
+			name:     "push branch patterns are quoted",
+			builder:  InteractiveWorkflowBuilder{Trigger: "push", Branches: []string{"main", "release/*"}},
+			expected: "on:\n  push:\n    branches: [main, \"release/*\"]\n",
+		},
+		{
+			name:     "command name",
+			builder:  InteractiveWorkflowBuilder{Trigger: "command", CommandName: "triage"},
+			expected: "on:\n  command:\n    name: triage\n",
+		},
+		{
+			name:     "command defaults to workflow name",
+			builder:  InteractiveWorkflowBuilder{Trigger: "command", WorkflowName: "issue-triage"},
+			expected: "on:\n  command:\n    name: issue-triage\n",
+		},
+		{
+			name:     "command without any name keeps placeholder",
+			builder:  InteractiveWorkflowBuilder{Trigger: "command"},
+			expected: "on:\n  command:\n    name: bot-name  # TODO: Replace with your bot name\n",
+		},
+		{
+			name:     "custom schedule",
+			builder:  InteractiveWorkflowBuilder{Trigger: "schedule_custom", ScheduleCron: "5 8 * * 1-5"},
+			expected: "on:\n  schedule:\n    - cron: \"5 8 * * 1-5\"  # Every weekday (Monday to Friday) at 08:05 UTC\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.builder.generateTriggerConfig(), "trigger config should match")
+		})
+	}
+}
+
+func TestDescribeTrigger_WithDetails(t *testing.T) {
+	b := &InteractiveWorkflowBuilder{Trigger: "push", Branches: []string{"main", "develop"}}
+	assert.Equal(t, "Push to branches: main, develop", b.describeTrigger(), "push description should list branches")
+
+	b = &InteractiveWorkflowBuilder{Trigger: "schedule_custom", ScheduleCron: "0 10 * * 5"}
+	assert.Equal(t, "Every Friday at 10:00 UTC", b.describeTrigger(), "schedule description should be human readable")
+
+	b = &InteractiveWorkflowBuilder{Trigger: "command", CommandName: "triage"}
+	assert.Equal(t, "Command trigger (/triage)", b.describeTrigger(), "command description should use the command name")
+}
+
+func TestPromptForTriggerDetailsFrom(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		setup  InteractiveWorkflowBuilder
+		verify func(t *testing.T, b *InteractiveWorkflowBuilder)
+	}{
+		{
+			name:  "issue types by value",
+			input: "opened,labeled\n",
+			setup: InteractiveWorkflowBuilder{Trigger: "issues"},
+			verify: func(t *testing.T, b *InteractiveWorkflowBuilder) {
+				assert.Equal(t, []string{"opened", "labeled"}, b.EventTypes, "issue types should be recorded")
+			},
+		},
+		{
+			name:  "pull request types blank keeps defaults",
+			input: "\n",
+			setup: InteractiveWorkflowBuilder{Trigger: "pull_request"},
+			verify: func(t *testing.T, b *InteractiveWorkflowBuilder) {
+				assert.Empty(t, b.EventTypes, "blank answer should keep defaults")
+				assert.Contains(t, b.generateTriggerConfig(), "types: [opened, synchronize]", "defaults should be generated")
+			},
+		},
+		{
+			name:  "push branches",
+			input: "main, release/*\n",
+			setup: InteractiveWorkflowBuilder{Trigger: "push"},
+			verify: func(t *testing.T, b *InteractiveWorkflowBuilder) {
+				assert.Equal(t, []string{"main", "release/*"}, b.Branches, "branches should be recorded")
+			},
+		},
+		{
+			name:  "command name",
+			input: "/triage\n",
+			setup: InteractiveWorkflowBuilder{Trigger: "command"},
+			verify: func(t *testing.T, b *InteractiveWorkflowBuilder) {
+				assert.Equal(t, "triage", b.CommandName, "command name should be recorded without slash")
+			},
+		},
+		{
+			name:  "weekly schedule",
+			input: "weekly\n14:30\n3\n",
+			setup: InteractiveWorkflowBuilder{Trigger: "schedule_custom"},
+			verify: func(t *testing.T, b *InteractiveWorkflowBuilder) {
+				assert.Equal(t, "30 14 * * 2", b.ScheduleCron, "weekly cron should be built")
+			},
+		},
+		{
+			name:  "monthly schedule with defaults",
+			input: "monthly\n\n\n",
+			setup: InteractiveWorkflowBuilder{Trigger: "schedule_custom"},
+			verify: func(t *testing.T, b *InteractiveWorkflowBuilder) {
+				assert.Equal(t, "0 9 1 * *", b.ScheduleCron, "monthly cron should use defaults")
+			},
+		},
+		{
+			name:  "raw cron expression",
+			input: "cron\n*/30 * * * *\n",
+			setup: InteractiveWorkflowBuilder{Trigger: "schedule_custom"},
+			verify: func(t *testing.T, b *InteractiveWorkflowBuilder) {
+				assert.Equal(t, "*/30 * * * *", b.ScheduleCron, "raw cron should be recorded")
+			},
+		},
+		{
+			name:  "workflow_dispatch asks nothing",
+			input: "",
+			setup: InteractiveWorkflowBuilder{Trigger: "workflow_dispatch"},
+			verify: func(t *testing.T, b *InteractiveWorkflowBuilder) {
+				assert.Empty(t, b.EventTypes, "no details should be collected")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := tt.setup
+			err := b.promptForTriggerDetailsFrom(newScannerFromString(tt.input))
+			require.NoError(t, err, "trigger details prompt should succeed")
+			tt.verify(t, &b)
+		})
+	}
+}
+
+func TestPromptForTriggerDetailsFrom_InvalidSchedule(t *testing.T) {
+	b := &InteractiveWorkflowBuilder{Trigger: "schedule_custom"}
+	err := b.promptForTriggerDetailsFrom(newScannerFromString("daily\n9am\n"))
+	require.Error(t, err, "invalid time should be rejected")
+	assert.Contains(t, err.Error(), "invalid schedule", "error should mention the schedule")
+}
+
+func TestFinalizeTriggerDetails(t *testing.T) {
+	b := &InteractiveWorkflowBuilder{Trigger: "schedule_custom", cronInput: cronBuilderInput{Frequency: "weekdays", TimeOfDay: "07:45"}}
+	require.NoError(t, b.finalizeTriggerDetails(), "finalize should succeed")
+	assert.Equal(t, "45 7 * * 1-5", b.ScheduleCron, "cron should be built from the form answers")
+
+	b = &InteractiveWorkflowBuilder{Trigger: "push", branchesInput: "main,develop"}
+	require.NoError(t, b.finalizeTriggerDetails(), "finalize should succeed")
+	assert.Equal(t, []string{"main", "develop"}, b.Branches, "branches should be parsed from the form answer")
+
+	b = &InteractiveWorkflowBuilder{Trigger: "workflow_dispatch", EventTypes: []string{"opened"}}
+	require.NoError(t, b.finalizeTriggerDetails(), "finalize should succeed")
+	assert.Nil(t, b.EventTypes, "event types from a previously selected trigger should be cleared")
+}
@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/github/gh-aw/pkg/constants"
 	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/workflow"
 	"github.com/spf13/cobra"
 )
 
@@ -34,20 +36,32 @@ var defaultGhAwActionlintIgnorePatterns = []string{
 func NewLintCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "lint [lock-file-or-directory]...",
-		Short: "Lint existing .lock.yml workflow files with actionlint only",
-		Long: `Lint existing .lock.yml workflow files from disk using actionlint only.
+		Short: "Lint agentic workflows with gh-aw rules and existing .lock.yml files with actionlint",
+		Long: `Lint agentic workflows using gh-aw's workflow rules and existing .lock.yml files using actionlint.
+
+Workflow rules run against the parsed Markdown source of each lock file and report
+findings such as write permissions in the agent job, web-fetch without default network
+access, create-issue without an explicit max, and unpinned actions in custom steps.
+Use --disable-rule to skip individual rules.
+
+With --format json or --format sarif, only workflow rule findings are reported (on stdout)
+and actionlint is not run. SARIF output can be uploaded to GitHub code scanning.
 
 This command does not recompile Markdown workflows and does not run zizmor or poutine.
 By default, shellcheck and pyflakes integrations are disabled for generated run scripts.`,
 		Example: `  ` + string(constants.CLIExtensionPrefix) + ` lint                                  # Lint all .lock.yml workflows in the default directory
   ` + string(constants.CLIExtensionPrefix) + ` lint .github/workflows/foo.lock.yml    # Lint a specific lock file
   ` + string(constants.CLIExtensionPrefix) + ` lint --dir custom-workflows/            # Lint all lock files in a custom directory
-  ` + string(constants.CLIExtensionPrefix) + ` lint --shellcheck --pyflakes            # Enable actionlint script integrations`,
+  ` + string(constants.CLIExtensionPrefix) + ` lint --shellcheck --pyflakes            # Enable actionlint script integrations
+  ` + string(constants.CLIExtensionPrefix) + ` lint --format sarif > lint.sarif        # Write workflow rule findings as SARIF
+  ` + string(constants.CLIExtensionPrefix) + ` lint --disable-rule create-issue-max    # Skip a workflow rule`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			workflowDir, _ := cmd.Flags().GetString("dir")
 			includeShellcheck, _ := cmd.Flags().GetBool("shellcheck")
 			includePyflakes, _ := cmd.Flags().GetBool("pyflakes")
 			verbose, _ := cmd.Flags().GetBool("verbose")
+			format, _ := cmd.Flags().GetString("format")
+			disabledRules, _ := cmd.Flags().GetStringSlice("disable-rule")
 			effectiveWorkflowDir := workflowDir
 			if effectiveWorkflowDir == "" && len(args) == 0 {
 				effectiveWorkflowDir = constants.GetWorkflowDir()
@@ -63,12 +77,9 @@ By default, shellcheck and pyflakes integrations are disabled for generated run
 
 			lintCommandLog.Printf("Resolved %d lock file(s) for linting", len(lockFiles))
 
-			initActionlintStats()
-			defer displayActionlintSummary()
-
-			// Lint should fail on any actionlint error.
-			strictActionlint := true
-			return runActionlintOnFilesWithOptions(cmd.Context(), lockFiles, verbose, strictActionlint, actionlintRunOptions{
+			ruleSet := workflow.DefaultLintRuleSet()
+			ruleSet.Disable(disabledRules...)
+			return runLint(cmd.Context(), lockFiles, ruleSet, format, verbose, actionlintRunOptions{
 				IncludeShellcheck: includeShellcheck,
 				IncludePyflakes:   includePyflakes,
 				IgnorePatterns:    defaultGhAwActionlintIgnorePatterns,
@@ -79,12 +90,61 @@ By default, shellcheck and pyflakes integrations are disabled for generated run
 	cmd.Flags().StringP("dir", "d", "", "Workflow directory (default: $GH_AW_WORKFLOWS_DIR or .github/workflows)")
 	cmd.Flags().Bool("shellcheck", false, "Enable shellcheck integration in actionlint")
 	cmd.Flags().Bool("pyflakes", false, "Enable pyflakes integration in actionlint")
+	cmd.Flags().String("format", lintFormatText, "Output format: text, json, sarif (json and sarif report workflow rule findings only)")
+	cmd.Flags().StringSlice("disable-rule", nil, "Workflow rule IDs to skip (e.g. "+workflow.LintRuleCreateIssueMax+")")
 
 	RegisterDirFlagCompletion(cmd, "dir")
 
 	return cmd
 }
 
+// runLint evaluates workflow rules against the Markdown sources of the lock files
+// and, for text output, runs actionlint on the lock files.
+func runLint(ctx context.Context, lockFiles []string, ruleSet *workflow.LintRuleSet, format string, verbose bool, opts actionlintRunOptions) error {
+	switch format {
+	case lintFormatText, lintFormatJSON, lintFormatSARIF:
+	default:
+		return fmt.Errorf("invalid --format %q: must be one of %s, %s, %s", format, lintFormatText, lintFormatJSON, lintFormatSARIF)
+	}
+
+	results := lintWorkflowSources(ctx, resolveWorkflowSourcesForLint(lockFiles), ruleSet, verbose)
+	ruleErrors := countLintErrors(results)
+	lintCommandLog.Printf("Workflow rules: %d workflow(s) checked, %d error(s)", len(results), ruleErrors)
+
+	if format != lintFormatText {
+		var output string
+		var err error
+		if format == lintFormatJSON {
+			output, err = formatLintResultsJSON(results)
+		} else {
+			output, err = formatLintResultsSARIF(results, ruleSet.Rules())
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Println(output)
+		if ruleErrors > 0 {
+			return fmt.Errorf("workflow lint found %d error(s)", ruleErrors)
+		}
+		return nil
+	}
+
+	printLintResultsText(results)
+
+	initActionlintStats()
+	defer displayActionlintSummary()
+
+	// Lint should fail on any actionlint error.
+	strictActionlint := true
+	if err := runActionlintOnFilesWithOptions(ctx, lockFiles, verbose, strictActionlint, opts); err != nil {
+		return err
+	}
+	if ruleErrors > 0 {
+		return fmt.Errorf("workflow lint found %d error(s)", ruleErrors)
+	}
+	return nil
+}
+
 func resolveLockFilesForLint(inputs []string, workflowDir string) ([]string, error) {
 	if workflowDir == "" {
 		workflowDir = constants.GetWorkflowDir()
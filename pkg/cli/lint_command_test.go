@@ -25,6 +25,9 @@ func TestNewLintCommand(t *testing.T) {
 	)
 	require.NotNil(t, cmd.Flags().Lookup("shellcheck"), "lint command should have a --shellcheck flag")
 	require.NotNil(t, cmd.Flags().Lookup("pyflakes"), "lint command should have a --pyflakes flag")
+	require.NotNil(t, cmd.Flags().Lookup("format"), "lint command should have a --format flag")
+	assert.Equal(t, "text", cmd.Flags().Lookup("format").DefValue, "--format should default to text")
+	require.NotNil(t, cmd.Flags().Lookup("disable-rule"), "lint command should have a --disable-rule flag")
 	assert.Contains(t, defaultGhAwActionlintIgnorePatterns, `unknown permission scope "copilot-requests"`,
 		"lint command should include built-in ignore for gh-aw permission extension")
 	assert.Contains(t, defaultGhAwActionlintIgnorePatterns, `unknown permission scope "vulnerability-alerts"`,
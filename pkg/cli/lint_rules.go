@@ -0,0 +1,265 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/workflow"
+)
+
+var lintRulesLog = logger.New("cli:lint_rules")
+
+// Output formats supported by the lint command.
+const (
+	lintFormatText  = "text"
+	lintFormatJSON  = "json"
+	lintFormatSARIF = "sarif"
+)
+
+// WorkflowLintResult holds the rule findings for a single workflow source file.
+type WorkflowLintResult struct {
+	Workflow string                 `json:"workflow"`
+	File     string                 `json:"file"`
+	Findings []workflow.LintFinding `json:"findings"`
+	Error    string                 `json:"error,omitempty"` // set when the workflow could not be parsed
+}
+
+// resolveWorkflowSourcesForLint maps lock files to their Markdown sources.
+// Lock files without a sibling .md source (e.g. removed workflows) are skipped.
+func resolveWorkflowSourcesForLint(lockFiles []string) []string {
+	var sources []string
+	for _, lockFile := range lockFiles {
+		source := strings.TrimSuffix(lockFile, ".lock.yml") + ".md"
+		if _, err := os.Stat(source); err != nil {
+			lintRulesLog.Printf("No Markdown source for %s, skipping rule checks", lockFile)
+			continue
+		}
+		sources = append(sources, source)
+	}
+	return sources
+}
+
+// lintWorkflowSources parses each Markdown workflow and evaluates the rule set against it.
+func lintWorkflowSources(ctx context.Context, sources []string, ruleSet *workflow.LintRuleSet, verbose bool) []WorkflowLintResult {
+	compiler := workflow.NewCompiler(workflow.WithVerbose(verbose))
+	compiler.SetContext(ctx)
+
+	results := make([]WorkflowLintResult, 0, len(sources))
+	for _, source := range sources {
+		result := WorkflowLintResult{
+			Workflow: strings.TrimSuffix(filepath.Base(source), ".md"),
+			File:     console.ToRelativePath(source),
+			Findings: []workflow.LintFinding{},
+		}
+		// Set workflow identifier so fuzzy schedules can be scattered during parsing
+		relPath, err := getRepositoryRelativePath(source)
+		if err != nil {
+			relPath = filepath.Base(source)
+		}
+		compiler.SetWorkflowIdentifier(relPath)
+
+		data, err := compiler.ParseWorkflowFile(source)
+		if err != nil {
+			var sharedErr *workflow.SharedWorkflowError
+			if errors.As(err, &sharedErr) {
+				lintRulesLog.Printf("Skipping shared workflow %s", source)
+				continue
+			}
+			result.Error = err.Error()
+			results = append(results, result)
+			continue
+		}
+		if findings := ruleSet.Run(data); len(findings) > 0 {
+			result.Findings = findings
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// countLintErrors returns the number of error-severity findings and parse failures.
+func countLintErrors(results []WorkflowLintResult) int {
+	count := 0
+	for _, r := range results {
+		if r.Error != "" {
+			count++
+		}
+		for _, f := range r.Findings {
+			if f.Severity == workflow.LintSeverityError {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// printLintResultsText writes findings to stderr in the IDE-parseable console format.
+func printLintResultsText(results []WorkflowLintResult) {
+	for _, r := range results {
+		if r.Error != "" {
+			fmt.Fprint(os.Stderr, console.FormatError(console.CompilerError{
+				Position: console.ErrorPosition{File: r.File},
+				Type:     "error",
+				Message:  "failed to parse workflow: " + r.Error,
+			}))
+		}
+		for _, f := range r.Findings {
+			fmt.Fprint(os.Stderr, console.FormatError(console.CompilerError{
+				Position: console.ErrorPosition{File: r.File, Line: f.Line, Column: max(f.Column, 1)},
+				Type:     string(f.Severity),
+				Message:  fmt.Sprintf("[%s] %s", f.RuleID, f.Message),
+			}))
+		}
+	}
+}
+
+// formatLintResultsJSON renders lint results as a JSON array.
+func formatLintResultsJSON(results []WorkflowLintResult) (string, error) {
+	jsonBytes, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	return string(jsonBytes), nil
+}
+
+// SARIF 2.1.0 types (only the subset needed for lint results).
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri,omitempty"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+// lintParseErrorRuleID identifies SARIF results for workflows that failed to parse.
+const lintParseErrorRuleID = "parse-error"
+
+// sarifLevel maps a lint severity to a SARIF result level.
+func sarifLevel(severity workflow.LintSeverity) string {
+	switch severity {
+	case workflow.LintSeverityError:
+		return "error"
+	case workflow.LintSeverityWarning:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// formatLintResultsSARIF renders lint results as a SARIF 2.1.0 log suitable
+// for upload to GitHub code scanning.
+func formatLintResultsSARIF(results []WorkflowLintResult, rules []workflow.LintRule) (string, error) {
+	driver := sarifDriver{
+		Name:           "gh-aw-lint",
+		Version:        GetVersion(),
+		InformationURI: "https://github.com/github/gh-aw",
+		Rules: []sarifRule{{
+			ID:                   lintParseErrorRuleID,
+			ShortDescription:     sarifMessage{Text: "The workflow could not be parsed"},
+			DefaultConfiguration: sarifConfiguration{Level: "error"},
+		}},
+	}
+	for _, rule := range rules {
+		driver.Rules = append(driver.Rules, sarifRule{
+			ID:                   rule.ID,
+			ShortDescription:     sarifMessage{Text: rule.Description},
+			DefaultConfiguration: sarifConfiguration{Level: sarifLevel(rule.Severity)},
+		})
+	}
+
+	sarifResults := []sarifResult{}
+	for _, r := range results {
+		uri := filepath.ToSlash(r.File)
+		if r.Error != "" {
+			sarifResults = append(sarifResults, sarifResult{
+				RuleID:    lintParseErrorRuleID,
+				Level:     "error",
+				Message:   sarifMessage{Text: r.Error},
+				Locations: []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: uri}}}},
+			})
+		}
+		for _, f := range r.Findings {
+			location := sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: uri}}
+			if f.Line > 0 {
+				location.Region = &sarifRegion{StartLine: f.Line, StartColumn: f.Column}
+			}
+			sarifResults = append(sarifResults, sarifResult{
+				RuleID:    f.RuleID,
+				Level:     sarifLevel(f.Severity),
+				Message:   sarifMessage{Text: f.Message},
+				Locations: []sarifLocation{{PhysicalLocation: location}},
+			})
+		}
+	}
+
+	log := sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{{Tool: sarifTool{Driver: driver}, Results: sarifResults}},
+	}
+	jsonBytes, err := json.MarshalIndent(log, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal SARIF: %w", err)
+	}
+	return string(jsonBytes), nil
+}
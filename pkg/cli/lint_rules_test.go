@@ -0,0 +1,131 @@
+//go:build !integration
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveWorkflowSourcesForLint(t *testing.T) {
+	tempDir := t.TempDir()
+	lockA := filepath.Join(tempDir, "a.lock.yml")
+	lockB := filepath.Join(tempDir, "b.lock.yml")
+	sourceA := filepath.Join(tempDir, "a.md")
+
+	require.NoError(t, os.WriteFile(lockA, []byte("name: a"), 0o644), "should create lock file a")
+	require.NoError(t, os.WriteFile(lockB, []byte("name: b"), 0o644), "should create lock file b")
+	require.NoError(t, os.WriteFile(sourceA, []byte("---\non: push\n---\n"), 0o644), "should create source a")
+
+	sources := resolveWorkflowSourcesForLint([]string{lockA, lockB})
+	assert.Equal(t, []string{sourceA}, sources, "only lock files with a Markdown source should be returned")
+}
+
+func TestLintWorkflowSources(t *testing.T) {
+	tempDir := t.TempDir()
+	source := filepath.Join(tempDir, "triage.md")
+	content := `---
+on:
+  issues:
+    types: [opened]
+permissions:
+  contents: read
+engine: copilot
+safe-outputs:
+  create-issue:
+---
+
+# Triage
+
+Triage the issue.
+`
+	require.NoError(t, os.WriteFile(source, []byte(content), 0o644), "should write workflow")
+
+	results := lintWorkflowSources(context.Background(), []string{source}, workflow.DefaultLintRuleSet(), false)
+	require.Len(t, results, 1, "should lint one workflow")
+	assert.Empty(t, results[0].Error, "workflow should parse")
+	assert.Equal(t, "triage", results[0].Workflow, "workflow name should be derived from the file name")
+	require.Len(t, results[0].Findings, 1, "should report one finding")
+	assert.Equal(t, workflow.LintRuleCreateIssueMax, results[0].Findings[0].RuleID, "create-issue without max should be reported")
+	assert.Equal(t, 8, results[0].Findings[0].Line, "finding should point at the safe-outputs key")
+	assert.Zero(t, countLintErrors(results), "warnings should not count as errors")
+}
+
+func TestCountLintErrors(t *testing.T) {
+	results := []WorkflowLintResult{
+		{Findings: []workflow.LintFinding{{Severity: workflow.LintSeverityError}, {Severity: workflow.LintSeverityWarning}}},
+		{Error: "parse failed"},
+	}
+	assert.Equal(t, 2, countLintErrors(results), "error findings and parse failures should be counted")
+}
+
+func TestFormatLintResultsJSON(t *testing.T) {
+	results := []WorkflowLintResult{{
+		Workflow: "triage",
+		File:     ".github/workflows/triage.md",
+		Findings: []workflow.LintFinding{{RuleID: "create-issue-max", Severity: workflow.LintSeverityWarning, Message: "no max", Field: "safe-outputs", Line: 9}},
+	}}
+
+	output, err := formatLintResultsJSON(results)
+	require.NoError(t, err, "JSON formatting should succeed")
+
+	var decoded []map[string]any
+	require.NoError(t, json.Unmarshal([]byte(output), &decoded), "output should be valid JSON")
+	require.Len(t, decoded, 1, "should contain one workflow")
+	findings := decoded[0]["findings"].([]any)
+	finding := findings[0].(map[string]any)
+	assert.Equal(t, "create-issue-max", finding["rule"], "rule ID should be serialized")
+	assert.Equal(t, "warning", finding["severity"], "severity should be serialized")
+	assert.InDelta(t, 9, finding["line"], 0, "line should be serialized")
+}
+
+func TestFormatLintResultsSARIF(t *testing.T) {
+	rules := workflow.DefaultLintRuleSet().Rules()
+	results := []WorkflowLintResult{
+		{
+			Workflow: "triage",
+			File:     ".github/workflows/triage.md",
+			Findings: []workflow.LintFinding{
+				{RuleID: workflow.LintRuleWritePermissions, Severity: workflow.LintSeverityError, Message: "write", Line: 5},
+				{RuleID: "custom", Severity: workflow.LintSeverityInfo, Message: "note"},
+			},
+		},
+		{Workflow: "broken", File: ".github/workflows/broken.md", Error: "bad frontmatter"},
+	}
+
+	output, err := formatLintResultsSARIF(results, rules)
+	require.NoError(t, err, "SARIF formatting should succeed")
+
+	var log sarifLog
+	require.NoError(t, json.Unmarshal([]byte(output), &log), "output should be valid SARIF JSON")
+	assert.Equal(t, "2.1.0", log.Version, "SARIF version should be 2.1.0")
+	require.Len(t, log.Runs, 1, "should contain one run")
+
+	run := log.Runs[0]
+	assert.Len(t, run.Tool.Driver.Rules, len(rules)+1, "driver should describe every rule plus parse errors")
+	require.Len(t, run.Results, 3, "should contain every finding and parse error")
+
+	assert.Equal(t, workflow.LintRuleWritePermissions, run.Results[0].RuleID, "rule ID should be preserved")
+	assert.Equal(t, "error", run.Results[0].Level, "error severity should map to SARIF error")
+	require.NotNil(t, run.Results[0].Locations[0].PhysicalLocation.Region, "line should produce a region")
+	assert.Equal(t, 5, run.Results[0].Locations[0].PhysicalLocation.Region.StartLine, "region should use the finding line")
+	assert.Equal(t, ".github/workflows/triage.md", run.Results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI, "artifact URI should be the workflow file")
+
+	assert.Equal(t, "note", run.Results[1].Level, "info severity should map to SARIF note")
+	assert.Nil(t, run.Results[1].Locations[0].PhysicalLocation.Region, "unknown line should omit the region")
+
+	assert.Equal(t, lintParseErrorRuleID, run.Results[2].RuleID, "parse failures should use the parse-error rule")
+}
+
+func TestRunLintInvalidFormat(t *testing.T) {
+	err := runLint(context.Background(), nil, workflow.DefaultLintRuleSet(), "xml", false, actionlintRunOptions{})
+	require.Error(t, err, "unknown format should be rejected")
+	assert.Contains(t, err.Error(), "invalid --format", "error should name the flag")
+}
@@ -84,7 +84,7 @@ func fetchJobDetailsWithCounts(ctx context.Context, runID int64, verbose bool) (
 
 	output, err := workflow.RunGHCombinedContext(ctx, "Fetching job details...", "api",
 		fmt.Sprintf("repos/{owner}/{repo}/actions/runs/%d/jobs", runID),
-		"--jq", ".jobs[] | {name: .name, status: .status, conclusion: (.conclusion // \"\"), started_at: .started_at, completed_at: .completed_at, steps: ((.steps // []) | map({name: .name, status: .status, conclusion: (.conclusion // \"\")}))}")
+		"--jq", ".jobs[] | {name: .name, status: .status, conclusion: (.conclusion // \"\"), created_at: .created_at, started_at: .started_at, completed_at: .completed_at, steps: ((.steps // []) | map({name: .name, status: .status, conclusion: (.conclusion // \"\"), started_at: .started_at, completed_at: .completed_at}))}")
 	if err != nil {
 		if verbose {
 			fmt.Fprintln(os.Stderr, console.FormatVerboseMessage(fmt.Sprintf("Failed to fetch job details for run %d: %v", runID, err)))
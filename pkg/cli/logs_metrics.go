@@ -154,36 +154,43 @@ func extractLogMetrics(logDir string, verbose bool, workflowPath ...string) (Log
 		}
 	}
 
-	// Try events.jsonl first – it provides a precise, structured event list from the Copilot CLI
-	// session state and is the most reliable source for tool calls, turns, and usage metrics.
-	// Fall back to walking .log files if events.jsonl is not present or cannot be parsed.
+	// Try agent_turns.jsonl first – the log parser step writes it in an engine-neutral format
+	// from its parse of the engine output, so it reflects the parser that ran with the workflow
+	// rather than the locally installed one. Then try events.jsonl, which provides a precise, structured event list from the
+	// Copilot CLI session state. Fall back to walking .log files if neither can be parsed.
 	var err error
-	eventsJSONLParsed := false
-	if eventsJSONLPath := findEventsJSONLFile(logDir); eventsJSONLPath != "" {
-		if verbose {
-			fileInfo, statErr := os.Stat(eventsJSONLPath)
-			if statErr == nil {
-				fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Found events.jsonl (%s), using as primary metrics source", console.FormatFileSize(fileInfo.Size()))))
-			} else {
-				fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Found events.jsonl, using as primary metrics source"))
-			}
-		}
-		eventsMetrics, eventsErr := parseEventsJSONLMetrics(eventsJSONLPath, verbose)
-		if eventsErr == nil {
-			metrics = eventsMetrics
-			eventsJSONLParsed = true
-			logsMetricsLog.Printf("events.jsonl parsed: turns=%d tokens=%d toolCalls=%d",
-				metrics.Turns, metrics.TokenUsage, len(metrics.ToolCalls))
-		} else {
-			logsMetricsLog.Printf("Failed to parse events.jsonl, falling back to log files: %v", eventsErr)
+	structuredMetricsParsed := false
+	if turnsMetrics, ok := parseAgentTurnsFile(logDir, verbose); ok {
+		metrics = turnsMetrics
+		structuredMetricsParsed = true
+	}
+	if !structuredMetricsParsed {
+		if eventsJSONLPath := findEventsJSONLFile(logDir); eventsJSONLPath != "" {
 			if verbose {
-				fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Failed to parse events.jsonl: %v", eventsErr)))
+				fileInfo, statErr := os.Stat(eventsJSONLPath)
+				if statErr == nil {
+					fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Found events.jsonl (%s), using as primary metrics source", console.FormatFileSize(fileInfo.Size()))))
+				} else {
+					fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Found events.jsonl, using as primary metrics source"))
+				}
+			}
+			eventsMetrics, eventsErr := parseEventsJSONLMetrics(eventsJSONLPath, verbose)
+			if eventsErr == nil {
+				metrics = eventsMetrics
+				structuredMetricsParsed = true
+				logsMetricsLog.Printf("events.jsonl parsed: turns=%d tokens=%d toolCalls=%d",
+					metrics.Turns, metrics.TokenUsage, len(metrics.ToolCalls))
+			} else {
+				logsMetricsLog.Printf("Failed to parse events.jsonl, falling back to log files: %v", eventsErr)
+				if verbose {
+					fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Failed to parse events.jsonl: %v", eventsErr)))
+				}
 			}
 		}
 	}
 
-	// Walk through all .log files when events.jsonl was not available or failed to parse
-	if !eventsJSONLParsed {
+	// Walk through all .log files when no structured metrics source was available or parsed
+	if !structuredMetricsParsed {
 		err = filepath.Walk(logDir, func(path string, info os.FileInfo, err error) error {
 			if err != nil {
 				return err
@@ -227,6 +234,7 @@ func extractLogMetrics(logDir string, verbose bool, workflowPath ...string) (Log
 				// Aggregate tool sequences and tool calls
 				metrics.ToolSequences = append(metrics.ToolSequences, fileMetrics.ToolSequences...)
 				metrics.ToolCalls = append(metrics.ToolCalls, fileMetrics.ToolCalls...)
+				metrics.TurnTokens = append(metrics.TurnTokens, fileMetrics.TurnTokens...)
 			}
 
 			return nil
@@ -804,3 +812,32 @@ func processMCPFailureEntry(entry map[string]any, run WorkflowRun, verbose bool,
 		}
 	}
 }
+
+// parseAgentTurnsFile parses the agent_turns.jsonl file written by the log parser step.
+// Returns false when the file is missing or cannot be parsed, so the caller can fall back
+// to the engine-specific log sources.
+func parseAgentTurnsFile(logDir string, verbose bool) (LogMetrics, bool) {
+	turnsPath := findFileInDir(logDir, constants.AgentTurnsFilename)
+	if turnsPath == "" {
+		return LogMetrics{}, false
+	}
+	content, err := os.ReadFile(turnsPath)
+	if err != nil {
+		logsMetricsLog.Printf("Failed to read %s: %v", turnsPath, err)
+		return LogMetrics{}, false
+	}
+	metrics, err := workflow.ParseAgentTurnsMetrics(string(content))
+	if err != nil {
+		logsMetricsLog.Printf("Failed to parse %s, falling back to engine logs: %v", turnsPath, err)
+		if verbose {
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Failed to parse %s: %v", constants.AgentTurnsFilename, err)))
+		}
+		return LogMetrics{}, false
+	}
+	if verbose {
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Found %s, using as primary metrics source", constants.AgentTurnsFilename)))
+	}
+	logsMetricsLog.Printf("%s parsed: turns=%d tokens=%d toolCalls=%d",
+		constants.AgentTurnsFilename, metrics.Turns, metrics.TokenUsage, len(metrics.ToolCalls))
+	return metrics, true
+}
@@ -62,6 +62,7 @@ type WorkflowRun struct {
 	Conclusion          string    `json:"conclusion"`
 	WorkflowName        string    `json:"workflowName"`
 	WorkflowPath        string    `json:"workflowPath"` // Workflow file path (e.g., .github/workflows/copilot-swe-agent.yml)
+	WorkflowDatabaseID  int64     `json:"workflowDatabaseId,omitempty"`
 	CreatedAt           time.Time `json:"createdAt"`
 	StartedAt           time.Time `json:"startedAt"`
 	UpdatedAt           time.Time `json:"updatedAt"`
@@ -231,6 +232,7 @@ type RunSummary struct {
 	MCPToolUsage            *MCPToolUsageData        `json:"mcp_tool_usage,omitempty"`          // MCP tool usage data
 	TokenUsage              *TokenUsageSummary       `json:"token_usage_summary,omitempty"`     // Token usage from firewall proxy
 	GitHubRateLimitUsage    *GitHubRateLimitUsage    `json:"github_rate_limit_usage,omitempty"` // GitHub API quota consumption
+	RunnerEnvironment       *RunnerEnvironment       `json:"runner_environment,omitempty"`      // Runner metadata from aw_info.json
 	ArtifactsList           []string                 `json:"artifacts_list"`                    // List of downloaded artifact files
 	JobDetails              []JobInfoWithDuration    `json:"job_details"`                       // Job execution details
 }
@@ -265,6 +267,7 @@ type JobInfo struct {
 	Name        string    `json:"name"`
 	Status      string    `json:"status"`
 	Conclusion  string    `json:"conclusion"`
+	CreatedAt   time.Time `json:"created_at,omitzero"` // when the job was queued
 	StartedAt   time.Time `json:"started_at,omitzero"`
 	CompletedAt time.Time `json:"completed_at,omitzero"`
 	Steps       []JobStep `json:"steps,omitempty"`
@@ -272,9 +275,11 @@ type JobInfo struct {
 
 // JobStep represents basic information about an individual workflow job step.
 type JobStep struct {
-	Name       string `json:"name"`
-	Status     string `json:"status,omitempty"`
-	Conclusion string `json:"conclusion,omitempty"`
+	Name        string    `json:"name"`
+	Status      string    `json:"status,omitempty"`
+	Conclusion  string    `json:"conclusion,omitempty"`
+	StartedAt   time.Time `json:"started_at,omitzero"`
+	CompletedAt time.Time `json:"completed_at,omitzero"`
 }
 
 // JobInfoWithDuration extends JobInfo with calculated duration
@@ -319,6 +324,8 @@ type AwInfo struct {
 	CreatedAt       string              `json:"created_at"`
 	Context         *AwContext          `json:"context,omitempty"`       // aw_context data passed via workflow_dispatch inputs
 	TokenWeights    *types.TokenWeights `json:"token_weights,omitempty"` // Historical/custom model cost data stored in aw_info.json
+	// RunnerEnvironment is added by the agent job (runner image, tool cache, engine CLI version)
+	RunnerEnvironment *RunnerEnvironment `json:"runner_environment,omitempty"`
 	// Additional fields that might be present
 	RunID      any    `json:"run_id,omitempty"`
 	RunNumber  any    `json:"run_number,omitempty"`
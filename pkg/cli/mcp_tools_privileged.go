@@ -544,6 +544,7 @@ then produces a diff showing:
 - Anomaly flags (new denied domains, previously-denied now allowed)
 - MCP tool invocation changes (new/removed tools, call/error count diffs)
 - Run metrics comparison (token usage, duration, turns)
+- Runner environment changes (runner image, tool cache, engine CLI versions)
 
 Returns JSON describing the differences between the base run and each comparison run.`,
 		InputSchema: schema,
@@ -0,0 +1,288 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/semverutil"
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/tty"
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/spf13/cobra"
+)
+
+var migrateLog = logger.New("cli:migrate_command")
+
+// MigrateConfig contains configuration for the migrate command
+type MigrateConfig struct {
+	WorkflowIDs        []string
+	WorkflowDir        string   // Custom workflow directory
+	FromVersion        string   // Overrides the compiler version detected from each lock file
+	DryRun             bool     // Report the migrations without writing files or compiling
+	NoCompile          bool     // Skip recompiling migrated workflows
+	JSON               bool     // Print the summary as JSON to stdout
+	Verbose            bool     // Enable verbose output
+	DisabledCodemodIDs []string // Codemod IDs to skip
+}
+
+// MigrationSummary is the result of a migrate run.
+type MigrationSummary struct {
+	ToVersion string              `json:"to_version"`
+	DryRun    bool                `json:"dry_run,omitempty"`
+	Workflows []WorkflowMigration `json:"workflows"`
+}
+
+// WorkflowMigration describes what migrate did to a single workflow.
+type WorkflowMigration struct {
+	Workflow    string   `json:"workflow"`
+	FromVersion string   `json:"from_version,omitempty"` // empty when the lock file does not record a compiler version
+	Codemods    []string `json:"codemods,omitempty"`     // names of the codemods that changed the workflow
+	Compiled    bool     `json:"compiled"`
+	Error       string   `json:"error,omitempty"`
+	guided      bool     // the error needs a manual fix
+}
+
+// NewMigrateCommand creates the migrate command
+func NewMigrateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate [workflow]...",
+		Short: "Migrate workflows compiled by an older gh-aw release to the current release",
+		Long: `Migrate agentic workflows across gh-aw releases.
+
+For each workflow, the command reads the compiler version recorded in the
+gh-aw-metadata header of its lock file and applies the codemods introduced after
+that version (renamed fields, moved settings, changed defaults) to the Markdown
+frontmatter. Migrated workflows are then recompiled and a summary is printed.
+
+When a lock file does not record a compiler version (for example, it was built by a
+development build), every codemod is considered, as with 'fix'. Use --from to set the
+version explicitly.
+
+Unlike 'fix', migrate writes changes by default; use --dry-run to preview them.
+
+If no workflows are specified, all Markdown files in .github/workflows will be processed.
+
+` + WorkflowIDExplanation,
+		Example: `  ` + string(constants.CLIExtensionPrefix) + ` migrate                    # Migrate and recompile all workflows
+  ` + string(constants.CLIExtensionPrefix) + ` migrate --dry-run          # Show which migrations would apply
+  ` + string(constants.CLIExtensionPrefix) + ` migrate my-workflow        # Migrate a specific workflow
+  ` + string(constants.CLIExtensionPrefix) + ` migrate --from v0.40.0     # Treat workflows as compiled by v0.40.0
+  ` + string(constants.CLIExtensionPrefix) + ` migrate --no-compile       # Migrate frontmatter without recompiling
+  ` + string(constants.CLIExtensionPrefix) + ` migrate --json             # Print the migration summary as JSON`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir, _ := cmd.Flags().GetString("dir")
+			from, _ := cmd.Flags().GetString("from")
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			noCompile, _ := cmd.Flags().GetBool("no-compile")
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			verbose, _ := cmd.Flags().GetBool("verbose")
+			disabledCodemods, _ := cmd.Flags().GetStringSlice("disable-codemod")
+
+			return RunMigrateWorkflows(cmd.Context(), MigrateConfig{
+				WorkflowIDs:        args,
+				WorkflowDir:        dir,
+				FromVersion:        from,
+				DryRun:             dryRun,
+				NoCompile:          noCompile,
+				JSON:               jsonOutput,
+				Verbose:            verbose,
+				DisabledCodemodIDs: disabledCodemods,
+			}, cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().String("from", "", "Compiler version to migrate from (default: the version recorded in each lock file)")
+	cmd.Flags().Bool("dry-run", false, "Show the migrations that would apply without writing files or compiling")
+	cmd.Flags().Bool("no-compile", false, "Skip recompiling migrated workflows")
+	cmd.Flags().StringP("dir", "d", "", "Workflow directory (default: $GH_AW_WORKFLOWS_DIR or .github/workflows)")
+	cmd.Flags().StringSlice("disable-codemod", nil, "Disable specific codemod IDs (repeatable)")
+	addJSONFlag(cmd)
+
+	cmd.ValidArgsFunction = CompleteWorkflowNames
+	RegisterDirFlagCompletion(cmd, "dir")
+
+	return cmd
+}
+
+// RunMigrateWorkflows migrates the configured workflows and writes the summary to out.
+func RunMigrateWorkflows(ctx context.Context, config MigrateConfig, out io.Writer) error {
+	migrateLog.Printf("Running migrate command: workflowIDs=%v, from=%s, dryRun=%v, noCompile=%v", config.WorkflowIDs, config.FromVersion, config.DryRun, config.NoCompile)
+
+	if config.FromVersion != "" && !semverutil.IsValid(config.FromVersion) {
+		return fmt.Errorf("invalid --from version %q: expected a version such as v0.40.0", config.FromVersion)
+	}
+
+	files, err := resolveFixWorkflowFiles(config.WorkflowIDs, config.Verbose, config.WorkflowDir)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage("No workflow files found."))
+		return nil
+	}
+
+	codemods, err := GetCodemods(config.DisabledCodemodIDs)
+	if err != nil {
+		return err
+	}
+
+	summary := MigrationSummary{ToVersion: workflow.GetVersion(), DryRun: config.DryRun}
+	for _, file := range files {
+		summary.Workflows = append(summary.Workflows, migrateWorkflowFile(ctx, file, codemods, config))
+	}
+
+	if config.JSON {
+		encoded, err := json.MarshalIndent(summary, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal migration summary: %w", err)
+		}
+		fmt.Fprintln(out, string(encoded))
+	} else {
+		renderMigrationSummary(summary)
+	}
+
+	return migrationExitError(summary)
+}
+
+// migrateWorkflowFile applies the codemods newer than the workflow's compiler version
+// and recompiles it.
+func migrateWorkflowFile(ctx context.Context, file string, codemods []Codemod, config MigrateConfig) WorkflowMigration {
+	result := WorkflowMigration{Workflow: strings.TrimSuffix(filepath.Base(file), ".md")}
+
+	result.FromVersion = config.FromVersion
+	if result.FromVersion == "" {
+		result.FromVersion = lockFileCompilerVersion(stringutil.MarkdownToLockFile(file))
+	}
+	selected := selectMigrationCodemods(codemods, result.FromVersion)
+	migrateLog.Printf("Migrating %s from version %q: %d of %d codemods selected", file, result.FromVersion, len(selected), len(codemods))
+
+	_, applied, err := processWorkflowFileWithInfo(file, selected, !config.DryRun, config.Verbose)
+	if err != nil {
+		var guidedErr *GuidedError
+		result.guided = errors.As(err, &guidedErr)
+		result.Error = err.Error()
+		return result
+	}
+	result.Codemods = applied
+
+	if config.DryRun || config.NoCompile {
+		return result
+	}
+	if err := compileWorkflowWithRefresh(ctx, file, config.Verbose, true, "", false, false); err != nil {
+		migrateLog.Printf("Failed to compile %s: %v", file, err)
+		result.Error = err.Error()
+		return result
+	}
+	result.Compiled = true
+	return result
+}
+
+// lockFileCompilerVersion returns the compiler version recorded in a lock file's
+// gh-aw-metadata header, or an empty string when the lock file is missing or the
+// version is not a release version.
+func lockFileCompilerVersion(lockFile string) string {
+	content, err := os.ReadFile(lockFile)
+	if err != nil {
+		migrateLog.Printf("No lock file at %s: %v", lockFile, err)
+		return ""
+	}
+	version := semverutil.NormalizeGitDescribeSemver(extractCompilerVersionFromLockContent(string(content)))
+	if !semverutil.IsValid(version) {
+		return ""
+	}
+	return version
+}
+
+// selectMigrationCodemods returns the codemods introduced after fromVersion, in registry
+// order. All codemods are returned when fromVersion is empty, and codemods without an
+// IntroducedIn version are always included.
+func selectMigrationCodemods(codemods []Codemod, fromVersion string) []Codemod {
+	if fromVersion == "" {
+		return codemods
+	}
+	selected := make([]Codemod, 0, len(codemods))
+	for _, codemod := range codemods {
+		if codemod.IntroducedIn == "" || semverutil.Compare(codemod.IntroducedIn, fromVersion) > 0 {
+			selected = append(selected, codemod)
+		}
+	}
+	return selected
+}
+
+// renderMigrationSummary prints the migration summary table to stderr.
+func renderMigrationSummary(summary MigrationSummary) {
+	rows := make([][]string, 0, len(summary.Workflows))
+	for _, wf := range summary.Workflows {
+		from := wf.FromVersion
+		if from == "" {
+			from = "unknown"
+		}
+		changes := strings.Join(wf.Codemods, ", ")
+		if changes == "" {
+			changes = "-"
+		}
+		status := "migrated"
+		switch {
+		case wf.Error != "":
+			status = "failed"
+		case summary.DryRun:
+			status = "dry run"
+		case wf.Compiled:
+			status = "compiled"
+		}
+		rows = append(rows, []string{wf.Workflow, from, changes, status})
+	}
+
+	fmt.Fprintln(os.Stderr, "")
+	fmt.Fprint(os.Stderr, console.RenderTable(console.TableConfig{
+		Title:   "Migration to " + summary.ToVersion,
+		Headers: []string{"Workflow", "From", "Changes", "Status"},
+		Rows:    rows,
+		TTYFunc: tty.IsStderrTerminal,
+	}))
+	for _, wf := range summary.Workflows {
+		if wf.Error != "" {
+			fmt.Fprintln(os.Stderr, console.FormatErrorMessage(fmt.Sprintf("%s: %s", wf.Workflow, wf.Error)))
+		}
+	}
+	if summary.DryRun && summary.migratedCount() > 0 {
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Would migrate %d of %d workflow(s). Run without --dry-run to apply.", summary.migratedCount(), len(summary.Workflows))))
+	}
+}
+
+func (s MigrationSummary) migratedCount() int {
+	count := 0
+	for _, wf := range s.Workflows {
+		if len(wf.Codemods) > 0 {
+			count++
+		}
+	}
+	return count
+}
+
+// migrationExitError mirrors the fix command's exit codes: 2 when a workflow needs a
+// manual fix, 1 when a workflow could not be migrated or compiled.
+func migrationExitError(summary MigrationSummary) error {
+	exitCode := 0
+	for _, wf := range summary.Workflows {
+		if wf.guided {
+			return &ExitCodeError{Code: 2}
+		}
+		if wf.Error != "" {
+			exitCode = 1
+		}
+	}
+	if exitCode != 0 {
+		return &ExitCodeError{Code: exitCode}
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+//go:build !integration
+
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectMigrationCodemods(t *testing.T) {
+	codemods := []Codemod{
+		{ID: "old", IntroducedIn: "0.1.0"},
+		{ID: "unversioned"},
+		{ID: "same", IntroducedIn: "0.17.0"},
+		{ID: "new", IntroducedIn: "1.0.76"},
+	}
+	ids := func(selected []Codemod) []string {
+		var result []string
+		for _, codemod := range selected {
+			result = append(result, codemod.ID)
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{"old", "unversioned", "same", "new"}, ids(selectMigrationCodemods(codemods, "")), "unknown version should select every codemod")
+	assert.Equal(t, []string{"unversioned", "new"}, ids(selectMigrationCodemods(codemods, "v0.17.0")), "only codemods introduced after the version should be selected")
+	assert.Equal(t, []string{"unversioned"}, ids(selectMigrationCodemods(codemods, "v2.0.0")), "unversioned codemods should always be selected")
+}
+
+func TestLockFileCompilerVersion(t *testing.T) {
+	tmpDir := t.TempDir()
+	release := filepath.Join(tmpDir, "release.lock.yml")
+	require.NoError(t, os.WriteFile(release, []byte("# gh-aw-metadata: {\"schema_version\":\"v4\",\"compiler_version\":\"v0.10.0\"}\nname: test\n"), 0644), "Failed to write lock file")
+	dev := filepath.Join(tmpDir, "dev.lock.yml")
+	require.NoError(t, os.WriteFile(dev, []byte("# gh-aw-metadata: {\"schema_version\":\"v4\"}\nname: test\n"), 0644), "Failed to write lock file")
+
+	assert.Equal(t, "v0.10.0", lockFileCompilerVersion(release), "release version should be read from the metadata header")
+	assert.Empty(t, lockFileCompilerVersion(dev), "development builds do not record a version")
+	assert.Empty(t, lockFileCompilerVersion(filepath.Join(tmpDir, "missing.lock.yml")), "missing lock file should have no version")
+}
+
+func TestRunMigrateWorkflows_AppliesCodemodsNewerThanLockVersion(t *testing.T) {
+	tmpDir := t.TempDir()
+	workflowFile := filepath.Join(tmpDir, "triage.md")
+	content := `---
+on: workflow_dispatch
+timeout_minutes: 15
+engine:
+  id: copilot
+  max-runs: 5
+---
+
+# Triage
+`
+	require.NoError(t, os.WriteFile(workflowFile, []byte(content), 0644), "Failed to write workflow")
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "triage.lock.yml"), []byte("# gh-aw-metadata: {\"schema_version\":\"v4\",\"compiler_version\":\"v0.10.0\"}\nname: triage\n"), 0644), "Failed to write lock file")
+
+	var out bytes.Buffer
+	err := RunMigrateWorkflows(context.Background(), MigrateConfig{WorkflowDir: tmpDir, NoCompile: true, JSON: true}, &out)
+	require.NoError(t, err, "migration should succeed")
+
+	var summary MigrationSummary
+	require.NoError(t, json.Unmarshal(out.Bytes(), &summary), "summary should be valid JSON")
+	require.Len(t, summary.Workflows, 1, "one workflow should be reported")
+	assert.Equal(t, "triage", summary.Workflows[0].Workflow, "workflow name should be reported")
+	assert.Equal(t, "v0.10.0", summary.Workflows[0].FromVersion, "version should come from the lock file")
+	assert.Equal(t, []string{"Move engine.max-runs to top-level max-turns"}, summary.Workflows[0].Codemods, "only codemods newer than v0.10.0 should apply")
+	assert.False(t, summary.Workflows[0].Compiled, "--no-compile should skip compilation")
+
+	updated, err := os.ReadFile(workflowFile)
+	require.NoError(t, err, "Failed to read migrated workflow")
+	assert.Contains(t, string(updated), "max-turns: 5", "engine.max-runs should move to max-turns")
+	assert.Contains(t, string(updated), "timeout_minutes: 15", "codemods older than the lock version should not apply")
+}
+
+func TestRunMigrateWorkflows_DryRun(t *testing.T) {
+	tmpDir := t.TempDir()
+	workflowFile := filepath.Join(tmpDir, "triage.md")
+	content := `---
+on: workflow_dispatch
+timeout_minutes: 15
+---
+
+# Triage
+`
+	require.NoError(t, os.WriteFile(workflowFile, []byte(content), 0644), "Failed to write workflow")
+
+	var out bytes.Buffer
+	require.NoError(t, RunMigrateWorkflows(context.Background(), MigrateConfig{WorkflowDir: tmpDir, DryRun: true, JSON: true}, &out), "dry run should succeed")
+
+	var summary MigrationSummary
+	require.NoError(t, json.Unmarshal(out.Bytes(), &summary), "summary should be valid JSON")
+	require.Len(t, summary.Workflows, 1, "one workflow should be reported")
+	assert.Empty(t, summary.Workflows[0].FromVersion, "workflow without a lock file has no version")
+	assert.Contains(t, summary.Workflows[0].Codemods, "Migrate timeout_minutes to timeout-minutes", "all codemods should be considered without a version")
+
+	unchanged, err := os.ReadFile(workflowFile)
+	require.NoError(t, err, "Failed to read workflow")
+	assert.Equal(t, content, string(unchanged), "dry run should not write files")
+}
+
+func TestRunMigrateWorkflows_InvalidFromVersion(t *testing.T) {
+	err := RunMigrateWorkflows(context.Background(), MigrateConfig{WorkflowDir: t.TempDir(), FromVersion: "latest"}, &bytes.Buffer{})
+	require.Error(t, err, "invalid --from version should be rejected")
+	assert.Contains(t, err.Error(), "invalid --from version", "error should name the flag")
+}
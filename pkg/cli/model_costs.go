@@ -83,6 +83,9 @@ func findModelPricing(provider, model string) (map[string]float64, bool) {
 
 	normalizedProvider := modelsdev.NormalizeProvider(provider)
 	normalizedModel := strings.ToLower(strings.TrimSpace(model))
+	// Model identifiers may carry sampling parameters ("gpt-5?temperature=0.2", as
+	// recorded in aw_info.json); pricing only depends on the base model.
+	normalizedModel, _, _ = strings.Cut(normalizedModel, "?")
 	comparableModel := modelsdev.NormalizeComparableModelID(normalizedModel)
 	if normalizedModel == "" { //nolint:tolowerequalfold
 		return nil, false
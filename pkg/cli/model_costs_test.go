@@ -18,6 +18,14 @@ func TestFindModelPricing(t *testing.T) {
 	assert.InDelta(t, 0.000003, pricing["input"], 1e-12)
 }
 
+func TestFindModelPricing_IgnoresModelParams(t *testing.T) {
+	base, ok := findModelPricing("anthropic", "claude-sonnet-4.6")
+	require.True(t, ok)
+	withParams, ok := findModelPricing("anthropic", "claude-sonnet-4.6?temperature=0.2&effort=high")
+	require.True(t, ok, "model parameters recorded in aw_info.json should not prevent a pricing match")
+	assert.Equal(t, base, withParams)
+}
+
 func TestComputeModelInferenceAIC(t *testing.T) {
 	aic := computeModelInferenceAIC("anthropic", "claude-sonnet-4.6", 1000, 200, 400, 50, 25)
 	assert.InDelta(t, 0.54825, aic, 1e-9)
@@ -0,0 +1,37 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Porcelain output is a stable, line-oriented format for scripts. Each line is a
+// record of tab-separated fields whose first field names the record type.
+// Empty fields are written as "-" so that whitespace-splitting tools keep the
+// column positions. New fields are only ever appended to the end of a record.
+
+// porcelainFieldReplacer flattens characters that would break the record format.
+var porcelainFieldReplacer = strings.NewReplacer("\t", " ", "\r\n", " ", "\n", " ", "\r", " ")
+
+// writePorcelainRecord writes one porcelain record to w.
+func writePorcelainRecord(w io.Writer, fields ...string) {
+	cleaned := make([]string, len(fields))
+	for i, field := range fields {
+		field = strings.TrimSpace(porcelainFieldReplacer.Replace(field))
+		if field == "" {
+			field = "-"
+		}
+		cleaned[i] = field
+	}
+	fmt.Fprintln(w, strings.Join(cleaned, "\t"))
+}
+
+// porcelainInt formats a number field, writing zero as "-".
+func porcelainInt[T int | int64](n T) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.FormatInt(int64(n), 10)
+}
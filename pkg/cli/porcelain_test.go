@@ -0,0 +1,59 @@
+//go:build !integration
+
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWritePorcelainRecord(t *testing.T) {
+	var buf bytes.Buffer
+	writePorcelainRecord(&buf, "error", "a\tb", "", "line one\nline two")
+	assert.Equal(t, "error\ta b\t-\tline one line two\n", buf.String(), "fields should be flattened and empty fields written as -")
+}
+
+func TestWriteCompilePorcelain(t *testing.T) {
+	var buf bytes.Buffer
+	writeCompilePorcelain(&buf, []ValidationResult{
+		{Workflow: "ok.md", Valid: true, CompiledFile: ".github/workflows/ok.lock.yml"},
+		{
+			Workflow: "bad.md",
+			Errors:   []CompileValidationError{{Type: "schema_validation", Message: "unknown property", Line: 4}},
+			Warnings: []CompileValidationError{{Type: "deprecation", Message: "use engine.id"}},
+		},
+	})
+	assert.Equal(t, "workflow\tvalid\tok.md\t.github/workflows/ok.lock.yml\n"+
+		"workflow\tinvalid\tbad.md\t-\n"+
+		"error\tbad.md\t4\tschema_validation\tunknown property\n"+
+		"warning\tbad.md\t-\tdeprecation\tuse engine.id\n", buf.String(), "compile porcelain output")
+}
+
+func TestWriteStatusPorcelain(t *testing.T) {
+	var buf bytes.Buffer
+	writeStatusPorcelain(&buf, []WorkflowStatus{
+		{WorkflowListItem: WorkflowListItem{Workflow: "triage", EngineID: "copilot", Compiled: "Yes"}, Status: "enabled", RunID: 42, RunStatus: "completed", RunConclusion: "success"},
+		{WorkflowListItem: WorkflowListItem{Workflow: "draft", EngineID: "claude", Compiled: "No"}, Status: "disabled"},
+	})
+	assert.Equal(t, "workflow\ttriage\tcopilot\tYes\tenabled\t42\tcompleted\tsuccess\n"+
+		"workflow\tdraft\tclaude\tNo\tdisabled\t-\t-\t-\n", buf.String(), "status porcelain output")
+}
+
+func TestWriteUpdatePorcelain(t *testing.T) {
+	var buf bytes.Buffer
+	writeUpdatePorcelain(&buf, []string{"triage"}, []updateFailure{{Name: "docs", Error: "merge conflict"}})
+	assert.Equal(t, "updated\ttriage\nfailed\tdocs\tmerge conflict\n", buf.String(), "update porcelain output")
+}
+
+func TestWriteAuditPorcelain(t *testing.T) {
+	var buf bytes.Buffer
+	writeAuditPorcelain(&buf, AuditData{
+		Overview: OverviewData{RunID: 123, WorkflowName: "Triage", Status: "completed", Conclusion: "failure"},
+		Metrics:  MetricsData{ErrorCount: 1},
+		Errors:   []ErrorInfo{{File: "agent-stdio.log", Line: 12, Type: "error", Message: "tool call failed"}},
+	}, "/tmp/run-123")
+	assert.Equal(t, "run\t123\tTriage\tcompleted\tfailure\t1\t0\t/tmp/run-123\n"+
+		"error\tagent-stdio.log\t12\ttool call failed\n", buf.String(), "audit porcelain output")
+}
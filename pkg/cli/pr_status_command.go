@@ -0,0 +1,477 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/sliceutil"
+	"github.com/github/gh-aw/pkg/tty"
+	"github.com/spf13/cobra"
+)
+
+var prStatusLog = logger.New("cli:pr_status_command")
+
+// PRStatusConfig holds configuration for the pr-status command.
+type PRStatusConfig struct {
+	Repo       string
+	PRNumber   string
+	OutputDir  string
+	JSONOutput bool
+	Verbose    bool
+}
+
+// PRStatusRun is one agentic workflow run associated with a pull request.
+type PRStatusRun struct {
+	RunID           int64               `json:"run_id"`
+	Workflow        string              `json:"workflow"`
+	WorkflowPath    string              `json:"workflow_path,omitempty"`
+	Event           string              `json:"event"`
+	Status          string              `json:"status"`
+	Conclusion      string              `json:"conclusion,omitempty"`
+	URL             string              `json:"url"`
+	CreatedAt       time.Time           `json:"created_at"`
+	Cached          bool                `json:"cached"` // True when a run summary was found in the logs cache
+	SafeOutputs     []CreatedItemReport `json:"safe_outputs,omitempty"`
+	AIC             float64             `json:"aic,omitempty"`
+	EffectiveTokens int                 `json:"effective_tokens,omitempty"`
+	ActionMinutes   float64             `json:"action_minutes,omitempty"`
+}
+
+// PRStatusTotals aggregates the activity of all runs on a pull request.
+type PRStatusTotals struct {
+	Runs            int            `json:"runs"`
+	UncachedRuns    int            `json:"uncached_runs"`
+	Conclusions     map[string]int `json:"conclusions"`
+	SafeOutputs     map[string]int `json:"safe_outputs"`
+	AIC             float64        `json:"aic"`
+	EffectiveTokens int            `json:"effective_tokens"`
+	ActionMinutes   float64        `json:"action_minutes"`
+}
+
+// PRStatusResult is the output of the pr-status command.
+type PRStatusResult struct {
+	PRNumber string         `json:"pr_number"`
+	HeadRef  string         `json:"head_ref"`
+	HeadSHA  string         `json:"head_sha"`
+	Runs     []PRStatusRun  `json:"runs"`
+	Totals   PRStatusTotals `json:"totals"`
+}
+
+// NewPRStatusCommand creates the pr-status command.
+func NewPRStatusCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pr-status <pr-number>",
+		Short: "Summarize agentic workflow activity on a pull request",
+		Long: `Summarize agentic workflow activity on a pull request.
+
+Lists the agentic workflow runs for the pull request with their conclusions, the
+safe outputs each run created (comments, reviews, pushes), and the aggregate cost.
+
+Runs are found through the GitHub API: runs on the pull request's head branch that
+GitHub associates with the pull request or that ran on one of its commits. Safe
+outputs and cost come from the run summaries in the logs cache written by
+'` + string(constants.CLIExtensionPrefix) + ` logs' and '` + string(constants.CLIExtensionPrefix) + ` audit'. Cached runs from other branches, such as runs
+triggered by a comment on the pull request, are included when they created a safe
+output on the pull request.`,
+		Example: `  ` + string(constants.CLIExtensionPrefix) + ` pr-status 42                    # Summarize activity on PR #42
+  ` + string(constants.CLIExtensionPrefix) + ` pr-status 42 --repo owner/repo  # Specify repository
+  ` + string(constants.CLIExtensionPrefix) + ` pr-status 42 -o ./logs          # Read run summaries from another logs directory
+  ` + string(constants.CLIExtensionPrefix) + ` pr-status 42 --json             # Output in JSON format`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			repo, _ := cmd.Flags().GetString("repo")
+			outputDir, _ := cmd.Flags().GetString("output")
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			verbose, _ := cmd.Flags().GetBool("verbose")
+
+			if _, err := strconv.Atoi(args[0]); err != nil {
+				return fmt.Errorf("invalid pull request number %q: must be a number", args[0])
+			}
+
+			return RunPRStatus(PRStatusConfig{
+				Repo:       repo,
+				PRNumber:   args[0],
+				OutputDir:  outputDir,
+				JSONOutput: jsonOutput,
+				Verbose:    verbose,
+			})
+		},
+	}
+
+	addRepoFlag(cmd)
+	addOutputFlag(cmd, defaultLogsOutputDir)
+	addJSONFlag(cmd)
+
+	return cmd
+}
+
+// RunPRStatus executes the pr-status command with the given configuration.
+func RunPRStatus(config PRStatusConfig) error {
+	prStatusLog.Printf("Running pr-status: pr=%s, repo=%s", config.PRNumber, config.Repo)
+
+	pr, err := fetchPRStatusPullRequest(config.Repo, config.PRNumber)
+	if err != nil {
+		return err
+	}
+
+	commits, err := fetchPRCommitSHAs(config.Repo, config.PRNumber)
+	if err != nil {
+		// Non-fatal: runs GitHub associates with the PR are still found
+		prStatusLog.Printf("Failed to fetch PR commits: %v", err)
+	}
+
+	runs, err := fetchBranchWorkflowRuns(config.Repo, config.PRNumber, pr.HeadRef)
+	if err != nil {
+		return fmt.Errorf("failed to list workflow runs: %w", err)
+	}
+
+	outputDir := config.OutputDir
+	if outputDir == "" {
+		outputDir = defaultLogsOutputDir
+	}
+	summaries, err := readCachedRunSummaries(outputDir)
+	if err != nil {
+		if config.Verbose {
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Failed to read run summaries: %v", err)))
+		}
+		summaries = nil
+	}
+
+	result := buildPRStatusResult(config.PRNumber, pr, commits, runs, summaries)
+
+	if config.JSONOutput {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(result); err != nil {
+			return fmt.Errorf("failed to encode JSON output: %w", err)
+		}
+		return nil
+	}
+
+	printPRStatusText(result)
+	return nil
+}
+
+// prStatusPullRequest holds the pull request fields used to find its runs.
+type prStatusPullRequest struct {
+	HeadRef   string    `json:"head_ref"`
+	HeadSHA   string    `json:"head_sha"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// prStatusAPIRun is a workflow run as returned by the Actions API, with the
+// numbers of the pull requests GitHub associates with it.
+type prStatusAPIRun struct {
+	WorkflowRun
+	PullRequests []int `json:"pullRequests"`
+}
+
+// fetchPRStatusPullRequest fetches the head branch, head SHA, and creation time of a PR.
+func fetchPRStatusPullRequest(repoOverride string, prNumber string) (prStatusPullRequest, error) {
+	output, err := execGHAPI(repoOverride, prNumber,
+		"api", "repos/{owner}/{repo}/pulls/"+prNumber,
+		"--jq", "{head_ref: .head.ref, head_sha: .head.sha, created_at: .created_at}")
+	if err != nil {
+		return prStatusPullRequest{}, err
+	}
+
+	var pr prStatusPullRequest
+	if err := json.Unmarshal(output, &pr); err != nil {
+		return prStatusPullRequest{}, fmt.Errorf("failed to parse pull request response: %w", err)
+	}
+	if pr.HeadRef == "" {
+		return prStatusPullRequest{}, errors.New(console.FormatErrorWithSuggestions(
+			"PR #"+prNumber+" returned an empty head branch",
+			[]string{
+				"Verify that PR #" + prNumber + " exists and is accessible",
+				"Check that the --repo flag points to the correct repository",
+			},
+		))
+	}
+	return pr, nil
+}
+
+// fetchPRCommitSHAs fetches the SHAs of the commits on a PR.
+func fetchPRCommitSHAs(repoOverride string, prNumber string) (map[string]bool, error) {
+	output, err := execGHAPI(repoOverride, prNumber,
+		"api", "repos/{owner}/{repo}/pulls/"+prNumber+"/commits?per_page=100", "--paginate", "--jq", ".[].sha")
+	if err != nil {
+		return nil, err
+	}
+
+	shas := make(map[string]bool)
+	for line := range strings.SplitSeq(string(output), "\n") {
+		if sha := strings.TrimSpace(line); sha != "" {
+			shas[sha] = true
+		}
+	}
+	return shas, nil
+}
+
+// fetchBranchWorkflowRuns lists the workflow runs on the head branch of a PR.
+func fetchBranchWorkflowRuns(repoOverride string, prNumber string, branch string) ([]prStatusAPIRun, error) {
+	jq := `.workflow_runs[] | {databaseId: .id, number: .run_number, url: .html_url, status: .status, conclusion: .conclusion, workflowName: .name, workflowPath: .path, createdAt: .created_at, startedAt: .run_started_at, updatedAt: .updated_at, event: .event, headBranch: .head_branch, headSha: .head_sha, displayTitle: .display_title, pullRequests: [.pull_requests[].number]}`
+	output, err := execGHAPI(repoOverride, prNumber,
+		"api", "repos/{owner}/{repo}/actions/runs?per_page=100&branch="+url.QueryEscape(branch), "--paginate", "--jq", jq)
+	if err != nil {
+		return nil, err
+	}
+	return parsePRStatusAPIRuns(output)
+}
+
+// parsePRStatusAPIRuns parses the one-object-per-line output of fetchBranchWorkflowRuns.
+func parsePRStatusAPIRuns(output []byte) ([]prStatusAPIRun, error) {
+	var runs []prStatusAPIRun
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var run prStatusAPIRun
+		if err := json.Unmarshal([]byte(line), &run); err != nil {
+			return nil, fmt.Errorf("failed to parse workflow run: %w", err)
+		}
+		runs = append(runs, run)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read workflow runs: %w", err)
+	}
+	return runs, nil
+}
+
+// isAgenticWorkflowPath reports whether a workflow file is a compiled agentic workflow.
+func isAgenticWorkflowPath(workflowPath string) bool {
+	return strings.HasSuffix(workflowPath, ".lock.yml")
+}
+
+// buildPRStatusResult selects the agentic runs of a PR and joins them with their cached summaries.
+//
+// A run on the PR head branch belongs to the PR when GitHub associates it with the PR or
+// it ran on one of the PR's commits; the branch name alone is not enough because a fork
+// can reuse a branch name. Cached runs from other branches (for example runs triggered
+// by a comment on the PR, which run on the default branch) belong to the PR when one of
+// their safe outputs targets it.
+func buildPRStatusResult(prNumber string, pr prStatusPullRequest, commits map[string]bool, apiRuns []prStatusAPIRun, summaries []*RunSummary) *PRStatusResult {
+	number, _ := strconv.Atoi(prNumber)
+
+	summaryByID := make(map[int64]*RunSummary, len(summaries))
+	for _, summary := range summaries {
+		summaryByID[summary.RunID] = summary
+	}
+
+	result := &PRStatusResult{
+		PRNumber: prNumber,
+		HeadRef:  pr.HeadRef,
+		HeadSHA:  pr.HeadSHA,
+		Runs:     []PRStatusRun{},
+	}
+	seen := make(map[int64]bool)
+
+	for _, apiRun := range apiRuns {
+		if !isAgenticWorkflowPath(apiRun.WorkflowPath) {
+			continue
+		}
+		if !slices.Contains(apiRun.PullRequests, number) && !commits[apiRun.HeadSha] && apiRun.HeadSha != pr.HeadSHA {
+			continue
+		}
+		seen[apiRun.DatabaseID] = true
+		result.Runs = append(result.Runs, newPRStatusRun(apiRun.WorkflowRun, summaryByID[apiRun.DatabaseID]))
+	}
+
+	for _, summary := range summaries {
+		if seen[summary.RunID] || summary.Run.CreatedAt.Before(pr.CreatedAt) {
+			continue
+		}
+		if summary.Run.WorkflowPath != "" && !isAgenticWorkflowPath(summary.Run.WorkflowPath) {
+			continue
+		}
+		items := loadPRStatusSafeOutputs(summary.Run.LogsPath)
+		if !slices.ContainsFunc(items, func(item CreatedItemReport) bool { return safeOutputTargetsPR(item, number) }) {
+			continue
+		}
+		seen[summary.RunID] = true
+		result.Runs = append(result.Runs, newPRStatusRun(summary.Run, summary))
+	}
+
+	slices.SortFunc(result.Runs, func(a, b PRStatusRun) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.RunID, b.RunID)
+	})
+
+	result.Totals = PRStatusTotals{
+		Runs:        len(result.Runs),
+		Conclusions: make(map[string]int),
+		SafeOutputs: make(map[string]int),
+	}
+	for _, run := range result.Runs {
+		result.Totals.Conclusions[prStatusRunState(run)]++
+		if !run.Cached {
+			result.Totals.UncachedRuns++
+			continue
+		}
+		for _, item := range run.SafeOutputs {
+			result.Totals.SafeOutputs[item.Type]++
+		}
+		result.Totals.AIC += run.AIC
+		result.Totals.EffectiveTokens += run.EffectiveTokens
+		result.Totals.ActionMinutes += run.ActionMinutes
+	}
+
+	prStatusLog.Printf("PR #%s: %d agentic run(s), %d uncached", prNumber, result.Totals.Runs, result.Totals.UncachedRuns)
+	return result
+}
+
+// newPRStatusRun builds a PRStatusRun from the API run and its cached summary, if any.
+func newPRStatusRun(run WorkflowRun, summary *RunSummary) PRStatusRun {
+	statusRun := PRStatusRun{
+		RunID:        run.DatabaseID,
+		Workflow:     run.WorkflowName,
+		WorkflowPath: run.WorkflowPath,
+		Event:        run.Event,
+		Status:       run.Status,
+		Conclusion:   run.Conclusion,
+		URL:          run.URL,
+		CreatedAt:    run.CreatedAt,
+	}
+	if summary == nil {
+		return statusRun
+	}
+
+	statusRun.Cached = true
+	statusRun.SafeOutputs = loadPRStatusSafeOutputs(summary.Run.LogsPath)
+	statusRun.EffectiveTokens = summary.Run.EffectiveTokens
+	statusRun.ActionMinutes = summary.Run.ActionMinutes
+	if summary.TokenUsage != nil {
+		statusRun.AIC = summary.TokenUsage.TotalAIC
+		if statusRun.EffectiveTokens == 0 {
+			statusRun.EffectiveTokens = summary.TokenUsage.TotalEffectiveTokens
+		}
+	}
+	// The summary was written after the run completed; the API may report an older state
+	if statusRun.Conclusion == "" {
+		statusRun.Status = summary.Run.Status
+		statusRun.Conclusion = summary.Run.Conclusion
+	}
+	if statusRun.Workflow == "" {
+		statusRun.Workflow = summary.Run.WorkflowName
+	}
+	return statusRun
+}
+
+// loadPRStatusSafeOutputs reads the safe output items created by a cached run.
+func loadPRStatusSafeOutputs(runDir string) []CreatedItemReport {
+	if runDir == "" {
+		return nil
+	}
+	items := extractCreatedItemsFromManifest(runDir)
+	if len(items) == 0 {
+		items = extractCreatedItemsFromManifest(filepath.Join(runDir, "safe-outputs-items"))
+	}
+	return items
+}
+
+// safeOutputTargetsPR reports whether a created item is on the given pull request.
+func safeOutputTargetsPR(item CreatedItemReport, prNumber int) bool {
+	marker := "/pull/" + strconv.Itoa(prNumber)
+	index := strings.Index(item.URL, marker)
+	if index < 0 {
+		return false
+	}
+	rest := item.URL[index+len(marker):]
+	return rest == "" || strings.HasPrefix(rest, "#") || strings.HasPrefix(rest, "/")
+}
+
+// prStatusRunState returns the conclusion of a completed run, or its status otherwise.
+func prStatusRunState(run PRStatusRun) string {
+	if run.Conclusion != "" {
+		return run.Conclusion
+	}
+	if run.Status != "" {
+		return run.Status
+	}
+	return "unknown"
+}
+
+// formatPRStatusSafeOutputs summarizes safe output items as "type (count)" entries.
+func formatPRStatusSafeOutputs(counts map[string]int) string {
+	if len(counts) == 0 {
+		return "none"
+	}
+	var parts []string
+	for _, itemType := range sliceutil.SortedKeys(counts) {
+		if counts[itemType] > 1 {
+			parts = append(parts, fmt.Sprintf("%s (%d)", itemType, counts[itemType]))
+		} else {
+			parts = append(parts, itemType)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// printPRStatusText prints the result in human-readable form to stderr.
+func printPRStatusText(result *PRStatusResult) {
+	if len(result.Runs) == 0 {
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("PR #%s: no agentic workflow runs found on %s", result.PRNumber, result.HeadRef)))
+		return
+	}
+
+	rows := make([][]string, 0, len(result.Runs))
+	for _, run := range result.Runs {
+		safeOutputs, aic := "-", "-"
+		if run.Cached {
+			counts := make(map[string]int)
+			for _, item := range run.SafeOutputs {
+				counts[item.Type]++
+			}
+			safeOutputs = formatPRStatusSafeOutputs(counts)
+			aic = fmt.Sprintf("%.2f", run.AIC)
+		}
+		rows = append(rows, []string{
+			run.Workflow,
+			strconv.FormatInt(run.RunID, 10),
+			run.Event,
+			prStatusRunState(run),
+			safeOutputs,
+			aic,
+		})
+	}
+	fmt.Fprint(os.Stderr, console.RenderTable(console.TableConfig{
+		Title:   fmt.Sprintf("Agentic activity on PR #%s (%s)", result.PRNumber, result.HeadRef),
+		Headers: []string{"Workflow", "Run", "Event", "Conclusion", "Safe Outputs", "AIC"},
+		Rows:    rows,
+		TTYFunc: tty.IsStderrTerminal,
+	}))
+
+	conclusions := make([]string, 0, len(result.Totals.Conclusions))
+	for _, conclusion := range sliceutil.SortedKeys(result.Totals.Conclusions) {
+		conclusions = append(conclusions, fmt.Sprintf("%d %s", result.Totals.Conclusions[conclusion], conclusion))
+	}
+	fmt.Fprintf(os.Stderr, "  runs: %d (%s)\n", result.Totals.Runs, strings.Join(conclusions, ", "))
+	fmt.Fprintf(os.Stderr, "  safe outputs: %s\n", formatPRStatusSafeOutputs(result.Totals.SafeOutputs))
+	fmt.Fprintf(os.Stderr, "  cost: %.2f AIC, %s effective tokens, %.0f Actions minutes\n",
+		result.Totals.AIC, console.FormatNumber(result.Totals.EffectiveTokens), result.Totals.ActionMinutes)
+
+	if result.Totals.UncachedRuns > 0 {
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf(
+			"%d run(s) have no cached summary and are not counted in safe outputs or cost; run '%s logs --ref %s' to download them",
+			result.Totals.UncachedRuns, string(constants.CLIExtensionPrefix), result.HeadRef)))
+	}
+}
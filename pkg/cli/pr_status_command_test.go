@@ -0,0 +1,116 @@
+//go:build !integration
+
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePRStatusAPIRuns(t *testing.T) {
+	output := []byte(`{"databaseId":1,"workflowName":"PR Reviewer","workflowPath":".github/workflows/reviewer.lock.yml","headSha":"abc","pullRequests":[42]}
+{"databaseId":2,"workflowName":"CI","workflowPath":".github/workflows/ci.yml","headSha":"abc","pullRequests":[]}
+`)
+	runs, err := parsePRStatusAPIRuns(output)
+	require.NoError(t, err, "runs should parse")
+	require.Len(t, runs, 2, "each line should be a run")
+	assert.Equal(t, int64(1), runs[0].DatabaseID, "run fields should be read")
+	assert.Equal(t, []int{42}, runs[0].PullRequests, "associated pull requests should be read")
+	assert.True(t, isAgenticWorkflowPath(runs[0].WorkflowPath), "lock files are agentic workflows")
+	assert.False(t, isAgenticWorkflowPath(runs[1].WorkflowPath), "other workflows are not")
+}
+
+func TestSafeOutputTargetsPR(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{url: "https://github.com/owner/repo/pull/42", want: true},
+		{url: "https://github.com/owner/repo/pull/42#issuecomment-1", want: true},
+		{url: "https://github.com/owner/repo/pull/42/files#r2", want: true},
+		{url: "https://github.com/owner/repo/pull/420", want: false},
+		{url: "https://github.com/owner/repo/issues/42", want: false},
+		{url: "", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			assert.Equal(t, tt.want, safeOutputTargetsPR(CreatedItemReport{Type: "add_comment", URL: tt.url}, 42), "target match for %q", tt.url)
+		})
+	}
+}
+
+func TestBuildPRStatusResult(t *testing.T) {
+	logsDir := testutil.TempDir(t, "pr-status-*")
+	created := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	writeRun := func(id int64, manifest string) *RunSummary {
+		runDir := filepath.Join(logsDir, fmt.Sprintf("run-%d", id))
+		require.NoError(t, os.MkdirAll(runDir, 0o755), "create run dir")
+		if manifest != "" {
+			require.NoError(t, os.WriteFile(filepath.Join(runDir, safeOutputItemsManifestFilename), []byte(manifest), 0o644), "write manifest")
+		}
+		return &RunSummary{
+			RunID:      id,
+			Run:        WorkflowRun{DatabaseID: id, WorkflowName: "Workflow", WorkflowPath: ".github/workflows/w.lock.yml", Conclusion: "success", CreatedAt: created.Add(time.Duration(id) * time.Minute), EffectiveTokens: 1000, ActionMinutes: 3, LogsPath: runDir},
+			TokenUsage: &TokenUsageSummary{TotalAIC: 1.5},
+		}
+	}
+
+	pr := prStatusPullRequest{HeadRef: "feature", HeadSHA: "head", CreatedAt: created}
+	apiRuns := []prStatusAPIRun{
+		{WorkflowRun: WorkflowRun{DatabaseID: 1, WorkflowName: "Reviewer", WorkflowPath: ".github/workflows/reviewer.lock.yml", Conclusion: "success", HeadSha: "old", CreatedAt: created.Add(time.Minute)}, PullRequests: []int{42}},
+		{WorkflowRun: WorkflowRun{DatabaseID: 2, WorkflowName: "Fixer", WorkflowPath: ".github/workflows/fixer.lock.yml", Status: "in_progress", HeadSha: "head", CreatedAt: created.Add(2 * time.Minute)}},
+		{WorkflowRun: WorkflowRun{DatabaseID: 3, WorkflowName: "CI", WorkflowPath: ".github/workflows/ci.yml", Conclusion: "failure", HeadSha: "head"}, PullRequests: []int{42}},
+		{WorkflowRun: WorkflowRun{DatabaseID: 4, WorkflowName: "Reviewer", WorkflowPath: ".github/workflows/reviewer.lock.yml", Conclusion: "success", HeadSha: "fork-main"}},
+	}
+	summaries := []*RunSummary{
+		writeRun(1, `{"type":"create_pull_request_review_comment","url":"https://github.com/o/r/pull/42#discussion_r1","timestamp":"t"}
+{"type":"add_comment","url":"https://github.com/o/r/pull/42#issuecomment-1","timestamp":"t"}
+`),
+		writeRun(5, `{"type":"push_to_pull_request_branch","url":"https://github.com/o/r/pull/42","timestamp":"t"}
+`),
+		writeRun(6, `{"type":"add_comment","url":"https://github.com/o/r/pull/7#issuecomment-2","timestamp":"t"}
+`),
+	}
+
+	result := buildPRStatusResult("42", pr, map[string]bool{"old": true}, apiRuns, summaries)
+
+	ids := make([]int64, 0, len(result.Runs))
+	for _, run := range result.Runs {
+		ids = append(ids, run.RunID)
+	}
+	assert.Equal(t, []int64{1, 2, 5}, ids, "associated runs, runs on PR commits, and cached runs targeting the PR should be listed in order")
+
+	assert.True(t, result.Runs[0].Cached, "runs with a summary should be marked cached")
+	assert.Len(t, result.Runs[0].SafeOutputs, 2, "safe outputs should come from the manifest")
+	assert.False(t, result.Runs[1].Cached, "runs without a summary should be listed without cost")
+
+	assert.Equal(t, 3, result.Totals.Runs, "all runs should be counted")
+	assert.Equal(t, 1, result.Totals.UncachedRuns, "uncached runs should be reported")
+	assert.Equal(t, map[string]int{"success": 2, "in_progress": 1}, result.Totals.Conclusions, "conclusions should fall back to status")
+	assert.Equal(t, map[string]int{"add_comment": 1, "create_pull_request_review_comment": 1, "push_to_pull_request_branch": 1}, result.Totals.SafeOutputs, "safe outputs should be counted by type")
+	assert.InDelta(t, 3.0, result.Totals.AIC, 0.001, "cost should be summed over cached runs")
+	assert.Equal(t, 2000, result.Totals.EffectiveTokens, "effective tokens should be summed")
+	assert.InDelta(t, 6.0, result.Totals.ActionMinutes, 0.001, "Actions minutes should be summed")
+}
+
+func TestFormatPRStatusSafeOutputs(t *testing.T) {
+	assert.Equal(t, "none", formatPRStatusSafeOutputs(nil), "no outputs")
+	assert.Equal(t, "add_comment (2), submit_pull_request_review", formatPRStatusSafeOutputs(map[string]int{"submit_pull_request_review": 1, "add_comment": 2}), "outputs should be sorted with counts")
+}
+
+func TestNewPRStatusCommand(t *testing.T) {
+	cmd := NewPRStatusCommand()
+	assert.Equal(t, "pr-status <pr-number>", cmd.Use, "command usage")
+	for _, flag := range []string{"repo", "output", "json"} {
+		assert.NotNil(t, cmd.Flags().Lookup(flag), "--%s flag should be registered", flag)
+	}
+	assert.Equal(t, defaultLogsOutputDir, cmd.Flags().Lookup("output").DefValue, "summaries should be read from the logs cache by default")
+	require.Error(t, cmd.RunE(cmd, []string{"abc"}), "non-numeric PR numbers should be rejected")
+}
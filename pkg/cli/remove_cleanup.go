@@ -0,0 +1,219 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/fileutil"
+	"github.com/github/gh-aw/pkg/gitutil"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/sliceutil"
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/workflow"
+)
+
+var removeCleanupLog = logger.New("cli:remove_cleanup")
+
+// warnAboutRemovedImports warns about remaining workflows that import one of the files
+// being removed, either in frontmatter imports or with body import directives.
+func warnAboutRemovedImports(workflowsDir string, mdFiles, filesToRemove []string) {
+	absWorkflowsDir, err := filepath.Abs(workflowsDir)
+	if err != nil {
+		removeCleanupLog.Printf("Failed to resolve workflows directory %s: %v", workflowsDir, err)
+		return
+	}
+
+	removing := make(map[string]struct{}, len(filesToRemove))
+	for _, file := range filesToRemove {
+		if absPath, err := filepath.Abs(file); err == nil {
+			removing[absPath] = struct{}{}
+		}
+	}
+
+	graph := NewDependencyGraph(absWorkflowsDir)
+	for _, file := range mdFiles {
+		absPath, err := filepath.Abs(file)
+		if err != nil {
+			continue
+		}
+		if _, ok := removing[absPath]; ok {
+			continue
+		}
+
+		imports, err := graph.extractImportsFromFile(absPath)
+		if err != nil {
+			removeCleanupLog.Printf("Skipping import analysis for %s: %v", file, err)
+			continue
+		}
+		if content, err := os.ReadFile(absPath); err == nil {
+			includes, _ := findIncludesInContent(string(content))
+			for _, include := range includes {
+				if resolved := graph.resolveImportPath(include, filepath.Dir(absPath)); resolved != "" {
+					imports = append(imports, resolved)
+				}
+			}
+		}
+
+		for _, imported := range sliceutil.Deduplicate(imports) {
+			if _, ok := removing[imported]; ok {
+				fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf(
+					"%s imports %s, which will be removed; update its imports before recompiling",
+					filepath.Base(file), filepath.Base(imported))))
+			}
+		}
+	}
+}
+
+// collectLockFileContents returns the concatenated lock files of the given workflow
+// markdown files. It is used to find the action pins a workflow references.
+func collectLockFileContents(mdFiles []string) string {
+	var builder strings.Builder
+	for _, file := range mdFiles {
+		content, err := os.ReadFile(stringutil.MarkdownToLockFile(file))
+		if err != nil {
+			continue
+		}
+		builder.Write(content)
+		builder.WriteByte('\n')
+	}
+	return builder.String()
+}
+
+// pruneRemovedActionLockEntries removes actions-lock.json entries whose pinned
+// "repo@sha" reference appeared in the removed lock files and no longer appears in
+// any remaining lock file. Returns true when actions-lock.json was changed.
+func pruneRemovedActionLockEntries(workflowsDir, removedLockContent string) bool {
+	if removedLockContent == "" {
+		return false
+	}
+
+	repoRoot, err := gitutil.FindGitRootFrom(workflowsDir)
+	if err != nil {
+		repoRoot = "."
+	}
+	actionCache := workflow.NewActionCache(repoRoot)
+	if !fileutil.FileExists(actionCache.GetCachePath()) {
+		return false
+	}
+	if err := actionCache.Load(); err != nil {
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Failed to load %s: %v", workflow.CacheFileName, err)))
+		return false
+	}
+
+	lockFiles, err := filepath.Glob(filepath.Join(workflowsDir, "*.lock.yml"))
+	if err != nil {
+		removeCleanupLog.Printf("Failed to list remaining lock files: %v", err)
+		return false
+	}
+	var remaining strings.Builder
+	for _, lockFile := range lockFiles {
+		if content, err := os.ReadFile(lockFile); err == nil {
+			remaining.Write(content)
+		}
+	}
+	remainingContent := remaining.String()
+
+	var pruned []string
+	for _, key := range sliceutil.SortedKeys(actionCache.Entries) {
+		entry := actionCache.Entries[key]
+		ref := entry.Repo + "@" + entry.SHA
+		if entry.SHA == "" || !strings.Contains(removedLockContent, ref) || strings.Contains(remainingContent, ref) {
+			continue
+		}
+		actionCache.DeleteByKey(key)
+		pruned = append(pruned, key)
+	}
+	if len(pruned) == 0 {
+		return false
+	}
+
+	if err := actionCache.Save(); err != nil {
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Failed to update %s: %v", workflow.CacheFileName, err)))
+		return false
+	}
+	removeCleanupLog.Printf("Pruned %d actions-lock.json entries: %v", len(pruned), pruned)
+	fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("Removed %d unused %s entries: %s", len(pruned), workflow.CacheFileName, strings.Join(pruned, ", "))))
+	return true
+}
+
+// stageActionsLock stages actions-lock.json after it was pruned.
+func stageActionsLock(ctx context.Context) {
+	if gitRoot, err := gitutil.FindGitRoot(); err == nil {
+		lockPath := filepath.Join(gitRoot, ".github", "aw", workflow.CacheFileName)
+		_ = exec.CommandContext(ctx, "git", "-C", gitRoot, "add", "--all", "--", lockPath).Run()
+	}
+}
+
+// deleteWorkflowCaches deletes the GitHub Actions caches created by the given workflows.
+func deleteWorkflowCaches(workflowIDs []string) error {
+	repoSlug, err := GetCurrentRepoSlug()
+	if err != nil {
+		return err
+	}
+
+	output, err := workflow.RunGH("Listing Actions caches...", "api", "--paginate",
+		fmt.Sprintf("repos/%s/actions/caches?per_page=100", repoSlug),
+		"--jq", `.actions_caches[] | "\(.id)\t\(.key)"`)
+	if err != nil {
+		return fmt.Errorf("failed to list Actions caches: %w", err)
+	}
+
+	sanitizedIDs := make([]string, 0, len(workflowIDs))
+	for _, id := range workflowIDs {
+		sanitizedIDs = append(sanitizedIDs, workflow.SanitizeWorkflowIDForCacheKey(id))
+	}
+
+	deleted := 0
+	for line := range strings.SplitSeq(strings.TrimSpace(string(output)), "\n") {
+		cacheID, key, ok := strings.Cut(line, "\t")
+		if !ok || !isRemovedWorkflowCacheKey(key, sanitizedIDs) {
+			continue
+		}
+		if _, err := workflow.RunGH("Deleting Actions cache...", "api", "-X", "DELETE",
+			fmt.Sprintf("repos/%s/actions/caches/%s", repoSlug, cacheID)); err != nil {
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Failed to delete cache %s: %v", key, err)))
+			continue
+		}
+		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage("Deleted cache: "+key))
+		deleted++
+	}
+
+	removeCleanupLog.Printf("Deleted %d Actions caches for %v", deleted, workflowIDs)
+	if deleted == 0 {
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage("No Actions caches found for the removed workflows"))
+	}
+	return nil
+}
+
+// isRemovedWorkflowCacheKey reports whether an Actions cache key was created by one of
+// the workflows with the given sanitized IDs. It matches cache-memory keys
+// ("memory-[<cache-id>-]<workflow>-<run-id>") and daily usage caches
+// ("agentic-workflow-usage-<workflow>-<run-id>").
+func isRemovedWorkflowCacheKey(key string, sanitizedIDs []string) bool {
+	for _, id := range sanitizedIDs {
+		if strings.HasPrefix(key, "agentic-workflow-usage-"+id+"-") {
+			return true
+		}
+		rest, ok := strings.CutPrefix(key, "memory-")
+		if !ok {
+			continue
+		}
+		lastDash := strings.LastIndexByte(rest, '-')
+		if lastDash < 0 {
+			continue
+		}
+		if _, err := strconv.ParseUint(rest[lastDash+1:], 10, 64); err != nil {
+			continue
+		}
+		if scope := rest[:lastDash]; scope == id || strings.HasSuffix(scope, "-"+id) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+//go:build !integration
+
+package cli
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsRemovedWorkflowCacheKey(t *testing.T) {
+	ids := []string{"dailyreport"}
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{key: "memory-dailyreport-123456", want: true},
+		{key: "memory-notes-dailyreport-123456", want: true},
+		{key: "agentic-workflow-usage-dailyreport-123456", want: true},
+		{key: "memory-otherdailyreport-123456", want: false},
+		{key: "memory-dailyreport-notes-123456", want: false},
+		{key: "memory-dailyreport-latest", want: false},
+		{key: "node-cache-dailyreport-123456", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			assert.Equal(t, tt.want, isRemovedWorkflowCacheKey(tt.key, ids), "cache key match for %s", tt.key)
+		})
+	}
+}
+
+func TestPruneRemovedActionLockEntries(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := exec.Command("git", "-C", tmpDir, "init").Run(); err != nil {
+		t.Skip("Skipping test - git not available")
+	}
+	workflowsDir := filepath.Join(tmpDir, ".github", "workflows")
+	require.NoError(t, os.MkdirAll(workflowsDir, 0755), "Failed to create workflows dir")
+
+	actionCache := workflow.NewActionCache(tmpDir)
+	actionCache.Set("actions/checkout", "v5", "aaaa")
+	actionCache.Set("octo/deploy", "v1", "bbbb")
+	actionCache.Set("octo/notify", "v2", "cccc")
+	require.NoError(t, actionCache.Save(), "Failed to write actions-lock.json")
+
+	remainingLock := "steps:\n  - uses: actions/checkout@aaaa # v5\n  - uses: octo/notify@cccc # v2\n"
+	require.NoError(t, os.WriteFile(filepath.Join(workflowsDir, "keep.lock.yml"), []byte(remainingLock), 0644), "Failed to write lock file")
+	removedLock := "steps:\n  - uses: actions/checkout@aaaa # v5\n  - uses: octo/deploy@bbbb # v1\n"
+
+	assert.True(t, pruneRemovedActionLockEntries(workflowsDir, removedLock), "actions-lock.json should be updated")
+
+	reloaded := workflow.NewActionCache(tmpDir)
+	require.NoError(t, reloaded.Load(), "Failed to reload actions-lock.json")
+	_, hasDeploy := reloaded.Get("octo/deploy", "v1")
+	assert.False(t, hasDeploy, "entry used only by the removed workflow should be pruned")
+	_, hasCheckout := reloaded.Get("actions/checkout", "v5")
+	assert.True(t, hasCheckout, "entry still used by another workflow should be kept")
+	_, hasNotify := reloaded.Get("octo/notify", "v2")
+	assert.True(t, hasNotify, "entry not used by the removed workflow should be kept")
+
+	assert.False(t, pruneRemovedActionLockEntries(workflowsDir, removedLock), "a second prune should change nothing")
+}
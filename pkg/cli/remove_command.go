@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -17,9 +18,23 @@ import (
 
 var removeLog = logger.New("cli:remove_command")
 
+// RemoveOptions contains all configuration options for removing workflows
+type RemoveOptions struct {
+	Pattern     string // Workflow ID or substring matching the workflows to remove
+	KeepOrphans bool   // Keep include files that are no longer referenced by any workflow
+	WorkflowDir string // Workflow directory (default: .github/workflows)
+	// Disable disables the workflows on GitHub before their files are removed, so
+	// scheduled runs stop even before the removal is pushed.
+	Disable bool
+	// DeleteCaches deletes the GitHub Actions caches created by the workflows
+	// (cache-memory and daily usage caches).
+	DeleteCaches bool
+}
+
 // RemoveWorkflows removes workflows matching a pattern
-func RemoveWorkflows(pattern string, keepOrphans bool, workflowDir string) error {
-	removeLog.Printf("Removing workflows: pattern=%q, keepOrphans=%v, workflowDir=%q", pattern, keepOrphans, workflowDir)
+func RemoveWorkflows(ctx context.Context, opts RemoveOptions) error {
+	pattern, keepOrphans, workflowDir := opts.Pattern, opts.KeepOrphans, opts.WorkflowDir
+	removeLog.Printf("Removing workflows: pattern=%q, keepOrphans=%v, workflowDir=%q, disable=%v, deleteCaches=%v", pattern, keepOrphans, workflowDir, opts.Disable, opts.DeleteCaches)
 	workflowsDir := workflowDir
 	if workflowsDir == "" {
 		workflowsDir = getWorkflowsDir()
@@ -121,6 +136,9 @@ func RemoveWorkflows(pattern string, keepOrphans bool, workflowDir string) error
 		}
 	}
 
+	// Warn about workflows that import the files being removed; they fail to compile afterwards
+	warnAboutRemovedImports(workflowsDir, mdFiles, filesToRemove)
+
 	// Ask for confirmation
 	confirmed, err := console.ConfirmAction(
 		"Are you sure you want to remove these workflows?",
@@ -135,6 +153,21 @@ func RemoveWorkflows(pattern string, keepOrphans bool, workflowDir string) error
 		return nil
 	}
 
+	workflowIDs := make([]string, 0, len(filesToRemove))
+	for _, file := range filesToRemove {
+		workflowIDs = append(workflowIDs, normalizeWorkflowID(filepath.Base(file)))
+	}
+
+	// Disable the workflows while their lock files still identify them on GitHub
+	if opts.Disable {
+		if err := DisableWorkflowsByNames(ctx, workflowIDs, ""); err != nil {
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Failed to disable workflows: %v", err)))
+		}
+	}
+
+	// Record the action pins used by the removed lock files before they are deleted
+	removedActionRefs := collectLockFileContents(filesToRemove)
+
 	// Remove the files
 	var removedFiles []string
 	for _, file := range filesToRemove {
@@ -163,9 +196,21 @@ func RemoveWorkflows(pattern string, keepOrphans bool, workflowDir string) error
 		}
 	}
 
+	// Drop actions-lock.json entries that only the removed workflows used
+	prunedActionsLock := len(removedFiles) > 0 && pruneRemovedActionLockEntries(workflowsDir, removedActionRefs)
+
+	if opts.DeleteCaches && len(removedFiles) > 0 {
+		if err := deleteWorkflowCaches(workflowIDs); err != nil {
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Failed to delete workflow caches: %v", err)))
+		}
+	}
+
 	// Stage changes to git if in a git repository
 	if len(removedFiles) > 0 && isGitRepo() {
 		stageWorkflowChanges()
+		if prunedActionsLock {
+			stageActionsLock(ctx)
+		}
 	}
 
 	return nil
@@ -0,0 +1,133 @@
+package cli
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var runMetricsSummaryLog = logger.New("cli:run_metrics_summary")
+
+// RunMetricsSummary is the data behind the "Run metrics" section that the compiled
+// workflow writes to GITHUB_STEP_SUMMARY and that `gh aw audit --format markdown` prints.
+//
+// The JavaScript renderer in actions/setup/js/generate_run_metrics_summary.cjs consumes the
+// same JSON shape and must produce identical markdown; both implementations are checked
+// against the shared fixtures in testdata/run_metrics_summary.
+type RunMetricsSummary struct {
+	Engine      string                        `json:"engine,omitempty"`
+	Turns       int                           `json:"turns"`
+	Tokens      int                           `json:"tokens"`
+	CostUSD     float64                       `json:"cost_usd,omitempty"`
+	ToolCalls   []RunMetricsSummaryToolCall   `json:"tool_calls,omitempty"`
+	SafeOutputs []RunMetricsSummarySafeOutput `json:"safe_outputs,omitempty"`
+	Firewall    *RunMetricsSummaryFirewall    `json:"firewall,omitempty"`
+}
+
+// RunMetricsSummaryToolCall is the number of calls made to a single tool
+type RunMetricsSummaryToolCall struct {
+	Name  string `json:"name"`
+	Calls int    `json:"calls"`
+}
+
+// RunMetricsSummarySafeOutput is the number of safe output items of a single type
+type RunMetricsSummarySafeOutput struct {
+	Type  string `json:"type"`
+	Count int    `json:"count"`
+}
+
+// RunMetricsSummaryFirewall holds the firewall request counts for a run
+type RunMetricsSummaryFirewall struct {
+	Allowed        int      `json:"allowed"`
+	Blocked        int      `json:"blocked"`
+	BlockedDomains []string `json:"blocked_domains,omitempty"`
+}
+
+// buildRunMetricsSummary extracts the run metrics summary from audit data
+func buildRunMetricsSummary(data AuditData, metrics LogMetrics) RunMetricsSummary {
+	summary := RunMetricsSummary{
+		Turns:   data.Metrics.Turns,
+		Tokens:  data.Metrics.TokenUsage,
+		CostUSD: metrics.EstimatedCost,
+	}
+	if data.EngineConfig != nil {
+		summary.Engine = data.EngineConfig.EngineID
+	}
+	for _, tool := range data.ToolUsage {
+		summary.ToolCalls = append(summary.ToolCalls, RunMetricsSummaryToolCall{Name: tool.Name, Calls: tool.CallCount})
+	}
+	counts := make(map[string]int)
+	for _, item := range data.CreatedItems {
+		counts[item.Type]++
+	}
+	for itemType, count := range counts {
+		summary.SafeOutputs = append(summary.SafeOutputs, RunMetricsSummarySafeOutput{Type: itemType, Count: count})
+	}
+	if fa := data.FirewallAnalysis; fa != nil {
+		summary.Firewall = &RunMetricsSummaryFirewall{
+			Allowed:        fa.AllowedRequests,
+			Blocked:        fa.BlockedRequests,
+			BlockedDomains: fa.BlockedDomains,
+		}
+	}
+	runMetricsSummaryLog.Printf("Built run metrics summary: turns=%d, tokens=%d, tools=%d, safe outputs=%d",
+		summary.Turns, summary.Tokens, len(summary.ToolCalls), len(summary.SafeOutputs))
+	return summary
+}
+
+// renderRunMetricsSummaryMarkdown renders the run metrics summary as a collapsible markdown section
+func renderRunMetricsSummaryMarkdown(summary RunMetricsSummary) string {
+	var sb strings.Builder
+	sb.WriteString("<details>\n<summary>Run metrics</summary>\n\n")
+
+	sb.WriteString("| Metric | Value |\n| --- | --- |\n")
+	if summary.Engine != "" {
+		fmt.Fprintf(&sb, "| Engine | %s |\n", escapeMarkdownTableCell(summary.Engine))
+	}
+	fmt.Fprintf(&sb, "| Turns | %d |\n", summary.Turns)
+	fmt.Fprintf(&sb, "| Tokens | %d |\n", summary.Tokens)
+	if summary.CostUSD > 0 {
+		fmt.Fprintf(&sb, "| Estimated cost | $%s |\n", strconv.FormatFloat(summary.CostUSD, 'f', 2, 64))
+	}
+	if summary.Firewall != nil {
+		fmt.Fprintf(&sb, "| Firewall requests | %d allowed, %d blocked |\n", summary.Firewall.Allowed, summary.Firewall.Blocked)
+	}
+
+	if len(summary.ToolCalls) > 0 {
+		toolCalls := slices.Clone(summary.ToolCalls)
+		slices.SortFunc(toolCalls, func(a, b RunMetricsSummaryToolCall) int {
+			return cmp.Or(cmp.Compare(b.Calls, a.Calls), strings.Compare(a.Name, b.Name))
+		})
+		sb.WriteString("\n#### Tool calls\n\n| Tool | Calls |\n| --- | --- |\n")
+		for _, tool := range toolCalls {
+			fmt.Fprintf(&sb, "| %s | %d |\n", escapeMarkdownTableCell(tool.Name), tool.Calls)
+		}
+	}
+
+	if len(summary.SafeOutputs) > 0 {
+		safeOutputs := slices.Clone(summary.SafeOutputs)
+		slices.SortFunc(safeOutputs, func(a, b RunMetricsSummarySafeOutput) int {
+			return strings.Compare(a.Type, b.Type)
+		})
+		sb.WriteString("\n#### Safe outputs\n\n| Type | Count |\n| --- | --- |\n")
+		for _, output := range safeOutputs {
+			fmt.Fprintf(&sb, "| %s | %d |\n", escapeMarkdownTableCell(output.Type), output.Count)
+		}
+	}
+
+	if summary.Firewall != nil && len(summary.Firewall.BlockedDomains) > 0 {
+		domains := slices.Clone(summary.Firewall.BlockedDomains)
+		slices.Sort(domains)
+		sb.WriteString("\n#### Blocked domains\n\n")
+		for _, domain := range domains {
+			fmt.Fprintf(&sb, "- `%s`\n", domain)
+		}
+	}
+
+	sb.WriteString("\n</details>\n")
+	return sb.String()
+}
@@ -0,0 +1,63 @@
+//go:build !integration
+
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRenderRunMetricsSummaryMarkdown_Fixtures checks the Go renderer against the fixtures
+// shared with actions/setup/js/generate_run_metrics_summary.test.cjs, so the job summary
+// and `gh aw audit --format markdown` stay identical.
+func TestRenderRunMetricsSummaryMarkdown_Fixtures(t *testing.T) {
+	inputs, err := filepath.Glob(filepath.Join("testdata", "run_metrics_summary", "*.json"))
+	require.NoError(t, err, "fixture glob should succeed")
+	require.NotEmpty(t, inputs, "fixtures should exist")
+
+	for _, input := range inputs {
+		name := strings.TrimSuffix(filepath.Base(input), ".json")
+		t.Run(name, func(t *testing.T) {
+			content, err := os.ReadFile(input)
+			require.NoError(t, err, "should read fixture input")
+			var summary RunMetricsSummary
+			require.NoError(t, json.Unmarshal(content, &summary), "fixture input should unmarshal")
+
+			expected, err := os.ReadFile(strings.TrimSuffix(input, ".json") + ".md")
+			require.NoError(t, err, "should read fixture output")
+			assert.Equal(t, string(expected), renderRunMetricsSummaryMarkdown(summary), "rendered markdown should match fixture")
+		})
+	}
+}
+
+func TestBuildRunMetricsSummary(t *testing.T) {
+	data := AuditData{
+		Metrics:      MetricsData{Turns: 4, TokenUsage: 1200},
+		EngineConfig: &AuditEngineConfig{EngineID: "copilot"},
+		ToolUsage:    []ToolUsageInfo{{Name: "bash", CallCount: 2}},
+		CreatedItems: []CreatedItemReport{{Type: "add_comment"}, {Type: "add_comment"}, {Type: "create_issue"}},
+		FirewallAnalysis: &FirewallAnalysis{AnalysisBase: AnalysisBase{
+			DomainBuckets:   DomainBuckets{BlockedDomains: []string{"blocked.example.com"}},
+			AllowedRequests: 9,
+			BlockedRequests: 1,
+		}},
+	}
+
+	summary := buildRunMetricsSummary(data, LogMetrics{EstimatedCost: 0.05})
+
+	assert.Equal(t, "copilot", summary.Engine, "engine should come from the engine config")
+	assert.Equal(t, 4, summary.Turns, "turns should come from metrics")
+	assert.Equal(t, 1200, summary.Tokens, "tokens should come from metrics")
+	assert.InDelta(t, 0.05, summary.CostUSD, 0.0001, "cost should come from log metrics")
+	assert.Equal(t, []RunMetricsSummaryToolCall{{Name: "bash", Calls: 2}}, summary.ToolCalls, "tool calls should be copied")
+	assert.ElementsMatch(t, []RunMetricsSummarySafeOutput{{Type: "add_comment", Count: 2}, {Type: "create_issue", Count: 1}}, summary.SafeOutputs, "created items should be counted by type")
+	require.NotNil(t, summary.Firewall, "firewall stats should be present")
+	assert.Equal(t, 9, summary.Firewall.Allowed, "allowed requests")
+	assert.Equal(t, 1, summary.Firewall.Blocked, "blocked requests")
+}
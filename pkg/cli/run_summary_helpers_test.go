@@ -0,0 +1,24 @@
+//go:build !integration
+
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// writeCachedRunSummary writes summary as run-{RunID}/run_summary.json under dir,
+// mirroring the layout the logs command leaves in its download cache.
+func writeCachedRunSummary(t *testing.T, dir string, summary RunSummary) {
+	t.Helper()
+	runDir := filepath.Join(dir, "run-"+strconv.FormatInt(summary.RunID, 10))
+	require.NoError(t, os.MkdirAll(runDir, 0755), "create run dir")
+	data, err := json.Marshal(summary)
+	require.NoError(t, err, "marshal run summary")
+	require.NoError(t, os.WriteFile(filepath.Join(runDir, runSummaryFileName), data, 0644), "write run summary")
+}
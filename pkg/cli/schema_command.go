@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/spf13/cobra"
+)
+
+var schemaCommandLog = logger.New("cli:schema_command")
+
+// NewSchemaCommand creates the schema command
+func NewSchemaCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schema",
+		Short: "Export the workflow frontmatter JSON Schema for editors",
+		Long: `Export the JSON Schema used to validate agentic workflow frontmatter.
+
+The schema is the same one the compiler validates against, with engine IDs and
+network ecosystem identifiers added as completion suggestions. Register it with
+an editor (for example through yaml-language-server) to get completion and
+validation while editing workflow frontmatter.
+
+The output is stable for a given gh aw version: keys are sorted and the schema
+keeps its $id, so regenerating it only changes when the schema itself changes.`,
+		Example: `  ` + string(constants.CLIExtensionPrefix) + ` schema                                   # Print the schema to stdout
+  ` + string(constants.CLIExtensionPrefix) + ` schema -o .vscode/gh-aw.schema.json      # Write the schema to a file`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputPath, _ := cmd.Flags().GetString("output")
+			return RunSchema(outputPath)
+		},
+	}
+
+	cmd.Flags().StringP("output", "o", "", "Write the schema to a file instead of stdout")
+
+	return cmd
+}
+
+// RunSchema writes the editor-ready frontmatter schema to outputPath, or stdout when empty
+func RunSchema(outputPath string) error {
+	schemaCommandLog.Printf("Exporting frontmatter schema: output=%q", outputPath)
+
+	schemaJSON, err := buildEditorSchema()
+	if err != nil {
+		return err
+	}
+
+	if outputPath == "" {
+		fmt.Fprint(os.Stdout, string(schemaJSON))
+		return nil
+	}
+
+	if err := os.WriteFile(outputPath, schemaJSON, constants.FilePermPublic); err != nil {
+		return fmt.Errorf("failed to write schema to %s: %w", outputPath, err)
+	}
+	fmt.Fprintln(os.Stderr, console.FormatSuccessMessage("Wrote frontmatter schema to "+outputPath))
+	return nil
+}
+
+// buildEditorSchema returns the main workflow schema with completion suggestions for
+// values that the schema accepts as free-form strings (engine IDs, because catalog
+// entries are also valid, and network ecosystem identifiers, because domains are too).
+func buildEditorSchema() ([]byte, error) {
+	var doc map[string]any
+	if err := json.Unmarshal([]byte(parser.GetMainWorkflowSchema()), &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse frontmatter schema: %w", err)
+	}
+
+	engineIDs := toAnySlice(workflow.GetGlobalEngineRegistry().GetSupportedEngines())
+	for _, branch := range schemaOneOfBranches(schemaNode(doc, "$defs", "engine_config")) {
+		switch branch["type"] {
+		case "string":
+			branch["examples"] = engineIDs
+		case "object":
+			if id := schemaNode(branch, "properties", "id"); id != nil {
+				id["examples"] = engineIDs
+			}
+		}
+	}
+
+	ecosystems := toAnySlice(workflow.GetEcosystemIdentifiers())
+	for _, branch := range schemaOneOfBranches(schemaNode(doc, "properties", "network")) {
+		for _, field := range []string{"allowed", "blocked"} {
+			if items := schemaNode(branch, "properties", field, "items"); items != nil {
+				items["examples"] = ecosystems
+			}
+		}
+	}
+
+	schemaCommandLog.Printf("Added %d engine IDs and %d ecosystem identifiers as suggestions", len(engineIDs), len(ecosystems))
+
+	// Keep <, > and & readable in descriptions; Encode adds the trailing newline.
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return nil, fmt.Errorf("failed to marshal frontmatter schema: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// schemaNode walks nested schema objects by key and returns nil if any step is missing
+func schemaNode(node map[string]any, path ...string) map[string]any {
+	for _, key := range path {
+		next, ok := node[key].(map[string]any)
+		if !ok {
+			return nil
+		}
+		node = next
+	}
+	return node
+}
+
+// schemaOneOfBranches returns the object branches of a schema node's oneOf list
+func schemaOneOfBranches(node map[string]any) []map[string]any {
+	if node == nil {
+		return nil
+	}
+	rawBranches, _ := node["oneOf"].([]any)
+	branches := make([]map[string]any, 0, len(rawBranches))
+	for _, raw := range rawBranches {
+		if branch, ok := raw.(map[string]any); ok {
+			branches = append(branches, branch)
+		}
+	}
+	return branches
+}
+
+func toAnySlice(values []string) []any {
+	result := make([]any, len(values))
+	for i, v := range values {
+		result[i] = v
+	}
+	return result
+}
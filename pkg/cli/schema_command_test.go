@@ -0,0 +1,59 @@
+//go:build !integration
+
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/parser"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildEditorSchema(t *testing.T) {
+	schemaJSON, err := buildEditorSchema()
+	require.NoError(t, err, "schema export should succeed")
+
+	again, err := buildEditorSchema()
+	require.NoError(t, err, "second schema export should succeed")
+	assert.Equal(t, string(schemaJSON), string(again), "schema export should be byte-for-byte stable")
+
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal(schemaJSON, &doc), "exported schema should be valid JSON")
+	assert.Equal(t, "https://github.com/github/gh-aw/schemas/main_workflow_schema.json", doc["$id"], "exported schema should keep its $id")
+	assert.NotNil(t, schemaNode(doc, "properties", "safe-outputs", "properties", "create-issue"), "exported schema should include safe-outputs keys")
+
+	engineBranches := schemaOneOfBranches(schemaNode(doc, "$defs", "engine_config"))
+	require.NotEmpty(t, engineBranches, "engine_config should have oneOf branches")
+	assert.Contains(t, engineBranches[0]["examples"], "claude", "engine string branch should suggest engine IDs")
+	assert.Contains(t, schemaNode(engineBranches[1], "properties", "id")["examples"], "copilot", "engine.id should suggest engine IDs")
+
+	var allowedItems map[string]any
+	for _, branch := range schemaOneOfBranches(schemaNode(doc, "properties", "network")) {
+		if items := schemaNode(branch, "properties", "allowed", "items"); items != nil {
+			allowedItems = items
+		}
+	}
+	require.NotNil(t, allowedItems, "network.allowed items should exist")
+	assert.Contains(t, allowedItems["examples"], "python", "network.allowed should suggest ecosystem identifiers")
+	assert.Contains(t, allowedItems["examples"], "default-safe-outputs", "network.allowed should suggest compound ecosystem identifiers")
+
+	_, err = parser.CompileSchema(string(schemaJSON), "https://github.com/github/gh-aw/schemas/main_workflow_schema.json")
+	assert.NoError(t, err, "exported schema should compile as JSON Schema")
+}
+
+func TestRunSchema_WritesFile(t *testing.T) {
+	outputPath := filepath.Join(testutil.TempDir(t, "schema-export"), "gh-aw.schema.json")
+
+	require.NoError(t, RunSchema(outputPath), "schema export to file should succeed")
+
+	written, err := os.ReadFile(outputPath)
+	require.NoError(t, err, "schema file should be written")
+	expected, err := buildEditorSchema()
+	require.NoError(t, err, "schema export should succeed")
+	assert.Equal(t, string(expected), string(written), "file output should match the exported schema")
+}
@@ -12,14 +12,20 @@ func NewStatusCommand() *cobra.Command {
 		Short: "Show status of all agentic workflows in the repository",
 		Long: `Show status of all agentic workflows in the repository.
 
-Displays a table with workflow name, AI engine, compilation status, enabled/disabled state,
-and time remaining until expiration (if stop-after is configured).
+Displays a table with workflow name, AI engine, compilation status (whether the lock file
+is up to date with its markdown source), enabled/disabled state, and time remaining until
+expiration (if stop-after is configured).
+
+The latest run of each workflow is shown with its status, conclusion, age, and duration.
+Token usage and AI credits are read from run summaries cached by the logs and audit
+commands; runs that have not been downloaded show no token cost.
 
 The optional pattern argument filters workflows by name (case-insensitive substring match).
 It accepts workflow IDs (basename without .md) or full filenames.`,
 		Example: `  ` + string(constants.CLIExtensionPrefix) + ` status                           # Show all workflow status
   ` + string(constants.CLIExtensionPrefix) + ` status ci-                       # Show workflows with 'ci-' in name
   ` + string(constants.CLIExtensionPrefix) + ` status --json                    # Output in JSON format
+  ` + string(constants.CLIExtensionPrefix) + ` status --porcelain               # Output tab-separated records for scripts
   ` + string(constants.CLIExtensionPrefix) + ` status --ref main                # Show latest run status for main branch
   ` + string(constants.CLIExtensionPrefix) + ` status --label automation        # Show workflows with 'automation' label
   ` + string(constants.CLIExtensionPrefix) + ` status --repo owner/other-repo   # Check status in different repository`,
@@ -33,12 +39,18 @@ It accepts workflow IDs (basename without .md) or full filenames.`,
 			ref, _ := cmd.Flags().GetString("ref")
 			labelFilter, _ := cmd.Flags().GetString("label")
 			repoOverride, _ := cmd.Flags().GetString("repo")
-			statusLog.Printf("Status command invoked: pattern=%q, json=%v, ref=%q, label=%q, repo=%q", pattern, jsonFlag, ref, labelFilter, repoOverride)
+			porcelain, _ := cmd.Flags().GetBool("porcelain")
+			statusLog.Printf("Status command invoked: pattern=%q, json=%v, porcelain=%v, ref=%q, label=%q, repo=%q", pattern, jsonFlag, porcelain, ref, labelFilter, repoOverride)
+			if porcelain {
+				return StatusWorkflowsPorcelain(pattern, ref, labelFilter, repoOverride)
+			}
 			return StatusWorkflows(pattern, verbose, jsonFlag, ref, labelFilter, repoOverride)
 		},
 	}
 
 	addJSONFlag(cmd)
+	addPorcelainFlag(cmd)
+	cmd.MarkFlagsMutuallyExclusive("json", "porcelain")
 	cmd.Flags().StringP("repo", "r", "", "Target repository ([HOST/]owner/repo format). Defaults to current repository")
 	cmd.Flags().String("ref", "", "Filter runs by branch or tag name (e.g., main, v1.0.0)")
 	cmd.Flags().String("label", "", "Filter workflows by label")
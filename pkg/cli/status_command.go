@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -19,6 +20,7 @@ import (
 	"github.com/github/gh-aw/pkg/logger"
 	"github.com/github/gh-aw/pkg/parser"
 	"github.com/github/gh-aw/pkg/styles"
+	"github.com/github/gh-aw/pkg/timeutil"
 	"github.com/github/gh-aw/pkg/workflow"
 )
 
@@ -35,13 +37,26 @@ type WorkflowStatus struct {
 	RunID         int64    `json:"run_id,omitempty" console:"header:run id,omitempty"`
 	RunStatus     string   `json:"run_status,omitempty" console:"header:status,omitempty"`
 	RunConclusion string   `json:"run_conclusion,omitempty" console:"header:conclusion,omitempty"`
+	RunStartedAt  string   `json:"run_started_at,omitempty" console:"-"`
+	LastRun       string   `json:"last_run,omitempty" console:"header:last run,omitempty"`
+	RunDuration   string   `json:"run_duration,omitempty" console:"header:duration,omitempty"`
+	RunTokens     int      `json:"run_tokens,omitempty" console:"header:tokens,format:number,omitempty"`
+	RunAIC        float64  `json:"run_aic,omitempty" console:"header:aic,omitempty"`
 }
 
 // GetWorkflowStatuses retrieves workflow status information and returns it as a slice.
 // This function is designed for programmatic access (e.g., from MCP server).
 // For CLI usage, use StatusWorkflows which handles output formatting.
+// Latest run details are only fetched when ref is set.
 func GetWorkflowStatuses(pattern string, ref string, labelFilter string, repoOverride string) ([]WorkflowStatus, error) {
-	statusLog.Printf("Getting workflow statuses: pattern=%s, ref=%s, labelFilter=%s, repo=%s", pattern, ref, labelFilter, repoOverride)
+	return getWorkflowStatuses(pattern, ref, labelFilter, repoOverride, ref != "")
+}
+
+// getWorkflowStatuses builds the workflow status list. When includeRuns is true the
+// latest run of each workflow (on ref, or on any branch when ref is empty) is fetched
+// and enriched with duration and token cost from cached run summaries.
+func getWorkflowStatuses(pattern string, ref string, labelFilter string, repoOverride string, includeRuns bool) ([]WorkflowStatus, error) {
+	statusLog.Printf("Getting workflow statuses: pattern=%s, ref=%s, labelFilter=%s, repo=%s, includeRuns=%v", pattern, ref, labelFilter, repoOverride, includeRuns)
 
 	// Get GitHub workflows data
 	statusLog.Print("Fetching GitHub workflow status")
@@ -53,10 +68,10 @@ func GetWorkflowStatuses(pattern string, ref string, labelFilter string, repoOve
 		statusLog.Printf("Successfully fetched %d GitHub workflows", len(githubWorkflows))
 	}
 
-	// Fetch latest workflow runs for ref if specified
+	// Fetch latest workflow runs (restricted to ref if specified)
 	var latestRunsByWorkflow map[string]*WorkflowRun
-	if ref != "" {
-		latestRunsByWorkflow, err = fetchLatestRunsByRef(ref, repoOverride, false)
+	if includeRuns {
+		latestRunsByWorkflow, err = fetchLatestRunsByRef(ref, repoOverride, githubWorkflows, false)
 		if err != nil {
 			statusLog.Printf("Failed to fetch workflow runs for ref %s: %v", ref, err)
 			latestRunsByWorkflow = make(map[string]*WorkflowRun)
@@ -167,19 +182,8 @@ func GetWorkflowStatuses(pattern string, ref string, labelFilter string, repoOve
 			}
 		}
 
-		// Get run status for ref if available
-		var runID int64
-		var runStatus, runConclusion string
-		if latestRunsByWorkflow != nil {
-			if run, exists := latestRunsByWorkflow[name]; exists {
-				runID = run.DatabaseID
-				runStatus = run.Status
-				runConclusion = run.Conclusion
-			}
-		}
-
 		// Build status object
-		statuses = append(statuses, WorkflowStatus{
+		workflowStatus := WorkflowStatus{
 			WorkflowListItem: WorkflowListItem{
 				Workflow: name,
 				EngineID: agent,
@@ -190,10 +194,11 @@ func GetWorkflowStatuses(pattern string, ref string, labelFilter string, repoOve
 			Status:        status,
 			TimeRemaining: timeRemaining,
 			Dependencies:  dependencies,
-			RunID:         runID,
-			RunStatus:     runStatus,
-			RunConclusion: runConclusion,
-		})
+		}
+		if run, exists := latestRunsByWorkflow[name]; exists {
+			applyLatestRun(&workflowStatus, run, time.Now())
+		}
+		statuses = append(statuses, workflowStatus)
 	}
 
 	return statuses, nil
@@ -215,31 +220,60 @@ func buildRemoteWorkflowStatuses(pattern string, githubWorkflows map[string]*Git
 			status = "disabled"
 		}
 
-		var runID int64
-		var runStatus, runConclusion string
-		if latestRunsByWorkflow != nil {
-			if run, exists := latestRunsByWorkflow[name]; exists {
-				runID = run.DatabaseID
-				runStatus = run.Status
-				runConclusion = run.Conclusion
-			}
-		}
-
-		statuses = append(statuses, WorkflowStatus{
+		workflowStatus := WorkflowStatus{
 			// Remote workflow status only includes the workflow name here; the
 			// GitHub Actions API response does not provide list metadata fields.
 			WorkflowListItem: WorkflowListItem{
 				Workflow: name,
 			},
-			Status:        status,
-			RunID:         runID,
-			RunStatus:     runStatus,
-			RunConclusion: runConclusion,
-		})
+			Status: status,
+		}
+		if run, exists := latestRunsByWorkflow[name]; exists {
+			applyLatestRun(&workflowStatus, run, time.Now())
+		}
+		statuses = append(statuses, workflowStatus)
 	}
 	return statuses
 }
 
+// applyLatestRun copies the latest run's status onto workflowStatus, along with its
+// age, duration, and token cost. Token usage and AI credits come from the run
+// summary cached by `gh aw logs`/`gh aw audit`; they stay empty for runs that have
+// not been downloaded yet.
+func applyLatestRun(workflowStatus *WorkflowStatus, run *WorkflowRun, now time.Time) {
+	workflowStatus.RunID = run.DatabaseID
+	workflowStatus.RunStatus = run.Status
+	workflowStatus.RunConclusion = run.Conclusion
+
+	startedAt := run.StartedAt
+	if startedAt.IsZero() {
+		startedAt = run.CreatedAt
+	}
+	if !startedAt.IsZero() {
+		workflowStatus.RunStartedAt = startedAt.UTC().Format(time.RFC3339)
+		workflowStatus.LastRun = timeutil.FormatDuration(now.Sub(startedAt).Truncate(time.Second)) + " ago"
+		if run.Status == "completed" && run.UpdatedAt.After(startedAt) {
+			workflowStatus.RunDuration = timeutil.FormatDuration(run.UpdatedAt.Sub(startedAt))
+		}
+	}
+
+	summary, ok := loadRunSummary(filepath.Join(defaultLogsOutputDir, fmt.Sprintf("run-%d", run.DatabaseID)), false)
+	if !ok || summary == nil {
+		return
+	}
+	statusLog.Printf("Using cached run summary for run %d", run.DatabaseID)
+	workflowStatus.RunTokens = summary.Run.TokenUsage
+	if summary.TokenUsage != nil {
+		workflowStatus.RunAIC = summary.TokenUsage.TotalAIC
+		if workflowStatus.RunTokens == 0 {
+			workflowStatus.RunTokens = summary.TokenUsage.TotalTokens()
+		}
+	}
+	if workflowStatus.RunDuration == "" && summary.Run.Duration > 0 {
+		workflowStatus.RunDuration = timeutil.FormatDuration(summary.Run.Duration)
+	}
+}
+
 func StatusWorkflows(pattern string, verbose bool, jsonOutput bool, ref string, labelFilter string, repoOverride string) error {
 	statusLog.Printf("Checking workflow status: pattern=%s, jsonOutput=%v, ref=%s, labelFilter=%s, repo=%s", pattern, jsonOutput, ref, labelFilter, repoOverride)
 	if verbose && !jsonOutput {
@@ -254,8 +288,8 @@ func StatusWorkflows(pattern string, verbose bool, jsonOutput bool, ref string,
 		fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Fetching GitHub workflow status..."))
 	}
 
-	// Get workflow statuses
-	statuses, err := GetWorkflowStatuses(pattern, ref, labelFilter, repoOverride)
+	// Get workflow statuses, including the latest run of each workflow
+	statuses, err := getWorkflowStatuses(pattern, ref, labelFilter, repoOverride, true)
 	if err != nil {
 		statusLog.Printf("Failed to get workflow statuses: %v", err)
 		fmt.Fprintln(os.Stderr, console.FormatErrorMessage(err.Error()))
@@ -296,6 +330,28 @@ func StatusWorkflows(pattern string, verbose bool, jsonOutput bool, ref string,
 	return nil
 }
 
+// StatusWorkflowsPorcelain prints workflow status as porcelain records, one per workflow:
+//
+//	workflow <name> <engine> <compiled> <state> <run-id> <run-status> <run-conclusion>
+//
+// Unlike StatusWorkflows, errors are returned so the exit code reflects them.
+func StatusWorkflowsPorcelain(pattern string, ref string, labelFilter string, repoOverride string) error {
+	statusLog.Printf("Checking workflow status (porcelain): pattern=%s, ref=%s, labelFilter=%s, repo=%s", pattern, ref, labelFilter, repoOverride)
+	statuses, err := getWorkflowStatuses(pattern, ref, labelFilter, repoOverride, true)
+	if err != nil {
+		return err
+	}
+	writeStatusPorcelain(os.Stdout, statuses)
+	return nil
+}
+
+// writeStatusPorcelain writes one porcelain record per workflow status.
+func writeStatusPorcelain(w io.Writer, statuses []WorkflowStatus) {
+	for _, s := range statuses {
+		writePorcelainRecord(w, "workflow", s.Workflow, s.EngineID, s.Compiled, s.Status, porcelainInt(s.RunID), s.RunStatus, s.RunConclusion)
+	}
+}
+
 // Removed duplicate code - now everything goes through GetWorkflowStatuses
 
 // calculateTimeRemaining calculates and formats the time remaining until stop-time
@@ -481,18 +537,28 @@ func isCompiledUpToDateWithCache(workflowPath, lockFilePath string, cache *parse
 	return "No"
 }
 
-// fetchLatestRunsByRef fetches the latest workflow run for each workflow from a specific ref (branch or tag)
-func fetchLatestRunsByRef(ref string, repoOverride string, verbose bool) (map[string]*WorkflowRun, error) {
+// fetchLatestRunsByRef fetches the latest workflow run for each workflow from a specific ref
+// (branch or tag), or across all branches when ref is empty. Runs are keyed by workflow file
+// name, resolved through githubWorkflows when available.
+func fetchLatestRunsByRef(ref string, repoOverride string, githubWorkflows map[string]*GitHubWorkflow, verbose bool) (map[string]*WorkflowRun, error) {
 	statusLog.Printf("Fetching latest workflow runs for ref: %s, repo: %s", ref, repoOverride)
 
 	// Start spinner for network operation (only if not in verbose mode)
-	spinner := console.NewSpinner("Fetching workflow runs for ref...")
+	spinnerMessage := "Fetching latest workflow runs..."
+	if ref != "" {
+		spinnerMessage = "Fetching workflow runs for ref..."
+	}
+	spinner := console.NewSpinner(spinnerMessage)
 	if !verbose {
 		spinner.Start()
 	}
 
 	// Fetch workflow runs for the ref (uses --branch flag which also works for tags)
-	args := []string{"run", "list", "--branch", ref, "--json", "databaseId,number,url,status,conclusion,workflowName,createdAt,headBranch", "--limit", "100"}
+	args := []string{"run", "list"}
+	if ref != "" {
+		args = append(args, "--branch", ref)
+	}
+	args = append(args, "--json", "databaseId,number,url,status,conclusion,workflowName,workflowDatabaseId,createdAt,startedAt,updatedAt,headBranch", "--limit", "200")
 	if repoOverride != "" {
 		args = append(args, "--repo", repoOverride)
 	}
@@ -562,18 +628,31 @@ func fetchLatestRunsByRef(ref string, repoOverride string, verbose bool) (map[st
 		spinner.StopWithMessage(fmt.Sprintf("✓ Fetched %d workflow runs", len(runs)))
 	}
 
-	// Build map of latest run for each workflow (first occurrence is the latest)
+	latestRuns := latestRunsByWorkflowName(runs, githubWorkflows)
+
+	statusLog.Printf("Fetched latest runs for %d workflows on ref %s", len(latestRuns), ref)
+	return latestRuns, nil
+}
+
+// latestRunsByWorkflowName keeps the first (latest) run of each workflow. Runs are keyed
+// by the workflow file name when their workflow ID is known, since the run's workflowName
+// is the display name, which differs from the file name for most agentic workflows.
+func latestRunsByWorkflowName(runs []WorkflowRun, githubWorkflows map[string]*GitHubWorkflow) map[string]*WorkflowRun {
+	namesByID := make(map[int64]string, len(githubWorkflows))
+	for name, wf := range githubWorkflows {
+		namesByID[wf.ID] = name
+	}
+
 	latestRuns := make(map[string]*WorkflowRun)
 	for i := range runs {
 		run := &runs[i]
-		// Extract workflow name from workflowName field
-		workflowName := extractWorkflowNameFromPath(run.WorkflowName)
-		// Only keep the first (latest) run for each workflow
+		workflowName, ok := namesByID[run.WorkflowDatabaseID]
+		if !ok || run.WorkflowDatabaseID == 0 {
+			workflowName = extractWorkflowNameFromPath(run.WorkflowName)
+		}
 		if _, exists := latestRuns[workflowName]; !exists {
 			latestRuns[workflowName] = run
 		}
 	}
-
-	statusLog.Printf("Fetched latest runs for %d workflows on ref %s", len(latestRuns), ref)
-	return latestRuns, nil
+	return latestRuns
 }
@@ -0,0 +1,80 @@
+//go:build !integration
+
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatestRunsByWorkflowName(t *testing.T) {
+	githubWorkflows := map[string]*GitHubWorkflow{
+		"smoke-copilot": {ID: 11, Name: "Smoke Copilot", Path: ".github/workflows/smoke-copilot.lock.yml"},
+		"daily-report":  {ID: 22, Name: "Daily Report", Path: ".github/workflows/daily-report.lock.yml"},
+	}
+	runs := []WorkflowRun{
+		{DatabaseID: 3, WorkflowName: "Smoke Copilot", WorkflowDatabaseID: 11},
+		{DatabaseID: 2, WorkflowName: "Smoke Copilot", WorkflowDatabaseID: 11},
+		{DatabaseID: 1, WorkflowName: "Daily Report", WorkflowDatabaseID: 22},
+		{DatabaseID: 4, WorkflowName: "ci"},
+	}
+
+	latest := latestRunsByWorkflowName(runs, githubWorkflows)
+
+	require.Contains(t, latest, "smoke-copilot", "run should be keyed by workflow file name")
+	assert.Equal(t, int64(3), latest["smoke-copilot"].DatabaseID, "first run should be kept as the latest")
+	require.Contains(t, latest, "daily-report", "run should be keyed by workflow file name")
+	assert.Equal(t, int64(1), latest["daily-report"].DatabaseID, "latest daily-report run should be kept")
+	assert.Contains(t, latest, "ci", "runs of unknown workflows should fall back to the display name")
+	assert.NotContains(t, latest, "Smoke Copilot", "display names should not be used when the workflow ID is known")
+}
+
+func TestApplyLatestRun(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	t.Run("completed run", func(t *testing.T) {
+		run := &WorkflowRun{
+			DatabaseID: 987654321,
+			Status:     "completed",
+			Conclusion: "success",
+			StartedAt:  now.Add(-2 * time.Hour),
+			UpdatedAt:  now.Add(-2*time.Hour + 3*time.Minute + 30*time.Second),
+		}
+		var status WorkflowStatus
+		applyLatestRun(&status, run, now)
+
+		assert.Equal(t, int64(987654321), status.RunID, "run ID should be copied")
+		assert.Equal(t, "completed", status.RunStatus, "run status should be copied")
+		assert.Equal(t, "success", status.RunConclusion, "run conclusion should be copied")
+		assert.Equal(t, "2026-03-10T10:00:00Z", status.RunStartedAt, "start time should be RFC3339")
+		assert.Equal(t, "2.0h ago", status.LastRun, "last run should be relative to now")
+		assert.Equal(t, "3.5m", status.RunDuration, "duration should span start to last update")
+		assert.Zero(t, status.RunTokens, "tokens should be empty without a cached summary")
+	})
+
+	t.Run("in progress run has no duration", func(t *testing.T) {
+		run := &WorkflowRun{
+			DatabaseID: 987654322,
+			Status:     "in_progress",
+			StartedAt:  now.Add(-time.Minute),
+			UpdatedAt:  now,
+		}
+		var status WorkflowStatus
+		applyLatestRun(&status, run, now)
+
+		assert.Equal(t, "in_progress", status.RunStatus, "run status should be copied")
+		assert.Empty(t, status.RunDuration, "running workflows should not report a duration")
+		assert.NotEmpty(t, status.LastRun, "running workflows should report when they started")
+	})
+
+	t.Run("falls back to created time", func(t *testing.T) {
+		run := &WorkflowRun{DatabaseID: 987654323, Status: "queued", CreatedAt: now.Add(-30 * time.Second)}
+		var status WorkflowStatus
+		applyLatestRun(&status, run, now)
+
+		assert.Equal(t, "2026-03-10T11:59:30Z", status.RunStartedAt, "created time should be used when the run has not started")
+	})
+}
@@ -23,6 +23,8 @@ Repository modes:
 - --logical-repo REPO: Simulates execution against a specified repository (github.repository context points to REPO while actually running in a temporary trial repository)
 - --host-repo REPO: Uses the specified repository as the host for trial execution instead of creating a temporary one
 - --clone-repo REPO: Clones the specified repository's contents into the trial repository before execution (useful for testing against actual repository state)
+- --repo REPO: Sandbox mode. Copies REPO into a new private sandbox repository, runs the workflows there, saves an audit
+  report for each run to trials/audits/, and deletes the sandbox afterward (use --keep-sandbox to retain it)
 
 All workflows must support the workflow_dispatch trigger to be used in trial mode.
 The host repository will be created as a private repository and retained by default unless --delete-host-repo-after is specified.
@@ -33,6 +35,7 @@ Trial results are saved both locally (in the trials/ directory) and in the host
   ` + string(constants.CLIExtensionPrefix) + ` trial githubnext/agentics/my-workflow --host-repo myorg/myrepo    # Use an existing host repository
   ` + string(constants.CLIExtensionPrefix) + ` trial githubnext/agentics/my-workflow --logical-repo myorg/myrepo # Simulate a different github.repository value
   ` + string(constants.CLIExtensionPrefix) + ` trial githubnext/agentics/my-workflow --clone-repo myorg/myrepo   # Clone repository contents into the trial host
+  ` + string(constants.CLIExtensionPrefix) + ` trial ./my-workflow.md --repo myorg/myrepo                       # Run in a disposable sandbox copy and audit the run
   ` + string(constants.CLIExtensionPrefix) + ` trial githubnext/agentics/my-workflow --repeat 3                  # Run 4 times total (1 initial + 3 repeats)
   ` + string(constants.CLIExtensionPrefix) + ` trial githubnext/agentics/my-workflow --delete-host-repo-after    # Delete the trial host repository when done
   ` + string(constants.CLIExtensionPrefix) + ` trial githubnext/agentics/my-workflow --dry-run                   # Preview changes without executing
@@ -54,6 +57,9 @@ Trial results are saved both locally (in the trials/ directory) and in the host
 			logicalRepoSpec, _ := cmd.Flags().GetString("logical-repo")
 			cloneRepoSpec, _ := cmd.Flags().GetString("clone-repo")
 			hostRepoSpec, _ := cmd.Flags().GetString("host-repo")
+			sandboxRepoSpec, _ := cmd.Flags().GetString("repo")
+			keepSandbox, _ := cmd.Flags().GetBool("keep-sandbox")
+			audit, _ := cmd.Flags().GetBool("audit")
 			deleteHostRepo, _ := cmd.Flags().GetBool("delete-host-repo-after")
 			legacyForceDelete, _ := cmd.Flags().GetBool("force-delete-host-repo-before")
 			deleteHostRepoBefore, _ := cmd.Flags().GetBool("delete-host-repo-before")
@@ -85,6 +91,7 @@ Trial results are saved both locally (in the trials/ directory) and in the host
 					LogicalRepo: logicalRepoSpec,
 					CloneRepo:   cloneRepoSpec,
 					HostRepo:    hostRepoSpec,
+					SandboxRepo: sandboxRepoSpec,
 				},
 				DeleteHostRepo:         deleteHostRepo,
 				ForceDelete:            forceDeleteHostRepo,
@@ -99,6 +106,8 @@ Trial results are saved both locally (in the trials/ directory) and in the host
 				AppendText:             appendText,
 				Verbose:                verbose,
 				DisableSecurityScanner: disableSecurityScanner,
+				KeepSandbox:            keepSandbox,
+				Audit:                  audit,
 			}
 
 			if err := RunWorkflowTrials(cmd.Context(), workflowSpecs, opts); err != nil {
@@ -113,6 +122,9 @@ Trial results are saved both locally (in the trials/ directory) and in the host
 	cmd.Flags().String("clone-repo", "", "Clone the contents of the specified repository into the host repository before execution (useful for testing against actual repository state)")
 
 	cmd.Flags().String("host-repo", "", "Custom host repository slug (defaults to '<username>/gh-aw-trial'). Use '.' for current repository")
+	cmd.Flags().StringP("repo", "r", "", "Run in a disposable private sandbox copy of this repository, audit each run, and delete the sandbox afterward")
+	cmd.Flags().Bool("keep-sandbox", false, "Keep the sandbox repository created by --repo instead of deleting it")
+	cmd.Flags().Bool("audit", false, "Audit each trial run and save the report to trials/audits/ (always on with --repo)")
 	cmd.Flags().Bool("delete-host-repo-after", false, "Delete the host repository after completion (retained by default)")
 	cmd.Flags().Bool("delete-host-repo-before", false, "Delete the host repository before creation if it already exists")
 	cmd.Flags().Bool("force-delete-host-repo-before", false, "Delete the host repository before creation if it already exists")
@@ -129,7 +141,8 @@ Trial results are saved both locally (in the trials/ directory) and in the host
 	cmd.Flags().Bool("no-security-scanner", false, "Skip security scanning of workflow markdown content")
 	cmd.Flags().Bool("disable-security-scanner", false, "Skip security scanning of workflow markdown content")
 	_ = cmd.Flags().MarkDeprecated("disable-security-scanner", "use --no-security-scanner instead")
-	cmd.MarkFlagsMutuallyExclusive("logical-repo", "clone-repo")
+	cmd.MarkFlagsMutuallyExclusive("logical-repo", "clone-repo", "repo")
+	cmd.MarkFlagsMutuallyExclusive("host-repo", "repo")
 
 	return cmd
 }
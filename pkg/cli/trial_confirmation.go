@@ -32,6 +32,8 @@ type trialConfirmationOptions struct {
 	repeatCount         int
 	directTrialMode     bool
 	engineOverride      string
+	sandbox             bool
+	audit               bool
 }
 
 // showTrialConfirmation displays a confirmation prompt to the user using parsed workflow specs
@@ -65,7 +67,11 @@ func showTrialConfirmation(opts trialConfirmationOptions) error {
 
 	// Display target repository info based on mode
 	var modeInfo strings.Builder
-	if opts.cloneRepoSlug != "" {
+	if opts.sandbox {
+		// Sandbox mode
+		fmt.Fprintf(&modeInfo, "Source:    %s (will be copied)\n", opts.cloneRepoSlug)
+		modeInfo.WriteString("Mode:      Run in a disposable sandbox copy of the repository")
+	} else if opts.cloneRepoSlug != "" {
 		// Clone-repo mode
 		fmt.Fprintf(&modeInfo, "Source:    %s (will be cloned)\n", opts.cloneRepoSlug)
 		modeInfo.WriteString("Mode:      Clone repository contents into host repository")
@@ -116,6 +122,10 @@ func showTrialConfirmation(opts trialConfirmationOptions) error {
 		configInfo.WriteString("\nAuto-merge: Pull requests will be automatically merged")
 	}
 
+	if opts.audit {
+		configInfo.WriteString("\nAudit:     Audit reports will be saved to " + trialAuditDir)
+	}
+
 	sections = append(sections, console.RenderInfoSection(configInfo.String())...)
 
 	sections = append(sections, "")
@@ -216,6 +226,12 @@ func showTrialConfirmation(opts trialConfirmationOptions) error {
 	}
 	stepNum++
 
+	// Step: Collect audit results before the host repository can be deleted
+	if opts.audit {
+		fmt.Fprintf(os.Stderr, console.FormatInfoMessageStderr("  %d. Audit each run and save the reports to %s\n"), stepNum, trialAuditDir)
+		stepNum++
+	}
+
 	// Final step: Delete/preserve repository
 	if opts.deleteHostRepo {
 		fmt.Fprintf(os.Stderr, console.FormatInfoMessageStderr("  %d. Delete the host repository\n"), stepNum)
@@ -119,6 +119,15 @@ func executeTrialRun(ctx context.Context, parsedSpecs []*WorkflowSpec, hostRepoS
 			return fmt.Errorf("failed to download artifacts for '%s': %w", parsedSpec.WorkflowName, err)
 		}
 
+		// Audit the run while it still exists; sandbox host repositories are deleted afterward
+		var auditDir string
+		if opts.Audit {
+			auditDir, err = auditTrialRun(ctx, hostRepoSlug, runID, opts.Verbose)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Failed to collect audit results: %v", err)))
+			}
+		}
+
 		// Save individual workflow results
 		result := WorkflowTrialResult{
 			WorkflowName: parsedSpec.WorkflowName,
@@ -127,6 +136,7 @@ func executeTrialRun(ctx context.Context, parsedSpecs []*WorkflowSpec, hostRepoS
 			//AgentStdioLogs:      artifacts.AgentStdioLogs,
 			AgenticRunInfo:      artifacts.AgenticRunInfo,
 			AdditionalArtifacts: artifacts.AdditionalArtifacts,
+			AuditDir:            auditDir,
 			Timestamp:           time.Now(),
 		}
 		workflowResults = append(workflowResults, result)
@@ -159,6 +169,10 @@ func executeTrialRun(ctx context.Context, parsedSpecs []*WorkflowSpec, hostRepoS
 			fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("=== Additional Artifacts Available from %s (%d files) ===", parsedSpec.WorkflowName, len(artifacts.AdditionalArtifacts))))
 		}
 
+		if auditDir != "" {
+			fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("=== Audit Results from %s saved to %s ===", parsedSpec.WorkflowName, auditDir)))
+		}
+
 		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage("Trial completed for workflow: "+parsedSpec.WorkflowName))
 	}
 
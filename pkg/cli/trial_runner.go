@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/github/gh-aw/pkg/console"
 	"github.com/github/gh-aw/pkg/logger"
@@ -17,7 +18,13 @@ var trialLog = logger.New("cli:trial_command")
 
 // RunWorkflowTrials executes the main logic for trialing one or more workflows
 func RunWorkflowTrials(ctx context.Context, workflowSpecs []string, opts TrialOptions) error {
-	trialLog.Printf("Starting trial execution: specs=%v, logicalRepo=%s, cloneRepo=%s, hostRepo=%s, repeat=%d", workflowSpecs, opts.Repos.LogicalRepo, opts.Repos.CloneRepo, opts.Repos.HostRepo, opts.RepeatCount)
+	trialLog.Printf("Starting trial execution: specs=%v, logicalRepo=%s, cloneRepo=%s, hostRepo=%s, sandboxRepo=%s, repeat=%d", workflowSpecs, opts.Repos.LogicalRepo, opts.Repos.CloneRepo, opts.Repos.HostRepo, opts.Repos.SandboxRepo, opts.RepeatCount)
+
+	// Sandbox mode audits every run and tears the sandbox down afterward unless asked to keep it
+	if opts.Repos.SandboxRepo != "" {
+		opts.Audit = true
+		opts.DeleteHostRepo = opts.DeleteHostRepo || !opts.KeepSandbox
+	}
 
 	// Show welcome banner for interactive mode
 	console.ShowWelcomeBanner("This tool will run a trial of your workflow in a test repository.")
@@ -50,7 +57,20 @@ func RunWorkflowTrials(ctx context.Context, workflowSpecs []string, opts TrialOp
 	var cloneRepoVersion string
 	var directTrialMode bool
 
-	if opts.Repos.CloneRepo != "" {
+	if opts.Repos.SandboxRepo != "" {
+		// Use sandbox mode: copy the repository into a new disposable host repository
+		sandboxRepo, err := parseRepoSpec(opts.Repos.SandboxRepo)
+		if err != nil {
+			return fmt.Errorf("invalid --repo specification '%s': %w", opts.Repos.SandboxRepo, err)
+		}
+
+		cloneRepoSlug = sandboxRepo.RepoSlug
+		cloneRepoVersion = sandboxRepo.Version
+		logicalRepoSlug = ""
+		directTrialMode = false
+		trialLog.Printf("Using sandbox mode: %s (version=%s)", cloneRepoSlug, cloneRepoVersion)
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Sandbox mode: Will copy %s into a disposable sandbox repository", cloneRepoSlug)))
+	} else if opts.Repos.CloneRepo != "" {
 		// Use clone-repo mode: clone the specified repo contents into host repo
 		cloneRepo, err := parseRepoSpec(opts.Repos.CloneRepo)
 		if err != nil {
@@ -106,6 +126,15 @@ func RunWorkflowTrials(ctx context.Context, workflowSpecs []string, opts TrialOp
 		}
 		hostRepoSlug = hostRepo.RepoSlug
 		trialLog.Printf("Using specified host repository: %s", hostRepoSlug)
+	} else if opts.Repos.SandboxRepo != "" {
+		// Use a fresh sandbox repository so an existing host repository is never overwritten
+		username, err := getCurrentGitHubUsername(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get GitHub username for sandbox repo: %w", err)
+		}
+		hostRepoSlug = sandboxHostRepoSlug(username, cloneRepoSlug, time.Now())
+		trialLog.Printf("Using sandbox host repository: %s", hostRepoSlug)
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Host repository (sandbox): "+hostRepoSlug))
 	} else {
 		// Use default trial repo with current username
 		username, err := getCurrentGitHubUsername(ctx)
@@ -130,6 +159,8 @@ func RunWorkflowTrials(ctx context.Context, workflowSpecs []string, opts TrialOp
 			repeatCount:         opts.RepeatCount,
 			directTrialMode:     directTrialMode,
 			engineOverride:      opts.EngineOverride,
+			sandbox:             opts.Repos.SandboxRepo != "",
+			audit:               opts.Audit,
 		}); err != nil {
 			return err
 		}
@@ -147,6 +178,15 @@ func RunWorkflowTrials(ctx context.Context, workflowSpecs []string, opts TrialOp
 		return nil
 	}
 
+	// Set up cleanup if requested, before any step that can fail so sandboxes are never left behind
+	if opts.DeleteHostRepo {
+		defer func() {
+			if err := cleanupTrialRepository(hostRepoSlug, opts.Verbose); err != nil {
+				fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Failed to cleanup host repository: %v", err)))
+			}
+		}()
+	}
+
 	// Step 2.5: Ensure engine secrets are configured when an explicit engine override is provided
 	// When no override is specified, the workflow will use its frontmatter engine and handle secrets during compilation
 	if opts.EngineOverride != "" {
@@ -173,15 +213,6 @@ func RunWorkflowTrials(ctx context.Context, workflowSpecs []string, opts TrialOp
 		}
 	}
 
-	// Set up cleanup if requested
-	if opts.DeleteHostRepo {
-		defer func() {
-			if err := cleanupTrialRepository(hostRepoSlug, opts.Verbose); err != nil {
-				fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Failed to cleanup host repository: %v", err)))
-			}
-		}()
-	}
-
 	// Step 2.7: Clone source repository contents if in clone-repo mode
 	if cloneRepoSlug != "" {
 		if err := cloneRepoContentsIntoHost(cloneRepoSlug, cloneRepoVersion, hostRepoSlug, opts.Verbose); err != nil {
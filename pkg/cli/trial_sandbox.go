@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/stringutil"
+)
+
+var trialSandboxLog = logger.New("cli:trial_sandbox")
+
+// trialAuditDir is where trial run audits are saved, relative to the current directory.
+const trialAuditDir = "trials/audits"
+
+// maxRepoNameLength is the longest repository name GitHub accepts.
+const maxRepoNameLength = 100
+
+// sandboxHostRepoSlug returns a unique host repository slug for a sandbox copy of
+// sourceRepoSlug, e.g. "octocat/gh-aw-sandbox-my-repo-20250101-120000". The timestamp keeps
+// concurrent or repeated sandbox trials from reusing (and force-pushing over) each other.
+func sandboxHostRepoSlug(owner, sourceRepoSlug string, now time.Time) string {
+	sourceName := sourceRepoSlug
+	if idx := strings.LastIndex(sourceRepoSlug, "/"); idx >= 0 {
+		sourceName = sourceRepoSlug[idx+1:]
+	}
+	suffix := "-" + now.UTC().Format("20060102-150405")
+	name := "gh-aw-sandbox-" + stringutil.SanitizeForFilename(sourceName)
+	if len(name)+len(suffix) > maxRepoNameLength {
+		name = name[:maxRepoNameLength-len(suffix)]
+	}
+	return owner + "/" + name + suffix
+}
+
+// auditTrialRun audits a completed trial run and saves the report under trials/audits.
+// The audit is saved locally so it survives sandbox teardown, which deletes the run.
+// Returns the relative directory containing the downloaded artifacts and run summary.
+func auditTrialRun(ctx context.Context, hostRepoSlug, runID string, verbose bool) (string, error) {
+	trialSandboxLog.Printf("Auditing trial run: repo=%s, runID=%s", hostRepoSlug, runID)
+
+	id, err := strconv.ParseInt(runID, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid run ID %q: %w", runID, err)
+	}
+	owner, repo, ok := strings.Cut(hostRepoSlug, "/")
+	if !ok {
+		return "", fmt.Errorf("invalid repository slug format: %s. Expected format: owner/repo", hostRepoSlug)
+	}
+
+	fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Collecting audit results for run "+runID))
+	if err := AuditWorkflowRun(ctx, id, AuditOptions{
+		Owner:     owner,
+		Repo:      repo,
+		OutputDir: trialAuditDir,
+		Verbose:   verbose,
+	}); err != nil {
+		return "", fmt.Errorf("failed to audit run %s: %w", runID, err)
+	}
+
+	return filepath.Join(trialAuditDir, "run-"+runID), nil
+}
@@ -0,0 +1,62 @@
+//go:build !integration
+
+package cli
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSandboxHostRepoSlug(t *testing.T) {
+	now := time.Date(2025, 3, 14, 9, 26, 53, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		sourceRepo string
+		expected   string
+	}{
+		{
+			name:       "uses repository name and timestamp",
+			sourceRepo: "myorg/my-repo",
+			expected:   "octocat/gh-aw-sandbox-my-repo-20250314-092653",
+		},
+		{
+			name:       "sanitizes unsupported characters",
+			sourceRepo: "myorg/repo name",
+			expected:   "octocat/gh-aw-sandbox-repo-name-20250314-092653",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, sandboxHostRepoSlug("octocat", tt.sourceRepo, now), "sandbox slug should match")
+		})
+	}
+
+	t.Run("truncates long repository names", func(t *testing.T) {
+		slug := sandboxHostRepoSlug("octocat", "myorg/"+strings.Repeat("a", 120), now)
+		_, name, ok := strings.Cut(slug, "/")
+		require.True(t, ok, "slug should contain an owner")
+		assert.Len(t, name, maxRepoNameLength, "sandbox repository name should be truncated to GitHub's limit")
+		assert.True(t, strings.HasSuffix(name, "-20250314-092653"), "truncation should keep the timestamp suffix")
+	})
+}
+
+func TestNewTrialCommand_SandboxFlags(t *testing.T) {
+	cmd := NewTrialCommand(func(string) error { return nil })
+
+	repoFlag := cmd.Flags().Lookup("repo")
+	require.NotNil(t, repoFlag, "trial command should define --repo")
+	assert.Equal(t, "r", repoFlag.Shorthand, "--repo should use the -r shorthand")
+	require.NotNil(t, cmd.Flags().Lookup("keep-sandbox"), "trial command should define --keep-sandbox")
+	require.NotNil(t, cmd.Flags().Lookup("audit"), "trial command should define --audit")
+
+	cmd.SetArgs([]string{"./workflow.md", "--repo", "myorg/myrepo", "--host-repo", "myorg/other"})
+	err := cmd.Execute()
+	require.Error(t, err, "--repo and --host-repo should be mutually exclusive")
+	assert.Contains(t, err.Error(), "none of the others can be", "error should come from the mutual exclusion check")
+}
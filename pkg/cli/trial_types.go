@@ -10,6 +10,7 @@ type WorkflowTrialResult struct {
 	//AgentStdioLogs      []string               `json:"agent_stdio_logs,omitempty"`
 	AgenticRunInfo      map[string]any `json:"agentic_run_info,omitempty"`
 	AdditionalArtifacts map[string]any `json:"additional_artifacts,omitempty"`
+	AuditDir            string         `json:"audit_dir,omitempty"` // Local audit output, kept after sandbox teardown
 	Timestamp           time.Time      `json:"timestamp"`
 }
 
@@ -25,6 +26,7 @@ type TrialRepoContext struct {
 	LogicalRepo string // The repo to simulate execution against
 	CloneRepo   string // Alternative to LogicalRepo: clone this repo's contents
 	HostRepo    string // The host repository where workflows will be installed
+	SandboxRepo string // Copy this repo into a disposable sandbox host repository
 }
 
 // TrialOptions contains all configuration options for running workflow trials
@@ -43,4 +45,6 @@ type TrialOptions struct {
 	AppendText             string
 	Verbose                bool
 	DisableSecurityScanner bool
+	KeepSandbox            bool // Keep the sandbox host repository instead of deleting it
+	Audit                  bool // Audit each trial run and save the report under trials/
 }
@@ -0,0 +1,139 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/gitutil"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/workflow"
+)
+
+var updateDirectivesLog = logger.New("cli:update_action_directives")
+
+// ActionLockDirectives lists the actions-lock.json directive changes requested
+// on the command line. Each spec is "owner/repo@version"; --unpin and
+// --ignore-major also accept "owner/repo" to target every entry for the repo.
+type ActionLockDirectives struct {
+	Pin         []string
+	Unpin       []string
+	IgnoreMajor []string
+}
+
+// IsEmpty reports whether no directive changes were requested.
+func (d ActionLockDirectives) IsEmpty() bool {
+	return len(d.Pin) == 0 && len(d.Unpin) == 0 && len(d.IgnoreMajor) == 0
+}
+
+// RunActionLockDirectives applies pin, unpin and ignore-major directives to
+// .github/aw/actions-lock.json in the current repository. When offline is set,
+// pinning a version that is not already in the lock file fails instead of
+// resolving its SHA from GitHub.
+func RunActionLockDirectives(ctx context.Context, directives ActionLockDirectives, offline, verbose bool) error {
+	actionCache := workflow.NewActionCache(".")
+	if err := actionCache.Load(); err != nil {
+		return fmt.Errorf("failed to parse actions lock file: %w", err)
+	}
+	deps := defaultActionUpdateDeps()
+	if offline {
+		deps = offlineActionUpdateDeps()
+	}
+	if err := applyActionLockDirectives(ctx, deps, actionCache, directives, verbose); err != nil {
+		return err
+	}
+	if err := actionCache.Save(); err != nil {
+		return fmt.Errorf("failed to save actions lock file: %w", err)
+	}
+	return nil
+}
+
+func applyActionLockDirectives(ctx context.Context, deps actionUpdateDeps, actionCache *workflow.ActionCache, directives ActionLockDirectives, verbose bool) error {
+	for _, spec := range directives.Pin {
+		repo, version, err := parseActionDirectiveSpec(spec)
+		if err != nil {
+			return fmt.Errorf("invalid --pin value: %w", err)
+		}
+		if version == "" {
+			return fmt.Errorf("invalid --pin value %q: a version is required (owner/repo@version)", spec)
+		}
+		if _, ok := actionCache.Get(repo, version); !ok {
+			// Resolve the SHA so the pinned entry is usable by the compiler right away.
+			sha, err := deps.getActionSHAForTag(ctx, gitutil.ExtractBaseRepo(repo), version)
+			if err != nil {
+				return fmt.Errorf("failed to resolve %s@%s: %w", repo, version, err)
+			}
+			updateDirectivesLog.Printf("Adding entry %s@%s (%s) to pin it", repo, version, sha)
+			actionCache.Set(repo, version, sha)
+		}
+		actionCache.SetPin(repo, version, true)
+		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("Pinned %s@%s in actions-lock.json", repo, version)))
+	}
+
+	for _, spec := range directives.IgnoreMajor {
+		repo, version, err := parseActionDirectiveSpec(spec)
+		if err != nil {
+			return fmt.Errorf("invalid --ignore-major value: %w", err)
+		}
+		versions := matchingEntryVersions(actionCache, repo, version)
+		if len(versions) == 0 {
+			return fmt.Errorf("no actions-lock.json entry found for %s", spec)
+		}
+		for _, v := range versions {
+			actionCache.SetIgnoreMajor(repo, v, true)
+			fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("Restricted %s@%s to minor and patch updates", repo, v)))
+		}
+	}
+
+	for _, spec := range directives.Unpin {
+		repo, version, err := parseActionDirectiveSpec(spec)
+		if err != nil {
+			return fmt.Errorf("invalid --unpin value: %w", err)
+		}
+		versions := matchingEntryVersions(actionCache, repo, version)
+		if len(versions) == 0 {
+			if verbose {
+				fmt.Fprintln(os.Stderr, console.FormatVerboseMessage("No actions-lock.json entry found for "+spec))
+			}
+			continue
+		}
+		for _, v := range versions {
+			actionCache.SetPin(repo, v, false)
+			actionCache.SetIgnoreMajor(repo, v, false)
+			fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("Removed update directives from %s@%s", repo, v)))
+		}
+	}
+	return nil
+}
+
+// parseActionDirectiveSpec splits "owner/repo[@version]" into its repo and version.
+func parseActionDirectiveSpec(spec string) (string, string, error) {
+	repo, version, _ := strings.Cut(strings.TrimSpace(spec), "@")
+	if strings.Count(repo, "/") < 1 || strings.HasPrefix(repo, "/") || strings.HasSuffix(repo, "/") {
+		return "", "", fmt.Errorf("%q is not in owner/repo[@version] format", spec)
+	}
+	if strings.Contains(spec, "@") && version == "" {
+		return "", "", fmt.Errorf("%q has an empty version", spec)
+	}
+	return repo, version, nil
+}
+
+// matchingEntryVersions returns the versions of cache entries for repo. When
+// version is non-empty only that version is returned, if present.
+func matchingEntryVersions(actionCache *workflow.ActionCache, repo, version string) []string {
+	var versions []string
+	for _, entry := range actionCache.Entries {
+		if entry.Repo != repo {
+			continue
+		}
+		if version != "" && entry.Version != version {
+			continue
+		}
+		versions = append(versions, entry.Version)
+	}
+	slices.Sort(versions)
+	return versions
+}
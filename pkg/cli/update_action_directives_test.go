@@ -0,0 +1,123 @@
+//go:build !integration
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/errorutil"
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseActionDirectiveSpec(t *testing.T) {
+	tests := []struct {
+		spec        string
+		wantRepo    string
+		wantVersion string
+		wantErr     bool
+	}{
+		{spec: "actions/checkout@v4", wantRepo: "actions/checkout", wantVersion: "v4"},
+		{spec: "github/codeql-action/upload-sarif@v3", wantRepo: "github/codeql-action/upload-sarif", wantVersion: "v3"},
+		{spec: " docker/login-action ", wantRepo: "docker/login-action"},
+		{spec: "checkout@v4", wantErr: true},
+		{spec: "actions/checkout@", wantErr: true},
+		{spec: "/checkout", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			repo, version, err := parseActionDirectiveSpec(tt.spec)
+			if tt.wantErr {
+				require.Error(t, err, "spec should be rejected")
+				return
+			}
+			require.NoError(t, err, "spec should be accepted")
+			assert.Equal(t, tt.wantRepo, repo, "repo should match")
+			assert.Equal(t, tt.wantVersion, version, "version should match")
+		})
+	}
+}
+
+func TestApplyActionLockDirectives(t *testing.T) {
+	cache := workflow.NewActionCache(t.TempDir())
+	cache.Set("actions/setup-node", "v4", "nodesha1234567890123456789012345678901234")
+	cache.Set("docker/login-action", "v3.1.0", "dockersha12345678901234567890123456789012")
+	cache.Set("docker/login-action", "v2", "dockerold12345678901234567890123456789012")
+
+	deps := newTestActionUpdateDeps()
+	var resolved []string
+	deps.getActionSHAForTag = func(_ context.Context, repo, tag string) (string, error) {
+		resolved = append(resolved, repo+"@"+tag)
+		return "cachesha123456789012345678901234567890123", nil
+	}
+
+	err := applyActionLockDirectives(context.Background(), deps, cache, ActionLockDirectives{
+		Pin:         []string{"actions/setup-node@v4", "actions/cache@v4"},
+		IgnoreMajor: []string{"docker/login-action"},
+	}, false)
+	require.NoError(t, err, "directives should apply")
+
+	assert.True(t, cache.Entries["actions/setup-node@v4"].Pin, "existing entry should be pinned")
+	assert.Equal(t, []string{"actions/cache@v4"}, resolved, "only the missing entry should be resolved")
+	assert.True(t, cache.Entries["actions/cache@v4"].Pin, "resolved entry should be pinned")
+	assert.Equal(t, "cachesha123456789012345678901234567890123", cache.Entries["actions/cache@v4"].SHA, "resolved SHA should be stored")
+	assert.True(t, cache.Entries["docker/login-action@v3.1.0"].IgnoreMajor, "repo-wide ignore-major should apply to every version")
+	assert.True(t, cache.Entries["docker/login-action@v2"].IgnoreMajor, "repo-wide ignore-major should apply to every version")
+
+	err = applyActionLockDirectives(context.Background(), deps, cache, ActionLockDirectives{
+		Unpin: []string{"actions/setup-node@v4", "docker/login-action@v2", "unknown/action"},
+	}, false)
+	require.NoError(t, err, "unpin should apply")
+	assert.False(t, cache.Entries["actions/setup-node@v4"].Pin, "pin should be cleared")
+	assert.False(t, cache.Entries["docker/login-action@v2"].IgnoreMajor, "ignore-major should be cleared for the named version")
+	assert.True(t, cache.Entries["docker/login-action@v3.1.0"].IgnoreMajor, "other versions should keep their directives")
+}
+
+func TestApplyActionLockDirectives_Offline(t *testing.T) {
+	cache := workflow.NewActionCache(t.TempDir())
+	cache.Set("actions/setup-node", "v4", "nodesha1234567890123456789012345678901234")
+	deps := offlineActionUpdateDeps()
+
+	err := applyActionLockDirectives(context.Background(), deps, cache, ActionLockDirectives{
+		Pin: []string{"actions/setup-node@v4"},
+	}, false)
+	require.NoError(t, err, "pinning a recorded entry should work offline")
+	assert.True(t, cache.Entries["actions/setup-node@v4"].Pin, "existing entry should be pinned")
+
+	err = applyActionLockDirectives(context.Background(), deps, cache, ActionLockDirectives{
+		Pin: []string{"actions/cache@v4"},
+	}, false)
+	require.ErrorIs(t, err, errorutil.ErrOffline, "pinning an unrecorded version should need the network")
+	assert.NotContains(t, cache.Entries, "actions/cache@v4", "no entry should be added offline")
+}
+
+func TestApplyActionLockDirectives_Errors(t *testing.T) {
+	deps := newTestActionUpdateDeps()
+	deps.getActionSHAForTag = func(_ context.Context, repo, tag string) (string, error) {
+		return "", errors.New("tag not found")
+	}
+
+	tests := []struct {
+		name       string
+		directives ActionLockDirectives
+		wantErr    string
+	}{
+		{name: "pin without version", directives: ActionLockDirectives{Pin: []string{"actions/checkout"}}, wantErr: "a version is required"},
+		{name: "pin unresolvable tag", directives: ActionLockDirectives{Pin: []string{"actions/checkout@v99"}}, wantErr: "tag not found"},
+		{name: "ignore-major unknown action", directives: ActionLockDirectives{IgnoreMajor: []string{"owner/unknown"}}, wantErr: "no actions-lock.json entry"},
+		{name: "invalid spec", directives: ActionLockDirectives{Unpin: []string{"checkout"}}, wantErr: "owner/repo[@version]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := workflow.NewActionCache(t.TempDir())
+			err := applyActionLockDirectives(context.Background(), deps, cache, tt.directives, false)
+			require.Error(t, err, "directives should be rejected")
+			assert.Contains(t, err.Error(), tt.wantErr, "error should explain the problem")
+		})
+	}
+}
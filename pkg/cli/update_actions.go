@@ -15,6 +15,7 @@ import (
 	"github.com/github/gh-aw/pkg/constants"
 
 	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/errorutil"
 	"github.com/github/gh-aw/pkg/gitutil"
 	"github.com/github/gh-aw/pkg/parser"
 	"github.com/github/gh-aw/pkg/semverutil"
@@ -41,6 +42,7 @@ type actionUpdateDeps struct {
 	getLatestReleaseViaGit func(ctx context.Context, repo, currentVersion string, allowMajor, verbose bool) (string, string, error)
 	runGHReleasesAPI       func(ctx context.Context, baseRepo string) ([]byte, error)
 	getActionSHAForTag     func(ctx context.Context, repo, tag string) (string, error)
+	getReleaseNotes        func(ctx context.Context, repo, tag string) (githubReleaseNotes, error)
 }
 
 func defaultActionUpdateDeps() actionUpdateDeps {
@@ -51,6 +53,32 @@ func defaultActionUpdateDeps() actionUpdateDeps {
 			return workflow.RunGHCombinedContext(ctx, "Fetching releases...", "api", fmt.Sprintf("/repos/%s/releases", baseRepo), "--jq", ".[].tag_name")
 		},
 		getActionSHAForTag: getActionSHAForTag,
+		getReleaseNotes:    getReleaseNotes,
+	}
+}
+
+// offlineActionUpdateDeps returns deps that never query GitHub. Used by update --offline,
+// where only directives for entries already in actions-lock.json can be applied.
+func offlineActionUpdateDeps() actionUpdateDeps {
+	offline := func(what, repo string) error {
+		return fmt.Errorf("%w: cannot %s for %s", errorutil.ErrOffline, what, repo)
+	}
+	return actionUpdateDeps{
+		getLatestRelease: func(_ context.Context, repo, _ string, _, _ bool) (string, string, error) {
+			return "", "", offline("look up the latest release", repo)
+		},
+		getLatestReleaseViaGit: func(_ context.Context, repo, _ string, _, _ bool) (string, string, error) {
+			return "", "", offline("look up the latest release", repo)
+		},
+		runGHReleasesAPI: func(_ context.Context, baseRepo string) ([]byte, error) {
+			return nil, offline("list releases", baseRepo)
+		},
+		getActionSHAForTag: func(_ context.Context, repo, tag string) (string, error) {
+			return "", offline("resolve tag "+tag, repo)
+		},
+		getReleaseNotes: func(_ context.Context, repo, tag string) (githubReleaseNotes, error) {
+			return githubReleaseNotes{}, offline("fetch release notes for "+tag, repo)
+		},
 	}
 }
 
@@ -66,10 +94,14 @@ func defaultActionUpdateDeps() actionUpdateDeps {
 // for safe-outputs.actions entries are preserved when their SHA is unchanged, and cleared
 // when the SHA changes (prompting a re-fetch on the next compile).
 func UpdateActions(ctx context.Context, allowMajor, verbose, disableReleaseBump bool, coolDown time.Duration) error {
-	return updateActions(ctx, defaultActionUpdateDeps(), allowMajor, verbose, disableReleaseBump, coolDown)
+	_, err := updateActions(ctx, defaultActionUpdateDeps(), allowMajor, verbose, disableReleaseBump, coolDown, updateGroupSelection{})
+	return err
 }
 
-func updateActions(ctx context.Context, deps actionUpdateDeps, allowMajor, verbose, disableReleaseBump bool, coolDown time.Duration) error {
+// updateActions implements UpdateActions and returns the pin changes it applied,
+// sorted by repository, for the update changelog. Entries outside the selected update
+// group are left unchanged.
+func updateActions(ctx context.Context, deps actionUpdateDeps, allowMajor, verbose, disableReleaseBump bool, coolDown time.Duration, group updateGroupSelection) ([]actionPinChange, error) {
 	updateLog.Print("Starting action updates")
 
 	if verbose {
@@ -83,18 +115,19 @@ func updateActions(ctx context.Context, deps actionUpdateDeps, allowMajor, verbo
 		if verbose {
 			fmt.Fprintln(os.Stderr, console.FormatVerboseMessage("Actions lock file not found: "+actionsLockPath))
 		}
-		return nil // Not an error, just skip
+		return nil, nil // Not an error, just skip
 	}
 
 	actionCache := workflow.NewActionCache(".")
 	if err := actionCache.Load(); err != nil {
-		return fmt.Errorf("failed to parse actions lock file: %w", err)
+		return nil, fmt.Errorf("failed to parse actions lock file: %w", err)
 	}
 
 	updateLog.Printf("Loaded %d action entries from actions-lock.json", len(actionCache.Entries))
 
 	// Track updates
 	var updatedActions []string
+	var changes []actionPinChange
 	var failedActions []actionUpdateFailure
 	var skippedActions []string
 
@@ -110,14 +143,28 @@ func updateActions(ctx context.Context, deps actionUpdateDeps, allowMajor, verbo
 
 	for _, s := range snapshot {
 		if ctx.Err() != nil {
-			return ctx.Err()
+			return nil, ctx.Err()
 		}
 		entry := s.entry
+		if !group.includes(gitutil.ExtractBaseRepo(entry.Repo)) {
+			updateLog.Printf("Skipping %s@%s: not in update group %q", entry.Repo, entry.Version, group.name)
+			continue
+		}
 		updateLog.Printf("Checking action: %s@%s", entry.Repo, entry.Version)
 
+		// Entries pinned in actions-lock.json are intentionally held back.
+		if entry.Pin {
+			updateLog.Printf("Skipping %s@%s: pinned in actions-lock.json", entry.Repo, entry.Version)
+			fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Skipping %s@%s: pinned in actions-lock.json", entry.Repo, entry.Version)))
+			skippedActions = append(skippedActions, entry.Repo)
+			continue
+		}
+
 		// By default all actions are force-updated to the latest major version.
 		// When disableReleaseBump is set, only core actions (actions/*) bypass the --major flag.
-		effectiveAllowMajor := !disableReleaseBump || allowMajor || isCoreAction(entry.Repo)
+		// An ignore-major directive in actions-lock.json always restricts the entry to
+		// minor/patch updates.
+		effectiveAllowMajor := (!disableReleaseBump || allowMajor || isCoreAction(entry.Repo)) && !entry.IgnoreMajor
 
 		// Check for latest release using the injectable function (also used by updateActionRefsInContent)
 		latestVersion, latestSHA, err := deps.getLatestRelease(ctx, entry.Repo, entry.Version, effectiveAllowMajor, verbose)
@@ -225,8 +272,13 @@ func updateActions(ctx context.Context, deps actionUpdateDeps, allowMajor, verbo
 		}
 		// Set the new entry; ActionCache.Set handles inputs/description preservation.
 		actionCache.Set(entry.Repo, latestVersion, latestSHA)
+		if entry.IgnoreMajor {
+			// Directives are keyed by version, so carry ignore-major over to the new key.
+			actionCache.SetIgnoreMajor(entry.Repo, latestVersion, true)
+		}
 
 		updatedActions = append(updatedActions, entry.Repo)
+		changes = append(changes, newActionPinChange(entry.Repo, entry.Version, entry.SHA, latestVersion, latestSHA))
 	}
 
 	// Show summary
@@ -257,14 +309,15 @@ func updateActions(ctx context.Context, deps actionUpdateDeps, allowMajor, verbo
 	// all entry fields (including inputs/descriptions for safe-outputs actions).
 	if len(updatedActions) > 0 {
 		if err := actionCache.Save(); err != nil {
-			return fmt.Errorf("failed to save actions lock file: %w", err)
+			return nil, fmt.Errorf("failed to save actions lock file: %w", err)
 		}
 
 		updateLog.Printf("Successfully wrote updated actions-lock.json with %d updates", len(updatedActions))
 		fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Updated actions-lock.json file"))
 	}
 
-	return nil
+	slices.SortFunc(changes, func(a, b actionPinChange) int { return strings.Compare(a.Repo, b.Repo) })
+	return changes, nil
 }
 
 // getLatestActionRelease gets the latest release for an action repository
@@ -618,6 +671,7 @@ func UpdateActionsInWorkflowFiles(ctx context.Context, workflowsDir, engineOverr
 // noCompile skips recompilation of updated workflow files.
 // coolDown is the minimum age a release must have before it is considered for upgrade.
 // approve auto-approves any interactive prompts during recompilation.
+// group restricts updates to the action references of one update group (update --group).
 type updateActionsOptions struct {
 	workflowsDir       string
 	engineOverride     string
@@ -626,6 +680,7 @@ type updateActionsOptions struct {
 	noCompile          bool
 	coolDown           time.Duration
 	approve            bool
+	group              updateGroupSelection
 }
 
 func updateActionsInWorkflowFiles(ctx context.Context, deps actionUpdateDeps, opts updateActionsOptions) error {
@@ -635,6 +690,10 @@ func updateActionsInWorkflowFiles(ctx context.Context, deps actionUpdateDeps, op
 
 	updateLog.Printf("Updating action references in workflow files: dir=%s", opts.workflowsDir)
 
+	// Honor pin/ignore-major directives from actions-lock.json for workflow file references too
+	directives := loadActionUpdateDirectives()
+	directives.group = opts.group
+
 	// Per-invocation cache: key = "repo@currentVersion", avoids repeated API calls
 	cache := make(map[string]latestReleaseResult)
 	// Per-invocation cooldown cache: key = "repo@tag", avoids redundant date API calls
@@ -661,19 +720,23 @@ func updateActionsInWorkflowFiles(ctx context.Context, deps actionUpdateDeps, op
 			return nil
 		}
 
-		updatedActions, newContent, err := updateActionRefsInContentWithDeps(ctx, deps, string(content), cache, coolDownCache, !opts.disableReleaseBump, opts.verbose, opts.coolDown)
+		updatedActions, newContent, err := updateActionRefsInContentWithDeps(ctx, deps, string(content), cache, coolDownCache, directives, !opts.disableReleaseBump, opts.verbose, opts.coolDown)
 		if err != nil {
 			if opts.verbose {
 				fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Failed to update action refs in %s: %v", path, err)))
 			}
 			return nil
 		}
-		updatedSkills, newContent, err := updateSkillRefsInContent(ctx, newContent, !opts.disableReleaseBump, opts.verbose, opts.coolDown)
-		if err != nil {
-			if opts.verbose {
-				fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Failed to update skill refs in %s: %v", path, err)))
+		// Skills are not part of any update group, so a group-scoped run leaves them unchanged
+		updatedSkills := false
+		if opts.group.name == "" {
+			updatedSkills, newContent, err = updateSkillRefsInContent(ctx, newContent, !opts.disableReleaseBump, opts.verbose, opts.coolDown)
+			if err != nil {
+				if opts.verbose {
+					fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Failed to update skill refs in %s: %v", path, err)))
+				}
+				return nil
 			}
-			return nil
 		}
 
 		if !updatedActions && !updatedSkills {
@@ -815,7 +878,66 @@ func updateSkillRefValue(
 	return true, spec + "@" + latestRef, nil
 }
 
-func updateActionRefsInContentWithDeps(ctx context.Context, deps actionUpdateDeps, content string, cache map[string]latestReleaseResult, coolDownCache map[string]coolDownCheckResult, allowMajor, verbose bool, coolDown time.Duration) (bool, string, error) {
+// actionUpdateDirectives holds the pin and ignore-major directives declared in
+// actions-lock.json, applied to action references in workflow markdown files, and the
+// update group selected for the run.
+type actionUpdateDirectives struct {
+	entries map[string]actionDirective // key: "repo@version" (as in actions-lock.json) and "repo@sha"
+	byRepo  map[string]actionDirective // key: repo; union of the directives of every entry for the repo
+	group   updateGroupSelection
+}
+
+// actionDirective is the update policy an actions-lock.json entry declares.
+type actionDirective struct {
+	pin         bool
+	ignoreMajor bool
+}
+
+// loadActionUpdateDirectives reads update directives from .github/aw/actions-lock.json.
+// A missing or unreadable lock file yields no directives.
+func loadActionUpdateDirectives() actionUpdateDirectives {
+	actionCache := workflow.NewActionCache(".")
+	if err := actionCache.Load(); err != nil {
+		updateLog.Printf("Could not load actions-lock.json for update directives: %v", err)
+		return actionUpdateDirectives{}
+	}
+	return newActionUpdateDirectives(actionCache.Entries)
+}
+
+func newActionUpdateDirectives(entries map[string]workflow.ActionCacheEntry) actionUpdateDirectives {
+	d := actionUpdateDirectives{entries: make(map[string]actionDirective), byRepo: make(map[string]actionDirective)}
+	for _, entry := range entries {
+		if !entry.Pin && !entry.IgnoreMajor {
+			continue
+		}
+		directive := actionDirective{pin: entry.Pin, ignoreMajor: entry.IgnoreMajor}
+		d.entries[entry.Repo+"@"+entry.Version] = directive
+		if entry.SHA != "" {
+			d.entries[entry.Repo+"@"+entry.SHA] = directive
+		}
+		merged := d.byRepo[entry.Repo]
+		d.byRepo[entry.Repo] = actionDirective{pin: merged.pin || entry.Pin, ignoreMajor: merged.ignoreMajor || entry.IgnoreMajor}
+	}
+	return d
+}
+
+// lookup returns the directive for a workflow action reference. References are matched by
+// repo@version like actions-lock.json keys; a SHA reference without a version comment is
+// matched by its SHA, and failing that by any directive declared for the repo, so a pinned
+// action is never bumped just because its version is unknown.
+func (d actionUpdateDirectives) lookup(repo, version, sha string) actionDirective {
+	if version != "" {
+		return d.entries[repo+"@"+version]
+	}
+	if sha != "" {
+		if directive, ok := d.entries[repo+"@"+sha]; ok {
+			return directive
+		}
+	}
+	return d.byRepo[repo]
+}
+
+func updateActionRefsInContentWithDeps(ctx context.Context, deps actionUpdateDeps, content string, cache map[string]latestReleaseResult, coolDownCache map[string]coolDownCheckResult, directives actionUpdateDirectives, allowMajor, verbose bool, coolDown time.Duration) (bool, string, error) {
 	changed := false
 	lines := strings.Split(content, "\n")
 
@@ -843,7 +965,6 @@ func updateActionRefsInContentWithDeps(ctx context.Context, deps actionUpdateDep
 		if !effectiveAllowMajor {
 			continue
 		}
-
 		// Determine the "current version" to pass to the latest-release resolver.
 		isSHA := IsCommitSHA(ref)
 		currentVersion := ref
@@ -861,6 +982,24 @@ func updateActionRefsInContentWithDeps(ctx context.Context, deps actionUpdateDep
 			}
 		}
 
+		if !directives.group.includes(gitutil.ExtractBaseRepo(repo)) {
+			updateLog.Printf("Skipping %s in workflow file: not in update group %q", repo, directives.group.name)
+			continue
+		}
+
+		sha := ""
+		if isSHA {
+			sha = ref
+		}
+		directive := directives.lookup(repo, currentVersion, sha)
+		if directive.pin {
+			updateLog.Printf("Skipping %s@%s in workflow file: pinned in actions-lock.json", repo, ref)
+			continue
+		}
+		if directive.ignoreMajor {
+			effectiveAllowMajor = false
+		}
+
 		// Resolve latest version/SHA, using the cache to avoid redundant API calls.
 		// Use "|" as separator since GitHub repo names cannot contain "|".
 		cacheKey := repo + "|" + currentVersion
@@ -157,7 +157,7 @@ func TestUpdateActions_SafeOutputsInputsPreserved(t *testing.T) {
 		t.Fatalf("failed to chdir: %v", err)
 	}
 
-	if err := updateActions(context.Background(), deps, false, false, false, 0); err != nil {
+	if _, err := updateActions(context.Background(), deps, false, false, false, 0, updateGroupSelection{}); err != nil {
 		t.Fatalf("UpdateActions() error = %v", err)
 	}
 
@@ -379,7 +379,7 @@ func TestUpdateActionRefsInContent_VersionTagReplacement(t *testing.T) {
   - run: echo hello`
 
 	cache := make(map[string]latestReleaseResult)
-	changed, got, err := updateActionRefsInContentWithDeps(context.Background(), deps, input, cache, make(map[string]coolDownCheckResult), true, false, 0)
+	changed, got, err := updateActionRefsInContentWithDeps(context.Background(), deps, input, cache, make(map[string]coolDownCheckResult), actionUpdateDirectives{}, true, false, 0)
 	if err != nil {
 		t.Fatalf("updateActionRefsInContent() error = %v", err)
 	}
@@ -402,7 +402,7 @@ func TestUpdateActionRefsInContent_SHAPinnedReplacement(t *testing.T) {
 	want := "        uses: actions/checkout@" + newSHA + "  # v6.0.2"
 
 	cache := make(map[string]latestReleaseResult)
-	changed, got, err := updateActionRefsInContentWithDeps(context.Background(), deps, input, cache, make(map[string]coolDownCheckResult), true, false, 0)
+	changed, got, err := updateActionRefsInContentWithDeps(context.Background(), deps, input, cache, make(map[string]coolDownCheckResult), actionUpdateDirectives{}, true, false, 0)
 	if err != nil {
 		t.Fatalf("updateActionRefsInContent() error = %v", err)
 	}
@@ -428,7 +428,7 @@ func TestUpdateActionRefsInContent_CacheReusedAcrossLines(t *testing.T) {
   - uses: actions/github-script@v7`
 
 	cache := make(map[string]latestReleaseResult)
-	changed, _, err := updateActionRefsInContentWithDeps(context.Background(), deps, input, cache, make(map[string]coolDownCheckResult), true, false, 0)
+	changed, _, err := updateActionRefsInContentWithDeps(context.Background(), deps, input, cache, make(map[string]coolDownCheckResult), actionUpdateDirectives{}, true, false, 0)
 	if err != nil {
 		t.Fatalf("updateActionRefsInContent() error = %v", err)
 	}
@@ -463,7 +463,7 @@ func TestUpdateActionRefsInContent_AllOrgsUpdatedWhenAllowMajor(t *testing.T) {
   - uses: github/codeql-action@v4`
 
 	cache := make(map[string]latestReleaseResult)
-	changed, got, err := updateActionRefsInContentWithDeps(context.Background(), deps, input, cache, make(map[string]coolDownCheckResult), true, false, 0)
+	changed, got, err := updateActionRefsInContentWithDeps(context.Background(), deps, input, cache, make(map[string]coolDownCheckResult), actionUpdateDirectives{}, true, false, 0)
 	if err != nil {
 		t.Fatalf("updateActionRefsInContent() error = %v", err)
 	}
@@ -705,7 +705,7 @@ func TestUpdateActions_GhAwNativeActionCappedAtCLIVersion(t *testing.T) {
 		t.Fatalf("failed to chdir: %v", err)
 	}
 
-	if err := updateActions(context.Background(), deps, false, false, false, 0); err != nil {
+	if _, err := updateActions(context.Background(), deps, false, false, false, 0, updateGroupSelection{}); err != nil {
 		t.Fatalf("UpdateActions() error = %v", err)
 	}
 
@@ -798,7 +798,7 @@ func TestUpdateActions_NeverDowngrades(t *testing.T) {
 		t.Fatalf("failed to chdir: %v", err)
 	}
 
-	if err := updateActions(context.Background(), deps, true, false, false, 0); err != nil {
+	if _, err := updateActions(context.Background(), deps, true, false, false, 0, updateGroupSelection{}); err != nil {
 		t.Fatalf("UpdateActions() error = %v", err)
 	}
 
@@ -886,6 +886,153 @@ func TestUpdateActionsInWorkflowFiles_NeverDowngrades(t *testing.T) {
 	}
 }
 
+func TestUpdateActions_HonorsLockDirectives(t *testing.T) {
+	deps := newTestActionUpdateDeps()
+	allowMajorByRepo := make(map[string]bool)
+	deps.getLatestRelease = func(_ context.Context, repo, currentVersion string, allowMajor, verbose bool) (string, string, error) {
+		allowMajorByRepo[repo] = allowMajor
+		switch repo {
+		case "owner/pinned":
+			return "v3.0.0", "pinnednew12345678901234567890123456789012", nil
+		case "owner/minor-only":
+			return "v2.5.0", "minornew123456789012345678901234567890123", nil
+		}
+		return currentVersion, "", nil
+	}
+
+	tmpDir := testutil.TempDir(t, "test-*")
+	cache := workflow.NewActionCache(tmpDir)
+	cache.Set("owner/pinned", "v2.0.0", "pinnedold12345678901234567890123456789012")
+	cache.SetPin("owner/pinned", "v2.0.0", true)
+	cache.Set("owner/minor-only", "v2.1.0", "minorold123456789012345678901234567890123")
+	cache.SetIgnoreMajor("owner/minor-only", "v2.1.0", true)
+	if err := cache.Save(); err != nil {
+		t.Fatalf("failed to save initial cache: %v", err)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get working directory: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := os.Chdir(wd); err != nil {
+			t.Errorf("failed to restore working directory: %v", err)
+		}
+	})
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatalf("failed to chdir: %v", err)
+	}
+
+	if _, err := updateActions(context.Background(), deps, true, false, false, 0, updateGroupSelection{}); err != nil {
+		t.Fatalf("UpdateActions() error = %v", err)
+	}
+
+	if _, checked := allowMajorByRepo["owner/pinned"]; checked {
+		t.Error("pinned action should not be checked for updates")
+	}
+	if allowMajorByRepo["owner/minor-only"] {
+		t.Error("ignore-major action should be resolved with allowMajor=false")
+	}
+
+	saved := workflow.NewActionCache(tmpDir)
+	if err := saved.Load(); err != nil {
+		t.Fatalf("failed to reload cache: %v", err)
+	}
+	if entry, ok := saved.Entries["owner/pinned@v2.0.0"]; !ok || !entry.Pin {
+		t.Errorf("pinned entry should be unchanged; got entries: %v", savedEntryKeys(saved))
+	}
+	entry, ok := saved.Entries["owner/minor-only@v2.5.0"]
+	if !ok {
+		t.Fatalf("expected owner/minor-only@v2.5.0 after update; got entries: %v", savedEntryKeys(saved))
+	}
+	if !entry.IgnoreMajor {
+		t.Error("ignore-major directive should carry over to the updated entry")
+	}
+}
+
+func TestUpdateActionRefsInContent_HonorsLockDirectives(t *testing.T) {
+	var allowMajorSeen bool
+	deps := newActionUpdateDepsWithLatestRelease(func(_ context.Context, repo, currentVersion string, allowMajor, verbose bool) (string, string, error) {
+		if repo == "owner/minor-only" {
+			allowMajorSeen = allowMajor
+		}
+		return "v9.0.0", "newsha12345678901234567890123456789012345", nil
+	})
+	directives := newActionUpdateDirectives(map[string]workflow.ActionCacheEntry{
+		"owner/pinned@v2":     {Repo: "owner/pinned", Version: "v2", Pin: true},
+		"owner/minor-only@v2": {Repo: "owner/minor-only", Version: "v2", IgnoreMajor: true},
+	})
+
+	input := "steps:\n  - uses: owner/pinned@v2\n  - uses: owner/minor-only@v2\n"
+	changed, got, err := updateActionRefsInContentWithDeps(context.Background(), deps, input, make(map[string]latestReleaseResult), make(map[string]coolDownCheckResult), directives, true, false, 0)
+	if err != nil {
+		t.Fatalf("updateActionRefsInContent() error = %v", err)
+	}
+	if !changed {
+		t.Error("updateActionRefsInContent() changed = false, want true")
+	}
+	if want := "steps:\n  - uses: owner/pinned@v2\n  - uses: owner/minor-only@v9.0.0\n"; got != want {
+		t.Errorf("updateActionRefsInContent() output mismatch\nGot:\n%s\nWant:\n%s", got, want)
+	}
+	if allowMajorSeen {
+		t.Error("ignore-major repo should be resolved with allowMajor=false")
+	}
+}
+
+func TestUpdateActionRefsInContent_HonorsLockDirectivesForBareSHARefs(t *testing.T) {
+	const pinnedSHA = "1111111111111111111111111111111111111111"
+	const otherSHA = "2222222222222222222222222222222222222222"
+	deps := newActionUpdateDepsWithLatestRelease(func(_ context.Context, repo, currentVersion string, allowMajor, verbose bool) (string, string, error) {
+		return "v9.0.0", "newsha12345678901234567890123456789012345", nil
+	})
+	directives := newActionUpdateDirectives(map[string]workflow.ActionCacheEntry{
+		"owner/pinned@v2":   {Repo: "owner/pinned", Version: "v2", SHA: pinnedSHA, Pin: true},
+		"owner/by-repo@v3":  {Repo: "owner/by-repo", Version: "v3", SHA: pinnedSHA, Pin: true},
+		"owner/unpinned@v1": {Repo: "owner/unpinned", Version: "v1", SHA: pinnedSHA},
+	})
+
+	input := "steps:\n" +
+		"  - uses: owner/pinned@" + pinnedSHA + "\n" +
+		"  - uses: owner/by-repo@" + otherSHA + "\n" +
+		"  - uses: owner/unpinned@" + pinnedSHA + "\n"
+	changed, got, err := updateActionRefsInContentWithDeps(context.Background(), deps, input, make(map[string]latestReleaseResult), make(map[string]coolDownCheckResult), directives, true, false, 0)
+	if err != nil {
+		t.Fatalf("updateActionRefsInContent() error = %v", err)
+	}
+	if !changed {
+		t.Error("updateActionRefsInContent() changed = false, want true")
+	}
+	want := "steps:\n" +
+		"  - uses: owner/pinned@" + pinnedSHA + "\n" +
+		"  - uses: owner/by-repo@" + otherSHA + "\n" +
+		"  - uses: owner/unpinned@newsha12345678901234567890123456789012345  # v9.0.0\n"
+	if got != want {
+		t.Errorf("updateActionRefsInContent() output mismatch\nGot:\n%s\nWant:\n%s", got, want)
+	}
+}
+
+func TestActionUpdateDirectivesLookup(t *testing.T) {
+	directives := newActionUpdateDirectives(map[string]workflow.ActionCacheEntry{
+		"owner/repo@v2": {Repo: "owner/repo", Version: "v2", SHA: "abc", IgnoreMajor: true},
+	})
+
+	if !directives.lookup("owner/repo", "v2", "").ignoreMajor {
+		t.Error("ignore-major should match the repo@version key from actions-lock.json")
+	}
+	if directives.lookup("owner/repo", "v1", "").ignoreMajor {
+		t.Error("ignore-major for v2 should not apply to a v1 reference")
+	}
+	if !directives.lookup("owner/repo", "", "abc").ignoreMajor {
+		t.Error("a bare SHA reference should match the entry with that SHA")
+	}
+	if !directives.lookup("owner/repo", "", "def").ignoreMajor {
+		t.Error("a bare SHA reference not in the lock file should fall back to the repo directives")
+	}
+	if directives.lookup("owner/other", "", "abc").ignoreMajor {
+		t.Error("directives should not leak across repositories")
+	}
+}
+
 // savedEntryKeys returns the map keys of a loaded ActionCache for error messages.
 func savedEntryKeys(cache *workflow.ActionCache) []string {
 	keys := make([]string, 0, len(cache.Entries))
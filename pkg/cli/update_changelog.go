@@ -0,0 +1,246 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/gitutil"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var updateChangelogLog = logger.New("cli:update_changelog")
+
+// releaseNotesExcerptMaxLines and releaseNotesExcerptMaxChars bound the release
+// notes copied into the changelog so the result stays usable as a PR body.
+const (
+	releaseNotesExcerptMaxLines = 15
+	releaseNotesExcerptMaxChars = 1000
+)
+
+// actionPinChange describes one actions-lock.json entry changed by update.
+type actionPinChange struct {
+	Repo         string `json:"repo"`
+	OldVersion   string `json:"old_version"`
+	NewVersion   string `json:"new_version"`
+	OldSHA       string `json:"old_sha"`
+	NewSHA       string `json:"new_sha"`
+	CompareURL   string `json:"compare_url"`
+	ReleaseURL   string `json:"release_url,omitempty"`
+	ReleaseNotes string `json:"release_notes,omitempty"`
+	Group        string `json:"group,omitempty"`
+}
+
+// actionChangelog is the document written by update --changelog.
+type actionChangelog struct {
+	Changes []actionPinChange `json:"changes"`
+}
+
+// githubReleaseNotes holds the fields of a GitHub release used in the changelog.
+type githubReleaseNotes struct {
+	Body    string `json:"body"`
+	HTMLURL string `json:"html_url"`
+}
+
+func getReleaseNotes(ctx context.Context, repo, tag string) (githubReleaseNotes, error) {
+	client, err := api.DefaultRESTClient()
+	if err != nil {
+		return githubReleaseNotes{}, fmt.Errorf("failed to create GitHub client: %w", err)
+	}
+	var release githubReleaseNotes
+	if err := client.DoWithContext(ctx, http.MethodGet, fmt.Sprintf("repos/%s/releases/tags/%s", repo, url.PathEscape(tag)), nil, &release); err != nil {
+		return githubReleaseNotes{}, fmt.Errorf("failed to fetch release notes for %s@%s: %w", repo, tag, err)
+	}
+	return release, nil
+}
+
+// newActionPinChange records a pin change with a compare URL between the old
+// and new refs. Moving tags keep the same version, so the SHAs are compared instead.
+func newActionPinChange(repo, oldVersion, oldSHA, newVersion, newSHA string) actionPinChange {
+	oldRef, newRef := oldVersion, newVersion
+	if oldVersion == newVersion {
+		oldRef, newRef = oldSHA, newSHA
+	}
+	baseRepo := gitutil.ExtractBaseRepo(repo)
+	return actionPinChange{
+		Repo:       repo,
+		OldVersion: oldVersion,
+		NewVersion: newVersion,
+		OldSHA:     oldSHA,
+		NewSHA:     newSHA,
+		CompareURL: fmt.Sprintf("%s/%s/compare/%s...%s", getGitHubHost(), baseRepo, oldRef, newRef),
+	}
+}
+
+// addReleaseNotes fetches the release notes of each new version and stores an
+// excerpt on the change. Failures are logged and leave the notes empty, since a
+// missing release (tag-only versions) must not block the update.
+func addReleaseNotes(ctx context.Context, deps actionUpdateDeps, changes []actionPinChange) {
+	notesByRelease := make(map[string]githubReleaseNotes)
+	for i := range changes {
+		change := &changes[i]
+		if change.OldVersion == change.NewVersion {
+			continue
+		}
+		baseRepo := gitutil.ExtractBaseRepo(change.Repo)
+		key := baseRepo + "@" + change.NewVersion
+		notes, ok := notesByRelease[key]
+		if !ok {
+			var err error
+			notes, err = deps.getReleaseNotes(ctx, baseRepo, change.NewVersion)
+			if err != nil {
+				updateChangelogLog.Printf("No release notes for %s: %v", key, err)
+			}
+			notesByRelease[key] = notes
+		}
+		change.ReleaseURL = notes.HTMLURL
+		change.ReleaseNotes = releaseNotesExcerpt(notes.Body)
+	}
+}
+
+// assignUpdateGroups records the update group of each change, so the changelog can
+// present the changes of each group separately.
+func assignUpdateGroups(changes []actionPinChange, groups updateGroups) {
+	for i := range changes {
+		changes[i].Group = groups.groupFor(gitutil.ExtractBaseRepo(changes[i].Repo))
+	}
+}
+
+// releaseNotesExcerpt trims release notes to the first lines and characters.
+func releaseNotesExcerpt(body string) string {
+	body = strings.TrimSpace(strings.ReplaceAll(body, "\r\n", "\n"))
+	if body == "" {
+		return ""
+	}
+	truncated := false
+	if lines := strings.Split(body, "\n"); len(lines) > releaseNotesExcerptMaxLines {
+		body = strings.Join(lines[:releaseNotesExcerptMaxLines], "\n")
+		truncated = true
+	}
+	if runes := []rune(body); len(runes) > releaseNotesExcerptMaxChars {
+		body = string(runes[:releaseNotesExcerptMaxChars])
+		truncated = true
+	}
+	body = strings.TrimSpace(body)
+	if truncated {
+		body += "\n…"
+	}
+	return body
+}
+
+// renderActionChangelogMarkdown renders pin changes as a pull request body.
+func renderActionChangelogMarkdown(changes []actionPinChange) string {
+	var b strings.Builder
+	b.WriteString("## GitHub Actions updates\n\n")
+	if len(changes) == 0 {
+		b.WriteString("No pins in `.github/aw/actions-lock.json` were changed.\n")
+		return b.String()
+	}
+	fmt.Fprintf(&b, "This update changes %d pin(s) in `.github/aw/actions-lock.json`.\n", len(changes))
+	sections := groupActionPinChanges(changes)
+	for _, section := range sections {
+		b.WriteString("\n")
+		if len(sections) > 1 || section.name != "" {
+			title := section.name
+			if title == "" {
+				title = "Ungrouped"
+			}
+			fmt.Fprintf(&b, "### %s\n\n", title)
+		}
+		b.WriteString("| Action | From | To | Changes |\n")
+		b.WriteString("| --- | --- | --- | --- |\n")
+		for _, change := range section.changes {
+			fmt.Fprintf(&b, "| `%s` | %s | %s | [compare](%s) |\n", change.Repo, formatChangelogRef(change.OldVersion, change.OldSHA), formatChangelogRef(change.NewVersion, change.NewSHA), change.CompareURL)
+		}
+	}
+	for _, change := range changes {
+		if change.ReleaseNotes == "" {
+			continue
+		}
+		fmt.Fprintf(&b, "\n<details>\n<summary><code>%s</code> %s release notes</summary>\n\n", change.Repo, change.NewVersion)
+		b.WriteString(change.ReleaseNotes)
+		b.WriteString("\n")
+		if change.ReleaseURL != "" {
+			fmt.Fprintf(&b, "\n[Full release notes](%s)\n", change.ReleaseURL)
+		}
+		b.WriteString("\n</details>\n")
+	}
+	return b.String()
+}
+
+// actionPinChangeSection is the set of changes of one update group in the changelog.
+type actionPinChangeSection struct {
+	name    string
+	changes []actionPinChange
+}
+
+// groupActionPinChanges splits changes by update group, in order of first appearance,
+// with changes outside any group last.
+func groupActionPinChanges(changes []actionPinChange) []actionPinChangeSection {
+	var sections []actionPinChangeSection
+	var ungrouped []actionPinChange
+	index := make(map[string]int)
+	for _, change := range changes {
+		if change.Group == "" {
+			ungrouped = append(ungrouped, change)
+			continue
+		}
+		i, ok := index[change.Group]
+		if !ok {
+			i = len(sections)
+			index[change.Group] = i
+			sections = append(sections, actionPinChangeSection{name: change.Group})
+		}
+		sections[i].changes = append(sections[i].changes, change)
+	}
+	if len(ungrouped) > 0 {
+		sections = append(sections, actionPinChangeSection{changes: ungrouped})
+	}
+	return sections
+}
+
+// formatChangelogRef renders a version with its abbreviated SHA.
+func formatChangelogRef(version, sha string) string {
+	if len(sha) > 7 {
+		sha = sha[:7]
+	}
+	if sha == "" {
+		return version
+	}
+	return fmt.Sprintf("%s (`%s`)", version, sha)
+}
+
+// writeActionChangelog writes pin changes to path. Paths ending in .json get the
+// machine-readable form; anything else gets markdown suitable for a PR body.
+func writeActionChangelog(path string, changes []actionPinChange) error {
+	updateChangelogLog.Printf("Writing changelog with %d change(s) to %s", len(changes), path)
+	var content []byte
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		if changes == nil {
+			changes = []actionPinChange{}
+		}
+		data, err := json.MarshalIndent(actionChangelog{Changes: changes}, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal changelog: %w", err)
+		}
+		content = append(data, '\n')
+	} else {
+		content = []byte(renderActionChangelogMarkdown(changes))
+	}
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, constants.DirPermPublic); err != nil {
+			return fmt.Errorf("failed to create changelog directory: %w", err)
+		}
+	}
+	if err := os.WriteFile(path, content, constants.FilePermPublic); err != nil {
+		return fmt.Errorf("failed to write changelog: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+//go:build !integration
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewActionPinChange(t *testing.T) {
+	t.Setenv("GITHUB_SERVER_URL", "")
+	t.Setenv("GITHUB_ENTERPRISE_HOST", "")
+	t.Setenv("GITHUB_HOST", "")
+	t.Setenv("GH_HOST", "")
+
+	change := newActionPinChange("github/codeql-action/upload-sarif", "v3", "aaa", "v4", "bbb")
+	assert.Equal(t, "https://github.com/github/codeql-action/compare/v3...v4", change.CompareURL, "version bump should compare tags")
+
+	moved := newActionPinChange("actions/checkout", "v5", "aaa", "v5", "bbb")
+	assert.Equal(t, "https://github.com/actions/checkout/compare/aaa...bbb", moved.CompareURL, "moving tag should compare SHAs")
+}
+
+func TestReleaseNotesExcerpt(t *testing.T) {
+	assert.Empty(t, releaseNotesExcerpt("  \r\n "), "blank notes")
+	assert.Equal(t, "## Changes\n- fix", releaseNotesExcerpt("## Changes\r\n- fix\r\n"), "short notes are kept")
+
+	long := strings.Repeat("line\n", releaseNotesExcerptMaxLines+5)
+	excerpt := releaseNotesExcerpt(long)
+	assert.Equal(t, releaseNotesExcerptMaxLines+1, strings.Count(excerpt, "\n")+1, "excerpt should keep the first lines plus a marker")
+	assert.True(t, strings.HasSuffix(excerpt, "\n…"), "truncated excerpt should end with a marker")
+
+	wide := releaseNotesExcerpt(strings.Repeat("é", releaseNotesExcerptMaxChars+10))
+	assert.Len(t, []rune(wide), releaseNotesExcerptMaxChars+2, "excerpt should be cut by characters")
+}
+
+func TestAddReleaseNotes(t *testing.T) {
+	calls := 0
+	deps := newTestActionUpdateDeps()
+	deps.getReleaseNotes = func(_ context.Context, repo, tag string) (githubReleaseNotes, error) {
+		calls++
+		if repo == "owner/tag-only" {
+			return githubReleaseNotes{}, errors.New("HTTP 404: Not Found")
+		}
+		return githubReleaseNotes{Body: "Notes for " + tag, HTMLURL: "https://github.com/" + repo + "/releases/tag/" + tag}, nil
+	}
+
+	changes := []actionPinChange{
+		{Repo: "github/codeql-action/init", OldVersion: "v3", NewVersion: "v4"},
+		{Repo: "github/codeql-action/upload-sarif", OldVersion: "v3", NewVersion: "v4"},
+		{Repo: "owner/tag-only", OldVersion: "v1.0.0", NewVersion: "v1.0.1"},
+		{Repo: "actions/checkout", OldVersion: "v5", NewVersion: "v5"},
+	}
+	addReleaseNotes(context.Background(), deps, changes)
+
+	assert.Equal(t, 2, calls, "release notes should be fetched once per release and not for moving tags")
+	assert.Equal(t, "Notes for v4", changes[1].ReleaseNotes, "sub-path actions share the repository release")
+	assert.Equal(t, "https://github.com/github/codeql-action/releases/tag/v4", changes[1].ReleaseURL, "release URL")
+	assert.Empty(t, changes[2].ReleaseNotes, "missing releases leave the notes empty")
+	assert.Empty(t, changes[3].ReleaseNotes, "moving tags have no release notes")
+}
+
+func TestWriteActionChangelog(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "changelog-*")
+	changes := []actionPinChange{{
+		Repo:         "actions/checkout",
+		OldVersion:   "v4",
+		NewVersion:   "v5",
+		OldSHA:       "1111111111111111111111111111111111111111",
+		NewSHA:       "2222222222222222222222222222222222222222",
+		CompareURL:   "https://github.com/actions/checkout/compare/v4...v5",
+		ReleaseURL:   "https://github.com/actions/checkout/releases/tag/v5",
+		ReleaseNotes: "- Node 24 runtime",
+	}}
+
+	t.Run("markdown", func(t *testing.T) {
+		path := filepath.Join(tmpDir, "out", "changelog.md")
+		require.NoError(t, writeActionChangelog(path, changes), "write markdown changelog")
+		content, err := os.ReadFile(path)
+		require.NoError(t, err, "read markdown changelog")
+		md := string(content)
+		assert.Contains(t, md, "| `actions/checkout` | v4 (`1111111`) | v5 (`2222222`) | [compare](https://github.com/actions/checkout/compare/v4...v5) |", "table row")
+		assert.Contains(t, md, "<summary><code>actions/checkout</code> v5 release notes</summary>", "release notes section")
+		assert.Contains(t, md, "[Full release notes](https://github.com/actions/checkout/releases/tag/v5)", "release link")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		path := filepath.Join(tmpDir, "changelog.json")
+		require.NoError(t, writeActionChangelog(path, changes), "write JSON changelog")
+		content, err := os.ReadFile(path)
+		require.NoError(t, err, "read JSON changelog")
+		var got actionChangelog
+		require.NoError(t, json.Unmarshal(content, &got), "changelog should be valid JSON")
+		assert.Equal(t, changes, got.Changes, "JSON changelog should round-trip")
+	})
+
+	t.Run("no changes", func(t *testing.T) {
+		path := filepath.Join(tmpDir, "empty.json")
+		require.NoError(t, writeActionChangelog(path, nil), "write empty changelog")
+		content, err := os.ReadFile(path)
+		require.NoError(t, err, "read empty changelog")
+		assert.JSONEq(t, `{"changes": []}`, string(content), "empty changelog")
+		assert.Contains(t, renderActionChangelogMarkdown(nil), "No pins", "empty markdown changelog")
+	})
+}
+
+func TestRenderActionChangelogMarkdownGroups(t *testing.T) {
+	groups, err := parseUpdateGroups([]byte(testUpdateGroupsConfig))
+	require.NoError(t, err, "valid update groups should parse")
+
+	changes := []actionPinChange{
+		{Repo: "actions/checkout", OldVersion: "v4", NewVersion: "v5", CompareURL: "https://github.com/actions/checkout/compare/v4...v5"},
+		{Repo: "docker/login-action", OldVersion: "v3", NewVersion: "v4", CompareURL: "https://github.com/docker/login-action/compare/v3...v4"},
+		{Repo: "github/codeql-action/init", OldVersion: "v3", NewVersion: "v4", CompareURL: "https://github.com/github/codeql-action/compare/v3...v4"},
+	}
+	assignUpdateGroups(changes, groups)
+	assert.Equal(t, "core-actions", changes[0].Group, "core action group")
+	assert.Equal(t, "third-party", changes[2].Group, "excluded sub-action should fall through to the next group")
+
+	markdown := renderActionChangelogMarkdown(changes)
+	core := strings.Index(markdown, "### core-actions")
+	thirdParty := strings.Index(markdown, "### third-party")
+	require.NotEqual(t, -1, core, "core-actions section should be rendered")
+	require.NotEqual(t, -1, thirdParty, "third-party section should be rendered")
+	assert.Less(t, core, thirdParty, "sections should follow the order of the changes")
+	assert.Less(t, thirdParty, strings.Index(markdown, "`docker/login-action`"), "third-party changes should be listed in their section")
+
+	ungrouped := []actionPinChange{{Repo: "actions/checkout", OldVersion: "v4", NewVersion: "v5"}}
+	assert.NotContains(t, renderActionChangelogMarkdown(ungrouped), "###", "ungrouped changes should not get a section heading")
+}
+
+func TestUpdateActionsReturnsPinChanges(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "changelog-update-*")
+	deps := newActionUpdateDepsWithLatestRelease(func(_ context.Context, repo, currentVersion string, _, _ bool) (string, string, error) {
+		switch repo {
+		case "actions/setup-node":
+			return "v5", "newnodesha2345678901234567890123456789012", nil
+		case "actions/checkout":
+			return "v5", "newcheckoutsha234567890123456789012345678", nil
+		default:
+			return currentVersion, "unchangedsha3456789012345678901234567890", nil
+		}
+	})
+
+	cache := workflow.NewActionCache(tmpDir)
+	cache.Set("actions/setup-node", "v4", "oldnodesha2345678901234567890123456789012")
+	cache.Set("actions/checkout", "v4", "oldcheckoutsha234567890123456789012345678")
+	cache.Set("owner/current", "v1", "unchangedsha3456789012345678901234567890")
+	require.NoError(t, cache.Save(), "save initial cache")
+
+	wd, err := os.Getwd()
+	require.NoError(t, err, "get working directory")
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+	require.NoError(t, os.Chdir(tmpDir), "chdir")
+
+	changes, err := updateActions(context.Background(), deps, false, false, false, 0, updateGroupSelection{})
+	require.NoError(t, err, "updateActions")
+	require.Len(t, changes, 2, "only updated pins are reported")
+	assert.Equal(t, "actions/checkout", changes[0].Repo, "changes are sorted by repository")
+	assert.Equal(t, "v4", changes[0].OldVersion, "old version")
+	assert.Equal(t, "v5", changes[0].NewVersion, "new version")
+	assert.Equal(t, "oldcheckoutsha234567890123456789012345678", changes[0].OldSHA, "old SHA")
+	assert.Equal(t, "newcheckoutsha234567890123456789012345678", changes[0].NewSHA, "new SHA")
+	assert.Equal(t, "actions/setup-node", changes[1].Repo, "second change")
+}
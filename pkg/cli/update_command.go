@@ -38,6 +38,31 @@ Use --no-merge to override local changes with the upstream version.
 By default, update also bumps all referenced GitHub Actions to their latest major version.
 Use --no-release-bump to restrict auto-bumping to core actions/* actions only.
 
+To hold back specific actions, record directives in .github/aw/actions-lock.json:
+- --pin owner/repo@version keeps the entry at that version
+- --ignore-major owner/repo[@version] only allows minor and patch updates
+- --unpin owner/repo[@version] removes both directives
+When any of these flags is given, only actions-lock.json is modified.
+With --offline, pinning a version that is not already in actions-lock.json fails
+instead of resolving its SHA from GitHub; all other updates require the network.
+
+MCP servers launched with npx or uvx float to the latest package version unless
+the workflow names one. Use --mcp to record the latest version of each such
+package in the "mcp" section of actions-lock.json; the compiler then launches
+the recorded version. Rerun --mcp to bump the pins.
+
+Use --engines to bump the engine.version pinned in workflow frontmatter to the
+latest engine CLI release on npm. Like actions, major version updates are only
+applied with --major. Workflows without engine.version use the version pinned
+by gh-aw, which 'gh aw upgrade' updates.
+
+Use --group to update only the actions (or, with --engines, the engine CLI
+packages) matching one of the groups declared under update.groups in
+.github/aw/config.yml. Each group lists glob patterns such as "actions/*" or
+"@anthropic-ai/*"; a dependency belongs to the first group that matches it.
+Group-scoped runs skip workflow source, skill and container pin updates, so
+each group's changes can be reviewed in a separate pull request.
+
 For workflow updates, it fetches the latest version based on the current ref:
 - If the ref is a tag, it updates to the latest release (use --major for major version updates)
 - If the ref is a branch, it fetches the latest commit from that branch
@@ -62,7 +87,17 @@ Note: In GitHub Enterprise repos, shorthand source specs resolve on your enterpr
   ` + string(constants.CLIExtensionPrefix) + ` update repo-assist --major # Allow major version updates
   ` + string(constants.CLIExtensionPrefix) + ` update --force            # Force update even if no changes
   ` + string(constants.CLIExtensionPrefix) + ` update --no-release-bump     # Disable force-bumping non-core actions (core actions/* are still force-updated)
+  ` + string(constants.CLIExtensionPrefix) + ` update --pin actions/setup-node@v4   # Hold actions/setup-node at v4
+  ` + string(constants.CLIExtensionPrefix) + ` update --ignore-major docker/login-action  # Only minor/patch updates
+  ` + string(constants.CLIExtensionPrefix) + ` update --unpin actions/setup-node  # Remove update directives
+  ` + string(constants.CLIExtensionPrefix) + ` update --offline --pin actions/setup-node@v4  # Pin using only actions-lock.json
+  ` + string(constants.CLIExtensionPrefix) + ` update --mcp                 # Pin npx/uvx MCP server packages to their latest versions
+  ` + string(constants.CLIExtensionPrefix) + ` update --engines             # Bump pinned engine.version fields to the latest CLI releases
+  ` + string(constants.CLIExtensionPrefix) + ` update --engines --major     # Also allow major engine CLI version updates
+  ` + string(constants.CLIExtensionPrefix) + ` update --group core-actions  # Update only the actions in one update group
   ` + string(constants.CLIExtensionPrefix) + ` update --no-compile           # Update without regenerating lock files
+  ` + string(constants.CLIExtensionPrefix) + ` update --porcelain            # Print tab-separated update results for scripts
+  ` + string(constants.CLIExtensionPrefix) + ` update --changelog changes.md  # Write pin changes as a pull request body
   ` + string(constants.CLIExtensionPrefix) + ` update --no-redirect          # Refuse workflows that use redirect frontmatter
   ` + string(constants.CLIExtensionPrefix) + ` update --dir custom/workflows  # Update workflows in custom directory
   ` + string(constants.CLIExtensionPrefix) + ` update --repo owner/repo        # Update workflows in another repository
@@ -96,11 +131,48 @@ Note: In GitHub Enterprise repos, shorthand source specs resolve on your enterpr
 			targetRepo, _ := cmd.Flags().GetString("repo")
 			targetOrg, _ := cmd.Flags().GetString("org")
 			repoGlobs, _ := cmd.Flags().GetStringSlice("repos")
+			pinSpecs, _ := cmd.Flags().GetStringSlice("pin")
+			unpinSpecs, _ := cmd.Flags().GetStringSlice("unpin")
+			ignoreMajorSpecs, _ := cmd.Flags().GetStringSlice("ignore-major")
+			mcpFlag, _ := cmd.Flags().GetBool("mcp")
+			enginesFlag, _ := cmd.Flags().GetBool("engines")
+			porcelain, _ := cmd.Flags().GetBool("porcelain")
+			changelogPath, _ := cmd.Flags().GetString("changelog")
+			offline, _ := cmd.Flags().GetBool("offline")
+			group, _ := cmd.Flags().GetString("group")
 
 			if err := validateEngine(engineOverride); err != nil {
 				return err
 			}
 
+			directives := ActionLockDirectives{Pin: pinSpecs, Unpin: unpinSpecs, IgnoreMajor: ignoreMajorSpecs}
+			if !directives.IsEmpty() {
+				if len(args) > 0 || targetRepo != "" || targetOrg != "" || createPR || createIssue {
+					return errors.New("--pin, --unpin and --ignore-major cannot be combined with workflow names, --repo, --org, --create-pull-request or --create-issue")
+				}
+				return RunActionLockDirectives(cmd.Context(), directives, offline, verbose)
+			}
+
+			if offline {
+				// Everything below fetches workflow sources, action releases, or package
+				// versions, so only the actions-lock.json directives work without a network.
+				return errors.New("--offline only supports --pin, --unpin and --ignore-major: updating workflows, action versions, engine versions and MCP pins requires network access (use 'compile --offline' to recompile from the lock file and import cache)")
+			}
+
+			if mcpFlag {
+				if len(args) > 0 || targetRepo != "" || targetOrg != "" || createPR || createIssue {
+					return errors.New("--mcp cannot be combined with workflow names, --repo, --org, --create-pull-request or --create-issue")
+				}
+				return RunUpdateMCPPins(cmd.Context(), workflowDir, engineOverride, noCompile, verbose, approveFlag)
+			}
+
+			if enginesFlag {
+				if len(args) > 0 || targetRepo != "" || targetOrg != "" || createPR || createIssue {
+					return errors.New("--engines cannot be combined with workflow names, --repo, --org, --create-pull-request or --create-issue")
+				}
+				return RunUpdateEngines(cmd.Context(), workflowDir, engineOverride, group, majorFlag, noCompile, verbose, approveFlag)
+			}
+
 			coolDown, err := parseCoolDownFlag(coolDownStr)
 			if err != nil {
 				return fmt.Errorf("invalid --cool-down value: %w", err)
@@ -114,6 +186,18 @@ Note: In GitHub Enterprise repos, shorthand source specs resolve on your enterpr
 				return errors.New("--create-issue requires --org to be specified")
 			}
 
+			if porcelain && targetOrg != "" {
+				return errors.New("--porcelain cannot be combined with --org")
+			}
+
+			if changelogPath != "" && targetOrg != "" {
+				return errors.New("--changelog cannot be combined with --org")
+			}
+
+			if group != "" && (len(args) > 0 || targetRepo != "" || targetOrg != "") {
+				return errors.New("--group cannot be combined with workflow names, --repo or --org")
+			}
+
 			if createPR && createIssue {
 				return errors.New("cannot specify both --create-pull-request and --create-issue")
 			}
@@ -141,6 +225,9 @@ Note: In GitHub Enterprise repos, shorthand source specs resolve on your enterpr
 				DisableSecurityScanner: disableSecurityScanner,
 				CoolDown:               coolDown,
 				Approve:                approveFlag,
+				Porcelain:              porcelain,
+				Changelog:              changelogPath,
+				Group:                  group,
 			}
 
 			if targetRepo != "" {
@@ -189,6 +276,15 @@ Note: In GitHub Enterprise repos, shorthand source specs resolve on your enterpr
 	cmd.Flags().Bool("create-issue", false, "Open a GitHub issue in each org repository that has pending workflow updates (requires --org)")
 	cmd.Flags().BoolP("yes", "y", false, "Auto-accept org-mode update confirmations (required in CI)")
 	cmd.Flags().String("cool-down", "7d", coolDownFlagUsage)
+	cmd.Flags().StringSlice("pin", nil, "Pin an action in actions-lock.json so updates skip it (owner/repo@version)")
+	cmd.Flags().StringSlice("unpin", nil, "Remove pin and ignore-major directives from an action in actions-lock.json (owner/repo[@version])")
+	cmd.Flags().StringSlice("ignore-major", nil, "Only apply minor and patch updates to an action in actions-lock.json (owner/repo[@version])")
+	cmd.Flags().Bool("offline", false, "Never use the network: only apply --pin, --unpin and --ignore-major to entries already in actions-lock.json")
+	cmd.Flags().Bool("mcp", false, "Pin npx/uvx MCP server packages to their latest versions in actions-lock.json")
+	cmd.Flags().Bool("engines", false, "Bump engine.version in workflow frontmatter to the latest engine CLI release on npm (use --major for major updates)")
+	cmd.Flags().String("changelog", "", "Write the actions-lock.json pin changes with compare links and release notes to a file (.json for JSON, otherwise markdown)")
+	cmd.Flags().String("group", "", "Only update dependencies in this update group from .github/aw/config.yml (update.groups)")
+	addPorcelainFlag(cmd)
 	_ = cmd.Flags().MarkHidden("pr") // Hide the short alias from help output
 
 	// Register completions for update command
@@ -204,25 +300,59 @@ Note: In GitHub Enterprise repos, shorthand source specs resolve on your enterpr
 func RunUpdateWorkflows(ctx context.Context, opts UpdateWorkflowsOptions) error {
 	updateLog.Printf("Starting update process: workflows=%v, allowMajor=%v, force=%v, noMerge=%v, disableReleaseBump=%v, noCompile=%v, noRedirect=%v, coolDown=%v", opts.WorkflowNames, opts.AllowMajor, opts.Force, opts.NoMerge, opts.DisableReleaseBump, opts.NoCompile, opts.NoRedirect, opts.CoolDown)
 
+	group, err := resolveUpdateGroupSelection(opts.Group)
+	if err != nil {
+		return err
+	}
+
 	var firstErr error
 
-	if err := UpdateWorkflows(ctx, opts); err != nil {
-		firstErr = fmt.Errorf("workflow update failed: %w", err)
+	// A group-scoped run only touches the dependencies of that group, so workflow
+	// sources are left for the ungrouped run.
+	if group.name == "" {
+		if err := UpdateWorkflows(ctx, opts); err != nil {
+			firstErr = fmt.Errorf("workflow update failed: %w", err)
+		}
 	}
 
 	// Update GitHub Actions versions in actions-lock.json.
 	// By default all actions are updated to the latest major version.
 	// Pass --no-release-bump to revert to only forcing updates for core (actions/*) actions.
 	updateLog.Printf("Updating GitHub Actions versions in actions-lock.json: allowMajor=%v, disableReleaseBump=%v", opts.AllowMajor, opts.DisableReleaseBump)
-	if err := UpdateActions(ctx, opts.AllowMajor, opts.Verbose, opts.DisableReleaseBump, opts.CoolDown); err != nil {
+	deps := defaultActionUpdateDeps()
+	pinChanges, err := updateActions(ctx, deps, opts.AllowMajor, opts.Verbose, opts.DisableReleaseBump, opts.CoolDown, group)
+	if err != nil {
 		// Non-fatal: warn but don't fail the update
 		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Warning: Failed to update actions-lock.json: %v", err)))
 	}
 
+	// Write the pin changelog (even when empty) so automation can tell "no changes"
+	// apart from a failed run and use the file as a pull request body.
+	if opts.Changelog != "" {
+		addReleaseNotes(ctx, deps, pinChanges)
+		assignUpdateGroups(pinChanges, group.groups)
+		if err := writeActionChangelog(opts.Changelog, pinChanges); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+		} else {
+			fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Wrote changelog for %d action pin change(s) to %s", len(pinChanges), opts.Changelog)))
+		}
+	}
+
 	// Update action references in user-provided steps within workflow .md files.
 	// By default all org/repo@version references are updated to the latest major version.
 	updateLog.Print("Updating action references in workflow .md files")
-	if err := UpdateActionsInWorkflowFiles(ctx, opts.WorkflowsDir, opts.EngineOverride, opts.Verbose, opts.DisableReleaseBump, opts.NoCompile, opts.CoolDown, opts.Approve); err != nil {
+	if err := updateActionsInWorkflowFiles(ctx, deps, updateActionsOptions{
+		workflowsDir:       opts.WorkflowsDir,
+		engineOverride:     opts.EngineOverride,
+		verbose:            opts.Verbose,
+		disableReleaseBump: opts.DisableReleaseBump,
+		noCompile:          opts.NoCompile,
+		coolDown:           opts.CoolDown,
+		approve:            opts.Approve,
+		group:              group,
+	}); err != nil {
 		// Non-fatal: warn but don't fail the update
 		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Warning: Failed to update action references in workflow files: %v", err)))
 	}
@@ -231,11 +361,15 @@ func RunUpdateWorkflows(ctx context.Context, opts UpdateWorkflowsOptions) error
 	// This runs after compilation (via UpdateActionsInWorkflowFiles) so that the lock files
 	// already reflect the current AWF version; stale pins from superseded versions are pruned
 	// and new versions are resolved in a single pass.
-	updateLog.Print("Updating container image digest pins")
-	newContainerPins, err := UpdateContainerPins(ctx, opts.WorkflowsDir, opts.Verbose)
-	if err != nil {
-		// Non-fatal: Docker may not be available in all environments.
-		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Warning: Failed to update container pins: %v", err)))
+	// Container images are not part of any update group.
+	var newContainerPins bool
+	if group.name == "" {
+		updateLog.Print("Updating container image digest pins")
+		newContainerPins, err = UpdateContainerPins(ctx, opts.WorkflowsDir, opts.Verbose)
+		if err != nil {
+			// Non-fatal: Docker may not be available in all environments.
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Warning: Failed to update container pins: %v", err)))
+		}
 	}
 
 	// Recompile all workflows when new container pins were added so that the
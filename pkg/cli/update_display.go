@@ -2,6 +2,7 @@ package cli
 
 import (
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/github/gh-aw/pkg/console"
@@ -33,3 +34,16 @@ func showUpdateSummary(successfulUpdates []string, failedUpdates []updateFailure
 		fmt.Fprintln(os.Stderr, "")
 	}
 }
+
+// writeUpdatePorcelain writes workflow update results as porcelain records:
+//
+//	updated <workflow>
+//	failed  <workflow> <error>
+func writeUpdatePorcelain(w io.Writer, successfulUpdates []string, failedUpdates []updateFailure) {
+	for _, name := range successfulUpdates {
+		writePorcelainRecord(w, "updated", name)
+	}
+	for _, failure := range failedUpdates {
+		writePorcelainRecord(w, "failed", failure.Name, failure.Error)
+	}
+}
@@ -0,0 +1,311 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+	"github.com/github/gh-aw/pkg/semverutil"
+)
+
+var updateEnginesLog = logger.New("cli:update_engines")
+
+// engineRegistryTimeout bounds each npm registry lookup for engine CLI packages.
+const engineRegistryTimeout = 15 * time.Second
+
+// engineNpmPackages maps the engines whose install step honors engine.version to the npm
+// package of their CLI. Copilot is not listed: its CLI version is pinned by gh-aw and
+// engine.version is ignored at install time.
+var engineNpmPackages = map[string]string{
+	"claude":   "@anthropic-ai/claude-code",
+	"codex":    "@openai/codex",
+	"gemini":   "@google/gemini-cli",
+	"opencode": "opencode-ai",
+	"pi":       "@earendil-works/pi-coding-agent",
+}
+
+// fetchEngineVersionsFunc allows tests to inject a stub for npm registry lookups.
+var fetchEngineVersionsFunc = fetchNpmPackageVersions
+
+// engineVersionRef is a pinned engine.version found in a workflow file.
+type engineVersionRef struct {
+	Path     string
+	EngineID string
+	Package  string
+	Version  string
+}
+
+// RunUpdateEngines bumps the engine.version field of every workflow in workflowsDir to the
+// latest published version of the engine CLI on npm and recompiles the workflows unless
+// noCompile is set. Like action updates, a version is only moved to a new major version
+// when allowMajor is set. Workflows without engine.version use the version pinned by gh-aw
+// and are left unchanged. A non-empty group limits the update to the engine CLI packages
+// of that update group.
+func RunUpdateEngines(ctx context.Context, workflowsDir, engineOverride, group string, allowMajor, noCompile, verbose, approve bool) error {
+	if workflowsDir == "" {
+		workflowsDir = getWorkflowsDir()
+	}
+	updateEnginesLog.Printf("Updating engine versions: dir=%s, allowMajor=%v", workflowsDir, allowMajor)
+
+	selection, err := resolveUpdateGroupSelection(group)
+	if err != nil {
+		return err
+	}
+
+	refs, err := collectEngineVersionRefs(workflowsDir, verbose)
+	if err != nil {
+		return err
+	}
+	refs = slices.DeleteFunc(refs, func(ref engineVersionRef) bool {
+		return !selection.includes(ref.Package)
+	})
+	if len(refs) == 0 {
+		if selection.name != "" {
+			fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("No workflows pin engine.version for an engine CLI in update group %q", selection.name)))
+			return nil
+		}
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage("No workflows pin engine.version for an engine CLI installed from npm"))
+		return nil
+	}
+
+	changed, updateErr := updateEngineVersions(ctx, refs, allowMajor, verbose)
+	if changed == 0 {
+		if updateErr != nil {
+			return updateErr
+		}
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage("All engine versions are up to date"))
+		return nil
+	}
+	fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("Updated engine.version in %d workflow(s)", changed)))
+
+	if !noCompile {
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Recompiling workflows to install the updated engine versions..."))
+		if err := recompileAllWorkflows(ctx, workflowsDir, engineOverride, verbose, approve); err != nil {
+			return err
+		}
+	}
+	return updateErr
+}
+
+// updateEngineVersions resolves the latest version of each referenced engine package and
+// rewrites engine.version in the workflows that are behind. It returns the number of
+// workflow files changed. Packages that cannot be resolved are reported after all other
+// workflows have been updated.
+func updateEngineVersions(ctx context.Context, refs []engineVersionRef, allowMajor, verbose bool) (int, error) {
+	available := make(map[string][]string)
+	var failures []string
+	changed := 0
+
+	for _, ref := range refs {
+		versions, resolved := available[ref.Package]
+		if !resolved {
+			var err error
+			versions, err = fetchEngineVersionsFunc(ctx, ref.Package)
+			if err != nil {
+				updateEnginesLog.Printf("Failed to resolve %s: %v", ref.Package, err)
+				failures = append(failures, fmt.Sprintf("%s: %v", ref.Package, err))
+				versions = nil
+			}
+			available[ref.Package] = versions
+		}
+		if versions == nil {
+			continue
+		}
+
+		latest, heldBack := selectEngineUpdateVersion(ref.Version, versions, allowMajor)
+		if heldBack != "" {
+			fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("%s: %s %s is available (major update, use --major to apply)", filepath.Base(ref.Path), ref.EngineID, heldBack)))
+		}
+		if latest == "" {
+			if verbose {
+				fmt.Fprintln(os.Stderr, console.FormatVerboseMessage(fmt.Sprintf("%s: %s %s is up to date", filepath.Base(ref.Path), ref.EngineID, ref.Version)))
+			}
+			continue
+		}
+
+		if err := rewriteEngineVersion(ref.Path, ref.Version, latest); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", ref.Path, err))
+			continue
+		}
+		changed++
+		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("Updated %s: %s %s → %s", filepath.Base(ref.Path), ref.EngineID, ref.Version, latest)))
+	}
+
+	if len(failures) > 0 {
+		return changed, fmt.Errorf("failed to update %d engine version(s):\n  %s", len(failures), strings.Join(failures, "\n  "))
+	}
+	return changed, nil
+}
+
+// selectEngineUpdateVersion returns the newest stable version in available that is newer
+// than current. When allowMajor is false, versions with a different major version are not
+// selected; the newest of them is returned as heldBack so callers can report it.
+func selectEngineUpdateVersion(current string, available []string, allowMajor bool) (latest, heldBack string) {
+	currentVersion := semverutil.ParseVersion(current)
+	if currentVersion == nil {
+		return "", ""
+	}
+	for _, candidate := range available {
+		version := semverutil.ParseVersion(candidate)
+		if version == nil || version.Pre != "" || semverutil.Compare(candidate, current) <= 0 {
+			continue
+		}
+		if !allowMajor && version.Major != currentVersion.Major {
+			if heldBack == "" || semverutil.Compare(candidate, heldBack) > 0 {
+				heldBack = candidate
+			}
+			continue
+		}
+		if latest == "" || semverutil.Compare(candidate, latest) > 0 {
+			latest = candidate
+		}
+	}
+	return latest, heldBack
+}
+
+// collectEngineVersionRefs returns the engine.version pins of every workflow (including
+// shared workflows) in workflowsDir whose engine CLI is installed from npm. Versions that
+// are not exact semantic versions (e.g. "latest" or expressions) are skipped.
+func collectEngineVersionRefs(workflowsDir string, verbose bool) ([]engineVersionRef, error) {
+	var refs []engineVersionRef
+	err := filepath.WalkDir(workflowsDir, func(path string, d os.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if d.IsDir() || !strings.HasSuffix(d.Name(), ".md") {
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		result, err := parser.ExtractFrontmatterFromContent(string(content))
+		if err != nil || result.Frontmatter == nil {
+			updateEnginesLog.Printf("Skipping %s: no parsable frontmatter", path)
+			return nil
+		}
+		engine, ok := result.Frontmatter["engine"].(map[string]any)
+		if !ok {
+			return nil
+		}
+		engineID, _ := engine["id"].(string)
+		version, _ := engine["version"].(string)
+		pkg, known := engineNpmPackages[engineID]
+		if !known || version == "" {
+			return nil
+		}
+		if parsed := semverutil.ParseVersion(version); parsed == nil || !parsed.IsPreciseVersion() {
+			if verbose {
+				fmt.Fprintln(os.Stderr, console.FormatVerboseMessage(fmt.Sprintf("%s: engine.version %q is not an exact version, skipping", filepath.Base(path), version)))
+			}
+			return nil
+		}
+		refs = append(refs, engineVersionRef{Path: path, EngineID: engineID, Package: pkg, Version: version})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan workflows for engine versions: %w", err)
+	}
+
+	slices.SortFunc(refs, func(a, b engineVersionRef) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+	updateEnginesLog.Printf("Found %d pinned engine version(s) in %s", len(refs), workflowsDir)
+	return refs, nil
+}
+
+// rewriteEngineVersion replaces the engine.version value in the workflow file at path.
+func rewriteEngineVersion(path, oldVersion, newVersion string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read workflow: %w", err)
+	}
+	updated, err := setEngineVersionInContent(string(content), oldVersion, newVersion)
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to stat workflow: %w", err)
+	}
+	return os.WriteFile(path, []byte(updated), info.Mode().Perm())
+}
+
+// setEngineVersionInContent replaces the engine.version value in workflow content, keeping
+// the surrounding formatting (quotes, comments, indentation) unchanged.
+func setEngineVersionInContent(content, oldVersion, newVersion string) (string, error) {
+	result, err := parser.ExtractFrontmatterFromContent(content)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse frontmatter: %w", err)
+	}
+	loc, ok := result.FieldLocations["engine.version"]
+	if !ok {
+		return "", errors.New("engine.version not found in frontmatter")
+	}
+
+	lines := strings.Split(content, "\n")
+	if loc.Line < 1 || loc.Line > len(lines) {
+		return "", errors.New("engine.version location is out of range")
+	}
+	line := lines[loc.Line-1]
+	valueStart := min(loc.Column-1+loc.Length, len(line))
+	idx := strings.Index(line[valueStart:], oldVersion)
+	if idx < 0 {
+		return "", fmt.Errorf("engine.version %q not found on line %d", oldVersion, loc.Line)
+	}
+	idx += valueStart
+	lines[loc.Line-1] = line[:idx] + newVersion + line[idx+len(oldVersion):]
+	return strings.Join(lines, "\n"), nil
+}
+
+// fetchNpmPackageVersions lists the published versions of an npm package.
+func fetchNpmPackageVersions(ctx context.Context, pkg string) ([]string, error) {
+	registryURL := "https://registry.npmjs.org/" + strings.Replace(pkg, "/", "%2F", 1)
+
+	reqCtx, cancel := context.WithTimeout(ctx, engineRegistryTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodGet, registryURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	// The abbreviated metadata format lists versions without the full manifests
+	req.Header.Set("Accept", "application/vnd.npm.install-v1+json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned HTTP %d", registryURL, resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 50<<20))
+	if err != nil {
+		return nil, err
+	}
+
+	var payload struct {
+		Versions map[string]json.RawMessage `json:"versions"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("invalid registry response: %w", err)
+	}
+	if len(payload.Versions) == 0 {
+		return nil, errors.New("registry response has no versions")
+	}
+	versions := make([]string, 0, len(payload.Versions))
+	for version := range payload.Versions {
+		versions = append(versions, version)
+	}
+	return versions, nil
+}
@@ -0,0 +1,102 @@
+//go:build !integration
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectEngineUpdateVersion(t *testing.T) {
+	available := []string{"1.0.0", "1.2.0", "1.3.0-beta.1", "2.0.0", "2.1.0"}
+
+	tests := []struct {
+		name         string
+		current      string
+		allowMajor   bool
+		wantLatest   string
+		wantHeldBack string
+	}{
+		{name: "minor update within major", current: "1.0.0", wantLatest: "1.2.0", wantHeldBack: "2.1.0"},
+		{name: "major update allowed", current: "1.0.0", allowMajor: true, wantLatest: "2.1.0"},
+		{name: "up to date", current: "2.1.0"},
+		{name: "prereleases are ignored", current: "1.2.0", wantHeldBack: "2.1.0"},
+		{name: "invalid current version", current: "latest", allowMajor: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			latest, heldBack := selectEngineUpdateVersion(tt.current, available, tt.allowMajor)
+			assert.Equal(t, tt.wantLatest, latest, "latest")
+			assert.Equal(t, tt.wantHeldBack, heldBack, "held back")
+		})
+	}
+}
+
+func TestSetEngineVersionInContent(t *testing.T) {
+	content := `---
+on: workflow_dispatch
+engine:
+  id: claude
+  version: "2.1.0" # pinned for reproducibility
+---
+
+# Version 2.1.0 notes
+`
+	updated, err := setEngineVersionInContent(content, "2.1.0", "2.3.4")
+	require.NoError(t, err)
+	assert.Contains(t, updated, `  version: "2.3.4" # pinned for reproducibility`)
+	assert.Contains(t, updated, "# Version 2.1.0 notes", "markdown body should be untouched")
+
+	_, err = setEngineVersionInContent("---\nengine: claude\n---\n", "2.1.0", "2.3.4")
+	require.Error(t, err)
+}
+
+func TestRunUpdateEngines(t *testing.T) {
+	orig := fetchEngineVersionsFunc
+	t.Cleanup(func() { fetchEngineVersionsFunc = orig })
+
+	lookups := 0
+	fetchEngineVersionsFunc = func(_ context.Context, pkg string) ([]string, error) {
+		lookups++
+		switch pkg {
+		case "@anthropic-ai/claude-code":
+			return []string{"2.1.0", "2.2.0", "3.0.0"}, nil
+		case "@openai/codex":
+			return []string{"0.144.6"}, nil
+		}
+		return nil, errors.New("not found")
+	}
+
+	dir := t.TempDir()
+	write := func(name, engine string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte("---\non: workflow_dispatch\nengine:\n"+engine+"---\n\n# Test\n"), 0644))
+		return path
+	}
+	claudeA := write("a.md", "  id: claude\n  version: 2.1.0\n")
+	claudeB := write("b.md", "  id: claude\n  version: 2.1.0\n")
+	codex := write("c.md", "  id: codex\n  version: 0.144.6\n")
+	copilot := write("d.md", "  id: copilot\n  version: 1.0.0\n")
+	latest := write("e.md", "  id: claude\n  version: latest\n")
+
+	require.NoError(t, RunUpdateEngines(context.Background(), dir, "", "", false, true, false, false))
+	assert.Equal(t, 2, lookups, "each package should be resolved once; copilot and non-exact versions are skipped")
+
+	for path, want := range map[string]string{
+		claudeA: "version: 2.2.0",
+		claudeB: "version: 2.2.0",
+		codex:   "version: 0.144.6",
+		copilot: "version: 1.0.0",
+		latest:  "version: latest",
+	} {
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), want, filepath.Base(path))
+	}
+}
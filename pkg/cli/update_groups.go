@@ -0,0 +1,173 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+)
+
+var updateGroupsLog = logger.New("cli:update_groups")
+
+// updateGroupsConfigFile is the repository configuration file that declares update groups
+// under update.groups, alongside other repository settings such as import aliases.
+const updateGroupsConfigFile = parser.ImportAliasConfigFile
+
+// updateGroup is a named set of dependencies that are updated together, in the style of
+// Dependabot groups. Patterns are globs matched against action repositories
+// (e.g. "actions/*") and engine CLI npm packages (e.g. "@anthropic-ai/*").
+type updateGroup struct {
+	Name            string
+	Patterns        []string
+	ExcludePatterns []string
+}
+
+// updateGroups are the groups declared in config.yml, in declaration order.
+type updateGroups []updateGroup
+
+// updateGroupFile mirrors the parts of .github/aw/config.yml read for update groups.
+type updateGroupFile struct {
+	Update struct {
+		Groups yaml.MapSlice `yaml:"groups"`
+	} `yaml:"update"`
+}
+
+type updateGroupSpec struct {
+	Patterns        []string `yaml:"patterns"`
+	ExcludePatterns []string `yaml:"exclude-patterns"`
+}
+
+// parseUpdateGroups parses the update.groups map of a config.yml document. Group order is
+// kept: a dependency belongs to the first group whose patterns match it.
+func parseUpdateGroups(content []byte) (updateGroups, error) {
+	var file updateGroupFile
+	if err := yaml.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", updateGroupsConfigFile, err)
+	}
+	var groups updateGroups
+	var errs []error
+	for _, item := range file.Update.Groups {
+		name, ok := item.Key.(string)
+		if !ok || strings.TrimSpace(name) == "" {
+			errs = append(errs, fmt.Errorf("update group names must be non-empty strings, got %v", item.Key))
+			continue
+		}
+		data, err := yaml.Marshal(item.Value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("update group %q: %w", name, err))
+			continue
+		}
+		var spec updateGroupSpec
+		if err := yaml.UnmarshalWithOptions(data, &spec, yaml.DisallowUnknownField()); err != nil {
+			errs = append(errs, fmt.Errorf("update group %q: %w", name, err))
+			continue
+		}
+		if len(spec.Patterns) == 0 {
+			errs = append(errs, fmt.Errorf("update group %q must declare at least one pattern", name))
+			continue
+		}
+		for _, pattern := range slices.Concat(spec.Patterns, spec.ExcludePatterns) {
+			if _, err := path.Match(pattern, ""); err != nil {
+				errs = append(errs, fmt.Errorf("update group %q has invalid pattern %q: %w", name, pattern, err))
+			}
+		}
+		groups = append(groups, updateGroup{Name: name, Patterns: spec.Patterns, ExcludePatterns: spec.ExcludePatterns})
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid %s: %w", updateGroupsConfigFile, errors.Join(errs...))
+	}
+	return groups, nil
+}
+
+// loadUpdateGroups returns the update groups declared in repoRoot/.github/aw/config.yml.
+// A missing file yields no groups.
+func loadUpdateGroups(repoRoot string) (updateGroups, error) {
+	configPath := filepath.Join(repoRoot, filepath.FromSlash(updateGroupsConfigFile))
+	content, err := os.ReadFile(configPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", updateGroupsConfigFile, err)
+	}
+	groups, err := parseUpdateGroups(content)
+	if err != nil {
+		return nil, err
+	}
+	updateGroupsLog.Printf("Loaded %d update group(s) from %s", len(groups), configPath)
+	return groups, nil
+}
+
+// groupFor returns the name of the first group that dependency belongs to, or "" when
+// it is not part of any group.
+func (g updateGroups) groupFor(dependency string) string {
+	for _, group := range g {
+		if matchesAnyUpdatePattern(group.Patterns, dependency) && !matchesAnyUpdatePattern(group.ExcludePatterns, dependency) {
+			return group.Name
+		}
+	}
+	return ""
+}
+
+func (g updateGroups) names() []string {
+	names := make([]string, len(g))
+	for i, group := range g {
+		names[i] = group.Name
+	}
+	return names
+}
+
+// matchesAnyUpdatePattern reports whether dependency matches one of the glob patterns.
+// "*" matches a whole dependency name, including any "/".
+func matchesAnyUpdatePattern(patterns []string, dependency string) bool {
+	for _, pattern := range patterns {
+		if pattern == "*" {
+			return true
+		}
+		if matched, _ := path.Match(pattern, dependency); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// updateGroupSelection restricts an update run to the dependencies of one group.
+// The zero value selects every dependency.
+type updateGroupSelection struct {
+	groups updateGroups
+	name   string
+}
+
+// resolveUpdateGroupSelection loads the update groups from the current repository and
+// selects the named group. An empty name selects every dependency.
+func resolveUpdateGroupSelection(name string) (updateGroupSelection, error) {
+	groups, err := loadUpdateGroups(".")
+	if err != nil {
+		return updateGroupSelection{}, err
+	}
+	if name == "" {
+		return updateGroupSelection{groups: groups}, nil
+	}
+	if len(groups) == 0 {
+		return updateGroupSelection{}, fmt.Errorf("--group %q: no update groups are declared under update.groups in %s", name, updateGroupsConfigFile)
+	}
+	for _, group := range groups {
+		if group.Name == name {
+			return updateGroupSelection{groups: groups, name: name}, nil
+		}
+	}
+	return updateGroupSelection{}, fmt.Errorf("--group %q: unknown update group (declared groups: %s)", name, strings.Join(groups.names(), ", "))
+}
+
+// includes reports whether dependency should be updated in this run.
+func (s updateGroupSelection) includes(dependency string) bool {
+	return s.name == "" || s.groups.groupFor(dependency) == s.name
+}
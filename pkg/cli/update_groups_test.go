@@ -0,0 +1,133 @@
+//go:build !integration
+
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testUpdateGroupsConfig = `imports:
+  aliases:
+    shared: octo/shared@v1
+update:
+  groups:
+    core-actions:
+      patterns: ["actions/*", "github/*"]
+      exclude-patterns: ["github/codeql-action"]
+    engines:
+      patterns: ["@anthropic-ai/*", "@openai/codex"]
+    third-party:
+      patterns: ["*"]
+`
+
+func TestParseUpdateGroups(t *testing.T) {
+	groups, err := parseUpdateGroups([]byte(testUpdateGroupsConfig))
+	require.NoError(t, err, "valid update groups should parse")
+	assert.Equal(t, []string{"core-actions", "engines", "third-party"}, groups.names(), "declaration order should be kept")
+
+	tests := []struct {
+		dependency string
+		want       string
+	}{
+		{dependency: "actions/checkout", want: "core-actions"},
+		{dependency: "github/stale-repos", want: "core-actions"},
+		{dependency: "github/codeql-action", want: "third-party"},
+		{dependency: "@anthropic-ai/claude-code", want: "engines"},
+		{dependency: "@openai/codex", want: "engines"},
+		{dependency: "docker/login-action", want: "third-party"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, groups.groupFor(tt.dependency), "group of %s", tt.dependency)
+	}
+}
+
+func TestParseUpdateGroupsWithoutGroups(t *testing.T) {
+	groups, err := parseUpdateGroups([]byte("imports:\n  aliases:\n    shared: octo/shared@v1\n"))
+	require.NoError(t, err, "config without update groups should parse")
+	assert.Empty(t, groups, "no groups should be declared")
+	assert.Empty(t, groups.groupFor("actions/checkout"), "dependencies should be ungrouped")
+}
+
+func TestParseUpdateGroupsErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		errContains string
+	}{
+		{
+			name:        "missing patterns",
+			content:     "update:\n  groups:\n    core: {}\n",
+			errContains: `update group "core" must declare at least one pattern`,
+		},
+		{
+			name:        "unknown field",
+			content:     "update:\n  groups:\n    core:\n      patterns: [\"actions/*\"]\n      schedule: weekly\n",
+			errContains: `update group "core"`,
+		},
+		{
+			name:        "invalid pattern",
+			content:     "update:\n  groups:\n    core:\n      patterns: [\"actions/[\"]\n",
+			errContains: `invalid pattern "actions/["`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseUpdateGroups([]byte(tt.content))
+			require.Error(t, err, "invalid update groups should be rejected")
+			assert.Contains(t, err.Error(), tt.errContains, "error should describe the problem")
+		})
+	}
+}
+
+func TestResolveUpdateGroupSelection(t *testing.T) {
+	dir := testutil.TempDir(t, "update-groups-*")
+	t.Chdir(dir)
+
+	all, err := resolveUpdateGroupSelection("")
+	require.NoError(t, err, "missing config should select everything")
+	assert.True(t, all.includes("actions/checkout"), "zero selection includes everything")
+
+	_, err = resolveUpdateGroupSelection("core-actions")
+	require.Error(t, err, "a group needs a config file")
+	assert.Contains(t, err.Error(), "no update groups are declared", "error should explain the missing config")
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".github", "aw"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".github", "aw", "config.yml"), []byte(testUpdateGroupsConfig), 0644))
+
+	selection, err := resolveUpdateGroupSelection("core-actions")
+	require.NoError(t, err, "declared group should resolve")
+	assert.True(t, selection.includes("actions/checkout"), "core action should be included")
+	assert.False(t, selection.includes("docker/login-action"), "third-party action should be excluded")
+
+	_, err = resolveUpdateGroupSelection("nightly")
+	require.Error(t, err, "unknown group should be rejected")
+	assert.Contains(t, err.Error(), "core-actions, engines, third-party", "error should list the declared groups")
+}
+
+func TestUpdateActionRefsInContent_GroupSelection(t *testing.T) {
+	deps := newActionUpdateDepsWithLatestRelease(func(_ context.Context, repo, currentVersion string, allowMajor, verbose bool) (string, string, error) {
+		return "v9", "", nil
+	})
+	groups, err := parseUpdateGroups([]byte(testUpdateGroupsConfig))
+	require.NoError(t, err, "valid update groups should parse")
+
+	input := `steps:
+  - uses: actions/checkout@v4
+  - uses: docker/login-action@v3`
+	want := `steps:
+  - uses: actions/checkout@v9
+  - uses: docker/login-action@v3`
+
+	directives := actionUpdateDirectives{group: updateGroupSelection{groups: groups, name: "core-actions"}}
+	changed, got, err := updateActionRefsInContentWithDeps(context.Background(), deps, input, make(map[string]latestReleaseResult), make(map[string]coolDownCheckResult), directives, true, false, 0)
+	require.NoError(t, err, "update should succeed")
+	assert.True(t, changed, "the core action should be updated")
+	assert.Equal(t, want, got, "only actions in the selected group should change")
+}
@@ -0,0 +1,215 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+	"github.com/github/gh-aw/pkg/workflow"
+)
+
+var updateMCPPinsLog = logger.New("cli:update_mcp_pins")
+
+// mcpRegistryTimeout bounds each package registry lookup.
+const mcpRegistryTimeout = 15 * time.Second
+
+// resolveMCPPackageVersionFunc allows tests to inject a stub for registry lookups.
+var resolveMCPPackageVersionFunc = resolveLatestMCPPackageVersion
+
+// RunUpdateMCPPins resolves the latest version of every unversioned npx/uvx MCP server
+// package referenced by the workflows in workflowsDir, records the versions in the "mcp"
+// section of .github/aw/actions-lock.json, and recompiles the workflows unless noCompile
+// is set. Pins for packages no longer referenced by any workflow are removed.
+func RunUpdateMCPPins(ctx context.Context, workflowsDir, engineOverride string, noCompile, verbose, approve bool) error {
+	if workflowsDir == "" {
+		workflowsDir = getWorkflowsDir()
+	}
+	updateMCPPinsLog.Printf("Updating MCP package pins: dir=%s", workflowsDir)
+
+	refs, err := collectMCPPackageRefsFromWorkflows(workflowsDir)
+	if err != nil {
+		return err
+	}
+
+	actionCache := workflow.NewActionCache(".")
+	if err := actionCache.Load(); err != nil {
+		return fmt.Errorf("failed to parse actions lock file: %w", err)
+	}
+
+	// Save before reporting resolution failures so pins that did resolve are kept.
+	changed, resolveErr := updateMCPPins(ctx, actionCache, refs, verbose)
+	if err := actionCache.Save(); err != nil {
+		return fmt.Errorf("failed to save actions lock file: %w", err)
+	}
+	if resolveErr != nil {
+		return resolveErr
+	}
+
+	if changed == 0 {
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage("All MCP server packages are up to date"))
+		return nil
+	}
+	fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("Updated %d MCP package pin(s) in actions-lock.json", changed)))
+
+	if noCompile {
+		return nil
+	}
+	fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Recompiling workflows to embed MCP package pins..."))
+	return recompileAllWorkflows(ctx, workflowsDir, engineOverride, verbose, approve)
+}
+
+// updateMCPPins refreshes the MCP pins in actionCache for the given package references and
+// prunes pins that are no longer referenced. It returns the number of pins added or changed.
+func updateMCPPins(ctx context.Context, actionCache *workflow.ActionCache, refs []workflow.MCPPackageRef, verbose bool) (int, error) {
+	referenced := make(map[string]struct{}, len(refs))
+	changed := 0
+	var failures []string
+
+	for _, ref := range refs {
+		if ref.Version != "" {
+			if verbose {
+				fmt.Fprintln(os.Stderr, console.FormatVerboseMessage(fmt.Sprintf("%s@%s is versioned in the workflow, skipping", ref.Package, ref.Version)))
+			}
+			continue
+		}
+		key := workflow.MCPPackagePinKey(ref.Registry, ref.Package)
+		if _, seen := referenced[key]; seen {
+			continue
+		}
+		referenced[key] = struct{}{}
+
+		version, err := resolveMCPPackageVersionFunc(ctx, ref.Registry, ref.Package)
+		if err != nil {
+			updateMCPPinsLog.Printf("Failed to resolve %s: %v", key, err)
+			failures = append(failures, fmt.Sprintf("%s: %v", key, err))
+			continue
+		}
+
+		current, ok := actionCache.GetMCPPin(ref.Registry, ref.Package)
+		if ok && current.Version == version {
+			if verbose {
+				fmt.Fprintln(os.Stderr, console.FormatVerboseMessage(fmt.Sprintf("%s is up to date (%s)", key, version)))
+			}
+			continue
+		}
+		actionCache.SetMCPPin(ref.Registry, ref.Package, version)
+		changed++
+		if ok {
+			fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("Updated %s: %s → %s", key, current.Version, version)))
+		} else {
+			fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("Pinned %s to %s", key, version)))
+		}
+	}
+
+	if len(failures) > 0 {
+		// Keep existing pins for packages that could not be resolved so a registry outage
+		// does not silently unpin them.
+		return changed, fmt.Errorf("failed to resolve %d MCP package(s):\n  %s", len(failures), strings.Join(failures, "\n  "))
+	}
+
+	if pruned := actionCache.PruneStaleMCPPins(referenced); pruned > 0 {
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Removed %d unused MCP package pin(s)", pruned)))
+	}
+	return changed, nil
+}
+
+// collectMCPPackageRefsFromWorkflows returns the npx/uvx MCP server packages declared in the
+// tools and mcp-servers frontmatter of every workflow (including shared workflows) in workflowsDir.
+func collectMCPPackageRefsFromWorkflows(workflowsDir string) ([]workflow.MCPPackageRef, error) {
+	var refs []workflow.MCPPackageRef
+	err := filepath.WalkDir(workflowsDir, func(path string, d os.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if d.IsDir() || !strings.HasSuffix(d.Name(), ".md") {
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		result, err := parser.ExtractFrontmatterFromContent(string(content))
+		if err != nil || result.Frontmatter == nil {
+			updateMCPPinsLog.Printf("Skipping %s: no parsable frontmatter", path)
+			return nil
+		}
+		for _, section := range []string{"mcp-servers", "tools"} {
+			if servers, ok := result.Frontmatter[section].(map[string]any); ok {
+				refs = append(refs, workflow.CollectMCPPackageRefs(servers)...)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan workflows for MCP servers: %w", err)
+	}
+
+	slices.SortFunc(refs, func(a, b workflow.MCPPackageRef) int {
+		return strings.Compare(workflow.MCPPackagePinKey(a.Registry, a.Package), workflow.MCPPackagePinKey(b.Registry, b.Package))
+	})
+	updateMCPPinsLog.Printf("Found %d MCP package reference(s) in %s", len(refs), workflowsDir)
+	return refs, nil
+}
+
+// resolveLatestMCPPackageVersion queries the npm or PyPI registry for the latest
+// published version of a package.
+func resolveLatestMCPPackageVersion(ctx context.Context, registry, pkg string) (string, error) {
+	var registryURL string
+	switch registry {
+	case "npm":
+		registryURL = "https://registry.npmjs.org/" + strings.Replace(pkg, "/", "%2F", 1) + "/latest"
+	case "pypi":
+		registryURL = "https://pypi.org/pypi/" + url.PathEscape(pkg) + "/json"
+	default:
+		return "", fmt.Errorf("unsupported package registry %q", registry)
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, mcpRegistryTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodGet, registryURL, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%s returned HTTP %d", registryURL, resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 10<<20))
+	if err != nil {
+		return "", err
+	}
+
+	var payload struct {
+		Version string `json:"version"` // npm /latest
+		Info    struct {
+			Version string `json:"version"`
+		} `json:"info"` // PyPI /json
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return "", fmt.Errorf("invalid registry response: %w", err)
+	}
+	version := payload.Version
+	if version == "" {
+		version = payload.Info.Version
+	}
+	if version == "" {
+		return "", errors.New("registry response has no version")
+	}
+	return version, nil
+}
@@ -0,0 +1,90 @@
+//go:build !integration
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/github/gh-aw/pkg/workflow"
+)
+
+func TestCollectMCPPackageRefsFromWorkflows(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "shared"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.md"), []byte(`---
+on: workflow_dispatch
+mcp-servers:
+  fs:
+    command: npx
+    args: ["-y", "@modelcontextprotocol/server-filesystem"]
+---
+`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "shared", "fetch.md"), []byte(`---
+tools:
+  fetch:
+    command: uvx
+    args: ["mcp-server-fetch==1.0.0"]
+---
+`), 0644))
+
+	refs, err := collectMCPPackageRefsFromWorkflows(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []workflow.MCPPackageRef{
+		{Registry: "npm", Package: "@modelcontextprotocol/server-filesystem"},
+		{Registry: "pypi", Package: "mcp-server-fetch", Version: "1.0.0"},
+	}, refs)
+}
+
+func TestUpdateMCPPins(t *testing.T) {
+	orig := resolveMCPPackageVersionFunc
+	t.Cleanup(func() { resolveMCPPackageVersionFunc = orig })
+
+	versions := map[string]string{"npm:mcp-a": "2.0.0", "npm:mcp-b": "1.0.0"}
+	lookups := 0
+	resolveMCPPackageVersionFunc = func(_ context.Context, registry, pkg string) (string, error) {
+		lookups++
+		if v, ok := versions[workflow.MCPPackagePinKey(registry, pkg)]; ok {
+			return v, nil
+		}
+		return "", errors.New("not found")
+	}
+
+	cache := workflow.NewActionCache(t.TempDir())
+	cache.SetMCPPin("npm", "mcp-a", "1.0.0")
+	cache.SetMCPPin("npm", "mcp-b", "1.0.0")
+	cache.SetMCPPin("npm", "mcp-removed", "1.0.0")
+
+	refs := []workflow.MCPPackageRef{
+		{Registry: "npm", Package: "mcp-a"},
+		{Registry: "npm", Package: "mcp-a"},
+		{Registry: "npm", Package: "mcp-b"},
+		{Registry: "npm", Package: "mcp-versioned", Version: "3.0.0"},
+	}
+	changed, err := updateMCPPins(context.Background(), cache, refs, false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, changed, "only mcp-a should change")
+	assert.Equal(t, 2, lookups, "duplicate and versioned refs should not be resolved")
+
+	pin, ok := cache.GetMCPPin("npm", "mcp-a")
+	require.True(t, ok)
+	assert.Equal(t, "2.0.0", pin.Version)
+	_, ok = cache.GetMCPPin("npm", "mcp-removed")
+	assert.False(t, ok, "unreferenced pins should be pruned")
+	_, ok = cache.GetMCPPin("npm", "mcp-versioned")
+	assert.False(t, ok, "versioned packages should not be pinned")
+
+	// A resolution failure keeps existing pins instead of pruning them.
+	changed, err = updateMCPPins(context.Background(), cache, []workflow.MCPPackageRef{{Registry: "npm", Package: "mcp-missing"}}, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "npm:mcp-missing")
+	assert.Equal(t, 0, changed)
+	_, ok = cache.GetMCPPin("npm", "mcp-a")
+	assert.True(t, ok, "pins should be kept when resolution fails")
+}
@@ -69,6 +69,9 @@ type UpdateWorkflowsOptions struct {
 	NoRedirect             bool
 	CoolDown               time.Duration
 	Approve                bool
+	Porcelain              bool   // Print update results as porcelain records on stdout
+	Changelog              string // Write actions-lock.json pin changes to this file (.json or markdown)
+	Group                  string // Only update dependencies in this update group (update.groups in config.yml)
 }
 
 // UpdateWorkflows updates workflows from their source repositories
@@ -142,6 +145,9 @@ func UpdateWorkflows(ctx context.Context, opts UpdateWorkflowsOptions) error {
 
 	// Show summary
 	showUpdateSummary(successfulUpdates, failedUpdates)
+	if opts.Porcelain {
+		writeUpdatePorcelain(os.Stdout, successfulUpdates, failedUpdates)
+	}
 
 	if len(successfulUpdates) == 0 {
 		// If all failures were due to GitHub API rate limiting, treat as non-fatal.
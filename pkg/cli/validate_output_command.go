@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/spf13/cobra"
+)
+
+var validateOutputLog = logger.New("cli:validate_output_command")
+
+// NewValidateOutputCommand creates the validate-output command.
+func NewValidateOutputCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate-output <workflow> [safe-output-file]",
+		Short: "Check agent output (safe_output.jsonl) against a workflow's safe-outputs configuration",
+		Long: `Check agent output against a workflow's safe-outputs configuration without running it.
+
+The file contains one JSON object per line, as written by the agent to safe_output.jsonl.
+Each line is validated with the same rules the safe-outputs job applies when it ingests
+agent output: enabled output types, max counts, required fields and field types,
+and target repositories (target-repo and allowed-repos). Each line is reported as
+accepted or rejected with the reason. Content sanitization is not reported.
+
+Reads from stdin when the file is omitted or "-". Exits with a non-zero status when any
+line is rejected.`,
+		Example: `  ` + string(constants.CLIExtensionPrefix) + ` validate-output issue-triage safe_output.jsonl   # Validate a downloaded agent output file
+  ` + string(constants.CLIExtensionPrefix) + ` validate-output issue-triage < agent_output.jsonl # Read agent output from stdin
+  ` + string(constants.CLIExtensionPrefix) + ` validate-output issue-triage out.jsonl --json     # Report results as JSON
+  ` + string(constants.CLIExtensionPrefix) + ` validate-output issue-triage out.jsonl -r o/r     # Treat o/r as the current repository`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			repo, _ := cmd.Flags().GetString("repo")
+			verbose, _ := cmd.Flags().GetBool("verbose")
+			outputFile := "-"
+			if len(args) == 2 {
+				outputFile = args[1]
+			}
+			return RunValidateOutput(args[0], outputFile, repo, jsonOutput, verbose, cmd.InOrStdin(), cmd.OutOrStdout())
+		},
+	}
+
+	addJSONFlag(cmd)
+	addRepoFlag(cmd)
+
+	return cmd
+}
+
+// RunValidateOutput validates an agent output file against the named workflow and
+// writes the report to out.
+func RunValidateOutput(workflowName, outputFile, repo string, jsonOutput, verbose bool, stdin io.Reader, out io.Writer) error {
+	validateOutputLog.Printf("Validating agent output: workflow=%s, file=%s, repo=%s", workflowName, outputFile, repo)
+
+	workflowFile, err := resolveWorkflowFile(workflowName, verbose)
+	if err != nil {
+		return err
+	}
+	compiler := workflow.NewCompiler(workflow.WithVerbose(verbose))
+	data, err := compiler.ParseWorkflowFile(workflowFile)
+	if err != nil {
+		return fmt.Errorf("failed to parse workflow %s: %w", workflowName, err)
+	}
+
+	if repo == "" {
+		if slug, err := GetCurrentRepoSlug(); err == nil {
+			repo = slug
+		} else {
+			validateOutputLog.Printf("Could not determine current repository: %v", err)
+		}
+	} else if parts := strings.Split(repo, "/"); len(parts) == 3 {
+		// Drop the host from HOST/owner/repo.
+		repo = parts[1] + "/" + parts[2]
+	}
+
+	input := stdin
+	if outputFile != "-" {
+		f, err := os.Open(outputFile)
+		if err != nil {
+			return fmt.Errorf("failed to open agent output file: %w", err)
+		}
+		defer f.Close()
+		input = f
+	}
+
+	report, err := workflow.ValidateSafeOutputJSONL(data, input, repo)
+	if err != nil {
+		return fmt.Errorf("%s: %w", filepath.Base(workflowFile), err)
+	}
+
+	if jsonOutput {
+		encoded, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal validation report: %w", err)
+		}
+		fmt.Fprintln(out, string(encoded))
+	} else {
+		printValidateOutputReport(out, report)
+	}
+
+	if !report.OK() {
+		return fmt.Errorf("agent output validation failed: %d of %d line(s) rejected", report.Rejected(), len(report.Lines))
+	}
+	return nil
+}
+
+func printValidateOutputReport(out io.Writer, report *workflow.SafeOutputValidationReport) {
+	for _, line := range report.Lines {
+		itemType := line.Type
+		if itemType == "" {
+			itemType = "(unknown)"
+		}
+		if line.Accepted {
+			fmt.Fprintln(out, console.FormatSuccessMessage(fmt.Sprintf("line %d: %s accepted", line.Line, itemType)))
+		} else {
+			fmt.Fprintln(out, console.FormatErrorMessage(fmt.Sprintf("line %d: %s rejected: %s", line.Line, itemType, line.Error)))
+		}
+	}
+	fmt.Fprintln(out, console.FormatInfoMessage(fmt.Sprintf("%d accepted, %d rejected", report.Accepted(), report.Rejected())))
+}
@@ -0,0 +1,89 @@
+//go:build !integration
+
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewValidateOutputCommand(t *testing.T) {
+	cmd := NewValidateOutputCommand()
+
+	require.NotNil(t, cmd, "NewValidateOutputCommand should return a non-nil command")
+	assert.Equal(t, "validate-output", cmd.Name(), "Command name should be 'validate-output'")
+	require.NotNil(t, cmd.Flags().Lookup("json"), "validate-output should have a --json flag")
+	require.NotNil(t, cmd.Flags().Lookup("repo"), "validate-output should have a --repo flag")
+	require.Error(t, cmd.Args(cmd, []string{}), "workflow argument should be required")
+	require.Error(t, cmd.Args(cmd, []string{"a", "b", "c"}), "at most two arguments should be accepted")
+}
+
+func TestRunValidateOutput(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "validate-output-test")
+	workflowFile := filepath.Join(tmpDir, "triage.md")
+	require.NoError(t, os.WriteFile(workflowFile, []byte(`---
+on: issues
+engine: copilot
+permissions:
+  contents: read
+safe-outputs:
+  add-comment:
+    max: 1
+---
+
+# Triage
+
+Comment on the issue.
+`), 0644), "Failed to write workflow")
+
+	accepted := `{"type":"add_comment","body":"Thanks for the report"}` + "\n"
+	rejected := accepted + `{"type":"add_comment","body":"Second comment"}` + "\n" + `{"type":"create_issue","title":"t","body":"b"}` + "\n"
+
+	t.Run("all accepted from stdin", func(t *testing.T) {
+		var out bytes.Buffer
+		err := RunValidateOutput(workflowFile, "-", "octo/repo", false, false, strings.NewReader(accepted), &out)
+		require.NoError(t, err, "accepted output should not fail")
+		assert.Contains(t, out.String(), "line 1: add_comment accepted", "accepted line should be reported")
+		assert.Contains(t, out.String(), "1 accepted, 0 rejected", "summary should be printed")
+	})
+
+	t.Run("rejected lines from file", func(t *testing.T) {
+		outputFile := filepath.Join(tmpDir, "safe_output.jsonl")
+		require.NoError(t, os.WriteFile(outputFile, []byte(rejected), 0644), "Failed to write agent output")
+
+		var out bytes.Buffer
+		err := RunValidateOutput(workflowFile, outputFile, "github.com/octo/repo", false, false, nil, &out)
+		require.Error(t, err, "rejected lines should fail the command")
+		assert.Contains(t, err.Error(), "2 of 3 line(s) rejected", "error should count rejected lines")
+		assert.Contains(t, out.String(), "Too many items of type 'add_comment'", "max violation should be reported")
+		assert.Contains(t, out.String(), "Unexpected output type 'create_issue'", "disabled type should be reported")
+	})
+
+	t.Run("json output", func(t *testing.T) {
+		var out bytes.Buffer
+		err := RunValidateOutput(workflowFile, "-", "octo/repo", true, false, strings.NewReader(rejected), &out)
+		require.Error(t, err, "rejected lines should fail the command")
+
+		var report workflow.SafeOutputValidationReport
+		require.NoError(t, json.Unmarshal(out.Bytes(), &report), "output should be valid JSON")
+		require.Len(t, report.Lines, 3, "all lines should be reported")
+		assert.True(t, report.Lines[0].Accepted, "first line should be accepted")
+		assert.False(t, report.Lines[1].Accepted, "second line should be rejected")
+	})
+
+	t.Run("missing output file", func(t *testing.T) {
+		var out bytes.Buffer
+		err := RunValidateOutput(workflowFile, filepath.Join(tmpDir, "missing.jsonl"), "octo/repo", false, false, nil, &out)
+		require.Error(t, err, "missing file should fail")
+		assert.Contains(t, err.Error(), "failed to open agent output file", "error should name the problem")
+	})
+}
@@ -0,0 +1,207 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/gitutil"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/spf13/cobra"
+)
+
+var verifyLog = logger.New("cli:verify_command")
+
+// NewVerifyCommand creates the verify command
+func NewVerifyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify [workflow-or-lock-file]...",
+		Short: "Verify that lock files match the markdown sources they were compiled from",
+		Long: `Verify the provenance recorded in each lock file header against the files on disk.
+
+The compiler embeds a gh-aw-provenance line in every lock file. It records:
+- The SHA-256 of the markdown source
+- The SHA-256 of every imported file (and the commit SHA of cached remote imports)
+- A digest of the actions-lock.json pins used by the workflow
+- The compiler version (release builds only)
+
+verify recomputes these hashes and fails when any input changed after compilation,
+so reviewers can confirm a lock file genuinely corresponds to its markdown source.
+Lock files compiled before provenance was recorded fail until they are recompiled.
+A differing compiler version is reported as a warning.`,
+		Example: `  ` + string(constants.CLIExtensionPrefix) + ` verify                                   # Verify all lock files in .github/workflows
+  ` + string(constants.CLIExtensionPrefix) + ` verify ci-doctor                          # Verify a single workflow
+  ` + string(constants.CLIExtensionPrefix) + ` verify .github/workflows/foo.lock.yml     # Verify a specific lock file`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			workflowDir, _ := cmd.Flags().GetString("dir")
+			verbose, _ := cmd.Flags().GetBool("verbose")
+			lockFiles, err := resolveLockFilesForVerify(args, workflowDir)
+			if err != nil {
+				return err
+			}
+			return RunVerify(lockFiles, verbose)
+		},
+	}
+
+	cmd.Flags().StringP("dir", "d", "", "Workflow directory (default: $GH_AW_WORKFLOWS_DIR or .github/workflows)")
+	RegisterDirFlagCompletion(cmd, "dir")
+
+	return cmd
+}
+
+// RunVerify checks the provenance of each lock file and returns an error when any of
+// them no longer matches its inputs.
+func RunVerify(lockFiles []string, verbose bool) error {
+	failed := 0
+	for _, lockFile := range lockFiles {
+		problems, warnings, err := verifyLockFile(lockFile)
+		if err != nil {
+			problems = append(problems, err.Error())
+		}
+		displayPath := console.ToRelativePath(lockFile)
+		for _, warning := range warnings {
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("%s: %s", displayPath, warning)))
+		}
+		if len(problems) == 0 {
+			fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(displayPath))
+			continue
+		}
+		failed++
+		fmt.Fprintln(os.Stderr, console.FormatErrorMessage(displayPath+" does not match its sources:"))
+		for _, problem := range problems {
+			fmt.Fprintln(os.Stderr, console.FormatListItemStderr(problem))
+		}
+	}
+	verifyLog.Printf("Verified %d lock file(s), %d failed", len(lockFiles), failed)
+	if failed > 0 {
+		return fmt.Errorf("%d of %d lock file(s) failed verification; run '%s compile' to regenerate them", failed, len(lockFiles), constants.CLIExtensionPrefix)
+	}
+	if verbose {
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Verified %d lock file(s)", len(lockFiles))))
+	}
+	return nil
+}
+
+// verifyLockFile compares the recorded provenance of one lock file with the current
+// state of its inputs. Problems fail verification; warnings are informational.
+func verifyLockFile(lockFile string) (problems []string, warnings []string, err error) {
+	content, err := os.ReadFile(lockFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read lock file: %w", err)
+	}
+	provenance, err := workflow.ExtractLockProvenanceFromLockFile(string(content))
+	if err != nil {
+		return nil, nil, err
+	}
+	if provenance == nil {
+		return []string{"no gh-aw-provenance header (compiled by an older gh-aw; recompile to record it)"}, nil, nil
+	}
+
+	lockDir := filepath.Dir(lockFile)
+	problems = append(problems, checkProvenanceFile(lockDir, "source", provenance.Source)...)
+	for _, imported := range provenance.Imports {
+		problems = append(problems, checkProvenanceFile(lockDir, "import", imported)...)
+	}
+
+	manifest, err := workflow.ExtractGHAWManifestFromLockFile(string(content))
+	if err != nil {
+		return problems, nil, err
+	}
+	problems = append(problems, checkActionsLockDigest(lockDir, provenance.ActionsLock, manifest)...)
+
+	if provenance.CompilerVersion != "" && provenance.CompilerVersion != workflow.GetVersion() {
+		warnings = append(warnings, fmt.Sprintf("compiled with gh-aw %s (current: %s)", provenance.CompilerVersion, workflow.GetVersion()))
+	}
+	return problems, warnings, nil
+}
+
+// checkProvenanceFile re-hashes a recorded input file relative to the lock file directory.
+func checkProvenanceFile(lockDir, kind string, file workflow.LockProvenanceFile) []string {
+	hash, err := workflow.HashProvenanceFile(filepath.Join(lockDir, filepath.FromSlash(file.Path)))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []string{fmt.Sprintf("%s %s is missing", kind, file.Path)}
+		}
+		return []string{fmt.Sprintf("%s %s could not be read: %v", kind, file.Path, err)}
+	}
+	if hash != file.SHA256 {
+		return []string{fmt.Sprintf("%s %s changed since compilation", kind, file.Path)}
+	}
+	return nil
+}
+
+// checkActionsLockDigest compares the pins recorded in the manifest with the SHAs
+// actions-lock.json holds today, then checks the manifest against the recorded digest.
+// Pins missing from actions-lock.json (e.g. embedded defaults) are not reported.
+func checkActionsLockDigest(lockDir, recorded string, manifest *workflow.GHAWManifest) []string {
+	if manifest == nil {
+		if recorded != "" {
+			return []string{"gh-aw-manifest header is missing, so actions-lock.json pins cannot be checked"}
+		}
+		return nil
+	}
+	repoRoot, err := gitutil.FindGitRootFrom(lockDir)
+	if err != nil {
+		repoRoot = "."
+	}
+	cache := workflow.NewActionCache(repoRoot)
+	if err := cache.Load(); err != nil {
+		return []string{fmt.Sprintf("failed to load %s: %v", workflow.CacheFileName, err)}
+	}
+
+	var problems []string
+	for _, action := range manifest.Actions {
+		if action.Version == "" || action.Version == action.SHA {
+			continue
+		}
+		if sha, ok := cache.Get(action.Repo, action.Version); ok && sha != action.SHA {
+			problems = append(problems, fmt.Sprintf("%s pins %s@%s to %s, but the lock file uses %s", workflow.CacheFileName, action.Repo, action.Version, sha, action.SHA))
+		}
+	}
+	if len(problems) == 0 && workflow.ComputeActionsLockDigest(manifest.Actions) != recorded {
+		problems = append(problems, "action pins in the gh-aw-manifest header do not match the recorded actions-lock digest")
+	}
+	return problems
+}
+
+// resolveLockFilesForVerify expands workflow IDs, markdown files, lock files, and
+// directories into a sorted list of lock files.
+func resolveLockFilesForVerify(inputs []string, workflowDir string) ([]string, error) {
+	if workflowDir == "" {
+		workflowDir = constants.GetWorkflowDir()
+	}
+	if len(inputs) == 0 {
+		inputs = []string{workflowDir}
+	}
+
+	var lockFiles []string
+	for _, input := range inputs {
+		candidate := input
+		switch {
+		case strings.HasSuffix(candidate, ".md"):
+			candidate = stringutil.MarkdownToLockFile(candidate)
+		case !strings.HasSuffix(candidate, ".lock.yml") && !strings.ContainsAny(candidate, `/\`):
+			if _, err := os.Stat(candidate); err != nil {
+				candidate = filepath.Join(workflowDir, candidate+".lock.yml")
+			}
+		}
+		expanded, err := expandLintCandidate(candidate)
+		if err != nil {
+			return nil, err
+		}
+		lockFiles = append(lockFiles, expanded...)
+	}
+
+	if len(lockFiles) == 0 {
+		return nil, errors.New("no .lock.yml files found to verify")
+	}
+	slices.Sort(lockFiles)
+	return slices.Compact(lockFiles), nil
+}
@@ -1,4 +1,60 @@
 //go:build !integration
 
-// Package cli - verify_command_test.go is superseded by validate_command_test.go.
 package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/github/gh-aw/pkg/workflow"
+)
+
+func TestVerifyLockFile(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "verify")
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "shared"), 0755), "Failed to create shared dir")
+	sharedFile := filepath.Join(tmpDir, "shared", "guidance.md")
+	require.NoError(t, os.WriteFile(sharedFile, []byte("Be concise.\n"), 0644), "Failed to write import")
+	workflowFile := filepath.Join(tmpDir, "triage.md")
+	require.NoError(t, os.WriteFile(workflowFile, []byte(`---
+on:
+  issues:
+    types: [opened]
+engine: copilot
+imports:
+  - shared/guidance.md
+---
+
+# Triage
+`), 0644), "Failed to write workflow")
+	require.NoError(t, workflow.NewCompiler().CompileWorkflow(workflowFile), "compilation should succeed")
+	lockFile := filepath.Join(tmpDir, "triage.lock.yml")
+
+	problems, _, err := verifyLockFile(lockFile)
+	require.NoError(t, err, "verification should run")
+	assert.Empty(t, problems, "a freshly compiled lock file should verify")
+
+	require.NoError(t, os.WriteFile(sharedFile, []byte("Be verbose.\n"), 0644), "Failed to edit import")
+	problems, _, err = verifyLockFile(lockFile)
+	require.NoError(t, err, "verification should run")
+	assert.Equal(t, []string{"import shared/guidance.md changed since compilation"}, problems, "edited imports should be reported")
+
+	require.NoError(t, os.Remove(workflowFile), "Failed to remove source")
+	problems, _, err = verifyLockFile(lockFile)
+	require.NoError(t, err, "verification should run")
+	assert.Contains(t, problems, "source triage.md is missing", "missing sources should be reported")
+}
+
+func TestVerifyLockFileWithoutProvenance(t *testing.T) {
+	lockFile := filepath.Join(testutil.TempDir(t, "verify-legacy"), "legacy.lock.yml")
+	require.NoError(t, os.WriteFile(lockFile, []byte("name: legacy\non: push\n"), 0644), "Failed to write lock file")
+
+	problems, _, err := verifyLockFile(lockFile)
+	require.NoError(t, err, "verification should run")
+	require.Len(t, problems, 1, "legacy lock files should fail verification")
+	assert.Contains(t, problems[0], "no gh-aw-provenance header", "problem should explain how to fix it")
+}
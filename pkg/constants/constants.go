@@ -216,6 +216,17 @@ const AWFConfigFilePath = "/tmp/gh-aw/awf-config.json"
 // AWFConfigFilePathExpr is the host-side AWF config path resolved by Actions expression.
 const AWFConfigFilePathExpr = GhAwRootDir + "/awf-config.json"
 
+// FirewallPolicyFilename is the name of the exported firewall policy document. It records the
+// compiled domain allowlist and the selected enforcement backend for review.
+const FirewallPolicyFilename = "firewall-policy.json"
+
+// FirewallPolicyFilePath is the path inside the /tmp/gh-aw tree where the firewall policy
+// is copied so it can be included in the unified agent artifact.
+const FirewallPolicyFilePath = "/tmp/gh-aw/" + FirewallPolicyFilename
+
+// FirewallPolicyFilePathExpr is the host-side firewall policy path resolved by Actions expression.
+const FirewallPolicyFilePathExpr = GhAwRootDir + "/" + FirewallPolicyFilename
+
 // AWFReflectFilePath is the path where the AWF API proxy /reflect response is persisted
 // by the agent harness before exiting. It is co-located with other firewall observability
 // data under /tmp/gh-aw/sandbox/firewall/ so the existing chmod and artifact-upload steps
@@ -383,7 +394,7 @@ var IgnoredFrontmatterFields = []string{}
 // The compiler enforces these restrictions at compile time with clear error messages.
 //
 // Forbidden fields fall into these categories:
-//   - Workflow triggers: on (defines it as a main workflow)
+//   - Workflow triggers: on (defines it as a main workflow), needs-workflow
 //   - Workflow execution: run-name, runs-on, concurrency, if, timeout-minutes
 //   - Workflow metadata: name, tracker-id, strict
 //   - Workflow features: container, environment, features
@@ -400,8 +411,10 @@ var SharedWorkflowForbiddenFields = []string{
 	"github-token",    // GitHub token configuration
 	"if",              // Conditional execution
 	"name",            // Workflow name
+	"needs-workflow",  // Workflow dependency trigger (expands to on.workflow_run)
 	"run-name",        // Run display name
 	"runs-on",         // Runner specification
+	"skip-if",         // Agent job skip condition
 	"strict",          // Strict mode
 	"timeout-minutes", // Timeout in minutes
 	"tracker-id",      // Tracker ID
@@ -123,6 +123,7 @@ func TestConstantValues(t *testing.T) {
 		{"AWFAuditDir", AWFAuditDir, "/tmp/gh-aw/sandbox/firewall/audit"},
 		{"PreAgentAuditFilePath", PreAgentAuditFilePath, "/tmp/gh-aw/pre-agent-audit.txt"},
 		{"AWFConfigFilePath", AWFConfigFilePath, "/tmp/gh-aw/awf-config.json"},
+		{"FirewallPolicyFilePath", FirewallPolicyFilePath, "/tmp/gh-aw/firewall-policy.json"},
 		{"AgentJobName", string(AgentJobName), "agent"},
 		{"ActivationJobName", string(ActivationJobName), "activation"},
 		{"PreActivationJobName", string(PreActivationJobName), "pre_activation"},
@@ -144,11 +145,13 @@ func TestConstantValues(t *testing.T) {
 		{"CheckSkipIfMatchStepID", string(CheckSkipIfMatchStepID), "check_skip_if_match"},
 		{"CheckSkipIfNoMatchStepID", string(CheckSkipIfNoMatchStepID), "check_skip_if_no_match"},
 		{"CheckCommandPositionStepID", string(CheckCommandPositionStepID), "check_command_position"},
+		{"CheckActiveHoursStepID", string(CheckActiveHoursStepID), "check_active_hours"},
 		{"IsTeamMemberOutput", IsTeamMemberOutput, "is_team_member"},
 		{"StopTimeOkOutput", StopTimeOkOutput, "stop_time_ok"},
 		{"SkipCheckOkOutput", SkipCheckOkOutput, "skip_check_ok"},
 		{"SkipNoMatchCheckOkOutput", SkipNoMatchCheckOkOutput, "skip_no_match_check_ok"},
 		{"CommandPositionOkOutput", CommandPositionOkOutput, "command_position_ok"},
+		{"ActiveHoursOkOutput", ActiveHoursOkOutput, "active_hours_ok"},
 		{"ActivatedOutput", ActivatedOutput, "activated"},
 		{"DefaultActivationJobRunnerImage", DefaultActivationJobRunnerImage, "ubuntu-slim"},
 	}
@@ -167,6 +170,7 @@ func TestKnownBuiltInJobNamesContainsAllKnownJobs(t *testing.T) {
 		string(PreActivationJobName),
 		string(PreActivationHyphenJobName),
 		string(DetectionJobName),
+		string(CompareEnginesJobName),
 		string(SafeOutputsJobName),
 		string(SafeOutputsHyphenJobName),
 		string(UploadAssetsJobName),
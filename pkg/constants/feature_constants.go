@@ -97,4 +97,14 @@ const (
 	//	features:
 	//	  gh-aw-detection: true
 	GHAWDetectionFeatureFlag FeatureFlag = "gh-aw-detection"
+	// PermissionTelemetryFeatureFlag enables runtime permission telemetry for the agent job.
+	// When enabled and the GitHub MCP tool is configured, the compiler emits a step that
+	// records which GitHub MCP tools the agent called together with the agent job's
+	// compiled permissions, so `gh aw audit` can highlight over-provisioned scopes.
+	//
+	// Workflow frontmatter usage:
+	//
+	//	features:
+	//	  permission-telemetry: true
+	PermissionTelemetryFeatureFlag FeatureFlag = "permission-telemetry"
 )
@@ -62,6 +62,7 @@ const PreActivationJobName JobName = "pre_activation"
 const PreActivationHyphenJobName JobName = "pre-activation"
 const DetectionJobName JobName = "detection"
 const EvalsJobName JobName = "evals"
+const CompareEnginesJobName JobName = "compare_engines"
 const SafeOutputsJobName JobName = "safe_outputs"
 const SafeOutputsHyphenJobName JobName = "safe-outputs"
 const UploadAssetsJobName JobName = "upload_assets"
@@ -80,6 +81,7 @@ var KnownBuiltInJobNames = map[string]struct{}{
 	string(PreActivationHyphenJobName): {},
 	string(DetectionJobName):           {},
 	string(EvalsJobName):               {},
+	string(CompareEnginesJobName):      {},
 	string(SafeOutputsJobName):         {},
 	string(SafeOutputsHyphenJobName):   {},
 	string(UploadAssetsJobName):        {},
@@ -123,11 +125,21 @@ const SafeOutputsFilename = "safeoutputs.jsonl"
 // consume structured token data without parsing the step summary or GITHUB_OUTPUT.
 const TokenUsageFilename = "agent_usage.json"
 
+// AgentTurnsFilename is the filename of the structured turn log written to /tmp/gh-aw/ by the
+// log parser step after the engine run. Each line is a JSON record with explicit turn, tool-call,
+// and usage markers derived from the parsed engine output.
+const AgentTurnsFilename = "agent_turns.jsonl"
+
 // GithubRateLimitsFilename is the filename of the GitHub API rate-limit log written to /tmp/gh-aw/.
 // Each line is a JSON object recording the x-ratelimit-* headers (or rate-limit API snapshot)
 // captured during github.rest API calls, enabling post-run analysis of rate-limit consumption.
 const GithubRateLimitsFilename = "github_rate_limits.jsonl"
 
+// PermissionUsageFilename is the filename of the permission telemetry JSON file written to
+// /tmp/gh-aw/ by record_permission_usage.cjs when the permission-telemetry feature is enabled.
+// It records the agent job's requested permissions and the GitHub MCP tools the agent called.
+const PermissionUsageFilename = "permission_usage.json"
+
 // OtelJsonlFilename is the filename of the OTLP span mirror written to /tmp/gh-aw/
 // by send_otlp_span.cjs. Each line is a full OTLP/HTTP JSON traces payload.
 // Included in the agent artifact so spans are available without a live collector.
@@ -214,6 +226,7 @@ const CheckRateLimitStepID StepID = "check_rate_limit"
 const CheckSkipRolesStepID StepID = "check_skip_roles"
 const CheckSkipBotsStepID StepID = "check_skip_bots"
 const CheckSkipIfCheckFailingStepID StepID = "check_skip_if_check_failing"
+const CheckActiveHoursStepID StepID = "check_active_hours"
 
 // PreActivationAppTokenStepID is the step ID for the unified GitHub App token mint step
 // emitted in the pre-activation job when on.github-app is configured alongside skip-if checks.
@@ -241,6 +254,7 @@ const RateLimitOkOutput = "rate_limit_ok"
 const SkipRolesOkOutput = "skip_roles_ok"
 const SkipBotsOkOutput = "skip_bots_ok"
 const SkipIfCheckFailingOkOutput = "skip_if_check_failing_ok"
+const ActiveHoursOkOutput = "active_hours_ok"
 const ActivatedOutput = "activated"
 
 // Rate limit defaults
@@ -3,6 +3,7 @@
 package errorutil
 
 import (
+	"errors"
 	"strings"
 
 	"github.com/github/gh-aw/pkg/logger"
@@ -10,6 +11,10 @@ import (
 
 var errorutilLog = logger.New("errorutil:errors")
 
+// ErrOffline is wrapped by every operation that refuses to use the network because
+// offline mode is enabled (remote imports, action pin resolution, version lookups).
+var ErrOffline = errors.New("network access is disabled (offline mode)")
+
 // IsNotFoundError reports whether err represents an HTTP 404 / "not found" response.
 // It returns false when err is nil.
 // The check is case-insensitive and matches both the numeric literal "404" and
@@ -0,0 +1,242 @@
+package parser
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var fieldMigrationsLog = logger.New("parser:field_migrations")
+
+// FieldMigration describes a frontmatter field that was renamed or removed from the
+// schema. The schema rejects these fields as unknown properties; the registry lets the
+// compiler explain what replaced them and which codemod rewrites them.
+type FieldMigration struct {
+	// Path is the JSON pointer of the object containing the field ("/" for top level).
+	Path string
+	// Field is the old property name.
+	Field string
+	// Replacement is the dotted path of the field that replaces it, or empty when the
+	// field was removed without a replacement.
+	Replacement string
+	// Note explains the change in a few words.
+	Note string
+	// Example is a one-line YAML snippet showing the migrated form.
+	Example string
+	// DocsURL links to the documentation for the replacement.
+	DocsURL string
+	// Codemod is the ID of the codemod that rewrites the field (see gh aw fix --list-codemods).
+	Codemod string
+}
+
+// OldField returns the dotted path of the deprecated field (e.g. "engine.steps").
+func (m FieldMigration) OldField() string {
+	parent := strings.ReplaceAll(strings.Trim(m.Path, "/"), "/", ".")
+	if parent == "" {
+		return m.Field
+	}
+	return parent + "." + m.Field
+}
+
+// Hint returns a one-line migration hint for the field, suitable for appending to an
+// "Unknown property" schema error.
+func (m FieldMigration) Hint() string {
+	var b strings.Builder
+	if m.Replacement != "" {
+		fmt.Fprintf(&b, "'%s' was replaced by '%s'", m.OldField(), m.Replacement)
+	} else {
+		fmt.Fprintf(&b, "'%s' was removed", m.OldField())
+	}
+	if m.Note != "" {
+		fmt.Fprintf(&b, " (%s)", m.Note)
+	}
+	if m.Example != "" {
+		fmt.Fprintf(&b, "; migrated form: `%s`", m.Example)
+	}
+	b.WriteString(". Run 'gh aw compile --migrate' to rewrite it automatically")
+	if m.DocsURL != "" {
+		b.WriteString(". See: " + m.DocsURL)
+	}
+	return b.String()
+}
+
+// fieldMigrations is the registry of renamed and removed frontmatter fields.
+// Fields that are still accepted by the schema but marked "deprecated" are reported
+// by FindDeprecatedFieldsInFrontmatter instead.
+var fieldMigrations = []FieldMigration{
+	{
+		Path:        "/",
+		Field:       "timeout_minutes",
+		Replacement: "timeout-minutes",
+		Example:     "timeout-minutes: 30",
+		DocsURL:     "https://github.github.com/gh-aw/reference/frontmatter/",
+		Codemod:     "timeout-minutes-migration",
+	},
+	{
+		Path:        "/",
+		Field:       "roles",
+		Replacement: "on.roles",
+		Example:     "on: { roles: [admin, maintainer] }",
+		DocsURL:     "https://github.github.com/gh-aw/reference/triggers/",
+		Codemod:     "roles-to-on-roles",
+	},
+	{
+		Path:        "/",
+		Field:       "bots",
+		Replacement: "on.bots",
+		Example:     "on: { bots: [dependabot[bot]] }",
+		DocsURL:     "https://github.github.com/gh-aw/reference/triggers/",
+		Codemod:     "bots-to-on-bots",
+	},
+	{
+		Path:        "/",
+		Field:       "safe-inputs",
+		Replacement: "mcp-scripts",
+		Example:     "mcp-scripts: { ... }",
+		DocsURL:     "https://github.github.com/gh-aw/reference/mcp-scripts/",
+		Codemod:     "safe-inputs-to-mcp-scripts",
+	},
+	{
+		Path:        "/",
+		Field:       "rate-limit",
+		Replacement: "user-rate-limit",
+		Note:        "'max-runs' is now 'max-runs-per-window'",
+		Example:     "user-rate-limit: { max-runs-per-window: 5, window: 60 }",
+		DocsURL:     "https://github.github.com/gh-aw/reference/rate-limiting-controls/",
+		Codemod:     "rate-limit-to-user-rate-limit",
+	},
+	{
+		Path:        "/",
+		Field:       "app",
+		Replacement: "github-app",
+		Example:     "github-app: { client-id: ${{ vars.APP_CLIENT_ID }}, private-key: ${{ secrets.APP_PRIVATE_KEY }} }",
+		DocsURL:     "https://github.github.com/gh-aw/reference/auth/",
+		Codemod:     "app-to-github-app",
+	},
+	{
+		Path:        "/",
+		Field:       "run-install-scripts",
+		Replacement: "runtimes.node.run-install-scripts",
+		Example:     "runtimes: { node: { run-install-scripts: true } }",
+		DocsURL:     "https://github.github.com/gh-aw/reference/dependencies/",
+		Codemod:     "run-install-scripts-to-runtimes-node",
+	},
+	{
+		Path:        "/",
+		Field:       "infer",
+		Replacement: "disable-model-invocation",
+		Note:        "the boolean value is inverted",
+		Example:     "disable-model-invocation: true",
+		DocsURL:     "https://github.github.com/gh-aw/reference/frontmatter/",
+		Codemod:     "infer-to-disable-model-invocation",
+	},
+	{
+		Path:    "/network",
+		Field:   "firewall",
+		Note:    "the agent firewall is always enabled through sandbox.agent",
+		DocsURL: "https://github.github.com/gh-aw/reference/sandbox/",
+		Codemod: "network-firewall-migration",
+	},
+	{
+		Path:        "/engine",
+		Field:       "steps",
+		Replacement: "steps",
+		Note:        "custom steps are configured at the top level",
+		Example:     "steps: [ ... ]",
+		DocsURL:     "https://github.github.com/gh-aw/reference/steps-jobs/",
+		Codemod:     "engine-steps-to-top-level",
+	},
+	{
+		Path:        "/engine",
+		Field:       "max-runs",
+		Replacement: "max-turns",
+		Example:     "max-turns: 50",
+		DocsURL:     "https://github.github.com/gh-aw/reference/engines/",
+		Codemod:     "engine-max-runs-to-top-level",
+	},
+	{
+		Path:        "/engine",
+		Field:       "copilot-sdk-driver",
+		Replacement: "engine.driver",
+		Example:     "engine: { id: copilot, driver: ./driver.cjs }",
+		DocsURL:     "https://github.github.com/gh-aw/reference/engines/",
+		Codemod:     "engine-copilot-sdk-driver-to-driver",
+	},
+	{
+		Path:        "/tools",
+		Field:       "mount-as-clis",
+		Replacement: "tools.cli-proxy",
+		Example:     "tools: { cli-proxy: true }",
+		DocsURL:     "https://github.github.com/gh-aw/reference/tools/",
+		Codemod:     "mount-as-clis-to-cli-proxy",
+	},
+	{
+		Path:        "/safe-outputs",
+		Field:       "upload-assets",
+		Replacement: "safe-outputs.upload-asset",
+		Example:     "safe-outputs: { upload-asset: {} }",
+		DocsURL:     "https://github.github.com/gh-aw/reference/safe-outputs/",
+		Codemod:     "upload-assets-to-upload-asset-migration",
+	},
+}
+
+// GetFieldMigrations returns a copy of the registry of renamed and removed frontmatter fields.
+func GetFieldMigrations() []FieldMigration {
+	return append([]FieldMigration(nil), fieldMigrations...)
+}
+
+// LookupFieldMigration returns the migration for field inside the object at jsonPath.
+func LookupFieldMigration(jsonPath, field string) (FieldMigration, bool) {
+	if jsonPath == "" {
+		jsonPath = "/"
+	}
+	for _, m := range fieldMigrations {
+		if m.Path == jsonPath && m.Field == field {
+			return m, true
+		}
+	}
+	return FieldMigration{}, false
+}
+
+// FindFieldMigrationsInFrontmatter returns the registry entries for renamed or removed
+// fields present in frontmatter, sorted by old field path.
+func FindFieldMigrationsInFrontmatter(frontmatter map[string]any) []FieldMigration {
+	var found []FieldMigration
+	for _, m := range fieldMigrations {
+		var parent map[string]any
+		if m.Path == "/" {
+			parent = frontmatter
+		} else {
+			parent, _ = frontmatter[strings.TrimPrefix(m.Path, "/")].(map[string]any)
+		}
+		if _, ok := parent[m.Field]; ok {
+			found = append(found, m)
+		}
+	}
+	slices.SortFunc(found, func(a, b FieldMigration) int { return strings.Compare(a.OldField(), b.OldField()) })
+	fieldMigrationsLog.Printf("Found %d renamed or removed fields in frontmatter", len(found))
+	return found
+}
+
+// appendFieldMigrationHints appends migration hints to an "Unknown property" message
+// for every listed property that is in the field migration registry. It reports
+// whether any hint was added.
+func appendFieldMigrationHints(message, jsonPath string) (string, bool) {
+	match := unknownPropertyPattern.FindStringSubmatch(message)
+	if match == nil {
+		return message, false
+	}
+	var hints []string
+	for prop := range strings.SplitSeq(match[1], ",") {
+		if m, ok := LookupFieldMigration(jsonPath, strings.TrimSpace(prop)); ok {
+			hints = append(hints, m.Hint())
+		}
+	}
+	if len(hints) == 0 {
+		return message, false
+	}
+	fieldMigrationsLog.Printf("Adding %d migration hints for path %s", len(hints), jsonPath)
+	return message + ". " + strings.Join(hints, ". "), true
+}
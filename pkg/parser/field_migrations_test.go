@@ -0,0 +1,97 @@
+//go:build !integration
+
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupFieldMigration(t *testing.T) {
+	m, ok := LookupFieldMigration("/", "timeout_minutes")
+	require.True(t, ok, "top-level timeout_minutes should be registered")
+	assert.Equal(t, "timeout-minutes", m.Replacement, "replacement should be timeout-minutes")
+	assert.Equal(t, "timeout-minutes-migration", m.Codemod, "codemod should be linked")
+
+	m, ok = LookupFieldMigration("/engine", "steps")
+	require.True(t, ok, "engine.steps should be registered")
+	assert.Equal(t, "engine.steps", m.OldField(), "old field should use dotted path")
+
+	_, ok = LookupFieldMigration("", "safe-inputs")
+	assert.True(t, ok, "empty path should be treated as top level")
+
+	_, ok = LookupFieldMigration("/engine", "timeout_minutes")
+	assert.False(t, ok, "field should only match under its registered parent")
+}
+
+func TestFieldMigrationHint(t *testing.T) {
+	m, ok := LookupFieldMigration("/", "infer")
+	require.True(t, ok, "infer should be registered")
+	assert.Equal(t,
+		"'infer' was replaced by 'disable-model-invocation' (the boolean value is inverted); migrated form: `disable-model-invocation: true`. Run 'gh aw compile --migrate' to rewrite it automatically. See: https://github.github.com/gh-aw/reference/frontmatter/",
+		m.Hint(), "hint should describe replacement, example, migration command, and docs")
+
+	m, ok = LookupFieldMigration("/network", "firewall")
+	require.True(t, ok, "network.firewall should be registered")
+	assert.True(t, strings.HasPrefix(m.Hint(), "'network.firewall' was removed ("), "removed field hint should say it was removed")
+}
+
+func TestFindFieldMigrationsInFrontmatter(t *testing.T) {
+	found := FindFieldMigrationsInFrontmatter(map[string]any{
+		"on":              "push",
+		"timeout_minutes": 10,
+		"engine":          map[string]any{"id": "copilot", "max-runs": 5},
+		"tools":           map[string]any{"bash": true},
+	})
+	require.Len(t, found, 2, "should find the two renamed fields")
+	assert.Equal(t, "engine.max-runs", found[0].OldField(), "results should be sorted by old field")
+	assert.Equal(t, "timeout_minutes", found[1].OldField(), "results should be sorted by old field")
+
+	assert.Empty(t, FindFieldMigrationsInFrontmatter(map[string]any{"on": "push", "engine": "copilot"}),
+		"current fields should not be reported")
+}
+
+func TestAppendFieldMigrationHints(t *testing.T) {
+	message, added := appendFieldMigrationHints("Unknown properties: bots, custom-field", "/")
+	assert.True(t, added, "hint should be added for registered field")
+	assert.Contains(t, message, "'bots' was replaced by 'on.bots'", "hint should name the replacement")
+	assert.NotContains(t, message, "custom-field' was", "unregistered fields should not get hints")
+
+	message, added = appendFieldMigrationHints("Unknown property: custom-field", "/")
+	assert.False(t, added, "no hint should be added for unregistered fields")
+	assert.Equal(t, "Unknown property: custom-field", message, "message should be unchanged")
+}
+
+// TestFieldMigrationsRejectedBySchema keeps the registry in sync with the schema: every
+// registered field must be rejected as an unknown property, and the error must carry the hint.
+func TestFieldMigrationsRejectedBySchema(t *testing.T) {
+	for _, m := range GetFieldMigrations() {
+		t.Run(m.OldField(), func(t *testing.T) {
+			frontmatter := map[string]any{"on": "push"}
+			switch m.Path {
+			case "/":
+				frontmatter[m.Field] = true
+			case "/engine":
+				frontmatter["engine"] = map[string]any{"id": "copilot", m.Field: true}
+			default:
+				frontmatter[strings.TrimPrefix(m.Path, "/")] = map[string]any{m.Field: true}
+			}
+
+			frontmatterYAML, err := yaml.Marshal(frontmatter)
+			require.NoError(t, err, "frontmatter should marshal")
+			workflowPath := filepath.Join(t.TempDir(), "workflow.md")
+			require.NoError(t, os.WriteFile(workflowPath, []byte("---\n"+string(frontmatterYAML)+"---\n"), 0644), "workflow should be written")
+
+			err = ValidateMainWorkflowFrontmatterWithSchemaAndLocation(frontmatter, workflowPath)
+			require.Error(t, err, "schema should reject %s", m.OldField())
+			assert.Contains(t, err.Error(), "'"+m.OldField()+"' was", "error should include the migration hint")
+			assert.Contains(t, err.Error(), "gh aw compile --migrate", "error should mention the migrate option")
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"maps"
 	"path/filepath"
 	"regexp"
 	"strings"
@@ -23,11 +24,18 @@ type FrontmatterResult struct {
 	FrontmatterLines []string       // Original frontmatter lines for error context
 	FrontmatterStart int            // Line number where frontmatter starts (1-based)
 	FieldLines       map[string]int // Absolute line numbers (1-based) of top-level frontmatter keys in the file
+	// Source locations of all frontmatter fields, keyed by field path (see LocateFrontmatterFields)
+	FieldLocations map[string]FrontmatterLocation
+	// Top-level keys defined more than once whose values were merged (see SetMergeDuplicateKeys)
+	MergedDuplicateKeys []DuplicateFrontmatterKey
 }
 
 // ExtractFrontmatterFromContent parses YAML frontmatter from markdown content string
 func ExtractFrontmatterFromContent(content string) (*FrontmatterResult, error) {
 	parserLog.Printf("Extracting frontmatter from content: size=%d bytes", len(content))
+	if issue, found := checkByteOrderMark(content); found {
+		return nil, formatFrontmatterIssues([]frontmatterIssue{issue}, content)
+	}
 	firstNewline, firstLine := splitFirstLine(content)
 	if !isFrontmatterDelimiterLine(firstLine) {
 		parserLog.Print("No frontmatter delimiter found, returning content as markdown")
@@ -42,11 +50,23 @@ func ExtractFrontmatterFromContent(content string) (*FrontmatterResult, error) {
 
 	frontmatterYAML := content[searchStart:frontmatterEndStart]
 	frontmatterLines, fieldLines := extractFrontmatterMetadata(frontmatterYAML, frontmatterStartLine)
-	frontmatter, err := parseFrontmatterYAML(frontmatterYAML)
+	markdown := extractMarkdownAfterFrontmatter(content, markdownStart)
+	markdownStartLine := strings.Count(content[:min(markdownStart, len(content))], "\n") + 1
+	issues := prevalidateFrontmatter(frontmatterLines, markdown, markdownStartLine)
+	parseYAML := frontmatterYAML
+	var mergedValues map[string]any
+	var mergedKeys []DuplicateFrontmatterKey
+	if IsMergeDuplicateKeys() {
+		parseYAML, mergedValues, mergedKeys, issues = mergeDuplicateTopLevelKeys(frontmatterLines, issues)
+	}
+	if len(issues) > 0 {
+		return nil, formatFrontmatterIssues(issues, content)
+	}
+	frontmatter, err := parseFrontmatterYAML(parseYAML)
 	if err != nil {
 		return nil, err
 	}
-	markdown := extractMarkdownAfterFrontmatter(content, markdownStart)
+	maps.Copy(frontmatter, mergedValues)
 
 	parserLog.Printf("Successfully extracted frontmatter: fields=%d, markdown_size=%d bytes", len(frontmatter), len(markdown))
 	return &FrontmatterResult{
@@ -55,6 +75,9 @@ func ExtractFrontmatterFromContent(content string) (*FrontmatterResult, error) {
 		FrontmatterLines: frontmatterLines,
 		FrontmatterStart: frontmatterStartLine,
 		FieldLines:       fieldLines,
+		FieldLocations:   LocateFrontmatterFields(frontmatterYAML, frontmatterStartLine),
+
+		MergedDuplicateKeys: mergedKeys,
 	}, nil
 }
 
@@ -253,11 +276,17 @@ func ExtractMarkdownSection(content, sectionName string) (string, error) {
 	// Create regex pattern to match headers at any level (H1-H3) with flexible spacing
 	headerPattern := regexp.MustCompile(`^(#{1,3})[\s\t]+` + regexp.QuoteMeta(sectionName) + `[\s\t]*$`)
 
+	inFence := false
 	for scanner.Scan() {
 		line := scanner.Text()
 
+		// Lines starting with # inside fenced code blocks are not headers
+		if isCodeFenceLine(line) {
+			inFence = !inFence
+		}
+
 		// Check if this line matches our target section
-		if matches := headerPattern.FindStringSubmatch(line); matches != nil {
+		if matches := headerPattern.FindStringSubmatch(line); matches != nil && !inFence {
 			inSection = true
 			sectionLevel = len(matches[1]) // Number of # characters
 			sectionContent.WriteString(line + "\n")
@@ -266,7 +295,7 @@ func ExtractMarkdownSection(content, sectionName string) (string, error) {
 
 		// If we're in the section, check if we've hit another header at same or higher level
 		if inSection {
-			if levelMatches := levelPattern.FindStringSubmatch(line); levelMatches != nil {
+			if levelMatches := levelPattern.FindStringSubmatch(line); levelMatches != nil && !inFence {
 				currentLevel := len(levelMatches[1])
 				// Stop if we encounter same or higher level header
 				if currentLevel <= sectionLevel {
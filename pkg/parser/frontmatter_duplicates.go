@@ -0,0 +1,183 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	"github.com/goccy/go-yaml"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var frontmatterDuplicatesLog = logger.New("parser:frontmatter_duplicates")
+
+// mergeDuplicateKeys is process-wide for the same reason as offline mode: frontmatter is
+// parsed from many entry points (main workflows, imports, dispatch targets) that do not
+// share a compiler instance.
+var mergeDuplicateKeys atomic.Bool
+
+// SetMergeDuplicateKeys enables or disables merging of duplicate top-level frontmatter
+// keys. While enabled, a key such as tools or imports that is defined more than once has
+// its list or mapping values merged instead of being rejected.
+func SetMergeDuplicateKeys(merge bool) {
+	mergeDuplicateKeys.Store(merge)
+}
+
+// IsMergeDuplicateKeys reports whether duplicate top-level frontmatter keys are merged.
+func IsMergeDuplicateKeys() bool {
+	return mergeDuplicateKeys.Load()
+}
+
+// DuplicateFrontmatterKey is a top-level frontmatter key that was defined more than once
+// and merged. Lines are the 1-based file lines of every occurrence, in order.
+type DuplicateFrontmatterKey struct {
+	Key   string
+	Lines []int
+}
+
+// frontmatterSegment is a top-level key and the frontmatter lines that belong to it.
+type frontmatterSegment struct {
+	key   string
+	start int // index of the key line in the frontmatter lines
+	end   int // index one past the last line of the segment
+}
+
+// mergeDuplicateTopLevelKeys merges the values of top-level keys reported as duplicates
+// by pre-validation. Lists are concatenated (dropping repeated items) and mappings are
+// combined as long as no nested key is set in more than one occurrence. It returns the
+// frontmatter with every occurrence after the first blanked out, so line numbers in later
+// parser errors stay accurate, the merged values to store under each key, the merged keys,
+// and the issues that remain because their values cannot be merged.
+func mergeDuplicateTopLevelKeys(lines []string, issues []frontmatterIssue) (string, map[string]any, []DuplicateFrontmatterKey, []frontmatterIssue) {
+	duplicated := make(map[string]bool)
+	for _, issue := range issues {
+		if issue.duplicateKey != "" {
+			duplicated[issue.duplicateKey] = true
+		}
+	}
+	if len(duplicated) == 0 {
+		return strings.Join(lines, "\n"), nil, nil, issues
+	}
+
+	occurrences := make(map[string][]frontmatterSegment)
+	var order []string
+	for _, segment := range splitTopLevelSegments(lines) {
+		if !duplicated[segment.key] {
+			continue
+		}
+		if _, seen := occurrences[segment.key]; !seen {
+			order = append(order, segment.key)
+		}
+		occurrences[segment.key] = append(occurrences[segment.key], segment)
+	}
+
+	blanked := slices.Clone(lines)
+	values := make(map[string]any)
+	unmergeable := make(map[string]string)
+	var merged []DuplicateFrontmatterKey
+	for _, key := range order {
+		segments := occurrences[key]
+		value, err := mergeDuplicateValues(key, lines, segments)
+		if err != nil {
+			frontmatterDuplicatesLog.Printf("Cannot merge duplicate key %q: %v", key, err)
+			unmergeable[key] = err.Error()
+			continue
+		}
+		values[key] = value
+		duplicate := DuplicateFrontmatterKey{Key: key}
+		for i, segment := range segments {
+			duplicate.Lines = append(duplicate.Lines, frontmatterStartLine+segment.start)
+			if i == 0 {
+				continue
+			}
+			for j := segment.start; j < segment.end; j++ {
+				blanked[j] = ""
+			}
+		}
+		merged = append(merged, duplicate)
+	}
+
+	var remaining []frontmatterIssue
+	for _, issue := range issues {
+		if issue.duplicateKey != "" {
+			if _, ok := values[issue.duplicateKey]; ok {
+				continue
+			}
+			if reason, ok := unmergeable[issue.duplicateKey]; ok {
+				issue.message += "; it cannot be merged: " + reason
+			}
+		}
+		remaining = append(remaining, issue)
+	}
+	frontmatterDuplicatesLog.Printf("Merged %d duplicate top-level key(s)", len(merged))
+	return strings.Join(blanked, "\n"), values, merged, remaining
+}
+
+// splitTopLevelSegments splits frontmatter lines into one segment per top-level key.
+// Lines before the first key are not part of any segment.
+func splitTopLevelSegments(lines []string) []frontmatterSegment {
+	var segments []frontmatterSegment
+	for i, line := range lines {
+		match := prevalidationKeyPattern.FindStringSubmatch(strings.TrimSuffix(line, "\r"))
+		if match == nil || match[1] != "" || match[2] != "" {
+			continue
+		}
+		if len(segments) > 0 {
+			segments[len(segments)-1].end = i
+		}
+		segments = append(segments, frontmatterSegment{key: strings.Trim(match[3], `"'`), start: i, end: len(lines)})
+	}
+	return segments
+}
+
+// mergeDuplicateValues parses each occurrence of key on its own and merges the values.
+func mergeDuplicateValues(key string, lines []string, segments []frontmatterSegment) (any, error) {
+	var merged any
+	for _, segment := range segments {
+		var doc map[string]any
+		if err := yaml.Unmarshal([]byte(strings.Join(lines[segment.start:segment.end], "\n")), &doc); err != nil {
+			return nil, fmt.Errorf("the occurrence on line %d is not valid YAML", frontmatterStartLine+segment.start)
+		}
+		value := doc[key]
+		switch value.(type) {
+		case nil:
+			continue
+		case []any, map[string]any:
+		default:
+			return nil, errors.New("only list and mapping values can be merged")
+		}
+		if merged == nil {
+			merged = value
+			continue
+		}
+		switch current := merged.(type) {
+		case []any:
+			items, ok := value.([]any)
+			if !ok {
+				return nil, errors.New("only occurrences that are all lists or all mappings can be merged")
+			}
+			for _, item := range items {
+				if !slices.ContainsFunc(current, func(existing any) bool { return reflect.DeepEqual(existing, item) }) {
+					current = append(current, item)
+				}
+			}
+			merged = current
+		case map[string]any:
+			fields, ok := value.(map[string]any)
+			if !ok {
+				return nil, errors.New("only occurrences that are all lists or all mappings can be merged")
+			}
+			for field, fieldValue := range fields {
+				if _, exists := current[field]; exists {
+					return nil, fmt.Errorf("'%s.%s' is set in more than one occurrence", key, field)
+				}
+				current[field] = fieldValue
+			}
+		}
+	}
+	return merged, nil
+}
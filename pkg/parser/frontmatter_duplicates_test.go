@@ -0,0 +1,98 @@
+//go:build !integration
+
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func enableMergeDuplicateKeys(t *testing.T) {
+	t.Helper()
+	SetMergeDuplicateKeys(true)
+	t.Cleanup(func() { SetMergeDuplicateKeys(false) })
+}
+
+func TestExtractFrontmatterFromContent_DuplicateKeysRejectedByDefault(t *testing.T) {
+	content := "---\non: issues\ntools:\n  github:\ntools:\n  bash: true\n---\n# Title\n"
+	_, err := ExtractFrontmatterFromContent(content)
+	require.Error(t, err, "duplicate keys should be rejected without merging")
+	assert.Contains(t, err.Error(), "[5:1] duplicate key 'tools' (first defined on line 3)", "error should point at the duplicate")
+	assert.Contains(t, err.Error(), "--merge-duplicate-keys", "error should mention the merge flag for top-level keys")
+}
+
+func TestExtractFrontmatterFromContent_MergeDuplicateKeys(t *testing.T) {
+	enableMergeDuplicateKeys(t)
+
+	content := `---
+on: issues
+imports:
+  - shared/a.md
+  - shared/b.md
+tools:
+  github:
+    toolsets: [issues]
+engine: copilot
+imports:
+  - shared/b.md
+  - shared/c.md
+tools:
+  bash: ["echo"]
+---
+# Title
+`
+	result, err := ExtractFrontmatterFromContent(content)
+	require.NoError(t, err, "list and mapping duplicates should be merged")
+
+	assert.Equal(t, []any{"shared/a.md", "shared/b.md", "shared/c.md"}, result.Frontmatter["imports"], "imports should be concatenated without repeats")
+	tools, ok := result.Frontmatter["tools"].(map[string]any)
+	require.True(t, ok, "tools should be a mapping")
+	assert.Contains(t, tools, "github", "tools from the first occurrence should be kept")
+	assert.Contains(t, tools, "bash", "tools from the second occurrence should be added")
+	assert.Equal(t, "copilot", result.Frontmatter["engine"], "keys between the occurrences should be kept")
+
+	assert.Equal(t, []DuplicateFrontmatterKey{
+		{Key: "imports", Lines: []int{3, 10}},
+		{Key: "tools", Lines: []int{6, 13}},
+	}, result.MergedDuplicateKeys, "every occurrence of each merged key should be reported")
+}
+
+func TestExtractFrontmatterFromContent_MergeDuplicateKeysErrors(t *testing.T) {
+	enableMergeDuplicateKeys(t)
+
+	tests := []struct {
+		name        string
+		content     string
+		errContains string
+	}{
+		{
+			name:        "scalar duplicate",
+			content:     "---\non: issues\nengine: copilot\nengine: claude\n---\n",
+			errContains: "duplicate key 'engine' (first defined on line 3); it cannot be merged: only list and mapping values can be merged",
+		},
+		{
+			name:        "nested key set twice",
+			content:     "---\non: issues\ntools:\n  bash: true\ntools:\n  bash: [\"ls\"]\n---\n",
+			errContains: "'tools.bash' is set in more than one occurrence",
+		},
+		{
+			name:        "list and mapping mixed",
+			content:     "---\non: issues\nimports:\n  - a.md\nimports:\n  a: b\n---\n",
+			errContains: "all lists or all mappings",
+		},
+		{
+			name:        "nested duplicates are never merged",
+			content:     "---\non:\n  issues:\n  issues:\n---\n",
+			errContains: "duplicate key 'issues' (first defined on line 3)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ExtractFrontmatterFromContent(tt.content)
+			require.Error(t, err, "unmergeable duplicates should be rejected")
+			assert.Contains(t, err.Error(), tt.errContains, "error should explain why the key was not merged")
+		})
+	}
+}
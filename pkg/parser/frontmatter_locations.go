@@ -0,0 +1,141 @@
+package parser
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/goccy/go-yaml/ast"
+	yamlparser "github.com/goccy/go-yaml/parser"
+	"github.com/goccy/go-yaml/token"
+)
+
+var frontmatterLocationsLog = logger.New("parser:frontmatter_locations")
+
+// FrontmatterLocation is the source location of a frontmatter field
+type FrontmatterLocation struct {
+	Line   int // Absolute line number (1-based) in the file
+	Column int // Column number (1-based)
+	Length int // Length of the key, or of the first line of the value for sequence items
+}
+
+// LocateFrontmatterFields returns the source location of every field in the frontmatter YAML.
+//
+// Map keys are field paths: mapping keys are joined with "." and sequence items use
+// "[index]", e.g. "engine", "safe-outputs.create-pull-request.max", "imports[1]".
+// Mapping fields point at their key; sequence items point at the start of the item.
+// startLine is the absolute line number of the first frontmatter line in the file.
+// Returns an empty map when the YAML cannot be parsed.
+func LocateFrontmatterFields(frontmatterYAML string, startLine int) map[string]FrontmatterLocation {
+	locations := make(map[string]FrontmatterLocation)
+	if strings.TrimSpace(frontmatterYAML) == "" {
+		return locations
+	}
+
+	file, err := yamlparser.ParseBytes([]byte(frontmatterYAML), 0)
+	if err != nil {
+		frontmatterLocationsLog.Printf("Failed to parse frontmatter for field locations: %v", err)
+		return locations
+	}
+	for _, doc := range file.Docs {
+		if doc != nil {
+			collectFieldLocations(doc.Body, "", startLine-1, locations)
+		}
+	}
+
+	frontmatterLocationsLog.Printf("Located %d frontmatter fields", len(locations))
+	return locations
+}
+
+// collectFieldLocations walks a YAML node and records the location of each field under prefix
+func collectFieldLocations(node ast.Node, prefix string, lineOffset int, locations map[string]FrontmatterLocation) {
+	switch n := unwrapLocationNode(node).(type) {
+	case *ast.MappingNode:
+		for _, value := range n.Values {
+			collectMappingValueLocation(value, prefix, lineOffset, locations)
+		}
+	case *ast.MappingValueNode:
+		collectMappingValueLocation(n, prefix, lineOffset, locations)
+	case *ast.SequenceNode:
+		for i, item := range n.Values {
+			path := prefix + "[" + strconv.Itoa(i) + "]"
+			if loc, ok := tokenLocation(itemStartNode(item), lineOffset); ok {
+				locations[path] = loc
+			}
+			collectFieldLocations(item, path, lineOffset, locations)
+		}
+	}
+}
+
+// collectMappingValueLocation records the location of a single mapping key and walks its value
+func collectMappingValueLocation(value *ast.MappingValueNode, prefix string, lineOffset int, locations map[string]FrontmatterLocation) {
+	if value == nil || value.Key == nil {
+		return
+	}
+	key := value.Key.GetToken()
+	if key == nil || key.Value == "<<" {
+		return
+	}
+	path := key.Value
+	if prefix != "" {
+		path = prefix + "." + key.Value
+	}
+	// Keep the first occurrence so duplicate keys point at the original definition
+	if _, exists := locations[path]; !exists {
+		if loc, ok := tokenLocation(value.Key, lineOffset); ok {
+			locations[path] = loc
+		}
+	}
+	collectFieldLocations(value.Value, path, lineOffset, locations)
+}
+
+// unwrapLocationNode returns the node an anchor or tag applies to
+func unwrapLocationNode(node ast.Node) ast.Node {
+	for {
+		switch n := node.(type) {
+		case *ast.AnchorNode:
+			node = n.Value
+		case *ast.TagNode:
+			node = n.Value
+		default:
+			return node
+		}
+	}
+}
+
+// itemStartNode returns the node at which a sequence item starts: the first key for mapping
+// items, since the token of a mapping node is its ":" indicator
+func itemStartNode(node ast.Node) ast.Node {
+	switch n := unwrapLocationNode(node).(type) {
+	case *ast.MappingNode:
+		if len(n.Values) > 0 && !n.IsFlowStyle {
+			return n.Values[0].Key
+		}
+		return n
+	case *ast.MappingValueNode:
+		return n.Key
+	default:
+		return n
+	}
+}
+
+// tokenLocation converts the position of a node's token into a FrontmatterLocation
+func tokenLocation(node ast.Node, lineOffset int) (FrontmatterLocation, bool) {
+	if node == nil {
+		return FrontmatterLocation{}, false
+	}
+	tk := node.GetToken()
+	if tk == nil || tk.Position == nil {
+		return FrontmatterLocation{}, false
+	}
+	value, _, _ := strings.Cut(tk.Value, "\n")
+	length := len(value)
+	if tk.Type == token.DoubleQuoteType || tk.Type == token.SingleQuoteType {
+		length += 2
+	}
+	return FrontmatterLocation{
+		Line:   tk.Position.Line + lineOffset,
+		Column: tk.Position.Column,
+		Length: length,
+	}, true
+}
@@ -0,0 +1,64 @@
+//go:build !integration
+
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocateFrontmatterFields(t *testing.T) {
+	frontmatterYAML := `on: push
+engine: copilot
+imports:
+  - shared/tools.md
+  - path: shared/review.md
+    inputs:
+      mode: strict
+safe-outputs:
+  create-pull-request:
+    max: 2
+labels: [bug, "needs triage"]
+`
+
+	locations := LocateFrontmatterFields(frontmatterYAML, 2)
+
+	tests := []struct {
+		path string
+		want FrontmatterLocation
+	}{
+		{path: "on", want: FrontmatterLocation{Line: 2, Column: 1, Length: 2}},
+		{path: "engine", want: FrontmatterLocation{Line: 3, Column: 1, Length: 6}},
+		{path: "imports[0]", want: FrontmatterLocation{Line: 5, Column: 5, Length: 15}},
+		{path: "imports[1]", want: FrontmatterLocation{Line: 6, Column: 5, Length: 4}},
+		{path: "imports[1].inputs.mode", want: FrontmatterLocation{Line: 8, Column: 7, Length: 4}},
+		{path: "safe-outputs.create-pull-request", want: FrontmatterLocation{Line: 10, Column: 3, Length: 19}},
+		{path: "safe-outputs.create-pull-request.max", want: FrontmatterLocation{Line: 11, Column: 5, Length: 3}},
+		{path: "labels[1]", want: FrontmatterLocation{Line: 12, Column: 15, Length: 14}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, ok := locations[tt.path]
+			require.True(t, ok, "field %s should be located", tt.path)
+			assert.Equal(t, tt.want, got, "location of %s", tt.path)
+		})
+	}
+}
+
+func TestLocateFrontmatterFields_InvalidYAML(t *testing.T) {
+	assert.Empty(t, LocateFrontmatterFields("", 2), "empty frontmatter should have no locations")
+	assert.Empty(t, LocateFrontmatterFields("on: [push\n", 2), "unparseable frontmatter should have no locations")
+}
+
+func TestExtractFrontmatterFromContent_FieldLocations(t *testing.T) {
+	content := "---\non: push\nsafe-outputs:\n  create-issue:\n    max: 3\n---\n# Workflow\n"
+
+	result, err := ExtractFrontmatterFromContent(content)
+	require.NoError(t, err, "frontmatter should parse")
+
+	loc, ok := result.FieldLocations["safe-outputs.create-issue.max"]
+	require.True(t, ok, "nested field should be located")
+	assert.Equal(t, FrontmatterLocation{Line: 5, Column: 5, Length: 3}, loc, "location should use absolute file lines")
+}
@@ -0,0 +1,295 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var frontmatterPrevalidationLog = logger.New("parser:frontmatter_prevalidation")
+
+// utf8BOM is the UTF-8 encoded byte order mark some editors prepend to files.
+const utf8BOM = "\uFEFF"
+
+var (
+	// prevalidationKeyPattern matches a block mapping key, optionally introduced by a list item dash.
+	prevalidationKeyPattern = regexp.MustCompile(`^( *)(- +)?([A-Za-z0-9_.$/-]+|"[^"]*"|'[^']*') *:(?: |$)`)
+	// blockScalarHeaderPattern matches values that start a literal or folded block scalar.
+	blockScalarHeaderPattern = regexp.MustCompile(`: *[|>][-+0-9]* *(?:#.*)?$`)
+)
+
+// frontmatterIssue is a structural problem found before the YAML parser runs. Line and
+// column are 1-based positions in the markdown file, so they can be reported directly.
+type frontmatterIssue struct {
+	line       int
+	column     int
+	message    string
+	suggestion string
+	// duplicateKey is set for a top-level key defined more than once, which
+	// --merge-duplicate-keys may be able to merge instead of rejecting.
+	duplicateKey string
+}
+
+// prevalidateFrontmatter scans raw frontmatter lines for problems that otherwise surface as
+// cryptic YAML parser errors (tab indentation, duplicate keys) or silently drop configuration
+// (a "..." document end marker, a "---" that closes the frontmatter early). markdown is the
+// content after the closing delimiter, which starts on line markdownStartLine.
+func prevalidateFrontmatter(frontmatterLines []string, markdown string, markdownStartLine int) []frontmatterIssue {
+	var issues []frontmatterIssue
+	issues = append(issues, findDocumentMarkers(frontmatterLines)...)
+	issues = append(issues, findTabIndentation(frontmatterLines)...)
+	issues = append(issues, findDuplicateKeys(frontmatterLines)...)
+	if issue, ok := findPrematureTerminator(markdown, markdownStartLine); ok {
+		issues = append(issues, issue)
+	}
+	if len(issues) > 0 {
+		frontmatterPrevalidationLog.Printf("Frontmatter pre-validation found %d issue(s)", len(issues))
+	}
+	return issues
+}
+
+// checkByteOrderMark reports a UTF-8 BOM in front of the opening frontmatter delimiter, which
+// otherwise hides the frontmatter entirely because the first line no longer reads "---".
+func checkByteOrderMark(content string) (frontmatterIssue, bool) {
+	rest, found := strings.CutPrefix(content, utf8BOM)
+	if !found {
+		return frontmatterIssue{}, false
+	}
+	_, firstLine := splitFirstLine(rest)
+	if !isFrontmatterDelimiterLine(firstLine) {
+		return frontmatterIssue{}, false
+	}
+	return frontmatterIssue{
+		line:       1,
+		column:     1,
+		message:    "file starts with a UTF-8 byte order mark (BOM), so the opening '---' is not recognized",
+		suggestion: "save the file as UTF-8 without BOM",
+	}, true
+}
+
+// findDocumentMarkers reports YAML document end markers, which make the parser ignore
+// every frontmatter line that follows them.
+func findDocumentMarkers(lines []string) []frontmatterIssue {
+	var issues []frontmatterIssue
+	for i, line := range lines {
+		if strings.TrimRight(line, " \t\r") != "..." {
+			continue
+		}
+		issues = append(issues, frontmatterIssue{
+			line:       frontmatterStartLine + i,
+			column:     1,
+			message:    "'...' ends the YAML document, so the frontmatter lines after it are ignored",
+			suggestion: "remove the '...' line; frontmatter must be a single YAML document",
+		})
+	}
+	return issues
+}
+
+// findTabIndentation reports tabs used for indentation. Tabs after the indentation of a
+// literal or folded block scalar are content (for example Makefile recipes) and are allowed.
+func findTabIndentation(lines []string) []frontmatterIssue {
+	var issues []frontmatterIssue
+	blockParentIndent := -1 // indentation of the key owning the current block scalar
+	blockIndent := 0        // content indentation of the block scalar, 0 until its first line
+	for i, line := range lines {
+		line = strings.TrimSuffix(line, "\r")
+		spaces := len(line) - len(strings.TrimLeft(line, " "))
+		rest := line[spaces:]
+		tabIndented := strings.HasPrefix(rest, "\t")
+		blank := strings.TrimSpace(line) == ""
+
+		if blockParentIndent >= 0 {
+			if blockIndent == 0 && !blank && !tabIndented && spaces > blockParentIndent {
+				blockIndent = spaces
+			}
+			if (blockIndent > 0 && spaces >= blockIndent) || (blank && !tabIndented) {
+				continue
+			}
+			if !tabIndented {
+				blockParentIndent = -1
+			}
+		}
+
+		if tabIndented {
+			fixed := strings.Repeat(" ", spaces) + strings.ReplaceAll(leadingWhitespace(rest), "\t", "  ") + strings.TrimLeft(rest, " \t")
+			issues = append(issues, frontmatterIssue{
+				line:       frontmatterStartLine + i,
+				column:     spaces + 1,
+				message:    "tab character used for indentation; YAML indentation must use spaces",
+				suggestion: fmt.Sprintf("replace the tab with spaces: %q", fixed),
+			})
+			continue
+		}
+
+		if !blank && blockScalarHeaderPattern.MatchString(stripYAMLComment(line)) {
+			blockParentIndent, blockIndent = spaces, 0
+			if match := prevalidationKeyPattern.FindStringSubmatch(line); match != nil {
+				blockParentIndent = len(match[1]) + len(match[2])
+			}
+		}
+	}
+	return issues
+}
+
+// findDuplicateKeys reports mapping keys defined twice in the same block mapping. Keys are
+// tracked per indentation level; a list item starts a fresh mapping.
+func findDuplicateKeys(lines []string) []frontmatterIssue {
+	type mappingScope struct {
+		indent int
+		keys   map[string]int // key -> first line (1-based file line)
+	}
+	var issues []frontmatterIssue
+	var scopes []mappingScope
+	blockParentIndent := -1
+	for i, line := range lines {
+		line = strings.TrimSuffix(line, "\r")
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		spaces := len(line) - len(strings.TrimLeft(line, " "))
+		if blockParentIndent >= 0 {
+			if spaces > blockParentIndent {
+				continue
+			}
+			blockParentIndent = -1
+		}
+
+		match := prevalidationKeyPattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		indent := len(match[1])
+		if match[2] != "" {
+			// "- key:" starts a new mapping nested under the list item
+			indent += len(match[2])
+			for len(scopes) > 0 && scopes[len(scopes)-1].indent >= indent {
+				scopes = scopes[:len(scopes)-1]
+			}
+		}
+		for len(scopes) > 0 && scopes[len(scopes)-1].indent > indent {
+			scopes = scopes[:len(scopes)-1]
+		}
+		if len(scopes) == 0 || scopes[len(scopes)-1].indent < indent {
+			scopes = append(scopes, mappingScope{indent: indent, keys: make(map[string]int)})
+		}
+
+		key := strings.Trim(match[3], `"'`)
+		lineNum := frontmatterStartLine + i
+		scope := scopes[len(scopes)-1]
+		if firstLine, seen := scope.keys[key]; seen && key != "<<" {
+			issue := frontmatterIssue{
+				line:       lineNum,
+				column:     indent + 1,
+				message:    fmt.Sprintf("duplicate key '%s' (first defined on line %d)", key, firstLine),
+				suggestion: fmt.Sprintf("merge the two '%s' entries into one, or remove the one you do not want", key),
+			}
+			if indent == 0 {
+				issue.duplicateKey = key
+				issue.suggestion += "; list and mapping values of top-level keys can also be merged with 'compile --merge-duplicate-keys'"
+			}
+			issues = append(issues, issue)
+		} else if !seen {
+			scope.keys[key] = lineNum
+		}
+
+		if blockScalarHeaderPattern.MatchString(stripYAMLComment(line)) {
+			blockParentIndent = indent
+		}
+	}
+	return issues
+}
+
+// findPrematureTerminator detects a "---" that closed the frontmatter while more frontmatter
+// keys follow it, e.g. a separator typed between sections. It only fires when the markdown
+// starts with a known top-level frontmatter key and another "---" line follows, so ordinary
+// markdown bodies with horizontal rules are not affected.
+func findPrematureTerminator(markdown string, markdownStartLine int) (frontmatterIssue, bool) {
+	key, keyLine := "", 0
+	lineNum := markdownStartLine - 1
+	for line := range strings.SplitSeq(markdown, "\n") {
+		lineNum++
+		if key == "" {
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			match := prevalidationKeyPattern.FindStringSubmatch(line)
+			if match == nil || match[1] != "" || match[2] != "" || !isMainWorkflowTopLevelKey(match[3]) {
+				return frontmatterIssue{}, false
+			}
+			key, keyLine = match[3], lineNum
+			continue
+		}
+		if isFrontmatterDelimiterLine(line) {
+			closingLine := markdownStartLine - 1
+			return frontmatterIssue{
+				line:       closingLine,
+				column:     1,
+				message:    fmt.Sprintf("'---' closes the frontmatter, but '%s:' on line %d is frontmatter too", key, keyLine),
+				suggestion: fmt.Sprintf("remove the '---' on line %d so the frontmatter ends at line %d", closingLine, lineNum),
+			}, true
+		}
+	}
+	return frontmatterIssue{}, false
+}
+
+// isMainWorkflowTopLevelKey reports whether key is a top-level property of the main workflow schema.
+func isMainWorkflowTopLevelKey(key string) bool {
+	rawDoc, err := getParsedSchemaDoc(mainWorkflowSchema)
+	if err != nil {
+		return false
+	}
+	schemaDoc, ok := rawDoc.(map[string]any)
+	if !ok {
+		return false
+	}
+	properties, ok := schemaDoc["properties"].(map[string]any)
+	if !ok {
+		return false
+	}
+	_, exists := properties[key]
+	return exists
+}
+
+// formatFrontmatterIssues renders pre-validation issues in the same "[line:col] message"
+// layout as yaml.FormatError(), followed by source context and a fix suggestion, so
+// callers that parse frontmatter errors can extract the position of the first issue.
+func formatFrontmatterIssues(issues []frontmatterIssue, content string) error {
+	lines := strings.Split(content, "\n")
+	first := issues[0]
+	var b strings.Builder
+	b.WriteString("failed to parse frontmatter:\n")
+	fmt.Fprintf(&b, "[%d:%d] %s\n", first.line, first.column, first.message)
+	for lineNum := max(1, first.line-2); lineNum <= min(len(lines), first.line+1); lineNum++ {
+		marker := " "
+		if lineNum == first.line {
+			marker = ">"
+		}
+		fmt.Fprintf(&b, "%s%3d | %s\n", marker, lineNum, strings.TrimPrefix(lines[lineNum-1], utf8BOM))
+		if lineNum == first.line {
+			fmt.Fprintf(&b, "%s^\n", strings.Repeat(" ", 6+first.column))
+		}
+	}
+	fmt.Fprintf(&b, "Suggestion: %s", first.suggestion)
+	for _, issue := range issues[1:] {
+		fmt.Fprintf(&b, "\n[%d:%d] %s. Suggestion: %s", issue.line, issue.column, issue.message, issue.suggestion)
+	}
+	return &FormattedParserError{formatted: b.String()}
+}
+
+// leadingWhitespace returns the run of spaces and tabs at the start of s.
+func leadingWhitespace(s string) string {
+	return s[:len(s)-len(strings.TrimLeft(s, " \t"))]
+}
+
+// stripYAMLComment removes a trailing " #" comment so block scalar headers such as
+// "run: | # comment" are still recognized. Quoted '#' characters are not special-cased
+// because block scalar headers never contain quotes.
+func stripYAMLComment(line string) string {
+	if idx := strings.Index(line, " #"); idx >= 0 {
+		return line[:idx]
+	}
+	return line
+}
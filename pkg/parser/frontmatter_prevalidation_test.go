@@ -0,0 +1,130 @@
+//go:build !integration
+
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractFrontmatterFromContent_Prevalidation(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		wantPosition  string
+		wantMessage   string
+		wantSuggested string
+	}{
+		{
+			name:          "byte order mark",
+			content:       "\uFEFF---\non: issues\n---\n# Title\n",
+			wantPosition:  "[1:1]",
+			wantMessage:   "byte order mark",
+			wantSuggested: "save the file as UTF-8 without BOM",
+		},
+		{
+			name:          "tab indentation",
+			content:       "---\non:\n  issues:\n\ttypes: [opened]\n---\n# Title\n",
+			wantPosition:  "[4:1]",
+			wantMessage:   "tab character used for indentation",
+			wantSuggested: `replace the tab with spaces: "  types: [opened]"`,
+		},
+		{
+			name:          "tab after spaces",
+			content:       "---\non:\n  issues:\n  \ttypes: [opened]\n---\n",
+			wantPosition:  "[4:3]",
+			wantMessage:   "tab character used for indentation",
+			wantSuggested: `"    types: [opened]"`,
+		},
+		{
+			name:          "duplicate top-level key",
+			content:       "---\non: issues\nengine: copilot\npermissions: read-all\nengine: claude\n---\n",
+			wantPosition:  "[5:1]",
+			wantMessage:   "duplicate key 'engine' (first defined on line 3)",
+			wantSuggested: "merge the two 'engine' entries",
+		},
+		{
+			name:          "duplicate nested key in list item",
+			content:       "---\non: issues\nsteps:\n  - name: first\n    run: echo 1\n    name: again\n---\n",
+			wantPosition:  "[6:5]",
+			wantMessage:   "duplicate key 'name' (first defined on line 4)",
+			wantSuggested: "merge the two 'name' entries",
+		},
+		{
+			name:          "document end marker",
+			content:       "---\non: issues\n...\nengine: copilot\n---\n",
+			wantPosition:  "[3:1]",
+			wantMessage:   "'...' ends the YAML document",
+			wantSuggested: "remove the '...' line",
+		},
+		{
+			name:          "premature document terminator",
+			content:       "---\non: issues\n---\nengine: copilot\ntools:\n  github:\n---\n# Title\n",
+			wantPosition:  "[3:1]",
+			wantMessage:   "'engine:' on line 4 is frontmatter too",
+			wantSuggested: "remove the '---' on line 3 so the frontmatter ends at line 7",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ExtractFrontmatterFromContent(tt.content)
+			require.Error(t, err, "pre-validation should reject the frontmatter")
+
+			var formatted *FormattedParserError
+			require.ErrorAs(t, err, &formatted, "pre-validation errors should be pre-formatted")
+			msg := err.Error()
+			assert.True(t, strings.HasPrefix(msg, "failed to parse frontmatter:\n"+tt.wantPosition+" "), "error should start with the position, got:\n%s", msg)
+			assert.Contains(t, msg, tt.wantMessage, "error should describe the problem")
+			assert.Contains(t, msg, "Suggestion: ", "error should include a fix suggestion")
+			assert.Contains(t, msg, tt.wantSuggested, "error should include the specific fix")
+			assert.Contains(t, msg, "\n>", "error should include source context")
+		})
+	}
+}
+
+func TestExtractFrontmatterFromContent_PrevalidationAllowsValidYAML(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{
+			name:    "tabs inside a block scalar",
+			content: "---\non: issues\nsteps:\n  - name: build\n    run: |\n      make:\n      \tcc -o app main.c\n\n      \techo done\n---\n# Title\n",
+		},
+		{
+			name:    "same key in different mappings and list items",
+			content: "---\non:\n  issues:\n    types: [opened]\n  pull_request:\n    types: [opened]\nsteps:\n  - name: a\n  - name: b\n---\n",
+		},
+		{
+			name:    "key-like text inside a block scalar",
+			content: "---\non: issues\ndescription: |\n  engine: one\n  engine: two\n---\n",
+		},
+		{
+			name:    "horizontal rule in markdown body",
+			content: "---\non: issues\n---\nNote: this body has a rule\n\n---\n\nMore text\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ExtractFrontmatterFromContent(tt.content)
+			require.NoError(t, err, "valid frontmatter should not be rejected")
+			assert.Contains(t, result.Frontmatter, "on", "frontmatter should be parsed")
+		})
+	}
+}
+
+func TestFormatFrontmatterIssues_ListsAdditionalIssues(t *testing.T) {
+	content := "---\non: issues\n\tengine: copilot\n\ttools: {}\n---\n"
+	_, err := ExtractFrontmatterFromContent(content)
+	require.Error(t, err, "tab-indented frontmatter should be rejected")
+
+	msg := err.Error()
+	assert.Contains(t, msg, "[3:1] tab character used for indentation", "first issue should be reported with its position")
+	assert.Contains(t, msg, "\n[4:1] tab character used for indentation", "additional issues should be listed")
+	assert.Contains(t, msg, ">  3 | \tengine: copilot\n       ^", "caret should point at the first issue column")
+}
@@ -0,0 +1,104 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goccy/go-yaml"
+
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/sliceutil"
+)
+
+var importAliasesLog = logger.New("parser:import_aliases")
+
+// ImportAliasConfigFile is the repository configuration file, relative to the repository
+// root, that declares import aliases under its aliases: key.
+const ImportAliasConfigFile = ".github/aw/config.yml"
+
+// importAliasNamePattern matches alias names such as "@shared". The restricted character
+// set keeps aliases distinct from @builtin: paths and workflowspec refs.
+var importAliasNamePattern = regexp.MustCompile(`^@[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// importAliasFile mirrors the parts of .github/aw/config.yml read by the parser.
+// Other top-level keys are ignored so the file can carry unrelated settings.
+type importAliasFile struct {
+	Aliases map[string]string `yaml:"aliases"`
+}
+
+type cachedImportAliases struct {
+	modTime time.Time
+	aliases map[string]string
+	err     error
+}
+
+// importAliasCache memoizes parsed alias tables per config file, invalidated by mtime
+// so watch-mode recompiles pick up edits.
+var importAliasCache sync.Map
+
+// IsImportAlias reports whether an import path uses alias syntax (@name/path).
+func IsImportAlias(importPath string) bool {
+	name, rest, ok := strings.Cut(filepath.ToSlash(importPath), "/")
+	return ok && rest != "" && importAliasNamePattern.MatchString(name)
+}
+
+// ParseImportAliases parses the aliases: map of a config.yml document. Alias names must
+// look like "@shared" and targets must be relative paths that stay inside the repository.
+func ParseImportAliases(content []byte) (map[string]string, error) {
+	var file importAliasFile
+	if err := yaml.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", ImportAliasConfigFile, err)
+	}
+	var errs []error
+	aliases := make(map[string]string, len(file.Aliases))
+	for _, name := range sliceutil.SortedKeys(file.Aliases) {
+		target := filepath.ToSlash(strings.TrimSpace(file.Aliases[name]))
+		if !importAliasNamePattern.MatchString(name) {
+			errs = append(errs, fmt.Errorf("alias %q must be a single name starting with @, such as @shared", name))
+			continue
+		}
+		cleaned := path.Clean(target)
+		if target == "" || path.IsAbs(target) || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+			errs = append(errs, fmt.Errorf("alias %s -> %q must be a path relative to the repository root", name, target))
+			continue
+		}
+		aliases[name] = cleaned
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid %s: %w", ImportAliasConfigFile, errors.Join(errs...))
+	}
+	return aliases, nil
+}
+
+// loadImportAliases returns the alias table declared in repoRoot/.github/aw/config.yml.
+// A missing file yields an empty table.
+func loadImportAliases(repoRoot string) (map[string]string, error) {
+	configPath := filepath.Join(repoRoot, filepath.FromSlash(ImportAliasConfigFile))
+	info, err := os.Stat(configPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", ImportAliasConfigFile, err)
+	}
+	if cached, ok := importAliasCache.Load(configPath); ok {
+		if entry, ok := cached.(cachedImportAliases); ok && entry.modTime.Equal(info.ModTime()) {
+			return entry.aliases, entry.err
+		}
+	}
+	content, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", ImportAliasConfigFile, err)
+	}
+	aliases, err := ParseImportAliases(content)
+	importAliasesLog.Printf("Loaded %d import alias(es) from %s", len(aliases), configPath)
+	importAliasCache.Store(configPath, cachedImportAliases{modTime: info.ModTime(), aliases: aliases, err: err})
+	return aliases, err
+}
@@ -0,0 +1,87 @@
+//go:build !integration
+
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/github/gh-aw/pkg/testutil"
+)
+
+func TestIsImportAlias(t *testing.T) {
+	assert.True(t, IsImportAlias("@shared/security.md"), "@name/path should be an alias")
+	assert.False(t, IsImportAlias("@shared"), "alias without a path is not an import")
+	assert.False(t, IsImportAlias("shared/security.md"), "relative paths are not aliases")
+	assert.False(t, IsImportAlias("owner/repo/path.md@v1"), "workflowspecs are not aliases")
+	assert.False(t, IsImportAlias("@builtin:engines/copilot.md"), "builtin paths are not aliases")
+}
+
+func TestParseImportAliases(t *testing.T) {
+	aliases, err := ParseImportAliases([]byte("version: 1\naliases:\n  \"@shared\": tools/agentics/shared/\n  \"@agents\": ./.github/agents\n"))
+	require.NoError(t, err, "valid config should parse")
+	assert.Equal(t, map[string]string{"@shared": "tools/agentics/shared", "@agents": ".github/agents"}, aliases, "targets should be cleaned")
+
+	tests := []struct {
+		name        string
+		content     string
+		errContains string
+	}{
+		{name: "missing @", content: "aliases:\n  shared: tools\n", errContains: `alias "shared" must be a single name starting with @`},
+		{name: "nested name", content: "aliases:\n  \"@a/b\": tools\n", errContains: "must be a single name"},
+		{name: "absolute target", content: "aliases:\n  \"@shared\": /etc\n", errContains: "must be a path relative to the repository root"},
+		{name: "escaping target", content: "aliases:\n  \"@shared\": ../other-repo\n", errContains: "must be a path relative to the repository root"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseImportAliases([]byte(tt.content))
+			require.Error(t, err, "invalid config should be rejected")
+			assert.Contains(t, err.Error(), tt.errContains, "error should describe the problem")
+		})
+	}
+}
+
+func TestResolveIncludePathAlias(t *testing.T) {
+	repoRoot := testutil.TempDir(t, "import-aliases")
+	workflowsDir := filepath.Join(repoRoot, ".github", "workflows")
+	sharedDir := filepath.Join(repoRoot, "tools", "agentics", "shared")
+	require.NoError(t, os.MkdirAll(workflowsDir, 0755), "Failed to create workflows dir")
+	require.NoError(t, os.MkdirAll(filepath.Join(repoRoot, ".github", "aw"), 0755), "Failed to create aw dir")
+	require.NoError(t, os.MkdirAll(sharedDir, 0755), "Failed to create shared dir")
+	require.NoError(t, os.WriteFile(filepath.Join(repoRoot, ".github", "aw", "config.yml"), []byte("aliases:\n  \"@shared\": tools/agentics/shared\n"), 0644), "Failed to write config")
+	require.NoError(t, os.WriteFile(filepath.Join(sharedDir, "security.md"), []byte("# Security\n"), 0644), "Failed to write shared file")
+
+	t.Run("resolves from the workflows folder", func(t *testing.T) {
+		fullPath, err := ResolveIncludePath("@shared/security.md", workflowsDir, nil)
+		require.NoError(t, err, "alias should resolve")
+		assert.Equal(t, filepath.Join(sharedDir, "security.md"), fullPath, "alias should map to the target directory")
+	})
+
+	t.Run("resolves from an aliased file", func(t *testing.T) {
+		fullPath, err := ResolveIncludePath("@shared/security.md", sharedDir, nil)
+		require.NoError(t, err, "nested alias imports should find the repository root")
+		assert.Equal(t, filepath.Join(sharedDir, "security.md"), fullPath, "alias should map to the target directory")
+	})
+
+	t.Run("unknown alias", func(t *testing.T) {
+		_, err := ResolveIncludePath("@missing/security.md", workflowsDir, nil)
+		require.Error(t, err, "undeclared aliases should fail")
+		assert.Contains(t, err.Error(), "unknown import alias @missing", "error should name the alias")
+	})
+
+	t.Run("path escaping the alias target", func(t *testing.T) {
+		_, err := ResolveIncludePath("@shared/../../../.github/aw/config.yml", workflowsDir, nil)
+		require.Error(t, err, "aliased paths must stay inside the target")
+		assert.Contains(t, err.Error(), "must stay within the @shared alias target", "error should explain the restriction")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := ResolveIncludePath("@shared/missing.md", workflowsDir, nil)
+		require.Error(t, err, "missing files should fail")
+		assert.Contains(t, err.Error(), "file not found", "error should report the missing file")
+	})
+}
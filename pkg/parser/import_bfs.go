@@ -35,7 +35,12 @@ func processImportsFromFrontmatterWithManifestAndSource(frontmatter map[string]a
 		return &ImportsResult{}, nil
 	}
 	parserLog.Printf("Found %d direct imports to process", len(importSpecs))
+	conflictPolicy, err := parseImportConflictPolicy(frontmatter)
+	if err != nil {
+		return nil, err
+	}
 	state := newImportBFSState()
+	state.acc.conflictPolicy = conflictPolicy
 	if err := seedInitialImportQueue(importSpecs, baseDir, cache, workflowFilePath, yamlContent, state); err != nil {
 		return nil, err
 	}
@@ -48,12 +53,17 @@ func processImportsFromFrontmatterWithManifestAndSource(frontmatter map[string]a
 		return nil, err
 	}
 	parserLog.Printf("Sorted imports in topological order: %v", topologicalOrder)
+	if err := state.acc.resolveImportConflicts(); err != nil {
+		return nil, err
+	}
 	return state.acc.toImportsResult(topologicalOrder), nil
 }
 
 type nestedImportEntry struct {
-	path   string
-	inputs map[string]any
+	path          string
+	inputs        map[string]any
+	markdown      MarkdownImportOptions
+	githubContext GitHubContextImportOptions
 }
 
 type importBFSState struct {
@@ -123,18 +133,25 @@ func seedInitialImportQueue(importSpecs []ImportSpec, baseDir string, cache *Imp
 
 func seedSingleImportSpec(importSpec ImportSpec, baseDir string, cache *ImportCache, workflowFilePath string, yamlContent string, state *importBFSState) error {
 	importPath := importSpec.Path
+	if handled, err := seedGitHubContextImport(importSpec, state.acc); handled || err != nil {
+		return err
+	}
 	if isRepositoryImport(importPath) {
 		parserLog.Printf("Detected repository import: %s", importPath)
 		state.acc.repositoryImports = append(state.acc.repositoryImports, importPath)
 		return nil
 	}
 	filePath, sectionName := splitImportPathAndSection(importPath)
+	markdownOpts, err := mergeMarkdownImportOptions(sectionName, importSpec.Markdown)
+	if err != nil {
+		return fmt.Errorf("import '%s': %w", importPath, err)
+	}
 	fullPath, err := resolveSeedImportPath(filePath, importPath, baseDir, cache, workflowFilePath, yamlContent)
 	if err != nil {
 		return err
 	}
 	origin := detectRemoteImportOrigin(filePath)
-	return enqueueImportPath(state, importPath, fullPath, sectionName, baseDir, importSpec.Inputs, origin)
+	return enqueueImportPath(state, importPath, fullPath, sectionName, baseDir, importSpec.Inputs, markdownOpts, origin)
 }
 
 func splitImportPathAndSection(importPath string) (string, string) {
@@ -195,12 +212,12 @@ func detectRemoteImportOrigin(filePath string) *remoteImportOrigin {
 	return origin
 }
 
-func enqueueImportPath(state *importBFSState, importPath, fullPath, sectionName, baseDir string, inputs map[string]any, origin *remoteImportOrigin) error {
+func enqueueImportPath(state *importBFSState, importPath, fullPath, sectionName, baseDir string, inputs map[string]any, markdownOpts MarkdownImportOptions, origin *remoteImportOrigin) error {
 	if !setutil.Contains(state.visited, fullPath) {
 		state.visited[fullPath] = struct{}{}
 		state.visitedInputs[fullPath] = inputs
 		state.queue = append(state.queue, importQueueItem{
-			importPath: importPath, fullPath: fullPath, sectionName: sectionName, baseDir: baseDir, inputs: inputs, remoteOrigin: origin,
+			importPath: importPath, fullPath: fullPath, sectionName: sectionName, markdown: markdownOpts, baseDir: baseDir, inputs: inputs, remoteOrigin: origin,
 		})
 		parserLog.Printf("Queued import: %s (resolved to %s)", importPath, fullPath)
 		return nil
@@ -227,6 +244,7 @@ func processQueueItem(item importQueueItem, baseDir string, cache *ImportCache,
 	parserLog.Printf("Processing import from queue: %s", item.fullPath)
 	maps.Copy(state.acc.importInputs, item.inputs)
 	state.processedOrder = append(state.processedOrder, item.importPath)
+	state.acc.recordImportSource(item)
 	handled, err := handleAgentImportItem(item, state)
 	if handled || err != nil {
 		return err
@@ -301,10 +319,26 @@ func appendYAMLImportJobsOrSteps(acc *importAccumulator, importPath, fullPath, j
 	}
 	if jobsOrStepsData != "" && jobsOrStepsData != "{}" {
 		acc.jobsBuilder.WriteString(jobsOrStepsData + "\n")
+		recordYAMLImportJobSources(acc, importPath, jobsOrStepsData)
 		parserLog.Printf("Added jobs from YAML workflow: %s", importPath)
 	}
 }
 
+// recordYAMLImportJobSources remembers which YAML workflow import defined each job so the
+// compiler can attribute job validation errors to the import. The first import wins,
+// matching the merge order of the jobs themselves.
+func recordYAMLImportJobSources(acc *importAccumulator, importPath, jobsJSON string) {
+	var jobs map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(jobsJSON), &jobs); err != nil {
+		return
+	}
+	for jobName := range jobs {
+		if _, exists := acc.yamlJobSources[jobName]; !exists {
+			acc.yamlJobSources[jobName] = importPath
+		}
+	}
+}
+
 func appendYAMLImportServices(acc *importAccumulator, importPath, servicesJSON string) {
 	if servicesJSON == "" || servicesJSON == "{}" {
 		return
@@ -386,14 +420,25 @@ func enqueueNestedImports(frontmatter map[string]any, item importQueueItem, base
 func nestedEntriesFromSpecs(specs []ImportSpec) []nestedImportEntry {
 	nestedImports := make([]nestedImportEntry, 0, len(specs))
 	for _, spec := range specs {
-		nestedImports = append(nestedImports, nestedImportEntry{path: spec.Path, inputs: spec.Inputs})
+		nestedImports = append(nestedImports, nestedImportEntry{path: spec.Path, inputs: spec.Inputs, markdown: spec.Markdown, githubContext: spec.GitHubContext})
 	}
 	return nestedImports
 }
 
 func enqueueNestedImportEntry(entry nestedImportEntry, item importQueueItem, baseDir string, cache *ImportCache, workflowFilePath string, yamlContent string, state *importBFSState) error {
 	nestedImportPath := entry.path
+	nestedSpec := ImportSpec{Path: nestedImportPath, Inputs: entry.inputs, Markdown: entry.markdown, GitHubContext: entry.githubContext}
+	if handled, err := seedGitHubContextImport(nestedSpec, state.acc); handled || err != nil {
+		if err != nil {
+			return fmt.Errorf("nested import '%s' from '%s': %w", nestedImportPath, item.fullPath, err)
+		}
+		return nil
+	}
 	nestedFilePath, nestedSectionName := splitImportPathAndSection(nestedImportPath)
+	markdownOpts, err := mergeMarkdownImportOptions(nestedSectionName, entry.markdown)
+	if err != nil {
+		return fmt.Errorf("nested import '%s' from '%s': %w", nestedImportPath, item.fullPath, err)
+	}
 	resolvedPath, nestedRemoteOrigin, err := resolveNestedImportPathAndOrigin(item, nestedFilePath)
 	if err != nil {
 		return err
@@ -404,7 +449,7 @@ func enqueueNestedImportEntry(entry nestedImportEntry, item importQueueItem, bas
 		return formatNestedResolveError(nestedImportPath, nestedFilePath, item, workflowFilePath, yamlContent, err)
 	}
 	canonicalImportPath := canonicalizeNestedImportPath(nestedImportPath, nestedBaseDir, baseDir, nestedRemoteOrigin, nestedFullPath)
-	return enqueueNestedVisitedPath(state, canonicalImportPath, nestedFullPath, nestedSectionName, baseDir, entry.inputs, nestedRemoteOrigin)
+	return enqueueNestedVisitedPath(state, canonicalImportPath, nestedFullPath, nestedSectionName, baseDir, entry.inputs, markdownOpts, nestedRemoteOrigin)
 }
 
 func resolveNestedImportPathAndOrigin(item importQueueItem, nestedFilePath string) (string, *remoteImportOrigin, error) {
@@ -433,6 +478,9 @@ func resolveRemoteNestedPath(item importQueueItem, nestedFilePath string) (strin
 	basePath = path.Clean(basePath)
 	resolvedPath := fmt.Sprintf("%s/%s/%s/%s@%s",
 		item.remoteOrigin.Owner, item.remoteOrigin.Repo, basePath, cleanPath, item.remoteOrigin.Ref)
+	if item.remoteOrigin.GitSSH != nil {
+		resolvedPath = item.remoteOrigin.GitSSH.specForPath(path.Join(basePath, cleanPath))
+	}
 	nestedRemoteOrigin := parseRemoteOrigin(resolvedPath)
 	importLog.Printf("Resolving nested import as remote workflowspec: %s -> %s (basePath=%s)", nestedFilePath, resolvedPath, basePath)
 	return resolvedPath, nestedRemoteOrigin, nil
@@ -466,12 +514,12 @@ func canonicalizeNestedImportPath(nestedImportPath, nestedBaseDir, baseDir strin
 	return filepath.ToSlash(rel)
 }
 
-func enqueueNestedVisitedPath(state *importBFSState, nestedImportPath, nestedFullPath, nestedSectionName, baseDir string, inputs map[string]any, nestedRemoteOrigin *remoteImportOrigin) error {
+func enqueueNestedVisitedPath(state *importBFSState, nestedImportPath, nestedFullPath, nestedSectionName, baseDir string, inputs map[string]any, markdownOpts MarkdownImportOptions, nestedRemoteOrigin *remoteImportOrigin) error {
 	if !setutil.Contains(state.visited, nestedFullPath) {
 		state.visited[nestedFullPath] = struct{}{}
 		state.visitedInputs[nestedFullPath] = inputs
 		state.queue = append(state.queue, importQueueItem{
-			importPath: nestedImportPath, fullPath: nestedFullPath, sectionName: nestedSectionName, baseDir: baseDir, inputs: inputs, remoteOrigin: nestedRemoteOrigin,
+			importPath: nestedImportPath, fullPath: nestedFullPath, sectionName: nestedSectionName, markdown: markdownOpts, baseDir: baseDir, inputs: inputs, remoteOrigin: nestedRemoteOrigin,
 		})
 		parserLog.Printf("Discovered nested import: %s (queued)", nestedFullPath)
 		return nil
@@ -486,7 +534,8 @@ func enqueueNestedVisitedPath(state *importBFSState, nestedImportPath, nestedFul
 // parseImportSpecsFromArray parses an []any slice into a list of ImportSpec values.
 // Each element must be a string (simple path) or a map with a required "path" or "uses"
 // key and an optional "inputs" or "with" map. The "uses"/"with" form mirrors GitHub Actions
-// reusable workflow syntax and is an alias for "path"/"inputs".
+// reusable workflow syntax and is an alias for "path"/"inputs". Objects may also set
+// "section", "demote-headings", and "strip-links" to reshape the imported markdown.
 func parseImportSpecsFromArray(items []any) ([]ImportSpec, error) {
 	var specs []ImportSpec
 	for _, item := range items {
@@ -522,7 +571,15 @@ func parseImportSpecsFromArray(items []any) ([]ImportSpec, error) {
 			if _, hasIf := importItem["if"]; hasIf {
 				return nil, errors.New("import 'if' is no longer supported; use {{#if ...}}{{#runtime-import? ...}}{{/if}} for experiment-specific prompt imports")
 			}
-			specs = append(specs, ImportSpec{Path: pathStr, Inputs: inputs})
+			markdownOpts, err := parseMarkdownImportOptions(importItem)
+			if err != nil {
+				return nil, err
+			}
+			contextOpts, err := parseGitHubContextImportOptions(importItem)
+			if err != nil {
+				return nil, err
+			}
+			specs = append(specs, ImportSpec{Path: pathStr, Inputs: inputs, Markdown: markdownOpts, GitHubContext: contextOpts})
 		default:
 			return nil, errors.New("import item must be a string or an object with 'path'/'uses' field")
 		}
@@ -8,6 +8,8 @@ import (
 	"strings"
 
 	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/errorutil"
+	"github.com/github/gh-aw/pkg/gitutil"
 
 	"github.com/github/gh-aw/pkg/logger"
 )
@@ -91,6 +93,47 @@ func (c *ImportCache) Get(owner, repo, path, sha string) (string, bool) {
 	return fullCachePath, true
 }
 
+// GetOffline looks up a cached import without contacting the remote. A full commit
+// SHA ref is looked up directly. Branch and tag refs cannot be resolved to a commit
+// offline, so the cache must hold exactly one snapshot of the file for the repository.
+// Misses return an error wrapping errorutil.ErrOffline.
+func (c *ImportCache) GetOffline(owner, repo, path, ref string) (string, error) {
+	if gitutil.IsValidFullSHA(ref) {
+		if cachedPath, found := c.Get(owner, repo, path, ref); found {
+			return cachedPath, nil
+		}
+		return "", fmt.Errorf("%w: %s/%s/%s@%s is not in the import cache (%s); compile once with network access to populate it",
+			errorutil.ErrOffline, owner, repo, path, ref, ImportCacheDir)
+	}
+
+	repoDir := filepath.Join(c.baseDir, ImportCacheDir, owner, repo)
+	entries, err := os.ReadDir(repoDir)
+	if err != nil && !os.IsNotExist(err) {
+		return "", fmt.Errorf("failed to read import cache %s: %w", repoDir, err)
+	}
+	var matches []string
+	for _, entry := range entries {
+		if !entry.IsDir() || !gitutil.IsValidFullSHA(entry.Name()) {
+			continue
+		}
+		if cachedPath, found := c.Get(owner, repo, path, entry.Name()); found {
+			matches = append(matches, cachedPath)
+		}
+	}
+	importCacheLog.Printf("Offline lookup for %s/%s/%s@%s: %d cached snapshot(s)", owner, repo, path, ref, len(matches))
+
+	switch len(matches) {
+	case 1:
+		return matches[0], nil
+	case 0:
+		return "", fmt.Errorf("%w: %s/%s/%s@%s is not in the import cache (%s); compile once with network access to populate it",
+			errorutil.ErrOffline, owner, repo, path, ref, ImportCacheDir)
+	default:
+		return "", fmt.Errorf("%w: the import cache holds %d snapshots of %s/%s/%s and %q cannot be resolved to a commit offline; pin the import to a commit SHA",
+			errorutil.ErrOffline, len(matches), owner, repo, path, ref)
+	}
+}
+
 // Set stores a new cache entry by saving the content to the cache directory
 // sha parameter should be the resolved commit SHA
 func (c *ImportCache) Set(owner, repo, path, sha string, content []byte) (string, error) {
@@ -7,6 +7,8 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/github/gh-aw/pkg/errorutil"
+
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -401,3 +403,75 @@ func TestImportCacheGetDoesNotValidateComponents(t *testing.T) {
 	_, found := cache.Get("../etc", "repo", "test.md", "sha")
 	assert.False(t, found, "Get with path traversal input should return not-found, not panic")
 }
+
+func TestImportCacheGetOffline(t *testing.T) {
+	const (
+		owner = "testowner"
+		repo  = "testrepo"
+		path  = "shared/tools.md"
+		sha1  = "1111111111111111111111111111111111111111"
+		sha2  = "2222222222222222222222222222222222222222"
+	)
+	content := []byte("# Tools\n")
+
+	t.Run("full SHA ref hits the matching snapshot", func(t *testing.T) {
+		cache := NewImportCache(t.TempDir())
+		cachedPath, err := cache.Set(owner, repo, path, sha1, content)
+		require.NoError(t, err, "Set should succeed")
+
+		got, err := cache.GetOffline(owner, repo, path, sha1)
+		require.NoError(t, err, "cached SHA should resolve offline")
+		assert.Equal(t, cachedPath, got, "offline lookup should return the cached file")
+	})
+
+	t.Run("branch ref uses the single cached snapshot", func(t *testing.T) {
+		cache := NewImportCache(t.TempDir())
+		cachedPath, err := cache.Set(owner, repo, path, sha1, content)
+		require.NoError(t, err, "Set should succeed")
+
+		got, err := cache.GetOffline(owner, repo, path, "main")
+		require.NoError(t, err, "single snapshot should resolve offline")
+		assert.Equal(t, cachedPath, got, "offline lookup should return the only snapshot")
+	})
+
+	t.Run("branch ref with several snapshots is ambiguous", func(t *testing.T) {
+		cache := NewImportCache(t.TempDir())
+		_, err := cache.Set(owner, repo, path, sha1, content)
+		require.NoError(t, err, "Set should succeed")
+		_, err = cache.Set(owner, repo, path, sha2, content)
+		require.NoError(t, err, "Set should succeed")
+
+		_, err = cache.GetOffline(owner, repo, path, "main")
+		require.ErrorIs(t, err, errorutil.ErrOffline, "ambiguous lookup should report offline mode")
+		assert.Contains(t, err.Error(), "pin the import to a commit SHA", "error should suggest pinning")
+	})
+
+	t.Run("missing entry reports offline mode", func(t *testing.T) {
+		cache := NewImportCache(t.TempDir())
+
+		_, err := cache.GetOffline(owner, repo, path, sha1)
+		require.ErrorIs(t, err, errorutil.ErrOffline, "missing SHA should report offline mode")
+		_, err = cache.GetOffline(owner, repo, path, "v1")
+		require.ErrorIs(t, err, errorutil.ErrOffline, "missing ref should report offline mode")
+	})
+}
+
+func TestResolveIncludePathOffline(t *testing.T) {
+	SetOfflineMode(true)
+	t.Cleanup(func() { SetOfflineMode(false) })
+
+	repoRoot := t.TempDir()
+	cache := NewImportCache(repoRoot)
+	cachedPath, err := cache.Set("octo", "shared", "workflows/tools.md", "3333333333333333333333333333333333333333", []byte("# Tools\n"))
+	require.NoError(t, err, "Set should succeed")
+
+	got, err := ResolveIncludePath("octo/shared/workflows/tools.md@v1", repoRoot, cache)
+	require.NoError(t, err, "cached workflowspec import should resolve offline")
+	assert.Equal(t, cachedPath, got, "offline resolution should use the import cache")
+
+	_, err = ResolveIncludePath("octo/other/workflows/tools.md@v1", repoRoot, cache)
+	require.ErrorIs(t, err, errorutil.ErrOffline, "uncached workflowspec import should fail offline")
+
+	_, err = DownloadFileFromGitHub(t.Context(), "octo", "shared", "README.md", "main")
+	require.ErrorIs(t, err, errorutil.ErrOffline, "remote downloads should be refused offline")
+}
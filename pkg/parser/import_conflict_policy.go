@@ -0,0 +1,176 @@
+// Package parser provides functions for parsing and processing workflow markdown files.
+// import_conflict_policy.go resolves conflicting tool and permission settings across
+// imported files according to the main workflow's imports-conflict policy, and records
+// which imported file contributed each final setting.
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/sliceutil"
+)
+
+var importConflictLog = logger.New("parser:import_conflict_policy")
+
+// ImportConflictPolicy controls how the compiler handles two imports that define the same
+// tool or permission scope with different values.
+type ImportConflictPolicy string
+
+const (
+	// ImportConflictUnion merges conflicting definitions (allowlists are concatenated and
+	// every imported permission level is validated). This is the default.
+	ImportConflictUnion ImportConflictPolicy = "union"
+	// ImportConflictFirstWins keeps the definition from the first import that defines a
+	// setting and ignores later, different definitions.
+	ImportConflictFirstWins ImportConflictPolicy = "first-wins"
+	// ImportConflictError fails compilation when two imports define a setting differently.
+	ImportConflictError ImportConflictPolicy = "error"
+)
+
+// importContribution is one imported file's top-level tool or permission settings.
+type importContribution struct {
+	importPath string
+	settings   map[string]any
+}
+
+// parseImportConflictPolicy reads imports-conflict from the main workflow frontmatter.
+func parseImportConflictPolicy(frontmatter map[string]any) (ImportConflictPolicy, error) {
+	raw, exists := frontmatter["imports-conflict"]
+	if !exists {
+		return ImportConflictUnion, nil
+	}
+	value, _ := raw.(string)
+	switch policy := ImportConflictPolicy(value); policy {
+	case ImportConflictUnion, ImportConflictFirstWins, ImportConflictError:
+		return policy, nil
+	}
+	return "", fmt.Errorf("invalid imports-conflict value %v: must be one of %q, %q, or %q", raw, ImportConflictError, ImportConflictUnion, ImportConflictFirstWins)
+}
+
+// recordToolContribution records the tools an imported file defines. toolsContent holds
+// one JSON object per line (the file's own tools and those of its @include directives).
+func (acc *importAccumulator) recordToolContribution(importPath, toolsContent string) {
+	merged, err := mergeToolsFromJSON(toolsContent)
+	if err != nil {
+		// Merge errors surface later when the compiler merges the full tool set.
+		importConflictLog.Printf("Skipping tool contribution from %s: %v", importPath, err)
+		return
+	}
+	var tools map[string]any
+	if err := json.Unmarshal([]byte(merged), &tools); err != nil || len(tools) == 0 {
+		return
+	}
+	acc.toolContributions = append(acc.toolContributions, importContribution{importPath: importPath, settings: tools})
+}
+
+// recordPermissionContribution records the permission scopes an imported file requires.
+// Shorthand values such as "read-all" have no scopes to compare and are not recorded.
+func (acc *importAccumulator) recordPermissionContribution(fm map[string]any, importPath string) {
+	permissions, ok := fm["permissions"].(map[string]any)
+	if !ok || len(permissions) == 0 {
+		return
+	}
+	acc.permissionContributions = append(acc.permissionContributions, importContribution{importPath: importPath, settings: permissions})
+}
+
+// resolveImportConflicts applies the imports-conflict policy to the recorded tool and
+// permission contributions. With first-wins, later conflicting definitions are removed
+// from the merged output; with error, the first conflict fails the import.
+func (acc *importAccumulator) resolveImportConflicts() error {
+	tools, toolSources, err := resolveImportContributions("tool", acc.conflictPolicy, acc.toolContributions)
+	if err != nil {
+		return err
+	}
+	permissions, permissionSources, err := resolveImportContributions("permission", acc.conflictPolicy, acc.permissionContributions)
+	if err != nil {
+		return err
+	}
+	acc.toolSources = toolSources
+	acc.permissionSources = permissionSources
+
+	if acc.conflictPolicy != ImportConflictFirstWins {
+		return nil
+	}
+	// Rebuild the merged outputs without the definitions that lost to an earlier import.
+	if len(acc.toolContributions) > 0 {
+		acc.toolsBuilder.Reset()
+		writeContributions(&acc.toolsBuilder, tools)
+	}
+	if len(acc.permissionContributions) > 0 {
+		// Shorthand permissions ("read-all") were never recorded as contributions; keep them.
+		var shorthands []string
+		for line := range strings.SplitSeq(acc.permissionsBuilder.String(), "\n") {
+			if strings.HasPrefix(line, `"`) {
+				shorthands = append(shorthands, line)
+			}
+		}
+		acc.permissionsBuilder.Reset()
+		writeContributions(&acc.permissionsBuilder, permissions)
+		for _, shorthand := range shorthands {
+			acc.permissionsBuilder.WriteString(shorthand + "\n")
+		}
+	}
+	return nil
+}
+
+// resolveImportContributions walks contributions in import order and compares each
+// top-level setting with the first definition seen. It returns the contributions with
+// first-wins losers removed and, for every setting, the imports that contributed to it.
+func resolveImportContributions(kind string, policy ImportConflictPolicy, contributions []importContribution) ([]importContribution, map[string][]string, error) {
+	if len(contributions) == 0 {
+		return nil, nil, nil
+	}
+	type firstDefinition struct {
+		importPath string
+		value      any
+	}
+	first := make(map[string]firstDefinition)
+	sources := make(map[string][]string)
+	resolved := make([]importContribution, 0, len(contributions))
+
+	for _, contribution := range contributions {
+		kept := make(map[string]any, len(contribution.settings))
+		for _, key := range sliceutil.SortedKeys(contribution.settings) {
+			value := contribution.settings[key]
+			earlier, seen := first[key]
+			if !seen {
+				first[key] = firstDefinition{importPath: contribution.importPath, value: value}
+			} else if !areEqual(earlier.value, value) {
+				importConflictLog.Printf("Conflicting %s %q: %s vs %s (policy=%s)", kind, key, earlier.importPath, contribution.importPath, policy)
+				switch policy {
+				case ImportConflictError:
+					return nil, nil, fmt.Errorf("%s %q is defined differently in imports %q and %q; make the definitions match, or set imports-conflict: union or first-wins in the main workflow", kind, key, earlier.importPath, contribution.importPath)
+				case ImportConflictFirstWins:
+					continue
+				}
+			}
+			kept[key] = value
+			if !slices.Contains(sources[key], contribution.importPath) {
+				sources[key] = append(sources[key], contribution.importPath)
+			}
+		}
+		resolved = append(resolved, importContribution{importPath: contribution.importPath, settings: kept})
+	}
+	return resolved, sources, nil
+}
+
+// writeContributions writes each non-empty contribution as one JSON object per line,
+// the format the compiler expects for merged imported tools and permissions.
+func writeContributions(builder *strings.Builder, contributions []importContribution) {
+	for _, contribution := range contributions {
+		if len(contribution.settings) == 0 {
+			continue
+		}
+		data, err := json.Marshal(contribution.settings)
+		if err != nil {
+			importConflictLog.Printf("Failed to marshal settings from %s: %v", contribution.importPath, err)
+			continue
+		}
+		builder.Write(data)
+		builder.WriteString("\n")
+	}
+}
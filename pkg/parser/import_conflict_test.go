@@ -242,3 +242,80 @@ permissions:
 		strings.Contains(err.Error(), "import conflict") || strings.Contains(err.Error(), "leaf.md"),
 		"Error should mention the conflict: %v", err)
 }
+
+// writeConflictingToolImports writes two shared workflows that allow different bash
+// commands and require different contents permission levels.
+func writeConflictingToolImports(t *testing.T, dir string) {
+	t.Helper()
+	lint := `---
+tools:
+  bash: ["make lint"]
+  web-fetch:
+permissions:
+  contents: read
+---
+# Lint
+`
+	release := `---
+tools:
+  bash: ["make release"]
+  web-fetch:
+permissions:
+  contents: write
+---
+# Release
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "lint.md"), []byte(lint), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "release.md"), []byte(release), 0644))
+}
+
+// TestImportConflict_Policies tests the imports-conflict policies for tools and permissions
+// defined differently by two imports.
+func TestImportConflict_Policies(t *testing.T) {
+	tempDir := testutil.TempDir(t, "test-import-conflict-policy-*")
+	writeConflictingToolImports(t, tempDir)
+	mainPath := filepath.Join(tempDir, "main.md")
+
+	process := func(policy string) (*parser.ImportsResult, error) {
+		frontmatter := map[string]any{
+			"on":      "issues",
+			"imports": []any{"lint.md", "release.md"},
+		}
+		if policy != "" {
+			frontmatter["imports-conflict"] = policy
+		}
+		return parser.ProcessImportsFromFrontmatterWithSource(frontmatter, tempDir, nil, mainPath, "")
+	}
+
+	t.Run("union is the default", func(t *testing.T) {
+		result, err := process("")
+		require.NoError(t, err, "union should merge conflicting imports")
+		assert.Contains(t, result.MergedTools, "make lint", "first allowlist should be kept")
+		assert.Contains(t, result.MergedTools, "make release", "second allowlist should be merged")
+		assert.Equal(t, []string{"lint.md", "release.md"}, result.MergedToolSources["bash"], "both imports contributed bash")
+		assert.Equal(t, []string{"lint.md", "release.md"}, result.MergedToolSources["web-fetch"], "identical definitions are not conflicts")
+		assert.Equal(t, []string{"lint.md", "release.md"}, result.MergedPermissionSources["contents"], "both imports require contents")
+	})
+
+	t.Run("first-wins keeps the first definition", func(t *testing.T) {
+		result, err := process("first-wins")
+		require.NoError(t, err, "first-wins should not fail on conflicts")
+		assert.Contains(t, result.MergedTools, "make lint", "first allowlist should be kept")
+		assert.NotContains(t, result.MergedTools, "make release", "later allowlist should be dropped")
+		assert.Equal(t, []string{"lint.md"}, result.MergedToolSources["bash"], "only the first import contributed bash")
+		assert.Equal(t, []string{"lint.md", "release.md"}, result.MergedToolSources["web-fetch"], "identical definitions are kept from both")
+		assert.NotContains(t, result.MergedPermissions, "write", "later permission level should be dropped")
+	})
+
+	t.Run("error names both imports", func(t *testing.T) {
+		_, err := process("error")
+		require.Error(t, err, "error policy should fail on conflicts")
+		assert.Contains(t, err.Error(), `tool "bash" is defined differently in imports "lint.md" and "release.md"`, "error should name the tool and both imports")
+	})
+
+	t.Run("invalid policy", func(t *testing.T) {
+		_, err := process("last-wins")
+		require.Error(t, err, "unknown policy should be rejected")
+		assert.Contains(t, err.Error(), "invalid imports-conflict value", "error should name the field")
+	})
+}
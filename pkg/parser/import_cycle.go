@@ -82,3 +82,26 @@ func dfsForCycle(current, target string, cycleNodes map[string]struct {
 	*path = (*path)[:len(*path)-1]
 	return false
 }
+
+// buildImportCycleEdges locates each import in a cycle path: the import of chain[i+1]
+// in the file chain[i]. Files that cannot be read are reported without a position.
+func buildImportCycleEdges(chain []string, baseDir string, cache *ImportCache) []ImportCycleEdge {
+	if len(chain) < 2 {
+		return nil
+	}
+	edges := make([]ImportCycleEdge, 0, len(chain)-1)
+	for i := range len(chain) - 1 {
+		edge := ImportCycleEdge{ImportPath: chain[i+1], FilePath: chain[i]}
+		fullPath, err := ResolveIncludePath(stripImportSection(chain[i]), baseDir, cache)
+		if err == nil {
+			edge.FilePath = fullPath
+			if content, readErr := readFileFunc(fullPath); readErr == nil {
+				edge.Line, edge.Column = findImportItemLocation(string(content), chain[i+1])
+				edge.Context = importContextLines(string(content), edge.Line)
+			}
+		}
+		importLog.Printf("Cycle edge: %s imports %s at line %d", edge.FilePath, edge.ImportPath, edge.Line)
+		edges = append(edges, edge)
+	}
+	return edges
+}
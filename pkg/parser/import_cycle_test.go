@@ -131,6 +131,12 @@ imports:
 		assert.Equal(t, "c.md", cycleErr.Chain[1], "Second element should be c.md")
 		assert.Equal(t, "d.md", cycleErr.Chain[2], "Third element should be d.md")
 		assert.Equal(t, "b.md", cycleErr.Chain[3], "Cycle should loop back to b.md")
+
+		// Each edge should point at the import line in the importing file
+		require.Len(t, cycleErr.Edges, 3, "Cycle should have one edge per import")
+		assert.Equal(t, parser.ImportCycleEdge{ImportPath: "c.md", FilePath: fileB, Line: 3, Column: 5, Context: []string{"---", "imports:", "  - c.md", "---", "# File B"}}, cycleErr.Edges[0], "First edge should locate the import of c.md in b.md")
+		assert.Equal(t, fileD, cycleErr.Edges[2].FilePath, "Back-edge should be in d.md")
+		assert.Equal(t, "b.md", cycleErr.Edges[2].ImportPath, "Back-edge should import b.md")
 	}
 
 	// Verify formatted error message contains the full chain
@@ -143,6 +149,9 @@ imports:
 	assert.Contains(t, errMsg, "c.md", "Error should mention c.md")
 	assert.Contains(t, errMsg, "d.md", "Error should mention d.md")
 	assert.Contains(t, errMsg, "cycles back", "Error should mention the back-edge")
+	assert.Contains(t, errMsg, "b.md:3:5: info: imports c.md", "Error should locate the import of c.md")
+	assert.Contains(t, errMsg, "c.md:3:5: info: imports d.md", "Error should locate the import of d.md")
+	assert.Contains(t, errMsg, "d.md:3:5: error: imports b.md, which closes the cycle", "Error should locate the back-edge")
 }
 
 // TestImportCycleDetection_Deterministic verifies that cycle detection is deterministic
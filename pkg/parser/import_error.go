@@ -22,8 +22,18 @@ type ImportError struct {
 
 // ImportCycleError represents a circular import dependency
 type ImportCycleError struct {
-	Chain        []string // Full import chain showing the cycle (e.g., ["a.md", "b.md", "c.md", "d.md", "b.md"])
-	WorkflowFile string   // The main workflow file being compiled
+	Chain        []string          // Full import chain showing the cycle (e.g., ["a.md", "b.md", "c.md", "d.md", "b.md"])
+	Edges        []ImportCycleEdge // Source location of each import in the chain (Chain[i] imports Chain[i+1])
+	WorkflowFile string            // The main workflow file being compiled
+}
+
+// ImportCycleEdge is a single import in an import cycle
+type ImportCycleEdge struct {
+	ImportPath string   // The imported path as written in the importing file (e.g., "c.md")
+	FilePath   string   // The file containing the import
+	Line       int      // Line number where the import is defined (0 if unknown)
+	Column     int      // Column number where the import is defined
+	Context    []string // Source lines around the import
 }
 
 // Error returns the error message for ImportCycleError
@@ -60,6 +70,24 @@ func FormatImportCycleError(err *ImportCycleError) error {
 		}
 	}
 
+	// Show where each import in the cycle is defined, closing with the back-edge
+	if len(err.Edges) > 0 {
+		messageBuilder.WriteString("\nImports in the cycle:\n\n")
+		for i, edge := range err.Edges {
+			compilerErr := console.CompilerError{
+				Position: console.ErrorPosition{File: edge.FilePath, Line: edge.Line, Column: edge.Column},
+				Type:     "info",
+				Message:  "imports " + edge.ImportPath,
+				Context:  edge.Context,
+			}
+			if i == len(err.Edges)-1 {
+				compilerErr.Type = "error"
+				compilerErr.Message = fmt.Sprintf("imports %s, which closes the cycle", edge.ImportPath)
+			}
+			messageBuilder.WriteString(console.FormatError(compilerErr))
+		}
+	}
+
 	messageBuilder.WriteString("\nTo fix this issue:\n")
 	messageBuilder.WriteString("1. Review the import dependencies in the files listed above\n")
 	messageBuilder.WriteString("2. Remove one of the imports to break the cycle\n")
@@ -95,18 +123,7 @@ func NewFormattedParserError(formatted string) *FormattedParserError {
 func FormatImportError(err *ImportError, yamlContent string) error {
 	importErrorLog.Printf("Formatting import error: path=%s, file=%s, line=%d", err.ImportPath, err.FilePath, err.Line)
 
-	lines := strings.Split(yamlContent, "\n")
-
-	// Create context lines around the error
-	var context []string
-	startLine := max(1, err.Line-2)
-	endLine := min(len(lines), err.Line+2)
-
-	for i := startLine; i <= endLine; i++ {
-		if i-1 < len(lines) {
-			context = append(context, lines[i-1])
-		}
-	}
+	context := importContextLines(yamlContent, err.Line)
 
 	// Determine the error message based on the cause
 	message := "failed to resolve import"
@@ -144,6 +161,21 @@ func FormatImportError(err *ImportError, yamlContent string) error {
 	return &FormattedParserError{formatted: formattedErr, cause: err.Cause}
 }
 
+// importContextLines returns the source lines around line for error context.
+func importContextLines(content string, line int) []string {
+	lines := strings.Split(content, "\n")
+	var context []string
+	startLine := max(1, line-2)
+	endLine := min(len(lines), line+2)
+
+	for i := startLine; i <= endLine; i++ {
+		if i-1 < len(lines) {
+			context = append(context, lines[i-1])
+		}
+	}
+	return context
+}
+
 // buildImportErrorHint returns a tailored fix hint for an import error based on its message and path.
 func buildImportErrorHint(message, importPath string) string {
 	switch {
@@ -162,52 +194,36 @@ func buildImportErrorHint(message, importPath string) string {
 
 // findImportsFieldLocation finds the line and column number of the imports field in YAML content
 func findImportsFieldLocation(yamlContent string) (line int, column int) {
-	lines := strings.Split(yamlContent, "\n")
-	for i, line := range lines {
-		trimmed := strings.TrimSpace(line)
-		// Look for "imports:" at the start of a line (accounting for indentation)
-		if strings.HasPrefix(trimmed, "imports:") {
-			// Find the column where "imports:" starts
-			col := strings.Index(line, "imports:") + 1 // +1 for 1-based indexing
-			importErrorLog.Printf("Found imports field at line=%d, col=%d", i+1, col)
-			return i + 1, col // +1 for 1-based line indexing
-		}
+	if loc, ok := LocateFrontmatterFields(yamlContent, 1)["imports"]; ok {
+		importErrorLog.Printf("Found imports field at line=%d, col=%d", loc.Line, loc.Column)
+		return loc.Line, loc.Column
 	}
 	// Default to line 1, column 1 if not found
 	importErrorLog.Print("imports field not found in YAML content, defaulting to line=1, col=1")
 	return 1, 1
 }
 
-// findImportItemLocation finds the line and column number of a specific import item in YAML content
+// findImportItemLocation finds the line and column number of a specific import item in YAML content.
+// It searches the located fields under imports (list items and, for object-form entries, their
+// nested keys) for the first line that mentions importPath.
 func findImportItemLocation(yamlContent string, importPath string) (line int, column int) {
 	importErrorLog.Printf("Locating import item in YAML: path=%s", importPath)
 	lines := strings.Split(yamlContent, "\n")
-	inImportsSection := false
 
-	for i, line := range lines {
-		trimmed := strings.TrimSpace(line)
-
-		// Check if we're entering the imports section
-		if strings.HasPrefix(trimmed, "imports:") {
-			inImportsSection = true
+	for fieldPath, loc := range LocateFrontmatterFields(yamlContent, 1) {
+		if !strings.HasPrefix(fieldPath, "imports[") && !strings.HasPrefix(fieldPath, "imports.") {
 			continue
 		}
-
-		// If we're in the imports section and find a line with our import path
-		if inImportsSection {
-			// Check if this line exits the imports section (new top-level key)
-			if line != "" && line[0] != ' ' && line[0] != '-' && line[0] != '\t' {
-				break
-			}
-
-			// Check for the import path in this line
-			if strings.Contains(line, importPath) {
-				// Find the column where the import path starts
-				col := strings.Index(line, importPath) + 1 // +1 for 1-based indexing
-				importErrorLog.Printf("Located import item at line=%d, col=%d", i+1, col)
-				return i + 1, col // +1 for 1-based line indexing
-			}
+		if loc.Line < 1 || loc.Line > len(lines) || (line != 0 && loc.Line >= line) {
+			continue
 		}
+		if col := strings.Index(lines[loc.Line-1], importPath); col >= 0 {
+			line, column = loc.Line, col+1 // +1 for 1-based indexing
+		}
+	}
+	if line != 0 {
+		importErrorLog.Printf("Located import item at line=%d, col=%d", line, column)
+		return line, column
 	}
 
 	// Fallback to imports field location
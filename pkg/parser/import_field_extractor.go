@@ -36,6 +36,7 @@ type importAccumulator struct {
 	jobsBuilder              strings.Builder   // Jobs from imported YAML workflows
 	envBuilder               strings.Builder   // env vars from imported workflows (JSON, one object per line)
 	envSources               map[string]string // env var name → source import path (for conflict detection and header listing)
+	yamlJobSources           map[string]string // job name → YAML workflow import path that first defined it
 	observabilityConfigs     []string          // observability config JSON blobs from all imports (merged into endpoint array)
 	engines                  []string
 	safeOutputs              []string
@@ -61,6 +62,7 @@ type importAccumulator struct {
 	agentFile                string
 	agentImportSpec          string
 	repositoryImports        []string
+	githubContextImports     []GitHubContextImport
 	importInputs             map[string]any
 	// First on.github-token / on.github-app found across all imported files (first-wins strategy)
 	activationGitHubToken string
@@ -90,6 +92,16 @@ type importAccumulator struct {
 	excludedEnvSet map[string]bool
 	// Best-effort sub-agent frontmatter warnings collected during BFS traversal.
 	warnings []string
+	// Per-import tool and permission settings, resolved with the main workflow's
+	// imports-conflict policy once traversal completes.
+	conflictPolicy          ImportConflictPolicy
+	toolContributions       []importContribution
+	permissionContributions []importContribution
+	toolSources             map[string][]string // tool name → imports that contributed it
+	permissionSources       map[string][]string // permission scope → imports that require it
+	// Every file read during traversal with its content hash (lock file provenance).
+	importSources    []ImportSourceFile
+	importSourcesSet map[string]bool
 }
 
 const (
@@ -108,8 +120,11 @@ func newImportAccumulator() *importAccumulator {
 		skipBotsSet:           make(map[string]bool),
 		importInputs:          make(map[string]any),
 		envSources:            make(map[string]string),
+		yamlJobSources:        make(map[string]string),
 		sandboxAgentMountsSet: make(map[string]bool),
 		excludedEnvSet:        make(map[string]bool),
+		importSourcesSet:      make(map[string]bool),
+		conflictPolicy:        ImportConflictUnion,
 	}
 }
 
@@ -127,13 +142,11 @@ func (acc *importAccumulator) extractAllImportFields(content []byte, item import
 		return err
 	}
 
-	// Phase 2: Validate 'with'/'inputs' values against the imported workflow's 'import-schema'.
-	// Always use the ORIGINAL (unsubstituted) frontmatter for schema lookup so the import-schema
-	// declaration itself is not affected by expression substitution.
-	if _, hasSchema := origFm["import-schema"]; hasSchema {
-		if err := validateWithImportSchema(item.inputs, origFm, item.importPath); err != nil {
-			return err
-		}
+	// Phase 2: Validate 'with'/'inputs' values against the imported workflow's 'import-schema'
+	// (or 'inputs') declaration. Always use the ORIGINAL (unsubstituted) frontmatter for schema
+	// lookup so the declaration itself is not affected by expression substitution.
+	if err := validateWithImportSchema(item.inputs, origFm, item.importPath); err != nil {
+		return err
 	}
 
 	// Phase 3: Extract engine configuration (id, runtime, mcp timeouts, model preference).
@@ -142,6 +155,8 @@ func (acc *importAccumulator) extractAllImportFields(content []byte, item import
 	// Phase 4: Extract scalar and builder-based configuration fields.
 	acc.extractConfigFields(fm, item.fullPath)
 
+	acc.recordPermissionContribution(fm, item.importPath)
+
 	// Phase 5: Extract activation, authentication, and access-control fields.
 	acc.extractActivationFields(fm, item)
 
@@ -179,8 +194,9 @@ func (acc *importAccumulator) prepareFrontmatter(content []byte, item importQueu
 		return nil, nil, err
 	}
 	acc.toolsBuilder.WriteString(toolsContent + "\n")
+	acc.recordToolContribution(item.importPath, toolsContent)
 	importRelPath := computeImportRelPath(item.fullPath, item.importPath)
-	if err := acc.trackRuntimeOrInlineImport(item.fullPath, importRelPath, rawContent, wasSubstituted); err != nil {
+	if err := acc.trackRuntimeOrInlineImport(item.fullPath, importRelPath, rawContent, wasSubstituted, item.markdown); err != nil {
 		return nil, nil, err
 	}
 
@@ -247,21 +263,34 @@ func (acc *importAccumulator) extractToolsContent(rawContent string, item import
 	return toolsContent, nil
 }
 
-func (acc *importAccumulator) trackRuntimeOrInlineImport(fullPath, importRelPath, rawContent string, wasSubstituted bool) error {
-	if !wasSubstituted && !strings.HasPrefix(importRelPath, BuiltinPathPrefix) {
+// trackRuntimeOrInlineImport records how an imported file's markdown reaches the prompt.
+// Imports are runtime-imported unless their inputs were substituted or a section or
+// heading/link transform was requested, in which case the markdown is inlined at compile time.
+// Aliased imports that resolve outside .github are inlined too, since the runtime checkout
+// only contains the .github and .agents folders.
+func (acc *importAccumulator) trackRuntimeOrInlineImport(fullPath, importRelPath, rawContent string, wasSubstituted bool, markdownOpts MarkdownImportOptions) error {
+	needsInline := wasSubstituted || !markdownOpts.IsZero() || IsImportAlias(importRelPath)
+	if !needsInline && !strings.HasPrefix(importRelPath, BuiltinPathPrefix) {
 		acc.importPaths = append(acc.importPaths, importRelPath)
 		acc.promptImports = append(acc.promptImports, PromptImportEntry{ImportPath: importRelPath})
 		parserLog.Printf("Added import path for runtime-import: %s", importRelPath)
 		return nil
 	}
-	if !wasSubstituted {
+	if !needsInline {
 		return nil
 	}
-	parserLog.Printf("Import %s has substituted inputs - will be inlined for compile-time substitution", importRelPath)
+	parserLog.Printf("Import %s will be inlined at compile time (substituted=%t, section=%q, alias=%t)", importRelPath, wasSubstituted, markdownOpts.Section, IsImportAlias(importRelPath))
 	markdownContent, err := ExtractMarkdownContent(rawContent)
 	if err != nil {
 		return fmt.Errorf("failed to extract markdown from imported file '%s': %w", fullPath, err)
 	}
+	if !markdownOpts.IsZero() {
+		markdownContent, err = ApplyMarkdownImportOptions(markdownContent, markdownOpts)
+		if err != nil {
+			return fmt.Errorf("failed to extract section '%s' from imported file '%s': %w", markdownOpts.Section, fullPath, err)
+		}
+		markdownContent = strings.Trim(markdownContent, "\n") + "\n"
+	}
 	appendMarkdownWithSeparator(&acc.markdownBuilder, markdownContent)
 	acc.promptImports = append(acc.promptImports, PromptImportEntry{Markdown: markdownContent})
 	return nil
@@ -909,15 +938,20 @@ func (acc *importAccumulator) toImportsResult(topologicalOrder []string) *Import
 		MergedJobs:                    acc.jobsBuilder.String(),
 		MergedEnv:                     acc.envBuilder.String(),
 		MergedEnvSources:              acc.envSources,
+		YAMLImportJobSources:          acc.yamlJobSources,
+		MergedToolSources:             acc.toolSources,
+		MergedPermissionSources:       acc.permissionSources,
 		MergedFeatures:                acc.features,
 		MergedModels:                  acc.models,
 		MergedModelPolicies:           acc.modelPolicies,
 		MergedModelCosts:              acc.modelCosts,
 		MergedObservability:           mergeObservabilityConfigs(acc.observabilityConfigs),
 		ImportedFiles:                 topologicalOrder,
+		ImportSources:                 acc.importSources,
 		AgentFile:                     acc.agentFile,
 		AgentImportSpec:               acc.agentImportSpec,
 		RepositoryImports:             acc.repositoryImports,
+		GitHubContextImports:          acc.githubContextImports,
 		ImportInputs:                  acc.importInputs,
 		MergedActivationGitHubToken:   acc.activationGitHubToken,
 		MergedActivationGitHubApp:     acc.activationGitHubApp,
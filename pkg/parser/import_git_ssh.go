@@ -0,0 +1,103 @@
+// import_git_ssh.go parses git+ssh:// import sources.
+//
+// Some organizations keep shared workflow fragments in repositories that are only
+// reachable over SSH (for example GHES instances that do not accept HTTPS token auth).
+// These imports use the form:
+//
+//	git+ssh://[user@]host/owner/repo/path/to/file.md[@ref][#Section]
+//
+// The host may be omitted (git+ssh:///owner/repo/path.md) to use the GitHub host
+// configured for gh (GH_HOST and friends). The user defaults to "git" and the ref
+// defaults to "main".
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var importGitSSHLog = logger.New("parser:import_git_ssh")
+
+// GitSSHImportPrefix is the scheme prefix that marks an import as fetched over git+ssh.
+const GitSSHImportPrefix = "git+ssh://"
+
+// gitSSHImportSpec is a parsed git+ssh:// import source.
+type gitSSHImportSpec struct {
+	User     string // SSH user (defaults to "git")
+	Host     string // SSH host, optionally with :port; empty means the configured GitHub host
+	Owner    string // Repository owner
+	Repo     string // Repository name (without .git suffix)
+	FilePath string // File path within the repository
+	Ref      string // Git ref - branch, tag, or SHA
+}
+
+// IsGitSSHImportSpec reports whether an import path uses the git+ssh:// scheme.
+func IsGitSSHImportSpec(importPath string) bool {
+	return strings.HasPrefix(importPath, GitSSHImportPrefix)
+}
+
+// parseGitSSHImportSpec parses a git+ssh:// import path. Section references (#Section)
+// are ignored; callers split them off before resolving the file.
+func parseGitSSHImportSpec(spec string) (*gitSSHImportSpec, error) {
+	rest, ok := strings.CutPrefix(spec, GitSSHImportPrefix)
+	if !ok {
+		return nil, fmt.Errorf("not a git+ssh import: %s", spec)
+	}
+	if before, _, found := strings.Cut(rest, "#"); found {
+		rest = before
+	}
+
+	authority, repoPath, found := strings.Cut(rest, "/")
+	if !found {
+		return nil, fmt.Errorf("invalid git+ssh import %q: expected git+ssh://[user@]host/owner/repo/path[@ref]", spec)
+	}
+
+	parsed := &gitSSHImportSpec{User: "git", Ref: "main"}
+	if user, host, hasUser := strings.Cut(authority, "@"); hasUser {
+		if user == "" {
+			return nil, fmt.Errorf("invalid git+ssh import %q: empty user before '@'", spec)
+		}
+		parsed.User = user
+		authority = host
+	}
+	parsed.Host = authority
+
+	// The ref follows the first '@' in the repository path, matching the workflowspec format.
+	if before, ref, hasRef := strings.Cut(repoPath, "@"); hasRef {
+		if ref == "" {
+			return nil, fmt.Errorf("invalid git+ssh import %q: empty ref after '@'", spec)
+		}
+		repoPath = before
+		parsed.Ref = ref
+	}
+
+	parts := strings.Split(repoPath, "/")
+	if len(parts) < 3 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("invalid git+ssh import %q: expected owner/repo/path after the host", spec)
+	}
+	parsed.Owner = parts[0]
+	parsed.Repo = strings.TrimSuffix(parts[1], ".git")
+	parsed.FilePath = path.Clean(strings.Join(parts[2:], "/"))
+	if parsed.FilePath == "." || parsed.FilePath == ".." || strings.HasPrefix(parsed.FilePath, "../") {
+		return nil, errors.New("invalid git+ssh import: file path must stay within the repository")
+	}
+
+	importGitSSHLog.Printf("Parsed git+ssh import: user=%s, host=%s, repo=%s/%s, file=%s, ref=%s",
+		parsed.User, parsed.Host, parsed.Owner, parsed.Repo, parsed.FilePath, parsed.Ref)
+	return parsed, nil
+}
+
+// authority returns the user@host part of the spec as written (host may be empty).
+func (s *gitSSHImportSpec) authority() string {
+	return s.User + "@" + s.Host
+}
+
+// specForPath builds a git+ssh:// import path for another file in the same repository
+// and ref. It is used to resolve nested relative imports of a git+ssh import.
+func (s *gitSSHImportSpec) specForPath(filePath string) string {
+	return fmt.Sprintf("%s%s/%s/%s/%s@%s", GitSSHImportPrefix, s.authority(), s.Owner, s.Repo, filePath, s.Ref)
+}
@@ -0,0 +1,129 @@
+//go:build !integration
+
+package parser
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGitSSHImportSpec(t *testing.T) {
+	tests := []struct {
+		name        string
+		spec        string
+		expected    *gitSSHImportSpec
+		errContains string
+	}{
+		{
+			name: "full spec with user, ref and section",
+			spec: "git+ssh://git@ghes.example.com/platform/shared.git/workflows/shared/tools.md@v1.2.0#Tools",
+			expected: &gitSSHImportSpec{
+				User: "git", Host: "ghes.example.com", Owner: "platform", Repo: "shared",
+				FilePath: "workflows/shared/tools.md", Ref: "v1.2.0",
+			},
+		},
+		{
+			name: "defaults user and ref",
+			spec: "git+ssh://ghes.example.com:2222/platform/shared/tools.md",
+			expected: &gitSSHImportSpec{
+				User: "git", Host: "ghes.example.com:2222", Owner: "platform", Repo: "shared",
+				FilePath: "tools.md", Ref: "main",
+			},
+		},
+		{
+			name: "empty host uses configured GitHub host",
+			spec: "git+ssh:///platform/shared/tools.md@main",
+			expected: &gitSSHImportSpec{
+				User: "git", Host: "", Owner: "platform", Repo: "shared",
+				FilePath: "tools.md", Ref: "main",
+			},
+		},
+		{
+			name:        "missing file path",
+			spec:        "git+ssh://git@ghes.example.com/platform/shared",
+			errContains: "expected owner/repo/path",
+		},
+		{
+			name:        "path escaping the repository",
+			spec:        "git+ssh://git@ghes.example.com/platform/shared/../../etc/passwd",
+			errContains: "must stay within the repository",
+		},
+		{
+			name:        "empty ref",
+			spec:        "git+ssh://git@ghes.example.com/platform/shared/tools.md@",
+			errContains: "empty ref",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := parseGitSSHImportSpec(tt.spec)
+			if tt.errContains != "" {
+				require.Error(t, err, "spec should be rejected")
+				assert.Contains(t, err.Error(), tt.errContains)
+				return
+			}
+			require.NoError(t, err, "spec should parse")
+			assert.Equal(t, tt.expected, parsed)
+		})
+	}
+}
+
+func TestGitSSHImportSpecURLs(t *testing.T) {
+	t.Setenv("GITHUB_SERVER_URL", "")
+	t.Setenv("GITHUB_ENTERPRISE_HOST", "")
+	t.Setenv("GITHUB_HOST", "")
+	t.Setenv("GH_HOST", "ghes.example.com")
+
+	parsed, err := parseGitSSHImportSpec("git+ssh:///platform/shared/workflows/tools.md@v1")
+	require.NoError(t, err)
+	assert.Equal(t, "ssh://git@ghes.example.com/platform/shared.git", parsed.repoURL(), "empty host should resolve to the gh host")
+	assert.Equal(t, "git+ssh://git@/platform/shared/workflows/other.md@v1", parsed.specForPath("workflows/other.md"))
+
+	origin := parseRemoteOrigin("git+ssh://deploy@git.example.com/platform/shared/workflows/tools.md@v1")
+	require.NotNil(t, origin, "git+ssh imports should track their remote origin")
+	assert.Equal(t, "workflows", origin.BasePath)
+	require.NotNil(t, origin.GitSSH)
+	assert.Equal(t, "ssh://deploy@git.example.com/platform/shared.git", origin.GitSSH.repoURL())
+}
+
+func TestDownloadIncludeFromGitSSHSpec(t *testing.T) {
+	const sha = "0123456789abcdef0123456789abcdef01234567"
+	origResolve, origRead := resolveGitSSHRefToSHAFunc, readGitSSHFileFunc
+	t.Cleanup(func() {
+		resolveGitSSHRefToSHAFunc, readGitSSHFileFunc = origResolve, origRead
+	})
+
+	var resolvedURL, readRef string
+	reads := 0
+	resolveGitSSHRefToSHAFunc = func(_ context.Context, repoURL, ref string) (string, error) {
+		resolvedURL = repoURL
+		return sha, nil
+	}
+	readGitSSHFileFunc = func(_ context.Context, repoURL, filePath, ref string) ([]byte, error) {
+		reads++
+		readRef = ref
+		return []byte("# Shared tools\n"), nil
+	}
+
+	cache := NewImportCache(t.TempDir())
+	spec := "git+ssh://git@ghes.example.com/platform/shared/workflows/tools.md@main"
+
+	cachedPath, err := ResolveIncludePath(spec, t.TempDir(), cache)
+	require.NoError(t, err, "git+ssh import should resolve")
+	assert.Equal(t, "ssh://git@ghes.example.com/platform/shared.git", resolvedURL)
+	assert.Equal(t, sha, readRef, "file should be read at the pinned commit")
+	assert.Contains(t, filepath.ToSlash(cachedPath), "platform/shared/"+sha, "cache should be keyed by commit SHA")
+	content, err := os.ReadFile(cachedPath)
+	require.NoError(t, err)
+	assert.Equal(t, "# Shared tools\n", string(content))
+
+	_, err = ResolveIncludePath(spec, t.TempDir(), cache)
+	require.NoError(t, err)
+	assert.Equal(t, 1, reads, "second resolve should be served from the cache")
+}
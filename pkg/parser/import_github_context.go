@@ -0,0 +1,153 @@
+// Package parser provides functions for parsing and processing workflow markdown files.
+// import_github_context.go recognizes imports of GitHub issues and discussions
+// ("issue:123", "discussion:88", or a github.com URL). Their content is not read at
+// compile time; the compiler emits a step that fetches it into the prompt at runtime.
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var githubContextImportLog = logger.New("parser:import_github_context")
+
+// GitHub context import kinds.
+const (
+	GitHubContextKindIssue      = "issue"
+	GitHubContextKindDiscussion = "discussion"
+)
+
+// githubContextURLPattern matches https://github.com/owner/repo/issues/N and
+// https://github.com/owner/repo/discussions/N, with an optional trailing fragment.
+var githubContextURLPattern = regexp.MustCompile(`^https://github\.com/([A-Za-z0-9-]+)/([A-Za-z0-9_.-]+)/(issues|discussions)/([0-9]+)/?(?:#.*)?$`)
+
+// GitHubContextImport is an import of a GitHub issue or discussion whose body and
+// comments are fetched at runtime and added to the prompt as context.
+type GitHubContextImport struct {
+	Kind     string `json:"kind"`               // GitHubContextKindIssue or GitHubContextKindDiscussion
+	Repo     string `json:"repo,omitempty"`     // "owner/repo" when imported by URL; empty means the current repository
+	Number   int    `json:"number"`             // Issue or discussion number
+	MaxSize  int    `json:"max_size,omitempty"` // Maximum characters of fetched content; 0 uses the runtime default
+	Comments bool   `json:"comments"`           // Whether comments are included after the body
+}
+
+// Key returns the identifier used for the import's prompt marker and for de-duplication
+// (e.g. "issue:123" or "discussion:octo/repo#88").
+func (g GitHubContextImport) Key() string {
+	if g.Repo != "" {
+		return fmt.Sprintf("%s:%s#%d", g.Kind, g.Repo, g.Number)
+	}
+	return fmt.Sprintf("%s:%d", g.Kind, g.Number)
+}
+
+// GitHubContextImportOptions holds the object-form import fields that only apply to
+// issue and discussion imports ('max-size' and 'comments').
+type GitHubContextImportOptions struct {
+	MaxSize         int  // Maximum characters of fetched content ('max-size'); 0 uses the runtime default
+	ExcludeComments bool // Set when 'comments: false' is given
+}
+
+// IsZero reports whether no GitHub context option is set.
+func (o GitHubContextImportOptions) IsZero() bool {
+	return o == GitHubContextImportOptions{}
+}
+
+// parseGitHubContextImportOptions reads the object-form import fields 'max-size' and 'comments'.
+func parseGitHubContextImportOptions(item map[string]any) (GitHubContextImportOptions, error) {
+	var opts GitHubContextImportOptions
+	if v, ok := item["max-size"]; ok {
+		n, isInt := toNonNegativeInt(v)
+		if !isInt || n == 0 {
+			return opts, errors.New("import 'max-size' must be a positive integer")
+		}
+		opts.MaxSize = n
+	}
+	if v, ok := item["comments"]; ok {
+		include, isBool := v.(bool)
+		if !isBool {
+			return opts, errors.New("import 'comments' must be a boolean")
+		}
+		opts.ExcludeComments = !include
+	}
+	return opts, nil
+}
+
+// parseGitHubContextImport parses an import path of the form "issue:N", "discussion:N",
+// or a github.com issue or discussion URL. It returns false when importPath is not a
+// GitHub context import, and an error when it uses the prefix but is malformed.
+func parseGitHubContextImport(importPath string, opts GitHubContextImportOptions) (*GitHubContextImport, bool, error) {
+	ref := &GitHubContextImport{MaxSize: opts.MaxSize, Comments: !opts.ExcludeComments}
+
+	if m := githubContextURLPattern.FindStringSubmatch(importPath); m != nil {
+		number, err := strconv.Atoi(m[4])
+		if err != nil || number <= 0 {
+			return nil, true, fmt.Errorf("invalid GitHub context import '%s': the number must be a positive integer", importPath)
+		}
+		ref.Repo = m[1] + "/" + m[2]
+		ref.Kind = GitHubContextKindIssue
+		if m[3] == "discussions" {
+			ref.Kind = GitHubContextKindDiscussion
+		}
+		ref.Number = number
+		githubContextImportLog.Printf("Parsed GitHub context import URL %s as %s", importPath, ref.Key())
+		return ref, true, nil
+	}
+
+	kind, value, found := strings.Cut(importPath, ":")
+	if !found || (kind != GitHubContextKindIssue && kind != GitHubContextKindDiscussion) {
+		return nil, false, nil
+	}
+	number, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || number <= 0 {
+		return nil, true, fmt.Errorf("invalid %s import '%s': expected %s:<number> or a GitHub %s URL", kind, importPath, kind, kind)
+	}
+	ref.Kind = kind
+	ref.Number = number
+	githubContextImportLog.Printf("Parsed GitHub context import %s", ref.Key())
+	return ref, true, nil
+}
+
+// IsGitHubContextImport reports whether importPath names a GitHub issue or discussion
+// rather than a file.
+func IsGitHubContextImport(importPath string) bool {
+	_, ok, _ := parseGitHubContextImport(importPath, GitHubContextImportOptions{})
+	return ok
+}
+
+// addGitHubContextImport records a GitHub context import, keeping the first
+// occurrence when the same issue or discussion is imported more than once.
+func (acc *importAccumulator) addGitHubContextImport(ref *GitHubContextImport) {
+	key := ref.Key()
+	for _, existing := range acc.githubContextImports {
+		if existing.Key() == key {
+			githubContextImportLog.Printf("Skipping duplicate GitHub context import: %s", key)
+			return
+		}
+	}
+	acc.githubContextImports = append(acc.githubContextImports, *ref)
+}
+
+// seedGitHubContextImport handles an import spec that names an issue or discussion.
+// It returns false when the spec is a regular file import.
+func seedGitHubContextImport(importSpec ImportSpec, acc *importAccumulator) (bool, error) {
+	ref, ok, err := parseGitHubContextImport(importSpec.Path, importSpec.GitHubContext)
+	if !ok {
+		if !importSpec.GitHubContext.IsZero() {
+			return false, fmt.Errorf("import '%s': 'max-size' and 'comments' are only supported for issue and discussion imports", importSpec.Path)
+		}
+		return false, nil
+	}
+	if err != nil {
+		return true, err
+	}
+	if len(importSpec.Inputs) > 0 || !importSpec.Markdown.IsZero() {
+		return true, fmt.Errorf("import '%s': issue and discussion imports do not support inputs or markdown options", importSpec.Path)
+	}
+	acc.addGitHubContextImport(ref)
+	return true, nil
+}
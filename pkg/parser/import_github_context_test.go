@@ -0,0 +1,135 @@
+//go:build !integration
+
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGitHubContextImport(t *testing.T) {
+	tests := []struct {
+		name       string
+		importPath string
+		opts       GitHubContextImportOptions
+		want       *GitHubContextImport
+		wantOK     bool
+		wantErr    string
+	}{
+		{
+			name:       "issue number",
+			importPath: "issue:123",
+			want:       &GitHubContextImport{Kind: "issue", Number: 123, Comments: true},
+			wantOK:     true,
+		},
+		{
+			name:       "discussion number with options",
+			importPath: "discussion:88",
+			opts:       GitHubContextImportOptions{MaxSize: 5000, ExcludeComments: true},
+			want:       &GitHubContextImport{Kind: "discussion", Number: 88, MaxSize: 5000},
+			wantOK:     true,
+		},
+		{
+			name:       "issue URL",
+			importPath: "https://github.com/octo/repo/issues/42",
+			want:       &GitHubContextImport{Kind: "issue", Repo: "octo/repo", Number: 42, Comments: true},
+			wantOK:     true,
+		},
+		{
+			name:       "discussion URL with fragment",
+			importPath: "https://github.com/octo/repo/discussions/7#discussioncomment-1",
+			want:       &GitHubContextImport{Kind: "discussion", Repo: "octo/repo", Number: 7, Comments: true},
+			wantOK:     true,
+		},
+		{
+			name:       "file path",
+			importPath: "shared/issue-triage.md",
+		},
+		{
+			name:       "workflowspec",
+			importPath: "octo/repo/shared/tools.md@v1",
+		},
+		{
+			name:       "non-numeric issue",
+			importPath: "issue:abc",
+			wantOK:     true,
+			wantErr:    "expected issue:<number>",
+		},
+		{
+			name:       "zero discussion",
+			importPath: "discussion:0",
+			wantOK:     true,
+			wantErr:    "expected discussion:<number>",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok, err := parseGitHubContextImport(tt.importPath, tt.opts)
+			assert.Equal(t, tt.wantOK, ok, "recognized as GitHub context import")
+			if tt.wantErr != "" {
+				require.Error(t, err, "import should be invalid")
+				assert.Contains(t, err.Error(), tt.wantErr, "error message")
+				return
+			}
+			require.NoError(t, err, "import should be valid")
+			assert.Equal(t, tt.want, got, "parsed import")
+		})
+	}
+}
+
+func TestGitHubContextImportKey(t *testing.T) {
+	assert.Equal(t, "issue:123", GitHubContextImport{Kind: "issue", Number: 123}.Key(), "current-repo key")
+	assert.Equal(t, "discussion:octo/repo#7", GitHubContextImport{Kind: "discussion", Repo: "octo/repo", Number: 7}.Key(), "cross-repo key")
+}
+
+func TestProcessImportsGitHubContext(t *testing.T) {
+	frontmatter := map[string]any{
+		"imports": []any{
+			"issue:12",
+			map[string]any{"uses": "https://github.com/octo/repo/discussions/3", "max-size": 5000, "comments": false},
+			"issue:12",
+		},
+	}
+
+	result, err := ProcessImportsFromFrontmatterWithSource(frontmatter, t.TempDir(), nil, "", "")
+	require.NoError(t, err, "imports should be processed")
+	assert.Equal(t, []GitHubContextImport{
+		{Kind: "issue", Number: 12, Comments: true},
+		{Kind: "discussion", Repo: "octo/repo", Number: 3, MaxSize: 5000},
+	}, result.GitHubContextImports, "duplicate imports should be collapsed")
+	assert.Empty(t, result.ImportedFiles, "no files should be imported")
+}
+
+func TestProcessImportsGitHubContextOptionErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		item    map[string]any
+		wantErr string
+	}{
+		{
+			name:    "max-size on file import",
+			item:    map[string]any{"path": "shared/tools.md", "max-size": 100},
+			wantErr: "'max-size' and 'comments' are only supported for issue and discussion imports",
+		},
+		{
+			name:    "invalid max-size",
+			item:    map[string]any{"path": "issue:1", "max-size": 0},
+			wantErr: "import 'max-size' must be a positive integer",
+		},
+		{
+			name:    "section on issue import",
+			item:    map[string]any{"path": "issue:1", "section": "Summary"},
+			wantErr: "issue and discussion imports do not support inputs or markdown options",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frontmatter := map[string]any{"imports": []any{tt.item}}
+			_, err := ProcessImportsFromFrontmatterWithSource(frontmatter, t.TempDir(), nil, "", "")
+			require.Error(t, err, "imports should be rejected")
+			assert.Contains(t, err.Error(), tt.wantErr, "error message")
+		})
+	}
+}
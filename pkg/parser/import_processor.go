@@ -58,6 +58,9 @@ type ImportsResult struct {
 	MergedJobs                    string                // Merged jobs from imported YAML workflows (JSON format)
 	MergedEnv                     string                // Merged env configuration from all imports (JSON format)
 	MergedEnvSources              map[string]string     // env var name → source import path (for conflict detection and lock file header listing)
+	YAMLImportJobSources          map[string]string     // job name → YAML workflow import path that first defined it (for job validation)
+	MergedToolSources             map[string][]string   // tool name → imports that contributed its final configuration (lock file header listing)
+	MergedPermissionSources       map[string][]string   // permission scope → imports that require it (lock file header listing)
 	MergedFeatures                []map[string]any      // Merged features configuration from all imports (parsed YAML structures)
 	MergedModels                  []map[string][]string // Merged model alias definitions from all imports (first import to define a key wins among imports)
 	MergedModelPolicies           []map[string][]string // Merged model policy sets from all imports (models.allowed/blocked)
@@ -74,9 +77,11 @@ type ImportsResult struct {
 	MergedMaxDailyAICredits       string                // First max-daily-ai-credits value found across all imports (JSON-encoded, first-wins)
 	MergedExcludedEnv             []string              // Union of excluded-env lists from all imports (deduplicated, used to extend the main workflow's excluded-env)
 	ImportedFiles                 []string              // List of imported file paths (for manifest)
+	ImportSources                 []ImportSourceFile    // Files read while resolving imports, with content hashes (for lock file provenance)
 	AgentFile                     string                // Path to custom agent file (if imported)
 	AgentImportSpec               string                // Original import specification for agent file (e.g., "owner/repo/path@ref")
 	RepositoryImports             []string              // List of repository imports (format: "owner/repo@ref") for .github folder merging
+	GitHubContextImports          []GitHubContextImport // Issue and discussion imports whose content is fetched into the prompt at runtime
 	// ImportInputs uses map[string]any because input values can be different types (string, number, boolean).
 	// This is parsed from YAML frontmatter where the structure is dynamic and not known at compile time.
 	// This is an appropriate use of 'any' for dynamic YAML/JSON data.
@@ -101,6 +106,12 @@ type ImportSpec struct {
 	// This is parsed from YAML frontmatter and validated against the imported workflow's input definitions.
 	// This is an appropriate use of 'any' for dynamic YAML data. See scratchpad/go-type-patterns.md.
 	Inputs map[string]any // Optional input values to pass to the imported workflow (values are string, number, or boolean)
+	// Markdown holds the object-form section and heading/link transform options
+	// ('section', 'demote-headings', 'strip-links').
+	Markdown MarkdownImportOptions
+	// GitHubContext holds the object-form options for issue and discussion imports
+	// ('max-size', 'comments').
+	GitHubContext GitHubContextImportOptions
 }
 
 // ProcessImportsFromFrontmatterWithSource processes imports field from frontmatter with source tracking
@@ -16,20 +16,22 @@ var importRemoteLog = logger.New("parser:import_remote")
 // When a file is fetched from a remote GitHub repository via workflowspec,
 // its nested relative imports must be resolved against the same remote repo.
 type remoteImportOrigin struct {
-	Owner    string // Repository owner (e.g., "elastic")
-	Repo     string // Repository name (e.g., "ai-github-actions")
-	Ref      string // Git ref - branch, tag, or SHA (e.g., "main", "v1.0.0", "abc123...")
-	BasePath string // Base directory path within the repo (e.g., "gh-agent-workflows" for gh-agent-workflows/gh-aw-workflows/file.md)
+	Owner    string            // Repository owner (e.g., "elastic")
+	Repo     string            // Repository name (e.g., "ai-github-actions")
+	Ref      string            // Git ref - branch, tag, or SHA (e.g., "main", "v1.0.0", "abc123...")
+	BasePath string            // Base directory path within the repo (e.g., "gh-agent-workflows" for gh-agent-workflows/gh-aw-workflows/file.md)
+	GitSSH   *gitSSHImportSpec // Non-nil when the file was fetched via a git+ssh:// import
 }
 
 // importQueueItem represents a file to be imported with its context
 type importQueueItem struct {
-	importPath   string              // Original import path (e.g., "file.md" or "file.md#Section")
-	fullPath     string              // Resolved absolute file path
-	sectionName  string              // Optional section name (from file.md#Section syntax)
-	baseDir      string              // Base directory for resolving nested imports
-	inputs       map[string]any      // Optional input values from parent import
-	remoteOrigin *remoteImportOrigin // Remote origin context (non-nil when imported from a remote repo)
+	importPath   string                // Original import path (e.g., "file.md" or "file.md#Section")
+	fullPath     string                // Resolved absolute file path
+	sectionName  string                // Optional section name (from file.md#Section syntax)
+	markdown     MarkdownImportOptions // Section and heading/link transforms applied when inlining the markdown
+	baseDir      string                // Base directory for resolving nested imports
+	inputs       map[string]any        // Optional input values from parent import
+	remoteOrigin *remoteImportOrigin   // Remote origin context (non-nil when imported from a remote repo)
 }
 
 // parseRemoteOrigin extracts the remote origin (owner, repo, ref, basePath) from a workflowspec path.
@@ -40,6 +42,9 @@ type importQueueItem struct {
 // produces BasePath="gh-agent-workflows" so nested imports resolve relative to that directory.
 func parseRemoteOrigin(spec string) *remoteImportOrigin {
 	importRemoteLog.Printf("Parsing remote import origin from spec: %q", spec)
+	if IsGitSSHImportSpec(spec) {
+		return parseGitSSHRemoteOrigin(spec)
+	}
 	// Remove section reference if present
 	cleanSpec := spec
 	if before, _, ok := strings.Cut(spec, "#"); ok {
@@ -90,3 +95,24 @@ func parseRemoteOrigin(spec string) *remoteImportOrigin {
 		BasePath: basePath,
 	}
 }
+
+// parseGitSSHRemoteOrigin builds the remote origin for a git+ssh:// import so nested
+// relative imports are fetched from the same repository over SSH.
+func parseGitSSHRemoteOrigin(spec string) *remoteImportOrigin {
+	parsed, err := parseGitSSHImportSpec(spec)
+	if err != nil {
+		importRemoteLog.Printf("Spec %q is not a valid git+ssh import: %v", spec, err)
+		return nil
+	}
+	basePath := path.Dir(parsed.FilePath)
+	if basePath == "." {
+		basePath = ""
+	}
+	return &remoteImportOrigin{
+		Owner:    parsed.Owner,
+		Repo:     parsed.Repo,
+		Ref:      parsed.Ref,
+		BasePath: basePath,
+		GitSSH:   parsed,
+	}
+}
@@ -6,48 +6,64 @@ package parser
 import (
 	"fmt"
 	"maps"
+	"slices"
+	"strings"
 )
 
+// importInputDeclarations returns the input declarations of an imported workflow.
+// 'import-schema' takes precedence; shared workflows may also declare their inputs
+// under a top-level 'inputs' map, which is validated the same way.
+func importInputDeclarations(fm map[string]any) map[string]any {
+	for _, key := range []string{"import-schema", "inputs"} {
+		if schemaMap, ok := fm[key].(map[string]any); ok && len(schemaMap) > 0 {
+			return schemaMap
+		}
+		if _, exists := fm[key]; exists {
+			return nil
+		}
+	}
+	return nil
+}
+
 // validateWithImportSchema validates the provided 'with'/'inputs' values against
-// the 'import-schema' declared in the imported workflow's frontmatter.
+// the 'import-schema' (or 'inputs') declared in the imported workflow's frontmatter.
 // It checks that:
 //   - all required parameters declared in import-schema are present in 'with'
 //   - no unknown parameters are provided (i.e., not declared in import-schema)
 //   - provided values match the declared type (string, number, boolean, choice)
-//   - choice values are within the allowed options list
+//   - choice values are within the allowed options list and values are within 'enum'
+//
+// Errors include a suggestion: the closest declared name for unknown inputs, or an
+// example value of the declared type for missing and mistyped inputs.
 //
-// If the imported workflow has no 'import-schema', all provided 'with' values are
-// accepted without validation (backward compatibility with 'inputs' form).
+// If the imported workflow declares no inputs, all provided 'with' values are
+// accepted without validation.
 func validateWithImportSchema(inputs map[string]any, fm map[string]any, importPath string) error {
 	importLog.Printf("Validating 'with' inputs against import-schema: import=%s, inputs=%d", importPath, len(inputs))
-	rawSchema, hasSchema := fm["import-schema"]
-	if !hasSchema {
-		return nil
-	}
-	schemaMap, ok := rawSchema.(map[string]any)
-	if !ok {
-		return nil
-	}
+	schemaMap := importInputDeclarations(fm)
 	if len(schemaMap) == 0 {
 		return nil
 	}
 	importLog.Printf("Import-schema declares %d field(s) for %s", len(schemaMap), importPath)
+	declaredNames := slices.Sorted(maps.Keys(schemaMap))
 
 	// Check for unknown keys not declared in import-schema
-	for key := range inputs {
+	for _, key := range slices.Sorted(maps.Keys(inputs)) {
 		if _, declared := schemaMap[key]; !declared {
-			return fmt.Errorf("import '%s': unknown 'with' input %q is not declared in the import-schema", importPath, key)
+			return fmt.Errorf("import '%s': unknown 'with' input %q is not declared in the import-schema. %s",
+				importPath, key, unknownImportInputSuggestion(key, declaredNames))
 		}
 	}
 
 	// Check each declared schema field
-	for paramName, paramDefRaw := range schemaMap {
-		paramDef, _ := paramDefRaw.(map[string]any)
+	for _, paramName := range declaredNames {
+		paramDef, _ := schemaMap[paramName].(map[string]any)
 
 		// Check required parameters
 		if req, _ := paramDef["required"].(bool); req {
 			if _, provided := inputs[paramName]; !provided {
-				return fmt.Errorf("import '%s': required 'with' input %q is missing (declared in import-schema)", importPath, paramName)
+				return fmt.Errorf("import '%s': required 'with' input %q is missing (declared in import-schema). Add it under 'with:', e.g. %s: %s",
+					importPath, paramName, paramName, importInputExample(paramDef))
 			}
 		}
 
@@ -121,7 +137,7 @@ func validateImportInputType(name string, value any, declaredType string, paramD
 	switch declaredType {
 	case "string":
 		if _, ok := value.(string); !ok {
-			return fmt.Errorf("import '%s': 'with' input %q must be a string (got %T)", importPath, name, value)
+			return fmt.Errorf("import '%s': 'with' input %q must be a string (got %T). Quote the value, e.g. %s: \"%v\"", importPath, name, value, name, value)
 		}
 	case "number":
 		// Accept all numeric types that YAML parsers may produce
@@ -131,11 +147,11 @@ func validateImportInputType(name string, value any, declaredType string, paramD
 			float32, float64:
 			// OK
 		default:
-			return fmt.Errorf("import '%s': 'with' input %q must be a number (got %T)", importPath, name, value)
+			return fmt.Errorf("import '%s': 'with' input %q must be a number (got %T). Use an unquoted number, e.g. %s: %s", importPath, name, value, name, importInputExample(paramDef))
 		}
 	case "boolean":
 		if _, ok := value.(bool); !ok {
-			return fmt.Errorf("import '%s': 'with' input %q must be a boolean (got %T)", importPath, name, value)
+			return fmt.Errorf("import '%s': 'with' input %q must be a boolean (got %T). Use an unquoted true or false, e.g. %s: true", importPath, name, value, name)
 		}
 	case "choice":
 		strVal, ok := value.(string)
@@ -149,7 +165,7 @@ func validateImportInputType(name string, value any, declaredType string, paramD
 						return nil
 					}
 				}
-				return fmt.Errorf("import '%s': 'with' input %q value %q is not in the allowed options", importPath, name, strVal)
+				return fmt.Errorf("import '%s': 'with' input %q value %q is not in the allowed options. %s", importPath, name, strVal, allowedImportInputValuesSuggestion(strVal, optsList))
 			}
 		}
 	case "array":
@@ -176,7 +192,73 @@ func validateImportInputType(name string, value any, declaredType string, paramD
 	case "object":
 		return validateObjectInput(name, value, paramDef, importPath)
 	}
-	return nil
+	return validateImportInputEnum(name, value, paramDef, importPath)
+}
+
+// validateImportInputEnum checks that a 'with' value is one of the values listed in
+// the declaration's 'enum', if any. Values are compared by their string form so that
+// numbers decoded as different Go types (int, uint64, float64) still match.
+func validateImportInputEnum(name string, value any, paramDef map[string]any, importPath string) error {
+	enumList, ok := paramDef["enum"].([]any)
+	if !ok || len(enumList) == 0 {
+		return nil
+	}
+	valueStr := fmt.Sprint(value)
+	for _, allowed := range enumList {
+		if fmt.Sprint(allowed) == valueStr {
+			return nil
+		}
+	}
+	return fmt.Errorf("import '%s': 'with' input %q value %v is not in the allowed values. %s", importPath, name, value, allowedImportInputValuesSuggestion(valueStr, enumList))
+}
+
+// unknownImportInputSuggestion suggests the closest declared input name for an
+// unknown 'with' key and lists the declared inputs.
+func unknownImportInputSuggestion(key string, declaredNames []string) string {
+	declared := "Declared inputs: " + strings.Join(declaredNames, ", ")
+	if matches := FindClosestMatches(key, declaredNames, 1); len(matches) > 0 {
+		return fmt.Sprintf("Did you mean %q? %s", matches[0], declared)
+	}
+	return declared
+}
+
+// allowedImportInputValuesSuggestion lists the allowed values for a choice or enum
+// input and suggests the closest one to the provided value.
+func allowedImportInputValuesSuggestion(value string, allowed []any) string {
+	allowedStrs := make([]string, 0, len(allowed))
+	for _, a := range allowed {
+		allowedStrs = append(allowedStrs, fmt.Sprint(a))
+	}
+	suggestion := "Allowed values: " + strings.Join(allowedStrs, ", ")
+	if matches := FindClosestMatches(value, allowedStrs, 1); len(matches) > 0 {
+		return fmt.Sprintf("Did you mean %q? %s", matches[0], suggestion)
+	}
+	return suggestion
+}
+
+// importInputExample returns an example YAML value for an input declaration, used in
+// error suggestions. It prefers the declared default, then the first allowed value.
+func importInputExample(paramDef map[string]any) string {
+	if def, ok := paramDef["default"]; ok {
+		return fmt.Sprint(def)
+	}
+	for _, key := range []string{"enum", "options"} {
+		if values, ok := paramDef[key].([]any); ok && len(values) > 0 {
+			return fmt.Sprint(values[0])
+		}
+	}
+	switch paramDef["type"] {
+	case "number":
+		return "1"
+	case "boolean":
+		return "true"
+	case "array":
+		return "[...]"
+	case "object":
+		return "{ ... }"
+	default:
+		return "\"...\""
+	}
 }
 
 // applyImportSchemaDefaultsFromFrontmatter applies import-schema defaults from an
@@ -186,12 +268,8 @@ func validateImportInputType(name string, value any, declaredType string, paramD
 // default values for schema parameters declared with a "default" field but not
 // present in the provided inputs map. Parameters already in inputs are left unchanged.
 func applyImportSchemaDefaultsFromFrontmatter(frontmatter map[string]any, inputs map[string]any) map[string]any {
-	rawSchema, ok := frontmatter["import-schema"]
-	if !ok {
-		return inputs
-	}
-	schemaMap, ok := rawSchema.(map[string]any)
-	if !ok || len(schemaMap) == 0 {
+	schemaMap := importInputDeclarations(frontmatter)
+	if len(schemaMap) == 0 {
 		return inputs
 	}
 
@@ -0,0 +1,107 @@
+//go:build !integration
+
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateWithImportSchema_Suggestions(t *testing.T) {
+	fm := map[string]any{
+		"import-schema": map[string]any{
+			"region": map[string]any{"type": "string", "required": true},
+			"environment": map[string]any{
+				"type":    "choice",
+				"options": []any{"staging", "production"},
+			},
+			"count": map[string]any{"type": "number", "default": 10},
+			"level": map[string]any{"type": "number", "enum": []any{1, 2, 3}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		inputs   map[string]any
+		contains []string
+	}{
+		{
+			name:     "unknown input suggests closest declared name",
+			inputs:   map[string]any{"region": "us", "regoin": "us"},
+			contains: []string{`unknown 'with' input "regoin"`, `Did you mean "region"?`, "Declared inputs: count, environment, level, region"},
+		},
+		{
+			name:     "missing required input shows example",
+			inputs:   map[string]any{},
+			contains: []string{`required 'with' input "region" is missing`, `e.g. region: "..."`},
+		},
+		{
+			name:     "wrong type suggests unquoted number",
+			inputs:   map[string]any{"region": "us", "count": "5"},
+			contains: []string{`"count" must be a number (got string)`, "e.g. count: 10"},
+		},
+		{
+			name:     "invalid choice suggests closest option",
+			inputs:   map[string]any{"region": "us", "environment": "prod"},
+			contains: []string{`value "prod" is not in the allowed options`, "Allowed values: staging, production"},
+		},
+		{
+			name:     "value outside enum is rejected",
+			inputs:   map[string]any{"region": "us", "level": uint64(4)},
+			contains: []string{`"level" value 4 is not in the allowed values`, "Allowed values: 1, 2, 3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateWithImportSchema(tt.inputs, fm, "shared/deploy.md")
+			require.Error(t, err, "validation should fail")
+			for _, want := range tt.contains {
+				assert.Contains(t, err.Error(), want, "error should contain %q", want)
+			}
+		})
+	}
+
+	t.Run("enum accepts numbers of any decoded type", func(t *testing.T) {
+		err := validateWithImportSchema(map[string]any{"region": "us", "level": uint64(2)}, fm, "shared/deploy.md")
+		assert.NoError(t, err, "enum value should be accepted")
+	})
+}
+
+func TestValidateWithImportSchema_InputsDeclaration(t *testing.T) {
+	fm := map[string]any{
+		"inputs": map[string]any{
+			"count": map[string]any{"type": "number", "default": 100},
+			"mode":  map[string]any{"type": "string", "enum": []any{"fast", "thorough"}, "required": true},
+		},
+	}
+
+	require.NoError(t, validateWithImportSchema(map[string]any{"mode": "fast", "count": 5}, fm, "shared/fetch.md"),
+		"valid inputs should pass")
+
+	err := validateWithImportSchema(map[string]any{"count": 5}, fm, "shared/fetch.md")
+	require.Error(t, err, "missing required input declared under 'inputs' should fail")
+	assert.Contains(t, err.Error(), "e.g. mode: fast", "example should use the first enum value")
+
+	err = validateWithImportSchema(map[string]any{"mode": "slow"}, fm, "shared/fetch.md")
+	require.Error(t, err, "value outside enum should fail")
+	assert.Contains(t, err.Error(), "Allowed values: fast, thorough", "error should list enum values")
+
+	withDefaults := applyImportSchemaDefaultsFromFrontmatter(fm, map[string]any{"mode": "fast"})
+	assert.Equal(t, 100, withDefaults["count"], "defaults from 'inputs' should be applied")
+}
+
+func TestImportInputDeclarations(t *testing.T) {
+	schema := map[string]any{"a": map[string]any{"type": "string"}}
+	inputs := map[string]any{"b": map[string]any{"type": "string"}}
+
+	assert.Equal(t, schema, importInputDeclarations(map[string]any{"import-schema": schema, "inputs": inputs}),
+		"import-schema should take precedence over inputs")
+	assert.Equal(t, inputs, importInputDeclarations(map[string]any{"inputs": inputs}),
+		"inputs should be used when import-schema is absent")
+	assert.Nil(t, importInputDeclarations(map[string]any{"import-schema": map[string]any{}, "inputs": inputs}),
+		"an empty import-schema should disable validation")
+	assert.Nil(t, importInputDeclarations(map[string]any{}), "no declarations should return nil")
+}
@@ -0,0 +1,54 @@
+package parser
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"path/filepath"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/gitutil"
+)
+
+// ImportSourceFile identifies a file read while resolving imports, together with the
+// SHA-256 of its content at compile time. The compiler records these in the lock file
+// provenance header so `gh aw verify` can detect imports that changed after compilation.
+type ImportSourceFile struct {
+	FullPath string // Resolved file path
+	SHA256   string // Hex-encoded SHA-256 of the file content
+	Commit   string // Commit SHA for remote imports served from the import cache
+}
+
+// recordImportSource hashes the file behind a queue item. Builtin files ship with the
+// compiler and are covered by its version, so they are not recorded.
+func (acc *importAccumulator) recordImportSource(item importQueueItem) {
+	if strings.HasPrefix(item.fullPath, BuiltinPathPrefix) || acc.importSourcesSet[item.fullPath] {
+		return
+	}
+	content, err := readFileFunc(item.fullPath)
+	if err != nil {
+		// The handlers report unreadable files with better context.
+		return
+	}
+	acc.importSourcesSet[item.fullPath] = true
+	sum := sha256.Sum256(content)
+	acc.importSources = append(acc.importSources, ImportSourceFile{
+		FullPath: item.fullPath,
+		SHA256:   hex.EncodeToString(sum[:]),
+		Commit:   importCacheCommit(item.fullPath),
+	})
+}
+
+// importCacheCommit returns the commit SHA segment of a path inside the import cache
+// (.github/aw/imports/<owner>/<repo>/<sha>/<file>), or "" for other paths.
+func importCacheCommit(fullPath string) string {
+	normalized := filepath.ToSlash(fullPath)
+	idx := strings.LastIndex(normalized, ImportCacheDir+"/")
+	if idx < 0 {
+		return ""
+	}
+	parts := strings.Split(normalized[idx+len(ImportCacheDir)+1:], "/")
+	if len(parts) < 4 || !gitutil.IsValidFullSHA(parts[2]) {
+		return ""
+	}
+	return parts[2]
+}
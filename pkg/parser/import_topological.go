@@ -36,6 +36,7 @@ func topologicalSortImports(imports []string, baseDir string, cache *ImportCache
 		if len(cyclePath) > 0 {
 			return nil, &ImportCycleError{
 				Chain:        cyclePath,
+				Edges:        buildImportCycleEdges(cyclePath, baseDir, cache),
 				WorkflowFile: workflowFile,
 			}
 		}
@@ -334,7 +334,8 @@ func extractIncludedMarkdownContent(filePath, sectionName string, content []byte
 		return "", fmt.Errorf("failed to process nested includes in %s: %w", filePath, err)
 	}
 	if sectionName != "" {
-		sectionContent, sectionErr := ExtractMarkdownSection(markdownContent, sectionName)
+		// sectionName may carry a heading level suffix (Section@h3)
+		sectionContent, sectionErr := ApplyMarkdownImportOptions(markdownContent, parseSectionReference(sectionName))
 		if sectionErr != nil {
 			return "", fmt.Errorf("failed to extract section '%s' from %s: %w", sectionName, filePath, sectionErr)
 		}
@@ -0,0 +1,414 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var markdownExpressionLog = logger.New("parser:markdown_expression_validation")
+
+// Markdown expression issue kinds reported by ValidateMarkdownExpressions.
+const (
+	// MarkdownExpressionUnavailable marks an expression whose event payload is
+	// never populated by any of the workflow's configured triggers.
+	MarkdownExpressionUnavailable = "unavailable"
+	// MarkdownExpressionInjection marks an expression that interpolates
+	// attacker-controlled text into the prompt.
+	MarkdownExpressionInjection = "injection"
+)
+
+var (
+	// markdownExpressionPattern matches single-line ${{ ... }} expressions in the markdown body.
+	markdownExpressionPattern = regexp.MustCompile(`\$\{\{(.*?)\}\}`)
+	// eventReferencePattern matches github.event.<root> references inside an expression.
+	eventReferencePattern = regexp.MustCompile(`github\.event\.([A-Za-z_]+)((?:\.[A-Za-z0-9_-]+|\[[^\]]*\])*)`)
+	// headRefPattern matches the github.head_ref context, which is the PR branch name.
+	headRefPattern = regexp.MustCompile(`\bgithub\.head_ref\b`)
+)
+
+// eventPayloadProviders maps the top-level github.event property to the events
+// whose payload contains it.
+var eventPayloadProviders = map[string][]string{
+	"issue":             {"issues", "issue_comment"},
+	"pull_request":      {"pull_request", "pull_request_target", "pull_request_review", "pull_request_review_comment"},
+	"comment":           {"issue_comment", "pull_request_review_comment", "commit_comment", "discussion_comment"},
+	"review":            {"pull_request_review"},
+	"discussion":        {"discussion", "discussion_comment"},
+	"answer":            {"discussion"},
+	"release":           {"release"},
+	"workflow_run":      {"workflow_run"},
+	"workflow_job":      {"workflow_job"},
+	"check_run":         {"check_run"},
+	"check_suite":       {"check_suite"},
+	"deployment":        {"deployment", "deployment_status"},
+	"deployment_status": {"deployment_status"},
+	"head_commit":       {"push"},
+	"commits":           {"push"},
+	"pusher":            {"push"},
+	"before":            {"push", "pull_request", "pull_request_target"},
+	"after":             {"push", "pull_request", "pull_request_target"},
+	"inputs":            {"workflow_dispatch"},
+	"client_payload":    {"repository_dispatch"},
+	"milestone":         {"milestone"},
+	"label":             {"label", "issues", "pull_request", "pull_request_target", "discussion"},
+	"pages":             {"gollum"},
+	"project":           {"project"},
+	"project_card":      {"project_card"},
+	"project_column":    {"project_column"},
+	"merge_group":       {"merge_group"},
+	"schedule":          {"schedule"},
+}
+
+// triggerEventExpansions maps gh-aw specific triggers to the GitHub events they compile to.
+var triggerEventExpansions = map[string][]string{
+	"command":       {"issues", "issue_comment", "pull_request", "pull_request_review_comment", "discussion", "discussion_comment"},
+	"slash_command": {"issues", "issue_comment", "pull_request", "pull_request_review_comment", "discussion", "discussion_comment"},
+	"label_command": {"issues", "pull_request", "discussion"},
+}
+
+// injectionProneEventFields lists github.event paths whose values are controlled
+// by whoever opened the issue, pushed the branch or wrote the comment.
+var injectionProneEventFields = []*regexp.Regexp{
+	regexp.MustCompile(`^(issue|pull_request|discussion)\.(title|body)$`),
+	regexp.MustCompile(`^(comment|review|review_comment|answer)\.body$`),
+	regexp.MustCompile(`^pull_request\.head\.(ref|label)$`),
+	regexp.MustCompile(`^pull_request\.head\.repo\.default_branch$`),
+	regexp.MustCompile(`^head_commit\.(message|author\.(name|email))$`),
+	regexp.MustCompile(`^commits(\[[^\]]*\])?\.(message|author\.(name|email))$`),
+	regexp.MustCompile(`^pages(\[[^\]]*\])?\.page_name$`),
+	regexp.MustCompile(`^release\.(name|body)$`),
+	regexp.MustCompile(`^workflow_run\.(head_branch|display_title|head_commit\.message)$`),
+}
+
+// MarkdownExpressionIssue describes a problem with a ${{ }} expression used in the
+// markdown body of a workflow.
+type MarkdownExpressionIssue struct {
+	Kind       string   // MarkdownExpressionUnavailable or MarkdownExpressionInjection
+	Expression string   // Trimmed expression text (without ${{ }})
+	Line       int      // 1-based line number in the workflow file
+	Column     int      // 1-based column of the opening ${{
+	Message    string   // Human-readable description of the problem
+	Suggestion string   // How to fix the problem
+	Context    []string // Source lines centered on Line
+}
+
+// FormatWarning renders the issue as a compiler warning for filePath.
+func (i MarkdownExpressionIssue) FormatWarning(filePath string) string {
+	return console.FormatError(console.CompilerError{
+		Position: console.ErrorPosition{
+			File:   filePath,
+			Line:   i.Line,
+			Column: i.Column,
+		},
+		Type:    "warning",
+		Message: i.Message,
+		Context: i.Context,
+		Hint:    i.Suggestion,
+	})
+}
+
+// ValidateMarkdownExpressions scans the markdown body of a workflow file for ${{ }}
+// expressions and reports the ones that reference event payloads none of the
+// configured triggers provide, as well as the ones that interpolate
+// attacker-controlled text (titles, bodies, branch names, commit messages) into
+// the prompt. Expressions inside fenced code blocks and HTML comments are
+// documentation rather than prompt text and are skipped. Line numbers are
+// relative to the full file content.
+func ValidateMarkdownExpressions(content string) ([]MarkdownExpressionIssue, error) {
+	if !strings.Contains(content, "${{") {
+		return nil, nil
+	}
+
+	result, err := ExtractFrontmatterFromContent(content)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := strings.Split(content, "\n")
+	bodyStart := markdownBodyStartIndex(lines)
+	events, checkAvailability := collectTriggerEvents(result.Frontmatter["on"])
+	markdownExpressionLog.Printf("Validating markdown expressions: body_start_line=%d, events=%v, check_availability=%t", bodyStart+1, events, checkAvailability)
+
+	var issues []MarkdownExpressionIssue
+	fence := ""
+	inComment := false
+	for idx := bodyStart; idx < len(lines); idx++ {
+		if marker := promptFenceMarker(lines[idx]); marker != "" && !inComment {
+			switch {
+			case fence == "":
+				fence = marker
+			case marker[0] == fence[0] && len(marker) >= len(fence):
+				fence = ""
+			}
+			continue
+		}
+		if fence != "" {
+			continue
+		}
+		line := blankHTMLComments(lines[idx], &inComment)
+		for _, loc := range markdownExpressionPattern.FindAllStringSubmatchIndex(line, -1) {
+			expression := strings.TrimSpace(line[loc[2]:loc[3]])
+			if expression == "" {
+				continue
+			}
+			base := MarkdownExpressionIssue{
+				Expression: expression,
+				Line:       idx + 1,
+				Column:     loc[0] + 1,
+				Context:    markdownIssueContext(lines, idx),
+			}
+			if checkAvailability {
+				if issue, ok := checkExpressionAvailability(base, events); ok {
+					issues = append(issues, issue)
+				}
+			}
+			if issue, ok := checkExpressionInjection(base); ok {
+				issues = append(issues, issue)
+			}
+		}
+	}
+
+	markdownExpressionLog.Printf("Found %d markdown expression issues", len(issues))
+	return issues, nil
+}
+
+// blankHTMLComments replaces HTML comments in line with spaces so that columns of
+// the remaining text are preserved. inComment tracks comments that span several lines.
+func blankHTMLComments(line string, inComment *bool) string {
+	blanked := []byte(line)
+	for i := 0; i < len(line); {
+		if !*inComment {
+			start := strings.Index(line[i:], "<!--")
+			if start < 0 {
+				break
+			}
+			*inComment = true
+			i += start
+		}
+		end := strings.Index(line[i:], "-->")
+		stop := len(line)
+		if end >= 0 {
+			*inComment = false
+			stop = i + end + len("-->")
+		}
+		for ; i < stop; i++ {
+			blanked[i] = ' '
+		}
+	}
+	return string(blanked)
+}
+
+// markdownBodyStartIndex returns the 0-based index of the first line after the
+// closing frontmatter delimiter, or 0 when the content has no frontmatter.
+func markdownBodyStartIndex(lines []string) int {
+	if len(lines) == 0 || !isFrontmatterDelimiterLine(lines[0]) {
+		return 0
+	}
+	for i := 1; i < len(lines); i++ {
+		if isFrontmatterDelimiterLine(lines[i]) {
+			return i + 1
+		}
+	}
+	return len(lines)
+}
+
+// markdownIssueContext returns three lines centered on idx, padding with empty
+// lines at the start of the file so the issue line stays centered.
+func markdownIssueContext(lines []string, idx int) []string {
+	context := make([]string, 0, 3)
+	for i := idx - 1; i <= idx+1; i++ {
+		switch {
+		case i < 0:
+			context = append(context, "")
+		case i < len(lines):
+			context = append(context, strings.TrimRight(lines[i], "\r"))
+		}
+	}
+	return context
+}
+
+// collectTriggerEvents returns the GitHub events configured in the "on" section.
+// The second return value is false when the events cannot be determined reliably
+// (trigger shorthands, reusable workflows), in which case availability is not checked.
+func collectTriggerEvents(onValue any) ([]string, bool) {
+	eventSet := make(map[string]bool)
+	addEvent := func(name string, config any) bool {
+		if name == "workflow_call" {
+			// The event payload is inherited from the caller.
+			return false
+		}
+		if expanded, ok := triggerEventExpansions[name]; ok {
+			for _, event := range commandTriggerEvents(expanded, config) {
+				eventSet[event] = true
+			}
+			return true
+		}
+		eventSet[name] = true
+		return true
+	}
+
+	switch on := onValue.(type) {
+	case string:
+		name := strings.TrimSpace(on)
+		if !isKnownPayloadEvent(name) {
+			return nil, false
+		}
+		if !addEvent(name, nil) {
+			return nil, false
+		}
+	case []any:
+		for _, item := range on {
+			name, ok := item.(string)
+			if !ok || !isKnownPayloadEvent(name) {
+				return nil, false
+			}
+			if !addEvent(name, nil) {
+				return nil, false
+			}
+		}
+	case map[string]any:
+		for name, config := range on {
+			if name != "workflow_call" && !isKnownPayloadEvent(name) {
+				// Trigger modifiers (stop-after, skip-if-match, ...) and events
+				// without a mapped payload do not affect availability.
+				continue
+			}
+			if !addEvent(name, config) {
+				return nil, false
+			}
+		}
+	default:
+		return nil, false
+	}
+
+	events := make([]string, 0, len(eventSet))
+	for event := range eventSet {
+		events = append(events, event)
+	}
+	sort.Strings(events)
+	return events, true
+}
+
+// commandTriggerEvents narrows the events of a command trigger to its "events:"
+// list when one is configured.
+func commandTriggerEvents(expanded []string, config any) []string {
+	configMap, ok := config.(map[string]any)
+	if !ok {
+		return expanded
+	}
+	rawEvents, ok := configMap["events"].([]any)
+	if !ok || len(rawEvents) == 0 {
+		return expanded
+	}
+	var events []string
+	for _, raw := range rawEvents {
+		name, _ := raw.(string)
+		switch name {
+		case "*":
+			return expanded
+		case "pull_request_comment":
+			name = "issue_comment"
+		}
+		if slices.Contains(expanded, name) {
+			events = append(events, name)
+		}
+	}
+	if len(events) == 0 {
+		return expanded
+	}
+	return events
+}
+
+// isKnownPayloadEvent reports whether name is a plain GitHub event or gh-aw trigger
+// name (as opposed to a trigger shorthand such as "daily" or "/review").
+func isKnownPayloadEvent(name string) bool {
+	if _, ok := triggerEventExpansions[name]; ok {
+		return true
+	}
+	for _, providers := range eventPayloadProviders {
+		if slices.Contains(providers, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkExpressionAvailability reports an issue when the expression only reads
+// github.event properties that none of the configured events provide. Each
+// operand of a top-level || chain is checked separately so that fallbacks such as
+// `github.event.inputs.limit || '10'` are accepted.
+func checkExpressionAvailability(base MarkdownExpressionIssue, events []string) (MarkdownExpressionIssue, bool) {
+	var unavailableRoots []string
+	for _, operand := range strings.Split(base.Expression, "||") {
+		roots := unavailableEventRoots(operand, events)
+		if len(roots) == 0 {
+			return MarkdownExpressionIssue{}, false
+		}
+		for _, root := range roots {
+			if !slices.Contains(unavailableRoots, root) {
+				unavailableRoots = append(unavailableRoots, root)
+			}
+		}
+	}
+
+	root := unavailableRoots[0]
+	providers := eventPayloadProviders[root]
+	issue := base
+	issue.Kind = MarkdownExpressionUnavailable
+	issue.Message = fmt.Sprintf("expression '%s' is never set for this workflow's triggers (%s): github.event.%s is only provided by %s events, so it will render as an empty string",
+		base.Expression, strings.Join(events, ", "), root, strings.Join(providers, ", "))
+	issue.Suggestion = fmt.Sprintf("add one of these triggers under 'on:' (%s), add a fallback such as `|| 'default'` to the expression, or remove it", strings.Join(providers, ", "))
+	return issue, true
+}
+
+// unavailableEventRoots returns the github.event roots in operand that none of
+// events provide. Roots without a known provider (repository, sender, ...) are
+// always considered available.
+func unavailableEventRoots(operand string, events []string) []string {
+	var roots []string
+	for _, match := range eventReferencePattern.FindAllStringSubmatch(operand, -1) {
+		root := match[1]
+		providers, known := eventPayloadProviders[root]
+		if !known {
+			continue
+		}
+		if slices.ContainsFunc(providers, func(p string) bool { return slices.Contains(events, p) }) {
+			continue
+		}
+		if !slices.Contains(roots, root) {
+			roots = append(roots, root)
+		}
+	}
+	return roots
+}
+
+// checkExpressionInjection reports an issue when the expression interpolates a
+// field whose content is controlled by an untrusted actor.
+func checkExpressionInjection(base MarkdownExpressionIssue) (MarkdownExpressionIssue, bool) {
+	field := ""
+	for _, match := range eventReferencePattern.FindAllStringSubmatch(base.Expression, -1) {
+		path := match[1] + match[2]
+		if slices.ContainsFunc(injectionProneEventFields, func(re *regexp.Regexp) bool { return re.MatchString(path) }) {
+			field = "github.event." + path
+			break
+		}
+	}
+	if field == "" && headRefPattern.MatchString(base.Expression) {
+		field = "github.head_ref"
+	}
+	if field == "" {
+		return MarkdownExpressionIssue{}, false
+	}
+
+	issue := base
+	issue.Kind = MarkdownExpressionInjection
+	issue.Message = fmt.Sprintf("expression '%s' inserts untrusted user input (%s) into the prompt verbatim, which allows prompt injection", base.Expression, field)
+	issue.Suggestion = "use the sanitized activation outputs instead: ${{ steps.sanitized.outputs.title }}, ${{ steps.sanitized.outputs.body }} or ${{ steps.sanitized.outputs.text }}; for branch names and commit messages, have the agent read them through the GitHub tools"
+	return issue, true
+}
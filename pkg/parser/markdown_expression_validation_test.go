@@ -0,0 +1,227 @@
+//go:build !integration
+
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateMarkdownExpressions(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		wantKinds []string
+		wantLines []int
+	}{
+		{
+			name: "available expression is accepted",
+			content: `---
+on:
+  issues:
+    types: [opened]
+---
+# Triage
+
+Issue #${{ github.event.issue.number }} in ${{ github.repository }}.
+`,
+		},
+		{
+			name: "event payload not provided by triggers",
+			content: `---
+on:
+  schedule: daily
+  workflow_dispatch:
+---
+# Report
+
+Commit ${{ github.event.head_commit.id }}
+`,
+			wantKinds: []string{MarkdownExpressionUnavailable},
+			wantLines: []int{8},
+		},
+		{
+			name: "fallback operand keeps expression available",
+			content: `---
+on:
+  workflow_dispatch:
+---
+PR ${{ github.event.pull_request.number || github.event.inputs.pr_number }}
+Limit ${{ github.event.issue.number || '10' }}
+`,
+		},
+		{
+			name: "all fallback operands unavailable",
+			content: `---
+on:
+  push:
+---
+Item ${{ github.event.issue.number || github.event.pull_request.number }}
+`,
+			wantKinds: []string{MarkdownExpressionUnavailable},
+			wantLines: []int{5},
+		},
+		{
+			name: "command trigger narrowed by events",
+			content: `---
+on:
+  label_command:
+    name: doctor
+    events: [pull_request]
+---
+PR ${{ github.event.pull_request.number }}
+Issue ${{ github.event.issue.number }}
+`,
+			wantKinds: []string{MarkdownExpressionUnavailable},
+			wantLines: []int{8},
+		},
+		{
+			name: "trigger modifiers are not treated as events",
+			content: `---
+on:
+  schedule: daily
+  stop-after: +30d
+  skip-if-match: is:issue is:open
+---
+Issue ${{ github.event.issue.number }}
+`,
+			wantKinds: []string{MarkdownExpressionUnavailable},
+			wantLines: []int{7},
+		},
+		{
+			name: "injection-prone title is flagged",
+			content: `---
+on:
+  pull_request:
+---
+Review "${{ github.event.pull_request.title }}"
+`,
+			wantKinds: []string{MarkdownExpressionInjection},
+			wantLines: []int{5},
+		},
+		{
+			name: "unavailable and injection-prone",
+			content: `---
+on: push
+---
+Title ${{ github.event.issue.title }}
+`,
+			wantKinds: []string{MarkdownExpressionUnavailable, MarkdownExpressionInjection},
+			wantLines: []int{4, 4},
+		},
+		{
+			name: "head_ref is injection-prone",
+			content: `---
+on:
+  pull_request:
+---
+Branch ${{ github.head_ref }}
+`,
+			wantKinds: []string{MarkdownExpressionInjection},
+			wantLines: []int{5},
+		},
+		{
+			name: "trigger shorthand skips availability",
+			content: `---
+on: daily
+---
+Issue ${{ github.event.issue.number }}
+`,
+		},
+		{
+			name: "workflow_call skips availability",
+			content: `---
+on:
+  workflow_call:
+---
+Issue ${{ github.event.issue.number }}
+`,
+		},
+		{
+			name: "frontmatter expressions are ignored",
+			content: `---
+on:
+  push:
+concurrency: ${{ github.event.issue.title }}
+---
+Body
+`,
+		},
+		{
+			name: "fenced code blocks are ignored",
+			content: "---\non:\n  push:\n---\n" +
+				"```yaml\nif: ${{ github.event.issue.title }}\n````\n" +
+				"~~~\n```\nBranch ${{ github.head_ref }}\n~~~\n" +
+				"Branch ${{ github.head_ref }}\n",
+			wantKinds: []string{MarkdownExpressionInjection},
+			wantLines: []int{12},
+		},
+		{
+			name: "html comments are ignored",
+			content: `---
+on:
+  push:
+---
+<!-- Title ${{ github.event.issue.title }} -->
+<!--
+Body ${{ github.event.issue.body }}
+--> Branch ${{ github.head_ref }}
+`,
+			wantKinds: []string{MarkdownExpressionInjection},
+			wantLines: []int{8},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues, err := ValidateMarkdownExpressions(tt.content)
+			require.NoError(t, err, "validation should not fail")
+
+			var kinds []string
+			var lines []int
+			for _, issue := range issues {
+				kinds = append(kinds, issue.Kind)
+				lines = append(lines, issue.Line)
+			}
+			assert.Equal(t, tt.wantKinds, kinds, "issue kinds should match")
+			assert.Equal(t, tt.wantLines, lines, "issue line numbers should match")
+		})
+	}
+}
+
+func TestMarkdownExpressionIssueFormatWarning(t *testing.T) {
+	content := `---
+on:
+  workflow_dispatch:
+---
+Run ${{ github.event.workflow_run.id }}
+`
+	issues, err := ValidateMarkdownExpressions(content)
+	require.NoError(t, err, "validation should not fail")
+	require.Len(t, issues, 1, "expected one issue")
+
+	issue := issues[0]
+	assert.Equal(t, 5, issue.Line, "line should point at the body line")
+	assert.Equal(t, 5, issue.Column, "column should point at the opening ${{")
+	assert.Contains(t, issue.Message, "triggers (workflow_dispatch)", "message should list the configured triggers")
+	assert.Contains(t, issue.Message, "github.event.workflow_run is only provided by workflow_run events", "message should name the providing events")
+	assert.Contains(t, issue.Suggestion, "workflow_run", "suggestion should name the missing trigger")
+
+	formatted := issue.FormatWarning("test.md")
+	assert.Contains(t, formatted, "test.md:5:5:", "warning should include the file location")
+	assert.Contains(t, formatted, "warning:", "warning should be rendered as a warning")
+	assert.Contains(t, formatted, "Run ${{ github.event.workflow_run.id }}", "warning should include the source line")
+}
+
+func TestBlankHTMLComments(t *testing.T) {
+	inComment := false
+	assert.Equal(t, "a         b <!", blankHTMLComments("a <!-- -->b <!", &inComment), "closed comments should be blanked in place")
+	assert.False(t, inComment, "a closed comment should not carry over")
+
+	assert.Equal(t, "a       ", blankHTMLComments("a <!-- x", &inComment), "an open comment should be blanked to the end of the line")
+	assert.True(t, inComment, "an open comment should carry over to the next line")
+	assert.Equal(t, "     ${{ x }}", blankHTMLComments("y -->${{ x }}", &inComment), "text after the closing marker should keep its column")
+	assert.False(t, inComment, "the comment should be closed")
+}
@@ -0,0 +1,181 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var markdownImportOptionsLog = logger.New("parser:markdown_import_options")
+
+// sectionHeadingLevelSuffix matches the "@hN" suffix of a section reference
+// (e.g. "Security@h3" → section "Security", heading level 3).
+var sectionHeadingLevelSuffix = regexp.MustCompile(`@h([1-6])$`)
+
+// markdownHeadingPattern matches ATX headings (# through ######).
+var markdownHeadingPattern = regexp.MustCompile(`^(#{1,6})([ \t].*)?$`)
+
+// markdownLinkPattern matches inline links [text](url), skipping images ![alt](url).
+var markdownLinkPattern = regexp.MustCompile(`(^|[^!\\])\[([^\]]*)\]\([^)]*\)`)
+
+// MarkdownImportOptions controls how imported markdown is reshaped before it is
+// inlined, so prompts assembled from many fragments keep a consistent heading hierarchy.
+// Front matter is always stripped from imported markdown before these options apply.
+type MarkdownImportOptions struct {
+	Section        string // Section to extract (file.md#Section or the 'section' field)
+	HeadingLevel   int    // Level (1-6) the section heading is remapped to (file.md#Section@hN); 0 keeps it
+	DemoteHeadings int    // Additional levels to push every heading down ('demote-headings')
+	StripLinks     bool   // Replace [text](url) links with their text ('strip-links')
+}
+
+// IsZero reports whether no section or transform is requested.
+func (o MarkdownImportOptions) IsZero() bool {
+	return o == MarkdownImportOptions{}
+}
+
+// parseSectionReference parses the part of an import path after '#', which is a section
+// name optionally followed by a heading level suffix ("Section" or "Section@h3").
+func parseSectionReference(ref string) MarkdownImportOptions {
+	if ref == "" {
+		return MarkdownImportOptions{}
+	}
+	if m := sectionHeadingLevelSuffix.FindStringSubmatch(ref); m != nil {
+		level, _ := strconv.Atoi(m[1])
+		return MarkdownImportOptions{
+			Section:      strings.TrimSpace(strings.TrimSuffix(ref, m[0])),
+			HeadingLevel: level,
+		}
+	}
+	return MarkdownImportOptions{Section: ref}
+}
+
+// parseMarkdownImportOptions reads the object-form import fields 'section',
+// 'demote-headings', and 'strip-links'.
+func parseMarkdownImportOptions(item map[string]any) (MarkdownImportOptions, error) {
+	var opts MarkdownImportOptions
+	if v, ok := item["section"]; ok {
+		section, isString := v.(string)
+		if !isString || strings.TrimSpace(section) == "" {
+			return opts, errors.New("import 'section' must be a non-empty string")
+		}
+		opts = parseSectionReference(strings.TrimSpace(section))
+	}
+	if v, ok := item["demote-headings"]; ok {
+		n, isInt := toNonNegativeInt(v)
+		if !isInt || n > 5 {
+			return opts, errors.New("import 'demote-headings' must be an integer between 0 and 5")
+		}
+		opts.DemoteHeadings = n
+	}
+	if v, ok := item["strip-links"]; ok {
+		strip, isBool := v.(bool)
+		if !isBool {
+			return opts, errors.New("import 'strip-links' must be a boolean")
+		}
+		opts.StripLinks = strip
+	}
+	return opts, nil
+}
+
+// toNonNegativeInt converts YAML numeric values to a non-negative int.
+func toNonNegativeInt(v any) (int, bool) {
+	switch n := v.(type) {
+	case int:
+		return n, n >= 0
+	case int64:
+		return int(n), n >= 0
+	case uint64:
+		return int(n), true
+	case float64:
+		return int(n), n >= 0 && n == float64(int(n))
+	}
+	return 0, false
+}
+
+// mergeMarkdownImportOptions combines a "#Section@hN" path suffix with the object-form
+// options. The section may be given in only one of the two places.
+func mergeMarkdownImportOptions(sectionRef string, spec MarkdownImportOptions) (MarkdownImportOptions, error) {
+	fromPath := parseSectionReference(sectionRef)
+	if fromPath.Section != "" && spec.Section != "" {
+		return MarkdownImportOptions{}, fmt.Errorf("import section is specified twice ('#%s' and section: %q)", sectionRef, spec.Section)
+	}
+	merged := spec
+	if fromPath.Section != "" {
+		merged.Section = fromPath.Section
+		merged.HeadingLevel = fromPath.HeadingLevel
+	}
+	return merged, nil
+}
+
+// ApplyMarkdownImportOptions extracts the requested section (if any) from markdown and
+// applies heading remapping and link stripping. Headings and links inside fenced code
+// blocks are left untouched.
+func ApplyMarkdownImportOptions(markdown string, opts MarkdownImportOptions) (string, error) {
+	if opts.Section != "" {
+		section, err := ExtractMarkdownSection(markdown, opts.Section)
+		if err != nil {
+			return "", err
+		}
+		markdown = section
+	}
+	if opts.HeadingLevel == 0 && opts.DemoteHeadings == 0 && !opts.StripLinks {
+		return markdown, nil
+	}
+
+	lines := strings.Split(markdown, "\n")
+	shift := opts.DemoteHeadings
+	if opts.HeadingLevel > 0 {
+		if first := firstHeadingLevel(lines); first > 0 {
+			shift += opts.HeadingLevel - first
+		}
+	}
+	markdownImportOptionsLog.Printf("Applying markdown import options: section=%q, shift=%d, strip_links=%t", opts.Section, shift, opts.StripLinks)
+
+	inFence := false
+	for i, line := range lines {
+		if isCodeFenceLine(line) {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		if shift != 0 {
+			if m := markdownHeadingPattern.FindStringSubmatch(line); m != nil {
+				level := min(max(len(m[1])+shift, 1), 6)
+				line = strings.Repeat("#", level) + m[2]
+			}
+		}
+		if opts.StripLinks {
+			line = markdownLinkPattern.ReplaceAllString(line, "$1$2")
+		}
+		lines[i] = line
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// firstHeadingLevel returns the level of the first heading outside code fences, or 0.
+func firstHeadingLevel(lines []string) int {
+	inFence := false
+	for _, line := range lines {
+		if isCodeFenceLine(line) {
+			inFence = !inFence
+			continue
+		}
+		if !inFence {
+			if m := markdownHeadingPattern.FindStringSubmatch(line); m != nil {
+				return len(m[1])
+			}
+		}
+	}
+	return 0
+}
+
+func isCodeFenceLine(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~")
+}
@@ -0,0 +1,125 @@
+//go:build !integration
+
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const markdownImportOptionsFixture = `# Guide
+
+## Usage
+
+See [the docs](https://example.com/docs) and ![logo](logo.png).
+
+### Details
+
+` + "```" + `
+# not a heading
+[kept](https://example.com)
+` + "```" + `
+
+## Other
+
+Other text.
+`
+
+func TestParseSectionReference(t *testing.T) {
+	assert.Equal(t, MarkdownImportOptions{}, parseSectionReference(""), "empty reference")
+	assert.Equal(t, MarkdownImportOptions{Section: "Usage"}, parseSectionReference("Usage"), "plain section")
+	assert.Equal(t, MarkdownImportOptions{Section: "Usage", HeadingLevel: 3}, parseSectionReference("Usage@h3"), "section with heading level")
+	assert.Equal(t, MarkdownImportOptions{Section: "team@h7"}, parseSectionReference("team@h7"), "out-of-range level stays part of the name")
+}
+
+func TestApplyMarkdownImportOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		opts MarkdownImportOptions
+		want string
+	}{
+		{
+			name: "section remapped to h3 shifts sub-headings",
+			opts: MarkdownImportOptions{Section: "Usage", HeadingLevel: 3},
+			want: "### Usage\n\nSee [the docs](https://example.com/docs) and ![logo](logo.png).\n\n#### Details\n\n```\n# not a heading\n[kept](https://example.com)\n```",
+		},
+		{
+			name: "heading level promotes when lower than source",
+			opts: MarkdownImportOptions{Section: "Details", HeadingLevel: 1},
+			want: "# Details\n\n```\n# not a heading\n[kept](https://example.com)\n```",
+		},
+		{
+			name: "demote headings and strip links on whole file",
+			opts: MarkdownImportOptions{DemoteHeadings: 2, StripLinks: true},
+			want: "### Guide\n\n#### Usage\n\nSee the docs and ![logo](logo.png).\n\n##### Details\n\n```\n# not a heading\n[kept](https://example.com)\n```\n\n#### Other\n\nOther text.\n",
+		},
+		{
+			name: "demotion is capped at h6",
+			opts: MarkdownImportOptions{Section: "Details", HeadingLevel: 5, DemoteHeadings: 3},
+			want: "###### Details\n\n```\n# not a heading\n[kept](https://example.com)\n```",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ApplyMarkdownImportOptions(markdownImportOptionsFixture, tt.opts)
+			require.NoError(t, err, "options should apply cleanly")
+			assert.Equal(t, tt.want, got, "transformed markdown should match")
+		})
+	}
+
+	_, err := ApplyMarkdownImportOptions(markdownImportOptionsFixture, MarkdownImportOptions{Section: "Missing"})
+	require.Error(t, err, "missing section should error")
+}
+
+func TestParseImportSpecsFromArray_MarkdownOptions(t *testing.T) {
+	specs, err := parseImportSpecsFromArray([]any{
+		map[string]any{
+			"path":            "shared/guide.md",
+			"section":         "Usage@h2",
+			"demote-headings": uint64(1),
+			"strip-links":     true,
+		},
+	})
+	require.NoError(t, err, "object-form markdown options should parse")
+	require.Len(t, specs, 1, "one spec expected")
+	assert.Equal(t, MarkdownImportOptions{Section: "Usage", HeadingLevel: 2, DemoteHeadings: 1, StripLinks: true}, specs[0].Markdown)
+
+	_, err = parseImportSpecsFromArray([]any{map[string]any{"path": "a.md", "demote-headings": 9}})
+	require.ErrorContains(t, err, "demote-headings", "out-of-range demotion should be rejected")
+
+	_, err = parseImportSpecsFromArray([]any{map[string]any{"path": "a.md", "strip-links": "yes"}})
+	require.ErrorContains(t, err, "strip-links", "non-boolean strip-links should be rejected")
+
+	_, err = mergeMarkdownImportOptions("Usage", MarkdownImportOptions{Section: "Other"})
+	require.ErrorContains(t, err, "specified twice", "section in path and object should conflict")
+}
+
+func TestProcessImports_SectionOptionsInlineMarkdown(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "test-section-import-*")
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "shared"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "shared", "guide.md"), []byte("---\ndescription: guide\n---\n"+markdownImportOptionsFixture), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "shared", "plain.md"), []byte("# Plain\n"), 0644))
+
+	frontmatter := map[string]any{
+		"on": "issues",
+		"imports": []any{
+			map[string]any{"path": "shared/guide.md", "section": "Usage@h3", "strip-links": true},
+			"shared/plain.md",
+		},
+	}
+	result, err := ProcessImportsFromFrontmatterWithSource(frontmatter, tmpDir, nil, "", "")
+	require.NoError(t, err, "imports should process")
+
+	require.Len(t, result.PromptImports, 2, "both imports should reach the prompt")
+	assert.Empty(t, result.PromptImports[0].ImportPath, "import with options should be inlined, not runtime-imported")
+	assert.Contains(t, result.PromptImports[0].Markdown, "### Usage\n\nSee the docs", "section should be remapped and links stripped")
+	assert.NotContains(t, result.PromptImports[0].Markdown, "## Other", "content outside the section should be dropped")
+	assert.NotContains(t, result.PromptImports[0].Markdown, "description: guide", "front matter should be stripped")
+	assert.Equal(t, "shared/plain.md", result.PromptImports[1].ImportPath, "plain imports should stay runtime imports")
+}
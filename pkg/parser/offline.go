@@ -0,0 +1,38 @@
+package parser
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/github/gh-aw/pkg/errorutil"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var offlineLog = logger.New("parser:offline")
+
+// offlineMode is process-wide because remote imports are resolved from many
+// entry points (ResolveIncludePath, BFS import processing, runtime imports) that
+// do not share a compiler instance.
+var offlineMode atomic.Bool
+
+// SetOfflineMode enables or disables offline mode. While enabled, remote imports are
+// served only from the import cache (.github/aw/imports) and any other remote fetch
+// fails with an error wrapping errorutil.ErrOffline.
+func SetOfflineMode(offline bool) {
+	offlineMode.Store(offline)
+}
+
+// IsOfflineMode reports whether offline mode is enabled.
+func IsOfflineMode() bool {
+	return offlineMode.Load()
+}
+
+// checkNetworkAllowed returns an error wrapping errorutil.ErrOffline when offline mode is enabled.
+// what describes the operation that would have needed the network.
+func checkNetworkAllowed(what string) error {
+	if !IsOfflineMode() {
+		return nil
+	}
+	offlineLog.Printf("Refusing network access in offline mode: %s", what)
+	return fmt.Errorf("%w: cannot %s", errorutil.ErrOffline, what)
+}
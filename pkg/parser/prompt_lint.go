@@ -0,0 +1,425 @@
+package parser
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var promptLintLog = logger.New("parser:prompt_lint")
+
+// Prompt lint rules reported by LintPrompt.
+const (
+	// PromptLintLineLength flags prompt lines longer than the configured maximum.
+	PromptLintLineLength = "line-length"
+	// PromptLintBrokenLink flags relative links that do not resolve to a file
+	// next to the fragment that contains them.
+	PromptLintBrokenLink = "broken-link"
+	// PromptLintDuplicateHeading flags headings that appear more than once in the
+	// assembled prompt.
+	PromptLintDuplicateHeading = "duplicate-heading"
+	// PromptLintTodoMarker flags TODO: markers left in the prompt, such as the
+	// customization sections written by the interactive workflow generator.
+	PromptLintTodoMarker = "todo-marker"
+)
+
+// PromptLintRules lists every prompt lint rule in reporting order.
+var PromptLintRules = []string{
+	PromptLintLineLength,
+	PromptLintBrokenLink,
+	PromptLintDuplicateHeading,
+	PromptLintTodoMarker,
+}
+
+// Prompt lint severities.
+const (
+	PromptLintSeverityError   = "error"
+	PromptLintSeverityWarning = "warning"
+	PromptLintSeverityOff     = "off"
+)
+
+// DefaultPromptLintMaxLineLength is the line length above which the line-length
+// rule reports a prompt line when no maximum is configured.
+const DefaultPromptLintMaxLineLength = 500
+
+// Disable comments recognized in prompt markdown. Each takes an optional list of
+// rule names; without one it applies to every rule.
+const (
+	promptLintDisableDirective         = "prompt-lint-disable"
+	promptLintEnableDirective          = "prompt-lint-enable"
+	promptLintDisableNextLineDirective = "prompt-lint-disable-next-line"
+)
+
+var (
+	// promptLintDirectivePattern matches a disable comment and captures its name and rules.
+	promptLintDirectivePattern = regexp.MustCompile(`^\s*(prompt-lint-(?:disable-next-line|disable|enable))\b(.*)$`)
+	// promptHeadingPattern matches an ATX heading and captures its level and text.
+	promptHeadingPattern = regexp.MustCompile(`^ {0,3}(#{1,6})\s+(.*?)(?:\s+#+)?\s*$`)
+	// promptLinkPattern matches an inline link or image and captures its target.
+	promptLinkPattern = regexp.MustCompile(`!?\[[^\]]*\]\(\s*(<[^>]*>|[^)\s]+)(?:\s+(?:"[^"]*"|'[^']*'))?\s*\)`)
+	// promptTodoPattern matches a TODO: marker.
+	promptTodoPattern = regexp.MustCompile(`\bTODO:`)
+	// promptInlineCodePattern matches an inline code span.
+	promptInlineCodePattern = regexp.MustCompile("`[^`]*`")
+	// promptURLSchemePattern matches link targets that carry a URL scheme.
+	promptURLSchemePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9+.-]*:`)
+	// promptLinkPlaceholderPattern matches link targets the agent is told to fill
+	// in, such as UPLOAD_URL or {run_url}.
+	promptLinkPlaceholderPattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$|[{}$]`)
+)
+
+// PromptLintConfig configures LintPrompt.
+type PromptLintConfig struct {
+	Rules         map[string]string // Severity per rule; unlisted rules are reported as warnings
+	MaxLineLength int               // Maximum line length; 0 uses DefaultPromptLintMaxLineLength
+}
+
+// Severity returns the configured severity for rule.
+func (c PromptLintConfig) Severity(rule string) string {
+	if severity, ok := c.Rules[rule]; ok && severity != "" {
+		return severity
+	}
+	return PromptLintSeverityWarning
+}
+
+// maxLineLength returns the configured maximum line length.
+func (c PromptLintConfig) maxLineLength() int {
+	if c.MaxLineLength > 0 {
+		return c.MaxLineLength
+	}
+	return DefaultPromptLintMaxLineLength
+}
+
+// PromptLintFragment is one markdown file that contributes to the assembled prompt.
+type PromptLintFragment struct {
+	Path    string // File path used in messages and to resolve relative links; empty when unknown
+	Content string // Full file content, including any frontmatter
+}
+
+// PromptLintIssue describes a problem found by LintPrompt.
+type PromptLintIssue struct {
+	Rule       string   // One of PromptLintRules
+	Severity   string   // PromptLintSeverityError or PromptLintSeverityWarning
+	File       string   // Fragment path, empty when unknown
+	Line       int      // 1-based line number in File
+	Column     int      // 1-based column
+	Message    string   // Human-readable description of the problem
+	Suggestion string   // How to fix the problem
+	Context    []string // Source lines centered on Line
+}
+
+// Format renders the issue as a compiler message. fallbackPath is used when the
+// issue comes from a fragment without a known path.
+func (i PromptLintIssue) Format(fallbackPath string) string {
+	file := i.File
+	if file == "" {
+		file = fallbackPath
+	}
+	return console.FormatError(console.CompilerError{
+		Position: console.ErrorPosition{
+			File:   file,
+			Line:   i.Line,
+			Column: i.Column,
+		},
+		Type:    i.Severity,
+		Message: fmt.Sprintf("%s [%s]", i.Message, i.Rule),
+		Context: i.Context,
+		Hint:    i.Suggestion,
+	})
+}
+
+// promptHeading records where a heading first appeared in the assembled prompt.
+type promptHeading struct {
+	file string
+	line int
+}
+
+// LintPrompt checks the markdown fragments of a prompt, in assembly order, for
+// overly long lines, broken relative links, headings repeated across the
+// assembled prompt and leftover TODO: markers. Frontmatter, fenced code blocks
+// and HTML comments are skipped because they do not reach the agent as prose.
+// Within one fragment a heading only duplicates a sibling under the same parent
+// heading, and headings inside {{#if}} blocks are not compared because only one
+// branch is rendered.
+// Rules can be disabled with <!-- prompt-lint-disable rule -->,
+// <!-- prompt-lint-enable rule --> and <!-- prompt-lint-disable-next-line rule -->
+// comments; without rule names the comment applies to every rule.
+func LintPrompt(fragments []PromptLintFragment, config PromptLintConfig) []PromptLintIssue {
+	headings := make(map[string]promptHeading)
+	var issues []PromptLintIssue
+	for _, fragment := range fragments {
+		issues = append(issues, lintPromptFragment(fragment, config, headings)...)
+	}
+	promptLintLog.Printf("Linted %d prompt fragments: %d issues", len(fragments), len(issues))
+	return issues
+}
+
+// lintPromptFragment lints one fragment. headings carries the headings seen in
+// earlier fragments so duplicates are detected across the assembled prompt; the
+// fragment's own headings are added to it once the fragment is done.
+func lintPromptFragment(fragment PromptLintFragment, config PromptLintConfig, headings map[string]promptHeading) []PromptLintIssue {
+	lines := strings.Split(fragment.Content, "\n")
+	suppressions := newPromptLintSuppressions()
+	var issues []PromptLintIssue
+	report := func(idx int, rule string, column int, message, suggestion string) {
+		severity := config.Severity(rule)
+		if severity == PromptLintSeverityOff || suppressions.suppressed(rule, idx) {
+			return
+		}
+		issues = append(issues, PromptLintIssue{
+			Rule:       rule,
+			Severity:   severity,
+			File:       fragment.Path,
+			Line:       idx + 1,
+			Column:     column,
+			Message:    message,
+			Suggestion: suggestion,
+			Context:    markdownIssueContext(lines, idx),
+		})
+	}
+
+	// Fences are tracked as a stack: prompt authors nest examples such as a
+	// ```bash block inside a ```markdown template, so a fence with an info
+	// string opens a nested block and a bare fence closes the innermost one.
+	var fences []string
+	inComment := false
+	conditionalDepth := 0
+	ancestors := make([]string, 7)
+	siblings := make(map[string]promptHeading)
+	fragmentHeadings := make(map[string]promptHeading)
+	for idx := markdownBodyStartIndex(lines); idx < len(lines); idx++ {
+		line := strings.TrimRight(lines[idx], "\r")
+		if !inComment {
+			if marker := promptFenceMarker(line); marker != "" {
+				info := strings.TrimSpace(strings.TrimLeft(line, " ")[len(marker):])
+				switch {
+				case len(fences) == 0 || info != "":
+					fences = append(fences, marker)
+				case marker[0] == fences[len(fences)-1][0] && len(marker) >= len(fences[len(fences)-1]):
+					fences = fences[:len(fences)-1]
+				}
+				continue
+			}
+			if len(fences) > 0 {
+				continue
+			}
+		}
+
+		visible, comments := splitPromptComments(line, &inComment)
+		for _, comment := range comments {
+			suppressions.apply(comment, idx)
+		}
+		if strings.TrimSpace(visible) == "" {
+			continue
+		}
+
+		if limit := config.maxLineLength(); len(visible) > limit && strings.ContainsAny(visible[limit:], " \t") {
+			report(idx, PromptLintLineLength, limit+1,
+				fmt.Sprintf("line is %d characters long, which exceeds the maximum of %d", len(visible), limit),
+				"wrap the line or split it into shorter sentences; set prompt.lint.max-line-length to change the limit")
+		}
+
+		conditionalDepth += strings.Count(visible, "{{#if ") - strings.Count(visible, "{{/if}}")
+		if match := promptHeadingPattern.FindStringSubmatch(visible); match != nil {
+			level := len(match[1])
+			text := strings.Join(strings.Fields(match[2]), " ")
+			clear(ancestors[level:])
+			parent := strings.Join(ancestors[1:level], "\x00")
+			ancestors[level] = strings.ToLower(text)
+			key := match[1] + " " + strings.ToLower(text)
+			here := promptHeading{file: promptLintDisplayPath(fragment.Path), line: idx + 1}
+			first, seen := headings[key]
+			if !seen {
+				first, seen = siblings[parent+"\x00"+key]
+			}
+			switch {
+			case text == "" || conditionalDepth > 0:
+			case seen:
+				report(idx, PromptLintDuplicateHeading, 1,
+					fmt.Sprintf("heading '%s %s' already appears in the assembled prompt at %s:%d", match[1], text, first.file, first.line),
+					"rename one of the sections or merge them so the agent does not receive two sections with the same title")
+			default:
+				siblings[parent+"\x00"+key] = here
+				if _, ok := fragmentHeadings[key]; !ok {
+					fragmentHeadings[key] = here
+				}
+			}
+		}
+
+		prose := promptInlineCodePattern.ReplaceAllStringFunc(visible, func(code string) string {
+			return strings.Repeat(" ", len(code))
+		})
+
+		if fragment.Path != "" {
+			for _, loc := range promptLinkPattern.FindAllStringSubmatchIndex(prose, -1) {
+				target := strings.Trim(prose[loc[2]:loc[3]], "<>")
+				if resolved, ok := resolvePromptLinkTarget(fragment.Path, target); ok && !promptLinkTargetExists(resolved) {
+					report(idx, PromptLintBrokenLink, loc[2]+1,
+						fmt.Sprintf("relative link '%s' does not resolve to an existing file (looked for %s)", target, resolved),
+						"fix the path relative to this file, or use an absolute URL")
+				}
+			}
+		}
+
+		if loc := promptTodoPattern.FindStringIndex(prose); loc != nil {
+			report(idx, PromptLintTodoMarker, loc[0]+1,
+				"prompt contains a leftover TODO: marker",
+				"finish or remove the TODO section before compiling; the agent receives it as an instruction")
+		}
+	}
+	for key, heading := range fragmentHeadings {
+		if _, ok := headings[key]; !ok {
+			headings[key] = heading
+		}
+	}
+	return issues
+}
+
+// promptFenceMarker returns the fence characters when line opens or closes a
+// fenced code block, and an empty string otherwise.
+func promptFenceMarker(line string) string {
+	trimmed := strings.TrimLeft(line, " ")
+	if len(line)-len(trimmed) > 3 {
+		return ""
+	}
+	for _, char := range []string{"`", "~"} {
+		marker := trimmed[:len(trimmed)-len(strings.TrimLeft(trimmed, char))]
+		if len(marker) >= 3 {
+			return marker
+		}
+	}
+	return ""
+}
+
+// splitPromptComments removes HTML comments from line and returns the remaining
+// text together with the text of every comment that ends on this line.
+// inComment tracks comments that span several lines.
+func splitPromptComments(line string, inComment *bool) (string, []string) {
+	var visible strings.Builder
+	var comments []string
+	rest := line
+	for rest != "" {
+		if *inComment {
+			end := strings.Index(rest, "-->")
+			if end < 0 {
+				return visible.String(), comments
+			}
+			*inComment = false
+			rest = rest[end+len("-->"):]
+			continue
+		}
+		start := strings.Index(rest, "<!--")
+		if start < 0 {
+			visible.WriteString(rest)
+			break
+		}
+		visible.WriteString(rest[:start])
+		body := rest[start+len("<!--"):]
+		end := strings.Index(body, "-->")
+		if end < 0 {
+			*inComment = true
+			break
+		}
+		comments = append(comments, body[:end])
+		rest = body[end+len("-->"):]
+	}
+	return visible.String(), comments
+}
+
+// promptLintSuppressions tracks the rules disabled by comments in one fragment.
+// The empty rule name stands for every rule; a rule-specific entry overrides it.
+type promptLintSuppressions struct {
+	disabled map[string]bool
+	nextLine map[int]map[string]bool
+}
+
+func newPromptLintSuppressions() *promptLintSuppressions {
+	return &promptLintSuppressions{
+		disabled: make(map[string]bool),
+		nextLine: make(map[int]map[string]bool),
+	}
+}
+
+// apply updates the suppressions for a comment found on line idx.
+func (s *promptLintSuppressions) apply(comment string, idx int) {
+	match := promptLintDirectivePattern.FindStringSubmatch(comment)
+	if match == nil {
+		return
+	}
+	rules := strings.Fields(strings.ReplaceAll(match[2], ",", " "))
+	if len(rules) == 0 {
+		rules = []string{""}
+	}
+	promptLintLog.Printf("Applying %s for %v at line %d", match[1], rules, idx+1)
+	for _, rule := range rules {
+		switch match[1] {
+		case promptLintDisableDirective, promptLintEnableDirective:
+			if rule == "" {
+				clear(s.disabled)
+			}
+			s.disabled[rule] = match[1] == promptLintDisableDirective
+		case promptLintDisableNextLineDirective:
+			if s.nextLine[idx+1] == nil {
+				s.nextLine[idx+1] = make(map[string]bool)
+			}
+			s.nextLine[idx+1][rule] = true
+		}
+	}
+}
+
+// suppressed reports whether rule is disabled on line idx.
+func (s *promptLintSuppressions) suppressed(rule string, idx int) bool {
+	if disabled, ok := s.disabled[rule]; ok {
+		if disabled {
+			return true
+		}
+	} else if s.disabled[""] {
+		return true
+	}
+	next := s.nextLine[idx]
+	return next[""] || next[rule]
+}
+
+// resolvePromptLinkTarget returns the file a relative link in fragmentPath points
+// to. The second return value is false for links that are not checked: URLs,
+// anchors, repository-absolute paths and placeholders or expressions.
+func resolvePromptLinkTarget(fragmentPath, target string) (string, bool) {
+	if target == "" || strings.HasPrefix(target, "#") || strings.HasPrefix(target, "/") ||
+		promptURLSchemePattern.MatchString(target) || promptLinkPlaceholderPattern.MatchString(target) {
+		return "", false
+	}
+	if idx := strings.IndexAny(target, "#?"); idx >= 0 {
+		target = target[:idx]
+	}
+	if unescaped, err := url.PathUnescape(target); err == nil {
+		target = unescaped
+	}
+	if target == "" {
+		return "", false
+	}
+	return filepath.Join(filepath.Dir(fragmentPath), filepath.FromSlash(target)), true
+}
+
+// promptLinkTargetExists reports whether a resolved link target exists on disk or
+// in the parser's virtual filesystem.
+func promptLinkTargetExists(path string) bool {
+	if _, err := os.Stat(path); err == nil {
+		return true
+	}
+	_, err := ReadFile(path)
+	return err == nil
+}
+
+// promptLintDisplayPath returns path for use in messages.
+func promptLintDisplayPath(path string) string {
+	if path == "" {
+		return "imported content"
+	}
+	return path
+}
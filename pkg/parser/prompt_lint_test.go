@@ -0,0 +1,148 @@
+//go:build !integration
+
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLintPrompt(t *testing.T) {
+	dir := testutil.TempDir(t, "prompt-lint-*")
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "shared", "docs"), 0o755), "create docs dir")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "shared", "docs", "guide.md"), []byte("# Guide\n"), 0o644), "write guide")
+	mainPath := filepath.Join(dir, "workflow.md")
+	importPath := filepath.Join(dir, "shared", "context.md")
+	longLine := strings.Repeat("word ", 30)
+
+	tests := []struct {
+		name      string
+		fragments []PromptLintFragment
+		config    PromptLintConfig
+		wantRules []string
+		wantLines []int
+	}{
+		{
+			name: "clean prompt",
+			fragments: []PromptLintFragment{
+				{Path: importPath, Content: "## Context\n\nSee the [guide](docs/guide.md#usage) and [site](https://example.com).\n\n![Chart](UPLOAD_URL_CHART) from [the run]({run_url}).\n"},
+				{Path: mainPath, Content: "---\non: issues\n---\n# Triage\n\nLabel the issue.\n"},
+			},
+		},
+		{
+			name: "long line",
+			fragments: []PromptLintFragment{
+				{Path: mainPath, Content: "# Report\n\n" + longLine + "\n"},
+			},
+			config:    PromptLintConfig{MaxLineLength: 100},
+			wantRules: []string{PromptLintLineLength},
+			wantLines: []int{3},
+		},
+		{
+			name: "long line without spaces past the limit",
+			fragments: []PromptLintFragment{
+				{Path: mainPath, Content: "See https://example.com/" + strings.Repeat("a", 200) + "\n"},
+			},
+			config: PromptLintConfig{MaxLineLength: 100},
+		},
+		{
+			name: "broken relative link in import",
+			fragments: []PromptLintFragment{
+				{Path: importPath, Content: "## Context\n\nRead [the guide](../docs/guide.md) and ![diagram](<docs/missing image.png>).\n"},
+			},
+			wantRules: []string{PromptLintBrokenLink, PromptLintBrokenLink},
+			wantLines: []int{3, 3},
+		},
+		{
+			name: "duplicate heading across fragments",
+			fragments: []PromptLintFragment{
+				{Path: importPath, Content: "## Guidelines\n\nBe concise.\n"},
+				{Path: mainPath, Content: "---\non: issues\n---\n# Triage\n\n##  guidelines\n\nBe kind.\n\n### Guidelines\n"},
+			},
+			wantRules: []string{PromptLintDuplicateHeading},
+			wantLines: []int{6},
+		},
+		{
+			name: "duplicate heading among siblings",
+			fragments: []PromptLintFragment{
+				{Path: mainPath, Content: "# Review\n\n## Style\n\n### Checks\n\n## Tests\n\n### Checks\n\n## Style\n"},
+			},
+			wantRules: []string{PromptLintDuplicateHeading},
+			wantLines: []int{11},
+		},
+		{
+			name: "headings in conditional branches and nested fences",
+			fragments: []PromptLintFragment{
+				{Path: mainPath, Content: "# Bot\n\n{{#if experiments.concise }}\n## Tasks\n{{else}}\n## Tasks\n{{/if}}\n\n```markdown\n## Summary\n```bash\nmake test\n```\n## Summary\n```\n"},
+			},
+		},
+		{
+			name: "todo marker from the interactive generator",
+			fragments: []PromptLintFragment{
+				{Path: mainPath, Content: "# Bot\n\n## TODO: Customize this workflow\n\nFind `TODO:` comments in the code.\n"},
+			},
+			wantRules: []string{PromptLintTodoMarker},
+			wantLines: []int{3},
+		},
+		{
+			name: "code blocks and comments are skipped",
+			fragments: []PromptLintFragment{
+				{Path: mainPath, Content: "# Bot\n\n<!--\n## TODO: Customize this workflow\n-->\n\n```markdown\n# Bot\nTODO: fill in\n```\n"},
+			},
+		},
+		{
+			name: "disable comments",
+			fragments: []PromptLintFragment{
+				{Path: mainPath, Content: "# Bot\n\n<!-- prompt-lint-disable-next-line todo-marker -->\nTODO: one\nTODO: two\n<!-- prompt-lint-disable -->\n[x](missing.md) TODO: three\n<!-- prompt-lint-enable broken-link -->\n[y](missing.md) TODO: four\n"},
+			},
+			wantRules: []string{PromptLintTodoMarker, PromptLintBrokenLink},
+			wantLines: []int{5, 9},
+		},
+		{
+			name: "rules turned off",
+			fragments: []PromptLintFragment{
+				{Path: mainPath, Content: "# Bot\n\nTODO: one\n"},
+			},
+			config: PromptLintConfig{Rules: map[string]string{PromptLintTodoMarker: PromptLintSeverityOff}},
+		},
+		{
+			name: "links are not checked without a fragment path",
+			fragments: []PromptLintFragment{
+				{Content: "[x](missing.md)\n"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := LintPrompt(tt.fragments, tt.config)
+			var rules []string
+			var lines []int
+			for _, issue := range issues {
+				rules = append(rules, issue.Rule)
+				lines = append(lines, issue.Line)
+			}
+			assert.Equal(t, tt.wantRules, rules, "reported rules")
+			assert.Equal(t, tt.wantLines, lines, "reported lines")
+		})
+	}
+}
+
+func TestLintPromptSeverity(t *testing.T) {
+	issues := LintPrompt([]PromptLintFragment{{Path: "workflow.md", Content: "# Bot\n\nTODO: one\n"}},
+		PromptLintConfig{Rules: map[string]string{PromptLintTodoMarker: PromptLintSeverityError}})
+	require.Len(t, issues, 1, "todo marker should be reported")
+	assert.Equal(t, PromptLintSeverityError, issues[0].Severity, "configured severity should be used")
+	assert.Equal(t, PromptLintSeverityWarning, PromptLintConfig{}.Severity(PromptLintLineLength), "rules default to warnings")
+
+	formatted := issues[0].Format("fallback.md")
+	assert.Contains(t, formatted, "workflow.md:3:1", "position should be included")
+	assert.Contains(t, formatted, "[todo-marker]", "rule name should be included")
+	assert.Contains(t, LintPrompt([]PromptLintFragment{{Content: "TODO: x\n"}}, PromptLintConfig{})[0].Format("fallback.md"), "fallback.md", "fragments without a path should use the fallback path")
+}
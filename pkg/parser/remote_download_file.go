@@ -48,6 +48,9 @@ func downloadFileFromGitHub(ctx context.Context, owner, repo, path, ref string)
 }
 
 func downloadFileFromGitHubWithDepth(ctx context.Context, owner, repo, path, ref string, symlinkDepth int, host string) ([]byte, error) {
+	if err := checkNetworkAllowed(fmt.Sprintf("download %s/%s/%s@%s", owner, repo, path, ref)); err != nil {
+		return nil, err
+	}
 	client, err := createRESTClientForHost(host)
 	if err != nil {
 		if gitutil.IsAuthError(err.Error()) {
@@ -372,13 +375,6 @@ func downloadFileViaRawURL(ctx context.Context, owner, repo, filePath, ref strin
 func downloadFileViaGitClone(ctx context.Context, owner, repo, path, ref, host string) ([]byte, error) {
 	remoteLog.Printf("Attempting git clone fallback for %s/%s/%s@%s", owner, repo, path, ref)
 
-	// Create a temporary directory for the shallow clone
-	tmpDir, err := os.MkdirTemp("", "gh-aw-git-clone-*")
-	if err != nil {
-		return nil, fmt.Errorf("failed to create temp directory: %w", err)
-	}
-	defer os.RemoveAll(tmpDir)
-
 	var githubHost string
 	if host != "" {
 		githubHost = "https://" + host
@@ -387,6 +383,25 @@ func downloadFileViaGitClone(ctx context.Context, owner, repo, path, ref, host s
 	}
 	repoURL := fmt.Sprintf("%s/%s/%s.git", githubHost, owner, repo)
 
+	content, err := readFileFromGitClone(ctx, repoURL, path, ref)
+	if err != nil {
+		return nil, err
+	}
+	remoteLog.Printf("Successfully downloaded file via git clone: %s/%s/%s@%s", owner, repo, path, ref)
+	return content, nil
+}
+
+// readFileFromGitClone clones repoURL at ref into a temporary directory and reads path from it.
+// SHA refs are checked out after a full-history fallback clone; branch and tag refs use a
+// shallow --branch clone.
+func readFileFromGitClone(ctx context.Context, repoURL, path, ref string) ([]byte, error) {
+	// Create a temporary directory for the shallow clone
+	tmpDir, err := os.MkdirTemp("", "gh-aw-git-clone-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
 	// Check if ref is a SHA (40 hex characters)
 	isSHA := len(ref) == 40 && gitutil.IsHexString(ref)
 
@@ -426,7 +441,5 @@ func downloadFileViaGitClone(ctx context.Context, owner, repo, path, ref, host s
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file from cloned repository: %w", err)
 	}
-
-	remoteLog.Printf("Successfully downloaded file via git clone: %s/%s/%s@%s", owner, repo, path, ref)
 	return content, nil
 }
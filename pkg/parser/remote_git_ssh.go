@@ -0,0 +1,79 @@
+//go:build !js && !wasm
+
+package parser
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/errorutil"
+	"github.com/github/gh-aw/pkg/gitutil"
+)
+
+// resolveGitSSHRefToSHAFunc allows tests to inject a stub for git ls-remote over SSH.
+var resolveGitSSHRefToSHAFunc = resolveRefToSHAViaGitURL
+
+// readGitSSHFileFunc allows tests to inject a stub for reading a file from an SSH clone.
+var readGitSSHFileFunc = readFileFromGitClone
+
+// repoURL returns the ssh:// clone URL for the spec, resolving an empty host to the
+// GitHub host configured for gh so GHES users can write git+ssh:///owner/repo/path.md.
+func (s *gitSSHImportSpec) repoURL() string {
+	host := s.Host
+	if host == "" {
+		host = strings.TrimPrefix(strings.TrimPrefix(GetGitHubHost(), "https://"), "http://")
+	}
+	return fmt.Sprintf("ssh://%s@%s/%s/%s.git", s.User, host, s.Owner, s.Repo)
+}
+
+// downloadIncludeFromGitSSHSpec fetches a git+ssh:// import. Like workflowspec imports,
+// the ref is resolved to a commit SHA first so the import cache is keyed by the pinned
+// commit and later compiles reuse the cached copy instead of contacting the remote.
+func downloadIncludeFromGitSSHSpec(spec string, cache *ImportCache) (string, error) {
+	parsed, err := parseGitSSHImportSpec(spec)
+	if err != nil {
+		return "", err
+	}
+	if IsOfflineMode() {
+		if cache == nil {
+			return "", fmt.Errorf("%w: cannot download include %s without an import cache", errorutil.ErrOffline, spec)
+		}
+		return cache.GetOffline(parsed.Owner, parsed.Repo, parsed.FilePath, parsed.Ref)
+	}
+
+	repoURL := parsed.repoURL()
+	ctx := context.Background()
+
+	sha := parsed.Ref
+	if len(sha) != 40 || !gitutil.IsHexString(sha) {
+		sha, err = resolveGitSSHRefToSHAFunc(ctx, repoURL, parsed.Ref)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve %s@%s over SSH (check that your SSH key has access): %w", repoURL, parsed.Ref, err)
+		}
+	}
+	remoteLog.Printf("Resolved git+ssh import %s to commit %s", spec, sha)
+
+	if cache != nil {
+		if cachedPath, found := cache.Get(parsed.Owner, parsed.Repo, parsed.FilePath, sha); found {
+			remoteLog.Printf("Using cached git+ssh import: %s/%s/%s@%s", parsed.Owner, parsed.Repo, parsed.FilePath, sha)
+			return cachedPath, nil
+		}
+	}
+
+	content, err := readGitSSHFileFunc(ctx, repoURL, parsed.FilePath, sha)
+	if err != nil {
+		return "", fmt.Errorf("failed to download include from %s: %w", spec, err)
+	}
+	remoteLog.Printf("Downloaded git+ssh import: size=%d bytes", len(content))
+
+	if cache != nil {
+		cachedPath, err := cache.Set(parsed.Owner, parsed.Repo, parsed.FilePath, sha, content)
+		if err != nil {
+			remoteLog.Printf("Failed to cache git+ssh import: %v", err)
+		} else {
+			return cachedPath, nil
+		}
+	}
+	return writeDownloadedIncludeToTempFile(content)
+}
@@ -177,6 +177,9 @@ func ListWorkflowFilesForHost(ctx context.Context, owner, repo, ref, workflowPat
 }
 
 func listWorkflowFilesForHost(ctx context.Context, owner, repo, ref, workflowPath, host string) ([]string, error) {
+	if err := checkNetworkAllowed(fmt.Sprintf("list %s/%s/%s@%s", owner, repo, workflowPath, ref)); err != nil {
+		return nil, err
+	}
 	remoteLog.Printf("Listing workflow files for %s/%s@%s (path: %s)", owner, repo, ref, workflowPath)
 
 	client, err := createRESTClientForHost(host)
@@ -303,6 +306,9 @@ func ListDirAllFilesForHost(ctx context.Context, owner, repo, ref, dirPath, host
 }
 
 func listDirAllFilesForHost(ctx context.Context, owner, repo, ref, dirPath, host string) ([]string, error) {
+	if err := checkNetworkAllowed(fmt.Sprintf("list %s/%s/%s@%s", owner, repo, dirPath, ref)); err != nil {
+		return nil, err
+	}
 	remoteLog.Printf("Listing all files in dir for %s/%s@%s (path: %s)", owner, repo, ref, dirPath)
 
 	client, err := createRESTClientForHost(host)
@@ -416,6 +422,9 @@ func ListDirAllFilesRecursivelyForHost(ctx context.Context, owner, repo, ref, di
 }
 
 func listDirAllFilesRecursivelyForHost(ctx context.Context, owner, repo, ref, dirPath, host string) ([]string, error) {
+	if err := checkNetworkAllowed(fmt.Sprintf("list %s/%s/%s@%s", owner, repo, dirPath, ref)); err != nil {
+		return nil, err
+	}
 	remoteLog.Printf("Listing all files recursively in dir for %s/%s@%s (path: %s)", owner, repo, ref, dirPath)
 
 	client, err := createRESTClientForHost(host)
@@ -523,6 +532,9 @@ func ListDirSubdirsForHost(ctx context.Context, owner, repo, ref, dirPath, host
 }
 
 func listDirSubdirsForHost(ctx context.Context, owner, repo, ref, dirPath, host string) ([]string, error) {
+	if err := checkNetworkAllowed(fmt.Sprintf("list %s/%s/%s@%s", owner, repo, dirPath, ref)); err != nil {
+		return nil, err
+	}
 	remoteLog.Printf("Listing subdirs in %s/%s@%s (path: %s)", owner, repo, ref, dirPath)
 
 	client, err := createRESTClientForHost(host)
@@ -106,6 +106,16 @@ func ResolveIncludePath(filePath, baseDir string, cache *ImportCache) (string, e
 		return builtinPath, err
 	}
 
+	if IsImportAlias(filePath) {
+		remoteLog.Printf("Detected import alias: %s", filePath)
+		return resolveImportAlias(filePath, baseDir)
+	}
+
+	if IsGitSSHImportSpec(filePath) {
+		remoteLog.Printf("Detected git+ssh import: %s", filePath)
+		return downloadIncludeFromGitSSHSpec(filePath, cache)
+	}
+
 	if IsWorkflowSpec(filePath) {
 		remoteLog.Printf("Detected workflowspec format: %s", filePath)
 		return downloadIncludeFromWorkflowSpec(filePath, cache)
@@ -116,6 +126,53 @@ func ResolveIncludePath(filePath, baseDir string, cache *ImportCache) (string, e
 	return resolveAndValidateLocalIncludePath(normalizedFilePath, resolveBase, securityBase)
 }
 
+// findImportAliasRepoRoot walks up from baseDir to the first directory that contains a
+// .github folder. Unlike findGitHubFolder it also works for files imported through an
+// alias, which typically live outside .github.
+func findImportAliasRepoRoot(baseDir string) (string, bool) {
+	dir := filepath.Clean(baseDir)
+	for {
+		if info, err := os.Stat(filepath.Join(dir, ".github")); err == nil && info.IsDir() {
+			return dir, true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// resolveImportAlias expands an @alias/path import against the alias table of the
+// repository containing baseDir. The resolved file must stay inside the alias target.
+func resolveImportAlias(importPath, baseDir string) (string, error) {
+	repoRoot, ok := findImportAliasRepoRoot(baseDir)
+	if !ok {
+		return "", fmt.Errorf("cannot resolve import alias in %s: no repository with a .github folder found above %s", importPath, baseDir)
+	}
+	aliases, err := loadImportAliases(repoRoot)
+	if err != nil {
+		return "", err
+	}
+	name, rest, _ := strings.Cut(filepath.ToSlash(importPath), "/")
+	target, ok := aliases[name]
+	if !ok {
+		return "", fmt.Errorf("unknown import alias %s; declare it under aliases: in %s", name, ImportAliasConfigFile)
+	}
+
+	targetDir := filepath.Join(repoRoot, filepath.FromSlash(target))
+	fullPath := filepath.Join(targetDir, filepath.FromSlash(rest))
+	relativePath, err := filepath.Rel(targetDir, fullPath)
+	if err != nil || relativePath == ".." || strings.HasPrefix(relativePath, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("security: import %s must stay within the %s alias target %s", importPath, name, target)
+	}
+	if _, err := os.Stat(fullPath); os.IsNotExist(err) {
+		return "", fmt.Errorf("file not found: %s (%s -> %s)", fullPath, name, target)
+	}
+	importAliasesLog.Printf("Resolved import alias %s -> %s", importPath, fullPath)
+	return fullPath, nil
+}
+
 func resolveBuiltinIncludePath(filePath string) (string, bool, error) {
 	if !strings.HasPrefix(filePath, BuiltinPathPrefix) {
 		return "", false, nil
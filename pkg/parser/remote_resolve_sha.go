@@ -36,6 +36,12 @@ func resolveRefToSHAViaGit(ctx context.Context, owner, repo, ref, host string) (
 	}
 	repoURL := fmt.Sprintf("%s/%s/%s.git", githubHost, owner, repo)
 
+	return resolveRefToSHAViaGitURL(ctx, repoURL, ref)
+}
+
+// resolveRefToSHAViaGitURL resolves a git ref to SHA by running git ls-remote against repoURL.
+// It is shared by the HTTPS fallback above and git+ssh:// imports.
+func resolveRefToSHAViaGitURL(ctx context.Context, repoURL, ref string) (string, error) {
 	// Try to resolve the ref using git ls-remote
 	// Format: git ls-remote <repo> <ref>
 	cmd := exec.CommandContext(ctx, "git", "ls-remote", repoURL, ref)
@@ -74,7 +80,7 @@ func resolveRefToSHAViaGit(ctx context.Context, owner, repo, ref, host string) (
 		return "", fmt.Errorf("invalid SHA format from git ls-remote: %s", sha)
 	}
 
-	remoteLog.Printf("Successfully resolved ref via git ls-remote: %s/%s@%s -> %s", owner, repo, ref, sha)
+	remoteLog.Printf("Successfully resolved ref via git ls-remote: %s@%s -> %s", repoURL, ref, sha)
 	return sha, nil
 }
 
@@ -84,6 +90,9 @@ func resolveRefToSHA(ctx context.Context, owner, repo, ref, host string) (string
 	if len(ref) == 40 && gitutil.IsHexString(ref) {
 		return ref, nil
 	}
+	if err := checkNetworkAllowed(fmt.Sprintf("resolve %s/%s@%s to a commit", owner, repo, ref)); err != nil {
+		return "", err
+	}
 
 	client, err := createRESTClientForHostFunc(host)
 	if err != nil {
@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"os"
 	"strings"
+
+	"github.com/github/gh-aw/pkg/errorutil"
 )
 
 // IsWorkflowSpec checks if a path looks like a workflowspec (owner/repo/path[@ref]).
@@ -75,6 +77,13 @@ func downloadIncludeFromWorkflowSpec(spec string, cache *ImportCache) (string, e
 	}
 	remoteLog.Printf("Parsed workflowspec: host=%s, owner=%s, repo=%s, file=%s, ref=%s", host, owner, repo, filePath, ref)
 
+	if IsOfflineMode() {
+		if cache == nil {
+			return "", fmt.Errorf("%w: cannot download include %s without an import cache", errorutil.ErrOffline, spec)
+		}
+		return cache.GetOffline(owner, repo, filePath, ref)
+	}
+
 	sha := resolveWorkflowSpecSHAForCache(owner, repo, ref, host, cache)
 	if cache != nil && sha != "" {
 		if cachedPath, found := cache.Get(owner, repo, filePath, sha); found {
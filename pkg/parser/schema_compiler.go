@@ -32,6 +32,12 @@ var RepoConfigSchema string
 //go:embed schemas/aw_manifest_schema.json
 var awManifestSchema string
 
+// GetMainWorkflowSchema returns the embedded frontmatter JSON schema that workflows are
+// validated against, so it can be exported for editors and other tooling.
+func GetMainWorkflowSchema() string {
+	return mainWorkflowSchema
+}
+
 // validateWithSchema validates frontmatter against a JSON schema
 // Cached compiled schemas to avoid recompiling on every validation
 var (
@@ -163,6 +169,10 @@ var safeOutputMetaFields = map[string]bool{
 	"messages":        true,
 	"needs":           true,
 	"timeout-minutes": true,
+	"retry":           true,
+	"max-total":       true,
+	"priority":        true,
+	"cloud":           true,
 }
 
 // GetSafeOutputTypeKeys returns the list of safe output type keys from the embedded main workflow schema.
@@ -512,7 +522,9 @@ func formatSchemaFailureDetail(pathInfo JSONPathInfo, schemaJSON, frontmatterCon
 	// When a hint was added we skip generateSchemaBasedSuggestions to avoid repeating the
 	// same valid-values or "Did you mean" content.
 	message, hintAdded := appendKnownFieldValidValuesHint(message, pathInfo.Path)
-	if !hintAdded {
+	// Explain renamed and removed fields with their replacement and migration command.
+	message, migrationAdded := appendFieldMigrationHints(message, path)
+	if !hintAdded && !migrationAdded {
 		suggestions := generateSchemaBasedSuggestions(schemaJSON, pathInfo.Message, pathInfo.Path, frontmatterContent)
 		if suggestions != "" {
 			message = message + ". " + suggestions
@@ -187,7 +187,7 @@ func generateFieldSuggestions(invalidProps, acceptedFields []string) string {
 	// Find closest matches using Levenshtein distance
 	var suggestions []string
 	for _, invalidProp := range invalidProps {
-		closest := stringutil.FindClosestMatches(invalidProp, acceptedFields, maxClosestMatches)
+		closest := closestSuggestions(invalidProp, acceptedFields, maxClosestMatches)
 		suggestions = append(suggestions, closest...)
 	}
 
@@ -226,11 +226,12 @@ func generateFieldSuggestions(invalidProps, acceptedFields []string) string {
 }
 
 // FindClosestMatches finds the closest matching strings using Levenshtein distance.
-// It returns up to maxResults matches that have a Levenshtein distance of 3 or less.
-// Results are sorted by distance (closest first), then alphabetically for ties.
+// It returns up to maxResults matches within the configured suggestion distance
+// (3 by default), preceded by any project synonym for target (see SetSuggestionConfig).
+// Fuzzy results are sorted by distance (closest first), then alphabetically for ties.
 func FindClosestMatches(target string, candidates []string, maxResults int) []string {
 	schemaSuggestionsLog.Printf("Finding closest matches for '%s' from %d candidates", target, len(candidates))
-	results := stringutil.FindClosestMatches(target, candidates, maxResults)
+	results := closestSuggestions(target, candidates, maxResults)
 	schemaSuggestionsLog.Printf("Found %d closest matches", len(results))
 	return results
 }
@@ -302,7 +303,7 @@ func enumSuggestion(errorMessage, jsonPath, frontmatterContent string) (string,
 		return "", true
 	}
 
-	closest := sliceutil.Deduplicate(stringutil.FindClosestMatches(userValue, enumValues, maxClosestMatches))
+	closest := sliceutil.Deduplicate(closestSuggestions(userValue, enumValues, maxClosestMatches))
 	if len(closest) == 1 {
 		return fmt.Sprintf("Did you mean '%s'?", closest[0]), true
 	}
@@ -0,0 +1,117 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/sliceutil"
+	"github.com/github/gh-aw/pkg/stringutil"
+)
+
+var suggestionConfigLog = logger.New("parser:suggestion_config")
+
+// maxSuggestionDistanceLimit caps max-distance; larger values suggest unrelated names.
+const maxSuggestionDistanceLimit = 10
+
+// SuggestionConfig customizes the "Did you mean" suggestions shown for unknown
+// frontmatter fields and values. Projects configure it in .github/aw/suggestions.json.
+type SuggestionConfig struct {
+	// MaxDistance is the maximum Levenshtein distance for fuzzy suggestions.
+	// Zero uses stringutil.DefaultMaxMatchDistance.
+	MaxDistance int `json:"max-distance,omitempty"`
+	// Synonyms maps project terminology to field or value names, for example
+	// "pr" to "pull_request". A synonym is suggested only where its target is valid.
+	Synonyms map[string]string `json:"synonyms,omitempty"`
+}
+
+var suggestionConfig struct {
+	mu     sync.RWMutex
+	config SuggestionConfig
+}
+
+// ParseSuggestionConfig parses and validates a suggestions.json document.
+func ParseSuggestionConfig(content []byte) (SuggestionConfig, error) {
+	var config SuggestionConfig
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&config); err != nil {
+		return SuggestionConfig{}, fmt.Errorf("invalid suggestion config: %w", err)
+	}
+	if config.MaxDistance < 0 || config.MaxDistance > maxSuggestionDistanceLimit {
+		return SuggestionConfig{}, fmt.Errorf("invalid suggestion config: max-distance must be between 0 and %d, got %d", maxSuggestionDistanceLimit, config.MaxDistance)
+	}
+	var errs []error
+	for _, term := range sliceutil.SortedKeys(config.Synonyms) {
+		if strings.TrimSpace(term) == "" || strings.TrimSpace(config.Synonyms[term]) == "" {
+			errs = append(errs, fmt.Errorf("synonym %q -> %q must have a non-empty term and target", term, config.Synonyms[term]))
+		}
+	}
+	if len(errs) > 0 {
+		return SuggestionConfig{}, fmt.Errorf("invalid suggestion config: %w", errors.Join(errs...))
+	}
+	return config, nil
+}
+
+// SetSuggestionConfig sets the suggestion settings used by FindClosestMatches and
+// schema validation errors. Pass a zero SuggestionConfig to restore the defaults.
+func SetSuggestionConfig(config SuggestionConfig) {
+	suggestionConfigLog.Printf("Setting suggestion config: maxDistance=%d, synonyms=%d", config.MaxDistance, len(config.Synonyms))
+	suggestionConfig.mu.Lock()
+	defer suggestionConfig.mu.Unlock()
+	suggestionConfig.config = config
+}
+
+func getSuggestionConfig() SuggestionConfig {
+	suggestionConfig.mu.RLock()
+	defer suggestionConfig.mu.RUnlock()
+	return suggestionConfig.config
+}
+
+// closestSuggestions returns synonym matches for target followed by fuzzy matches,
+// up to maxResults in total. Synonyms are compared case-insensitively and are only
+// returned when their target is one of the candidates.
+func closestSuggestions(target string, candidates []string, maxResults int) []string {
+	config := getSuggestionConfig()
+
+	var results []string
+	if synonym, ok := lookupSynonym(config.Synonyms, target); ok {
+		for _, candidate := range candidates {
+			if strings.EqualFold(candidate, synonym) {
+				suggestionConfigLog.Printf("Synonym %q -> %q matched a candidate", target, candidate)
+				results = append(results, candidate)
+				break
+			}
+		}
+	}
+
+	maxDistance := config.MaxDistance
+	if maxDistance == 0 {
+		maxDistance = stringutil.DefaultMaxMatchDistance
+	}
+	for _, match := range stringutil.FindClosestMatchesWithin(target, candidates, maxResults, maxDistance) {
+		if len(results) >= maxResults {
+			break
+		}
+		if len(results) == 0 || results[0] != match {
+			results = append(results, match)
+		}
+	}
+	return results
+}
+
+func lookupSynonym(synonyms map[string]string, term string) (string, bool) {
+	if synonym, ok := synonyms[term]; ok {
+		return synonym, true
+	}
+	for _, key := range sliceutil.SortedKeys(synonyms) {
+		if strings.EqualFold(key, term) {
+			return synonyms[key], true
+		}
+	}
+	return "", false
+}
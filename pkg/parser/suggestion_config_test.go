@@ -0,0 +1,77 @@
+//go:build !integration
+
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setTestSuggestionConfig(t *testing.T, config SuggestionConfig) {
+	t.Helper()
+	SetSuggestionConfig(config)
+	t.Cleanup(func() { SetSuggestionConfig(SuggestionConfig{}) })
+}
+
+func TestParseSuggestionConfig(t *testing.T) {
+	config, err := ParseSuggestionConfig([]byte(`{"max-distance": 2, "synonyms": {"pr": "pull_request", "creds": "github-token"}}`))
+	require.NoError(t, err, "valid config should parse")
+	assert.Equal(t, 2, config.MaxDistance, "max-distance should be parsed")
+	assert.Equal(t, "github-token", config.Synonyms["creds"], "synonyms should be parsed")
+
+	tests := []struct {
+		name        string
+		content     string
+		errContains string
+	}{
+		{name: "unknown field", content: `{"distance": 2}`, errContains: "unknown field"},
+		{name: "negative distance", content: `{"max-distance": -1}`, errContains: "max-distance must be between 0 and 10"},
+		{name: "distance too large", content: `{"max-distance": 11}`, errContains: "max-distance must be between 0 and 10"},
+		{name: "empty target", content: `{"synonyms": {"pr": ""}}`, errContains: "non-empty term and target"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseSuggestionConfig([]byte(tt.content))
+			require.Error(t, err, "invalid config should be rejected")
+			assert.Contains(t, err.Error(), tt.errContains, "error should describe the problem")
+		})
+	}
+}
+
+func TestFindClosestMatches_SuggestionConfig(t *testing.T) {
+	candidates := []string{"pull_request", "push", "issues", "timeout-minutes"}
+
+	assert.Equal(t, []string{"push"}, FindClosestMatches("pr", candidates, 3), "only fuzzy matches without a synonym")
+	assert.Equal(t, []string{"timeout-minutes"}, FindClosestMatches("timeout-mins", candidates, 3), "default distance should allow 3 edits")
+
+	setTestSuggestionConfig(t, SuggestionConfig{MaxDistance: 2, Synonyms: map[string]string{"PR": "pull_request", "creds": "github-token"}})
+	assert.Equal(t, []string{"pull_request"}, FindClosestMatches("pr", candidates, 3), "synonym should be suggested, case-insensitively")
+	assert.Empty(t, FindClosestMatches("creds", candidates, 3), "synonym should be skipped when its target is not a candidate")
+	assert.Empty(t, FindClosestMatches("timeout-mins", candidates, 3), "configured distance should reject 3 edits")
+	assert.Equal(t, []string{"issues"}, FindClosestMatches("isues", candidates, 3), "fuzzy matching should still apply")
+}
+
+func TestGenerateSchemaBasedSuggestions_Synonyms(t *testing.T) {
+	schemaJSON := `{
+		"type": "object",
+		"properties": {
+			"safe-outputs": {
+				"type": "object",
+				"properties": {
+					"github-token": {"type": "string"},
+					"create-issue": {"type": "object"}
+				},
+				"additionalProperties": false
+			}
+		},
+		"additionalProperties": false
+	}`
+	errorMessage := "additional property 'creds' not allowed"
+
+	assert.NotContains(t, generateSchemaBasedSuggestions(schemaJSON, errorMessage, "/safe-outputs", ""), "Did you mean", "no close match without a synonym")
+
+	setTestSuggestionConfig(t, SuggestionConfig{Synonyms: map[string]string{"creds": "github-token"}})
+	assert.Contains(t, generateSchemaBasedSuggestions(schemaJSON, errorMessage, "/safe-outputs", ""), "Did you mean 'github-token'?", "synonym should be suggested")
+}
@@ -10,21 +10,29 @@ import (
 
 var fuzzyMatchLog = logger.New("stringutil:fuzzy_match")
 
+// DefaultMaxMatchDistance is the maximum Levenshtein distance accepted by FindClosestMatches.
+const DefaultMaxMatchDistance = 3
+
 // FindClosestMatches finds the closest matching strings using Levenshtein distance.
-// It returns up to maxResults matches that have a Levenshtein distance of 3 or less.
+// It returns up to maxResults matches that have a Levenshtein distance of
+// DefaultMaxMatchDistance or less.
 // Results are sorted by distance (closest first), then alphabetically for ties.
 //
 // This function is useful for "Did you mean?" suggestions when a user provides
 // an unrecognized value (e.g., a typo in an engine name or event type).
 func FindClosestMatches(target string, candidates []string, maxResults int) []string {
-	fuzzyMatchLog.Printf("FindClosestMatches: target=%q, candidates=%d, maxResults=%d", target, len(candidates), maxResults)
+	return FindClosestMatchesWithin(target, candidates, maxResults, DefaultMaxMatchDistance)
+}
+
+// FindClosestMatchesWithin is FindClosestMatches with a caller-supplied maximum
+// Levenshtein distance.
+func FindClosestMatchesWithin(target string, candidates []string, maxResults, maxDistance int) []string {
+	fuzzyMatchLog.Printf("FindClosestMatches: target=%q, candidates=%d, maxResults=%d, maxDistance=%d", target, len(candidates), maxResults, maxDistance)
 	type match struct {
 		value    string
 		distance int
 	}
 
-	const maxDistance = 3 // Maximum acceptable Levenshtein distance
-
 	var matches []match
 	targetLower := strings.ToLower(target)
 
@@ -124,6 +124,13 @@ func TestFindClosestMatches(t *testing.T) {
 	}
 }
 
+func TestFindClosestMatchesWithin(t *testing.T) {
+	candidates := []string{"timeout-minutes", "runs-on"}
+	assert.Empty(t, FindClosestMatchesWithin("timeout_mins", candidates, 3, 2), "distance 4 should be rejected with a limit of 2")
+	assert.Equal(t, []string{"timeout-minutes"}, FindClosestMatchesWithin("timeout_mins", candidates, 3, 4), "distance 4 should be accepted with a limit of 4")
+	assert.Equal(t, FindClosestMatches("runs_on", candidates, 3), FindClosestMatchesWithin("runs_on", candidates, 3, DefaultMaxMatchDistance), "default limit should match FindClosestMatches")
+}
+
 func TestLevenshteinDistance(t *testing.T) {
 	tests := []struct {
 		name string
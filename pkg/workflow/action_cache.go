@@ -33,12 +33,15 @@ type ActionCacheEntry struct {
 	ReleasedAt        *time.Time                  `json:"released_at,omitempty"`        // publication date of this release, used for cooldown checks
 	Inputs            map[string]*ActionYAMLInput `json:"inputs,omitempty"`             // cached inputs from action.yml
 	ActionDescription string                      `json:"action_description,omitempty"` // cached description from action.yml
+	Pin               bool                        `json:"pin,omitempty"`                // hold this entry at its current version during `gh aw update`
+	IgnoreMajor       bool                        `json:"ignore-major,omitempty"`       // only apply minor/patch updates during `gh aw update`
 }
 
 // ActionCache manages cached action pin resolutions.
 type ActionCache struct {
 	Entries       map[string]ActionCacheEntry `json:"entries"`              // key: "repo@version"
 	ContainerPins map[string]ContainerPin     `json:"containers,omitempty"` // key: image tag
+	MCPPins       map[string]MCPPackagePin    `json:"mcp,omitempty"`        // key: "registry:package" (e.g. "npm:@scope/server")
 	path          string
 	dirty         bool // tracks if cache has unsaved changes
 }
@@ -50,6 +53,7 @@ func NewActionCache(repoRoot string) *ActionCache {
 	return &ActionCache{
 		Entries:       make(map[string]ActionCacheEntry),
 		ContainerPins: make(map[string]ContainerPin),
+		MCPPins:       make(map[string]MCPPackagePin),
 		path:          cachePath,
 		// dirty is initialized to false (zero value)
 	}
@@ -199,11 +203,14 @@ func (c *ActionCache) Load() error {
 	if c.ContainerPins == nil {
 		c.ContainerPins = make(map[string]ContainerPin)
 	}
+	if c.MCPPins == nil {
+		c.MCPPins = make(map[string]MCPPackagePin)
+	}
 
 	// Mark cache as clean after successful load (it matches disk state)
 	c.dirty = false
 
-	actionCacheLog.Printf("Successfully loaded cache with %d entries, %d container pins", len(c.Entries), len(c.ContainerPins))
+	actionCacheLog.Printf("Successfully loaded cache with %d entries, %d container pins, %d MCP pins", len(c.Entries), len(c.ContainerPins), len(c.MCPPins))
 	return nil
 }
 
@@ -220,8 +227,8 @@ func (c *ActionCache) Save() error {
 
 	actionCacheLog.Printf("Saving action cache to: %s with %d entries", c.path, len(c.Entries))
 
-	// If cache is empty (no entries and no container pins), skip saving and delete the file if it exists
-	if len(c.Entries) == 0 && len(c.ContainerPins) == 0 {
+	// If cache is empty (no entries, container pins or MCP pins), skip saving and delete the file if it exists
+	if len(c.Entries) == 0 && len(c.ContainerPins) == 0 && len(c.MCPPins) == 0 {
 		actionCacheLog.Print("Cache is empty, skipping file creation")
 		// Remove the file if it exists
 		if _, err := os.Stat(c.path); err == nil {
@@ -317,6 +324,26 @@ func (c *ActionCache) marshalSorted() ([]byte, error) {
 		result = append(result, "  }"...)
 	}
 
+	// Add MCP package pins section if non-empty
+	if len(c.MCPPins) > 0 {
+		mcpKeys := sliceutil.SortedKeys(c.MCPPins)
+
+		result = append(result, ",\n  \"mcp\": {\n"...)
+		for i, k := range mcpKeys {
+			pinJSON, err := json.MarshalIndent(c.MCPPins[k], "    ", "  ")
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, "    \""+k+"\": "...)
+			result = append(result, pinJSON...)
+			if i < len(mcpKeys)-1 {
+				result = append(result, ',')
+			}
+			result = append(result, '\n')
+		}
+		result = append(result, "  }"...)
+	}
+
 	result = append(result, '\n', '}')
 	return result, nil
 }
@@ -471,10 +498,49 @@ func (c *ActionCache) Set(repo, version, sha string) {
 		ReleasedAt:        releasedAt,
 		Inputs:            inputs,
 		ActionDescription: description,
+		// Update directives are user-managed and survive SHA changes.
+		Pin:         existing.Pin,
+		IgnoreMajor: existing.IgnoreMajor,
 	}
 	c.dirty = true // Mark cache as modified
 }
 
+// SetPin sets or clears the pin directive on the cache entry for the given repo
+// and version. Pinned entries are held at their current version by `gh aw update`.
+// Returns false if no entry exists for the key.
+func (c *ActionCache) SetPin(repo, version string, pin bool) bool {
+	key := formatActionCacheKey(repo, version)
+	entry, exists := c.Entries[key]
+	if !exists {
+		return false
+	}
+	if entry.Pin != pin {
+		entry.Pin = pin
+		c.Entries[key] = entry
+		c.dirty = true
+	}
+	actionCacheLog.Printf("Set pin=%v for key=%s", pin, key)
+	return true
+}
+
+// SetIgnoreMajor sets or clears the ignore-major directive on the cache entry for
+// the given repo and version. Entries with ignore-major only receive minor and
+// patch updates from `gh aw update`. Returns false if no entry exists for the key.
+func (c *ActionCache) SetIgnoreMajor(repo, version string, ignoreMajor bool) bool {
+	key := formatActionCacheKey(repo, version)
+	entry, exists := c.Entries[key]
+	if !exists {
+		return false
+	}
+	if entry.IgnoreMajor != ignoreMajor {
+		entry.IgnoreMajor = ignoreMajor
+		c.Entries[key] = entry
+		c.dirty = true
+	}
+	actionCacheLog.Printf("Set ignore-major=%v for key=%s", ignoreMajor, key)
+	return true
+}
+
 // GetInputs retrieves the cached action inputs for the given repo and version.
 // Returns the inputs map and true if cached inputs exist, otherwise nil and false.
 func (c *ActionCache) GetInputs(repo, version string) (map[string]*ActionYAMLInput, bool) {
@@ -595,6 +661,7 @@ func (c *ActionCache) deduplicateEntries() {
 			continue
 		}
 		keepVersion, removedKeys, removedVersions := collectDedupRemovals(keyInfos)
+		c.mergeUpdateDirectives(keyInfos[0].key, removedKeys)
 		for _, removedKey := range removedKeys {
 			toDelete = append(toDelete, removedKey)
 			actionCacheLog.Printf("Deduplicating: keeping %s, removing %s", keyInfos[0].key, removedKey)
@@ -663,6 +730,18 @@ func collectDedupRemovals(keyInfos []cacheKeyInfo) (string, []string, []string)
 	return keepVersion, removedKeys, removedVersions
 }
 
+// mergeUpdateDirectives carries pin/ignore-major directives from entries that are
+// about to be removed onto the kept entry so deduplication never drops them.
+func (c *ActionCache) mergeUpdateDirectives(keepKey string, removedKeys []string) {
+	kept := c.Entries[keepKey]
+	for _, key := range removedKeys {
+		removed := c.Entries[key]
+		kept.Pin = kept.Pin || removed.Pin
+		kept.IgnoreMajor = kept.IgnoreMajor || removed.IgnoreMajor
+	}
+	c.Entries[keepKey] = kept
+}
+
 func (c *ActionCache) deleteDedupEntries(toDelete []string) {
 	for _, key := range toDelete {
 		delete(c.Entries, key)
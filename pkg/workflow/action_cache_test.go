@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -883,6 +884,61 @@ func TestActionCacheReleasedAtClearedOnSHAChange(t *testing.T) {
 	}
 }
 
+// TestActionCacheUpdateDirectives verifies that pin/ignore-major directives
+// survive SHA changes, round-trip through actions-lock.json, and are merged
+// onto the kept entry during deduplication.
+func TestActionCacheUpdateDirectives(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "test-*")
+	cache := NewActionCache(tmpDir)
+
+	if cache.SetPin("owner/action", "v2", true) {
+		t.Error("SetPin should report false for a missing entry")
+	}
+
+	cache.Set("owner/action", "v2", "oldsha1234567890123456789012345678901234a")
+	if !cache.SetPin("owner/action", "v2", true) {
+		t.Fatal("SetPin should report true for an existing entry")
+	}
+	cache.Set("other/action", "v1.2.0", "othersha123456789012345678901234567890ab")
+	cache.SetIgnoreMajor("other/action", "v1.2.0", true)
+
+	// A moving tag resolving to a new SHA must keep the directive.
+	cache.Set("owner/action", "v2", "newsha1234567890123456789012345678901234b")
+	if !cache.Entries["owner/action@v2"].Pin {
+		t.Error("Pin should be preserved when the SHA changes")
+	}
+
+	// Deduplication keeps the most precise version and carries the directive over.
+	cache.Set("other/action", "v1", "othersha123456789012345678901234567890ab")
+	cache.SetPin("other/action", "v1", true)
+	if err := cache.Save(); err != nil {
+		t.Fatalf("Failed to save cache: %v", err)
+	}
+
+	data, err := os.ReadFile(cache.GetCachePath())
+	if err != nil {
+		t.Fatalf("Failed to read cache file: %v", err)
+	}
+	if !strings.Contains(string(data), `"pin": true`) || !strings.Contains(string(data), `"ignore-major": true`) {
+		t.Errorf("Expected pin and ignore-major keys in cache file, got:\n%s", data)
+	}
+
+	loaded := NewActionCache(tmpDir)
+	if err := loaded.Load(); err != nil {
+		t.Fatalf("Failed to load cache: %v", err)
+	}
+	if _, exists := loaded.Entries["other/action@v1"]; exists {
+		t.Error("other/action@v1 should have been deduplicated")
+	}
+	kept := loaded.Entries["other/action@v1.2.0"]
+	if !kept.Pin || !kept.IgnoreMajor {
+		t.Errorf("Kept entry should carry both directives, got pin=%v ignore-major=%v", kept.Pin, kept.IgnoreMajor)
+	}
+	if !loaded.Entries["owner/action@v2"].Pin {
+		t.Error("Pin should round-trip through the cache file")
+	}
+}
+
 // TestPruneOrphanedEntries verifies that PruneOrphanedEntries removes entries
 // whose keys are absent from the referenced set, while preserving referenced ones.
 func TestPruneOrphanedEntries(t *testing.T) {
@@ -1,12 +1,14 @@
 package workflow
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strings"
 
 	actionpins "github.com/github/gh-aw/pkg/actionpins"
 	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/errorutil"
 	"github.com/github/gh-aw/pkg/gitutil"
 	"github.com/github/gh-aw/pkg/logger"
 	"github.com/github/gh-aw/pkg/semverutil"
@@ -349,6 +351,11 @@ func applyActionPinToTypedStep(step *WorkflowStep, data *WorkflowData) (*Workflo
 	}
 
 	pinnedRef, err := getActionPinWithData(actionRepo, rawVersion, data)
+	if errors.Is(err, errorutil.ErrOffline) {
+		// Offline compiles must not silently emit an unpinned ref that a networked
+		// compile would have pinned.
+		return nil, err
+	}
 	if err != nil || pinnedRef == "" {
 		actionPinsLog.Printf("Skipping pin for %s@%s: no pin available", actionRepo, rawVersion)
 		return step, nil
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/github/gh-aw/pkg/actionpins"
+	"github.com/github/gh-aw/pkg/errorutil"
 	"github.com/github/gh-aw/pkg/gitutil"
 	"github.com/github/gh-aw/pkg/logger"
 	"github.com/github/gh-aw/pkg/semverutil"
@@ -20,6 +21,7 @@ type ActionResolver struct {
 	cache             *ActionCache
 	failedResolutions map[string]struct{} // tracks failed resolution attempts in current run (key: "repo@version")
 	usedCacheKeys     map[string]struct{} // tracks cache keys that were hit or newly set during this run
+	offline           bool                // never query GitHub; cache and embedded pin misses fail with errorutil.ErrOffline
 }
 
 // NewActionResolver creates a new action resolver
@@ -31,6 +33,13 @@ func NewActionResolver(cache *ActionCache) *ActionResolver {
 	}
 }
 
+// SetOffline configures whether the resolver may query GitHub. When offline,
+// refs missing from the cache and the embedded pins fail with an error wrapping
+// errorutil.ErrOffline instead of calling the API.
+func (r *ActionResolver) SetOffline(offline bool) {
+	r.offline = offline
+}
+
 // GetUsedCacheKeys returns the set of cache keys (in "repo@version" format) that
 // were successfully resolved from the cache or written to the cache during this run.
 // These represent the action pins actually referenced by the compiled workflows.
@@ -121,6 +130,11 @@ func (r *ActionResolver) ResolveSHA(ctx context.Context, repo, version string) (
 		return sha, nil
 	}
 
+	if r.offline {
+		resolverLog.Printf("No cached or embedded pin for %s@%s and offline mode is enabled", repo, version)
+		return "", fmt.Errorf("%w: %s@%s is not pinned in .github/aw/actions-lock.json; compile once with network access to record it", errorutil.ErrOffline, repo, version)
+	}
+
 	resolverLog.Printf("No embedded pin for %s@%s, querying GitHub API", repo, version)
 	resolverLog.Printf("This may take a moment as we query GitHub API at /repos/%s/git/ref/tags/%s", gitutil.ExtractBaseRepo(repo), version)
 
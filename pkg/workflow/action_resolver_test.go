@@ -4,11 +4,13 @@ package workflow
 
 import (
 	"context"
+	"errors"
 	"os"
 	"slices"
 	"strings"
 	"testing"
 
+	"github.com/github/gh-aw/pkg/errorutil"
 	"github.com/github/gh-aw/pkg/gitutil"
 	"github.com/github/gh-aw/pkg/testutil"
 )
@@ -112,6 +114,31 @@ func TestActionResolverFailedResolutionCache(t *testing.T) {
 	}
 }
 
+func TestActionResolverOffline(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "test-*")
+	cache := NewActionCache(tmpDir)
+	cache.Set("actions/checkout", "v5", "test-sha-123")
+	resolver := NewActionResolver(cache)
+	resolver.SetOffline(true)
+
+	sha, err := resolver.ResolveSHA(context.Background(), "actions/checkout", "v5")
+	if err != nil || sha != "test-sha-123" {
+		t.Errorf("Expected cached SHA offline, got %q, %v", sha, err)
+	}
+
+	// A miss must not reach the GitHub API, and must keep reporting the offline
+	// error on retries so callers never downgrade it to a warning.
+	for range 2 {
+		_, err = resolver.ResolveSHA(context.Background(), "nonexistent/action", "v999.999.999")
+		if !errors.Is(err, errorutil.ErrOffline) {
+			t.Fatalf("Expected offline error for uncached action, got: %v", err)
+		}
+	}
+	if !strings.Contains(err.Error(), "actions-lock.json") {
+		t.Errorf("Expected error to point at actions-lock.json, got: %v", err)
+	}
+}
+
 // Note: Testing the actual GitHub API resolution requires network access
 // and is tested in integration tests or with network-dependent test tags
 
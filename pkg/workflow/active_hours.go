@@ -0,0 +1,364 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	// Embed the IANA database so timezone validation does not depend on the host
+	// (or fail outright in the WebAssembly build).
+	_ "time/tzdata"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var activeHoursLog = logger.New("workflow:active_hours")
+
+// activeHoursRangePattern matches an "HH:MM-HH:MM" time range in 24-hour notation.
+var activeHoursRangePattern = regexp.MustCompile(`^([01][0-9]|2[0-3]):([0-5][0-9])-([01][0-9]|2[0-3]):([0-5][0-9])$`)
+
+// activeHoursTimezonePattern accepts "UTC" and IANA zone names such as Europe/Berlin.
+// Names that pass are then resolved with time.LoadLocation, so a typo fails compilation
+// instead of every pre-activation run; check_active_hours.cjs uses Intl.DateTimeFormat.
+var activeHoursTimezonePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_+-]*(/[A-Za-z0-9_+-]+)*$`)
+
+// activeHoursWeekdays lists day names in the order used by the runtime check (Sunday first).
+var activeHoursWeekdays = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// activeHoursDayNames maps accepted day spellings to their three-letter abbreviation.
+var activeHoursDayNames = map[string]string{
+	"sun": "sun", "sunday": "sun",
+	"mon": "mon", "monday": "mon",
+	"tue": "tue", "tuesday": "tue",
+	"wed": "wed", "wednesday": "wed",
+	"thu": "thu", "thursday": "thu",
+	"fri": "fri", "friday": "fri",
+	"sat": "sat", "saturday": "sat",
+}
+
+// defaultActiveHoursDays is used when active-hours.days is omitted.
+var defaultActiveHoursDays = []string{"mon", "tue", "wed", "thu", "fri"}
+
+// defaultActiveHoursEvents are the human-initiated events gated when active-hours.events is omitted.
+// Other events (schedule, workflow_dispatch, ...) always pass so maintainers can still run the
+// workflow manually outside office hours.
+var defaultActiveHoursEvents = []string{
+	"discussion",
+	"discussion_comment",
+	"issue_comment",
+	"issues",
+	"pull_request",
+	"pull_request_review",
+	"pull_request_review_comment",
+}
+
+// activeHoursQueueEvents are the events whose out-of-hours triggers can be queued. The queue
+// lives in the notice comment on the issue or pull request; discussion comments cannot be
+// listed repository-wide, so discussion triggers are only notified.
+var activeHoursQueueEvents = []string{
+	"issue_comment",
+	"issues",
+	"pull_request",
+	"pull_request_review",
+	"pull_request_review_comment",
+}
+
+// ActiveHoursConfig describes the active-hours: frontmatter section. Triggers outside
+// the configured days and time range are stopped in the pre-activation job and, when
+// Comment is enabled, the triggering issue, pull request, or discussion is told when
+// the next window opens. When Queue is enabled the notice also records the trigger, and a
+// scheduled run at the start of the next window re-dispatches it.
+type ActiveHoursConfig struct {
+	Days     []string // three-letter lowercase day names (sun, mon, ...)
+	Start    string   // window start in HH:MM
+	End      string   // window end in HH:MM; earlier than Start for overnight windows
+	Timezone string   // IANA timezone name (default: UTC)
+	Events   []string // events the window applies to
+	Comment  bool     // post a comment on the triggering item when outside the window
+	Message  string   // optional custom comment body
+	Queue    bool     // re-dispatch out-of-hours triggers when the next window opens
+}
+
+// extractActiveHoursConfig parses the active-hours: section. The hours field is required;
+// days default to Monday through Friday, timezone to UTC, and events to the human-initiated
+// issue, pull request, and discussion events.
+func extractActiveHoursConfig(frontmatter map[string]any) (*ActiveHoursConfig, error) {
+	raw, exists := frontmatter["active-hours"]
+	if !exists || raw == nil {
+		return nil, nil
+	}
+	v, ok := raw.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("active-hours must be an object with days, hours, and timezone, got %T", raw)
+	}
+
+	config := &ActiveHoursConfig{Timezone: "UTC", Comment: true}
+
+	hours, _ := v["hours"].(string)
+	match := activeHoursRangePattern.FindStringSubmatch(strings.TrimSpace(hours))
+	if match == nil {
+		return nil, fmt.Errorf("active-hours.hours must be a 24-hour range such as \"09:00-17:00\", got %q", hours)
+	}
+	config.Start = match[1] + ":" + match[2]
+	config.End = match[3] + ":" + match[4]
+	if config.Start == config.End {
+		return nil, fmt.Errorf("active-hours.hours: start and end must differ, got %q", hours)
+	}
+
+	days, err := parseActiveHoursDays(v["days"])
+	if err != nil {
+		return nil, err
+	}
+	config.Days = days
+
+	if tz, exists := v["timezone"]; exists {
+		tzStr, _ := tz.(string)
+		tzStr = strings.TrimSpace(tzStr)
+		if !activeHoursTimezonePattern.MatchString(tzStr) || tzStr == "Local" {
+			return nil, fmt.Errorf("active-hours.timezone must be an IANA timezone name such as \"Europe/Berlin\", got %q", tzStr)
+		}
+		if _, err := time.LoadLocation(tzStr); err != nil {
+			return nil, fmt.Errorf("active-hours.timezone: unknown timezone %q: %w", tzStr, err)
+		}
+		config.Timezone = tzStr
+	}
+
+	config.Events = defaultActiveHoursEvents
+	if rawEvents, exists := v["events"]; exists {
+		events := parseOptionalStringSliceField(rawEvents, "active-hours.events")
+		if len(events) == 0 {
+			return nil, errors.New("active-hours.events must list at least one event")
+		}
+		slices.Sort(events)
+		config.Events = slices.Compact(events)
+	}
+
+	if rawComment, exists := v["comment"]; exists {
+		comment, ok := rawComment.(bool)
+		if !ok {
+			return nil, fmt.Errorf("active-hours.comment must be a boolean, got %T", rawComment)
+		}
+		config.Comment = comment
+	}
+	config.Message, _ = v["message"].(string)
+
+	config.Queue = config.Comment
+	if rawQueue, exists := v["queue"]; exists {
+		queue, ok := rawQueue.(bool)
+		if !ok {
+			return nil, fmt.Errorf("active-hours.queue must be a boolean, got %T", rawQueue)
+		}
+		if queue && !config.Comment {
+			return nil, errors.New("active-hours.queue requires comment: true because queued triggers are recorded in the notice comment")
+		}
+		config.Queue = queue
+	}
+	if config.Queue && !slices.ContainsFunc(config.Events, func(event string) bool { return slices.Contains(activeHoursQueueEvents, event) }) {
+		activeHoursLog.Printf("No queueable events in %v, disabling the active-hours queue", config.Events)
+		config.Queue = false
+	}
+
+	activeHoursLog.Printf("Extracted active-hours: days=%v, window=%s-%s, timezone=%s, events=%v, comment=%v, queue=%v",
+		config.Days, config.Start, config.End, config.Timezone, config.Events, config.Comment, config.Queue)
+	return config, nil
+}
+
+// parseActiveHoursDays normalizes day names ("Monday", "mon", "MON") to three-letter
+// lowercase names ordered from Sunday to Saturday.
+func parseActiveHoursDays(raw any) ([]string, error) {
+	if raw == nil {
+		return defaultActiveHoursDays, nil
+	}
+	names := parseOptionalStringSliceField(raw, "active-hours.days")
+	if len(names) == 0 {
+		return nil, errors.New("active-hours.days must list at least one day")
+	}
+	seen := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		day := strings.ToLower(strings.TrimSpace(name))
+		abbrev, ok := activeHoursDayNames[day]
+		if !ok {
+			return nil, fmt.Errorf("active-hours.days: unknown day %q (use mon, tue, wed, thu, fri, sat, sun)", name)
+		}
+		seen[abbrev] = struct{}{}
+	}
+	days := make([]string, 0, len(seen))
+	for _, day := range activeHoursWeekdays {
+		if _, ok := seen[day]; ok {
+			days = append(days, day)
+		}
+	}
+	return days, nil
+}
+
+// activeHoursQueueCrons returns the UTC cron expressions that fire when the window opens on
+// each configured day. A zone with daylight saving time yields one expression per UTC offset;
+// at runtime the queue is only drained by the run that lands inside the window.
+func activeHoursQueueCrons(config *ActiveHoursConfig) ([]string, error) {
+	loc, err := time.LoadLocation(config.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("active-hours.timezone: unknown timezone %q: %w", config.Timezone, err)
+	}
+	hour, _ := strconv.Atoi(config.Start[:2])
+	minute, _ := strconv.Atoi(config.Start[3:])
+	start := hour*60 + minute
+
+	var crons []string
+	for _, month := range []time.Month{time.January, time.July} {
+		_, offset := time.Date(2025, month, 15, 12, 0, 0, 0, loc).Zone()
+		minutes := start - offset/60
+		shift := 0
+		if minutes < 0 {
+			minutes += 24 * 60
+			shift = -1
+		} else if minutes >= 24*60 {
+			minutes -= 24 * 60
+			shift = 1
+		}
+		days := make([]int, 0, len(config.Days))
+		for _, day := range config.Days {
+			days = append(days, (slices.Index(activeHoursWeekdays, day)+shift+7)%7)
+		}
+		slices.Sort(days)
+		dayList := make([]string, 0, len(days))
+		for _, day := range days {
+			dayList = append(dayList, strconv.Itoa(day))
+		}
+		cron := fmt.Sprintf("%d %d * * %s", minutes%60, minutes/60, strings.Join(dayList, ","))
+		if !slices.Contains(crons, cron) {
+			crons = append(crons, cron)
+		}
+	}
+	return crons, nil
+}
+
+// preprocessActiveHoursQueue adds the triggers the active-hours queue relies on to the on:
+// section: a schedule entry at each window start, whose run drains the queue, and
+// workflow_dispatch, which replays each queued trigger with its original aw_context.
+// It modifies the frontmatter map in place; configuration errors are left for
+// extractActiveHoursConfig to report.
+func preprocessActiveHoursQueue(frontmatter map[string]any) {
+	config, err := extractActiveHoursConfig(frontmatter)
+	if err != nil || config == nil || !config.Queue {
+		return
+	}
+	crons, err := activeHoursQueueCrons(config)
+	if err != nil {
+		return
+	}
+
+	// Shared workflows without an on: section are left alone; the importing workflow
+	// compiles its own queue triggers.
+	onMap := map[string]any{}
+	switch on := frontmatter["on"].(type) {
+	case map[string]any:
+		onMap = on
+	case string:
+		onMap[on] = nil
+	case []any:
+		for _, item := range on {
+			if event, ok := item.(string); ok {
+				onMap[event] = nil
+			}
+		}
+	default:
+		return
+	}
+
+	schedule, ok := onMap["schedule"].([]any)
+	if !ok && onMap["schedule"] != nil {
+		activeHoursLog.Printf("Unexpected on.schedule type %T, skipping the active-hours queue triggers", onMap["schedule"])
+		return
+	}
+	for _, cron := range crons {
+		schedule = append(schedule, map[string]any{"cron": cron})
+	}
+	activeHoursLog.Printf("Adding active-hours queue schedule: %v", crons)
+	onMap["schedule"] = schedule
+	if _, hasDispatch := onMap["workflow_dispatch"]; !hasDispatch {
+		onMap["workflow_dispatch"] = nil
+	}
+	frontmatter["on"] = onMap
+}
+
+// buildPreActivationActiveHoursStep emits the check_active_hours step. It runs after the
+// other pre-activation gates so it only comments on triggers that would otherwise have run:
+// GH_AW_ACTIVE_HOURS_NOTIFY carries the combined result of those gates.
+func (c *Compiler) buildPreActivationActiveHoursStep(data *WorkflowData, steps []string, needsPermissionCheck bool) []string {
+	if data.ActiveHours == nil {
+		return steps
+	}
+	config := data.ActiveHours
+	activeHoursLog.Printf("Adding active-hours check step: window=%s-%s %s", config.Start, config.End, config.Timezone)
+
+	steps = append(steps, "      - name: Check active hours\n")
+	steps = append(steps, fmt.Sprintf("        id: %s\n", constants.CheckActiveHoursStepID))
+	script := generateGitHubScriptWithRequire("check_active_hours.cjs")
+	steps = append(steps, fmt.Sprintf("        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", data), script)))
+	steps = append(steps, "        env:\n")
+	steps = append(steps, fmt.Sprintf("          GH_AW_ACTIVE_HOURS_DAYS: %q\n", strings.Join(config.Days, ",")))
+	steps = append(steps, fmt.Sprintf("          GH_AW_ACTIVE_HOURS_START: %q\n", config.Start))
+	steps = append(steps, fmt.Sprintf("          GH_AW_ACTIVE_HOURS_END: %q\n", config.End))
+	steps = append(steps, fmt.Sprintf("          GH_AW_ACTIVE_HOURS_TIMEZONE: %q\n", config.Timezone))
+	steps = append(steps, fmt.Sprintf("          GH_AW_ACTIVE_HOURS_EVENTS: %q\n", strings.Join(config.Events, ",")))
+	steps = append(steps, fmt.Sprintf("          GH_AW_ACTIVE_HOURS_COMMENT: \"%t\"\n", config.Comment))
+	if config.Comment {
+		notify := `"true"`
+		if gates := buildPreActivationGateConditions(data, needsPermissionCheck); len(gates) > 0 {
+			node := gates[0]
+			for _, gate := range gates[1:] {
+				node = BuildAnd(node, gate)
+			}
+			notify = fmt.Sprintf("${{ %s }}", node.Render())
+		}
+		steps = append(steps, fmt.Sprintf("          GH_AW_ACTIVE_HOURS_NOTIFY: %s\n", notify))
+		if config.Message != "" {
+			steps = append(steps, fmt.Sprintf("          GH_AW_ACTIVE_HOURS_MESSAGE: %q\n", config.Message))
+		}
+	}
+	if config.Queue {
+		// The queue crons are matched against github.event.schedule, so a drain run can be
+		// told apart from the workflow's own schedule triggers.
+		crons, err := activeHoursQueueCrons(config)
+		if err == nil {
+			steps = append(steps, fmt.Sprintf("          GH_AW_ACTIVE_HOURS_QUEUE_CRONS: %q\n", strings.Join(crons, ";")))
+		}
+		if len(data.Command) > 0 {
+			steps = append(steps, fmt.Sprintf("          GH_AW_ACTIVE_HOURS_COMMAND: ${{ steps.%s.outputs.%s }}\n", constants.CheckCommandPositionStepID, constants.MatchedCommandOutput))
+		}
+	}
+	steps = append(steps, fmt.Sprintf("          GH_AW_WORKFLOW_NAME: %q\n", data.Name))
+	return append(steps, c.githubScriptWith(script))
+}
+
+// activeHoursCommentPermissions returns the scopes the pre-activation job needs to comment
+// on triggers that arrive outside the active-hours window and, with the queue enabled, to
+// re-dispatch them when the window opens.
+func activeHoursCommentPermissions(config *ActiveHoursConfig) map[PermissionScope]PermissionLevel {
+	if config == nil || !config.Comment {
+		return nil
+	}
+	perms := make(map[PermissionScope]PermissionLevel)
+	if config.Queue {
+		perms[PermissionActions] = PermissionWrite
+	}
+	for _, event := range config.Events {
+		switch event {
+		case "issues":
+			perms[PermissionIssues] = PermissionWrite
+		case "issue_comment":
+			// issue_comment fires for pull request conversations as well.
+			perms[PermissionIssues] = PermissionWrite
+			perms[PermissionPullRequests] = PermissionWrite
+		case "pull_request", "pull_request_review", "pull_request_review_comment":
+			perms[PermissionPullRequests] = PermissionWrite
+		case "discussion", "discussion_comment":
+			perms[PermissionDiscussions] = PermissionWrite
+		}
+	}
+	return perms
+}
@@ -0,0 +1,191 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/github/gh-aw/pkg/testutil"
+)
+
+func TestExtractActiveHoursConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		frontmatter map[string]any
+		expected    *ActiveHoursConfig
+		wantErr     string
+	}{
+		{
+			name:        "not configured",
+			frontmatter: map[string]any{},
+		},
+		{
+			name:        "defaults",
+			frontmatter: map[string]any{"active-hours": map[string]any{"hours": "09:00-17:00"}},
+			expected: &ActiveHoursConfig{
+				Days:     []string{"mon", "tue", "wed", "thu", "fri"},
+				Start:    "09:00",
+				End:      "17:00",
+				Timezone: "UTC",
+				Events:   defaultActiveHoursEvents,
+				Comment:  true,
+				Queue:    true,
+			},
+		},
+		{
+			name: "full configuration",
+			frontmatter: map[string]any{"active-hours": map[string]any{
+				"days":     []any{"Saturday", "mon", "MON"},
+				"hours":    "22:00-06:00",
+				"timezone": "Europe/Berlin",
+				"events":   []any{"issues", "issue_comment"},
+				"comment":  false,
+				"message":  "Back {next}",
+			}},
+			expected: &ActiveHoursConfig{
+				Days:     []string{"mon", "sat"},
+				Start:    "22:00",
+				End:      "06:00",
+				Timezone: "Europe/Berlin",
+				Events:   []string{"issue_comment", "issues"},
+				Comment:  false,
+				Message:  "Back {next}",
+			},
+		},
+		{
+			name:        "missing hours",
+			frontmatter: map[string]any{"active-hours": map[string]any{"days": []any{"mon"}}},
+			wantErr:     "active-hours.hours must be a 24-hour range",
+		},
+		{
+			name:        "empty window",
+			frontmatter: map[string]any{"active-hours": map[string]any{"hours": "09:00-09:00"}},
+			wantErr:     "start and end must differ",
+		},
+		{
+			name:        "unknown day",
+			frontmatter: map[string]any{"active-hours": map[string]any{"hours": "09:00-17:00", "days": []any{"funday"}}},
+			wantErr:     `unknown day "funday"`,
+		},
+		{
+			name: "discussion events are not queued",
+			frontmatter: map[string]any{"active-hours": map[string]any{
+				"hours":  "09:00-17:00",
+				"events": []any{"discussion_comment"},
+			}},
+			expected: &ActiveHoursConfig{
+				Days:     []string{"mon", "tue", "wed", "thu", "fri"},
+				Start:    "09:00",
+				End:      "17:00",
+				Timezone: "UTC",
+				Events:   []string{"discussion_comment"},
+				Comment:  true,
+			},
+		},
+		{
+			name:        "queue without comment",
+			frontmatter: map[string]any{"active-hours": map[string]any{"hours": "09:00-17:00", "comment": false, "queue": true}},
+			wantErr:     "active-hours.queue requires comment: true",
+		},
+		{
+			name:        "unknown timezone",
+			frontmatter: map[string]any{"active-hours": map[string]any{"hours": "09:00-17:00", "timezone": "Europe/Berln"}},
+			wantErr:     `active-hours.timezone: unknown timezone "Europe/Berln"`,
+		},
+		{
+			name:        "local timezone",
+			frontmatter: map[string]any{"active-hours": map[string]any{"hours": "09:00-17:00", "timezone": "Local"}},
+			wantErr:     "active-hours.timezone must be an IANA timezone name",
+		},
+		{
+			name:        "invalid timezone",
+			frontmatter: map[string]any{"active-hours": map[string]any{"hours": "09:00-17:00", "timezone": "UTC; rm -rf"}},
+			wantErr:     "active-hours.timezone must be an IANA timezone name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := extractActiveHoursConfig(tt.frontmatter)
+			if tt.wantErr != "" {
+				require.Error(t, err, "expected an error")
+				assert.Contains(t, err.Error(), tt.wantErr, "error message should explain the problem")
+				return
+			}
+			require.NoError(t, err, "extraction should succeed")
+			assert.Equal(t, tt.expected, config, "parsed config should match")
+		})
+	}
+}
+
+func TestActiveHoursQueueCrons(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   *ActiveHoursConfig
+		expected []string
+	}{
+		{
+			name:     "UTC",
+			config:   &ActiveHoursConfig{Days: []string{"mon", "tue", "wed", "thu", "fri"}, Start: "09:00", Timezone: "UTC"},
+			expected: []string{"0 9 * * 1,2,3,4,5"},
+		},
+		{
+			name:     "daylight saving time yields one cron per offset",
+			config:   &ActiveHoursConfig{Days: []string{"mon", "fri"}, Start: "09:30", Timezone: "Europe/Berlin"},
+			expected: []string{"30 8 * * 1,5", "30 7 * * 1,5"},
+		},
+		{
+			name:     "window start before midnight UTC shifts the day back",
+			config:   &ActiveHoursConfig{Days: []string{"sun", "mon"}, Start: "08:00", Timezone: "Asia/Tokyo"},
+			expected: []string{"0 23 * * 0,6"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			crons, err := activeHoursQueueCrons(tt.config)
+			require.NoError(t, err, "crons should be computed")
+			assert.Equal(t, tt.expected, crons, "crons should fire at the window start in UTC")
+		})
+	}
+}
+
+func TestActiveHoursWorkflowCompilation(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "active-hours")
+	content := `---
+on:
+  slash_command:
+    name: triage
+engine: copilot
+active-hours:
+  hours: "09:00-17:00"
+  timezone: Europe/Berlin
+---
+
+# Triage
+`
+	workflowFile := filepath.Join(tmpDir, "triage.md")
+	require.NoError(t, os.WriteFile(workflowFile, []byte(content), 0644), "Failed to write workflow")
+	require.NoError(t, NewCompiler().CompileWorkflow(workflowFile), "compilation should succeed")
+
+	lockBytes, err := os.ReadFile(filepath.Join(tmpDir, "triage.lock.yml"))
+	require.NoError(t, err, "Failed to read lock file")
+	lock := string(lockBytes)
+
+	assert.Contains(t, lock, "id: check_active_hours", "pre-activation should check active hours")
+	assert.Contains(t, lock, `GH_AW_ACTIVE_HOURS_TIMEZONE: "Europe/Berlin"`, "timezone should be passed to the check")
+	assert.Contains(t, lock, "GH_AW_ACTIVE_HOURS_NOTIFY: ${{ steps.check_membership.outputs.is_team_member == 'true'", "notice should wait for the other gates")
+	assert.Contains(t, lock, "steps.check_active_hours.outputs.active_hours_ok == 'true'", "activated output should include the window")
+	assert.Contains(t, lock, "issues: write", "pre-activation should be able to comment on issues")
+	assert.Contains(t, lock, "actions: write", "pre-activation should be able to re-dispatch queued triggers")
+	assert.Contains(t, lock, `- cron: "0 8 * * 1,2,3,4,5"`, "winter window start should drain the queue")
+	assert.Contains(t, lock, `- cron: "0 7 * * 1,2,3,4,5"`, "summer window start should drain the queue")
+	assert.Contains(t, lock, `GH_AW_ACTIVE_HOURS_QUEUE_CRONS: "0 8 * * 1,2,3,4,5;0 7 * * 1,2,3,4,5"`, "queue crons should be passed to the check")
+	assert.Contains(t, lock, "GH_AW_ACTIVE_HOURS_COMMAND: ${{ steps.check_command_position.outputs.matched_command }}", "queued triggers should keep the matched command")
+	assert.Contains(t, lock, "workflow_dispatch:", "queued triggers are replayed through workflow_dispatch")
+}
@@ -25,6 +25,7 @@ type AddCommentsConfig struct {
 	PullRequests           *bool    `yaml:"pull-requests,omitempty"`             // When false, excludes pull-requests:write permission and PRs from event condition. Default (nil or true) includes pull-requests:write.
 	Discussions            *bool    `yaml:"discussions,omitempty"`               // When true, includes discussions:write permission. Default (nil or false) excludes discussions:write.
 	Footer                 *string  `yaml:"footer,omitempty"`                    // Controls whether AI-generated footer is added. When false, visible footer is omitted but XML markers are kept.
+	UpdateIfExists         string   `yaml:"update-if-exists,omitempty"`          // "anchor" edits the previous anchored comment in place instead of posting a new one
 }
 
 // parseCommentsConfig handles add-comment configuration
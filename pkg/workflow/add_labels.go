@@ -12,6 +12,7 @@ type AddLabelsConfig struct {
 	SafeOutputTargetConfig     `yaml:",inline"`
 	SafeOutputFilterConfig     `yaml:",inline"`
 	SafeOutputAllowBlockConfig `yaml:",inline"`
+	Batch                      bool `yaml:"batch,omitempty"` // When true, a single output may target many issues/PRs via item_numbers
 }
 
 // parseAddLabelsConfig handles add-labels configuration
@@ -23,7 +24,7 @@ func (c *Compiler) parseAddLabelsConfig(outputMap map[string]any) *AddLabelsConf
 		return &AddLabelsConfig{}
 	})
 	if config != nil {
-		addLabelsLog.Printf("Parsed configuration: allowed_count=%d, blocked_count=%d, target=%s", len(config.Allowed), len(config.Blocked), config.Target)
+		addLabelsLog.Printf("Parsed configuration: allowed_count=%d, blocked_count=%d, target=%s, batch=%t", len(config.Allowed), len(config.Blocked), config.Target, config.Batch)
 	}
 	return config
 }
@@ -0,0 +1,81 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goccy/go-yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/github/gh-aw/pkg/testutil"
+)
+
+func compileAgentJobEnvWorkflow(t *testing.T, compiler *Compiler, agentEnv string) (map[string]any, error) {
+	t.Helper()
+	tmpDir := testutil.TempDir(t, "agent-job-env")
+	workflowContent := `---
+on: workflow_dispatch
+engine: copilot
+strict: false
+permissions:
+  contents: read
+env:
+  SHARED: value
+jobs:
+  agent:
+    env:
+` + agentEnv + `---
+Agent job env
+`
+	workflowFile := filepath.Join(tmpDir, "agent-job-env.md")
+	require.NoError(t, os.WriteFile(workflowFile, []byte(workflowContent), 0644))
+	if err := compiler.CompileWorkflow(workflowFile); err != nil {
+		return nil, err
+	}
+
+	lockBytes, err := os.ReadFile(filepath.Join(tmpDir, "agent-job-env.lock.yml"))
+	require.NoError(t, err)
+	var lock map[string]any
+	require.NoError(t, yaml.Unmarshal(lockBytes, &lock))
+	jobs, ok := lock["jobs"].(map[string]any)
+	require.True(t, ok, "lock file should have jobs")
+	agent, ok := jobs["agent"].(map[string]any)
+	require.True(t, ok, "lock file should have an agent job")
+	env, _ := agent["env"].(map[string]any)
+	return env, nil
+}
+
+func TestAgentJobEnv(t *testing.T) {
+	env, err := compileAgentJobEnvWorkflow(t, NewCompiler(), `      LOG_LEVEL: debug
+      FEATURE_FLAGS: "[a, b]"
+      COPILOT_GITHUB_TOKEN: ${{ secrets.MY_ORG_COPILOT_TOKEN }}
+      DEPLOY_KEY: ${{ secrets.DEPLOY_KEY }}
+`)
+	require.NoError(t, err, "workflow with jobs.agent.env should compile")
+
+	assert.Equal(t, "debug", env["LOG_LEVEL"], "plain values should be emitted verbatim")
+	assert.Equal(t, "[a, b]", env["FEATURE_FLAGS"], "flow-indicator values should stay strings")
+	assert.Equal(t, "${{ secrets.MY_ORG_COPILOT_TOKEN }}", env["COPILOT_GITHUB_TOKEN"], "engine secret overrides should be kept")
+	assert.NotContains(t, env, "DEPLOY_KEY", "secrets outside the engine allowlist should be dropped")
+	assert.Contains(t, env, "GH_AW_WORKFLOW_ID_SANITIZED", "compiler-generated env should be preserved")
+}
+
+func TestAgentJobEnvRejectsCompilerGeneratedKeys(t *testing.T) {
+	_, err := compileAgentJobEnvWorkflow(t, NewCompiler(), `      GH_AW_WORKFLOW_ID_SANITIZED: custom
+`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "jobs.agent.env.GH_AW_WORKFLOW_ID_SANITIZED conflicts with a compiler-generated environment variable")
+}
+
+func TestAgentJobEnvStrictModeSecrets(t *testing.T) {
+	compiler := NewCompiler()
+	compiler.SetStrictMode(true)
+	_, err := compileAgentJobEnvWorkflow(t, compiler, `      DEPLOY_KEY: ${{ secrets.DEPLOY_KEY }}
+`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "secrets detected in 'jobs.agent.env' section")
+}
@@ -0,0 +1,134 @@
+package workflow
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var agentTurnsLog = logger.New("workflow:agent_turns_log")
+
+// AgentTurnsFormat is the format identifier in the header record of agent_turns.jsonl
+const AgentTurnsFormat = "gh-aw.agent-turns"
+
+// AgentTurnsVersion is the agent_turns.jsonl format version this parser understands
+const AgentTurnsVersion = 1
+
+// agentTurnRecord is a single line of agent_turns.jsonl. The file is written by
+// agent_turns.cjs after the engine log parser has normalized the engine output, so the
+// record layout is the same for every engine.
+type agentTurnRecord struct {
+	Type             string   `json:"type"`
+	Format           string   `json:"format"`
+	Version          int      `json:"version"`
+	Engine           string   `json:"engine"`
+	Turn             int      `json:"turn"`
+	Turns            *int     `json:"turns"`
+	Name             string   `json:"name"`
+	InputSize        int      `json:"input_size"`
+	OutputSize       int      `json:"output_size"`
+	InputTokens      int      `json:"input_tokens"`
+	OutputTokens     int      `json:"output_tokens"`
+	CacheReadTokens  int      `json:"cache_read_tokens"`
+	CacheWriteTokens int      `json:"cache_write_tokens"`
+	CostUSD          *float64 `json:"cost_usd"`
+}
+
+// tokens returns the sum of all token counts in the record
+func (r agentTurnRecord) tokens() int {
+	return r.InputTokens + r.OutputTokens + r.CacheReadTokens + r.CacheWriteTokens
+}
+
+// ParseAgentTurnsMetrics extracts metrics from the content of an agent_turns.jsonl file.
+// It returns an error when the content does not start with a supported header record, so
+// callers can fall back to parsing the raw engine logs. Unknown record types are ignored
+// so newer writers stay readable.
+func ParseAgentTurnsMetrics(content string) (LogMetrics, error) {
+	var metrics LogMetrics
+	toolCallMap := make(map[string]*ToolCallInfo)
+	var sequence []string
+	var result *agentTurnRecord
+	turnIndex := make(map[int]int) // Index into metrics.TurnTokens by turn number
+	headerSeen := false
+
+	for i, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		var record agentTurnRecord
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			return LogMetrics{}, fmt.Errorf("invalid agent turns record on line %d: %w", i+1, err)
+		}
+
+		if !headerSeen {
+			if record.Type != "header" || record.Format != AgentTurnsFormat {
+				return LogMetrics{}, errors.New("agent turns log does not start with a header record")
+			}
+			if record.Version != AgentTurnsVersion {
+				return LogMetrics{}, fmt.Errorf("unsupported agent turns log version %d", record.Version)
+			}
+			headerSeen = true
+			agentTurnsLog.Printf("Parsing agent turns log written for engine %s", record.Engine)
+			continue
+		}
+
+		switch record.Type {
+		case "turn":
+			turnIndex[record.Turn] = len(metrics.TurnTokens)
+			metrics.TurnTokens = append(metrics.TurnTokens, TurnTokenUsage{
+				Turn:             record.Turn,
+				InputTokens:      record.InputTokens,
+				OutputTokens:     record.OutputTokens,
+				CacheReadTokens:  record.CacheReadTokens,
+				CacheWriteTokens: record.CacheWriteTokens,
+			})
+		case "tool_call":
+			name := PrettifyToolName(record.Name)
+			info, ok := toolCallMap[name]
+			if !ok {
+				info = &ToolCallInfo{Name: name}
+				toolCallMap[name] = info
+			}
+			info.CallCount++
+			info.MaxInputSize = max(info.MaxInputSize, record.InputSize)
+			sequence = append(sequence, name)
+			if idx, ok := turnIndex[record.Turn]; ok {
+				metrics.TurnTokens[idx].ToolCalls = append(metrics.TurnTokens[idx].ToolCalls, name)
+			}
+		case "tool_result":
+			if info, ok := toolCallMap[PrettifyToolName(record.Name)]; ok {
+				info.MaxOutputSize = max(info.MaxOutputSize, record.OutputSize)
+			}
+		case "result":
+			result = &record
+		}
+	}
+	if !headerSeen {
+		return LogMetrics{}, errors.New("agent turns log is empty")
+	}
+
+	metrics.Turns = len(metrics.TurnTokens)
+	if result != nil {
+		if result.Turns != nil && *result.Turns > 0 {
+			metrics.Turns = *result.Turns
+		}
+		metrics.TokenUsage = result.tokens()
+		if result.CostUSD != nil {
+			metrics.EstimatedCost = *result.CostUSD
+		}
+	}
+	if metrics.TokenUsage == 0 {
+		for _, turn := range metrics.TurnTokens {
+			metrics.TokenUsage += turn.TotalTokens()
+		}
+	}
+
+	FinalizeToolCallsAndSequence(&metrics, toolCallMap, sequence)
+	agentTurnsLog.Printf("Parsed agent turns log: turns=%d, tokenUsage=%d, toolCalls=%d",
+		metrics.Turns, metrics.TokenUsage, len(metrics.ToolCalls))
+	return metrics, nil
+}
@@ -0,0 +1,72 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleAgentTurnsLog = `{"type":"header","format":"gh-aw.agent-turns","version":1,"engine":"Claude"}
+{"type":"turn","turn":1,"input_tokens":100,"output_tokens":20,"cache_read_tokens":50,"cache_write_tokens":0}
+{"type":"tool_call","turn":1,"id":"toolu_1","name":"Bash","input_size":16}
+{"type":"tool_result","turn":1,"id":"toolu_1","name":"Bash","is_error":false,"output_size":11}
+{"type":"turn","turn":2,"input_tokens":200,"output_tokens":30,"cache_read_tokens":0,"cache_write_tokens":0}
+{"type":"tool_call","turn":2,"id":"toolu_2","name":"mcp__github__get_issue","input_size":18}
+{"type":"tool_call","turn":2,"id":"toolu_3","name":"Bash","input_size":40}
+{"type":"tool_result","turn":2,"id":"toolu_2","name":"mcp__github__get_issue","is_error":true,"output_size":9}
+{"type":"tool_result","turn":2,"id":"toolu_3","name":"Bash","is_error":false,"output_size":4}
+{"type":"future_record","turn":2}
+{"type":"result","turns":2,"input_tokens":300,"output_tokens":50,"cache_read_tokens":50,"cache_write_tokens":5,"cost_usd":0.05}
+`
+
+func TestParseAgentTurnsMetrics(t *testing.T) {
+	metrics, err := ParseAgentTurnsMetrics(sampleAgentTurnsLog)
+	require.NoError(t, err, "valid agent turns log should parse")
+
+	assert.Equal(t, 2, metrics.Turns, "turns should come from the result record")
+	assert.Equal(t, 405, metrics.TokenUsage, "token usage should include cached tokens")
+	assert.InDelta(t, 0.05, metrics.EstimatedCost, 1e-9, "cost should come from the result record")
+
+	require.Len(t, metrics.ToolCalls, 2, "tool calls should be grouped by name")
+	assert.Equal(t, ToolCallInfo{Name: "bash", CallCount: 2, MaxInputSize: 40, MaxOutputSize: 11}, metrics.ToolCalls[0], "bash statistics")
+	assert.Equal(t, ToolCallInfo{Name: "github_get_issue", CallCount: 1, MaxInputSize: 18, MaxOutputSize: 9}, metrics.ToolCalls[1], "MCP tool statistics")
+	assert.Equal(t, [][]string{{"bash", "github_get_issue", "bash"}}, metrics.ToolSequences, "tool sequence should keep log order")
+
+	require.Len(t, metrics.TurnTokens, 2, "one entry per turn record")
+	assert.Equal(t, TurnTokenUsage{Turn: 1, InputTokens: 100, OutputTokens: 20, CacheReadTokens: 50, ToolCalls: []string{"bash"}}, metrics.TurnTokens[0], "first turn")
+	assert.Equal(t, []string{"github_get_issue", "bash"}, metrics.TurnTokens[1].ToolCalls, "second turn tool calls")
+}
+
+func TestParseAgentTurnsMetrics_WithoutResult(t *testing.T) {
+	content := `{"type":"header","format":"gh-aw.agent-turns","version":1,"engine":"Codex"}
+{"type":"turn","turn":1,"input_tokens":10,"output_tokens":5}
+{"type":"turn","turn":2,"input_tokens":20,"output_tokens":5}
+`
+	metrics, err := ParseAgentTurnsMetrics(content)
+	require.NoError(t, err, "log without a result record should parse")
+	assert.Equal(t, 2, metrics.Turns, "turns should be counted from turn records")
+	assert.Equal(t, 40, metrics.TokenUsage, "token usage should be summed over turns")
+}
+
+func TestParseAgentTurnsMetrics_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{name: "empty", content: "\n", wantErr: "empty"},
+		{name: "missing header", content: `{"type":"turn","turn":1}`, wantErr: "header"},
+		{name: "unsupported version", content: `{"type":"header","format":"gh-aw.agent-turns","version":2}`, wantErr: "version 2"},
+		{name: "malformed line", content: "{\"type\":\"header\",\"format\":\"gh-aw.agent-turns\",\"version\":1}\nnot json", wantErr: "line 2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseAgentTurnsMetrics(tt.content)
+			require.Error(t, err, "invalid agent turns log should fail")
+			assert.Contains(t, err.Error(), tt.wantErr, "error message")
+		})
+	}
+}
@@ -305,7 +305,11 @@ fi`,
 		)
 		// Explicitly mount the workspace so AWF can see it without path-prefix translation.
 		// GITHUB_WORKSPACE is on the shared work volume, so the Docker daemon can access it.
-		expandableArgs += ` --mount "${GITHUB_WORKSPACE}:${GITHUB_WORKSPACE}:rw"`
+		workspaceMode := "rw"
+		if isAgentFilesystemReadOnly(config.WorkflowData) {
+			workspaceMode = "ro"
+		}
+		expandableArgs += fmt.Sprintf(` --mount "${GITHUB_WORKSPACE}:${GITHUB_WORKSPACE}:%s"`, workspaceMode)
 		// Pre-create the rw mount source directories. AWF validates that mount source
 		// paths exist before starting containers, so these must be created on the host
 		// before the AWF invocation. The parent ${RUNNER_TEMP}/gh-aw/ already exists
@@ -334,6 +338,9 @@ fi`,
 	if err != nil {
 		awfHelpersLog.Printf("Warning: failed to build AWF config JSON: %v", err)
 	} else {
+		// Keep the valid JSON for the firewall policy export; the runtime variable
+		// injection below makes awfConfigJSON a shell template rather than JSON.
+		compiledAWFConfigJSON := awfConfigJSON
 		// When max-ai-credits is not set by frontmatter/imports, export a local shell
 		// variable (GH_AW_MAX_AI_CREDITS) holding a GitHub Actions runtime expression,
 		// then inject a reference to that variable (${GH_AW_MAX_AI_CREDITS}) into the
@@ -419,6 +426,12 @@ fi`,
 			}
 		}
 		configFileSetup += fmt.Sprintf("\ncp %q %s", awfConfigRuntimePathExpr, constants.AWFConfigFilePath)
+		// Export the compiled domain policy as a standalone document for review.
+		if policyExport, policyErr := buildFirewallPolicyExportScript(config.WorkflowData, compiledAWFConfigJSON); policyErr != nil {
+			awfHelpersLog.Printf("Warning: failed to build firewall policy export: %v", policyErr)
+		} else {
+			configFileSetup += "\n" + policyExport
+		}
 		// Add --config as the first expandable arg so it appears before --container-workdir.
 		expandableArgs = fmt.Sprintf("--config %q ", awfConfigRuntimePathExpr) + expandableArgs
 		awfHelpersLog.Print("Using AWF config file (--config flag)")
@@ -685,6 +698,20 @@ func BuildAWFArgs(config AWFCommandConfig) []string {
 		awfHelpersLog.Printf("Added %d custom mounts from agent config", len(sortedMounts))
 	}
 
+	// Agent sandbox profile: restrict what commands spawned by the engine can do beyond
+	// the domain firewall. Validation rejects these settings for AWF versions that predate
+	// the flags, so the version guard here only protects against direct callers.
+	if awfSupportsSandboxProfile(firewallConfig) {
+		if isAgentFilesystemReadOnly(config.WorkflowData) {
+			awfArgs = append(awfArgs, "--read-only-filesystem")
+			awfHelpersLog.Print("Added --read-only-filesystem (sandbox.agent.filesystem: read-only)")
+		}
+		if isAgentBashNetworkDisabled(config.WorkflowData) {
+			awfArgs = append(awfArgs, "--no-bash-network")
+			awfHelpersLog.Print("Added --no-bash-network (sandbox.agent.bash-network: false)")
+		}
+	}
+
 	// Set log level
 	awfLogLevel := string(constants.AWFDefaultLogLevel)
 	if firewallConfig != nil && firewallConfig.LogLevel != "" {
@@ -768,6 +795,12 @@ func BuildAWFArgs(config AWFCommandConfig) []string {
 		awfHelpersLog.Printf("Added --gemini-api-base-path=%s", geminiBasePath)
 	}
 
+	// Select the egress enforcement backend (network.enforcement)
+	if backendArgs := getFirewallBackend(config.WorkflowData).AWFArgs(); len(backendArgs) > 0 {
+		awfArgs = append(awfArgs, backendArgs...)
+		awfHelpersLog.Printf("Added firewall backend args: %v", backendArgs)
+	}
+
 	// Add SSL Bump support for HTTPS content inspection (v0.9.0+)
 	sslBumpArgs := getSSLBumpArgs(firewallConfig)
 	awfArgs = append(awfArgs, sslBumpArgs...)
@@ -1088,6 +1121,12 @@ func awfSupportsLegacySecurity(firewallConfig *FirewallConfig) bool {
 	return awfVersionAtLeast(firewallConfig, constants.AWFLegacySecurityMinVersion)
 }
 
+// awfSupportsSandboxProfile returns true when the effective AWF version supports the
+// --read-only-filesystem and --no-bash-network flags.
+func awfSupportsSandboxProfile(firewallConfig *FirewallConfig) bool {
+	return awfVersionAtLeast(firewallConfig, constants.AWFSandboxProfileMinVersion)
+}
+
 // buildArcDindChrootConfigPatchBody returns the Node.js command that patches the AWF
 // config file with chroot.binariesSourcePath and chroot.identity.*. It is designed to be
 // embedded inside a bash if-block that already guards on DOCKER_HOST=tcp://...
@@ -664,7 +664,7 @@ func generateCacheMemoryGitCommitSteps(builder *strings.Builder, data *WorkflowD
 
 // generateCacheMemoryValidation generates validation steps for cache-memory file types
 // This should be called after agent execution to validate files before upload/save
-func generateCacheMemoryValidation(builder *strings.Builder, data *WorkflowData) {
+func (c *Compiler) generateCacheMemoryValidation(builder *strings.Builder, data *WorkflowData) {
 	if data.CacheMemoryConfig == nil || len(data.CacheMemoryConfig.Caches) == 0 {
 		return
 	}
@@ -707,7 +707,7 @@ func generateCacheMemoryValidation(builder *strings.Builder, data *WorkflowData)
 		if !useBackwardCompatiblePaths {
 			stepName = fmt.Sprintf("Validate cache-memory file types (%s)", cache.ID)
 		}
-		builder.WriteString(generateInlineGitHubScriptStep(stepName, validationScript.String(), "always()", data))
+		builder.WriteString(c.generateInlineGitHubScriptStep(stepName, validationScript.String(), "always()", data))
 	}
 }
 
@@ -989,7 +989,7 @@ func (c *Compiler) buildUpdateCacheMemoryJob(data *WorkflowData, threatDetection
 			// Generate validation step using helper with condition to only run if cache has content
 			stepName := fmt.Sprintf("Validate cache-memory file types (%s)", cache.ID)
 			condition := fmt.Sprintf("steps.%s.outputs.has_content == 'true'", checkStepID)
-			steps = append(steps, generateInlineGitHubScriptStep(stepName, validationScript.String(), condition, data))
+			steps = append(steps, c.generateInlineGitHubScriptStep(stepName, validationScript.String(), condition, data))
 		}
 
 		// Generate cache key using integrity-aware format (matches generateCacheMemorySteps)
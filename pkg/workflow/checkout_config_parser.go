@@ -276,9 +276,86 @@ func checkoutConfigFromMap(m map[string]any) (*CheckoutConfig, error) {
 		cfg.CleanGitCredentials = b
 	}
 
+	if v, ok := m["same-repo-worktree"]; ok {
+		b, ok := v.(bool)
+		if !ok {
+			return nil, errors.New("checkout.same-repo-worktree must be a boolean")
+		}
+		cfg.SameRepoWorktree = b
+	}
+	if cfg.SameRepoWorktree {
+		if err := validateSameRepoWorktreeConfig(cfg); err != nil {
+			return nil, err
+		}
+	}
+
 	return cfg, nil
 }
 
+// validateSameRepoWorktreeConfig rejects same-repo-worktree entries that set options
+// only actions/checkout can honor. A worktree reuses the clone, credentials, and
+// git configuration of the checkout it is added to.
+func validateSameRepoWorktreeConfig(cfg *CheckoutConfig) error {
+	if strings.TrimSpace(cfg.Ref) == "" {
+		return errors.New("checkout.same-repo-worktree requires ref")
+	}
+	if strings.TrimSpace(cfg.Path) == "" {
+		return errors.New("checkout.same-repo-worktree requires a path other than the workspace root")
+	}
+	var unsupported []string
+	if cfg.GitHubToken != "" {
+		unsupported = append(unsupported, "github-token")
+	}
+	if cfg.GitHubApp != nil {
+		unsupported = append(unsupported, "github-app")
+	}
+	if cfg.SparseCheckout != "" {
+		unsupported = append(unsupported, "sparse-checkout")
+	}
+	if cfg.Submodules != "" {
+		unsupported = append(unsupported, "submodules")
+	}
+	if cfg.LFS {
+		unsupported = append(unsupported, "lfs")
+	}
+	if cfg.Wiki {
+		unsupported = append(unsupported, "wiki")
+	}
+	if cfg.CleanGitCredentials {
+		unsupported = append(unsupported, "force-clean-git-credentials")
+	}
+	if len(unsupported) > 0 {
+		return fmt.Errorf("checkout.same-repo-worktree cannot be combined with %s; the worktree shares the clone and credentials of the checkout it is added to", strings.Join(unsupported, ", "))
+	}
+	return nil
+}
+
+// validateSameRepoWorktreeSources checks that every same-repo-worktree entry has a
+// regular checkout of the same repository to be added to. Worktrees of the current
+// repository may use the default workspace checkout, which is emitted even when it is
+// not listed.
+func validateSameRepoWorktreeSources(configs []*CheckoutConfig) error {
+	sources := make(map[string]struct{})
+	for _, cfg := range configs {
+		if !cfg.SameRepoWorktree && !cfg.Wiki {
+			sources[strings.TrimSpace(cfg.Repository)] = struct{}{}
+		}
+	}
+	for i, cfg := range configs {
+		if !cfg.SameRepoWorktree {
+			continue
+		}
+		repository := strings.TrimSpace(cfg.Repository)
+		if repository == "" {
+			continue
+		}
+		if _, ok := sources[repository]; !ok {
+			return fmt.Errorf("checkout[%d]: same-repo-worktree requires another checkout of %s without same-repo-worktree", i, repository)
+		}
+	}
+	return nil
+}
+
 // buildCheckoutsPromptContent returns a markdown bullet list describing all user-configured
 // checkouts for inclusion in the GitHub context prompt.
 // Returns an empty string when no checkouts are configured.
@@ -339,8 +416,11 @@ func buildCheckoutsPromptContent(checkouts []*CheckoutConfig) string {
 			line += " (**current** - this is the repository you are working on; use this as the target for all GitHub operations unless otherwise specified)"
 		}
 
-		// Annotate fetch-depth so the agent knows how much history is available
-		if cfg.FetchDepth != nil && *cfg.FetchDepth == 0 {
+		// Annotate fetch-depth so the agent knows how much history is available.
+		// Worktrees share the history of the checkout they were added to.
+		if cfg.SameRepoWorktree {
+			line += fmt.Sprintf(" [git worktree of ref `%s`, sharing history with the other checkout of this repository]", cfg.Ref)
+		} else if cfg.FetchDepth != nil && *cfg.FetchDepth == 0 {
 			line += " [full history, all branches available as remote-tracking refs]"
 		} else if cfg.FetchDepth != nil {
 			line += fmt.Sprintf(" [shallow clone, fetch-depth=%d]", *cfg.FetchDepth)
@@ -114,6 +114,12 @@ type CheckoutConfig struct {
 	// injects a follow-up cleanup step that removes credentials from git config files
 	// (including submodule configs) without using git submodule foreach.
 	CleanGitCredentials bool `json:"force-clean-git-credentials,omitempty"`
+
+	// SameRepoWorktree checks out Ref as a git worktree of another checkout of the same
+	// repository instead of running a second actions/checkout. The ref is fetched into
+	// the existing clone and added at Path with "git worktree add", so both refs share
+	// one object store. Requires ref and a non-root path.
+	SameRepoWorktree bool `json:"same-repo-worktree,omitempty"`
 }
 
 // checkoutKey uniquely identifies a checkout target used for grouping/deduplication.
@@ -141,6 +147,7 @@ type resolvedCheckout struct {
 	current        bool     // true if this checkout is the logical current repository
 	fetchRefs      []string // merged fetch ref patterns (see CheckoutConfig.Fetch)
 	cleanCreds     bool     // true enables persist-credentials + injected cleanup step
+	worktree       bool     // true adds this checkout as a git worktree of another checkout
 	// wiki is intentionally not stored here; use entry.key.wiki instead.
 }
 
@@ -259,6 +266,30 @@ func (cm *CheckoutManager) SetPushToken(token string) {
 	cm.pushToken = token
 }
 
+// EnsureDefaultFullHistory makes the default workspace checkout fetch full history
+// (fetch-depth: 0) unless the user explicitly configured a fetch-depth for it.
+// Call this for jobs that run git operations needing commit history, such as the
+// safe_outputs job for create-pull-request and push-to-pull-request-branch, which
+// otherwise fail at runtime with "shallow update not allowed" on a shallow clone.
+//
+// An explicit user fetch-depth is respected because users may intentionally limit
+// history on very large repositories. Returns true when the depth was changed.
+func (cm *CheckoutManager) EnsureDefaultFullHistory() bool {
+	override := cm.GetDefaultCheckoutOverride()
+	if override != nil && override.fetchDepth != nil {
+		checkoutManagerLog.Printf("Keeping user-configured fetch-depth=%d for default checkout", *override.fetchDepth)
+		return false
+	}
+	fullHistory := 0
+	if override != nil {
+		override.fetchDepth = &fullHistory
+	} else {
+		cm.add(&CheckoutConfig{FetchDepth: &fullHistory})
+	}
+	checkoutManagerLog.Print("Derived fetch-depth=0 for default checkout")
+	return true
+}
+
 // add processes a single CheckoutConfig and either creates a new entry or merges
 // it into an existing entry with the same key.
 func (cm *CheckoutManager) add(cfg *CheckoutConfig) {
@@ -319,6 +350,9 @@ func (cm *CheckoutManager) add(cfg *CheckoutConfig) {
 		if cfg.CleanGitCredentials {
 			entry.cleanCreds = true
 		}
+		if cfg.SameRepoWorktree {
+			entry.worktree = true
+		}
 		checkoutManagerLog.Printf("Merged checkout for path=%q repository=%q", key.path, key.repository)
 	} else {
 		entry := &resolvedCheckout{
@@ -332,6 +366,7 @@ func (cm *CheckoutManager) add(cfg *CheckoutConfig) {
 			lfs:           cfg.LFS,
 			current:       cfg.Current,
 			cleanCreds:    cfg.CleanGitCredentials,
+			worktree:      cfg.SameRepoWorktree,
 		}
 		if cfg.SparseCheckout != "" {
 			entry.sparsePatterns = mergeSparsePatterns(nil, cfg.SparseCheckout)
@@ -642,6 +642,45 @@ func TestDeeperFetchDepth(t *testing.T) {
 	}
 }
 
+// TestEnsureDefaultFullHistory verifies that the default checkout is deepened to full
+// history unless the user configured an explicit fetch-depth.
+func TestEnsureDefaultFullHistory(t *testing.T) {
+	t.Run("no user checkout adds full-history default", func(t *testing.T) {
+		cm := NewCheckoutManager(nil)
+		assert.True(t, cm.EnsureDefaultFullHistory(), "should report a derived depth")
+		override := cm.GetDefaultCheckoutOverride()
+		require.NotNil(t, override, "should create a default override")
+		require.NotNil(t, override.fetchDepth, "fetch depth should be set")
+		assert.Equal(t, 0, *override.fetchDepth, "fetch depth should be 0")
+
+		lines := strings.Join(cm.GenerateDefaultCheckoutStep(false, "", func(s string) string { return s }), "")
+		assert.Contains(t, lines, "fetch-depth: 0", "default checkout step should fetch full history")
+	})
+
+	t.Run("existing default override without depth is deepened", func(t *testing.T) {
+		cm := NewCheckoutManager([]*CheckoutConfig{{Ref: "main"}})
+		assert.True(t, cm.EnsureDefaultFullHistory(), "should report a derived depth")
+		override := cm.GetDefaultCheckoutOverride()
+		require.NotNil(t, override.fetchDepth, "fetch depth should be set")
+		assert.Equal(t, 0, *override.fetchDepth, "fetch depth should be 0")
+		assert.Equal(t, "main", override.ref, "ref should be preserved")
+	})
+
+	t.Run("explicit user fetch-depth is respected", func(t *testing.T) {
+		depth := 50
+		cm := NewCheckoutManager([]*CheckoutConfig{{FetchDepth: &depth}})
+		assert.False(t, cm.EnsureDefaultFullHistory(), "should not change an explicit depth")
+		assert.Equal(t, 50, *cm.GetDefaultCheckoutOverride().fetchDepth, "fetch depth should be unchanged")
+	})
+
+	t.Run("additional checkouts are not affected", func(t *testing.T) {
+		cm := NewCheckoutManager([]*CheckoutConfig{{Repository: "owner/other", Path: "other"}})
+		cm.EnsureDefaultFullHistory()
+		require.Len(t, cm.ordered, 2, "should add a separate default entry")
+		assert.Nil(t, cm.ordered[0].fetchDepth, "additional checkout depth should be unchanged")
+	})
+}
+
 // TestMergeSparsePatterns tests pattern deduplication and merging.
 func TestMergeSparsePatterns(t *testing.T) {
 	t.Run("merges unique patterns", func(t *testing.T) {
@@ -1699,6 +1738,38 @@ func TestGenerateCheckoutManifestStep(t *testing.T) {
 // step never inlines GitHub Actions expressions directly into the shell run: block.
 // Regression test for: compiler inlines workflow_dispatch input into generated step,
 // tripping the template-injection scanner for target-repo workflows.
+func TestGenerateAdditionalCheckoutCleanupStep(t *testing.T) {
+	t.Run("no token-authenticated checkouts emits nothing", func(t *testing.T) {
+		cm := NewCheckoutManager([]*CheckoutConfig{
+			{Path: "."},
+			{Repository: "owner/public", Path: "./public"},
+		})
+		assert.Empty(t, cm.GenerateAdditionalCheckoutCleanupStep(), "checkouts using the default token should not be wiped")
+	})
+
+	t.Run("root checkout with token is not wiped", func(t *testing.T) {
+		cm := NewCheckoutManager([]*CheckoutConfig{
+			{Repository: "owner/other", GitHubToken: "${{ secrets.PAT }}"},
+		})
+		assert.Empty(t, cm.GenerateAdditionalCheckoutCleanupStep(), "root checkout replaces the workspace and must be kept")
+	})
+
+	t.Run("token and app checkouts are listed", func(t *testing.T) {
+		cm := NewCheckoutManager([]*CheckoutConfig{
+			{Repository: "owner/libs", Path: "./libs", GitHubToken: "${{ secrets.PAT }}"},
+			{Repository: "owner/docs", Path: "docs", GitHubApp: &GitHubAppConfig{AppID: "${{ vars.APP_ID }}", PrivateKey: "${{ secrets.KEY }}"}},
+			{Repository: "owner/public", Path: "./public"},
+		})
+		step := cm.GenerateAdditionalCheckoutCleanupStep()
+		require.NotEmpty(t, step, "should emit a cleanup step")
+		assert.Contains(t, step, "if: always()")
+		assert.Contains(t, step, "            ./libs\n")
+		assert.Contains(t, step, "            docs\n")
+		assert.NotContains(t, step, "public", "default-token checkouts should be kept")
+		assert.Contains(t, step, "wipe_additional_checkouts.sh")
+	})
+}
+
 func TestGenerateConfigureGitCredentialsSteps(t *testing.T) {
 	alwaysTrue := BuildBooleanLiteral(true)
 	token := "${{ steps.safe-outputs-app-token.outputs.token }}"
@@ -1795,3 +1866,91 @@ func TestGenerateConfigureGitCredentialsSteps(t *testing.T) {
 		assert.Contains(t, combined, `${GH_AW_SUBREPO_1}.git`)
 	})
 }
+
+// TestSameRepoWorktreeCheckout verifies that same-repo-worktree checkouts reuse an
+// existing clone instead of running a second actions/checkout.
+func TestSameRepoWorktreeCheckout(t *testing.T) {
+	getPin := func(action string) string { return action + "@v4" }
+
+	t.Run("parse same-repo-worktree", func(t *testing.T) {
+		configs, err := ParseCheckoutConfigs([]any{
+			map[string]any{"ref": "main", "path": "./base"},
+			map[string]any{"ref": "${{ github.head_ref }}", "path": "./head", "same-repo-worktree": true},
+		})
+		require.NoError(t, err, "should parse without error")
+		require.Len(t, configs, 2)
+		assert.False(t, configs[0].SameRepoWorktree, "base should be a regular checkout")
+		assert.True(t, configs[1].SameRepoWorktree, "head should be a worktree")
+	})
+
+	t.Run("invalid worktree configs return errors", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			raw     map[string]any
+			wantErr string
+		}{
+			{name: "non-boolean", raw: map[string]any{"ref": "main", "path": "./head", "same-repo-worktree": "yes"}, wantErr: "must be a boolean"},
+			{name: "missing ref", raw: map[string]any{"path": "./head", "same-repo-worktree": true}, wantErr: "requires ref"},
+			{name: "root path", raw: map[string]any{"ref": "main", "path": ".", "same-repo-worktree": true}, wantErr: "requires a path"},
+			{name: "own token", raw: map[string]any{"ref": "main", "path": "./head", "github-token": "${{ secrets.PAT }}", "same-repo-worktree": true}, wantErr: "cannot be combined with github-token"},
+			{name: "sparse checkout", raw: map[string]any{"ref": "main", "path": "./head", "sparse-checkout": "src/", "same-repo-worktree": true}, wantErr: "cannot be combined with sparse-checkout"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := ParseCheckoutConfigs(tt.raw)
+				require.Error(t, err, "config should be rejected")
+				assert.Contains(t, err.Error(), tt.wantErr, "error message")
+			})
+		}
+	})
+
+	t.Run("cross-repo worktree requires a checkout of that repository", func(t *testing.T) {
+		err := validateSameRepoWorktreeSources([]*CheckoutConfig{
+			{Repository: "owner/other", Ref: "feature", Path: "./other-head", SameRepoWorktree: true},
+		})
+		require.Error(t, err, "worktree without a source should be rejected")
+		assert.Contains(t, err.Error(), "requires another checkout of owner/other", "error message")
+
+		err = validateSameRepoWorktreeSources([]*CheckoutConfig{
+			{Repository: "owner/other", Path: "./other"},
+			{Repository: "owner/other", Ref: "feature", Path: "./other-head", SameRepoWorktree: true},
+			{Ref: "feature", Path: "./head", SameRepoWorktree: true},
+		})
+		require.NoError(t, err, "worktrees with a source checkout should be valid")
+	})
+
+	t.Run("worktree is added to the listed checkout", func(t *testing.T) {
+		fullHistory := 0
+		cm := NewCheckoutManager([]*CheckoutConfig{
+			{Ref: "${{ github.head_ref }}", Path: "./head", SameRepoWorktree: true},
+			{Ref: "main", Path: "./base", FetchDepth: &fullHistory},
+		})
+		combined := strings.Join(cm.GenerateAdditionalCheckoutSteps(getPin), "")
+
+		assert.Equal(t, 1, strings.Count(combined, "uses: actions/checkout@v4"), "only the base should use actions/checkout")
+		assert.Less(t, strings.Index(combined, "Checkout ./base"), strings.Index(combined, "Add worktree ./head"), "worktree should be added after its source checkout")
+		assert.Contains(t, combined, "GH_AW_WORKTREE_REF: ${{ github.head_ref }}", "ref should be passed through the environment")
+		assert.Contains(t, combined, `git -C "${{ github.workspace }}/./base" -c "http.extraheader=Authorization: Basic ${header}" fetch --no-tags origin "${GH_AW_WORKTREE_REF}"`, "ref should be fetched into the base clone with its depth")
+		assert.Contains(t, combined, `git -C "${{ github.workspace }}/./base" worktree add --detach "${{ github.workspace }}/./head" FETCH_HEAD`, "worktree should be added from the base clone")
+	})
+
+	t.Run("worktree of the current repository defaults to the workspace checkout", func(t *testing.T) {
+		cm := NewCheckoutManager([]*CheckoutConfig{
+			{Ref: "main", Path: "./base", SameRepoWorktree: true},
+		})
+		combined := strings.Join(cm.GenerateAdditionalCheckoutSteps(getPin), "")
+
+		assert.NotContains(t, combined, "actions/checkout", "no additional actions/checkout should be emitted")
+		assert.Contains(t, combined, `git -c "http.extraheader=Authorization: Basic ${header}" fetch --no-tags origin --depth=1 "${GH_AW_WORKTREE_REF}"`, "ref should be fetched into the workspace clone")
+		assert.Contains(t, combined, `git worktree add --detach "${{ github.workspace }}/./base" FETCH_HEAD`, "worktree should be added from the workspace clone")
+	})
+
+	t.Run("worktrees are not listed in the checkout manifest", func(t *testing.T) {
+		cm := NewCheckoutManager([]*CheckoutConfig{
+			{Repository: "owner/other", Path: "./other"},
+			{Repository: "owner/other", Ref: "feature", Path: "./other-head", SameRepoWorktree: true},
+		})
+		combined := strings.Join(cm.GenerateCheckoutManifestStep(getPin), "")
+		assert.Contains(t, combined, "GH_AW_CHECKOUT_MANIFEST_COUNT: \"1\"", "only the source checkout should be recorded")
+	})
+}
@@ -101,16 +101,71 @@ func (cm *CheckoutManager) GenerateAdditionalCheckoutSteps(getActionPin func(str
 	checkoutManagerLog.Printf("Generating additional checkout steps from %d configured entries", len(cm.ordered))
 	var lines []string
 	for checkoutIndex, entry := range cm.ordered {
-		// Skip the default checkout (handled separately)
-		if entry.key.path == "" && entry.key.repository == "" {
+		// Skip the default checkout (handled separately) and worktrees (emitted below)
+		if (entry.key.path == "" && entry.key.repository == "") || entry.worktree {
 			continue
 		}
 		lines = append(lines, generateCheckoutStepLines(entry, checkoutIndex, cm.keepCredentialsForPush, cm.pushToken, getActionPin)...)
 	}
+	// Worktrees are added after every regular checkout so the clone they share exists.
+	for checkoutIndex, entry := range cm.ordered {
+		if !entry.worktree {
+			continue
+		}
+		sourceIndex := cm.worktreeSourceIndex(entry)
+		var source *resolvedCheckout
+		if sourceIndex >= 0 {
+			source = cm.ordered[sourceIndex]
+		}
+		lines = append(lines, generateWorktreeStepLines(entry, checkoutIndex, source, sourceIndex))
+		if fetchStep := generateFetchStepLines(entry, checkoutIndex); fetchStep != "" {
+			lines = append(lines, fetchStep)
+		}
+	}
 	checkoutManagerLog.Printf("Generated %d additional checkout step(s)", len(lines))
 	return lines
 }
 
+// GenerateAdditionalCheckoutCleanupStep emits a post-agent step that deletes the
+// working trees of additional checkouts that authenticate with their own
+// github-token or GitHub App, along with any credential files actions/checkout left
+// in RUNNER_TEMP. Such checkouts usually target repositories unrelated to the
+// safe-output jobs, so removing them once the agent artifact has been uploaded
+// limits what a later job on the same runner can reach.
+//
+// Root checkouts (empty path) are never wiped since they replace the workspace.
+// Returns an empty string when there is nothing to clean up.
+func (cm *CheckoutManager) GenerateAdditionalCheckoutCleanupStep() string {
+	var paths []string
+	seen := make(map[string]bool)
+	for _, entry := range cm.ordered {
+		if entry.key.path == "" || (entry.token == "" && entry.githubApp == nil) {
+			continue
+		}
+		if seen[entry.key.path] {
+			continue
+		}
+		seen[entry.key.path] = true
+		paths = append(paths, entry.key.path)
+	}
+	if len(paths) == 0 {
+		return ""
+	}
+	checkoutManagerLog.Printf("Generating cleanup step for %d additional checkout(s)", len(paths))
+
+	var sb strings.Builder
+	sb.WriteString("      - name: Remove additional checkouts\n")
+	sb.WriteString("        if: always()\n")
+	sb.WriteString("        continue-on-error: true\n")
+	sb.WriteString("        env:\n")
+	sb.WriteString("          GH_AW_ADDITIONAL_CHECKOUT_PATHS: |\n")
+	for _, path := range paths {
+		fmt.Fprintf(&sb, "            %s\n", path)
+	}
+	sb.WriteString("        run: bash \"${RUNNER_TEMP}/gh-aw/actions/wipe_additional_checkouts.sh\"\n")
+	return sb.String()
+}
+
 // GenerateCheckoutManifestStep emits a step that writes a JSON manifest describing
 // each non-default cross-repository checkout, keyed by lowercase repo slug. The
 // manifest records the on-disk path and the resolved default branch for each repo
@@ -133,7 +188,7 @@ func (cm *CheckoutManager) GenerateCheckoutManifestStep(getActionPin func(string
 	}
 	var entries []manifestEntry
 	for checkoutIndex, entry := range cm.ordered {
-		if entry.key.wiki {
+		if entry.key.wiki || entry.worktree {
 			continue
 		}
 		if entry.key.repository == "" {
@@ -612,6 +667,59 @@ func generateSparseCheckoutPartialCloneResetStep(path string) string {
 `, gitPrefix, gitPrefix)
 }
 
+// worktreeSourceIndex returns the index of the checkout a same-repo-worktree entry is
+// added to: the first regular checkout of the same repository. Returns -1 when none is
+// listed, in which case a worktree of the current repository uses the default
+// workspace checkout.
+func (cm *CheckoutManager) worktreeSourceIndex(worktree *resolvedCheckout) int {
+	for i, entry := range cm.ordered {
+		if !entry.worktree && !entry.key.wiki && entry.key.repository == worktree.key.repository {
+			return i
+		}
+	}
+	return -1
+}
+
+// generateWorktreeStepLines generates a step that fetches a same-repo-worktree entry's
+// ref into the checkout it shares and adds it with "git worktree add". This replaces a
+// second full clone of the repository with a single fetch of the missing commits.
+//
+// The source is the checkout the worktree is added to; nil means the default workspace
+// checkout. Without its own fetch-depth the worktree fetches as deep as the source.
+// The fetch authenticates the same way as generateFetchStepLines, since the
+// source checkout does not persist credentials. The ref is passed through an environment
+// variable so expressions such as ${{ github.head_ref }} are never interpolated into the
+// script.
+func generateWorktreeStepLines(entry *resolvedCheckout, index int, source *resolvedCheckout, sourceIndex int) string {
+	checkoutManagerLog.Printf("Generating worktree step: index=%d, path=%q, ref=%q, sourceIndex=%d", index, entry.key.path, entry.ref, sourceIndex)
+
+	token := getEffectiveGitHubToken("")
+	gitPrefix := "git"
+	fetchDepth := entry.fetchDepth
+	if source != nil {
+		token = resolveCheckoutTokenExpression(source, sourceIndex, true)
+		// Inherit the source's depth so a shallow fetch does not graft a full clone.
+		if fetchDepth == nil {
+			fetchDepth = source.fetchDepth
+		}
+		if source.key.path != "" {
+			gitPrefix = fmt.Sprintf(`git -C "${{ github.workspace }}/%s"`, source.key.path)
+		}
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "      - name: Add worktree %s\n", checkoutStepName(entry.key))
+	sb.WriteString("        env:\n")
+	fmt.Fprintf(&sb, "          GH_AW_FETCH_TOKEN: %s\n", token)
+	fmt.Fprintf(&sb, "          GH_AW_WORKTREE_REF: %s\n", entry.ref)
+	sb.WriteString("        run: |\n")
+	sb.WriteString("          header=$(printf \"x-access-token:%s\" \"${GH_AW_FETCH_TOKEN}\" | base64 -w 0)\n")
+	fmt.Fprintf(&sb, `          %s -c "http.extraheader=Authorization: Basic ${header}" fetch --no-tags origin%s "${GH_AW_WORKTREE_REF}"`+"\n",
+		gitPrefix, fetchDepthFlag(fetchDepth))
+	fmt.Fprintf(&sb, `          %s worktree add --detach "${{ github.workspace }}/%s" FETCH_HEAD`+"\n", gitPrefix, entry.key.path)
+	return sb.String()
+}
+
 // checkoutStepName returns a human-readable description for a checkout step.
 func checkoutStepName(key checkoutKey) string {
 	if key.repository != "" && key.path != "" {
@@ -689,17 +797,7 @@ func generateFetchStepLines(entry *resolvedCheckout, index int) string {
 
 	// Mirror the fetch-depth from the actions/checkout step so this fetch doesn't
 	// expand a shallow clone into a full-history fetch.
-	// - nil (unset) → actions/checkout defaults to depth=1; pass --depth=1
-	// - 0           → full history explicitly requested; omit the flag
-	// - N > 0       → pass --depth=N to match
-	depthFlag := ""
-	effectiveDepth := 1
-	if entry.fetchDepth != nil {
-		effectiveDepth = *entry.fetchDepth
-	}
-	if effectiveDepth > 0 {
-		depthFlag = fmt.Sprintf(" --depth=%d", effectiveDepth)
-	}
+	depthFlag := fetchDepthFlag(entry.fetchDepth)
 
 	var sb strings.Builder
 	fmt.Fprintf(&sb, "      - name: %s\n", name)
@@ -712,6 +810,21 @@ func generateFetchStepLines(entry *resolvedCheckout, index int) string {
 	return sb.String()
 }
 
+// fetchDepthFlag returns the git fetch --depth flag matching a checkout's fetch-depth:
+//   - nil (unset) → actions/checkout defaults to depth=1; pass --depth=1
+//   - 0           → full history explicitly requested; omit the flag
+//   - N > 0       → pass --depth=N to match
+func fetchDepthFlag(fetchDepth *int) string {
+	effectiveDepth := 1
+	if fetchDepth != nil {
+		effectiveDepth = *fetchDepth
+	}
+	if effectiveDepth > 0 {
+		return fmt.Sprintf(" --depth=%d", effectiveDepth)
+	}
+	return ""
+}
+
 func resolveCheckoutTokenExpression(entry *resolvedCheckout, checkoutIndex int, defaultWhenEmpty bool) string {
 	token := entry.token
 	if entry.githubApp != nil {
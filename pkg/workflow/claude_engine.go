@@ -487,6 +487,7 @@ func buildClaudeBaseEnvMap(provider string, workflowData *WorkflowData) map[stri
 }
 
 // applyClaudeTimeoutEnvVars sets MCP/Bash timeout env vars derived from workflow config.
+// tool-timeouts.bash overrides tools.timeout for the Bash tool only.
 func applyClaudeTimeoutEnvVars(env map[string]string, workflowData *WorkflowData) {
 	startupTimeoutMs := int(constants.DefaultMCPStartupTimeout / time.Millisecond)
 	if n := templatableIntValue(&workflowData.ToolsStartupTimeout); n > 0 {
@@ -498,8 +499,12 @@ func applyClaudeTimeoutEnvVars(env map[string]string, workflowData *WorkflowData
 	}
 	env["MCP_TIMEOUT"] = strconv.Itoa(startupTimeoutMs)
 	env["MCP_TOOL_TIMEOUT"] = strconv.Itoa(timeoutMs)
-	env["BASH_DEFAULT_TIMEOUT_MS"] = strconv.Itoa(timeoutMs)
-	env["BASH_MAX_TIMEOUT_MS"] = strconv.Itoa(timeoutMs)
+	bashTimeoutMs := timeoutMs
+	if n := workflowData.ToolTimeouts["bash"]; n > 0 {
+		bashTimeoutMs = n * 1000
+	}
+	env["BASH_DEFAULT_TIMEOUT_MS"] = strconv.Itoa(bashTimeoutMs)
+	env["BASH_MAX_TIMEOUT_MS"] = strconv.Itoa(bashTimeoutMs)
 }
 
 // applyClaudeModelEnvVars configures ANTHROPIC_MODEL (or fallback env vars) in env.
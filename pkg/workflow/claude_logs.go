@@ -26,6 +26,7 @@ func (e *ClaudeEngine) ParseLogMetrics(logContent string, verbose bool) LogMetri
 			metrics.Turns = resultMetrics.Turns
 			metrics.ToolCalls = resultMetrics.ToolCalls         // Copy tool calls
 			metrics.ToolSequences = resultMetrics.ToolSequences // Copy tool sequences
+			metrics.TurnTokens = resultMetrics.TurnTokens       // Copy per-turn token usage
 		}
 	}
 
@@ -236,6 +237,7 @@ func (e *ClaudeEngine) parseClaudeJSONLog(logContent string, verbose bool) LogMe
 	// Look for the result entry with type: "result"
 	toolCallMap := make(map[string]*ToolCallInfo) // Track tool calls across entries
 	var currentSequence []string                  // Track tool sequence within current context
+	turnIndexByMessageID := make(map[string]int)  // Index into metrics.TurnTokens by assistant message id
 
 	for _, entry := range logEntries {
 		if entryType, exists := entry["type"]; exists {
@@ -290,6 +292,7 @@ func (e *ClaudeEngine) parseClaudeJSONLog(logContent string, verbose bool) LogMe
 								if len(sequenceInMessage) > 0 {
 									currentSequence = append(currentSequence, sequenceInMessage...)
 								}
+								metrics.TurnTokens = e.recordClaudeTurnUsage(metrics.TurnTokens, messageMap, sequenceInMessage, turnIndexByMessageID)
 							}
 						}
 					}
@@ -330,6 +333,41 @@ func (e *ClaudeEngine) parseClaudeJSONLog(logContent string, verbose bool) LogMe
 	return metrics
 }
 
+// recordClaudeTurnUsage records the token usage of an assistant message as a turn.
+// Claude stream-json emits one assistant entry per content block, each repeating the
+// message usage, so entries that share a message id are merged into a single turn.
+func (e *ClaudeEngine) recordClaudeTurnUsage(turns []TurnTokenUsage, messageMap map[string]any, toolCalls []string, turnIndex map[string]int) []TurnTokenUsage {
+	var usage TurnTokenUsage
+	hasUsage := false
+	if usageMap, ok := messageMap["usage"].(map[string]any); ok {
+		usage, hasUsage = ExtractTurnTokenUsage(usageMap)
+	}
+
+	id, _ := messageMap["id"].(string)
+	if idx, seen := turnIndex[id]; seen && id != "" {
+		turn := &turns[idx]
+		if hasUsage {
+			// Streaming entries report cumulative output; keep the largest counts seen
+			turn.InputTokens = max(turn.InputTokens, usage.InputTokens)
+			turn.OutputTokens = max(turn.OutputTokens, usage.OutputTokens)
+			turn.CacheReadTokens = max(turn.CacheReadTokens, usage.CacheReadTokens)
+			turn.CacheWriteTokens = max(turn.CacheWriteTokens, usage.CacheWriteTokens)
+		}
+		turn.ToolCalls = append(turn.ToolCalls, toolCalls...)
+		return turns
+	}
+	if !hasUsage {
+		return turns
+	}
+
+	usage.Turn = len(turns) + 1
+	usage.ToolCalls = append([]string(nil), toolCalls...)
+	if id != "" {
+		turnIndex[id] = len(turns)
+	}
+	return append(turns, usage)
+}
+
 // parseToolCallsWithSequence extracts tool call information from Claude log content array and returns sequence
 func (e *ClaudeEngine) parseToolCallsWithSequence(contentArray []any, toolCallMap map[string]*ToolCallInfo) []string {
 	var sequence []string
@@ -55,33 +55,35 @@ func (e *CodexEngine) RenderMCPConfig(yaml *strings.Builder, tools map[string]an
 	// Generate [mcp_servers] section
 	for _, toolName := range mcpTools {
 		renderer := createRenderer(false) // isLast is always false in TOML format
+		var serverConfig strings.Builder
 		switch toolName {
 		case "github":
 			githubTool, _ := expandedTools["github"].(map[string]any)
-			renderer.RenderGitHubMCP(&mcpConfigContent, githubTool, workflowData)
+			renderer.RenderGitHubMCP(&serverConfig, githubTool, workflowData)
 		case "playwright":
 			playwrightTool := expandedTools["playwright"]
-			renderer.RenderPlaywrightMCP(&mcpConfigContent, playwrightTool)
+			renderer.RenderPlaywrightMCP(&serverConfig, playwrightTool)
 		case "agentic-workflows":
-			renderer.RenderAgenticWorkflowsMCP(&mcpConfigContent)
+			renderer.RenderAgenticWorkflowsMCP(&serverConfig)
 		case "safe-outputs":
 			// Add safe-outputs MCP server if safe-outputs are configured
 			hasSafeOutputs := workflowData != nil && workflowData.SafeOutputs != nil && HasSafeOutputsEnabled(workflowData.SafeOutputs)
 			if hasSafeOutputs {
-				renderer.RenderSafeOutputsMCP(&mcpConfigContent, workflowData)
+				renderer.RenderSafeOutputsMCP(&serverConfig, workflowData)
 			}
 		case "mcp-scripts":
 			// Add mcp-scripts MCP server if mcp-scripts are configured and feature flag is enabled
 			hasMCPScripts := workflowData != nil && IsMCPScriptsEnabled(workflowData.MCPScripts)
 			if hasMCPScripts {
-				renderer.RenderMCPScriptsMCP(&mcpConfigContent, workflowData.MCPScripts, workflowData)
+				renderer.RenderMCPScriptsMCP(&serverConfig, workflowData.MCPScripts, workflowData)
 			}
 		default:
 			// Handle custom MCP tools using shared helper (with adapter for isLast parameter)
-			HandleCustomMCPToolInSwitch(&mcpConfigContent, toolName, expandedTools, false, func(yaml *strings.Builder, toolName string, toolConfig map[string]any, isLast bool) error {
+			HandleCustomMCPToolInSwitch(&serverConfig, toolName, expandedTools, false, func(yaml *strings.Builder, toolName string, toolConfig map[string]any, isLast bool) error {
 				return e.renderCodexMCPConfigWithContext(yaml, toolName, toolConfig, workflowData)
 			})
 		}
+		mcpConfigContent.WriteString(applyCodexToolTimeout(serverConfig.String(), toolName, workflowData))
 	}
 
 	// Append custom config if provided
@@ -0,0 +1,356 @@
+package workflow
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var compareEnginesLog = logger.New("workflow:compare_engines")
+
+// compareEnginesDir is where the compare_engines job downloads each engine's agent artifact.
+const compareEnginesDir = "/tmp/gh-aw/compare-engines"
+
+// CompareEnginesConfig is the compare-engines: configuration. The workflow's engine runs
+// in the agent job and every other listed engine runs the same prompt in a parallel
+// agent_<engine> job. Safe outputs are not executed; the compare_engines job collects
+// each engine's output and summarizes them side by side. Because a comment publishes
+// agent text, Comment adds a threat detection job per agent job and only posts when
+// every engine's output passed detection.
+type CompareEnginesConfig struct {
+	Engines []string // engine IDs to compare, including the workflow engine
+	Comment bool     // post the comparison as a comment on the triggering issue or pull request
+}
+
+// extractCompareEnginesConfig parses compare-engines: in either the list form
+// (compare-engines: [copilot, claude]) or the object form with engines and comment.
+func extractCompareEnginesConfig(frontmatter map[string]any) (*CompareEnginesConfig, error) {
+	raw, exists := frontmatter["compare-engines"]
+	if !exists || raw == nil {
+		return nil, nil
+	}
+
+	config := &CompareEnginesConfig{}
+	var engines any
+	switch v := raw.(type) {
+	case []any:
+		engines = v
+	case map[string]any:
+		engines = v["engines"]
+		if comment, ok := v["comment"]; ok {
+			commentBool, ok := comment.(bool)
+			if !ok {
+				return nil, fmt.Errorf("compare-engines.comment must be a boolean, got %T", comment)
+			}
+			config.Comment = commentBool
+		}
+	default:
+		return nil, fmt.Errorf("compare-engines must be a list of engine IDs or an object with engines, got %T", raw)
+	}
+
+	list, ok := engines.([]any)
+	if !ok {
+		return nil, errors.New("compare-engines.engines must be a list of engine IDs")
+	}
+	for _, item := range list {
+		id, ok := item.(string)
+		if !ok || strings.TrimSpace(id) == "" {
+			return nil, fmt.Errorf("compare-engines: engine IDs must be non-empty strings, got %v", item)
+		}
+		config.Engines = append(config.Engines, strings.TrimSpace(id))
+	}
+
+	compareEnginesLog.Printf("Extracted compare-engines: engines=%v, comment=%v", config.Engines, config.Comment)
+	return config, nil
+}
+
+// validateCompareEngines checks that compare-engines lists at least two distinct, known
+// engines including the workflow engine, and that the workflow does not use features
+// whose state would be written by more than one agent job.
+func (c *Compiler) validateCompareEngines(data *WorkflowData) error {
+	config := data.CompareEngines
+	if config == nil {
+		return nil
+	}
+
+	const example = "\n\nExample:\n  engine: copilot\n  compare-engines: [copilot, claude]"
+	if len(config.Engines) < 2 {
+		return errors.New("compare-engines must list at least two engines" + example)
+	}
+	seen := make(map[string]bool, len(config.Engines))
+	for _, id := range config.Engines {
+		if !c.engineRegistry.IsValidEngine(id) {
+			return fmt.Errorf("compare-engines: unknown engine %q. Known engines are: %s%s", id, strings.Join(c.engineRegistry.GetSupportedEngines(), ", "), example)
+		}
+		if seen[id] {
+			return fmt.Errorf("compare-engines: engine %q is listed more than once%s", id, example)
+		}
+		seen[id] = true
+	}
+
+	primary := ""
+	if data.EngineConfig != nil {
+		primary = data.EngineConfig.ID
+	}
+	if !seen[primary] {
+		return fmt.Errorf("compare-engines must include the workflow engine %q, which runs in the agent job%s", primary, example)
+	}
+
+	var conflicts []string
+	if data.EngineConfig != nil && len(data.EngineConfig.Fallbacks) > 0 {
+		conflicts = append(conflicts, "an engine fallback chain")
+	}
+	if data.CacheMemoryConfig != nil && len(data.CacheMemoryConfig.Caches) > 0 {
+		conflicts = append(conflicts, "cache-memory")
+	}
+	if data.RepoMemoryConfig != nil && len(data.RepoMemoryConfig.Memories) > 0 {
+		conflicts = append(conflicts, "repo-memory")
+	}
+	if data.Evals.HasEvals() {
+		conflicts = append(conflicts, "evals")
+	}
+	if data.LockForAgent {
+		conflicts = append(conflicts, "lock-for-agent")
+	}
+	if len(conflicts) > 0 {
+		return fmt.Errorf("compare-engines cannot be combined with %s", strings.Join(conflicts, ", "))
+	}
+	if config.Comment && !IsDetectionJobEnabled(data.SafeOutputs) {
+		return errors.New("compare-engines.comment requires threat detection, which scans each engine's output before the comparison is posted. Configure safe-outputs with threat detection enabled, or set compare-engines.comment: false")
+	}
+
+	compareEnginesLog.Printf("compare-engines validated: primary=%s, engines=%v", primary, config.Engines)
+	return nil
+}
+
+// compareEngineJobName returns the job that runs engineID in compare-engines mode.
+// The workflow engine keeps the agent job so existing references to it still work.
+func compareEngineJobName(data *WorkflowData, engineID string) string {
+	if data.EngineConfig != nil && data.EngineConfig.ID == engineID {
+		return string(constants.AgentJobName)
+	}
+	return string(constants.AgentJobName) + compareEngineJobSuffix(engineID)
+}
+
+func compareEngineJobSuffix(engineID string) string {
+	return "_" + strings.ReplaceAll(engineID, "-", "_")
+}
+
+// detectionScannedJobName returns the agent job whose output a detection job scans. In
+// compare-engines mode each additional agent job gets its own detection_<engine> job.
+func detectionScannedJobName(data *WorkflowData) string {
+	if data.CompareEngineID != "" {
+		return string(constants.AgentJobName) + compareEngineJobSuffix(data.CompareEngineID)
+	}
+	return string(constants.AgentJobName)
+}
+
+// detectionJobNameFor returns the name of the detection job that scans data's agent job.
+func detectionJobNameFor(data *WorkflowData) string {
+	if data.CompareEngineID != "" {
+		return string(constants.DetectionJobName) + compareEngineJobSuffix(data.CompareEngineID)
+	}
+	return string(constants.DetectionJobName)
+}
+
+// detectionArtifactPrefixExpr is artifactPrefixExprForAgentDownstreamJob for a detection
+// job, which only depends on the agent job it scans.
+func detectionArtifactPrefixExpr(data *WorkflowData) string {
+	if data.CompareEngineID == "" || !hasWorkflowCallTrigger(data.On) {
+		return artifactPrefixExprForAgentDownstreamJob(data)
+	}
+	return fmt.Sprintf("${{ needs.%s.outputs.artifact_prefix }}", detectionScannedJobName(data))
+}
+
+// detectionArtifactNameFor returns the artifact uploaded by the detection job for data.
+func detectionArtifactNameFor(data *WorkflowData) string {
+	if data.CompareEngineID != "" {
+		return constants.DetectionArtifactName + "-" + data.CompareEngineID
+	}
+	return constants.DetectionArtifactName
+}
+
+// agentArtifactName returns the name of the unified artifact uploaded by an agent job.
+// Additional compare-engines agent jobs upload as agent-<engine> so the artifacts of
+// parallel agent jobs do not clash.
+func agentArtifactName(data *WorkflowData) string {
+	if data.CompareEngineID != "" {
+		return compareEngineArtifactName(data.CompareEngineID)
+	}
+	return constants.AgentArtifactName
+}
+
+func compareEngineArtifactName(engineID string) string {
+	return constants.AgentArtifactName + "-" + engineID
+}
+
+// buildCompareEnginesJobs builds the additional agent jobs and the compare_engines job.
+// It is used instead of buildSafeOutputsJobs: in compare-engines mode the agents still
+// record safe outputs, but nothing is executed.
+func (c *Compiler) buildCompareEnginesJobs(data *WorkflowData, activationJobCreated bool) error {
+	primary := data.EngineConfig.ID
+	for _, engineID := range data.CompareEngines.Engines {
+		if engineID == primary {
+			continue
+		}
+		engineData := buildEngineFallbackWorkflowData(data, engineID)
+		engineData.CompareEngineID = engineID
+		// Step ordering (secret redaction before uploads) is validated per agent job.
+		c.stepOrderTracker = NewStepOrderTracker()
+		job, err := c.buildMainJob(engineData, activationJobCreated)
+		if err != nil {
+			return fmt.Errorf("failed to build agent job for compare engine %s: %w", engineID, err)
+		}
+		job.Name = compareEngineJobName(data, engineID)
+		if err := c.jobManager.AddJob(job); err != nil {
+			return fmt.Errorf("failed to add agent job for compare engine %s: %w", engineID, err)
+		}
+		compareEnginesLog.Printf("Added compare agent job %s", job.Name)
+	}
+
+	// The comment publishes every engine's output, so each agent job gets a detection job.
+	// Detection itself keeps the workflow's configuration; only the scanned job differs.
+	if data.CompareEngines.Comment {
+		for _, engineID := range data.CompareEngines.Engines {
+			detectionData := data
+			if engineID != primary {
+				scanned := *data
+				scanned.CompareEngineID = engineID
+				detectionData = &scanned
+			}
+			job, err := c.buildDetectionJob(detectionData)
+			if err != nil {
+				return fmt.Errorf("failed to build detection job for compare engine %s: %w", engineID, err)
+			}
+			if err := c.jobManager.AddJob(job); err != nil {
+				return fmt.Errorf("failed to add detection job for compare engine %s: %w", engineID, err)
+			}
+			compareEnginesLog.Printf("Added detection job %s", job.Name)
+		}
+	}
+
+	job, err := c.buildCompareEnginesJob(data, activationJobCreated)
+	if err != nil {
+		return err
+	}
+	return c.jobManager.AddJob(job)
+}
+
+// compareEngineEntry describes one engine for compare_engines.cjs.
+type compareEngineEntry struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Job  string `json:"job"`
+	Dir  string `json:"dir"`
+	// Model overrides the job's model output, which every agent job copies from the
+	// activation job and so only describes the workflow engine.
+	Model string `json:"model,omitempty"`
+	// Detection is the threat detection job that must pass before the comparison is
+	// posted as a comment. It is only set when comments are enabled.
+	Detection string `json:"detection,omitempty"`
+}
+
+// buildCompareEnginesJob creates the job that downloads every engine's agent artifact
+// and writes the comparison to the step summary and, optionally, a comment.
+func (c *Compiler) buildCompareEnginesJob(data *WorkflowData, activationJobCreated bool) (*Job, error) {
+	var steps []string
+
+	setupActionRef := c.resolveActionReference("./actions/setup", data)
+	if setupActionRef != "" || c.actionMode.IsScript() {
+		steps = append(steps, c.generateCheckoutActionsFolder(data)...)
+		traceID := fmt.Sprintf("${{ needs.%s.outputs.setup-trace-id }}", constants.ActivationJobName)
+		parentSpanID := setupParentSpanNeedsExpr(constants.ActivationJobName)
+		steps = append(steps, c.generateSetupStep(data, setupActionRef, SetupActionDestination, false, traceID, parentSpanID)...)
+	}
+
+	artifactPrefix := artifactPrefixExprForDownstreamJob(data)
+	var needs []string
+	if activationJobCreated {
+		needs = append(needs, string(constants.ActivationJobName))
+	}
+	entries := make([]compareEngineEntry, 0, len(data.CompareEngines.Engines))
+	for _, engineID := range data.CompareEngines.Engines {
+		engine, err := c.getAgenticEngine(engineID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve compare engine %q: %w", engineID, err)
+		}
+		entry := compareEngineEntry{
+			ID:   engineID,
+			Name: engine.GetDisplayName(),
+			Job:  compareEngineJobName(data, engineID),
+			Dir:  compareEnginesDir + "/" + engineID,
+		}
+		artifactName := constants.AgentArtifactName
+		if entry.Job != string(constants.AgentJobName) {
+			artifactName = compareEngineArtifactName(engineID)
+			entry.Model = "engine default"
+		}
+		needs = append(needs, entry.Job)
+		if data.CompareEngines.Comment {
+			detectionData := *data
+			if entry.Job != string(constants.AgentJobName) {
+				detectionData.CompareEngineID = engineID
+			}
+			entry.Detection = detectionJobNameFor(&detectionData)
+			needs = append(needs, entry.Detection)
+		}
+		entries = append(entries, entry)
+
+		steps = append(steps,
+			fmt.Sprintf("      - name: Download %s output\n", engine.GetDisplayName()),
+			"        continue-on-error: true\n",
+			fmt.Sprintf("        uses: %s\n", c.getActionPin("actions/download-artifact")),
+			"        with:\n",
+			fmt.Sprintf("          name: %s%s\n", artifactPrefix, artifactName),
+			fmt.Sprintf("          path: %s/\n", entry.Dir),
+		)
+	}
+
+	entriesJSON, err := json.Marshal(entries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal compare engines: %w", err)
+	}
+	script := generateGitHubScriptWithRequire("compare_engines.cjs")
+	steps = append(steps,
+		"      - name: Compare engine outputs\n",
+		"        id: compare_engines\n",
+		fmt.Sprintf("        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", data), script)),
+		"        env:\n",
+		formatYAMLEnv("          ", "GH_AW_COMPARE_ENGINES", string(entriesJSON)),
+		"          GH_AW_COMPARE_NEEDS: ${{ toJSON(needs) }}\n",
+		fmt.Sprintf("          GH_AW_COMPARE_COMMENT: %q\n", fmt.Sprintf("%t", data.CompareEngines.Comment)),
+		formatYAMLEnv("          ", "GH_AW_WORKFLOW_NAME", data.Name),
+		c.githubScriptWith(script),
+	)
+
+	if c.actionMode.IsDev() {
+		steps = append(steps, c.generateRestoreActionsSetupStep())
+	}
+
+	// Run once the agent jobs have finished, unless the primary agent job was skipped.
+	agentNotSkipped := BuildNotEquals(
+		BuildPropertyAccess(fmt.Sprintf("needs.%s.result", constants.AgentJobName)),
+		BuildStringLiteral("skipped"),
+	)
+	jobCondition := RenderCondition(BuildAnd(BuildFunctionCall("always"), agentNotSkipped))
+
+	perms := NewPermissionsContentsRead()
+	if data.CompareEngines.Comment {
+		perms.Set(PermissionIssues, PermissionWrite)
+		perms.Set(PermissionPullRequests, PermissionWrite)
+	}
+
+	compareEnginesLog.Printf("Built compare_engines job: needs=%v, comment=%v", needs, data.CompareEngines.Comment)
+	return &Job{
+		Name:        string(constants.CompareEnginesJobName),
+		Needs:       needs,
+		If:          jobCondition,
+		RunsOn:      c.formatFrameworkJobRunsOn(data),
+		Permissions: perms.RenderToYAML(),
+		Steps:       steps,
+	}, nil
+}
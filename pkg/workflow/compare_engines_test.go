@@ -0,0 +1,240 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractCompareEnginesConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		frontmatter map[string]any
+		want        *CompareEnginesConfig
+		wantErr     string
+	}{
+		{
+			name:        "absent",
+			frontmatter: map[string]any{},
+		},
+		{
+			name:        "list form",
+			frontmatter: map[string]any{"compare-engines": []any{"copilot", "claude"}},
+			want:        &CompareEnginesConfig{Engines: []string{"copilot", "claude"}},
+		},
+		{
+			name: "object form with comment",
+			frontmatter: map[string]any{"compare-engines": map[string]any{
+				"engines": []any{"copilot", "codex"},
+				"comment": true,
+			}},
+			want: &CompareEnginesConfig{Engines: []string{"copilot", "codex"}, Comment: true},
+		},
+		{
+			name:        "invalid type",
+			frontmatter: map[string]any{"compare-engines": "copilot"},
+			wantErr:     "must be a list of engine IDs or an object",
+		},
+		{
+			name:        "non-string engine",
+			frontmatter: map[string]any{"compare-engines": []any{"copilot", 1}},
+			wantErr:     "engine IDs must be non-empty strings",
+		},
+		{
+			name:        "non-boolean comment",
+			frontmatter: map[string]any{"compare-engines": map[string]any{"engines": []any{"copilot", "claude"}, "comment": "yes"}},
+			wantErr:     "compare-engines.comment must be a boolean",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := extractCompareEnginesConfig(tt.frontmatter)
+			if tt.wantErr != "" {
+				require.Error(t, err, "config should be rejected")
+				assert.Contains(t, err.Error(), tt.wantErr, "error message")
+				return
+			}
+			require.NoError(t, err, "config should be valid")
+			assert.Equal(t, tt.want, got, "parsed compare-engines config")
+		})
+	}
+}
+
+func TestValidateCompareEngines(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    *WorkflowData
+		wantErr string
+	}{
+		{
+			name: "valid",
+			data: &WorkflowData{
+				EngineConfig:   &EngineConfig{ID: "copilot"},
+				CompareEngines: &CompareEnginesConfig{Engines: []string{"copilot", "claude"}},
+			},
+		},
+		{
+			name: "single engine",
+			data: &WorkflowData{
+				EngineConfig:   &EngineConfig{ID: "copilot"},
+				CompareEngines: &CompareEnginesConfig{Engines: []string{"copilot"}},
+			},
+			wantErr: "at least two engines",
+		},
+		{
+			name: "unknown engine",
+			data: &WorkflowData{
+				EngineConfig:   &EngineConfig{ID: "copilot"},
+				CompareEngines: &CompareEnginesConfig{Engines: []string{"copilot", "nope"}},
+			},
+			wantErr: `unknown engine "nope"`,
+		},
+		{
+			name: "duplicate engine",
+			data: &WorkflowData{
+				EngineConfig:   &EngineConfig{ID: "copilot"},
+				CompareEngines: &CompareEnginesConfig{Engines: []string{"copilot", "copilot"}},
+			},
+			wantErr: "listed more than once",
+		},
+		{
+			name: "missing workflow engine",
+			data: &WorkflowData{
+				EngineConfig:   &EngineConfig{ID: "copilot"},
+				CompareEngines: &CompareEnginesConfig{Engines: []string{"claude", "codex"}},
+			},
+			wantErr: `must include the workflow engine "copilot"`,
+		},
+		{
+			name: "comment with threat detection",
+			data: &WorkflowData{
+				EngineConfig:   &EngineConfig{ID: "copilot"},
+				CompareEngines: &CompareEnginesConfig{Engines: []string{"copilot", "claude"}, Comment: true},
+				SafeOutputs:    &SafeOutputsConfig{ThreatDetection: &ThreatDetectionConfig{}},
+			},
+		},
+		{
+			name: "comment without threat detection",
+			data: &WorkflowData{
+				EngineConfig:   &EngineConfig{ID: "copilot"},
+				CompareEngines: &CompareEnginesConfig{Engines: []string{"copilot", "claude"}, Comment: true},
+				SafeOutputs:    &SafeOutputsConfig{},
+			},
+			wantErr: "compare-engines.comment requires threat detection",
+		},
+		{
+			name: "lock-for-agent",
+			data: &WorkflowData{
+				EngineConfig:   &EngineConfig{ID: "copilot"},
+				CompareEngines: &CompareEnginesConfig{Engines: []string{"copilot", "claude"}},
+				LockForAgent:   true,
+			},
+			wantErr: "cannot be combined with lock-for-agent",
+		},
+	}
+	compiler := NewCompiler()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := compiler.validateCompareEngines(tt.data)
+			if tt.wantErr != "" {
+				require.Error(t, err, "config should be rejected")
+				assert.Contains(t, err.Error(), tt.wantErr, "error message")
+				return
+			}
+			require.NoError(t, err, "config should be valid")
+		})
+	}
+}
+
+func TestCompareEnginesCompile(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "compare-engines-test")
+
+	workflow := `---
+on:
+  issues:
+    types: [opened]
+engine: copilot
+permissions:
+  contents: read
+compare-engines:
+  engines: [copilot, claude]
+  comment: true
+safe-outputs:
+  add-comment:
+---
+
+# Triage
+
+Triage this issue.
+`
+	testFile := filepath.Join(tmpDir, "compare-engines.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(workflow), 0644), "Failed to write test workflow")
+
+	compiler := NewCompiler(WithVersion("1.0.0"))
+	require.NoError(t, compiler.CompileWorkflow(testFile), "Failed to compile workflow")
+
+	lockContent, err := os.ReadFile(filepath.Join(tmpDir, "compare-engines.lock.yml"))
+	require.NoError(t, err, "Failed to read lock file")
+	lock := string(lockContent)
+
+	assert.Contains(t, lock, "\n  agent:\n", "workflow engine should keep the agent job")
+	assert.Contains(t, lock, "\n  agent_claude:\n", "compared engine should run in its own agent job")
+	assert.Contains(t, lock, "\n  compare_engines:\n", "compare_engines job should be generated")
+	assert.Contains(t, lock, "name: agent-claude", "compared engine should upload its own artifact")
+	assert.Contains(t, lock, "compare_engines.cjs", "compare job should run the compare script")
+	assert.Contains(t, lock, `GH_AW_COMPARE_COMMENT: "true"`, "comment setting should be passed to the script")
+	assert.NotContains(t, lock, "\n  safe_outputs:\n", "safe outputs should not be executed")
+
+	// The comment publishes every engine's output, so each agent job gets its own detection job.
+	assert.Contains(t, lock, "\n  detection:\n", "workflow engine output should be scanned")
+	assert.Contains(t, lock, "\n  detection_claude:\n", "compared engine output should be scanned")
+	claudeDetection := extractJobSection(lock, "detection_claude")
+	assert.Contains(t, claudeDetection, "- agent_claude", "compared engine detection should wait for its agent job")
+	assert.Contains(t, claudeDetection, "OUTPUT_TYPES: ${{ needs.agent_claude.outputs.output_types }}", "compared engine detection should read its agent job outputs")
+	assert.Contains(t, claudeDetection, "name: agent-claude", "compared engine detection should scan its own artifact")
+	assert.Contains(t, claudeDetection, "name: detection-claude", "compared engine detection should upload its own artifact")
+	assert.NotContains(t, claudeDetection, "needs.agent.", "compared engine detection should not read the workflow engine's outputs")
+	compareJob := extractJobSection(lock, "compare_engines")
+	assert.Contains(t, compareJob, "- detection_claude", "compare job should wait for every detection job")
+	assert.Contains(t, compareJob, `\"detection\":\"detection_claude\"`, "compare script should know which detection job cleared each engine")
+}
+
+func TestCompareEnginesCompileWithoutComment(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "compare-engines-test")
+
+	workflow := `---
+on:
+  issues:
+    types: [opened]
+engine: copilot
+permissions:
+  contents: read
+compare-engines: [copilot, claude]
+safe-outputs:
+  add-comment:
+---
+
+# Triage
+
+Triage this issue.
+`
+	testFile := filepath.Join(tmpDir, "compare-engines.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(workflow), 0644), "Failed to write test workflow")
+
+	compiler := NewCompiler(WithVersion("1.0.0"))
+	require.NoError(t, compiler.CompileWorkflow(testFile), "Failed to compile workflow")
+
+	lockContent, err := os.ReadFile(filepath.Join(tmpDir, "compare-engines.lock.yml"))
+	require.NoError(t, err, "Failed to read lock file")
+	lock := string(lockContent)
+
+	assert.Contains(t, lock, "\n  compare_engines:\n", "compare_engines job should be generated")
+	assert.NotContains(t, lock, "\n  detection:\n", "nothing is published, so threat detection should not run")
+	assert.NotContains(t, lock, "\n  detection_claude:\n", "nothing is published, so threat detection should not run")
+}
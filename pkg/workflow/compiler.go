@@ -97,6 +97,10 @@ func (c *Compiler) validateWorkflowData(workflowData *WorkflowData, markdownPath
 		return err
 	}
 
+	if err := c.loadPromptPolicy(workflowData, markdownPath); err != nil {
+		return err
+	}
+
 	if err := c.validateFeatureConfig(workflowData, markdownPath); err != nil {
 		return err
 	}
@@ -213,6 +217,22 @@ func (c *Compiler) generateAndValidateYAML(workflowData *WorkflowData, markdownP
 			return "", nil, nil, formattedErr
 		}
 
+		// Validate firewall configuration (log-level enum)
+		workflowLog.Print("Validating firewall configuration")
+		if err := c.validateFirewallConfig(workflowData); err != nil {
+			return "", nil, nil, formatCompilerError(markdownPath, "error", fmt.Sprintf("firewall configuration validation failed: %v", err), err)
+		}
+
+		// The remaining checks query container registries, package registries,
+		// and the GitHub API, so offline compiles skip them.
+		if c.offline {
+			workflowLog.Print("Offline mode: skipping container image, runtime package, and repository feature validation")
+			if c.verbose {
+				fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Offline mode: skipping container image, runtime package, and repository feature validation"))
+			}
+			return yamlContent, bodySecrets, bodyActions, nil
+		}
+
 		// Validate container images used in MCP configurations
 		workflowLog.Print("Validating container images")
 		if err := c.validateContainerImages(workflowData); err != nil {
@@ -228,12 +248,6 @@ func (c *Compiler) generateAndValidateYAML(workflowData *WorkflowData, markdownP
 			return "", nil, nil, formatCompilerError(markdownPath, "error", fmt.Sprintf("runtime package validation failed: %v", err), err)
 		}
 
-		// Validate firewall configuration (log-level enum)
-		workflowLog.Print("Validating firewall configuration")
-		if err := c.validateFirewallConfig(workflowData); err != nil {
-			return "", nil, nil, formatCompilerError(markdownPath, "error", fmt.Sprintf("firewall configuration validation failed: %v", err), err)
-		}
-
 		// Validate repository features (discussions, issues)
 		workflowLog.Print("Validating repository features")
 		if err := c.validateRepositoryFeatures(workflowData); err != nil {
@@ -253,27 +267,30 @@ func (c *Compiler) writeWorkflowOutput(lockFile, yamlContent string, markdownPat
 	// Write to lock file (unless noEmit is enabled)
 	if c.noEmit {
 		workflowLog.Print("Validation completed - no lock file generated (--no-emit enabled)")
+		if c.checkLockFiles && !lockFileUpToDate(lockFile, yamlContent) {
+			workflowLog.Printf("Lock file is missing or out of date: %s", lockFile)
+			c.staleLockFiles = append(c.staleLockFiles, lockFile)
+		}
 	} else {
 		workflowLog.Printf("Writing output to: %s", lockFile)
 
-		// Check if content has actually changed
-		contentUnchanged := false
-		if existingContent, err := os.ReadFile(lockFile); err == nil {
-			if normalizeHeredocDelimiters(string(existingContent)) == normalizeHeredocDelimiters(yamlContent) {
-				// Content is identical (modulo random heredoc tokens) - skip write to preserve timestamp
-				contentUnchanged = true
-				workflowLog.Print("Lock file content unchanged - skipping write to preserve timestamp")
-			}
-		}
-
-		// Only write if content has changed
-		if !contentUnchanged {
+		// Only write if content has changed; skipping the write preserves the timestamp
+		if lockFileUpToDate(lockFile, yamlContent) {
+			workflowLog.Print("Lock file content unchanged - skipping write to preserve timestamp")
+		} else {
 			if err := os.WriteFile(lockFile, []byte(yamlContent), constants.FilePermPublic); err != nil {
 				return formatCompilerError(lockFile, "error", fmt.Sprintf("failed to write lock file: %v", err), err)
 			}
 			workflowLog.Print("Lock file written successfully")
 		}
 
+		if err := c.writeHandlerScripts(markdownPath); err != nil {
+			return formatCompilerError(lockFile, "error", err.Error(), err)
+		}
+		if err := c.writeSharedActions(markdownPath); err != nil {
+			return formatCompilerError(lockFile, "error", err.Error(), err)
+		}
+
 		// Validate file size after writing
 		if lockFileInfo, err := os.Stat(lockFile); err == nil {
 			if lockFileInfo.Size() > MaxLockFileSize {
@@ -303,6 +320,16 @@ func (c *Compiler) writeWorkflowOutput(lockFile, yamlContent string, markdownPat
 	return nil
 }
 
+// lockFileUpToDate reports whether the lock file exists and matches the compiled content,
+// ignoring the random tokens in heredoc delimiters.
+func lockFileUpToDate(lockFile, yamlContent string) bool {
+	existingContent, err := os.ReadFile(lockFile)
+	if err != nil {
+		return false
+	}
+	return normalizeHeredocDelimiters(string(existingContent)) == normalizeHeredocDelimiters(yamlContent)
+}
+
 // validateTemplateInjection checks compiled YAML for template injection vulnerabilities
 // (unsafe GitHub Actions expressions used directly in run: blocks).
 //
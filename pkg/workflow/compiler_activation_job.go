@@ -376,15 +376,14 @@ func (c *Compiler) generateResolveHostRepoStep(data *WorkflowData) string {
 	var step strings.Builder
 	step.WriteString("      - name: Resolve host repo for activation checkout\n")
 	step.WriteString("        id: resolve-host-repo\n")
-	fmt.Fprintf(&step, "        uses: %s\n", getCachedActionPin("actions/github-script", data))
+	script := generateGitHubScriptWithRequire("resolve_host_repo.cjs")
+	fmt.Fprintf(&step, "        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", data), script))
 	step.WriteString("        env:\n")
 	step.WriteString("          JOB_WORKFLOW_REPOSITORY: ${{ job.workflow_repository }}\n")
 	step.WriteString("          JOB_WORKFLOW_SHA: ${{ job.workflow_sha }}\n")
 	step.WriteString("          JOB_WORKFLOW_REF: ${{ job.workflow_ref }}\n")
 	step.WriteString("          JOB_WORKFLOW_FILE_PATH: ${{ job.workflow_file_path }}\n")
-	step.WriteString("        with:\n")
-	step.WriteString("          script: |\n")
-	step.WriteString(generateGitHubScriptWithRequire("resolve_host_repo.cjs"))
+	step.WriteString(c.githubScriptWith(script))
 	return step.String()
 }
 
@@ -46,26 +46,27 @@ func (c *Compiler) addActivationCommandAndLabelOutputs(ctx *activationJobBuildCo
 		compilerActivationJobLog.Print("Adding remove-trigger-label step for label-command workflow")
 		ctx.steps = append(ctx.steps, "      - name: Remove trigger label\n")
 		ctx.steps = append(ctx.steps, fmt.Sprintf("        id: %s\n", constants.RemoveTriggerLabelStepID))
-		ctx.steps = append(ctx.steps, fmt.Sprintf("        uses: %s\n", getCachedActionPin("actions/github-script", data)))
+		script := generateGitHubScriptWithRequire("remove_trigger_label.cjs")
+		ctx.steps = append(ctx.steps, fmt.Sprintf("        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", data), script)))
 		ctx.steps = append(ctx.steps, "        env:\n")
 		labelNamesJSON, err := json.Marshal(data.LabelCommand)
 		if err != nil {
 			return fmt.Errorf("failed to marshal label-command names: %w", err)
 		}
 		ctx.steps = append(ctx.steps, formatYAMLEnv("          ", "GH_AW_LABEL_NAMES", string(labelNamesJSON)))
-		ctx.steps = append(ctx.steps, "        with:\n")
+		var tokenInputs []string
 		labelToken := c.resolveActivationToken(data)
 		if labelToken != "${{ secrets.GITHUB_TOKEN }}" {
-			ctx.steps = append(ctx.steps, fmt.Sprintf("          github-token: %s\n", labelToken))
+			tokenInputs = append(tokenInputs, fmt.Sprintf("          github-token: %s\n", labelToken))
 		}
-		ctx.steps = append(ctx.steps, "          script: |\n")
-		ctx.steps = append(ctx.steps, generateGitHubScriptWithRequire("remove_trigger_label.cjs"))
+		ctx.steps = append(ctx.steps, c.githubScriptWith(script, tokenInputs...))
 		ctx.outputs["label_command"] = fmt.Sprintf("${{ steps.%s.outputs.label_name }}", constants.RemoveTriggerLabelStepID)
 	} else if ctx.hasLabelCommand {
 		compilerActivationJobLog.Print("Adding get-trigger-label step for label-command workflow")
 		ctx.steps = append(ctx.steps, "      - name: Get trigger label name\n")
 		ctx.steps = append(ctx.steps, fmt.Sprintf("        id: %s\n", constants.GetTriggerLabelStepID))
-		ctx.steps = append(ctx.steps, fmt.Sprintf("        uses: %s\n", getCachedActionPin("actions/github-script", data)))
+		script := generateGitHubScriptWithRequire("get_trigger_label.cjs")
+		ctx.steps = append(ctx.steps, fmt.Sprintf("        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", data), script)))
 		if len(data.Command) > 0 {
 			ctx.steps = append(ctx.steps, "        env:\n")
 			if ctx.preActivationJob {
@@ -74,9 +75,7 @@ func (c *Compiler) addActivationCommandAndLabelOutputs(ctx *activationJobBuildCo
 				ctx.steps = append(ctx.steps, fmt.Sprintf("          GH_AW_MATCHED_COMMAND: ${{ steps.%s.outputs.%s }}\n", constants.CheckCommandPositionStepID, constants.MatchedCommandOutput))
 			}
 		}
-		ctx.steps = append(ctx.steps, "        with:\n")
-		ctx.steps = append(ctx.steps, "          script: |\n")
-		ctx.steps = append(ctx.steps, generateGitHubScriptWithRequire("get_trigger_label.cjs"))
+		ctx.steps = append(ctx.steps, c.githubScriptWith(script))
 		ctx.outputs["label_command"] = fmt.Sprintf("${{ steps.%s.outputs.label_name }}", constants.GetTriggerLabelStepID)
 		ctx.outputs["command_name"] = fmt.Sprintf("${{ steps.%s.outputs.command_name }}", constants.GetTriggerLabelStepID)
 	}
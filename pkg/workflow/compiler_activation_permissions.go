@@ -125,6 +125,7 @@ func (c *Compiler) buildActivationPermissions(ctx *activationJobBuildContext) (s
 	c.addCentralizedCommandActivationPermissions(permsMap, ctx)
 	c.addWorkflowCallActivationPermissions(permsMap, ctx)
 	c.addActivationLabelPermissions(permsMap, ctx)
+	addActivationGitHubContextImportPermissions(permsMap, ctx.data)
 	if err := c.addActivationScriptPermissions(permsMap, ctx); err != nil {
 		return "", err
 	}
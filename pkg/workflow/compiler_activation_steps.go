@@ -23,13 +23,11 @@ func (c *Compiler) addActivationReactionStep(ctx *activationJobBuildContext) {
 	ctx.steps = append(ctx.steps, fmt.Sprintf("      - name: Add %s reaction for immediate feedback\n", ctx.data.AIReaction))
 	ctx.steps = append(ctx.steps, "        id: react\n")
 	ctx.steps = append(ctx.steps, fmt.Sprintf("        if: %s\n", RenderCondition(reactionCondition)))
-	ctx.steps = append(ctx.steps, fmt.Sprintf("        uses: %s\n", getCachedActionPin("actions/github-script", ctx.data)))
+	script := generateGitHubScriptWithRequire("add_reaction.cjs")
+	ctx.steps = append(ctx.steps, fmt.Sprintf("        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", ctx.data), script)))
 	ctx.steps = append(ctx.steps, "        env:\n")
 	ctx.steps = append(ctx.steps, fmt.Sprintf("          GH_AW_REACTION: %q\n", ctx.data.AIReaction))
-	ctx.steps = append(ctx.steps, "        with:\n")
-	ctx.steps = append(ctx.steps, fmt.Sprintf("          github-token: %s\n", c.resolveActivationToken(ctx.data)))
-	ctx.steps = append(ctx.steps, "          script: |\n")
-	ctx.steps = append(ctx.steps, generateGitHubScriptWithRequire("add_reaction.cjs"))
+	ctx.steps = append(ctx.steps, c.githubScriptWith(script, fmt.Sprintf("          github-token: %s\n", c.resolveActivationToken(ctx.data))))
 }
 
 func (c *Compiler) addActivationSecretValidationStep(ctx *activationJobBuildContext) {
@@ -113,6 +111,9 @@ func (c *Compiler) addActivationRepositoryAndOutputSteps(ctx *activationJobBuild
 	if err := c.addActivationTextOutputStep(ctx); err != nil {
 		return err
 	}
+	if err := c.addActivationSanitizedInputsStep(ctx); err != nil {
+		return err
+	}
 	if err := c.addActivationStatusCommentStep(ctx); err != nil {
 		return err
 	}
@@ -141,20 +142,20 @@ func (c *Compiler) addActivationLockFileStep(ctx *activationJobBuildContext) {
 	}
 	ctx.steps = append(ctx.steps, "      - name: Check workflow lock file\n")
 	ctx.steps = append(ctx.steps, "        id: check-lock-file\n")
-	ctx.steps = append(ctx.steps, fmt.Sprintf("        uses: %s\n", getCachedActionPin("actions/github-script", ctx.data)))
+	script := generateGitHubScriptWithRequire("check_workflow_timestamp_api.cjs")
+	ctx.steps = append(ctx.steps, fmt.Sprintf("        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", ctx.data), script)))
 	ctx.steps = append(ctx.steps, "        env:\n")
 	ctx.steps = append(ctx.steps, fmt.Sprintf("          GH_AW_WORKFLOW_FILE: \"%s\"\n", ctx.lockFilename))
 	ctx.steps = append(ctx.steps, "          GH_AW_CONTEXT_WORKFLOW_REF: \"${{ github.workflow_ref }}\"\n")
 	if ctx.data.StaleCheckFull {
 		ctx.steps = append(ctx.steps, "          GH_AW_STALE_CHECK_FULL: \"true\"\n")
 	}
-	ctx.steps = append(ctx.steps, "        with:\n")
+	var tokenInputs []string
 	hashToken := c.resolveActivationToken(ctx.data)
 	if hashToken != "${{ secrets.GITHUB_TOKEN }}" {
-		ctx.steps = append(ctx.steps, fmt.Sprintf("          github-token: %s\n", hashToken))
+		tokenInputs = append(tokenInputs, fmt.Sprintf("          github-token: %s\n", hashToken))
 	}
-	ctx.steps = append(ctx.steps, "          script: |\n")
-	ctx.steps = append(ctx.steps, generateGitHubScriptWithRequire("check_workflow_timestamp_api.cjs"))
+	ctx.steps = append(ctx.steps, c.githubScriptWith(script, tokenInputs...))
 }
 
 func (c *Compiler) addActivationVersionCheckStep(ctx *activationJobBuildContext) {
@@ -162,12 +163,11 @@ func (c *Compiler) addActivationVersionCheckStep(ctx *activationJobBuildContext)
 		return
 	}
 	ctx.steps = append(ctx.steps, "      - name: Check compile-agentic version\n")
-	ctx.steps = append(ctx.steps, fmt.Sprintf("        uses: %s\n", getCachedActionPin("actions/github-script", ctx.data)))
+	script := generateGitHubScriptWithRequire("check_version_updates.cjs")
+	ctx.steps = append(ctx.steps, fmt.Sprintf("        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", ctx.data), script)))
 	ctx.steps = append(ctx.steps, "        env:\n")
 	ctx.steps = append(ctx.steps, fmt.Sprintf("          GH_AW_COMPILED_VERSION: \"%s\"\n", c.version))
-	ctx.steps = append(ctx.steps, "        with:\n")
-	ctx.steps = append(ctx.steps, "          script: |\n")
-	ctx.steps = append(ctx.steps, generateGitHubScriptWithRequire("check_version_updates.cjs"))
+	ctx.steps = append(ctx.steps, c.githubScriptWith(script))
 }
 
 func (c *Compiler) addActivationSkillInstallSteps(ctx *activationJobBuildContext) error {
@@ -225,10 +225,9 @@ func (c *Compiler) addActivationSkillInstallSteps(ctx *activationJobBuildContext
 		ctx.steps = append(ctx.steps, formatYAMLEnv("          ", "GH_AW_GH_SKILL_AGENT_NAME", skillInstallAgentName))
 		ctx.steps = append(ctx.steps, formatYAMLEnv("          ", "GH_AW_SKILL_DIR", skillDir))
 		ctx.steps = append(ctx.steps, formatYAMLEnv("          ", "GH_AW_FRONTMATTER_SKILLS", skillRef.Skill))
-		ctx.steps = append(ctx.steps, fmt.Sprintf("        uses: %s\n", getCachedActionPin("actions/github-script", ctx.data)))
-		ctx.steps = append(ctx.steps, "        with:\n")
-		ctx.steps = append(ctx.steps, "          script: |\n")
-		ctx.steps = append(ctx.steps, generateGitHubScriptWithRequire("install_frontmatter_skills.cjs"))
+		script := generateGitHubScriptWithRequire("install_frontmatter_skills.cjs")
+		ctx.steps = append(ctx.steps, fmt.Sprintf("        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", ctx.data), script)))
+		ctx.steps = append(ctx.steps, c.githubScriptWith(script))
 	}
 
 	// Collect skill install failures written by each install step into a shared file.
@@ -236,10 +235,9 @@ func (c *Compiler) addActivationSkillInstallSteps(ctx *activationJobBuildContext
 	ctx.steps = append(ctx.steps, "      - name: Collect skill install failures\n")
 	ctx.steps = append(ctx.steps, "        id: collect-skill-install-failures\n")
 	ctx.steps = append(ctx.steps, "        if: always()\n")
-	ctx.steps = append(ctx.steps, fmt.Sprintf("        uses: %s\n", getCachedActionPin("actions/github-script", ctx.data)))
-	ctx.steps = append(ctx.steps, "        with:\n")
-	ctx.steps = append(ctx.steps, "          script: |\n")
-	ctx.steps = append(ctx.steps, generateGitHubScriptWithRequire("collect_skill_install_failures.cjs"))
+	script := generateGitHubScriptWithRequire("collect_skill_install_failures.cjs")
+	ctx.steps = append(ctx.steps, fmt.Sprintf("        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", ctx.data), script)))
+	ctx.steps = append(ctx.steps, c.githubScriptWith(script))
 
 	ctx.outputs["skill_install_failure_count"] = "${{ steps.collect-skill-install-failures.outputs.failure_count || '0' }}"
 	ctx.outputs["skill_install_errors"] = "${{ steps.collect-skill-install-failures.outputs.errors || '' }}"
@@ -253,7 +251,8 @@ func (c *Compiler) addActivationTextOutputStep(ctx *activationJobBuildContext) e
 	}
 	ctx.steps = append(ctx.steps, "      - name: Compute current body text\n")
 	ctx.steps = append(ctx.steps, "        id: sanitized\n")
-	ctx.steps = append(ctx.steps, fmt.Sprintf("        uses: %s\n", getCachedActionPin("actions/github-script", ctx.data)))
+	script := generateGitHubScriptWithRequire("compute_text.cjs")
+	ctx.steps = append(ctx.steps, fmt.Sprintf("        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", ctx.data), script)))
 	domainsStr, err := c.computeActivationSanitizationDomains(ctx.data)
 	if err != nil {
 		return err
@@ -263,9 +262,7 @@ func (c *Compiler) addActivationTextOutputStep(ctx *activationJobBuildContext) e
 		ctx.steps = append(ctx.steps, "        env:\n")
 		ctx.steps = append(ctx.steps, envLines...)
 	}
-	ctx.steps = append(ctx.steps, "        with:\n")
-	ctx.steps = append(ctx.steps, "          script: |\n")
-	ctx.steps = append(ctx.steps, generateGitHubScriptWithRequire("compute_text.cjs"))
+	ctx.steps = append(ctx.steps, c.githubScriptWith(script))
 	ctx.outputs["text"] = "${{ steps.sanitized.outputs.text }}"
 	ctx.outputs["title"] = "${{ steps.sanitized.outputs.title }}"
 	ctx.outputs["body"] = "${{ steps.sanitized.outputs.body }}"
@@ -303,7 +300,8 @@ func (c *Compiler) addActivationStatusCommentStep(ctx *activationJobBuildContext
 	ctx.steps = append(ctx.steps, "      - name: Add comment with workflow run link\n")
 	ctx.steps = append(ctx.steps, "        id: add-comment\n")
 	ctx.steps = append(ctx.steps, fmt.Sprintf("        if: %s\n", RenderCondition(statusCommentCondition)))
-	ctx.steps = append(ctx.steps, fmt.Sprintf("        uses: %s\n", getCachedActionPin("actions/github-script", ctx.data)))
+	script := generateGitHubScriptWithRequire("add_workflow_run_comment.cjs")
+	ctx.steps = append(ctx.steps, fmt.Sprintf("        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", ctx.data), script)))
 	ctx.steps = append(ctx.steps, "        env:\n")
 	ctx.steps = append(ctx.steps, fmt.Sprintf("          GH_AW_WORKFLOW_NAME: %q\n", ctx.data.Name))
 	if ctx.data.TrackerID != "" {
@@ -315,13 +313,12 @@ func (c *Compiler) addActivationStatusCommentStep(ctx *activationJobBuildContext
 	if err := addActivationSafeOutputMessagesEnv(ctx); err != nil {
 		return err
 	}
-	ctx.steps = append(ctx.steps, "        with:\n")
+	var tokenInputs []string
 	commentToken := c.resolveActivationToken(ctx.data)
 	if commentToken != "${{ secrets.GITHUB_TOKEN }}" {
-		ctx.steps = append(ctx.steps, fmt.Sprintf("          github-token: %s\n", commentToken))
+		tokenInputs = append(tokenInputs, fmt.Sprintf("          github-token: %s\n", commentToken))
 	}
-	ctx.steps = append(ctx.steps, "          script: |\n")
-	ctx.steps = append(ctx.steps, generateGitHubScriptWithRequire("add_workflow_run_comment.cjs"))
+	ctx.steps = append(ctx.steps, c.githubScriptWith(script, tokenInputs...))
 	ctx.outputs["comment_id"] = "${{ steps.add-comment.outputs.comment-id }}"
 	ctx.outputs["comment_url"] = "${{ steps.add-comment.outputs.comment-url }}"
 	ctx.outputs["comment_repo"] = "${{ steps.add-comment.outputs.comment-repo }}"
@@ -353,10 +350,9 @@ func (c *Compiler) addActivationIssueLockStep(ctx *activationJobBuildContext) {
 	ctx.steps = append(ctx.steps, "      - name: Lock issue for agentic workflow\n")
 	ctx.steps = append(ctx.steps, "        id: lock-issue\n")
 	ctx.steps = append(ctx.steps, fmt.Sprintf("        if: %s\n", RenderCondition(lockCondition)))
-	ctx.steps = append(ctx.steps, fmt.Sprintf("        uses: %s\n", getCachedActionPin("actions/github-script", ctx.data)))
-	ctx.steps = append(ctx.steps, "        with:\n")
-	ctx.steps = append(ctx.steps, "          script: |\n")
-	ctx.steps = append(ctx.steps, generateGitHubScriptWithRequire("lock-issue.cjs"))
+	script := generateGitHubScriptWithRequire("lock-issue.cjs")
+	ctx.steps = append(ctx.steps, fmt.Sprintf("        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", ctx.data), script)))
+	ctx.steps = append(ctx.steps, c.githubScriptWith(script))
 	ctx.outputs["issue_locked"] = "${{ steps.lock-issue.outputs.locked }}"
 	if ctx.data.AIReaction != "" && ctx.data.AIReaction != "none" {
 		compilerActivationJobLog.Print("Adding lock notification to reaction message")
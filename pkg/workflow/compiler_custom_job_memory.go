@@ -84,7 +84,7 @@ func (c *Compiler) buildRestoreMemorySteps(cfg *restoreMemoryConfig, jobName str
 	if cfg.CommentMemory {
 		if configLines, ok := c.generateCommentMemoryEarlyConfigLines(data); ok {
 			memoryLines = append(memoryLines, configLines...)
-			memoryLines = append(memoryLines, generateCommentMemoryRestoreLines(data)...)
+			memoryLines = append(memoryLines, c.generateCommentMemoryRestoreLines(data)...)
 		}
 	}
 
@@ -174,7 +174,7 @@ func generateRepoMemoryRestoreLines(data *WorkflowData) []string {
 // generateCommentMemoryRestoreLines produces a read-only comment-memory prepare step
 // for a custom job. The step fetches the comment-memory content from GitHub and
 // materialises it as local files — the same operation performed in the agent job.
-func generateCommentMemoryRestoreLines(data *WorkflowData) []string {
+func (c *Compiler) generateCommentMemoryRestoreLines(data *WorkflowData) []string {
 	if data.SafeOutputs == nil || data.SafeOutputs.CommentMemory == nil {
 		return nil
 	}
@@ -182,13 +182,8 @@ func generateCommentMemoryRestoreLines(data *WorkflowData) []string {
 	var lines []string
 	lines = append(lines, "      # restore-memory: comment-memory (read-only restore)\n")
 	lines = append(lines, "      - name: Prepare comment memory files\n")
-	lines = append(lines, fmt.Sprintf("        uses: %s\n", getCachedActionPin("actions/github-script", data)))
-	lines = append(lines, "        with:\n")
-	lines = append(lines, fmt.Sprintf("          github-token: %s\n", getEffectiveSafeOutputGitHubToken(data.SafeOutputs.CommentMemory.GitHubToken)))
-	lines = append(lines, "          script: |\n")
-	lines = append(lines, "            const { setupGlobals } = require('${{ runner.temp }}/gh-aw/actions/setup_globals.cjs');\n")
-	lines = append(lines, "            setupGlobals(core, github, context, exec, io, getOctokit);\n")
-	lines = append(lines, "            const { main } = require('${{ runner.temp }}/gh-aw/actions/setup_comment_memory_files.cjs');\n")
-	lines = append(lines, "            await main();\n")
+	script := generateGitHubScriptWithRequire("setup_comment_memory_files.cjs")
+	lines = append(lines, fmt.Sprintf("        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", data), script)))
+	lines = append(lines, c.githubScriptWith(script, fmt.Sprintf("          github-token: %s\n", getEffectiveSafeOutputGitHubToken(data.SafeOutputs.CommentMemory.GitHubToken))))
 	return lines
 }
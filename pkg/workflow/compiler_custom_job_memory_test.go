@@ -546,8 +546,9 @@ func TestGenerateRepoMemoryRestoreLinesNilData(t *testing.T) {
 
 // TestGenerateCommentMemoryRestoreLinesNilData verifies nil/empty SafeOutputs returns nil.
 func TestGenerateCommentMemoryRestoreLinesNilData(t *testing.T) {
-	assert.Nil(t, generateCommentMemoryRestoreLines(&WorkflowData{}))
-	assert.Nil(t, generateCommentMemoryRestoreLines(&WorkflowData{
+	compiler := NewCompiler()
+	assert.Nil(t, compiler.generateCommentMemoryRestoreLines(&WorkflowData{}))
+	assert.Nil(t, compiler.generateCommentMemoryRestoreLines(&WorkflowData{
 		SafeOutputs: &SafeOutputsConfig{},
 	}))
 }
@@ -740,6 +740,76 @@ func (c *Compiler) applyBuiltinJobNeedsAugmentations(data *WorkflowData) error {
 	return nil
 }
 
+// applyAgentJobEnv merges jobs.agent.env into the compiler-generated agent job env.
+// Values are emitted verbatim, but secret references are passed through FilterEnvForSecrets
+// so only the engine's own secrets (or env var keys it supports) reach the agent job;
+// validateEnvSecrets has already reported any dropped references. Keys that collide with
+// compiler-generated variables are rejected rather than silently overridden.
+func (c *Compiler) applyAgentJobEnv(data *WorkflowData) error {
+	if data == nil || data.Jobs == nil {
+		return nil
+	}
+	agentJobName := string(constants.AgentJobName)
+	rawConfig, exists := data.Jobs[agentJobName]
+	if !exists {
+		return nil
+	}
+	configMap, ok := rawConfig.(map[string]any)
+	if !ok {
+		return fmt.Errorf("jobs.%s must be an object, got %T", agentJobName, rawConfig)
+	}
+	rawEnv, hasEnv := configMap["env"]
+	if !hasEnv || rawEnv == nil {
+		return nil
+	}
+	envMap, ok := rawEnv.(map[string]any)
+	if !ok {
+		return fmt.Errorf("jobs.%s.env must be an object mapping variable names to values, got %T", agentJobName, rawEnv)
+	}
+
+	job, exists := c.jobManager.GetJob(agentJobName)
+	if !exists {
+		return fmt.Errorf("jobs.%s.env: this workflow does not generate an agent job", agentJobName)
+	}
+
+	userEnv := make(map[string]string, len(envMap))
+	for key, value := range envMap {
+		if _, reserved := job.Env[key]; reserved {
+			return fmt.Errorf("jobs.%s.env.%s conflicts with a compiler-generated environment variable; use a different name", agentJobName, key)
+		}
+		switch v := value.(type) {
+		case string:
+			userEnv[key] = v
+		case nil:
+			userEnv[key] = ""
+		default:
+			userEnv[key] = fmt.Sprint(v)
+		}
+	}
+
+	var allowed []string
+	for key := range c.getEngineBaseEnvVarKeys(data.AI) {
+		allowed = append(allowed, key)
+	}
+	filtered := FilterEnvForSecrets(userEnv, allowed)
+	if len(filtered) == 0 {
+		return nil
+	}
+
+	if job.Env == nil {
+		job.Env = make(map[string]string, len(filtered))
+	}
+	for key, value := range filtered {
+		if value == "" {
+			job.Env[key] = `""`
+			continue
+		}
+		job.Env[key] = yamlStringValue(value)
+	}
+	compilerJobsLog.Printf("Applied %d jobs.%s.env variable(s) (%d dropped by secret filtering)", len(filtered), agentJobName, len(userEnv)-len(filtered))
+	return nil
+}
+
 func validateRestrictedBuiltinSetupSteps(jobName string, hasSetupSteps bool) error {
 	if !hasSetupSteps {
 		return nil
@@ -0,0 +1,98 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCompileWorkflow_DeterministicOutput compiles a workflow with map-valued
+// configuration several times and checks that the lock file never changes
+func TestCompileWorkflow_DeterministicOutput(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "determinism-test")
+	workflowPath := filepath.Join(tmpDir, "deterministic.md")
+	content := `---
+on: issues
+permissions:
+  contents: read
+  issues: read
+  pull-requests: read
+engine:
+  id: copilot
+  env:
+    ZETA: "z"
+    ALPHA: "a"
+    MIKE: "m"
+tools:
+  github:
+    toolsets: [issues, pull_requests]
+  bash: ["echo", "ls"]
+safe-outputs:
+  env:
+    ZULU_VAR: "z"
+    ALPHA_VAR: "a"
+    MIKE_VAR: "m"
+    BRAVO_VAR: "b"
+  create-issue:
+  add-comment:
+---
+
+# Deterministic Workflow
+
+Compile me repeatedly.
+`
+	require.NoError(t, os.WriteFile(workflowPath, []byte(content), 0644), "failed to write workflow")
+	lockFile := stringutil.MarkdownToLockFile(workflowPath)
+
+	var first string
+	for i := range 10 {
+		compiler := NewCompiler(WithVersion("1.0.0"))
+		compiler.SetQuiet(true)
+		require.NoError(t, os.RemoveAll(lockFile), "failed to remove lock file")
+		require.NoError(t, compiler.CompileWorkflow(workflowPath), "compilation %d should succeed", i)
+
+		lockContent, err := os.ReadFile(lockFile)
+		require.NoError(t, err, "failed to read lock file")
+		normalized := normalizeHeredocDelimiters(string(lockContent))
+		if i == 0 {
+			first = normalized
+			continue
+		}
+		require.Equal(t, first, normalized, "compilation %d should produce the same lock file", i)
+	}
+
+	assert.Regexp(t, `(?s)ALPHA_VAR: a\s+BRAVO_VAR: b\s+MIKE_VAR: m\s+ZULU_VAR: z`, first, "safe-outputs env should be sorted")
+}
+
+func TestRenderGuardPoliciesToml_SortedOutput(t *testing.T) {
+	policies := map[string]any{
+		"write-sink":  map[string]any{"accept": []string{"private:*"}, "deny": []string{"public:*"}},
+		"read-source": map[string]any{"accept": []string{"*"}},
+	}
+
+	var first string
+	for i := range 10 {
+		var yaml strings.Builder
+		renderGuardPoliciesToml(&yaml, policies, "github")
+		if i == 0 {
+			first = yaml.String()
+			continue
+		}
+		require.Equal(t, first, yaml.String(), "render %d should match the first render", i)
+	}
+
+	readSource := strings.Index(first, `"guard-policies".read-source]`)
+	writeSink := strings.Index(first, `"guard-policies".write-sink]`)
+	accept := strings.Index(first, `accept = ["private:*"]`)
+	deny := strings.Index(first, `deny = ["public:*"]`)
+	assert.True(t, readSource >= 0 && readSource < writeSink, "policies should be sorted by name")
+	assert.True(t, accept >= 0 && accept < deny, "policy fields should be sorted by name")
+}
@@ -462,20 +462,16 @@ func (c *Compiler) generateExperimentRepoSteps(data *WorkflowData, experimentNam
 	var steps []string
 
 	// ── Step 1: Restore experiment state from git branch ─────────────────────
+	script := generateGitHubScriptWithRequire("load_experiment_state_from_repo.cjs")
 	steps = append(steps,
 		"      - name: Restore experiment state from git\n",
 		"        id: restore-experiment-state\n",
-		fmt.Sprintf("        uses: %s\n", getCachedActionPin("actions/github-script", data)),
+		fmt.Sprintf("        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", data), script)),
 		"        env:\n",
 		fmt.Sprintf("          GH_AW_EXPERIMENT_STATE_FILE: %s\n", experimentStateFile),
 		fmt.Sprintf("          GH_AW_EXPERIMENT_STATE_DIR: %s\n", experimentsCacheDir),
 		fmt.Sprintf("          GH_AW_EXPERIMENT_BRANCH: %s\n", branchName),
-		"        with:\n",
-		"          script: |\n",
-		"            const { setupGlobals } = require('"+SetupActionDestination+"/setup_globals.cjs');\n",
-		"            setupGlobals(core, github, context, exec, io, getOctokit);\n",
-		"            const { main } = require('"+SetupActionDestination+"/load_experiment_state_from_repo.cjs');\n",
-		"            await main();\n",
+		c.githubScriptWith(script),
 	)
 
 	steps = append(steps, c.generatePickExperimentStep(data, experimentNames)...)
@@ -486,20 +482,16 @@ func (c *Compiler) generateExperimentRepoSteps(data *WorkflowData, experimentNam
 // generatePickExperimentStep generates the "Pick experiment variants" step shared by both storage modes.
 func (c *Compiler) generatePickExperimentStep(data *WorkflowData, experimentNames []string) []string {
 	specJSON := buildExperimentSpecJSON(data.Experiments, data.ExperimentConfigs, experimentNames)
+	script := generateGitHubScriptWithRequire("pick_experiment.cjs")
 	return []string{
 		"      - name: Pick experiment variants\n",
 		"        id: pick-experiment\n",
-		fmt.Sprintf("        uses: %s\n", getCachedActionPin("actions/github-script", data)),
+		fmt.Sprintf("        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", data), script)),
 		"        env:\n",
 		fmt.Sprintf("          GH_AW_EXPERIMENT_SPEC: '%s'\n", strings.ReplaceAll(specJSON, "'", "''")),
 		fmt.Sprintf("          GH_AW_EXPERIMENT_STATE_FILE: %s\n", experimentStateFile),
 		fmt.Sprintf("          GH_AW_EXPERIMENT_STATE_DIR: %s\n", experimentsCacheDir),
-		"        with:\n",
-		"          script: |\n",
-		"            const { setupGlobals } = require('" + SetupActionDestination + "/setup_globals.cjs');\n",
-		"            setupGlobals(core, github, context, exec, io, getOctokit);\n",
-		"            const { main } = require('" + SetupActionDestination + "/pick_experiment.cjs');\n",
-		"            await main();\n",
+		c.githubScriptWith(script),
 	}
 }
 
@@ -696,19 +688,15 @@ func (c *Compiler) buildPushExperimentsStateJob(data *WorkflowData) (*Job, error
 	pushStep.WriteString("      - name: Push experiment state to git\n")
 	pushStep.WriteString("        id: push_experiments_state\n")
 	pushStep.WriteString("        if: always()\n")
-	fmt.Fprintf(&pushStep, "        uses: %s\n", getCachedActionPin("actions/github-script", data))
+	script := generateGitHubScriptWithRequire("push_experiment_state.cjs")
+	fmt.Fprintf(&pushStep, "        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", data), script))
 	pushStep.WriteString("        env:\n")
 	pushStep.WriteString("          GH_TOKEN: ${{ github.token }}\n")
 	pushStep.WriteString("          GITHUB_RUN_ID: ${{ github.run_id }}\n")
 	pushStep.WriteString("          GITHUB_SERVER_URL: ${{ github.server_url }}\n")
 	fmt.Fprintf(&pushStep, "          GH_AW_EXPERIMENT_STATE_DIR: %s\n", experimentsCacheDir)
 	fmt.Fprintf(&pushStep, "          GH_AW_EXPERIMENT_BRANCH: %s\n", branchName)
-	pushStep.WriteString("        with:\n")
-	pushStep.WriteString("          script: |\n")
-	pushStep.WriteString("            const { setupGlobals } = require('" + SetupActionDestination + "/setup_globals.cjs');\n")
-	pushStep.WriteString("            setupGlobals(core, github, context, exec, io, getOctokit);\n")
-	pushStep.WriteString("            const { main } = require('" + SetupActionDestination + "/push_experiment_state.cjs');\n")
-	pushStep.WriteString("            await main();\n")
+	pushStep.WriteString(c.githubScriptWith(script))
 	steps = append(steps, pushStep.String())
 
 	// Restore the checkout in dev mode (same reason as push_repo_memory).
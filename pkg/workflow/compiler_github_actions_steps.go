@@ -29,6 +29,40 @@ func generateGitHubScriptWithRequire(scriptPath string) string {
 	return script.String()
 }
 
+// githubScriptUses returns the action a github-script step runs. script is the step's script,
+// indented as generated (see generateGitHubScriptWithRequire). With --shared-actions a script
+// that moves into a shared composite action returns that action, recorded to be written next
+// to the lock file; otherwise it returns githubScriptPin.
+func (c *Compiler) githubScriptUses(githubScriptPin, script string) string {
+	name, lines, ok := c.sharedScript(script)
+	if !ok {
+		return githubScriptPin
+	}
+	c.recordSharedAction(name, githubScriptPin, lines)
+	return "./" + SharedActionsDir + "/" + name
+}
+
+// githubScriptWith returns the with section of a github-script step that runs script.
+// tokenInputs are the formatted github-token input lines written before the script. When the
+// script moves into a shared composite action (see githubScriptUses), only the token is
+// passed to the action and the section is omitted without one.
+func (c *Compiler) githubScriptWith(script string, tokenInputs ...string) string {
+	_, _, shared := c.sharedScript(script)
+	if shared && len(tokenInputs) == 0 {
+		return ""
+	}
+	var with strings.Builder
+	with.WriteString("        with:\n")
+	for _, input := range tokenInputs {
+		with.WriteString(input)
+	}
+	if !shared {
+		with.WriteString("          script: |\n")
+		with.WriteString(script)
+	}
+	return with.String()
+}
+
 // generateInlineGitHubScriptStep generates a simple inline github-script step
 // for validation or utility operations that don't require artifact downloads.
 //
@@ -38,7 +72,7 @@ func generateGitHubScriptWithRequire(scriptPath string) string {
 //   - condition: Optional if condition (e.g., "always()"). Empty string means no condition.
 //
 // Returns a string containing the complete YAML for the github-script step.
-func generateInlineGitHubScriptStep(stepName, script, condition string, data *WorkflowData) string {
+func (c *Compiler) generateInlineGitHubScriptStep(stepName, script, condition string, data *WorkflowData) string {
 	compilerGitHubActionsStepsLog.Printf("Generating inline GitHub script step: name=%q, condition=%q", stepName, condition)
 	var step strings.Builder
 
@@ -46,10 +80,8 @@ func generateInlineGitHubScriptStep(stepName, script, condition string, data *Wo
 	if condition != "" {
 		step.WriteString("        if: " + condition + "\n")
 	}
-	step.WriteString("        uses: " + getCachedActionPin("actions/github-script", data) + "\n")
-	step.WriteString("        with:\n")
-	step.WriteString("          script: |\n")
-	step.WriteString(script)
+	step.WriteString("        uses: " + c.githubScriptUses(getCachedActionPin("actions/github-script", data), script) + "\n")
+	step.WriteString(c.githubScriptWith(script))
 
 	return step.String()
 }
@@ -57,18 +89,42 @@ func generateInlineGitHubScriptStep(stepName, script, condition string, data *Wo
 // generatePlaceholderSubstitutionStep generates a JavaScript-based step that performs
 // safe placeholder substitution using the substitute_placeholders script.
 // This replaces the multiple sed commands with a single JavaScript step.
-func generatePlaceholderSubstitutionStep(yaml *strings.Builder, expressionMappings []*ExpressionMapping, indent string, data *WorkflowData) {
+func (c *Compiler) generatePlaceholderSubstitutionStep(yaml *strings.Builder, expressionMappings []*ExpressionMapping, data *WorkflowData) {
 	if len(expressionMappings) == 0 {
 		return
 	}
 
 	compilerGitHubActionsStepsLog.Printf("Generating placeholder substitution step with %d mappings", len(expressionMappings))
 
+	var script strings.Builder
+	// Use setup_globals helper to make GitHub Actions objects available globally
+	script.WriteString("            const { setupGlobals } = require('" + SetupActionDestination + "/setup_globals.cjs');\n")
+	script.WriteString("            setupGlobals(core, github, context, exec, io, getOctokit);\n")
+	script.WriteString("            \n")
+	// Use require() to load script from copied files
+	script.WriteString("            const substitutePlaceholders = require('" + SetupActionDestination + "/substitute_placeholders.cjs');\n")
+	script.WriteString("            \n")
+	script.WriteString("            // Call the substitution function\n")
+	script.WriteString("            return await substitutePlaceholders({\n")
+	script.WriteString("              file: process.env.GH_AW_PROMPT,\n")
+	script.WriteString("              substitutions: {\n")
+
+	for i, mapping := range expressionMappings {
+		comma := ","
+		if i == len(expressionMappings)-1 {
+			comma = ""
+		}
+		fmt.Fprintf(&script, "                %s: process.env.%s%s\n", mapping.EnvVar, mapping.EnvVar, comma)
+	}
+
+	script.WriteString("              }\n")
+	script.WriteString("            });\n")
+
 	// Use actions/github-script to perform the substitutions
-	yaml.WriteString(indent + "- name: Substitute placeholders\n")
-	fmt.Fprintf(yaml, indent+"  uses: %s\n", getCachedActionPin("actions/github-script", data))
-	yaml.WriteString(indent + "  env:\n")
-	yaml.WriteString(indent + "    GH_AW_PROMPT: /tmp/gh-aw/aw-prompts/prompt.txt\n")
+	yaml.WriteString("      - name: Substitute placeholders\n")
+	fmt.Fprintf(yaml, "        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", data), script.String()))
+	yaml.WriteString("        env:\n")
+	yaml.WriteString("          GH_AW_PROMPT: /tmp/gh-aw/aw-prompts/prompt.txt\n")
 
 	// Add all environment variables
 	// For static values (wrapped in quotes), output them directly without ${{ }}
@@ -86,39 +142,15 @@ func generatePlaceholderSubstitutionStep(yaml *strings.Builder, expressionMappin
 				escaped := strings.ReplaceAll(innerValue, `\`, `\\`)
 				escaped = strings.ReplaceAll(escaped, `"`, `\"`)
 				escaped = strings.ReplaceAll(escaped, "\n", `\n`)
-				fmt.Fprintf(yaml, indent+"    %s: \"%s\"\n", mapping.EnvVar, escaped)
+				fmt.Fprintf(yaml, "          %s: \"%s\"\n", mapping.EnvVar, escaped)
 			} else {
-				fmt.Fprintf(yaml, indent+"    %s: %s\n", mapping.EnvVar, content)
+				fmt.Fprintf(yaml, "          %s: %s\n", mapping.EnvVar, content)
 			}
 		} else {
 			// GitHub expression - wrap in ${{ }}
-			fmt.Fprintf(yaml, indent+"    %s: ${{ %s }}\n", mapping.EnvVar, content)
-		}
-	}
-
-	yaml.WriteString(indent + "  with:\n")
-	yaml.WriteString(indent + "    script: |\n")
-
-	// Use setup_globals helper to make GitHub Actions objects available globally
-	yaml.WriteString(indent + "      const { setupGlobals } = require('" + SetupActionDestination + "/setup_globals.cjs');\n")
-	yaml.WriteString(indent + "      setupGlobals(core, github, context, exec, io, getOctokit);\n")
-	yaml.WriteString(indent + "      \n")
-	// Use require() to load script from copied files
-	yaml.WriteString(indent + "      const substitutePlaceholders = require('" + SetupActionDestination + "/substitute_placeholders.cjs');\n")
-	yaml.WriteString(indent + "      \n")
-	yaml.WriteString(indent + "      // Call the substitution function\n")
-	yaml.WriteString(indent + "      return await substitutePlaceholders({\n")
-	yaml.WriteString(indent + "        file: process.env.GH_AW_PROMPT,\n")
-	yaml.WriteString(indent + "        substitutions: {\n")
-
-	for i, mapping := range expressionMappings {
-		comma := ","
-		if i == len(expressionMappings)-1 {
-			comma = ""
+			fmt.Fprintf(yaml, "          %s: ${{ %s }}\n", mapping.EnvVar, content)
 		}
-		fmt.Fprintf(yaml, indent+"          %s: process.env.%s%s\n", mapping.EnvVar, mapping.EnvVar, comma)
 	}
 
-	yaml.WriteString(indent + "        }\n")
-	yaml.WriteString(indent + "      });\n")
+	yaml.WriteString(c.githubScriptWith(script.String()))
 }
@@ -74,7 +74,9 @@ func (c *Compiler) generateGitHubMCPLockdownDetectionStep(yaml *strings.Builder,
 	// Generate the step using the determine_automatic_lockdown.cjs action
 	yaml.WriteString("      - name: Determine automatic lockdown mode for GitHub MCP Server\n")
 	yaml.WriteString("        id: determine-automatic-lockdown\n")
-	fmt.Fprintf(yaml, "        uses: %s\n", pinnedAction)
+	script := "            const determineAutomaticLockdown = require('${{ runner.temp }}/gh-aw/actions/determine_automatic_lockdown.cjs');\n" +
+		"            await determineAutomaticLockdown(github, context, core);\n"
+	fmt.Fprintf(yaml, "        uses: %s\n", c.githubScriptUses(pinnedAction, script))
 	yaml.WriteString("        env:\n")
 	yaml.WriteString("          GH_AW_GITHUB_TOKEN: ${{ secrets.GH_AW_GITHUB_TOKEN }}\n")
 	yaml.WriteString("          GH_AW_GITHUB_MCP_SERVER_TOKEN: ${{ secrets.GH_AW_GITHUB_MCP_SERVER_TOKEN }}\n")
@@ -87,10 +89,7 @@ func (c *Compiler) generateGitHubMCPLockdownDetectionStep(yaml *strings.Builder,
 	if privateToPublicFlowsAllow {
 		yaml.WriteString("          GH_AW_PRIVATE_TO_PUBLIC_FLOWS: " + quoteYAMLEnvValue("allow") + "\n")
 	}
-	yaml.WriteString("        with:\n")
-	yaml.WriteString("          script: |\n")
-	yaml.WriteString("            const determineAutomaticLockdown = require('${{ runner.temp }}/gh-aw/actions/determine_automatic_lockdown.cjs');\n")
-	yaml.WriteString("            await determineAutomaticLockdown(github, context, core);\n")
+	yaml.WriteString(c.githubScriptWith(script))
 }
 
 // serializeEnvStringValue converts a workflow config value to a string suitable for a
@@ -40,6 +40,15 @@ func (c *Compiler) buildMainJobCondition(data *WorkflowData, activationJobCreate
 			jobCondition = RenderCondition(BuildAnd(&ExpressionNode{Expression: stripExpressionWrapper(jobCondition)}, guard))
 		}
 	}
+	if data.SkipIf != "" {
+		compilerMainJobLog.Print("Applying skip-if to main job condition")
+		guard := &NotNode{Child: &ExpressionNode{Expression: data.SkipIf}}
+		if jobCondition == "" {
+			jobCondition = RenderCondition(guard)
+		} else {
+			jobCondition = RenderCondition(BuildAnd(&ExpressionNode{Expression: stripExpressionWrapper(jobCondition)}, guard))
+		}
+	}
 	compilerMainJobLog.Printf("Built main job condition: activationJobCreated=%v, hasCondition=%v", activationJobCreated, jobCondition != "")
 	return jobCondition
 }
@@ -55,6 +64,7 @@ func (c *Compiler) buildMainJobDependencies(data *WorkflowData, activationJobCre
 	// Custom jobs that depend on pre_activation are now dependencies of activation,
 	// so the agent job gets them transitively through activation
 	// Custom jobs that depend on agent should run AFTER the agent job, not before it
+	// Custom jobs that consume safe_outputs results run after safe_outputs, and so after the agent job
 	if data.Jobs != nil {
 		for _, jobName := range sliceutil.SortedKeys(data.Jobs) {
 			// Skip built-in jobs as they are handled separately and should not become custom dependencies.
@@ -62,7 +72,7 @@ func (c *Compiler) buildMainJobDependencies(data *WorkflowData, activationJobCre
 				continue
 			}
 			if configMap, ok := data.Jobs[jobName].(map[string]any); ok {
-				if !jobDependsOnPreActivation(configMap) && !jobDependsOnAgent(configMap) {
+				if !jobDependsOnPreActivation(configMap) && !jobDependsOnAgent(configMap) && !isSafeOutputsConsumerJob(configMap) {
 					depends = append(depends, jobName)
 				}
 			}
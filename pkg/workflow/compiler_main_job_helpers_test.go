@@ -24,6 +24,7 @@ func TestBuildMainJobCondition(t *testing.T) {
 		name                 string
 		dataIf               string
 		activationJobCreated bool
+		dataSkipIf           string
 		rawFrontmatter       map[string]any // nil = guardrail enabled (default)
 		jobs                 map[string]any
 		want                 string
@@ -59,6 +60,27 @@ func TestBuildMainJobCondition(t *testing.T) {
 			rawFrontmatter:       disableAICGuardrailFrontmatter(),
 			want:                 "",
 		},
+		{
+			name:                 "activation, skip-if, guardrail disabled — negated skip-if",
+			dataSkipIf:           "github.event_name == 'schedule'",
+			activationJobCreated: true,
+			rawFrontmatter:       disableAICGuardrailFrontmatter(),
+			want:                 "!(github.event_name == 'schedule')",
+		},
+		{
+			name:                 "activation, skip-if, guardrail enabled — AIC guard and negated skip-if",
+			dataSkipIf:           "github.event_name == 'schedule'",
+			activationJobCreated: true,
+			want:                 "(needs.activation.outputs.daily_ai_credits_exceeded != 'true') && (!(github.event_name == 'schedule'))",
+		},
+		{
+			name:                 "no activation, with if and skip-if — both applied",
+			dataIf:               "github.event_name == 'push'",
+			dataSkipIf:           "github.ref == 'refs/heads/main'",
+			activationJobCreated: false,
+			rawFrontmatter:       disableAICGuardrailFrontmatter(),
+			want:                 "(github.event_name == 'push') && (!(github.ref == 'refs/heads/main'))",
+		},
 	}
 
 	for _, tt := range tests {
@@ -66,6 +88,7 @@ func TestBuildMainJobCondition(t *testing.T) {
 			c := NewCompiler()
 			data := &WorkflowData{
 				If:             tt.dataIf,
+				SkipIf:         tt.dataSkipIf,
 				Jobs:           tt.jobs,
 				RawFrontmatter: tt.rawFrontmatter,
 			}
@@ -70,6 +70,10 @@ func (c *Compiler) setupEngineAndImports(result *parser.FrontmatterResult, clean
 		return nil, err
 	}
 	engineConfig, model = c.applyEngineImportDefaults(engineConfig, model, engineSetting, importsResult, preservedMaxTurns, preservedMaxAICredits, preservedMaxRuns, preservedMaxTurnCacheMisses)
+	model, err = applyEngineModelSettings(model, engineConfig, result.Frontmatter)
+	if err != nil {
+		return nil, err
+	}
 	agenticEngine, configSteps, err := c.resolveEngineRuntimeConfig(engineSetting, engineConfig)
 	if err != nil {
 		return nil, err
@@ -202,6 +206,10 @@ func (c *Compiler) processEngineImportsAndMerge(
 	if err := scanImportedMarkdownFiles(importsResult.ImportedFiles, markdownDir, importCache); err != nil {
 		return nil, nil, err
 	}
+	if err := c.mergeLocalSkills(result.Frontmatter, markdownDir, importCache, importsResult); err != nil {
+		orchestratorEngineLog.Printf("Local skills merge failed: %v", err)
+		return nil, nil, err
+	}
 	if importsResult.MergedNetwork != "" {
 		orchestratorEngineLog.Printf("Merging network permissions from imports")
 		networkPermissions, err = c.MergeNetworkPermissions(networkPermissions, importsResult.MergedNetwork)
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/github/gh-aw/pkg/console"
@@ -117,12 +118,28 @@ func (c *Compiler) parseFrontmatterSection(markdownPath string) (*frontmatterPar
 		return nil, errors.New("no frontmatter found")
 	}
 
+	// Duplicate top-level keys are only merged with --merge-duplicate-keys; report every
+	// occurrence so the author can consolidate them.
+	for _, duplicate := range result.MergedDuplicateKeys {
+		fmt.Fprintln(os.Stderr, formatCompilerMessage(cleanPath, "warning", formatMergedDuplicateKey(duplicate)))
+		c.IncrementWarningCount()
+	}
+
 	// Preprocess schedule fields to convert human-friendly format to cron expressions
 	if err := c.preprocessScheduleFields(result.Frontmatter, cleanPath, contentString); err != nil {
 		orchestratorFrontmatterLog.Printf("Schedule preprocessing failed: %v", err)
 		return nil, err
 	}
 
+	// Expand needs-workflow into an on.workflow_run trigger
+	if err := c.preprocessNeedsWorkflow(result.Frontmatter, cleanPath); err != nil {
+		orchestratorFrontmatterLog.Printf("needs-workflow preprocessing failed: %v", err)
+		return nil, fmt.Errorf("%s: %w", cleanPath, err)
+	}
+
+	// Add the schedule and workflow_dispatch triggers that drain the active-hours queue
+	preprocessActiveHoursQueue(result.Frontmatter)
+
 	// Create a copy of frontmatter without internal markers for schema validation
 	// Keep the original frontmatter with markers for YAML generation
 	frontmatterForValidation := c.copyFrontmatterWithoutInternalMarkers(result.Frontmatter)
@@ -274,6 +291,16 @@ func (c *Compiler) parseFrontmatterSection(markdownPath string) (*frontmatterPar
 	}, nil
 }
 
+// formatMergedDuplicateKey describes a duplicate top-level key merged by the parser,
+// e.g. "duplicate frontmatter key 'tools' on lines 3, 9 was merged".
+func formatMergedDuplicateKey(duplicate parser.DuplicateFrontmatterKey) string {
+	lines := make([]string, len(duplicate.Lines))
+	for i, line := range duplicate.Lines {
+		lines[i] = strconv.Itoa(line)
+	}
+	return fmt.Sprintf("duplicate frontmatter key '%s' on lines %s was merged; consolidate the occurrences into one '%s:' entry", duplicate.Key, strings.Join(lines, ", "), duplicate.Key)
+}
+
 // copyFrontmatterWithoutInternalMarkers creates a copy of frontmatter without internal marker fields.
 // This is used for schema validation while preserving markers in the original for YAML generation.
 // As an optimization, it checks whether any internal markers are present before allocating a copy.
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/github/gh-aw/pkg/parser"
 	"github.com/github/gh-aw/pkg/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -567,3 +568,40 @@ engine: copilot
 
 	assert.Equal(t, subDir, result.markdownDir, "Should extract correct directory")
 }
+
+// TestParseFrontmatterSection_MergedDuplicateKeys tests that merged duplicate keys are reported as warnings
+func TestParseFrontmatterSection_MergedDuplicateKeys(t *testing.T) {
+	parser.SetMergeDuplicateKeys(true)
+	t.Cleanup(func() { parser.SetMergeDuplicateKeys(false) })
+
+	tmpDir := testutil.TempDir(t, "frontmatter-duplicates")
+
+	testContent := `---
+on: push
+tools:
+  github:
+engine: copilot
+tools:
+  bash: true
+---
+
+# Test Workflow
+`
+
+	testFile := filepath.Join(tmpDir, "test.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644))
+
+	compiler := NewCompiler()
+	result, err := compiler.parseFrontmatterSection(testFile)
+
+	require.NoError(t, err, "Duplicate tools keys should be merged")
+	tools, ok := result.frontmatterResult.Frontmatter["tools"].(map[string]any)
+	require.True(t, ok, "tools should be a mapping")
+	assert.Len(t, tools, 2, "Both tools entries should be kept")
+	assert.Equal(t, 1, compiler.GetWarningCount(), "Merged key should produce one warning")
+}
+
+func TestFormatMergedDuplicateKey(t *testing.T) {
+	msg := formatMergedDuplicateKey(parser.DuplicateFrontmatterKey{Key: "imports", Lines: []int{3, 9, 14}})
+	assert.Equal(t, "duplicate frontmatter key 'imports' on lines 3, 9, 14 was merged; consolidate the occurrences into one 'imports:' entry", msg)
+}
@@ -22,6 +22,7 @@ type toolsProcessingResult struct {
 	runInstallScripts     bool // true when runtimes.node.run-install-scripts: true is set (from main + imports)
 	toolsTimeout          string
 	toolsStartupTimeout   string
+	toolTimeouts          map[string]int
 	markdownContent       string
 	importedMarkdown      string   // Only imports WITH inputs (for compile-time substitution)
 	importPaths           []string // Import paths for runtime-import macro generation (imports without inputs)
@@ -88,6 +89,7 @@ func (c *Compiler) processToolsAndMarkdown(result *parser.FrontmatterResult, cle
 		runInstallScripts:     runInstallScripts,
 		toolsTimeout:          toolsData.toolsTimeout,
 		toolsStartupTimeout:   toolsData.toolsStartupTimeout,
+		toolTimeouts:          toolsData.toolTimeouts,
 		markdownContent:       markdownData.markdownContent,
 		importedMarkdown:      markdownData.importedMarkdown,
 		importPaths:           markdownData.importPaths,
@@ -116,6 +118,7 @@ type mergedToolsData struct {
 	includedToolFiles     []string
 	toolsTimeout          string
 	toolsStartupTimeout   string
+	toolTimeouts          map[string]int
 	hasExplicitGitHubTool bool
 }
 
@@ -210,12 +213,17 @@ func (c *Compiler) resolveToolsConfiguration(
 	if err := c.validateEngineToolRequirements(result.Frontmatter, agenticEngine, tools); err != nil {
 		return nil, err
 	}
+	toolTimeouts, err := c.extractPerToolTimeouts(result.Frontmatter, tools, agenticEngine)
+	if err != nil {
+		return nil, err
+	}
 	return &mergedToolsData{
 		tools:                 tools,
 		resolvedMCPServers:    resolvedMCPServers,
 		includedToolFiles:     includedToolFiles,
 		toolsTimeout:          toolsTimeout,
 		toolsStartupTimeout:   toolsStartupTimeout,
+		toolTimeouts:          toolTimeouts,
 		hasExplicitGitHubTool: githubToolExplicit,
 	}, nil
 }
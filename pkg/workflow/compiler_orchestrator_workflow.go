@@ -148,6 +148,7 @@ func (c *Compiler) validateWorkflowEngineSettings(cleanPath string, workflowData
 		c.validateLSPSupport,
 		c.validateEngineHarnessScript,
 		c.validateEngineDriver,
+		c.validateEngineFallbacks,
 		c.validateEngineMCPSessionTimeout,
 		c.validateEngineMCPToolTimeout,
 	}
@@ -193,6 +194,7 @@ func (c *Compiler) validateWorkflowToolConfigurations(ctx *workflowBuildContext)
 // populateWorkflowBuildContext merges imported configuration and finalizes workflow data.
 func (c *Compiler) populateWorkflowBuildContext(ctx *workflowBuildContext) error {
 	c.attachSharedActionResolver(ctx.workflowData)
+	applyMCPPackagePins(ctx.workflowData)
 	if err := c.extractYAMLSections(ctx.frontmatter.Frontmatter, ctx.workflowData); err != nil {
 		return formatCompilerError(ctx.cleanPath, "error", err.Error(), err)
 	}
@@ -219,7 +221,18 @@ func (c *Compiler) populateWorkflowBuildContext(ctx *workflowBuildContext) error
 	if err := c.mergeImportedOnFields(ctx.frontmatter.Frontmatter, ctx.workflowData, ctx.engineSetup.importsResult); err != nil {
 		return err
 	}
-	return c.processOnSectionAndFilters(ctx.frontmatter.Frontmatter, ctx.workflowData, ctx.cleanPath)
+	if err := c.processOnSectionAndFilters(ctx.frontmatter.Frontmatter, ctx.workflowData, ctx.cleanPath); err != nil {
+		return err
+	}
+	compareEngines, err := extractCompareEnginesConfig(ctx.frontmatter.Frontmatter)
+	if err != nil {
+		return formatCompilerError(ctx.cleanPath, "error", err.Error(), err)
+	}
+	ctx.workflowData.CompareEngines = compareEngines
+	if err := c.validateCompareEngines(ctx.workflowData); err != nil {
+		return formatCompilerError(ctx.cleanPath, "error", err.Error(), err)
+	}
+	return nil
 }
 
 func (c *Compiler) attachSharedActionResolver(workflowData *WorkflowData) {
@@ -237,6 +250,7 @@ func (c *Compiler) mergeImportedWorkflowConfiguration(ctx *workflowBuildContext)
 	if err := c.mergeWorkflowEnv(ctx.frontmatter.Frontmatter, ctx.workflowData, ctx.engineSetup.importsResult); err != nil {
 		return err
 	}
+	setImportedSettingSources(ctx.frontmatter.Frontmatter, ctx.workflowData, ctx.engineSetup.importsResult)
 	c.injectOTLPConfig(ctx.workflowData)
 	if len(ctx.engineSetup.importsResult.MergedFeatures) == 0 {
 		return nil
@@ -367,6 +381,22 @@ func (c *Compiler) mergeWorkflowEnv(frontmatter map[string]any, workflowData *Wo
 	return nil
 }
 
+// setImportedSettingSources records which files contributed each imported tool and which
+// imports require each permission scope, for the lock file header.
+func setImportedSettingSources(frontmatter map[string]any, workflowData *WorkflowData, importsResult *parser.ImportsResult) {
+	if len(importsResult.MergedToolSources) > 0 {
+		topTools := extractToolsMapFromFrontmatter(frontmatter)
+		workflowData.ToolSources = make(map[string][]string, len(importsResult.MergedToolSources))
+		for tool, sources := range importsResult.MergedToolSources {
+			if _, inTop := topTools[tool]; inTop {
+				sources = append([]string{"(main workflow)"}, sources...)
+			}
+			workflowData.ToolSources[tool] = sources
+		}
+	}
+	workflowData.PermissionSources = importsResult.MergedPermissionSources
+}
+
 func setMainWorkflowEnvSources(workflowData *WorkflowData, topEnv map[string]any) {
 	if len(topEnv) == 0 {
 		return
@@ -427,13 +457,32 @@ func (c *Compiler) extractAdditionalConfigurations(
 
 	// Merge jobs from imported YAML workflows
 	if importsResult.MergedJobs != "" && importsResult.MergedJobs != "{}" {
+		if err := validateYAMLImportJobs(importsResult.MergedJobs, importsResult.YAMLImportJobSources); err != nil {
+			return err
+		}
 		workflowData.Jobs = c.mergeJobsFromYAMLImports(workflowData.Jobs, importsResult.MergedJobs)
 	}
 
+	sanitizedInputs, err := extractSanitizedInputsConfig(frontmatter)
+	if err != nil {
+		return err
+	}
+	workflowData.SanitizedInputs = sanitizedInputs
+
 	workflowData.Roles = c.extractRoles(frontmatter)
 	workflowData.Bots = expandBotNames(mergeBots(c.extractBots(frontmatter), importsResult.MergedBots))
 	workflowData.LabelNames = c.extractLabelNames(frontmatter)
 	workflowData.RateLimit = c.extractRateLimitConfig(frontmatter)
+	activeHours, err := extractActiveHoursConfig(frontmatter)
+	if err != nil {
+		return err
+	}
+	workflowData.ActiveHours = activeHours
+	conclusion, err := extractConclusionConfig(frontmatter)
+	if err != nil {
+		return err
+	}
+	workflowData.Conclusion = conclusion
 	workflowData.SkipRoles = mergeSkipRoles(c.extractSkipRoles(frontmatter), importsResult.MergedSkipRoles)
 	workflowData.SkipBots = expandBotNames(mergeSkipBots(c.extractSkipBots(frontmatter), importsResult.MergedSkipBots))
 	workflowData.SkipAuthorAssociations = c.extractSkipAuthorAssociations(frontmatter)
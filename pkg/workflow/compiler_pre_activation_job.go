@@ -48,6 +48,7 @@ func (c *Compiler) buildPreActivationJob(data *WorkflowData, needsPermissionChec
 	steps = c.buildPreActivationSkipIfQuerySteps(data, steps, skipIfToken)
 	steps = c.buildPreActivationSkipIfCheckFailingStep(data, steps)
 	steps = c.buildPreActivationRolesBotsCmdSteps(data, steps)
+	steps = c.buildPreActivationActiveHoursStep(data, steps, needsPermissionCheck)
 	steps = c.buildPreActivationMemoryRestoreSteps(data, steps)
 	steps, onStepIDs, err := c.injectPreActivationOnSteps(data, steps, customSteps)
 	if err != nil {
@@ -99,6 +100,15 @@ func (c *Compiler) buildPreActivationPermissions(data *WorkflowData, setupAction
 		}
 		perms.Set(PermissionActions, PermissionRead)
 	}
+	// Add write scopes for the comment posted when a trigger arrives outside active hours.
+	if commentPerms := activeHoursCommentPermissions(data.ActiveHours); len(commentPerms) > 0 {
+		if perms == nil {
+			perms = NewPermissions()
+		}
+		for scope, level := range commentPerms {
+			perms.Set(scope, level)
+		}
+	}
 	// Auto-grant pull-requests: read when label_command uses decentralized strategy
 	// with pull_request events. The check_membership.cjs script calls the pulls API
 	// to verify PR provenance, which requires pull-requests: read.
@@ -136,13 +146,12 @@ func (c *Compiler) buildPreActivationCheckSteps(data *WorkflowData, steps []stri
 	compilerActivationJobsLog.Printf("Adding stop-time check step: stop_time=%s", data.StopTime)
 	steps = append(steps, "      - name: Check stop-time limit\n")
 	steps = append(steps, fmt.Sprintf("        id: %s\n", constants.CheckStopTimeStepID))
-	steps = append(steps, fmt.Sprintf("        uses: %s\n", getCachedActionPin("actions/github-script", data)))
+	script := generateGitHubScriptWithRequire("check_stop_time.cjs")
+	steps = append(steps, fmt.Sprintf("        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", data), script)))
 	steps = append(steps, "        env:\n")
 	steps = append(steps, fmt.Sprintf("          GH_AW_STOP_TIME: %q\n", stringutil.StripANSI(data.StopTime)))
 	steps = append(steps, fmt.Sprintf("          GH_AW_WORKFLOW_NAME: %q\n", data.Name))
-	steps = append(steps, "        with:\n")
-	steps = append(steps, "          script: |\n")
-	return append(steps, generateGitHubScriptWithRequire("check_stop_time.cjs"))
+	return append(steps, c.githubScriptWith(script))
 }
 
 func (c *Compiler) buildPreActivationSkipIfQuerySteps(data *WorkflowData, steps []string, skipIfToken string) []string {
@@ -160,7 +169,8 @@ func (c *Compiler) buildPreActivationSkipIfQuerySteps(data *WorkflowData, steps
 func (c *Compiler) appendPreActivationSkipIfMatchStep(data *WorkflowData, steps []string, skipIfToken string) []string {
 	steps = append(steps, "      - name: Check skip-if-match query\n")
 	steps = append(steps, fmt.Sprintf("        id: %s\n", constants.CheckSkipIfMatchStepID))
-	steps = append(steps, fmt.Sprintf("        uses: %s\n", getCachedActionPin("actions/github-script", data)))
+	script := generateGitHubScriptWithRequire("check_skip_if_match.cjs")
+	steps = append(steps, fmt.Sprintf("        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", data), script)))
 	steps = append(steps, "        env:\n")
 	steps = append(steps, fmt.Sprintf("          GH_AW_SKIP_QUERY: %q\n", data.SkipIfMatch.Query))
 	steps = append(steps, fmt.Sprintf("          GH_AW_WORKFLOW_NAME: %q\n", data.Name))
@@ -168,18 +178,18 @@ func (c *Compiler) appendPreActivationSkipIfMatchStep(data *WorkflowData, steps
 	if data.SkipIfMatch.Scope != "" {
 		steps = append(steps, fmt.Sprintf("          GH_AW_SKIP_SCOPE: %q\n", data.SkipIfMatch.Scope))
 	}
-	steps = append(steps, "        with:\n")
+	var tokenInputs []string
 	if skipIfToken != "" {
-		steps = append(steps, fmt.Sprintf("          github-token: %s\n", skipIfToken))
+		tokenInputs = append(tokenInputs, fmt.Sprintf("          github-token: %s\n", skipIfToken))
 	}
-	steps = append(steps, "          script: |\n")
-	return append(steps, generateGitHubScriptWithRequire("check_skip_if_match.cjs"))
+	return append(steps, c.githubScriptWith(script, tokenInputs...))
 }
 
 func (c *Compiler) appendPreActivationSkipIfNoMatchStep(data *WorkflowData, steps []string, skipIfToken string) []string {
 	steps = append(steps, "      - name: Check skip-if-no-match query\n")
 	steps = append(steps, fmt.Sprintf("        id: %s\n", constants.CheckSkipIfNoMatchStepID))
-	steps = append(steps, fmt.Sprintf("        uses: %s\n", getCachedActionPin("actions/github-script", data)))
+	script := generateGitHubScriptWithRequire("check_skip_if_no_match.cjs")
+	steps = append(steps, fmt.Sprintf("        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", data), script)))
 	steps = append(steps, "        env:\n")
 	steps = append(steps, fmt.Sprintf("          GH_AW_SKIP_QUERY: %q\n", data.SkipIfNoMatch.Query))
 	steps = append(steps, fmt.Sprintf("          GH_AW_WORKFLOW_NAME: %q\n", data.Name))
@@ -187,12 +197,11 @@ func (c *Compiler) appendPreActivationSkipIfNoMatchStep(data *WorkflowData, step
 	if data.SkipIfNoMatch.Scope != "" {
 		steps = append(steps, fmt.Sprintf("          GH_AW_SKIP_SCOPE: %q\n", data.SkipIfNoMatch.Scope))
 	}
-	steps = append(steps, "        with:\n")
+	var tokenInputs []string
 	if skipIfToken != "" {
-		steps = append(steps, fmt.Sprintf("          github-token: %s\n", skipIfToken))
+		tokenInputs = append(tokenInputs, fmt.Sprintf("          github-token: %s\n", skipIfToken))
 	}
-	steps = append(steps, "          script: |\n")
-	return append(steps, generateGitHubScriptWithRequire("check_skip_if_no_match.cjs"))
+	return append(steps, c.githubScriptWith(script, tokenInputs...))
 }
 
 func (c *Compiler) buildPreActivationSkipIfCheckFailingStep(data *WorkflowData, steps []string) []string {
@@ -203,7 +212,8 @@ func (c *Compiler) buildPreActivationSkipIfCheckFailingStep(data *WorkflowData,
 	compilerActivationJobsLog.Printf("Adding skip-if-check-failing check step: include=%v, exclude=%v", data.SkipIfCheckFailing.Include, data.SkipIfCheckFailing.Exclude)
 	steps = append(steps, "      - name: Check skip-if-check-failing\n")
 	steps = append(steps, fmt.Sprintf("        id: %s\n", constants.CheckSkipIfCheckFailingStepID))
-	steps = append(steps, fmt.Sprintf("        uses: %s\n", getCachedActionPin("actions/github-script", data)))
+	script := generateGitHubScriptWithRequire("check_skip_if_check_failing.cjs")
+	steps = append(steps, fmt.Sprintf("        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", data), script)))
 
 	cfg := data.SkipIfCheckFailing
 	if len(cfg.Include) > 0 || len(cfg.Exclude) > 0 || cfg.Branch != "" || cfg.AllowPending {
@@ -223,9 +233,7 @@ func (c *Compiler) buildPreActivationSkipIfCheckFailingStep(data *WorkflowData,
 			steps = append(steps, "          GH_AW_SKIP_CHECK_ALLOW_PENDING: \"true\"\n")
 		}
 	}
-	steps = append(steps, "        with:\n")
-	steps = append(steps, "          script: |\n")
-	return append(steps, generateGitHubScriptWithRequire("check_skip_if_check_failing.cjs"))
+	return append(steps, c.githubScriptWith(script))
 }
 
 func (c *Compiler) buildPreActivationRolesBotsCmdSteps(data *WorkflowData, steps []string) []string {
@@ -265,14 +273,9 @@ func (c *Compiler) buildPreActivationMemoryRestoreSteps(data *WorkflowData, step
 			steps = append(steps, strings.Join(configLines, ""))
 			var commentMemorySteps strings.Builder
 			commentMemorySteps.WriteString("      - name: Prepare comment memory files\n")
-			fmt.Fprintf(&commentMemorySteps, "        uses: %s\n", getCachedActionPin("actions/github-script", data))
-			commentMemorySteps.WriteString("        with:\n")
-			fmt.Fprintf(&commentMemorySteps, "          github-token: %s\n", getEffectiveSafeOutputGitHubToken(data.SafeOutputs.CommentMemory.GitHubToken))
-			commentMemorySteps.WriteString("          script: |\n")
-			commentMemorySteps.WriteString("            const { setupGlobals } = require('${{ runner.temp }}/gh-aw/actions/setup_globals.cjs');\n")
-			commentMemorySteps.WriteString("            setupGlobals(core, github, context, exec, io, getOctokit);\n")
-			commentMemorySteps.WriteString("            const { main } = require('${{ runner.temp }}/gh-aw/actions/setup_comment_memory_files.cjs');\n")
-			commentMemorySteps.WriteString("            await main();\n")
+			script := generateGitHubScriptWithRequire("setup_comment_memory_files.cjs")
+			fmt.Fprintf(&commentMemorySteps, "        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", data), script))
+			commentMemorySteps.WriteString(c.githubScriptWith(script, fmt.Sprintf("          github-token: %s\n", getEffectiveSafeOutputGitHubToken(data.SafeOutputs.CommentMemory.GitHubToken))))
 			steps = append(steps, commentMemorySteps.String())
 		}
 	}
@@ -316,44 +319,40 @@ func generatePreActivationCacheMemoryRestoreSteps(builder *strings.Builder, data
 func (c *Compiler) appendPreActivationSkipRolesStep(data *WorkflowData, steps []string) []string {
 	steps = append(steps, "      - name: Check skip-roles\n")
 	steps = append(steps, fmt.Sprintf("        id: %s\n", constants.CheckSkipRolesStepID))
-	steps = append(steps, fmt.Sprintf("        uses: %s\n", getCachedActionPin("actions/github-script", data)))
+	script := generateGitHubScriptWithRequire("check_skip_roles.cjs")
+	steps = append(steps, fmt.Sprintf("        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", data), script)))
 	steps = append(steps, "        env:\n")
 	steps = append(steps, fmt.Sprintf("          GH_AW_SKIP_ROLES: %q\n", strings.Join(data.SkipRoles, ",")))
 	steps = append(steps, fmt.Sprintf("          GH_AW_WORKFLOW_NAME: %q\n", data.Name))
-	steps = append(steps, "        with:\n")
-	steps = append(steps, "          github-token: ${{ secrets.GITHUB_TOKEN }}\n")
-	steps = append(steps, "          script: |\n")
-	return append(steps, generateGitHubScriptWithRequire("check_skip_roles.cjs"))
+	return append(steps, c.githubScriptWith(script, "          github-token: ${{ secrets.GITHUB_TOKEN }}\n"))
 }
 
 func (c *Compiler) appendPreActivationSkipBotsStep(data *WorkflowData, steps []string) []string {
 	steps = append(steps, "      - name: Check skip-bots\n")
 	steps = append(steps, fmt.Sprintf("        id: %s\n", constants.CheckSkipBotsStepID))
-	steps = append(steps, fmt.Sprintf("        uses: %s\n", getCachedActionPin("actions/github-script", data)))
+	script := generateGitHubScriptWithRequire("check_skip_bots.cjs")
+	steps = append(steps, fmt.Sprintf("        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", data), script)))
 	steps = append(steps, "        env:\n")
 	steps = append(steps, fmt.Sprintf("          GH_AW_SKIP_BOTS: %q\n", strings.Join(data.SkipBots, ",")))
 	steps = append(steps, fmt.Sprintf("          GH_AW_WORKFLOW_NAME: %q\n", data.Name))
 	if data.AllowBotAuthoredTriggerComment {
 		steps = append(steps, "          GH_AW_ALLOW_BOT_AUTHORED_TRIGGER_COMMENT: \"true\"\n")
 	}
-	steps = append(steps, "        with:\n")
-	steps = append(steps, "          script: |\n")
-	return append(steps, generateGitHubScriptWithRequire("check_skip_bots.cjs"))
+	return append(steps, c.githubScriptWith(script))
 }
 
 func (c *Compiler) appendPreActivationCommandPositionStep(data *WorkflowData, steps []string) []string {
 	steps = append(steps, "      - name: Check command position\n")
 	steps = append(steps, fmt.Sprintf("        id: %s\n", constants.CheckCommandPositionStepID))
-	steps = append(steps, fmt.Sprintf("        uses: %s\n", getCachedActionPin("actions/github-script", data)))
+	script := generateGitHubScriptWithRequire("check_command_position.cjs")
+	steps = append(steps, fmt.Sprintf("        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", data), script)))
 	steps = append(steps, "        env:\n")
 	commandsJSON, _ := json.Marshal(data.Command) //nolint:jsonmarshalignoredeerror // marshaling a string slice cannot fail
 	steps = append(steps, fmt.Sprintf("          GH_AW_COMMANDS: %q\n", string(commandsJSON)))
 	if data.CommandPlaceholder != "" {
 		steps = append(steps, fmt.Sprintf("          GH_AW_COMMAND_PLACEHOLDER: %q\n", data.CommandPlaceholder))
 	}
-	steps = append(steps, "        with:\n")
-	steps = append(steps, "          script: |\n")
-	return append(steps, generateGitHubScriptWithRequire("check_command_position.cjs"))
+	return append(steps, c.githubScriptWith(script))
 }
 
 func (c *Compiler) injectPreActivationOnSteps(data *WorkflowData, steps, customSteps []string) ([]string, []string, error) {
@@ -384,6 +383,13 @@ func (c *Compiler) injectPreActivationOnSteps(data *WorkflowData, steps, customS
 }
 
 func buildPreActivationActivatedConditions(data *WorkflowData, needsPermissionCheck bool) []ConditionNode {
+	conditions := buildPreActivationGateConditions(data, needsPermissionCheck)
+	return appendPreActivationCondition(conditions, data.ActiveHours != nil, constants.CheckActiveHoursStepID, constants.ActiveHoursOkOutput)
+}
+
+// buildPreActivationGateConditions returns every activation condition except the
+// active-hours window, which is evaluated last and only notifies when these pass.
+func buildPreActivationGateConditions(data *WorkflowData, needsPermissionCheck bool) []ConditionNode {
 	conditions := buildPreActivationMembershipAndTimeConditions(data, needsPermissionCheck)
 	return append(conditions, buildPreActivationSkipAndCommandConditions(data)...)
 }
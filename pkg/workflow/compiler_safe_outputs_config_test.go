@@ -835,6 +835,22 @@ func TestAddHandlerManagerConfigEnvVar(t *testing.T) {
 			checkJSON:    true,
 			expectedKeys: []string{"create_check_run"},
 		},
+		{
+			name: "create_commit_status config",
+			safeOutputs: &SafeOutputsConfig{
+				CreateCommitStatus: &CreateCommitStatusConfig{
+					BaseSafeOutputConfig: BaseSafeOutputConfig{
+						Max: strPtr("1"),
+					},
+					Context: "ai-review",
+				},
+			},
+			checkContains: []string{
+				"GH_AW_SAFE_OUTPUTS_HANDLER_CONFIG",
+			},
+			checkJSON:    true,
+			expectedKeys: []string{"create_commit_status"},
+		},
 		{
 			name: "comment_memory config",
 			safeOutputs: &SafeOutputsConfig{
@@ -1198,6 +1214,17 @@ func TestHandlerConfigBooleanFields(t *testing.T) {
 			checkKey:   "hide_older_comments",
 			expected:   true,
 		},
+		{
+			name: "add comment update-if-exists anchor",
+			safeOutputs: &SafeOutputsConfig{
+				AddComments: &AddCommentsConfig{
+					UpdateIfExists: "anchor",
+				},
+			},
+			checkField: "add_comment",
+			checkKey:   "update_if_exists",
+			expected:   "anchor",
+		},
 		{
 			name: "add comment discussions opt-in",
 			safeOutputs: &SafeOutputsConfig{
@@ -1717,16 +1744,16 @@ func TestParseSafeOutputsMaxPatchFiles(t *testing.T) {
 		{name: "int value", value: 250, expected: 250},
 		{name: "uint64 value", value: uint64(300), expected: 300},
 		{name: "float value", value: 150.0, expected: 150},
-		{name: "zero falls back to default", value: 0, expected: 100},
-		{name: "negative falls back to default", value: -5, expected: 100},
+		{name: "zero is left unset", value: 0, expected: 0},
+		{name: "negative is left unset", value: -5, expected: 0},
 		// Overflow / out-of-range guards: values that would wrap or produce
 		// undefined results when narrowed to int must be clamped or rejected,
-		// not silently treated as 0 (which would fall back to the default).
+		// not silently wrapped into a bogus limit.
 		{name: "uint64 max clamps to MaxInt", value: uint64(math.MaxUint64), expected: math.MaxInt},
-		{name: "huge float ignored (out of int range)", value: 1e30, expected: 100},
-		{name: "negative huge float ignored", value: -1e30, expected: 100},
-		{name: "NaN ignored", value: math.NaN(), expected: 100},
-		{name: "+Inf ignored", value: math.Inf(1), expected: 100},
+		{name: "huge float ignored (out of int range)", value: 1e30, expected: 0},
+		{name: "negative huge float ignored", value: -1e30, expected: 0},
+		{name: "NaN ignored", value: math.NaN(), expected: 0},
+		{name: "+Inf ignored", value: math.Inf(1), expected: 0},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1795,7 +1822,7 @@ func TestHandlerConfigCreatePullRequestPatchLimitsOverrideGlobals(t *testing.T)
 	assert.True(t, found, "GH_AW_SAFE_OUTPUTS_HANDLER_CONFIG step should be present")
 }
 
-func TestHandlerConfigPushToPullRequestBranchPatchSizeOverridesGlobal(t *testing.T) {
+func TestHandlerConfigPushToPullRequestBranchPatchLimits(t *testing.T) {
 	compiler := NewCompiler()
 
 	workflowData := &WorkflowData{
@@ -1803,7 +1830,8 @@ func TestHandlerConfigPushToPullRequestBranchPatchSizeOverridesGlobal(t *testing
 		SafeOutputs: &SafeOutputsConfig{
 			MaximumPatchSize: 4096,
 			PushToPullRequestBranch: &PushToPullRequestBranchConfig{
-				MaxPatchSize: 2048,
+				MaxPatchSize:  2048,
+				MaxPatchFiles: 50,
 			},
 		},
 	}
@@ -1834,12 +1862,36 @@ func TestHandlerConfigPushToPullRequestBranchPatchSizeOverridesGlobal(t *testing
 		maxSize, ok := pushConfig["max_patch_size"]
 		require.True(t, ok, "max_patch_size should be present in handler config")
 		assert.InDelta(t, float64(2048), maxSize, 0.0001, "push-to-pull-request-branch max_patch_size should use per-handler override")
+
+		maxFiles, ok := pushConfig["max_patch_files"]
+		require.True(t, ok, "max_patch_files should be present in handler config")
+		assert.InDelta(t, float64(50), maxFiles, 0.0001, "push-to-pull-request-branch max_patch_files should use the handler setting")
 		found = true
 	}
 
 	assert.True(t, found, "GH_AW_SAFE_OUTPUTS_HANDLER_CONFIG step should be present")
 }
 
+func TestHandlerConfigPushToPullRequestBranchMaxPatchFilesUnsetByDefault(t *testing.T) {
+	config := handlerRegistry["push_to_pull_request_branch"](&SafeOutputsConfig{
+		PushToPullRequestBranch: &PushToPullRequestBranchConfig{},
+	})
+
+	assert.NotContains(t, config, "max_patch_files", "pushes should have no file limit unless max-patch-files is set")
+	assert.Contains(t, config, "max_patch_size", "the patch size limit should still apply")
+}
+
+func TestHandlerConfigPushToPullRequestBranchMaxPatchFilesFromGlobal(t *testing.T) {
+	config := handlerRegistry["push_to_pull_request_branch"](&SafeOutputsConfig{
+		MaximumPatchFiles:       800,
+		PushToPullRequestBranch: &PushToPullRequestBranchConfig{},
+	})
+
+	maxFiles, ok := config["max_patch_files"]
+	require.True(t, ok, "the top-level max-patch-files should apply to pushes")
+	assert.EqualValues(t, 800, maxFiles, "pushes should use the top-level max-patch-files")
+}
+
 // testBoolPtr is a helper function for bool pointers in config tests
 func testBoolPtr(b bool) *bool { return new(b) }
 
@@ -24,6 +24,10 @@ const stepNameLinePrefix = "      - name: "
 // It must match the literal slice appended in buildPreambleTokenSteps.
 const uploadArtifactStagingDownloadStepCount = 6
 
+// createArtifactStagingDownloadStepCount is the number of YAML string entries emitted by the
+// create-artifact staging download step block (name, continue-on-error, uses, with, name, path).
+const createArtifactStagingDownloadStepCount = 6
+
 // getSafeOutputsHeadApp returns the first non-nil HeadGitHubApp config from
 // create-pull-request or push-to-pull-request-branch handlers, used to generate
 // the safe-outputs-head-app-token step.
@@ -128,7 +132,7 @@ func (c *Compiler) buildConsolidatedSafeOutputsJob(data *WorkflowData, mainJobNa
 	// credentials, id-token: write is needed so the safe_outputs job can mint the OTLP
 	// OIDC token via core.getIDToken(audience) (mirrors threat_detection_job.go).
 	if hasOTLPGitHubOIDCAuth(data.ParsedFrontmatter, data.RawFrontmatter) {
-		permissions.Set(PermissionIdToken, PermissionWrite)
+		permissions.Require(PermissionIdToken, PermissionWrite, "observability.otlp")
 	}
 	threatDetectionEnabled := IsDetectionJobEnabled(data.SafeOutputs)
 
@@ -183,8 +187,8 @@ func (c *Compiler) buildSafeOutputsSetupAndDownloadSteps(data *WorkflowData, age
 		// For dev mode (local action path), checkout the actions folder first
 		steps = append(steps, c.generateCheckoutActionsFolder(data)...)
 
-		// Enable artifact client flag if upload-artifact safe output is configured
-		enableArtifactClient := data.SafeOutputs != nil && data.SafeOutputs.UploadArtifact != nil
+		// Enable artifact client flag if upload-artifact or create-artifact safe output is configured
+		enableArtifactClient := usesSafeOutputsArtifactClient(data.SafeOutputs)
 
 		// Safe outputs job depends on agent job; reuse the agent's trace ID so all jobs share one OTLP trace
 		safeOutputsTraceID := fmt.Sprintf("${{ needs.%s.outputs.setup-trace-id }}", constants.ActivationJobName)
@@ -234,6 +238,14 @@ func (c *Compiler) buildSafeOutputsSetupAndDownloadSteps(data *WorkflowData, age
 	// correct enterprise instance.
 	steps = append(steps, generateGHESHostConfigurationStep())
 
+	// Authenticate to the configured cloud provider and verify the assumed identity
+	// before any user-provided step can use the credentials.
+	cloudSteps, err := buildCloudSteps(data.SafeOutputs.Cloud, data)
+	if err != nil {
+		return nil, err
+	}
+	steps = append(steps, cloudSteps...)
+
 	// Add user-provided steps after checkout/setup, before safe-output code
 	if len(data.SafeOutputs.Steps) > 0 {
 		consolidatedSafeOutputsJobLog.Printf("Adding %d user-provided steps to safe-outputs job", len(data.SafeOutputs.Steps))
@@ -283,7 +295,8 @@ func (c *Compiler) buildSafeOutputsHandlerOutputsAndActionSteps(data *WorkflowDa
 	// 2. Assign To Agent - assigns issue to agent (after handler managers complete)
 	// 3. Create Agent Session - creates agent session (after assignment)
 	//
-	// Note: All project-related operations (create_project, update_project, create_project_status_update)
+	// Note: All project-related operations (create_project, update_project, create_project_status_update,
+	// update_project_item)
 	// are now handled by the unified handler in the handler manager step.
 
 	// Check if any handler-manager-supported types are enabled
@@ -315,11 +328,15 @@ func (c *Compiler) buildSafeOutputsHandlerOutputsAndActionSteps(data *WorkflowDa
 		data.SafeOutputs.CreateCodeScanningAlerts != nil ||
 		data.SafeOutputs.AutofixCodeScanningAlert != nil ||
 		data.SafeOutputs.CreateCheckRun != nil ||
+		data.SafeOutputs.CreateCommitStatus != nil ||
+		data.SafeOutputs.Notify != nil ||
+		data.SafeOutputs.UpdateProjectItems != nil ||
 		data.SafeOutputs.MissingTool != nil ||
 		data.SafeOutputs.MissingData != nil ||
 		data.SafeOutputs.AssignToAgent != nil || // assign_to_agent is now handled by the handler manager
 		data.SafeOutputs.CreateAgentSessions != nil || // create_agent_session is now handled by the handler manager
 		data.SafeOutputs.UploadArtifact != nil || // upload_artifact is handled inline in the handler loop
+		data.SafeOutputs.CreateArtifact != nil || // create_artifact is handled inline in the handler loop
 		len(data.SafeOutputs.Scripts) > 0 || // Custom scripts run in the handler loop
 		len(data.SafeOutputs.Actions) > 0 // Custom actions need handler to export their payloads
 
@@ -352,6 +369,20 @@ func (c *Compiler) buildSafeOutputsHandlerOutputsAndActionSteps(data *WorkflowDa
 		)
 	}
 
+	// Download the encrypted create-artifact results for the create_artifact handler.
+	if data.SafeOutputs.CreateArtifact != nil {
+		consolidatedSafeOutputsJobLog.Print("Adding create-artifact staging download step")
+		stagingArtifactName := agentArtifactPrefix + SafeOutputsCreateArtifactStagingArtifactName
+		steps = append(steps,
+			"      - name: Download create-artifact staging\n",
+			"        continue-on-error: true\n",
+			fmt.Sprintf("        uses: %s\n", c.getActionPin("actions/download-artifact")),
+			"        with:\n",
+			fmt.Sprintf("          name: %s\n", stagingArtifactName),
+			fmt.Sprintf("          path: %s\n", createArtifactStagingDirExpr),
+		)
+	}
+
 	// 1. Handler Manager step (processes create_issue, update_issue, add_comment, assign_to_agent,
 	// upload_artifact, etc.)
 	// This processes all safe output types that are handled by the unified handler
@@ -373,6 +404,12 @@ func (c *Compiler) buildSafeOutputsHandlerOutputsAndActionSteps(data *WorkflowDa
 		outputs["code_push_failure_errors"] = "${{ steps.process_safe_outputs.outputs.code_push_failure_errors }}"
 		outputs["code_push_failure_count"] = "${{ steps.process_safe_outputs.outputs.code_push_failure_count }}"
 
+		// Export outputs dropped by the safe-outputs.max-total cap for the conclusion comment
+		if data.SafeOutputs.MaxTotal > 0 {
+			outputs["dropped_outputs"] = "${{ steps.process_safe_outputs.outputs.dropped_outputs }}"
+			outputs["dropped_output_count"] = "${{ steps.process_safe_outputs.outputs.dropped_output_count }}"
+		}
+
 		// Note: Permissions are now computed centrally by ComputePermissionsForSafeOutputs()
 		// at the start of this function to ensure consistent permission calculation
 
@@ -405,6 +442,13 @@ func (c *Compiler) buildSafeOutputsHandlerOutputsAndActionSteps(data *WorkflowDa
 			}
 		}
 
+		// Export create_artifact outputs set by the create_artifact handler.
+		if data.SafeOutputs.CreateArtifact != nil {
+			consolidatedSafeOutputsJobLog.Print("Exposing create_artifact outputs from handler manager")
+			outputs["create_artifact_count"] = "${{ steps.process_safe_outputs.outputs.create_artifact_count }}"
+			outputs["create_artifact_names"] = "${{ steps.process_safe_outputs.outputs.create_artifact_names }}"
+		}
+
 	}
 
 	// 2. SARIF output — expose sarif_file from the handler so the dedicated
@@ -545,7 +589,7 @@ func (c *Compiler) buildSafeOutputsJobFromParts(
 	job := &Job{
 		Name:           "safe_outputs",
 		If:             RenderCondition(jobCondition),
-		RunsOn:         c.formatFrameworkJobRunsOn(data),
+		RunsOn:         c.formatSafeOutputsJobRunsOn(data),
 		Environment:    c.indentYAMLLines(resolveSafeOutputsEnvironment(data), "    "),
 		Permissions:    permissions.RenderToYAML(),
 		TimeoutMinutes: timeoutMinutes,
@@ -625,7 +669,7 @@ func (c *Compiler) calculatePreambleInsertIndex(steps []string, data *WorkflowDa
 		insertIndex += len(c.generateCheckoutActionsFolder(data))
 		countTraceID := fmt.Sprintf("${{ needs.%s.outputs.setup-trace-id }}", constants.ActivationJobName)
 		countParentSpanID := setupParentSpanNeedsExpr(constants.ActivationJobName)
-		insertIndex += len(c.generateSetupStep(data, setupActionRef, SetupActionDestination, data.SafeOutputs != nil && data.SafeOutputs.UploadArtifact != nil, countTraceID, countParentSpanID))
+		insertIndex += len(c.generateSetupStep(data, setupActionRef, SetupActionDestination, usesSafeOutputsArtifactClient(data.SafeOutputs), countTraceID, countParentSpanID))
 	}
 	if isOTLPHeadersPresent(data) {
 		insertIndex += strings.Count(generateOTLPHeadersMaskStep(), stepNameLinePrefix)
@@ -638,6 +682,9 @@ func (c *Compiler) calculatePreambleInsertIndex(steps []string, data *WorkflowDa
 		// The staging download step has uploadArtifactStagingDownloadStepCount YAML string entries.
 		insertIndex += uploadArtifactStagingDownloadStepCount
 	}
+	if data.SafeOutputs.CreateArtifact != nil {
+		insertIndex += createArtifactStagingDownloadStepCount
+	}
 	if usesPatchesAndCheckouts(data.SafeOutputs) {
 		patchDownloadSteps := buildArtifactDownloadSteps(ArtifactDownloadConfig{
 			ArtifactName: agentArtifactPrefix + constants.AgentArtifactName,
@@ -38,6 +38,11 @@ func (c *Compiler) buildSharedPRCheckoutSteps(data *WorkflowData) []string {
 	// safe_outputs handler code (not the untrusted agent) is the only consumer.
 	checkoutMgr.SetKeepCredentialsForPush(true)
 
+	// The handlers fetch the base branch and push commits from this checkout. Both fail
+	// on a shallow clone ("shallow update not allowed"), so derive a full-history
+	// fetch-depth for the default checkout unless the user configured one explicitly.
+	checkoutMgr.EnsureDefaultFullHistory()
+
 	// Persist the resolved PR push token (not just the default GITHUB_TOKEN) into
 	// .git/config so the retained credential matches the token the handlers use to
 	// fetch/push. This keeps a single, correct Authorization header on the wire and
@@ -106,7 +111,8 @@ func (c *Compiler) buildHandlerManagerStep(data *WorkflowData) ([]string, error)
 	// Step name and metadata
 	steps = append(steps, "      - name: Process Safe Outputs\n")
 	steps = append(steps, "        id: process_safe_outputs\n")
-	steps = append(steps, fmt.Sprintf("        uses: %s\n", getCachedActionPin("actions/github-script", data)))
+	script := generateGitHubScriptWithRequire("safe_output_handler_manager.cjs")
+	steps = append(steps, fmt.Sprintf("        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", data), script)))
 
 	// Environment variables
 	steps = append(steps, "        env:\n")
@@ -202,12 +208,14 @@ func (c *Compiler) buildHandlerManagerStep(data *WorkflowData) ([]string, error)
 	// These are set from the project URL and token configured in any project-related safe-output:
 	// - update-project
 	// - create-project-status-update
+	// - update-project-item
 	// - create-project
+	// - create-issue (token only, when a project is configured)
 	//
 	// The project field is REQUIRED in update-project and create-project-status-update (enforced by schema validation)
 	// Agents can optionally override this per-message by including a project field in their output
 	//
-	// Note: If multiple project configs are present, we prefer update-project > create-project-status-update > create-project
+	// Note: If multiple project configs are present, we prefer update-project > create-project-status-update > update-project-item > create-project
 	// This is only relevant for the environment variables - each configuration must explicitly specify its own settings
 	projectURL, projectToken := resolveProjectURLAndToken(data.SafeOutputs)
 
@@ -219,6 +227,13 @@ func (c *Compiler) buildHandlerManagerStep(data *WorkflowData) ([]string, error)
 		steps = append(steps, fmt.Sprintf("          GH_AW_PROJECT_GITHUB_TOKEN: %s\n", projectToken))
 	}
 
+	// Expose each notify channel's webhook secret to the notify handler. The URLs are kept
+	// out of the handler config JSON so they only ever exist as masked secret values.
+	if data.SafeOutputs != nil && data.SafeOutputs.Notify != nil {
+		steps = append(steps, buildNotifyWebhookEnvVars(data.SafeOutputs.Notify)...)
+		consolidatedSafeOutputsStepsLog.Printf("Added %d notify webhook env var(s)", len(data.SafeOutputs.Notify.Channels))
+	}
+
 	// Add GH_AW_ASSIGN_TO_AGENT_TOKEN when assign-to-agent is configured OR when create-issue
 	// or create-pull-request is configured with copilot in assignees. All handlers create a
 	// dedicated Octokit using this token (agent token preference chain), which is required
@@ -274,7 +289,6 @@ func (c *Compiler) buildHandlerManagerStep(data *WorkflowData) ([]string, error)
 	// With section for github-token
 	// Use the standard safe-outputs token for the shared github-script client.
 	// Project operations use GH_AW_PROJECT_GITHUB_TOKEN from env with dedicated handler logic.
-	steps = append(steps, "        with:\n")
 	// Token precedence for the handler manager step:
 	//   1. Safe-outputs level token (so.GitHubToken)
 	//   2. Magic secret fallback via getEffectiveSafeOutputGitHubToken()
@@ -286,13 +300,10 @@ func (c *Compiler) buildHandlerManagerStep(data *WorkflowData) ([]string, error)
 	if data.SafeOutputs != nil && data.SafeOutputs.GitHubToken != "" {
 		configToken = data.SafeOutputs.GitHubToken
 	}
-	c.addSafeOutputGitHubTokenForConfig(&steps, data, configToken)
+	var tokenInputs []string
+	c.addSafeOutputGitHubTokenForConfig(&tokenInputs, data, configToken)
 
-	steps = append(steps, "          script: |\n")
-	steps = append(steps, "            const { setupGlobals } = require('"+SetupActionDestination+"/setup_globals.cjs');\n")
-	steps = append(steps, "            setupGlobals(core, github, context, exec, io, getOctokit);\n")
-	steps = append(steps, "            const { main } = require('"+SetupActionDestination+"/safe_output_handler_manager.cjs');\n")
-	steps = append(steps, "            await main();\n")
+	steps = append(steps, c.githubScriptWith(script, tokenInputs...))
 
 	return steps, nil
 }
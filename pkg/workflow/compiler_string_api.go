@@ -89,6 +89,14 @@ func (c *Compiler) ParseWorkflowString(content string, virtualPath string) (*Wor
 		return nil, err
 	}
 
+	// Expand needs-workflow into an on.workflow_run trigger
+	if err := c.preprocessNeedsWorkflow(result.Frontmatter, cleanPath); err != nil {
+		return nil, fmt.Errorf("%s: %w", cleanPath, err)
+	}
+
+	// Add the schedule and workflow_dispatch triggers that drain the active-hours queue
+	preprocessActiveHoursQueue(result.Frontmatter)
+
 	frontmatterForValidation := c.copyFrontmatterWithoutInternalMarkers(result.Frontmatter)
 
 	// Check if "on" field is missing - distinguish redirect-only placeholders from shared workflows
@@ -196,6 +204,7 @@ func (c *Compiler) ParseWorkflowString(content string, virtualPath string) (*Wor
 	workflowData.ActionPinWarnings = c.actionPinWarnings
 	workflowData.ActionPinMappings = c.getActionPinMappings()
 	workflowData.ContainerPinMappings = c.getContainerPinMappings()
+	applyMCPPackagePins(workflowData)
 
 	// Extract YAML configuration sections
 	if err := c.extractYAMLSections(parseResult.frontmatterResult.Frontmatter, workflowData); err != nil {
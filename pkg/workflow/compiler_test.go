@@ -852,6 +852,37 @@ func TestWriteWorkflowOutput_ContentUnchanged(t *testing.T) {
 	assert.Equal(t, initialModTime, finalModTime, "File should not be rewritten if content is unchanged")
 }
 
+// TestWriteWorkflowOutput_CheckLockFiles tests that check mode records missing and
+// outdated lock files without writing them
+func TestWriteWorkflowOutput_CheckLockFiles(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "check-test")
+	yamlContent := "name: test\non: push\njobs:\n  test:\n    runs-on: ubuntu-latest\n"
+
+	upToDate := filepath.Join(tmpDir, "up-to-date.lock.yml")
+	require.NoError(t, os.WriteFile(upToDate, []byte(yamlContent), 0644))
+	outdated := filepath.Join(tmpDir, "outdated.lock.yml")
+	require.NoError(t, os.WriteFile(outdated, []byte("name: old\n"), 0644))
+	missing := filepath.Join(tmpDir, "missing.lock.yml")
+
+	compiler := NewCompiler()
+	compiler.SetNoEmit(true)
+	compiler.SetCheckLockFiles(true)
+	compiler.SetQuiet(true)
+
+	for _, lockFile := range []string{upToDate, outdated, missing} {
+		markdownPath := strings.TrimSuffix(lockFile, ".lock.yml") + ".md"
+		require.NoError(t, compiler.writeWorkflowOutput(lockFile, yamlContent, markdownPath), "check mode should not fail on %s", lockFile)
+	}
+
+	assert.Equal(t, []string{outdated, missing}, compiler.GetStaleLockFiles(), "missing and outdated lock files should be reported")
+
+	content, err := os.ReadFile(outdated)
+	require.NoError(t, err, "outdated lock file should still exist")
+	assert.Equal(t, "name: old\n", string(content), "check mode should not rewrite lock files")
+	_, err = os.Stat(missing)
+	assert.True(t, os.IsNotExist(err), "check mode should not create lock files")
+}
+
 // TestCompileWorkflow_ConcurrentCompilation tests thread-safety of concurrent compilations
 func TestCompileWorkflow_ConcurrentCompilation(t *testing.T) {
 	const numWorkers = 10
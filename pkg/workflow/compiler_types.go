@@ -2,6 +2,7 @@ package workflow
 
 import (
 	"context"
+	"io/fs"
 	"os"
 
 	"github.com/github/gh-aw/pkg/logger"
@@ -63,7 +64,10 @@ type Compiler struct {
 	version                 string                   // Version of the extension
 	skipValidation          bool                     // If true, skip schema validation
 	noEmit                  bool                     // If true, validate without generating lock files
+	checkLockFiles          bool                     // If true (with noEmit), compare compiled output against existing lock files instead of writing them
+	staleLockFiles          []string                 // Lock files found missing or out of date in check mode
 	strictMode              bool                     // If true, enforce strict validation requirements
+	strictExplicit          bool                     // If true, refuse implicit defaults for security-relevant settings
 	allowActionRefs         bool                     // If true, unresolved action refs are warnings instead of errors
 	approve                 bool                     // If true, approve safe update changes (skip safe update enforcement)
 	forceStaged             bool                     // If true, force all safe-outputs into staged mode
@@ -78,6 +82,11 @@ type Compiler struct {
 	actionMode              ActionMode               // Mode for generating JavaScript steps (inline vs custom actions)
 	actionTag               string                   // Override action SHA or tag for actions/setup (when set, overrides actionMode to release)
 	actionsRepo             string                   // Override the external actions repository (default: github/gh-aw-actions)
+	handlerScriptMode       HandlerScriptMode        // How compiled steps load JavaScript handler scripts (default: action)
+	handlerScriptSources    fs.FS                    // Handler script sources used by the files and inline handler script modes
+	pendingHandlerScripts   map[string]string        // Handler scripts to write to .github/aw/scripts for the current workflow (files mode)
+	sharedActions           bool                     // If true, move github-script steps into shared composite actions under .github/actions/gh-aw
+	pendingSharedActions    map[string]*sharedAction // Shared actions to write to .github/actions/gh-aw for the current workflow
 	jobManager              *JobManager              // Manages jobs and dependencies
 	engineRegistry          *EngineRegistry          // Registry of available agentic engines
 	engineCatalog           *EngineCatalog           // Catalog of engine definitions backed by the registry
@@ -104,6 +113,7 @@ type Compiler struct {
 	inlinePrompt            bool                     // If true, inline markdown content in YAML instead of using runtime-import macros (for Wasm builds)
 	priorManifests          map[string]*GHAWManifest // Pre-cached manifests keyed by lock file path; takes precedence over git HEAD / filesystem reads
 	requireDocker           bool                     // If true, fail validation when Docker is not available instead of silently skipping
+	offline                 bool                     // If true, never use the network: action pins come from actions-lock.json or embedded pins only
 	ghesCompatFromCLI       bool                     // If true, GHES compat was requested via --ghes CLI flag (takes precedence over aw.json)
 	ghesArtifactCompat      bool                     // If true, GHES compatibility mode is enabled; artifact actions still use latest non-v3 pins
 	ownerTypeCache          map[string]string        // Cached GitHub owner type ("User"/"Organization"/"") keyed by owner login; not goroutine-safe (Compiler is used sequentially)
@@ -195,6 +205,18 @@ func (c *Compiler) SetNoEmit(noEmit bool) {
 	c.noEmit = noEmit
 }
 
+// SetCheckLockFiles configures whether to compare compiled output against existing
+// lock files instead of writing them. Lock files that are missing or differ are
+// recorded and returned by GetStaleLockFiles. Requires noEmit to be enabled.
+func (c *Compiler) SetCheckLockFiles(check bool) {
+	c.checkLockFiles = check
+}
+
+// GetStaleLockFiles returns the lock files found missing or out of date in check mode
+func (c *Compiler) GetStaleLockFiles() []string {
+	return c.staleLockFiles
+}
+
 // SetApprove configures whether to skip safe update enforcement via the CLI --approve flag.
 // When true, safe update enforcement is disabled regardless of strict mode setting,
 // approving all changes.
@@ -235,6 +257,12 @@ func (c *Compiler) SetStrictMode(strict bool) {
 	c.strictMode = strict
 }
 
+// SetStrictExplicit configures whether to refuse implicit defaults for
+// security-relevant settings (compile --strict-explicit)
+func (c *Compiler) SetStrictExplicit(strictExplicit bool) {
+	c.strictExplicit = strictExplicit
+}
+
 // SetAllowActionRefs configures whether unresolved action refs are warnings.
 // When false (default), unresolved action refs are compiler errors.
 func (c *Compiler) SetAllowActionRefs(allow bool) {
@@ -258,6 +286,21 @@ func (c *Compiler) SetForceRefreshActionPins(force bool) {
 	c.forceRefreshActionPins = force
 }
 
+// SetOffline configures offline mode. Offline compiles resolve action pins only from
+// actions-lock.json and the embedded pins, reuse cached action inputs, and skip
+// validations that query registries or the GitHub API.
+func (c *Compiler) SetOffline(offline bool) {
+	c.offline = offline
+	if c.actionResolver != nil {
+		c.actionResolver.SetOffline(offline)
+	}
+}
+
+// IsOffline reports whether offline mode is enabled
+func (c *Compiler) IsOffline() bool {
+	return c.offline
+}
+
 // SetActionMode configures the action mode for JavaScript step generation
 func (c *Compiler) SetActionMode(mode ActionMode) {
 	c.actionMode = mode
@@ -284,6 +327,19 @@ func (c *Compiler) SetActionsRepo(repo string) {
 	c.actionsRepo = repo
 }
 
+// SetHandlerScripts sets how compiled steps load JavaScript handler scripts and the
+// script sources used by the files and inline modes.
+func (c *Compiler) SetHandlerScripts(mode HandlerScriptMode, sources fs.FS) {
+	c.handlerScriptMode = mode
+	c.handlerScriptSources = sources
+}
+
+// SetSharedActions configures whether github-script steps are moved into shared composite
+// actions under .github/actions/gh-aw that every lock file references.
+func (c *Compiler) SetSharedActions(enabled bool) {
+	c.sharedActions = enabled
+}
+
 // effectiveActionsRepo returns the actions repository to use for action mode references.
 // Returns the override if set, otherwise returns the default GitHubActionsOrgRepo constant.
 func (c *Compiler) effectiveActionsRepo() string {
@@ -411,6 +467,7 @@ func (c *Compiler) getSharedActionResolver() (*ActionCache, *ActionResolver) {
 		}
 
 		c.actionResolver = NewActionResolver(c.actionCache)
+		c.actionResolver.SetOffline(c.offline)
 		logTypes.Print("Initialized shared action cache and resolver for compiler")
 	} else if c.forceRefreshActionPins && !c.actionCacheCleared {
 		// If cache already exists but force refresh is set and we haven't cleared it yet, clear it once
@@ -59,10 +59,9 @@ func (c *Compiler) buildUnlockJob(data *WorkflowData, threatDetectionEnabled boo
 	steps = append(steps, "      - name: Unlock issue after agentic workflow\n")
 	steps = append(steps, "        id: unlock-issue\n")
 	steps = append(steps, fmt.Sprintf("        if: %s\n", RenderCondition(unlockCondition)))
-	steps = append(steps, fmt.Sprintf("        uses: %s\n", getCachedActionPin("actions/github-script", data)))
-	steps = append(steps, "        with:\n")
-	steps = append(steps, "          script: |\n")
-	steps = append(steps, generateGitHubScriptWithRequire("unlock-issue.cjs"))
+	script := generateGitHubScriptWithRequire("unlock-issue.cjs")
+	steps = append(steps, fmt.Sprintf("        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", data), script)))
+	steps = append(steps, c.githubScriptWith(script))
 
 	compilerUnlockJobLog.Print("Added unlock issue step to dedicated unlock job")
 
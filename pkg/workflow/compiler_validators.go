@@ -10,6 +10,7 @@ import (
 
 	"github.com/github/gh-aw/pkg/console"
 	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/parser"
 )
 
 // validateExpressions checks expression safety and runtime-import file references
@@ -38,6 +39,10 @@ func (c *Compiler) validateExpressions(workflowData *WorkflowData, markdownPath
 		}
 	}
 
+	// Check markdown expressions against the configured triggers and flag
+	// injection-prone contexts. These are advisory and never fail compilation.
+	c.validateMarkdownExpressionUsage(workflowData, markdownPath)
+
 	// Validate expressions in runtime-import files at compile time
 	if strings.Contains(workflowData.MarkdownContent, "{{#runtime-import") {
 		workflowLog.Printf("Validating runtime-import files")
@@ -62,6 +67,20 @@ func (c *Compiler) validateExpressions(workflowData *WorkflowData, markdownPath
 	// of the recommended /tmp/gh-aw/agent/ subtree.
 	c.validatePromptTmpPaths(workflowData, markdownPath)
 
+	// Warn when the workflow body and imports alone exceed prompt.max-tokens.
+	c.validatePromptBudget(workflowData, markdownPath)
+
+	// Check the assembled prompt for long lines, broken links, duplicate headings
+	// and leftover TODO markers. Rules configured as errors fail compilation.
+	if err := c.validatePromptLint(workflowData, markdownPath); err != nil {
+		return err
+	}
+
+	// Check that route: targets exist so label routing cannot silently drop the prompt.
+	if err := c.validatePromptRouting(workflowData, markdownPath); err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error(), err)
+	}
+
 	return nil
 }
 
@@ -107,6 +126,29 @@ func (c *Compiler) validatePromptTmpPaths(workflowData *WorkflowData, markdownPa
 	}
 }
 
+// validateMarkdownExpressionUsage emits a warning, with the source line, for each
+// markdown expression that no configured trigger populates or that interpolates
+// untrusted user input into the prompt.
+func (c *Compiler) validateMarkdownExpressionUsage(workflowData *WorkflowData, markdownPath string) {
+	if !strings.Contains(workflowData.MarkdownContent, "${{") {
+		return
+	}
+	content, err := parser.ReadFile(markdownPath)
+	if err != nil {
+		expressionValidationLog.Printf("Skipping markdown expression usage checks: %v", err)
+		return
+	}
+	issues, err := parser.ValidateMarkdownExpressions(string(content))
+	if err != nil {
+		expressionValidationLog.Printf("Skipping markdown expression usage checks: %v", err)
+		return
+	}
+	for _, issue := range issues {
+		fmt.Fprint(os.Stderr, issue.FormatWarning(markdownPath))
+		c.IncrementWarningCount()
+	}
+}
+
 // validateFeatureConfig validates feature flags declared in the workflow frontmatter
 // and applies any action-mode override specified via the "action-mode" feature flag.
 func (c *Compiler) validateFeatureConfig(workflowData *WorkflowData, markdownPath string) error {
@@ -166,7 +208,9 @@ func (c *Compiler) validateCoreToolConfiguration(workflowData *WorkflowData, mar
 	}{
 		{logMessage: "Validating sandbox configuration", validateFn: func() error { return validateSandboxConfig(workflowData) }},
 		{logMessage: "Validating safe-outputs target fields", validateFn: func() error { return validateSafeOutputsTarget(workflowData.SafeOutputs) }},
+		{logMessage: "Validating safe-outputs target-repo patterns", validateFn: func() error { return validateSafeOutputsTargetRepo(workflowData.SafeOutputs) }},
 		{logMessage: "Validating safe-outputs max fields", validateFn: func() error { return validateSafeOutputsMax(workflowData.SafeOutputs) }},
+		{logMessage: "Validating safe-outputs priority", validateFn: func() error { return validateSafeOutputsPriority(workflowData.SafeOutputs) }},
 		{logMessage: "Validating safe-outputs samples entries against MCP tool schemas", validateFn: func() error { return validateSafeOutputsSamples(workflowData.SafeOutputs) }},
 		{logMessage: "Validating safe-outputs urls policy", validateFn: func() error { return validateSafeOutputsURLs(workflowData.SafeOutputs) }},
 		{logMessage: "Validating safe-outputs allowed-domains", validateFn: func() error { return c.validateSafeOutputsAllowedDomains(workflowData.SafeOutputs) }},
@@ -174,6 +218,10 @@ func (c *Compiler) validateCoreToolConfiguration(workflowData *WorkflowData, mar
 		{logMessage: "Validating safe-outputs needs declarations", validateFn: func() error { return validateSafeOutputsNeeds(workflowData) }},
 		{logMessage: "Validating on.needs declarations", validateFn: func() error { return c.validateOnNeeds(workflowData) }},
 		{logMessage: "Validating safe-job needs declarations", validateFn: func() error { return validateSafeJobNeeds(workflowData) }},
+		{logMessage: "Validating step and skip-if conditions", validateFn: func() error { return validateStepConditions(workflowData) }},
+		{logMessage: "Validating secret-masking patterns", validateFn: func() error { return validateSecretMaskingPatterns(workflowData.SecretMasking) }},
+		{logMessage: "Validating safe-outputs create-issue dedupe", validateFn: func() error { return validateCreateIssueDedupe(workflowData.SafeOutputs) }},
+		{logMessage: "Loading safe-outputs create-issue form", validateFn: func() error { return loadCreateIssueForm(workflowData.SafeOutputs, markdownPath) }},
 		{logMessage: "Validating safe-outputs allowed-labels glob scope", validateFn: func() error { return c.validateSafeOutputsAllowedLabelsGlobScope(workflowData.SafeOutputs) }},
 		{logMessage: "Validating network allowed domains", validateFn: func() error { return c.validateNetworkAllowedDomains(workflowData.NetworkPermissions) }},
 		{logMessage: "Validating network firewall configuration", validateFn: func() error { return validateNetworkFirewallConfig(workflowData.NetworkPermissions) }},
@@ -184,6 +232,8 @@ func (c *Compiler) validateCoreToolConfiguration(workflowData *WorkflowData, mar
 		{logMessage: "Validating max-daily-ai-credits frontmatter", validateFn: func() error { return validateMaxDailyAICFrontmatter(workflowData) }},
 		{logMessage: "Validating private-to-public-flows string value", validateFn: func() error { return validatePrivateToPublicFlowsStringValue(workflowData) }},
 		{logMessage: "Validating private-to-public-flows server IDs", validateFn: func() error { return validatePrivateToPublicFlowsServerIDs(workflowData) }},
+		{logMessage: "Validating same-repo-worktree checkouts", validateFn: func() error { return validateSameRepoWorktreeSources(workflowData.CheckoutConfigs) }},
+		{logMessage: "Validating strict mode explicit defaults", validateFn: func() error { return c.validateStrictExplicitDefaults(workflowData) }},
 	}
 	// This validation is intentionally outside the table below because strict mode
 	// turns the same validation result into either an error or a warning.
@@ -389,6 +439,7 @@ func (c *Compiler) validateResourcesAndDispatches(workflowData *WorkflowData, ma
 		{logMessage: "Validating dispatch-workflow configuration", errPrefix: "dispatch-workflow validation failed: ", validateFn: c.validateDispatchWorkflow},
 		{logMessage: "Validating dispatch_repository configuration", errPrefix: "dispatch_repository validation failed: ", validateFn: c.validateDispatchRepository},
 		{logMessage: "Validating call-workflow configuration", errPrefix: "call-workflow validation failed: ", validateFn: c.validateCallWorkflow},
+		{logMessage: "Validating safe-outputs cloud configuration", errPrefix: "safe-outputs cloud validation failed: ", validateFn: c.validateSafeOutputsCloud},
 	}
 	for _, validator := range dispatchValidators {
 		workflowLog.Print(validator.logMessage)
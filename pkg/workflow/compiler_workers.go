@@ -0,0 +1,99 @@
+package workflow
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var compilerWorkersLog = logger.New("workflow:compiler_workers")
+
+// MergeWorkerCompilers folds the state accumulated by worker compilers back into c.
+//
+// Compiler is not goroutine-safe, so parallel compilation gives each worker its own
+// instance. Call this once every worker has finished: warnings, check-mode results and
+// action cache changes are then reported, pruned and saved through c exactly as if c had
+// compiled every workflow itself.
+func (c *Compiler) MergeWorkerCompilers(workers []*Compiler) {
+	cache, resolver := c.getSharedActionResolver()
+	base := cache.snapshot()
+
+	for _, worker := range workers {
+		if worker == nil || worker == c {
+			continue
+		}
+		c.warningCount += worker.warningCount
+		c.scheduleWarnings = append(c.scheduleWarnings, worker.scheduleWarnings...)
+		for _, warning := range worker.safeUpdateWarnings {
+			c.AddSafeUpdateWarning(warning)
+		}
+		c.staleLockFiles = append(c.staleLockFiles, worker.staleLockFiles...)
+
+		if worker.actionCache != nil {
+			cache.mergeChanges(base, worker.actionCache)
+		}
+		if worker.actionResolver != nil {
+			for key := range worker.actionResolver.usedCacheKeys {
+				resolver.usedCacheKeys[key] = struct{}{}
+			}
+		}
+	}
+
+	// Workers finish in arbitrary order; keep check-mode output stable.
+	slices.Sort(c.staleLockFiles)
+	compilerWorkersLog.Printf("Merged %d worker compilers: warnings=%d, stale=%d, cacheDirty=%t",
+		len(workers), c.warningCount, len(c.staleLockFiles), cache.dirty)
+}
+
+// actionCacheSnapshot is a copy of the action cache maps taken before workers run,
+// used to tell which entries a worker added, updated or deleted.
+type actionCacheSnapshot struct {
+	entries       map[string]ActionCacheEntry
+	containerPins map[string]ContainerPin
+	mcpPins       map[string]MCPPackagePin
+}
+
+// snapshot copies the cache maps so later changes can be detected.
+func (c *ActionCache) snapshot() actionCacheSnapshot {
+	return actionCacheSnapshot{
+		entries:       maps.Clone(c.Entries),
+		containerPins: maps.Clone(c.ContainerPins),
+		mcpPins:       maps.Clone(c.MCPPins),
+	}
+}
+
+// mergeChanges applies the changes other made relative to base. Entries other left
+// untouched are skipped so they cannot overwrite updates merged from another worker.
+func (c *ActionCache) mergeChanges(base actionCacheSnapshot, other *ActionCache) {
+	changed := mergeChangedCacheEntries(c.Entries, base.entries, other.Entries)
+	changed = mergeChangedCacheEntries(c.ContainerPins, base.containerPins, other.ContainerPins) || changed
+	changed = mergeChangedCacheEntries(c.MCPPins, base.mcpPins, other.MCPPins) || changed
+	if changed {
+		c.dirty = true
+	}
+}
+
+// mergeChangedCacheEntries copies the keys src added or changed relative to base into dst
+// and removes the keys src deleted. It reports whether dst was modified.
+func mergeChangedCacheEntries[V any](dst, base, src map[string]V) bool {
+	changed := false
+	for key, value := range src {
+		if baseValue, ok := base[key]; ok && reflect.DeepEqual(baseValue, value) {
+			continue
+		}
+		dst[key] = value
+		changed = true
+	}
+	for key := range base {
+		if _, kept := src[key]; kept {
+			continue
+		}
+		if _, exists := dst[key]; exists {
+			delete(dst, key)
+			changed = true
+		}
+	}
+	return changed
+}
@@ -0,0 +1,50 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeWorkerCompilers(t *testing.T) {
+	dir := t.TempDir()
+	newCompilerWithCache := func() *Compiler {
+		c := NewCompiler()
+		c.gitRoot = dir
+		cache := c.GetSharedActionCache()
+		cache.Entries["actions/checkout@v5"] = ActionCacheEntry{Repo: "actions/checkout", Version: "v5", SHA: "old"}
+		cache.Entries["actions/cache@v4"] = ActionCacheEntry{Repo: "actions/cache", Version: "v4", SHA: "cache"}
+		cache.dirty = false
+		return c
+	}
+
+	primary := newCompilerWithCache()
+	updated := newCompilerWithCache()
+	untouched := newCompilerWithCache()
+
+	updated.IncrementWarningCount()
+	updated.scheduleWarnings = []string{"schedule"}
+	updated.AddSafeUpdateWarning("new secret")
+	updated.staleLockFiles = []string{"b.lock.yml"}
+	updated.GetSharedActionCache().Set("actions/checkout", "v5", "new")
+	updated.GetSharedActionCache().DeleteByKey("actions/cache@v4")
+	updated.GetSharedActionResolver().MarkCacheKeyAsUsed("actions/checkout@v5")
+
+	untouched.IncrementWarningCount()
+	untouched.staleLockFiles = []string{"a.lock.yml"}
+
+	primary.MergeWorkerCompilers([]*Compiler{updated, untouched})
+
+	assert.Equal(t, 2, primary.GetWarningCount(), "warnings should be summed")
+	assert.Equal(t, []string{"schedule"}, primary.GetScheduleWarnings(), "schedule warnings should be collected")
+	assert.Equal(t, []string{"new secret"}, primary.GetSafeUpdateWarnings(), "safe update warnings should be collected")
+	assert.Equal(t, []string{"a.lock.yml", "b.lock.yml"}, primary.GetStaleLockFiles(), "stale lock files should be sorted")
+
+	cache := primary.GetSharedActionCache()
+	assert.Equal(t, "new", cache.Entries["actions/checkout@v5"].SHA, "an untouched worker entry must not overwrite an update")
+	assert.NotContains(t, cache.Entries, "actions/cache@v4", "worker deletions should be applied")
+	assert.True(t, cache.dirty, "merged changes should be saved")
+	assert.Contains(t, primary.GetSharedActionResolver().GetUsedCacheKeys(), "actions/checkout@v5", "used keys drive orphan pruning")
+}
@@ -19,6 +19,7 @@ func (c *Compiler) buildJobsAndValidate(data *WorkflowData, markdownPath string)
 
 	// Reset job manager for this compilation
 	c.jobManager = NewJobManager()
+	c.pendingSharedActions = nil
 
 	// Build all jobs
 	if err := c.buildJobs(data, markdownPath); err != nil {
@@ -38,6 +39,11 @@ func (c *Compiler) buildJobsAndValidate(data *WorkflowData, markdownPath string)
 		return fmt.Errorf("duplicate step validation failed: %w", err)
 	}
 
+	// Make the shared actions referenced by handler steps available to their jobs
+	if err := c.placeSharedActionSteps(data); err != nil {
+		return fmt.Errorf("failed to place shared action steps: %w", err)
+	}
+
 	return nil
 }
 
@@ -195,6 +201,16 @@ func (c *Compiler) generateYAML(data *WorkflowData, markdownPath string) (string
 		}
 	}
 
+	// Inline or record the handler scripts required by the compiled steps
+	bodyContent, err := c.applyHandlerScriptMode(bodyContent)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	if markdownPath != "" {
+		data.LockProvenance = c.buildLockProvenance(data, markdownPath, actions)
+	}
+
 	// Generate workflow header comments (including metadata as first line, plus secrets/actions lists)
 	c.generateWorkflowHeader(&yaml, data, frontmatterHash, bodyHash, secrets, actions)
 
@@ -22,6 +22,11 @@ func (c *Compiler) generateEngineExecutionSteps(yaml *strings.Builder, data *Wor
 	compilerYamlLog.Printf("Generating engine execution steps: engine=%s, steps=%d", engine.GetID(), len(steps))
 
 	for _, step := range steps {
+		// With an engine fallback chain, a failed primary engine must not stop the job
+		// before the fallback engines get a chance to run.
+		if hasEngineFallbacks(data) && isAgenticExecutionStep(step) {
+			step = withContinueOnError(step)
+		}
 		for _, line := range step {
 			yaml.WriteString(line)
 			yaml.WriteByte('\n')
@@ -52,7 +57,9 @@ func (c *Compiler) generateLogParsing(yaml *strings.Builder, data *WorkflowData,
 
 	yaml.WriteString("      - name: Parse agent logs for step summary\n")
 	yaml.WriteString("        if: always()\n")
-	fmt.Fprintf(yaml, "        uses: %s\n", getCachedActionPin("actions/github-script", data))
+	// Load log parser script from external file using require()
+	script := generateGitHubScriptWithRequire(parserScriptName + ".cjs")
+	fmt.Fprintf(yaml, "        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", data), script))
 	yaml.WriteString("        env:\n")
 	fmt.Fprintf(yaml, "          GH_AW_AGENT_OUTPUT: %s\n", logFileForParsing)
 	// GH_AW_SAFE_OUTPUTS lets the log parser detect safe-output entries written by the agent
@@ -63,15 +70,7 @@ func (c *Compiler) generateLogParsing(yaml *strings.Builder, data *WorkflowData,
 	if data.SafeOutputs != nil {
 		yaml.WriteString("          GH_AW_SAFE_OUTPUTS: ${{ steps.set-runtime-paths.outputs.GH_AW_SAFE_OUTPUTS }}\n")
 	}
-	yaml.WriteString("        with:\n")
-	yaml.WriteString("          script: |\n")
-
-	// Use the setup_globals helper to store GitHub Actions objects in global scope
-	yaml.WriteString("            const { setupGlobals } = require('" + SetupActionDestination + "/setup_globals.cjs');\n")
-	yaml.WriteString("            setupGlobals(core, github, context, exec, io, getOctokit);\n")
-	// Load log parser script from external file using require()
-	yaml.WriteString("            const { main } = require('${{ runner.temp }}/gh-aw/actions/" + parserScriptName + ".cjs');\n")
-	yaml.WriteString("            await main();\n")
+	yaml.WriteString(c.githubScriptWith(script))
 }
 
 // generateMCPScriptsLogParsing generates a step that parses mcp-scripts logs and adds them to the step summary
@@ -80,16 +79,10 @@ func (c *Compiler) generateMCPScriptsLogParsing(yaml *strings.Builder, data *Wor
 
 	yaml.WriteString("      - name: Parse MCP Scripts logs for step summary\n")
 	yaml.WriteString("        if: always()\n")
-	fmt.Fprintf(yaml, "        uses: %s\n", getCachedActionPin("actions/github-script", data))
-	yaml.WriteString("        with:\n")
-	yaml.WriteString("          script: |\n")
-
-	// Use the setup_globals helper to store GitHub Actions objects in global scope
-	yaml.WriteString("            const { setupGlobals } = require('" + SetupActionDestination + "/setup_globals.cjs');\n")
-	yaml.WriteString("            setupGlobals(core, github, context, exec, io, getOctokit);\n")
 	// Load mcp-scripts log parser script from external file using require()
-	yaml.WriteString("            const { main } = require('${{ runner.temp }}/gh-aw/actions/parse_mcp_scripts_logs.cjs');\n")
-	yaml.WriteString("            await main();\n")
+	script := generateGitHubScriptWithRequire("parse_mcp_scripts_logs.cjs")
+	fmt.Fprintf(yaml, "        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", data), script))
+	yaml.WriteString(c.githubScriptWith(script))
 }
 
 // generateMCPGatewayLogParsing generates a step that parses MCP gateway logs and adds them to the step summary
@@ -99,16 +92,24 @@ func (c *Compiler) generateMCPGatewayLogParsing(yaml *strings.Builder, data *Wor
 	yaml.WriteString("      - name: Parse MCP Gateway logs for step summary\n")
 	yaml.WriteString("        if: always()\n")
 	fmt.Fprintf(yaml, "        id: %s\n", constants.ParseMCPGatewayStepID)
-	fmt.Fprintf(yaml, "        uses: %s\n", getCachedActionPin("actions/github-script", data))
-	yaml.WriteString("        with:\n")
-	yaml.WriteString("          script: |\n")
-
-	// Use the setup_globals helper to store GitHub Actions objects in global scope
-	yaml.WriteString("            const { setupGlobals } = require('" + SetupActionDestination + "/setup_globals.cjs');\n")
-	yaml.WriteString("            setupGlobals(core, github, context, exec, io, getOctokit);\n")
 	// Load MCP gateway log parser script from external file using require()
-	yaml.WriteString("            const { main } = require('${{ runner.temp }}/gh-aw/actions/parse_mcp_gateway_log.cjs');\n")
-	yaml.WriteString("            await main();\n")
+	script := generateGitHubScriptWithRequire("parse_mcp_gateway_log.cjs")
+	fmt.Fprintf(yaml, "        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", data), script))
+	yaml.WriteString(c.githubScriptWith(script))
+}
+
+// generateRunMetricsSummary generates a step that writes the run metrics section (turns, tokens,
+// cost, tool calls, safe outputs, firewall stats) to the step summary. The markdown matches
+// the output of `gh aw audit --format markdown` for the same run.
+func (c *Compiler) generateRunMetricsSummary(yaml *strings.Builder, data *WorkflowData) {
+	compilerYamlLog.Print("Generating run metrics step summary")
+
+	yaml.WriteString("      - name: Generate run metrics summary\n")
+	yaml.WriteString("        if: always()\n")
+	yaml.WriteString("        continue-on-error: true\n")
+	script := generateGitHubScriptWithRequire("generate_run_metrics_summary.cjs")
+	fmt.Fprintf(yaml, "        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", data), script))
+	yaml.WriteString(c.githubScriptWith(script))
 }
 
 // generateObservabilitySummary generates a step that synthesizes a compact
@@ -123,13 +124,12 @@ func (c *Compiler) generateObservabilitySummary(yaml *strings.Builder, data *Wor
 
 	yaml.WriteString("      - name: Generate observability summary\n")
 	yaml.WriteString("        if: always()\n")
-	fmt.Fprintf(yaml, "        uses: %s\n", getCachedActionPin("actions/github-script", data))
-	yaml.WriteString("        with:\n")
-	yaml.WriteString("          script: |\n")
-	yaml.WriteString("            const { setupGlobals } = require('" + SetupActionDestination + "/setup_globals.cjs');\n")
-	yaml.WriteString("            setupGlobals(core, github, context, exec, io, getOctokit);\n")
-	yaml.WriteString("            const { main } = require('${{ runner.temp }}/gh-aw/actions/generate_observability_summary.cjs');\n")
-	yaml.WriteString("            await main(core);\n")
+	script := "            const { setupGlobals } = require('" + SetupActionDestination + "/setup_globals.cjs');\n" +
+		"            setupGlobals(core, github, context, exec, io, getOctokit);\n" +
+		"            const { main } = require('" + SetupActionDestination + "/generate_observability_summary.cjs');\n" +
+		"            await main(core);\n"
+	fmt.Fprintf(yaml, "        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", data), script))
+	yaml.WriteString(c.githubScriptWith(script))
 }
 
 // isOTLPEnabled returns true when OTLP has been configured in the workflow (including
@@ -202,13 +202,9 @@ func (c *Compiler) generateTokenUsageSummary(yaml *strings.Builder, data *Workfl
 	yaml.WriteString("      - name: Parse token usage for step summary\n")
 	yaml.WriteString("        if: always()\n")
 	yaml.WriteString("        continue-on-error: true\n")
-	fmt.Fprintf(yaml, "        uses: %s\n", getCachedActionPin("actions/github-script", data))
-	yaml.WriteString("        with:\n")
-	yaml.WriteString("          script: |\n")
-	yaml.WriteString("            const { setupGlobals } = require('" + SetupActionDestination + "/setup_globals.cjs');\n")
-	yaml.WriteString("            setupGlobals(core, github, context, exec, io, getOctokit);\n")
-	yaml.WriteString("            const { main } = require('" + SetupActionDestination + "/parse_token_usage.cjs');\n")
-	yaml.WriteString("            await main();\n")
+	script := generateGitHubScriptWithRequire("parse_token_usage.cjs")
+	fmt.Fprintf(yaml, "        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", data), script))
+	yaml.WriteString(c.githubScriptWith(script))
 }
 
 // generateAWFReflectSummary generates a step that reads the AWF /reflect payload
@@ -224,13 +220,9 @@ func (c *Compiler) generateAWFReflectSummary(yaml *strings.Builder, data *Workfl
 	yaml.WriteString("      - name: Print AWF reflect summary\n")
 	yaml.WriteString("        if: always()\n")
 	yaml.WriteString("        continue-on-error: true\n")
-	fmt.Fprintf(yaml, "        uses: %s\n", getCachedActionPin("actions/github-script", data))
-	yaml.WriteString("        with:\n")
-	yaml.WriteString("          script: |\n")
-	yaml.WriteString("            const { setupGlobals } = require('" + SetupActionDestination + "/setup_globals.cjs');\n")
-	yaml.WriteString("            setupGlobals(core, github, context, exec, io, getOctokit);\n")
-	yaml.WriteString("            const { main } = require('" + SetupActionDestination + "/awf_reflect_summary.cjs');\n")
-	yaml.WriteString("            await main();\n")
+	script := generateGitHubScriptWithRequire("awf_reflect_summary.cjs")
+	fmt.Fprintf(yaml, "        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", data), script))
+	yaml.WriteString(c.githubScriptWith(script))
 }
 
 // generateDetectAgentErrorsStep emits a host-runner step that runs the engine's error detection
@@ -309,6 +301,9 @@ func (c *Compiler) generateEngineInstallAndPreAgentSteps(yaml *strings.Builder,
 		}
 	}
 
+	// Record runner image, tool cache and engine CLI versions once all tooling is installed.
+	c.generateRunnerEnvironmentCaptureStep(yaml, data, engine)
+
 	// GH_AW_SAFE_OUTPUTS is now set at job level, no setup step needed
 
 	// Mint the GitHub MCP App token directly in the agent job.
@@ -450,6 +445,12 @@ func (c *Compiler) generateAgentRunSteps(yaml *strings.Builder, data *WorkflowDa
 	// detection script via GetErrorDetectionScriptId will emit this step.
 	c.generateDetectAgentErrorsStep(yaml, data, engine)
 
+	// Run fallback engines (engine: [copilot, claude]) when the primary engine failed
+	// with a rate-limit, authentication, or provider-unavailable error.
+	if err := c.generateEngineFallbackSteps(yaml, data, logFileFull); err != nil {
+		return nil, "", err
+	}
+
 	// Mark that we've completed agent execution - step order validation starts from here
 	compilerYamlLog.Print("Marking agent execution as complete for step order tracking")
 	c.stepOrderTracker.MarkAgentExecutionComplete()
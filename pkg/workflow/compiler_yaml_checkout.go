@@ -118,7 +118,8 @@ func (c *Compiler) generateInitialAndCheckoutSteps(yaml *strings.Builder, data *
 	if needsGithubMerge {
 		compilerYamlLog.Printf("Adding merge remote .github folder step")
 		yaml.WriteString("      - name: Merge remote .github folder\n")
-		fmt.Fprintf(yaml, "        uses: %s\n", getCachedActionPin("actions/github-script", data))
+		script := generateGitHubScriptWithRequire("merge_remote_agent_github_folder.cjs")
+		fmt.Fprintf(yaml, "        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", data), script))
 		yaml.WriteString("        env:\n")
 
 		// Set repository imports if present
@@ -137,12 +138,7 @@ func (c *Compiler) generateInitialAndCheckoutSteps(yaml *strings.Builder, data *
 			writeYAMLEnv(yaml, "          ", "GH_AW_AGENT_IMPORT_SPEC", data.AgentImportSpec)
 		}
 
-		yaml.WriteString("        with:\n")
-		yaml.WriteString("          script: |\n")
-		yaml.WriteString("            const { setupGlobals } = require('${{ runner.temp }}/gh-aw/actions/setup_globals.cjs');\n")
-		yaml.WriteString("            setupGlobals(core, github, context, exec, io, getOctokit);\n")
-		yaml.WriteString("            const { main } = require('${{ runner.temp }}/gh-aw/actions/merge_remote_agent_github_folder.cjs');\n")
-		yaml.WriteString("            await main();\n")
+		yaml.WriteString(c.githubScriptWith(script))
 	}
 
 	return checkoutMgr, needsCheckout, nil
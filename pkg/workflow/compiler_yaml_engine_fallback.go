@@ -0,0 +1,218 @@
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var engineFallbackLog = logger.New("workflow:compiler_yaml_engine_fallback")
+
+// engineFallbackSupportedEngines lists the engines that may appear after the primary
+// engine in an engine fallback chain. A fallback engine reuses the MCP gateway started
+// for the primary engine, so it must be configurable by re-running its gateway config
+// converter alone.
+var engineFallbackSupportedEngines = []string{"claude", "copilot"}
+
+const (
+	// agenticExecutionStepID is the step ID used by every engine's main execution step.
+	agenticExecutionStepID = "agentic_execution"
+
+	// engineFallbackCheckStepIDPrefix prefixes the step that decides whether fallback n runs.
+	engineFallbackCheckStepIDPrefix = "engine_fallback_check_"
+)
+
+var stepIDLinePattern = regexp.MustCompile(`^        id: (\S+)$`)
+
+// hasEngineFallbacks reports whether the workflow declares an engine fallback chain
+// that should be compiled into the agent job.
+func hasEngineFallbacks(data *WorkflowData) bool {
+	return data != nil && !data.UseSamples && data.EngineConfig != nil && len(data.EngineConfig.Fallbacks) > 0
+}
+
+// engineFallbackStepID returns the ID of the execution step for fallback n (1-based).
+// Fallback 0 is the primary engine.
+func engineFallbackStepID(n int) string {
+	if n == 0 {
+		return agenticExecutionStepID
+	}
+	return fmt.Sprintf("%s_fallback_%d", agenticExecutionStepID, n)
+}
+
+// isAgenticExecutionStep reports whether step is an engine's main execution step.
+func isAgenticExecutionStep(step GitHubActionStep) bool {
+	return slices.Contains(step, "        id: "+agenticExecutionStepID)
+}
+
+// withContinueOnError returns a copy of step with continue-on-error: true inserted
+// after the step name so a failure does not stop the job before the fallback runs.
+func withContinueOnError(step GitHubActionStep) GitHubActionStep {
+	if len(step) == 0 || slices.Contains(step, "        continue-on-error: true") {
+		return step
+	}
+	result := make(GitHubActionStep, 0, len(step)+1)
+	result = append(result, step[0], "        continue-on-error: true")
+	return append(result, step[1:]...)
+}
+
+// generateEngineFallbackSteps emits the steps that run each fallback engine of an
+// engine fallback chain (engine: [copilot, claude]) after the primary engine step.
+//
+// For each fallback engine the compiler emits:
+//   - a check step that runs only when the previous engine step failed and classifies
+//     the failure from the agent log (rate-limit, authentication, or provider unavailable)
+//   - the fallback engine's installation steps
+//   - an MCP config conversion step for the fallback engine (when MCP servers are configured)
+//   - the fallback engine's execution steps, with the same prompt and log file
+//
+// All fallback steps are gated on the check step's fallback output. A final step fails
+// the job when the primary engine failed and no fallback engine succeeded, since every
+// engine execution step runs with continue-on-error.
+func (c *Compiler) generateEngineFallbackSteps(yaml *strings.Builder, data *WorkflowData, logFile string) error {
+	if !hasEngineFallbacks(data) {
+		return nil
+	}
+
+	fallbacks := data.EngineConfig.Fallbacks
+	engineFallbackLog.Printf("Generating engine fallback steps: primary=%s, fallbacks=%v", data.EngineConfig.ID, fallbacks)
+
+	for i, fallbackID := range fallbacks {
+		n := i + 1
+		engine, err := c.getAgenticEngine(fallbackID)
+		if err != nil {
+			return fmt.Errorf("failed to resolve fallback engine %q: %w", fallbackID, err)
+		}
+		fallbackData := buildEngineFallbackWorkflowData(data, fallbackID)
+		checkStepID := fmt.Sprintf("%s%d", engineFallbackCheckStepIDPrefix, n)
+
+		fmt.Fprintf(yaml, "      - name: Check engine fallback to %s\n", engine.GetDisplayName())
+		fmt.Fprintf(yaml, "        id: %s\n", checkStepID)
+		fmt.Fprintf(yaml, "        if: always() && steps.%s.outcome == 'failure'\n", engineFallbackStepID(n-1))
+		yaml.WriteString("        env:\n")
+		fmt.Fprintf(yaml, "          GH_AW_FALLBACK_ENGINE: %s\n", fallbackID)
+		yaml.WriteString("        run: node \"${RUNNER_TEMP}/gh-aw/actions/check_engine_fallback.cjs\"\n")
+
+		var steps []GitHubActionStep
+		steps = append(steps, engine.GetInstallationSteps(fallbackData)...)
+		if HasMCPServers(data) {
+			steps = append(steps, buildEngineFallbackMCPConfigStep(data, fallbackID))
+		}
+		for _, step := range engine.GetExecutionSteps(fallbackData, logFile) {
+			if isAgenticExecutionStep(step) {
+				step = withContinueOnError(step)
+			}
+			steps = append(steps, step)
+		}
+
+		condition := fmt.Sprintf("steps.%s.outputs.fallback == 'true'", checkStepID)
+		for _, step := range rewriteEngineFallbackSteps(steps, n, engine.GetDisplayName(), condition) {
+			for _, line := range step {
+				yaml.WriteString(line)
+				yaml.WriteByte('\n')
+			}
+		}
+	}
+
+	failureConditions := []string{fmt.Sprintf("steps.%s.outcome == 'failure'", agenticExecutionStepID)}
+	for n := 1; n <= len(fallbacks); n++ {
+		failureConditions = append(failureConditions, fmt.Sprintf("steps.%s.outcome != 'success'", engineFallbackStepID(n)))
+	}
+	yaml.WriteString("      - name: Fail when all engines failed\n")
+	fmt.Fprintf(yaml, "        if: %s\n", strings.Join(failureConditions, " && "))
+	yaml.WriteString("        run: |\n")
+	fmt.Fprintf(yaml, "          echo \"::error::The agentic engine failed and no fallback engine (%s) completed successfully.\"\n", strings.Join(fallbacks, ", "))
+	yaml.WriteString("          exit 1\n")
+	return nil
+}
+
+// buildEngineFallbackWorkflowData returns a shallow copy of data configured for the
+// fallback engine. The primary engine's model is not carried over because model names
+// are engine-specific, and the firewall domain cache is reset so the fallback engine's
+// API domains are allowed.
+func buildEngineFallbackWorkflowData(data *WorkflowData, engineID string) *WorkflowData {
+	fallbackData := *data
+	fallbackData.AI = engineID
+	fallbackData.Model = ""
+	fallbackData.CachedAllowedDomainsComputed = false
+	fallbackData.CachedAllowedDomainsStr = ""
+	engineConfig := EngineConfig{}
+	if data.EngineConfig != nil {
+		engineConfig = *data.EngineConfig
+	}
+	engineConfig.ID = engineID
+	engineConfig.Version = ""
+	engineConfig.Fallbacks = nil
+	fallbackData.EngineConfig = &engineConfig
+	return &fallbackData
+}
+
+// buildEngineFallbackMCPConfigStep converts the running MCP gateway's configuration into
+// the fallback engine's MCP config format. The primary engine's conversion runs inside
+// start_mcp_gateway.sh; the fallback engine needs its own config file.
+func buildEngineFallbackMCPConfigStep(data *WorkflowData, engineID string) GitHubActionStep {
+	step := GitHubActionStep{
+		"      - name: Configure MCP servers",
+		"        env:",
+		"          MCP_GATEWAY_OUTPUT: /tmp/gh-aw/mcp-config/gateway-output.json",
+		"          MCP_GATEWAY_DOMAIN: ${{ steps.start-mcp-gateway.outputs.gateway-domain }}",
+		"          MCP_GATEWAY_PORT: ${{ steps.start-mcp-gateway.outputs.gateway-port }}",
+	}
+	if cliServers := getMCPCLIExcludeFromAgentConfig(data); len(cliServers) > 0 {
+		if cliServersJSON, err := json.Marshal(cliServers); err == nil {
+			step = append(step, "          GH_AW_MCP_CLI_SERVERS: '"+string(cliServersJSON)+"'")
+		}
+	}
+	return append(step, fmt.Sprintf("        run: node \"${RUNNER_TEMP}/gh-aw/actions/convert_gateway_config_%s.cjs\"", engineID))
+}
+
+// rewriteEngineFallbackSteps adapts the steps of fallback engine n so they can run in
+// the same job as the primary engine: step names are labelled with the engine, step IDs
+// get a "_fallback_<n>" suffix (references to them are updated), and every step is
+// gated on condition.
+func rewriteEngineFallbackSteps(steps []GitHubActionStep, n int, engineName, condition string) []GitHubActionStep {
+	suffix := fmt.Sprintf("_fallback_%d", n)
+
+	renamedIDs := make(map[string]string)
+	for _, step := range steps {
+		for _, line := range step {
+			if match := stepIDLinePattern.FindStringSubmatch(line); match != nil {
+				renamedIDs[match[1]] = match[1] + suffix
+			}
+		}
+	}
+
+	result := make([]GitHubActionStep, 0, len(steps))
+	for _, step := range steps {
+		if len(step) == 0 {
+			continue
+		}
+		rewritten := make(GitHubActionStep, 0, len(step)+1)
+		hasCondition := false
+		for i, line := range step {
+			for oldID, newID := range renamedIDs {
+				line = strings.ReplaceAll(line, "steps."+oldID+".", "steps."+newID+".")
+			}
+			switch {
+			case i == 0 && strings.HasPrefix(line, "      - name: "):
+				line = fmt.Sprintf("%s (%s fallback)", line, engineName)
+			case stepIDLinePattern.MatchString(line):
+				line += suffix
+			case strings.HasPrefix(line, "        if: "):
+				hasCondition = true
+				existing := strings.TrimPrefix(line, "        if: ")
+				existing = strings.TrimSuffix(strings.TrimPrefix(existing, "${{ "), " }}")
+				line = fmt.Sprintf("        if: (%s) && %s", existing, condition)
+			}
+			rewritten = append(rewritten, line)
+		}
+		if !hasCondition {
+			rewritten = append(rewritten[:1], append(GitHubActionStep{"        if: " + condition}, rewritten[1:]...)...)
+		}
+		result = append(result, rewritten)
+	}
+	return result
+}
@@ -0,0 +1,142 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractEngineConfigFallbackChain(t *testing.T) {
+	compiler := NewCompiler()
+
+	engineSetting, config, _ := compiler.ExtractEngineConfig(map[string]any{
+		"engine":    []any{"copilot", "claude"},
+		"max-turns": 10,
+	})
+	assert.Equal(t, "copilot", engineSetting, "first engine should be the primary engine")
+	require.NotNil(t, config, "engine config should be parsed")
+	assert.Equal(t, "copilot", config.ID, "engine ID should be the primary engine")
+	assert.Equal(t, []string{"claude"}, config.Fallbacks, "remaining engines should be fallbacks")
+	assert.Equal(t, "10", config.MaxTurns, "top-level settings should still apply")
+
+	engineSetting, config, _ = compiler.ExtractEngineConfig(map[string]any{"engine": []any{"claude"}})
+	assert.Equal(t, "claude", engineSetting, "single-entry list should select the engine")
+	require.NotNil(t, config, "engine config should be parsed")
+	assert.Empty(t, config.Fallbacks, "single-entry list should have no fallbacks")
+}
+
+func TestValidateEngineFallbacks(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      *EngineConfig
+		errContains string
+	}{
+		{name: "no engine config", config: nil},
+		{name: "no fallbacks", config: &EngineConfig{ID: "copilot"}},
+		{name: "copilot to claude", config: &EngineConfig{ID: "copilot", Fallbacks: []string{"claude"}}},
+		{name: "codex to claude and copilot", config: &EngineConfig{ID: "codex", Fallbacks: []string{"claude", "copilot"}}},
+		{
+			name:        "unsupported fallback engine",
+			config:      &EngineConfig{ID: "copilot", Fallbacks: []string{"codex"}},
+			errContains: `fallback engine "codex" is not supported`,
+		},
+		{
+			name:        "fallback repeats primary",
+			config:      &EngineConfig{ID: "claude", Fallbacks: []string{"claude"}},
+			errContains: "appears more than once",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewCompiler().validateEngineFallbacks(&WorkflowData{EngineConfig: tt.config})
+			if tt.errContains == "" {
+				assert.NoError(t, err, "fallback chain should be valid")
+				return
+			}
+			require.Error(t, err, "fallback chain should be rejected")
+			assert.Contains(t, err.Error(), tt.errContains, "error should explain the problem")
+		})
+	}
+}
+
+func TestRewriteEngineFallbackSteps(t *testing.T) {
+	steps := []GitHubActionStep{
+		{
+			"      - name: Execute Claude Code CLI",
+			"        id: agentic_execution",
+			"        run: claude",
+		},
+		{
+			"      - name: Copy logs",
+			"        if: always()",
+			"        run: echo ${{ steps.agentic_execution.outputs.result }}",
+		},
+	}
+
+	rewritten := rewriteEngineFallbackSteps(steps, 1, "Claude Code", "steps.engine_fallback_check_1.outputs.fallback == 'true'")
+	require.Len(t, rewritten, 2, "all steps should be kept")
+
+	assert.Equal(t, GitHubActionStep{
+		"      - name: Execute Claude Code CLI (Claude Code fallback)",
+		"        if: steps.engine_fallback_check_1.outputs.fallback == 'true'",
+		"        id: agentic_execution_fallback_1",
+		"        run: claude",
+	}, rewritten[0], "step should be renamed, gated, and get a suffixed ID")
+	assert.Equal(t, GitHubActionStep{
+		"      - name: Copy logs (Claude Code fallback)",
+		"        if: (always()) && steps.engine_fallback_check_1.outputs.fallback == 'true'",
+		"        run: echo ${{ steps.agentic_execution_fallback_1.outputs.result }}",
+	}, rewritten[1], "existing condition should be combined and step references updated")
+}
+
+func TestEngineFallbackCompiledSteps(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "engine-fallback-*")
+	workflowPath := filepath.Join(tmpDir, "fallback.md")
+	content := `---
+on: workflow_dispatch
+engine: [copilot, claude]
+permissions:
+  contents: read
+  issues: read
+tools:
+  github:
+    toolsets: [issues]
+---
+
+# Fallback
+
+Summarize open issues.
+`
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "fallback.md"), []byte(content), 0644), "Failed to write workflow")
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(workflowPath), "workflow with an engine fallback chain should compile")
+
+	lockContent, err := os.ReadFile(filepath.Join(tmpDir, "fallback.lock.yml"))
+	require.NoError(t, err, "Failed to read lock file")
+	lock := string(lockContent)
+
+	assert.Contains(t, lock, "      - name: Execute GitHub Copilot CLI\n        continue-on-error: true\n        id: agentic_execution\n",
+		"primary engine step should continue on error")
+	assert.Contains(t, lock, "        id: engine_fallback_check_1\n        if: always() && steps.agentic_execution.outcome == 'failure'\n",
+		"fallback check should run when the primary engine fails")
+	assert.Contains(t, lock, "check_engine_fallback.cjs", "fallback check should classify the failure")
+	assert.Contains(t, lock, "convert_gateway_config_claude.cjs", "fallback engine should get its own MCP config")
+	assert.Contains(t, lock, "      - name: Execute Claude Code CLI (Claude Code fallback)\n        if: steps.engine_fallback_check_1.outputs.fallback == 'true'\n        continue-on-error: true\n        id: agentic_execution_fallback_1\n",
+		"fallback engine should run when the check allows it")
+	assert.Contains(t, lock, "if: steps.agentic_execution.outcome == 'failure' && steps.agentic_execution_fallback_1.outcome != 'success'",
+		"job should fail when every engine failed")
+
+	fallbackStart := strings.Index(lock, "(Claude Code fallback)")
+	require.Positive(t, fallbackStart, "fallback steps should be emitted")
+	assert.Less(t, strings.Index(lock, "id: detect-agent-errors"), fallbackStart,
+		"fallback steps should run after primary error detection")
+}
@@ -68,6 +68,16 @@ func (c *Compiler) generateWorkflowHeader(yaml *strings.Builder, data *WorkflowD
 		compilerYamlHeaderLog.Printf("Failed to serialize gh-aw-manifest: %v. Safe update mode will not be available for future compilations of this workflow.", err)
 	}
 
+	// Record the compile inputs so `gh aw verify` can confirm the lock file still
+	// corresponds to its markdown source, imports, and actions-lock.json pins.
+	if data.LockProvenance != nil {
+		if provenanceJSON, err := data.LockProvenance.ToJSON(); err == nil {
+			fmt.Fprintf(yaml, "# gh-aw-provenance: %s\n", provenanceJSON)
+		} else {
+			compilerYamlHeaderLog.Printf("Failed to serialize gh-aw-provenance: %v", err)
+		}
+	}
+
 	// Add workflow header with logo and instructions
 	sourceFile := "the corresponding .md file"
 	if data.Source != "" {
@@ -148,6 +158,11 @@ func (c *Compiler) generateWorkflowHeader(yaml *strings.Builder, data *WorkflowD
 		}
 	}
 
+	// Add the files that contributed each imported tool and permission requirement
+	// (resolved with the imports-conflict policy).
+	writeSettingSources(yaml, "Imported tools", data.ToolSources)
+	writeSettingSources(yaml, "Imported permission requirements", data.PermissionSources)
+
 	// Add list of secrets referenced in the workflow
 	if len(secrets) > 0 {
 		yaml.WriteString("#\n")
@@ -191,3 +206,15 @@ func (c *Compiler) generateWorkflowHeader(yaml *strings.Builder, data *WorkflowD
 
 	yaml.WriteString("\n")
 }
+
+// writeSettingSources writes a sorted "name: source, source" list under a header comment.
+func writeSettingSources(yaml *strings.Builder, title string, sources map[string][]string) {
+	if len(sources) == 0 {
+		return
+	}
+	yaml.WriteString("#\n")
+	fmt.Fprintf(yaml, "# %s:\n", title)
+	for _, name := range sliceutil.SortedKeys(sources) {
+		fmt.Fprintf(yaml, "#   - %s: %s\n", name, strings.Join(sources[name], ", "))
+	}
+}
@@ -238,6 +238,25 @@ func TestGenerateWorkflowHeader(t *testing.T) {
 				"#   - MAIN_VAR: (main workflow)",
 			},
 		},
+		{
+			name: "header with imported tool and permission sources",
+			data: &WorkflowData{
+				ToolSources: map[string][]string{
+					"bash":      {"(main workflow)", "shared/lint.md", "shared/release.md"},
+					"web-fetch": {"shared/lint.md"},
+				},
+				PermissionSources: map[string][]string{
+					"contents": {"shared/lint.md", "shared/release.md"},
+				},
+			},
+			expectInStr: []string{
+				"# Imported tools:",
+				"#   - bash: (main workflow), shared/lint.md, shared/release.md",
+				"#   - web-fetch: shared/lint.md",
+				"# Imported permission requirements:",
+				"#   - contents: shared/lint.md, shared/release.md",
+			},
+		},
 		{
 			name:        "minimal header",
 			data:        &WorkflowData{},
@@ -473,7 +492,7 @@ func TestGeneratePlaceholderSubstitutionStep(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			var yaml strings.Builder
-			generatePlaceholderSubstitutionStep(&yaml, tt.mappings, "      ", nil)
+			NewCompiler().generatePlaceholderSubstitutionStep(&yaml, tt.mappings, nil)
 			result := yaml.String()
 
 			for _, expected := range tt.expectInStr {
@@ -39,10 +39,18 @@ func (c *Compiler) collectArtifactPaths(data *WorkflowData, engine CodingAgentEn
 	// Collect agent stdio logs path for unified upload
 	paths = append(paths, logFileFull)
 
+	// Include the structured turn log written by the log parser so gh aw logs and
+	// gh aw audit can reuse the in-run parse instead of re-parsing stdio.
+	paths = append(paths, constants.TmpGhAwDirSlash+constants.AgentTurnsFilename)
+
 	// Include the pre-agent audit file (file listing of agent-related directories captured
 	// before agent execution) so it is available in the agent artifact for post-run inspection.
 	paths = append(paths, constants.PreAgentAuditFilePath)
 
+	// Include aw_info.json, which the agent job enriches with runner_environment, so audit
+	// can compare the environments of two runs.
+	paths = append(paths, constants.TmpGhAwDirSlash+"aw_info.json")
+
 	// Collect agent-generated files path for unified upload
 	// This directory is used by workflows that instruct the agent to write files
 	// (e.g., smoke-claude status summaries)
@@ -52,6 +60,12 @@ func (c *Compiler) collectArtifactPaths(data *WorkflowData, engine CodingAgentEn
 	// Written by github_rate_limit_logger.cjs during REST API calls.
 	paths = append(paths, constants.TmpGhAwDirSlash+constants.GithubRateLimitsFilename)
 
+	// Collect GitHub permission telemetry (requested permissions and GitHub MCP tool calls)
+	// written by record_permission_usage.cjs for the audit permissions section.
+	if isPermissionTelemetryEnabled(data) {
+		paths = append(paths, constants.TmpGhAwDirSlash+constants.PermissionUsageFilename)
+	}
+
 	// Collect OTLP span mirror — enables post-hoc trace debugging without a live collector.
 	// Written by send_otlp_span.cjs; each line is a full OTLP/HTTP JSON traces payload.
 	// Only included when OTLP is configured for this workflow.
@@ -98,11 +112,13 @@ func (c *Compiler) collectArtifactPaths(data *WorkflowData, engine CodingAgentEn
 			// On ARC/DinD, logs are under ${{ runner.temp }}/gh-aw (daemon-visible path).
 			// Use ${{ runner.temp }} because `with:` blocks expand Actions expressions, not shell vars.
 			paths = append(paths, constants.AWFConfigFilePathExpr)
+			paths = append(paths, constants.FirewallPolicyFilePathExpr)
 			paths = append(paths, constants.AWFProxyLogsDirExpr+"/")
 			paths = append(paths, constants.AWFAuditDirExpr+"/")
 			paths = append(paths, constants.AWFReflectFilePathExpr)
 		} else {
 			paths = append(paths, constants.AWFConfigFilePath)
+			paths = append(paths, constants.FirewallPolicyFilePath)
 			paths = append(paths, constants.AWFProxyLogsDir+"/")
 			paths = append(paths, constants.AWFAuditDir+"/")
 			// Include the AWF /reflect payload persisted by the agent harness.
@@ -125,7 +141,7 @@ func (c *Compiler) collectArtifactPaths(data *WorkflowData, engine CodingAgentEn
 
 // generateSummarySteps emits all GITHUB_STEP_SUMMARY log-parsing steps for the agent job.
 // It covers agent log parsing, MCP scripts, MCP gateway, firewall logs, token usage,
-// AWF reflect summary, and observability summary.
+// AWF reflect summary, run metrics summary, and observability summary.
 func (c *Compiler) generateSummarySteps(yaml *strings.Builder, data *WorkflowData, engine CodingAgentEngine) {
 	// Parse agent logs for GITHUB_STEP_SUMMARY
 	c.generateLogParsing(yaml, data, engine)
@@ -139,6 +155,9 @@ func (c *Compiler) generateSummarySteps(yaml *strings.Builder, data *WorkflowDat
 	// The MCP gateway is always enabled, even when agent sandbox is disabled.
 	c.generateMCPGatewayLogParsing(yaml, data)
 
+	// Record GitHub MCP tool usage against the agent job's permissions (permission-telemetry feature).
+	c.generatePermissionTelemetryStep(yaml, data)
+
 	// Add firewall log parsing for all firewall-enabled engines.
 	// This replaces the previous per-engine blocks (Copilot, Codex, Claude) and extends
 	// support to all engines (including Gemini) so every agentic workflow uploads audit logs.
@@ -162,6 +181,9 @@ func (c *Compiler) generateSummarySteps(yaml *strings.Builder, data *WorkflowDat
 		c.generateAWFReflectSummary(yaml, data)
 	}
 
+	// Summarize turns, tokens, tool calls, safe outputs and firewall stats for the run.
+	c.generateRunMetricsSummary(yaml, data)
+
 	// Synthesize a compact observability section from runtime artifacts when OTLP is enabled.
 	c.generateObservabilitySummary(yaml, data)
 }
@@ -169,8 +191,8 @@ func (c *Compiler) generateSummarySteps(yaml *strings.Builder, data *WorkflowDat
 // generatePostAgentCollectionAndUpload orchestrates the post-agent phase:
 // engine output cleanup, access log collection, artifact path accumulation via collectArtifactPaths,
 // step-summary generation via generateSummarySteps, safe-outputs/memory/staging artifact uploads,
-// post-steps, the unified artifact upload, token invalidation, dev-mode actions restore,
-// and step-order validation.
+// post-steps, the unified artifact upload, additional checkout cleanup, token invalidation,
+// dev-mode actions restore, and step-order validation.
 func (c *Compiler) generatePostAgentCollectionAndUpload(yaml *strings.Builder, data *WorkflowData, engine CodingAgentEngine, artifactPaths []string, logFileFull string, checkoutMgr *CheckoutManager) error {
 	compilerYamlLog.Print("Generating post-agent collection and upload steps")
 	// Generate engine output cleanup step so workspace files are removed after collection.
@@ -215,7 +237,7 @@ func (c *Compiler) generatePostAgentCollectionAndUpload(yaml *strings.Builder, d
 
 	// Add cache-memory validation (after agent execution)
 	// This validates file types before cache is saved or uploaded
-	generateCacheMemoryValidation(yaml, data)
+	c.generateCacheMemoryValidation(yaml, data)
 
 	// Add cache-memory artifact upload (after agent execution)
 	// This ensures artifacts are uploaded after the agent has finished modifying the cache
@@ -230,6 +252,10 @@ func (c *Compiler) generatePostAgentCollectionAndUpload(yaml *strings.Builder, d
 	// to be downloaded and processed by the upload_artifact job
 	generateSafeOutputsArtifactStagingUpload(yaml, data, c.getActionPin)
 
+	// Encrypt create-artifact results and upload only the encrypted files
+	// for the safe_outputs job to publish
+	generateCreateArtifactEncryptionAndUpload(yaml, data, c.getActionPin)
+
 	// Add post-steps (if any) after AI execution
 	c.generatePostSteps(yaml, data)
 
@@ -246,7 +272,14 @@ func (c *Compiler) generatePostAgentCollectionAndUpload(yaml *strings.Builder, d
 	// In workflow_call context, apply the per-invocation prefix to avoid name clashes.
 	agentArtifactPrefix := artifactPrefixExprForDownstreamJob(data)
 	compilerYamlLog.Printf("Emitting unified agent artifact upload with %d path(s)", len(artifactPaths))
-	c.generateUnifiedArtifactUpload(yaml, artifactPaths, agentArtifactPrefix)
+	c.generateUnifiedArtifactUpload(yaml, artifactPaths, agentArtifactPrefix, agentArtifactName(data))
+
+	// Remove token-authenticated additional checkouts (and their persisted credential
+	// files) once everything the downstream jobs need has been uploaded.
+	if cleanupStep := checkoutMgr.GenerateAdditionalCheckoutCleanupStep(); cleanupStep != "" {
+		yaml.WriteString(cleanupStep)
+		compilerYamlLog.Print("Added additional checkout cleanup step to agent job")
+	}
 
 	// In dev mode the setup action is referenced via a local path (./actions/setup), so its files
 	// live in the workspace. When a checkout: entry targets an external repository without a path
@@ -48,12 +48,13 @@ func splitContentIntoChunks(content string) []string {
 func (c *Compiler) generatePrompt(yaml *strings.Builder, data *WorkflowData, preActivationJobCreated bool, beforeActivationJobs []string) {
 	compilerYamlPromptLog.Printf("Generating prompt for workflow: %s (markdown size: %d bytes)", data.Name, len(data.MarkdownContent))
 
-	builtinSections := c.collectPromptSections(data)
+	builtinSections := promptPolicySections(data.PromptPolicy, c.collectPromptSections(data))
 	compilerYamlPromptLog.Printf("Collected %d built-in prompt sections", len(builtinSections))
 
 	// Process imports and enrich with main-markdown expressions, activation filters,
 	// and experiment mappings.
 	userPromptChunks, expressionMappings := c.processPromptImportEntries(data)
+	userPromptChunks = append(userPromptChunks, githubContextImportChunks(data)...)
 	expressionMappings = c.enrichExpressionMappings(data, expressionMappings, beforeActivationJobs)
 
 	// Build main workflow content chunks (inline embed or runtime-import macro) and
@@ -76,10 +77,11 @@ func (c *Compiler) generatePrompt(yaml *strings.Builder, data *WorkflowData, pre
 	c.generateInterpolationAndTemplateStep(yaml, expressionMappings, data)
 
 	if len(allExpressionMappings) > 0 {
-		generatePlaceholderSubstitutionStep(yaml, allExpressionMappings, "      ", data)
+		c.generatePlaceholderSubstitutionStep(yaml, allExpressionMappings, data)
 	}
 
 	writePromptBashStep(yaml, "Validate prompt placeholders", "validate_prompt_placeholders.sh")
+	c.generateGitHubContextImportStep(yaml, data)
 	writePromptBashStep(yaml, "Print prompt", "print_prompt_summary.sh")
 }
 
@@ -126,20 +128,25 @@ func (c *Compiler) buildMainWorkflowPromptChunks(data *WorkflowData, userPromptC
 		return userPromptChunks, expressionMappings
 	}
 	// Normal mode: use runtime-import macro so users can edit without recompilation.
-	normalizedPath := filepath.ToSlash(c.markdownPath)
-	var workflowFilePath string
+	workflowFilePath := mainWorkflowImportPath(c.markdownPath)
+	runtimeImportMacro := fmt.Sprintf("{{#runtime-import %s}}", workflowFilePath)
+	compilerYamlPromptLog.Printf("Using runtime-import for main workflow markdown: %s", workflowFilePath)
+	return append(userPromptChunks, runtimeImportMacro), expressionMappings
+}
+
+// mainWorkflowImportPath returns the workspace-relative path used to runtime-import
+// the main workflow markdown (e.g. ".github/workflows/triage.md").
+func mainWorkflowImportPath(markdownPath string) string {
+	normalizedPath := filepath.ToSlash(markdownPath)
 	githubDirPattern := "/.github/"
 	githubIndex := strings.LastIndex(normalizedPath, githubDirPattern)
 	if githubIndex != -1 {
-		workflowFilePath = normalizedPath[githubIndex+1:]
-	} else if strings.HasPrefix(normalizedPath, constants.GithubDir) {
-		workflowFilePath = normalizedPath
-	} else {
-		workflowFilePath = filepath.Base(c.markdownPath)
+		return normalizedPath[githubIndex+1:]
 	}
-	runtimeImportMacro := fmt.Sprintf("{{#runtime-import %s}}", workflowFilePath)
-	compilerYamlPromptLog.Printf("Using runtime-import for main workflow markdown: %s", workflowFilePath)
-	return append(userPromptChunks, runtimeImportMacro), expressionMappings
+	if strings.HasPrefix(normalizedPath, constants.GithubDir) {
+		return normalizedPath
+	}
+	return filepath.Base(markdownPath)
 }
 
 // mergeKnownNeedsExpressions merges knownNeedsExpressions into all, with all-entries taking
@@ -0,0 +1,50 @@
+package workflow
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var runnerEnvironmentStepLog = logger.New("workflow:compiler_yaml_runner_environment_step")
+
+// engineCLICommands maps engine IDs to the executable each engine installs on the runner.
+// Engines without an entry (e.g. custom behavior-defined engines) skip the version probe.
+var engineCLICommands = map[string]string{
+	"copilot":     "copilot",
+	"claude":      "claude",
+	"codex":       "codex",
+	"gemini":      "gemini",
+	"antigravity": "agy",
+	"opencode":    "opencode",
+	"pi":          "pi",
+}
+
+// resolveEngineCLICommand returns the engine executable whose version is recorded in
+// aw_info.json, preferring a custom engine.command over the engine default.
+func resolveEngineCLICommand(data *WorkflowData, engine CodingAgentEngine) string {
+	if data.EngineConfig != nil && data.EngineConfig.Command != "" {
+		return data.EngineConfig.Command
+	}
+	return engineCLICommands[engine.GetID()]
+}
+
+// generateRunnerEnvironmentCaptureStep emits a step that merges runner metadata (image
+// version, hosted tool cache versions, installed engine CLI version) into aw_info.json.
+// aw_info.json is written by the activation job, which can run on a different runner, so
+// the capture happens in the agent job after the engine CLI is installed. The updated
+// aw_info.json is uploaded with the agent artifact and diffed by `gh aw audit`.
+//
+// The step runs with continue-on-error so metadata collection never blocks the agent.
+func (c *Compiler) generateRunnerEnvironmentCaptureStep(yaml *strings.Builder, data *WorkflowData, engine CodingAgentEngine) {
+	command := resolveEngineCLICommand(data, engine)
+	runnerEnvironmentStepLog.Printf("Generating runner environment capture step: engine=%s, command=%s", engine.GetID(), command)
+	yaml.WriteString("      - name: Capture runner environment\n")
+	yaml.WriteString("        continue-on-error: true\n")
+	if command != "" {
+		yaml.WriteString("        env:\n")
+		fmt.Fprintf(yaml, "          GH_AW_ENGINE_CLI_COMMAND: %q\n", command)
+	}
+	fmt.Fprintf(yaml, "        run: node \"%s/capture_runner_environment.cjs\"\n", SetupActionDestinationShell)
+}
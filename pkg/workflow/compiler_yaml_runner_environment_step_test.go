@@ -0,0 +1,56 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunnerEnvironmentCaptureStepGenerated(t *testing.T) {
+	tmpDir := t.TempDir()
+	testContent := `---
+on: push
+permissions:
+  contents: read
+engine: claude
+strict: false
+---
+
+Test workflow to verify runner environment capture.
+`
+	testFile := filepath.Join(tmpDir, "test-runner-env.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644), "writing test workflow")
+	require.NoError(t, NewCompiler().CompileWorkflow(testFile), "compiling workflow")
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "test-runner-env.lock.yml"))
+	require.NoError(t, err, "reading lock file")
+	lockContent := string(content)
+
+	assert.Contains(t, lockContent, "name: Capture runner environment", "capture step should be present")
+	assert.Contains(t, lockContent, `GH_AW_ENGINE_CLI_COMMAND: "claude"`, "engine CLI command should be passed to the capture script")
+	assert.Contains(t, lockContent, "capture_runner_environment.cjs", "capture script should run")
+	assert.Contains(t, lockContent, "            /tmp/gh-aw/aw_info.json\n", "aw_info.json should be uploaded")
+
+	captureIdx := strings.Index(lockContent, "name: Capture runner environment")
+	installIdx := strings.Index(lockContent, "npm install -g @anthropic-ai/claude-code")
+	require.Positive(t, installIdx, "engine install step should be present")
+	assert.Greater(t, captureIdx, installIdx, "capture should run after the engine CLI is installed")
+}
+
+func TestResolveEngineCLICommand(t *testing.T) {
+	registry := GetGlobalEngineRegistry()
+	copilot, err := registry.GetEngine("copilot")
+	require.NoError(t, err, "copilot engine should be registered")
+	antigravity, err := registry.GetEngine("antigravity")
+	require.NoError(t, err, "antigravity engine should be registered")
+
+	assert.Equal(t, "copilot", resolveEngineCLICommand(&WorkflowData{}, copilot), "default copilot command")
+	assert.Equal(t, "agy", resolveEngineCLICommand(&WorkflowData{}, antigravity), "antigravity installs the agy binary")
+	assert.Equal(t, "/opt/copilot/bin/copilot", resolveEngineCLICommand(&WorkflowData{EngineConfig: &EngineConfig{Command: "/opt/copilot/bin/copilot"}}, copilot), "custom command should win")
+}
@@ -245,14 +245,9 @@ func (c *Compiler) generateActivationArtifactAndCommentMemorySteps(yaml *strings
 	}
 
 	yaml.WriteString("      - name: Prepare comment memory files\n")
-	fmt.Fprintf(yaml, "        uses: %s\n", getCachedActionPin("actions/github-script", data))
-	yaml.WriteString("        with:\n")
-	fmt.Fprintf(yaml, "          github-token: %s\n", getEffectiveSafeOutputGitHubToken(data.SafeOutputs.CommentMemory.GitHubToken))
-	yaml.WriteString("          script: |\n")
-	yaml.WriteString("            const { setupGlobals } = require('${{ runner.temp }}/gh-aw/actions/setup_globals.cjs');\n")
-	yaml.WriteString("            setupGlobals(core, github, context, exec, io, getOctokit);\n")
-	yaml.WriteString("            const { main } = require('${{ runner.temp }}/gh-aw/actions/setup_comment_memory_files.cjs');\n")
-	yaml.WriteString("            await main();\n")
+	script := generateGitHubScriptWithRequire("setup_comment_memory_files.cjs")
+	fmt.Fprintf(yaml, "        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", data), script))
+	yaml.WriteString(c.githubScriptWith(script, fmt.Sprintf("          github-token: %s\n", getEffectiveSafeOutputGitHubToken(data.SafeOutputs.CommentMemory.GitHubToken))))
 }
 
 // generateCommentMemoryEarlyConfigStep emits a step that writes a minimal comment-memory
@@ -147,16 +147,15 @@ func (c *Compiler) generateOTLPOIDCMintStep(data *WorkflowData) []string {
 	}
 
 	compilerYamlStepGenerationLog.Print("Generating OTLP OIDC token mint step before setup")
+	script := "            const audience = (process.env.GH_AW_OTLP_OIDC_AUDIENCE || '').trim();\n" +
+		"            const token = audience ? await core.getIDToken(audience) : await core.getIDToken();\n" +
+		"            core.setSecret(token);\n" +
+		"            core.setOutput('token', token);\n"
 	lines := []string{
 		"      - name: Mint OTLP OIDC token\n",
 		"        id: mint-otlp-oidc-token\n",
-		fmt.Sprintf("        uses: %s\n", getCachedActionPin("actions/github-script", data)),
-		"        with:\n",
-		"          script: |\n",
-		"            const audience = (process.env.GH_AW_OTLP_OIDC_AUDIENCE || '').trim();\n",
-		"            const token = audience ? await core.getIDToken(audience) : await core.getIDToken();\n",
-		"            core.setSecret(token);\n",
-		"            core.setOutput('token', token);\n",
+		fmt.Sprintf("        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", data), script)),
+		c.githubScriptWith(script),
 	}
 
 	if audience := strings.TrimSpace(githubApp.Audience); audience != "" {
@@ -231,6 +230,7 @@ func (c *Compiler) generateSetupStepWithArtifactClientCondition(data *WorkflowDa
 			}
 		}
 		lines = append(lines, setupLines...)
+		lines = append(lines, c.generateHandlerScriptsInstallSteps(destination)...)
 		return lines
 	}
 
@@ -281,6 +281,7 @@ func (c *Compiler) generateSetupStepWithArtifactClientCondition(data *WorkflowDa
 		setupLines = append(setupLines, "          GH_AW_SETUP_AW_CONTEXT: ${{ inputs.aw_context }}\n")
 	}
 	lines = append(lines, setupLines...)
+	lines = append(lines, c.generateHandlerScriptsInstallSteps(destination)...)
 	return lines
 }
 
@@ -131,7 +131,7 @@ func (c *Compiler) generateCreateAwInfo(yaml *strings.Builder, data *WorkflowDat
 	// Firewall type
 	firewallType := ""
 	if isFirewallEnabled(data) {
-		firewallType = "squid"
+		firewallType = string(getFirewallBackend(data).Enforcement())
 	}
 
 	compilerYamlStepLifecycleLog.Printf("Generating aw_info step: engine=%s, modelConfigured=%t, version=%s, firewallEnabled=%t, staged=%s", engineID, modelConfigured, version, firewallEnabled, stagedValue)
@@ -229,13 +229,12 @@ func (c *Compiler) generateCreateAwInfo(yaml *strings.Builder, data *WorkflowDat
 			compilerYamlStepLifecycleLog.Printf("Failed to marshal skills for GH_AW_INFO_SKILLS, engine will not receive skill list: %v", err)
 		}
 	}
-	fmt.Fprintf(yaml, "        uses: %s\n", getCachedActionPin("actions/github-script", data))
-	yaml.WriteString("        with:\n")
-	yaml.WriteString("          script: |\n")
-	yaml.WriteString("            const { setupGlobals } = require('${{ runner.temp }}/gh-aw/actions/setup_globals.cjs');\n")
-	yaml.WriteString("            setupGlobals(core, github, context, exec, io, getOctokit);\n")
-	yaml.WriteString("            const { main } = require('${{ runner.temp }}/gh-aw/actions/generate_aw_info.cjs');\n")
-	yaml.WriteString("            await main(core, context);\n")
+	script := "            const { setupGlobals } = require('" + SetupActionDestination + "/setup_globals.cjs');\n" +
+		"            setupGlobals(core, github, context, exec, io, getOctokit);\n" +
+		"            const { main } = require('" + SetupActionDestination + "/generate_aw_info.cjs');\n" +
+		"            await main(core, context);\n"
+	fmt.Fprintf(yaml, "        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", data), script))
+	yaml.WriteString(c.githubScriptWith(script))
 }
 
 func (c *Compiler) generateOutputCollectionStep(yaml *strings.Builder, data *WorkflowData) error {
@@ -252,7 +251,9 @@ func (c *Compiler) generateOutputCollectionStep(yaml *strings.Builder, data *Wor
 	yaml.WriteString("      - name: Ingest agent output\n")
 	yaml.WriteString("        id: collect_output\n")
 	yaml.WriteString("        if: always()\n")
-	fmt.Fprintf(yaml, "        uses: %s\n", getCachedActionPin("actions/github-script", data))
+	// Load script from external file using require()
+	script := generateGitHubScriptWithRequire("collect_ndjson_output.cjs")
+	fmt.Fprintf(yaml, "        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", data), script))
 
 	// Add environment variables for JSONL validation
 	yaml.WriteString("        env:\n")
@@ -311,14 +312,7 @@ func (c *Compiler) generateOutputCollectionStep(yaml *strings.Builder, data *Wor
 		}
 	}
 
-	yaml.WriteString("        with:\n")
-	yaml.WriteString("          script: |\n")
-
-	// Load script from external file using require()
-	yaml.WriteString("            const { setupGlobals } = require('${{ runner.temp }}/gh-aw/actions/setup_globals.cjs');\n")
-	yaml.WriteString("            setupGlobals(core, github, context, exec, io, getOctokit);\n")
-	yaml.WriteString("            const { main } = require('${{ runner.temp }}/gh-aw/actions/collect_ndjson_output.cjs');\n")
-	yaml.WriteString("            await main();\n")
+	yaml.WriteString(c.githubScriptWith(script))
 
 	return nil
 }
@@ -133,7 +133,7 @@ strict: false
 Invalid YAML with duplicate keys.`,
 			expectedErrorLine:   7, // Line 7 in file (line 6 in YAML content - second permissions:)
 			expectedErrorColumn: 1,
-			expectedMessagePart: "mapping key \"permissions\" already defined",
+			expectedMessagePart: "duplicate key 'permissions' (first defined on line 3)",
 			description:         "duplicate keys should be detected",
 		},
 		{
@@ -218,7 +218,7 @@ Invalid YAML with scalar github tool config that has nested keys.`,
 			content:             "---\non: push\npermissions:\n  contents: read\n\tissues: write\nengine: claude\n---\n\n# Test Workflow\n\nInvalid YAML with mixed tabs and spaces.",
 			expectedErrorLine:   5, // Line 5 in file (line 4 in YAML content - the line with tab)
 			expectedErrorColumn: 1,
-			expectedMessagePart: "found character '\t' that cannot start any token",
+			expectedMessagePart: "tab character used for indentation",
 			description:         "mixed tabs and spaces should be detected",
 		},
 		{
@@ -407,7 +407,7 @@ engine: copilot
 
 Test content.`,
 			expectedLineCol: "[6:1]", // Line 6 in file (second tools: key)
-			expectedInError: []string{"mapping key \"tools\" already defined"},
+			expectedInError: []string{"duplicate key 'tools' (first defined on line 3)", "Suggestion: merge the two 'tools' entries"},
 			expectPointer:   true,
 			description:     "duplicate key error shows formatted output with both locations",
 		},
@@ -0,0 +1,106 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var conclusionTemplateLog = logger.New("workflow:conclusion_template")
+
+// conclusionTemplatePlaceholderPattern matches {name} placeholders, mirroring renderTemplate in messages_core.cjs.
+var conclusionTemplatePlaceholderPattern = regexp.MustCompile(`\{(\w+)\}`)
+
+// conclusionTemplateVariables lists the placeholders notify_comment_error.cjs provides when
+// rendering conclusion.template. Keep in sync with renderConclusionTemplate.
+var conclusionTemplateVariables = []string{
+	"ai_credits",
+	"ai_credits_formatted",
+	"conclusion",
+	"effective_tokens",
+	"errors",
+	"noop_messages",
+	"output_count",
+	"outputs",
+	"run_url",
+	"status",
+	"status_message",
+	"workflow_name",
+}
+
+// ConclusionConfig describes the conclusion: frontmatter section, which customizes the
+// comment posted by the conclusion job once the agent run finishes.
+type ConclusionConfig struct {
+	Template string // Markdown body with {placeholder} variables; replaces the default report
+}
+
+// extractConclusionConfig parses the conclusion: section and validates that the template
+// only references placeholders that are available at runtime.
+func extractConclusionConfig(frontmatter map[string]any) (*ConclusionConfig, error) {
+	raw, exists := frontmatter["conclusion"]
+	if !exists || raw == nil {
+		return nil, nil
+	}
+	v, ok := raw.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("conclusion must be an object with a template field, got %T", raw)
+	}
+	rawTemplate, exists := v["template"]
+	if !exists {
+		return nil, nil
+	}
+	template, ok := rawTemplate.(string)
+	if !ok {
+		return nil, fmt.Errorf("conclusion.template must be a string, got %T", rawTemplate)
+	}
+	if strings.TrimSpace(template) == "" {
+		return nil, errors.New("conclusion.template must not be empty")
+	}
+	if err := validateConclusionTemplate(template); err != nil {
+		return nil, err
+	}
+	conclusionTemplateLog.Printf("Extracted conclusion template (%d bytes)", len(template))
+	return &ConclusionConfig{Template: template}, nil
+}
+
+// validateConclusionTemplate reports placeholders that the conclusion job cannot fill.
+// Unknown placeholders would otherwise be left verbatim in the posted comment.
+func validateConclusionTemplate(template string) error {
+	var unknown []string
+	for _, match := range conclusionTemplatePlaceholderPattern.FindAllStringSubmatch(template, -1) {
+		name := match[1]
+		if !slices.Contains(conclusionTemplateVariables, name) && !slices.Contains(unknown, name) {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	placeholders := make([]string, len(unknown))
+	for i, name := range unknown {
+		placeholders[i] = "{" + name + "}"
+	}
+	available := make([]string, len(conclusionTemplateVariables))
+	for i, name := range conclusionTemplateVariables {
+		available[i] = "{" + name + "}"
+	}
+	return fmt.Errorf("conclusion.template uses unknown placeholder(s) %s; available: %s",
+		strings.Join(placeholders, ", "), strings.Join(available, ", "))
+}
+
+// buildConclusionTemplateEnvVars returns the env vars the conclusion comment step needs to
+// render conclusion.template, including the run metrics it exposes as placeholders.
+func buildConclusionTemplateEnvVars(data *WorkflowData, mainJobName string) []string {
+	if data.Conclusion == nil {
+		return nil
+	}
+	return []string{
+		fmt.Sprintf("          GH_AW_CONCLUSION_TEMPLATE: %q\n", data.Conclusion.Template),
+		fmt.Sprintf("          GH_AW_AIC: ${{ needs.%s.outputs.aic }}\n", mainJobName),
+		fmt.Sprintf("          GH_AW_EFFECTIVE_TOKENS: ${{ needs.%s.outputs.effective_tokens || '' }}\n", mainJobName),
+	}
+}
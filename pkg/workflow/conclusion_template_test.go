@@ -0,0 +1,91 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/github/gh-aw/pkg/testutil"
+)
+
+func TestExtractConclusionConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		frontmatter map[string]any
+		expected    *ConclusionConfig
+		wantErr     string
+	}{
+		{
+			name:        "not configured",
+			frontmatter: map[string]any{},
+		},
+		{
+			name:        "known placeholders",
+			frontmatter: map[string]any{"conclusion": map[string]any{"template": "{workflow_name}: {status}\n{outputs}\n{errors} ({ai_credits_formatted} AIC)"}},
+			expected:    &ConclusionConfig{Template: "{workflow_name}: {status}\n{outputs}\n{errors} ({ai_credits_formatted} AIC)"},
+		},
+		{
+			name:        "unknown placeholders",
+			frontmatter: map[string]any{"conclusion": map[string]any{"template": "{workflow_name} {duration} {cost} {duration}"}},
+			wantErr:     "unknown placeholder(s) {duration}, {cost}",
+		},
+		{
+			name:        "empty template",
+			frontmatter: map[string]any{"conclusion": map[string]any{"template": "  "}},
+			wantErr:     "conclusion.template must not be empty",
+		},
+		{
+			name:        "non-string template",
+			frontmatter: map[string]any{"conclusion": map[string]any{"template": 42}},
+			wantErr:     "conclusion.template must be a string",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := extractConclusionConfig(tt.frontmatter)
+			if tt.wantErr != "" {
+				require.Error(t, err, "expected an error")
+				assert.Contains(t, err.Error(), tt.wantErr, "error message should explain the problem")
+				return
+			}
+			require.NoError(t, err, "extraction should succeed")
+			assert.Equal(t, tt.expected, config, "parsed config should match")
+		})
+	}
+}
+
+func TestConclusionTemplateWorkflowCompilation(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "conclusion-template")
+	content := `---
+on:
+  issues:
+    types: [opened]
+  status-comment: true
+engine: copilot
+safe-outputs:
+  create-issue:
+conclusion:
+  template: |
+    ### {workflow_name}: {status}
+    {outputs}
+---
+
+# Triage
+`
+	workflowFile := filepath.Join(tmpDir, "triage.md")
+	require.NoError(t, os.WriteFile(workflowFile, []byte(content), 0644), "Failed to write workflow")
+	require.NoError(t, NewCompiler().CompileWorkflow(workflowFile), "compilation should succeed")
+
+	lockBytes, err := os.ReadFile(filepath.Join(tmpDir, "triage.lock.yml"))
+	require.NoError(t, err, "Failed to read lock file")
+	lock := string(lockBytes)
+
+	assert.Contains(t, lock, `GH_AW_CONCLUSION_TEMPLATE: "### {workflow_name}: {status}\n{outputs}\n"`, "template should be passed to the conclusion step")
+	assert.Contains(t, lock, "GH_AW_EFFECTIVE_TOKENS: ${{ needs.agent.outputs.effective_tokens || '' }}", "metrics should be available to the template")
+}
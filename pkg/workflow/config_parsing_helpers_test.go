@@ -1368,6 +1368,24 @@ func TestParsePushToPullRequestBranchMaxPatchSize(t *testing.T) {
 	}
 }
 
+func TestParsePushToPullRequestBranchMaxPatchFiles(t *testing.T) {
+	compiler := &Compiler{}
+	outputMap := map[string]any{
+		"push-to-pull-request-branch": map[string]any{
+			"max-patch-files": 25,
+		},
+	}
+
+	result := compiler.parsePushToPullRequestBranchConfig(outputMap)
+	if result == nil {
+		t.Fatal("expected non-nil result")
+	}
+
+	if result.MaxPatchFiles != 25 {
+		t.Fatalf("expected MaxPatchFiles=25, got %d", result.MaxPatchFiles)
+	}
+}
+
 // TestHandlerConfigExpressionFields verifies that the handler config builder
 // emits expression strings as JSON strings (not arrays) when a single-element
 // expression slice is provided.
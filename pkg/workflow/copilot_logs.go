@@ -365,6 +365,10 @@ func (e *CopilotEngine) ParseLogMetrics(logContent string, verbose bool) LogMetr
 		if jsonMetrics.EstimatedCost > 0 {
 			metrics.EstimatedCost += jsonMetrics.EstimatedCost
 		}
+		if usage, ok := e.extractTurnTokenUsage(jsonStr); ok {
+			usage.Turn = turns
+			metrics.TurnTokens = append(metrics.TurnTokens, usage)
+		}
 		e.extractToolCallSizes(jsonStr, toolCallMap, verbose)
 		inDataBlock = false
 		currentJSONLines = []string{}
@@ -479,6 +483,10 @@ func (e *CopilotEngine) ParseLogMetrics(logContent string, verbose bool) LogMetr
 		// Copilot CLI JSON blocks have empty tool_calls arrays but emit execution log lines.
 		if toolName := e.parseCopilotToolCallsWithSequence(line, toolCallMap); toolName != "" {
 			currentSequence = append(currentSequence, toolName)
+			// Tools executed after a response belong to the turn that requested them
+			if n := len(metrics.TurnTokens); n > 0 && metrics.TurnTokens[n-1].Turn == turns {
+				metrics.TurnTokens[n-1].ToolCalls = append(metrics.TurnTokens[n-1].ToolCalls, toolName)
+			}
 		}
 	}
 
@@ -503,6 +511,24 @@ func (e *CopilotEngine) ParseLogMetrics(logContent string, verbose bool) LogMetr
 	return metrics
 }
 
+// extractTurnTokenUsage extracts the token split of a single API response from a
+// Copilot debug data block. Returns false when the block has no usage object.
+func (e *CopilotEngine) extractTurnTokenUsage(jsonStr string) (TurnTokenUsage, bool) {
+	clean := sanitizeJSONBlock(jsonStr)
+	if clean == "" {
+		return TurnTokenUsage{}, false
+	}
+	var data map[string]any
+	if err := json.Unmarshal([]byte(clean), &data); err != nil {
+		return TurnTokenUsage{}, false
+	}
+	usage, ok := data["usage"].(map[string]any)
+	if !ok {
+		return TurnTokenUsage{}, false
+	}
+	return ExtractTurnTokenUsage(usage)
+}
+
 // extractToolCallSizes extracts tool call input sizes from Copilot JSON responses.
 // It sanitizes the JSON block first to handle trailing non-JSON log lines (e.g.
 // [INFO] lines that are appended after the closing brace in the wireApi=responses format).
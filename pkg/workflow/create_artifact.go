@@ -0,0 +1,123 @@
+package workflow
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/typeutil"
+)
+
+var createArtifactLog = logger.New("workflow:create_artifact")
+
+// defaultCreateArtifactMaxSizeBytes is the default maximum size of a single create_artifact result (10 MB).
+const defaultCreateArtifactMaxSizeBytes int64 = 10485760
+
+// createArtifactStagingDirExpr is the GitHub Actions expression form of the create-artifact staging
+// directory. The safe-outputs MCP server writes plaintext results here in the agent job, and the
+// safe_outputs job downloads the encrypted results back into the same path.
+const createArtifactStagingDirExpr = "${{ runner.temp }}/gh-aw/safeoutputs/create-artifacts/"
+
+// createArtifactEncryptedDirExpr is the directory the encryption step writes <name>.gpg files to.
+// It is the only create-artifact directory uploaded from the agent job, so a staged file can never
+// leave the runner unencrypted.
+const createArtifactEncryptedDirExpr = "${{ runner.temp }}/gh-aw/safeoutputs/create-artifacts-encrypted/"
+
+// SafeOutputsCreateArtifactStagingArtifactName is the artifact that carries the encrypted
+// create-artifact results from the main agent job to the safe_outputs job.
+const SafeOutputsCreateArtifactStagingArtifactName = "safe-outputs-create-artifacts"
+
+// createArtifactEncryptStepID is the step id of the agent job step that encrypts create-artifact results.
+const createArtifactEncryptStepID = "encrypt_create_artifacts"
+
+// CreateArtifactConfig holds configuration for the create-artifact safe output type.
+type CreateArtifactConfig struct {
+	BaseSafeOutputConfig `yaml:",inline"`
+	PublicKey            string  `yaml:"public-key,omitempty"`     // Armored GPG public key (usually ${{ vars.* }}) used to encrypt results
+	RetentionDays        *string `yaml:"retention-days,omitempty"` // Fixed retention period in days (templatable int; agent cannot override)
+	MaxSizeBytes         int64   `yaml:"max-size-bytes,omitempty"` // Max bytes per result (default: 10 MB)
+}
+
+// parseCreateArtifactConfig parses the create-artifact key from the safe-outputs map.
+func (c *Compiler) parseCreateArtifactConfig(outputMap map[string]any) *CreateArtifactConfig {
+	configData, exists := outputMap["create-artifact"]
+	if !exists {
+		return nil
+	}
+
+	configMap, ok := configData.(map[string]any)
+	if !ok {
+		// A public key is required, so there is no default-only form.
+		createArtifactLog.Print("create-artifact is not a configuration object, skipping")
+		return nil
+	}
+
+	createArtifactLog.Print("Parsing create-artifact configuration")
+	config := &CreateArtifactConfig{
+		MaxSizeBytes: defaultCreateArtifactMaxSizeBytes,
+	}
+
+	if publicKey, ok := configMap["public-key"].(string); ok {
+		config.PublicKey = strings.TrimSpace(publicKey)
+	}
+
+	// Parse retention-days (templatable int).
+	if err := preprocessIntFieldAsString(configMap, "retention-days", createArtifactLog); err != nil {
+		createArtifactLog.Printf("Warning: %v", err)
+	}
+	if retDays, exists := configMap["retention-days"]; exists {
+		if s, ok := retDays.(string); ok && s != "" {
+			config.RetentionDays = &s
+		}
+	}
+
+	// Parse max-size-bytes.
+	if maxBytes, exists := configMap["max-size-bytes"]; exists {
+		if v, ok := typeutil.ParseIntValue(maxBytes); ok && v > 0 {
+			config.MaxSizeBytes = int64(v)
+		}
+	}
+
+	// Parse common base fields (max, github-token, staged).
+	c.parseBaseSafeOutputConfig(configMap, &config.BaseSafeOutputConfig, 1)
+
+	createArtifactLog.Printf("Parsed create-artifact config: retention_days=%v, max_size_bytes=%d", config.RetentionDays, config.MaxSizeBytes)
+	return config
+}
+
+// generateCreateArtifactEncryptionAndUpload generates the agent job steps that encrypt the
+// create-artifact results with the configured public key and upload the encrypted files so the
+// safe_outputs job can publish them. Only the encrypted output directory is uploaded; the plaintext
+// staging directory is emptied by the encryption step, and the upload is skipped when it fails.
+// pinAction resolves the upload-artifact action reference; pass c.getActionPin from Compiler methods.
+func generateCreateArtifactEncryptionAndUpload(builder *strings.Builder, data *WorkflowData, pinAction func(string) string) {
+	if data.SafeOutputs == nil || data.SafeOutputs.CreateArtifact == nil {
+		return
+	}
+
+	createArtifactLog.Print("Generating create-artifact encryption and staging upload steps")
+
+	prefix := artifactPrefixExprForDownstreamJob(data)
+
+	builder.WriteString("      - name: Encrypt create-artifact files\n")
+	fmt.Fprintf(builder, "        id: %s\n", createArtifactEncryptStepID)
+	builder.WriteString("        if: always()\n")
+	builder.WriteString("        env:\n")
+	fmt.Fprintf(builder, "          GH_AW_CREATE_ARTIFACT_PUBLIC_KEY: %s\n", data.SafeOutputs.CreateArtifact.PublicKey)
+	builder.WriteString("        run: bash \"${RUNNER_TEMP}/gh-aw/actions/encrypt_create_artifacts.sh\"\n")
+	builder.WriteString("      # Upload encrypted create-artifact results for the safe_outputs job\n")
+	builder.WriteString("      - name: Upload create-artifact staging\n")
+	fmt.Fprintf(builder, "        if: always() && steps.%s.outcome == 'success'\n", createArtifactEncryptStepID)
+	fmt.Fprintf(builder, "        uses: %s\n", pinAction("actions/upload-artifact"))
+	builder.WriteString("        with:\n")
+	fmt.Fprintf(builder, "          name: %s%s\n", prefix, SafeOutputsCreateArtifactStagingArtifactName)
+	fmt.Fprintf(builder, "          path: %s\n", createArtifactEncryptedDirExpr)
+	builder.WriteString("          retention-days: 1\n")
+	builder.WriteString("          if-no-files-found: ignore\n")
+}
+
+// usesSafeOutputsArtifactClient reports whether the safe_outputs job uploads artifacts
+// directly from its handlers and therefore needs the artifact client set up.
+func usesSafeOutputsArtifactClient(safeOutputs *SafeOutputsConfig) bool {
+	return safeOutputs != nil && (safeOutputs.UploadArtifact != nil || safeOutputs.CreateArtifact != nil)
+}
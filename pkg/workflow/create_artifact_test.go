@@ -0,0 +1,86 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/github/gh-aw/pkg/testutil"
+)
+
+func TestParseCreateArtifactConfig(t *testing.T) {
+	c := &Compiler{}
+
+	assert.Nil(t, c.parseCreateArtifactConfig(map[string]any{}), "missing key should disable create-artifact")
+	assert.Nil(t, c.parseCreateArtifactConfig(map[string]any{"create-artifact": nil}), "config without a public key object should be ignored")
+
+	config := c.parseCreateArtifactConfig(map[string]any{
+		"create-artifact": map[string]any{
+			"public-key":     "${{ vars.ARTIFACT_PUBLIC_KEY }}",
+			"max":            2,
+			"retention-days": 7,
+		},
+	})
+	require.NotNil(t, config, "config should be parsed")
+	assert.Equal(t, "${{ vars.ARTIFACT_PUBLIC_KEY }}", config.PublicKey, "public key should be parsed")
+	assert.Equal(t, strPtr("2"), config.Max, "max should be parsed")
+	assert.Equal(t, strPtr("7"), config.RetentionDays, "retention-days should be parsed")
+	assert.Equal(t, defaultCreateArtifactMaxSizeBytes, config.MaxSizeBytes, "max-size-bytes should default to 10 MB")
+}
+
+func TestGenerateCreateArtifactEncryptionAndUpload(t *testing.T) {
+	var b strings.Builder
+	data := &WorkflowData{
+		SafeOutputs: &SafeOutputsConfig{
+			CreateArtifact: &CreateArtifactConfig{PublicKey: "${{ vars.ARTIFACT_PUBLIC_KEY }}"},
+		},
+	}
+	generateCreateArtifactEncryptionAndUpload(&b, data, getActionPin)
+	result := b.String()
+
+	assert.Contains(t, result, "GH_AW_CREATE_ARTIFACT_PUBLIC_KEY: ${{ vars.ARTIFACT_PUBLIC_KEY }}", "public key should be passed to the encryption step")
+	assert.Contains(t, result, "encrypt_create_artifacts.sh", "encryption script should run")
+	assert.Contains(t, result, "if: always() && steps.encrypt_create_artifacts.outcome == 'success'", "upload should be skipped when encryption fails")
+	assert.Contains(t, result, "path: ${{ runner.temp }}/gh-aw/safeoutputs/create-artifacts-encrypted/", "only the encrypted output directory should be uploaded")
+	assert.NotContains(t, result, "path: ${{ runner.temp }}/gh-aw/safeoutputs/create-artifacts/\n", "the plaintext staging directory should never be uploaded")
+	assert.Less(t, strings.Index(result, "Encrypt create-artifact files"), strings.Index(result, "Upload create-artifact staging"), "encryption should run before upload")
+
+	b.Reset()
+	generateCreateArtifactEncryptionAndUpload(&b, &WorkflowData{SafeOutputs: &SafeOutputsConfig{}}, getActionPin)
+	assert.Empty(t, b.String(), "should generate nothing when create-artifact is not configured")
+}
+
+func TestCreateArtifactWorkflowCompilation(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "create-artifact")
+	content := `---
+on: workflow_dispatch
+engine: copilot
+safe-outputs:
+  create-artifact:
+    public-key: ${{ vars.ARTIFACT_PUBLIC_KEY }}
+    retention-days: 5
+---
+
+# Secret scan
+`
+	workflowFile := filepath.Join(tmpDir, "scan.md")
+	require.NoError(t, os.WriteFile(workflowFile, []byte(content), 0644), "Failed to write workflow")
+	require.NoError(t, NewCompiler().CompileWorkflow(workflowFile), "compilation should succeed")
+
+	lockBytes, err := os.ReadFile(filepath.Join(tmpDir, "scan.lock.yml"))
+	require.NoError(t, err, "Failed to read lock file")
+	lock := string(lockBytes)
+
+	assert.Contains(t, lock, `mkdir -p "${RUNNER_TEMP}/gh-aw/safeoutputs/create-artifacts"`, "staging directory should be created for the MCP server")
+	assert.Contains(t, lock, "name: Encrypt create-artifact files", "agent job should encrypt results")
+	assert.Contains(t, lock, "name: Download create-artifact staging", "safe_outputs job should download encrypted results")
+	assert.Contains(t, lock, `\"create_artifact\":{\"max\":1,\"max-size-bytes\":10485760,\"retention-days\":5}`, "handler config should include create_artifact")
+	assert.Contains(t, lock, "create_artifact_count: ${{ steps.process_safe_outputs.outputs.create_artifact_count }}", "count should be a job output")
+	assert.NotContains(t, lock, "public-key", "public key should not be written to the handler config")
+}
@@ -0,0 +1,52 @@
+package workflow
+
+import "github.com/github/gh-aw/pkg/logger"
+
+var createCommitStatusLog = logger.New("workflow:create_commit_status")
+
+// CreateCommitStatusConfig holds configuration for setting commit statuses from agent output
+type CreateCommitStatusConfig struct {
+	BaseSafeOutputConfig `yaml:",inline"`
+	Target               string `yaml:"target,omitempty"`  // Target pull request whose head commit receives the status: "triggering", "*", or explicit PR number
+	Context              string `yaml:"context,omitempty"` // Status context shown in the PR checks list (defaults to the workflow name)
+}
+
+// parseCreateCommitStatusConfig handles create-commit-status configuration
+func (c *Compiler) parseCreateCommitStatusConfig(outputMap map[string]any) *CreateCommitStatusConfig {
+	if _, exists := outputMap["create-commit-status"]; !exists {
+		return nil
+	}
+
+	createCommitStatusLog.Print("Parsing create-commit-status configuration")
+	configData := outputMap["create-commit-status"]
+	statusConfig := &CreateCommitStatusConfig{}
+
+	if configMap, ok := configData.(map[string]any); ok {
+		// Parse context
+		if statusContext, ok := configMap["context"].(string); ok {
+			statusConfig.Context = statusContext
+			createCommitStatusLog.Printf("Using custom status context: %s", statusContext)
+		}
+
+		// Parse target (optional PR targeting mode)
+		if target, exists := configMap["target"]; exists {
+			if targetStr, ok := target.(string); ok {
+				statusConfig.Target = targetStr
+				createCommitStatusLog.Printf("Using commit status target: %s", targetStr)
+			} else {
+				createCommitStatusLog.Printf("Warning: create-commit-status target value %v is not a string and will be ignored", target)
+			}
+		}
+
+		// Parse common base fields with default max of 1
+		c.parseBaseSafeOutputConfig(configMap, &statusConfig.BaseSafeOutputConfig, 1)
+	} else {
+		// If configData is nil or not a map (e.g., "create-commit-status:" with no value),
+		// still set the default max of 1
+		createCommitStatusLog.Print("No config map provided, using defaults (max=1)")
+		statusConfig.Max = defaultIntStr(1)
+	}
+
+	createCommitStatusLog.Printf("Parsed create-commit-status config: context=%q", statusConfig.Context)
+	return statusConfig
+}
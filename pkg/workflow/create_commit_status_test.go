@@ -0,0 +1,41 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCreateCommitStatusConfig(t *testing.T) {
+	compiler := NewCompiler()
+
+	t.Run("absent key returns nil", func(t *testing.T) {
+		assert.Nil(t, compiler.parseCreateCommitStatusConfig(map[string]any{}), "config should be nil when not configured")
+	})
+
+	t.Run("null value uses defaults", func(t *testing.T) {
+		config := compiler.parseCreateCommitStatusConfig(map[string]any{"create-commit-status": nil})
+		require.NotNil(t, config, "config should be created for a null value")
+		require.NotNil(t, config.Max, "max should default")
+		assert.Equal(t, "1", *config.Max, "max should default to 1")
+		assert.Empty(t, config.Context, "context should default to the workflow name at runtime")
+	})
+
+	t.Run("parses context and target", func(t *testing.T) {
+		config := compiler.parseCreateCommitStatusConfig(map[string]any{
+			"create-commit-status": map[string]any{
+				"context": "ai-review",
+				"target":  "*",
+				"max":     2,
+			},
+		})
+		require.NotNil(t, config, "config should be parsed")
+		assert.Equal(t, "ai-review", config.Context, "context should be parsed")
+		assert.Equal(t, "*", config.Target, "target should be parsed")
+		require.NotNil(t, config.Max, "max should be parsed")
+		assert.Equal(t, "2", *config.Max, "max should be parsed")
+	})
+}
@@ -1,6 +1,7 @@
 package workflow
 
 import (
+	"fmt"
 	"slices"
 
 	"github.com/github/gh-aw/pkg/logger"
@@ -11,21 +12,46 @@ var createIssueLog = logger.New("workflow:create_issue")
 // CreateIssuesConfig holds configuration for creating GitHub issues from agent output
 type CreateIssuesConfig struct {
 	BaseSafeOutputConfig `yaml:",inline"`
-	TitlePrefix          string                `yaml:"title-prefix,omitempty"`
-	RequireTemporaryID   bool                  `yaml:"require-temporary-id,omitempty"` // When true, create_issue tool calls must include temporary_id.
-	Labels               []string              `yaml:"labels,omitempty"`
-	AllowedLabels        []string              `yaml:"allowed-labels,omitempty"`       // Optional list of allowed labels. If omitted, any labels are allowed (including creating new ones).
-	AllowedFields        []string              `yaml:"allowed-fields,omitempty"`       // Optional list of allowed issue field names. If omitted or empty, any issue fields are allowed. Use ["*"] to explicitly allow all.
-	Assignees            []string              `yaml:"assignees,omitempty"`            // List of users/bots to assign the issue to
-	DeduplicateByTitle   *TemplatableBoolOrInt `yaml:"deduplicate-by-title,omitempty"` // When true or 0, deduplicate by exact title match. When set to a positive integer N, also allow fuzzy matches up to edit distance N. When false or omitted, disable title-based deduplication. Accepts GitHub Actions expressions.
-	TargetRepoSlug       string                `yaml:"target-repo,omitempty"`          // Target repository in format "owner/repo" for cross-repository issues
-	AllowedRepos         []string              `yaml:"allowed-repos,omitempty"`        // List of additional repositories that issues can be created in
-	CloseOlderIssues     *string               `yaml:"close-older-issues,omitempty"`   // When true, close older issues with same title prefix or labels as "not planned"
-	CloseOlderKey        string                `yaml:"close-older-key,omitempty"`      // Optional explicit deduplication key for close-older matching. When set, uses gh-aw-close-key marker instead of workflow-id markers.
-	GroupByDay           *string               `yaml:"group-by-day,omitempty"`         // When true, if an open issue was already created today (UTC), post new content as a comment on it instead of creating a duplicate. Works best with close-older-issues: true.
-	Expires              int                   `yaml:"expires,omitempty"`              // Hours until the issue expires and should be automatically closed
-	Group                *string               `yaml:"group,omitempty"`                // If true, group issues as sub-issues under a parent issue (workflow ID is used as group identifier)
-	Footer               *string               `yaml:"footer,omitempty"`               // Controls whether AI-generated footer is added. When false, visible footer is omitted but XML markers are kept.
+	TitlePrefix          string                   `yaml:"title-prefix,omitempty"`
+	RequireTemporaryID   bool                     `yaml:"require-temporary-id,omitempty"` // When true, create_issue tool calls must include temporary_id.
+	Labels               []string                 `yaml:"labels,omitempty"`               // Labels applied to every created issue, in addition to any labels the agent requests
+	AllowedLabels        []string                 `yaml:"allowed-labels,omitempty"`       // Optional list of allowed labels. If omitted, any labels are allowed (including creating new ones).
+	AllowedFields        []string                 `yaml:"allowed-fields,omitempty"`       // Optional list of allowed issue field names. If omitted or empty, any issue fields are allowed. Use ["*"] to explicitly allow all.
+	Assignees            []string                 `yaml:"assignees,omitempty"`            // List of users/bots to assign the issue to
+	Milestone            string                   `yaml:"milestone,omitempty"`            // Milestone applied to every created issue: a title, a number, or "next-open" for the open milestone due soonest
+	Project              string                   `yaml:"project,omitempty"`              // Project (v2) every created issue is added to: a project URL, number, or title owned by the target repository owner
+	DeduplicateByTitle   *TemplatableBoolOrInt    `yaml:"deduplicate-by-title,omitempty"` // When true or 0, deduplicate by exact title match. When set to a positive integer N, also allow fuzzy matches up to edit distance N. When false or omitted, disable title-based deduplication. Accepts GitHub Actions expressions.
+	TargetRepoSlug       string                   `yaml:"target-repo,omitempty"`          // Target repository in format "owner/repo" for cross-repository issues
+	AllowedRepos         []string                 `yaml:"allowed-repos,omitempty"`        // List of additional repositories that issues can be created in
+	CloseOlderIssues     *string                  `yaml:"close-older-issues,omitempty"`   // When true, close older issues with same title prefix or labels as "not planned"
+	CloseOlderKey        string                   `yaml:"close-older-key,omitempty"`      // Optional explicit deduplication key for close-older matching. When set, uses gh-aw-close-key marker instead of workflow-id markers.
+	GroupByDay           *string                  `yaml:"group-by-day,omitempty"`         // When true, if an open issue was already created today (UTC), post new content as a comment on it instead of creating a duplicate. Works best with close-older-issues: true.
+	Expires              int                      `yaml:"expires,omitempty"`              // Hours until the issue expires and should be automatically closed
+	Group                *string                  `yaml:"group,omitempty"`                // If true, group issues as sub-issues under a parent issue (workflow ID is used as group identifier)
+	Footer               *string                  `yaml:"footer,omitempty"`               // Controls whether AI-generated footer is added. When false, visible footer is omitted but XML markers are kept.
+	Dedupe               *CreateIssueDedupeConfig `yaml:"dedupe,omitempty"`               // When set, comment on a matching open issue instead of creating a duplicate
+	Form                 string                   `yaml:"form,omitempty"`                 // Issue form file name in .github/ISSUE_TEMPLATE whose fields the agent fills in
+	IssueForm            *IssueForm               `yaml:"-"`                              // Parsed issue form, loaded during validation
+}
+
+// CreateIssueDedupeConfig holds cross-run deduplication criteria for create-issue.
+// An existing open issue is a duplicate when it satisfies every configured criterion.
+type CreateIssueDedupeConfig struct {
+	Title       string   `yaml:"title,omitempty"`       // Regular expression the existing issue title must match
+	Labels      []string `yaml:"labels,omitempty"`      // Labels the existing issue must carry
+	Fingerprint string   `yaml:"fingerprint,omitempty"` // Custom key embedded as a gh-aw-dedupe marker in the issue body
+}
+
+// handlerConfig returns the dedupe criteria in the handler config format.
+func (d *CreateIssueDedupeConfig) handlerConfig() map[string]any {
+	if d == nil {
+		return nil
+	}
+	return newHandlerConfigBuilder().
+		AddIfNotEmpty("title", d.Title).
+		AddStringSlice("labels", d.Labels).
+		AddIfNotEmpty("fingerprint", d.Fingerprint).
+		Build()
 }
 
 // parseCreateIssuesConfig handles create-issue configuration
@@ -46,6 +72,13 @@ func (c *Compiler) parseCreateIssuesConfig(outputMap map[string]any) *CreateIssu
 		},
 		func(configData map[string]any) bool {
 			coerceStringOrArrayFields(configData, []string{"assignees"}, createIssueLog)
+			// milestone and project accept bare numbers as well as titles
+			for _, field := range []string{"milestone", "project"} {
+				switch v := configData[field].(type) {
+				case int, int64, uint64, float64:
+					configData[field] = fmt.Sprint(v)
+				}
+			}
 			return true
 		},
 		func(_ map[string]any, config *CreateIssuesConfig, expiresDisabled bool) {
@@ -64,6 +97,10 @@ func (c *Compiler) parseCreateIssuesConfig(outputMap map[string]any) *CreateIssu
 	)
 }
 
+// nextOpenMilestone is the create-issue milestone value that selects the open milestone
+// with the earliest due date when each issue is created.
+const nextOpenMilestone = "next-open"
+
 // hasCopilotAssignee checks if "copilot" is in the assignees list
 func hasCopilotAssignee(assignees []string) bool {
 	return slices.Contains(assignees, "copilot")
@@ -0,0 +1,89 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var createIssueDedupeValidationLog = logger.New("workflow:create_issue_dedupe_validation")
+
+// validateCreateIssueDedupe checks the safe-outputs.create-issue.dedupe block.
+// At least one criterion must be set, and a literal title pattern must be a valid
+// regular expression in the subset shared by Go and JavaScript, since the handler
+// evaluates it with JavaScript's RegExp. Patterns containing GitHub Actions
+// expressions are checked at runtime instead.
+func validateCreateIssueDedupe(config *SafeOutputsConfig) error {
+	if config == nil || config.CreateIssues == nil || config.CreateIssues.Dedupe == nil {
+		return nil
+	}
+	dedupe := config.CreateIssues.Dedupe
+
+	hasLabels := false
+	for _, label := range dedupe.Labels {
+		if strings.TrimSpace(label) == "" {
+			return errors.New("safe-outputs.create-issue.dedupe.labels must not contain empty labels")
+		}
+		hasLabels = true
+	}
+	if strings.TrimSpace(dedupe.Title) == "" && !hasLabels && strings.TrimSpace(dedupe.Fingerprint) == "" {
+		return errors.New("safe-outputs.create-issue.dedupe must set at least one of title, labels, or fingerprint")
+	}
+
+	if dedupe.Title != "" && !containsExpression(dedupe.Title) {
+		if _, err := regexp.Compile(dedupe.Title); err != nil {
+			createIssueDedupeValidationLog.Printf("Invalid dedupe title pattern %q: %v", dedupe.Title, err)
+			return fmt.Errorf("safe-outputs.create-issue.dedupe.title is not a valid regular expression: %w", err)
+		}
+		if construct := findGoOnlyRegexSyntax(dedupe.Title); construct != "" {
+			createIssueDedupeValidationLog.Printf("Dedupe title pattern %q uses Go-only syntax %q", dedupe.Title, construct)
+			return fmt.Errorf("safe-outputs.create-issue.dedupe.title uses %q, which JavaScript regular expressions do not support; the pattern is evaluated with JavaScript's RegExp at runtime", construct)
+		}
+	}
+	return nil
+}
+
+// findGoOnlyRegexSyntax returns the first construct in pattern that RE2 accepts but
+// JavaScript's RegExp rejects or reads differently, or "" if there is none. The
+// constructs are inline flags, (?P<name>) groups, \A, \z, \Q...\E, \p classes
+// (which need the u flag in JavaScript) and POSIX [[:alpha:]] classes.
+func findGoOnlyRegexSyntax(pattern string) string {
+	inClass := false
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '\\':
+			if i+1 < len(pattern) && strings.IndexByte("AzQEpP", pattern[i+1]) >= 0 {
+				return pattern[i : i+2]
+			}
+			i++
+		case '(':
+			if inClass || !strings.HasPrefix(pattern[i:], "(?") || i+2 >= len(pattern) {
+				continue
+			}
+			if strings.HasPrefix(pattern[i:], "(?P<") {
+				return "(?P<"
+			}
+			if strings.IndexByte("imsU-", pattern[i+2]) >= 0 {
+				return pattern[i : i+3]
+			}
+		case '[':
+			if !inClass {
+				inClass = true
+				// A leading ']' or '^]' is a literal member of the class.
+				if strings.HasPrefix(pattern[i+1:], "^]") {
+					i += 2
+				} else if strings.HasPrefix(pattern[i+1:], "]") {
+					i++
+				}
+			} else if strings.HasPrefix(pattern[i+1:], ":") && strings.Contains(pattern[i+2:], ":]") {
+				return "[:...:]"
+			}
+		case ']':
+			inClass = false
+		}
+	}
+	return ""
+}
@@ -0,0 +1,96 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCreateIssuesConfigDedupe(t *testing.T) {
+	compiler := &Compiler{}
+	outputMap := map[string]any{
+		"create-issue": map[string]any{
+			"dedupe": map[string]any{
+				"title":       `^\[scan\]`,
+				"labels":      []any{"security", "automated"},
+				"fingerprint": "nightly-scan",
+			},
+		},
+	}
+
+	result := compiler.parseCreateIssuesConfig(outputMap)
+	require.NotNil(t, result, "create-issue config should be parsed")
+	require.NotNil(t, result.Dedupe, "dedupe block should be parsed")
+	assert.Equal(t, `^\[scan\]`, result.Dedupe.Title, "title pattern should be parsed")
+	assert.Equal(t, []string{"security", "automated"}, result.Dedupe.Labels, "labels should be parsed")
+	assert.Equal(t, "nightly-scan", result.Dedupe.Fingerprint, "fingerprint should be parsed")
+}
+
+func TestValidateCreateIssueDedupe(t *testing.T) {
+	tests := []struct {
+		name    string
+		dedupe  *CreateIssueDedupeConfig
+		wantErr string
+	}{
+		{name: "not configured"},
+		{name: "labels only", dedupe: &CreateIssueDedupeConfig{Labels: []string{"security"}}},
+		{name: "title pattern", dedupe: &CreateIssueDedupeConfig{Title: `^\[scan\] .+`}},
+		{name: "expression title is checked at runtime", dedupe: &CreateIssueDedupeConfig{Title: "${{ inputs.pattern }}"}},
+		{name: "fingerprint only", dedupe: &CreateIssueDedupeConfig{Fingerprint: "scan-${{ github.workflow }}"}},
+		{name: "no criteria", dedupe: &CreateIssueDedupeConfig{}, wantErr: "at least one of title, labels, or fingerprint"},
+		{name: "empty label", dedupe: &CreateIssueDedupeConfig{Labels: []string{" "}}, wantErr: "must not contain empty labels"},
+		{name: "invalid pattern", dedupe: &CreateIssueDedupeConfig{Title: "[scan"}, wantErr: "not a valid regular expression"},
+		{name: "inline flags", dedupe: &CreateIssueDedupeConfig{Title: `(?i)^\[scan\]`}, wantErr: `uses "(?i"`},
+		{name: "go named group", dedupe: &CreateIssueDedupeConfig{Title: `^(?P<kind>scan)`}, wantErr: `uses "(?P<"`},
+		{name: "end of text anchor", dedupe: &CreateIssueDedupeConfig{Title: `findings\z`}, wantErr: `uses "\\z"`},
+		{name: "posix class", dedupe: &CreateIssueDedupeConfig{Title: `^[[:upper:]]+`}, wantErr: "[:...:]"},
+		{name: "common subset", dedupe: &CreateIssueDedupeConfig{Title: `^(?:\[scan\]|(?<kind>audit)) [^\]:]+\d{2}$`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &SafeOutputsConfig{CreateIssues: &CreateIssuesConfig{Dedupe: tt.dedupe}}
+			err := validateCreateIssueDedupe(config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err, "dedupe config should be valid")
+				return
+			}
+			require.Error(t, err, "dedupe config should be rejected")
+			assert.Contains(t, err.Error(), tt.wantErr, "error should explain the problem")
+		})
+	}
+}
+
+func TestCreateIssueHandlerConfigDedupe(t *testing.T) {
+	data := &WorkflowData{
+		SafeOutputs: &SafeOutputsConfig{
+			CreateIssues: &CreateIssuesConfig{
+				BaseSafeOutputConfig: BaseSafeOutputConfig{Max: strPtr("1")},
+				Dedupe:               &CreateIssueDedupeConfig{Labels: []string{"security"}, Fingerprint: "nightly-scan"},
+			},
+		},
+	}
+
+	result, err := generateSafeOutputsConfig(data)
+	require.NoError(t, err, "generateSafeOutputsConfig should not return an error")
+
+	var parsed map[string]any
+	require.NoError(t, json.Unmarshal([]byte(result), &parsed), "Result must be valid JSON")
+	ciConfig, ok := parsed["create_issue"].(map[string]any)
+	require.True(t, ok, "Expected create_issue key in config")
+
+	dedupe, ok := ciConfig["dedupe"].(map[string]any)
+	require.True(t, ok, "Expected dedupe object in create_issue config")
+	assert.Equal(t, []any{"security"}, dedupe["labels"], "labels should be serialized")
+	assert.Equal(t, "nightly-scan", dedupe["fingerprint"], "fingerprint should be serialized")
+	assert.NotContains(t, dedupe, "title", "empty title should be omitted")
+
+	data.SafeOutputs.CreateIssues.Dedupe = nil
+	result, err = generateSafeOutputsConfig(data)
+	require.NoError(t, err, "generateSafeOutputsConfig should not return an error")
+	assert.NotContains(t, result, "dedupe", "dedupe should be omitted when not configured")
+}
@@ -0,0 +1,301 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/goccy/go-yaml"
+)
+
+var createIssueFormLog = logger.New("workflow:create_issue_form")
+
+// issueFormTemplateDir is the repository directory GitHub reads issue forms from.
+const issueFormTemplateDir = ".github/ISSUE_TEMPLATE"
+
+// issueFormFileNamePattern restricts create-issue.form to a plain file name inside
+// .github/ISSUE_TEMPLATE so the setting cannot reach outside that directory.
+var issueFormFileNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*\.ya?ml$`)
+
+// issueFormFieldIDPattern matches the ids GitHub accepts for issue form inputs.
+var issueFormFieldIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// IssueForm is the subset of a GitHub issue form (YAML issue template) that
+// create-issue needs to validate agent input and render a matching issue body.
+type IssueForm struct {
+	File   string           // File name inside .github/ISSUE_TEMPLATE
+	Name   string           // Form name shown in the template chooser
+	Title  string           // Default issue title, used as the title prefix
+	Labels []string         // Labels GitHub applies to issues created from the form
+	Fields []IssueFormField // Input fields in form order; markdown blocks are skipped
+}
+
+// IssueFormField is one input of an issue form.
+type IssueFormField struct {
+	ID              string   // Key the agent uses in the create_issue form object
+	Type            string   // input, textarea, dropdown or checkboxes
+	Label           string   // Heading GitHub renders above the value
+	Required        bool     // Whether a value must be provided
+	Options         []string // Dropdown or checkbox options
+	Multiple        bool     // Dropdown allows several options
+	Render          string   // Textarea values are rendered in a code block with this language
+	RequiredOptions []string // Checkbox options that must be checked
+}
+
+// issueFormFile mirrors the GitHub issue form syntax.
+type issueFormFile struct {
+	Name   string          `yaml:"name"`
+	Title  string          `yaml:"title"`
+	Labels any             `yaml:"labels"`
+	Body   []issueFormItem `yaml:"body"`
+}
+
+type issueFormItem struct {
+	Type       string `yaml:"type"`
+	ID         string `yaml:"id"`
+	Attributes struct {
+		Label    string `yaml:"label"`
+		Options  []any  `yaml:"options"`
+		Multiple bool   `yaml:"multiple"`
+		Render   string `yaml:"render"`
+	} `yaml:"attributes"`
+	Validations struct {
+		Required bool `yaml:"required"`
+	} `yaml:"validations"`
+}
+
+// loadCreateIssueForm reads the issue form named by safe-outputs.create-issue.form from
+// the repository containing markdownPath and stores it on the create-issue config.
+func loadCreateIssueForm(config *SafeOutputsConfig, markdownPath string) error {
+	if config == nil || config.CreateIssues == nil || config.CreateIssues.Form == "" {
+		return nil
+	}
+	createIssue := config.CreateIssues
+	if !issueFormFileNamePattern.MatchString(createIssue.Form) {
+		return fmt.Errorf("safe-outputs.create-issue.form '%s' must be the file name of an issue form in %s (for example: bug_report.yml)", createIssue.Form, issueFormTemplateDir)
+	}
+
+	// Navigate up from .github/workflows to the repository root
+	repoRoot := filepath.Join(filepath.Dir(markdownPath), "..", "..")
+	formPath := filepath.Join(repoRoot, issueFormTemplateDir, createIssue.Form)
+	createIssueFormLog.Printf("Loading issue form: %s", formPath)
+
+	content, err := os.ReadFile(formPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("safe-outputs.create-issue.form '%s' does not exist. Expected an issue form at %s/%s", createIssue.Form, issueFormTemplateDir, createIssue.Form)
+		}
+		return fmt.Errorf("failed to read issue form '%s': %w", createIssue.Form, err)
+	}
+
+	form, err := parseIssueForm(createIssue.Form, content)
+	if err != nil {
+		return fmt.Errorf("safe-outputs.create-issue.form '%s' is not a valid issue form: %w", createIssue.Form, err)
+	}
+	createIssue.IssueForm = form
+	return nil
+}
+
+// parseIssueForm parses the YAML of a GitHub issue form.
+func parseIssueForm(file string, content []byte) (*IssueForm, error) {
+	var raw issueFormFile
+	if err := yaml.Unmarshal(content, &raw); err != nil {
+		return nil, err
+	}
+	if len(raw.Body) == 0 {
+		return nil, errors.New("body must contain at least one field")
+	}
+
+	form := &IssueForm{
+		File:  file,
+		Name:  raw.Name,
+		Title: raw.Title,
+	}
+	switch labels := raw.Labels.(type) {
+	case string:
+		for label := range strings.SplitSeq(labels, ",") {
+			if label = strings.TrimSpace(label); label != "" {
+				form.Labels = append(form.Labels, label)
+			}
+		}
+	case []any:
+		for _, label := range labels {
+			if s, ok := label.(string); ok && strings.TrimSpace(s) != "" {
+				form.Labels = append(form.Labels, strings.TrimSpace(s))
+			}
+		}
+	}
+
+	seen := make(map[string]bool)
+	for i, item := range raw.Body {
+		if item.Type == "markdown" {
+			continue
+		}
+		field, err := parseIssueFormField(item)
+		if err != nil {
+			return nil, fmt.Errorf("body[%d]: %w", i, err)
+		}
+		if seen[field.ID] {
+			return nil, fmt.Errorf("body[%d]: duplicate field id '%s'", i, field.ID)
+		}
+		seen[field.ID] = true
+		form.Fields = append(form.Fields, field)
+	}
+	if len(form.Fields) == 0 {
+		return nil, errors.New("body must contain at least one input, textarea, dropdown or checkboxes field")
+	}
+	createIssueFormLog.Printf("Parsed issue form %s: fields=%d, labels=%d", file, len(form.Fields), len(form.Labels))
+	return form, nil
+}
+
+// parseIssueFormField converts one non-markdown body item into a field.
+func parseIssueFormField(item issueFormItem) (IssueFormField, error) {
+	field := IssueFormField{
+		ID:       item.ID,
+		Type:     item.Type,
+		Label:    strings.TrimSpace(item.Attributes.Label),
+		Required: item.Validations.Required,
+		Multiple: item.Attributes.Multiple,
+		Render:   item.Attributes.Render,
+	}
+	if field.Label == "" {
+		return field, fmt.Errorf("%s field is missing attributes.label", item.Type)
+	}
+	if field.ID == "" {
+		field.ID = issueFormFieldIDFromLabel(field.Label)
+	}
+	if !issueFormFieldIDPattern.MatchString(field.ID) {
+		return field, fmt.Errorf("field id '%s' may only contain letters, digits, '-' and '_'", field.ID)
+	}
+
+	switch item.Type {
+	case "input", "textarea":
+	case "dropdown":
+		for _, option := range item.Attributes.Options {
+			if s, ok := option.(string); ok {
+				field.Options = append(field.Options, s)
+			}
+		}
+		if len(field.Options) == 0 {
+			return field, fmt.Errorf("dropdown '%s' must define options", field.ID)
+		}
+	case "checkboxes":
+		for _, option := range item.Attributes.Options {
+			entry, ok := option.(map[string]any)
+			if !ok {
+				continue
+			}
+			label, _ := entry["label"].(string)
+			if label == "" {
+				continue
+			}
+			field.Options = append(field.Options, label)
+			if required, _ := entry["required"].(bool); required {
+				field.RequiredOptions = append(field.RequiredOptions, label)
+			}
+		}
+		if len(field.Options) == 0 {
+			return field, fmt.Errorf("checkboxes '%s' must define options", field.ID)
+		}
+		// GitHub validates checkboxes per option, so the field is required when any option is.
+		field.Required = len(field.RequiredOptions) > 0
+	default:
+		return field, fmt.Errorf("unsupported field type '%s'", item.Type)
+	}
+	return field, nil
+}
+
+// issueFormFieldIDFromLabel derives a field id for items without an explicit id.
+func issueFormFieldIDFromLabel(label string) string {
+	var b strings.Builder
+	lastUnderscore := true
+	for _, r := range strings.ToLower(label) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			lastUnderscore = false
+		} else if !lastUnderscore {
+			b.WriteByte('_')
+			lastUnderscore = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "_")
+}
+
+// requiredFieldIDs returns the ids of the fields that must be provided.
+func (f *IssueForm) requiredFieldIDs() []string {
+	var ids []string
+	for _, field := range f.Fields {
+		if field.Required {
+			ids = append(ids, field.ID)
+		}
+	}
+	return ids
+}
+
+// handlerConfig returns the form in the handler config format.
+func (f *IssueForm) handlerConfig() map[string]any {
+	if f == nil {
+		return nil
+	}
+	fields := make([]map[string]any, 0, len(f.Fields))
+	for _, field := range f.Fields {
+		fields = append(fields, newHandlerConfigBuilder().
+			AddIfNotEmpty("id", field.ID).
+			AddIfNotEmpty("type", field.Type).
+			AddIfNotEmpty("label", field.Label).
+			AddIfTrue("required", field.Required).
+			AddStringSlice("options", field.Options).
+			AddIfTrue("multiple", field.Multiple).
+			AddIfNotEmpty("render", field.Render).
+			AddStringSlice("required_options", field.RequiredOptions).
+			Build())
+	}
+	return newHandlerConfigBuilder().
+		AddIfNotEmpty("file", f.File).
+		AddIfNotEmpty("title", f.Title).
+		AddStringSlice("labels", f.Labels).
+		AddDefault("fields", fields).
+		Build()
+}
+
+// toolInputSchema returns the JSON Schema of the create_issue form property.
+func (f *IssueForm) toolInputSchema() map[string]any {
+	properties := make(map[string]any, len(f.Fields))
+	for _, field := range f.Fields {
+		description := field.Label
+		var property map[string]any
+		switch field.Type {
+		case "dropdown":
+			if field.Multiple {
+				property = map[string]any{"type": "array", "items": map[string]any{"type": "string", "enum": field.Options}}
+			} else {
+				property = map[string]any{"type": "string", "enum": field.Options}
+			}
+		case "checkboxes":
+			property = map[string]any{"type": "array", "items": map[string]any{"type": "string", "enum": field.Options}}
+			description += ". List the options to check"
+			if len(field.RequiredOptions) > 0 {
+				description += fmt.Sprintf("; must include %s", formatStringList(field.RequiredOptions))
+			}
+		default:
+			property = map[string]any{"type": "string"}
+		}
+		property["description"] = description + "."
+		properties[field.ID] = property
+	}
+
+	schema := map[string]any{
+		"type":                 "object",
+		"description":          fmt.Sprintf("Values for the %q issue form fields. The issue body is rendered in the form's format, with 'body' placed above the form sections.", f.File),
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	if required := f.requiredFieldIDs(); len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
@@ -0,0 +1,157 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testBugReportForm = `name: Bug report
+description: File a bug report
+title: "[Bug]: "
+labels: ["bug", "triage"]
+body:
+  - type: markdown
+    attributes:
+      value: Thanks for taking the time to fill out this bug report!
+  - type: textarea
+    id: what-happened
+    attributes:
+      label: What happened?
+    validations:
+      required: true
+  - type: dropdown
+    id: version
+    attributes:
+      label: Version
+      options:
+        - 1.0.0
+        - 2.0.0
+    validations:
+      required: true
+  - type: textarea
+    attributes:
+      label: Relevant log output
+      render: shell
+  - type: checkboxes
+    id: terms
+    attributes:
+      label: Code of Conduct
+      options:
+        - label: I agree to follow the Code of Conduct
+          required: true
+        - label: I searched existing issues
+`
+
+func TestParseIssueForm(t *testing.T) {
+	form, err := parseIssueForm("bug_report.yml", []byte(testBugReportForm))
+	require.NoError(t, err, "valid issue form should parse")
+
+	assert.Equal(t, "Bug report", form.Name, "form name")
+	assert.Equal(t, "[Bug]: ", form.Title, "form title")
+	assert.Equal(t, []string{"bug", "triage"}, form.Labels, "form labels")
+	require.Len(t, form.Fields, 4, "markdown blocks should be skipped")
+
+	assert.Equal(t, IssueFormField{ID: "what-happened", Type: "textarea", Label: "What happened?", Required: true}, form.Fields[0], "textarea field")
+	assert.Equal(t, []string{"1.0.0", "2.0.0"}, form.Fields[1].Options, "dropdown options")
+	assert.Equal(t, "relevant_log_output", form.Fields[2].ID, "id should be derived from the label")
+	assert.Equal(t, "shell", form.Fields[2].Render, "render language")
+	assert.True(t, form.Fields[3].Required, "checkboxes with a required option are required")
+	assert.Equal(t, []string{"I agree to follow the Code of Conduct"}, form.Fields[3].RequiredOptions, "required checkbox options")
+	assert.Equal(t, []string{"what-happened", "version", "terms"}, form.requiredFieldIDs(), "required field ids")
+}
+
+func TestParseIssueFormErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{name: "no body", content: "name: Empty\n", wantErr: "at least one field"},
+		{name: "only markdown", content: "body:\n  - type: markdown\n    attributes:\n      value: hi\n", wantErr: "at least one input"},
+		{name: "missing label", content: "body:\n  - type: input\n    id: a\n", wantErr: "missing attributes.label"},
+		{name: "dropdown without options", content: "body:\n  - type: dropdown\n    id: a\n    attributes:\n      label: A\n", wantErr: "must define options"},
+		{name: "duplicate id", content: "body:\n  - type: input\n    id: a\n    attributes:\n      label: A\n  - type: input\n    id: a\n    attributes:\n      label: B\n", wantErr: "duplicate field id 'a'"},
+		{name: "unsupported type", content: "body:\n  - type: slider\n    id: a\n    attributes:\n      label: A\n", wantErr: "unsupported field type 'slider'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseIssueForm("form.yml", []byte(tt.content))
+			require.Error(t, err, "invalid issue form should be rejected")
+			assert.Contains(t, err.Error(), tt.wantErr, "error should describe the problem")
+		})
+	}
+}
+
+func TestLoadCreateIssueForm(t *testing.T) {
+	repoRoot := t.TempDir()
+	workflowsDir := filepath.Join(repoRoot, ".github", "workflows")
+	templateDir := filepath.Join(repoRoot, ".github", "ISSUE_TEMPLATE")
+	require.NoError(t, os.MkdirAll(workflowsDir, 0o755), "create workflows dir")
+	require.NoError(t, os.MkdirAll(templateDir, 0o755), "create template dir")
+	require.NoError(t, os.WriteFile(filepath.Join(templateDir, "bug_report.yml"), []byte(testBugReportForm), 0o644), "write issue form")
+	markdownPath := filepath.Join(workflowsDir, "triage.md")
+
+	t.Run("loads the form", func(t *testing.T) {
+		config := &SafeOutputsConfig{CreateIssues: &CreateIssuesConfig{Form: "bug_report.yml"}}
+		require.NoError(t, loadCreateIssueForm(config, markdownPath), "existing form should load")
+		require.NotNil(t, config.CreateIssues.IssueForm, "form should be stored on the config")
+		assert.Len(t, config.CreateIssues.IssueForm.Fields, 4, "form fields")
+	})
+
+	t.Run("no form configured", func(t *testing.T) {
+		assert.NoError(t, loadCreateIssueForm(&SafeOutputsConfig{CreateIssues: &CreateIssuesConfig{}}, markdownPath), "form is optional")
+	})
+
+	t.Run("missing form", func(t *testing.T) {
+		config := &SafeOutputsConfig{CreateIssues: &CreateIssuesConfig{Form: "feature.yml"}}
+		err := loadCreateIssueForm(config, markdownPath)
+		require.Error(t, err, "missing form should be rejected")
+		assert.Contains(t, err.Error(), "does not exist", "error should explain the form is missing")
+	})
+
+	t.Run("path outside the template directory", func(t *testing.T) {
+		config := &SafeOutputsConfig{CreateIssues: &CreateIssuesConfig{Form: "../workflows/triage.yml"}}
+		err := loadCreateIssueForm(config, markdownPath)
+		require.Error(t, err, "paths should be rejected")
+		assert.Contains(t, err.Error(), "must be the file name", "error should ask for a file name")
+	})
+}
+
+func TestIssueFormToolIntegration(t *testing.T) {
+	form, err := parseIssueForm("bug_report.yml", []byte(testBugReportForm))
+	require.NoError(t, err, "valid issue form should parse")
+	safeOutputs := &SafeOutputsConfig{CreateIssues: &CreateIssuesConfig{Form: "bug_report.yml", IssueForm: form}}
+
+	t.Run("form property is injected", func(t *testing.T) {
+		schema, ok := computePropertyInjections(safeOutputs)["create_issue"]["form"].(map[string]any)
+		require.True(t, ok, "create_issue should receive a form property")
+		assert.Equal(t, []string{"what-happened", "version", "terms"}, schema["required"], "required form fields")
+		properties := schema["properties"].(map[string]any)
+		assert.Equal(t, map[string]any{"type": "string", "enum": []string{"1.0.0", "2.0.0"}, "description": "Version."}, properties["version"], "dropdown schema")
+		assert.Equal(t, "array", properties["terms"].(map[string]any)["type"], "checkboxes are a list of checked options")
+	})
+
+	t.Run("form is required when it has required fields", func(t *testing.T) {
+		assert.Equal(t, []string{"form"}, computeRequiredFieldAdditions(safeOutputs)["create_issue"], "form should be required")
+	})
+
+	t.Run("handler config carries the form", func(t *testing.T) {
+		config := handlerRegistry["create_issue"](safeOutputs)
+		formConfig, ok := config["form"].(map[string]any)
+		require.True(t, ok, "handler config should include the form")
+		assert.Equal(t, "[Bug]: ", formConfig["title"], "form title")
+		assert.Equal(t, []string{"bug", "triage"}, formConfig["labels"], "form labels")
+		assert.Len(t, formConfig["fields"], 4, "form fields")
+	})
+
+	t.Run("tool description lists required fields", func(t *testing.T) {
+		constraints := createIssueConstraints(safeOutputs.CreateIssues)
+		assert.Contains(t, constraints, `Issues follow the "bug_report.yml" issue form: provide its fields in 'form'. Required fields: ["what-happened" "version" "terms"].`, "constraint should describe the form")
+	})
+}
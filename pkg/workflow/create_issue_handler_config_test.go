@@ -295,3 +295,68 @@ Create an issue.
 		}
 	}
 }
+
+// TestCreateIssueHandlerConfigMilestoneAndProject verifies that milestone and project
+// conventions reach the handler config and that the project token is exposed to the handler.
+func TestCreateIssueHandlerConfigMilestoneAndProject(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "handler-config-test")
+
+	testContent := `---
+name: Test Milestone And Project
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: copilot
+safe-outputs:
+  create-issue:
+    labels: [triage]
+    milestone: 3
+    project: Roadmap
+---
+
+Create an issue with title "Test" and body "Test body".
+`
+
+	testFile := filepath.Join(tmpDir, "test-milestone-project.md")
+	if err := os.WriteFile(testFile, []byte(testContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	compiler := NewCompiler()
+	if err := compiler.CompileWorkflow(testFile); err != nil {
+		t.Fatalf("Failed to compile workflow: %v", err)
+	}
+
+	compiledContent, err := os.ReadFile(filepath.Join(tmpDir, "test-milestone-project.lock.yml"))
+	if err != nil {
+		t.Fatalf("Failed to read compiled output: %v", err)
+	}
+	compiledStr := string(compiledContent)
+
+	var configJSON string
+	for line := range strings.SplitSeq(compiledStr, "\n") {
+		if _, after, found := strings.Cut(line, "GH_AW_SAFE_OUTPUTS_HANDLER_CONFIG:"); found {
+			configJSON = strings.ReplaceAll(strings.Trim(strings.TrimSpace(after), "\""), "\\\"", "\"")
+			break
+		}
+	}
+
+	var config map[string]any
+	if err := json.Unmarshal([]byte(configJSON), &config); err != nil {
+		t.Fatalf("Failed to parse handler config JSON: %v\nJSON: %s", err, configJSON)
+	}
+	createIssueConfig, ok := config["create_issue"].(map[string]any)
+	if !ok {
+		t.Fatal("Expected create_issue in handler config")
+	}
+
+	if milestone := createIssueConfig["milestone"]; milestone != "3" {
+		t.Errorf("Expected milestone=\"3\" in create_issue config, got: %v", milestone)
+	}
+	if project := createIssueConfig["project"]; project != "Roadmap" {
+		t.Errorf("Expected project=Roadmap in create_issue config, got: %v", project)
+	}
+	if !strings.Contains(compiledStr, "GH_AW_PROJECT_GITHUB_TOKEN: ${{ secrets.GH_AW_PROJECT_GITHUB_TOKEN }}") {
+		t.Error("Expected GH_AW_PROJECT_GITHUB_TOKEN to be set for the create-issue project")
+	}
+}
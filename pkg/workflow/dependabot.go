@@ -393,8 +393,8 @@ func (c *Compiler) generateDependabotConfig(path string, ecosystems map[string]s
 		config = DependabotConfig{Version: 2}
 	}
 
-	// Add ecosystems that don't already exist for .github/workflows
-	for ecosystem := range ecosystems {
+	// Add ecosystems that don't already exist for .github/workflows, sorted for deterministic output
+	for _, ecosystem := range sliceutil.SortedKeys(ecosystems) {
 		exists := false
 		for _, update := range config.Updates {
 			if update.PackageEcosystem == ecosystem && update.Directory == "/.github/workflows" {
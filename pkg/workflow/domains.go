@@ -299,6 +299,19 @@ var compoundEcosystems = map[string][]string{
 	"default-safe-outputs": {"defaults", "dev-tools", "github", "local"},
 }
 
+// GetEcosystemIdentifiers returns the sorted ecosystem identifiers accepted in
+// network.allowed and network.blocked, including compound identifiers.
+func GetEcosystemIdentifiers() []string {
+	identifiers := make(map[string]struct{})
+	for id := range getLoadedEcosystemDomains() {
+		identifiers[id] = struct{}{}
+	}
+	for id := range compoundEcosystems {
+		identifiers[id] = struct{}{}
+	}
+	return sliceutil.SortedKeys(identifiers)
+}
+
 // getEcosystemDomains returns the domains for a given ecosystem category.
 // Supports compound ecosystem identifiers (see compoundEcosystems).
 // The returned list is sorted and contains unique entries.
@@ -47,6 +47,7 @@ type EngineConfig struct {
 	ID                 string
 	Version            string
 	LLMProvider        string // Inference provider override for this engine (engine.provider / engine.model-provider)
+	Temperature        string // Sampling temperature for the configured model (engine.temperature), applied as the model's ?temperature= parameter
 	PermissionMode     string
 	MaxTurns           string
 	MaxToolDenials     string // Maximum repeated tool denials before stopping inference (copilot SDK mode only)
@@ -80,6 +81,10 @@ type EngineConfig struct {
 	MCPSessionTimeout string // session-timeout: Go duration string for MCP gateway sessions (e.g. "4h", "30m")
 	MCPToolTimeout    string // tool-timeout: Go duration string for individual MCP tool calls (e.g. "2m", "30s")
 
+	// Fallbacks lists engine IDs to try, in order, when the primary engine fails with a
+	// rate-limit, authentication, or provider-unavailable error (engine: [copilot, claude]).
+	Fallbacks []string
+
 	// Extensions is a list of engine-specific plugin names to install before launching the engine.
 	// Currently used by the Pi engine: each entry is passed to `pi install <extension>`.
 	Extensions []string
@@ -156,11 +161,12 @@ type EngineAuthConfig struct {
 // Ecosystem identifiers in the Allowed list are expanded to their corresponding domain lists.
 // See GetAllowedDomains() for the list of supported ecosystem identifiers.
 type NetworkPermissions struct {
-	Allowed           []string        `yaml:"allowed,omitempty"` // List of allowed domains or ecosystem identifiers (e.g., "defaults", "github", "python")
-	AllowedInput      bool            `yaml:"allowed-input,omitempty"`
-	Blocked           []string        `yaml:"blocked,omitempty"`  // List of blocked domains (takes precedence over allowed)
-	Firewall          *FirewallConfig `yaml:"firewall,omitempty"` // AWF firewall configuration (see firewall.go)
-	ExplicitlyDefined bool            `yaml:"-"`                  // Internal flag: true if network field was explicitly set in frontmatter
+	Allowed           []string            `yaml:"allowed,omitempty"` // List of allowed domains or ecosystem identifiers (e.g., "defaults", "github", "python")
+	AllowedInput      bool                `yaml:"allowed-input,omitempty"`
+	Blocked           []string            `yaml:"blocked,omitempty"`     // List of blocked domains (takes precedence over allowed)
+	Firewall          *FirewallConfig     `yaml:"firewall,omitempty"`    // AWF firewall configuration (see firewall.go)
+	Enforcement       FirewallEnforcement `yaml:"enforcement,omitempty"` // Egress enforcement backend (see firewall_backend.go); empty means squid
+	ExplicitlyDefined bool                `yaml:"-"`                     // Internal flag: true if network field was explicitly set in frontmatter
 }
 
 // EngineNetworkConfig combines engine configuration with top-level network permissions
@@ -218,9 +224,32 @@ func (c *Compiler) ExtractEngineConfig(frontmatter map[string]any) (string, *Eng
 	if engineObj, ok := engine.(map[string]any); ok {
 		return extractObjectEngineConfig(engineObj, topLevel)
 	}
+	if engineList, ok := engine.([]any); ok {
+		return extractEngineChainConfig(engineList, topLevel)
+	}
 	return buildTopLevelOnlyEngineConfig(topLevel)
 }
 
+// extractEngineChainConfig parses the ordered list form (engine: [copilot, claude]).
+// The first entry is the primary engine; the remaining entries become fallbacks.
+func extractEngineChainConfig(engineList []any, topLevel engineTopLevelConfig) (string, *EngineConfig, string) {
+	var ids []string
+	for _, item := range engineList {
+		if id, ok := item.(string); ok && id != "" {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return buildTopLevelOnlyEngineConfig(topLevel)
+	}
+	engineLog.Printf("Found engine fallback chain: %v", ids)
+	engineSetting, config, model := extractStringEngineConfig(ids[0], topLevel)
+	if len(ids) > 1 {
+		config.Fallbacks = ids[1:]
+	}
+	return engineSetting, config, model
+}
+
 func parseTopLevelEngineConfig(frontmatter map[string]any) engineTopLevelConfig {
 	topLevel := engineTopLevelConfig{
 		maxTurns:           parseMaxTurnsValue(frontmatter["max-turns"]),
@@ -338,6 +367,7 @@ func applyReferencedEngineFields(config *EngineConfig, engineObj map[string]any,
 	applyEngineTurnFields(config, engineObj, topLevel)
 	applyEngineConcurrencyField(config, engineObj)
 	applyEngineStringFields(config, engineObj)
+	applyEngineTemperatureField(config, engineObj)
 	applyEngineHarnessField(config, engineObj)
 	applyEngineEnvField(config, engineObj)
 	applyEngineAuthField(config, engineObj)
@@ -421,6 +451,24 @@ func applyEngineConcurrencyField(config *EngineConfig, engineObj map[string]any)
 	}
 }
 
+func applyEngineTemperatureField(config *EngineConfig, engineObj map[string]any) {
+	switch temperature := engineObj["temperature"].(type) {
+	case float64:
+		config.Temperature = strconv.FormatFloat(temperature, 'f', -1, 64)
+	case int:
+		config.Temperature = strconv.Itoa(temperature)
+	case int64:
+		config.Temperature = strconv.FormatInt(temperature, 10)
+	case uint64:
+		config.Temperature = strconv.FormatUint(temperature, 10)
+	case string:
+		config.Temperature = strings.TrimSpace(temperature)
+	}
+	if config.Temperature != "" {
+		engineLog.Printf("Extracted engine.temperature: %s", config.Temperature)
+	}
+}
+
 func applyEngineStringFields(config *EngineConfig, engineObj map[string]any) {
 	if userAgent, ok := engineObj["user-agent"].(string); ok {
 		config.UserAgent = userAgent
@@ -97,7 +97,7 @@ func TestEngineCatalog_BuiltInsPresent(t *testing.T) {
 func TestEngineCatalogMatchesSchema(t *testing.T) {
 	variants := engineSchemaOneOfVariants(t)
 
-	require.Len(t, variants, 6, "engine_config oneOf should have exactly 6 variants: string, object-with-id, object-with-runtime, engine-definition, mcp-only, model-only")
+	require.Len(t, variants, 7, "engine_config oneOf should have exactly 7 variants: string, object-with-id, object-with-runtime, engine-definition, mcp-only, model-only, fallback-chain")
 
 	// Variant 0: plain string (no enum — allows built-ins and custom named catalog entries)
 	assert.Equal(t, "string", variants[0]["type"],
@@ -162,4 +162,11 @@ func TestEngineCatalogMatchesSchema(t *testing.T) {
 		"model-only variant must NOT have an 'id' property")
 	assert.NotContains(t, props5, "runtime",
 		"model-only variant must NOT have a 'runtime' property")
+
+	// Variant 6: ordered engine list — the primary engine followed by fallback engines
+	assert.Equal(t, "array", variants[6]["type"],
+		"seventh variant should be type array (engine fallback chain)")
+	items6, ok := variants[6]["items"].(map[string]any)
+	require.True(t, ok, "fallback chain variant should define items")
+	assert.Equal(t, "string", items6["type"], "fallback chain entries should be engine name strings")
 }
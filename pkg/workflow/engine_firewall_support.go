@@ -156,8 +156,13 @@ func generateFirewallLogParsingStep(workflowName string, workflowData *WorkflowD
 func defaultGetSquidLogsSteps(workflowData *WorkflowData, debugLog *logger.Logger) []GitHubActionStep {
 	var steps []GitHubActionStep
 
-	// Only add upload and parsing steps if firewall is enabled
-	if isFirewallEnabled(workflowData) {
+	// Only add upload and parsing steps if firewall is enabled and its backend writes Squid access logs
+	switch {
+	case !isFirewallEnabled(workflowData):
+		debugLog.Print("Firewall disabled, skipping Squid logs upload")
+	case !getFirewallBackend(workflowData).ProducesAccessLogs():
+		debugLog.Printf("Firewall backend %s does not write access logs, skipping Squid logs upload", getFirewallBackend(workflowData).Enforcement())
+	default:
 		debugLog.Printf("Adding Squid logs upload and parsing steps for workflow: %s", workflowData.Name)
 
 		squidLogsUpload := generateSquidLogsUploadStep(workflowData.Name, workflowData)
@@ -166,8 +171,6 @@ func defaultGetSquidLogsSteps(workflowData *WorkflowData, debugLog *logger.Logge
 		// Add firewall log parsing step to create step summary
 		firewallLogParsing := generateFirewallLogParsingStep(workflowData.Name, workflowData)
 		steps = append(steps, firewallLogParsing)
-	} else {
-		debugLog.Print("Firewall disabled, skipping Squid logs upload")
 	}
 
 	return steps
@@ -211,6 +211,26 @@ func TestGetNpmBinPathSetup_NoGorootDoesNotBreakChain(t *testing.T) {
 	}
 }
 
+// TestGetNpmBinPathSetup_MissingToolCacheDir verifies that self-hosted runners whose
+// RUNNER_TOOL_CACHE directory does not exist (no actions/setup-* tools were ever cached)
+// keep the command chain running with PATH unchanged.
+func TestGetNpmBinPathSetup_MissingToolCacheDir(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("Skipping shell-based test on non-Linux platform")
+	}
+
+	missingToolCache := filepath.Join(t.TempDir(), "_tool")
+	shellCmd := fmt.Sprintf(`unset GOROOT ERLANG_HOME; export RUNNER_TOOL_CACHE=%q; export PATH=/usr/bin:/bin; %s && echo "PATH=$PATH"`, missingToolCache, GetNpmBinPathSetup())
+
+	output, err := exec.Command("bash", "-c", shellCmd).Output()
+	if err != nil {
+		t.Fatalf("PATH setup should not fail when the tool cache directory is missing: %v", err)
+	}
+	if result := strings.TrimSpace(string(output)); result != "PATH=/usr/bin:/bin" {
+		t.Errorf("Expected PATH to be unchanged when the tool cache directory is missing, got: %q", result)
+	}
+}
+
 // TestGetNpmBinPathSetup_ErlangHomeOrdering verifies that ERLANG_HOME/bin takes precedence
 // over any Erlang/OTP bins that might appear in RUNNER_TOOL_CACHE, ensuring that the erl
 // binary installed by erlef/setup-beam (in ${RUNNER_TEMP}/.setup-beam/otp/) is found.
@@ -0,0 +1,89 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var engineModelOverrideLog = logger.New("workflow:engine_model_override")
+
+// ModelInputName is the workflow_dispatch input that, when declared, overrides the
+// configured model for manually dispatched runs.
+const ModelInputName = "model"
+
+// hasWorkflowDispatchModelInput reports whether on.workflow_dispatch.inputs declares
+// the model input.
+func hasWorkflowDispatchModelInput(frontmatter map[string]any) bool {
+	onMap, ok := frontmatter["on"].(map[string]any)
+	if !ok {
+		return false
+	}
+	dispatch, ok := onMap["workflow_dispatch"].(map[string]any)
+	if !ok {
+		return false
+	}
+	inputs, ok := dispatch["inputs"].(map[string]any)
+	if !ok {
+		return false
+	}
+	_, declared := inputs[ModelInputName]
+	return declared
+}
+
+// applyEngineModelSettings folds engine.temperature and the optional workflow_dispatch
+// model input into the resolved model string:
+//
+//   - engine.temperature is appended as the model's ?temperature= parameter, so every
+//     engine and aw_info.json see the same identifier ("gpt-5?temperature=0.2").
+//   - When on.workflow_dispatch.inputs.model is declared, the model becomes
+//     ${{ inputs.model || '<configured model>' }} so a dispatched run can pick another
+//     model while all other triggers keep the configured one. The temperature belongs
+//     to the configured model and is not applied to the override.
+//
+// Models that are already expressions are left to the author and are not wrapped.
+func applyEngineModelSettings(model string, engineConfig *EngineConfig, frontmatter map[string]any) (string, error) {
+	temperature := ""
+	if engineConfig != nil {
+		temperature = engineConfig.Temperature
+	}
+
+	if temperature != "" {
+		if model == "" {
+			return "", errors.New("engine.temperature requires a model; set 'model:' so the temperature applies to a known model")
+		}
+		if containsExpression(model) {
+			return "", fmt.Errorf("engine.temperature cannot be combined with the expression model %q; add '?temperature=%s' to the model values instead", model, temperature)
+		}
+		if err := ValidateTemperatureParam(temperature); err != nil {
+			return "", fmt.Errorf("engine.temperature: %w", err)
+		}
+		if parsed, err := ParseModelIdentifier(model); err == nil {
+			if _, exists := parsed.Params[modelParamTemperature]; exists {
+				return "", fmt.Errorf("engine.temperature conflicts with the temperature parameter already set in model %q", model)
+			}
+		}
+		separator := "?"
+		if strings.Contains(model, "?") {
+			separator = "&"
+		}
+		model += separator + modelParamTemperature + "=" + temperature
+		engineModelOverrideLog.Printf("Applied engine.temperature to model: %s", model)
+	}
+
+	if !hasWorkflowDispatchModelInput(frontmatter) || containsExpression(model) {
+		return model, nil
+	}
+
+	if model == "" {
+		// Engines already fall back to their vars-based default when an expression
+		// model evaluates to an empty string.
+		model = fmt.Sprintf("${{ inputs.%s }}", ModelInputName)
+	} else {
+		model = fmt.Sprintf("${{ inputs.%s || '%s' }}", ModelInputName, strings.ReplaceAll(model, "'", "''"))
+	}
+	engineModelOverrideLog.Printf("Wired workflow_dispatch model input into model: %s", model)
+	return model, nil
+}
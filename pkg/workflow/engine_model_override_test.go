@@ -0,0 +1,159 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyEngineModelSettings(t *testing.T) {
+	dispatchWithModelInput := map[string]any{
+		"on": map[string]any{
+			"workflow_dispatch": map[string]any{
+				"inputs": map[string]any{
+					"model": map[string]any{"type": "string"},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		model       string
+		temperature string
+		frontmatter map[string]any
+		want        string
+		wantErr     string
+	}{
+		{
+			name:  "model without settings is unchanged",
+			model: "gpt-5",
+			want:  "gpt-5",
+		},
+		{
+			name:        "temperature appended as model parameter",
+			model:       "gpt-5",
+			temperature: "0.2",
+			want:        "gpt-5?temperature=0.2",
+		},
+		{
+			name:        "temperature appended after existing parameters",
+			model:       "gpt-5?effort=high",
+			temperature: "1",
+			want:        "gpt-5?effort=high&temperature=1",
+		},
+		{
+			name:        "dispatch input overrides configured model",
+			model:       "gpt-5",
+			temperature: "0.2",
+			frontmatter: dispatchWithModelInput,
+			want:        "${{ inputs.model || 'gpt-5?temperature=0.2' }}",
+		},
+		{
+			name:        "dispatch input without configured model",
+			frontmatter: dispatchWithModelInput,
+			want:        "${{ inputs.model }}",
+		},
+		{
+			name:        "expression model is not wrapped",
+			model:       "${{ inputs.model || 'gpt-5' }}",
+			frontmatter: dispatchWithModelInput,
+			want:        "${{ inputs.model || 'gpt-5' }}",
+		},
+		{
+			name:        "temperature requires a model",
+			temperature: "0.2",
+			wantErr:     "requires a model",
+		},
+		{
+			name:        "temperature out of range",
+			model:       "gpt-5",
+			temperature: "3",
+			wantErr:     "out of range",
+		},
+		{
+			name:        "temperature conflicts with model parameter",
+			model:       "gpt-5?temperature=0.5",
+			temperature: "0.2",
+			wantErr:     "conflicts",
+		},
+		{
+			name:        "temperature with expression model",
+			model:       "${{ inputs.model }}",
+			temperature: "0.2",
+			wantErr:     "cannot be combined with the expression model",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frontmatter := tt.frontmatter
+			if frontmatter == nil {
+				frontmatter = map[string]any{"on": "issues"}
+			}
+			got, err := applyEngineModelSettings(tt.model, &EngineConfig{ID: "copilot", Temperature: tt.temperature}, frontmatter)
+			if tt.wantErr != "" {
+				require.Error(t, err, "expected an error")
+				assert.Contains(t, err.Error(), tt.wantErr, "error should explain the problem")
+				return
+			}
+			require.NoError(t, err, "settings should apply cleanly")
+			assert.Equal(t, tt.want, got, "resolved model should match")
+		})
+	}
+}
+
+func TestExtractEngineConfig_Temperature(t *testing.T) {
+	compiler := NewCompiler()
+	_, config, model := compiler.ExtractEngineConfig(map[string]any{
+		"engine": map[string]any{"id": "copilot", "temperature": 0.2},
+		"model":  "gpt-5",
+	})
+	require.NotNil(t, config, "engine config should be extracted")
+	assert.Equal(t, "0.2", config.Temperature, "temperature should be normalized to a string")
+	assert.Equal(t, "gpt-5", model, "model should be resolved independently of temperature")
+}
+
+func TestEngineModelOverrideCompilation(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "engine-model-override-*")
+	workflowPath := filepath.Join(tmpDir, "model-override.md")
+	content := `---
+on:
+  issues:
+    types: [opened]
+  workflow_dispatch:
+    inputs:
+      model:
+        description: Model to use for this run
+        required: false
+        type: string
+permissions:
+  contents: read
+engine:
+  id: copilot
+  temperature: 0.2
+model: gpt-5
+---
+
+# Model override
+
+Triage the issue.
+`
+	require.NoError(t, os.WriteFile(workflowPath, []byte(content), 0644))
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(workflowPath), "workflow with engine.temperature and a model input should compile")
+
+	lockContent, err := os.ReadFile(filepath.Join(tmpDir, "model-override.lock.yml"))
+	require.NoError(t, err, "lock file should be written")
+	lock := string(lockContent)
+
+	assert.Contains(t, lock, `GH_AW_INFO_MODEL: "${{ inputs.model || 'gpt-5?temperature=0.2' }}"`, "aw_info.json should capture the run-time model")
+	assert.Contains(t, lock, "COPILOT_MODEL: ${{ inputs.model || 'gpt-5?temperature=0.2' }}", "engine env should receive the run-time model")
+}
@@ -40,6 +40,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 	"time"
 
@@ -223,6 +224,32 @@ func (c *Compiler) validateEngineMCPToolTimeout(workflowData *WorkflowData) erro
 	return nil
 }
 
+// validateEngineFallbacks validates the engine fallback chain (engine: [copilot, claude]).
+// Each fallback must be an engine whose MCP gateway configuration can be converted at
+// runtime, must differ from the primary engine, and may only appear once.
+func (c *Compiler) validateEngineFallbacks(workflowData *WorkflowData) error {
+	if workflowData == nil || workflowData.EngineConfig == nil || len(workflowData.EngineConfig.Fallbacks) == 0 {
+		return nil
+	}
+
+	primary := workflowData.EngineConfig.ID
+	seen := map[string]bool{primary: true}
+	for _, fallback := range workflowData.EngineConfig.Fallbacks {
+		if !slices.Contains(engineFallbackSupportedEngines, fallback) {
+			return fmt.Errorf("engine: fallback engine %q is not supported. Fallback engines must be one of: %s.\n\nExample:\n  engine: [copilot, claude]\n\nSee: %s",
+				fallback, strings.Join(engineFallbackSupportedEngines, ", "), constants.DocsEnginesURL)
+		}
+		if seen[fallback] {
+			return fmt.Errorf("engine: engine %q appears more than once in the fallback chain. Each engine may only be listed once.\n\nExample:\n  engine: [copilot, claude]\n\nSee: %s",
+				fallback, constants.DocsEnginesURL)
+		}
+		seen[fallback] = true
+	}
+
+	engineValidationLog.Printf("engine fallback chain validated: %s -> %v", primary, workflowData.EngineConfig.Fallbacks)
+	return nil
+}
+
 // validateEngineInlineDefinition validates an inline engine definition parsed from
 // engine.runtime + optional engine.provider in the workflow frontmatter.
 // Returns an error if:
@@ -147,11 +147,12 @@ func (c *Compiler) buildPushEvalsStateJob(data *WorkflowData) (*Job, error) {
 	)
 
 	branchName := evalsBranchName(data.WorkflowID)
+	script := generateGitHubScriptWithRequire("push_experiment_state.cjs")
 	steps = append(steps,
 		"      - name: Push evals results to git\n",
 		"        id: push_evals_state\n",
 		"        if: always()\n",
-		fmt.Sprintf("        uses: %s\n", getCachedActionPin("actions/github-script", data)),
+		fmt.Sprintf("        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", data), script)),
 		"        env:\n",
 		"          GH_TOKEN: ${{ github.token }}\n",
 		"          GITHUB_RUN_ID: ${{ github.run_id }}\n",
@@ -160,12 +161,7 @@ func (c *Compiler) buildPushEvalsStateJob(data *WorkflowData) (*Job, error) {
 		fmt.Sprintf("          GH_AW_STATE_BRANCH: %s\n", branchName),
 		fmt.Sprintf("          GH_AW_STATE_FILES: %s\n", constants.EvalsResultFilename),
 		"          GH_AW_STATE_LABEL: evals results\n",
-		"        with:\n",
-		"          script: |\n",
-		"            const { setupGlobals } = require('"+SetupActionDestination+"/setup_globals.cjs');\n",
-		"            setupGlobals(core, github, context, exec, io, getOctokit);\n",
-		"            const { main } = require('"+SetupActionDestination+"/push_experiment_state.cjs');\n",
-		"            await main();\n",
+		c.githubScriptWith(script),
 	)
 
 	if c.actionMode.IsDev() {
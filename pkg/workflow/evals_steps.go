@@ -80,17 +80,13 @@ func (c *Compiler) buildEvalsJobSteps(data *WorkflowData) []string {
 }
 
 func (c *Compiler) buildParseMCPGatewayLogStep(data *WorkflowData) []string {
+	script := generateGitHubScriptWithRequire("parse_mcp_gateway_log.cjs")
 	return []string{
 		"      - name: Parse MCP Gateway logs for step summary\n",
 		"        if: always()\n",
 		fmt.Sprintf("        id: %s\n", constants.ParseMCPGatewayStepID),
-		fmt.Sprintf("        uses: %s\n", getCachedActionPin("actions/github-script", data)),
-		"        with:\n",
-		"          script: |\n",
-		"            const { setupGlobals } = require('" + SetupActionDestination + "/setup_globals.cjs');\n",
-		"            setupGlobals(core, github, context, exec, io, getOctokit);\n",
-		"            const { main } = require('${{ runner.temp }}/gh-aw/actions/parse_mcp_gateway_log.cjs');\n",
-		"            await main();\n",
+		fmt.Sprintf("        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", data), script)),
+		c.githubScriptWith(script),
 	}
 }
 
@@ -132,21 +128,20 @@ func (c *Compiler) buildSetupEvalsStep(data *WorkflowData) []string {
 setupGlobals(core, github, context, exec, io, getOctokit);
 const { main } = require('` + SetupActionDestination + `/run_evals.cjs');
 await main();`
+	yamlScript := strings.Join(FormatJavaScriptForYAML(script), "")
 
 	steps := []string{
 		"      - name: Setup BinEval evaluations\n",
 		"        if: always()\n",
-		fmt.Sprintf("        uses: %s\n", getCachedActionPin("actions/github-script", data)),
+		fmt.Sprintf("        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", data), yamlScript)),
 		"        env:\n",
 		fmt.Sprintf("          GH_AW_EVALS_QUESTIONS: '%s'\n", escapeYAMLSingleQuoted(questionsJSON)),
 	}
 	steps = appendEvalsModelEnvLines(steps, c.getEvalsEngineID(data), model)
 	steps = append(steps,
 		"          GH_AW_EVALS_PHASE: setup\n",
-		"        with:\n",
-		"          script: |\n",
+		c.githubScriptWith(yamlScript),
 	)
-	steps = append(steps, FormatJavaScriptForYAML(script)...)
 	return steps
 }
 
@@ -291,12 +286,13 @@ func (c *Compiler) buildParseEvalsResultsStep(data *WorkflowData) []string {
 setupGlobals(core, github, context, exec, io, getOctokit);
 const { main } = require('` + SetupActionDestination + `/run_evals.cjs');
 await main();`
+	yamlScript := strings.Join(FormatJavaScriptForYAML(script), "")
 
 	steps := []string{
 		"      - name: Parse BinEval results\n",
 		"        if: always()\n",
 		"        continue-on-error: true\n",
-		fmt.Sprintf("        uses: %s\n", getCachedActionPin("actions/github-script", data)),
+		fmt.Sprintf("        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", data), yamlScript)),
 		"        env:\n",
 		fmt.Sprintf("          GH_AW_EVALS_QUESTIONS: '%s'\n", escapeYAMLSingleQuoted(questionsJSON)),
 	}
@@ -304,10 +300,8 @@ await main();`
 	steps = append(steps,
 		"          GH_AW_EVALS_PHASE: parse\n",
 		"          GITHUB_RUN_ID: ${{ github.run_id }}\n",
-		"        with:\n",
-		"          script: |\n",
+		c.githubScriptWith(yamlScript),
 	)
-	steps = append(steps, FormatJavaScriptForYAML(script)...)
 	return steps
 }
 
@@ -318,6 +312,7 @@ func (c *Compiler) buildRedactEvalsSecretsStep(data *WorkflowData) []string {
 setupGlobals(core, github, context, exec, io, getOctokit);
 const { main } = require('` + SetupActionDestination + `/redact_evals_results.cjs');
 await main();`
+	yamlScript := strings.Join(FormatJavaScriptForYAML(script), "")
 
 	secretReferences := c.collectEvalsSecretReferences(data)
 
@@ -325,7 +320,7 @@ await main();`
 		"      - name: Redact secrets in evals results\n",
 		"        id: redact_evals_results\n",
 		"        if: always()\n",
-		fmt.Sprintf("        uses: %s\n", getCachedActionPin("actions/github-script", data)),
+		fmt.Sprintf("        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", data), yamlScript)),
 	}
 	if len(secretReferences) > 0 {
 		steps = append(steps, "        env:\n")
@@ -339,11 +334,7 @@ await main();`
 			steps = append(steps, fmt.Sprintf("          SECRET_%s: ${{ secrets.%s }}\n", escapedSecretName, secretName))
 		}
 	}
-	steps = append(steps,
-		"        with:\n",
-		"          script: |\n",
-	)
-	steps = append(steps, FormatJavaScriptForYAML(script)...)
+	steps = append(steps, c.githubScriptWith(yamlScript))
 	return steps
 }
 
@@ -356,16 +347,15 @@ func (c *Compiler) buildRenderEvalsSummaryStep(data *WorkflowData) []string {
 setupGlobals(core, github, context, exec, io, getOctokit);
 const { main } = require('` + SetupActionDestination + `/render_evals_summary.cjs');
 await main();`
+	yamlScript := strings.Join(FormatJavaScriptForYAML(script), "")
 
 	steps := []string{
 		"      - name: Render evals results to step summary\n",
 		"        if: steps.redact_evals_results.outcome == 'success'\n",
 		"        continue-on-error: true\n",
-		fmt.Sprintf("        uses: %s\n", getCachedActionPin("actions/github-script", data)),
-		"        with:\n",
-		"          script: |\n",
+		fmt.Sprintf("        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", data), yamlScript)),
+		c.githubScriptWith(yamlScript),
 	}
-	steps = append(steps, FormatJavaScriptForYAML(script)...)
 	return steps
 }
 
@@ -150,6 +150,26 @@ func isDockerSbxRuntime(workflowData *WorkflowData) bool {
 	return agentConfig.Runtime == AgentRuntimeDockerSbx
 }
 
+// isAgentFilesystemReadOnly returns true when sandbox.agent.filesystem is read-only.
+func isAgentFilesystemReadOnly(workflowData *WorkflowData) bool {
+	agentConfig := getAgentConfig(workflowData)
+	if agentConfig == nil || agentConfig.Disabled {
+		return false
+	}
+	return agentConfig.Filesystem == AgentFilesystemReadOnly
+}
+
+// isAgentBashNetworkDisabled returns true when sandbox.agent.bash-network is false, so
+// commands spawned by the engine run without network access while the engine process
+// itself still reaches the LLM API through the firewall.
+func isAgentBashNetworkDisabled(workflowData *WorkflowData) bool {
+	agentConfig := getAgentConfig(workflowData)
+	if agentConfig == nil || agentConfig.Disabled || agentConfig.BashNetwork == nil {
+		return false
+	}
+	return !*agentConfig.BashNetwork
+}
+
 func isAWFNetworkIsolationEnabled(workflowData *WorkflowData) bool {
 	agentConfig := getAgentConfig(workflowData)
 	if agentConfig == nil || agentConfig.Disabled {
@@ -0,0 +1,190 @@
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/jsonutil"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var firewallBackendLog = logger.New("workflow:firewall_backend")
+
+// FirewallEnforcement identifies the egress-control implementation selected with network.enforcement.
+type FirewallEnforcement string
+
+const (
+	// FirewallEnforcementSquid routes agent egress through the AWF Squid proxy (default).
+	FirewallEnforcementSquid FirewallEnforcement = "squid"
+	// FirewallEnforcementEBPF enforces the domain policy in the kernel with iptables/eBPF
+	// rules attached to the agent container, without an HTTP proxy.
+	FirewallEnforcementEBPF FirewallEnforcement = "ebpf"
+)
+
+// firewallPolicyVersion is the schema version of the exported firewall-policy.json document.
+const firewallPolicyVersion = 1
+
+// firewallPolicyRuntimePathExpr is the host-side path the firewall policy is written to,
+// next to the AWF config it is derived from.
+const firewallPolicyRuntimePathExpr = "${RUNNER_TEMP}/gh-aw/" + constants.FirewallPolicyFilename
+
+// FirewallBackend is an egress-control implementation for the agent container.
+// The compiler asks the backend which AWF features it supports and how to select it,
+// so that adding a backend does not require touching the step generators.
+type FirewallBackend interface {
+	// Enforcement returns the network.enforcement value that selects this backend.
+	Enforcement() FirewallEnforcement
+	// SupportsURLFiltering reports whether HTTPS URL path filtering (ssl-bump, allow-urls) is available.
+	SupportsURLFiltering() bool
+	// SupportsNetworkIsolation reports whether the backend works with the rootless network-isolation topology.
+	SupportsNetworkIsolation() bool
+	// ProducesAccessLogs reports whether the backend writes Squid-format access logs to the proxy logs directory.
+	ProducesAccessLogs() bool
+	// AWFArgs returns the AWF CLI arguments that select the backend.
+	AWFArgs() []string
+}
+
+// squidFirewallBackend enforces egress with the AWF Squid proxy.
+type squidFirewallBackend struct{}
+
+func (squidFirewallBackend) Enforcement() FirewallEnforcement { return FirewallEnforcementSquid }
+func (squidFirewallBackend) SupportsURLFiltering() bool       { return true }
+func (squidFirewallBackend) SupportsNetworkIsolation() bool   { return true }
+func (squidFirewallBackend) ProducesAccessLogs() bool         { return true }
+
+// AWFArgs returns no arguments because Squid is the AWF default.
+func (squidFirewallBackend) AWFArgs() []string { return nil }
+
+// ebpfFirewallBackend enforces egress with iptables/eBPF rules on the agent container.
+// Decisions are made per connection from DNS answers and SNI, so there is no proxy to
+// terminate TLS or to write access logs.
+type ebpfFirewallBackend struct{}
+
+func (ebpfFirewallBackend) Enforcement() FirewallEnforcement { return FirewallEnforcementEBPF }
+func (ebpfFirewallBackend) SupportsURLFiltering() bool       { return false }
+func (ebpfFirewallBackend) SupportsNetworkIsolation() bool   { return false }
+func (ebpfFirewallBackend) ProducesAccessLogs() bool         { return false }
+func (ebpfFirewallBackend) AWFArgs() []string {
+	return []string{"--enforcement", string(FirewallEnforcementEBPF)}
+}
+
+// firewallBackends holds the registered backends keyed by enforcement value.
+var firewallBackends = map[FirewallEnforcement]FirewallBackend{
+	FirewallEnforcementSquid: squidFirewallBackend{},
+	FirewallEnforcementEBPF:  ebpfFirewallBackend{},
+}
+
+// getFirewallBackendByEnforcement returns the backend registered for an enforcement value.
+func getFirewallBackendByEnforcement(enforcement FirewallEnforcement) (FirewallBackend, bool) {
+	backend, ok := firewallBackends[enforcement]
+	return backend, ok
+}
+
+// supportedFirewallEnforcements returns the registered enforcement values in sorted order.
+func supportedFirewallEnforcements() []string {
+	values := make([]string, 0, len(firewallBackends))
+	for enforcement := range firewallBackends {
+		values = append(values, string(enforcement))
+	}
+	slices.Sort(values)
+	return values
+}
+
+// getFirewallBackend returns the backend selected by network.enforcement, defaulting to Squid.
+// Unknown values fall back to Squid here; validateFirewallBackend reports them as errors.
+func getFirewallBackend(workflowData *WorkflowData) FirewallBackend {
+	if workflowData != nil && workflowData.NetworkPermissions != nil && workflowData.NetworkPermissions.Enforcement != "" {
+		if backend, ok := getFirewallBackendByEnforcement(workflowData.NetworkPermissions.Enforcement); ok {
+			return backend
+		}
+		firewallBackendLog.Printf("Unknown network.enforcement %q, using squid", workflowData.NetworkPermissions.Enforcement)
+	}
+	return squidFirewallBackend{}
+}
+
+// validateFirewallBackend checks that network.enforcement names a registered backend and that
+// the firewall and sandbox settings only use features the backend supports.
+func validateFirewallBackend(workflowData *WorkflowData) error {
+	if workflowData.NetworkPermissions == nil || workflowData.NetworkPermissions.Enforcement == "" {
+		return nil
+	}
+
+	enforcement := workflowData.NetworkPermissions.Enforcement
+	backend, ok := getFirewallBackendByEnforcement(enforcement)
+	if !ok {
+		return fmt.Errorf("invalid network.enforcement '%s', must be one of: %s", enforcement, strings.Join(supportedFirewallEnforcements(), ", "))
+	}
+	firewallBackendLog.Printf("Validating firewall backend: %s", enforcement)
+
+	if firewallConfig := workflowData.NetworkPermissions.Firewall; firewallConfig != nil && !backend.SupportsURLFiltering() {
+		if firewallConfig.SSLBump || len(firewallConfig.AllowURLs) > 0 {
+			return fmt.Errorf("network.enforcement '%s' does not support ssl-bump or allow-urls; URL path filtering requires the '%s' backend", enforcement, FirewallEnforcementSquid)
+		}
+	}
+	if isAWFNetworkIsolationEnabled(workflowData) && !backend.SupportsNetworkIsolation() {
+		return fmt.Errorf("network.enforcement '%s' cannot be combined with sandbox.agent network isolation; use '%s' or disable network isolation", enforcement, FirewallEnforcementSquid)
+	}
+	return nil
+}
+
+// FirewallPolicy is the compiled egress policy exported as firewall-policy.json so reviewers
+// can inspect the effective domain allowlist without reading the AWF config.
+type FirewallPolicy struct {
+	Version        int                 `json:"version"`
+	Enforcement    FirewallEnforcement `json:"enforcement"`
+	AllowedDomains []string            `json:"allowedDomains"`
+	BlockedDomains []string            `json:"blockedDomains"`
+	AllowedInput   bool                `json:"allowedInput"` // Callers may add domains at runtime via the network_allowed input
+	SSLBump        bool                `json:"sslBump"`
+	AllowURLs      []string            `json:"allowUrls"`
+}
+
+// buildFirewallPolicy derives the exported policy from the generated AWF config JSON, so the
+// domain lists match exactly what AWF enforces.
+func buildFirewallPolicy(workflowData *WorkflowData, awfConfigJSON string) (*FirewallPolicy, error) {
+	var awfConfig AWFConfigFile
+	if err := json.Unmarshal([]byte(awfConfigJSON), &awfConfig); err != nil {
+		return nil, fmt.Errorf("failed to parse AWF config JSON: %w", err)
+	}
+
+	policy := &FirewallPolicy{
+		Version:        firewallPolicyVersion,
+		Enforcement:    getFirewallBackend(workflowData).Enforcement(),
+		AllowedDomains: []string{},
+		BlockedDomains: []string{},
+		AllowedInput:   shouldUseWorkflowCallNetworkAllowedInput(workflowData),
+		AllowURLs:      []string{},
+	}
+	if awfConfig.Network != nil {
+		policy.AllowedDomains = append(policy.AllowedDomains, awfConfig.Network.AllowDomains...)
+		policy.BlockedDomains = append(policy.BlockedDomains, awfConfig.Network.BlockDomains...)
+	}
+	if firewallConfig := getFirewallConfig(workflowData); firewallConfig != nil {
+		policy.SSLBump = firewallConfig.SSLBump
+		policy.AllowURLs = append(policy.AllowURLs, firewallConfig.AllowURLs...)
+	}
+	firewallBackendLog.Printf("Built firewall policy: enforcement=%s, allowed=%d, blocked=%d", policy.Enforcement, len(policy.AllowedDomains), len(policy.BlockedDomains))
+	return policy, nil
+}
+
+// buildFirewallPolicyExportScript returns the shell commands that write firewall-policy.json
+// next to the AWF config and copy it into /tmp/gh-aw for the unified agent artifact.
+func buildFirewallPolicyExportScript(workflowData *WorkflowData, awfConfigJSON string) (string, error) {
+	policy, err := buildFirewallPolicy(workflowData, awfConfigJSON)
+	if err != nil {
+		return "", err
+	}
+	policyJSON, err := jsonutil.MarshalCompactNoHTMLEscape(policy)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal firewall policy: %w", err)
+	}
+	return fmt.Sprintf("printf '%%s\\n' %s > %q\ncp %q %s",
+		shellEscapeArg(policyJSON),
+		firewallPolicyRuntimePathExpr,
+		firewallPolicyRuntimePathExpr,
+		constants.FirewallPolicyFilePath,
+	), nil
+}
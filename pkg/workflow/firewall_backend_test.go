@@ -0,0 +1,131 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetFirewallBackend(t *testing.T) {
+	tests := []struct {
+		name        string
+		network     *NetworkPermissions
+		enforcement FirewallEnforcement
+	}{
+		{name: "no network defaults to squid", network: nil, enforcement: FirewallEnforcementSquid},
+		{name: "unset enforcement defaults to squid", network: &NetworkPermissions{}, enforcement: FirewallEnforcementSquid},
+		{name: "ebpf", network: &NetworkPermissions{Enforcement: FirewallEnforcementEBPF}, enforcement: FirewallEnforcementEBPF},
+		{name: "unknown falls back to squid", network: &NetworkPermissions{Enforcement: "nftables"}, enforcement: FirewallEnforcementSquid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := getFirewallBackend(&WorkflowData{NetworkPermissions: tt.network})
+			assert.Equal(t, tt.enforcement, backend.Enforcement(), "backend should match network.enforcement")
+		})
+	}
+}
+
+func TestValidateFirewallBackend(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    *WorkflowData
+		wantErr string
+	}{
+		{
+			name: "squid supports url filtering",
+			data: &WorkflowData{NetworkPermissions: &NetworkPermissions{
+				Enforcement: FirewallEnforcementSquid,
+				Firewall:    &FirewallConfig{Enabled: true, SSLBump: true, AllowURLs: []string{"https://github.com/org/*"}},
+			}},
+		},
+		{
+			name: "ebpf without proxy features",
+			data: &WorkflowData{NetworkPermissions: &NetworkPermissions{Enforcement: FirewallEnforcementEBPF}},
+		},
+		{
+			name:    "unknown enforcement",
+			data:    &WorkflowData{NetworkPermissions: &NetworkPermissions{Enforcement: "nftables"}},
+			wantErr: "must be one of: ebpf, squid",
+		},
+		{
+			name: "ebpf rejects ssl-bump",
+			data: &WorkflowData{NetworkPermissions: &NetworkPermissions{
+				Enforcement: FirewallEnforcementEBPF,
+				Firewall:    &FirewallConfig{Enabled: true, SSLBump: true},
+			}},
+			wantErr: "does not support ssl-bump or allow-urls",
+		},
+		{
+			name: "ebpf rejects network isolation",
+			data: &WorkflowData{
+				NetworkPermissions: &NetworkPermissions{Enforcement: FirewallEnforcementEBPF},
+				SandboxConfig:      &SandboxConfig{Agent: &AgentSandboxConfig{NetworkIsolation: true}},
+			},
+			wantErr: "network isolation",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateFirewallBackend(tt.data)
+			if tt.wantErr == "" {
+				assert.NoError(t, err, "configuration should be valid")
+				return
+			}
+			require.Error(t, err, "configuration should be rejected")
+			assert.Contains(t, err.Error(), tt.wantErr, "error should explain the conflict")
+		})
+	}
+}
+
+func TestFirewallBackendInAWFCommand(t *testing.T) {
+	newConfig := func(enforcement FirewallEnforcement) AWFCommandConfig {
+		return AWFCommandConfig{
+			EngineName:     "copilot",
+			EngineCommand:  "copilot --prompt test",
+			LogFile:        "/tmp/gh-aw/agent-stdio.log",
+			AllowedDomains: "github.com,api.github.com",
+			WorkflowData: &WorkflowData{
+				EngineConfig: &EngineConfig{ID: "copilot"},
+				NetworkPermissions: &NetworkPermissions{
+					Enforcement: enforcement,
+					Firewall:    &FirewallConfig{Enabled: true},
+				},
+			},
+		}
+	}
+
+	t.Run("squid adds no backend args", func(t *testing.T) {
+		args := BuildAWFArgs(newConfig(""))
+		assert.NotContains(t, args, "--enforcement", "squid is the AWF default")
+	})
+
+	t.Run("ebpf selects the backend", func(t *testing.T) {
+		args := strings.Join(BuildAWFArgs(newConfig(FirewallEnforcementEBPF)), " ")
+		assert.Contains(t, args, "--enforcement ebpf", "ebpf backend should be passed to AWF")
+	})
+
+	t.Run("policy is exported next to the AWF config", func(t *testing.T) {
+		command := BuildAWFCommand(newConfig(FirewallEnforcementEBPF))
+		assert.Contains(t, command, `"enforcement":"ebpf"`, "policy should record the backend")
+		assert.Contains(t, command, `"allowedDomains":["github.com","api.github.com"]`, "policy domains should come from the AWF config")
+		assert.Contains(t, command, "/tmp/gh-aw/firewall-policy.json", "policy should be copied for the agent artifact")
+	})
+}
+
+func TestDefaultGetSquidLogsStepsSkipsBackendsWithoutAccessLogs(t *testing.T) {
+	data := &WorkflowData{
+		Name: "ebpf-workflow",
+		NetworkPermissions: &NetworkPermissions{
+			Enforcement: FirewallEnforcementEBPF,
+			Firewall:    &FirewallConfig{Enabled: true},
+		},
+	}
+	assert.Empty(t, defaultGetSquidLogsSteps(data, firewallBackendLog), "ebpf does not write Squid access logs")
+
+	data.NetworkPermissions.Enforcement = FirewallEnforcementSquid
+	assert.Len(t, defaultGetSquidLogsSteps(data, firewallBackendLog), 2, "squid logs should be uploaded and parsed")
+}
@@ -2,6 +2,7 @@
 //
 // This file contains domain-specific validation functions for firewall configuration:
 //   - validateFirewallConfig() - Validates the overall firewall configuration
+//   - validateFirewallBackend() - Validates network.enforcement (see firewall_backend.go)
 //   - ValidateLogLevel() - Validates firewall log-level values
 //
 // These validation functions are organized in a dedicated file following the validation
@@ -19,8 +20,11 @@ import (
 
 var firewallValidationLog = logger.New("workflow:firewall_validation")
 
-// validateFirewallConfig validates firewall configuration including log-level
+// validateFirewallConfig validates firewall configuration including the enforcement backend and log-level
 func (c *Compiler) validateFirewallConfig(workflowData *WorkflowData) error {
+	if err := validateFirewallBackend(workflowData); err != nil {
+		return err
+	}
 	if workflowData.NetworkPermissions == nil || workflowData.NetworkPermissions.Firewall == nil {
 		return nil
 	}
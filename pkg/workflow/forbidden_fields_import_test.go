@@ -26,9 +26,11 @@ func TestForbiddenFieldsImportRejection(t *testing.T) {
 		"github-token":    `github-token: ${{ secrets.TOKEN }}`,
 		"if":              `if: success()`,
 		"name":            `name: Test Workflow`,
+		"needs-workflow":  `needs-workflow: ci`,
 		"run-name":        `run-name: Test Run`,
 		"runs-on":         `runs-on: ubuntu-latest`,
 		"sandbox":         `sandbox: {enabled: true}`,
+		"skip-if":         `skip-if: github.event_name == 'schedule'`,
 		"strict":          `strict: true`,
 		"timeout-minutes": `timeout-minutes: 30`,
 		"tracker-id":      `tracker-id: "12345"`,
@@ -60,6 +60,12 @@ func (c *Compiler) extractNetworkPermissions(frontmatter map[string]any) *Networ
 				}
 			}
 
+			// Extract enforcement backend if present (validated in validateFirewallBackend)
+			if enforcement, ok := networkObj["enforcement"].(string); ok {
+				permissions.Enforcement = FirewallEnforcement(enforcement)
+				frontmatterExtractionSecurityLog.Printf("Extracted network enforcement: %s", enforcement)
+			}
+
 			// Empty object {} means no network access (empty allowed list)
 			return permissions
 		}
@@ -274,6 +280,22 @@ func (c *Compiler) extractAgentSandboxConfig(agentVal any) *AgentSandboxConfig {
 		}
 	}
 
+	// Extract filesystem (host filesystem access profile for the agent)
+	if fsVal, hasFS := agentObj["filesystem"]; hasFS {
+		if fsStr, ok := fsVal.(string); ok {
+			agentConfig.Filesystem = AgentFilesystemMode(fsStr)
+			frontmatterExtractionSecurityLog.Printf("Extracted sandbox.agent.filesystem: %s", fsStr)
+		}
+	}
+
+	// Extract bash-network (network access for commands spawned by the engine)
+	if bashNetVal, hasBashNet := agentObj["bash-network"]; hasBashNet {
+		if bashNetBool, ok := bashNetVal.(bool); ok {
+			agentConfig.BashNetwork = &bashNetBool
+			frontmatterExtractionSecurityLog.Printf("Extracted sandbox.agent.bash-network: %t", bashNetBool)
+		}
+	}
+
 	// Extract legacy-security (opt-in to legacy sudo/iptables mode)
 	if legacyVal, hasLegacy := agentObj["legacy-security"]; hasLegacy {
 		if legacyStr, ok := legacyVal.(string); ok && legacyStr == "enable" {
@@ -199,6 +199,20 @@ func (c *Compiler) extractIfCondition(frontmatter map[string]any) (string, error
 	return ifExpr, nil
 }
 
+// extractSkipIfCondition extracts the workflow-level skip-if expression from frontmatter
+// as a bare expression (the ${{ }} wrapper is optional and stripped).
+func extractSkipIfCondition(frontmatter map[string]any) string {
+	value, ok := frontmatter["skip-if"].(string)
+	if !ok {
+		return ""
+	}
+	skipIf := stripExpressionWrapper(value)
+	if skipIf != "" {
+		frontmatterLog.Printf("Extracted skip-if condition from frontmatter: %s", skipIf)
+	}
+	return skipIf
+}
+
 // extractExpressionFromIfString extracts the expression part from a string that might
 // contain "if: expression" or just "expression", returning just the expression
 func (c *Compiler) extractExpressionFromIfString(ifString string) string {
@@ -142,7 +142,7 @@ func (fc *FrontmatterConfig) ToMap() map[string]any {
 	if fc.Network != nil {
 		// Convert NetworkPermissions to map format
 		// If allowed list is just ["defaults"], convert to string format "defaults"
-		if len(fc.Network.Allowed) == 1 && fc.Network.Allowed[0] == "defaults" && !fc.Network.AllowedInput && fc.Network.Firewall == nil && len(fc.Network.Blocked) == 0 {
+		if len(fc.Network.Allowed) == 1 && fc.Network.Allowed[0] == "defaults" && !fc.Network.AllowedInput && fc.Network.Firewall == nil && len(fc.Network.Blocked) == 0 && fc.Network.Enforcement == "" {
 			result["network"] = "defaults"
 		} else {
 			networkMap := make(map[string]any)
@@ -158,6 +158,9 @@ func (fc *FrontmatterConfig) ToMap() map[string]any {
 			if fc.Network.Firewall != nil {
 				networkMap["firewall"] = fc.Network.Firewall
 			}
+			if fc.Network.Enforcement != "" {
+				networkMap["enforcement"] = string(fc.Network.Enforcement)
+			}
 			if len(networkMap) > 0 {
 				result["network"] = networkMap
 			}
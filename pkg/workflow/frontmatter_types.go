@@ -327,10 +327,11 @@ type FrontmatterConfig struct {
 	// Configuration sections - using strongly-typed structs
 	Tools            *ToolsConfig               `json:"tools,omitempty"`
 	LSP              map[string]LSPServerConfig `json:"lsp,omitempty"`
-	MCPServers       map[string]any             `json:"mcp-servers,omitempty"` // Legacy field, use Tools instead
-	RuntimesTyped    *RuntimesConfig            `json:"-"`                     // New typed field (not in JSON to avoid conflict)
-	Runtimes         map[string]any             `json:"runtimes,omitempty"`    // Deprecated: use RuntimesTyped
-	Jobs             map[string]any             `json:"jobs,omitempty"`        // Custom workflow jobs (too dynamic to type)
+	ToolTimeouts     map[string]int             `json:"tool-timeouts,omitempty"` // Per-tool timeouts in seconds
+	MCPServers       map[string]any             `json:"mcp-servers,omitempty"`   // Legacy field, use Tools instead
+	RuntimesTyped    *RuntimesConfig            `json:"-"`                       // New typed field (not in JSON to avoid conflict)
+	Runtimes         map[string]any             `json:"runtimes,omitempty"`      // Deprecated: use RuntimesTyped
+	Jobs             map[string]any             `json:"jobs,omitempty"`          // Custom workflow jobs (too dynamic to type)
 	SafeOutputs      *SafeOutputsConfig         `json:"safe-outputs,omitempty"`
 	MCPScripts       *MCPScriptsConfig          `json:"mcp-scripts,omitempty"`
 	PermissionsTyped *PermissionsConfig         `json:"-"` // New typed field (not in JSON to avoid conflict)
@@ -0,0 +1,70 @@
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+)
+
+var githubContextImportsLog = logger.New("workflow:github_context_imports")
+
+// githubContextImportMarker returns the prompt line that the fetch step replaces with
+// the content of an issue or discussion import.
+func githubContextImportMarker(ref parser.GitHubContextImport) string {
+	return fmt.Sprintf("{{#github-context %s}}", ref.Key())
+}
+
+// githubContextImportChunks returns one prompt marker chunk per issue or discussion import.
+func githubContextImportChunks(data *WorkflowData) []string {
+	chunks := make([]string, 0, len(data.GitHubContextImports))
+	for _, ref := range data.GitHubContextImports {
+		chunks = append(chunks, githubContextImportMarker(ref))
+	}
+	return chunks
+}
+
+// addActivationGitHubContextImportPermissions grants the read scopes the fetch step needs.
+func addActivationGitHubContextImportPermissions(permsMap map[PermissionScope]PermissionLevel, data *WorkflowData) {
+	for _, ref := range data.GitHubContextImports {
+		scope := PermissionIssues
+		if ref.Kind == parser.GitHubContextKindDiscussion {
+			scope = PermissionDiscussions
+		}
+		if _, exists := permsMap[scope]; !exists {
+			permsMap[scope] = PermissionRead
+		}
+	}
+}
+
+// generateGitHubContextImportStep emits the step that fetches issue and discussion
+// imports and replaces their prompt markers with sanitized content. It runs after
+// placeholder validation so the fetched text is never interpreted as template
+// syntax or expression placeholders.
+func (c *Compiler) generateGitHubContextImportStep(yaml *strings.Builder, data *WorkflowData) {
+	if len(data.GitHubContextImports) == 0 {
+		return
+	}
+	githubContextImportsLog.Printf("Generating fetch step for %d GitHub context imports", len(data.GitHubContextImports))
+
+	importsJSON, err := json.Marshal(data.GitHubContextImports)
+	if err != nil {
+		// The slice only holds strings, ints, and bools, so this cannot fail.
+		githubContextImportsLog.Printf("Failed to marshal GitHub context imports: %v", err)
+		return
+	}
+
+	yaml.WriteString("      - name: Fetch issue and discussion imports\n")
+	script := generateGitHubScriptWithRequire("fetch_github_context_imports.cjs")
+	fmt.Fprintf(yaml, "        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", data), script))
+	yaml.WriteString("        env:\n")
+	yaml.WriteString("          GH_AW_PROMPT: /tmp/gh-aw/aw-prompts/prompt.txt\n")
+	writeYAMLEnv(yaml, "          ", "GH_AW_GITHUB_CONTEXT_IMPORTS", string(importsJSON))
+	var tokenInputs []string
+	if token := c.resolveActivationToken(data); token != "${{ secrets.GITHUB_TOKEN }}" {
+		tokenInputs = append(tokenInputs, fmt.Sprintf("          github-token: %s\n", token))
+	}
+	yaml.WriteString(c.githubScriptWith(script, tokenInputs...))
+}
@@ -0,0 +1,90 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitHubContextImportsCompile(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "github-context-imports-test")
+
+	workflow := `---
+on: workflow_dispatch
+engine: copilot
+permissions:
+  contents: read
+imports:
+  - issue:12
+  - uses: https://github.com/octo/repo/discussions/3
+    max-size: 5000
+    comments: false
+---
+
+# Test Workflow
+
+Summarize the linked issue and discussion.
+`
+	testFile := filepath.Join(tmpDir, "context-imports.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(workflow), 0644), "Failed to write test workflow")
+
+	compiler := NewCompiler(WithVersion("1.0.0"))
+	require.NoError(t, compiler.CompileWorkflow(testFile), "Failed to compile workflow")
+
+	lockContent, err := os.ReadFile(filepath.Join(tmpDir, "context-imports.lock.yml"))
+	require.NoError(t, err, "Failed to read lock file")
+	lock := string(lockContent)
+
+	assert.Contains(t, lock, "{{#github-context issue:12}}", "prompt should contain the issue marker")
+	assert.Contains(t, lock, "{{#github-context discussion:octo/repo#3}}", "prompt should contain the discussion marker")
+	assert.Contains(t, lock, "- name: Fetch issue and discussion imports", "fetch step should be generated")
+	assert.Contains(t, lock, `GH_AW_GITHUB_CONTEXT_IMPORTS: "[{\"kind\":\"issue\",\"number\":12,\"comments\":true},{\"kind\":\"discussion\",\"repo\":\"octo/repo\",\"number\":3,\"max_size\":5000,\"comments\":false}]"`, "fetch step should receive the imports")
+	assert.Contains(t, lock, "fetch_github_context_imports.cjs", "fetch step should run the fetch script")
+
+	validateIdx := strings.Index(lock, "- name: Validate prompt placeholders")
+	fetchIdx := strings.Index(lock, "- name: Fetch issue and discussion imports")
+	printIdx := strings.Index(lock, "- name: Print prompt")
+	assert.Less(t, validateIdx, fetchIdx, "fetch step should run after placeholder validation")
+	assert.Less(t, fetchIdx, printIdx, "fetch step should run before the prompt is printed")
+
+	activationStart := strings.Index(lock, "\n  activation:")
+	require.NotEqual(t, -1, activationStart, "activation job should exist")
+	activationSection := lock[activationStart:]
+	if end := strings.Index(activationSection[1:], "\n  agent:"); end != -1 {
+		activationSection = activationSection[:end]
+	}
+	assert.Contains(t, activationSection, "issues: read", "activation job should read issues")
+	assert.Contains(t, activationSection, "discussions: read", "activation job should read discussions")
+}
+
+func TestGitHubContextImportsAbsent(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "github-context-imports-absent-test")
+
+	workflow := `---
+on: workflow_dispatch
+engine: copilot
+permissions:
+  contents: read
+---
+
+# Test Workflow
+
+Do the work.
+`
+	testFile := filepath.Join(tmpDir, "no-context-imports.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(workflow), 0644), "Failed to write test workflow")
+
+	compiler := NewCompiler(WithVersion("1.0.0"))
+	require.NoError(t, compiler.CompileWorkflow(testFile), "Failed to compile workflow")
+
+	lockContent, err := os.ReadFile(filepath.Join(tmpDir, "no-context-imports.lock.yml"))
+	require.NoError(t, err, "Failed to read lock file")
+	assert.NotContains(t, string(lockContent), "Fetch issue and discussion imports", "no fetch step without issue or discussion imports")
+}
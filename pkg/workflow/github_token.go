@@ -246,7 +246,7 @@ func resolveStaticCheckoutToken(safeOutputs *SafeOutputsConfig, checkoutMgr *Che
 }
 
 // resolveProjectToken resolves the project token using precedence:
-//  1. Per-config token (e.g., update-project/create-project/create-project-status-update)
+//  1. Per-config token (e.g., update-project/create-project/create-project-status-update/update-project-item)
 //  2. safe-outputs.github-token
 //  3. GH_AW_PROJECT_GITHUB_TOKEN fallback via getEffectiveProjectGitHubToken()
 func resolveProjectToken(perConfigToken string, safeOutputsToken string) string {
@@ -258,7 +258,7 @@ func resolveProjectToken(perConfigToken string, safeOutputsToken string) string
 }
 
 // resolveProjectURLAndToken resolves project URL/token from project-related safe output config.
-// Priority: update-project > create-project-status-update > create-project.
+// Priority: update-project > create-project-status-update > update-project-item > create-project > create-issue.
 func resolveProjectURLAndToken(safeOutputs *SafeOutputsConfig) (projectURL, projectToken string) {
 	if safeOutputs == nil {
 		return "", ""
@@ -282,9 +282,29 @@ func resolveProjectURLAndToken(safeOutputs *SafeOutputsConfig) (projectURL, proj
 		return
 	}
 
+	// update-project-item's project is optional (agents may pass it per message), so its
+	// token applies even when no default project URL is configured.
+	if safeOutputs.UpdateProjectItems != nil {
+		projectURL = safeOutputs.UpdateProjectItems.Project
+		projectToken = resolveProjectToken(safeOutputs.UpdateProjectItems.GitHubToken, safeOutputsToken)
+		if projectURL != "" {
+			tokenLog.Printf("Setting GH_AW_PROJECT_URL from update-project-item config: %s", projectURL)
+		}
+		tokenLog.Printf("Setting GH_AW_PROJECT_GITHUB_TOKEN from update-project-item config")
+		return
+	}
+
 	if safeOutputs.CreateProjects != nil {
 		projectToken = resolveProjectToken(safeOutputs.CreateProjects.GitHubToken, safeOutputsToken)
 		tokenLog.Printf("Setting GH_AW_PROJECT_GITHUB_TOKEN from create-project config")
+		return
+	}
+
+	// create-issue adds issues to its project with the project token; its github-token only
+	// covers issue creation, so it is not used for the project.
+	if safeOutputs.CreateIssues != nil && safeOutputs.CreateIssues.Project != "" {
+		projectToken = resolveProjectToken("", safeOutputsToken)
+		tokenLog.Printf("Setting GH_AW_PROJECT_GITHUB_TOKEN from create-issue project config")
 	}
 
 	return
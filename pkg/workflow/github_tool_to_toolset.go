@@ -22,3 +22,15 @@ var getGitHubToolToToolsetMap = sync.OnceValues(func() (map[string]string, error
 	githubToolToToolsetLog.Printf("Loaded GitHub tool-to-toolset mapping: %d entries", len(toolToToolsetMap))
 	return toolToToolsetMap, nil
 })
+
+// GitHubToolToolset returns the GitHub MCP toolset that provides toolName. The boolean is
+// false when the tool is not in the tool-to-toolset mapping.
+func GitHubToolToolset(toolName string) (string, bool) {
+	toolToToolset, err := getGitHubToolToToolsetMap()
+	if err != nil {
+		githubToolToToolsetLog.Printf("Failed to load tool-to-toolset mapping: %v", err)
+		return "", false
+	}
+	toolset, ok := toolToToolset[toolName]
+	return toolset, ok
+}
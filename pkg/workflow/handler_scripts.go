@@ -0,0 +1,299 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/gitutil"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var handlerScriptsLog = logger.New("workflow:handler_scripts")
+
+// HandlerScriptMode controls how compiled workflows reference the JavaScript handler
+// scripts that the setup action copies to the runner.
+type HandlerScriptMode string
+
+const (
+	// HandlerScriptModeAction loads handler scripts from the setup action (default).
+	HandlerScriptModeAction HandlerScriptMode = "action"
+	// HandlerScriptModeFiles writes the handler scripts used by a workflow to
+	// .github/aw/scripts. Each job checks them out and installs them over the setup
+	// action's copies, so the reviewed files are the code that runs.
+	HandlerScriptModeFiles HandlerScriptMode = "files"
+	// HandlerScriptModeInline inlines each handler entry script, minified, into the
+	// github-script step that runs it. Shared helpers still come from the setup action.
+	HandlerScriptModeInline HandlerScriptMode = "inline"
+)
+
+// HandlerScriptsDir is the repository directory handler scripts are written to in files mode.
+const HandlerScriptsDir = ".github/aw/scripts"
+
+// handlerScriptsCheckoutPath is the workspace path handler scripts are checked out to
+// before being installed into the setup action destination.
+const handlerScriptsCheckoutPath = ".gh-aw-handler-scripts"
+
+// String returns the string representation of the handler script mode.
+func (m HandlerScriptMode) String() string {
+	return string(m)
+}
+
+// IsValid checks if the handler script mode is valid.
+func (m HandlerScriptMode) IsValid() bool {
+	return m == HandlerScriptModeAction || m == HandlerScriptModeFiles || m == HandlerScriptModeInline
+}
+
+// handlerScriptRequirePattern matches a require of a setup action script in a compiled step,
+// capturing the script file name.
+var handlerScriptRequirePattern = regexp.MustCompile(`require\('` + regexp.QuoteMeta(SetupActionDestination) + `/([A-Za-z0-9_.-]+\.cjs)'\)`)
+
+// handlerScriptLocalRequirePattern matches a relative require between handler scripts.
+var handlerScriptLocalRequirePattern = regexp.MustCompile(`require\(["']\./([A-Za-z0-9_.-]+\.cjs)["']\)`)
+
+// handlerScriptReferencePattern matches any quoted handler script file name. Scripts that
+// load other scripts by computed path, such as the safe output handler manager, name them
+// in string literals.
+var handlerScriptReferencePattern = regexp.MustCompile(`["'](?:\./)?([A-Za-z0-9_.-]+\.cjs)["']`)
+
+// handlerScriptPackageRequirePattern matches a require of a module by name.
+var handlerScriptPackageRequirePattern = regexp.MustCompile(`require\(["']([^./"'][^"']*)["']\)`)
+
+// nodeBuiltinModules are the Node.js modules inlined handler scripts may require by name.
+// Any other module is resolved relative to the setup action, so scripts requiring one
+// keep their require.
+var nodeBuiltinModules = []string{
+	"assert", "buffer", "child_process", "crypto", "dns", "events", "fs", "fs/promises", "http", "https",
+	"net", "os", "path", "perf_hooks", "readline", "stream", "stream/promises", "timers", "timers/promises",
+	"tls", "url", "util", "worker_threads", "zlib",
+}
+
+// applyHandlerScriptMode rewrites the compiled workflow body for the configured handler
+// script mode. In files mode the body is unchanged and the scripts it requires, with their
+// dependencies, are recorded to be written next to the lock file.
+func (c *Compiler) applyHandlerScriptMode(body string) (string, error) {
+	c.pendingHandlerScripts = nil
+	switch c.handlerScriptMode {
+	case HandlerScriptModeFiles:
+		// Handler steps that use shared actions require their scripts from the action
+		required := body
+		for _, action := range c.pendingSharedActions {
+			required += "\n" + strings.Join(action.script, "\n")
+		}
+		scripts, err := collectHandlerScripts(c.handlerScriptSources, handlerScriptEntries(required))
+		if err != nil {
+			return "", err
+		}
+		c.pendingHandlerScripts = scripts
+		handlerScriptsLog.Printf("Recorded %d handler scripts for %s", len(scripts), HandlerScriptsDir)
+		return body, nil
+	case HandlerScriptModeInline:
+		return inlineHandlerScripts(body, c.handlerScriptSources)
+	default:
+		return body, nil
+	}
+}
+
+// handlerScriptEntries returns the setup action scripts required by the compiled body, sorted.
+func handlerScriptEntries(body string) []string {
+	seen := make(map[string]bool)
+	for _, match := range handlerScriptRequirePattern.FindAllStringSubmatch(body, -1) {
+		seen[match[1]] = true
+	}
+	return slices.Sorted(maps.Keys(seen))
+}
+
+// collectHandlerScripts reads the entry scripts and every script they reference by name.
+func collectHandlerScripts(sources fs.FS, entries []string) (map[string]string, error) {
+	if sources == nil {
+		return nil, errors.New("handler script sources are not available")
+	}
+	scripts := make(map[string]string)
+	for _, name := range entries {
+		if _, err := fs.Stat(sources, name); err != nil {
+			return nil, fmt.Errorf("handler script %s not found: %w", name, err)
+		}
+	}
+	queue := slices.Clone(entries)
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		if _, done := scripts[name]; done {
+			continue
+		}
+		content, err := fs.ReadFile(sources, name)
+		if err != nil {
+			// Referenced names that are not handler scripts, such as user script paths
+			handlerScriptsLog.Printf("Skipping referenced script %s: %v", name, err)
+			continue
+		}
+		scripts[name] = string(content)
+		for _, match := range handlerScriptReferencePattern.FindAllStringSubmatch(string(content), -1) {
+			queue = append(queue, match[1])
+		}
+	}
+	return scripts, nil
+}
+
+// inlineHandlerScripts replaces each require of a setup action entry script in a
+// github-script step with the minified script wrapped in a module scope. Scripts that
+// depend on their own file location or contain GitHub Actions expressions keep their
+// require. Setup action paths are resolved from RUNNER_TEMP at run time instead of the
+// runner.temp expression, because GitHub Actions limits values containing expressions
+// to 21KB.
+func inlineHandlerScripts(body string, sources fs.FS) (string, error) {
+	if sources == nil {
+		return "", errors.New("handler script sources are not available")
+	}
+	minified := make(map[string]string)
+	blockIndent := ""
+	inlinedCount := 0
+	var result strings.Builder
+	result.Grow(len(body))
+	for i, line := range strings.Split(body, "\n") {
+		if i > 0 {
+			result.WriteByte('\n')
+		}
+		trimmed := strings.TrimSpace(line)
+		if blockIndent != "" && trimmed != "" && !strings.HasPrefix(line, blockIndent) {
+			blockIndent = ""
+		}
+		if strings.HasSuffix(trimmed, "script: |") {
+			blockIndent = strings.Repeat(" ", len(line)-len(strings.TrimLeft(line, " "))+2)
+			result.WriteString(line)
+			continue
+		}
+		loc := handlerScriptRequirePattern.FindStringSubmatchIndex(line)
+		if loc == nil || blockIndent == "" {
+			result.WriteString(line)
+			continue
+		}
+		name := line[loc[2]:loc[3]]
+
+		code, ok := minified[name]
+		if !ok {
+			source, err := fs.ReadFile(sources, name)
+			if err != nil {
+				return "", fmt.Errorf("handler script %s not found: %w", name, err)
+			}
+			code = minifyJavaScript(string(source))
+			if !canInlineHandlerScript(code) {
+				handlerScriptsLog.Printf("Keeping require for %s: script cannot be inlined", name)
+				code = ""
+			} else {
+				code = handlerScriptLocalRequirePattern.ReplaceAllStringFunc(code, func(match string) string {
+					return runtimeHandlerScriptRequire(handlerScriptLocalRequirePattern.FindStringSubmatch(match)[1])
+				})
+			}
+			minified[name] = code
+		}
+
+		result.WriteString(line[:loc[0]])
+		if code == "" {
+			result.WriteString(runtimeHandlerScriptRequire(name))
+		} else {
+			result.WriteString("(() => {\n")
+			result.WriteString(blockIndent + "const module = { exports: {} };\n")
+			result.WriteString(blockIndent + "const exports = module.exports;\n")
+			for codeLine := range strings.SplitSeq(code, "\n") {
+				result.WriteString(blockIndent + codeLine + "\n")
+			}
+			result.WriteString(blockIndent + "return module.exports;\n")
+			result.WriteString(blockIndent + "})()")
+			inlinedCount++
+		}
+		// Any further requires on the same line are kept but resolved at run time
+		result.WriteString(handlerScriptRequirePattern.ReplaceAllStringFunc(line[loc[1]:], func(match string) string {
+			return runtimeHandlerScriptRequire(handlerScriptRequirePattern.FindStringSubmatch(match)[1])
+		}))
+	}
+	handlerScriptsLog.Printf("Inlined %d handler script requires (%d distinct scripts)", inlinedCount, len(minified))
+	return result.String(), nil
+}
+
+// canInlineHandlerScript reports whether a minified handler script behaves the same when
+// inlined: it must not locate files relative to itself, require packages installed next
+// to it, or contain text GitHub Actions would evaluate as an expression.
+func canInlineHandlerScript(code string) bool {
+	if strings.Contains(code, "${{") || strings.Contains(code, "__dirname") || strings.Contains(code, "__filename") {
+		return false
+	}
+	for _, match := range handlerScriptPackageRequirePattern.FindAllStringSubmatch(code, -1) {
+		if !slices.Contains(nodeBuiltinModules, strings.TrimPrefix(match[1], "node:")) {
+			return false
+		}
+	}
+	return true
+}
+
+// runtimeHandlerScriptRequire returns a require of a setup action script that resolves
+// the setup action destination from RUNNER_TEMP at run time.
+func runtimeHandlerScriptRequire(name string) string {
+	return "require(process.env.RUNNER_TEMP + '" + strings.TrimPrefix(SetupActionDestination, "${{ runner.temp }}") + "/" + name + "')"
+}
+
+// generateHandlerScriptsInstallSteps returns the steps that install the handler scripts
+// checked in under .github/aw/scripts over the setup action's copies in destination.
+// Returns nil unless the files handler script mode is enabled.
+func (c *Compiler) generateHandlerScriptsInstallSteps(destination string) []string {
+	if c.handlerScriptMode != HandlerScriptModeFiles {
+		return nil
+	}
+	return []string{
+		"      - name: Checkout handler scripts\n",
+		fmt.Sprintf("        uses: %s\n", getActionPin("actions/checkout")),
+		"        with:\n",
+		"          sparse-checkout: |\n",
+		"            " + HandlerScriptsDir + "\n",
+		"          path: " + handlerScriptsCheckoutPath + "\n",
+		"          persist-credentials: false\n",
+		"      - name: Install handler scripts\n",
+		"        env:\n",
+		"          GH_AW_HANDLER_SCRIPTS_DESTINATION: " + destination + "\n",
+		"        run: |\n",
+		"          cp " + handlerScriptsCheckoutPath + "/" + HandlerScriptsDir + "/*.cjs \"$GH_AW_HANDLER_SCRIPTS_DESTINATION/\"\n",
+		"          rm -rf " + handlerScriptsCheckoutPath + "\n",
+	}
+}
+
+// writeHandlerScripts writes the handler scripts recorded for the current workflow to
+// .github/aw/scripts in the repository containing markdownPath. Files whose content is
+// unchanged are left untouched.
+func (c *Compiler) writeHandlerScripts(markdownPath string) error {
+	if len(c.pendingHandlerScripts) == 0 {
+		return nil
+	}
+	repoRoot, err := gitutil.FindGitRootFrom(filepath.Dir(markdownPath))
+	if err != nil {
+		// Not in a git repository: navigate up from .github/workflows to the repository root
+		repoRoot = filepath.Join(filepath.Dir(markdownPath), "..", "..")
+	}
+	dir := filepath.Join(repoRoot, HandlerScriptsDir)
+	if err := os.MkdirAll(dir, constants.DirPermPublic); err != nil {
+		return fmt.Errorf("failed to create %s: %w", HandlerScriptsDir, err)
+	}
+	written := 0
+	for _, name := range slices.Sorted(maps.Keys(c.pendingHandlerScripts)) {
+		path := filepath.Join(dir, name)
+		content := c.pendingHandlerScripts[name]
+		if existing, err := os.ReadFile(path); err == nil && string(existing) == content {
+			continue
+		}
+		if err := os.WriteFile(path, []byte(content), constants.FilePermPublic); err != nil {
+			return fmt.Errorf("failed to write handler script %s: %w", name, err)
+		}
+		if c.fileTracker != nil {
+			c.fileTracker.TrackCreated(path)
+		}
+		written++
+	}
+	handlerScriptsLog.Printf("Wrote %d of %d handler scripts to %s", written, len(c.pendingHandlerScripts), dir)
+	return nil
+}
@@ -0,0 +1,243 @@
+package workflow
+
+import (
+	"slices"
+	"strings"
+)
+
+// regexPrecedingKeywords are the keywords after which a '/' starts a regular expression
+// literal rather than a division.
+var regexPrecedingKeywords = []string{"return", "typeof", "instanceof", "in", "of", "new", "delete", "void", "throw", "case", "do", "else", "yield", "await"}
+
+// minifyJavaScript removes comments, indentation, blank lines and repeated whitespace from
+// a CommonJS module. Line breaks between statements are kept so automatic semicolon
+// insertion behaves exactly as in the original source, and string, template and regular
+// expression literals are copied unchanged.
+func minifyJavaScript(src string) string {
+	m := &jsMinifier{src: src, atLineStart: true}
+	m.run()
+	return strings.TrimRight(m.out.String(), "\n")
+}
+
+type jsMinifier struct {
+	src          string
+	i            int
+	out          strings.Builder
+	atLineStart  bool
+	pendingSpace bool
+	// templateDepths tracks open braces inside each nested template substitution (${ ... }).
+	templateDepths  []int
+	lastSignificant byte
+	lastWord        string
+}
+
+func (m *jsMinifier) run() {
+	// Drop a leading hashbang line.
+	if strings.HasPrefix(m.src, "#!") {
+		if nl := strings.IndexByte(m.src, '\n'); nl >= 0 {
+			m.i = nl + 1
+		} else {
+			m.i = len(m.src)
+		}
+	}
+	for m.i < len(m.src) {
+		c := m.src[m.i]
+		switch {
+		case c == '\n' || c == '\r':
+			m.newline()
+			m.i++
+		case c == ' ' || c == '\t':
+			for m.i < len(m.src) && (m.src[m.i] == ' ' || m.src[m.i] == '\t') {
+				m.i++
+			}
+			m.space()
+		case c == '/' && m.peek(1) == '/':
+			for m.i < len(m.src) && m.src[m.i] != '\n' {
+				m.i++
+			}
+		case c == '/' && m.peek(1) == '*':
+			end := strings.Index(m.src[m.i+2:], "*/")
+			comment := m.src[m.i:]
+			if end >= 0 {
+				comment = m.src[m.i : m.i+2+end+2]
+			}
+			m.i += len(comment)
+			if strings.Contains(comment, "\n") {
+				m.newline()
+			} else {
+				m.space()
+			}
+		case c == '/' && m.regexAllowed():
+			m.copyRegex()
+		case c == '"' || c == '\'':
+			m.copyString(c)
+		case c == '`':
+			m.write(c)
+			m.i++
+			m.copyTemplate()
+		case c == '{' && len(m.templateDepths) > 0:
+			m.templateDepths[len(m.templateDepths)-1]++
+			m.emit(c)
+		case c == '}' && len(m.templateDepths) > 0 && m.templateDepths[len(m.templateDepths)-1] == 0:
+			// End of a template substitution: continue copying the template literal.
+			m.templateDepths = m.templateDepths[:len(m.templateDepths)-1]
+			m.write(c)
+			m.i++
+			m.copyTemplate()
+		case c == '}' && len(m.templateDepths) > 0:
+			m.templateDepths[len(m.templateDepths)-1]--
+			m.emit(c)
+		default:
+			m.emit(c)
+		}
+	}
+}
+
+func (m *jsMinifier) peek(offset int) byte {
+	if m.i+offset < len(m.src) {
+		return m.src[m.i+offset]
+	}
+	return 0
+}
+
+// emit copies a code character and records it for the regular expression heuristic.
+func (m *jsMinifier) emit(c byte) {
+	m.write(c)
+	m.i++
+	if isJSIdentifierByte(c) {
+		if isJSIdentifierByte(m.lastSignificant) {
+			m.lastWord += string(c)
+		} else {
+			m.lastWord = string(c)
+		}
+	} else {
+		m.lastWord = ""
+	}
+	m.lastSignificant = c
+}
+
+func (m *jsMinifier) write(c byte) {
+	if m.pendingSpace {
+		m.out.WriteByte(' ')
+		m.pendingSpace = false
+	}
+	m.out.WriteByte(c)
+	m.atLineStart = false
+}
+
+// newline ends the current output line, collapsing blank lines.
+func (m *jsMinifier) newline() {
+	m.pendingSpace = false
+	if !m.atLineStart {
+		m.out.WriteByte('\n')
+	}
+	m.atLineStart = true
+}
+
+// space separates the surrounding tokens with a single space, written lazily so
+// that trailing whitespace is never emitted.
+func (m *jsMinifier) space() {
+	if !m.atLineStart {
+		m.pendingSpace = true
+	}
+}
+
+// regexAllowed reports whether a '/' at the current position starts a regular expression.
+func (m *jsMinifier) regexAllowed() bool {
+	switch prev := m.lastSignificant; {
+	case prev == 0:
+		return true
+	case isJSIdentifierByte(prev):
+		return slices.Contains(regexPrecedingKeywords, m.lastWord)
+	default:
+		return !strings.ContainsRune(")]}.", rune(prev))
+	}
+}
+
+func (m *jsMinifier) copyString(quote byte) {
+	m.write(quote)
+	m.i++
+	for m.i < len(m.src) {
+		c := m.src[m.i]
+		m.write(c)
+		m.i++
+		if c == '\\' && m.i < len(m.src) {
+			m.write(m.src[m.i])
+			m.i++
+			continue
+		}
+		if c == quote || c == '\n' {
+			break
+		}
+	}
+	m.lastSignificant = quote
+	m.lastWord = ""
+}
+
+// copyTemplate copies template literal text until the closing backtick or the start
+// of a substitution, which is then minified as code.
+func (m *jsMinifier) copyTemplate() {
+	for m.i < len(m.src) {
+		c := m.src[m.i]
+		switch {
+		case c == '\\' && m.i+1 < len(m.src):
+			m.write(c)
+			m.write(m.src[m.i+1])
+			m.i += 2
+		case c == '`':
+			m.write(c)
+			m.i++
+			m.lastSignificant = '`'
+			m.lastWord = ""
+			return
+		case c == '$' && m.peek(1) == '{':
+			m.write('$')
+			m.write('{')
+			m.i += 2
+			m.templateDepths = append(m.templateDepths, 0)
+			m.lastSignificant = '{'
+			m.lastWord = ""
+			return
+		default:
+			m.write(c)
+			m.i++
+		}
+	}
+}
+
+func (m *jsMinifier) copyRegex() {
+	m.write('/')
+	m.i++
+	inClass := false
+	for m.i < len(m.src) {
+		c := m.src[m.i]
+		if c == '\n' {
+			break
+		}
+		m.write(c)
+		m.i++
+		switch {
+		case c == '\\' && m.i < len(m.src):
+			m.write(m.src[m.i])
+			m.i++
+		case c == '[':
+			inClass = true
+		case c == ']':
+			inClass = false
+		case c == '/' && !inClass:
+			for m.i < len(m.src) && isJSIdentifierByte(m.src[m.i]) {
+				m.write(m.src[m.i])
+				m.i++
+			}
+			m.lastSignificant = '/'
+			m.lastWord = ""
+			return
+		}
+	}
+	m.lastSignificant = '/'
+	m.lastWord = ""
+}
+
+func isJSIdentifierByte(c byte) bool {
+	return c == '_' || c == '$' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c >= 0x80
+}
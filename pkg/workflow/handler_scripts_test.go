@@ -0,0 +1,170 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMinifyJavaScript(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{
+			name: "comments and indentation",
+			src:  "// @ts-check\n/** Docs */\nfunction main() {\n    // comment\n    return 1; /* inline */\n}\n",
+			want: "function main() {\nreturn 1;\n}",
+		},
+		{
+			name: "strings keep comment markers and spaces",
+			src:  "const url = \"https://example.com  //x\";\nconst s = '/* not a comment */';\n",
+			want: "const url = \"https://example.com  //x\";\nconst s = '/* not a comment */';",
+		},
+		{
+			name: "template substitutions are minified",
+			src:  "const msg = `a  // b ${ items.map(i => {  return `${i}  x`; }) }  c`;\n",
+			want: "const msg = `a  // b ${ items.map(i => { return `${i}  x`; }) }  c`;",
+		},
+		{
+			name: "regular expressions",
+			src:  "const re = /\\/\\/ [a-z/]+/g;\nconst half = total / 2; // half\nif (x) return /a b/.test(y);\n",
+			want: "const re = /\\/\\/ [a-z/]+/g;\nconst half = total / 2;\nif (x) return /a b/.test(y);",
+		},
+		{
+			name: "line breaks are kept for ASI",
+			src:  "const a = b\n\n\n(c || d).run()\nconst e = 1\n",
+			want: "const a = b\n(c || d).run()\nconst e = 1",
+		},
+		{
+			name: "hashbang",
+			src:  "#!/usr/bin/env node\nmain();\n",
+			want: "main();",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, minifyJavaScript(tt.src), "minified output")
+		})
+	}
+}
+
+func TestHandlerScriptModeIsValid(t *testing.T) {
+	for _, mode := range []HandlerScriptMode{HandlerScriptModeAction, HandlerScriptModeFiles, HandlerScriptModeInline} {
+		assert.True(t, mode.IsValid(), "mode %s should be valid", mode)
+	}
+	assert.False(t, HandlerScriptMode("bundle").IsValid(), "unknown mode should be invalid")
+}
+
+var testHandlerScripts = fstest.MapFS{
+	"create_issue.cjs":   {Data: []byte("// @ts-check\nconst { helper } = require(\"./helpers.cjs\");\n\n/** Main */\nasync function main() {\n  return helper();\n}\n\nmodule.exports = { main };\n")},
+	"helpers.cjs":        {Data: []byte("const { base } = require('./base.cjs');\nmodule.exports = { helper: () => base };\n")},
+	"base.cjs":           {Data: []byte("module.exports = { base: 1 };\n")},
+	"setup_globals.cjs":  {Data: []byte("module.exports = { setupGlobals() {} };\n")},
+	"load_templates.cjs": {Data: []byte("const path = require(\"path\");\nmodule.exports = { dir: path.join(__dirname, \"templates\") };\n")},
+}
+
+const testHandlerScriptsBody = `jobs:
+  safe_outputs:
+    steps:
+      - name: Create issue
+        uses: actions/github-script@v8
+        with:
+          script: |
+            const { setupGlobals } = require('${{ runner.temp }}/gh-aw/actions/setup_globals.cjs');
+            setupGlobals(core, github, context, exceptions, io, getOctokit);
+            const { main } = require('${{ runner.temp }}/gh-aw/actions/create_issue.cjs');
+            const { dir } = require('${{ runner.temp }}/gh-aw/actions/load_templates.cjs');
+            await main();
+      - name: Run node
+        run: |
+          node -e "require('${{ runner.temp }}/gh-aw/actions/create_issue.cjs')"
+`
+
+func TestCollectHandlerScripts(t *testing.T) {
+	entries := handlerScriptEntries(testHandlerScriptsBody)
+	assert.Equal(t, []string{"create_issue.cjs", "load_templates.cjs", "setup_globals.cjs"}, entries, "entry scripts required by the body")
+
+	scripts, err := collectHandlerScripts(testHandlerScripts, entries)
+	require.NoError(t, err, "scripts should be collected")
+	assert.Len(t, scripts, 5, "referenced scripts should be collected transitively")
+	assert.Equal(t, "module.exports = { base: 1 };\n", scripts["base.cjs"], "scripts are written verbatim")
+
+	_, err = collectHandlerScripts(testHandlerScripts, []string{"missing.cjs"})
+	require.Error(t, err, "missing scripts should be reported")
+	assert.Contains(t, err.Error(), "handler script missing.cjs not found", "error should name the script")
+}
+
+func TestInlineHandlerScripts(t *testing.T) {
+	result, err := inlineHandlerScripts(testHandlerScriptsBody, testHandlerScripts)
+	require.NoError(t, err, "inlining should succeed")
+
+	assert.Contains(t, result, `            const { main } = (() => {
+            const module = { exports: {} };
+            const exports = module.exports;
+            const { helper } = require(process.env.RUNNER_TEMP + '/gh-aw/actions/helpers.cjs');
+            async function main() {
+            return helper();
+            }
+            module.exports = { main };
+            return module.exports;
+            })();`, "entry script should be inlined with helpers resolved from the setup action")
+	assert.Contains(t, result, "const { dir } = require(process.env.RUNNER_TEMP + '/gh-aw/actions/load_templates.cjs');", "scripts using __dirname should keep their require")
+	assert.Contains(t, result, `node -e "require('${{ runner.temp }}/gh-aw/actions/create_issue.cjs')"`, "requires outside github-script steps should be unchanged")
+
+	scriptStart := strings.Index(result, "script: |")
+	scriptEnd := strings.Index(result, "- name: Run node")
+	assert.NotContains(t, result[scriptStart:scriptEnd], "${{", "inlined script blocks should not contain expressions")
+}
+
+func TestCanInlineHandlerScript(t *testing.T) {
+	assert.True(t, canInlineHandlerScript(`const fs = require("fs");
+const { join } = require("node:path");`), "node builtins can be required from inlined code")
+	assert.False(t, canInlineHandlerScript(`const sdk = require("@github/copilot-sdk");`), "packages next to the setup action cannot be resolved")
+	assert.False(t, canInlineHandlerScript(`require(path.join(__dirname, "shim.cjs"));`), "paths relative to the script cannot be resolved")
+	assert.False(t, canInlineHandlerScript("const s = `${{ a: 1 }}`;"), "expression markers would be evaluated by GitHub Actions")
+}
+
+func TestHandlerScriptsInstallSteps(t *testing.T) {
+	compiler := NewCompiler()
+	assert.Nil(t, compiler.generateHandlerScriptsInstallSteps(SetupActionDestination), "no install steps in the default mode")
+
+	compiler.SetHandlerScripts(HandlerScriptModeFiles, testHandlerScripts)
+	steps := strings.Join(compiler.generateHandlerScriptsInstallSteps(SetupActionDestination), "")
+	assert.Contains(t, steps, "          sparse-checkout: |\n            .github/aw/scripts\n", "handler scripts should be checked out sparsely")
+	assert.Contains(t, steps, "          persist-credentials: false\n", "checkout should not persist credentials")
+	assert.Contains(t, steps, "          GH_AW_HANDLER_SCRIPTS_DESTINATION: ${{ runner.temp }}/gh-aw/actions\n", "scripts should be installed into the setup action destination")
+}
+
+func TestCompileWorkflowHandlerScriptsFiles(t *testing.T) {
+	repoRoot := t.TempDir()
+	workflowsDir := filepath.Join(repoRoot, ".github", "workflows")
+	require.NoError(t, os.MkdirAll(workflowsDir, 0o755), "create workflows dir")
+	markdownPath := filepath.Join(workflowsDir, "triage.md")
+	require.NoError(t, os.WriteFile(markdownPath, []byte("---\non: issues\npermissions:\n  contents: read\nengine: copilot\nsafe-outputs:\n  create-issue:\n---\n\n# Triage\n"), 0o644), "write workflow")
+
+	compiler := NewCompiler(WithVersion("1.0.0"))
+	compiler.SetQuiet(true)
+	compiler.SetHandlerScripts(HandlerScriptModeFiles, os.DirFS(filepath.Join("..", "..", "actions", "setup", "js")))
+	require.NoError(t, compiler.CompileWorkflow(markdownPath), "compilation should succeed")
+
+	lockContent, err := os.ReadFile(filepath.Join(workflowsDir, "triage.lock.yml"))
+	require.NoError(t, err, "lock file should be written")
+	assert.Contains(t, string(lockContent), "- name: Install handler scripts", "jobs should install the checked-in handler scripts")
+
+	scriptsDir := filepath.Join(repoRoot, ".github", "aw", "scripts")
+	for _, name := range []string{"create_issue.cjs", "safe_output_handler_manager.cjs"} {
+		assert.FileExists(t, filepath.Join(scriptsDir, name), "handler script %s should be written", name)
+	}
+	for _, name := range handlerScriptEntries(string(lockContent)) {
+		assert.FileExists(t, filepath.Join(scriptsDir, name), "every required script should be written")
+	}
+}
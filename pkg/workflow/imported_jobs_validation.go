@@ -0,0 +1,116 @@
+// This file provides validation for jobs imported from GitHub Actions YAML workflows.
+//
+// # Imported Jobs Validation
+//
+// Jobs from imported .yml workflows are merged into the compiled workflow verbatim.
+// Before merging, each imported job is checked for:
+//   - Name collisions with jobs generated by gh-aw (agent, activation, safe_outputs, ...).
+//     A colliding job would be dropped or merged into the generated job, so this is an
+//     error that suggests a namespaced name.
+//   - Conformance to the GitHub Actions job schema, so mistakes are reported against
+//     the import instead of as a schema error on the generated lock file.
+//
+// Jobs defined in markdown imports or the main frontmatter are not checked here:
+// jobs.<built-in>: entries there intentionally customize the generated jobs.
+//
+// For general validation, see validation.go.
+// For GitHub Actions schema validation of the lock file, see schema_validation.go.
+
+package workflow
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var importedJobsValidationLog = logger.New("workflow:imported_jobs_validation")
+
+// validateYAMLImportJobs validates the jobs that YAML workflow imports contribute to
+// mergedJobsJSON. sources maps each job name to the YAML import that defined it.
+func validateYAMLImportJobs(mergedJobsJSON string, sources map[string]string) error {
+	if len(sources) == 0 || mergedJobsJSON == "" {
+		return nil
+	}
+	importedJobsValidationLog.Printf("Validating %d job(s) imported from YAML workflows", len(sources))
+
+	// Keep the first definition of each job, matching mergeJobsFromYAMLImports
+	jobs := make(map[string]any)
+	for line := range strings.SplitSeq(mergedJobsJSON, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || line == "{}" {
+			continue
+		}
+		var lineJobs map[string]any
+		if err := json.Unmarshal([]byte(line), &lineJobs); err != nil {
+			continue
+		}
+		for name, job := range lineJobs {
+			if _, exists := jobs[name]; !exists {
+				jobs[name] = job
+			}
+		}
+	}
+
+	names := make([]string, 0, len(sources))
+	for name := range sources {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var errs []error
+	for _, name := range names {
+		importPath := sources[name]
+		if isBuiltinJobName(name) || isReservedOnNeedsTarget(name) {
+			errs = append(errs, fmt.Errorf(
+				"imported job '%s' from '%s' conflicts with the '%s' job generated by gh-aw. Rename the job in the imported workflow, e.g. '%s'",
+				name, importPath, name, suggestImportedJobName(importPath, name)))
+			continue
+		}
+		job, ok := jobs[name]
+		if !ok {
+			continue
+		}
+		if err := validateImportedJobSchema(name, job); err != nil {
+			errs = append(errs, fmt.Errorf("imported job '%s' from '%s' is not a valid GitHub Actions job: %w", name, importPath, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validateImportedJobSchema validates a single job against the GitHub Actions workflow schema
+func validateImportedJobSchema(name string, job any) error {
+	schema, err := getCompiledSchema()
+	if err != nil {
+		return err
+	}
+	workflow := map[string]any{
+		"on":   "workflow_dispatch",
+		"jobs": map[string]any{name: job},
+	}
+	if err := schema.Validate(workflow); err != nil {
+		return enhanceSchemaValidationError(err)
+	}
+	return nil
+}
+
+// suggestImportedJobName returns a job name namespaced by the import file name,
+// e.g. "shared/ci.yml" and "agent" → "ci_agent"
+func suggestImportedJobName(importPath, jobName string) string {
+	base := strings.TrimSuffix(filepath.Base(importPath), filepath.Ext(importPath))
+	base = strings.Map(func(r rune) rune {
+		if r == '-' || r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, base)
+	if base == "" {
+		base = "imported"
+	}
+	return base + "_" + jobName
+}
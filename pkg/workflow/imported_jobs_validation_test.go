@@ -0,0 +1,111 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateYAMLImportJobs(t *testing.T) {
+	tests := []struct {
+		name        string
+		mergedJobs  string
+		sources     map[string]string
+		errContains []string
+	}{
+		{
+			name:       "no YAML import jobs",
+			mergedJobs: `{"agent":{"pre-steps":[{"run":"echo hi"}]}}`,
+			sources:    map[string]string{},
+		},
+		{
+			name:       "valid imported job",
+			mergedJobs: `{"lint":{"runs-on":"ubuntu-latest","steps":[{"run":"make lint"}]}}`,
+			sources:    map[string]string{"lint": "shared/ci.yml"},
+		},
+		{
+			name:        "imported job collides with built-in job",
+			mergedJobs:  `{"agent":{"runs-on":"ubuntu-latest","steps":[{"run":"make lint"}]}}`,
+			sources:     map[string]string{"agent": "shared/ci.yml"},
+			errContains: []string{"imported job 'agent' from 'shared/ci.yml' conflicts", "'ci_agent'"},
+		},
+		{
+			name:        "imported job collides with reserved job",
+			mergedJobs:  `{"push_repo_memory":{"runs-on":"ubuntu-latest","steps":[{"run":"true"}]}}`,
+			sources:     map[string]string{"push_repo_memory": "shared/memory-sync.yml"},
+			errContains: []string{"'push_repo_memory'", "'memory-sync_push_repo_memory'"},
+		},
+		{
+			name:        "imported job with invalid steps",
+			mergedJobs:  `{"lint":{"runs-on":"ubuntu-latest","steps":"make lint"}}`,
+			sources:     map[string]string{"lint": "shared/ci.yml"},
+			errContains: []string{"imported job 'lint' from 'shared/ci.yml' is not a valid GitHub Actions job"},
+		},
+		{
+			name:       "markdown import job with built-in name is not validated",
+			mergedJobs: "{\"agent\":{\"pre-steps\":[{\"run\":\"echo hi\"}]}}\n{\"lint\":{\"runs-on\":\"ubuntu-latest\",\"steps\":[{\"run\":\"make lint\"}]}}",
+			sources:    map[string]string{"lint": "shared/ci.yml"},
+		},
+		{
+			name:       "multiple errors are reported together",
+			mergedJobs: `{"agent":{"runs-on":"ubuntu-latest","steps":[]},"lint":{"runs-on":"ubuntu-latest","steps":"make lint"}}`,
+			sources:    map[string]string{"agent": "shared/ci.yml", "lint": "shared/ci.yml"},
+			errContains: []string{
+				"imported job 'agent'",
+				"imported job 'lint'",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateYAMLImportJobs(tt.mergedJobs, tt.sources)
+			if len(tt.errContains) == 0 {
+				assert.NoError(t, err, "expected imported jobs to be valid")
+				return
+			}
+			require.Error(t, err, "expected imported jobs to be rejected")
+			for _, want := range tt.errContains {
+				assert.Contains(t, err.Error(), want, "error message should mention %q", want)
+			}
+		})
+	}
+}
+
+func TestCompileWorkflow_RejectsImportedYAMLJobNamedAgent(t *testing.T) {
+	tmpDir := t.TempDir()
+	workflowsDir := filepath.Join(tmpDir, ".github", "workflows")
+	require.NoError(t, os.MkdirAll(filepath.Join(workflowsDir, "shared"), 0755))
+
+	importedWorkflow := `name: CI
+on: push
+jobs:
+  agent:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make test
+`
+	require.NoError(t, os.WriteFile(filepath.Join(workflowsDir, "shared", "ci.yml"), []byte(importedWorkflow), 0600))
+
+	workflowContent := `---
+on: workflow_dispatch
+imports:
+  - shared/ci.yml
+engine: copilot
+---
+
+# Imported job collision
+`
+	workflowFile := filepath.Join(workflowsDir, "test-workflow.md")
+	require.NoError(t, os.WriteFile(workflowFile, []byte(workflowContent), 0600))
+
+	err := NewCompiler().CompileWorkflow(workflowFile)
+	require.Error(t, err, "compiling a workflow whose YAML import defines an 'agent' job should fail")
+	assert.Contains(t, err.Error(), "imported job 'agent' from 'shared/ci.yml' conflicts", "error should name the import")
+	assert.Contains(t, err.Error(), "'ci_agent'", "error should suggest a namespaced job name")
+}
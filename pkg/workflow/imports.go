@@ -116,6 +116,7 @@ func (c *Compiler) MergeNetworkPermissions(topNetwork *NetworkPermissions, impor
 	if topNetwork != nil {
 		result.Allowed = make([]string, len(topNetwork.Allowed))
 		copy(result.Allowed, topNetwork.Allowed)
+		result.Enforcement = topNetwork.Enforcement
 		importsLog.Printf("Starting with %d top-level allowed domains", len(topNetwork.Allowed))
 	}
 
@@ -470,6 +471,18 @@ func mergeSafeOutputConfig(result *SafeOutputsConfig, config map[string]any, c *
 	if result.Footer == nil && importedConfig.Footer != nil {
 		result.Footer = importedConfig.Footer
 	}
+	if result.Retry == nil && importedConfig.Retry != nil {
+		result.Retry = importedConfig.Retry
+	}
+	if result.MaxTotal == 0 && importedConfig.MaxTotal > 0 {
+		result.MaxTotal = importedConfig.MaxTotal
+	}
+	if len(result.Priority) == 0 && len(importedConfig.Priority) > 0 {
+		result.Priority = importedConfig.Priority
+	}
+	if result.Cloud == nil && importedConfig.Cloud != nil {
+		result.Cloud = importedConfig.Cloud
+	}
 	if len(result.AllowGitHubReferences) == 0 && len(importedConfig.AllowGitHubReferences) > 0 {
 		result.AllowGitHubReferences = importedConfig.AllowGitHubReferences
 	}
@@ -265,6 +265,9 @@ func (jm *JobManager) renderJobTo(b *strings.Builder, job *Job) {
 					fmt.Fprintf(b, "      %s\n", strings.TrimSpace(line))
 				}
 			}
+		} else if strings.HasPrefix(job.If, "!") {
+			// A plain scalar starting with '!' is parsed as a YAML tag, so quote it.
+			fmt.Fprintf(b, "    if: \"%s\"\n", escapeForYAMLDoubleQuoted(job.If))
 		} else {
 			// Single line expression that's not too long
 			fmt.Fprintf(b, "    if: %s\n", job.If)
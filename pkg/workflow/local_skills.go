@@ -0,0 +1,304 @@
+// This file implements local composite skills: skills: entries that point at a
+// directory in the repository (skills: [./.github/skills/reviewer]) instead of an
+// external skill pinned to a commit SHA.
+//
+// A local skill directory contains a SKILL.md file whose frontmatter declares the
+// tools and mcp-servers the skill needs, and whose body is the skill's prompt
+// fragment. Any other *.md files in the directory are appended to the fragment in
+// name order. Skills are merged into the workflow like imports, with two differences:
+//   - Namespacing: each skill's prompt is wrapped in a <skill name="..."> block so the
+//     agent can tell which capability an instruction belongs to.
+//   - Conflict detection: two skills with the same name, an MCP server defined
+//     differently by two sources, or a tool configured with incompatible values
+//     are compile errors instead of a silent last-wins merge.
+
+package workflow
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+)
+
+var localSkillsLog = logger.New("workflow:local_skills")
+
+// localSkillFile is the file that defines a local skill directory.
+const localSkillFile = "SKILL.md"
+
+var localSkillNameRegexp = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+
+// localSkillFrontmatterFields are the frontmatter fields accepted in SKILL.md.
+var localSkillFrontmatterFields = []string{"name", "description", "tools", "mcp-servers"}
+
+// LocalSkill is a composite skill loaded from a repository directory.
+type LocalSkill struct {
+	Name        string         // namespace for the skill's prompt; defaults to the directory name
+	Path        string         // repository-relative path of the skill directory
+	Description string         // optional one-line summary shown in the prompt block
+	Tools       map[string]any // tools: allowlist contributed by the skill
+	MCPServers  map[string]any // mcp-servers: contributed by the skill
+	Prompt      string         // SKILL.md body followed by any other *.md fragments
+}
+
+// isLocalSkillPath reports whether a skills: string entry refers to a local skill directory.
+func isLocalSkillPath(spec string) bool {
+	return strings.HasPrefix(spec, "./")
+}
+
+func validateLocalSkillPath(spec string, idx int) error {
+	for segment := range strings.SplitSeq(strings.TrimPrefix(spec, "./"), "/") {
+		if segment == ".." {
+			return fmt.Errorf("skills[%d]: local skill path %q must not contain '..'. Example: skills[%d]: \"./.github/skills/reviewer\"", idx, spec, idx)
+		}
+	}
+	if strings.Trim(strings.TrimPrefix(spec, "./"), "/") == "" {
+		return fmt.Errorf("skills[%d]: local skill path must name a directory. Example: skills[%d]: \"./.github/skills/reviewer\"", idx, idx)
+	}
+	return nil
+}
+
+// extractLocalSkillPaths returns the local skill directory entries of skills:, in order.
+func extractLocalSkillPaths(frontmatter map[string]any) []string {
+	rawSkills, ok := frontmatter["skills"].([]any)
+	if !ok {
+		return nil
+	}
+	var paths []string
+	for _, rawSkill := range rawSkills {
+		if spec, ok := rawSkill.(string); ok && isLocalSkillPath(strings.TrimSpace(spec)) {
+			paths = append(paths, strings.TrimSpace(spec))
+		}
+	}
+	return paths
+}
+
+// loadLocalSkill reads and validates the SKILL.md of a local skill directory.
+// Paths are resolved like imports: .github/-prefixed paths from the repository root,
+// other paths from the workflow's directory, and both must stay inside .github.
+func loadLocalSkill(spec, markdownDir string, cache *parser.ImportCache) (*LocalSkill, error) {
+	skillDir := strings.TrimSuffix(strings.TrimPrefix(spec, "./"), "/")
+	skillFile, err := parser.ResolveIncludePath(path.Join(skillDir, localSkillFile), markdownDir, cache)
+	if err != nil {
+		return nil, fmt.Errorf("skill %s: %s not found: %w", spec, localSkillFile, err)
+	}
+	content, err := parser.ReadFile(skillFile)
+	if err != nil {
+		return nil, fmt.Errorf("skill %s: failed to read %s: %w", spec, localSkillFile, err)
+	}
+	if findings := ScanMarkdownSecurity(string(content)); len(findings) > 0 {
+		return nil, fmt.Errorf("skill %s failed security scan: %s", spec, FormatSecurityFindings(findings, path.Join(skillDir, localSkillFile)))
+	}
+	parsed, err := parser.ExtractFrontmatterFromContent(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("skill %s: failed to parse %s: %w", spec, localSkillFile, err)
+	}
+
+	for key := range parsed.Frontmatter {
+		if !slices.Contains(localSkillFrontmatterFields, key) {
+			return nil, fmt.Errorf("skill %s: unknown %s field %q (valid fields: %s)", spec, localSkillFile, key, strings.Join(localSkillFrontmatterFields, ", "))
+		}
+	}
+
+	skill := &LocalSkill{
+		Name:        path.Base(skillDir),
+		Path:        skillDir,
+		Description: extractStringFromMap(parsed.Frontmatter, "description", nil),
+		Tools:       extractToolsMapFromFrontmatter(parsed.Frontmatter),
+		MCPServers:  extractMCPServersMapFromFrontmatter(parsed.Frontmatter),
+	}
+	if name := extractStringFromMap(parsed.Frontmatter, "name", nil); name != "" {
+		skill.Name = name
+	}
+	if !localSkillNameRegexp.MatchString(skill.Name) {
+		return nil, fmt.Errorf("skill %s: name %q must start with a lowercase letter and contain only lowercase letters, digits, '-' and '_'; set name in %s", spec, skill.Name, localSkillFile)
+	}
+
+	extraFragments, err := readLocalSkillFragments(filepath.Dir(skillFile), skillDir)
+	if err != nil {
+		return nil, fmt.Errorf("skill %s %w", spec, err)
+	}
+	fragments := append([]string{strings.TrimSpace(parsed.Markdown)}, extraFragments...)
+	skill.Prompt = strings.TrimSpace(strings.Join(fragments, "\n\n"))
+
+	localSkillsLog.Printf("Loaded local skill %s from %s: tools=%d, mcp-servers=%d, prompt=%d bytes",
+		skill.Name, skill.Path, len(skill.Tools), len(skill.MCPServers), len(skill.Prompt))
+	return skill, nil
+}
+
+// readLocalSkillFragments returns the bodies of the *.md files next to SKILL.md, in name order.
+// Fragments go through the same security scan as SKILL.md.
+func readLocalSkillFragments(dir, skillDir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		localSkillsLog.Printf("Failed to list skill directory %s: %v", dir, err)
+		return nil, nil
+	}
+	var fragments []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || name == localSkillFile || !strings.HasSuffix(name, ".md") {
+			continue
+		}
+		content, err := parser.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			localSkillsLog.Printf("Failed to read skill fragment %s: %v", name, err)
+			continue
+		}
+		if findings := ScanMarkdownSecurity(string(content)); len(findings) > 0 {
+			return nil, fmt.Errorf("failed security scan: %s", FormatSecurityFindings(findings, path.Join(skillDir, name)))
+		}
+		body, err := parser.ExtractMarkdownContent(string(content))
+		if err != nil {
+			body = string(content)
+		}
+		if body = strings.TrimSpace(body); body != "" {
+			fragments = append(fragments, body)
+		}
+	}
+	return fragments, nil
+}
+
+// renderLocalSkillPrompt wraps a skill's prompt in its namespace block.
+func renderLocalSkillPrompt(skill *LocalSkill) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "<skill name=%q", skill.Name)
+	if skill.Description != "" {
+		fmt.Fprintf(&b, " description=%q", skill.Description)
+	}
+	b.WriteString(">\n")
+	b.WriteString(skill.Prompt)
+	b.WriteString("\n</skill>\n")
+	return b.String()
+}
+
+// localSkillDefinition records which source defined an MCP server or tool, for conflict errors.
+type localSkillDefinition struct {
+	source string
+	value  any
+}
+
+// sameDefinition compares two frontmatter values by their JSON encoding, which ignores the
+// integer types the YAML decoder picks and sorts map keys.
+func sameDefinition(a, b any) bool {
+	aJSON, aErr := json.Marshal(a)
+	bJSON, bErr := json.Marshal(b)
+	return aErr == nil && bErr == nil && bytes.Equal(aJSON, bJSON)
+}
+
+// toolValuesConflict reports whether two values for the same tool cannot be merged.
+// Allowlists (arrays) are unioned and objects are merged recursively, as for imports;
+// any other pair of differing values is a conflict.
+func toolValuesConflict(existing, incoming any) bool {
+	_, existingArray := existing.([]any)
+	_, incomingArray := incoming.([]any)
+	if existingArray && incomingArray {
+		return false
+	}
+	_, existingMap := existing.(map[string]any)
+	_, incomingMap := incoming.(map[string]any)
+	if existingMap && incomingMap {
+		return false
+	}
+	return !sameDefinition(existing, incoming)
+}
+
+// mergeLocalSkills loads the local skills listed in skills: and merges their tools,
+// MCP servers, and namespaced prompts into importsResult.
+func (c *Compiler) mergeLocalSkills(frontmatter map[string]any, markdownDir string, cache *parser.ImportCache, importsResult *parser.ImportsResult) error {
+	specs := extractLocalSkillPaths(frontmatter)
+	if len(specs) == 0 {
+		return nil
+	}
+	localSkillsLog.Printf("Merging %d local skill(s)", len(specs))
+
+	mcpServers := make(map[string]localSkillDefinition)
+	for name, config := range extractMCPServersMapFromFrontmatter(frontmatter) {
+		mcpServers[name] = localSkillDefinition{source: "the workflow", value: config}
+	}
+	for line := range strings.SplitSeq(importsResult.MergedMCPServers, "\n") {
+		var imported map[string]any
+		if err := json.Unmarshal([]byte(strings.TrimSpace(line)), &imported); err != nil {
+			continue
+		}
+		for name, config := range imported {
+			mcpServers[name] = localSkillDefinition{source: "an import", value: config}
+		}
+	}
+	tools := make(map[string]localSkillDefinition)
+	for name, config := range extractToolsMapFromFrontmatter(frontmatter) {
+		tools[name] = localSkillDefinition{source: "the workflow", value: config}
+	}
+
+	skillPaths := make(map[string]string)
+	for _, spec := range specs {
+		skill, err := loadLocalSkill(spec, markdownDir, cache)
+		if err != nil {
+			return err
+		}
+		if other, exists := skillPaths[skill.Name]; exists {
+			return fmt.Errorf("skills %s and %s both use the name %q; set a distinct name in %s", other, skill.Path, skill.Name, localSkillFile)
+		}
+		skillPaths[skill.Name] = skill.Path
+		source := fmt.Sprintf("skill %q", skill.Name)
+
+		for _, name := range slices.Sorted(maps.Keys(skill.MCPServers)) {
+			config := skill.MCPServers[name]
+			if existing, ok := mcpServers[name]; ok {
+				if !sameDefinition(existing.value, config) {
+					return fmt.Errorf("%s: mcp-servers.%s conflicts with the definition in %s; rename one of the servers or share a single definition", source, name, existing.source)
+				}
+				delete(skill.MCPServers, name)
+				continue
+			}
+			mcpServers[name] = localSkillDefinition{source: source, value: config}
+		}
+		for _, name := range slices.Sorted(maps.Keys(skill.Tools)) {
+			config := skill.Tools[name]
+			if existing, ok := tools[name]; ok {
+				if toolValuesConflict(existing.value, config) {
+					return fmt.Errorf("%s: tools.%s conflicts with the value in %s; use the same value or an allowlist that can be merged", source, name, existing.source)
+				}
+				continue
+			}
+			tools[name] = localSkillDefinition{source: source, value: config}
+		}
+
+		if err := appendLocalSkillToImports(importsResult, skill); err != nil {
+			return fmt.Errorf("%s: %w", source, err)
+		}
+	}
+	return nil
+}
+
+// appendLocalSkillToImports adds a skill's contributions to the merged import results.
+func appendLocalSkillToImports(importsResult *parser.ImportsResult, skill *LocalSkill) error {
+	if len(skill.Tools) > 0 {
+		toolsJSON, err := json.Marshal(skill.Tools)
+		if err != nil {
+			return fmt.Errorf("failed to marshal tools: %w", err)
+		}
+		importsResult.MergedTools = strings.Join(nonEmptyStrings(importsResult.MergedTools, string(toolsJSON)), "\n")
+	}
+	if len(skill.MCPServers) > 0 {
+		mcpJSON, err := json.Marshal(skill.MCPServers)
+		if err != nil {
+			return fmt.Errorf("failed to marshal mcp-servers: %w", err)
+		}
+		importsResult.MergedMCPServers = strings.Join(nonEmptyStrings(importsResult.MergedMCPServers, string(mcpJSON)), "\n")
+	}
+	if skill.Prompt != "" {
+		importsResult.PromptImports = append(importsResult.PromptImports, parser.PromptImportEntry{Markdown: renderLocalSkillPrompt(skill)})
+	}
+	importsResult.ImportedFiles = append(importsResult.ImportedFiles, path.Join(skill.Path, localSkillFile))
+	return nil
+}
@@ -0,0 +1,137 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupLocalSkillsRepo creates a repository with the given skill files and a workflow
+// that lists the given skills, and returns the workflow path.
+func setupLocalSkillsRepo(t *testing.T, files map[string]string, workflowFrontmatter string) string {
+	t.Helper()
+	repoDir := testutil.TempDir(t, "local-skills-test")
+	require.NoError(t, os.MkdirAll(filepath.Join(repoDir, ".git"), 0755), "Failed to create .git dir")
+	for name, content := range files {
+		filePath := filepath.Join(repoDir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(filePath), 0755), "Failed to create skill dir")
+		require.NoError(t, os.WriteFile(filePath, []byte(content), 0644), "Failed to write skill file")
+	}
+	workflowsDir := filepath.Join(repoDir, ".github", "workflows")
+	require.NoError(t, os.MkdirAll(workflowsDir, 0755), "Failed to create workflows dir")
+	workflowPath := filepath.Join(workflowsDir, "review.md")
+	workflow := "---\non:\n  pull_request:\n    types: [opened]\nengine: copilot\npermissions:\n  contents: read\n" +
+		workflowFrontmatter + "---\n\n# Review\n\nReview this pull request.\n"
+	require.NoError(t, os.WriteFile(workflowPath, []byte(workflow), 0644), "Failed to write workflow")
+	return workflowPath
+}
+
+const reviewerSkill = `---
+description: Reviews pull requests
+tools:
+  bash: ["git diff"]
+mcp-servers:
+  lint:
+    command: npx
+    args: ["-y", "@example/lint-mcp"]
+    allowed: ["lint_file"]
+---
+
+Run the lint tools on every changed file.
+`
+
+func TestLocalSkillsCompile(t *testing.T) {
+	workflowPath := setupLocalSkillsRepo(t, map[string]string{
+		".github/skills/reviewer/SKILL.md":     reviewerSkill,
+		".github/skills/reviewer/checklist.md": "Checklist: tests updated.\n",
+		".github/skills/docs/SKILL.md":         "---\ntools:\n  bash: [\"markdownlint\"]\n---\n\nCheck the docs.\n",
+	}, "skills:\n  - ./.github/skills/reviewer\n  - ./.github/skills/docs\n")
+
+	compiler := NewCompiler(WithVersion("1.0.0"))
+	require.NoError(t, compiler.CompileWorkflow(workflowPath), "Failed to compile workflow")
+
+	lockContent, err := os.ReadFile(filepath.Join(filepath.Dir(workflowPath), "review.lock.yml"))
+	require.NoError(t, err, "Failed to read lock file")
+	lock := string(lockContent)
+
+	assert.Contains(t, lock, `<skill name="reviewer" description="Reviews pull requests">`, "skill prompt should be namespaced")
+	assert.Contains(t, lock, "Checklist: tests updated.", "other markdown files should be appended to the skill prompt")
+	assert.Contains(t, lock, `<skill name="docs">`, "each skill should get its own block")
+	assert.Contains(t, lock, "@example/lint-mcp", "skill MCP server should be configured")
+	assert.Contains(t, lock, "git diff", "skill bash allowlist should be merged")
+	assert.Contains(t, lock, "markdownlint", "bash allowlists from both skills should be merged")
+	assert.Contains(t, lock, ".github/skills/reviewer/SKILL.md", "skill should be listed as an import in the lock header")
+}
+
+func TestLocalSkillsConflicts(t *testing.T) {
+	tests := []struct {
+		name        string
+		files       map[string]string
+		frontmatter string
+		wantErr     string
+	}{
+		{
+			name: "duplicate skill names",
+			files: map[string]string{
+				".github/skills/reviewer/SKILL.md": reviewerSkill,
+				".github/skills/other/SKILL.md":    "---\nname: reviewer\n---\n\nAnother reviewer.\n",
+			},
+			frontmatter: "skills:\n  - ./.github/skills/reviewer\n  - ./.github/skills/other\n",
+			wantErr:     `both use the name "reviewer"`,
+		},
+		{
+			name: "mcp server defined differently by the workflow",
+			files: map[string]string{
+				".github/skills/reviewer/SKILL.md": reviewerSkill,
+			},
+			frontmatter: "skills:\n  - ./.github/skills/reviewer\nmcp-servers:\n  lint:\n    command: node\n    args: [\"lint.js\"]\n",
+			wantErr:     `skill "reviewer": mcp-servers.lint conflicts with the definition in the workflow`,
+		},
+		{
+			name: "tool configured with incompatible values",
+			files: map[string]string{
+				".github/skills/reviewer/SKILL.md": reviewerSkill,
+				".github/skills/shell/SKILL.md":    "---\ntools:\n  bash: true\n---\n\nUse any shell command.\n",
+			},
+			frontmatter: "skills:\n  - ./.github/skills/reviewer\n  - ./.github/skills/shell\n",
+			wantErr:     `skill "shell": tools.bash conflicts with the value in skill "reviewer"`,
+		},
+		{
+			name: "unknown SKILL.md field",
+			files: map[string]string{
+				".github/skills/reviewer/SKILL.md": "---\nengine: claude\n---\n\nReview.\n",
+			},
+			frontmatter: "skills:\n  - ./.github/skills/reviewer\n",
+			wantErr:     `unknown SKILL.md field "engine"`,
+		},
+		{
+			name:        "missing SKILL.md",
+			frontmatter: "skills:\n  - ./.github/skills/missing\n",
+			wantErr:     "SKILL.md not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workflowPath := setupLocalSkillsRepo(t, tt.files, tt.frontmatter)
+			compiler := NewCompiler(WithVersion("1.0.0"))
+			err := compiler.CompileWorkflow(workflowPath)
+			require.Error(t, err, "compilation should fail")
+			assert.Contains(t, err.Error(), tt.wantErr, "error message")
+		})
+	}
+}
+
+func TestLocalSkillsIdenticalMCPServerIsShared(t *testing.T) {
+	workflowPath := setupLocalSkillsRepo(t, map[string]string{
+		".github/skills/reviewer/SKILL.md": reviewerSkill,
+	}, "skills:\n  - ./.github/skills/reviewer\nmcp-servers:\n  lint:\n    command: npx\n    args: [\"-y\", \"@example/lint-mcp\"]\n    allowed: [\"lint_file\"]\n")
+
+	compiler := NewCompiler(WithVersion("1.0.0"))
+	require.NoError(t, compiler.CompileWorkflow(workflowPath), "identical MCP server definitions should not conflict")
+}
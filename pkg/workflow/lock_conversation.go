@@ -0,0 +1,55 @@
+package workflow
+
+import (
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var lockIssueLog = logger.New("workflow:lock_issue")
+var lockPullRequestLog = logger.New("workflow:lock_pull_request")
+
+// LockConversationConfig holds configuration for locking issue or pull request conversations
+type LockConversationConfig struct {
+	BaseSafeOutputConfig   `yaml:",inline"`
+	SafeOutputTargetConfig `yaml:",inline"`
+	SafeOutputFilterConfig `yaml:",inline"`
+	AllowedReasons         []string `yaml:"allowed-reasons,omitempty"` // Lock reasons the agent may use (default: all of off-topic, too heated, resolved, spam)
+}
+
+// LockIssueConfig holds configuration for the lock-issue safe output
+type LockIssueConfig = LockConversationConfig
+
+// LockPullRequestConfig holds configuration for the lock-pull-request safe output
+type LockPullRequestConfig = LockConversationConfig
+
+// parseLockIssueConfig handles lock-issue configuration
+func (c *Compiler) parseLockIssueConfig(outputMap map[string]any) *LockIssueConfig {
+	return parseLockConversationConfig(outputMap, "lock-issue", lockIssueLog)
+}
+
+// parseLockPullRequestConfig handles lock-pull-request configuration
+func (c *Compiler) parseLockPullRequestConfig(outputMap map[string]any) *LockPullRequestConfig {
+	return parseLockConversationConfig(outputMap, "lock-pull-request", lockPullRequestLog)
+}
+
+func parseLockConversationConfig(outputMap map[string]any, key string, log *logger.Logger) *LockConversationConfig {
+	config := parseConfigScaffold(outputMap, key, log, func(err error) *LockConversationConfig {
+		log.Printf("Failed to unmarshal config: %v", err)
+		// A bare or malformed value still enables the output with a max of 1
+		log.Print("Using default configuration")
+		return &LockConversationConfig{
+			BaseSafeOutputConfig: BaseSafeOutputConfig{Max: defaultIntStr(1)},
+		}
+	})
+	if config == nil {
+		return nil
+	}
+
+	// Set default max if not specified
+	if config.Max == nil {
+		config.Max = defaultIntStr(1)
+	}
+
+	log.Printf("Parsed configuration: allowed_reasons=%v, target=%s", config.AllowedReasons, config.Target)
+
+	return config
+}
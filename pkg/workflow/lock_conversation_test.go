@@ -0,0 +1,66 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/github/gh-aw/pkg/testutil"
+)
+
+func TestParseLockConversationConfig(t *testing.T) {
+	c := &Compiler{}
+
+	assert.Nil(t, c.parseLockIssueConfig(map[string]any{}), "missing key should disable lock-issue")
+
+	config := c.parseLockIssueConfig(map[string]any{"lock-issue": nil})
+	require.NotNil(t, config, "null config should enable lock-issue")
+	assert.Equal(t, strPtr("1"), config.Max, "max should default to 1")
+
+	config = c.parseLockPullRequestConfig(map[string]any{
+		"lock-pull-request": map[string]any{
+			"allowed-reasons": []any{"too heated", "spam"},
+			"target":          "*",
+			"max":             3,
+		},
+	})
+	require.NotNil(t, config, "config should be parsed")
+	assert.Equal(t, []string{"too heated", "spam"}, config.AllowedReasons, "allowed-reasons should be parsed")
+	assert.Equal(t, "*", config.Target, "target should be parsed")
+	assert.Equal(t, strPtr("3"), config.Max, "max should be parsed")
+}
+
+func TestLockConversationWorkflowCompilation(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "lock-conversation")
+	content := `---
+on:
+  issue_comment:
+    types: [created]
+engine: copilot
+safe-outputs:
+  lock-issue:
+    allowed-reasons: [too heated, off-topic]
+  lock-pull-request:
+    max: 2
+---
+
+# Moderate the conversation
+`
+	workflowFile := filepath.Join(tmpDir, "moderate.md")
+	require.NoError(t, os.WriteFile(workflowFile, []byte(content), 0644), "Failed to write workflow")
+	require.NoError(t, NewCompiler().CompileWorkflow(workflowFile), "compilation should succeed")
+
+	lockBytes, err := os.ReadFile(filepath.Join(tmpDir, "moderate.lock.yml"))
+	require.NoError(t, err, "Failed to read lock file")
+	lock := string(lockBytes)
+
+	assert.Contains(t, lock, `\"lock_issue\":{\"allowed_reasons\":[\"too heated\",\"off-topic\"],\"max\":1}`, "handler config should include lock_issue")
+	assert.Contains(t, lock, `\"lock_pull_request\":{\"max\":2}`, "handler config should include lock_pull_request")
+	assert.Contains(t, lock, "issues: write", "safe_outputs job should be able to lock issues")
+	assert.Contains(t, lock, "pull-requests: write", "safe_outputs job should be able to lock pull requests")
+}
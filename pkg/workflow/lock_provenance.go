@@ -0,0 +1,123 @@
+package workflow
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+)
+
+var lockProvenanceLog = logger.New("workflow:lock_provenance")
+
+// lockProvenancePattern matches a "# gh-aw-provenance: {...}" line in a lock file header.
+var lockProvenancePattern = regexp.MustCompile(`#\s*gh-aw-provenance:\s*(\{.+\})`)
+
+// LockProvenance is the single-line JSON payload embedded as a "# gh-aw-provenance: ..."
+// comment in lock file headers. It records the inputs a lock file was compiled from so
+// `gh aw verify` can confirm the lock file still corresponds to its markdown source.
+// Paths are relative to the directory containing the lock file.
+type LockProvenance struct {
+	CompilerVersion string               `json:"compiler_version,omitempty"`
+	Source          LockProvenanceFile   `json:"source"`
+	Imports         []LockProvenanceFile `json:"imports,omitempty"`
+	ActionsLock     string               `json:"actions_lock,omitempty"`
+}
+
+// LockProvenanceFile records one input file and the SHA-256 of its content at compile time.
+type LockProvenanceFile struct {
+	Path   string `json:"path"`
+	SHA256 string `json:"sha256"`
+	Commit string `json:"commit,omitempty"` // commit SHA for remote imports served from the import cache
+}
+
+// buildLockProvenance hashes the markdown source and the files read while resolving its
+// imports. Returns nil when the source cannot be read (e.g. in-memory wasm compilation).
+func (c *Compiler) buildLockProvenance(data *WorkflowData, markdownPath string, actions []string) *LockProvenance {
+	content, err := parser.DefaultFileReader(markdownPath)
+	if err != nil {
+		lockProvenanceLog.Printf("Skipping provenance for %s: %v", markdownPath, err)
+		return nil
+	}
+	lockDir := filepath.Dir(markdownPath)
+	provenance := &LockProvenance{
+		Source:      LockProvenanceFile{Path: filepath.Base(markdownPath), SHA256: hashProvenanceContent(content)},
+		ActionsLock: ComputeActionsLockDigest(parseActionRefs(actions)),
+	}
+	if IsRelease() || c.GetActionTag() != "" {
+		provenance.CompilerVersion = c.GetVersion()
+	}
+	for _, source := range data.ImportSources {
+		rel, err := filepath.Rel(lockDir, source.FullPath)
+		if err != nil {
+			rel = source.FullPath
+		}
+		provenance.Imports = append(provenance.Imports, LockProvenanceFile{
+			Path:   filepath.ToSlash(rel),
+			SHA256: source.SHA256,
+			Commit: source.Commit,
+		})
+	}
+	lockProvenanceLog.Printf("Built provenance for %s: imports=%d, actions_lock=%t", markdownPath, len(provenance.Imports), provenance.ActionsLock != "")
+	return provenance
+}
+
+// ComputeActionsLockDigest returns the SHA-256 of the actions-lock.json pins a workflow
+// uses, one "repo@version sha" line per action. Actions referenced directly by SHA are
+// not resolved through actions-lock.json and are skipped. Returns "" when no pins apply.
+func ComputeActionsLockDigest(actions []GHAWManifestAction) string {
+	var lines strings.Builder
+	for _, action := range actions {
+		if action.Version == "" || action.Version == action.SHA {
+			continue
+		}
+		fmt.Fprintf(&lines, "%s@%s %s\n", action.Repo, action.Version, action.SHA)
+	}
+	if lines.Len() == 0 {
+		return ""
+	}
+	return hashProvenanceContent([]byte(lines.String()))
+}
+
+// HashProvenanceFile returns the hex-encoded SHA-256 of a file, as recorded in provenance.
+func HashProvenanceFile(path string) (string, error) {
+	content, err := parser.DefaultFileReader(path)
+	if err != nil {
+		return "", err
+	}
+	return hashProvenanceContent(content), nil
+}
+
+func hashProvenanceContent(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// ToJSON serialises the provenance to a compact, single-line JSON string.
+func (p *LockProvenance) ToJSON() (string, error) {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize gh-aw-provenance: %w", err)
+	}
+	return string(data), nil
+}
+
+// ExtractLockProvenanceFromLockFile parses the gh-aw-provenance line from a lock file's
+// comment header. Returns nil (with no error) for lock files compiled before provenance
+// was recorded.
+func ExtractLockProvenanceFromLockFile(content string) (*LockProvenance, error) {
+	matches := lockProvenancePattern.FindStringSubmatch(content)
+	if len(matches) < 2 {
+		return nil, nil
+	}
+	var p LockProvenance
+	if err := json.Unmarshal([]byte(matches[1]), &p); err != nil {
+		return nil, fmt.Errorf("failed to parse gh-aw-provenance JSON: %w", err)
+	}
+	return &p, nil
+}
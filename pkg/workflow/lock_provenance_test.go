@@ -0,0 +1,69 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/github/gh-aw/pkg/testutil"
+)
+
+func TestComputeActionsLockDigest(t *testing.T) {
+	pinned := []GHAWManifestAction{
+		{Repo: "actions/checkout", SHA: "3d3c42e5aac5ba805825da76410c181273ba90b1", Version: "v5"},
+		{Repo: "owner/custom", SHA: "0123456789abcdef0123456789abcdef01234567", Version: "0123456789abcdef0123456789abcdef01234567"},
+	}
+	digest := ComputeActionsLockDigest(pinned)
+	assert.Len(t, digest, 64, "digest should be a hex-encoded SHA-256")
+	assert.Equal(t, digest, ComputeActionsLockDigest(pinned[:1]), "actions referenced by SHA should not affect the digest")
+
+	repinned := []GHAWManifestAction{{Repo: "actions/checkout", SHA: "ffffffffffffffffffffffffffffffffffffffff", Version: "v5"}}
+	assert.NotEqual(t, digest, ComputeActionsLockDigest(repinned), "a different pin should change the digest")
+	assert.Empty(t, ComputeActionsLockDigest(pinned[1:]), "no pinned actions should yield an empty digest")
+}
+
+func TestLockProvenanceHeader(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "lock-provenance")
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "shared"), 0755), "Failed to create shared dir")
+	sharedContent := []byte("---\ntools:\n  github:\n    toolsets: [issues]\n---\nShared guidance.\n")
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "shared", "tools.md"), sharedContent, 0644), "Failed to write import")
+	content := []byte(`---
+on:
+  issues:
+    types: [opened]
+engine: copilot
+imports:
+  - shared/tools.md
+---
+
+# Triage
+`)
+	workflowFile := filepath.Join(tmpDir, "triage.md")
+	require.NoError(t, os.WriteFile(workflowFile, content, 0644), "Failed to write workflow")
+	require.NoError(t, NewCompiler().CompileWorkflow(workflowFile), "compilation should succeed")
+
+	lockBytes, err := os.ReadFile(filepath.Join(tmpDir, "triage.lock.yml"))
+	require.NoError(t, err, "Failed to read lock file")
+	provenance, err := ExtractLockProvenanceFromLockFile(string(lockBytes))
+	require.NoError(t, err, "provenance should parse")
+	require.NotNil(t, provenance, "lock file should carry a provenance header")
+
+	assert.Equal(t, LockProvenanceFile{Path: "triage.md", SHA256: hashProvenanceContent(content)}, provenance.Source, "source should be hashed")
+	assert.Equal(t, []LockProvenanceFile{{Path: "shared/tools.md", SHA256: hashProvenanceContent(sharedContent)}}, provenance.Imports, "imports should be hashed relative to the lock file")
+	assert.NotEmpty(t, provenance.ActionsLock, "pinned actions should produce an actions-lock digest")
+	assert.Empty(t, provenance.CompilerVersion, "development builds should not record the compiler version")
+}
+
+func TestExtractLockProvenanceFromLockFile(t *testing.T) {
+	provenance, err := ExtractLockProvenanceFromLockFile("# gh-aw-metadata: {}\nname: test\n")
+	require.NoError(t, err, "missing provenance is not an error")
+	assert.Nil(t, provenance, "lock files without the header should yield nil")
+
+	_, err = ExtractLockProvenanceFromLockFile("# gh-aw-provenance: {not json}\n")
+	require.Error(t, err, "malformed provenance should be reported")
+}
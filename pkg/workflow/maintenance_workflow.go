@@ -10,6 +10,7 @@ import (
 	"github.com/github/gh-aw/pkg/constants"
 
 	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/errorutil"
 	"github.com/github/gh-aw/pkg/fileutil"
 	"github.com/github/gh-aw/pkg/logger"
 )
@@ -112,6 +113,20 @@ func FetchDefaultBranch(slug string) string {
 	return branch
 }
 
+// localDefaultBranch reads the default branch from the origin/HEAD ref of the git
+// repository containing dir, for offline compiles that cannot query the GitHub API.
+// It returns an error wrapping errorutil.ErrOffline when the ref is not set.
+func localDefaultBranch(dir string) (string, error) {
+	output, err := RunGitCombined("Reading default branch...", "-C", dir, "symbolic-ref", "--short", "refs/remotes/origin/HEAD")
+	branch := strings.TrimPrefix(strings.TrimSpace(string(output)), "origin/")
+	if err != nil || branch == "" {
+		maintenanceLog.Printf("Failed to read origin/HEAD in %s: %v (%s)", dir, err, strings.TrimSpace(string(output)))
+		return "", fmt.Errorf("%w: cannot determine the default branch for the maintenance workflow push trigger; run 'git remote set-head origin --auto' or compile without --offline", errorutil.ErrOffline)
+	}
+	maintenanceLog.Printf("Default branch from origin/HEAD: %s", branch)
+	return branch, nil
+}
+
 // GenerateMaintenanceWorkflowOptions configures a maintenance workflow generation run.
 type GenerateMaintenanceWorkflowOptions struct {
 	WorkflowDataList []*WorkflowData
@@ -121,6 +136,7 @@ type GenerateMaintenanceWorkflowOptions struct {
 	ActionTag        string
 	RepoConfig       *RepoConfig
 	RepoSlug         string
+	Offline          bool // read the default branch from the local origin/HEAD instead of the GitHub API
 }
 
 const defaultNoOpIssueExpirationHours = 24 * 30
@@ -257,7 +273,16 @@ func GenerateMaintenanceWorkflow(ctx context.Context, opts GenerateMaintenanceWo
 
 	// Fetch the default branch for the push trigger (dev mode only)
 	// Resolved here to avoid passing it through multiple layers; empty slug falls back to "main"
-	defaultBranch := FetchDefaultBranch(repoSlug)
+	defaultBranch := "main"
+	if !opts.Offline {
+		defaultBranch = FetchDefaultBranch(repoSlug)
+	} else if actionMode == ActionModeDev {
+		branch, err := localDefaultBranch(workflowDir)
+		if err != nil {
+			return err
+		}
+		defaultBranch = branch
+	}
 
 	// Generate the YAML content for the maintenance workflow
 	maintenanceLog.Printf(
@@ -10,6 +10,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/github/gh-aw/pkg/errorutil"
 	"github.com/github/gh-aw/pkg/stringutil"
 	"github.com/stretchr/testify/require"
 	yamlv3 "gopkg.in/yaml.v3"
@@ -2814,3 +2815,27 @@ func TestGenerateMaintenanceWorkflow_CopilotOrgBilling(t *testing.T) {
 		}
 	})
 }
+
+func TestGenerateMaintenanceWorkflowOfflineDefaultBranch(t *testing.T) {
+	repoDir := t.TempDir()
+	runGitCommand(t, repoDir, "init", "-q")
+	workflowDir := filepath.Join(repoDir, ".github", "workflows")
+	require.NoError(t, os.MkdirAll(workflowDir, 0o755), "workflow dir should be created")
+	opts := GenerateMaintenanceWorkflowOptions{
+		WorkflowDataList: []*WorkflowData{{Name: "wf", SafeOutputs: &SafeOutputsConfig{CreateIssues: &CreateIssuesConfig{Expires: 48}}}},
+		WorkflowDir:      workflowDir,
+		Version:          "v1.0.0",
+		ActionMode:       ActionModeDev,
+		RepoSlug:         "owner/repo",
+		Offline:          true,
+	}
+
+	err := GenerateMaintenanceWorkflow(context.Background(), opts)
+	require.ErrorIs(t, err, errorutil.ErrOffline, "offline dev-mode generation should fail without origin/HEAD")
+
+	runGitCommand(t, repoDir, "symbolic-ref", "refs/remotes/origin/HEAD", "refs/remotes/origin/trunk")
+	require.NoError(t, GenerateMaintenanceWorkflow(context.Background(), opts), "offline generation should read origin/HEAD")
+	content, err := os.ReadFile(filepath.Join(workflowDir, "agentics-maintenance.yml"))
+	require.NoError(t, err, "maintenance workflow should be generated")
+	require.Contains(t, string(content), "  push:\n    branches:\n      - trunk\n", "push trigger should use the local default branch")
+}
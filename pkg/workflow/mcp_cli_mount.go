@@ -311,13 +311,12 @@ func (c *Compiler) generateMCPCLIMountStep(yaml *strings.Builder, data *Workflow
 	yaml.WriteString("          MCP_GATEWAY_API_KEY: ${{ steps.start-mcp-gateway.outputs.gateway-api-key }}\n")
 	yaml.WriteString("          MCP_GATEWAY_DOMAIN: ${{ steps.start-mcp-gateway.outputs.gateway-domain }}\n")
 	yaml.WriteString("          MCP_GATEWAY_PORT: ${{ steps.start-mcp-gateway.outputs.gateway-port }}\n")
-	fmt.Fprintf(yaml, "        uses: %s\n", getActionPin("actions/github-script"))
-	yaml.WriteString("        with:\n")
-	yaml.WriteString("          script: |\n")
-	yaml.WriteString("            const { setupGlobals } = require('" + SetupActionDestination + "/setup_globals.cjs');\n")
-	yaml.WriteString("            setupGlobals(core, github, context, exec, io);\n")
-	yaml.WriteString("            const { main } = require('" + SetupActionDestination + "/mount_mcp_as_cli.cjs');\n")
-	yaml.WriteString("            await main();\n")
+	script := "            const { setupGlobals } = require('" + SetupActionDestination + "/setup_globals.cjs');\n" +
+		"            setupGlobals(core, github, context, exec, io);\n" +
+		"            const { main } = require('" + SetupActionDestination + "/mount_mcp_as_cli.cjs');\n" +
+		"            await main();\n"
+	fmt.Fprintf(yaml, "        uses: %s\n", c.githubScriptUses(getActionPin("actions/github-script"), script))
+	yaml.WriteString(c.githubScriptWith(script))
 }
 
 // GetMCPCLIPathSetup returns a shell command that adds the MCP CLI bin directory
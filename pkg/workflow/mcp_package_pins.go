@@ -0,0 +1,164 @@
+// This file provides version pinning for package-launched MCP servers.
+//
+// # MCP Package Pins
+//
+// MCP servers launched with npx or uvx (for example `command: npx` with
+// `args: ["-y", "@modelcontextprotocol/server-filesystem"]`) resolve the latest
+// published version every time the agent job starts. To make agent environments
+// reproducible, `gh aw update --mcp` records the resolved version of each
+// unversioned package in the "mcp" section of .github/aw/actions-lock.json, and
+// the compiler rewrites the package argument to `name@version` when a pin exists.
+//
+// Packages that already carry an explicit version in the workflow are left as-is
+// and are never recorded in the lock file.
+
+package workflow
+
+import (
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var mcpPackagePinsLog = logger.New("workflow:mcp_package_pins")
+
+// MCPPackagePin is a resolved package version for a package-launched MCP server.
+type MCPPackagePin struct {
+	Registry string `json:"registry"` // Package registry: "npm" (npx) or "pypi" (uvx)
+	Package  string `json:"package"`  // Package name without version
+	Version  string `json:"version"`  // Resolved version
+}
+
+// MCPPackageRef is a package referenced by an MCP server launcher command.
+type MCPPackageRef struct {
+	Registry string // Package registry: "npm" or "pypi"
+	Package  string // Package name without version
+	Version  string // Version written in the workflow, empty when unversioned
+}
+
+// mcpPackageLaunchers maps MCP launcher commands to the registry their packages come from.
+var mcpPackageLaunchers = map[string]string{
+	"npx": "npm",
+	"uvx": "pypi",
+}
+
+// MCPPackagePinKey returns the actions-lock.json key for a package pin.
+func MCPPackagePinKey(registry, pkg string) string {
+	return registry + ":" + pkg
+}
+
+// GetMCPPin returns the cached pin for the given registry and package.
+func (c *ActionCache) GetMCPPin(registry, pkg string) (MCPPackagePin, bool) {
+	if c == nil || c.MCPPins == nil {
+		return MCPPackagePin{}, false
+	}
+	pin, ok := c.MCPPins[MCPPackagePinKey(registry, pkg)]
+	return pin, ok
+}
+
+// SetMCPPin stores the resolved version for the given registry and package.
+func (c *ActionCache) SetMCPPin(registry, pkg, version string) {
+	if c.MCPPins == nil {
+		c.MCPPins = make(map[string]MCPPackagePin)
+	}
+	c.MCPPins[MCPPackagePinKey(registry, pkg)] = MCPPackagePin{Registry: registry, Package: pkg, Version: version}
+	c.dirty = true
+	mcpPackagePinsLog.Printf("Set MCP package pin: %s:%s@%s", registry, pkg, version)
+}
+
+// PruneStaleMCPPins removes MCP pins whose keys are not in referencedKeys and returns
+// the number of entries removed.
+func (c *ActionCache) PruneStaleMCPPins(referencedKeys map[string]struct{}) int {
+	pruned := 0
+	for key := range c.MCPPins {
+		if _, ok := referencedKeys[key]; !ok {
+			delete(c.MCPPins, key)
+			c.dirty = true
+			pruned++
+			mcpPackagePinsLog.Printf("Pruned stale MCP package pin: %s", key)
+		}
+	}
+	return pruned
+}
+
+// splitMCPPackageSpec splits a launcher package argument into name and version.
+// Scoped npm packages keep their leading '@' (e.g. "@scope/pkg@1.2.0"); uvx also
+// accepts the pip-style "pkg==1.2.0" form.
+func splitMCPPackageSpec(registry, spec string) (string, string) {
+	if registry == "pypi" {
+		if name, version, found := strings.Cut(spec, "=="); found {
+			return name, version
+		}
+	}
+	if idx := strings.LastIndex(spec, "@"); idx > 0 {
+		return spec[:idx], spec[idx+1:]
+	}
+	return spec, ""
+}
+
+// mcpPackageArgIndex returns the launcher registry, the args, the index of the package
+// argument (the first non-flag argument), and that argument for an npx/uvx MCP server config.
+func mcpPackageArgIndex(config map[string]any) (string, []any, int, string) {
+	command, _ := config["command"].(string)
+	registry, ok := mcpPackageLaunchers[command]
+	if !ok {
+		return "", nil, -1, ""
+	}
+	args, _ := config["args"].([]any)
+	for i, arg := range args {
+		if argStr, ok := arg.(string); ok && !strings.HasPrefix(argStr, "-") {
+			return registry, args, i, argStr
+		}
+	}
+	return "", nil, -1, ""
+}
+
+// CollectMCPPackageRefs returns the packages launched by npx/uvx MCP servers in the
+// given tools or mcp-servers map.
+func CollectMCPPackageRefs(tools map[string]any) []MCPPackageRef {
+	var refs []MCPPackageRef
+	for _, toolValue := range tools {
+		config, ok := toolValue.(map[string]any)
+		if !ok {
+			continue
+		}
+		registry, _, idx, spec := mcpPackageArgIndex(config)
+		if idx < 0 {
+			continue
+		}
+		name, version := splitMCPPackageSpec(registry, spec)
+		refs = append(refs, MCPPackageRef{Registry: registry, Package: name, Version: version})
+	}
+	return refs
+}
+
+// applyMCPPackagePins rewrites unversioned npx/uvx package arguments in the workflow's
+// MCP servers to the versions recorded in actions-lock.json.
+func applyMCPPackagePins(workflowData *WorkflowData) {
+	if workflowData.ActionCache == nil || len(workflowData.ActionCache.MCPPins) == 0 {
+		return
+	}
+	for toolName, toolValue := range workflowData.Tools {
+		config, ok := toolValue.(map[string]any)
+		if !ok {
+			continue
+		}
+		registry, args, idx, spec := mcpPackageArgIndex(config)
+		if idx < 0 {
+			continue
+		}
+		name, version := splitMCPPackageSpec(registry, spec)
+		if version != "" {
+			continue
+		}
+		pin, ok := workflowData.ActionCache.GetMCPPin(registry, name)
+		if !ok || pin.Version == "" {
+			continue
+		}
+		pinnedArgs := make([]any, len(args))
+		copy(pinnedArgs, args)
+		pinnedArgs[idx] = name + "@" + pin.Version
+		config["args"] = pinnedArgs
+		mcpPackagePinsLog.Printf("Pinned MCP server %s package %s to %s", toolName, name, pin.Version)
+	}
+}
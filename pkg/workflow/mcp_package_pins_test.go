@@ -0,0 +1,99 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/github/gh-aw/pkg/testutil"
+)
+
+func TestSplitMCPPackageSpec(t *testing.T) {
+	tests := []struct {
+		registry, spec, name, version string
+	}{
+		{"npm", "@modelcontextprotocol/server-filesystem", "@modelcontextprotocol/server-filesystem", ""},
+		{"npm", "@modelcontextprotocol/server-filesystem@1.2.0", "@modelcontextprotocol/server-filesystem", "1.2.0"},
+		{"npm", "mcp-server-time@latest", "mcp-server-time", "latest"},
+		{"pypi", "mcp-server-fetch==2025.1.1", "mcp-server-fetch", "2025.1.1"},
+		{"pypi", "mcp-server-fetch@2025.1.1", "mcp-server-fetch", "2025.1.1"},
+		{"pypi", "mcp-server-fetch", "mcp-server-fetch", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			name, version := splitMCPPackageSpec(tt.registry, tt.spec)
+			assert.Equal(t, tt.name, name)
+			assert.Equal(t, tt.version, version)
+		})
+	}
+}
+
+func TestCollectMCPPackageRefs(t *testing.T) {
+	refs := CollectMCPPackageRefs(map[string]any{
+		"fs":     map[string]any{"command": "npx", "args": []any{"-y", "@modelcontextprotocol/server-filesystem", "/tmp"}},
+		"fetch":  map[string]any{"command": "uvx", "args": []any{"mcp-server-fetch==1.0.0"}},
+		"docker": map[string]any{"container": "mcp/time"},
+		"github": map[string]any{"toolsets": []any{"default"}},
+	})
+	assert.ElementsMatch(t, []MCPPackageRef{
+		{Registry: "npm", Package: "@modelcontextprotocol/server-filesystem"},
+		{Registry: "pypi", Package: "mcp-server-fetch", Version: "1.0.0"},
+	}, refs)
+}
+
+func TestMCPPackagePinsCompile(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "mcp-package-pins")
+	lockDir := filepath.Join(tmpDir, ".github", "aw")
+	require.NoError(t, os.MkdirAll(lockDir, 0755))
+	cache := NewActionCache(tmpDir)
+	cache.SetMCPPin("npm", "@modelcontextprotocol/server-filesystem", "2025.8.21")
+	require.NoError(t, cache.Save())
+
+	saved, err := os.ReadFile(cache.GetCachePath())
+	require.NoError(t, err)
+	assert.Contains(t, string(saved), `"npm:@modelcontextprotocol/server-filesystem"`, "pin should be written to the mcp section")
+
+	reloaded := NewActionCache(tmpDir)
+	require.NoError(t, reloaded.Load())
+	pin, ok := reloaded.GetMCPPin("npm", "@modelcontextprotocol/server-filesystem")
+	require.True(t, ok, "pin should survive a save/load round trip")
+	assert.Equal(t, "2025.8.21", pin.Version)
+
+	workflowsDir := filepath.Join(tmpDir, ".github", "workflows")
+	require.NoError(t, os.MkdirAll(workflowsDir, 0755))
+	workflowFile := filepath.Join(workflowsDir, "mcp-pins.md")
+	require.NoError(t, os.WriteFile(workflowFile, []byte(`---
+on: workflow_dispatch
+engine: claude
+permissions:
+  contents: read
+mcp-servers:
+  fs:
+    command: npx
+    args: ["-y", "@modelcontextprotocol/server-filesystem", "/tmp"]
+    allowed: ["*"]
+  time:
+    command: npx
+    args: ["-y", "mcp-server-time@1.0.0"]
+    allowed: ["*"]
+---
+MCP pins
+`), 0644))
+
+	compiler := NewCompiler()
+	compiler.actionCache = reloaded
+	compiler.actionResolver = NewActionResolver(reloaded)
+	require.NoError(t, compiler.CompileWorkflow(workflowFile))
+	lock, err := os.ReadFile(filepath.Join(workflowsDir, "mcp-pins.lock.yml"))
+	require.NoError(t, err)
+	lockContent := string(lock)
+	assert.Contains(t, lockContent, "@modelcontextprotocol/server-filesystem@2025.8.21", "unversioned package should use the pinned version")
+	assert.Contains(t, lockContent, "mcp-server-time@1.0.0", "explicit versions should be left as-is")
+	assert.False(t, strings.Contains(lockContent, `"@modelcontextprotocol/server-filesystem",`), "floating package reference should be replaced")
+}
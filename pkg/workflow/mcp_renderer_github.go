@@ -145,7 +145,7 @@ func (r *MCPConfigRendererUnified) renderGitHubTOML(yaml *strings.Builder, githu
 	}
 	fmt.Fprintf(yaml, "          startup_timeout_sec = %d\n", startupTimeout)
 
-	// Use tools.timeout if specified, otherwise default to DefaultToolTimeout
+	// Use tool-timeouts.github, then tools.timeout, otherwise default to DefaultToolTimeout
 	// For GitHub Actions expressions, fall back to default (TOML format doesn't support expressions)
 	toolTimeout := int(constants.DefaultToolTimeout / time.Second)
 	if workflowData != nil && workflowData.ToolsTimeout != "" {
@@ -153,6 +153,9 @@ func (r *MCPConfigRendererUnified) renderGitHubTOML(yaml *strings.Builder, githu
 			toolTimeout = n
 		}
 	}
+	if workflowData != nil && workflowData.ToolTimeouts["github"] > 0 {
+		toolTimeout = workflowData.ToolTimeouts["github"]
+	}
 	fmt.Fprintf(yaml, "          tool_timeout_sec = %d\n", toolTimeout)
 
 	// Check if remote mode is enabled
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+
+	"github.com/github/gh-aw/pkg/sliceutil"
 )
 
 // guardExprSentinel is a prefix that marks a string value in the guard-policies map as a
@@ -69,14 +71,15 @@ func renderGuardPoliciesToml(yaml *strings.Builder, policies map[string]any, ser
 	yaml.WriteString("          \n")
 	yaml.WriteString("          [mcp_servers." + serverID + ".\"guard-policies\"]\n")
 
-	// Iterate over each policy (e.g., "write-sink")
-	for policyName, policyConfig := range policies {
+	// Iterate over each policy (e.g., "write-sink"), sorted for deterministic output
+	for _, policyName := range sliceutil.SortedKeys(policies) {
 		yaml.WriteString("          \n")
 		yaml.WriteString("          [mcp_servers." + serverID + ".\"guard-policies\"." + policyName + "]\n")
 
 		// Extract policy fields (e.g., "accept")
-		if configMap, ok := policyConfig.(map[string]any); ok {
-			for fieldName, fieldValue := range configMap {
+		if configMap, ok := policies[policyName].(map[string]any); ok {
+			for _, fieldName := range sliceutil.SortedKeys(configMap) {
+				fieldValue := configMap[fieldName]
 				// Handle array values (e.g., accept = ["private:github/gh-aw*"])
 				if arrayValue, ok := fieldValue.([]string); ok {
 					yaml.WriteString("          " + fieldName + " = [")
@@ -274,6 +274,9 @@ func generateSafeOutputsSetup(c *Compiler, yaml *strings.Builder, safeOutputConf
 	if workflowData.SafeOutputs != nil && workflowData.SafeOutputs.UploadArtifact != nil {
 		yaml.WriteString("          mkdir -p \"${RUNNER_TEMP}/gh-aw/safeoutputs/upload-artifacts\"\n")
 	}
+	if workflowData.SafeOutputs != nil && workflowData.SafeOutputs.CreateArtifact != nil {
+		yaml.WriteString("          mkdir -p \"${RUNNER_TEMP}/gh-aw/safeoutputs/create-artifacts\"\n")
+	}
 	if workflowData.SafeOutputs != nil && workflowData.SafeOutputs.UploadAssets != nil {
 		yaml.WriteString("          mkdir -p \"${RUNNER_TEMP}/gh-aw/safeoutputs/assets\"\n")
 	}
@@ -322,10 +325,9 @@ func generateSafeOutputsSetup(c *Compiler, yaml *strings.Builder, safeOutputConf
 	for line := range strings.SplitSeq(validationConfigJSON, "\n") {
 		yaml.WriteString("            " + line + "\n")
 	}
-	fmt.Fprintf(yaml, "        uses: %s\n", getCachedActionPin("actions/github-script", workflowData))
-	yaml.WriteString("        with:\n")
-	yaml.WriteString("          script: |\n")
-	yaml.WriteString(generateGitHubScriptWithRequire("generate_safe_outputs_tools.cjs"))
+	script := generateGitHubScriptWithRequire("generate_safe_outputs_tools.cjs")
+	fmt.Fprintf(yaml, "        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", workflowData), script))
+	yaml.WriteString(c.githubScriptWith(script))
 }
 
 // safeOutputsSecretEnvPrefix is prepended to secret names when generating step env var names for
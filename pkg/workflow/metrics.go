@@ -22,16 +22,38 @@ type ToolCallInfo struct {
 	OutputSample  string        // Preview of the largest tool response (first few lines, truncated)
 }
 
+// TurnTokenUsage is the token usage reported for a single LLM response (one agent turn)
+// together with the tools the model called in that response
+type TurnTokenUsage struct {
+	Turn             int      // 1-based turn number in log order
+	InputTokens      int      // Uncached input tokens
+	OutputTokens     int      // Output tokens, including the arguments of any tool calls
+	CacheReadTokens  int      // Input tokens served from the prompt cache
+	CacheWriteTokens int      // Input tokens written to the prompt cache
+	ToolCalls        []string // Prettified names of the tools called in this turn, in order
+}
+
+// PromptTokens returns the size of the prompt sent for this turn (input plus cached tokens)
+func (t TurnTokenUsage) PromptTokens() int {
+	return t.InputTokens + t.CacheReadTokens + t.CacheWriteTokens
+}
+
+// TotalTokens returns the sum of all token types for this turn
+func (t TurnTokenUsage) TotalTokens() int {
+	return t.PromptTokens() + t.OutputTokens
+}
+
 // LogMetrics represents extracted metrics from log files
 type LogMetrics struct {
 	TokenUsage             int
 	EstimatedCost          float64
-	Turns                  int            // Number of turns needed to complete the task
-	ToolCalls              []ToolCallInfo // Tool call statistics
-	ToolSequences          [][]string     // Sequences of tool calls preserving order
-	AvgTimeBetweenTurns    time.Duration  // Mean time between consecutive LLM API calls (computed from per-turn timestamps when available)
-	MaxTimeBetweenTurns    time.Duration  // Maximum time between any two consecutive LLM API calls
-	MedianTimeBetweenTurns time.Duration  // Median time between consecutive LLM API calls
+	Turns                  int              // Number of turns needed to complete the task
+	ToolCalls              []ToolCallInfo   // Tool call statistics
+	ToolSequences          [][]string       // Sequences of tool calls preserving order
+	TurnTokens             []TurnTokenUsage // Per-turn token usage, when the engine log reports usage for each response
+	AvgTimeBetweenTurns    time.Duration    // Mean time between consecutive LLM API calls (computed from per-turn timestamps when available)
+	MaxTimeBetweenTurns    time.Duration    // Maximum time between any two consecutive LLM API calls
+	MedianTimeBetweenTurns time.Duration    // Median time between consecutive LLM API calls
 	// StdDevTimeBetweenTurns is the sample standard deviation (Bessel's correction, n-1
 	// denominator) of inter-turn intervals, treating the observed turns as a sample of
 	// the agent's execution behaviour rather than an exhaustive population.
@@ -162,6 +184,32 @@ func ExtractJSONTokenUsage(data map[string]any) int {
 	return 0
 }
 
+// ExtractTurnTokenUsage extracts the input, output, and cache token split from a usage
+// object in Claude format (input_tokens, cache_read_input_tokens, ...) or OpenAI format
+// (prompt_tokens, completion_tokens, prompt_tokens_details.cached_tokens). OpenAI counts
+// cached tokens as part of prompt_tokens, so they are subtracted from InputTokens.
+// Returns false when the object reports no tokens.
+func ExtractTurnTokenUsage(usage map[string]any) (TurnTokenUsage, bool) {
+	turn := TurnTokenUsage{
+		InputTokens:      typeutil.ConvertToInt(usage["input_tokens"]),
+		OutputTokens:     typeutil.ConvertToInt(usage["output_tokens"]),
+		CacheReadTokens:  typeutil.ConvertToInt(usage["cache_read_input_tokens"]),
+		CacheWriteTokens: typeutil.ConvertToInt(usage["cache_creation_input_tokens"]),
+	}
+	if turn.InputTokens == 0 && turn.OutputTokens == 0 {
+		turn.InputTokens = typeutil.ConvertToInt(usage["prompt_tokens"])
+		turn.OutputTokens = typeutil.ConvertToInt(usage["completion_tokens"])
+		if details, ok := usage["prompt_tokens_details"].(map[string]any); ok {
+			cached := typeutil.ConvertToInt(details["cached_tokens"])
+			if cached > 0 && cached <= turn.InputTokens {
+				turn.CacheReadTokens = cached
+				turn.InputTokens -= cached
+			}
+		}
+	}
+	return turn, turn.TotalTokens() > 0
+}
+
 // ExtractJSONCost extracts cost information from JSON data
 func ExtractJSONCost(data map[string]any) float64 {
 	// Common cost field names
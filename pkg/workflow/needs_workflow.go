@@ -0,0 +1,209 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+)
+
+var needsWorkflowLog = logger.New("workflow:needs_workflow")
+
+// needsWorkflowIDPattern matches entries that may refer to a sibling agentic workflow
+// file (e.g. "ci-doctor" or "ci-doctor.md") rather than a workflow display name.
+var needsWorkflowIDPattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// NeedsWorkflowConfig is the normalized form of the top-level needs-workflow field.
+type NeedsWorkflowConfig struct {
+	Workflows   []string // Workflow names or sibling agentic workflow IDs
+	Conclusions []string // Required conclusions of the upstream run (default: success)
+	Branches    []string // Optional branch filter for the upstream run
+}
+
+// parseNeedsWorkflowConfig normalizes the needs-workflow value, which may be a single
+// workflow, a list of workflows, or an object with workflows/conclusion/branches.
+func parseNeedsWorkflowConfig(value any) (*NeedsWorkflowConfig, error) {
+	config := &NeedsWorkflowConfig{}
+
+	switch v := value.(type) {
+	case string, []any, []string:
+		config.Workflows = normalizeStringOrStringSlice(v)
+	case map[string]any:
+		config.Workflows = normalizeStringOrStringSlice(v["workflows"])
+		if conclusion, ok := v["conclusion"]; ok {
+			config.Conclusions = normalizeStringOrStringSlice(conclusion)
+			if len(config.Conclusions) == 0 {
+				return nil, errors.New("needs-workflow.conclusion must be a string or a non-empty list of strings")
+			}
+		}
+		if branches, ok := v["branches"]; ok {
+			config.Branches = normalizeStringOrStringSlice(branches)
+		}
+	default:
+		return nil, fmt.Errorf("needs-workflow must be a string, a list of strings, or an object, got %T", value)
+	}
+
+	workflows := make([]string, 0, len(config.Workflows))
+	for _, w := range config.Workflows {
+		if w = strings.TrimSpace(w); w != "" {
+			workflows = append(workflows, w)
+		}
+	}
+	if len(workflows) == 0 {
+		return nil, errors.New("needs-workflow must list at least one workflow")
+	}
+	config.Workflows = workflows
+
+	if len(config.Conclusions) == 0 {
+		config.Conclusions = []string{"success"}
+	}
+	for _, c := range config.Conclusions {
+		if !isValidWorkflowRunConclusion(c) {
+			return nil, fmt.Errorf("invalid needs-workflow conclusion %q: must be one of %s",
+				c, strings.Join(validWorkflowRunConclusions, ", "))
+		}
+	}
+
+	return config, nil
+}
+
+// resolveNeedsWorkflowName maps an entry that names a sibling agentic workflow
+// (<id>.md next to the current workflow) to the name its lock file is compiled with,
+// since workflow_run filters on workflow names rather than file names. Entries that
+// do not match a sibling file are returned unchanged.
+func resolveNeedsWorkflowName(entry string, markdownPath string) string {
+	if markdownPath == "" || !needsWorkflowIDPattern.MatchString(entry) {
+		return entry
+	}
+
+	id := strings.TrimSuffix(entry, ".md")
+	siblingPath := filepath.Join(filepath.Dir(markdownPath), id+".md")
+	if filepath.Clean(siblingPath) == filepath.Clean(markdownPath) {
+		return entry
+	}
+
+	content, err := os.ReadFile(siblingPath)
+	if err != nil {
+		needsWorkflowLog.Printf("No sibling workflow for needs-workflow entry %q: %v", entry, err)
+		return entry
+	}
+
+	result, err := parser.ExtractFrontmatterFromContent(string(content))
+	if err != nil {
+		needsWorkflowLog.Printf("Failed to parse sibling workflow %s: %v", siblingPath, err)
+		return entry
+	}
+	if name := extractStringFromMap(result.Frontmatter, "name", nil); name != "" {
+		needsWorkflowLog.Printf("Resolved needs-workflow entry %q to frontmatter name %q", entry, name)
+		return name
+	}
+	name, err := parser.ExtractWorkflowNameFromMarkdownBody(result.Markdown, siblingPath)
+	if err != nil || name == "" {
+		return entry
+	}
+	needsWorkflowLog.Printf("Resolved needs-workflow entry %q to workflow name %q", entry, name)
+	return name
+}
+
+// preprocessNeedsWorkflow expands the top-level needs-workflow field into an
+// on.workflow_run trigger (types: [completed]) with the configured conclusion and
+// branch filters. The conclusion is compiled into the activation if: condition by
+// extractWorkflowRunConclusionCondition. It modifies the frontmatter map in place.
+func (c *Compiler) preprocessNeedsWorkflow(frontmatter map[string]any, markdownPath string) error {
+	value, exists := frontmatter["needs-workflow"]
+	if !exists {
+		return nil
+	}
+
+	config, err := parseNeedsWorkflowConfig(value)
+	if err != nil {
+		return err
+	}
+	needsWorkflowLog.Printf("Expanding needs-workflow: workflows=%v, conclusions=%v, branches=%v",
+		config.Workflows, config.Conclusions, config.Branches)
+
+	onMap := map[string]any{}
+	switch on := frontmatter["on"].(type) {
+	case nil:
+	case map[string]any:
+		onMap = on
+	case string:
+		onMap[on] = nil
+	case []any:
+		for _, item := range on {
+			if event, ok := item.(string); ok {
+				onMap[event] = nil
+			}
+		}
+	default:
+		return fmt.Errorf("needs-workflow cannot be combined with an 'on' section of type %T", on)
+	}
+
+	if _, hasWorkflowRun := onMap["workflow_run"]; hasWorkflowRun {
+		return errors.New("needs-workflow cannot be combined with on.workflow_run; declare the dependency in one place")
+	}
+
+	workflows := make([]any, 0, len(config.Workflows))
+	for _, entry := range config.Workflows {
+		workflows = append(workflows, resolveNeedsWorkflowName(entry, markdownPath))
+	}
+
+	workflowRun := map[string]any{
+		"workflows": workflows,
+		"types":     []any{"completed"},
+	}
+	if len(config.Branches) > 0 {
+		branches := make([]any, 0, len(config.Branches))
+		for _, b := range config.Branches {
+			branches = append(branches, b)
+		}
+		workflowRun["branches"] = branches
+	}
+	if len(config.Conclusions) == 1 {
+		workflowRun["conclusion"] = config.Conclusions[0]
+	} else {
+		conclusions := make([]any, 0, len(config.Conclusions))
+		for _, c := range config.Conclusions {
+			conclusions = append(conclusions, c)
+		}
+		workflowRun["conclusion"] = conclusions
+	}
+
+	onMap["workflow_run"] = workflowRun
+	frontmatter["on"] = onMap
+	return nil
+}
+
+// buildWorkflowRunContextPromptSection returns the prompt section that tells the agent
+// which upstream run triggered it, or nil when the workflow has no workflow_run trigger.
+// The section is only rendered at runtime when the event is actually workflow_run.
+func buildWorkflowRunContextPromptSection(data *WorkflowData) *PromptSection {
+	workflowRunMap, ok := parseWorkflowRunTrigger(data.On)
+	if !ok {
+		return nil
+	}
+
+	// Static values are wrapped in single quotes by the prompt step, so escape them here.
+	upstream := strings.ReplaceAll(strings.Join(normalizeStringOrStringSlice(workflowRunMap["workflows"]), ", "), "'", "''")
+	if upstream == "" {
+		upstream = ghaEmptyStringExpr
+	}
+
+	return &PromptSection{
+		Content:        workflowRunContextPromptFile,
+		IsFile:         true,
+		ShellCondition: `[ "$GITHUB_EVENT_NAME" = "workflow_run" ]`,
+		EnvVars: map[string]string{
+			"GH_AW_UPSTREAM_WORKFLOWS":      upstream,
+			"GH_AW_UPSTREAM_RUN_ID":         "${{ github.event.workflow_run.id }}",
+			"GH_AW_UPSTREAM_RUN_CONCLUSION": "${{ github.event.workflow_run.conclusion }}",
+			"GH_AW_UPSTREAM_RUN_URL":        "${{ github.event.workflow_run.html_url }}",
+			"GH_AW_UPSTREAM_HEAD_SHA":       "${{ github.event.workflow_run.head_sha }}",
+		},
+	}
+}
@@ -0,0 +1,194 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNeedsWorkflowConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   any
+		want    *NeedsWorkflowConfig
+		wantErr string
+	}{
+		{
+			name:  "single workflow string defaults to success",
+			value: "ci",
+			want:  &NeedsWorkflowConfig{Workflows: []string{"ci"}, Conclusions: []string{"success"}},
+		},
+		{
+			name:  "list of workflows",
+			value: []any{"build", " test "},
+			want:  &NeedsWorkflowConfig{Workflows: []string{"build", "test"}, Conclusions: []string{"success"}},
+		},
+		{
+			name: "object form",
+			value: map[string]any{
+				"workflows":  []any{"CI"},
+				"conclusion": []any{"failure", "timed_out"},
+				"branches":   []any{"main"},
+			},
+			want: &NeedsWorkflowConfig{
+				Workflows:   []string{"CI"},
+				Conclusions: []string{"failure", "timed_out"},
+				Branches:    []string{"main"},
+			},
+		},
+		{
+			name:    "empty list",
+			value:   []any{},
+			wantErr: "at least one workflow",
+		},
+		{
+			name:    "object without workflows",
+			value:   map[string]any{"conclusion": "success"},
+			wantErr: "at least one workflow",
+		},
+		{
+			name:    "invalid conclusion",
+			value:   map[string]any{"workflows": "CI", "conclusion": "done"},
+			wantErr: `invalid needs-workflow conclusion "done"`,
+		},
+		{
+			name:    "unsupported type",
+			value:   42,
+			wantErr: "must be a string, a list of strings, or an object",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseNeedsWorkflowConfig(tt.value)
+			if tt.wantErr != "" {
+				require.Error(t, err, "expected parse error")
+				assert.Contains(t, err.Error(), tt.wantErr, "error message should explain the problem")
+				return
+			}
+			require.NoError(t, err, "valid needs-workflow should parse")
+			assert.Equal(t, tt.want, got, "parsed config should match")
+		})
+	}
+}
+
+func TestPreprocessNeedsWorkflow(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "needs-workflow-*")
+	markdownPath := filepath.Join(tmpDir, "report.md")
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "build-check.md"), []byte("---\non: push\n---\n# Build Check\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "named.md"), []byte("---\nname: Nightly Build\non: push\n---\n# Ignored Header\n"), 0644))
+
+	t.Run("no needs-workflow leaves frontmatter untouched", func(t *testing.T) {
+		frontmatter := map[string]any{"on": "push"}
+		require.NoError(t, NewCompiler().preprocessNeedsWorkflow(frontmatter, markdownPath), "should not error")
+		assert.Equal(t, "push", frontmatter["on"], "on should be unchanged")
+	})
+
+	t.Run("creates on section and resolves sibling workflow names", func(t *testing.T) {
+		frontmatter := map[string]any{
+			"needs-workflow": []any{"build-check", "named.md", "CI"},
+		}
+		require.NoError(t, NewCompiler().preprocessNeedsWorkflow(frontmatter, markdownPath), "should not error")
+		assert.Equal(t, map[string]any{
+			"workflow_run": map[string]any{
+				"workflows":  []any{"Build Check", "Nightly Build", "CI"},
+				"types":      []any{"completed"},
+				"conclusion": "success",
+			},
+		}, frontmatter["on"], "needs-workflow should expand into on.workflow_run")
+	})
+
+	t.Run("merges with existing triggers", func(t *testing.T) {
+		frontmatter := map[string]any{
+			"on": []any{"workflow_dispatch"},
+			"needs-workflow": map[string]any{
+				"workflows":  "CI",
+				"conclusion": []any{"success", "failure"},
+				"branches":   []any{"main"},
+			},
+		}
+		require.NoError(t, NewCompiler().preprocessNeedsWorkflow(frontmatter, markdownPath), "should not error")
+		onMap, ok := frontmatter["on"].(map[string]any)
+		require.True(t, ok, "on should be converted to a map")
+		assert.Contains(t, onMap, "workflow_dispatch", "existing trigger should be preserved")
+		assert.Equal(t, map[string]any{
+			"workflows":  []any{"CI"},
+			"types":      []any{"completed"},
+			"branches":   []any{"main"},
+			"conclusion": []any{"success", "failure"},
+		}, onMap["workflow_run"], "workflow_run should carry branches and conclusions")
+	})
+
+	t.Run("rejects explicit workflow_run", func(t *testing.T) {
+		frontmatter := map[string]any{
+			"on": map[string]any{
+				"workflow_run": map[string]any{"workflows": []any{"CI"}},
+			},
+			"needs-workflow": "CI",
+		}
+		err := NewCompiler().preprocessNeedsWorkflow(frontmatter, markdownPath)
+		require.Error(t, err, "needs-workflow and on.workflow_run should conflict")
+		assert.Contains(t, err.Error(), "cannot be combined with on.workflow_run", "error should name the conflict")
+	})
+}
+
+func TestNeedsWorkflowCompilation(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "needs-workflow-compile-*")
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "build-check.md"), []byte("---\non: workflow_dispatch\n---\n# Build Check\n\nBuild.\n"), 0644))
+
+	workflowPath := filepath.Join(tmpDir, "report.md")
+	content := `---
+needs-workflow:
+  workflows: [build-check]
+  conclusion: [success, failure]
+  branches: [main]
+permissions:
+  contents: read
+  actions: read
+engine: copilot
+---
+
+# Report
+
+Summarize the upstream run.
+`
+	require.NoError(t, os.WriteFile(workflowPath, []byte(content), 0644))
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(workflowPath), "workflow with needs-workflow should compile")
+
+	lockContent, err := os.ReadFile(filepath.Join(tmpDir, "report.lock.yml"))
+	require.NoError(t, err, "lock file should be written")
+	lock := string(lockContent)
+
+	assert.Contains(t, lock, "workflow_run:", "should compile to a workflow_run trigger")
+	assert.Contains(t, lock, "- Build Check", "sibling workflow ID should resolve to its workflow name")
+	assert.Contains(t, lock, "- completed", "should only trigger on completed runs")
+	assert.Contains(t, lock, "github.event.workflow_run.conclusion == 'success' || github.event.workflow_run.conclusion == 'failure'",
+		"conclusion check should be compiled into the if condition")
+	assert.Contains(t, lock, "github.event.workflow_run.repository.id == github.repository_id", "repo safety check should be applied")
+	assert.Contains(t, lock, "workflow_run_context_prompt.md", "upstream run context should be added to the prompt")
+	assert.Contains(t, lock, "GH_AW_UPSTREAM_RUN_ID: ${{ github.event.workflow_run.id }}", "upstream run ID should be passed to the prompt")
+	assert.False(t, strings.Contains(lock, "needs-workflow:"), "needs-workflow should not leak into the compiled YAML")
+}
+
+func TestWorkflowRunContextPromptSection(t *testing.T) {
+	assert.Nil(t, buildWorkflowRunContextPromptSection(&WorkflowData{On: "on:\n  push:\n"}), "no section without workflow_run")
+
+	section := buildWorkflowRunContextPromptSection(&WorkflowData{
+		On: "on:\n  workflow_run:\n    workflows:\n      - CI\n      - Bob's Build\n    types:\n      - completed\n",
+	})
+	require.NotNil(t, section, "section should be built for workflow_run triggers")
+	assert.Equal(t, workflowRunContextPromptFile, section.Content, "section should reference the runtime prompt file")
+	assert.True(t, section.IsFile, "section should be file based")
+	assert.Contains(t, section.ShellCondition, "workflow_run", "section should only render for workflow_run events")
+	assert.Equal(t, "CI, Bob''s Build", section.EnvVars["GH_AW_UPSTREAM_WORKFLOWS"], "workflow names should be listed and quote-escaped")
+	assert.Equal(t, "${{ github.event.workflow_run.id }}", section.EnvVars["GH_AW_UPSTREAM_RUN_ID"], "run ID should come from the event payload")
+}
@@ -204,6 +204,8 @@ func BuildNpmEngineInstallStepsWithAWF(npmSteps []GitHubActionStep, workflowData
 //
 // RUNNER_TOOL_CACHE is required because the Actions runner populates it from the
 // runner tool_cache context. The generated command does not guess fallback paths.
+// The directory itself may be missing on self-hosted runners that never cached a
+// tool; in that case the find yields nothing and PATH is left unchanged.
 //
 // Returns:
 //   - string: A shell command that exports PATH with hostedtoolcache bin directories prepended
@@ -0,0 +1,115 @@
+package workflow
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var notifyLog = logger.New("workflow:notify")
+
+// NotifyConfig holds configuration for posting agent notifications to chat webhooks.
+// The agent can only choose among the configured channels; webhook URLs are secrets that
+// are exposed to the safe outputs job as environment variables, never to the agent.
+type NotifyConfig struct {
+	BaseSafeOutputConfig `yaml:",inline"`
+	Channels             map[string]*NotifyChannelConfig `yaml:"channels,omitempty"` // Allowed channels keyed by name
+	Template             string                          `yaml:"template,omitempty"` // Message template with {placeholder} substitution
+}
+
+// NotifyChannelConfig describes a single notification channel.
+type NotifyChannelConfig struct {
+	Webhook string `yaml:"webhook"`          // Secret expression holding the webhook URL (e.g. "${{ secrets.SLACK_WEBHOOK_URL }}")
+	Format  string `yaml:"format,omitempty"` // Payload format: "slack" (default), "teams", or "json"
+}
+
+// parseNotifyConfig handles notify configuration
+func (c *Compiler) parseNotifyConfig(outputMap map[string]any) *NotifyConfig {
+	configData, exists := outputMap["notify"]
+	if !exists {
+		return nil
+	}
+
+	notifyLog.Print("Parsing notify configuration")
+	config := &NotifyConfig{Channels: make(map[string]*NotifyChannelConfig)}
+
+	if configMap, ok := configData.(map[string]any); ok {
+		c.parseBaseSafeOutputConfig(configMap, &config.BaseSafeOutputConfig, 1)
+
+		if template, ok := configMap["template"].(string); ok {
+			config.Template = template
+		}
+
+		if channels, ok := configMap["channels"].(map[string]any); ok {
+			for name, channelData := range channels {
+				channelMap, ok := channelData.(map[string]any)
+				if !ok {
+					notifyLog.Printf("Warning: notify channel %q is not an object and will be ignored", name)
+					continue
+				}
+				channel := &NotifyChannelConfig{}
+				if webhook, ok := channelMap["webhook"].(string); ok {
+					channel.Webhook = webhook
+				}
+				if format, ok := channelMap["format"].(string); ok {
+					channel.Format = format
+				}
+				config.Channels[name] = channel
+			}
+		}
+	} else {
+		config.Max = defaultIntStr(1)
+	}
+
+	notifyLog.Printf("Parsed notify config: channels=%v, hasTemplate=%v", config.channelNames(), config.Template != "")
+	return config
+}
+
+// channelNames returns the configured channel names in sorted order.
+func (n *NotifyConfig) channelNames() []string {
+	names := make([]string, 0, len(n.Channels))
+	for name := range n.Channels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// notifyWebhookEnvVarName returns the environment variable that carries the webhook URL
+// for a channel. Must stay in sync with getWebhookEnvVarName in actions/setup/js/notify.cjs.
+func notifyWebhookEnvVarName(channel string) string {
+	return "GH_AW_NOTIFY_WEBHOOK_" + strings.ToUpper(strings.ReplaceAll(channel, "-", "_"))
+}
+
+// buildNotifyWebhookEnvVars returns the handler manager step env entries that expose
+// each channel's webhook secret to the notify handler.
+func buildNotifyWebhookEnvVars(config *NotifyConfig) []string {
+	if config == nil {
+		return nil
+	}
+	var envVars []string
+	for _, name := range config.channelNames() {
+		webhook := config.Channels[name].Webhook
+		if webhook == "" {
+			continue
+		}
+		envVars = append(envVars, fmt.Sprintf("          %s: %s\n", notifyWebhookEnvVarName(name), webhook))
+	}
+	return envVars
+}
+
+// notifyChannelsHandlerConfig returns the channel settings passed to the notify handler.
+// Webhook URLs are deliberately omitted; the handler reads them from the environment.
+func notifyChannelsHandlerConfig(config *NotifyConfig) map[string]any {
+	channels := make(map[string]any, len(config.Channels))
+	for name, channel := range config.Channels {
+		settings := map[string]any{}
+		if channel.Format != "" {
+			settings["format"] = channel.Format
+		}
+		channels[name] = settings
+	}
+	return channels
+}
@@ -2,9 +2,11 @@ package workflow
 
 import (
 	"fmt"
+	"os"
 	"slices"
 	"strings"
 
+	"github.com/github/gh-aw/pkg/console"
 	"github.com/github/gh-aw/pkg/constants"
 	"github.com/github/gh-aw/pkg/logger"
 )
@@ -57,6 +59,9 @@ func (c *Compiler) buildConclusionJob(data *WorkflowData, mainJobName string, sa
 			ScriptFile:    "notify_comment_error.cjs",
 			CustomToken:   token,
 		})...)
+	} else if data.Conclusion != nil {
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage("conclusion.template has no effect without status comments; set on.status-comment: true to post the conclusion comment"))
+		c.IncrementWarningCount()
 	}
 	if c.actionMode.IsScript() {
 		steps = append(steps, c.generateScriptModeCleanupStep())
@@ -79,13 +84,13 @@ func (c *Compiler) buildConclusionJob(data *WorkflowData, mainJobName string, sa
 	// credentials, id-token: write is needed so the conclusion job can mint the OTLP
 	// OIDC token via core.getIDToken(audience) (mirrors threat_detection_job.go).
 	if hasOTLPGitHubOIDCAuth(data.ParsedFrontmatter, data.RawFrontmatter) {
-		conclusionPerms.Set(PermissionIdToken, PermissionWrite)
+		conclusionPerms.Require(PermissionIdToken, PermissionWrite, "observability.otlp")
 	}
 	// The daily-AIC usage cache save step must not run with a fully read-only GITHUB_TOKEN.
 	// If safe-outputs already granted some writable scope (for example issues: write for
 	// comment updates), reuse that existing write access instead of broadening the job.
 	if needsDailyAICCachePermission(data) && !conclusionPerms.HasAnyWriteScope() {
-		conclusionPerms.Set(PermissionActions, PermissionWrite)
+		conclusionPerms.Require(PermissionActions, PermissionWrite, maxDailyAICreditsField+" usage cache")
 	}
 	return &Job{
 		Name:        "conclusion",
@@ -193,10 +198,14 @@ func buildUsageArtifactUploadSteps(prefix string, hasEvals bool, pinAction func(
 // The sequence is: restore latest snapshot → append current run entry → save updated snapshot.
 // The restore step uses a prefix restore-key so it picks up the most recent snapshot even when
 // the exact key (which includes the current run ID) does not exist yet.
-func buildDailyAICUsageCacheSteps(data *WorkflowData, pinAction func(string) string) []string {
+func (c *Compiler) buildDailyAICUsageCacheSteps(data *WorkflowData, pinAction func(string) string) []string {
 	sanitized := SanitizeWorkflowIDForCacheKey(data.WorkflowID)
 	cacheKeyPrefix := fmt.Sprintf("agentic-workflow-usage-%s-", sanitized)
 	cacheKey := cacheKeyPrefix + "${{ github.run_id }}"
+	script := "            const { setupGlobals } = require('" + SetupActionDestination + "/setup_globals.cjs');\n" +
+		"            setupGlobals(core, github, context);\n" +
+		"            const { main } = require('" + SetupActionDestination + "/write_daily_aic_usage_cache.cjs');\n" +
+		"            await main();\n"
 	return []string{
 		"      - name: Restore daily AIC usage cache\n",
 		"        id: restore-daily-aic-cache-conclusion\n",
@@ -211,14 +220,8 @@ func buildDailyAICUsageCacheSteps(data *WorkflowData, pinAction func(string) str
 		"        id: write-daily-aic-cache\n",
 		"        if: always()\n",
 		"        continue-on-error: true\n",
-		fmt.Sprintf("        uses: %s\n", pinAction("actions/github-script")),
-		"        with:\n",
-		"          github-token: ${{ github.token }}\n",
-		"          script: |\n",
-		"            const { setupGlobals } = require('" + SetupActionDestination + "/setup_globals.cjs');\n",
-		"            setupGlobals(core, github, context);\n",
-		"            const { main } = require('" + SetupActionDestination + "/write_daily_aic_usage_cache.cjs');\n",
-		"            await main();\n",
+		fmt.Sprintf("        uses: %s\n", c.githubScriptUses(pinAction("actions/github-script"), script)),
+		c.githubScriptWith(script, "          github-token: ${{ github.token }}\n"),
 		"      - name: Save daily AIC usage cache\n",
 		"        id: save-daily-aic-cache\n",
 		"        if: always()\n",
@@ -52,7 +52,7 @@ func (c *Compiler) buildConclusionSetupSteps(data *WorkflowData) []string {
 	steps = append(steps, buildAgentOutputDownloadSteps(artifactPrefixExprForDownstreamJob(data), c.getActionPin)...)
 	steps = append(steps, buildUsageArtifactUploadSteps(artifactPrefixExprForDownstreamJob(data), data.Evals != nil && data.Evals.HasEvals(), c.getActionPin)...)
 	if needsDailyAICCachePermission(data) {
-		steps = append(steps, buildDailyAICUsageCacheSteps(data, c.getActionPin)...)
+		steps = append(steps, c.buildDailyAICUsageCacheSteps(data, c.getActionPin)...)
 	}
 
 	return steps
@@ -456,9 +456,14 @@ func (c *Compiler) buildConclusionScriptEnvVars(data *WorkflowData, mainJobName
 	if data.SafeOutputs.AssignToAgent != nil {
 		envVars = append(envVars, "          GH_AW_ASSIGNMENT_ERROR_COUNT: ${{ needs.safe_outputs.outputs.assign_to_agent_assignment_error_count }}\n")
 	}
+	if data.SafeOutputs.MaxTotal > 0 {
+		envVars = append(envVars, "          GH_AW_DROPPED_OUTPUTS: ${{ needs.safe_outputs.outputs.dropped_outputs }}\n")
+		envVars = append(envVars, "          GH_AW_DROPPED_OUTPUT_COUNT: ${{ needs.safe_outputs.outputs.dropped_output_count }}\n")
+	}
 	if messagesJSON != "" {
 		envVars = append(envVars, fmt.Sprintf("          GH_AW_SAFE_OUTPUT_MESSAGES: %q\n", messagesJSON))
 	}
+	envVars = append(envVars, buildConclusionTemplateEnvVars(data, mainJobName)...)
 	if len(safeOutputJobNames) > 0 {
 		if safeOutputJobsJSON, jobURLEnvVars := buildSafeOutputJobsEnvVars(safeOutputJobNames); safeOutputJobsJSON != "" {
 			envVars = append(envVars, fmt.Sprintf("          GH_AW_SAFE_OUTPUT_JOBS: %q\n", safeOutputJobsJSON))
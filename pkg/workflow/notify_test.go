@@ -0,0 +1,67 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNotifyConfig(t *testing.T) {
+	compiler := NewCompiler()
+
+	t.Run("absent key returns nil", func(t *testing.T) {
+		assert.Nil(t, compiler.parseNotifyConfig(map[string]any{}), "config should be nil when not configured")
+	})
+
+	t.Run("parses channels and template", func(t *testing.T) {
+		config := compiler.parseNotifyConfig(map[string]any{
+			"notify": map[string]any{
+				"channels": map[string]any{
+					"alerts":    map[string]any{"webhook": "${{ secrets.SLACK_ALERTS_WEBHOOK }}"},
+					"eng-teams": map[string]any{"webhook": "${{ secrets.TEAMS_WEBHOOK }}", "format": "teams"},
+				},
+				"template": "*{title}*\n{message}",
+				"max":      3,
+			},
+		})
+		require.NotNil(t, config, "config should be parsed")
+		assert.Equal(t, []string{"alerts", "eng-teams"}, config.channelNames(), "channels should be parsed")
+		assert.Equal(t, "${{ secrets.TEAMS_WEBHOOK }}", config.Channels["eng-teams"].Webhook, "webhook should be parsed")
+		assert.Equal(t, "teams", config.Channels["eng-teams"].Format, "format should be parsed")
+		assert.Empty(t, config.Channels["alerts"].Format, "format should default to slack at runtime")
+		assert.Equal(t, "*{title}*\n{message}", config.Template, "template should be parsed")
+		require.NotNil(t, config.Max, "max should be parsed")
+		assert.Equal(t, "3", *config.Max, "max should be parsed")
+	})
+}
+
+func TestNotifyWebhooksStayOutOfHandlerConfig(t *testing.T) {
+	compiler := NewCompiler()
+	workflowData := &WorkflowData{
+		Name: "notify-workflow",
+		SafeOutputs: &SafeOutputsConfig{
+			Notify: &NotifyConfig{
+				BaseSafeOutputConfig: BaseSafeOutputConfig{Max: strPtr("1")},
+				Channels: map[string]*NotifyChannelConfig{
+					"eng-alerts": {Webhook: "${{ secrets.SLACK_WEBHOOK_URL }}", Format: "slack"},
+				},
+			},
+		},
+	}
+
+	steps, err := compiler.buildHandlerManagerStep(workflowData)
+	require.NoError(t, err, "handler manager step should build")
+	yamlStr := strings.Join(steps, "")
+
+	assert.Contains(t, yamlStr, "GH_AW_NOTIFY_WEBHOOK_ENG_ALERTS: ${{ secrets.SLACK_WEBHOOK_URL }}", "webhook secret should be exposed as a channel env var")
+
+	handlerConfig := extractHandlerManagerConfigFromYAML(t, yamlStr)
+	notifyConfig, ok := handlerConfig["notify"]
+	require.True(t, ok, "notify handler should be configured")
+	assert.Equal(t, map[string]any{"eng-alerts": map[string]any{"format": "slack"}}, notifyConfig["channels"], "channels should be passed without webhook URLs")
+	assert.NotContains(t, yamlStr, `\"webhook\"`, "webhook secrets must not be written to the handler config")
+}
@@ -558,3 +558,39 @@ imports:
 		}
 	}
 }
+
+func TestCompileWorkflow_IncludesRunMetricsSummaryStep(t *testing.T) {
+	tmpDir := t.TempDir()
+	workflowPath := filepath.Join(tmpDir, "run-metrics-summary.md")
+	content := `---
+on: push
+permissions:
+  contents: read
+engine: copilot
+---
+
+# Test Run Metrics Summary
+`
+
+	if err := os.WriteFile(workflowPath, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write workflow: %v", err)
+	}
+
+	compiler := NewCompiler()
+	if err := compiler.CompileWorkflow(workflowPath); err != nil {
+		t.Fatalf("Unexpected compile error: %v", err)
+	}
+
+	lockContent, err := os.ReadFile(filepath.Join(tmpDir, "run-metrics-summary.lock.yml"))
+	if err != nil {
+		t.Fatalf("Failed to read lock file: %v", err)
+	}
+
+	compiled := string(lockContent)
+	if !strings.Contains(compiled, "- name: Generate run metrics summary") {
+		t.Fatal("Expected run metrics summary step to be generated")
+	}
+	if !strings.Contains(compiled, "require('${{ runner.temp }}/gh-aw/actions/generate_run_metrics_summary.cjs')") {
+		t.Fatal("Expected generated workflow to load generate_run_metrics_summary.cjs")
+	}
+}
@@ -0,0 +1,70 @@
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var permissionTelemetryLog = logger.New("workflow:permission_telemetry")
+
+// isPermissionTelemetryEnabled reports whether the agent job should record which GitHub
+// MCP tools the agent called. It requires the permission-telemetry feature flag and the
+// GitHub MCP tool, since the GitHub MCP server is the only source of API usage telemetry.
+func isPermissionTelemetryEnabled(data *WorkflowData) bool {
+	return isFeatureEnabled(constants.PermissionTelemetryFeatureFlag, data) && hasGitHubTool(data.ParsedTools)
+}
+
+// buildRequestedPermissionsJSON returns the agent job's compiled permissions as a JSON
+// object mapping scope to level (e.g. {"contents":"read","issues":"read"}). Shorthand
+// permissions such as read-all are expanded to every scope they grant.
+func (c *Compiler) buildRequestedPermissionsJSON(data *WorkflowData) (string, error) {
+	permissionsYAML, err := c.buildMainJobPermissions(data)
+	if err != nil {
+		return "", err
+	}
+
+	requested := make(map[string]string)
+	if permissionsYAML != "" {
+		permissions := NewPermissionsParser(permissionsYAML).ToPermissions()
+		for _, scope := range GetAllPermissionScopes() {
+			if level, ok := permissions.Get(scope); ok && level != PermissionNone {
+				requested[string(scope)] = string(level)
+			}
+		}
+	}
+
+	requestedJSON, err := json.Marshal(requested)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal requested permissions: %w", err)
+	}
+	return string(requestedJSON), nil
+}
+
+// generatePermissionTelemetryStep emits a step that writes permission_usage.json with the
+// agent job's requested permissions and the GitHub MCP tools called during the run.
+// `gh aw audit` maps each tool to the permission scopes its toolset needs and reports
+// requested scopes that were never used.
+func (c *Compiler) generatePermissionTelemetryStep(yaml *strings.Builder, data *WorkflowData) {
+	if !isPermissionTelemetryEnabled(data) {
+		return
+	}
+
+	requestedJSON, err := c.buildRequestedPermissionsJSON(data)
+	if err != nil {
+		// The same error is reported when the agent job's permissions are rendered.
+		permissionTelemetryLog.Printf("Skipping permission telemetry step: %v", err)
+		return
+	}
+	permissionTelemetryLog.Printf("Generating permission telemetry step: requested=%s", requestedJSON)
+
+	yaml.WriteString("      - name: Record GitHub permission usage\n")
+	yaml.WriteString("        if: always()\n")
+	yaml.WriteString("        continue-on-error: true\n")
+	yaml.WriteString("        env:\n")
+	fmt.Fprintf(yaml, "          GH_AW_REQUESTED_PERMISSIONS: '%s'\n", requestedJSON)
+	yaml.WriteString("        run: node \"${RUNNER_TEMP}/gh-aw/actions/record_permission_usage.cjs\"\n")
+}
@@ -0,0 +1,82 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitHubToolReadPermissions(t *testing.T) {
+	scopes, ok := GitHubToolReadPermissions("issue_read")
+	require.True(t, ok, "issue_read should be mapped")
+	assert.Equal(t, []PermissionScope{PermissionIssues}, scopes, "issue_read needs issues")
+
+	scopes, ok = GitHubToolReadPermissions("get_me")
+	require.True(t, ok, "get_me should be mapped")
+	assert.Empty(t, scopes, "context tools need no permission scopes")
+
+	_, ok = GitHubToolReadPermissions("not_a_github_tool")
+	assert.False(t, ok, "unknown tools should not be mapped")
+}
+
+func TestPermissionTelemetryStep(t *testing.T) {
+	tests := []struct {
+		name        string
+		frontmatter string
+		wantStep    bool
+	}{
+		{
+			name: "feature enabled with GitHub tool",
+			frontmatter: `features:
+  permission-telemetry: true
+tools:
+  github:
+    toolsets: [issues]`,
+			wantStep: true,
+		},
+		{
+			name: "feature disabled",
+			frontmatter: `tools:
+  github:
+    toolsets: [issues]`,
+		},
+		{
+			name: "feature enabled without GitHub tool",
+			frontmatter: `features:
+  permission-telemetry: true
+tools:
+  github: false`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := testutil.TempDir(t, "permission-telemetry-*")
+			workflowPath := filepath.Join(tmpDir, "telemetry.md")
+			content := "---\non: workflow_dispatch\npermissions:\n  contents: read\n  issues: read\n" + tt.frontmatter + "\n---\n\n# Telemetry\n"
+			require.NoError(t, os.WriteFile(workflowPath, []byte(content), 0644), "Failed to write workflow")
+
+			require.NoError(t, NewCompiler().CompileWorkflow(workflowPath), "workflow should compile")
+			lockContent, err := os.ReadFile(filepath.Join(tmpDir, "telemetry.lock.yml"))
+			require.NoError(t, err, "Failed to read lock file")
+			lock := string(lockContent)
+
+			if !tt.wantStep {
+				assert.NotContains(t, lock, "record_permission_usage.cjs", "telemetry step should not be emitted")
+				assert.NotContains(t, lock, "permission_usage.json", "telemetry file should not be uploaded")
+				return
+			}
+			assert.Contains(t, lock, "      - name: Record GitHub permission usage\n        if: always()\n        continue-on-error: true\n",
+				"telemetry step should always run without failing the job")
+			assert.Contains(t, lock, `GH_AW_REQUESTED_PERMISSIONS: '{"contents":"read","issues":"read"}'`,
+				"compiled agent job permissions should be recorded")
+			assert.Contains(t, lock, "/tmp/gh-aw/permission_usage.json", "telemetry file should be uploaded with the agent artifact")
+		})
+	}
+}
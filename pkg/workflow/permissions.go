@@ -210,4 +210,7 @@ type Permissions struct {
 	hasAll        bool
 	allLevel      PermissionLevel
 	explicitEmpty bool // When true, renders "permissions: {}" even if no permissions are set
+	// requiredBy records the features that require each scope (recorded via Require).
+	// RenderToYAML emits them as comments so lock file reviewers can see why a scope is granted.
+	requiredBy map[PermissionScope][]string
 }
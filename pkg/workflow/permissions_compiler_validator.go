@@ -188,6 +188,10 @@ func (c *Compiler) repositoryOwnerIsIndividualUser() bool {
 		workflowLog.Printf("Skipping owner-type check: slug %q is not in owner/repo format", slug)
 		return false
 	}
+	if c.offline {
+		workflowLog.Print("Skipping owner-type check: offline mode")
+		return false
+	}
 
 	ownerType, cached := c.ownerTypeCache[owner]
 	if !cached {
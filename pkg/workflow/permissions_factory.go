@@ -2,6 +2,7 @@ package workflow
 
 import (
 	"maps"
+	"slices"
 
 	"github.com/github/gh-aw/pkg/logger"
 )
@@ -184,6 +185,24 @@ func NewPermissionsContentsReadChecksWritePRRead() *Permissions {
 	})
 }
 
+// NewPermissionsContentsReadStatusesWrite creates permissions with contents: read and statuses: write
+func NewPermissionsContentsReadStatusesWrite() *Permissions {
+	return NewPermissionsFromMap(map[PermissionScope]PermissionLevel{
+		PermissionContents: PermissionRead,
+		PermissionStatuses: PermissionWrite,
+	})
+}
+
+// NewPermissionsContentsReadStatusesWritePRRead creates permissions with contents: read, statuses: write, and pull-requests: read
+// Used when create-commit-status has a target configured and must resolve the PR head SHA via the REST API
+func NewPermissionsContentsReadStatusesWritePRRead() *Permissions {
+	return NewPermissionsFromMap(map[PermissionScope]PermissionLevel{
+		PermissionContents:     PermissionRead,
+		PermissionStatuses:     PermissionWrite,
+		PermissionPullRequests: PermissionRead,
+	})
+}
+
 // Clone returns a deep copy of the Permissions object. The clone shares no underlying
 // state with the original, so callers can safely call Set() on the clone without
 // affecting the original (e.g. when reusing CachedPermissions).
@@ -201,6 +220,12 @@ func (p *Permissions) Clone() *Permissions {
 		clone.permissions = make(map[PermissionScope]PermissionLevel, len(p.permissions))
 		maps.Copy(clone.permissions, p.permissions)
 	}
+	if p.requiredBy != nil {
+		clone.requiredBy = make(map[PermissionScope][]string, len(p.requiredBy))
+		for scope, features := range p.requiredBy {
+			clone.requiredBy[scope] = slices.Clone(features)
+		}
+	}
 	return clone
 }
 
@@ -238,9 +238,44 @@ func (p *Permissions) Set(scope PermissionScope, level PermissionLevel) {
 		p.hasAll = false
 		p.allLevel = ""
 	}
+	// Features recorded for a different level no longer explain this scope
+	if current, exists := p.permissions[scope]; !exists || current != level {
+		delete(p.requiredBy, scope)
+	}
 	p.permissions[scope] = level
 }
 
+// Require grants a scope on behalf of a feature and records the feature as the reason
+// the scope is needed. Write takes precedence over read; only the features that need the
+// resulting level are kept, so the rendered comment names exactly what requires the access.
+func (p *Permissions) Require(scope PermissionScope, level PermissionLevel, feature string) {
+	if level == PermissionNone {
+		return
+	}
+	current, exists := p.Get(scope)
+	if exists && current != level && isPermissionSufficient(current, level) {
+		// A stronger requirement already covers this one
+		return
+	}
+	if !exists || current != level {
+		p.Set(scope, level)
+	}
+	if p.requiredBy == nil {
+		p.requiredBy = make(map[PermissionScope][]string)
+	}
+	if !slices.Contains(p.requiredBy[scope], feature) {
+		p.requiredBy[scope] = append(p.requiredBy[scope], feature)
+	}
+}
+
+// RequiredBy returns the features recorded as requiring the given scope.
+func (p *Permissions) RequiredBy(scope PermissionScope) []string {
+	if p == nil {
+		return nil
+	}
+	return p.requiredBy[scope]
+}
+
 // GetExplicit returns the permission level only if the scope was explicitly declared in the
 // permissions map. Unlike Get, it never returns a level derived from shorthand (read-all /
 // write-all) or "all: read" defaults. Use this when you need to know what the user explicitly
@@ -482,6 +517,9 @@ func (p *Permissions) RenderToYAML() string {
 		}
 
 		hasRenderable = true
+		if features := p.requiredBy[scope]; len(features) > 0 {
+			lines = append(lines, fmt.Sprintf("      # %s: %s — required by %s", scope, level, strings.Join(features, ", ")))
+		}
 		// Add 2 spaces for proper indentation under permissions:
 		// When rendered in a job, the job renderer adds 4 spaces to the first line only,
 		// so we need to pre-indent continuation lines with 4 additional spaces
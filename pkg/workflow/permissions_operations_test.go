@@ -1022,3 +1022,75 @@ func TestPermissions_Clone(t *testing.T) {
 		}
 	})
 }
+
+func TestPermissions_Require(t *testing.T) {
+	t.Run("write replaces the features that only needed read", func(t *testing.T) {
+		p := NewPermissions()
+		p.Require(PermissionContents, PermissionRead, "create-issue")
+		p.Require(PermissionContents, PermissionWrite, "create-pull-request")
+		p.Require(PermissionContents, PermissionRead, "add-comment")
+		p.Require(PermissionContents, PermissionWrite, "push-to-pull-request-branch")
+
+		if level, _ := p.Get(PermissionContents); level != PermissionWrite {
+			t.Errorf("expected contents: write, got %q", level)
+		}
+		if got := strings.Join(p.RequiredBy(PermissionContents), ","); got != "create-pull-request,push-to-pull-request-branch" {
+			t.Errorf("unexpected features for contents: %q", got)
+		}
+	})
+
+	t.Run("features are recorded once and none is ignored", func(t *testing.T) {
+		p := NewPermissions()
+		p.Require(PermissionIssues, PermissionWrite, "create-issue")
+		p.Require(PermissionIssues, PermissionWrite, "create-issue")
+		p.Require(PermissionActions, PermissionNone, "noop")
+
+		if got := p.RequiredBy(PermissionIssues); len(got) != 1 {
+			t.Errorf("expected create-issue to be recorded once, got %v", got)
+		}
+		if _, exists := p.Get(PermissionActions); exists {
+			t.Error("a none requirement should not grant the scope")
+		}
+	})
+
+	t.Run("Set to a different level drops the recorded features", func(t *testing.T) {
+		p := NewPermissions()
+		p.Require(PermissionIssues, PermissionRead, "update-project")
+		p.Set(PermissionIssues, PermissionWrite)
+		if got := p.RequiredBy(PermissionIssues); len(got) != 0 {
+			t.Errorf("expected stale features to be dropped, got %v", got)
+		}
+	})
+
+	t.Run("rendered YAML explains each required scope", func(t *testing.T) {
+		p := NewPermissions()
+		p.Require(PermissionContents, PermissionRead, "create-issue")
+		p.Require(PermissionIssues, PermissionWrite, "create-issue")
+		p.Require(PermissionIssues, PermissionWrite, "add-comment")
+		p.Set(PermissionActions, PermissionRead)
+
+		expected := "permissions:\n" +
+			"      actions: read\n" +
+			"      # contents: read — required by create-issue\n" +
+			"      contents: read\n" +
+			"      # issues: write — required by create-issue, add-comment\n" +
+			"      issues: write"
+		if got := p.RenderToYAML(); got != expected {
+			t.Errorf("unexpected rendering:\n%s\nwant:\n%s", got, expected)
+		}
+	})
+
+	t.Run("clone copies the recorded features", func(t *testing.T) {
+		original := NewPermissions()
+		original.Require(PermissionIssues, PermissionWrite, "create-issue")
+		clone := original.Clone()
+		clone.Require(PermissionIssues, PermissionWrite, "add-comment")
+
+		if got := original.RequiredBy(PermissionIssues); len(got) != 1 {
+			t.Errorf("Clone.Require() mutated the original features: %v", got)
+		}
+		if got := clone.RequiredBy(PermissionIssues); len(got) != 2 {
+			t.Errorf("expected clone to keep and extend features, got %v", got)
+		}
+	})
+}
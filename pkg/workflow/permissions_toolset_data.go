@@ -4,6 +4,7 @@ import (
 	_ "embed"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 	"sync"
 
@@ -143,3 +144,26 @@ func isPermissionSufficient(current, required PermissionLevel) bool {
 	}
 	return false
 }
+
+// GitHubToolReadPermissions returns the permission scopes the GitHub MCP server needs to
+// serve toolName, derived from the toolset the tool belongs to. GitHub App-only scopes are
+// omitted because they cannot be granted to GITHUB_TOKEN. The boolean is false when the
+// tool is not in the tool-to-toolset mapping.
+func GitHubToolReadPermissions(toolName string) ([]PermissionScope, bool) {
+	toolToToolset, err := getGitHubToolToToolsetMap()
+	if err != nil {
+		permissionsValidationLog.Printf("Failed to load tool-to-toolset mapping: %v", err)
+		return nil, false
+	}
+	toolset, ok := toolToToolset[toolName]
+	if !ok {
+		return nil, false
+	}
+	required := collectRequiredPermissions([]string{toolset}, true)
+	scopes := make([]PermissionScope, 0, len(required))
+	for scope := range required {
+		scopes = append(scopes, scope)
+	}
+	slices.Sort(scopes)
+	return scopes, true
+}
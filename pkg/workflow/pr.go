@@ -99,8 +99,21 @@ func (c *Compiler) generatePRReadyForReviewCheckout(yaml *strings.Builder, data
 	)
 	RenderConditionAsIf(yaml, condition, "          ")
 
+	var script strings.Builder
+	if useRequire {
+		// Use require() to load script from copied files using setup_globals helper
+		script.WriteString(generateGitHubScriptWithRequire("checkout_pr_branch.cjs"))
+	} else {
+		// Inline JavaScript: Attach GitHub Actions builtin objects to global scope before script execution
+		script.WriteString("            const { setupGlobals } = require('" + SetupActionDestination + "/setup_globals.cjs');\n")
+		script.WriteString("            setupGlobals(core, github, context, exec, io, getOctokit);\n")
+
+		// Add the JavaScript for checking out the PR branch
+		WriteJavaScriptToYAML(&script, "const { main } = require('${{ runner.temp }}/gh-aw/actions/checkout_pr_branch.cjs'); await main();")
+	}
+
 	// Use actions/github-script instead of shell script
-	fmt.Fprintf(yaml, "        uses: %s\n", getCachedActionPin("actions/github-script", data))
+	fmt.Fprintf(yaml, "        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", data), script.String()))
 
 	// Add env section with GH_TOKEN for gh CLI
 	// Use safe-outputs github-token if available, otherwise default token
@@ -113,25 +126,6 @@ func (c *Compiler) generatePRReadyForReviewCheckout(yaml *strings.Builder, data
 	yaml.WriteString("        env:\n")
 	fmt.Fprintf(yaml, "          GH_TOKEN: %s\n", effectiveToken)
 
-	yaml.WriteString("        with:\n")
-
 	// Add github-token to make it available to the GitHub API client
-	fmt.Fprintf(yaml, "          github-token: %s\n", effectiveToken)
-
-	yaml.WriteString("          script: |\n")
-
-	if useRequire {
-		// Use require() to load script from copied files using setup_globals helper
-		yaml.WriteString("            const { setupGlobals } = require('" + SetupActionDestination + "/setup_globals.cjs');\n")
-		yaml.WriteString("            setupGlobals(core, github, context, exec, io, getOctokit);\n")
-		yaml.WriteString("            const { main } = require('" + SetupActionDestination + "/checkout_pr_branch.cjs');\n")
-		yaml.WriteString("            await main();\n")
-	} else {
-		// Inline JavaScript: Attach GitHub Actions builtin objects to global scope before script execution
-		yaml.WriteString("            const { setupGlobals } = require('" + SetupActionDestination + "/setup_globals.cjs');\n")
-		yaml.WriteString("            setupGlobals(core, github, context, exec, io, getOctokit);\n")
-
-		// Add the JavaScript for checking out the PR branch
-		WriteJavaScriptToYAML(yaml, "const { main } = require('${{ runner.temp }}/gh-aw/actions/checkout_pr_branch.cjs'); await main();")
-	}
+	yaml.WriteString(c.githubScriptWith(script.String(), fmt.Sprintf("          github-token: %s\n", effectiveToken)))
 }
@@ -0,0 +1,117 @@
+package workflow
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+	"github.com/github/gh-aw/pkg/typeutil"
+)
+
+var promptBudgetLog = logger.New("workflow:prompt_budget")
+
+// promptCharsPerToken is the characters-per-token ratio used to estimate prompt size.
+// It matches estimate_tokens.cjs so compile-time and runtime estimates agree.
+const promptCharsPerToken = 4
+
+// PromptConfig represents the prompt: frontmatter section.
+type PromptConfig struct {
+	// MaxTokens is the estimated token budget for the rendered prompt. When the
+	// prompt exceeds it at runtime, imported context is truncated to fit.
+	MaxTokens int `json:"max-tokens,omitempty" yaml:"max-tokens,omitempty"`
+}
+
+// extractPromptConfig extracts the prompt configuration from frontmatter.
+// Returns nil when no prompt budget is configured.
+func extractPromptConfig(frontmatter map[string]any) *PromptConfig {
+	prompt, exists := frontmatter["prompt"]
+	if !exists {
+		return nil
+	}
+
+	promptObj, ok := prompt.(map[string]any)
+	if !ok {
+		promptBudgetLog.Printf("prompt field has unexpected type %T, expected object", prompt)
+		return nil
+	}
+
+	maxTokens, ok := typeutil.ParseIntValue(promptObj["max-tokens"])
+	if !ok || maxTokens <= 0 {
+		return nil
+	}
+	promptBudgetLog.Printf("Prompt token budget: %d", maxTokens)
+	return &PromptConfig{MaxTokens: maxTokens}
+}
+
+// promptMaxTokens returns the configured prompt token budget, or 0 when none is set.
+func (data *WorkflowData) promptMaxTokens() int {
+	if data == nil || data.PromptConfig == nil {
+		return 0
+	}
+	return data.PromptConfig.MaxTokens
+}
+
+// estimatePromptTokens estimates the token count of prompt text.
+func estimatePromptTokens(text string) int {
+	return (len(text) + promptCharsPerToken - 1) / promptCharsPerToken
+}
+
+// staticPromptContent returns the prompt content known at compile time: the main
+// workflow body and the markdown of every import. Runtime-imported files are read
+// from the workspace; built-in instructions and expression values are not included.
+func staticPromptContent(data *WorkflowData, markdownPath string) string {
+	var b strings.Builder
+	workspaceRoot := resolveWorkspaceRoot(markdownPath)
+	appendImport := func(importPath string) {
+		rawContent, err := os.ReadFile(filepath.Join(workspaceRoot, filepath.FromSlash(importPath)))
+		if err != nil {
+			promptBudgetLog.Printf("Skipping unreadable import %s: %v", importPath, err)
+			return
+		}
+		body, err := parser.ExtractMarkdownContent(string(rawContent))
+		if err != nil {
+			body = string(rawContent)
+		}
+		b.WriteString(body)
+	}
+
+	if len(data.PromptImports) > 0 {
+		for _, entry := range data.PromptImports {
+			if entry.Markdown != "" {
+				b.WriteString(entry.Markdown)
+			} else if entry.ImportPath != "" {
+				appendImport(entry.ImportPath)
+			}
+		}
+	} else {
+		b.WriteString(data.ImportedMarkdown)
+		for _, importPath := range data.ImportPaths {
+			appendImport(importPath)
+		}
+	}
+	b.WriteString(removeXMLComments(data.MainWorkflowMarkdown))
+	return b.String()
+}
+
+// validatePromptBudget emits a warning when the workflow's static prompt content
+// already exceeds prompt.max-tokens. Runtime trimming only shortens imported
+// context, so a body that is over budget on its own cannot be brought under it.
+func (c *Compiler) validatePromptBudget(workflowData *WorkflowData, markdownPath string) {
+	maxTokens := workflowData.promptMaxTokens()
+	if maxTokens == 0 {
+		return
+	}
+	estimated := estimatePromptTokens(staticPromptContent(workflowData, markdownPath))
+	promptBudgetLog.Printf("Static prompt estimate: %d tokens (budget %d)", estimated, maxTokens)
+	if estimated <= maxTokens {
+		return
+	}
+	msg := fmt.Sprintf("static prompt content is about %d tokens, which exceeds prompt.max-tokens (%d). "+
+		"Imported context is truncated at runtime to fit the budget; shorten the workflow body or its imports, or raise prompt.max-tokens.",
+		estimated, maxTokens)
+	fmt.Fprintln(os.Stderr, formatCompilerMessage(markdownPath, "warning", msg))
+	c.IncrementWarningCount()
+}
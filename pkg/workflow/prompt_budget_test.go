@@ -0,0 +1,112 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractPromptConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		frontmatter map[string]any
+		want        *PromptConfig
+	}{
+		{
+			name:        "no prompt field",
+			frontmatter: map[string]any{},
+			want:        nil,
+		},
+		{
+			name:        "integer budget",
+			frontmatter: map[string]any{"prompt": map[string]any{"max-tokens": 8000}},
+			want:        &PromptConfig{MaxTokens: 8000},
+		},
+		{
+			name:        "uint64 budget from YAML",
+			frontmatter: map[string]any{"prompt": map[string]any{"max-tokens": uint64(32000)}},
+			want:        &PromptConfig{MaxTokens: 32000},
+		},
+		{
+			name:        "zero budget",
+			frontmatter: map[string]any{"prompt": map[string]any{"max-tokens": 0}},
+			want:        nil,
+		},
+		{
+			name:        "missing max-tokens",
+			frontmatter: map[string]any{"prompt": map[string]any{}},
+			want:        nil,
+		},
+		{
+			name:        "prompt is not an object",
+			frontmatter: map[string]any{"prompt": "short"},
+			want:        nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, extractPromptConfig(tt.frontmatter), "prompt config")
+		})
+	}
+}
+
+func TestPromptBudgetCompile(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "prompt-budget-test")
+
+	workflow := `---
+on: workflow_dispatch
+engine: copilot
+permissions:
+  contents: read
+prompt:
+  max-tokens: %d
+---
+
+# Test Workflow
+
+` + strings.Repeat("Summarize the repository activity for the last week.\n", 20)
+
+	t.Run("within budget", func(t *testing.T) {
+		testFile := filepath.Join(tmpDir, "within-budget.md")
+		require.NoError(t, os.WriteFile(testFile, []byte(strings.Replace(workflow, "%d", "8000", 1)), 0644), "Failed to write test workflow")
+
+		compiler := NewCompiler(WithVersion("1.0.0"))
+		require.NoError(t, compiler.CompileWorkflow(testFile), "Failed to compile workflow")
+		assert.Equal(t, 0, compiler.GetWarningCount(), "workflow within budget should not warn")
+
+		lockContent, err := os.ReadFile(filepath.Join(tmpDir, "within-budget.lock.yml"))
+		require.NoError(t, err, "Failed to read lock file")
+		lock := string(lockContent)
+		assert.Contains(t, lock, `GH_AW_PROMPT_MAX_TOKENS: "8000"`, "interpolation step should receive the budget")
+		assert.Contains(t, lock, "GH_AW_PROMPT_MAIN_FILE: ", "interpolation step should receive the main workflow file")
+	})
+
+	t.Run("over budget", func(t *testing.T) {
+		testFile := filepath.Join(tmpDir, "over-budget.md")
+		require.NoError(t, os.WriteFile(testFile, []byte(strings.Replace(workflow, "%d", "50", 1)), 0644), "Failed to write test workflow")
+
+		compiler := NewCompiler(WithVersion("1.0.0"))
+		require.NoError(t, compiler.CompileWorkflow(testFile), "Over-budget workflow should still compile")
+		assert.Equal(t, 1, compiler.GetWarningCount(), "workflow over budget should warn once")
+	})
+
+	t.Run("no budget", func(t *testing.T) {
+		testFile := filepath.Join(tmpDir, "no-budget.md")
+		content := strings.Replace(workflow, "prompt:\n  max-tokens: %d\n", "", 1)
+		require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Failed to write test workflow")
+
+		compiler := NewCompiler(WithVersion("1.0.0"))
+		require.NoError(t, compiler.CompileWorkflow(testFile), "Failed to compile workflow")
+
+		lockContent, err := os.ReadFile(filepath.Join(tmpDir, "no-budget.lock.yml"))
+		require.NoError(t, err, "Failed to read lock file")
+		assert.NotContains(t, string(lockContent), "GH_AW_PROMPT_MAX_TOKENS", "no budget should be emitted without prompt.max-tokens")
+	})
+}
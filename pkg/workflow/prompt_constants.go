@@ -29,6 +29,7 @@ const (
 	mcpCLIToolsPromptFile                   = "mcp_cli_tools_prompt.md"
 	cliProxyPromptFile                      = "cli_proxy_prompt.md"
 	cliProxyWithSafeOutputsPromptFile       = "cli_proxy_with_safeoutputs_prompt.md"
+	workflowRunContextPromptFile            = "workflow_run_context_prompt.md"
 )
 
 // GitHub context prompt is kept embedded because it contains GitHub Actions expressions
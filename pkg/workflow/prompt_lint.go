@@ -0,0 +1,121 @@
+package workflow
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+	"github.com/github/gh-aw/pkg/typeutil"
+)
+
+var promptLintLog = logger.New("workflow:prompt_lint")
+
+// promptLintMaxLineLengthKey is the prompt.lint key that sets the line-length limit.
+// Every other key is a rule name mapped to its severity.
+const promptLintMaxLineLengthKey = "max-line-length"
+
+// extractPromptLintConfig extracts the prompt.lint frontmatter setting. Rules not
+// listed keep the default warning severity; "lint: false" turns every rule off.
+func extractPromptLintConfig(frontmatter map[string]any) parser.PromptLintConfig {
+	config := parser.PromptLintConfig{}
+	promptObj, ok := frontmatter["prompt"].(map[string]any)
+	if !ok {
+		return config
+	}
+
+	switch lint := promptObj["lint"].(type) {
+	case bool:
+		if !lint {
+			config.Rules = make(map[string]string, len(parser.PromptLintRules))
+			for _, rule := range parser.PromptLintRules {
+				config.Rules[rule] = parser.PromptLintSeverityOff
+			}
+		}
+	case map[string]any:
+		for key, value := range lint {
+			if key == promptLintMaxLineLengthKey {
+				if maxLength, ok := typeutil.ParseIntValue(value); ok && maxLength > 0 {
+					config.MaxLineLength = maxLength
+				}
+				continue
+			}
+			severity, ok := value.(string)
+			if !ok {
+				promptLintLog.Printf("prompt.lint.%s has unexpected type %T, expected string", key, value)
+				continue
+			}
+			if config.Rules == nil {
+				config.Rules = make(map[string]string)
+			}
+			config.Rules[key] = severity
+		}
+	}
+	promptLintLog.Printf("Prompt lint config: rules=%v, max_line_length=%d", config.Rules, config.MaxLineLength)
+	return config
+}
+
+// promptLintFragments returns the markdown files that make up the static prompt,
+// in the order staticPromptContent assembles them, with the main workflow last.
+// Imports with inputs are inlined at compile time and have no source path.
+func promptLintFragments(data *WorkflowData, markdownPath string) []parser.PromptLintFragment {
+	var fragments []parser.PromptLintFragment
+	workspaceRoot := resolveWorkspaceRoot(markdownPath)
+	appendImport := func(importPath string) {
+		fullPath := filepath.Join(workspaceRoot, filepath.FromSlash(importPath))
+		rawContent, err := parser.ReadFile(fullPath)
+		if err != nil {
+			promptLintLog.Printf("Skipping unreadable import %s: %v", importPath, err)
+			return
+		}
+		fragments = append(fragments, parser.PromptLintFragment{Path: fullPath, Content: string(rawContent)})
+	}
+
+	if len(data.PromptImports) > 0 {
+		for _, entry := range data.PromptImports {
+			if entry.Markdown != "" {
+				fragments = append(fragments, parser.PromptLintFragment{Content: entry.Markdown})
+			} else if entry.ImportPath != "" {
+				appendImport(entry.ImportPath)
+			}
+		}
+	} else {
+		if data.ImportedMarkdown != "" {
+			fragments = append(fragments, parser.PromptLintFragment{Content: data.ImportedMarkdown})
+		}
+		for _, importPath := range data.ImportPaths {
+			appendImport(importPath)
+		}
+	}
+
+	mainContent, err := parser.ReadFile(markdownPath)
+	if err != nil {
+		promptLintLog.Printf("Skipping unreadable workflow file %s: %v", markdownPath, err)
+		return fragments
+	}
+	return append(fragments, parser.PromptLintFragment{Path: markdownPath, Content: string(mainContent)})
+}
+
+// validatePromptLint runs the prompt lint rules over the workflow body and its
+// imports. Warnings are printed and counted; rules configured as errors fail
+// compilation with every error-level issue in the returned error.
+func (c *Compiler) validatePromptLint(workflowData *WorkflowData, markdownPath string) error {
+	issues := parser.LintPrompt(promptLintFragments(workflowData, markdownPath), workflowData.PromptLint)
+	var errorMessages []string
+	for _, issue := range issues {
+		formatted := issue.Format(markdownPath)
+		if issue.Severity == parser.PromptLintSeverityError {
+			errorMessages = append(errorMessages, strings.TrimRight(formatted, "\n"))
+			continue
+		}
+		fmt.Fprint(os.Stderr, formatted)
+		c.IncrementWarningCount()
+	}
+	if len(errorMessages) == 0 {
+		return nil
+	}
+	promptLintLog.Printf("Prompt lint found %d errors", len(errorMessages))
+	return &wrappedCompilerError{formatted: strings.Join(errorMessages, "\n")}
+}
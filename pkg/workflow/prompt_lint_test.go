@@ -0,0 +1,118 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/parser"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractPromptLintConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		frontmatter map[string]any
+		want        parser.PromptLintConfig
+	}{
+		{
+			name:        "no prompt field",
+			frontmatter: map[string]any{},
+		},
+		{
+			name:        "no lint field",
+			frontmatter: map[string]any{"prompt": map[string]any{"max-tokens": 8000}},
+		},
+		{
+			name: "rule severities and line length",
+			frontmatter: map[string]any{"prompt": map[string]any{"lint": map[string]any{
+				"todo-marker":     "error",
+				"line-length":     "off",
+				"max-line-length": uint64(200),
+			}}},
+			want: parser.PromptLintConfig{
+				Rules:         map[string]string{"todo-marker": "error", "line-length": "off"},
+				MaxLineLength: 200,
+			},
+		},
+		{
+			name:        "lint disabled",
+			frontmatter: map[string]any{"prompt": map[string]any{"lint": false}},
+			want: parser.PromptLintConfig{Rules: map[string]string{
+				"line-length":       "off",
+				"broken-link":       "off",
+				"duplicate-heading": "off",
+				"todo-marker":       "off",
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, extractPromptLintConfig(tt.frontmatter), "prompt lint config")
+		})
+	}
+}
+
+func TestPromptLintCompile(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "prompt-lint-test")
+	workflowsDir := filepath.Join(tmpDir, ".github", "workflows")
+	require.NoError(t, os.MkdirAll(filepath.Join(workflowsDir, "shared"), 0755), "Failed to create workflows dir")
+	require.NoError(t, os.WriteFile(filepath.Join(workflowsDir, "shared", "context.md"), []byte(`## Guidelines
+
+Read the [style guide](missing-style.md) before answering.
+`), 0644), "Failed to write import")
+
+	workflow := func(promptConfig string) string {
+		return `---
+on: workflow_dispatch
+engine: copilot
+permissions:
+  contents: read
+imports:
+  - shared/context.md
+` + promptConfig + `---
+
+# Test Workflow
+
+## Guidelines
+
+Summarize the repository activity for the last week.
+
+## TODO: Customize this workflow
+`
+	}
+
+	t.Run("warnings by default", func(t *testing.T) {
+		testFile := filepath.Join(workflowsDir, "lint-default.md")
+		require.NoError(t, os.WriteFile(testFile, []byte(workflow("")), 0644), "Failed to write test workflow")
+
+		compiler := NewCompiler(WithVersion("1.0.0"))
+		require.NoError(t, compiler.CompileWorkflow(testFile), "Lint warnings should not fail compilation")
+		assert.Equal(t, 3, compiler.GetWarningCount(), "broken link, duplicate heading and TODO marker should each warn")
+	})
+
+	t.Run("error severity fails compilation", func(t *testing.T) {
+		testFile := filepath.Join(workflowsDir, "lint-error.md")
+		content := workflow("prompt:\n  lint:\n    todo-marker: error\n    broken-link: off\n")
+		require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Failed to write test workflow")
+
+		compiler := NewCompiler(WithVersion("1.0.0"))
+		err := compiler.CompileWorkflow(testFile)
+		require.Error(t, err, "TODO markers configured as errors should fail compilation")
+		assert.Contains(t, err.Error(), "lint-error.md:20:4", "error should point at the TODO marker")
+		assert.Contains(t, err.Error(), "[todo-marker]", "error should name the rule")
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		testFile := filepath.Join(workflowsDir, "lint-off.md")
+		require.NoError(t, os.WriteFile(testFile, []byte(workflow("prompt:\n  lint: false\n")), 0644), "Failed to write test workflow")
+
+		compiler := NewCompiler(WithVersion("1.0.0"))
+		require.NoError(t, compiler.CompileWorkflow(testFile), "Failed to compile workflow")
+		assert.Equal(t, 0, compiler.GetWarningCount(), "lint: false should turn every rule off")
+	})
+}
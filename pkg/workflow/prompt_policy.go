@@ -0,0 +1,256 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+	"github.com/github/gh-aw/pkg/sliceutil"
+)
+
+var promptPolicyLog = logger.New("workflow:prompt_policy")
+
+// promptPolicyDefaultFile is the policy fragment, relative to the repository root,
+// that is prepended to every prompt when config.yml does not list other files.
+const promptPolicyDefaultFile = ".github/aw/policy.md"
+
+// promptPolicyRequired is the config.yml shorthand that makes the default policy
+// fragment mandatory.
+const promptPolicyRequired = "required"
+
+// promptPolicyConfigFile mirrors the parts of .github/aw/config.yml read for the
+// prompt policy. Other top-level keys are ignored.
+type promptPolicyConfigFile struct {
+	Policy any `yaml:"policy"`
+}
+
+// PromptPolicyConfig is the policy: section of .github/aw/config.yml. It lists the
+// fragments placed at the start and end of every compiled prompt's system
+// instructions. Entries are paths relative to the repository root or
+// owner/repo/path@ref specs for fragments kept in another repository, such as an
+// organization's .github repository.
+type PromptPolicyConfig struct {
+	Prepend  []string // Fragments placed before the built-in system instructions
+	Append   []string // Fragments placed after the built-in system instructions
+	Required bool     // Whether a missing fragment is a compile error
+}
+
+// PromptPolicy is the policy text resolved at compile time.
+type PromptPolicy struct {
+	Prepend string   // Text placed before the built-in system instructions
+	Append  string   // Text placed after the built-in system instructions
+	Sources []string // Fragments the text was read from, in order
+}
+
+// parsePromptPolicyConfig parses the policy: section of a config.yml document.
+// Without a policy: key the default fragment is prepended when it exists.
+func parsePromptPolicyConfig(content []byte) (PromptPolicyConfig, error) {
+	defaultConfig := PromptPolicyConfig{Prepend: []string{promptPolicyDefaultFile}}
+	var file promptPolicyConfigFile
+	if err := yaml.Unmarshal(content, &file); err != nil {
+		return PromptPolicyConfig{}, fmt.Errorf("invalid %s: %w", parser.ImportAliasConfigFile, err)
+	}
+
+	switch policy := file.Policy.(type) {
+	case nil:
+		return defaultConfig, nil
+	case string:
+		if policy != promptPolicyRequired {
+			return PromptPolicyConfig{}, fmt.Errorf("invalid %s: policy must be %q or an object with prepend, append and required, got %q", parser.ImportAliasConfigFile, promptPolicyRequired, policy)
+		}
+		defaultConfig.Required = true
+		return defaultConfig, nil
+	case map[string]any:
+		config := PromptPolicyConfig{}
+		var errs []error
+		for _, key := range sliceutil.SortedKeys(policy) {
+			value := policy[key]
+			switch key {
+			case "prepend", "append":
+				paths, err := parsePromptPolicyPaths(key, value)
+				if err != nil {
+					errs = append(errs, err)
+					continue
+				}
+				if key == "prepend" {
+					config.Prepend = paths
+				} else {
+					config.Append = paths
+				}
+			case "required":
+				required, ok := value.(bool)
+				if !ok {
+					errs = append(errs, fmt.Errorf("policy.required must be a boolean, got %T", value))
+					continue
+				}
+				config.Required = required
+			default:
+				errs = append(errs, fmt.Errorf("unknown policy key %q; expected prepend, append or required", key))
+			}
+		}
+		if len(errs) > 0 {
+			return PromptPolicyConfig{}, fmt.Errorf("invalid %s: %w", parser.ImportAliasConfigFile, errors.Join(errs...))
+		}
+		if len(config.Prepend) == 0 && len(config.Append) == 0 {
+			config.Prepend = defaultConfig.Prepend
+		}
+		return config, nil
+	default:
+		return PromptPolicyConfig{}, fmt.Errorf("invalid %s: policy must be %q or an object, got %T", parser.ImportAliasConfigFile, promptPolicyRequired, file.Policy)
+	}
+}
+
+// parsePromptPolicyPaths accepts a single path or a list of paths.
+func parsePromptPolicyPaths(key string, value any) ([]string, error) {
+	switch v := value.(type) {
+	case string:
+		if strings.TrimSpace(v) == "" {
+			return nil, fmt.Errorf("policy.%s must not be empty", key)
+		}
+		return []string{strings.TrimSpace(v)}, nil
+	case []any:
+		paths := make([]string, 0, len(v))
+		for _, item := range v {
+			path, ok := item.(string)
+			if !ok || strings.TrimSpace(path) == "" {
+				return nil, fmt.Errorf("policy.%s entries must be non-empty strings", key)
+			}
+			paths = append(paths, strings.TrimSpace(path))
+		}
+		return paths, nil
+	default:
+		return nil, fmt.Errorf("policy.%s must be a path or a list of paths, got %T", key, value)
+	}
+}
+
+// loadPromptPolicyConfig reads the policy configuration of the repository rooted
+// at workspaceRoot. A missing config.yml yields the default configuration.
+func loadPromptPolicyConfig(workspaceRoot string) (PromptPolicyConfig, error) {
+	content, err := parser.ReadFile(filepath.Join(workspaceRoot, filepath.FromSlash(parser.ImportAliasConfigFile)))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return parsePromptPolicyConfig(nil)
+		}
+		return PromptPolicyConfig{}, fmt.Errorf("failed to read %s: %w", parser.ImportAliasConfigFile, err)
+	}
+	return parsePromptPolicyConfig(content)
+}
+
+// resolvePromptPolicy reads the configured policy fragments. Missing fragments are
+// skipped unless the policy is required, in which case they are an error.
+func (c *Compiler) resolvePromptPolicy(config PromptPolicyConfig, workspaceRoot string) (*PromptPolicy, error) {
+	policy := &PromptPolicy{}
+	readFragments := func(paths []string) (string, error) {
+		var parts []string
+		for _, policyPath := range paths {
+			text, found, err := c.readPromptPolicyFragment(policyPath, workspaceRoot)
+			if err != nil {
+				return "", err
+			}
+			if !found {
+				if config.Required {
+					return "", fmt.Errorf("policy fragment %s is required by policy in %s but was not found", policyPath, parser.ImportAliasConfigFile)
+				}
+				promptPolicyLog.Printf("Optional policy fragment %s not found", policyPath)
+				continue
+			}
+			if text == "" {
+				continue
+			}
+			parts = append(parts, text)
+			policy.Sources = append(policy.Sources, policyPath)
+		}
+		return strings.Join(parts, "\n\n"), nil
+	}
+
+	var err error
+	if policy.Prepend, err = readFragments(config.Prepend); err != nil {
+		return nil, err
+	}
+	if policy.Append, err = readFragments(config.Append); err != nil {
+		return nil, err
+	}
+	if len(policy.Sources) == 0 {
+		return nil, nil
+	}
+	promptPolicyLog.Printf("Resolved prompt policy from %v", policy.Sources)
+	return policy, nil
+}
+
+// readPromptPolicyFragment returns the markdown body of a policy fragment with
+// HTML comments removed. The second return value is false when the fragment does
+// not exist.
+func (c *Compiler) readPromptPolicyFragment(policyPath, workspaceRoot string) (string, bool, error) {
+	fullPath := filepath.Join(workspaceRoot, filepath.FromSlash(policyPath))
+	if isRemotePromptPolicyPath(policyPath) {
+		resolved, err := parser.ResolveIncludePath(policyPath, workspaceRoot, c.getSharedImportCache())
+		if err != nil {
+			return "", false, fmt.Errorf("failed to fetch policy fragment %s: %w", policyPath, err)
+		}
+		fullPath = resolved
+	}
+
+	content, err := parser.ReadFile(fullPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", false, nil
+		}
+		return "", false, fmt.Errorf("failed to read policy fragment %s: %w", policyPath, err)
+	}
+	body, err := parser.ExtractMarkdownContent(string(content))
+	if err != nil {
+		return "", false, fmt.Errorf("failed to parse policy fragment %s: %w", policyPath, err)
+	}
+	body = strings.TrimSpace(removeXMLComments(body))
+	if strings.Contains(body, "${{") {
+		return "", false, fmt.Errorf("policy fragment %s must not contain GitHub Actions expressions (${{ }})", policyPath)
+	}
+	return body, true, nil
+}
+
+// isRemotePromptPolicyPath reports whether policyPath is an owner/repo/path@ref
+// spec. The ref is required so that repository paths such as
+// docs/policy/agent.md are never fetched from another repository.
+func isRemotePromptPolicyPath(policyPath string) bool {
+	return strings.Contains(policyPath, "@") && parser.IsWorkflowSpec(policyPath)
+}
+
+// loadPromptPolicy resolves the repository's policy fragments for the workflow at
+// markdownPath and stores them on workflowData.
+func (c *Compiler) loadPromptPolicy(workflowData *WorkflowData, markdownPath string) error {
+	workspaceRoot := resolveWorkspaceRoot(markdownPath)
+	config, err := loadPromptPolicyConfig(workspaceRoot)
+	if err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error(), err)
+	}
+	policy, err := c.resolvePromptPolicy(config, workspaceRoot)
+	if err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error(), err)
+	}
+	workflowData.PromptPolicy = policy
+	return nil
+}
+
+// promptPolicySections wraps sections with the policy text: the prepended policy
+// comes before every built-in section and the appended policy after them, so both
+// land inside the <system> block.
+func promptPolicySections(policy *PromptPolicy, sections []PromptSection) []PromptSection {
+	if policy == nil {
+		return sections
+	}
+	wrapped := make([]PromptSection, 0, len(sections)+2)
+	if policy.Prepend != "" {
+		wrapped = append(wrapped, PromptSection{Content: policy.Prepend})
+	}
+	wrapped = append(wrapped, sections...)
+	if policy.Append != "" {
+		wrapped = append(wrapped, PromptSection{Content: policy.Append})
+	}
+	return wrapped
+}
@@ -0,0 +1,157 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePromptPolicyConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    PromptPolicyConfig
+		wantErr string
+	}{
+		{
+			name:    "no policy key",
+			content: "aliases:\n  \"@shared\": shared\n",
+			want:    PromptPolicyConfig{Prepend: []string{".github/aw/policy.md"}},
+		},
+		{
+			name:    "required shorthand",
+			content: "policy: required\n",
+			want:    PromptPolicyConfig{Prepend: []string{".github/aw/policy.md"}, Required: true},
+		},
+		{
+			name:    "prepend and append",
+			content: "policy:\n  prepend: [.github/aw/policy.md, myorg/.github/aw/policy.md@main]\n  append: .github/aw/footer.md\n  required: true\n",
+			want: PromptPolicyConfig{
+				Prepend:  []string{".github/aw/policy.md", "myorg/.github/aw/policy.md@main"},
+				Append:   []string{".github/aw/footer.md"},
+				Required: true,
+			},
+		},
+		{
+			name:    "object without files uses the default fragment",
+			content: "policy:\n  required: true\n",
+			want:    PromptPolicyConfig{Prepend: []string{".github/aw/policy.md"}, Required: true},
+		},
+		{
+			name:    "unknown shorthand",
+			content: "policy: optional\n",
+			wantErr: `policy must be "required"`,
+		},
+		{
+			name:    "unknown key",
+			content: "policy:\n  before: policy.md\n",
+			wantErr: `unknown policy key "before"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parsePromptPolicyConfig([]byte(tt.content))
+			if tt.wantErr != "" {
+				require.Error(t, err, "invalid policy should be rejected")
+				assert.Contains(t, err.Error(), tt.wantErr, "error message")
+				return
+			}
+			require.NoError(t, err, "policy should parse")
+			assert.Equal(t, tt.want, got, "policy config")
+		})
+	}
+}
+
+func TestIsRemotePromptPolicyPath(t *testing.T) {
+	assert.True(t, isRemotePromptPolicyPath("myorg/.github/aw/policy.md@main"), "owner/repo/path@ref is remote")
+	assert.False(t, isRemotePromptPolicyPath("docs/policy/agent.md"), "repository paths without a ref are local")
+	assert.False(t, isRemotePromptPolicyPath(".github/aw/policy.md"), "default policy path is local")
+}
+
+func TestPromptPolicyCompile(t *testing.T) {
+	workflow := `---
+on: workflow_dispatch
+engine: copilot
+permissions:
+  contents: read
+---
+
+# Test Workflow
+
+Summarize the repository activity for the last week.
+`
+	setup := func(t *testing.T, files map[string]string) string {
+		t.Helper()
+		repoDir := testutil.TempDir(t, "prompt-policy-test")
+		files[".github/workflows/test.md"] = workflow
+		for name, content := range files {
+			path := filepath.Join(repoDir, filepath.FromSlash(name))
+			require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755), "Failed to create directory")
+			require.NoError(t, os.WriteFile(path, []byte(content), 0644), "Failed to write %s", name)
+		}
+		return filepath.Join(repoDir, ".github", "workflows", "test.md")
+	}
+	compileLock := func(t *testing.T, testFile string) string {
+		t.Helper()
+		compiler := NewCompiler(WithVersion("1.0.0"))
+		require.NoError(t, compiler.CompileWorkflow(testFile), "Failed to compile workflow")
+		lockContent, err := os.ReadFile(strings.TrimSuffix(testFile, ".md") + ".lock.yml")
+		require.NoError(t, err, "Failed to read lock file")
+		return string(lockContent)
+	}
+
+	t.Run("default policy is prepended to the system instructions", func(t *testing.T) {
+		testFile := setup(t, map[string]string{
+			".github/aw/policy.md": "<!-- maintained by the security team -->\nNever disclose secrets.\n",
+		})
+		lock := compileLock(t, testFile)
+		systemStart := strings.Index(lock, "<system>")
+		policyIdx := strings.Index(lock, "Never disclose secrets.")
+		require.NotEqual(t, -1, policyIdx, "policy text should be in the prompt")
+		assert.Greater(t, policyIdx, systemStart, "policy should be inside the system block")
+		assert.Less(t, policyIdx, strings.Index(lock, "</system>"), "policy should come before the end of the system block")
+		assert.NotContains(t, lock, "maintained by the security team", "HTML comments should be removed")
+	})
+
+	t.Run("appended policy comes after the built-in instructions", func(t *testing.T) {
+		testFile := setup(t, map[string]string{
+			".github/aw/config.yml": "policy:\n  prepend: .github/aw/policy.md\n  append: .github/aw/footer.md\n",
+			".github/aw/policy.md":  "Never disclose secrets.\n",
+			".github/aw/footer.md":  "Report policy violations.\n",
+		})
+		lock := compileLock(t, testFile)
+		footerIdx := strings.Index(lock, "Report policy violations.")
+		require.NotEqual(t, -1, footerIdx, "appended policy should be in the prompt")
+		assert.Greater(t, footerIdx, strings.Index(lock, "Never disclose secrets."), "appended policy should follow the prepended one")
+		assert.Less(t, footerIdx, strings.Index(lock, "</system>"), "appended policy should be inside the system block")
+	})
+
+	t.Run("missing optional policy", func(t *testing.T) {
+		testFile := setup(t, map[string]string{})
+		lock := compileLock(t, testFile)
+		assert.NotContains(t, lock, "policy.md", "no policy should be referenced")
+	})
+
+	t.Run("missing required policy fails compilation", func(t *testing.T) {
+		testFile := setup(t, map[string]string{".github/aw/config.yml": "policy: required\n"})
+		compiler := NewCompiler(WithVersion("1.0.0"))
+		err := compiler.CompileWorkflow(testFile)
+		require.Error(t, err, "missing required policy should fail compilation")
+		assert.Contains(t, err.Error(), "policy fragment .github/aw/policy.md is required", "error should name the missing fragment")
+	})
+
+	t.Run("expressions are rejected", func(t *testing.T) {
+		testFile := setup(t, map[string]string{".github/aw/policy.md": "Act for ${{ github.actor }}.\n"})
+		compiler := NewCompiler(WithVersion("1.0.0"))
+		err := compiler.CompileWorkflow(testFile)
+		require.Error(t, err, "policy expressions should fail compilation")
+		assert.Contains(t, err.Error(), "must not contain GitHub Actions expressions", "error should explain the problem")
+	})
+}
@@ -0,0 +1,173 @@
+package workflow
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/sliceutil"
+)
+
+var promptRoutingLog = logger.New("workflow:prompt_routing")
+
+// promptRouteDefaultKey is the route: key whose target is used when no label matches.
+const promptRouteDefaultKey = "default"
+
+// promptRouteHeadingPattern matches markdown ATX headings and captures their text.
+var promptRouteHeadingPattern = regexp.MustCompile(`(?m)^#{1,6}[ \t]+(.+?)[ \t]*#*[ \t]*$`)
+
+// PromptRouting represents the route: frontmatter section. It selects parts of the
+// prompt from the labels of the triggering issue, pull request, or discussion.
+type PromptRouting struct {
+	Routes  []PromptRoute `json:"routes"`
+	Default *PromptRoute  `json:"default,omitempty"`
+}
+
+// PromptRoute maps label patterns to a section of the workflow markdown or to a
+// markdown file that is runtime-imported when one of the patterns matches.
+type PromptRoute struct {
+	Labels  []string `json:"labels,omitempty"`  // Glob patterns matched case-insensitively against label names
+	Section string   `json:"section,omitempty"` // Heading text of a section in the workflow markdown
+	Import  string   `json:"import,omitempty"`  // Markdown file path, relative to .github/
+}
+
+// extractPromptRouting extracts the route: frontmatter section. Label patterns that map to
+// the same target are merged into one route. Returns nil when no routes are configured.
+func extractPromptRouting(frontmatter map[string]any) *PromptRouting {
+	routeMap, ok := frontmatter["route"].(map[string]any)
+	if !ok || len(routeMap) == 0 {
+		return nil
+	}
+
+	routing := &PromptRouting{}
+	byTarget := make(map[string]*PromptRoute)
+	for _, pattern := range sliceutil.SortedKeys(routeMap) {
+		target, ok := routeMap[pattern].(string)
+		if !ok {
+			promptRoutingLog.Printf("Ignoring route %q with non-string target %T", pattern, routeMap[pattern])
+			continue
+		}
+		route := newPromptRoute(target)
+		if pattern == promptRouteDefaultKey {
+			routing.Default = &route
+			continue
+		}
+		key := route.target()
+		if byTarget[key] == nil {
+			byTarget[key] = &route
+		}
+		byTarget[key].Labels = append(byTarget[key].Labels, pattern)
+	}
+	for _, route := range byTarget {
+		routing.Routes = append(routing.Routes, *route)
+	}
+	slices.SortFunc(routing.Routes, func(a, b PromptRoute) int {
+		return strings.Compare(a.Labels[0], b.Labels[0])
+	})
+	promptRoutingLog.Printf("Prompt routing: routes=%d, default=%t", len(routing.Routes), routing.Default != nil)
+	return routing
+}
+
+// newPromptRoute parses a route target: "#Heading" selects a section of the workflow
+// markdown, anything else is a markdown file to import.
+func newPromptRoute(target string) PromptRoute {
+	target = strings.TrimSpace(target)
+	if strings.HasPrefix(target, "#") {
+		return PromptRoute{Section: strings.TrimSpace(strings.TrimLeft(target, "#"))}
+	}
+	return PromptRoute{Import: target}
+}
+
+// target returns the route target as written in frontmatter, for error messages.
+func (r PromptRoute) target() string {
+	if r.Section != "" {
+		return "#" + r.Section
+	}
+	return r.Import
+}
+
+// hasPromptRouting reports whether the workflow selects prompt content by label.
+func (data *WorkflowData) hasPromptRouting() bool {
+	return data != nil && data.PromptRouting != nil && (len(data.PromptRouting.Routes) > 0 || data.PromptRouting.Default != nil)
+}
+
+// ToJSON serialises the routing table for the GH_AW_PROMPT_ROUTES environment variable.
+func (r *PromptRouting) ToJSON() (string, error) {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize route configuration: %w", err)
+	}
+	return string(data), nil
+}
+
+// validatePromptRouting checks that every routed section exists in the workflow markdown
+// or its imports and that routed files are markdown files inside .github/. Missing files
+// are reported as warnings because they may be created after compilation.
+func (c *Compiler) validatePromptRouting(workflowData *WorkflowData, markdownPath string) error {
+	if !workflowData.hasPromptRouting() {
+		return nil
+	}
+
+	headings := make(map[string]struct{})
+	for _, match := range promptRouteHeadingPattern.FindAllStringSubmatch(staticPromptContent(workflowData, markdownPath), -1) {
+		headings[strings.ToLower(strings.TrimSpace(match[1]))] = struct{}{}
+	}
+
+	routes := workflowData.PromptRouting.Routes
+	if workflowData.PromptRouting.Default != nil {
+		routes = append(slices.Clone(routes), *workflowData.PromptRouting.Default)
+	}
+	var errs []error
+	for _, route := range routes {
+		name := strings.Join(route.Labels, ", ")
+		if name == "" {
+			name = promptRouteDefaultKey
+		}
+		for _, label := range route.Labels {
+			if strings.Contains(label, "${{") {
+				errs = append(errs, fmt.Errorf("route: label pattern %q cannot contain GitHub Actions expressions", label))
+			}
+		}
+		switch {
+		case route.Section != "":
+			if _, ok := headings[strings.ToLower(route.Section)]; !ok {
+				errs = append(errs, fmt.Errorf("route: %s -> %s: no heading %q in the workflow markdown or its imports", name, route.target(), route.Section))
+			}
+		case route.Import == "":
+			errs = append(errs, fmt.Errorf("route: %s has an empty target; use \"#Heading\" for a section or a markdown file path", name))
+		default:
+			if err := c.validatePromptRouteImport(route.Import, markdownPath); err != nil {
+				errs = append(errs, fmt.Errorf("route: %s -> %s: %w", name, route.Import, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validatePromptRouteImport checks a routed file the way runtime-import resolves it:
+// relative to the .github folder, with or without the .github/ prefix.
+func (c *Compiler) validatePromptRouteImport(importPath, markdownPath string) error {
+	cleaned := path.Clean(filepath.ToSlash(importPath))
+	if path.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return errors.New("file must be a path inside the .github folder")
+	}
+	if !strings.HasSuffix(cleaned, ".md") {
+		return errors.New("file must be a markdown (.md) file")
+	}
+	relative := strings.TrimPrefix(cleaned, constants.GithubDir)
+	fullPath := filepath.Join(resolveWorkspaceRoot(markdownPath), filepath.FromSlash(constants.GithubDir+relative))
+	if _, err := os.Stat(fullPath); err != nil {
+		msg := fmt.Sprintf("routed file %s was not found; the workflow fails at runtime when its route matches", constants.GithubDir+relative)
+		fmt.Fprintln(os.Stderr, formatCompilerMessage(markdownPath, "warning", msg))
+		c.IncrementWarningCount()
+	}
+	return nil
+}
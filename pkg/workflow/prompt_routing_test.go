@@ -0,0 +1,116 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractPromptRouting(t *testing.T) {
+	tests := []struct {
+		name        string
+		frontmatter map[string]any
+		want        *PromptRouting
+	}{
+		{
+			name:        "no route field",
+			frontmatter: map[string]any{},
+			want:        nil,
+		},
+		{
+			name:        "empty route map",
+			frontmatter: map[string]any{"route": map[string]any{}},
+			want:        nil,
+		},
+		{
+			name: "sections, imports and default",
+			frontmatter: map[string]any{"route": map[string]any{
+				"bug":         "#Bug Triage",
+				"feature*":    "## Feature Intake",
+				"enhancement": "#Feature Intake",
+				"docs":        "prompts/docs.md",
+				"default":     "#General",
+			}},
+			want: &PromptRouting{
+				Routes: []PromptRoute{
+					{Labels: []string{"bug"}, Section: "Bug Triage"},
+					{Labels: []string{"docs"}, Import: "prompts/docs.md"},
+					{Labels: []string{"enhancement", "feature*"}, Section: "Feature Intake"},
+				},
+				Default: &PromptRoute{Section: "General"},
+			},
+		},
+		{
+			name:        "non-string targets are ignored",
+			frontmatter: map[string]any{"route": map[string]any{"bug": 1, "docs": "prompts/docs.md"}},
+			want:        &PromptRouting{Routes: []PromptRoute{{Labels: []string{"docs"}, Import: "prompts/docs.md"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, extractPromptRouting(tt.frontmatter), "prompt routing")
+		})
+	}
+}
+
+func TestPromptRoutingCompile(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "prompt-routing-test")
+
+	compile := func(t *testing.T, name, route string) (string, error) {
+		t.Helper()
+		content := `---
+on:
+  issues:
+    types: [opened, labeled]
+engine: copilot
+permissions:
+  contents: read
+route:
+` + route + `---
+
+# Triage
+
+## Bug Triage
+
+Reproduce the bug.
+
+## Feature Intake
+
+Summarize the request.
+`
+		testFile := filepath.Join(tmpDir, name+".md")
+		require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Failed to write test workflow")
+		compiler := NewCompiler(WithVersion("1.0.0"))
+		if err := compiler.CompileWorkflow(testFile); err != nil {
+			return "", err
+		}
+		lockContent, err := os.ReadFile(filepath.Join(tmpDir, name+".lock.yml"))
+		require.NoError(t, err, "Failed to read lock file")
+		return string(lockContent), nil
+	}
+
+	t.Run("routes are passed to the interpolation step", func(t *testing.T) {
+		lock, err := compile(t, "routed", "  bug: \"#Bug Triage\"\n  default: \"#Feature Intake\"\n")
+		require.NoError(t, err, "Failed to compile workflow")
+		assert.Contains(t, lock, `GH_AW_PROMPT_ROUTES: "{\"routes\":[{\"labels\":[\"bug\"],\"section\":\"Bug Triage\"}],\"default\":{\"section\":\"Feature Intake\"}}"`,
+			"interpolation step should receive the routing table")
+	})
+
+	t.Run("unknown heading", func(t *testing.T) {
+		_, err := compile(t, "unknown-heading", "  bug: \"#Bug Reports\"\n")
+		require.Error(t, err, "a missing routed heading should fail compilation")
+		assert.Contains(t, err.Error(), `no heading "Bug Reports"`, "error should name the missing heading")
+	})
+
+	t.Run("import outside .github", func(t *testing.T) {
+		_, err := compile(t, "bad-import", "  docs: ../secrets.md\n")
+		require.Error(t, err, "a routed file outside .github should fail compilation")
+		assert.Contains(t, err.Error(), "inside the .github folder", "error should explain the restriction")
+	})
+}
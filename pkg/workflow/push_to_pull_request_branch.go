@@ -32,6 +32,7 @@ type PushToPullRequestBranchConfig struct {
 	AllowedFiles                   []string         `yaml:"allowed-files,omitempty"`                       // Strict allowlist of glob patterns for files eligible for push. Checked independently of protected-files; both checks must pass.
 	ExcludedFiles                  []string         `yaml:"excluded-files,omitempty"`                      // List of glob patterns for files to exclude from the patch using git :(exclude) pathspecs. Matching files are stripped by git at generation time and will not appear in the commit or be subject to allowed-files or protected-files checks.
 	MaxPatchSize                   int              `yaml:"max-patch-size,omitempty"`                      // Maximum allowed patch size in KB for push-to-pull-request-branch only. Overrides safe-outputs.max-patch-size when set.
+	MaxPatchFiles                  int              `yaml:"max-patch-files,omitempty"`                     // Maximum allowed unique files in push-to-pull-request-branch patch only. Overrides safe-outputs.max-patch-files when set; no limit when neither is set.
 	PatchFormat                    string           `yaml:"patch-format,omitempty"`                        // Transport format for packaging changes: "bundle" (default, uses git bundle and preserves merge topology/per-commit metadata) or "am" (uses git format-patch).
 	FallbackAsPullRequest          *bool            `yaml:"fallback-as-pull-request,omitempty"`            // When true (default), creates a fallback pull request if direct push fails due to diverged/non-fast-forward branch. When false, fallback is disabled and pull-requests: write is not requested.
 	SignedCommits                  *bool            `yaml:"signed-commits,omitempty"`                      // When false, skips GitHub GraphQL signed commits and pushes the local git history directly. Default is true.
@@ -204,6 +205,13 @@ func (c *Compiler) parsePushToPullRequestBranchConfig(outputMap map[string]any)
 				}
 			}
 
+			// Parse max-patch-files override (optional, must be > 0)
+			if maxPatchFiles, exists := configMap["max-patch-files"]; exists {
+				if maxPatchFilesInt, ok := typeutil.ParseIntValue(maxPatchFiles); ok && maxPatchFilesInt > 0 {
+					pushToBranchConfig.MaxPatchFiles = maxPatchFilesInt
+				}
+			}
+
 			// Parse patch-format: valid values are "bundle" (default) and "am"
 			patchFormatEnums := []string{"am", "bundle"}
 			validateStringEnumField(configMap, "patch-format", patchFormatEnums, pushToPullRequestBranchLog)
@@ -37,6 +37,10 @@ func (c *Compiler) computeRepositoryVisibility() string {
 		pushToPullRequestBranchValidationLog.Printf("Skipping repository visibility check: slug %q has empty owner or repo", slug)
 		return ""
 	}
+	if c.offline {
+		pushToPullRequestBranchValidationLog.Print("Skipping repository visibility check: offline mode")
+		return ""
+	}
 
 	pushToPullRequestBranchValidationLog.Printf("Checking repository visibility for: %s", slug)
 	visibility, err := fetchRepositoryVisibility(slug)
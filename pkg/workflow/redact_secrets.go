@@ -1,6 +1,7 @@
 package workflow
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -149,9 +150,14 @@ func (c *Compiler) generateSecretRedactionStep(yaml *strings.Builder, yamlConten
 	// This is important for validation to ensure the step ordering is correct
 	c.stepOrderTracker.RecordSecretRedaction("Redact secrets in logs")
 
-	// If no secrets found, we still generate the step but it will be a no-op at runtime
+	var maskingPatterns []string
+	if data.SecretMasking != nil {
+		maskingPatterns = data.SecretMasking.Patterns
+	}
+
+	// If no secrets or patterns found, we still generate the step but it will be a no-op at runtime
 	// This ensures consistent step ordering and validation
-	if len(secretReferences) == 0 {
+	if len(secretReferences) == 0 && len(maskingPatterns) == 0 {
 		secretMaskingLog.Print("No secrets found, generating no-op redaction step")
 		// Generate a minimal no-op redaction step for validation purposes
 		yaml.WriteString("      - name: Redact secrets in logs\n")
@@ -161,16 +167,11 @@ func (c *Compiler) generateSecretRedactionStep(yaml *strings.Builder, yamlConten
 		secretMaskingLog.Printf("Generating redaction step for %d secret(s)", len(secretReferences))
 		yaml.WriteString("      - name: Redact secrets in logs\n")
 		yaml.WriteString("        if: always()\n")
-		fmt.Fprintf(yaml, "        uses: %s\n", getCachedActionPin("actions/github-script", data))
-		yaml.WriteString("        with:\n")
-		yaml.WriteString("          script: |\n")
-
 		// Load redact_secrets script from external file
 		// Use setupGlobals helper to attach GitHub Actions builtin objects to global scope
-		yaml.WriteString("            const { setupGlobals } = require('" + SetupActionDestination + "/setup_globals.cjs');\n")
-		yaml.WriteString("            setupGlobals(core, github, context, exec, io, getOctokit);\n")
-		yaml.WriteString("            const { main } = require('${{ runner.temp }}/gh-aw/actions/redact_secrets.cjs');\n")
-		yaml.WriteString("            await main();\n")
+		script := generateGitHubScriptWithRequire("redact_secrets.cjs")
+		fmt.Fprintf(yaml, "        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", data), script))
+		yaml.WriteString(c.githubScriptWith(script))
 
 		// Add environment variables
 		yaml.WriteString("        env:\n")
@@ -192,6 +193,18 @@ func (c *Compiler) generateSecretRedactionStep(yaml *strings.Builder, yamlConten
 			// to only contain safe characters (uppercase letters, numbers, underscores)
 			fmt.Fprintf(yaml, "          SECRET_%s: ${{ secrets.%s }}\n", escapedSecretName, secretName)
 		}
+
+		// Pass custom masking patterns as a JSON array; matches are masked with
+		// ::add-mask:: and redacted from the files scanned above
+		if len(maskingPatterns) > 0 {
+			patternsJSON, err := json.Marshal(maskingPatterns)
+			if err != nil {
+				secretMaskingLog.Printf("Failed to marshal secret-masking patterns: %v", err)
+			} else {
+				secretMaskingLog.Printf("Passing %d custom secret-masking pattern(s)", len(maskingPatterns))
+				fmt.Fprintf(yaml, "          GH_AW_SECRET_PATTERNS: %s\n", quoteYAMLEnvValue(string(patternsJSON)))
+			}
+		}
 	}
 
 	// Inject custom secret masking steps if configured
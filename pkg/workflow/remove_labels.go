@@ -12,6 +12,7 @@ type RemoveLabelsConfig struct {
 	SafeOutputTargetConfig     `yaml:",inline"`
 	SafeOutputFilterConfig     `yaml:",inline"`
 	SafeOutputAllowBlockConfig `yaml:",inline"`
+	Batch                      bool `yaml:"batch,omitempty"` // When true, a single output may target many issues/PRs via item_numbers
 }
 
 // parseRemoveLabelsConfig handles remove-labels configuration
@@ -23,7 +24,7 @@ func (c *Compiler) parseRemoveLabelsConfig(outputMap map[string]any) *RemoveLabe
 		return &RemoveLabelsConfig{}
 	})
 	if config != nil {
-		removeLabelsLog.Printf("Parsed configuration: allowed_count=%d, blocked_count=%d, target=%s", len(config.Allowed), len(config.Blocked), config.Target)
+		removeLabelsLog.Printf("Parsed configuration: allowed_count=%d, blocked_count=%d, target=%s, batch=%t", len(config.Allowed), len(config.Blocked), config.Target, config.Batch)
 	}
 	return config
 }
@@ -563,7 +563,18 @@ func (c *Compiler) buildSinglePushRepoMemoryStep(data *WorkflowData, memory Repo
 	}
 	fmt.Fprintf(&step, "        id: push_repo_memory_%s\n", memory.ID)
 	step.WriteString("        if: always()\n")
-	fmt.Fprintf(&step, "        uses: %s\n", getCachedActionPin("actions/github-script", data))
+	var script strings.Builder
+	script.WriteString("            const { setupGlobals } = require('" + SetupActionDestination + "/setup_globals.cjs');\n")
+	script.WriteString("            setupGlobals(core, github, context, exec, io, getOctokit);\n")
+	if useRequire {
+		script.WriteString("            const { main } = require('" + SetupActionDestination + "/push_repo_memory.cjs');\n")
+		script.WriteString("            await main();\n")
+	} else {
+		for _, line := range FormatJavaScriptForYAML("const { main } = require('${{ runner.temp }}/gh-aw/actions/push_repo_memory.cjs'); await main();") {
+			script.WriteString(line)
+		}
+	}
+	fmt.Fprintf(&step, "        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", data), script.String()))
 	step.WriteString("        env:\n")
 	step.WriteString("          GH_TOKEN: ${{ github.token }}\n")
 	step.WriteString("          GITHUB_RUN_ID: ${{ github.run_id }}\n")
@@ -586,18 +597,7 @@ func (c *Compiler) buildSinglePushRepoMemoryStep(data *WorkflowData, memory Repo
 	if memory.FormatJSON {
 		step.WriteString("          FORMAT_JSON: 'true'\n")
 	}
-	step.WriteString("        with:\n")
-	step.WriteString("          script: |\n")
-	step.WriteString("            const { setupGlobals } = require('" + SetupActionDestination + "/setup_globals.cjs');\n")
-	step.WriteString("            setupGlobals(core, github, context, exec, io, getOctokit);\n")
-	if useRequire {
-		step.WriteString("            const { main } = require('" + SetupActionDestination + "/push_repo_memory.cjs');\n")
-		step.WriteString("            await main();\n")
-	} else {
-		for _, line := range FormatJavaScriptForYAML("const { main } = require('${{ runner.temp }}/gh-aw/actions/push_repo_memory.cjs'); await main();") {
-			step.WriteString(line)
-		}
-	}
+	step.WriteString(c.githubScriptWith(script.String()))
 	return step.String()
 }
 
@@ -0,0 +1,55 @@
+package workflow
+
+import (
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var requestTeamReviewLog = logger.New("workflow:request_team_review")
+
+// RequestTeamReviewConfig holds configuration for requesting team reviews on PRs from agent output.
+// Team names are resolved to slugs through the organization teams API at runtime.
+type RequestTeamReviewConfig struct {
+	BaseSafeOutputConfig   `yaml:",inline"`
+	SafeOutputTargetConfig `yaml:",inline"`
+	SafeOutputFilterConfig `yaml:",inline"`
+	Allowed                []string `yaml:"allowed,omitempty"` // Allowed team slugs (empty: any team in the repository owner's organization)
+}
+
+// parseRequestTeamReviewConfig handles request-team-review configuration
+func (c *Compiler) parseRequestTeamReviewConfig(outputMap map[string]any) *RequestTeamReviewConfig {
+	if _, exists := outputMap["request-team-review"]; !exists {
+		return nil
+	}
+
+	// Pre-process allowed to convert a single string to an array BEFORE unmarshaling
+	configData, _ := outputMap["request-team-review"].(map[string]any)
+	if configData != nil {
+		if str, ok := configData["allowed"].(string); ok {
+			configData["allowed"] = []string{str}
+		}
+	}
+
+	// Pre-process templatable int fields
+	if err := preprocessIntFieldAsString(configData, "max", requestTeamReviewLog); err != nil {
+		requestTeamReviewLog.Printf("Invalid max value: %v", err)
+		return nil
+	}
+
+	config := parseConfigScaffold(outputMap, "request-team-review", requestTeamReviewLog, func(err error) *RequestTeamReviewConfig {
+		requestTeamReviewLog.Printf("Failed to unmarshal config: %v", err)
+		// A bare or malformed value still enables the output; the default max is applied below
+		return &RequestTeamReviewConfig{}
+	})
+	if config == nil {
+		return nil
+	}
+
+	// Set default max if not specified
+	if config.Max == nil {
+		config.Max = defaultIntStr(3)
+	}
+
+	requestTeamReviewLog.Printf("Parsed request-team-review config: allowed=%d, target=%s", len(config.Allowed), config.Target)
+
+	return config
+}
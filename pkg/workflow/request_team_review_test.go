@@ -0,0 +1,63 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/github/gh-aw/pkg/testutil"
+)
+
+func TestParseRequestTeamReviewConfig(t *testing.T) {
+	c := &Compiler{}
+
+	assert.Nil(t, c.parseRequestTeamReviewConfig(map[string]any{}), "missing key should disable request-team-review")
+
+	config := c.parseRequestTeamReviewConfig(map[string]any{"request-team-review": nil})
+	require.NotNil(t, config, "null config should enable request-team-review")
+	assert.Equal(t, strPtr("3"), config.Max, "max should default to 3")
+
+	config = c.parseRequestTeamReviewConfig(map[string]any{
+		"request-team-review": map[string]any{
+			"allowed": "platform-reviewers",
+			"target":  "*",
+			"max":     1,
+		},
+	})
+	require.NotNil(t, config, "config should be parsed")
+	assert.Equal(t, []string{"platform-reviewers"}, config.Allowed, "a single allowed team should become a list")
+	assert.Equal(t, "*", config.Target, "target should be parsed")
+	assert.Equal(t, strPtr("1"), config.Max, "max should be parsed")
+}
+
+func TestRequestTeamReviewWorkflowCompilation(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "request-team-review")
+	content := `---
+on:
+  pull_request:
+    types: [opened]
+engine: copilot
+safe-outputs:
+  request-team-review:
+    allowed: [platform-reviewers, security]
+    max: 2
+---
+
+# Route the pull request to the owning teams
+`
+	workflowFile := filepath.Join(tmpDir, "route.md")
+	require.NoError(t, os.WriteFile(workflowFile, []byte(content), 0644), "Failed to write workflow")
+	require.NoError(t, NewCompiler().CompileWorkflow(workflowFile), "compilation should succeed")
+
+	lockBytes, err := os.ReadFile(filepath.Join(tmpDir, "route.lock.yml"))
+	require.NoError(t, err, "Failed to read lock file")
+	lock := string(lockBytes)
+
+	assert.Contains(t, lock, `\"request_team_review\":{\"allowed\":[\"platform-reviewers\",\"security\"],\"max\":2}`, "handler config should include request_team_review")
+	assert.Contains(t, lock, "pull-requests: write", "safe_outputs job should be able to request reviews")
+}
@@ -23,7 +23,8 @@ func (c *Compiler) generateMembershipCheck(data *WorkflowData, steps []string) [
 		steps = append(steps, "      - name: Check team membership for workflow\n")
 	}
 	steps = append(steps, fmt.Sprintf("        id: %s\n", constants.CheckMembershipStepID))
-	steps = append(steps, fmt.Sprintf("        uses: %s\n", getCachedActionPin("actions/github-script", data)))
+	script := generateGitHubScriptWithRequire("check_membership.cjs")
+	steps = append(steps, fmt.Sprintf("        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", data), script)))
 
 	// Add environment variables for permission check
 	steps = append(steps, "        env:\n")
@@ -35,12 +36,9 @@ func (c *Compiler) generateMembershipCheck(data *WorkflowData, steps []string) [
 		steps = append(steps, "          GH_AW_ALLOW_BOT_AUTHORED_TRIGGER_COMMENT: \"true\"\n")
 	}
 
-	steps = append(steps, "        with:\n")
 	// Explicitly use the GitHub Actions token (GITHUB_TOKEN) for role membership checks
 	// This ensures we only use the action token and not any other custom secrets
-	steps = append(steps, "          github-token: ${{ secrets.GITHUB_TOKEN }}\n")
-	steps = append(steps, "          script: |\n")
-	steps = append(steps, generateGitHubScriptWithRequire("check_membership.cjs"))
+	steps = append(steps, c.githubScriptWith(script, "          github-token: ${{ secrets.GITHUB_TOKEN }}\n"))
 
 	return steps
 }
@@ -49,7 +47,8 @@ func (c *Compiler) generateMembershipCheck(data *WorkflowData, steps []string) [
 func (c *Compiler) generateRateLimitCheck(data *WorkflowData, steps []string) []string {
 	steps = append(steps, "      - name: Check user rate limit\n")
 	steps = append(steps, fmt.Sprintf("        id: %s\n", constants.CheckRateLimitStepID))
-	steps = append(steps, fmt.Sprintf("        uses: %s\n", getCachedActionPin("actions/github-script", data)))
+	script := generateGitHubScriptWithRequire("check_rate_limit.cjs")
+	steps = append(steps, fmt.Sprintf("        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", data), script)))
 
 	// Add environment variables for rate limit check
 	steps = append(steps, "        env:\n")
@@ -86,10 +85,7 @@ func (c *Compiler) generateRateLimitCheck(data *WorkflowData, steps []string) []
 		steps = append(steps, fmt.Sprintf("          GH_AW_RATE_LIMIT_IGNORED_ROLES: %q\n", strings.Join(ignoredRoles, ",")))
 	}
 
-	steps = append(steps, "        with:\n")
-	steps = append(steps, "          github-token: ${{ secrets.GITHUB_TOKEN }}\n")
-	steps = append(steps, "          script: |\n")
-	steps = append(steps, generateGitHubScriptWithRequire("check_rate_limit.cjs"))
+	steps = append(steps, c.githubScriptWith(script, "          github-token: ${{ secrets.GITHUB_TOKEN }}\n"))
 
 	return steps
 }
@@ -61,6 +61,36 @@ func normalizeRunsOnSnippet(value string) string {
 	return ensureRunsOnContinuationIndent(snippet)
 }
 
+// isSelfHostedRunsOnSnippet reports whether a rendered runs-on snippet selects self-hosted
+// runners: a "self-hosted" label (string or array form) or a runner group.
+func isSelfHostedRunsOnSnippet(snippet string) bool {
+	snippet = strings.TrimSpace(snippet)
+	if snippet == "" {
+		return false
+	}
+	if !strings.HasPrefix(snippet, "runs-on:") {
+		snippet = "runs-on: " + snippet
+	}
+
+	var snippetMap map[string]any
+	if err := yaml.Unmarshal([]byte(snippet), &snippetMap); err != nil {
+		runsOnSnippetLog.Printf("Could not parse runs-on snippet for self-hosted detection: %v", err)
+		return false
+	}
+	runsOn := snippetMap["runs-on"]
+	if runsOnMap, ok := runsOn.(map[string]any); ok {
+		if group, ok := runsOnMap["group"].(string); ok && strings.TrimSpace(group) != "" {
+			return true
+		}
+	}
+	for _, label := range extractRunnerLabels(runsOn) {
+		if strings.EqualFold(strings.TrimSpace(label), "self-hosted") {
+			return true
+		}
+	}
+	return false
+}
+
 func ensureRunsOnContinuationIndent(snippet string) string {
 	lines := strings.Split(snippet, "\n")
 	if len(lines) <= 1 {
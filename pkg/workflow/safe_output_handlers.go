@@ -28,7 +28,11 @@ var safeOutputHandlers = []safeOutputHandlerDescriptor{
 			if !isSafeOutputHandlerEnabledAndUnstaged(safeOutputs, "CreateIssues") {
 				return nil
 			}
-			return NewPermissionsContentsReadIssuesWrite()
+			permissions := NewPermissionsContentsReadIssuesWrite()
+			if safeOutputs.CreateIssues.Project != "" {
+				permissions.Set(PermissionOrganizationProj, PermissionWrite)
+			}
+			return permissions
 		},
 	},
 	{
@@ -266,6 +270,27 @@ var safeOutputHandlers = []safeOutputHandlerDescriptor{
 			return NewPermissionsContentsReadChecksWrite()
 		},
 	},
+	{
+		Key:         "create-commit-status",
+		StructField: "CreateCommitStatus",
+		ToolName:    "create_commit_status",
+		NewConfig:   func() any { return &CreateCommitStatusConfig{} },
+		PermissionBuilder: func(safeOutputs *SafeOutputsConfig) *Permissions {
+			if !isSafeOutputHandlerEnabledAndUnstaged(safeOutputs, "CreateCommitStatus") {
+				return nil
+			}
+			if safeOutputs.CreateCommitStatus != nil && safeOutputs.CreateCommitStatus.Target != "" {
+				return NewPermissionsContentsReadStatusesWritePRRead()
+			}
+			return NewPermissionsContentsReadStatusesWrite()
+		},
+	},
+	{
+		Key:         "notify",
+		StructField: "Notify",
+		ToolName:    "notify",
+		NewConfig:   func() any { return &NotifyConfig{} },
+	},
 	{
 		Key:         "add-labels",
 		StructField: "AddLabels",
@@ -314,6 +339,18 @@ var safeOutputHandlers = []safeOutputHandlerDescriptor{
 			return NewPermissionsContentsReadPRWrite()
 		},
 	},
+	{
+		Key:         "request-team-review",
+		StructField: "RequestTeamReview",
+		ToolName:    "request_team_review",
+		NewConfig:   func() any { return &RequestTeamReviewConfig{} },
+		PermissionBuilder: func(safeOutputs *SafeOutputsConfig) *Permissions {
+			if !isSafeOutputHandlerEnabledAndUnstaged(safeOutputs, "RequestTeamReview") {
+				return nil
+			}
+			return NewPermissionsContentsReadPRWrite()
+		},
+	},
 	{
 		Key:         "assign-milestone",
 		StructField: "AssignMilestone",
@@ -362,6 +399,30 @@ var safeOutputHandlers = []safeOutputHandlerDescriptor{
 			return NewPermissionsContentsReadIssuesWrite()
 		},
 	},
+	{
+		Key:         "lock-issue",
+		StructField: "LockIssue",
+		ToolName:    "lock_issue",
+		NewConfig:   func() any { return &LockIssueConfig{} },
+		PermissionBuilder: func(safeOutputs *SafeOutputsConfig) *Permissions {
+			if !isSafeOutputHandlerEnabledAndUnstaged(safeOutputs, "LockIssue") {
+				return nil
+			}
+			return NewPermissionsContentsReadIssuesWrite()
+		},
+	},
+	{
+		Key:         "lock-pull-request",
+		StructField: "LockPullRequest",
+		ToolName:    "lock_pull_request",
+		NewConfig:   func() any { return &LockPullRequestConfig{} },
+		PermissionBuilder: func(safeOutputs *SafeOutputsConfig) *Permissions {
+			if !isSafeOutputHandlerEnabledAndUnstaged(safeOutputs, "LockPullRequest") {
+				return nil
+			}
+			return NewPermissionsContentsReadIssuesWritePRWrite()
+		},
+	},
 	{
 		Key:         "update-issue",
 		StructField: "UpdateIssues",
@@ -442,6 +503,11 @@ var safeOutputHandlers = []safeOutputHandlerDescriptor{
 		StructField: "UploadArtifact",
 		ToolName:    "upload_artifact",
 	},
+	{
+		Key:         "create-artifact",
+		StructField: "CreateArtifact",
+		ToolName:    "create_artifact",
+	},
 	{
 		Key:         "update-release",
 		StructField: "UpdateRelease",
@@ -494,6 +560,21 @@ var safeOutputHandlers = []safeOutputHandlerDescriptor{
 			return NewPermissionsContentsReadProjectsWrite()
 		},
 	},
+	{
+		Key:         "update-project-item",
+		StructField: "UpdateProjectItems",
+		ToolName:    "update_project_item",
+		NewConfig:   func() any { return &UpdateProjectItemConfig{} },
+		PermissionBuilder: func(safeOutputs *SafeOutputsConfig) *Permissions {
+			if !isSafeOutputHandlerEnabledAndUnstaged(safeOutputs, "UpdateProjectItems") {
+				return nil
+			}
+			permissions := NewPermissionsContentsReadProjectsWrite()
+			permissions.Set(PermissionIssues, PermissionRead)
+			permissions.Set(PermissionPullRequests, PermissionRead)
+			return permissions
+		},
+	},
 	{
 		Key:         "link-sub-issue",
 		StructField: "LinkSubIssue",
@@ -721,6 +802,52 @@ func safeOutputHandlerStaged(field reflect.Value) *TemplatableBool {
 	return nil
 }
 
+// safeOutputHandlerRetry returns the per-handler retry policy for the handler with the
+// given tool name (e.g. "create_issue"), or nil when the handler has none configured.
+func safeOutputHandlerRetry(safeOutputs *SafeOutputsConfig, toolName string) *SafeOutputRetryConfig {
+	for _, handler := range safeOutputHandlers {
+		if handler.ToolName != toolName {
+			continue
+		}
+		field, ok := safeOutputPointerFieldValue(safeOutputs, handler.StructField)
+		if !ok || field.IsNil() || field.Elem().Kind() != reflect.Struct {
+			return nil
+		}
+		baseConfig := field.Elem().FieldByName("BaseSafeOutputConfig")
+		if !baseConfig.IsValid() || baseConfig.Kind() != reflect.Struct {
+			return nil
+		}
+		retry, _ := baseConfig.FieldByName("Retry").Interface().(*SafeOutputRetryConfig)
+		return retry
+	}
+	return nil
+}
+
+// SafeOutputMaxCounts returns the configured max value for each enabled safe output,
+// keyed by its frontmatter name (e.g. "create-issue"). Handlers without an explicit max
+// map to an empty string; expressions are returned verbatim.
+func SafeOutputMaxCounts(safeOutputs *SafeOutputsConfig) map[string]string {
+	counts := make(map[string]string)
+	for _, handler := range safeOutputHandlers {
+		if handler.ToolName == "" {
+			continue
+		}
+		field, ok := safeOutputPointerFieldValue(safeOutputs, handler.StructField)
+		if !ok || field.IsNil() || field.Elem().Kind() != reflect.Struct {
+			continue
+		}
+		counts[handler.Key] = ""
+		baseConfig := field.Elem().FieldByName("BaseSafeOutputConfig")
+		if !baseConfig.IsValid() || baseConfig.Kind() != reflect.Struct {
+			continue
+		}
+		if maxValue, _ := baseConfig.FieldByName("Max").Interface().(*string); maxValue != nil {
+			counts[handler.Key] = *maxValue
+		}
+	}
+	return counts
+}
+
 func templatableBoolFromReflectValue(value reflect.Value) (*TemplatableBool, bool) {
 	if !value.IsValid() {
 		return nil, false
@@ -227,7 +227,12 @@ func (c *Compiler) fetchAndParseActionYAML(actionName string, config *SafeOutput
 
 			// If inputs are still not resolved, fetch action.yml from the network and
 			// store the result in the cache to make future compilations deterministic.
-			if config.Inputs == nil {
+			if config.Inputs == nil && c.offline {
+				// Offline compiles never fetch action.yml; the action falls back to a single payload input.
+				fmt.Fprintln(os.Stderr, formatCompilerMessage(markdownPath, "warning",
+					fmt.Sprintf("inputs for safe output action %q (%s@%s) are not cached in .github/aw/actions-lock.json and cannot be fetched offline", actionName, ref.Repo, ref.Ref)))
+				c.IncrementWarningCount()
+			} else if config.Inputs == nil {
 				actionYAML, err = fetchRemoteActionYAML(ref.Repo, ref.Subdir, fetchRef)
 				if err != nil {
 					safeOutputActionsLog.Printf("Warning: failed to fetch action.yml for %q (%s): %v", actionName, config.Uses, err)
@@ -0,0 +1,263 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var safeOutputsCloudLog = logger.New("workflow:safe_outputs_cloud")
+
+// Supported safe-outputs.cloud providers.
+const (
+	CloudProviderAWS   = "aws"
+	CloudProviderAzure = "azure"
+	CloudProviderGCP   = "gcp"
+)
+
+// cloudAuthActions maps each cloud provider to the OIDC login action used for it.
+var cloudAuthActions = map[string]string{
+	CloudProviderAWS:   "aws-actions/configure-aws-credentials@v4",
+	CloudProviderAzure: "azure/login@v2",
+	CloudProviderGCP:   "google-github-actions/auth@v2",
+}
+
+// cloudConfigExpressionPattern matches the only expressions allowed in cloud identity fields.
+// Repository variables and secrets are fixed before the run starts, so the agent cannot
+// influence their values; step outputs, inputs, and event payloads can be agent-controlled.
+var cloudConfigExpressionPattern = regexp.MustCompile(`^\$\{\{\s*(vars|secrets)\.[A-Za-z_][A-Za-z0-9_]*\s*\}\}$`)
+
+// cloudOperationPattern matches an allowed-operations entry (e.g. "s3:PutObject", "deploy/staging").
+var cloudOperationPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9:._*/-]*$`)
+
+// SafeOutputCloudConfig configures OIDC authentication to a cloud provider in the safe-outputs job.
+// The compiler emits the provider's login step followed by a policy-check step that fails the job
+// unless the authenticated identity matches the configured role.
+type SafeOutputCloudConfig struct {
+	Provider                 string   `yaml:"provider"`                             // "aws", "azure", or "gcp"
+	Role                     string   `yaml:"role"`                                 // AWS role ARN, Azure client ID, or GCP service account email
+	Audience                 string   `yaml:"audience,omitempty"`                   // OIDC audience for the token request (provider default when empty)
+	Region                   string   `yaml:"region,omitempty"`                     // AWS region (required for aws)
+	TenantID                 string   `yaml:"tenant-id,omitempty"`                  // Azure tenant ID (required for azure)
+	SubscriptionID           string   `yaml:"subscription-id,omitempty"`            // Azure subscription ID (required for azure)
+	WorkloadIdentityProvider string   `yaml:"workload-identity-provider,omitempty"` // GCP workload identity provider resource name (required for gcp)
+	AllowedOperations        []string `yaml:"allowed-operations,omitempty"`         // Operations later steps may perform, exported as GH_AW_CLOUD_ALLOWED_OPERATIONS
+}
+
+// parseSafeOutputCloudConfig parses the safe-outputs.cloud block. Validation happens in validateSafeOutputsCloud.
+func parseSafeOutputCloudConfig(cloud any) *SafeOutputCloudConfig {
+	cloudMap, ok := cloud.(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	config := &SafeOutputCloudConfig{}
+	stringFields := map[string]*string{
+		"provider":                   &config.Provider,
+		"role":                       &config.Role,
+		"audience":                   &config.Audience,
+		"region":                     &config.Region,
+		"tenant-id":                  &config.TenantID,
+		"subscription-id":            &config.SubscriptionID,
+		"workload-identity-provider": &config.WorkloadIdentityProvider,
+	}
+	for key, target := range stringFields {
+		if value, ok := cloudMap[key].(string); ok {
+			*target = strings.TrimSpace(value)
+		}
+	}
+	if operations, ok := cloudMap["allowed-operations"].([]any); ok {
+		for _, operation := range operations {
+			if operationStr, ok := operation.(string); ok && operationStr != "" {
+				config.AllowedOperations = append(config.AllowedOperations, operationStr)
+			}
+		}
+	}
+
+	safeOutputsCloudLog.Printf("Parsed cloud config: provider=%s, allowed-operations=%d", config.Provider, len(config.AllowedOperations))
+	return config
+}
+
+// validateSafeOutputsCloud validates the safe-outputs.cloud block. Identity fields must be literals
+// or vars/secrets references so the agent cannot change which role is assumed, and user-provided
+// safe-outputs steps must not run their own OIDC login that would replace the verified identity.
+func (c *Compiler) validateSafeOutputsCloud(data *WorkflowData, workflowPath string) error {
+	if data.SafeOutputs == nil || data.SafeOutputs.Cloud == nil {
+		return nil
+	}
+	config := data.SafeOutputs.Cloud
+	safeOutputsCloudLog.Printf("Validating cloud config for provider %s", config.Provider)
+
+	if _, ok := cloudAuthActions[config.Provider]; !ok {
+		return fmt.Errorf("safe-outputs.cloud: unsupported provider %q (expected one of: aws, azure, gcp)", config.Provider)
+	}
+
+	required := map[string][]string{
+		CloudProviderAWS:   {"role", "region"},
+		CloudProviderAzure: {"role", "tenant-id", "subscription-id"},
+		CloudProviderGCP:   {"role", "workload-identity-provider"},
+	}
+	fields := config.identityFields()
+	for _, key := range required[config.Provider] {
+		if fields[key] == "" {
+			return fmt.Errorf("safe-outputs.cloud: provider %q requires %q", config.Provider, key)
+		}
+	}
+
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		value := fields[key]
+		if strings.Contains(value, "${{") && !cloudConfigExpressionPattern.MatchString(value) {
+			return fmt.Errorf("safe-outputs.cloud: %q must be a literal or a single ${{ vars.NAME }} / ${{ secrets.NAME }} reference, got %q; other expressions could let the agent change which role is assumed", key, value)
+		}
+	}
+
+	for _, operation := range config.AllowedOperations {
+		if !cloudOperationPattern.MatchString(operation) {
+			return fmt.Errorf("safe-outputs.cloud: invalid allowed-operations entry %q (use letters, digits, and : . _ * / -)", operation)
+		}
+	}
+
+	if data.SafeOutputs.IDToken != nil && *data.SafeOutputs.IDToken == "none" {
+		return errors.New("safe-outputs.cloud requires the id-token: write permission; remove 'id-token: none' from safe-outputs")
+	}
+
+	for i, step := range data.SafeOutputs.Steps {
+		if stepsRequireIDToken([]any{step}) {
+			return fmt.Errorf("safe-outputs.steps[%d]: OIDC login actions cannot be combined with safe-outputs.cloud, which already authenticates as the configured role", i)
+		}
+	}
+
+	return nil
+}
+
+// identityFields returns the fields that determine which cloud identity is assumed.
+func (config *SafeOutputCloudConfig) identityFields() map[string]string {
+	return map[string]string{
+		"role":                       config.Role,
+		"audience":                   config.Audience,
+		"region":                     config.Region,
+		"tenant-id":                  config.TenantID,
+		"subscription-id":            config.SubscriptionID,
+		"workload-identity-provider": config.WorkloadIdentityProvider,
+	}
+}
+
+// buildCloudAuthStepMap returns the provider login step for the cloud config.
+func buildCloudAuthStepMap(config *SafeOutputCloudConfig) map[string]any {
+	with := map[string]any{}
+	switch config.Provider {
+	case CloudProviderAWS:
+		with["role-to-assume"] = config.Role
+		with["aws-region"] = config.Region
+		with["role-session-name"] = "gh-aw-safe-outputs-${{ github.run_id }}"
+		if config.Audience != "" {
+			with["audience"] = config.Audience
+		}
+	case CloudProviderAzure:
+		with["client-id"] = config.Role
+		with["tenant-id"] = config.TenantID
+		with["subscription-id"] = config.SubscriptionID
+		if config.Audience != "" {
+			with["audience"] = config.Audience
+		}
+	case CloudProviderGCP:
+		with["workload_identity_provider"] = config.WorkloadIdentityProvider
+		with["service_account"] = config.Role
+		if config.Audience != "" {
+			with["audience"] = config.Audience
+		}
+	}
+
+	return map[string]any{
+		"name": "Authenticate to cloud provider",
+		"id":   "cloud-auth",
+		"uses": cloudAuthActions[config.Provider],
+		"with": with,
+	}
+}
+
+// cloudPolicyCheckScripts holds the identity check for each provider. Each script compares the
+// authenticated identity with GH_AW_CLOUD_ROLE and exits non-zero on a mismatch.
+var cloudPolicyCheckScripts = map[string]string{
+	CloudProviderAWS: `ACTUAL_ARN="$(aws sts get-caller-identity --query Arn --output text)"
+ROLE_NAME="${GH_AW_CLOUD_ROLE##*/}"
+ACCOUNT_ID="$(echo "$GH_AW_CLOUD_ROLE" | cut -d: -f5)"
+case "$ACTUAL_ARN" in
+  arn:aws*:sts::"${ACCOUNT_ID}":assumed-role/"${ROLE_NAME}"/*) ;;
+  *)
+    echo "::error::Assumed identity ${ACTUAL_ARN} does not match configured role ${GH_AW_CLOUD_ROLE}"
+    exit 1
+    ;;
+esac`,
+	CloudProviderAzure: `ACTUAL_CLIENT_ID="$(az account show --query user.name --output tsv)"
+ACTUAL_SUBSCRIPTION_ID="$(az account show --query id --output tsv)"
+if [ "$ACTUAL_CLIENT_ID" != "$GH_AW_CLOUD_ROLE" ] || [ "$ACTUAL_SUBSCRIPTION_ID" != "$GH_AW_CLOUD_SUBSCRIPTION_ID" ]; then
+  echo "::error::Signed-in identity ${ACTUAL_CLIENT_ID} (subscription ${ACTUAL_SUBSCRIPTION_ID}) does not match the configured client ID and subscription"
+  exit 1
+fi`,
+	CloudProviderGCP: `ACTUAL_ACCOUNT="$(gcloud auth list --filter=status:ACTIVE --format='value(account)')"
+if [ "$ACTUAL_ACCOUNT" != "$GH_AW_CLOUD_ROLE" ]; then
+  echo "::error::Active account ${ACTUAL_ACCOUNT} does not match configured service account ${GH_AW_CLOUD_ROLE}"
+  exit 1
+fi`,
+}
+
+// buildCloudPolicyCheckStepMap returns the step that verifies the assumed identity and exports
+// the allowed operations for the user-provided steps that follow.
+func buildCloudPolicyCheckStepMap(config *SafeOutputCloudConfig) map[string]any {
+	env := map[string]any{
+		"GH_AW_CLOUD_ROLE":               config.Role,
+		"GH_AW_CLOUD_ALLOWED_OPERATIONS": strings.Join(config.AllowedOperations, ","),
+	}
+	if config.Provider == CloudProviderAzure {
+		env["GH_AW_CLOUD_SUBSCRIPTION_ID"] = config.SubscriptionID
+	}
+
+	script := "set -euo pipefail\n" + cloudPolicyCheckScripts[config.Provider] + "\n" +
+		"echo \"GH_AW_CLOUD_PROVIDER=" + config.Provider + "\" >> \"$GITHUB_ENV\"\n" +
+		"echo \"GH_AW_CLOUD_ALLOWED_OPERATIONS=${GH_AW_CLOUD_ALLOWED_OPERATIONS}\" >> \"$GITHUB_ENV\"\n"
+
+	return map[string]any{
+		"name":  "Verify cloud identity policy",
+		"id":    "cloud-policy-check",
+		"shell": "bash",
+		"env":   env,
+		"run":   script,
+	}
+}
+
+// buildCloudSteps returns the OIDC login and policy-check steps for the safe-outputs job.
+func buildCloudSteps(config *SafeOutputCloudConfig, data *WorkflowData) ([]string, error) {
+	if config == nil {
+		return nil, nil
+	}
+	safeOutputsCloudLog.Printf("Adding cloud authentication steps for provider %s", config.Provider)
+
+	authStep, err := MapToStep(buildCloudAuthStepMap(config))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build cloud authentication step: %w", err)
+	}
+	pinnedAuthStep, err := applyActionPinToTypedStep(authStep, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pin cloud authentication action: %w", err)
+	}
+
+	var steps []string
+	for _, stepMap := range []map[string]any{pinnedAuthStep.ToMap(), buildCloudPolicyCheckStepMap(config)} {
+		stepYAML, err := ConvertStepToYAML(stepMap)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert cloud step to YAML: %w", err)
+		}
+		steps = append(steps, stepYAML)
+	}
+	return steps, nil
+}
@@ -0,0 +1,165 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSafeOutputCloudConfig(t *testing.T) {
+	config := parseSafeOutputCloudConfig(map[string]any{
+		"provider":           "aws",
+		"role":               " arn:aws:iam::123456789012:role/deploy ",
+		"region":             "us-east-1",
+		"allowed-operations": []any{"s3:PutObject", "", 42},
+	})
+	require.NotNil(t, config, "cloud config should be parsed")
+	assert.Equal(t, "aws", config.Provider)
+	assert.Equal(t, "arn:aws:iam::123456789012:role/deploy", config.Role, "role should be trimmed")
+	assert.Equal(t, "us-east-1", config.Region)
+	assert.Equal(t, []string{"s3:PutObject"}, config.AllowedOperations, "non-string and empty operations should be dropped")
+
+	assert.Nil(t, parseSafeOutputCloudConfig("aws"), "non-mapping values should be ignored")
+}
+
+func TestValidateSafeOutputsCloud(t *testing.T) {
+	noneStr := "none"
+	awsConfig := func() *SafeOutputCloudConfig {
+		return &SafeOutputCloudConfig{Provider: "aws", Role: "arn:aws:iam::123456789012:role/deploy", Region: "us-east-1"}
+	}
+
+	tests := []struct {
+		name        string
+		safeOutputs *SafeOutputsConfig
+		errContains string
+	}{
+		{
+			name:        "valid aws config",
+			safeOutputs: &SafeOutputsConfig{Cloud: awsConfig()},
+		},
+		{
+			name: "vars and secrets references are allowed",
+			safeOutputs: &SafeOutputsConfig{Cloud: &SafeOutputCloudConfig{
+				Provider: "azure", Role: "${{ vars.AZURE_CLIENT_ID }}", TenantID: "${{ secrets.AZURE_TENANT_ID }}", SubscriptionID: "sub",
+			}},
+		},
+		{
+			name:        "unsupported provider",
+			safeOutputs: &SafeOutputsConfig{Cloud: &SafeOutputCloudConfig{Provider: "oracle", Role: "r"}},
+			errContains: "unsupported provider",
+		},
+		{
+			name:        "missing provider-specific field",
+			safeOutputs: &SafeOutputsConfig{Cloud: &SafeOutputCloudConfig{Provider: "gcp", Role: "deploy@proj.iam.gserviceaccount.com"}},
+			errContains: `requires "workload-identity-provider"`,
+		},
+		{
+			name: "agent-controlled expression in role",
+			safeOutputs: &SafeOutputsConfig{Cloud: &SafeOutputCloudConfig{
+				Provider: "aws", Role: "${{ needs.agent.outputs.role }}", Region: "us-east-1",
+			}},
+			errContains: "could let the agent change which role is assumed",
+		},
+		{
+			name: "invalid allowed operation",
+			safeOutputs: &SafeOutputsConfig{Cloud: &SafeOutputCloudConfig{
+				Provider: "aws", Role: "arn:aws:iam::123456789012:role/deploy", Region: "us-east-1", AllowedOperations: []string{"rm -rf"},
+			}},
+			errContains: "invalid allowed-operations entry",
+		},
+		{
+			name:        "id-token none conflicts",
+			safeOutputs: &SafeOutputsConfig{Cloud: awsConfig(), IDToken: &noneStr},
+			errContains: "id-token: none",
+		},
+		{
+			name: "user step with its own OIDC login",
+			safeOutputs: &SafeOutputsConfig{Cloud: awsConfig(), Steps: []any{
+				map[string]any{"run": "echo ok"},
+				map[string]any{"uses": "aws-actions/configure-aws-credentials@v4"},
+			}},
+			errContains: "safe-outputs.steps[1]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewCompiler().validateSafeOutputsCloud(&WorkflowData{SafeOutputs: tt.safeOutputs}, "test.md")
+			if tt.errContains == "" {
+				assert.NoError(t, err, "config should be valid")
+				return
+			}
+			require.Error(t, err, "config should be rejected")
+			assert.Contains(t, err.Error(), tt.errContains)
+		})
+	}
+}
+
+func TestComputePermissionsForSafeOutputs_Cloud(t *testing.T) {
+	permissions := ComputePermissionsForSafeOutputs(&SafeOutputsConfig{
+		Cloud: &SafeOutputCloudConfig{Provider: "aws", Role: "arn:aws:iam::123456789012:role/deploy", Region: "us-east-1"},
+	})
+	level, ok := permissions.Get(PermissionIdToken)
+	require.True(t, ok, "cloud config should add id-token permission")
+	assert.Equal(t, PermissionWrite, level)
+}
+
+func TestSafeOutputsCloudCompilation(t *testing.T) {
+	compiler := NewCompiler(WithVersion("1.0.0"))
+
+	markdown := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+safe-outputs:
+  cloud:
+    provider: aws
+    role: ${{ vars.DEPLOY_ROLE_ARN }}
+    region: us-east-1
+    allowed-operations: [s3:PutObject]
+  steps:
+    - name: Deploy
+      run: aws s3 sync ./site s3://example-bucket
+  noop:
+---
+
+# Deploy
+
+Deploy the site.
+`
+
+	tmpDir := t.TempDir()
+	workflowsDir := filepath.Join(tmpDir, ".github", "workflows")
+	require.NoError(t, os.MkdirAll(workflowsDir, 0755))
+	mdPath := filepath.Join(workflowsDir, "deploy.md")
+	require.NoError(t, os.WriteFile(mdPath, []byte(markdown), 0644))
+
+	origDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir(origDir) }()
+
+	require.NoError(t, compiler.CompileWorkflow(mdPath), "workflow with cloud config should compile")
+
+	lockContent, err := os.ReadFile(filepath.Join(workflowsDir, "deploy.lock.yml"))
+	require.NoError(t, err)
+	lock := string(lockContent)
+
+	assert.Contains(t, lock, "aws-actions/configure-aws-credentials", "lock file should include the OIDC login action")
+	assert.Contains(t, lock, "role-to-assume: ${{ vars.DEPLOY_ROLE_ARN }}")
+	assert.Contains(t, lock, "id-token: write")
+	assert.Contains(t, lock, "GH_AW_CLOUD_ALLOWED_OPERATIONS: s3:PutObject")
+
+	authIdx := strings.Index(lock, "id: cloud-auth")
+	checkIdx := strings.Index(lock, "id: cloud-policy-check")
+	deployIdx := strings.Index(lock, "name: Deploy")
+	require.NotEqual(t, -1, authIdx, "auth step should be emitted")
+	assert.Less(t, authIdx, checkIdx, "policy check should follow the login step")
+	assert.Less(t, checkIdx, deployIdx, "user steps should run after the policy check")
+}
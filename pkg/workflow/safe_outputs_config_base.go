@@ -4,7 +4,7 @@ import (
 	"github.com/github/gh-aw/pkg/typeutil"
 )
 
-// parseBaseSafeOutputConfig parses common fields (max, github-token, github-app, staged) from a config map.
+// parseBaseSafeOutputConfig parses common fields (max, github-token, github-app, staged, retry) from a config map.
 // If defaultMax is provided (> 0), it will be set as the default value for config.Max
 // before parsing the max field from configMap. Supports both integer values and GitHub
 // Actions expression strings (e.g. "${{ inputs.max }}").
@@ -61,6 +61,11 @@ func (c *Compiler) parseBaseSafeOutputConfig(configMap map[string]any, config *B
 		}
 	}
 
+	// Parse retry policy (per-handler override of the global safe-outputs retry)
+	if retry, exists := configMap["retry"]; exists {
+		config.Retry = parseSafeOutputRetryConfig(retry)
+	}
+
 	// Parse samples list (hidden feature: deterministic replay samples for --use-samples).
 	// Accepts either a YAML list of objects, or a single object that is auto-wrapped
 	// into a one-element list. The JSON schema rejects scalar/string shapes so we
@@ -74,6 +79,29 @@ func (c *Compiler) parseBaseSafeOutputConfig(configMap map[string]any, config *B
 	}
 }
 
+// parseSafeOutputRetryConfig parses a `retry` mapping with max-attempts and backoff (seconds).
+// Returns nil when the value is not a mapping or sets neither field.
+func parseSafeOutputRetryConfig(retry any) *SafeOutputRetryConfig {
+	retryMap, ok := retry.(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	config := &SafeOutputRetryConfig{}
+	if maxAttempts, ok := typeutil.ParseIntValue(retryMap["max-attempts"]); ok && maxAttempts > 0 {
+		config.MaxAttempts = maxAttempts
+	}
+	if backoff, ok := typeutil.ParseIntValue(retryMap["backoff"]); ok && backoff > 0 {
+		config.Backoff = backoff
+	}
+	if config.MaxAttempts == 0 && config.Backoff == 0 {
+		return nil
+	}
+
+	safeOutputsConfigLog.Printf("Parsed retry policy: max-attempts=%d, backoff=%ds", config.MaxAttempts, config.Backoff)
+	return config
+}
+
 // parseSamplesValue normalizes a `samples` frontmatter value into a list of
 // objects. Accepted shapes:
 //   - YAML list of mappings: returned as-is
@@ -83,6 +83,12 @@ func (c *Compiler) extractSafeOutputsConfig(frontmatter map[string]any) *SafeOut
 				config.CreateProjectStatusUpdates = createProjectStatusUpdateConfig
 			}
 
+			// Handle update-project-item (project item field updates)
+			updateProjectItemConfig := c.parseUpdateProjectItemConfig(outputMap)
+			if updateProjectItemConfig != nil {
+				config.UpdateProjectItems = updateProjectItemConfig
+			}
+
 			// Handle create-discussion
 			discussionsConfig := c.parseCreateDiscussionsConfig(outputMap)
 			if discussionsConfig != nil {
@@ -174,6 +180,18 @@ func (c *Compiler) extractSafeOutputsConfig(frontmatter map[string]any) *SafeOut
 				config.CreateCheckRun = createCheckRunConfig
 			}
 
+			// Handle create-commit-status
+			createCommitStatusConfig := c.parseCreateCommitStatusConfig(outputMap)
+			if createCommitStatusConfig != nil {
+				config.CreateCommitStatus = createCommitStatusConfig
+			}
+
+			// Handle notify (chat webhook notifications)
+			notifyConfig := c.parseNotifyConfig(outputMap)
+			if notifyConfig != nil {
+				config.Notify = notifyConfig
+			}
+
 			// Parse add-labels configuration
 			addLabelsConfig := c.parseAddLabelsConfig(outputMap)
 			if addLabelsConfig != nil {
@@ -198,6 +216,12 @@ func (c *Compiler) extractSafeOutputsConfig(frontmatter map[string]any) *SafeOut
 				config.AddReviewer = addReviewerConfig
 			}
 
+			// Parse request-team-review configuration
+			requestTeamReviewConfig := c.parseRequestTeamReviewConfig(outputMap)
+			if requestTeamReviewConfig != nil {
+				config.RequestTeamReview = requestTeamReviewConfig
+			}
+
 			// Parse assign-milestone configuration
 			assignMilestoneConfig := c.parseAssignMilestoneConfig(outputMap)
 			if assignMilestoneConfig != nil {
@@ -222,6 +246,18 @@ func (c *Compiler) extractSafeOutputsConfig(frontmatter map[string]any) *SafeOut
 				config.UnassignFromUser = unassignFromUserConfig
 			}
 
+			// Handle lock-issue
+			lockIssueConfig := c.parseLockIssueConfig(outputMap)
+			if lockIssueConfig != nil {
+				config.LockIssue = lockIssueConfig
+			}
+
+			// Handle lock-pull-request
+			lockPullRequestConfig := c.parseLockPullRequestConfig(outputMap)
+			if lockPullRequestConfig != nil {
+				config.LockPullRequest = lockPullRequestConfig
+			}
+
 			// Handle update-issue
 			updateIssuesConfig := c.parseUpdateIssuesConfig(outputMap)
 			if updateIssuesConfig != nil {
@@ -264,6 +300,12 @@ func (c *Compiler) extractSafeOutputsConfig(frontmatter map[string]any) *SafeOut
 				config.UploadArtifact = uploadArtifactConfig
 			}
 
+			// Handle create-artifact
+			createArtifactConfig := c.parseCreateArtifactConfig(outputMap)
+			if createArtifactConfig != nil {
+				config.CreateArtifact = createArtifactConfig
+			}
+
 			// Handle update-release
 			updateReleaseConfig := c.parseUpdateReleaseConfig(outputMap)
 			if updateReleaseConfig != nil {
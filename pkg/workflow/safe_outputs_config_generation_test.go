@@ -465,6 +465,31 @@ func TestGenerateSafeOutputsConfigAddLabelsBlocked(t *testing.T) {
 	assert.Equal(t, "triage-needed", blockedSlice[3], "Fourth blocked pattern should match")
 }
 
+// TestGenerateSafeOutputsConfigLabelBatch tests that batch: true is passed to the
+// add_labels and remove_labels handlers and omitted when disabled.
+func TestGenerateSafeOutputsConfigLabelBatch(t *testing.T) {
+	data := &WorkflowData{
+		SafeOutputs: &SafeOutputsConfig{
+			AddLabels:    &AddLabelsConfig{Batch: true},
+			RemoveLabels: &RemoveLabelsConfig{},
+		},
+	}
+
+	result, err := generateSafeOutputsConfig(data)
+	require.NoError(t, err, "generateSafeOutputsConfig should not return an error")
+
+	var parsed map[string]any
+	require.NoError(t, json.Unmarshal([]byte(result), &parsed), "Result must be valid JSON")
+
+	addLabelsConfig, ok := parsed["add_labels"].(map[string]any)
+	require.True(t, ok, "Expected add_labels key in config")
+	assert.Equal(t, true, addLabelsConfig["batch"], "add_labels should enable batch mode")
+
+	removeLabelsConfig, ok := parsed["remove_labels"].(map[string]any)
+	require.True(t, ok, "Expected remove_labels key in config")
+	assert.NotContains(t, removeLabelsConfig, "batch", "remove_labels should not enable batch mode by default")
+}
+
 // TestGenerateSafeOutputsConfigSafeJobMax tests that the max field is emitted in config.json
 // for custom safe-jobs so the output collector can enforce it.
 func TestGenerateSafeOutputsConfigSafeJobMax(t *testing.T) {
@@ -81,10 +81,11 @@ func (c *Compiler) extractGlobalConfigFields(outputMap map[string]any, config *S
 	config.MaximumPatchSize = parseBoundedIntFieldOrDefault(outputMap, "max-patch-size", 4096, safeOutputsConfigLog)
 
 	// Handle max-patch-files configuration (maximum unique files allowed in
-	// a create-pull-request patch). parseBoundedIntField centralizes the
+	// a create-pull-request or push-to-pull-request-branch patch). parseBoundedIntField centralizes the
 	// overflow checks, clamping, and float truncation behavior shared with
-	// the other global bounded integer fields.
-	config.MaximumPatchFiles = parseBoundedIntFieldOrDefault(outputMap, "max-patch-files", 100, safeOutputsConfigLog)
+	// the other global bounded integer fields. It stays 0 when unset: create-pull-request
+	// then applies its own default of 100, while pushes have no file limit.
+	config.MaximumPatchFiles, _ = parseBoundedIntField(outputMap, "max-patch-files", safeOutputsConfigLog)
 
 	// Handle threat-detection
 	threatDetectionConfig := c.parseThreatDetectionConfig(outputMap)
@@ -137,6 +138,29 @@ func (c *Compiler) extractGlobalConfigFields(outputMap map[string]any, config *S
 		}
 	}
 
+	// Handle global retry policy for handler API calls
+	if retry, exists := outputMap["retry"]; exists {
+		config.Retry = parseSafeOutputRetryConfig(retry)
+	}
+
+	// Handle max-total cap across all safe output types
+	if maxTotal, ok := parseBoundedIntField(outputMap, "max-total", safeOutputsConfigLog); ok {
+		config.MaxTotal = maxTotal
+		safeOutputsConfigLog.Printf("Configured max-total: %d", maxTotal)
+	}
+
+	// Handle priority ordering used when max-total is exceeded
+	if priority, exists := outputMap["priority"]; exists {
+		if priorityArray, ok := priority.([]any); ok {
+			for _, entry := range priorityArray {
+				if entryStr, ok := entry.(string); ok && entryStr != "" {
+					config.Priority = append(config.Priority, entryStr)
+				}
+			}
+			safeOutputsConfigLog.Printf("Configured priority with %d type(s)", len(config.Priority))
+		}
+	}
+
 	// Handle group-reports flag
 	if groupReports, exists := outputMap["group-reports"]; exists {
 		if groupReportsBool, ok := groupReports.(bool); ok {
@@ -233,6 +257,11 @@ func (c *Compiler) extractGlobalConfigFields(outputMap map[string]any, config *S
 		}
 	}
 
+	// Handle cloud configuration (OIDC login and identity policy check for deployment steps)
+	if cloud, exists := outputMap["cloud"]; exists {
+		config.Cloud = parseSafeOutputCloudConfig(cloud)
+	}
+
 	// Handle concurrency-group configuration
 	if concurrencyGroup, exists := outputMap["concurrency-group"]; exists {
 		if concurrencyGroupStr, ok := concurrencyGroup.(string); ok && concurrencyGroupStr != "" {
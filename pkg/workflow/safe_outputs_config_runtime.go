@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/github/gh-aw/pkg/sliceutil"
+	"github.com/github/gh-aw/pkg/stringutil"
 )
 
 // SafeOutputStepConfig holds configuration for building a single safe output step
@@ -33,7 +34,7 @@ func (c *Compiler) addHandlerManagerConfigEnvVar(steps *[]string, data *Workflow
 
 	safeOutputsConfigLog.Print("Building handler manager configuration for safe-outputs")
 	// config holds both per-handler configs (keyed by handler name, e.g. "add_comment") and
-	// global runtime knobs (e.g. "mentions") that safe_output_handler_manager.cjs forwards to
+	// global runtime knobs (e.g. "mentions", "retry") that safe_output_handler_manager.cjs forwards to
 	// specific handlers at startup. Handler names are the reserved keys defined in handlerRegistry;
 	// non-handler keys ("mentions") are documented in safe_outputs_config_generation.go.
 	config := make(map[string]any)
@@ -88,6 +89,9 @@ func (c *Compiler) addHandlerManagerConfigEnvVar(steps *[]string, data *Workflow
 					handlerConfig["protected_dot_folder_excludes"] = dotFolderExcludes
 				}
 			}
+			if retry := safeOutputHandlerRetry(safeOutputs, handlerName); retry != nil {
+				handlerConfig["retry"] = buildRetryHandlerConfig(retry)
+			}
 			safeOutputsConfigLog.Printf("Adding %s handler configuration", handlerName)
 			config[handlerName] = handlerConfig
 		}
@@ -102,6 +106,23 @@ func (c *Compiler) addHandlerManagerConfigEnvVar(steps *[]string, data *Workflow
 		}
 	}
 
+	// Include the global retry policy; handlers without their own "retry" entry use it.
+	if safeOutputs.Retry != nil {
+		config["retry"] = buildRetryHandlerConfig(safeOutputs.Retry)
+	}
+
+	// Include the cross-type cap and the order in which types are kept when it is exceeded.
+	if safeOutputs.MaxTotal > 0 {
+		config["max_total"] = safeOutputs.MaxTotal
+		if len(safeOutputs.Priority) > 0 {
+			priority := make([]string, 0, len(safeOutputs.Priority))
+			for _, outputType := range safeOutputs.Priority {
+				priority = append(priority, stringutil.NormalizeSafeOutputIdentifier(outputType))
+			}
+			config["priority"] = priority
+		}
+	}
+
 	// Only add the env var if there are handlers to configure
 	if len(config) > 0 {
 		safeOutputsConfigLog.Printf("Marshaling handler config with %d handlers", len(config))
@@ -119,6 +140,20 @@ func (c *Compiler) addHandlerManagerConfigEnvVar(steps *[]string, data *Workflow
 	}
 }
 
+// buildRetryHandlerConfig converts a SafeOutputRetryConfig into the map format used by
+// GH_AW_SAFE_OUTPUTS_HANDLER_CONFIG. Unset fields are omitted so the runtime keeps the
+// handler's own retry defaults for them.
+func buildRetryHandlerConfig(r *SafeOutputRetryConfig) map[string]any {
+	cfg := make(map[string]any)
+	if r.MaxAttempts > 0 {
+		cfg["max_attempts"] = r.MaxAttempts
+	}
+	if r.Backoff > 0 {
+		cfg["backoff"] = r.Backoff
+	}
+	return cfg
+}
+
 // buildMentionsHandlerConfig converts a MentionsConfig into the map format used by
 // GH_AW_SAFE_OUTPUTS_HANDLER_CONFIG so safe_output_handler_manager.cjs can pass
 // the top-level mentions policy through to mention-aware handlers.
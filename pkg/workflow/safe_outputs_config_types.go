@@ -20,12 +20,13 @@ var safeOutputsConfigLog = logger.New("workflow:safe_outputs_config")
 
 // BaseSafeOutputConfig holds common configuration fields for all safe output types
 type BaseSafeOutputConfig struct {
-	Max                      *string          `yaml:"max,omitempty"`                        // Maximum number of items to create (supports integer or GitHub Actions expression)
-	GitHubToken              string           `yaml:"github-token,omitempty"`               // GitHub token for this specific output type
-	GitHubApp                *GitHubAppConfig `yaml:"github-app,omitempty"`                 // GitHub App credentials for minting a per-handler installation access token
-	Staged                   *TemplatableBool `yaml:"staged,omitempty"`                     // Templatable preview-only mode for this specific output type
-	IssueIntent              *bool            `yaml:"issue-intent,omitempty"`               // When true, enable issue-intent rationale/confidence guidance and schema requirements for this output type.
-	NormalizeClosingKeywords *bool            `yaml:"normalize-closing-keywords,omitempty"` // When true for this output type, strip backticks from recognized issue-closing keywords in body fields.
+	Max                      *string                `yaml:"max,omitempty"`                        // Maximum number of items to create (supports integer or GitHub Actions expression)
+	GitHubToken              string                 `yaml:"github-token,omitempty"`               // GitHub token for this specific output type
+	GitHubApp                *GitHubAppConfig       `yaml:"github-app,omitempty"`                 // GitHub App credentials for minting a per-handler installation access token
+	Staged                   *TemplatableBool       `yaml:"staged,omitempty"`                     // Templatable preview-only mode for this specific output type
+	IssueIntent              *bool                  `yaml:"issue-intent,omitempty"`               // When true, enable issue-intent rationale/confidence guidance and schema requirements for this output type.
+	NormalizeClosingKeywords *bool                  `yaml:"normalize-closing-keywords,omitempty"` // When true for this output type, strip backticks from recognized issue-closing keywords in body fields.
+	Retry                    *SafeOutputRetryConfig `yaml:"retry,omitempty"`                      // Retry policy for transient GitHub API failures; overrides the global safe-outputs retry
 	// Samples carries deterministic replay samples for the hidden
 	// `gh aw compile --use-samples` flag. Each entry is the JSON object
 	// passed to the corresponding MCP tool's `tools/call` arguments.
@@ -54,26 +55,33 @@ type SafeOutputsConfig struct {
 	ResolvePullRequestReviewThread         *ResolvePullRequestReviewThreadConfig  `yaml:"resolve-pull-request-review-thread,omitempty"`   // Resolve a review thread on a pull request
 	CreateCodeScanningAlerts               *CreateCodeScanningAlertsConfig        `yaml:"create-code-scanning-alert,omitempty"`
 	AutofixCodeScanningAlert               *AutofixCodeScanningAlertConfig        `yaml:"autofix-code-scanning-alert,omitempty"`
-	CreateCheckRun                         *CreateCheckRunConfig                  `yaml:"create-check-run,omitempty"` // Create GitHub Check Runs to report agent analysis results
+	CreateCheckRun                         *CreateCheckRunConfig                  `yaml:"create-check-run,omitempty"`     // Create GitHub Check Runs to report agent analysis results
+	CreateCommitStatus                     *CreateCommitStatusConfig              `yaml:"create-commit-status,omitempty"` // Set commit statuses to report pass/fail verdicts
+	Notify                                 *NotifyConfig                          `yaml:"notify,omitempty"`               // Post notifications to configured chat webhooks
 	AddLabels                              *AddLabelsConfig                       `yaml:"add-labels,omitempty"`
 	RemoveLabels                           *RemoveLabelsConfig                    `yaml:"remove-labels,omitempty"`
 	ReplaceLabel                           *ReplaceLabelConfig                    `yaml:"replace-label,omitempty"` // Replace one label with another in a single atomic operation
 	AddReviewer                            *AddReviewerConfig                     `yaml:"add-reviewer,omitempty"`
+	RequestTeamReview                      *RequestTeamReviewConfig               `yaml:"request-team-review,omitempty"` // Request reviews from organization teams
 	AssignMilestone                        *AssignMilestoneConfig                 `yaml:"assign-milestone,omitempty"`
 	AssignToAgent                          *AssignToAgentConfig                   `yaml:"assign-to-agent,omitempty"`
 	AssignToUser                           *AssignToUserConfig                    `yaml:"assign-to-user,omitempty"`     // Assign users to issues
 	UnassignFromUser                       *UnassignFromUserConfig                `yaml:"unassign-from-user,omitempty"` // Remove assignees from issues
+	LockIssue                              *LockIssueConfig                       `yaml:"lock-issue,omitempty"`         // Lock issue conversations
+	LockPullRequest                        *LockPullRequestConfig                 `yaml:"lock-pull-request,omitempty"`  // Lock pull request conversations
 	UpdateIssues                           *UpdateIssuesConfig                    `yaml:"update-issue,omitempty"`
 	UpdatePullRequests                     *UpdatePullRequestsConfig              `yaml:"update-pull-request,omitempty"` // Update GitHub pull request title/body
 	MergePullRequest                       *MergePullRequestConfig                `yaml:"merge-pull-request,omitempty"`  // Merge pull requests under constrained policy checks
 	PushToPullRequestBranch                *PushToPullRequestBranchConfig         `yaml:"push-to-pull-request-branch,omitempty"`
 	UploadAssets                           *UploadAssetsConfig                    `yaml:"upload-asset,omitempty"`
 	UploadArtifact                         *UploadArtifactConfig                  `yaml:"upload-artifact,omitempty"`              // Upload files as run-scoped GitHub Actions artifacts
+	CreateArtifact                         *CreateArtifactConfig                  `yaml:"create-artifact,omitempty"`              // Upload agent results as GPG-encrypted GitHub Actions artifacts
 	UpdateRelease                          *UpdateReleaseConfig                   `yaml:"update-release,omitempty"`               // Update GitHub release descriptions
 	CreateAgentSessions                    *CreateAgentSessionConfig              `yaml:"create-agent-session,omitempty"`         // Create GitHub Copilot coding agent sessions
 	UpdateProjects                         *UpdateProjectConfig                   `yaml:"update-project,omitempty"`               // Smart project board management (create/add/update)
 	CreateProjects                         *CreateProjectsConfig                  `yaml:"create-project,omitempty"`               // Create GitHub Projects V2
 	CreateProjectStatusUpdates             *CreateProjectStatusUpdateConfig       `yaml:"create-project-status-update,omitempty"` // Create GitHub project status updates
+	UpdateProjectItems                     *UpdateProjectItemConfig               `yaml:"update-project-item,omitempty"`          // Set validated Project v2 field values on existing project items
 	LinkSubIssue                           *LinkSubIssueConfig                    `yaml:"link-sub-issue,omitempty"`               // Link issues as sub-issues
 	HideComment                            *HideCommentConfig                     `yaml:"hide-comment,omitempty"`                 // Hide comments
 	SetIssueType                           *SetIssueTypeConfig                    `yaml:"set-issue-type,omitempty"`               // Set the type of an issue (empty string clears the type)
@@ -96,11 +104,14 @@ type SafeOutputsConfig struct {
 	Env                                    map[string]string                      `yaml:"env,omitempty"`                          // Environment variables to pass to safe output jobs
 	GitHubToken                            string                                 `yaml:"github-token,omitempty"`                 // GitHub token for safe output jobs
 	MaximumPatchSize                       int                                    `yaml:"max-patch-size,omitempty"`               // Maximum allowed patch size in KB (defaults to 4096)
-	MaximumPatchFiles                      int                                    `yaml:"max-patch-files,omitempty"`              // Maximum allowed unique files per create-pull-request patch (defaults to 100)
+	MaximumPatchFiles                      int                                    `yaml:"max-patch-files,omitempty"`              // Maximum allowed unique files per create-pull-request or push-to-pull-request-branch patch (0 when unset)
 	RunsOn                                 string                                 `yaml:"runs-on,omitempty"`                      // Runner configuration for safe-outputs jobs
 	Messages                               *SafeOutputMessagesConfig              `yaml:"messages,omitempty"`                     // Custom message templates for footer and notifications
 	Mentions                               *MentionsConfig                        `yaml:"mentions,omitempty"`                     // Configuration for @mention filtering in safe outputs
 	Footer                                 *bool                                  `yaml:"footer,omitempty"`                       // Global footer control - when false, omits visible footer from all safe outputs (XML markers still included)
+	Retry                                  *SafeOutputRetryConfig                 `yaml:"retry,omitempty"`                        // Default retry policy for handlers on transient GitHub API failures (5xx, secondary rate limits)
+	MaxTotal                               int                                    `yaml:"max-total,omitempty"`                    // Maximum number of safe outputs processed across all types; lower-priority outputs beyond it are dropped
+	Priority                               []string                               `yaml:"priority,omitempty"`                     // Safe output types in the order they are kept when max-total is exceeded
 	GroupReports                           bool                                   `yaml:"group-reports,omitempty"`                // If true, create parent "Failed runs" issue for agent failures (default: false)
 	ReportFailureAsIssue                   any                                    `yaml:"report-failure-as-issue,omitempty"`      // Controls failure issue creation: bool, templatable expression string, or []interface{} categories (parsed to ReportFailureAsIssueCategories/ExcludedCategories). Default: true
 	ReportFailureAsIssueCategories         []string                               `yaml:"-"`                                      // Parsed failure categories for report-failure-as-issue (internal use only, included categories)
@@ -109,6 +120,7 @@ type SafeOutputsConfig struct {
 	MaxBotMentions                         *string                                `yaml:"max-bot-mentions,omitempty"`             // Maximum bot trigger references (e.g. 'fixes #123') allowed before filtering. Default: 10. Supports integer or GitHub Actions expression.
 	Steps                                  []any                                  `yaml:"steps,omitempty"`                        // User-provided steps injected after setup/checkout and before safe-output code
 	IDToken                                *string                                `yaml:"id-token,omitempty"`                     // Override id-token permission: "write" to force-add, "none" to disable auto-detection
+	Cloud                                  *SafeOutputCloudConfig                 `yaml:"cloud,omitempty"`                        // OIDC cloud authentication with an identity policy check, injected before user-provided steps
 	ConcurrencyGroup                       string                                 `yaml:"concurrency-group,omitempty"`            // Concurrency group for the safe-outputs job (cancel-in-progress is always false)
 	Needs                                  []string                               `yaml:"needs,omitempty"`                        // Additional custom workflow jobs that safe_outputs should depend on
 	Environment                            string                                 `yaml:"environment,omitempty"`                  // Override the GitHub deployment environment for the safe-outputs job (defaults to the top-level environment: field)
@@ -140,6 +152,13 @@ type SafeOutputMessagesConfig struct {
 	DisclosureHeader               string `yaml:"disclosure-header,omitempty" json:"disclosureHeader,omitempty"`                               // AI authorship disclosure header prepended to every message body. Set to "true" for built-in default text, or provide a custom template string. Placeholders: {workflow_name}, {run_url}
 }
 
+// SafeOutputRetryConfig holds the retry policy for GitHub API calls made by safe-output handlers.
+// Only transient failures (server errors, timeouts and secondary rate limits) are retried.
+type SafeOutputRetryConfig struct {
+	MaxAttempts int `yaml:"max-attempts,omitempty"` // Total attempts per API call, including the first one
+	Backoff     int `yaml:"backoff,omitempty"`      // Delay in seconds before the first retry; doubles on each following retry
+}
+
 // MentionsConfig holds configuration for @mention filtering in safe outputs
 type MentionsConfig struct {
 	// Enabled can be:
@@ -171,5 +190,6 @@ type MentionsConfig struct {
 
 // SecretMaskingConfig holds configuration for secret redaction behavior
 type SecretMaskingConfig struct {
-	Steps []map[string]any `yaml:"steps,omitempty"` // Additional secret redaction steps to inject after built-in redaction
+	Steps    []map[string]any `yaml:"steps,omitempty"`    // Additional secret redaction steps to inject after built-in redaction
+	Patterns []string         `yaml:"patterns,omitempty"` // Regular expressions whose matches are masked in the job log and redacted from artifacts
 }
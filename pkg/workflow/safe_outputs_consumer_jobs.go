@@ -0,0 +1,95 @@
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/sliceutil"
+)
+
+var safeOutputsConsumerJobsLog = logger.New("workflow:safe_outputs_consumer_jobs")
+
+// safeOutputsOutputRefPattern matches needs.safe_outputs.outputs.<name> references in
+// custom job definitions.
+var safeOutputsOutputRefPattern = regexp.MustCompile(`needs\.safe_outputs\.outputs\.([A-Za-z0-9_-]+)`)
+
+// wireSafeOutputsConsumerJobs connects custom jobs (from frontmatter jobs: or imported
+// YAML workflows) to the safe_outputs job when they read its outputs, so post-processing
+// jobs can react to what the agent created (created_issue_number, created_pr_url, ...).
+//
+// For each custom job that references needs.safe_outputs.outputs.<name>:
+//   - safe_outputs is added to the job's needs when missing
+//   - every referenced output must be exported by the safe_outputs job
+//   - the job must not be listed in safe-outputs.needs, which would create a cycle
+func (c *Compiler) wireSafeOutputsConsumerJobs(data *WorkflowData) error {
+	if len(data.Jobs) == 0 {
+		return nil
+	}
+	safeOutputsJobName := string(constants.SafeOutputsJobName)
+
+	for _, jobName := range sliceutil.SortedKeys(data.Jobs) {
+		if isReservedSafeOutputsNeedsTarget(jobName) {
+			continue
+		}
+		refs := safeOutputsOutputRefs(data.Jobs[jobName])
+		if len(refs) == 0 {
+			continue
+		}
+		safeOutputsConsumerJobsLog.Printf("Custom job %q references safe_outputs outputs: %v", jobName, refs)
+
+		safeOutputsJob, exists := c.jobManager.GetJob(safeOutputsJobName)
+		if !exists {
+			return fmt.Errorf("jobs.%s references needs.safe_outputs.outputs.%s, but this workflow has no safe_outputs job. Configure a safe output such as create-issue or create-pull-request in safe-outputs:", jobName, refs[0])
+		}
+		if data.SafeOutputs != nil && slices.Contains(data.SafeOutputs.Needs, jobName) {
+			return fmt.Errorf("jobs.%s cannot read needs.safe_outputs.outputs because it is listed in safe-outputs.needs, which would create a dependency cycle", jobName)
+		}
+		for _, ref := range refs {
+			if _, ok := safeOutputsJob.Outputs[ref]; !ok {
+				return fmt.Errorf("jobs.%s references needs.safe_outputs.outputs.%s, which is not an output of the safe_outputs job. Available outputs: %s",
+					jobName, ref, strings.Join(sliceutil.SortedKeys(safeOutputsJob.Outputs), ", "))
+			}
+		}
+
+		job, exists := c.jobManager.GetJob(jobName)
+		if !exists || slices.Contains(job.Needs, safeOutputsJobName) {
+			continue
+		}
+		job.Needs = append(job.Needs, safeOutputsJobName)
+		safeOutputsConsumerJobsLog.Printf("Added automatic dependency: custom job %q now depends on %q", jobName, safeOutputsJobName)
+	}
+
+	return nil
+}
+
+// isSafeOutputsConsumerJob reports whether a custom job runs after the safe_outputs job,
+// either because it lists safe_outputs in needs or because it reads safe_outputs outputs.
+// Such jobs must not become dependencies of the agent job.
+func isSafeOutputsConsumerJob(jobConfig map[string]any) bool {
+	if slices.Contains(parseNeedsField(jobConfig["needs"]), string(constants.SafeOutputsJobName)) {
+		return true
+	}
+	return len(safeOutputsOutputRefs(jobConfig)) > 0
+}
+
+// safeOutputsOutputRefs returns the distinct safe_outputs output names referenced
+// anywhere in a custom job definition, in order of first appearance.
+func safeOutputsOutputRefs(jobConfig any) []string {
+	content, err := json.Marshal(jobConfig)
+	if err != nil {
+		safeOutputsConsumerJobsLog.Printf("Failed to serialize job config: %v", err)
+		return nil
+	}
+	var refs []string
+	for _, match := range safeOutputsOutputRefPattern.FindAllStringSubmatch(string(content), -1) {
+		if !slices.Contains(refs, match[1]) {
+			refs = append(refs, match[1])
+		}
+	}
+	return refs
+}
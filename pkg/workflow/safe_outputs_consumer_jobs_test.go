@@ -0,0 +1,136 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goccy/go-yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/github/gh-aw/pkg/testutil"
+)
+
+func TestSafeOutputsOutputRefs(t *testing.T) {
+	jobConfig := map[string]any{
+		"if": "needs.safe_outputs.outputs.created_issue_number != ''",
+		"steps": []any{
+			map[string]any{"run": "echo ${{ needs.safe_outputs.outputs.created_issue_number }} ${{ needs.safe_outputs.outputs.created_issue_url }}"},
+			map[string]any{"run": "echo ${{ needs.agent.outputs.model }}"},
+		},
+	}
+	assert.Equal(t, []string{"created_issue_number", "created_issue_url"}, safeOutputsOutputRefs(jobConfig), "refs should be distinct and in order of appearance")
+	assert.Empty(t, safeOutputsOutputRefs(map[string]any{"steps": []any{map[string]any{"run": "echo hi"}}}), "job without references should have no refs")
+}
+
+func TestWireSafeOutputsConsumerJobs(t *testing.T) {
+	tests := []struct {
+		name        string
+		frontmatter string
+		wantNeeds   []string
+		errContains string
+	}{
+		{
+			name: "dependency added to job without needs",
+			frontmatter: `safe-outputs:
+  create-issue:
+jobs:
+  label_issue:
+    runs-on: ubuntu-latest
+    if: needs.safe_outputs.outputs.created_issue_number != ''
+    steps:
+      - run: echo "${{ needs.safe_outputs.outputs.created_issue_url }}"`,
+			wantNeeds: []string{"activation", "safe_outputs"},
+		},
+		{
+			name: "explicit needs kept without duplicates",
+			frontmatter: `safe-outputs:
+  create-pull-request:
+jobs:
+  notify:
+    needs: [safe_outputs]
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo "${{ needs.safe_outputs.outputs.created_pr_number }}"`,
+			wantNeeds: []string{"safe_outputs"},
+		},
+		{
+			name: "unknown output rejected",
+			frontmatter: `safe-outputs:
+  create-issue:
+jobs:
+  notify:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo "${{ needs.safe_outputs.outputs.created_pr_number }}"`,
+			errContains: "needs.safe_outputs.outputs.created_pr_number, which is not an output of the safe_outputs job",
+		},
+		{
+			name: "job listed in safe-outputs.needs rejected",
+			frontmatter: `safe-outputs:
+  needs: [notify]
+  create-issue:
+jobs:
+  notify:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo "${{ needs.safe_outputs.outputs.created_issue_number }}"`,
+			errContains: "would create a dependency cycle",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := testutil.TempDir(t, "safe-outputs-consumer-jobs")
+			content := "---\non: workflow_dispatch\nengine: copilot\nstrict: false\n" + tt.frontmatter + "\n---\n\n# Consumer jobs\n"
+			workflowFile := filepath.Join(tmpDir, "consumer.md")
+			require.NoError(t, os.WriteFile(workflowFile, []byte(content), 0644), "Failed to write workflow")
+
+			err := NewCompiler().CompileWorkflow(workflowFile)
+			if tt.errContains != "" {
+				require.Error(t, err, "compilation should fail")
+				assert.Contains(t, err.Error(), tt.errContains, "error should explain the problem")
+				return
+			}
+			require.NoError(t, err, "compilation should succeed")
+
+			lockBytes, err := os.ReadFile(filepath.Join(tmpDir, "consumer.lock.yml"))
+			require.NoError(t, err, "Failed to read lock file")
+			var lock struct {
+				Jobs map[string]struct {
+					Needs any `yaml:"needs"`
+				} `yaml:"jobs"`
+			}
+			require.NoError(t, yaml.Unmarshal(lockBytes, &lock), "lock file should be valid YAML")
+
+			var consumer string
+			for name := range lock.Jobs {
+				if name == "label_issue" || name == "notify" {
+					consumer = name
+				}
+			}
+			require.NotEmpty(t, consumer, "consumer job should be compiled")
+			needs := parseNeedsField(lock.Jobs[consumer].Needs)
+			assert.ElementsMatch(t, tt.wantNeeds, needs, "consumer job needs should include safe_outputs once")
+		})
+	}
+}
+
+func TestWireSafeOutputsConsumerJobs_NoSafeOutputsJob(t *testing.T) {
+	compiler := NewCompiler()
+	compiler.jobManager = NewJobManager()
+	data := &WorkflowData{
+		Jobs: map[string]any{
+			"notify": map[string]any{
+				"steps": []any{map[string]any{"run": "echo ${{ needs.safe_outputs.outputs.created_issue_number }}"}},
+			},
+		},
+	}
+
+	err := compiler.wireSafeOutputsConsumerJobs(data)
+	require.Error(t, err, "references without a safe_outputs job should be rejected")
+	assert.Contains(t, err.Error(), "this workflow has no safe_outputs job", "error should explain the problem")
+}
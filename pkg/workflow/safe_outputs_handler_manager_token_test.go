@@ -394,6 +394,11 @@ func TestHandlerManagerGitHubTokenIsolationAcrossSafeOutputHandlers(t *testing.T
 		"update_project":               true,
 		"create_project_status_update": true,
 		"create_project":               true,
+		"update_project_item":          true,
+	}
+	// create-issue adds issues to its project with the project token, never its own github-token
+	sharedProjectTokenHandlers := map[string]bool{
+		"create_issue": true,
 	}
 
 	handlerNames := make([]string, 0, len(handlerRegistry))
@@ -427,6 +432,8 @@ func TestHandlerManagerGitHubTokenIsolationAcrossSafeOutputHandlers(t *testing.T
 			assert.NotContains(t, yamlStr, "github-token: "+handlerToken)
 			if projectHandlers[handlerName] {
 				assert.Contains(t, yamlStr, "GH_AW_PROJECT_GITHUB_TOKEN: "+handlerToken)
+			} else if sharedProjectTokenHandlers[handlerName] {
+				assert.Contains(t, yamlStr, "GH_AW_PROJECT_GITHUB_TOKEN: "+safeOutputsToken)
 			} else {
 				assert.NotContains(t, yamlStr, "GH_AW_PROJECT_GITHUB_TOKEN:")
 			}
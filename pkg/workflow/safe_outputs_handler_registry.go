@@ -23,6 +23,8 @@ var handlerRegistry = map[string]handlerBuilder{
 			AddStringSlice("labels", c.Labels).
 			AddIfNotEmpty("title_prefix", c.TitlePrefix).
 			AddStringSlice("assignees", c.Assignees).
+			AddIfNotEmpty("milestone", c.Milestone).
+			AddIfNotEmpty("project", c.Project).
 			AddIfNotEmpty("target-repo", c.TargetRepoSlug).
 			AddTemplatableBool("group", c.Group).
 			AddTemplatableBool("close_older_issues", c.CloseOlderIssues).
@@ -33,6 +35,12 @@ var handlerRegistry = map[string]handlerBuilder{
 			AddBoolPtr("normalize_closing_keywords", c.NormalizeClosingKeywords).
 			AddTemplatableBool("staged", templatableBoolPtrToStringPtr(c.Staged)).
 			AddTemplatableBoolOrInt("deduplicate_by_title", c.DeduplicateByTitle)
+		if dedupe := c.Dedupe.handlerConfig(); len(dedupe) > 0 {
+			builder.AddDefault("dedupe", dedupe)
+		}
+		if form := c.IssueForm.handlerConfig(); len(form) > 0 {
+			builder.AddDefault("form", form)
+		}
 		return builder.Build()
 	},
 	"add_comment": func(cfg *SafeOutputsConfig) map[string]any {
@@ -45,6 +53,7 @@ var handlerRegistry = map[string]handlerBuilder{
 			AddIfNotEmpty("target", c.Target).
 			AddTemplatableBool("hide_older_comments", c.HideOlderComments).
 			AddStringSlice("hide_older_comments_match", c.HideOlderCommentsMatch).
+			AddIfNotEmpty("update_if_exists", c.UpdateIfExists).
 			AddBoolPtr("discussions", c.Discussions).
 			AddIfNotEmpty("target-repo", c.TargetRepoSlug).
 			AddTemplatableStringSlice("allowed_repos", c.AllowedRepos).
@@ -144,6 +153,7 @@ var handlerRegistry = map[string]handlerBuilder{
 			AddIfNotEmpty("target", c.Target).
 			AddIfNotEmpty("target-repo", c.TargetRepoSlug).
 			AddStringSlice("allowed_repos", c.AllowedRepos).
+			AddIfTrue("batch", c.Batch).
 			AddStringSlice("required_labels", c.RequiredLabels).
 			AddIfNotEmpty("required_title_prefix", c.RequiredTitlePrefix).
 			AddIfNotEmpty("github-token", c.GitHubToken).
@@ -170,6 +180,7 @@ var handlerRegistry = map[string]handlerBuilder{
 			AddIfNotEmpty("target", c.Target).
 			AddIfNotEmpty("target-repo", c.TargetRepoSlug).
 			AddStringSlice("allowed_repos", c.AllowedRepos).
+			AddIfTrue("batch", c.Batch).
 			AddStringSlice("required_labels", c.RequiredLabels).
 			AddIfNotEmpty("required_title_prefix", c.RequiredTitlePrefix).
 			AddIfNotEmpty("github-token", c.GitHubToken).
@@ -223,6 +234,21 @@ var handlerRegistry = map[string]handlerBuilder{
 			AddTemplatableBool("staged", templatableBoolPtrToStringPtr(c.Staged)).
 			Build()
 	},
+	"request_team_review": func(cfg *SafeOutputsConfig) map[string]any {
+		if cfg.RequestTeamReview == nil {
+			return nil
+		}
+		c := cfg.RequestTeamReview
+		return newHandlerConfigBuilder().
+			AddTemplatableInt("max", c.Max).
+			AddStringSlice("allowed", c.Allowed).
+			AddIfNotEmpty("target", c.Target).AddStringSlice("required_labels", c.RequiredLabels).
+			AddIfNotEmpty("required_title_prefix", c.RequiredTitlePrefix).AddIfNotEmpty("target-repo", c.TargetRepoSlug).
+			AddStringSlice("allowed_repos", c.AllowedRepos).
+			AddIfNotEmpty("github-token", c.GitHubToken).
+			AddTemplatableBool("staged", templatableBoolPtrToStringPtr(c.Staged)).
+			Build()
+	},
 	"assign_milestone": func(cfg *SafeOutputsConfig) map[string]any {
 		if cfg.AssignMilestone == nil {
 			return nil
@@ -312,6 +338,31 @@ var handlerRegistry = map[string]handlerBuilder{
 		}
 		return builder.Build()
 	},
+	"notify": func(cfg *SafeOutputsConfig) map[string]any {
+		if cfg.Notify == nil {
+			return nil
+		}
+		c := cfg.Notify
+		return newHandlerConfigBuilder().
+			AddTemplatableInt("max", c.Max).
+			AddDefault("channels", notifyChannelsHandlerConfig(c)).
+			AddIfNotEmpty("template", c.Template).
+			AddTemplatableBool("staged", templatableBoolPtrToStringPtr(c.Staged)).
+			Build()
+	},
+	"create_commit_status": func(cfg *SafeOutputsConfig) map[string]any {
+		if cfg.CreateCommitStatus == nil {
+			return nil
+		}
+		c := cfg.CreateCommitStatus
+		return newHandlerConfigBuilder().
+			AddTemplatableInt("max", c.Max).
+			AddIfNotEmpty("target", c.Target).
+			AddIfNotEmpty("context", c.Context).
+			AddIfNotEmpty("github-token", c.GitHubToken).
+			AddTemplatableBool("staged", templatableBoolPtrToStringPtr(c.Staged)).
+			Build()
+	},
 	"create_agent_session": func(cfg *SafeOutputsConfig) map[string]any {
 		if cfg.CreateAgentSessions == nil {
 			return nil
@@ -563,6 +614,12 @@ var handlerRegistry = map[string]handlerBuilder{
 		if c.MaxPatchSize > 0 {
 			maxPatchSize = c.MaxPatchSize
 		}
+		// Unlike create-pull-request there is no default file limit; pushes are capped only
+		// when safe-outputs.max-patch-files or the handler's own max-patch-files is set.
+		maxPatchFiles := cfg.MaximumPatchFiles
+		if c.MaxPatchFiles > 0 {
+			maxPatchFiles = c.MaxPatchFiles
+		}
 		builder := newHandlerConfigBuilder().
 			AddTemplatableInt("max", c.Max).
 			AddIfNotEmpty("target", c.Target).
@@ -572,6 +629,7 @@ var handlerRegistry = map[string]handlerBuilder{
 			AddIfTrue("ignore_missing_branch_failure", c.IgnoreMissingBranchFailure).
 			AddIfNotEmpty("commit_title_suffix", c.CommitTitleSuffix).
 			AddDefault("max_patch_size", maxPatchSize).
+			AddIfPositive("max_patch_files", maxPatchFiles).
 			AddIfNotEmpty("target-repo", c.TargetRepoSlug).
 			AddIfNotEmpty("head-repo", c.HeadRepoSlug).
 			AddIfNotEmpty("base_branch", c.BaseBranch).
@@ -867,6 +925,21 @@ var handlerRegistry = map[string]handlerBuilder{
 		}
 		return b.Build()
 	},
+	"create_artifact": func(cfg *SafeOutputsConfig) map[string]any {
+		if cfg.CreateArtifact == nil {
+			return nil
+		}
+		c := cfg.CreateArtifact
+		b := newHandlerConfigBuilder().
+			AddTemplatableInt("max", c.Max).
+			AddTemplatableInt("retention-days", c.RetentionDays).
+			AddIfNotEmpty("github-token", c.GitHubToken).
+			AddTemplatableBool("staged", templatableBoolPtrToStringPtr(c.Staged))
+		if c.MaxSizeBytes > 0 {
+			b = b.AddDefault("max-size-bytes", c.MaxSizeBytes)
+		}
+		return b.Build()
+	},
 	"autofix_code_scanning_alert": func(cfg *SafeOutputsConfig) map[string]any {
 		if cfg.AutofixCodeScanningAlert == nil {
 			return nil
@@ -878,7 +951,7 @@ var handlerRegistry = map[string]handlerBuilder{
 			AddTemplatableBool("staged", templatableBoolPtrToStringPtr(c.Staged)).
 			Build()
 	},
-	// Note: create_project, update_project and create_project_status_update are handled by the unified handler,
+	// Note: create_project, update_project, create_project_status_update and update_project_item are handled by the unified handler,
 	// not the separate project handler manager, so they are included in this registry.
 	"create_project": func(cfg *SafeOutputsConfig) map[string]any {
 		if cfg.CreateProjects == nil {
@@ -955,6 +1028,40 @@ var handlerRegistry = map[string]handlerBuilder{
 			AddTemplatableBool("staged", templatableBoolPtrToStringPtr(c.Staged)).
 			Build()
 	},
+	"lock_issue": func(cfg *SafeOutputsConfig) map[string]any {
+		if cfg.LockIssue == nil {
+			return nil
+		}
+		c := cfg.LockIssue
+		return newHandlerConfigBuilder().
+			AddTemplatableInt("max", c.Max).
+			AddStringSlice("allowed_reasons", c.AllowedReasons).
+			AddIfNotEmpty("target", c.Target).
+			AddStringSlice("required_labels", c.RequiredLabels).
+			AddIfNotEmpty("required_title_prefix", c.RequiredTitlePrefix).
+			AddIfNotEmpty("target-repo", c.TargetRepoSlug).
+			AddStringSlice("allowed_repos", c.AllowedRepos).
+			AddIfNotEmpty("github-token", c.GitHubToken).
+			AddTemplatableBool("staged", templatableBoolPtrToStringPtr(c.Staged)).
+			Build()
+	},
+	"lock_pull_request": func(cfg *SafeOutputsConfig) map[string]any {
+		if cfg.LockPullRequest == nil {
+			return nil
+		}
+		c := cfg.LockPullRequest
+		return newHandlerConfigBuilder().
+			AddTemplatableInt("max", c.Max).
+			AddStringSlice("allowed_reasons", c.AllowedReasons).
+			AddIfNotEmpty("target", c.Target).
+			AddStringSlice("required_labels", c.RequiredLabels).
+			AddIfNotEmpty("required_title_prefix", c.RequiredTitlePrefix).
+			AddIfNotEmpty("target-repo", c.TargetRepoSlug).
+			AddStringSlice("allowed_repos", c.AllowedRepos).
+			AddIfNotEmpty("github-token", c.GitHubToken).
+			AddTemplatableBool("staged", templatableBoolPtrToStringPtr(c.Staged)).
+			Build()
+	},
 	"create_project_status_update": func(cfg *SafeOutputsConfig) map[string]any {
 		if cfg.CreateProjectStatusUpdates == nil {
 			return nil
@@ -967,6 +1074,20 @@ var handlerRegistry = map[string]handlerBuilder{
 			AddTemplatableBool("staged", templatableBoolPtrToStringPtr(c.Staged)).
 			Build()
 	},
+	"update_project_item": func(cfg *SafeOutputsConfig) map[string]any {
+		if cfg.UpdateProjectItems == nil {
+			return nil
+		}
+		c := cfg.UpdateProjectItems
+		return newHandlerConfigBuilder().
+			AddTemplatableInt("max", c.Max).
+			AddIfNotEmpty("github-token", c.GitHubToken).
+			AddIfNotEmpty("project", c.Project).
+			AddIfNotEmpty("target-repo", c.TargetRepoSlug).
+			AddStringSlice("allowed_fields", c.AllowedFields).
+			AddTemplatableBool("staged", templatableBoolPtrToStringPtr(c.Staged)).
+			Build()
+	},
 	"set_issue_type": func(cfg *SafeOutputsConfig) map[string]any {
 		if cfg.SetIssueType == nil {
 			return nil
@@ -0,0 +1,115 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSafeOutputsMaxTotal(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "safe-outputs-max-total-test")
+
+	workflow := `---
+on:
+  issues:
+    types: [opened]
+  status-comment: true
+engine: copilot
+permissions:
+  contents: read
+safe-outputs:
+  max-total: 10
+  priority: [create-pull-request, create-issue, add-comment]
+  create-issue:
+    max: 5
+  add-comment:
+    max: 10
+  create-pull-request:
+---
+
+# Test Workflow
+
+Triage the issue.
+`
+	testFile := filepath.Join(tmpDir, "max-total.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(workflow), 0644), "Failed to write test workflow")
+
+	compiler := NewCompiler(WithVersion("1.0.0"))
+	workflowData, err := compiler.ParseWorkflowFile(testFile)
+	require.NoError(t, err, "Failed to parse workflow")
+	require.NotNil(t, workflowData.SafeOutputs, "SafeOutputs should not be nil")
+	assert.Equal(t, 10, workflowData.SafeOutputs.MaxTotal, "max-total should be parsed")
+	assert.Equal(t, []string{"create-pull-request", "create-issue", "add-comment"}, workflowData.SafeOutputs.Priority, "priority should be parsed")
+
+	require.NoError(t, compiler.CompileWorkflow(testFile), "Failed to compile workflow")
+	lockContent, err := os.ReadFile(filepath.Join(tmpDir, "max-total.lock.yml"))
+	require.NoError(t, err, "Failed to read lock file")
+	lock := string(lockContent)
+
+	assert.Contains(t, lock, `\"max_total\":10`, "handler config should include max_total")
+	assert.Contains(t, lock, `\"priority\":[\"create_pull_request\",\"create_issue\",\"add_comment\"]`, "handler config should include normalized priority")
+	assert.Contains(t, lock, "dropped_outputs: ${{ steps.process_safe_outputs.outputs.dropped_outputs }}", "safe_outputs job should export dropped outputs")
+	assert.Contains(t, lock, "GH_AW_DROPPED_OUTPUTS: ${{ needs.safe_outputs.outputs.dropped_outputs }}", "conclusion comment should receive dropped outputs")
+	assert.Contains(t, lock, "GH_AW_DROPPED_OUTPUT_COUNT: ${{ needs.safe_outputs.outputs.dropped_output_count }}", "conclusion comment should receive dropped output count")
+}
+
+func TestValidateSafeOutputsPriority(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *SafeOutputsConfig
+		wantErr string
+	}{
+		{
+			name:   "nil config",
+			config: nil,
+		},
+		{
+			name:   "no priority",
+			config: &SafeOutputsConfig{MaxTotal: 5},
+		},
+		{
+			name:   "known types",
+			config: &SafeOutputsConfig{MaxTotal: 5, Priority: []string{"create-pull-request", "add_comment"}},
+		},
+		{
+			name: "custom script",
+			config: &SafeOutputsConfig{
+				MaxTotal: 5,
+				Priority: []string{"post-summary"},
+				Scripts:  map[string]*SafeScriptConfig{"post-summary": {}},
+			},
+		},
+		{
+			name:    "priority without max-total",
+			config:  &SafeOutputsConfig{Priority: []string{"create-issue"}},
+			wantErr: "safe-outputs.priority requires safe-outputs.max-total",
+		},
+		{
+			name:    "unknown type",
+			config:  &SafeOutputsConfig{MaxTotal: 5, Priority: []string{"create-isue"}},
+			wantErr: `safe-outputs.priority: unknown safe output type "create-isue"`,
+		},
+		{
+			name:    "duplicate type",
+			config:  &SafeOutputsConfig{MaxTotal: 5, Priority: []string{"create-issue", "create_issue"}},
+			wantErr: `safe-outputs.priority: "create_issue" is listed more than once`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSafeOutputsPriority(tt.config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err, "priority should be valid")
+				return
+			}
+			require.Error(t, err, "priority should be invalid")
+			assert.Contains(t, err.Error(), tt.wantErr, "error message")
+		})
+	}
+}
@@ -1,12 +1,14 @@
 package workflow
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
 
 	"github.com/github/gh-aw/pkg/logger"
 	"github.com/github/gh-aw/pkg/sliceutil"
+	"github.com/github/gh-aw/pkg/stringutil"
 )
 
 var safeOutputsMaxValidationLog = logger.New("workflow:safe_outputs_max_validation")
@@ -81,6 +83,11 @@ func validateSafeOutputsMax(config *SafeOutputsConfig) error {
 			return err
 		}
 	}
+	if config.RequestTeamReview != nil {
+		if err := checkMaxField("request_team_review", config.RequestTeamReview.Max); err != nil {
+			return err
+		}
+	}
 	if config.AssignMilestone != nil {
 		if err := checkMaxField("assign_milestone", config.AssignMilestone.Max); err != nil {
 			return err
@@ -131,6 +138,16 @@ func validateSafeOutputsMax(config *SafeOutputsConfig) error {
 			return err
 		}
 	}
+	if config.CreateCommitStatus != nil {
+		if err := checkMaxField("create_commit_status", config.CreateCommitStatus.Max); err != nil {
+			return err
+		}
+	}
+	if config.Notify != nil {
+		if err := checkMaxField("notify", config.Notify.Max); err != nil {
+			return err
+		}
+	}
 	if config.CreateCodeScanningAlerts != nil {
 		if err := checkMaxField("create_code_scanning_alert", config.CreateCodeScanningAlerts.Max); err != nil {
 			return err
@@ -256,6 +273,16 @@ func validateSafeOutputsMax(config *SafeOutputsConfig) error {
 			return err
 		}
 	}
+	if config.LockIssue != nil {
+		if err := checkMaxField("lock_issue", config.LockIssue.Max); err != nil {
+			return err
+		}
+	}
+	if config.LockPullRequest != nil {
+		if err := checkMaxField("lock_pull_request", config.LockPullRequest.Max); err != nil {
+			return err
+		}
+	}
 	if config.UpdateDiscussions != nil {
 		if err := checkMaxField("update_discussion", config.UpdateDiscussions.Max); err != nil {
 			return err
@@ -271,6 +298,11 @@ func validateSafeOutputsMax(config *SafeOutputsConfig) error {
 			return err
 		}
 	}
+	if config.UpdateProjectItems != nil {
+		if err := checkMaxField("update_project_item", config.UpdateProjectItems.Max); err != nil {
+			return err
+		}
+	}
 	if config.UpdatePullRequests != nil {
 		if err := checkMaxField("update_pull_request", config.UpdatePullRequests.Max); err != nil {
 			return err
@@ -286,6 +318,11 @@ func validateSafeOutputsMax(config *SafeOutputsConfig) error {
 			return err
 		}
 	}
+	if config.CreateArtifact != nil {
+		if err := checkMaxField("create_artifact", config.CreateArtifact.Max); err != nil {
+			return err
+		}
+	}
 	if config.UploadAssets != nil {
 		if err := checkMaxField("upload_asset", config.UploadAssets.Max); err != nil {
 			return err
@@ -321,3 +358,37 @@ func validateSafeOutputsMax(config *SafeOutputsConfig) error {
 	safeOutputsMaxValidationLog.Print("Safe-outputs max fields validation passed")
 	return nil
 }
+
+// validateSafeOutputsPriority validates the safe-outputs.priority list used to decide which
+// outputs are kept when safe-outputs.max-total is exceeded. Every entry must name a safe output
+// type handled by the safe-outputs handler manager (or a custom script), and may appear only once.
+func validateSafeOutputsPriority(config *SafeOutputsConfig) error {
+	if config == nil || len(config.Priority) == 0 {
+		return nil
+	}
+	if config.MaxTotal == 0 {
+		return errors.New("safe-outputs.priority requires safe-outputs.max-total\n\nThe priority list only decides which outputs are kept when more than max-total are emitted. Example:\nsafe-outputs:\n  max-total: 10\n  priority: [create-pull-request, create-issue, add-comment]")
+	}
+
+	safeOutputsMaxValidationLog.Printf("Validating safe-outputs priority with %d type(s)", len(config.Priority))
+	seen := make(map[string]bool, len(config.Priority))
+	for _, outputType := range config.Priority {
+		normalized := stringutil.NormalizeSafeOutputIdentifier(outputType)
+		_, isHandler := handlerRegistry[normalized]
+		isScript := false
+		for scriptName := range config.Scripts {
+			if stringutil.NormalizeSafeOutputIdentifier(scriptName) == normalized {
+				isScript = true
+				break
+			}
+		}
+		if !isHandler && !isScript {
+			return fmt.Errorf("safe-outputs.priority: unknown safe output type %q\n\nList safe output types such as create-issue, add-comment, or create-pull-request", outputType)
+		}
+		if seen[normalized] {
+			return fmt.Errorf("safe-outputs.priority: %q is listed more than once", outputType)
+		}
+		seen[normalized] = true
+	}
+	return nil
+}
@@ -0,0 +1,540 @@
+package workflow
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var safeOutputsOutputValidationLog = logger.New("workflow:safe_outputs_output_validation")
+
+// ========================================
+// Local Agent Output Validation
+// ========================================
+//
+// This file mirrors the validation performed by the safe-outputs ingestion step
+// (collect_ndjson_output.cjs and safe_output_type_validator.cjs) so that agent
+// output can be checked locally against a workflow's configuration. It uses the
+// same sources of truth: ValidationConfig for field rules and the config.json
+// produced by generateSafeOutputsConfig for enabled types, max counts, and
+// allowed repositories. Sanitization is not reproduced; only accept/reject
+// decisions are reported.
+
+// maxSafeOutputLineSize bounds a single line of agent output read by the validator.
+const maxSafeOutputLineSize = 16 * 1024 * 1024
+
+var (
+	safeOutputTemporaryIDPattern = regexp.MustCompile(`(?i)^#?aw_[A-Za-z0-9_]{3,12}$`)
+	jsLeadingIntegerPattern      = regexp.MustCompile(`^\s*[+-]?\d+`)
+)
+
+// SafeOutputLineResult reports whether one line of agent output would be accepted.
+type SafeOutputLineResult struct {
+	Line     int    `json:"line"`
+	Type     string `json:"type,omitempty"`
+	Accepted bool   `json:"accepted"`
+	Error    string `json:"error,omitempty"`
+}
+
+// SafeOutputValidationReport is the result of validating agent output against a workflow.
+type SafeOutputValidationReport struct {
+	Lines []SafeOutputLineResult `json:"lines"`
+}
+
+// Accepted returns the number of accepted lines.
+func (r *SafeOutputValidationReport) Accepted() int {
+	count := 0
+	for _, line := range r.Lines {
+		if line.Accepted {
+			count++
+		}
+	}
+	return count
+}
+
+// Rejected returns the number of rejected lines.
+func (r *SafeOutputValidationReport) Rejected() int {
+	return len(r.Lines) - r.Accepted()
+}
+
+// OK reports whether every line was accepted.
+func (r *SafeOutputValidationReport) OK() bool {
+	return r.Rejected() == 0
+}
+
+// ValidateSafeOutputJSONL validates agent output (one JSON object per line, as
+// written to safe_output.jsonl) against the workflow's safe-outputs configuration.
+// currentRepo is the repository the workflow runs in ("owner/repo"); it is the
+// default target for repo checks and may be empty to skip them for handlers
+// without a target-repo.
+func ValidateSafeOutputJSONL(data *WorkflowData, r io.Reader, currentRepo string) (*SafeOutputValidationReport, error) {
+	configJSON, err := generateSafeOutputsConfig(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate safe-outputs configuration: %w", err)
+	}
+	if configJSON == "" {
+		return nil, errors.New("workflow has no safe-outputs configuration")
+	}
+	var rawConfig map[string]any
+	if err := json.Unmarshal([]byte(configJSON), &rawConfig); err != nil {
+		return nil, fmt.Errorf("failed to parse safe-outputs configuration: %w", err)
+	}
+	// Normalize keys the same way the ingestion step does.
+	expected := make(map[string]any, len(rawConfig))
+	for key, value := range rawConfig {
+		expected[strings.ReplaceAll(key, "-", "_")] = value
+	}
+	delete(expected, "max_bot_mentions")
+	expectedTypes := make([]string, 0, len(expected))
+	for key := range expected {
+		expectedTypes = append(expectedTypes, key)
+	}
+	slices.Sort(expectedTypes)
+
+	report := &SafeOutputValidationReport{Lines: []SafeOutputLineResult{}}
+	counts := make(map[string]int)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxSafeOutputLineSize)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		result := SafeOutputLineResult{Line: lineNum}
+		itemType, errMsg := validateSafeOutputLine(line, expected, expectedTypes, counts, currentRepo)
+		result.Type = itemType
+		if errMsg != "" {
+			result.Error = errMsg
+		} else {
+			result.Accepted = true
+			counts[itemType]++
+		}
+		report.Lines = append(report.Lines, result)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read agent output: %w", err)
+	}
+
+	safeOutputsOutputValidationLog.Printf("Validated %d line(s): %d accepted, %d rejected", len(report.Lines), report.Accepted(), report.Rejected())
+	return report, nil
+}
+
+// validateSafeOutputLine validates a single non-empty line and returns the
+// normalized item type and an error message when the line is rejected.
+func validateSafeOutputLine(line string, expected map[string]any, expectedTypes []string, counts map[string]int, currentRepo string) (string, string) {
+	var item map[string]any
+	if err := json.Unmarshal([]byte(line), &item); err != nil {
+		return "", "Invalid JSON - " + err.Error()
+	}
+	rawType, _ := item["type"].(string)
+	if rawType == "" {
+		return "", "Missing required 'type' field"
+	}
+	itemType := strings.ReplaceAll(rawType, "-", "_")
+	typeConfig, ok := expected[itemType]
+	if !ok {
+		return itemType, fmt.Sprintf("Unexpected output type '%s'. Expected one of: %s", itemType, strings.Join(expectedTypes, ", "))
+	}
+
+	maxAllowed := safeOutputConfigCount(typeConfig, "max")
+	if maxAllowed <= 0 {
+		maxAllowed = 1
+		if validation, ok := ValidationConfig[itemType]; ok {
+			maxAllowed = validation.DefaultMax
+		}
+	}
+	if counts[itemType] >= maxAllowed {
+		return itemType, fmt.Sprintf("Too many items of type '%s'. Maximum allowed: %d.", itemType, maxAllowed)
+	}
+
+	if validation, ok := ValidationConfig[itemType]; ok {
+		if errMsg := validateSafeOutputItem(item, itemType, validation); errMsg != "" {
+			return itemType, errMsg
+		}
+	} else if errMsg := validateSafeOutputItemInputs(item, typeConfig); errMsg != "" {
+		return itemType, errMsg
+	}
+
+	if repo, ok := item["repo"].(string); ok && strings.TrimSpace(repo) != "" {
+		if errMsg := validateSafeOutputTargetRepo(strings.TrimSpace(repo), typeConfig, currentRepo); errMsg != "" {
+			return itemType, errMsg
+		}
+	}
+	return itemType, ""
+}
+
+// safeOutputConfigCount reads a numeric max/min value from a handler config.
+// Values that are not plain numbers (e.g. unresolved expressions) return 0.
+func safeOutputConfigCount(typeConfig any, key string) int {
+	cfg, ok := typeConfig.(map[string]any)
+	if !ok {
+		return 0
+	}
+	switch v := cfg[key].(type) {
+	case float64:
+		return int(v)
+	case string:
+		n, err := strconv.Atoi(strings.TrimSpace(v))
+		if err != nil {
+			return 0
+		}
+		return n
+	}
+	return 0
+}
+
+// validateSafeOutputItem applies the ValidationConfig rules for a built-in type.
+func validateSafeOutputItem(item map[string]any, itemType string, validation TypeValidationConfig) string {
+	if errMsg := validateSafeOutputCustomRule(item, itemType, validation.CustomValidation); errMsg != "" {
+		return errMsg
+	}
+	fieldNames := make([]string, 0, len(validation.Fields))
+	for name := range validation.Fields {
+		fieldNames = append(fieldNames, name)
+	}
+	slices.Sort(fieldNames)
+	for _, name := range fieldNames {
+		field := validation.Fields[name]
+		errMsg := validateSafeOutputField(item[name], name, field, itemType)
+		if errMsg != "" && !(field.StripOnError && !field.Required) {
+			return errMsg
+		}
+	}
+	return ""
+}
+
+// validateSafeOutputField mirrors validateField in safe_output_type_validator.cjs.
+func validateSafeOutputField(value any, fieldName string, field FieldValidation, itemType string) string {
+	label := fmt.Sprintf("%s '%s'", itemType, fieldName)
+	if field.PositiveInteger {
+		return validateSafeOutputPositiveInteger(value, label, true)
+	}
+	if field.IssueNumberOrTemporaryID {
+		if value == nil {
+			if field.Required {
+				return label + " is required"
+			}
+			return ""
+		}
+		if s, ok := value.(string); ok && safeOutputTemporaryIDPattern.MatchString(s) {
+			return ""
+		}
+		if errMsg := validateSafeOutputPositiveInteger(value, label, true); errMsg != "" {
+			return fmt.Sprintf("%s must be a positive integer or temporary ID (got: %v)", label, value)
+		}
+		return ""
+	}
+	if value == nil {
+		if field.Required {
+			fieldType := field.TypeHint
+			if fieldType == "" {
+				fieldType = field.Type
+			}
+			if fieldType == "" {
+				fieldType = "string"
+			}
+			return fmt.Sprintf("%s requires a '%s' field (%s)", itemType, fieldName, fieldType)
+		}
+		return ""
+	}
+	if field.OptionalPositiveInteger {
+		return validateSafeOutputPositiveInteger(value, label, false)
+	}
+	if field.IssueOrPRNumber {
+		switch value.(type) {
+		case float64, string:
+			return ""
+		}
+		return label + " must be a number or string"
+	}
+
+	switch field.Type {
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			if field.Required {
+				fieldType := field.TypeHint
+				if fieldType == "" {
+					fieldType = "string"
+				}
+				return fmt.Sprintf("%s requires a '%s' field (%s)", itemType, fieldName, fieldType)
+			}
+			return label + " must be a string"
+		}
+		if field.Pattern != "" {
+			if re, err := regexp.Compile(field.Pattern); err == nil && !re.MatchString(strings.TrimSpace(s)) {
+				errMsg := field.PatternError
+				if errMsg == "" {
+					errMsg = "must match pattern " + field.Pattern
+				}
+				return label + " " + errMsg
+			}
+		}
+		if len(field.Enum) > 0 {
+			if !slices.ContainsFunc(field.Enum, func(e string) bool { return strings.EqualFold(e, s) }) {
+				if len(field.Enum) == 2 {
+					return fmt.Sprintf("%s must be '%s' or '%s'", label, field.Enum[0], field.Enum[1])
+				}
+				return fmt.Sprintf("%s must be one of: %s", label, strings.Join(field.Enum, ", "))
+			}
+			return ""
+		}
+		if field.MinLength > 0 && len([]rune(strings.TrimSpace(s))) < field.MinLength {
+			return fmt.Sprintf("%s is too short (minimum %d characters)", label, field.MinLength)
+		}
+	case "array":
+		// create_issue accepts comma-separated labels as a string.
+		if s, ok := value.(string); ok && itemType == "create_issue" && fieldName == "labels" {
+			labels := make([]any, 0)
+			for label := range strings.SplitSeq(s, ",") {
+				labels = append(labels, strings.TrimSpace(label))
+			}
+			value = labels
+		}
+		items, ok := value.([]any)
+		if !ok {
+			if field.Required {
+				return fmt.Sprintf("%s requires a '%s' field (array)", itemType, fieldName)
+			}
+			return label + " must be an array"
+		}
+		if field.ItemType == "string" {
+			for _, element := range items {
+				if _, ok := element.(string); !ok {
+					return fmt.Sprintf("%s %s array must contain only strings", itemType, fieldName)
+				}
+			}
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return label + " must be a boolean"
+		}
+	case "number":
+		if _, ok := value.(float64); !ok {
+			return label + " must be a number"
+		}
+	}
+	return ""
+}
+
+// validateSafeOutputPositiveInteger accepts numbers and numeric strings, using
+// JavaScript parseInt semantics for strings.
+func validateSafeOutputPositiveInteger(value any, label string, required bool) string {
+	if value == nil {
+		if required {
+			return label + " is required"
+		}
+		return ""
+	}
+	var parsed float64
+	switch v := value.(type) {
+	case float64:
+		parsed = v
+	case string:
+		digits := jsLeadingIntegerPattern.FindString(v)
+		n, err := strconv.Atoi(strings.TrimSpace(digits))
+		if err != nil {
+			return fmt.Sprintf("%s must be a valid positive integer (got: %v)", label, value)
+		}
+		parsed = float64(n)
+	default:
+		return label + " must be a number or string"
+	}
+	if parsed <= 0 || parsed != float64(int64(parsed)) {
+		return fmt.Sprintf("%s must be a valid positive integer (got: %v)", label, value)
+	}
+	return ""
+}
+
+// validateSafeOutputCustomRule mirrors executeCustomValidation.
+func validateSafeOutputCustomRule(item map[string]any, itemType, rule string) string {
+	switch {
+	case rule == "":
+		return ""
+	case strings.HasPrefix(rule, "requiresOneOf:"):
+		fields := strings.Split(strings.TrimPrefix(rule, "requiresOneOf:"), ",")
+		for _, field := range fields {
+			if value, ok := item[field]; ok && value != false {
+				return ""
+			}
+		}
+		quoted := make([]string, len(fields))
+		for i, field := range fields {
+			quoted[i] = "'" + field + "'"
+		}
+		return fmt.Sprintf("%s requires at least one of: %s fields", itemType, strings.Join(quoted, ", "))
+	case rule == "startLineLessOrEqualLine":
+		start, startOK := safeOutputNumber(item["start_line"])
+		end, endOK := safeOutputNumber(item["line"])
+		if startOK && endOK && start > end {
+			return itemType + " 'start_line' must be less than or equal to 'line'"
+		}
+	case rule == "parentAndSubDifferent":
+		normalize := func(v any) any {
+			if s, ok := v.(string); ok {
+				return strings.ToLower(s)
+			}
+			return v
+		}
+		if normalize(item["parent_issue_number"]) == normalize(item["sub_issue_number"]) {
+			return itemType + " 'parent_issue_number' and 'sub_issue_number' must be different"
+		}
+	}
+	return ""
+}
+
+func safeOutputNumber(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case string:
+		n, err := strconv.Atoi(strings.TrimSpace(jsLeadingIntegerPattern.FindString(v)))
+		return float64(n), err == nil
+	}
+	return 0, false
+}
+
+// validateSafeOutputItemInputs validates safe-job and safe-script items against
+// their declared inputs, mirroring validateItemWithSafeJobConfig.
+func validateSafeOutputItemInputs(item map[string]any, typeConfig any) string {
+	cfg, ok := typeConfig.(map[string]any)
+	if !ok {
+		return ""
+	}
+	inputs, ok := cfg["inputs"].(map[string]any)
+	if !ok {
+		return ""
+	}
+	names := make([]string, 0, len(inputs))
+	for name := range inputs {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		schema, _ := inputs[name].(map[string]any)
+		value, present := item[name]
+		if !present || value == nil {
+			if required, _ := schema["required"].(bool); required {
+				return name + " is required"
+			}
+			continue
+		}
+		inputType, _ := schema["type"].(string)
+		switch inputType {
+		case "", "string":
+			if _, ok := value.(string); !ok {
+				return name + " must be a string"
+			}
+		case "boolean":
+			if _, ok := value.(bool); !ok {
+				return name + " must be a boolean"
+			}
+		case "number":
+			if _, ok := value.(float64); !ok {
+				return name + " must be a number"
+			}
+		case "choice":
+			s, ok := value.(string)
+			if !ok {
+				return name + " must be a string for choice type"
+			}
+			if options, ok := schema["options"].([]any); ok && len(options) > 0 {
+				allowed := make([]string, 0, len(options))
+				for _, option := range options {
+					allowed = append(allowed, fmt.Sprint(option))
+				}
+				if !slices.Contains(allowed, s) {
+					return fmt.Sprintf("%s must be one of: %s", name, strings.Join(allowed, ", "))
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// validateSafeOutputTargetRepo mirrors validateRepo in repo_helpers.cjs using the
+// handler's target-repo (or the current repository) and allowed_repos.
+func validateSafeOutputTargetRepo(repo string, typeConfig any, currentRepo string) string {
+	cfg, _ := typeConfig.(map[string]any)
+	defaultRepo := currentRepo
+	if target, ok := cfg["target-repo"].(string); ok && strings.TrimSpace(target) != "" {
+		defaultRepo = strings.TrimSpace(target)
+	}
+	if defaultRepo == "" || strings.Contains(defaultRepo, "${{") {
+		return ""
+	}
+	var allowed []string
+	switch v := cfg["allowed_repos"].(type) {
+	case []any:
+		for _, entry := range v {
+			if s := strings.TrimSpace(fmt.Sprint(entry)); s != "" {
+				allowed = append(allowed, s)
+			}
+		}
+	case string:
+		for entry := range strings.SplitSeq(v, ",") {
+			if s := strings.TrimSpace(entry); s != "" {
+				allowed = append(allowed, s)
+			}
+		}
+	}
+
+	qualified := repo
+	if !strings.Contains(repo, "/") {
+		if owner, _, ok := strings.Cut(defaultRepo, "/"); ok && !strings.Contains(owner, "*") {
+			qualified = owner + "/" + repo
+		}
+	}
+	validSlug := func(slug string) bool {
+		owner, name, ok := strings.Cut(slug, "/")
+		return ok && owner != "" && name != "" && !strings.Contains(name, "/") && !strings.Contains(slug, "*")
+	}
+	if defaultRepo == "*" {
+		if !validSlug(qualified) {
+			return fmt.Sprintf("Repository '%s' is not a valid 'owner/repo' slug.", repo)
+		}
+		return ""
+	}
+	if defaultRepo != "*" && strings.Contains(defaultRepo, "*") {
+		if !validSlug(qualified) {
+			return fmt.Sprintf("Repository '%s' is not a valid 'owner/repo' slug.", repo)
+		}
+		if safeOutputRepoAllowed(qualified, append([]string{defaultRepo}, allowed...)) {
+			return ""
+		}
+	}
+	if qualified == defaultRepo || safeOutputRepoAllowed(qualified, allowed) {
+		return ""
+	}
+	allowedList := defaultRepo
+	if len(allowed) > 0 {
+		allowedList += ", " + strings.Join(allowed, ", ")
+	}
+	return fmt.Sprintf("Repository '%s' is not in the allowed-repos list. Allowed: %s", repo, allowedList)
+}
+
+func safeOutputRepoAllowed(repo string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if pattern == "*" || pattern == repo {
+			return true
+		}
+		if strings.Contains(pattern, "*") {
+			if matched, _ := path.Match(pattern, repo); matched {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,107 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parseSafeOutputValidationWorkflow(t *testing.T, frontmatter string) *WorkflowData {
+	t.Helper()
+	tmpDir := testutil.TempDir(t, "safe-output-validation-test")
+	testFile := filepath.Join(tmpDir, "validate.md")
+	content := "---\non: issues\nengine: copilot\npermissions:\n  contents: read\n" + frontmatter + "---\n\n# Test\n\nDo the work.\n"
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Failed to write test workflow")
+
+	data, err := NewCompiler(WithVersion("1.0.0")).ParseWorkflowFile(testFile)
+	require.NoError(t, err, "Failed to parse workflow")
+	return data
+}
+
+func TestValidateSafeOutputJSONL(t *testing.T) {
+	data := parseSafeOutputValidationWorkflow(t, `safe-outputs:
+  create-issue:
+    max: 2
+    allowed-repos: [other/repo]
+  add-labels:
+    allowed: [bug]
+`)
+	body := "this body is long enough to be accepted"
+	output := strings.Join([]string{
+		`{"type":"create_issue","title":"A","body":"` + body + `"}`,
+		"",
+		`{"type":"create-issue","title":"B","body":"` + body + `","repo":"evil/repo"}`,
+		`{"type":"create_issue","title":"C","body":"` + body + `","repo":"other/repo"}`,
+		`{"type":"create_issue","title":"D","body":"` + body + `"}`,
+		`{"type":"create_issue","body":"` + body + `"}`,
+		`{"type":"push_to_pull_request_branch"}`,
+		`{"title":"no type"}`,
+		`not json`,
+		`{"type":"add_labels","labels":["bug"]}`,
+		`{"type":"missing_tool","tool":"x","reason":"needed"}`,
+	}, "\n")
+
+	report, err := ValidateSafeOutputJSONL(data, strings.NewReader(output), "me/repo")
+	require.NoError(t, err, "validation should run")
+	require.Len(t, report.Lines, 10, "empty lines should be skipped")
+
+	byLine := make(map[int]SafeOutputLineResult)
+	for _, line := range report.Lines {
+		byLine[line.Line] = line
+	}
+	assert.True(t, byLine[1].Accepted, "first create_issue should be accepted")
+	assert.Equal(t, "create_issue", byLine[3].Type, "dashes in the type should be normalized")
+	assert.Contains(t, byLine[3].Error, "Repository 'evil/repo' is not in the allowed-repos list", "repo outside allowed-repos should be rejected")
+	assert.True(t, byLine[4].Accepted, "allowed repo should be accepted")
+	assert.Contains(t, byLine[5].Error, "Too many items of type 'create_issue'. Maximum allowed: 2.", "max should be enforced")
+	assert.Contains(t, byLine[6].Error, "Too many items", "max is checked before fields")
+	assert.Contains(t, byLine[7].Error, "Unexpected output type 'push_to_pull_request_branch'", "disabled type should be rejected")
+	assert.Equal(t, "Missing required 'type' field", byLine[8].Error, "missing type should be rejected")
+	assert.Contains(t, byLine[9].Error, "Invalid JSON", "invalid JSON should be rejected")
+	assert.True(t, byLine[10].Accepted, "add_labels should be accepted: %s", byLine[10].Error)
+	assert.True(t, byLine[11].Accepted, "missing_tool should be accepted: %s", byLine[11].Error)
+
+	assert.Equal(t, 4, report.Accepted(), "accepted count")
+	assert.Equal(t, 6, report.Rejected(), "rejected count")
+	assert.False(t, report.OK(), "report with rejected lines is not OK")
+}
+
+func TestValidateSafeOutputJSONLFieldRules(t *testing.T) {
+	data := parseSafeOutputValidationWorkflow(t, `safe-outputs:
+  create-issue:
+  add-comment:
+    max: 3
+`)
+	tests := []struct {
+		name    string
+		line    string
+		wantErr string
+	}{
+		{name: "missing title", line: `{"type":"create_issue","body":"this body is long enough to pass"}`, wantErr: "create_issue requires a 'title' field"},
+		{name: "short body", line: `{"type":"create_issue","title":"t","body":"short"}`, wantErr: "too short"},
+		{name: "labels as comma string", line: `{"type":"create_issue","title":"t","body":"this body is long enough to pass","labels":"a, b"}`},
+		{name: "labels wrong type", line: `{"type":"create_issue","title":"t","body":"this body is long enough to pass","labels":3}`, wantErr: "must be an array"},
+		{name: "comment with temporary id", line: `{"type":"add_comment","body":"hello","item_number":"aw_abc123"}`},
+		{name: "comment with bad number", line: `{"type":"add_comment","body":"hello","item_number":true}`, wantErr: "'item_number' must be a number or string"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := ValidateSafeOutputJSONL(data, strings.NewReader(tt.line), "")
+			require.NoError(t, err, "validation should run")
+			require.Len(t, report.Lines, 1, "one line should be reported")
+			if tt.wantErr == "" {
+				assert.True(t, report.Lines[0].Accepted, "line should be accepted: %s", report.Lines[0].Error)
+				return
+			}
+			assert.False(t, report.Lines[0].Accepted, "line should be rejected")
+			assert.Contains(t, report.Lines[0].Error, tt.wantErr, "rejection reason")
+		})
+	}
+}
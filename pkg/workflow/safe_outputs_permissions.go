@@ -1,6 +1,7 @@
 package workflow
 
 import (
+	"maps"
 	"slices"
 	"strings"
 
@@ -76,6 +77,8 @@ func getCheckBranchProtection(config *PushToPullRequestBranchConfig) bool {
 // permissions that are required by the configured safe outputs.
 // Handlers that are staged (globally or per-handler) are skipped because
 // staged mode only emits preview output and does not make any API calls.
+// Every scope is granted through Permissions.Require, so a scope exists only while
+// a configured feature needs it and the lock file names that feature in a comment.
 func ComputePermissionsForSafeOutputs(safeOutputs *SafeOutputsConfig) *Permissions {
 	if safeOutputs == nil {
 		safeOutputsPermissionsLog.Print("No safe outputs configured, returning empty permissions")
@@ -92,10 +95,8 @@ func ComputePermissionsForSafeOutputs(safeOutputs *SafeOutputsConfig) *Permissio
 		if handlerPermissions == nil {
 			continue
 		}
-		if handler.Key != "" {
-			safeOutputsPermissionsLog.Printf("Adding permissions for %s", handler.Key)
-		}
-		permissions.Merge(handlerPermissions)
+		safeOutputsPermissionsLog.Printf("Adding permissions for %s", handler.Key)
+		requireHandlerPermissions(permissions, handlerPermissions, handler.Key)
 	}
 
 	// NoOp and MissingTool don't require write permissions beyond what's already included
@@ -108,10 +109,13 @@ func ComputePermissionsForSafeOutputs(safeOutputs *SafeOutputsConfig) *Permissio
 		safeOutputsPermissionsLog.Print("id-token permission explicitly disabled (none)")
 	} else if safeOutputs.IDToken != nil && *safeOutputs.IDToken == "write" {
 		safeOutputsPermissionsLog.Print("id-token: write explicitly requested")
-		permissions.Set(PermissionIdToken, PermissionWrite)
+		permissions.Require(PermissionIdToken, PermissionWrite, "safe-outputs.id-token")
+	} else if safeOutputs.Cloud != nil {
+		safeOutputsPermissionsLog.Print("Cloud authentication configured; adding id-token: write")
+		permissions.Require(PermissionIdToken, PermissionWrite, "safe-outputs.cloud")
 	} else if stepsRequireIDToken(safeOutputs.Steps) {
 		safeOutputsPermissionsLog.Print("Auto-detected OIDC/vault action in steps; adding id-token: write")
-		permissions.Set(PermissionIdToken, PermissionWrite)
+		permissions.Require(PermissionIdToken, PermissionWrite, "OIDC action in safe-outputs.steps")
 	}
 
 	// If safeOutputs is configured but no permissions were accumulated (all handlers staged),
@@ -151,3 +155,21 @@ func SafeOutputsConfigFromKeys(keys []string) *SafeOutputsConfig {
 	}
 	return config
 }
+
+// requireHandlerPermissions records every scope granted by a handler's permissions as required
+// by that handler. Scopes are resolved through Get so that shorthand (read-all, write-all) and
+// "all:" levels contribute each scope they imply instead of being skipped.
+func requireHandlerPermissions(permissions, handlerPermissions *Permissions, feature string) {
+	scopes := slices.Collect(maps.Keys(handlerPermissions.permissions))
+	if handlerPermissions.shorthand != "" || handlerPermissions.hasAll {
+		scopes = append(scopes, GetAllPermissionScopes()...)
+	}
+	for _, scope := range scopes {
+		level, ok := handlerPermissions.Get(scope)
+		// id-token does not support the read level
+		if !ok || (scope == PermissionIdToken && level == PermissionRead) {
+			continue
+		}
+		permissions.Require(scope, level, feature)
+	}
+}
@@ -32,6 +32,32 @@ func TestComputePermissionsForSafeOutputs(t *testing.T) {
 				PermissionIssues:   PermissionWrite,
 			},
 		},
+		{
+			name: "create-commit-status requires statuses permission",
+			safeOutputs: &SafeOutputsConfig{
+				CreateCommitStatus: &CreateCommitStatusConfig{
+					BaseSafeOutputConfig: BaseSafeOutputConfig{Max: strPtr("1")},
+				},
+			},
+			expected: map[PermissionScope]PermissionLevel{
+				PermissionContents: PermissionRead,
+				PermissionStatuses: PermissionWrite,
+			},
+		},
+		{
+			name: "create-commit-status with target also reads pull requests",
+			safeOutputs: &SafeOutputsConfig{
+				CreateCommitStatus: &CreateCommitStatusConfig{
+					BaseSafeOutputConfig: BaseSafeOutputConfig{Max: strPtr("1")},
+					Target:               "*",
+				},
+			},
+			expected: map[PermissionScope]PermissionLevel{
+				PermissionContents:     PermissionRead,
+				PermissionStatuses:     PermissionWrite,
+				PermissionPullRequests: PermissionRead,
+			},
+		},
 		{
 			name: "create-discussion requires discussions permission",
 			safeOutputs: &SafeOutputsConfig{
@@ -843,3 +869,61 @@ func TestComputePermissionsForSafeOutputs_StagedYAMLRendering(t *testing.T) {
 		})
 	}
 }
+
+func TestComputePermissionsForSafeOutputs_RequiredBy(t *testing.T) {
+	permissions := ComputePermissionsForSafeOutputs(&SafeOutputsConfig{
+		CreateIssues:       &CreateIssuesConfig{},
+		AddLabels:          &AddLabelsConfig{BaseSafeOutputConfig: BaseSafeOutputConfig{Staged: templatableBoolPtr("true")}},
+		CreatePullRequests: &CreatePullRequestsConfig{},
+		IDToken:            strPtr("write"),
+	})
+	require.NotNil(t, permissions, "Permissions should not be nil")
+
+	assert.Equal(t, []string{"create-pull-request"}, permissions.RequiredBy(PermissionContents), "only write requirements should explain contents: write")
+	assert.Equal(t, []string{"create-issue", "create-pull-request"}, permissions.RequiredBy(PermissionIssues), "issues: write should list every handler that needs it")
+	assert.Equal(t, []string{"safe-outputs.id-token"}, permissions.RequiredBy(PermissionIdToken), "id-token should name the frontmatter setting")
+
+	rendered := permissions.RenderToYAML()
+	assert.Contains(t, rendered, "      # issues: write — required by create-issue, create-pull-request\n      issues: write", "rendered permissions should explain issues: write")
+	assert.NotContains(t, rendered, "add-labels", "staged handlers should not be listed as requiring permissions")
+}
+
+func TestRequireHandlerPermissions(t *testing.T) {
+	t.Run("explicit scopes", func(t *testing.T) {
+		permissions := NewPermissions()
+		requireHandlerPermissions(permissions, NewPermissionsContentsReadIssuesWrite(), "create-issue")
+
+		level, ok := permissions.Get(PermissionIssues)
+		require.True(t, ok, "issues should be granted")
+		assert.Equal(t, PermissionWrite, level, "issues level should be kept")
+		assert.Equal(t, []string{"create-issue"}, permissions.RequiredBy(PermissionContents), "contents should name the handler")
+	})
+
+	t.Run("shorthand is expanded instead of ignored", func(t *testing.T) {
+		permissions := NewPermissions()
+		requireHandlerPermissions(permissions, NewPermissionsReadAll(), "custom-handler")
+
+		level, ok := permissions.Get(PermissionActions)
+		require.True(t, ok, "read-all should grant actions")
+		assert.Equal(t, PermissionRead, level, "read-all should grant read")
+		assert.Equal(t, []string{"custom-handler"}, permissions.RequiredBy(PermissionPullRequests), "expanded scopes should name the handler")
+		_, ok = permissions.Get(PermissionIdToken)
+		assert.False(t, ok, "id-token does not support read and should not be granted")
+	})
+
+	t.Run("all level is merged with explicit overrides", func(t *testing.T) {
+		handlerPermissions := NewPermissions()
+		handlerPermissions.hasAll = true
+		handlerPermissions.allLevel = PermissionRead
+		handlerPermissions.permissions[PermissionIssues] = PermissionWrite
+
+		permissions := NewPermissions()
+		requireHandlerPermissions(permissions, handlerPermissions, "custom-handler")
+
+		level, _ := permissions.Get(PermissionIssues)
+		assert.Equal(t, PermissionWrite, level, "explicit write should override all: read")
+		level, ok := permissions.Get(PermissionContents)
+		require.True(t, ok, "all: read should grant contents")
+		assert.Equal(t, PermissionRead, level, "all: read should grant read")
+	})
+}
@@ -0,0 +1,105 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func handlerManagerConfigFromSteps(t *testing.T, steps []string) map[string]any {
+	t.Helper()
+	for _, step := range steps {
+		parts := strings.Split(step, "GH_AW_SAFE_OUTPUTS_HANDLER_CONFIG: ")
+		if len(parts) != 2 {
+			continue
+		}
+		jsonStr := strings.Trim(strings.TrimSpace(parts[1]), "\"")
+		jsonStr = strings.ReplaceAll(jsonStr, "\\\"", "\"")
+		var handlerConfig map[string]any
+		require.NoError(t, json.Unmarshal([]byte(jsonStr), &handlerConfig), "handler config should be valid JSON")
+		return handlerConfig
+	}
+	require.FailNow(t, "GH_AW_SAFE_OUTPUTS_HANDLER_CONFIG not found in steps")
+	return nil
+}
+
+func TestSafeOutputsRetryConfig(t *testing.T) {
+	compiler := NewCompiler()
+	frontmatter := map[string]any{
+		"name": "Test",
+		"safe-outputs": map[string]any{
+			"retry": map[string]any{"max-attempts": 4, "backoff": 10},
+			"create-issue": map[string]any{
+				"retry": map[string]any{"max-attempts": 6},
+			},
+			"create-pull-request": map[string]any{
+				"retry": map[string]any{"backoff": 30},
+			},
+			"add-comment": map[string]any{},
+		},
+	}
+	config := compiler.extractSafeOutputsConfig(frontmatter)
+	require.NotNil(t, config, "safe-outputs config should be extracted")
+	require.NotNil(t, config.Retry, "global retry should be parsed")
+	assert.Equal(t, SafeOutputRetryConfig{MaxAttempts: 4, Backoff: 10}, *config.Retry, "global retry should keep both fields")
+	require.NotNil(t, config.CreateIssues.Retry, "create-issue retry should be parsed")
+	require.NotNil(t, config.CreatePullRequests.Retry, "create-pull-request retry should be parsed")
+
+	var steps []string
+	compiler.addHandlerManagerConfigEnvVar(&steps, &WorkflowData{Name: "Test", SafeOutputs: config})
+	handlerConfig := handlerManagerConfigFromSteps(t, steps)
+
+	assert.Equal(t, map[string]any{"max_attempts": float64(4), "backoff": float64(10)}, handlerConfig["retry"], "global retry should be emitted at the top level")
+
+	createIssue, ok := handlerConfig["create_issue"].(map[string]any)
+	require.True(t, ok, "create_issue handler config should exist")
+	assert.Equal(t, map[string]any{"max_attempts": float64(6)}, createIssue["retry"], "create_issue should carry its own retry")
+
+	createPR, ok := handlerConfig["create_pull_request"].(map[string]any)
+	require.True(t, ok, "create_pull_request handler config should exist")
+	assert.Equal(t, map[string]any{"backoff": float64(30)}, createPR["retry"], "create_pull_request should carry its own retry")
+
+	addComment, ok := handlerConfig["add_comment"].(map[string]any)
+	require.True(t, ok, "add_comment handler config should exist")
+	assert.NotContains(t, addComment, "retry", "handlers without retry should fall back to the global policy at runtime")
+}
+
+func TestParseSafeOutputRetryConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    any
+		expected *SafeOutputRetryConfig
+	}{
+		{
+			name:     "both fields",
+			input:    map[string]any{"max-attempts": 3, "backoff": 5},
+			expected: &SafeOutputRetryConfig{MaxAttempts: 3, Backoff: 5},
+		},
+		{
+			name:     "float values from JSON",
+			input:    map[string]any{"max-attempts": float64(2)},
+			expected: &SafeOutputRetryConfig{MaxAttempts: 2},
+		},
+		{
+			name:     "empty mapping",
+			input:    map[string]any{},
+			expected: nil,
+		},
+		{
+			name:     "not a mapping",
+			input:    true,
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parseSafeOutputRetryConfig(tt.input), "parsed retry policy should match")
+		})
+	}
+}
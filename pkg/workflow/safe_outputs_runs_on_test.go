@@ -374,6 +374,58 @@ This is a test workflow.`,
 	}
 }
 
+// TestFormatSafeOutputsJobRunsOn verifies that the safe_outputs job follows a self-hosted
+// agent runner unless a framework runner override is configured.
+func TestFormatSafeOutputsJobRunsOn(t *testing.T) {
+	compiler := NewCompiler()
+
+	tests := []struct {
+		name           string
+		data           *WorkflowData
+		expectedRunsOn string
+	}{
+		{
+			name:           "hosted agent runner keeps the framework default",
+			data:           &WorkflowData{RunsOn: "runs-on: ubuntu-latest", SafeOutputs: &SafeOutputsConfig{}},
+			expectedRunsOn: "runs-on: " + constants.DefaultActivationJobRunnerImage,
+		},
+		{
+			name:           "self-hosted string label is inherited",
+			data:           &WorkflowData{RunsOn: "runs-on: self-hosted", SafeOutputs: &SafeOutputsConfig{}},
+			expectedRunsOn: "runs-on: self-hosted",
+		},
+		{
+			name:           "self-hosted label array is inherited",
+			data:           &WorkflowData{RunsOn: "runs-on:\n- self-hosted\n- linux\n- x64", SafeOutputs: &SafeOutputsConfig{}},
+			expectedRunsOn: "runs-on:\n      - self-hosted\n      - linux\n      - x64",
+		},
+		{
+			name:           "runner group is inherited",
+			data:           &WorkflowData{RunsOn: "runs-on:\n  group: runner-group\n  labels:\n  - linux", SafeOutputs: &SafeOutputsConfig{}},
+			expectedRunsOn: "runs-on:\n      group: runner-group\n      labels:\n        - linux",
+		},
+		{
+			name:           "runs-on-slim takes precedence over the agent runner",
+			data:           &WorkflowData{RunsOn: "runs-on: self-hosted", RunsOnSlim: "runs-on: ubuntu-22.04", SafeOutputs: &SafeOutputsConfig{}},
+			expectedRunsOn: "runs-on: ubuntu-22.04",
+		},
+		{
+			name:           "safe-outputs.runs-on takes precedence over the agent runner",
+			data:           &WorkflowData{RunsOn: "runs-on: self-hosted", SafeOutputs: &SafeOutputsConfig{RunsOn: "runs-on: ubuntu-latest"}},
+			expectedRunsOn: "runs-on: ubuntu-latest",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := compiler.formatSafeOutputsJobRunsOn(tt.data)
+			if result != tt.expectedRunsOn {
+				t.Errorf("formatSafeOutputsJobRunsOn() = %q, want %q", result, tt.expectedRunsOn)
+			}
+		})
+	}
+}
+
 // TestFormatFrameworkJobRunsOn tests the formatFrameworkJobRunsOn helper directly.
 func TestFormatFrameworkJobRunsOn(t *testing.T) {
 	compiler := NewCompiler()
@@ -37,6 +37,23 @@ func (c *Compiler) formatFrameworkJobRunsOn(data *WorkflowData) string {
 	return "runs-on: " + constants.DefaultActivationJobRunnerImage
 }
 
+// formatSafeOutputsJobRunsOn returns the runs-on value for the safe_outputs job.
+//
+// It follows formatFrameworkJobRunsOn, except that when neither safe-outputs.runs-on nor
+// runs-on-slim is set and the agent job targets self-hosted runners (a "self-hosted" label
+// or a runner group), the safe_outputs job runs on the agent's runner. Self-hosted setups
+// often have no access to the GitHub-hosted ubuntu-slim runner, which would leave the job
+// queued forever.
+func (c *Compiler) formatSafeOutputsJobRunsOn(data *WorkflowData) string {
+	hasFrameworkOverride := data != nil && ((data.SafeOutputs != nil && data.SafeOutputs.RunsOn != "") || data.RunsOnSlim != "")
+	if !hasFrameworkOverride && data != nil && isSelfHostedRunsOnSnippet(data.RunsOn) {
+		snippet := normalizeRunsOnSnippet(data.RunsOn)
+		safeOutputsRuntimeLog.Printf("safe_outputs job runs-on inherited from self-hosted agent runner: %s", snippet)
+		return c.indentYAMLLines(snippet, "    ")
+	}
+	return c.formatFrameworkJobRunsOn(data)
+}
+
 // usesPatchesAndCheckouts checks if the workflow uses safe outputs that require
 // git patches and checkouts (create-pull-request or push-to-pull-request-branch).
 // Staged handlers are excluded because they only emit preview output and do not
@@ -60,24 +60,31 @@ func hasAnySafeOutputEnabled(safeOutputs *SafeOutputsConfig) bool {
 		safeOutputs.CreateCodeScanningAlerts != nil ||
 		safeOutputs.AutofixCodeScanningAlert != nil ||
 		safeOutputs.CreateCheckRun != nil ||
+		safeOutputs.CreateCommitStatus != nil ||
+		safeOutputs.Notify != nil ||
 		safeOutputs.AddLabels != nil ||
 		safeOutputs.RemoveLabels != nil ||
 		safeOutputs.ReplaceLabel != nil ||
 		safeOutputs.AddReviewer != nil ||
+		safeOutputs.RequestTeamReview != nil ||
 		safeOutputs.AssignMilestone != nil ||
 		safeOutputs.AssignToAgent != nil ||
 		safeOutputs.AssignToUser != nil ||
 		safeOutputs.UnassignFromUser != nil ||
+		safeOutputs.LockIssue != nil ||
+		safeOutputs.LockPullRequest != nil ||
 		safeOutputs.UpdateIssues != nil ||
 		safeOutputs.UpdatePullRequests != nil ||
 		safeOutputs.MergePullRequest != nil ||
 		safeOutputs.PushToPullRequestBranch != nil ||
 		safeOutputs.UploadAssets != nil ||
 		safeOutputs.UploadArtifact != nil ||
+		safeOutputs.CreateArtifact != nil ||
 		safeOutputs.UpdateRelease != nil ||
 		safeOutputs.UpdateProjects != nil ||
 		safeOutputs.CreateProjects != nil ||
 		safeOutputs.CreateProjectStatusUpdates != nil ||
+		safeOutputs.UpdateProjectItems != nil ||
 		safeOutputs.LinkSubIssue != nil ||
 		safeOutputs.HideComment != nil ||
 		safeOutputs.DispatchWorkflow != nil ||
@@ -127,24 +134,31 @@ func hasNonBuiltinSafeOutputsEnabled(safeOutputs *SafeOutputsConfig) bool {
 		safeOutputs.CreateCodeScanningAlerts != nil ||
 		safeOutputs.AutofixCodeScanningAlert != nil ||
 		safeOutputs.CreateCheckRun != nil ||
+		safeOutputs.CreateCommitStatus != nil ||
+		safeOutputs.Notify != nil ||
 		safeOutputs.AddLabels != nil ||
 		safeOutputs.RemoveLabels != nil ||
 		safeOutputs.ReplaceLabel != nil ||
 		safeOutputs.AddReviewer != nil ||
+		safeOutputs.RequestTeamReview != nil ||
 		safeOutputs.AssignMilestone != nil ||
 		safeOutputs.AssignToAgent != nil ||
 		safeOutputs.AssignToUser != nil ||
 		safeOutputs.UnassignFromUser != nil ||
+		safeOutputs.LockIssue != nil ||
+		safeOutputs.LockPullRequest != nil ||
 		safeOutputs.UpdateIssues != nil ||
 		safeOutputs.UpdatePullRequests != nil ||
 		safeOutputs.MergePullRequest != nil ||
 		safeOutputs.PushToPullRequestBranch != nil ||
 		safeOutputs.UploadAssets != nil ||
 		safeOutputs.UploadArtifact != nil ||
+		safeOutputs.CreateArtifact != nil ||
 		safeOutputs.UpdateRelease != nil ||
 		safeOutputs.UpdateProjects != nil ||
 		safeOutputs.CreateProjects != nil ||
 		safeOutputs.CreateProjectStatusUpdates != nil ||
+		safeOutputs.UpdateProjectItems != nil ||
 		safeOutputs.LinkSubIssue != nil ||
 		safeOutputs.HideComment != nil ||
 		safeOutputs.DispatchWorkflow != nil ||
@@ -2,6 +2,7 @@ package workflow
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/github/gh-aw/pkg/constants"
 	"github.com/github/gh-aw/pkg/logger"
@@ -156,7 +157,8 @@ func (c *Compiler) buildGitHubScriptStep(data *WorkflowData, config GitHubScript
 	// Step name and metadata
 	steps = append(steps, fmt.Sprintf("      - name: %s\n", config.StepName))
 	steps = append(steps, fmt.Sprintf("        id: %s\n", config.StepID))
-	steps = append(steps, fmt.Sprintf("        uses: %s\n", getCachedActionPin("actions/github-script", data)))
+	script := gitHubScriptStepScript(config)
+	steps = append(steps, fmt.Sprintf("        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", data), script)))
 
 	// Environment variables section
 	steps = append(steps, "        env:\n")
@@ -171,29 +173,8 @@ func (c *Compiler) buildGitHubScriptStep(data *WorkflowData, config GitHubScript
 	// Add custom environment variables from safe-outputs.env
 	c.addCustomSafeOutputEnvVars(&steps, data)
 
-	// With section for github-token
-	steps = append(steps, "        with:\n")
-	if config.UseCopilotCodingAgentToken {
-		c.addSafeOutputAgentGitHubTokenForConfig(&steps, data, config.CustomToken)
-	} else if config.UseCopilotRequestsToken {
-		c.addSafeOutputCopilotGitHubTokenForConfig(&steps, data, config.CustomToken)
-	} else {
-		c.addSafeOutputGitHubTokenForConfig(&steps, data, config.CustomToken)
-	}
-
-	steps = append(steps, "          script: |\n")
-
-	// Use require() if ScriptFile is specified, otherwise inline the script
-	if config.ScriptFile != "" {
-		steps = append(steps, "            const { setupGlobals } = require('"+SetupActionDestination+"/setup_globals.cjs');\n")
-		steps = append(steps, "            setupGlobals(core, github, context, exec, io, getOctokit);\n")
-		steps = append(steps, fmt.Sprintf("            const { main } = require('"+SetupActionDestination+"/%s');\n", config.ScriptFile))
-		steps = append(steps, "            await main();\n")
-	} else {
-		// Add the formatted JavaScript script (inline)
-		formattedScript := FormatJavaScriptForYAML(config.Script)
-		steps = append(steps, formattedScript...)
-	}
+	// With section for github-token and the script
+	steps = append(steps, c.githubScriptWith(script, c.gitHubScriptStepTokenInputs(data, config)...))
 
 	return steps
 }
@@ -213,7 +194,8 @@ func (c *Compiler) buildGitHubScriptStepWithoutDownload(data *WorkflowData, conf
 	if config.StepCondition != "" {
 		steps = append(steps, fmt.Sprintf("        if: %s\n", config.StepCondition))
 	}
-	steps = append(steps, fmt.Sprintf("        uses: %s\n", getCachedActionPin("actions/github-script", data)))
+	script := gitHubScriptStepScript(config)
+	steps = append(steps, fmt.Sprintf("        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", data), script)))
 
 	// Environment variables section
 	steps = append(steps, "        env:\n")
@@ -228,31 +210,32 @@ func (c *Compiler) buildGitHubScriptStepWithoutDownload(data *WorkflowData, conf
 	// Add custom environment variables from safe-outputs.env
 	c.addCustomSafeOutputEnvVars(&steps, data)
 
-	// With section for github-token
-	steps = append(steps, "        with:\n")
-	if config.UseCopilotCodingAgentToken {
-		c.addSafeOutputAgentGitHubTokenForConfig(&steps, data, config.CustomToken)
-	} else if config.UseCopilotRequestsToken {
-		c.addSafeOutputCopilotGitHubTokenForConfig(&steps, data, config.CustomToken)
-	} else {
-		c.addSafeOutputGitHubTokenForConfig(&steps, data, config.CustomToken)
-	}
+	// With section for github-token and the script
+	steps = append(steps, c.githubScriptWith(script, c.gitHubScriptStepTokenInputs(data, config)...))
 
-	steps = append(steps, "          script: |\n")
+	return steps
+}
 
-	// Use require() if ScriptFile is specified, otherwise inline the script
+// gitHubScriptStepScript returns the script of a GitHub Script step: a require() of
+// ScriptFile if specified, otherwise the inlined Script.
+func gitHubScriptStepScript(config GitHubScriptStepConfig) string {
 	if config.ScriptFile != "" {
-		steps = append(steps, "            const { setupGlobals } = require('"+SetupActionDestination+"/setup_globals.cjs');\n")
-		steps = append(steps, "            setupGlobals(core, github, context, exec, io, getOctokit);\n")
-		steps = append(steps, fmt.Sprintf("            const { main } = require('"+SetupActionDestination+"/%s');\n", config.ScriptFile))
-		steps = append(steps, "            await main();\n")
-	} else {
-		// Add the formatted JavaScript script (inline)
-		formattedScript := FormatJavaScriptForYAML(config.Script)
-		steps = append(steps, formattedScript...)
+		return generateGitHubScriptWithRequire(config.ScriptFile)
 	}
+	return strings.Join(FormatJavaScriptForYAML(config.Script), "")
+}
 
-	return steps
+// gitHubScriptStepTokenInputs returns the github-token input lines of a GitHub Script step.
+func (c *Compiler) gitHubScriptStepTokenInputs(data *WorkflowData, config GitHubScriptStepConfig) []string {
+	var inputs []string
+	if config.UseCopilotCodingAgentToken {
+		c.addSafeOutputAgentGitHubTokenForConfig(&inputs, data, config.CustomToken)
+	} else if config.UseCopilotRequestsToken {
+		c.addSafeOutputCopilotGitHubTokenForConfig(&inputs, data, config.CustomToken)
+	} else {
+		c.addSafeOutputGitHubTokenForConfig(&inputs, data, config.CustomToken)
+	}
+	return inputs
 }
 
 // buildAgentOutputDownloadSteps creates steps to download the agent output artifact
@@ -262,9 +245,16 @@ func (c *Compiler) buildGitHubScriptStepWithoutDownload(data *WorkflowData, conf
 // pinAction resolves the download-artifact action reference; pass c.getActionPin from Compiler methods.
 func buildAgentOutputDownloadSteps(prefix string, pinAction func(string) string) []string {
 	safeOutputsStepsLog.Printf("Building agent output download steps with prefix: %q", prefix)
+	// Unified agent artifact (prefixed in workflow_call)
+	return buildNamedAgentOutputDownloadSteps(prefix+constants.AgentArtifactName, pinAction)
+}
+
+// buildNamedAgentOutputDownloadSteps downloads agent_output.json from the named agent
+// artifact, e.g. agent-<engine> for an additional compare-engines agent job.
+func buildNamedAgentOutputDownloadSteps(artifactName string, pinAction func(string) string) []string {
 	return buildArtifactDownloadSteps(ArtifactDownloadConfig{
-		ArtifactName:     prefix + constants.AgentArtifactName, // Unified agent artifact (prefixed in workflow_call)
-		ArtifactFilename: constants.AgentOutputFilename,        // Filename inside the artifact directory
+		ArtifactName:     artifactName,
+		ArtifactFilename: constants.AgentOutputFilename, // Filename inside the artifact directory
 		DownloadPath:     constants.TmpGhAwDirSlash,
 		SetupEnvStep:     true,
 		EnvVarName:       "GH_AW_AGENT_OUTPUT",
@@ -0,0 +1,68 @@
+package workflow
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var safeOutputsTargetRepoValidationLog = logger.New("workflow:safe_outputs_target_repo_validation")
+
+// repoPatternUnsupportedTools lists safe outputs that need a concrete checkout of
+// the target repository and therefore cannot accept a target-repo pattern.
+var repoPatternUnsupportedTools = map[string]bool{
+	"create_pull_request":         true,
+	"push_to_pull_request_branch": true,
+}
+
+// isRepoSlugPattern reports whether a target-repo value is a repository pattern
+// such as "org/*". The bare wildcard "*" and expressions are not patterns.
+func isRepoSlugPattern(slug string) bool {
+	return slug != "*" && strings.Contains(slug, "*") && !containsExpression(slug)
+}
+
+// validateSafeOutputsTargetRepo validates target-repo values that are repository
+// patterns. Literal slugs and GitHub Actions expressions (e.g. "${{ vars.TARGET_REPO }}")
+// are resolved and validated by the safe output handler at runtime.
+//
+// A pattern like "org/*" has no single default repository, so it is only accepted
+// for safe outputs whose tools expose a repo parameter; the handler then requires
+// each item to name a repository matching the pattern or the allowed-repos list.
+func validateSafeOutputsTargetRepo(config *SafeOutputsConfig) error {
+	if config == nil {
+		return nil
+	}
+
+	for _, fieldName := range getSortedSafeOutputFieldNames() {
+		field, ok := safeOutputPointerFieldValue(config, fieldName)
+		if !ok || field.IsNil() {
+			continue
+		}
+		targetRepoField := field.Elem().FieldByName("TargetRepoSlug")
+		if !targetRepoField.IsValid() {
+			continue
+		}
+		targetRepo := strings.TrimSpace(targetRepoField.String())
+		if !isRepoSlugPattern(targetRepo) {
+			continue
+		}
+
+		toolName := safeOutputFieldMapping[fieldName]
+		configKey := "safe-outputs." + strings.ReplaceAll(toolName, "_", "-") + ".target-repo"
+		safeOutputsTargetRepoValidationLog.Printf("Validating target-repo pattern for %s: %q", toolName, targetRepo)
+
+		if _, _, ok := parseRepoSlugLiteral(targetRepo); !ok {
+			return NewValidationError(configKey, targetRepo,
+				"repository pattern must have the form 'owner/name' with '*' wildcards",
+				"Use a pattern such as 'my-org/*' or 'my-org/service-*'")
+		}
+		if repoPatternUnsupportedTools[toolName] || computeRepoParamForTool(toolName, config) == nil {
+			return NewValidationError(configKey, targetRepo,
+				fmt.Sprintf("repository patterns are not supported for %s", strings.ReplaceAll(toolName, "_", "-")),
+				"Use a literal 'owner/repo' or an expression such as '${{ vars.TARGET_REPO }}'")
+		}
+	}
+
+	return nil
+}
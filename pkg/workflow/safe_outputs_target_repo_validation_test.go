@@ -0,0 +1,93 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsRepoSlugPattern(t *testing.T) {
+	tests := []struct {
+		slug     string
+		expected bool
+	}{
+		{slug: "", expected: false},
+		{slug: "*", expected: false},
+		{slug: "org/repo", expected: false},
+		{slug: "${{ vars.TARGET_REPO }}", expected: false},
+		{slug: "org/*", expected: true},
+		{slug: "org/service-*", expected: true},
+		{slug: "*/gh-aw", expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.slug, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isRepoSlugPattern(tt.slug), "isRepoSlugPattern(%q)", tt.slug)
+		})
+	}
+}
+
+func TestValidateSafeOutputsTargetRepo(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      *SafeOutputsConfig
+		errContains string
+	}{
+		{
+			name:   "nil config",
+			config: nil,
+		},
+		{
+			name:   "literal target-repo",
+			config: &SafeOutputsConfig{CreateIssues: &CreateIssuesConfig{TargetRepoSlug: "org/repo"}},
+		},
+		{
+			name:   "expression target-repo",
+			config: &SafeOutputsConfig{CreateIssues: &CreateIssuesConfig{TargetRepoSlug: "${{ vars.TARGET_REPO }}"}},
+		},
+		{
+			name:   "pattern target-repo",
+			config: &SafeOutputsConfig{AddComments: &AddCommentsConfig{TargetRepoSlug: "org/*"}},
+		},
+		{
+			name:        "malformed pattern",
+			config:      &SafeOutputsConfig{CreateIssues: &CreateIssuesConfig{TargetRepoSlug: "org-*"}},
+			errContains: "must have the form 'owner/name'",
+		},
+		{
+			name:        "pattern on create-pull-request",
+			config:      &SafeOutputsConfig{CreatePullRequests: &CreatePullRequestsConfig{TargetRepoSlug: "org/*"}},
+			errContains: "not supported for create-pull-request",
+		},
+		{
+			name:        "pattern on safe output without repo parameter",
+			config:      &SafeOutputsConfig{CreateCodeScanningAlerts: &CreateCodeScanningAlertsConfig{TargetRepoSlug: "org/*"}},
+			errContains: "not supported for create-code-scanning-alert",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSafeOutputsTargetRepo(tt.config)
+			if tt.errContains == "" {
+				assert.NoError(t, err, "target-repo should be valid")
+				return
+			}
+			require.Error(t, err, "target-repo should be rejected")
+			assert.Contains(t, err.Error(), tt.errContains, "error should explain the problem")
+		})
+	}
+}
+
+func TestRepoParameterForTargetRepoPattern(t *testing.T) {
+	safeOutputs := &SafeOutputsConfig{
+		CreateIssues: &CreateIssuesConfig{TargetRepoSlug: "org/*"},
+	}
+
+	repoParam := computeRepoParamForTool("create_issue", safeOutputs)
+	require.NotNil(t, repoParam, "pattern target-repo should expose a repo parameter")
+	assert.Contains(t, repoParam["description"], `Must match "org/*"`, "description should mention the pattern")
+}
@@ -88,6 +88,14 @@ func computeEnabledToolNames(data *WorkflowData) map[string]struct {
 		enabledTools["create_check_run"] = struct {
 		}{}
 	}
+	if data.SafeOutputs.CreateCommitStatus != nil {
+		enabledTools["create_commit_status"] = struct {
+		}{}
+	}
+	if data.SafeOutputs.Notify != nil {
+		enabledTools["notify"] = struct {
+		}{}
+	}
 	if data.SafeOutputs.AddLabels != nil {
 		enabledTools["add_labels"] = struct {
 		}{}
@@ -104,6 +112,10 @@ func computeEnabledToolNames(data *WorkflowData) map[string]struct {
 		enabledTools["add_reviewer"] = struct {
 		}{}
 	}
+	if data.SafeOutputs.RequestTeamReview != nil {
+		enabledTools["request_team_review"] = struct {
+		}{}
+	}
 	if data.SafeOutputs.AssignMilestone != nil {
 		enabledTools["assign_milestone"] = struct {
 		}{}
@@ -120,6 +132,14 @@ func computeEnabledToolNames(data *WorkflowData) map[string]struct {
 		enabledTools["unassign_from_user"] = struct {
 		}{}
 	}
+	if data.SafeOutputs.LockIssue != nil {
+		enabledTools["lock_issue"] = struct {
+		}{}
+	}
+	if data.SafeOutputs.LockPullRequest != nil {
+		enabledTools["lock_pull_request"] = struct {
+		}{}
+	}
 	if data.SafeOutputs.UpdateIssues != nil {
 		enabledTools["update_issue"] = struct {
 		}{}
@@ -140,6 +160,10 @@ func computeEnabledToolNames(data *WorkflowData) map[string]struct {
 		enabledTools["upload_artifact"] = struct {
 		}{}
 	}
+	if data.SafeOutputs.CreateArtifact != nil {
+		enabledTools["create_artifact"] = struct {
+		}{}
+	}
 	if data.SafeOutputs.MissingTool != nil {
 		enabledTools["missing_tool"] = struct {
 		}{}
@@ -180,6 +204,10 @@ func computeEnabledToolNames(data *WorkflowData) map[string]struct {
 		enabledTools["create_project_status_update"] = struct {
 		}{}
 	}
+	if data.SafeOutputs.UpdateProjectItems != nil {
+		enabledTools["update_project_item"] = struct {
+		}{}
+	}
 	if data.SafeOutputs.CreateProjects != nil {
 		enabledTools["create_project"] = struct {
 		}{}
@@ -17,12 +17,23 @@ import (
 // filtered subset containing only those tools enabled by the workflow's
 // SafeOutputsConfig. Dynamic tools (dispatch-workflow, custom jobs) are also
 // generated here.
-//
-// generateToolsMetaJSON generates a small "meta" JSON (description suffixes,
-// repo params, dynamic tools) to be written at compile time. At runtime,
-// generate_safe_outputs_tools.cjs reads the source safe_outputs_tools.json
-// from the actions folder, applies the meta overrides, and writes the final
-// tools.json—avoiding inlining the entire file into the compiled workflow YAML.
+
+// maxBatchItems mirrors MAX_BATCH_ITEMS in actions/setup/js/constants.cjs.
+const maxBatchItems = 100
+
+// batchItemNumbersInjection returns the item_numbers property exposed to the agent when
+// a label handler is configured with batch: true.
+func batchItemNumbersInjection(action string) map[string]any {
+	return map[string]any{
+		"item_numbers": map[string]any{
+			"type":     "array",
+			"items":    map[string]any{"type": []string{"number", "string"}},
+			"maxItems": maxBatchItems,
+			"description": fmt.Sprintf("Batch mode: issue/PR numbers (or temporary IDs) to %s. "+
+				"Use instead of item_number when one change applies to many items; a batch counts as a single output.", action),
+		},
+	}
+}
 
 // generateDynamicTools generates MCP tool definitions for dynamic tools:
 // custom safe-jobs, dispatch_workflow targets, and call_workflow targets.
@@ -254,8 +265,13 @@ func computeRequiredFieldAdditions(safeOutputs *SafeOutputsConfig) map[string][]
 	if safeOutputs == nil {
 		return additions
 	}
-	if safeOutputs.CreateIssues != nil && safeOutputs.CreateIssues.RequireTemporaryID {
-		additions["create_issue"] = []string{"temporary_id"}
+	if safeOutputs.CreateIssues != nil {
+		if safeOutputs.CreateIssues.RequireTemporaryID {
+			additions["create_issue"] = append(additions["create_issue"], "temporary_id")
+		}
+		if form := safeOutputs.CreateIssues.IssueForm; form != nil && len(form.requiredFieldIDs()) > 0 {
+			additions["create_issue"] = append(additions["create_issue"], "form")
+		}
 	}
 	if safeOutputs.CreatePullRequests != nil && safeOutputs.CreatePullRequests.RequireTemporaryID {
 		additions["create_pull_request"] = []string{"temporary_id"}
@@ -289,13 +305,28 @@ var closeIssueStateReasonValues = []string{"completed", "not_planned", "duplicat
 // computePropertyInjections returns a map of tool name → property name → property schema
 // for properties that must be injected into the tool schema based on workflow configuration.
 //
-// Currently handles close_issue state_reason:
+// Handles add_labels/remove_labels batch mode (batch: true injects item_numbers),
+// the create_issue form object for create-issue.form, and close_issue state_reason:
 //   - Omitted config (no state-reason): inject state_reason with all three supported values.
 //   - List config (state-reason: [...]): inject state_reason with the configured subset.
 //   - Scalar config (state-reason: "..."): no injection (fixed reason, agent cannot choose).
 func computePropertyInjections(safeOutputs *SafeOutputsConfig) map[string]map[string]any {
 	injections := make(map[string]map[string]any)
-	if safeOutputs == nil || safeOutputs.CloseIssues == nil {
+	if safeOutputs == nil {
+		return injections
+	}
+	if safeOutputs.AddLabels != nil && safeOutputs.AddLabels.Batch {
+		injections["add_labels"] = batchItemNumbersInjection("add the same labels to")
+	}
+	if safeOutputs.RemoveLabels != nil && safeOutputs.RemoveLabels.Batch {
+		injections["remove_labels"] = batchItemNumbersInjection("remove the same labels from")
+	}
+	if safeOutputs.CreateIssues != nil && safeOutputs.CreateIssues.IssueForm != nil {
+		injections["create_issue"] = map[string]any{
+			"form": safeOutputs.CreateIssues.IssueForm.toolInputSchema(),
+		}
+	}
+	if safeOutputs.CloseIssues == nil {
 		return injections
 	}
 	c := safeOutputs.CloseIssues
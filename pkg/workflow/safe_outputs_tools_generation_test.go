@@ -491,6 +491,33 @@ func TestComputePropertyInjectionsNilCloseIssues(t *testing.T) {
 	assert.Empty(t, injections)
 }
 
+// TestComputePropertyInjectionsLabelBatch verifies that batch: true on add-labels and
+// remove-labels exposes the item_numbers array to the agent.
+func TestComputePropertyInjectionsLabelBatch(t *testing.T) {
+	injections := computePropertyInjections(&SafeOutputsConfig{
+		AddLabels:    &AddLabelsConfig{Batch: true},
+		RemoveLabels: &RemoveLabelsConfig{Batch: true},
+	})
+
+	for _, tool := range []string{"add_labels", "remove_labels"} {
+		require.Contains(t, injections, tool)
+		prop, ok := injections[tool]["item_numbers"].(map[string]any)
+		require.True(t, ok, "item_numbers should be a property map for %s", tool)
+		assert.Equal(t, "array", prop["type"])
+		assert.Equal(t, maxBatchItems, prop["maxItems"])
+	}
+}
+
+// TestComputePropertyInjectionsLabelBatchDisabled verifies that item_numbers is not
+// exposed unless batch mode is enabled.
+func TestComputePropertyInjectionsLabelBatchDisabled(t *testing.T) {
+	injections := computePropertyInjections(&SafeOutputsConfig{
+		AddLabels:    &AddLabelsConfig{},
+		RemoveLabels: &RemoveLabelsConfig{},
+	})
+	assert.Empty(t, injections)
+}
+
 // TestPreprocessStateReasonListSlice verifies that a []any slice is converted to allowed-state-reason.
 func TestPreprocessStateReasonListSlice(t *testing.T) {
 	configData := map[string]any{
@@ -85,8 +85,8 @@ func addRepoParameterIfNeeded(tool map[string]any, toolName string, safeOutputs
 			targetRepoSlug = config.TargetRepoSlug
 		}
 	case "add_labels", "remove_labels", "replace_label", "hide_comment", "link_sub_issue", "mark_pull_request_as_ready_for_review",
-		"add_reviewer", "assign_milestone", "assign_to_agent", "assign_to_user", "unassign_from_user",
-		"set_issue_type", "set_issue_field":
+		"add_reviewer", "request_team_review", "assign_milestone", "assign_to_agent", "assign_to_user", "unassign_from_user",
+		"lock_issue", "lock_pull_request", "set_issue_type", "set_issue_field":
 		// These use SafeOutputTargetConfig - check the appropriate config
 		switch toolName {
 		case "add_labels":
@@ -124,6 +124,11 @@ func addRepoParameterIfNeeded(tool map[string]any, toolName string, safeOutputs
 				hasAllowedRepos = len(config.AllowedRepos) > 0
 				targetRepoSlug = config.TargetRepoSlug
 			}
+		case "request_team_review":
+			if config := safeOutputs.RequestTeamReview; config != nil {
+				hasAllowedRepos = len(config.AllowedRepos) > 0
+				targetRepoSlug = config.TargetRepoSlug
+			}
 		case "assign_milestone":
 			if config := safeOutputs.AssignMilestone; config != nil {
 				hasAllowedRepos = len(config.AllowedRepos) > 0
@@ -144,6 +149,16 @@ func addRepoParameterIfNeeded(tool map[string]any, toolName string, safeOutputs
 				hasAllowedRepos = len(config.AllowedRepos) > 0
 				targetRepoSlug = config.TargetRepoSlug
 			}
+		case "lock_issue":
+			if config := safeOutputs.LockIssue; config != nil {
+				hasAllowedRepos = len(config.AllowedRepos) > 0
+				targetRepoSlug = config.TargetRepoSlug
+			}
+		case "lock_pull_request":
+			if config := safeOutputs.LockPullRequest; config != nil {
+				hasAllowedRepos = len(config.AllowedRepos) > 0
+				targetRepoSlug = config.TargetRepoSlug
+			}
 		case "set_issue_type":
 			if config := safeOutputs.SetIssueType; config != nil {
 				hasAllowedRepos = len(config.AllowedRepos) > 0
@@ -157,9 +172,9 @@ func addRepoParameterIfNeeded(tool map[string]any, toolName string, safeOutputs
 		}
 	}
 
-	// Only add repo parameter if allowed-repos has entries or target-repo is wildcard ("*")
-	if !hasAllowedRepos && targetRepoSlug != "*" {
-		safeOutputsConfigLog.Printf("Skipping repo parameter for tool %s: no allowed-repos and target-repo is not wildcard", toolName)
+	// Only add repo parameter if allowed-repos has entries or target-repo is wildcard ("*") or a pattern ("org/*")
+	if !hasAllowedRepos && targetRepoSlug != "*" && !isRepoSlugPattern(targetRepoSlug) {
+		safeOutputsConfigLog.Printf("Skipping repo parameter for tool %s: no allowed-repos and target-repo is not wildcard or pattern", toolName)
 		return
 	}
 
@@ -178,6 +193,8 @@ func addRepoParameterIfNeeded(tool map[string]any, toolName string, safeOutputs
 	var repoDescription string
 	if targetRepoSlug == "*" {
 		repoDescription = "Target repository for this operation in 'owner/repo' format. Any repository can be targeted."
+	} else if isRepoSlugPattern(targetRepoSlug) {
+		repoDescription = fmt.Sprintf("Target repository for this operation in 'owner/repo' format. Required. Must match %q or be in the allowed-repos list.", targetRepoSlug)
 	} else if targetRepoSlug != "" {
 		repoDescription = fmt.Sprintf("Target repository for this operation in 'owner/repo' format. Default is %q. Must be the target-repo or in the allowed-repos list.", targetRepoSlug)
 	} else {
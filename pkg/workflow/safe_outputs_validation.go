@@ -124,6 +124,9 @@ func validateSafeOutputsTarget(config *SafeOutputsConfig) error {
 	if config.AddReviewer != nil {
 		configs = append(configs, targetConfig{"add-reviewer", config.AddReviewer.Target})
 	}
+	if config.RequestTeamReview != nil {
+		configs = append(configs, targetConfig{"request-team-review", config.RequestTeamReview.Target})
+	}
 	if config.AssignMilestone != nil {
 		configs = append(configs, targetConfig{"assign-milestone", config.AssignMilestone.Target})
 	}
@@ -136,6 +139,12 @@ func validateSafeOutputsTarget(config *SafeOutputsConfig) error {
 	if config.LinkSubIssue != nil {
 		configs = append(configs, targetConfig{"link-sub-issue", config.LinkSubIssue.Target})
 	}
+	if config.LockIssue != nil {
+		configs = append(configs, targetConfig{"lock-issue", config.LockIssue.Target})
+	}
+	if config.LockPullRequest != nil {
+		configs = append(configs, targetConfig{"lock-pull-request", config.LockPullRequest.Target})
+	}
 	if config.HideComment != nil {
 		configs = append(configs, targetConfig{"hide-comment", config.HideComment.Target})
 	}
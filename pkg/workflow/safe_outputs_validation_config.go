@@ -64,6 +64,7 @@ var ValidationConfig = map[string]TypeValidationConfig{
 			"body":         {Required: true, Type: "string", Sanitize: true, MaxLength: MaxBodyLength, MinLength: MinIssueBodyLength},
 			"labels":       {Type: "array", ItemType: "string", ItemSanitize: true, ItemMaxLength: 128},
 			"fields":       {Type: "array"},
+			"form":         {Type: "object"},
 			"parent":       {IssueOrPRNumber: true},
 			"temporary_id": {Type: "string"},
 			"repo":         {Type: "string", MaxLength: 256}, // Optional: target repository in format "owner/repo"
@@ -109,9 +110,10 @@ var ValidationConfig = map[string]TypeValidationConfig{
 	"add_labels": {
 		DefaultMax: 5,
 		Fields: map[string]FieldValidation{
-			"labels":      {Required: true, Type: "array"}, // Item-level validation/sanitization handled by JS issue-intent label normalization.
-			"item_number": {IssueNumberOrTemporaryID: true},
-			"repo":        {Type: "string", MaxLength: 256}, // Optional: target repository in format "owner/repo"
+			"labels":       {Required: true, Type: "array"}, // Item-level validation/sanitization handled by JS issue-intent label normalization.
+			"item_number":  {IssueNumberOrTemporaryID: true},
+			"item_numbers": {Type: "array"},                  // Optional: batch targets (only accepted when batch: true); items validated by the JS handler
+			"repo":         {Type: "string", MaxLength: 256}, // Optional: target repository in format "owner/repo"
 		},
 	},
 	"add_reviewer": {
@@ -124,6 +126,14 @@ var ValidationConfig = map[string]TypeValidationConfig{
 			"repo":                {Type: "string", MaxLength: 256}, // Optional: target repository in format "owner/repo"
 		},
 	},
+	"request_team_review": {
+		DefaultMax: 3,
+		Fields: map[string]FieldValidation{
+			"teams":               {Required: true, Type: "array", ItemType: "string", ItemSanitize: true, ItemMaxLength: MaxGitHubTeamSlugLength},
+			"pull_request_number": {IssueOrPRNumber: true},
+			"repo":                {Type: "string", MaxLength: 256}, // Optional: target repository in format "owner/repo"
+		},
+	},
 	"assign_milestone": {
 		DefaultMax:       1,
 		CustomValidation: "requiresOneOf:milestone_number,milestone_title",
@@ -241,7 +251,8 @@ var ValidationConfig = map[string]TypeValidationConfig{
 			"pull_request_number": {OptionalPositiveInteger: true},
 			"start_line":          {OptionalPositiveInteger: true},
 			"side":                {Type: "string", Enum: []string{"LEFT", "RIGHT"}},
-			"repo":                {Type: "string", MaxLength: 256}, // Optional: target repository in format "owner/repo"
+			"suggestion":          {Type: "string", Sanitize: true, MaxLength: MaxBodyLength}, // Optional: replacement code rendered as a ```suggestion block
+			"repo":                {Type: "string", MaxLength: 256},                           // Optional: target repository in format "owner/repo"
 		},
 	},
 	"submit_pull_request_review": {
@@ -334,12 +345,27 @@ var ValidationConfig = map[string]TypeValidationConfig{
 			"path": {Required: true, Type: "string"},
 		},
 	},
+	"create_artifact": {
+		DefaultMax: 1,
+		Fields: map[string]FieldValidation{
+			"name":       {Required: true, Type: "string", MaxLength: 128, Pattern: "^[a-zA-Z0-9._-]+$", PatternError: "must contain only alphanumeric characters, dots, hyphens, and underscores"},
+			"size_bytes": {OptionalPositiveInteger: true},
+		},
+	},
 	"noop": {
 		DefaultMax: 1,
 		Fields: map[string]FieldValidation{
 			"message": {Required: true, Type: "string", Sanitize: true, MaxLength: MaxBodyLength},
 		},
 	},
+	"notify": {
+		DefaultMax: 1,
+		Fields: map[string]FieldValidation{
+			"channel": {Type: "string", MaxLength: 64},
+			"title":   {Type: "string", Sanitize: true, MaxLength: 256},
+			"message": {Required: true, Type: "string", Sanitize: true, MaxLength: 4000},
+		},
+	},
 	"create_code_scanning_alert": {
 		DefaultMax: 40,
 		Fields: map[string]FieldValidation{
@@ -392,6 +418,15 @@ var ValidationConfig = map[string]TypeValidationConfig{
 			"target_date": {Type: "string", Pattern: "^\\d{4}-\\d{2}-\\d{2}$", PatternError: "must be in YYYY-MM-DD format"},
 		},
 	},
+	"update_project_item": {
+		DefaultMax: 10,
+		Fields: map[string]FieldValidation{
+			"project":        {Required: true, Type: "string", Sanitize: true, MaxLength: 512, Pattern: "^https://[^/]+/(orgs|users)/[^/]+/projects/\\d+", PatternError: "must be a full GitHub project URL (e.g., https://github.com/orgs/myorg/projects/42)"},
+			"content_type":   {Required: true, Type: "string", Enum: []string{"issue", "pull_request"}},
+			"content_number": {Required: true, IssueNumberOrTemporaryID: true},
+			"fields":         {Required: true, Type: "object"},
+		},
+	},
 	"update_discussion": {
 		DefaultMax:       1,
 		CustomValidation: "requiresOneOf:title,body,labels",
@@ -406,9 +441,10 @@ var ValidationConfig = map[string]TypeValidationConfig{
 	"remove_labels": {
 		DefaultMax: 5,
 		Fields: map[string]FieldValidation{
-			"labels":      {Required: true, Type: "array"}, // Item-level validation/sanitization handled by JS issue-intent label normalization.
-			"item_number": {IssueNumberOrTemporaryID: true},
-			"repo":        {Type: "string", MaxLength: 256}, // Optional: target repository in format "owner/repo"
+			"labels":       {Required: true, Type: "array"}, // Item-level validation/sanitization handled by JS issue-intent label normalization.
+			"item_number":  {IssueNumberOrTemporaryID: true},
+			"item_numbers": {Type: "array"},                  // Optional: batch targets (only accepted when batch: true); items validated by the JS handler
+			"repo":         {Type: "string", MaxLength: 256}, // Optional: target repository in format "owner/repo"
 		},
 	},
 	"replace_label": {
@@ -429,6 +465,22 @@ var ValidationConfig = map[string]TypeValidationConfig{
 			"repo":         {Type: "string", MaxLength: 256}, // Optional: target repository in format "owner/repo"
 		},
 	},
+	"lock_issue": {
+		DefaultMax: 1,
+		Fields: map[string]FieldValidation{
+			"issue_number": {IssueOrPRNumber: true},
+			"reason":       {Type: "string", Enum: []string{"off-topic", "too heated", "resolved", "spam"}},
+			"repo":         {Type: "string", MaxLength: 256}, // Optional: target repository in format "owner/repo"
+		},
+	},
+	"lock_pull_request": {
+		DefaultMax: 1,
+		Fields: map[string]FieldValidation{
+			"pull_request_number": {IssueOrPRNumber: true},
+			"reason":              {Type: "string", Enum: []string{"off-topic", "too heated", "resolved", "spam"}},
+			"repo":                {Type: "string", MaxLength: 256}, // Optional: target repository in format "owner/repo"
+		},
+	},
 	"hide_comment": {
 		DefaultMax: 5,
 		Fields: map[string]FieldValidation{
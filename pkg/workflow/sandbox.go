@@ -60,6 +60,20 @@ const (
 	AgentRuntimeDockerSbx AgentRuntime = "docker-sbx"
 )
 
+// AgentFilesystemMode controls write access to the host filesystem inside the agent container.
+type AgentFilesystemMode string
+
+const (
+	// AgentFilesystemReadWrite is the default: user paths such as $GITHUB_WORKSPACE,
+	// $HOME, and /tmp are writable by the agent.
+	AgentFilesystemReadWrite AgentFilesystemMode = "read-write"
+
+	// AgentFilesystemReadOnly mounts the host filesystem read-only for the engine process
+	// and every command it spawns. Only the gh-aw directories that AWF manages (agent
+	// logs, safe outputs, upload staging) stay writable.
+	AgentFilesystemReadOnly AgentFilesystemMode = "read-only"
+)
+
 // AgentSandboxConfig represents the agent sandbox configuration
 type AgentSandboxConfig struct {
 	ID                    string                                `yaml:"id,omitempty"`             // Agent ID: "awf" or "srt" (replaces Type in new object format)
@@ -78,6 +92,8 @@ type AgentSandboxConfig struct {
 	Env                   map[string]string                     `yaml:"env,omitempty"`            // Environment variables to set on the step
 	Mounts                []string                              `yaml:"mounts,omitempty"`         // Container mounts to add for AWF (format: "source:dest:mode")
 	Memory                string                                `yaml:"memory,omitempty"`         // Memory limit for the AWF container (e.g., "4g", "8g")
+	Filesystem            AgentFilesystemMode                   `yaml:"filesystem,omitempty"`     // Host filesystem access for the agent: "read-write" (default) or "read-only"
+	BashNetwork           *bool                                 `yaml:"bash-network,omitempty"`   // Network access for commands spawned by the engine (nil = allowed through the firewall)
 	ModelFallback         *TemplatableBool                      `yaml:"model-fallback,omitempty"` // AWF API proxy model fallback enable/disable flag (optional)
 	Targets               map[string]*AgentAPIProxyTargetConfig `yaml:"targets,omitempty"`        // Per-provider API proxy target overrides keyed by provider name (e.g. "openai", "anthropic")
 }
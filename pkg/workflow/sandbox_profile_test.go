@@ -0,0 +1,117 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateAgentSandboxProfile(t *testing.T) {
+	bashNetworkOff := false
+	bashNetworkOn := true
+	tests := []struct {
+		name        string
+		agent       *AgentSandboxConfig
+		version     string
+		errContains string
+	}{
+		{
+			name:  "no profile",
+			agent: &AgentSandboxConfig{ID: "awf"},
+		},
+		{
+			name:  "read-only filesystem with default AWF",
+			agent: &AgentSandboxConfig{ID: "awf", Filesystem: AgentFilesystemReadOnly},
+		},
+		{
+			name:    "bash-network true on old AWF",
+			agent:   &AgentSandboxConfig{ID: "awf", BashNetwork: &bashNetworkOn},
+			version: "v0.25.0",
+		},
+		{
+			name:        "unknown filesystem mode",
+			agent:       &AgentSandboxConfig{ID: "awf", Filesystem: "none"},
+			errContains: "filesystem must be 'read-only' or 'read-write'",
+		},
+		{
+			name:        "read-only filesystem on old AWF",
+			agent:       &AgentSandboxConfig{ID: "awf", Filesystem: AgentFilesystemReadOnly},
+			version:     "v0.27.32",
+			errContains: "sandbox profile options require AWF v0.27.40 or newer",
+		},
+		{
+			name:        "bash-network false on old AWF",
+			agent:       &AgentSandboxConfig{ID: "awf", Version: "v0.25.0", BashNetwork: &bashNetworkOff},
+			errContains: "sandbox.agent.bash-network",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workflowData := &WorkflowData{SandboxConfig: &SandboxConfig{Agent: tt.agent}}
+			if tt.version != "" {
+				workflowData.NetworkPermissions = &NetworkPermissions{Firewall: &FirewallConfig{Enabled: true, Version: tt.version}}
+			}
+			err := validateAgentSandboxProfile(workflowData, tt.agent)
+			if tt.errContains == "" {
+				assert.NoError(t, err, "sandbox profile should be valid")
+				return
+			}
+			require.Error(t, err, "sandbox profile should be rejected")
+			assert.Contains(t, err.Error(), tt.errContains, "error should explain the rejection")
+		})
+	}
+}
+
+func TestBuildAWFArgsSandboxProfile(t *testing.T) {
+	bashNetworkOff := false
+	workflowData := &WorkflowData{
+		EngineConfig: &EngineConfig{ID: "copilot"},
+		SandboxConfig: &SandboxConfig{Agent: &AgentSandboxConfig{
+			ID:          "awf",
+			Filesystem:  AgentFilesystemReadOnly,
+			BashNetwork: &bashNetworkOff,
+		}},
+		NetworkPermissions: &NetworkPermissions{Firewall: &FirewallConfig{Enabled: true}},
+	}
+
+	args := BuildAWFArgs(AWFCommandConfig{EngineName: "copilot", WorkflowData: workflowData})
+	assert.Contains(t, args, "--read-only-filesystem", "read-only filesystem should be passed to AWF")
+	assert.Contains(t, args, "--no-bash-network", "disabled bash network should be passed to AWF")
+
+	workflowData.SandboxConfig.Agent = &AgentSandboxConfig{ID: "awf"}
+	args = BuildAWFArgs(AWFCommandConfig{EngineName: "copilot", WorkflowData: workflowData})
+	assert.NotContains(t, args, "--read-only-filesystem", "default profile should not restrict the filesystem")
+	assert.NotContains(t, args, "--no-bash-network", "default profile should not restrict bash network")
+}
+
+func TestSandboxProfileCompilation(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "sandbox-profile")
+	content := `---
+on: workflow_dispatch
+engine: copilot
+sandbox:
+  agent:
+    id: awf
+    filesystem: read-only
+    bash-network: false
+---
+
+# Review the code without changing it
+`
+	workflowFile := filepath.Join(tmpDir, "review.md")
+	require.NoError(t, os.WriteFile(workflowFile, []byte(content), 0644), "Failed to write workflow")
+	require.NoError(t, NewCompiler().CompileWorkflow(workflowFile), "workflow with sandbox profile should compile")
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(workflowFile))
+	require.NoError(t, err, "Failed to read lock file")
+	assert.Contains(t, string(lockContent), "--read-only-filesystem", "lock file should restrict the filesystem")
+	assert.Contains(t, string(lockContent), "--no-bash-network", "lock file should disable bash network")
+}
@@ -2,6 +2,7 @@
 //
 // This file contains domain-specific validation functions for sandbox configuration:
 //   - validateMountsSyntax() - Validates container mount syntax
+//   - validateAgentSandboxProfile() - Validates filesystem and bash-network profile options
 //   - validateSandboxConfig() - Validates complete sandbox configuration
 //
 // These validation functions are organized in a dedicated file following the validation
@@ -173,6 +174,10 @@ func validateSandboxConfig(workflowData *WorkflowData) error {
 		sandboxValidationLog.Print("docker-sbx runtime configured -- topology, sudo, and AWF version checks passed")
 	}
 
+	if err := validateAgentSandboxProfile(workflowData, agentConfig); err != nil {
+		return err
+	}
+
 	// Validate config structure if provided (deprecated - was only for SRT)
 	if sandboxConfig.Config != nil {
 		// Config is no longer used - SRT removed
@@ -250,3 +255,49 @@ func getFeatureValueCaseInsensitive(features map[string]any, flagName string) (a
 	}
 	return nil, false
 }
+
+// validateAgentSandboxProfile validates sandbox.agent.filesystem and sandbox.agent.bash-network.
+// Both are enforced by AWF flags, so they are rejected (rather than silently dropped) when the
+// effective AWF version predates those flags.
+func validateAgentSandboxProfile(workflowData *WorkflowData, agentConfig *AgentSandboxConfig) error {
+	if agentConfig == nil || agentConfig.Disabled {
+		return nil
+	}
+
+	var field string
+	switch agentConfig.Filesystem {
+	case "", AgentFilesystemReadWrite:
+	case AgentFilesystemReadOnly:
+		field = "sandbox.agent.filesystem"
+	default:
+		return NewValidationError(
+			"sandbox.agent.filesystem",
+			string(agentConfig.Filesystem),
+			"filesystem must be 'read-only' or 'read-write'",
+			"Use 'filesystem: read-only' to prevent the agent from modifying the checkout, or omit the field for the default read-write access.",
+		)
+	}
+	if isAgentBashNetworkDisabled(workflowData) && field == "" {
+		field = "sandbox.agent.bash-network"
+	}
+	if field == "" {
+		return nil
+	}
+
+	firewallConfig := getFirewallConfig(workflowData)
+	if !awfSupportsSandboxProfile(firewallConfig) {
+		effectiveVersion := string(constants.DefaultFirewallVersion)
+		if firewallConfig != nil && firewallConfig.Version != "" {
+			effectiveVersion = firewallConfig.Version
+		}
+		return NewValidationError(
+			field,
+			effectiveVersion,
+			fmt.Sprintf("sandbox profile options require AWF %s or newer", constants.AWFSandboxProfileMinVersion),
+			fmt.Sprintf("filesystem: read-only and bash-network: false are enforced with AWF flags that are only supported in AWF %s+.\n\nThe effective AWF version is %s. Set firewall.version or sandbox.agent.version to %s or newer.", constants.AWFSandboxProfileMinVersion, effectiveVersion, constants.AWFSandboxProfileMinVersion),
+		)
+	}
+
+	sandboxValidationLog.Printf("Agent sandbox profile: filesystem=%q, bash-network disabled=%t", agentConfig.Filesystem, isAgentBashNetworkDisabled(workflowData))
+	return nil
+}
@@ -0,0 +1,121 @@
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/typeutil"
+)
+
+var sanitizedInputsLog = logger.New("workflow:sanitized_inputs")
+
+// sanitizedInputsStepID is the activation step that exposes sanitized event fields
+// as ${{ steps.sanitized_inputs.outputs.<name> }}.
+const sanitizedInputsStepID = "sanitized_inputs"
+
+// defaultSanitizedInputMaxLength is the default maximum length of a sanitized field.
+const defaultSanitizedInputMaxLength = 20000
+
+var sanitizedInputNamePattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// sanitizedInputPathPattern matches event payload paths such as github.event.issue.body.
+var sanitizedInputPathPattern = regexp.MustCompile(`^github\.event(\.[a-zA-Z0-9_-]+)+$`)
+
+// SanitizedInputConfig describes one entry of the sanitized-inputs: frontmatter section.
+// The activation job reads the event payload field at Path, sanitizes it, and exposes
+// the result as a step output named Name.
+type SanitizedInputConfig struct {
+	Name      string `json:"name"`
+	Path      string `json:"path"`
+	MaxLength int    `json:"max_length"`
+	StripHTML bool   `json:"strip_html,omitempty"`
+	Allow     string `json:"allow,omitempty"`
+}
+
+// extractSanitizedInputsConfig parses the sanitized-inputs: section. Each entry is
+// either an event path string or an object with path, max-length, strip-html, and allow.
+// Entries are returned sorted by name so the generated step is deterministic.
+func extractSanitizedInputsConfig(frontmatter map[string]any) ([]SanitizedInputConfig, error) {
+	raw, exists := frontmatter["sanitized-inputs"]
+	if !exists || raw == nil {
+		return nil, nil
+	}
+	entries, ok := raw.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("sanitized-inputs must be an object mapping names to event fields, got %T", raw)
+	}
+
+	inputs := make([]SanitizedInputConfig, 0, len(entries))
+	for name, value := range entries {
+		if !sanitizedInputNamePattern.MatchString(name) {
+			return nil, fmt.Errorf("sanitized-inputs: invalid name %q: use letters, digits, and underscores, starting with a letter or underscore", name)
+		}
+		input := SanitizedInputConfig{Name: name, MaxLength: defaultSanitizedInputMaxLength}
+		switch v := value.(type) {
+		case string:
+			input.Path = v
+		case map[string]any:
+			input.Path, _ = v["path"].(string)
+			if rawMax, ok := v["max-length"]; ok {
+				maxLength, ok := typeutil.ParseIntValue(rawMax)
+				if !ok || maxLength <= 0 {
+					return nil, fmt.Errorf("sanitized-inputs.%s: max-length must be a positive integer", name)
+				}
+				input.MaxLength = maxLength
+			}
+			input.StripHTML, _ = v["strip-html"].(bool)
+			input.Allow, _ = v["allow"].(string)
+		default:
+			return nil, fmt.Errorf("sanitized-inputs.%s must be an event path or an object, got %T", name, value)
+		}
+
+		input.Path = strings.TrimSpace(input.Path)
+		if !sanitizedInputPathPattern.MatchString(input.Path) {
+			return nil, fmt.Errorf("sanitized-inputs.%s: path %q must be an event payload field such as github.event.issue.body", name, input.Path)
+		}
+		if input.Allow != "" {
+			if _, err := regexp.Compile(input.Allow); err != nil {
+				return nil, fmt.Errorf("sanitized-inputs.%s: invalid allow pattern %q: %w", name, input.Allow, err)
+			}
+		}
+		inputs = append(inputs, input)
+	}
+
+	slices.SortFunc(inputs, func(a, b SanitizedInputConfig) int { return strings.Compare(a.Name, b.Name) })
+	sanitizedInputsLog.Printf("Extracted %d sanitized input(s)", len(inputs))
+	return inputs, nil
+}
+
+// addActivationSanitizedInputsStep appends the step that sanitizes the configured
+// event payload fields. Its outputs are available to the prompt as
+// ${{ steps.sanitized_inputs.outputs.<name> }}.
+func (c *Compiler) addActivationSanitizedInputsStep(ctx *activationJobBuildContext) error {
+	if len(ctx.data.SanitizedInputs) == 0 {
+		return nil
+	}
+	inputsJSON, err := json.Marshal(ctx.data.SanitizedInputs)
+	if err != nil {
+		return fmt.Errorf("failed to marshal sanitized inputs: %w", err)
+	}
+	domainsStr, err := c.computeActivationSanitizationDomains(ctx.data)
+	if err != nil {
+		return err
+	}
+	sanitizedInputsLog.Printf("Adding sanitized inputs step for %d field(s)", len(ctx.data.SanitizedInputs))
+
+	ctx.steps = append(ctx.steps, "      - name: Sanitize event inputs\n")
+	ctx.steps = append(ctx.steps, fmt.Sprintf("        id: %s\n", sanitizedInputsStepID))
+	script := generateGitHubScriptWithRequire("sanitize_inputs.cjs")
+	ctx.steps = append(ctx.steps, fmt.Sprintf("        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", ctx.data), script)))
+	ctx.steps = append(ctx.steps, "        env:\n")
+	ctx.steps = append(ctx.steps, formatYAMLEnv("          ", "GH_AW_SANITIZED_INPUTS", string(inputsJSON)))
+	if domainsStr != "" {
+		ctx.steps = append(ctx.steps, formatYAMLEnv("          ", "GH_AW_ALLOWED_DOMAINS", domainsStr))
+	}
+	ctx.steps = append(ctx.steps, c.githubScriptWith(script))
+	return nil
+}
@@ -0,0 +1,144 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractSanitizedInputsConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		frontmatter map[string]any
+		want        []SanitizedInputConfig
+		wantErr     string
+	}{
+		{
+			name:        "absent",
+			frontmatter: map[string]any{},
+		},
+		{
+			name: "string and object entries",
+			frontmatter: map[string]any{"sanitized-inputs": map[string]any{
+				"issue_title": "github.event.issue.title",
+				"branch": map[string]any{
+					"path":       "github.event.pull_request.head.ref",
+					"max-length": 200,
+					"strip-html": true,
+					"allow":      "^[A-Za-z0-9._/-]+$",
+				},
+			}},
+			want: []SanitizedInputConfig{
+				{Name: "branch", Path: "github.event.pull_request.head.ref", MaxLength: 200, StripHTML: true, Allow: "^[A-Za-z0-9._/-]+$"},
+				{Name: "issue_title", Path: "github.event.issue.title", MaxLength: defaultSanitizedInputMaxLength},
+			},
+		},
+		{
+			name:        "invalid name",
+			frontmatter: map[string]any{"sanitized-inputs": map[string]any{"issue-title": "github.event.issue.title"}},
+			wantErr:     `invalid name "issue-title"`,
+		},
+		{
+			name:        "non-event path",
+			frontmatter: map[string]any{"sanitized-inputs": map[string]any{"token": "secrets.GITHUB_TOKEN"}},
+			wantErr:     "must be an event payload field",
+		},
+		{
+			name:        "invalid allow pattern",
+			frontmatter: map[string]any{"sanitized-inputs": map[string]any{"body": map[string]any{"path": "github.event.issue.body", "allow": "("}}},
+			wantErr:     "invalid allow pattern",
+		},
+		{
+			name:        "invalid max-length",
+			frontmatter: map[string]any{"sanitized-inputs": map[string]any{"body": map[string]any{"path": "github.event.issue.body", "max-length": 0}}},
+			wantErr:     "max-length must be a positive integer",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := extractSanitizedInputsConfig(tt.frontmatter)
+			if tt.wantErr != "" {
+				require.Error(t, err, "config should be rejected")
+				assert.Contains(t, err.Error(), tt.wantErr, "error message")
+				return
+			}
+			require.NoError(t, err, "config should be valid")
+			assert.Equal(t, tt.want, got, "parsed sanitized inputs")
+		})
+	}
+}
+
+func TestSanitizedInputsCompile(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "sanitized-inputs-test")
+
+	workflow := `---
+on:
+  issues:
+    types: [opened]
+engine: copilot
+permissions:
+  contents: read
+sanitized-inputs:
+  issue_body:
+    path: github.event.issue.body
+    max-length: 4000
+    strip-html: true
+---
+
+# Triage
+
+Triage this issue:
+
+${{ steps.sanitized_inputs.outputs.issue_body }}
+`
+	testFile := filepath.Join(tmpDir, "sanitized-inputs.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(workflow), 0644), "Failed to write test workflow")
+
+	compiler := NewCompiler(WithVersion("1.0.0"))
+	require.NoError(t, compiler.CompileWorkflow(testFile), "Failed to compile workflow")
+
+	lockContent, err := os.ReadFile(filepath.Join(tmpDir, "sanitized-inputs.lock.yml"))
+	require.NoError(t, err, "Failed to read lock file")
+	lock := string(lockContent)
+
+	assert.Contains(t, lock, "- name: Sanitize event inputs", "sanitize step should be generated")
+	assert.Contains(t, lock, "id: sanitized_inputs", "sanitize step should have a stable id")
+	assert.Contains(t, lock, `GH_AW_SANITIZED_INPUTS: "[{\"name\":\"issue_body\",\"path\":\"github.event.issue.body\",\"max_length\":4000,\"strip_html\":true}]"`, "sanitize step should receive the configuration")
+	assert.Contains(t, lock, "sanitize_inputs.cjs", "sanitize step should run the sanitize script")
+
+	sanitizeIdx := strings.Index(lock, "- name: Sanitize event inputs")
+	outputRef := strings.Index(lock, "steps.sanitized_inputs.outputs.issue_body")
+	assert.Less(t, sanitizeIdx, outputRef, "prompt should read the output after the sanitize step")
+}
+
+func TestSanitizedInputsAbsent(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "sanitized-inputs-absent-test")
+
+	workflow := `---
+on: workflow_dispatch
+engine: copilot
+permissions:
+  contents: read
+---
+
+# Test Workflow
+
+Do the work.
+`
+	testFile := filepath.Join(tmpDir, "no-sanitized-inputs.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(workflow), 0644), "Failed to write test workflow")
+
+	compiler := NewCompiler(WithVersion("1.0.0"))
+	require.NoError(t, compiler.CompileWorkflow(testFile), "Failed to compile workflow")
+
+	lockContent, err := os.ReadFile(filepath.Join(tmpDir, "no-sanitized-inputs.lock.yml"))
+	require.NoError(t, err, "Failed to read lock file")
+	assert.NotContains(t, string(lockContent), "Sanitize event inputs", "no sanitize step without sanitized-inputs")
+}
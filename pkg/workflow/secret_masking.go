@@ -2,6 +2,9 @@ package workflow
 
 import (
 	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
 	"strings"
 )
 
@@ -27,9 +30,21 @@ func (c *Compiler) extractSecretMaskingConfig(frontmatter map[string]any) *Secre
 				}
 			}
 
-			// Return nil if no steps were found
-			if len(config.Steps) == 0 {
-				secretMaskingLog.Print("No secret-masking steps found in frontmatter")
+			// Extract patterns array
+			if patterns, exists := secretMaskingMap["patterns"]; exists {
+				if patternsArray, ok := patterns.([]any); ok {
+					for _, pattern := range patternsArray {
+						if patternStr, ok := pattern.(string); ok {
+							config.Patterns = append(config.Patterns, patternStr)
+						}
+					}
+					secretMaskingLog.Printf("Extracted %d secret-masking patterns from frontmatter", len(config.Patterns))
+				}
+			}
+
+			// Return nil if no steps or patterns were found
+			if len(config.Steps) == 0 && len(config.Patterns) == 0 {
+				secretMaskingLog.Print("No secret-masking steps or patterns found in frontmatter")
 				return nil
 			}
 
@@ -55,7 +70,8 @@ func (c *Compiler) MergeSecretMasking(topConfig *SecretMaskingConfig, importedSe
 	if topConfig != nil {
 		result.Steps = make([]map[string]any, len(topConfig.Steps))
 		copy(result.Steps, topConfig.Steps)
-		secretMaskingLog.Printf("Starting with %d top-level steps", len(topConfig.Steps))
+		result.Patterns = slices.Clone(topConfig.Patterns)
+		secretMaskingLog.Printf("Starting with %d top-level steps and %d patterns", len(topConfig.Steps), len(topConfig.Patterns))
 	}
 
 	// Split by newlines to handle multiple JSON objects from different imports
@@ -80,13 +96,46 @@ func (c *Compiler) MergeSecretMasking(topConfig *SecretMaskingConfig, importedSe
 			result.Steps = append(result.Steps, importedConfig.Steps...)
 			secretMaskingLog.Printf("Merged %d steps from import", len(importedConfig.Steps))
 		}
+
+		// Append patterns from imported config, skipping duplicates
+		for _, pattern := range importedConfig.Patterns {
+			if !slices.Contains(result.Patterns, pattern) {
+				result.Patterns = append(result.Patterns, pattern)
+			}
+		}
 	}
 
-	if len(result.Steps) == 0 {
-		secretMaskingLog.Print("No secret-masking steps after merging")
+	if len(result.Steps) == 0 && len(result.Patterns) == 0 {
+		secretMaskingLog.Print("No secret-masking steps or patterns after merging")
 		return nil, nil
 	}
 
-	secretMaskingLog.Printf("Successfully merged secret-masking with %d total steps", len(result.Steps))
+	secretMaskingLog.Printf("Successfully merged secret-masking with %d total steps and %d patterns", len(result.Steps), len(result.Patterns))
 	return result, nil
 }
+
+// validateSecretMaskingPatterns checks that each secret-masking pattern is a valid
+// regular expression in the RE2 subset shared by Go and JavaScript, and that it
+// cannot match an empty string (which would redact between every character).
+func validateSecretMaskingPatterns(config *SecretMaskingConfig) error {
+	if config == nil {
+		return nil
+	}
+	for i, pattern := range config.Patterns {
+		field := fmt.Sprintf("secret-masking.patterns[%d]", i)
+		if strings.TrimSpace(pattern) == "" {
+			return NewValidationError(field, pattern, "pattern is empty", "Remove the entry or provide a regular expression such as: 'acme_[0-9a-f]{32}'")
+		}
+		if strings.Contains(pattern, "${{") {
+			return NewValidationError(field, pattern, "patterns cannot contain GitHub Actions expressions", "Write the pattern as a literal regular expression")
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return NewValidationError(field, pattern, fmt.Sprintf("invalid regular expression: %v", err), "Use RE2 syntax; lookarounds and backreferences are not supported")
+		}
+		if re.MatchString("") {
+			return NewValidationError(field, pattern, "pattern matches an empty string", "Require at least one character, for example: '[0-9a-f]{32}' instead of '[0-9a-f]*'")
+		}
+	}
+	return nil
+}
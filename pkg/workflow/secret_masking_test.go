@@ -279,3 +279,86 @@ func TestSecretMaskingIntegration(t *testing.T) {
 		t.Error("Expected step name but got nil")
 	}
 }
+
+func TestSecretMaskingPatterns(t *testing.T) {
+	c := NewCompiler()
+
+	config := c.extractSecretMaskingConfig(map[string]any{
+		"secret-masking": map[string]any{
+			"patterns": []any{"acme_[0-9a-f]{32}"},
+		},
+	})
+	if config == nil {
+		t.Fatal("Expected config with patterns only but got nil")
+	}
+	if len(config.Patterns) != 1 || config.Patterns[0] != "acme_[0-9a-f]{32}" {
+		t.Errorf("Expected extracted pattern but got %v", config.Patterns)
+	}
+
+	merged, err := c.MergeSecretMasking(config, `{"patterns":["acme_[0-9a-f]{32}","internal-key-[A-Z0-9]{20}"]}
+{"steps":[{"name":"Imported step","run":"echo imported"}]}`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []string{"acme_[0-9a-f]{32}", "internal-key-[A-Z0-9]{20}"}
+	if strings.Join(merged.Patterns, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected merged patterns %v but got %v", expected, merged.Patterns)
+	}
+	if len(merged.Steps) != 1 {
+		t.Errorf("Expected 1 merged step but got %d", len(merged.Steps))
+	}
+	if len(config.Patterns) != 1 {
+		t.Errorf("Expected top-level config to be unchanged but got %v", config.Patterns)
+	}
+}
+
+func TestValidateSecretMaskingPatterns(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		wantErr  string
+	}{
+		{name: "valid patterns", patterns: []string{"acme_[0-9a-f]{32}", `internal-key-\d{8}`}},
+		{name: "empty pattern", patterns: []string{" "}, wantErr: "pattern is empty"},
+		{name: "expression", patterns: []string{"${{ secrets.TOKEN }}"}, wantErr: "cannot contain GitHub Actions expressions"},
+		{name: "invalid regex", patterns: []string{"(unclosed"}, wantErr: "invalid regular expression"},
+		{name: "lookahead", patterns: []string{"key(?=[0-9])"}, wantErr: "invalid regular expression"},
+		{name: "matches empty string", patterns: []string{"acme_[0-9a-f]*", "x?"}, wantErr: "secret-masking.patterns[1]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSecretMaskingPatterns(&SecretMaskingConfig{Patterns: tt.patterns})
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Expected error containing %q but got nil", tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q but got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestSecretRedactionStepWithPatterns(t *testing.T) {
+	c := NewCompiler()
+	c.stepOrderTracker = NewStepOrderTracker()
+	var yaml strings.Builder
+
+	data := &WorkflowData{SecretMasking: &SecretMaskingConfig{Patterns: []string{"acme_[0-9a-f]{32}", `it's-\d+`}}}
+	c.generateSecretRedactionStep(&yaml, "", data)
+
+	result := yaml.String()
+	if strings.Contains(result, "No secrets to redact") {
+		t.Errorf("Expected a redaction script step when patterns are configured.\nGenerated YAML:\n%s", result)
+	}
+	expected := `GH_AW_SECRET_PATTERNS: '["acme_[0-9a-f]{32}","it''s-\\d+"]'`
+	if !strings.Contains(result, expected) {
+		t.Errorf("Expected YAML to contain %q.\nGenerated YAML:\n%s", expected, result)
+	}
+}
@@ -0,0 +1,630 @@
+package workflow
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/gitutil"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var sharedActionsLog = logger.New("workflow:shared_actions")
+
+// SharedActionsDir is the repository directory shared composite actions are written to.
+const SharedActionsDir = ".github/actions/gh-aw"
+
+// SharedActionHeader is the first line of every generated shared action. Directories whose
+// action.yml starts with it are managed by the compiler.
+const SharedActionHeader = "# This file was automatically generated by gh-aw. DO NOT EDIT."
+
+// sharedActionsSnapshotDir is where jobs keep a copy of the shared actions while steps that
+// may change the workspace run.
+const sharedActionsSnapshotDir = "${RUNNER_TEMP}/gh-aw/shared-actions"
+
+// githubScriptIndent is the indentation of script lines in a generated github-script step.
+const githubScriptIndent = "            "
+
+// sharedActionScriptRequirePattern matches a require of a setup action script, capturing the
+// script name without its extension.
+var sharedActionScriptRequirePattern = regexp.MustCompile(`require\('` + regexp.QuoteMeta(SetupActionDestination) + `/([A-Za-z0-9_.-]+)\.cjs'\)`)
+
+// sharedActionShippedScriptPattern matches a run step that only invokes a script installed
+// by the setup action. Such steps do not change the workspace.
+var sharedActionShippedScriptPattern = regexp.MustCompile(`^bash "\$\{RUNNER_TEMP\}/gh-aw/actions/[A-Za-z0-9_.-]+\.sh"`)
+
+// sharedActionStepOutputPattern matches a reference to a step output.
+var sharedActionStepOutputPattern = regexp.MustCompile(`steps\.([A-Za-z0-9_-]+)\.outputs\.([A-Za-z0-9_-]+)`)
+
+// workspaceChangingHandlerScripts are handler scripts that check out, fetch, or apply
+// changes to files in the workspace, directly or through the handlers they load.
+var workspaceChangingHandlerScripts = []string{
+	"apply_samples",
+	"check_workflow_recompile_needed",
+	"checkout_pr_branch",
+	"create_pull_request",
+	"dynamic_checkout",
+	"generate_git_bundle",
+	"generate_git_patch",
+	"handle_agent_failure",
+	"merge_remote_agent_github_folder",
+	"push_experiment_state",
+	"push_repo_memory",
+	"push_to_pull_request_branch",
+	"run_operation_update_upgrade",
+	"safe_output_handler_manager",
+	"setup_threat_detection",
+	"upload_assets",
+}
+
+// workspaceNeutralActions are actions that do not write to the workspace.
+var workspaceNeutralActions = []string{
+	"actions/cache/save",
+	"actions/upload-artifact",
+}
+
+// pathWritingActions are actions that only write to the paths given in their "path" input.
+var pathWritingActions = []string{
+	"actions/cache/restore",
+	"actions/download-artifact",
+}
+
+// sharedAction is a composite action that runs one handler script in actions/github-script.
+type sharedAction struct {
+	githubScript string   // pinned actions/github-script reference, with its version comment
+	script       []string // script lines, without indentation
+	outputs      []string // outputs read by the compiled workflow, sorted
+}
+
+// jobStep is one step of a job: its YAML text and the fields that decide how it affects the
+// workspace.
+type jobStep struct {
+	text   string
+	fields jobStepFields
+	shared string // name of the shared action the step uses, if any
+}
+
+// jobStepFields are the step fields read when placing the shared action steps.
+type jobStepFields struct {
+	ID   string         `yaml:"id"`
+	Uses string         `yaml:"uses"`
+	Run  string         `yaml:"run"`
+	With map[string]any `yaml:"with"`
+}
+
+// workspaceState tracks whether a job's workspace holds the shared actions.
+type workspaceState int
+
+const (
+	// workspaceFresh means nothing has been written to the workspace yet, so the shared
+	// actions can be checked out before the first step that uses them.
+	workspaceFresh workspaceState = iota
+	// workspaceTrusted means the workspace holds the repository's .github directory as
+	// checked out for this run.
+	workspaceTrusted
+	// workspaceTainted means the workspace may hold other files, such as a pull request
+	// branch or files written by the agent, so the shared actions must be restored before
+	// they run.
+	workspaceTainted
+)
+
+// sharedScript returns the shared action that runs a github-script step's script, given
+// with the indentation of a generated step. Scripts that read expressions other than
+// runner.temp stay in the step, since a composite action cannot see the step's contexts.
+func (c *Compiler) sharedScript(script string) (string, []string, bool) {
+	if !c.sharedActions {
+		return "", nil, false
+	}
+	var lines []string
+	for line := range strings.SplitSeq(strings.TrimRight(script, "\n"), "\n") {
+		lines = append(lines, strings.TrimPrefix(line, githubScriptIndent))
+	}
+	text := strings.Join(lines, "\n")
+	if strings.TrimSpace(text) == "" || strings.Contains(strings.ReplaceAll(text, "${{ runner.temp }}", ""), "${{") {
+		return "", nil, false
+	}
+	return sharedActionName(lines), lines, true
+}
+
+// recordSharedAction records a shared action to be written next to the lock file.
+func (c *Compiler) recordSharedAction(name, githubScriptPin string, script []string) {
+	if c.pendingSharedActions == nil {
+		c.pendingSharedActions = make(map[string]*sharedAction)
+	}
+	if _, found := c.pendingSharedActions[name]; !found {
+		c.pendingSharedActions[name] = &sharedAction{githubScript: githubScriptPin, script: script}
+	}
+}
+
+// sharedActionName names the action after the handler script it runs. Scripts other than
+// the standard handler entry point get a content hash suffix so that every distinct script
+// has its own action.
+func sharedActionName(script []string) string {
+	entry := ""
+	for _, match := range sharedActionScriptRequirePattern.FindAllStringSubmatch(strings.Join(script, "\n"), -1) {
+		if match[1] != "setup_globals" {
+			entry = match[1]
+			break
+		}
+	}
+	if entry != "" && slices.Equal(script, standardHandlerScript(entry)) {
+		return strings.ReplaceAll(entry, "_", "-")
+	}
+	sum := sha256.Sum256([]byte(strings.Join(script, "\n")))
+	base := "handler"
+	if entry != "" {
+		base = strings.ReplaceAll(entry, "_", "-")
+	}
+	return base + "-" + hex.EncodeToString(sum[:])[:8]
+}
+
+// standardHandlerScript returns the script the compiler generates to run a handler's main().
+func standardHandlerScript(entry string) []string {
+	return []string{
+		"const { setupGlobals } = require('" + SetupActionDestination + "/setup_globals.cjs');",
+		"setupGlobals(core, github, context, exec, io, getOctokit);",
+		"const { main } = require('" + SetupActionDestination + "/" + entry + ".cjs');",
+		"await main();",
+	}
+}
+
+// placeSharedActionSteps makes the shared actions available to the steps that use them.
+//
+// Local actions are loaded from the workspace, so a step using a shared action only runs
+// while the job's workspace holds the actions as checked out from the repository and ref
+// running the workflow. Jobs check the actions out before their first shared step, save a
+// copy to the runner's temporary directory before any step that may change the workspace,
+// and restore that copy before the next shared step. Shared steps that would need a restore
+// before the agent runs go back to running their script inline. Actions no step uses are
+// dropped, and each action exposes the outputs the workflow reads from its steps.
+func (c *Compiler) placeSharedActionSteps(data *WorkflowData) error {
+	if len(c.pendingSharedActions) == 0 {
+		return nil
+	}
+	used := make(map[string]bool)
+	outputs := make(map[string]map[string]bool)
+	for _, job := range c.jobManager.GetAllJobs() {
+		if len(job.Steps) == 0 {
+			continue
+		}
+		steps, err := parseJobSteps(strings.Join(job.Steps, ""))
+		if err != nil {
+			return fmt.Errorf("failed to parse steps of job %s: %w", job.Name, err)
+		}
+		steps, checkedOut := c.placeJobSharedActions(steps, data)
+		var text strings.Builder
+		stepActions := make(map[string]string) // step id -> shared action name
+		for _, step := range steps {
+			text.WriteString(step.text)
+			if step.shared == "" {
+				continue
+			}
+			used[step.shared] = true
+			if step.fields.ID != "" {
+				stepActions[step.fields.ID] = step.shared
+			}
+		}
+		job.Steps = []string{text.String()}
+		if checkedOut {
+			job.Permissions = withContentsRead(job.Permissions)
+		}
+
+		// Expose every output the job reads from a shared step
+		references := text.String() + strings.Join(slices.Collect(maps.Values(job.Outputs)), "\n")
+		for _, match := range sharedActionStepOutputPattern.FindAllStringSubmatch(references, -1) {
+			if name, ok := stepActions[match[1]]; ok {
+				if outputs[name] == nil {
+					outputs[name] = make(map[string]bool)
+				}
+				outputs[name][match[2]] = true
+			}
+		}
+	}
+
+	maps.DeleteFunc(c.pendingSharedActions, func(name string, _ *sharedAction) bool { return !used[name] })
+	for name, action := range c.pendingSharedActions {
+		action.outputs = slices.Sorted(maps.Keys(outputs[name]))
+	}
+	sharedActionsLog.Printf("Placed %d shared actions", len(c.pendingSharedActions))
+	return nil
+}
+
+// parseJobSteps splits the steps of a job into one entry per step.
+func parseJobSteps(content string) ([]jobStep, error) {
+	var steps []jobStep
+	var current []string
+	flush := func() error {
+		if len(current) == 0 {
+			return nil
+		}
+		text := strings.Join(current, "")
+		var parsed []jobStepFields
+		if err := yaml.Unmarshal([]byte(text), &parsed); err != nil {
+			return err
+		}
+		step := jobStep{text: text}
+		if len(parsed) == 1 {
+			step.fields = parsed[0]
+		}
+		if name, ok := strings.CutPrefix(step.fields.Uses, "./"+SharedActionsDir+"/"); ok {
+			step.shared = name
+		}
+		steps = append(steps, step)
+		current = nil
+		return nil
+	}
+	for line := range strings.SplitAfterSeq(content, "\n") {
+		if strings.HasPrefix(line, "      - ") {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+		current = append(current, line)
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return steps, nil
+}
+
+// placeJobSharedActions adds the steps that check out, save, and restore the shared actions
+// around the shared steps of one job, and reports whether the job checks them out.
+func (c *Compiler) placeJobSharedActions(steps []jobStep, data *WorkflowData) ([]jobStep, bool) {
+	lastShared := -1
+	lastAgent := -1
+	for k, step := range steps {
+		if step.shared != "" {
+			lastShared = k
+		}
+		if strings.HasSuffix(step.fields.ID, "agentic_execution") {
+			lastAgent = k
+		}
+	}
+	if lastShared < 0 {
+		return steps, false
+	}
+
+	var result []jobStep
+	checkedOut := false
+	state := workspaceFresh
+	saved := false
+	for k, step := range steps {
+		// Restoring the actions would show up as changes in the agent's workspace
+		canRestore := saved && k > lastAgent
+		if step.shared != "" && state == workspaceTainted && !canRestore {
+			step = c.inlineSharedStep(step)
+		}
+		if step.shared == "" {
+			next := c.nextWorkspaceState(step, state)
+			if next == workspaceTainted && state != workspaceTainted && !saved && k < lastShared {
+				// Keep a copy of the actions for the shared steps after this one
+				if state == workspaceFresh {
+					result = append(result, sharedActionsCheckoutStep(data))
+					checkedOut = true
+				}
+				result = append(result, sharedActionsSaveStep())
+				saved = true
+				next = c.nextWorkspaceState(step, workspaceTrusted)
+			}
+			result = append(result, step)
+			state = next
+			continue
+		}
+
+		switch state {
+		case workspaceFresh:
+			result = append(result, sharedActionsCheckoutStep(data))
+			checkedOut = true
+		case workspaceTainted:
+			result = append(result, sharedActionsRestoreStep())
+		}
+		next := c.nextWorkspaceState(step, workspaceTrusted)
+		if next == workspaceTainted && !saved && k < lastShared {
+			result = append(result, sharedActionsSaveStep())
+			saved = true
+		}
+		result = append(result, step)
+		state = next
+	}
+	return result, checkedOut
+}
+
+// inlineSharedStep returns a shared step that runs its action's script in
+// actions/github-script instead.
+func (c *Compiler) inlineSharedStep(step jobStep) jobStep {
+	action := c.pendingSharedActions[step.shared]
+	var script strings.Builder
+	script.WriteString("          script: |\n")
+	for _, line := range action.script {
+		if line != "" {
+			script.WriteString(githubScriptIndent + line)
+		}
+		script.WriteString("\n")
+	}
+
+	lines := strings.SplitAfter(step.text, "\n")
+	var text strings.Builder
+	inWith := false
+	hasWith := false
+	for _, line := range lines {
+		if inWith && !strings.HasPrefix(line, "          ") {
+			text.WriteString(script.String())
+			inWith = false
+		}
+		switch {
+		case strings.TrimSpace(line) == "uses: ./"+SharedActionsDir+"/"+step.shared:
+			text.WriteString(line[:strings.Index(line, "uses:")] + "uses: " + action.githubScript + "\n")
+		case line == "        with:\n":
+			inWith = true
+			hasWith = true
+			text.WriteString(line)
+		default:
+			text.WriteString(line)
+		}
+	}
+	if inWith {
+		text.WriteString(script.String())
+	} else if !hasWith {
+		text.WriteString("        with:\n" + script.String())
+	}
+	step.text = text.String()
+	step.fields.Uses = action.githubScript
+	step.fields.With = map[string]any{"script": strings.Join(action.script, "\n")}
+	step.shared = ""
+	return step
+}
+
+// nextWorkspaceState returns the workspace state after a step runs.
+func (c *Compiler) nextWorkspaceState(step jobStep, state workspaceState) workspaceState {
+	uses := step.fields.Uses
+	with := step.fields.With
+	switch {
+	case step.shared != "":
+		return scriptWorkspaceState(strings.Join(c.pendingSharedActions[step.shared].script, "\n"), state)
+	case strings.HasPrefix(uses, "actions/checkout@"):
+		path := withValue(with, "path")
+		if path != "" && path != "." {
+			// Checking out to a subdirectory leaves the workspace root alone, but a later
+			// checkout of the shared actions would delete it
+			if state == workspaceFresh && path != handlerScriptsCheckoutPath && !filepath.IsAbs(path) {
+				return workspaceTainted
+			}
+			return state
+		}
+		if isRepositoryCheckout(with) {
+			return workspaceTrusted
+		}
+		return workspaceTainted
+	case strings.HasPrefix(uses, "actions/github-script@"):
+		return scriptWorkspaceState(withValue(with, "script"), state)
+	case isSetupActionReference(uses):
+		return state
+	case slices.Contains(workspaceNeutralActions, actionRepoWithPath(uses)):
+		return state
+	case slices.Contains(pathWritingActions, actionRepoWithPath(uses)):
+		paths := strings.Fields(withValue(with, "path"))
+		if len(paths) == 0 {
+			return workspaceTainted
+		}
+		for _, path := range paths {
+			if !isOutsideWorkspace(path) {
+				return workspaceTainted
+			}
+		}
+		return state
+	case uses == "" && isWorkspaceNeutralRun(step.fields.Run):
+		return state
+	default:
+		return workspaceTainted
+	}
+}
+
+// scriptWorkspaceState returns the workspace state after a github-script step runs script.
+func scriptWorkspaceState(script string, state workspaceState) workspaceState {
+	for _, match := range sharedActionScriptRequirePattern.FindAllStringSubmatch(script, -1) {
+		if slices.Contains(workspaceChangingHandlerScripts, match[1]) {
+			return workspaceTainted
+		}
+	}
+	return state
+}
+
+// withValue returns a with input as a string, or "" when it is not set.
+func withValue(with map[string]any, key string) string {
+	value, ok := with[key]
+	if !ok || value == nil {
+		return ""
+	}
+	return strings.TrimSpace(fmt.Sprint(value))
+}
+
+// actionRepoWithPath returns the action reference without its version.
+func actionRepoWithPath(uses string) string {
+	ref, _, _ := strings.Cut(uses, "@")
+	return ref
+}
+
+// isOutsideWorkspace reports whether a path input refers to a location outside the
+// workspace, such as the runner's temporary directory.
+func isOutsideWorkspace(path string) bool {
+	path = strings.Trim(path, `"'`)
+	return filepath.IsAbs(path) || strings.HasPrefix(path, "${{ runner.temp }}") || strings.HasPrefix(path, "${RUNNER_TEMP}")
+}
+
+// isRepositoryCheckout reports whether a checkout at the workspace root fetches the
+// repository and ref running the workflow with its .github directory.
+func isRepositoryCheckout(with map[string]any) bool {
+	if repository := withValue(with, "repository"); repository != "" && repository != "${{ github.repository }}" {
+		return false
+	}
+	if withValue(with, "ref") != "" {
+		return false
+	}
+	sparse, ok := with["sparse-checkout"]
+	if !ok {
+		return true
+	}
+	return slices.ContainsFunc(strings.Fields(fmt.Sprint(sparse)), isGitHubDirPattern)
+}
+
+func isGitHubDirPattern(pattern string) bool {
+	pattern = strings.TrimSuffix(pattern, "/")
+	return pattern == ".github" || pattern == ".github/actions" || pattern == SharedActionsDir
+}
+
+// isSetupActionReference reports whether uses refers to the gh-aw setup action.
+func isSetupActionReference(uses string) bool {
+	ref, _, _ := strings.Cut(uses, "@")
+	return strings.HasSuffix(ref, "/actions/setup") || strings.HasSuffix(ref, "/setup") && strings.Contains(ref, "gh-aw")
+}
+
+// isWorkspaceNeutralRun reports whether a run step only runs a script installed by the setup
+// action or installs checked-in handler scripts, neither of which changes the workspace.
+func isWorkspaceNeutralRun(run string) bool {
+	run = strings.TrimSpace(run)
+	if !strings.Contains(run, "\n") {
+		return sharedActionShippedScriptPattern.MatchString(run)
+	}
+	return strings.HasPrefix(run, "cp "+handlerScriptsCheckoutPath+"/")
+}
+
+// sharedActionsCheckoutStep returns the step that checks out the shared actions from the
+// repository and ref running the workflow. Like the steps that save and restore them, it
+// runs even after a failure so that shared steps with an always() condition can run.
+func sharedActionsCheckoutStep(data *WorkflowData) jobStep {
+	return jobStep{text: "      - name: Checkout shared actions\n" +
+		"        if: always()\n" +
+		"        uses: " + getCachedActionPin("actions/checkout", data) + "\n" +
+		"        with:\n" +
+		"          sparse-checkout: |\n" +
+		"            " + SharedActionsDir + "\n" +
+		"          persist-credentials: false\n"}
+}
+
+// sharedActionsSaveStep returns the step that copies the shared actions out of the workspace
+// before a step that may change it.
+func sharedActionsSaveStep() jobStep {
+	return jobStep{text: "      - name: Save shared actions\n" +
+		"        if: always()\n" +
+		"        run: rm -rf \"" + sharedActionsSnapshotDir + "\" && mkdir -p \"${RUNNER_TEMP}/gh-aw\" && cp -R " + SharedActionsDir + " \"" + sharedActionsSnapshotDir + "\"\n"}
+}
+
+// sharedActionsRestoreStep returns the step that replaces the shared actions in the
+// workspace with the saved copy.
+func sharedActionsRestoreStep() jobStep {
+	return jobStep{text: "      - name: Restore shared actions\n" +
+		"        if: always()\n" +
+		"        run: rm -rf " + SharedActionsDir + " && mkdir -p " + filepath.Dir(SharedActionsDir) + " && cp -R \"" + sharedActionsSnapshotDir + "\" " + SharedActionsDir + "\n"}
+}
+
+// withContentsRead returns job permissions that also allow checking out the shared actions.
+// A job without its own permissions inherits the workflow's empty permissions, so it gets
+// contents: read alone.
+func withContentsRead(permissions string) string {
+	perms := NewPermissionsParser(permissions).ToPermissions()
+	if level, ok := perms.Get(PermissionContents); ok && (level == PermissionRead || level == PermissionWrite) {
+		return permissions
+	}
+	perms.Set(PermissionContents, PermissionRead)
+	return perms.RenderToYAML()
+}
+
+// render returns the action.yml content. Outputs already declared in existing are kept so
+// that workflows compiled earlier keep reading them.
+func (a *sharedAction) render(name string, existing []byte) string {
+	outputs := slices.Clone(a.outputs)
+	if len(existing) > 0 {
+		var previous struct {
+			Outputs map[string]any `yaml:"outputs"`
+		}
+		if err := yaml.Unmarshal(existing, &previous); err == nil {
+			outputs = append(outputs, slices.Collect(maps.Keys(previous.Outputs))...)
+		}
+	}
+	slices.Sort(outputs)
+	outputs = slices.Compact(outputs)
+
+	var b strings.Builder
+	b.WriteString(SharedActionHeader + "\n")
+	b.WriteString("# To regenerate, run: gh aw compile --shared-actions\n")
+	fmt.Fprintf(&b, "name: gh-aw %s\n", name)
+	b.WriteString("description: Runs a gh-aw handler script in actions/github-script\n")
+	b.WriteString("inputs:\n")
+	b.WriteString("  github-token:\n")
+	b.WriteString("    description: Token for the GitHub client\n")
+	b.WriteString("    required: false\n")
+	b.WriteString("    default: ${{ github.token }}\n")
+	if len(outputs) > 0 {
+		b.WriteString("outputs:\n")
+		for _, output := range outputs {
+			fmt.Fprintf(&b, "  %s:\n", output)
+			fmt.Fprintf(&b, "    value: ${{ steps.script.outputs.%s }}\n", output)
+		}
+	}
+	b.WriteString("runs:\n")
+	b.WriteString("  using: composite\n")
+	b.WriteString("  steps:\n")
+	b.WriteString("    - id: script\n")
+	fmt.Fprintf(&b, "      uses: %s\n", a.githubScript)
+	b.WriteString("      with:\n")
+	b.WriteString("        github-token: ${{ inputs.github-token }}\n")
+	b.WriteString("        script: |\n")
+	for _, line := range a.script {
+		if line == "" {
+			b.WriteString("\n")
+			continue
+		}
+		b.WriteString("          " + line + "\n")
+	}
+	return b.String()
+}
+
+// writeSharedActions writes the shared actions recorded for the current workflow to
+// .github/actions/gh-aw in the repository containing markdownPath. Files whose content is
+// unchanged are left untouched.
+func (c *Compiler) writeSharedActions(markdownPath string) error {
+	if len(c.pendingSharedActions) == 0 {
+		return nil
+	}
+	repoRoot, err := gitutil.FindGitRootFrom(filepath.Dir(markdownPath))
+	if err != nil {
+		// Not in a git repository: navigate up from .github/workflows to the repository root
+		repoRoot = filepath.Join(filepath.Dir(markdownPath), "..", "..")
+	}
+	written := 0
+	for _, name := range slices.Sorted(maps.Keys(c.pendingSharedActions)) {
+		dir := filepath.Join(repoRoot, SharedActionsDir, name)
+		path := filepath.Join(dir, "action.yml")
+		existing, _ := os.ReadFile(path)
+		content := c.pendingSharedActions[name].render(name, existing)
+		if c.handlerScriptMode == HandlerScriptModeInline {
+			if content, err = inlineHandlerScripts(content, c.handlerScriptSources); err != nil {
+				return fmt.Errorf("failed to inline handler scripts in shared action %s: %w", name, err)
+			}
+		}
+		if string(existing) == content {
+			continue
+		}
+		if err := os.MkdirAll(dir, constants.DirPermPublic); err != nil {
+			return fmt.Errorf("failed to create %s: %w", filepath.Join(SharedActionsDir, name), err)
+		}
+		if err := os.WriteFile(path, []byte(content), constants.FilePermPublic); err != nil {
+			return fmt.Errorf("failed to write shared action %s: %w", name, err)
+		}
+		if c.fileTracker != nil {
+			c.fileTracker.TrackCreated(path)
+		}
+		written++
+	}
+	sharedActionsLog.Printf("Wrote %d of %d shared actions to %s", written, len(c.pendingSharedActions), SharedActionsDir)
+	return nil
+}
@@ -0,0 +1,257 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testGitHubScriptPin = "actions/github-script@v9"
+
+const testTokenInput = "          github-token: ${{ secrets.GH_AW_GITHUB_TOKEN }}\n"
+
+// testHandlerScript returns the indented script of a generated step that runs entry's main().
+func testHandlerScript(entry string) string {
+	var script strings.Builder
+	for _, line := range standardHandlerScript(entry) {
+		script.WriteString(githubScriptIndent + line + "\n")
+	}
+	return script.String()
+}
+
+// testHandlerStep generates a github-script step the way the compiler's step generators do.
+func testHandlerStep(compiler *Compiler, name, id, entry string, tokenInputs ...string) string {
+	script := testHandlerScript(entry)
+	step := "      - name: " + name + "\n"
+	if id != "" {
+		step += "        id: " + id + "\n"
+	}
+	step += "        uses: " + compiler.githubScriptUses(testGitHubScriptPin, script) + "\n"
+	step += compiler.githubScriptWith(script, tokenInputs...)
+	return step
+}
+
+// placeTestJob adds a job with the given steps and places the shared actions in it.
+func placeTestJob(t *testing.T, compiler *Compiler, job *Job) *Job {
+	t.Helper()
+	require.NoError(t, compiler.jobManager.AddJob(job), "job should be added")
+	require.NoError(t, compiler.placeSharedActionSteps(&WorkflowData{}), "shared actions should be placed")
+	return job
+}
+
+func TestGitHubScriptStepWithoutSharedActions(t *testing.T) {
+	compiler := NewCompiler()
+	step := testHandlerStep(compiler, "Process no-op messages", "noop", "noop", testTokenInput)
+
+	assert.Equal(t, "      - name: Process no-op messages\n"+
+		"        id: noop\n"+
+		"        uses: "+testGitHubScriptPin+"\n"+
+		"        with:\n"+
+		testTokenInput+
+		"          script: |\n"+
+		testHandlerScript("noop"), step, "the step should run its script in actions/github-script")
+	assert.Nil(t, compiler.pendingSharedActions, "no actions should be recorded")
+}
+
+func TestGitHubScriptStepWithSharedActions(t *testing.T) {
+	compiler := NewCompiler()
+	compiler.SetSharedActions(true)
+
+	withToken := testHandlerStep(compiler, "Process no-op messages", "noop", "noop", testTokenInput)
+	assert.Equal(t, "      - name: Process no-op messages\n"+
+		"        id: noop\n"+
+		"        uses: ./.github/actions/gh-aw/noop\n"+
+		"        with:\n"+
+		testTokenInput, withToken, "the step should pass only its token to the shared action")
+
+	withoutToken := testHandlerStep(compiler, "Record missing tool", "", "missing_tool")
+	assert.Equal(t, "      - name: Record missing tool\n"+
+		"        uses: ./.github/actions/gh-aw/missing-tool\n", withoutToken, "a step without a token should have no with section")
+
+	require.Contains(t, compiler.pendingSharedActions, "noop", "the action should be recorded")
+	assert.Equal(t, testGitHubScriptPin, compiler.pendingSharedActions["noop"].githubScript, "the pinned github-script should be kept")
+	assert.Equal(t, standardHandlerScript("noop"), compiler.pendingSharedActions["noop"].script, "the script should be recorded without indentation")
+}
+
+func TestGitHubScriptStepNonStandardScript(t *testing.T) {
+	compiler := NewCompiler()
+	compiler.SetSharedActions(true)
+
+	script := strings.Replace(testHandlerScript("generate_aw_info"), "await main();", "await main(core, context);", 1)
+	assert.Regexp(t, `^\./\.github/actions/gh-aw/generate-aw-info-[0-9a-f]{8}$`, compiler.githubScriptUses(testGitHubScriptPin, script),
+		"scripts other than the standard entry point should get a content hash")
+
+	inline := githubScriptIndent + "core.info(\"${{ github.event.issue.title }}\");\n"
+	assert.Equal(t, testGitHubScriptPin, compiler.githubScriptUses(testGitHubScriptPin, inline), "scripts with expressions should stay inline")
+	assert.Equal(t, "        with:\n          script: |\n"+inline, compiler.githubScriptWith(inline), "scripts with expressions should keep their script")
+	assert.Len(t, compiler.pendingSharedActions, 1, "only the shareable script should be recorded")
+}
+
+func TestPlaceSharedActionSteps(t *testing.T) {
+	compiler := NewCompiler()
+	compiler.SetSharedActions(true)
+
+	job := placeTestJob(t, compiler, &Job{
+		Name:    "conclusion",
+		RunsOn:  "runs-on: ubuntu-slim",
+		Outputs: map[string]string{"noop_message": "${{ steps.noop.outputs.noop_message }}"},
+		Steps: []string{
+			testHandlerStep(compiler, "Process no-op messages", "noop", "noop", testTokenInput),
+			testHandlerStep(compiler, "Record missing tool", "", "missing_tool"),
+		},
+	})
+
+	steps := strings.Join(job.Steps, "")
+	assert.True(t, strings.HasPrefix(steps, "      - name: Checkout shared actions\n"), "the job should check out the shared actions first")
+	assert.Equal(t, 1, strings.Count(steps, "Checkout shared actions"), "the actions should be checked out once per job")
+	assert.Contains(t, steps, "uses: ./.github/actions/gh-aw/noop", "the shared step should be kept")
+	assert.Equal(t, "permissions:\n      contents: read", job.Permissions, "the job should be allowed to check out the actions")
+
+	require.Contains(t, compiler.pendingSharedActions, "noop", "the action should be kept")
+	assert.Equal(t, []string{"noop_message"}, compiler.pendingSharedActions["noop"].outputs, "outputs read by the job should be exposed")
+	assert.Empty(t, compiler.pendingSharedActions["missing-tool"].outputs, "unread outputs should not be declared")
+}
+
+func TestPlaceSharedActionStepsWorkspaceChanges(t *testing.T) {
+	compiler := NewCompiler()
+	compiler.SetSharedActions(true)
+
+	job := placeTestJob(t, compiler, &Job{
+		Name:        "agent",
+		Permissions: "permissions:\n      contents: read",
+		Steps: []string{
+			"      - name: Checkout repository\n" +
+				"        uses: actions/checkout@v6\n" +
+				"        with:\n" +
+				"          persist-credentials: false\n",
+			testHandlerStep(compiler, "Checkout PR branch", "checkout-pr", "checkout_pr_branch"),
+			testHandlerStep(compiler, "Determine automatic lockdown mode", "", "determine_automatic_lockdown"),
+			"      - name: Execute agent\n" +
+				"        id: agentic_execution\n" +
+				"        run: |\n" +
+				"          agent --prompt prompt.txt\n",
+			testHandlerStep(compiler, "Redact secrets in logs", "", "redact_secrets"),
+			testHandlerStep(compiler, "Ingest agent output", "", "collect_ndjson_output"),
+		},
+	})
+
+	steps := strings.Join(job.Steps, "")
+	assert.NotContains(t, steps, "Checkout shared actions", "a checkout of the repository already provides the actions")
+	assert.Less(t, strings.Index(steps, "Save shared actions"), strings.Index(steps, "- name: Checkout PR branch"),
+		"the actions should be saved before the pull request branch is checked out")
+	assert.Contains(t, steps, "uses: ./.github/actions/gh-aw/checkout-pr-branch", "the checkout step itself runs from the trusted workspace")
+	assert.Contains(t, steps, "        uses: "+testGitHubScriptPin+"\n        with:\n          script: |\n"+testHandlerScript("determine_automatic_lockdown"),
+		"steps before the agent should run inline rather than change its workspace")
+	assert.Equal(t, 1, strings.Count(steps, "Restore shared actions"), "the actions should be restored once after the agent")
+	assert.Less(t, strings.Index(steps, "- name: Execute agent"), strings.Index(steps, "Restore shared actions"), "the restore should run after the agent")
+	assert.Contains(t, steps, "uses: ./.github/actions/gh-aw/redact-secrets", "steps after the restore should stay shared")
+	assert.Contains(t, steps, "uses: ./.github/actions/gh-aw/collect-ndjson-output", "consecutive steps should share one restore")
+	assert.Equal(t, "permissions:\n      contents: read", job.Permissions, "jobs that do not check out the actions keep their permissions")
+	assert.NotContains(t, compiler.pendingSharedActions, "determine-automatic-lockdown", "actions no step uses should be dropped")
+}
+
+func TestWithContentsRead(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions string
+		expected    string
+	}{
+		{
+			name:        "inherits workflow permissions",
+			permissions: "",
+			expected:    "permissions:\n      contents: read",
+		},
+		{
+			name:        "empty permissions",
+			permissions: "permissions: {}",
+			expected:    "permissions:\n      contents: read",
+		},
+		{
+			name:        "contents none",
+			permissions: "permissions:\n  contents: none\n  issues: write",
+			expected:    "permissions:\n      contents: read\n      issues: write",
+		},
+		{
+			name:        "contents write",
+			permissions: "permissions:\n  contents: write",
+			expected:    "permissions:\n  contents: write",
+		},
+		{
+			name:        "read-all",
+			permissions: "permissions: read-all",
+			expected:    "permissions: read-all",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, withContentsRead(tt.permissions), "the job should be able to check out the actions")
+		})
+	}
+}
+
+func TestWriteSharedActions(t *testing.T) {
+	repoRoot := t.TempDir()
+	workflowsDir := filepath.Join(repoRoot, ".github", "workflows")
+	require.NoError(t, os.MkdirAll(workflowsDir, 0o755), "create workflows dir")
+	markdownPath := filepath.Join(workflowsDir, "triage.md")
+
+	compiler := NewCompiler()
+	compiler.pendingSharedActions = map[string]*sharedAction{
+		"noop": {githubScript: testGitHubScriptPin, script: standardHandlerScript("noop"), outputs: []string{"noop_message"}},
+	}
+	require.NoError(t, compiler.writeSharedActions(markdownPath), "actions should be written")
+
+	actionPath := filepath.Join(repoRoot, ".github", "actions", "gh-aw", "noop", "action.yml")
+	content, err := os.ReadFile(actionPath)
+	require.NoError(t, err, "action.yml should be written")
+	action := string(content)
+	assert.True(t, strings.HasPrefix(action, SharedActionHeader+"\n"), "action should be marked as generated")
+	assert.Contains(t, action, "    default: ${{ github.token }}\n", "the token input should default like actions/github-script")
+	assert.Contains(t, action, "  noop_message:\n    value: ${{ steps.script.outputs.noop_message }}\n", "outputs should map to the script step")
+	assert.Contains(t, action, "      uses: "+testGitHubScriptPin+"\n", "the pinned github-script should be kept")
+	assert.Contains(t, action, "          const { main } = require('${{ runner.temp }}/gh-aw/actions/noop.cjs');\n", "the script should be embedded")
+
+	// A workflow that reads no outputs keeps the ones other workflows need
+	compiler.pendingSharedActions["noop"].outputs = nil
+	require.NoError(t, compiler.writeSharedActions(markdownPath), "actions should be written again")
+	content, err = os.ReadFile(actionPath)
+	require.NoError(t, err, "action.yml should still exist")
+	assert.Equal(t, action, string(content), "declared outputs should be kept")
+}
+
+func TestCompileWorkflowSharedActions(t *testing.T) {
+	repoRoot := t.TempDir()
+	workflowsDir := filepath.Join(repoRoot, ".github", "workflows")
+	require.NoError(t, os.MkdirAll(workflowsDir, 0o755), "create workflows dir")
+	markdownPath := filepath.Join(workflowsDir, "triage.md")
+	require.NoError(t, os.WriteFile(markdownPath, []byte("---\non: issues\npermissions:\n  contents: read\nengine: copilot\nsafe-outputs:\n  create-issue:\n---\n\n# Triage\n"), 0o644), "write workflow")
+
+	compiler := NewCompiler(WithVersion("1.0.0"))
+	compiler.SetQuiet(true)
+	compiler.SetSharedActions(true)
+	require.NoError(t, compiler.CompileWorkflow(markdownPath), "compilation should succeed")
+
+	lockContent, err := os.ReadFile(filepath.Join(workflowsDir, "triage.lock.yml"))
+	require.NoError(t, err, "lock file should be written")
+	matches := sharedActionReferences(string(lockContent))
+	require.NotEmpty(t, matches, "handler steps should reference shared actions")
+	for _, name := range matches {
+		assert.FileExists(t, filepath.Join(repoRoot, SharedActionsDir, name, "action.yml"), "shared action %s should be written", name)
+	}
+}
+
+func sharedActionReferences(lockContent string) []string {
+	var names []string
+	for line := range strings.SplitSeq(lockContent, "\n") {
+		if name, ok := strings.CutPrefix(strings.TrimSpace(line), "uses: ./"+SharedActionsDir+"/"); ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -53,6 +53,12 @@ func validateFrontmatterSkills(frontmatter map[string]any) error {
 	for i, rawSkill := range skills {
 		switch typed := rawSkill.(type) {
 		case string:
+			if isLocalSkillPath(typed) {
+				if err := validateLocalSkillPath(typed, i); err != nil {
+					return err
+				}
+				continue
+			}
 			if err := validateSkillSpecValue(typed, i); err != nil {
 				return err
 			}
@@ -125,7 +131,8 @@ func parseRawSkillReferences(rawSkills []any) []SkillReference {
 		switch typed := rawSkill.(type) {
 		case string:
 			skillSpec := strings.TrimSpace(typed)
-			if skillSpec == "" {
+			if skillSpec == "" || isLocalSkillPath(skillSpec) {
+				// Local skill directories are merged at compile time, not installed.
 				continue
 			}
 			refs = append(refs, SkillReference{Skill: skillSpec})
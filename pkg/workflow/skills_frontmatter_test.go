@@ -47,6 +47,25 @@ func TestValidateFrontmatterSkills(t *testing.T) {
 		require.Error(t, err)
 	})
 
+	t.Run("accepts local skill directories", func(t *testing.T) {
+		err := validateFrontmatterSkills(map[string]any{
+			"skills": []any{
+				"./.github/skills/reviewer",
+			},
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("rejects local skill paths that leave the repository", func(t *testing.T) {
+		err := validateFrontmatterSkills(map[string]any{
+			"skills": []any{
+				"./../skills/reviewer",
+			},
+		})
+		require.Error(t, err)
+		require.ErrorContains(t, err, "must not contain '..'")
+	})
+
 	t.Run("rejects github actions expressions", func(t *testing.T) {
 		err := validateFrontmatterSkills(map[string]any{
 			"skills": []any{
@@ -0,0 +1,101 @@
+package workflow
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/goccy/go-yaml"
+)
+
+var stepConditionsValidationLog = logger.New("workflow:step_conditions_validation")
+
+// secretsReferencePattern matches references to the secrets context, which GitHub
+// Actions does not allow in if: conditions.
+var secretsReferencePattern = regexp.MustCompile(`\bsecrets\.`)
+
+// validateStepConditions validates the workflow-level skip-if expression and the
+// if: conditions of the agent job's custom steps (frontmatter steps merged with
+// imported steps).
+func validateStepConditions(workflowData *WorkflowData) error {
+	if raw, ok := workflowData.RawFrontmatter["skip-if"]; ok {
+		skipIf, isString := raw.(string)
+		if !isString {
+			return NewValidationError("skip-if", fmt.Sprintf("%v", raw), "skip-if must be a string expression", "Use an expression such as: skip-if: github.event_name == 'schedule'")
+		}
+		if err := validateConditionExpression("skip-if", skipIf); err != nil {
+			return err
+		}
+	}
+
+	if workflowData.CustomSteps == "" {
+		return nil
+	}
+	var wrapper map[string]any
+	if err := yaml.Unmarshal([]byte(workflowData.CustomSteps), &wrapper); err != nil {
+		stepConditionsValidationLog.Printf("Skipping step condition validation, failed to parse steps: %v", err)
+		return nil
+	}
+	steps, _ := wrapper["steps"].([]any)
+	for i, rawStep := range steps {
+		step, ok := rawStep.(map[string]any)
+		if !ok {
+			continue
+		}
+		rawIf, hasIf := step["if"]
+		if !hasIf {
+			continue
+		}
+		field := fmt.Sprintf("steps[%d].if", i)
+		if name, ok := step["name"].(string); ok && name != "" {
+			field = fmt.Sprintf("steps[%d] (%s).if", i, name)
+		}
+		condition, isString := rawIf.(string)
+		if !isString {
+			// Booleans and numbers are valid literal conditions.
+			continue
+		}
+		if err := validateConditionExpression(field, condition); err != nil {
+			return err
+		}
+	}
+	stepConditionsValidationLog.Printf("Validated conditions for %d custom steps", len(steps))
+	return nil
+}
+
+// validateConditionExpression checks an if:-style condition. The condition may be a
+// bare expression or a single ${{ }} expression; mixing text and expressions produces
+// a non-empty string that GitHub Actions always treats as true.
+func validateConditionExpression(field, condition string) error {
+	trimmed := strings.TrimSpace(condition)
+	bare := stripExpressionWrapper(trimmed)
+	if bare == "" {
+		return NewValidationError(field, condition, "condition is empty", "Provide an expression such as: github.event_name == 'issues'")
+	}
+	if strings.Contains(bare, "${{") || strings.Contains(bare, "}}") {
+		return NewValidationError(
+			field,
+			condition,
+			"condition mixes text with ${{ }} expressions, which GitHub Actions evaluates as an always-true string",
+			"Write the whole condition as one expression, for example: github.event_name == 'issues' && github.event.action == 'opened'",
+		)
+	}
+	if secretsReferencePattern.MatchString(bare) {
+		return NewValidationError(
+			field,
+			condition,
+			"secrets cannot be referenced directly in if: conditions",
+			"Expose the secret through an env variable and test it instead, for example: env.HAS_TOKEN == 'true'",
+		)
+	}
+
+	wrapped := "${{ " + bare + " }}"
+	if err := validateBalancedBraces(wrapped); err != nil {
+		return fmt.Errorf("invalid %s condition: %w", field, err)
+	}
+	if err := validateExpressionSyntax(wrapped); err != nil {
+		return fmt.Errorf("invalid %s condition: %w", field, err)
+	}
+	return nil
+}
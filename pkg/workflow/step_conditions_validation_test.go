@@ -0,0 +1,148 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateStepConditions(t *testing.T) {
+	tests := []struct {
+		name        string
+		skipIf      any
+		customSteps string
+		wantErr     string
+	}{
+		{
+			name: "no conditions",
+			customSteps: `steps:
+  - name: Build
+    run: make
+`,
+		},
+		{
+			name:   "valid bare and wrapped conditions",
+			skipIf: "github.event_name == 'schedule'",
+			customSteps: `steps:
+  - name: Install
+    if: github.event_name == 'pull_request'
+    run: npm ci
+  - name: Fetch
+    if: ${{ contains(github.event.issue.labels.*.name, 'bug') }}
+    run: echo hi
+  - name: Always
+    if: true
+    run: echo always
+`,
+		},
+		{
+			name: "mixed text and expressions",
+			customSteps: `steps:
+  - name: Fetch
+    if: ${{ github.event_name }} == 'issues'
+    run: echo hi
+`,
+			wantErr: "steps[0] (Fetch).if",
+		},
+		{
+			name: "secrets reference",
+			customSteps: `steps:
+  - run: echo hi
+    if: secrets.TOKEN != ''
+`,
+			wantErr: "secrets cannot be referenced",
+		},
+		{
+			name: "unbalanced parentheses",
+			customSteps: `steps:
+  - name: Broken
+    if: contains(github.event.issue.title, 'x'
+    run: echo hi
+`,
+			wantErr: "unclosed parentheses",
+		},
+		{
+			name:    "empty skip-if",
+			skipIf:  "${{ }}",
+			wantErr: "condition is empty",
+		},
+		{
+			name:    "non-string skip-if",
+			skipIf:  true,
+			wantErr: "skip-if must be a string expression",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frontmatter := map[string]any{}
+			if tt.skipIf != nil {
+				frontmatter["skip-if"] = tt.skipIf
+			}
+			err := validateStepConditions(&WorkflowData{RawFrontmatter: frontmatter, CustomSteps: tt.customSteps})
+			if tt.wantErr == "" {
+				assert.NoError(t, err, "conditions should be valid")
+				return
+			}
+			require.Error(t, err, "expected a validation error")
+			assert.Contains(t, err.Error(), tt.wantErr, "error should describe the problem")
+		})
+	}
+}
+
+func TestSkipIfAndImportedStepConditionsCompile(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "skip-if-test")
+	sharedDir := filepath.Join(tmpDir, "shared")
+	require.NoError(t, os.MkdirAll(sharedDir, 0755), "failed to create shared dir")
+
+	shared := `---
+steps:
+  - name: Install deps
+    if: github.event_name == 'pull_request'
+    run: npm ci
+---
+`
+	require.NoError(t, os.WriteFile(filepath.Join(sharedDir, "setup.md"), []byte(shared), 0644), "failed to write shared workflow")
+
+	workflow := `---
+on:
+  issues:
+    types: [opened]
+  pull_request:
+    types: [opened]
+max-daily-ai-credits: -1
+skip-if: ${{ contains(github.event.issue.labels.*.name, 'no-agent') }}
+imports:
+  - shared/setup.md
+steps:
+  - name: Fetch
+    if: github.event_name == 'issues'
+    run: echo hi
+engine: copilot
+---
+Do things.
+`
+	workflowPath := filepath.Join(tmpDir, "test.md")
+	require.NoError(t, os.WriteFile(workflowPath, []byte(workflow), 0644), "failed to write workflow")
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(workflowPath), "workflow should compile")
+
+	lockContent, err := os.ReadFile(filepath.Join(tmpDir, "test.lock.yml"))
+	require.NoError(t, err, "failed to read lock file")
+	lock := string(lockContent)
+
+	assert.Contains(t, lock, "- if: github.event_name == 'pull_request'\n        name: Install deps", "imported step condition should be preserved")
+	assert.Contains(t, lock, "- if: github.event_name == 'issues'\n        name: Fetch", "frontmatter step condition should be preserved")
+
+	agentIdx := strings.Index(lock, "\n  agent:\n")
+	require.NotEqual(t, -1, agentIdx, "agent job should exist")
+	assert.Contains(t, lock[agentIdx:agentIdx+300], `if: "!(contains(github.event.issue.labels.*.name, 'no-agent'))"`, "agent job should be skipped by skip-if")
+}
@@ -25,28 +25,38 @@ import (
 //
 //	COPILOT_GITHUB_TOKEN: ${{ secrets.MY_ORG_COPILOT_TOKEN }}
 //
-// No other engine.env var is allowed to have secrets.
+// No other engine.env var is allowed to have secrets. The same allowlist applies to
+// jobs.agent.env, whose disallowed secret references are dropped when the agent job is built.
 func (c *Compiler) validateEnvSecrets(frontmatter map[string]any) error {
 	// Check top-level env section (no allowed overrides here)
 	if err := c.validateEnvSecretsSection(frontmatter, "env", nil); err != nil {
 		return err
 	}
 
+	// Determine which env var keys may carry secrets: those that the engine itself
+	// requires (e.g. COPILOT_GITHUB_TOKEN for the copilot engine).
+	// The second return value is *EngineConfig (not an error); we only need the engine ID.
+	engineSetting, _, _ := c.ExtractEngineConfig(frontmatter)
+	allowedEnvVarKeys := c.getEngineBaseEnvVarKeys(engineSetting)
+
 	// Check engine.env section when engine is in object format
 	if engineValue, exists := frontmatter["engine"]; exists {
 		if engineObj, ok := engineValue.(map[string]any); ok {
-			// Determine which env var keys may carry secrets: those that the engine itself
-			// requires (e.g. COPILOT_GITHUB_TOKEN for the copilot engine).
-			// The second return value is *EngineConfig (not an error); we only need the engine ID.
-			engineSetting, _, _ := c.ExtractEngineConfig(frontmatter)
-			allowedEnvVarKeys := c.getEngineBaseEnvVarKeys(engineSetting)
-
 			if err := c.validateEnvSecretsSection(engineObj, "engine.env", allowedEnvVarKeys); err != nil {
 				return err
 			}
 		}
 	}
 
+	// Check jobs.agent.env, which is merged into the agent job's env block
+	if jobs, ok := frontmatter["jobs"].(map[string]any); ok {
+		if agentJob, ok := jobs[string(constants.AgentJobName)].(map[string]any); ok {
+			if err := c.validateEnvSecretsSection(agentJob, "jobs.agent.env", allowedEnvVarKeys); err != nil {
+				return err
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -139,17 +149,23 @@ func (c *Compiler) validateEnvSecretsSection(config map[string]any, sectionName
 			// engine-specific configuration.
 			return fmt.Errorf("strict mode: secrets detected in 'engine.env' section are excluded from the agent sandbox via awf --exclude-env (requires AWF %s+) and are not accessible to the agent when that version is in use. Found: %s. Use engine-specific secret configuration instead. See: https://github.github.com/gh-aw/reference/engines/", constants.AWFExcludeEnvMinVersion, strings.Join(secretRefs, ", "))
 		}
+		if sectionName == "jobs.agent.env" {
+			return fmt.Errorf("strict mode: secrets detected in 'jobs.agent.env' section are not among the engine's allowed secrets. Found: %s. Only the engine's own secret variables may be set on the agent job; move other secrets to a separate job. See: https://github.github.com/gh-aw/reference/environment-variables/", strings.Join(secretRefs, ", "))
+		}
 		return fmt.Errorf("strict mode: secrets detected in '%s' section will be leaked to the agent container. Found: %s. Use engine-specific secret configuration instead. See: https://github.github.com/gh-aw/reference/engines/", sectionName, strings.Join(secretRefs, ", "))
 	}
 
 	// In non-strict mode, emit a warning
 	var warningMsg string
-	if sectionName == "engine.env" {
+	switch sectionName {
+	case "jobs.agent.env":
+		warningMsg = fmt.Sprintf("Warning: secrets detected in 'jobs.agent.env' section are not among the engine's allowed secrets and will be dropped from the agent job. Found: %s. Move operations that need these secrets to a separate job.", strings.Join(secretRefs, ", "))
+	case "engine.env":
 		// engine.env secrets are excluded from the agent sandbox via awf --exclude-env
 		// (requires AWF v0.25.3+). On older AWF versions this protection is not applied and
 		// the values will reach the agent container.
 		warningMsg = fmt.Sprintf("Warning: secrets detected in 'engine.env' section will be excluded from the agent sandbox via awf --exclude-env (requires AWF %s+); on older AWF versions the agent process will see these values. Found: %s. Consider using engine-specific secret configuration instead.", constants.AWFExcludeEnvMinVersion, strings.Join(secretRefs, ", "))
-	} else {
+	default:
 		warningMsg = fmt.Sprintf("Warning: secrets detected in '%s' section will be leaked to the agent container. Found: %s. Consider using engine-specific secret configuration instead.", sectionName, strings.Join(secretRefs, ", "))
 	}
 	fmt.Fprintln(os.Stderr, console.FormatWarningMessage(warningMsg))
@@ -0,0 +1,177 @@
+// Package workflow provides the explicit-defaults validation for strict mode.
+//
+// When the `compile --strict-explicit` CLI flag is set, the compiler refuses to
+// rely on implicit defaults for security-relevant settings:
+//   - web-fetch or web-search enabled without a top-level network configuration
+//   - destructive safe outputs (close, merge, update, remove, ...) without an explicit max
+//   - third-party actions in custom steps that are not pinned to a full commit SHA
+//   - an engine, including the default engine, without an explicit engine.version
+//     (or with version "latest")
+//
+// These checks are opt-in and separate from `--strict` and frontmatter
+// `strict: true`, so existing strict workflows keep compiling unchanged.
+package workflow
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var strictExplicitDefaultsLog = logger.New("workflow:strict_mode_explicit_defaults_validation")
+
+// destructiveSafeOutputKeys lists safe outputs that close, merge, overwrite, or
+// remove existing repository state. Explicit strict mode requires a max for each.
+var destructiveSafeOutputKeys = []string{
+	"close-discussion",
+	"close-issue",
+	"close-pull-request",
+	"dismiss-pull-request-review",
+	"hide-comment",
+	"lock-issue",
+	"lock-pull-request",
+	"merge-pull-request",
+	"push-to-pull-request-branch",
+	"remove-labels",
+	"unassign-from-user",
+	"update-discussion",
+	"update-issue",
+	"update-pull-request",
+	"update-release",
+}
+
+// firstPartyActionOwners are the action owners exempt from the third-party pinning check.
+var firstPartyActionOwners = []string{"actions", "github"}
+
+// validateStrictExplicitDefaults refuses security-relevant settings that are left
+// implicit when the workflow is compiled with --strict-explicit.
+func (c *Compiler) validateStrictExplicitDefaults(workflowData *WorkflowData) error {
+	if !c.strictExplicit {
+		strictExplicitDefaultsLog.Print("--strict-explicit flag not set, skipping explicit defaults validation")
+		return nil
+	}
+
+	strictExplicitDefaultsLog.Print("Validating explicit defaults for strict mode")
+	collector := NewErrorCollector(c.failFast)
+	checks := []func(*WorkflowData) []error{
+		checkStrictWebToolsNetwork,
+		checkStrictDestructiveSafeOutputMax,
+		checkStrictThirdPartyActionPins,
+		checkStrictEngineVersion,
+	}
+	for _, check := range checks {
+		for _, err := range check(workflowData) {
+			if returnErr := collector.Add(err); returnErr != nil {
+				return returnErr // Fail-fast mode
+			}
+		}
+	}
+
+	strictExplicitDefaultsLog.Printf("Explicit defaults validation completed: %d violations", collector.Count())
+	return collector.FormattedError("strict mode")
+}
+
+// checkStrictWebToolsNetwork requires a top-level network configuration when web tools are enabled.
+func checkStrictWebToolsNetwork(workflowData *WorkflowData) []error {
+	if workflowData.NetworkPermissions != nil && workflowData.NetworkPermissions.ExplicitlyDefined {
+		return nil
+	}
+	var errs []error
+	for _, tool := range []string{"web-fetch", "web-search"} {
+		if _, ok := workflowData.Tools[tool]; !ok {
+			continue
+		}
+		errs = append(errs, NewValidationError(
+			"network",
+			"",
+			fmt.Sprintf("strict mode: tools.%s is enabled without an explicit network configuration", tool),
+			"Declare the domains the agent may reach, for example:\n\nnetwork:\n  allowed:\n    - defaults\n    - example.com",
+		))
+	}
+	return errs
+}
+
+// checkStrictDestructiveSafeOutputMax requires an explicit max on destructive safe outputs
+// declared in the workflow's own frontmatter. Imported configurations own their limits.
+func checkStrictDestructiveSafeOutputMax(workflowData *WorkflowData) []error {
+	safeOutputs, ok := workflowData.RawFrontmatter["safe-outputs"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	var errs []error
+	for _, key := range destructiveSafeOutputKeys {
+		raw, exists := safeOutputs[key]
+		if !exists {
+			continue
+		}
+		if cfg, ok := raw.(map[string]any); ok {
+			if _, hasMax := cfg["max"]; hasMax {
+				continue
+			}
+		}
+		errs = append(errs, NewValidationError(
+			"safe-outputs."+key,
+			"",
+			fmt.Sprintf("strict mode: safe-outputs.%s has no explicit max", key),
+			fmt.Sprintf("Bound the number of operations per run:\n\nsafe-outputs:\n  %s:\n    max: 1", key),
+		))
+	}
+	return errs
+}
+
+// checkStrictThirdPartyActionPins requires third-party actions in custom steps to be
+// pinned to a full commit SHA after the compiler's pin resolution.
+func checkStrictThirdPartyActionPins(workflowData *WorkflowData) []error {
+	var errs []error
+	for _, section := range customStepSections(workflowData) {
+		for _, uses := range collectStepUses(section.yaml) {
+			if isPinnedActionRef(uses) || isFirstPartyActionRef(uses) {
+				continue
+			}
+			errs = append(errs, NewValidationError(
+				section.field,
+				uses,
+				fmt.Sprintf("strict mode: third-party action %q is not pinned to a full commit SHA", uses),
+				"Pin the action to a full 40-character commit SHA, for example:\n\nuses: owner/action@<sha> # v1.2.3",
+			))
+		}
+	}
+	return errs
+}
+
+// isFirstPartyActionRef reports whether a "uses" reference belongs to a first-party owner.
+func isFirstPartyActionRef(uses string) bool {
+	owner, _, _ := strings.Cut(uses, "/")
+	for _, firstParty := range firstPartyActionOwners {
+		if strings.EqualFold(owner, firstParty) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkStrictEngineVersion requires the engine CLI version to be pinned explicitly.
+// A workflow without an engine: block is checked against the default engine it runs on.
+func checkStrictEngineVersion(workflowData *WorkflowData) []error {
+	engineID, version := "", ""
+	if engine := workflowData.EngineConfig; engine != nil {
+		engineID, version = engine.ID, engine.Version
+	}
+	if engineID == "" {
+		engineID = workflowData.AI
+	}
+	if engineID == "" {
+		engineID = string(constants.DefaultEngine)
+	}
+	if version != "" && !strings.EqualFold(version, "latest") {
+		return nil
+	}
+	return []error{NewValidationError(
+		"engine.version",
+		engineID,
+		"strict mode: engine.version must pin a specific engine CLI version",
+		fmt.Sprintf("Pin the engine CLI version:\n\nengine:\n  id: %s\n  version: <version>", engineID),
+	)}
+}
@@ -0,0 +1,163 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateStrictExplicitDefaults(t *testing.T) {
+	pinned := "uses: actions/checkout@v4\n"
+	tests := []struct {
+		name           string
+		strictExplicit bool
+		data           *WorkflowData
+		errContains    []string
+	}{
+		{
+			name:           "skipped without --strict-explicit",
+			strictExplicit: false,
+			data: &WorkflowData{
+				Tools:          map[string]any{"web-fetch": nil},
+				EngineConfig:   &EngineConfig{ID: "copilot"},
+				RawFrontmatter: map[string]any{"strict": true},
+			},
+		},
+		{
+			name:           "explicit settings pass",
+			strictExplicit: true,
+			data: &WorkflowData{
+				Tools:              map[string]any{"web-fetch": nil},
+				NetworkPermissions: &NetworkPermissions{Allowed: []string{"defaults"}, ExplicitlyDefined: true},
+				EngineConfig:       &EngineConfig{ID: "copilot", Version: "1.0.73"},
+				RawFrontmatter: map[string]any{
+					"safe-outputs": map[string]any{"close-issue": map[string]any{"max": 2}, "create-issue": nil},
+				},
+				CustomSteps: "steps:\n  - " + pinned + "  - uses: owner/tool@0123456789abcdef0123456789abcdef01234567\n",
+			},
+		},
+		{
+			name:           "web tools without network",
+			strictExplicit: true,
+			data: &WorkflowData{
+				Tools:        map[string]any{"web-search": nil},
+				EngineConfig: &EngineConfig{ID: "copilot", Version: "1.0.73"},
+			},
+			errContains: []string{"tools.web-search is enabled without an explicit network configuration"},
+		},
+		{
+			name:           "destructive safe outputs without max",
+			strictExplicit: true,
+			data: &WorkflowData{
+				EngineConfig: &EngineConfig{ID: "copilot", Version: "1.0.73"},
+				RawFrontmatter: map[string]any{
+					"safe-outputs": map[string]any{"merge-pull-request": nil, "remove-labels": map[string]any{"allowed": []any{"bug"}}},
+				},
+			},
+			errContains: []string{"safe-outputs.merge-pull-request has no explicit max", "safe-outputs.remove-labels has no explicit max"},
+		},
+		{
+			name:           "unpinned third-party action",
+			strictExplicit: true,
+			data: &WorkflowData{
+				EngineConfig: &EngineConfig{ID: "copilot", Version: "1.0.73"},
+				PreSteps:     "pre-steps:\n  - " + pinned + "  - uses: owner/tool@v2\n",
+			},
+			errContains: []string{`third-party action "owner/tool@v2" is not pinned`},
+		},
+		{
+			name:           "engine without version",
+			strictExplicit: true,
+			data:           &WorkflowData{EngineConfig: &EngineConfig{ID: "claude"}},
+			errContains:    []string{"engine.version must pin a specific engine CLI version"},
+		},
+		{
+			name:           "implicit default engine without version",
+			strictExplicit: true,
+			data:           &WorkflowData{},
+			errContains:    []string{"engine.version must pin a specific engine CLI version", "id: " + string(constants.DefaultEngine)},
+		},
+		{
+			name:           "engine version latest",
+			strictExplicit: true,
+			data:           &WorkflowData{EngineConfig: &EngineConfig{ID: "codex", Version: "latest"}},
+			errContains:    []string{"engine.version must pin a specific engine CLI version"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compiler := NewCompiler()
+			compiler.SetStrictExplicit(tt.strictExplicit)
+			err := compiler.validateStrictExplicitDefaults(tt.data)
+			if len(tt.errContains) == 0 {
+				assert.NoError(t, err, "validation should pass")
+				return
+			}
+			require.Error(t, err, "validation should fail")
+			for _, want := range tt.errContains {
+				assert.Contains(t, err.Error(), want, "error should describe the implicit default")
+			}
+		})
+	}
+}
+
+func TestStrictExplicitDefaultsCompilation(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "strict-explicit-defaults")
+	content := `---
+on: workflow_dispatch
+strict: true
+engine: copilot
+tools:
+  web-fetch:
+safe-outputs:
+  close-issue:
+---
+
+# Close stale issues
+`
+	workflowFile := filepath.Join(tmpDir, "stale.md")
+	require.NoError(t, os.WriteFile(workflowFile, []byte(content), 0644), "Failed to write workflow")
+
+	require.NoError(t, NewCompiler().CompileWorkflow(workflowFile), "frontmatter strict: true should not enable explicit defaults checks")
+
+	strictCompiler := NewCompiler()
+	strictCompiler.SetStrictMode(true)
+	require.NoError(t, strictCompiler.CompileWorkflow(workflowFile), "--strict should not enable explicit defaults checks")
+
+	compiler := NewCompiler()
+	compiler.SetStrictExplicit(true)
+	err := compiler.CompileWorkflow(workflowFile)
+	require.Error(t, err, "--strict-explicit should refuse implicit defaults")
+	assert.Contains(t, err.Error(), "tools.web-fetch is enabled without an explicit network configuration", "network should be required")
+	assert.Contains(t, err.Error(), "safe-outputs.close-issue has no explicit max", "max should be required")
+	assert.Contains(t, err.Error(), "engine.version must pin a specific engine CLI version", "engine version should be required")
+}
+
+func TestStrictExplicitDefaultsCompilationDefaultEngine(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "strict-explicit-default-engine")
+	content := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+---
+
+# Summarize the repository
+`
+	workflowFile := filepath.Join(tmpDir, "summary.md")
+	require.NoError(t, os.WriteFile(workflowFile, []byte(content), 0644), "Failed to write workflow")
+
+	compiler := NewCompiler()
+	compiler.SetStrictExplicit(true)
+	err := compiler.CompileWorkflow(workflowFile)
+	require.Error(t, err, "--strict-explicit should refuse the unpinned default engine")
+	assert.Contains(t, err.Error(), "engine.version must pin a specific engine CLI version", "engine version should be required without an engine: block")
+	assert.Contains(t, err.Error(), "id: "+string(constants.DefaultEngine), "hint should name the default engine")
+}
@@ -10,6 +10,7 @@
 //   - strict_mode_steps_validation.go: steps and bash tool validation
 //   - strict_mode_sandbox_validation.go: sandbox configuration validation
 //   - strict_mode_update_check_validation.go: check-for-updates flag validation
+//   - strict_mode_explicit_defaults_validation.go: implicit defaults refused by --strict-explicit
 //
 // # Integration with Security Scanners
 //
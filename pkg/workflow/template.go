@@ -104,22 +104,33 @@ func (c *Compiler) generateInterpolationAndTemplateStep(yaml *strings.Builder, e
 	hasInlineSubAgents := inlineSubAgentPattern.MatchString(data.MarkdownContent)
 	hasTemplates := hasTemplatePattern || hasGitHubContext || hasInlineSubAgents
 
+	// The step also enforces prompt.max-tokens, so it is needed whenever a budget is set.
+	maxTokens := data.promptMaxTokens()
+
+	// The step also selects route: sections and imports from the triggering labels.
+	hasRouting := data.hasPromptRouting()
+
 	// Skip if neither interpolation nor template rendering is needed
-	if !hasExpressions && !hasTemplates {
+	if !hasExpressions && !hasTemplates && maxTokens == 0 && !hasRouting {
 		templateLog.Print("No interpolation or template rendering needed, skipping step generation")
 		return
 	}
 
-	templateLog.Printf("Generating interpolation and template step: expressions=%d, hasPattern=%v, hasGitHubContext=%v, hasInlineSubAgents=%v",
-		len(expressionMappings), hasTemplatePattern, hasGitHubContext, hasInlineSubAgents)
+	templateLog.Printf("Generating interpolation and template step: expressions=%d, hasPattern=%v, hasGitHubContext=%v, hasInlineSubAgents=%v, hasRouting=%v",
+		len(expressionMappings), hasTemplatePattern, hasGitHubContext, hasInlineSubAgents, hasRouting)
+
+	// Load interpolate_prompt script from external file
+	// Use setup_globals helper to store GitHub Actions objects in global scope
+	script := generateGitHubScriptWithRequire("interpolate_prompt.cjs")
 
 	yaml.WriteString("      - name: Interpolate variables and render templates\n")
-	fmt.Fprintf(yaml, "        uses: %s\n", getCachedActionPin("actions/github-script", data))
+	fmt.Fprintf(yaml, "        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", data), script))
 	yaml.WriteString("        env:\n")
 	yaml.WriteString("          GH_AW_PROMPT: /tmp/gh-aw/aw-prompts/prompt.txt\n")
 	if data.EngineConfig != nil && data.EngineConfig.ID != "" {
 		fmt.Fprintf(yaml, "          GH_AW_ENGINE_ID: \"%s\"\n", data.EngineConfig.ID)
 	}
+	c.writePromptSelectionEnv(yaml, data, maxTokens)
 
 	// Add environment variables for extracted expressions (deduplicated by EnvVar)
 	seen := make(map[string]struct{})
@@ -133,13 +144,25 @@ func (c *Compiler) generateInterpolationAndTemplateStep(yaml *strings.Builder, e
 		fmt.Fprintf(yaml, "          %s: ${{ %s }}\n", mapping.EnvVar, mapping.Content)
 	}
 
-	yaml.WriteString("        with:\n")
-	yaml.WriteString("          script: |\n")
+	yaml.WriteString(c.githubScriptWith(script))
+}
 
-	// Load interpolate_prompt script from external file
-	// Use setup_globals helper to store GitHub Actions objects in global scope
-	yaml.WriteString("            const { setupGlobals } = require('${{ runner.temp }}/gh-aw/actions/setup_globals.cjs');\n")
-	yaml.WriteString("            setupGlobals(core, github, context, exec, io, getOctokit);\n")
-	yaml.WriteString("            const { main } = require('${{ runner.temp }}/gh-aw/actions/interpolate_prompt.cjs');\n")
-	yaml.WriteString("            await main();\n")
+// writePromptSelectionEnv writes the environment variables that control which content
+// reaches the prompt: the prompt.max-tokens budget and the route: table.
+func (c *Compiler) writePromptSelectionEnv(yaml *strings.Builder, data *WorkflowData, maxTokens int) {
+	if maxTokens > 0 {
+		fmt.Fprintf(yaml, "          GH_AW_PROMPT_MAX_TOKENS: \"%d\"\n", maxTokens)
+		// The main workflow body is never trimmed; only its imports are.
+		if !c.inlinePrompt && !data.InlinedImports {
+			fmt.Fprintf(yaml, "          GH_AW_PROMPT_MAIN_FILE: \"%s\"\n", mainWorkflowImportPath(c.markdownPath))
+		}
+	}
+	if data.hasPromptRouting() {
+		routesJSON, err := data.PromptRouting.ToJSON()
+		if err != nil {
+			templateLog.Printf("Skipping prompt routes: %v", err)
+			return
+		}
+		fmt.Fprintf(yaml, "          GH_AW_PROMPT_ROUTES: %q\n", routesJSON)
+	}
 }
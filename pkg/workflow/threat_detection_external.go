@@ -378,7 +378,7 @@ func extractStepEnvLines(step GitHubActionStep) []string {
 // detection artifact. Used when features: gh-aw-detection: true is set; the inline
 // path uses buildUploadDetectionLogStep which only uploads detection.log.
 func (c *Compiler) buildUploadDetectionArtifactStep(data *WorkflowData) []string {
-	detectionArtifactName := artifactPrefixExprForAgentDownstreamJob(data) + constants.DetectionArtifactName
+	detectionArtifactName := detectionArtifactPrefixExpr(data) + detectionArtifactNameFor(data)
 	return []string{
 		"      - name: Upload threat detection artifact\n",
 		fmt.Sprintf("        if: %s\n", detectionStepCondition),
@@ -452,7 +452,7 @@ func (c *Compiler) buildWorkspaceCheckoutForDetectionStep(data *WorkflowData) []
 
 	steps := []string{
 		"      - name: Checkout repository for patch context\n",
-		fmt.Sprintf("        if: needs.%s.outputs.has_patch == 'true'\n", constants.AgentJobName),
+		fmt.Sprintf("        if: needs.%s.outputs.has_patch == 'true'\n", detectionScannedJobName(data)),
 		fmt.Sprintf("        uses: %s\n", checkoutPin),
 		"        with:\n",
 		"          persist-credentials: false\n",
@@ -47,8 +47,8 @@ func (c *Compiler) buildDetectionJob(data *WorkflowData) (*Job, error) {
 
 	// Download agent output artifact to access output files (prompt.txt, agent_output.json, patches).
 	// Use agent-downstream prefix since this job depends on the agent job.
-	agentArtifactPrefix := artifactPrefixExprForAgentDownstreamJob(data)
-	steps = append(steps, buildAgentOutputDownloadSteps(agentArtifactPrefix, c.getActionPin)...)
+	agentArtifactPrefix := detectionArtifactPrefixExpr(data)
+	steps = append(steps, buildNamedAgentOutputDownloadSteps(agentArtifactPrefix+agentArtifactName(data), c.getActionPin)...)
 
 	// Download experiment artifact so the detection agent can read the current variant assignments.
 	// The experiment artifact is uploaded by the activation job.
@@ -71,7 +71,7 @@ func (c *Compiler) buildDetectionJob(data *WorkflowData) (*Job, error) {
 	}
 
 	// Detection job depends on agent job and activation job (for trace ID)
-	needs := []string{string(constants.AgentJobName), string(constants.ActivationJobName)}
+	needs := []string{detectionScannedJobName(data), string(constants.ActivationJobName)}
 
 	// Scan the effective detection engine env values for needs.<customJob>.outputs.*
 	// expressions and add the referenced custom jobs as direct dependencies of the
@@ -139,7 +139,7 @@ func (c *Compiler) buildDetectionJob(data *WorkflowData) (*Job, error) {
 	// needs.detection.result == 'success' and behave correctly.
 	alwaysFunc := BuildFunctionCall("always")
 	agentNotSkipped := BuildNotEquals(
-		BuildPropertyAccess(fmt.Sprintf("needs.%s.result", constants.AgentJobName)),
+		BuildPropertyAccess(fmt.Sprintf("needs.%s.result", detectionScannedJobName(data))),
 		BuildStringLiteral("skipped"),
 	)
 	jobConditionNode := BuildAnd(alwaysFunc, agentNotSkipped)
@@ -192,7 +192,7 @@ func (c *Compiler) buildDetectionJob(data *WorkflowData) (*Job, error) {
 	}
 
 	job := &Job{
-		Name:        string(constants.DetectionJobName),
+		Name:        detectionJobNameFor(data),
 		Needs:       needs,
 		If:          jobCondition,
 		RunsOn:      c.indentYAMLLines(runsOn, "    "),
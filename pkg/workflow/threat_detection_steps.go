@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"maps"
 	"strconv"
+	"strings"
 
 	"github.com/github/gh-aw/pkg/constants"
 )
@@ -43,7 +44,7 @@ func (c *Compiler) buildDetectionJobSteps(data *WorkflowData) []string {
 	}
 
 	// Step 2: Detection guard - determines whether detection should run
-	steps = append(steps, c.buildDetectionGuardStep()...)
+	steps = append(steps, c.buildDetectionGuardStep(data)...)
 
 	// Step 3: Clear MCP configuration files so the detection engine runs without MCP servers
 	steps = append(steps, c.buildClearMCPConfigStep()...)
@@ -118,14 +119,15 @@ func (c *Compiler) buildDetectionJobSteps(data *WorkflowData) []string {
 // buildDetectionGuardStep creates a guard step that checks if detection should run.
 // Uses always() to run even if the agent job failed (detection still analyzes whatever output exists).
 // In the separate detection job, output metadata is read from the agent job's outputs.
-func (c *Compiler) buildDetectionGuardStep() []string {
+func (c *Compiler) buildDetectionGuardStep(data *WorkflowData) []string {
+	agentJob := detectionScannedJobName(data)
 	return []string{
 		"      - name: Check if detection needed\n",
 		"        id: detection_guard\n",
 		"        if: always()\n",
 		"        env:\n",
-		"          OUTPUT_TYPES: ${{ needs.agent.outputs.output_types }}\n",
-		"          HAS_PATCH: ${{ needs.agent.outputs.has_patch }}\n",
+		fmt.Sprintf("          OUTPUT_TYPES: ${{ needs.%s.outputs.output_types }}\n", agentJob),
+		fmt.Sprintf("          HAS_PATCH: ${{ needs.%s.outputs.has_patch }}\n", agentJob),
 		"        run: |\n",
 		"          if [[ -n \"$OUTPUT_TYPES\" || \"$HAS_PATCH\" == \"true\" ]]; then\n",
 		"            echo \"run_detection=true\" >> \"$GITHUB_OUTPUT\"\n",
@@ -244,20 +246,16 @@ func (c *Compiler) buildDetectionConclusionStep(data *WorkflowData) []string {
 		coeEnvLine = fmt.Sprintf("          GH_AW_DETECTION_CONTINUE_ON_ERROR: %q\n", strconv.FormatBool(continueOnError))
 	}
 
+	script := strings.Join(FormatJavaScriptForYAML(c.buildResultsParsingScriptRequire()), "")
 	steps = append(steps, []string{
-		fmt.Sprintf("        uses: %s\n", getCachedActionPin("actions/github-script", data)),
+		fmt.Sprintf("        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", data), script)),
 		"        env:\n",
 		"          RUN_DETECTION: ${{ steps.detection_guard.outputs.run_detection }}\n",
 		"          DETECTION_AGENTIC_EXECUTION_OUTCOME: ${{ steps.detection_agentic_execution.outcome }}\n",
 		coeEnvLine,
-		"        with:\n",
-		"          script: |\n",
+		c.githubScriptWith(script),
 	}...)
 
-	script := c.buildResultsParsingScriptRequire()
-	formattedScript := FormatJavaScriptForYAML(script)
-	steps = append(steps, formattedScript...)
-
 	return steps
 }
 
@@ -265,20 +263,16 @@ func (c *Compiler) buildDetectionConclusionStep(data *WorkflowData) []string {
 // firewall token usage, appends a separate table to the detection job summary,
 // and exposes AI Credits for downstream jobs.
 func (c *Compiler) buildDetectionTokenUsageSummaryStep(data *WorkflowData) []string {
+	script := generateGitHubScriptWithRequire("parse_token_usage.cjs")
 	return []string{
 		"      - name: Parse threat detection token usage for step summary\n",
 		"        id: parse_detection_token_usage\n",
 		"        if: always()\n",
 		"        continue-on-error: true\n",
-		fmt.Sprintf("        uses: %s\n", getCachedActionPin("actions/github-script", data)),
+		fmt.Sprintf("        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", data), script)),
 		"        env:\n",
 		"          GH_AW_TOKEN_USAGE_SUMMARY_TITLE: Threat Detection Token Usage\n",
-		"        with:\n",
-		"          script: |\n",
-		"            const { setupGlobals } = require('" + SetupActionDestination + "/setup_globals.cjs');\n",
-		"            setupGlobals(core, github, context, exec, io, getOctokit);\n",
-		"            const { main } = require('" + SetupActionDestination + "/parse_token_usage.cjs');\n",
-		"            await main();\n",
+		c.githubScriptWith(script),
 	}
 }
 
@@ -286,17 +280,20 @@ func (c *Compiler) buildDetectionTokenUsageSummaryStep(data *WorkflowData) []str
 func (c *Compiler) buildThreatDetectionAnalysisStep(data *WorkflowData) []string {
 	var steps []string
 
+	// Require the setup_threat_detection.cjs module and call main with the template
+	setupScript := strings.Join(FormatJavaScriptForYAML(c.buildSetupScriptRequire()), "")
+
 	// Setup step
 	steps = append(steps, []string{
 		"      - name: Setup threat detection\n",
 		fmt.Sprintf("        if: %s\n", detectionStepCondition),
-		fmt.Sprintf("        uses: %s\n", getCachedActionPin("actions/github-script", data)),
+		fmt.Sprintf("        uses: %s\n", c.githubScriptUses(getCachedActionPin("actions/github-script", data), setupScript)),
 		"        env:\n",
 	}...)
 	steps = append(steps, c.buildWorkflowContextEnvVars(data)...)
 
 	// Add HAS_PATCH environment variable from the agent job output (detection runs in a separate job)
-	steps = append(steps, "          HAS_PATCH: ${{ needs.agent.outputs.has_patch }}\n")
+	steps = append(steps, fmt.Sprintf("          HAS_PATCH: ${{ needs.%s.outputs.has_patch }}\n", detectionScannedJobName(data)))
 
 	// Add custom prompt instructions if configured
 	customPrompt := ""
@@ -307,15 +304,7 @@ func (c *Compiler) buildThreatDetectionAnalysisStep(data *WorkflowData) []string
 		steps = append(steps, fmt.Sprintf("          CUSTOM_PROMPT: %q\n", customPrompt))
 	}
 
-	steps = append(steps, []string{
-		"        with:\n",
-		"          script: |\n",
-	}...)
-
-	// Require the setup_threat_detection.cjs module and call main with the template
-	setupScript := c.buildSetupScriptRequire()
-	formattedSetupScript := FormatJavaScriptForYAML(setupScript)
-	steps = append(steps, formattedSetupScript...)
+	steps = append(steps, c.githubScriptWith(setupScript))
 
 	// Add a small shell step in YAML to ensure the output directory and log file exist
 	steps = append(steps, []string{
@@ -420,7 +409,7 @@ func (c *Compiler) buildCustomThreatDetectionSteps(steps []any) []string {
 // same reusable workflow is called multiple times within a single workflow run.
 // The prefix comes from the agent job output since the detection job depends on the agent job.
 func (c *Compiler) buildUploadDetectionLogStep(data *WorkflowData) []string {
-	detectionArtifactName := artifactPrefixExprForAgentDownstreamJob(data) + constants.DetectionArtifactName
+	detectionArtifactName := detectionArtifactPrefixExpr(data) + detectionArtifactNameFor(data)
 	return []string{
 		"      - name: Upload threat detection log\n",
 		fmt.Sprintf("        if: %s\n", detectionStepCondition),
@@ -1043,7 +1043,7 @@ func TestDetectionGuardStepCondition(t *testing.T) {
 	compiler := NewCompiler()
 
 	// Build the detection guard step
-	steps := compiler.buildDetectionGuardStep()
+	steps := compiler.buildDetectionGuardStep(&WorkflowData{})
 
 	if len(steps) == 0 {
 		t.Fatal("Expected non-empty guard steps")
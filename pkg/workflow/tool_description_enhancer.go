@@ -61,6 +61,10 @@ var toolConstraintBuilders = map[string]toolConstraintBuilder{
 	"create_check_run": func(safeOutputs *SafeOutputsConfig) []string {
 		return createCheckRunConstraints(safeOutputs.CreateCheckRun)
 	},
+	"create_commit_status": func(safeOutputs *SafeOutputsConfig) []string {
+		return createCommitStatusConstraints(safeOutputs.CreateCommitStatus)
+	},
+	"notify":     func(safeOutputs *SafeOutputsConfig) []string { return notifyConstraints(safeOutputs.Notify) },
 	"add_labels": func(safeOutputs *SafeOutputsConfig) []string { return addLabelsConstraints(safeOutputs.AddLabels) },
 	"remove_labels": func(safeOutputs *SafeOutputsConfig) []string {
 		return removeLabelsConstraints(safeOutputs.RemoveLabels)
@@ -69,6 +73,9 @@ var toolConstraintBuilders = map[string]toolConstraintBuilder{
 		return replaceLabelConstraints(safeOutputs.ReplaceLabel)
 	},
 	"add_reviewer": func(safeOutputs *SafeOutputsConfig) []string { return addReviewerConstraints(safeOutputs.AddReviewer) },
+	"request_team_review": func(safeOutputs *SafeOutputsConfig) []string {
+		return requestTeamReviewConstraints(safeOutputs.RequestTeamReview)
+	},
 	"update_issue": func(safeOutputs *SafeOutputsConfig) []string { return updateIssueConstraints(safeOutputs.UpdateIssues) },
 	"update_pull_request": func(safeOutputs *SafeOutputsConfig) []string {
 		return updatePullRequestConstraints(safeOutputs.UpdatePullRequests)
@@ -96,6 +103,9 @@ var toolConstraintBuilders = map[string]toolConstraintBuilder{
 	"create_project_status_update": func(safeOutputs *SafeOutputsConfig) []string {
 		return createProjectStatusUpdateConstraints(safeOutputs.CreateProjectStatusUpdates)
 	},
+	"update_project_item": func(safeOutputs *SafeOutputsConfig) []string {
+		return updateProjectItemConstraints(safeOutputs.UpdateProjectItems)
+	},
 }
 
 // formatStringList formats a slice of strings with proper quoting for readability
@@ -179,12 +189,27 @@ func createIssueConstraints(config *CreateIssuesConfig) []string {
 	if len(config.Assignees) > 0 {
 		constraints = append(constraints, fmt.Sprintf("Assignees %s will be automatically assigned.", formatStringList(config.Assignees)))
 	}
+	if config.Milestone == nextOpenMilestone {
+		constraints = append(constraints, "Issues will be automatically added to the next open milestone.")
+	} else if config.Milestone != "" {
+		constraints = append(constraints, fmt.Sprintf("Issues will be automatically added to milestone %q.", config.Milestone))
+	}
+	if config.Project != "" {
+		constraints = append(constraints, fmt.Sprintf("Issues will be automatically added to project %q.", config.Project))
+	}
 	if config.TargetRepoSlug != "" {
 		constraints = append(constraints, fmt.Sprintf("Issues will be created in repository %q.", config.TargetRepoSlug))
 	}
 	if config.RequireTemporaryID {
 		constraints = append(constraints, "temporary_id is required.")
 	}
+	if config.IssueForm != nil {
+		constraint := fmt.Sprintf("Issues follow the %q issue form: provide its fields in 'form'.", config.IssueForm.File)
+		if required := config.IssueForm.requiredFieldIDs(); len(required) > 0 {
+			constraint += fmt.Sprintf(" Required fields: %s.", formatStringList(required))
+		}
+		constraints = append(constraints, constraint)
+	}
 	if config.NormalizeClosingKeywords != nil && *config.NormalizeClosingKeywords {
 		constraints = append(constraints, "Backtick-wrapped issue-closing keyword references (e.g. `Closes #1`) in the body field will be automatically normalized to plain text.")
 	}
@@ -492,6 +517,32 @@ func createCheckRunConstraints(config *CreateCheckRunConfig) []string {
 	return constraints
 }
 
+func createCommitStatusConstraints(config *CreateCommitStatusConfig) []string {
+	if config == nil {
+		return nil
+	}
+
+	var constraints []string
+	appendMaxConstraint(&constraints, config.Max, "Maximum %d commit status(es) can be set.")
+	if config.Context != "" {
+		constraints = append(constraints, fmt.Sprintf("Status context: %q.", config.Context))
+	}
+	return constraints
+}
+
+func notifyConstraints(config *NotifyConfig) []string {
+	if config == nil {
+		return nil
+	}
+
+	var constraints []string
+	appendMaxConstraint(&constraints, config.Max, "Maximum %d notification(s) can be sent.")
+	if names := config.channelNames(); len(names) > 0 {
+		constraints = append(constraints, "Allowed channels: "+formatStringList(names)+".")
+	}
+	return constraints
+}
+
 func addLabelsConstraints(config *AddLabelsConfig) []string {
 	if config == nil {
 		return nil
@@ -560,6 +611,22 @@ func addReviewerConstraints(config *AddReviewerConfig) []string {
 	return constraints
 }
 
+func requestTeamReviewConstraints(config *RequestTeamReviewConfig) []string {
+	if config == nil {
+		return nil
+	}
+
+	var constraints []string
+	appendMaxConstraint(&constraints, config.Max, "Maximum %d team(s) can be requested.")
+	if len(config.Allowed) > 0 {
+		constraints = append(constraints, fmt.Sprintf("Only these teams are allowed: %s.", formatStringList(config.Allowed)))
+	}
+	if config.Target != "" {
+		constraints = append(constraints, fmt.Sprintf("Target: %s.", config.Target))
+	}
+	return constraints
+}
+
 func updateIssueConstraints(config *UpdateIssuesConfig) []string {
 	if config == nil {
 		return nil
@@ -738,3 +805,19 @@ func createProjectStatusUpdateConstraints(config *CreateProjectStatusUpdateConfi
 	}
 	return constraints
 }
+
+func updateProjectItemConstraints(config *UpdateProjectItemConfig) []string {
+	if config == nil {
+		return nil
+	}
+
+	var constraints []string
+	appendMaxConstraint(&constraints, config.Max, "Maximum %d project item(s) can be updated.")
+	if config.Project != "" {
+		constraints = append(constraints, fmt.Sprintf("Default project URL: %q.", config.Project))
+	}
+	if len(config.AllowedFields) > 0 {
+		constraints = append(constraints, "Only these fields can be set: "+formatStringList(config.AllowedFields)+".")
+	}
+	return constraints
+}
@@ -0,0 +1,128 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/sliceutil"
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/typeutil"
+)
+
+var toolTimeoutsLog = logger.New("workflow:tool_timeouts")
+
+// bashToolTimeoutKey is the tool-timeouts key for the engine's shell tool.
+const bashToolTimeoutKey = "bash"
+
+// extractPerToolTimeouts parses the top-level tool-timeouts map and checks every key
+// against the tools the workflow actually configures. Keys must be "bash" or an MCP
+// server; values are whole seconds (expressions are not allowed because some engines
+// write the value into TOML).
+func (c *Compiler) extractPerToolTimeouts(frontmatter map[string]any, tools map[string]any, engine CodingAgentEngine) (map[string]int, error) {
+	raw, exists := frontmatter["tool-timeouts"]
+	if !exists || raw == nil {
+		return nil, nil
+	}
+	rawMap, ok := raw.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("tool-timeouts must be a map of tool name to timeout in seconds, got %T", raw)
+	}
+
+	known := toolTimeoutCandidates(tools)
+	timeouts := make(map[string]int, len(rawMap))
+	for _, name := range sliceutil.SortedKeys(rawMap) {
+		if _, ok := known[name]; !ok {
+			msg := fmt.Sprintf("tool-timeouts: unknown tool %q; expected %q or a configured MCP server", name, bashToolTimeoutKey)
+			if matches := stringutil.FindClosestMatches(name, sliceutil.SortedKeys(known), 1); len(matches) > 0 {
+				msg += fmt.Sprintf(". Did you mean %q?", matches[0])
+			}
+			return nil, errors.New(msg)
+		}
+		seconds, ok := typeutil.ParseIntValue(rawMap[name])
+		if !ok || seconds < 1 {
+			return nil, fmt.Errorf("tool-timeouts.%s must be a positive integer number of seconds, got %v", name, rawMap[name])
+		}
+		timeouts[name] = seconds
+	}
+
+	toolTimeoutsLog.Printf("Extracted %d per-tool timeout(s) for engine %s", len(timeouts), engine.GetID())
+	c.warnUnenforcedToolTimeouts(timeouts, engine)
+	return timeouts, nil
+}
+
+// toolTimeoutCandidates returns the tool names that can carry a per-tool timeout:
+// bash plus every configured MCP server.
+func toolTimeoutCandidates(tools map[string]any) map[string]struct{} {
+	candidates := map[string]struct{}{bashToolTimeoutKey: {}}
+	for name, value := range tools {
+		if value == false {
+			continue
+		}
+		switch name {
+		case "github", "playwright", "agentic-workflows":
+			candidates[name] = struct{}{}
+		default:
+			if mcpConfig, ok := value.(map[string]any); ok {
+				if hasMcp, _ := hasMCPConfig(mcpConfig); hasMcp {
+					candidates[name] = struct{}{}
+				}
+			}
+		}
+	}
+	return candidates
+}
+
+// toolTimeoutEnforced reports whether the engine can kill a hung call to the named tool.
+// Claude honors a bash-specific timeout through its environment, and Codex reads a
+// tool_timeout_sec per MCP server from config.toml. No engine exposes both.
+func toolTimeoutEnforced(engineID string, toolName string) bool {
+	switch engineID {
+	case string(constants.ClaudeEngine):
+		return toolName == bashToolTimeoutKey
+	case string(constants.CodexEngine):
+		return toolName != bashToolTimeoutKey
+	default:
+		return false
+	}
+}
+
+// warnUnenforcedToolTimeouts emits one warning per tool-timeouts entry the engine ignores.
+func (c *Compiler) warnUnenforcedToolTimeouts(timeouts map[string]int, engine CodingAgentEngine) {
+	for _, name := range sliceutil.SortedKeys(timeouts) {
+		if toolTimeoutEnforced(engine.GetID(), name) {
+			continue
+		}
+		toolTimeoutsLog.Printf("Engine %s cannot enforce timeout for tool %s", engine.GetID(), name)
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Engine '%s' cannot enforce tool-timeouts.%s; the setting will be ignored. Per-tool timeouts are enforced for bash by the 'claude' engine and for MCP servers by the 'codex' engine. Use tools.timeout for a limit that applies to all tools.", engine.GetID(), name)))
+		c.IncrementWarningCount()
+	}
+}
+
+// applyCodexToolTimeout adds a tool_timeout_sec line to a rendered [mcp_servers.<id>]
+// TOML table when tool-timeouts sets a timeout for that server. The GitHub server
+// already writes tool_timeout_sec itself, so it is left alone here.
+func applyCodexToolTimeout(serverConfig string, toolName string, workflowData *WorkflowData) string {
+	if workflowData == nil {
+		return serverConfig
+	}
+	seconds, ok := workflowData.ToolTimeouts[toolName]
+	if !ok || toolName == "github" {
+		return serverConfig
+	}
+	serverID := toolName
+	if toolName == "agentic-workflows" {
+		serverID = constants.AgenticWorkflowsMCPServerID.String()
+	}
+	header := "          [mcp_servers." + serverID + "]\n"
+	if !strings.Contains(serverConfig, header) {
+		return serverConfig
+	}
+	toolTimeoutsLog.Printf("Setting Codex tool_timeout_sec=%d for MCP server %s", seconds, serverID)
+	return strings.Replace(serverConfig, header, header+"          tool_timeout_sec = "+strconv.Itoa(seconds)+"\n", 1)
+}
@@ -0,0 +1,120 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func compileToolTimeoutsWorkflow(t *testing.T, frontmatter string) (string, error) {
+	t.Helper()
+	tmpDir := testutil.TempDir(t, "tool-timeouts-test")
+	workflowPath := filepath.Join(tmpDir, "tool-timeouts.md")
+	content := "---\non: workflow_dispatch\npermissions:\n  contents: read\n" + frontmatter + "---\n\n# Tool Timeouts\n\nRun the tools.\n"
+	require.NoError(t, os.WriteFile(workflowPath, []byte(content), 0644), "failed to write workflow")
+
+	compiler := NewCompiler(WithVersion("1.0.0"))
+	compiler.SetQuiet(true)
+	if err := compiler.CompileWorkflow(workflowPath); err != nil {
+		return "", err
+	}
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(workflowPath))
+	require.NoError(t, err, "failed to read lock file")
+	return string(lockContent), nil
+}
+
+func TestToolTimeouts_ClaudeBash(t *testing.T) {
+	lock, err := compileToolTimeoutsWorkflow(t, `engine: claude
+tools:
+  timeout: 90
+  bash: ["make"]
+tool-timeouts:
+  bash: 600
+`)
+	require.NoError(t, err, "compilation should succeed")
+
+	assert.Contains(t, lock, "BASH_DEFAULT_TIMEOUT_MS: 600000", "bash default timeout should come from tool-timeouts")
+	assert.Contains(t, lock, "BASH_MAX_TIMEOUT_MS: 600000", "bash max timeout should come from tool-timeouts")
+	assert.Contains(t, lock, "MCP_TOOL_TIMEOUT: 90000", "MCP tool timeout should still use tools.timeout")
+}
+
+func TestToolTimeouts_CodexMCPServers(t *testing.T) {
+	lock, err := compileToolTimeoutsWorkflow(t, `engine: codex
+tools:
+  github:
+    toolsets: [repos]
+  playwright:
+mcp-servers:
+  slow-server:
+    command: "node"
+    args: ["server.js"]
+tool-timeouts:
+  github: 45
+  playwright: 300
+  slow-server: 900
+`)
+	require.NoError(t, err, "compilation should succeed")
+
+	assert.Regexp(t, `\[mcp_servers\.github\][^\[]*tool_timeout_sec = 45\n`, lock, "github server should use its per-tool timeout")
+	assert.Contains(t, lock, "[mcp_servers.playwright]\n          tool_timeout_sec = 300\n", "playwright server should get a per-tool timeout")
+	assert.Contains(t, lock, "[mcp_servers.slow-server]\n          tool_timeout_sec = 900\n", "custom server should get a per-tool timeout")
+	assert.Equal(t, 1, strings.Count(lock, "tool_timeout_sec = 45"), "github timeout should be written once")
+}
+
+func TestToolTimeouts_Validation(t *testing.T) {
+	tests := []struct {
+		name        string
+		frontmatter string
+		errorMsg    string
+	}{
+		{
+			name:        "unknown tool with suggestion",
+			frontmatter: "engine: codex\ntools:\n  playwright:\ntool-timeouts:\n  playwrite: 60\n",
+			errorMsg:    `tool-timeouts: unknown tool "playwrite"; expected "bash" or a configured MCP server. Did you mean "playwright"?`,
+		},
+		{
+			name:        "tool that is not configured",
+			frontmatter: "engine: codex\ntool-timeouts:\n  playwright: 60\n",
+			errorMsg:    `unknown tool "playwright"`,
+		},
+		{
+			name:        "non-positive timeout",
+			frontmatter: "engine: claude\ntool-timeouts:\n  bash: 0\n",
+			errorMsg:    "tool-timeouts",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := compileToolTimeoutsWorkflow(t, tt.frontmatter)
+			require.Error(t, err, "compilation should fail")
+			assert.Contains(t, err.Error(), tt.errorMsg, "error should explain the invalid tool-timeouts entry")
+		})
+	}
+}
+
+func TestToolTimeoutEnforced(t *testing.T) {
+	assert.True(t, toolTimeoutEnforced("claude", "bash"), "claude should enforce bash timeouts")
+	assert.False(t, toolTimeoutEnforced("claude", "github"), "claude has no per-server MCP timeout")
+	assert.True(t, toolTimeoutEnforced("codex", "github"), "codex should enforce MCP server timeouts")
+	assert.False(t, toolTimeoutEnforced("codex", "bash"), "codex has no bash timeout setting")
+	assert.False(t, toolTimeoutEnforced("copilot", "bash"), "copilot has no per-tool timeout setting")
+}
+
+func TestApplyCodexToolTimeout(t *testing.T) {
+	workflowData := &WorkflowData{ToolTimeouts: map[string]int{"agentic-workflows": 120}}
+	config := "          \n          [mcp_servers.agenticworkflows]\n          container = \"alpine\"\n"
+
+	got := applyCodexToolTimeout(config, "agentic-workflows", workflowData)
+	assert.Contains(t, got, "[mcp_servers.agenticworkflows]\n          tool_timeout_sec = 120\n", "timeout should follow the server header")
+	assert.Equal(t, config, applyCodexToolTimeout(config, "playwright", workflowData), "servers without a timeout should be unchanged")
+	assert.Equal(t, config, applyCodexToolTimeout(config, "agentic-workflows", nil), "nil workflow data should be a no-op")
+}
@@ -0,0 +1,106 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractTurnTokenUsage(t *testing.T) {
+	tests := []struct {
+		name     string
+		usage    map[string]any
+		expected TurnTokenUsage
+		ok       bool
+	}{
+		{
+			name: "claude format",
+			usage: map[string]any{
+				"input_tokens":                float64(12),
+				"output_tokens":               float64(340),
+				"cache_read_input_tokens":     float64(18000),
+				"cache_creation_input_tokens": float64(900),
+			},
+			expected: TurnTokenUsage{InputTokens: 12, OutputTokens: 340, CacheReadTokens: 18000, CacheWriteTokens: 900},
+			ok:       true,
+		},
+		{
+			name: "openai format subtracts cached prompt tokens",
+			usage: map[string]any{
+				"prompt_tokens":         float64(1500),
+				"completion_tokens":     float64(80),
+				"prompt_tokens_details": map[string]any{"cached_tokens": float64(1000)},
+			},
+			expected: TurnTokenUsage{InputTokens: 500, OutputTokens: 80, CacheReadTokens: 1000},
+			ok:       true,
+		},
+		{
+			name:  "empty usage",
+			usage: map[string]any{"output_tokens": float64(0)},
+			ok:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usage, ok := ExtractTurnTokenUsage(tt.usage)
+			assert.Equal(t, tt.ok, ok, "ok should match")
+			if tt.ok {
+				assert.Equal(t, tt.expected, usage, "token split should match")
+			}
+		})
+	}
+}
+
+func TestClaudeEngine_ParseLogMetrics_TurnTokens(t *testing.T) {
+	// Claude emits one assistant entry per content block; both entries of msg_1 repeat its usage
+	logContent := `[
+  {"type": "assistant", "message": {"id": "msg_1", "content": [{"type": "text", "text": "Let me look"}], "usage": {"input_tokens": 10, "output_tokens": 5, "cache_read_input_tokens": 1000}}},
+  {"type": "assistant", "message": {"id": "msg_1", "content": [{"type": "tool_use", "id": "toolu_1", "name": "Read", "input": {"file_path": "README.md"}}], "usage": {"input_tokens": 10, "output_tokens": 40, "cache_read_input_tokens": 1000}}},
+  {"type": "user", "message": {"content": [{"type": "tool_result", "tool_use_id": "toolu_1", "content": "contents"}]}},
+  {"type": "assistant", "message": {"id": "msg_2", "content": [{"type": "text", "text": "Done"}], "usage": {"input_tokens": 8, "output_tokens": 20, "cache_read_input_tokens": 1600}}},
+  {"type": "result", "num_turns": 2, "usage": {"input_tokens": 18, "output_tokens": 60}}
+]`
+
+	metrics := NewClaudeEngine().ParseLogMetrics(logContent, false)
+
+	require.Len(t, metrics.TurnTokens, 2, "entries sharing a message id should be merged into one turn")
+	first := metrics.TurnTokens[0]
+	assert.Equal(t, 1, first.Turn, "first turn number")
+	assert.Equal(t, 40, first.OutputTokens, "merged turn should keep the largest output count")
+	assert.Equal(t, 1000, first.CacheReadTokens, "cache reads should be recorded")
+	assert.Equal(t, []string{"Read"}, first.ToolCalls, "tool calls should be attributed to their turn")
+	assert.Equal(t, 2, metrics.TurnTokens[1].Turn, "second turn number")
+	assert.Empty(t, metrics.TurnTokens[1].ToolCalls, "final turn should have no tool calls")
+}
+
+func TestCopilotEngine_ParseLogMetrics_TurnTokens(t *testing.T) {
+	logContent := `2025-09-26T11:13:17.989Z [DEBUG] data:
+2025-09-26T11:13:17.989Z [DEBUG] {
+2025-09-26T11:13:17.990Z [DEBUG]   "usage": {
+2025-09-26T11:13:17.990Z [DEBUG]     "prompt_tokens": 1524,
+2025-09-26T11:13:17.990Z [DEBUG]     "completion_tokens": 89,
+2025-09-26T11:13:17.990Z [DEBUG]     "prompt_tokens_details": {"cached_tokens": 1024}
+2025-09-26T11:13:17.990Z [DEBUG]   }
+2025-09-26T11:13:17.990Z [DEBUG] }
+2025-09-26T11:13:17.990Z [DEBUG] Executing tool: bash
+2025-09-26T11:13:18.500Z [DEBUG] data:
+2025-09-26T11:13:18.501Z [DEBUG] {
+2025-09-26T11:13:18.501Z [DEBUG]   "usage": {
+2025-09-26T11:13:18.501Z [DEBUG]     "prompt_tokens": 1689,
+2025-09-26T11:13:18.501Z [DEBUG]     "completion_tokens": 23
+2025-09-26T11:13:18.501Z [DEBUG]   }
+2025-09-26T11:13:18.501Z [DEBUG] }
+`
+
+	metrics := NewCopilotEngine().ParseLogMetrics(logContent, false)
+
+	require.Len(t, metrics.TurnTokens, 2, "each data block should produce a turn")
+	assert.Equal(t, TurnTokenUsage{Turn: 1, InputTokens: 500, OutputTokens: 89, CacheReadTokens: 1024, ToolCalls: []string{"bash"}},
+		metrics.TurnTokens[0], "first turn should include the tool executed after it")
+	assert.Equal(t, TurnTokenUsage{Turn: 2, InputTokens: 1689, OutputTokens: 23},
+		metrics.TurnTokens[1], "second turn should have no tool calls")
+}
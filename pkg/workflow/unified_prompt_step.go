@@ -208,7 +208,13 @@ func (c *Compiler) collectPromptSections(data *WorkflowData) []PromptSection {
 		})
 	}
 
-	// 11. PR context (if comment-related triggers and checkout is needed)
+	// 11. Upstream workflow run context (if triggered by workflow_run, e.g. via needs-workflow)
+	if section := buildWorkflowRunContextPromptSection(data); section != nil {
+		unifiedPromptLog.Print("Adding upstream workflow run context section")
+		sections = append(sections, *section)
+	}
+
+	// 12. PR context (if comment-related triggers and checkout is needed)
 	hasCommentTriggers := c.hasCommentRelatedTriggers(data)
 	needsCheckout := c.shouldAddCheckoutStep(data)
 	var hasContentsRead bool
@@ -624,6 +630,9 @@ func buildSafeOutputsSections(safeOutputs *SafeOutputsConfig) []PromptSection {
 	if safeOutputs.AddReviewer != nil {
 		tools = append(tools, toolWithMaxBudget("add_reviewer", safeOutputs.AddReviewer.Max))
 	}
+	if safeOutputs.RequestTeamReview != nil {
+		tools = append(tools, toolWithMaxBudget("request_team_review", safeOutputs.RequestTeamReview.Max))
+	}
 	if safeOutputs.AssignMilestone != nil {
 		tools = append(tools, toolWithMaxBudget("assign_milestone", safeOutputs.AssignMilestone.Max))
 	}
@@ -636,6 +645,12 @@ func buildSafeOutputsSections(safeOutputs *SafeOutputsConfig) []PromptSection {
 	if safeOutputs.UnassignFromUser != nil {
 		tools = append(tools, toolWithMaxBudget("unassign_from_user", safeOutputs.UnassignFromUser.Max))
 	}
+	if safeOutputs.LockIssue != nil {
+		tools = append(tools, toolWithMaxBudget("lock_issue", safeOutputs.LockIssue.Max))
+	}
+	if safeOutputs.LockPullRequest != nil {
+		tools = append(tools, toolWithMaxBudget("lock_pull_request", safeOutputs.LockPullRequest.Max))
+	}
 	if safeOutputs.PushToPullRequestBranch != nil {
 		tools = append(tools, toolWithMaxBudget("push_to_pull_request_branch", safeOutputs.PushToPullRequestBranch.Max))
 	}
@@ -648,9 +663,18 @@ func buildSafeOutputsSections(safeOutputs *SafeOutputsConfig) []PromptSection {
 	if safeOutputs.CreateCheckRun != nil {
 		tools = append(tools, toolWithMaxBudget("create_check_run", safeOutputs.CreateCheckRun.Max))
 	}
+	if safeOutputs.CreateCommitStatus != nil {
+		tools = append(tools, toolWithMaxBudget("create_commit_status", safeOutputs.CreateCommitStatus.Max))
+	}
+	if safeOutputs.Notify != nil {
+		tools = append(tools, toolWithMaxBudget("notify", safeOutputs.Notify.Max))
+	}
 	if safeOutputs.UploadAssets != nil {
 		tools = append(tools, toolWithMaxBudget("upload_asset", safeOutputs.UploadAssets.Max))
 	}
+	if safeOutputs.CreateArtifact != nil {
+		tools = append(tools, toolWithMaxBudget("create_artifact", safeOutputs.CreateArtifact.Max))
+	}
 	if safeOutputs.UpdateRelease != nil {
 		tools = append(tools, toolWithMaxBudget("update_release", safeOutputs.UpdateRelease.Max))
 	}
@@ -663,6 +687,9 @@ func buildSafeOutputsSections(safeOutputs *SafeOutputsConfig) []PromptSection {
 	if safeOutputs.CreateProjectStatusUpdates != nil {
 		tools = append(tools, toolWithMaxBudget("create_project_status_update", safeOutputs.CreateProjectStatusUpdates.Max))
 	}
+	if safeOutputs.UpdateProjectItems != nil {
+		tools = append(tools, toolWithMaxBudget("update_project_item", safeOutputs.UpdateProjectItems.Max))
+	}
 	if safeOutputs.LinkSubIssue != nil {
 		tools = append(tools, toolWithMaxBudget("link_sub_issue", safeOutputs.LinkSubIssue.Max))
 	}
@@ -0,0 +1,60 @@
+package workflow
+
+import (
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var updateProjectItemLog = logger.New("workflow:update_project_item")
+
+// UpdateProjectItemConfig holds configuration for setting Project v2 field values on
+// existing project items. Unlike update-project, fields are never created: every field
+// name and value is validated against the project's field definitions in the handler.
+type UpdateProjectItemConfig struct {
+	BaseSafeOutputConfig `yaml:",inline"`
+	GitHubToken          string   `yaml:"github-token,omitempty"`   // Optional custom GitHub token with Projects access
+	Project              string   `yaml:"project,omitempty"`        // Optional default project URL
+	TargetRepoSlug       string   `yaml:"target-repo,omitempty"`    // Repository that owns the issues/PRs in "owner/repo" format (defaults to the workflow repository)
+	AllowedFields        []string `yaml:"allowed-fields,omitempty"` // Optional list of field names the agent may set (case-insensitive)
+}
+
+// parseUpdateProjectItemConfig handles update-project-item configuration
+func (c *Compiler) parseUpdateProjectItemConfig(outputMap map[string]any) *UpdateProjectItemConfig {
+	configData, exists := outputMap["update-project-item"]
+	if !exists {
+		updateProjectItemLog.Print("No update-project-item configuration found")
+		return nil
+	}
+
+	updateProjectItemLog.Print("Parsing update-project-item configuration")
+	config := &UpdateProjectItemConfig{}
+	config.Max = defaultIntStr(10) // Default max is 10
+
+	if configMap, ok := configData.(map[string]any); ok {
+		c.parseBaseSafeOutputConfig(configMap, &config.BaseSafeOutputConfig, 10)
+
+		// Parse custom GitHub token
+		if token, ok := configMap["github-token"].(string); ok {
+			config.GitHubToken = token
+			updateProjectItemLog.Print("Using custom GitHub token for update-project-item")
+		}
+
+		// Parse project URL override if specified
+		if project, ok := configMap["project"].(string); ok {
+			config.Project = project
+			updateProjectItemLog.Printf("Using custom project URL for update-project-item: %s", project)
+		}
+
+		// Parse target-repo for content resolution (no wildcard allowed)
+		targetRepoSlug, isInvalid := parseTargetRepoWithValidation(configMap)
+		if isInvalid {
+			return nil
+		}
+		config.TargetRepoSlug = targetRepoSlug
+
+		config.AllowedFields = ParseStringArrayFromConfig(configMap, "allowed-fields", updateProjectItemLog)
+	}
+
+	updateProjectItemLog.Printf("Parsed update-project-item config: max=%v, hasCustomToken=%v, hasCustomProject=%v, targetRepo=%q, allowedFieldCount=%d",
+		config.Max, config.GitHubToken != "", config.Project != "", config.TargetRepoSlug, len(config.AllowedFields))
+	return config
+}
@@ -0,0 +1,90 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseUpdateProjectItemConfig(t *testing.T) {
+	compiler := NewCompiler()
+
+	t.Run("absent key returns nil", func(t *testing.T) {
+		assert.Nil(t, compiler.parseUpdateProjectItemConfig(map[string]any{}), "config should be nil when not configured")
+	})
+
+	t.Run("null value uses defaults", func(t *testing.T) {
+		config := compiler.parseUpdateProjectItemConfig(map[string]any{"update-project-item": nil})
+		require.NotNil(t, config, "config should be created for a null value")
+		require.NotNil(t, config.Max, "max should default")
+		assert.Equal(t, "10", *config.Max, "max should default to 10")
+		assert.Empty(t, config.AllowedFields, "all project fields should be allowed by default")
+	})
+
+	t.Run("parses project, target-repo and allowed-fields", func(t *testing.T) {
+		config := compiler.parseUpdateProjectItemConfig(map[string]any{
+			"update-project-item": map[string]any{
+				"max":            3,
+				"project":        "https://github.com/orgs/acme/projects/7",
+				"github-token":   "${{ secrets.PROJECTS_PAT }}",
+				"target-repo":    "acme/backend",
+				"allowed-fields": []any{"Status", "Sprint"},
+			},
+		})
+		require.NotNil(t, config, "config should be parsed")
+		require.NotNil(t, config.Max, "max should be parsed")
+		assert.Equal(t, "3", *config.Max, "max should be parsed")
+		assert.Equal(t, "https://github.com/orgs/acme/projects/7", config.Project, "project should be parsed")
+		assert.Equal(t, "${{ secrets.PROJECTS_PAT }}", config.GitHubToken, "github-token should be parsed")
+		assert.Equal(t, "acme/backend", config.TargetRepoSlug, "target-repo should be parsed")
+		assert.Equal(t, []string{"Status", "Sprint"}, config.AllowedFields, "allowed-fields should be parsed")
+	})
+
+	t.Run("wildcard target-repo is rejected", func(t *testing.T) {
+		config := compiler.parseUpdateProjectItemConfig(map[string]any{
+			"update-project-item": map[string]any{"target-repo": "*"},
+		})
+		assert.Nil(t, config, "wildcard target-repo should be rejected")
+	})
+}
+
+func TestUpdateProjectItemCompilation(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "update-project-item-*")
+	workflowPath := filepath.Join(tmpDir, "triage.md")
+	content := `---
+on:
+  issues:
+    types: [opened]
+permissions:
+  contents: read
+engine: copilot
+safe-outputs:
+  update-project-item:
+    max: 5
+    project: "https://github.com/orgs/acme/projects/7"
+    allowed-fields: [Status, Sprint]
+---
+
+# Triage
+
+Set the Status and Sprint fields of the issue on the project board.
+`
+	require.NoError(t, os.WriteFile(workflowPath, []byte(content), 0600))
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(workflowPath), "workflow with update-project-item should compile")
+
+	lockContent, err := os.ReadFile(filepath.Join(tmpDir, "triage.lock.yml"))
+	require.NoError(t, err, "lock file should be written")
+	lock := string(lockContent)
+
+	assert.Contains(t, lock, `\"update_project_item\":{`, "handler config should include update_project_item")
+	assert.Contains(t, lock, `\"allowed_fields\":[\"Status\",\"Sprint\"]`, "handler config should include allowed fields")
+	assert.Contains(t, lock, "GH_AW_PROJECT_URL: \"https://github.com/orgs/acme/projects/7\"", "project URL should be exported to the handler manager")
+}
@@ -41,6 +41,7 @@ func (c *Compiler) buildInitialWorkflowData(
 		FrontmatterEmoji:           toolsResult.frontmatterEmoji,
 		FrontmatterYAML:            strings.Join(result.FrontmatterLines, "\n"),
 		FrontmatterFieldLines:      result.FieldLines,
+		FrontmatterFieldLocations:  result.FieldLocations,
 		RawMarkdown:                result.Markdown,
 		Description:                c.extractDescription(result.Frontmatter),
 		Source:                     c.extractSource(result.Frontmatter),
@@ -49,6 +50,7 @@ func (c *Compiler) buildInitialWorkflowData(
 		MaxDailyAICredits:          resolveMaxDailyAIC(result.Frontmatter, importsResult.MergedMaxDailyAICredits),
 		MaxDailyAICreditsGitHubApp: extractMaxDailyAICGitHubApp(result.Frontmatter),
 		ImportedFiles:              importsResult.ImportedFiles,
+		ImportSources:              importsResult.ImportSources,
 		Skills:                     extractFrontmatterSkills(toolsResult.parsedFrontmatter, result.Frontmatter),
 		SkillReferences:            extractFrontmatterSkillReferences(toolsResult.parsedFrontmatter, result.Frontmatter),
 		ImportedMarkdown:           toolsResult.importedMarkdown, // Only imports WITH inputs
@@ -69,12 +71,17 @@ func (c *Compiler) buildInitialWorkflowData(
 		AgentFile:                  agentFile,
 		AgentImportSpec:            agentImportSpec,
 		RepositoryImports:          importsResult.RepositoryImports,
+		GitHubContextImports:       importsResult.GitHubContextImports,
 		NetworkPermissions:         engineSetup.networkPermissions,
 		SandboxConfig:              applySandboxDefaults(engineSetup.sandboxConfig, engineSetup.engineConfig),
 		RunnerConfig:               extractRunnerConfig(result.Frontmatter),
+		PromptConfig:               extractPromptConfig(result.Frontmatter),
+		PromptLint:                 extractPromptLintConfig(result.Frontmatter),
+		PromptRouting:              extractPromptRouting(result.Frontmatter),
 		NeedsTextOutput:            toolsResult.needsTextOutput,
 		ToolsTimeout:               toolsResult.toolsTimeout,
 		ToolsStartupTimeout:        toolsResult.toolsStartupTimeout,
+		ToolTimeouts:               toolsResult.toolTimeouts,
 		TrialMode:                  c.trialMode,
 		TrialLogicalRepo:           c.trialLogicalRepoSlug,
 		UseSamples:                 c.useSamples,
@@ -593,6 +600,7 @@ func (c *Compiler) extractYAMLSections(frontmatter map[string]any, workflowData
 		return err
 	}
 	workflowData.If = ifCondition
+	workflowData.SkipIf = extractSkipIfCondition(frontmatter)
 
 	// Extract timeout-minutes (canonical form)
 	workflowData.TimeoutMinutes = c.extractTopLevelYAMLSection(frontmatter, "timeout-minutes")
@@ -35,24 +35,27 @@ type SkipIfCheckFailingConfig struct {
 }
 type WorkflowData struct {
 	Name                           string
-	WorkflowID                     string           // workflow identifier derived from markdown filename (basename without extension)
-	TrialMode                      bool             // whether the workflow is running in trial mode
-	TrialLogicalRepo               string           // target repository slug for trial mode (owner/repo)
-	UseSamples                     bool             // whether the agentic step should be replaced by a deterministic samples replay driver (hidden feature)
-	FrontmatterName                string           // name field from frontmatter (for code scanning alert driver default)
-	FrontmatterEmoji               string           // emoji field from frontmatter (for display in footers and UI)
-	FrontmatterYAML                string           // raw frontmatter YAML content (rendered as comment in lock file for reference)
-	FrontmatterHash                string           // SHA-256 hash of frontmatter (computed before job building, used to derive stable heredoc delimiters)
-	FrontmatterFieldLines          map[string]int   // absolute 1-based line numbers of top-level frontmatter keys in the source file (populated by parser)
-	RawMarkdown                    string           // raw markdown body before include expansion, used for frontmatter hash computation without re-reading the file
-	Description                    string           // optional description rendered as comment in lock file
-	Source                         string           // optional source field (owner/repo@ref/path) rendered as comment in lock file
-	Redirect                       string           // optional redirect field describing a moved workflow location
-	TrackerID                      string           // optional tracker identifier for created assets (min 8 chars, alphanumeric + hyphens/underscores)
-	MaxDailyAICredits              *string          // optional 24-hour per-workflow ET threshold (numeric string or GitHub Actions expression)
-	MaxDailyAICreditsGitHubApp     *GitHubAppConfig // optional GitHub App for minting the token used by the daily AIC guardrail
-	ImportedFiles                  []string         // list of files imported via imports field (rendered as comment in lock file)
-	Skills                         []string         // skill specs from frontmatter (owner/repo@sha or owner/repo/skill/path@sha)
+	WorkflowID                     string                                // workflow identifier derived from markdown filename (basename without extension)
+	TrialMode                      bool                                  // whether the workflow is running in trial mode
+	TrialLogicalRepo               string                                // target repository slug for trial mode (owner/repo)
+	UseSamples                     bool                                  // whether the agentic step should be replaced by a deterministic samples replay driver (hidden feature)
+	FrontmatterName                string                                // name field from frontmatter (for code scanning alert driver default)
+	FrontmatterEmoji               string                                // emoji field from frontmatter (for display in footers and UI)
+	FrontmatterYAML                string                                // raw frontmatter YAML content (rendered as comment in lock file for reference)
+	FrontmatterHash                string                                // SHA-256 hash of frontmatter (computed before job building, used to derive stable heredoc delimiters)
+	FrontmatterFieldLines          map[string]int                        // absolute 1-based line numbers of top-level frontmatter keys in the source file (populated by parser)
+	FrontmatterFieldLocations      map[string]parser.FrontmatterLocation // source locations of all frontmatter fields keyed by field path, e.g. "safe-outputs.create-issue.max" (populated by parser)
+	RawMarkdown                    string                                // raw markdown body before include expansion, used for frontmatter hash computation without re-reading the file
+	Description                    string                                // optional description rendered as comment in lock file
+	Source                         string                                // optional source field (owner/repo@ref/path) rendered as comment in lock file
+	Redirect                       string                                // optional redirect field describing a moved workflow location
+	TrackerID                      string                                // optional tracker identifier for created assets (min 8 chars, alphanumeric + hyphens/underscores)
+	MaxDailyAICredits              *string                               // optional 24-hour per-workflow ET threshold (numeric string or GitHub Actions expression)
+	MaxDailyAICreditsGitHubApp     *GitHubAppConfig                      // optional GitHub App for minting the token used by the daily AIC guardrail
+	ImportedFiles                  []string                              // list of files imported via imports field (rendered as comment in lock file)
+	ImportSources                  []parser.ImportSourceFile             // files read while resolving imports, with content hashes (for lock file provenance)
+	LockProvenance                 *LockProvenance                       // compile inputs recorded in the gh-aw-provenance header (set during YAML generation)
+	Skills                         []string                              // skill specs from frontmatter (owner/repo@sha or owner/repo/skill/path@sha)
 	SkillReferences                []SkillReference
 	ImportedMarkdown               string   // Only imports WITH inputs (for compile-time substitution)
 	ImportPaths                    []string // Import file paths for runtime-import macro generation (imports without inputs)
@@ -66,8 +69,11 @@ type WorkflowData struct {
 	Concurrency                    string // workflow-level concurrency configuration
 	RunName                        string
 	Env                            string
-	EnvSources                     map[string]string // env var name → source ("(main workflow)" or import file path) for lock file header
+	EnvSources                     map[string]string   // env var name → source ("(main workflow)" or import file path) for lock file header
+	ToolSources                    map[string][]string // tool name → files that contributed its configuration ("(main workflow)" or import paths) for lock file header
+	PermissionSources              map[string][]string // permission scope → imports that require it, for lock file header
 	If                             string
+	SkipIf                         string // bare expression that skips the agent job when true (from skip-if:)
 	TimeoutMinutes                 string
 	CustomSteps                    string
 	PreSteps                       string // steps to run at the very start of the agent job, before checkout
@@ -82,12 +88,13 @@ type WorkflowData struct {
 	LSP                            map[string]LSPServerConfig // top-level LSP server configuration for Copilot CLI
 	ParsedTools                    *Tools                     // Structured tools configuration (NEW: parsed from Tools map)
 	MarkdownContent                string
-	AI                             string        // "claude" or "codex" (for backwards compatibility)
-	Model                          string        // Top-level LLM model override (from frontmatter model: field or imports)
-	EngineConfig                   *EngineConfig // Extended engine configuration
-	AgentFile                      string        // Path to custom agent file (from imports)
-	AgentImportSpec                string        // Original import specification for agent file (e.g., "owner/repo/path@ref")
-	RepositoryImports              []string      // Repository-only imports (format: "owner/repo@ref") for .github folder merging
+	AI                             string                       // "claude" or "codex" (for backwards compatibility)
+	Model                          string                       // Top-level LLM model override (from frontmatter model: field or imports)
+	EngineConfig                   *EngineConfig                // Extended engine configuration
+	AgentFile                      string                       // Path to custom agent file (from imports)
+	AgentImportSpec                string                       // Original import specification for agent file (e.g., "owner/repo/path@ref")
+	RepositoryImports              []string                     // Repository-only imports (format: "owner/repo@ref") for .github folder merging
+	GitHubContextImports           []parser.GitHubContextImport // Issue and discussion imports fetched into the prompt at runtime
 	StopTime                       string
 	SkipIfMatch                    *SkipIfMatchConfig              // skip-if-match configuration with query and max threshold
 	SkipIfNoMatch                  *SkipIfNoMatchConfig            // skip-if-no-match configuration with query and min threshold
@@ -126,20 +133,28 @@ type WorkflowData struct {
 	Jobs                           map[string]any                  // custom job configurations with dependencies
 	Cache                          string                          // cache configuration
 	NeedsTextOutput                bool                            // whether the workflow uses ${{ needs.task.outputs.text }}
+	SanitizedInputs                []SanitizedInputConfig          // event payload fields sanitized in the activation job
 	NetworkPermissions             *NetworkPermissions             // parsed network permissions
 	SandboxConfig                  *SandboxConfig                  // parsed sandbox configuration (AWF or SRT)
 	RunnerConfig                   *RunnerConfig                   // parsed runner topology configuration (e.g., arc-dind)
+	PromptConfig                   *PromptConfig                   // parsed prompt configuration (token budget)
+	PromptLint                     parser.PromptLintConfig         // parsed prompt.lint configuration (rule severities)
+	PromptPolicy                   *PromptPolicy                   // policy fragments from .github/aw/config.yml wrapped around the system instructions
+	PromptRouting                  *PromptRouting                  // parsed route: configuration (label-based prompt selection)
 	SafeOutputs                    *SafeOutputsConfig              // output configuration for automatic output routes
 	MCPScripts                     *MCPScriptsConfig               // mcp-scripts configuration for custom MCP tools
 	LabelNames                     []string                        // label names that must match for pull_request_target labeled events (on.labels)
 	Roles                          []string                        // permission levels required to trigger workflow
 	Bots                           []string                        // allow list of bot identifiers that can trigger workflow
 	RateLimit                      *RateLimitConfig                // rate limiting configuration for workflow triggers
+	ActiveHours                    *ActiveHoursConfig              // office-hours window for human-initiated triggers
+	Conclusion                     *ConclusionConfig               // custom conclusion comment template
 	CacheMemoryConfig              *CacheMemoryConfig              // parsed cache-memory configuration
 	RepoMemoryConfig               *RepoMemoryConfig               // parsed repo-memory configuration
 	Runtimes                       map[string]any                  // runtime version overrides from frontmatter
 	ToolsTimeout                   string                          // timeout for tool/MCP operations: numeric string (seconds) or GitHub Actions expression (empty = use engine default)
 	ToolsStartupTimeout            string                          // timeout for MCP server startup: numeric string (seconds) or GitHub Actions expression (empty = use engine default)
+	ToolTimeouts                   map[string]int                  // per-tool timeouts in seconds from tool-timeouts, keyed by tool name ("bash" or an MCP server)
 	Features                       map[string]any                  // feature flags and configuration options from frontmatter (supports bool and string values)
 	Ctx                            context.Context                 // context propagated from the caller for network operations (e.g. SHA resolution)
 	ActionCache                    *ActionCache                    // cache for action pin resolutions
@@ -199,6 +214,8 @@ type WorkflowData struct {
 	ContainerPinMappings           map[string]string               // container-pin redirect table from aw.json container_pins: maps source image → replacement image
 	Evals                          *EvalsConfig                    // BinEval evaluation configuration parsed from frontmatter evals field
 	ExcludedEnv                    []string                        // additional env var names to exclude from agent container via AWF --exclude-env (from frontmatter excluded-env field)
+	CompareEngines                 *CompareEnginesConfig           // compare-engines: mode; runs the prompt with several engines in parallel agent jobs
+	CompareEngineID                string                          // set on the per-engine copy used to build an additional compare-engines agent job
 }
 
 // PinContext returns an actionpins.PinContext backed by this WorkflowData.
@@ -0,0 +1,112 @@
+package workflow
+
+import (
+	"slices"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var workflowLintLog = logger.New("workflow:workflow_lint")
+
+// LintSeverity is the severity level of a lint finding.
+type LintSeverity string
+
+const (
+	// LintSeverityError marks findings that should fail the lint run.
+	LintSeverityError LintSeverity = "error"
+	// LintSeverityWarning marks findings that are likely mistakes but do not fail the run.
+	LintSeverityWarning LintSeverity = "warning"
+	// LintSeverityInfo marks advisory findings.
+	LintSeverityInfo LintSeverity = "info"
+)
+
+// LintFinding is a single issue reported by a lint rule.
+type LintFinding struct {
+	RuleID   string       `json:"rule"`
+	Severity LintSeverity `json:"severity"`
+	Message  string       `json:"message"`
+	Field    string       `json:"field,omitempty"`  // frontmatter field path the finding relates to (e.g. "engine" or "safe-outputs.create-issue.max")
+	Line     int          `json:"line,omitempty"`   // 1-based line of Field in the source file, when known
+	Column   int          `json:"column,omitempty"` // 1-based column of Field in the source file, when known
+}
+
+// LintRule checks parsed workflow data for a single class of problems.
+//
+// Check returns findings with Message and (optionally) Field set; the rule
+// set fills in RuleID, Severity, Line and Column.
+type LintRule struct {
+	ID          string
+	Description string
+	Severity    LintSeverity
+	Check       func(data *WorkflowData) []LintFinding
+}
+
+// LintRuleSet is an ordered collection of lint rules. Custom rule sets can be
+// assembled with NewLintRuleSet and extended with Add.
+type LintRuleSet struct {
+	rules []LintRule
+}
+
+// NewLintRuleSet creates a rule set containing the given rules.
+func NewLintRuleSet(rules ...LintRule) *LintRuleSet {
+	return &LintRuleSet{rules: slices.Clone(rules)}
+}
+
+// DefaultLintRuleSet returns a rule set containing all built-in lint rules.
+func DefaultLintRuleSet() *LintRuleSet {
+	return NewLintRuleSet(builtinLintRules()...)
+}
+
+// Add appends rules to the set. A rule whose ID is already present replaces
+// the existing rule so custom rule sets can override built-in behavior.
+func (s *LintRuleSet) Add(rules ...LintRule) {
+	for _, rule := range rules {
+		if idx := slices.IndexFunc(s.rules, func(r LintRule) bool { return r.ID == rule.ID }); idx >= 0 {
+			s.rules[idx] = rule
+			continue
+		}
+		s.rules = append(s.rules, rule)
+	}
+}
+
+// Disable removes the rules with the given IDs from the set.
+func (s *LintRuleSet) Disable(ids ...string) {
+	s.rules = slices.DeleteFunc(s.rules, func(r LintRule) bool { return slices.Contains(ids, r.ID) })
+}
+
+// Rules returns the rules in the set, in evaluation order.
+func (s *LintRuleSet) Rules() []LintRule {
+	return slices.Clone(s.rules)
+}
+
+// Run evaluates every rule against the workflow data and returns the findings
+// in rule order.
+func (s *LintRuleSet) Run(data *WorkflowData) []LintFinding {
+	if data == nil {
+		return nil
+	}
+	var findings []LintFinding
+	for _, rule := range s.rules {
+		for _, f := range rule.Check(data) {
+			f.RuleID = rule.ID
+			if f.Severity == "" {
+				f.Severity = rule.Severity
+			}
+			if f.Line == 0 && f.Field != "" {
+				if loc, ok := data.FrontmatterFieldLocations[f.Field]; ok {
+					f.Line, f.Column = loc.Line, loc.Column
+				} else {
+					f.Line = data.FrontmatterFieldLines[f.Field]
+				}
+			}
+			findings = append(findings, f)
+		}
+	}
+	workflowLintLog.Printf("Ran %d lint rule(s) on %s: %d finding(s)", len(s.rules), data.WorkflowID, len(findings))
+	return findings
+}
+
+// HasLintErrors reports whether any finding has error severity.
+func HasLintErrors(findings []LintFinding) bool {
+	return slices.ContainsFunc(findings, func(f LintFinding) bool { return f.Severity == LintSeverityError })
+}
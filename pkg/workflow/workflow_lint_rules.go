@@ -0,0 +1,199 @@
+package workflow
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/gitutil"
+	"github.com/goccy/go-yaml"
+)
+
+// Built-in lint rule IDs.
+const (
+	LintRuleWritePermissions        = "write-permissions"
+	LintRuleWebFetchNetworkDefaults = "web-fetch-network-defaults"
+	LintRuleCreateIssueMax          = "create-issue-max"
+	LintRuleUnpinnedAction          = "unpinned-action"
+)
+
+// builtinLintRules returns the rules included in DefaultLintRuleSet.
+func builtinLintRules() []LintRule {
+	return []LintRule{
+		{
+			ID:          LintRuleWritePermissions,
+			Description: "The agent job should not request write permissions; use safe-outputs for write operations",
+			Severity:    LintSeverityError,
+			Check:       checkWritePermissions,
+		},
+		{
+			ID:          LintRuleWebFetchNetworkDefaults,
+			Description: "web-fetch is enabled but the network allowlist does not include defaults",
+			Severity:    LintSeverityWarning,
+			Check:       checkWebFetchNetworkDefaults,
+		},
+		{
+			ID:          LintRuleCreateIssueMax,
+			Description: "create-issue should declare an explicit max",
+			Severity:    LintSeverityWarning,
+			Check:       checkCreateIssueMax,
+		},
+		{
+			ID:          LintRuleUnpinnedAction,
+			Description: "Actions used in custom steps should be pinned to a full commit SHA",
+			Severity:    LintSeverityWarning,
+			Check:       checkUnpinnedActions,
+		},
+	}
+}
+
+// checkWritePermissions reports write scopes requested for the agent job.
+// id-token and copilot-requests are excluded because they do not grant write
+// access to repository content.
+func checkWritePermissions(data *WorkflowData) []LintFinding {
+	if data.Permissions == "" {
+		return nil
+	}
+	perms := NewPermissionsParser(data.Permissions).ToPermissions()
+	if !perms.HasAnyWriteScope() {
+		return nil
+	}
+
+	var scopes []string
+	for _, scope := range GetAllPermissionScopes() {
+		if scope == PermissionIdToken {
+			continue
+		}
+		if level, ok := perms.Get(scope); ok && level == PermissionWrite {
+			scopes = append(scopes, string(scope))
+		}
+	}
+	if len(scopes) == 0 {
+		return nil
+	}
+	return []LintFinding{{
+		Field:   "permissions",
+		Message: fmt.Sprintf("agent job requests write permission for: %s; remove them and use safe-outputs for write operations", strings.Join(scopes, ", ")),
+	}}
+}
+
+// checkWebFetchNetworkDefaults reports workflows that enable web-fetch with an
+// explicit network allowlist that omits the "defaults" ecosystem.
+func checkWebFetchNetworkDefaults(data *WorkflowData) []LintFinding {
+	if _, ok := data.Tools["web-fetch"]; !ok {
+		return nil
+	}
+	network := data.NetworkPermissions
+	if network == nil || !network.ExplicitlyDefined {
+		// No network configuration means the defaults apply.
+		return nil
+	}
+	if slices.Contains(network.Allowed, "defaults") || slices.Contains(network.Allowed, "*") {
+		return nil
+	}
+	return []LintFinding{{
+		Field:   "network",
+		Message: "web-fetch is enabled but network.allowed does not include \"defaults\"; add it so baseline infrastructure domains remain reachable",
+	}}
+}
+
+// checkCreateIssueMax reports create-issue configurations that rely on the
+// implicit max instead of declaring one.
+func checkCreateIssueMax(data *WorkflowData) []LintFinding {
+	if data.SafeOutputs == nil || data.SafeOutputs.CreateIssues == nil {
+		return nil
+	}
+	safeOutputs, ok := data.RawFrontmatter["safe-outputs"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	raw, exists := safeOutputs["create-issue"]
+	if !exists {
+		// Configured by an import; the import owns the limit.
+		return nil
+	}
+	if cfg, ok := raw.(map[string]any); ok {
+		if _, hasMax := cfg["max"]; hasMax {
+			return nil
+		}
+	}
+	return []LintFinding{{
+		Field:   "safe-outputs",
+		Message: "create-issue has no explicit max; set safe-outputs.create-issue.max to bound the number of issues a run can create",
+	}}
+}
+
+// checkUnpinnedActions reports actions in custom steps that are not pinned to
+// a full commit SHA after the compiler's pin resolution.
+func checkUnpinnedActions(data *WorkflowData) []LintFinding {
+	var findings []LintFinding
+	for _, section := range customStepSections(data) {
+		for _, uses := range collectStepUses(section.yaml) {
+			if isPinnedActionRef(uses) {
+				continue
+			}
+			findings = append(findings, LintFinding{
+				Field:   section.field,
+				Message: fmt.Sprintf("action %q in %s is not pinned to a full commit SHA", uses, section.field),
+			})
+		}
+	}
+	return findings
+}
+
+// customStepSection is a custom steps section of the workflow with its frontmatter field name.
+type customStepSection struct {
+	field string
+	yaml  string
+}
+
+// customStepSections returns the custom steps sections that may reference actions.
+func customStepSections(data *WorkflowData) []customStepSection {
+	return []customStepSection{
+		{"steps", data.CustomSteps},
+		{"pre-steps", data.PreSteps},
+		{"pre-agent-steps", data.PreAgentSteps},
+		{"post-steps", data.PostSteps},
+	}
+}
+
+// collectStepUses returns the "uses" values of all steps in a steps YAML
+// section (for example "steps:\n  - uses: actions/checkout@v4").
+func collectStepUses(stepsYAML string) []string {
+	if stepsYAML == "" {
+		return nil
+	}
+	var wrapper map[string]any
+	if err := yaml.Unmarshal([]byte(stepsYAML), &wrapper); err != nil {
+		workflowLintLog.Printf("Failed to parse steps for lint: %v", err)
+		return nil
+	}
+	var uses []string
+	for _, value := range wrapper {
+		steps, ok := value.([]any)
+		if !ok {
+			continue
+		}
+		for _, step := range steps {
+			if stepMap, ok := step.(map[string]any); ok {
+				if ref, ok := stepMap["uses"].(string); ok && ref != "" {
+					uses = append(uses, ref)
+				}
+			}
+		}
+	}
+	return uses
+}
+
+// isPinnedActionRef reports whether a step "uses" reference is pinned.
+// Local actions and docker references are always considered pinned.
+func isPinnedActionRef(uses string) bool {
+	if strings.HasPrefix(uses, "./") || strings.HasPrefix(uses, "docker://") {
+		return true
+	}
+	_, ref, ok := strings.Cut(uses, "@")
+	if !ok {
+		return false
+	}
+	return gitutil.IsValidFullSHA(ref)
+}
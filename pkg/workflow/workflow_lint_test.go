@@ -0,0 +1,178 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/github/gh-aw/pkg/parser"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func findingsForRule(findings []LintFinding, ruleID string) []LintFinding {
+	var out []LintFinding
+	for _, f := range findings {
+		if f.RuleID == ruleID {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+func TestLintRuleWritePermissions(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions string
+		wantFinding bool
+		wantScopes  string
+	}{
+		{name: "read only", permissions: "permissions:\n  contents: read\n  issues: read\n"},
+		{name: "issues write", permissions: "permissions:\n  contents: read\n  issues: write\n", wantFinding: true, wantScopes: "issues"},
+		{name: "write-all shorthand", permissions: "permissions: write-all\n", wantFinding: true, wantScopes: "contents"},
+		{name: "id-token write only", permissions: "permissions:\n  id-token: write\n"},
+		{name: "no permissions", permissions: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := &WorkflowData{Permissions: tt.permissions, FrontmatterFieldLines: map[string]int{"permissions": 4}}
+			findings := findingsForRule(DefaultLintRuleSet().Run(data), LintRuleWritePermissions)
+			if !tt.wantFinding {
+				assert.Empty(t, findings, "should not report write permissions")
+				return
+			}
+			require.Len(t, findings, 1, "should report one finding")
+			assert.Equal(t, LintSeverityError, findings[0].Severity, "write permissions should be an error")
+			assert.Equal(t, 4, findings[0].Line, "finding should point at the permissions field")
+			assert.Contains(t, findings[0].Message, tt.wantScopes, "message should list the write scopes")
+		})
+	}
+}
+
+func TestLintRuleSetRunUsesFieldLocations(t *testing.T) {
+	data := &WorkflowData{
+		Permissions:               "permissions: write-all\n",
+		FrontmatterFieldLines:     map[string]int{"permissions": 4},
+		FrontmatterFieldLocations: map[string]parser.FrontmatterLocation{"permissions": {Line: 4, Column: 3, Length: 11}},
+	}
+	findings := findingsForRule(DefaultLintRuleSet().Run(data), LintRuleWritePermissions)
+	require.Len(t, findings, 1, "should report one finding")
+	assert.Equal(t, 4, findings[0].Line, "finding line should come from the field location")
+	assert.Equal(t, 3, findings[0].Column, "finding column should come from the field location")
+}
+
+func TestLintRuleWebFetchNetworkDefaults(t *testing.T) {
+	webFetch := map[string]any{"web-fetch": nil}
+
+	tests := []struct {
+		name        string
+		tools       map[string]any
+		network     *NetworkPermissions
+		wantFinding bool
+	}{
+		{name: "web-fetch without network config", tools: webFetch},
+		{name: "web-fetch with defaults", tools: webFetch, network: &NetworkPermissions{Allowed: []string{"defaults", "python"}, ExplicitlyDefined: true}},
+		{name: "web-fetch without defaults", tools: webFetch, network: &NetworkPermissions{Allowed: []string{"example.com"}, ExplicitlyDefined: true}, wantFinding: true},
+		{name: "no web-fetch", tools: map[string]any{"bash": nil}, network: &NetworkPermissions{Allowed: []string{"example.com"}, ExplicitlyDefined: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := &WorkflowData{Tools: tt.tools, NetworkPermissions: tt.network}
+			findings := findingsForRule(DefaultLintRuleSet().Run(data), LintRuleWebFetchNetworkDefaults)
+			if tt.wantFinding {
+				require.Len(t, findings, 1, "should report missing defaults")
+				assert.Equal(t, "network", findings[0].Field, "finding should relate to network")
+			} else {
+				assert.Empty(t, findings, "should not report")
+			}
+		})
+	}
+}
+
+func TestLintRuleCreateIssueMax(t *testing.T) {
+	tests := []struct {
+		name        string
+		raw         map[string]any
+		wantFinding bool
+	}{
+		{name: "null config", raw: map[string]any{"safe-outputs": map[string]any{"create-issue": nil}}, wantFinding: true},
+		{name: "config without max", raw: map[string]any{"safe-outputs": map[string]any{"create-issue": map[string]any{"title-prefix": "[bot] "}}}, wantFinding: true},
+		{name: "config with max", raw: map[string]any{"safe-outputs": map[string]any{"create-issue": map[string]any{"max": 3}}}},
+		{name: "configured by import", raw: map[string]any{"safe-outputs": map[string]any{"add-comment": nil}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := &WorkflowData{
+				RawFrontmatter: tt.raw,
+				SafeOutputs:    &SafeOutputsConfig{CreateIssues: &CreateIssuesConfig{}},
+			}
+			findings := findingsForRule(DefaultLintRuleSet().Run(data), LintRuleCreateIssueMax)
+			if tt.wantFinding {
+				require.Len(t, findings, 1, "should report missing max")
+			} else {
+				assert.Empty(t, findings, "should not report")
+			}
+		})
+	}
+}
+
+func TestLintRuleUnpinnedAction(t *testing.T) {
+	data := &WorkflowData{
+		CustomSteps: "steps:\n" +
+			"  - uses: actions/checkout@08c6903cd8c0fde910a37f88322edcfb5dd907a8 # v5\n" +
+			"  - uses: some-org/some-action@v1\n" +
+			"  - uses: ./local-action\n" +
+			"  - run: echo hi\n",
+		PostSteps: "post-steps:\n  - uses: docker://alpine:3\n  - uses: other-org/tool@main\n",
+	}
+
+	findings := findingsForRule(DefaultLintRuleSet().Run(data), LintRuleUnpinnedAction)
+	require.Len(t, findings, 2, "should report the two unpinned actions")
+	assert.Equal(t, "steps", findings[0].Field, "first finding should be in steps")
+	assert.Contains(t, findings[0].Message, "some-org/some-action@v1", "message should name the action")
+	assert.Equal(t, "post-steps", findings[1].Field, "second finding should be in post-steps")
+	assert.Contains(t, findings[1].Message, "other-org/tool@main", "message should name the action")
+}
+
+func TestLintRuleSetCustomization(t *testing.T) {
+	custom := LintRule{
+		ID:          "require-description",
+		Description: "Workflows should have a description",
+		Severity:    LintSeverityInfo,
+		Check: func(data *WorkflowData) []LintFinding {
+			if data.Description == "" {
+				return []LintFinding{{Message: "missing description"}}
+			}
+			return nil
+		},
+	}
+
+	ruleSet := DefaultLintRuleSet()
+	ruleSet.Add(custom)
+	ruleSet.Disable(LintRuleWritePermissions)
+
+	ids := make([]string, 0, len(ruleSet.Rules()))
+	for _, r := range ruleSet.Rules() {
+		ids = append(ids, r.ID)
+	}
+	assert.NotContains(t, ids, LintRuleWritePermissions, "disabled rule should be removed")
+	assert.Contains(t, ids, "require-description", "custom rule should be added")
+
+	findings := ruleSet.Run(&WorkflowData{Permissions: "permissions: write-all\n"})
+	require.Len(t, findings, 1, "only the custom rule should fire")
+	assert.Equal(t, "require-description", findings[0].RuleID, "finding should carry the rule ID")
+	assert.Equal(t, LintSeverityInfo, findings[0].Severity, "finding should inherit the rule severity")
+	assert.False(t, HasLintErrors(findings), "info findings are not errors")
+
+	// Adding a rule with an existing ID replaces it
+	replacement := custom
+	replacement.Severity = LintSeverityError
+	ruleSet.Add(replacement)
+	findings = ruleSet.Run(&WorkflowData{})
+	require.Len(t, findings, 1, "replaced rule should still run once")
+	assert.True(t, HasLintErrors(findings), "replacement severity should apply")
+}